@@ -0,0 +1,128 @@
+package apicmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/real-staging-ai/api/command"
+	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// SetModel is "realstaging admin set-model", which switches the model new
+// staging jobs run on, as PUT /api/v1/admin/models/active does.
+var SetModel = &command.Command{
+	Name:    "set-model",
+	Summary: "set the active staging model",
+	Run:     runSetModel,
+}
+
+// SyncModels is "realstaging admin sync-models", which refreshes the catalog's
+// Replicate models from Replicate now rather than at the API's next sync.
+var SyncModels = &command.Command{
+	Name:    "sync-models",
+	Summary: "sync model versions and input schemas from Replicate",
+	Run:     runSyncModels,
+}
+
+// MigrateS3Keys is "realstaging admin migrate-s3-keys", which copies objects
+// stored under the legacy uploads/ and staged/ prefixes into the
+// users/<user_id>/projects/<project_id>/ namespace and rewrites the image URLs
+// and original image keys that reference them.
+var MigrateS3Keys = &command.Command{
+	Name:    "migrate-s3-keys",
+	Summary: "move legacy objects into per-user storage keys",
+	Run:     runMigrateS3Keys,
+}
+
+// setModelResult is printed by set-model.
+type setModelResult struct {
+	PreviousModel string `json:"previous_model"`
+	ActiveModel   string `json:"active_model"`
+}
+
+func runSetModel(ctx context.Context, args []string) error {
+	fs := command.NewFlagSet("admin set-model")
+	modelID := fs.String("model", "", "ID of the model to activate, e.g. qwen/qwen-image-edit")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *modelID == "" {
+		return fmt.Errorf("%w: -model is required", command.ErrUsage)
+	}
+
+	_, db, err := connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	svc := settings.NewDefaultService(settings.NewDefaultRepository(db.Pool()), nil)
+	previous, err := svc.GetActiveModel(ctx)
+	if err != nil {
+		return err
+	}
+	if err := svc.UpdateActiveModel(ctx, *modelID, ""); err != nil {
+		return err
+	}
+	return command.PrintJSON(setModelResult{PreviousModel: previous, ActiveModel: *modelID})
+}
+
+func runSyncModels(ctx context.Context, args []string) error {
+	fs := command.NewFlagSet("admin sync-models")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	cfg, db, err := connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if cfg.Replicate.APIToken == "" {
+		return settings.ErrUpstreamNotConfigured
+	}
+
+	syncer := settings.NewSyncer(settings.NewDefaultRepository(db.Pool()), settings.NewReplicateUpstream(cfg.Replicate))
+	result, err := syncer.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	return command.PrintJSON(result)
+}
+
+func runMigrateS3Keys(ctx context.Context, args []string) error {
+	var opts storage.KeyMigrationOptions
+	fs := command.NewFlagSet("admin migrate-s3-keys")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "report what would change without copying objects or updating rows")
+	fs.BoolVar(&opts.DeleteSource, "delete-source", false, "delete legacy objects after all rows have been rewritten")
+	fs.IntVar(&opts.BatchSize, "batch-size", 500, "number of image rows to scan per query")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	cfg, db, err := connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	s3Service, err := storage.NewDefaultS3Service(ctx, &cfg.S3)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 service: %w", err)
+	}
+
+	migrator := storage.NewKeyMigrator(db, s3Service, cfg.S3.BucketName)
+	result, err := migrator.Run(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := command.PrintJSON(result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return errors.New("some objects could not be migrated; see errors above")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package apicmd
+
+import (
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// loadConfig loads the API configuration, which APP_ENV and CONFIG_DIR select.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// connect loads the configuration and opens the database it names.
+func connect() (*config.Config, *storage.DefaultDatabase, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, err := storage.NewDefaultDatabase(&cfg.DB)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return cfg, db, nil
+}
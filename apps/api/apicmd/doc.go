@@ -0,0 +1,2 @@
+// Package apicmd implements the realstaging subcommands that run API code.
+package apicmd
@@ -0,0 +1,57 @@
+package apicmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/real-staging-ai/api/command"
+)
+
+// defaultMigrationsPath is where the realstaging image keeps infra/migrations.
+const defaultMigrationsPath = "/app/migrations"
+
+// Migrate is "realstaging migrate", which runs golang-migrate against the
+// configured database, e.g. "realstaging migrate up" or "realstaging migrate
+// down 1". The migrate binary must be on PATH; the realstaging image ships it.
+var Migrate = &command.Command{
+	Name:    "migrate",
+	Summary: "apply or roll back database migrations",
+	Run:     runMigrate,
+}
+
+func runMigrate(ctx context.Context, args []string) error {
+	fs := command.NewFlagSet("migrate")
+	path := fs.String("path", defaultMigrationsPath, "directory holding the migration files")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "usage: realstaging migrate [flags] up [N] | down [N | -all] | version | force V")
+		fs.PrintDefaults()
+	}
+	if err := command.Parse(fs, args, 2); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("%w: a migrate command is required", command.ErrUsage)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	bin, err := exec.LookPath("migrate")
+	if err != nil {
+		return errors.New("golang-migrate is not installed: migrate not found on PATH")
+	}
+
+	migrateArgs := append([]string{"-path", *path, "-database", cfg.DatabaseURL()}, fs.Args()...)
+	cmd := exec.CommandContext(ctx, bin, migrateArgs...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("migrate %v: %w", fs.Args(), err)
+	}
+	return nil
+}
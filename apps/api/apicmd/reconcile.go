@@ -0,0 +1,252 @@
+package apicmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/command"
+	"github.com/real-staging-ai/api/internal/originalimage"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/reconcile"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// Reconcile is "realstaging reconcile", which checks image rows against S3,
+// cleans up stuck queued images and recovers stuck processing ones, either
+// once or on a schedule, and finds S3 objects no row references.
+var Reconcile = &command.Command{
+	Name:    "reconcile",
+	Summary: "check stored files against the database",
+	Commands: []*command.Command{
+		{Name: "images", Summary: "check image files in storage once and mark missing ones as errors", Run: runImages},
+		{Name: "cleanup-stuck", Summary: "delete images that have been queued for too long", Run: runCleanupStuck},
+		{
+			Name: "recover-processing", Summary: "requeue or fail images that have been processing for too long",
+			Run: runRecoverProcessing,
+		},
+		{
+			Name: "daemon", Summary: "run images, cleanup-stuck and recover-processing on a schedule until interrupted",
+			Run: runDaemon,
+		},
+		{Name: "orphans", Summary: "report (or delete) stored objects no database row references", Run: runOrphans},
+	},
+}
+
+// passFlags registers the flags that scope a reconciliation pass.
+func passFlags(fs *flag.FlagSet, opts *reconcile.Options) {
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "report missing files without updating any rows")
+	fs.IntVar(&opts.BatchSize, "batch-size", reconcile.DefaultBatchSize, "number of images to check per batch")
+	fs.IntVar(&opts.Concurrency, "concurrency", reconcile.DefaultConcurrency, "number of concurrent S3 checks")
+	fs.StringVar(&opts.ProjectID, "project-id", "", "only check images in this project")
+	fs.StringVar(&opts.Status, "status", "", "only check images with this status (queued, processing, ready, error)")
+}
+
+// processingFlags registers the flags that configure the recovery of images
+// stuck in processing.
+func processingFlags(fs *flag.FlagSet, opts *reconcile.ProcessingOptions, timeoutUsage string) *string {
+	fs.DurationVar(&opts.Timeout, "processing-timeout", reconcile.DefaultProcessingTimeout, timeoutUsage)
+	fs.IntVar(&opts.MaxRetries, "max-retries", reconcile.DefaultProcessingRetries,
+		"requeue a stuck image this many times before marking it as an error")
+	return fs.String("model-timeouts", "",
+		"comma-separated per-model processing timeouts, e.g. black-forest-labs/flux-kontext-max=45m")
+}
+
+// parseModelTimeouts parses "model=duration" pairs separated by commas.
+func parseModelTimeouts(raw string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, pair := range strings.Split(raw, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		model, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(model) == "" {
+			return nil, fmt.Errorf("invalid model timeout %q, want model=duration", pair)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid model timeout %q: %w", pair, err)
+		}
+		timeouts[strings.TrimSpace(model)] = timeout
+	}
+	return timeouts, nil
+}
+
+func runImages(ctx context.Context, args []string) error {
+	var opts reconcile.Options
+	fs := command.NewFlagSet("reconcile images")
+	passFlags(fs, &opts)
+	fs.IntVar(&opts.Limit, "limit", 0, "stop after this many images and print a cursor to resume from (0 checks all)")
+	fs.StringVar(&opts.Cursor, "cursor", "", "resume after this image ID")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	svc, cleanup, err := newReconcileService(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	result, err := svc.ReconcileImages(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return command.PrintJSON(result)
+}
+
+func runCleanupStuck(ctx context.Context, args []string) error {
+	fs := command.NewFlagSet("reconcile cleanup-stuck")
+	olderThan := fs.Duration("older-than", 24*time.Hour, "delete images queued for longer than this")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	svc, cleanup, err := newReconcileService(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	deleted, err := svc.CleanupStuckQueuedImages(ctx, *olderThan)
+	if err != nil {
+		return err
+	}
+	return command.PrintJSON(map[string]int{"deleted": deleted})
+}
+
+func runRecoverProcessing(ctx context.Context, args []string) error {
+	var opts reconcile.ProcessingOptions
+	fs := command.NewFlagSet("reconcile recover-processing")
+	modelTimeouts := processingFlags(fs, &opts, "recover images processing for longer than this")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+	var err error
+	if opts.ModelTimeouts, err = parseModelTimeouts(*modelTimeouts); err != nil {
+		return err
+	}
+
+	svc, cleanup, err := newReconcileService(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	result, err := svc.RecoverStuckProcessingImages(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := command.PrintJSON(result); err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d stuck images could not be recovered", result.Failed)
+	}
+	return nil
+}
+
+func runOrphans(ctx context.Context, args []string) error {
+	var opts reconcile.OrphanOptions
+	fs := command.NewFlagSet("reconcile orphans")
+	prefixes := fs.String("prefix", strings.Join(reconcile.DefaultOrphanPrefixes, ","),
+		"comma-separated key prefixes to scan")
+	fs.DurationVar(&opts.MinAge, "min-age", reconcile.DefaultOrphanMinAge,
+		"skip objects modified more recently than this")
+	fs.BoolVar(&opts.Delete, "delete", false, "delete orphaned objects instead of only reporting them")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+	for _, prefix := range strings.Split(*prefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			opts.Prefixes = append(opts.Prefixes, prefix)
+		}
+	}
+
+	svc, cleanup, err := newReconcileService(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	result, err := svc.ReconcileOrphans(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if err := command.PrintJSON(result); err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d orphaned objects could not be deleted", result.Failed)
+	}
+	return nil
+}
+
+func runDaemon(ctx context.Context, args []string) error {
+	var cfg reconcile.DaemonConfig
+	fs := command.NewFlagSet("reconcile daemon")
+	passFlags(fs, &cfg.Options)
+	fs.DurationVar(&cfg.Interval, "interval", time.Hour, "time between the end of one run and the start of the next")
+	fs.DurationVar(&cfg.Jitter, "jitter", 5*time.Minute, "random delay of up to this much added before each run")
+	fs.DurationVar(&cfg.StuckAfter, "stuck-after", 24*time.Hour,
+		"delete images queued for longer than this on each run (0 disables)")
+	modelTimeouts := processingFlags(fs, &cfg.Processing,
+		"recover images processing for longer than this on each run (0 disables)")
+	fs.DurationVar(&cfg.RunTimeout, "run-timeout", 30*time.Minute, "cancel a run that takes longer than this (0 disables)")
+	fs.DurationVar(&cfg.ShutdownGrace, "shutdown-grace", 30*time.Second,
+		"how long an in-flight run may continue after SIGTERM")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+	var err error
+	if cfg.Processing.ModelTimeouts, err = parseModelTimeouts(*modelTimeouts); err != nil {
+		return err
+	}
+
+	svc, cleanup, err := newReconcileService(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	daemon, err := reconcile.NewDaemon(svc, cfg)
+	if err != nil {
+		return err
+	}
+	daemon.Run(ctx)
+	return nil
+}
+
+// newReconcileService wires the reconcile service from configuration. The
+// returned func closes the database pool and Redis client.
+func newReconcileService(ctx context.Context) (*reconcile.DefaultService, func(), error) {
+	cfg, db, err := connect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s3Service, err := storage.NewDefaultS3Service(ctx, &cfg.S3)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to create S3 service: %w", err)
+	}
+
+	originals := originalimage.NewDefaultService(originalimage.NewDefaultRepository(db), s3Service)
+	// Without Redis, stuck processing images can't be requeued and are
+	// marked as errors.
+	var enqueuer queue.Enqueuer
+	closeAll := db.Close
+	if e, err := queue.NewAsynqEnqueuerFromEnv(cfg); err == nil {
+		enqueuer = e
+		closeAll = func() {
+			_ = e.Close()
+			db.Close()
+		}
+	}
+	svc := reconcile.NewDefaultService(
+		reconcile.NewDefaultRepository(db), s3Service, cfg.S3.BucketName, originals, enqueuer,
+	)
+	return svc, closeAll, nil
+}
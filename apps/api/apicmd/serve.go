@@ -0,0 +1,195 @@
+package apicmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/command"
+	"github.com/real-staging-ai/api/internal/billing"
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/delivery"
+	"github.com/real-staging-ai/api/internal/dunning"
+	"github.com/real-staging-ai/api/internal/errorcleanup"
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/http"
+	"github.com/real-staging-ai/api/internal/idempotency"
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/imageevent"
+	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/originalimage"
+	"github.com/real-staging-ai/api/internal/overage"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/promptlib"
+	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/internal/slo"
+	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/internal/webhook"
+)
+
+// ServeAPI is "realstaging serve api", which runs the API server until ctx is
+// done.
+var ServeAPI = &command.Command{
+	Name:    "api",
+	Summary: "run the HTTP API server",
+	Run:     runServeAPI,
+}
+
+func runServeAPI(ctx context.Context, args []string) error {
+	fs := command.NewFlagSet("serve api")
+	addr := fs.String("addr", ":8080", "address to listen on")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 30*time.Second,
+		"how long in-flight requests may continue after SIGTERM")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	log := logging.Default()
+
+	// Load configuration
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	log.Info(ctx, fmt.Sprintf("Loaded configuration for environment: %s", cfg.App.Env))
+
+	db, err := storage.NewDefaultDatabase(&cfg.DB)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	s3Service, err := storage.NewDefaultS3Service(ctx, &cfg.S3)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("failed to create S3 service: %v", err))
+	} else if cfg.App.Env == "development" || cfg.App.Env == "local" || cfg.App.Env == "test" {
+		// Ensure bucket exists in dev/local (MinIO) to avoid presign/upload failures
+		// Skip in production where bucket already exists and IAM may not have bucket creation perms
+		if err := s3Service.CreateBucket(ctx); err != nil {
+			log.Error(ctx, fmt.Sprintf("failed to ensure S3 bucket exists: %v", err))
+		}
+	}
+
+	// Domain event bus: side effects subscribe here instead of being called inline.
+	bus := events.NewDefaultBus(log)
+	events.RegisterLogSubscribers(bus, log)
+	if addr := cfg.Redis.Addr(); addr != "" {
+		rdb := redis.NewClient(&redis.Options{Addr: addr})
+		go events.RunJobUpdateBridge(ctx, rdb, bus)
+		// Billing changes reach open billing streams on every instance.
+		sse.RegisterBillingSubscribers(bus, rdb)
+	}
+
+	// The model catalog lives in the models table. Every instance keeps a copy,
+	// reloaded every minute; with a Replicate token, Replicate models are also
+	// synced from Replicate every six hours.
+	settingsRepo := settings.NewDefaultRepository(db.Pool())
+	if err := settings.LoadCatalog(ctx, settingsRepo); err != nil {
+		return fmt.Errorf("failed to load model catalog: %w", err)
+	}
+	go settings.RunCatalogRefresh(ctx, settingsRepo, time.Minute)
+	if cfg.Replicate.APIToken != "" {
+		syncer := settings.NewSyncer(settingsRepo, settings.NewReplicateUpstream(cfg.Replicate))
+		go settings.RunModelSync(ctx, syncer, 6*time.Hour)
+	}
+
+	// Room types and styles are accepted when the worker has published
+	// prompts for them.
+	promptRepo := promptlib.NewDefaultRepository(db)
+	if err := promptlib.LoadCatalog(ctx, promptRepo); err != nil {
+		return fmt.Errorf("failed to load prompt catalog: %w", err)
+	}
+	go promptlib.RunCatalogRefresh(ctx, promptRepo, time.Minute)
+
+	// Create repositories
+	imageRepo := image.NewDefaultRepository(db)
+	jobRepo := job.NewDefaultRepository(db)
+	originalImageRepo := originalimage.NewDefaultRepository(db)
+
+	// Create services
+	originalImageService := originalimage.NewDefaultService(originalImageRepo, s3Service)
+	log.Info(ctx, fmt.Sprintf("Setting up image service (queue: %s)", cfg.Job.QueueName))
+	// Images past the free allowance of users without a subscription are paid
+	// for with credits, taken with the image and given back when staging fails.
+	usageService := billing.NewDefaultUsageService(db, &cfg.Plans)
+	credits := billing.NewCreditPolicy(usageService, project.NewDefaultRepository(db))
+	imageService := image.NewDefaultService(cfg, imageRepo, jobRepo, originalImageService, bus, credits)
+
+	// Outbox relay: re-queue deliveries whose enqueue failed after the row was written.
+	deliveryService := delivery.NewDefaultService(cfg, delivery.NewDefaultRepository(db))
+	go delivery.RunRelay(ctx, deliveryService, time.Minute)
+
+	// Image lifecycle events fan out to users' registered webhook endpoints through the outbox.
+	webhook.RegisterSubscribers(bus, webhook.NewDefaultService(webhook.NewDefaultRepository(db), deliveryService))
+
+	// Every created image starts its staging history; the worker appends the rest.
+	imageevent.RegisterSubscribers(bus, imageevent.NewDefaultService(imageevent.NewDefaultRepository(db)))
+
+	// Credits spent on images that fail to stage are given back.
+	billing.RegisterCreditSubscribers(bus, credit.NewDefaultRepository(db))
+
+	// Subscribers to plans with overage are billed through a Stripe meter for
+	// images past their monthly limit, and not for those that fail.
+	if cfg.Plans.OverageMeterEvent != "" && cfg.Stripe.SecretKey != "" {
+		meter := overage.NewStripeMeter(cfg.Stripe.SecretKey, cfg.Plans.OverageMeterEvent)
+		billing.RegisterOverageSubscribers(bus, usageService, overage.NewDefaultRepository(db), meter,
+			project.NewDefaultRepository(db), user.NewDefaultRepository(db), log)
+	}
+
+	// Subscribers whose renewal payment failed keep their plan for the grace
+	// period; its end is announced once, reaching the user's billing stream.
+	if grace := cfg.Plans.GracePeriod(); grace > 0 {
+		expiry := dunning.NewDefaultService(dunning.NewDefaultRepository(db), bus, grace)
+		go dunning.RunExpiry(ctx, expiry, 15*time.Minute)
+	}
+
+	// Trash retention: images deleted longer ago than the retention period are
+	// removed for good, files included.
+	if retention := cfg.Trash.Retention(); retention > 0 && s3Service != nil {
+		go image.RunTrashRetention(ctx, imageService, s3Service, retention, time.Hour)
+	}
+
+	// Images left in error are trashed after their owners have been emailed a notice.
+	if retention := cfg.ErrorImages.Retention(); retention > 0 {
+		cleanup := errorcleanup.NewDefaultService(errorcleanup.NewDefaultRepository(db), deliveryService)
+		opts := errorcleanup.Options{Retention: retention, Notice: cfg.ErrorImages.Notice()}
+		go errorcleanup.RunCleanup(ctx, cleanup, opts, time.Hour)
+	}
+
+	// Idempotency keys are forgotten a day after their first use.
+	go idempotency.RunPurge(ctx, idempotency.NewDefaultRepository(db), time.Hour)
+
+	s := http.NewServer(cfg, ctx, log, db, bus, imageService, s3Service)
+
+	// SLO tracking: flush per-route rollups and log burn-rate alert changes.
+	sloRepo := slo.NewDefaultRepository(db)
+	go slo.RunFlusher(ctx, s.SLORecorder(), sloRepo, time.Minute, slo.RollupRetention)
+	go slo.RunEvaluator(ctx, slo.NewDefaultService(sloRepo), time.Minute)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Start(*addr) }()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to start server: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Info(ctx, "Shutting down API server...")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), *shutdownTimeout)
+	defer cancel()
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+		return fmt.Errorf("server stopped: %w", err)
+	}
+	return nil
+}
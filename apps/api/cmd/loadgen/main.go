@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/real-staging-ai/api/internal/loadgen"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// main drives synthetic traffic against an API and prints a latency and error
+// report per operation.
+func main() {
+	log := logging.Default()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx); err != nil {
+		log.Error(ctx, fmt.Sprintf("loadgen failed: %v", err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	var (
+		cfg          loadgen.Config
+		tokens       string
+		users        int
+		testUsers    bool
+		mix          string
+		watch        string
+		size         string
+		asJSON       bool
+		maxErrorRate float64
+	)
+	flag.StringVar(&cfg.BaseURL, "target", envOr("LOADGEN_TARGET", "http://localhost:8080"), "API base URL")
+	flag.StringVar(&tokens, "tokens", os.Getenv("LOADGEN_TOKENS"),
+		"comma-separated bearer tokens, one per user; users beyond the list reuse them in turn")
+	flag.IntVar(&users, "users", 0, "number of synthetic users (default: one per token, or 1)")
+	flag.BoolVar(&testUsers, "test-users", false,
+		"send a distinct X-Test-User per user, for local targets that honour it")
+	flag.Float64Var(&cfg.RPS, "rps", loadgen.DefaultRPS, "iterations started per second")
+	flag.DurationVar(&cfg.Duration, "duration", loadgen.DefaultDuration, "how long to start iterations for")
+	flag.IntVar(&cfg.MaxInFlight, "max-in-flight", loadgen.DefaultMaxInFlight,
+		"concurrent iterations; starts beyond this are dropped and reported")
+	flag.StringVar(&mix, "mix", loadgen.DefaultMix.String(), "scenario weights (upload, browse, poll)")
+	flag.StringVar(&watch, "watch", string(loadgen.WatchPoll), "how uploads wait for staging: poll or sse")
+	flag.DurationVar(&cfg.PollInterval, "poll-interval", loadgen.DefaultPollInterval, "delay between status polls")
+	flag.DurationVar(&cfg.ReadyTimeout, "ready-timeout", loadgen.DefaultReadyTimeout,
+		"how long an upload is watched before it counts as timed out")
+	flag.StringVar(&size, "image-size", fmt.Sprintf("%dx%d", loadgen.DefaultImageWidth, loadgen.DefaultImageHeight),
+		"WIDTHxHEIGHT of the generated JPEG uploads")
+	flag.Uint64Var(&cfg.Seed, "seed", 0, "seed for the scenario draw (0 picks one)")
+	flag.BoolVar(&asJSON, "json", false, "print the report as JSON")
+	flag.Float64Var(&maxErrorRate, "max-error-rate", 0,
+		"exit non-zero when any operation's error rate is above this fraction (0 disables the check)")
+	flag.Parse()
+
+	m, err := loadgen.ParseMix(mix)
+	if err != nil {
+		return err
+	}
+	cfg.Mix = m
+	cfg.Watch = loadgen.Watch(watch)
+	if _, err := fmt.Sscanf(size, "%dx%d", &cfg.ImageWidth, &cfg.ImageHeight); err != nil {
+		return fmt.Errorf("invalid -image-size %q: want WIDTHxHEIGHT", size)
+	}
+	cfg.Users = buildUsers(splitList(tokens), users, testUsers)
+
+	runner, err := loadgen.NewRunner(cfg, nil)
+	if err != nil {
+		return err
+	}
+	rep, err := runner.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		out, err := json.MarshalIndent(rep, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal report: %w", err)
+		}
+		fmt.Println(string(out))
+	} else if err := rep.WriteText(os.Stdout); err != nil {
+		return err
+	}
+
+	if maxErrorRate > 0 {
+		for _, op := range rep.Ops {
+			if op.ErrorRate() > maxErrorRate {
+				return fmt.Errorf("%s error rate %.1f%% is above %.1f%%", op.Name, 100*op.ErrorRate(), 100*maxErrorRate)
+			}
+		}
+	}
+	return nil
+}
+
+// buildUsers makes n users, or one per token when n is 0, cycling through
+// the tokens.
+func buildUsers(tokens []string, n int, testUsers bool) []loadgen.User {
+	if n <= 0 {
+		n = max(len(tokens), 1)
+	}
+	out := make([]loadgen.User, n)
+	for i := range out {
+		if len(tokens) > 0 {
+			out[i].Token = tokens[i%len(tokens)]
+		}
+		if testUsers {
+			out[i].TestUser = fmt.Sprintf("loadgen|user-%d", i)
+		}
+	}
+	return out
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
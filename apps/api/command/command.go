@@ -0,0 +1,128 @@
+// Package command dispatches realstaging subcommands and holds the conventions
+// every subcommand follows: flags are parsed with the standard flag package,
+// results are printed to stdout as one indented JSON document, and the exit
+// code is 0 on success, 1 on failure and 2 on a usage error. It imports only
+// the standard library, so the worker's commands can use it too.
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrUsage reports that a command was invoked with invalid arguments.
+var ErrUsage = errors.New("usage error")
+
+// Command is a realstaging subcommand. A command either runs itself or groups
+// further subcommands.
+type Command struct {
+	Name    string
+	Summary string
+
+	// Run runs the command with the arguments that follow its name.
+	Run func(ctx context.Context, args []string) error
+
+	// Commands are the subcommands of a group.
+	Commands []*Command
+}
+
+// Execute runs the command args name below root and returns the process exit
+// code. Usage and errors are written to stderr.
+func Execute(ctx context.Context, root *Command, args []string, stderr io.Writer) int {
+	cmd, path := root, []string{root.Name}
+	for len(cmd.Commands) > 0 {
+		if len(args) == 0 {
+			writeUsage(stderr, cmd, path)
+			return 2
+		}
+		name := args[0]
+		if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+			writeUsage(stderr, cmd, path)
+			return 0
+		}
+		sub := cmd.lookup(name)
+		if sub == nil {
+			_, _ = fmt.Fprintf(stderr, "unknown command %q\n\n", strings.Join(append(path, name), " "))
+			writeUsage(stderr, cmd, path)
+			return 2
+		}
+		cmd, path, args = sub, append(path, name), args[1:]
+	}
+
+	err := cmd.Run(ctx, args)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, ErrUsage):
+		_, _ = fmt.Fprintf(stderr, "%s: %v\n", strings.Join(path, " "), err)
+		return 2
+	default:
+		_, _ = fmt.Fprintf(stderr, "%s failed: %v\n", strings.Join(path, " "), err)
+		return 1
+	}
+}
+
+func (c *Command) lookup(name string) *Command {
+	for _, sub := range c.Commands {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func writeUsage(w io.Writer, cmd *Command, path []string) {
+	name := strings.Join(path, " ")
+	_, _ = fmt.Fprintf(w, "usage: %s <command> [flags]\n\ncommands:\n", name)
+	width := 0
+	for _, sub := range cmd.Commands {
+		width = max(width, len(sub.Name))
+	}
+	for _, sub := range cmd.Commands {
+		_, _ = fmt.Fprintf(w, "  %-*s  %s\n", width, sub.Name, sub.Summary)
+	}
+	_, _ = fmt.Fprintf(w, "\nrun \"%s <command> -h\" for the flags of a command\n", name)
+}
+
+// NewFlagSet returns a flag set for a command that reports parse errors to the
+// caller instead of exiting.
+func NewFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// Parse parses args into fs, rejecting positional arguments beyond the first
+// maxArgs. Parse errors wrap ErrUsage; -h returns flag.ErrHelp.
+func Parse(fs *flag.FlagSet, args []string, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	if fs.NArg() > maxArgs {
+		return fmt.Errorf("%w: unexpected arguments %q", ErrUsage, fs.Args()[maxArgs:])
+	}
+	return nil
+}
+
+// PrintJSON writes v to stdout as indented JSON.
+func PrintJSON(v any) error {
+	return writeJSON(os.Stdout, v)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	var ran []string
+	newRoot := func(runErr error) *Command {
+		leaf := &Command{Name: "images", Summary: "check images", Run: func(ctx context.Context, args []string) error {
+			ran = args
+			return runErr
+		}}
+		return &Command{Name: "realstaging", Commands: []*Command{
+			{Name: "reconcile", Summary: "reconcile storage", Commands: []*Command{leaf}},
+		}}
+	}
+
+	t.Run("success: runs the named leaf with the remaining args", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		code := Execute(context.Background(), newRoot(nil), []string{"reconcile", "images", "-dry-run"}, &stderr)
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, []string{"-dry-run"}, ran)
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("success: help lists the subcommands", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		code := Execute(context.Background(), newRoot(nil), []string{"reconcile", "-h"}, &stderr)
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stderr.String(), "usage: realstaging reconcile <command> [flags]")
+		assert.Contains(t, stderr.String(), "images  check images")
+	})
+
+	t.Run("fail: missing subcommand is a usage error", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		code := Execute(context.Background(), newRoot(nil), []string{"reconcile"}, &stderr)
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), "usage: realstaging reconcile")
+	})
+
+	t.Run("fail: unknown subcommand is a usage error", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		code := Execute(context.Background(), newRoot(nil), []string{"reconcile", "nope"}, &stderr)
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), `unknown command "realstaging reconcile nope"`)
+	})
+
+	t.Run("fail: usage errors from a command exit 2", func(t *testing.T) {
+		var stderr bytes.Buffer
+		root := newRoot(errors.Join(ErrUsage, errors.New("-model is required")))
+
+		code := Execute(context.Background(), root, []string{"reconcile", "images"}, &stderr)
+
+		assert.Equal(t, 2, code)
+	})
+
+	t.Run("fail: command errors exit 1", func(t *testing.T) {
+		var stderr bytes.Buffer
+
+		code := Execute(context.Background(), newRoot(errors.New("boom")), []string{"reconcile", "images"}, &stderr)
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "realstaging reconcile images failed: boom\n", stderr.String())
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("success: parses flags and allowed arguments", func(t *testing.T) {
+		fs := NewFlagSet("migrate")
+		path := fs.String("path", "", "")
+
+		require.NoError(t, Parse(fs, []string{"-path", "/m", "down", "1"}, 2))
+		assert.Equal(t, "/m", *path)
+		assert.Equal(t, []string{"down", "1"}, fs.Args())
+	})
+
+	t.Run("fail: unknown flag", func(t *testing.T) {
+		fs := NewFlagSet("images")
+		fs.SetOutput(&bytes.Buffer{})
+
+		assert.ErrorIs(t, Parse(fs, []string{"-nope"}, 0), ErrUsage)
+	})
+
+	t.Run("fail: unexpected arguments", func(t *testing.T) {
+		err := Parse(NewFlagSet("images"), []string{"extra"}, 0)
+
+		assert.ErrorIs(t, err, ErrUsage)
+		assert.ErrorContains(t, err, `unexpected arguments ["extra"]`)
+	})
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, writeJSON(&out, map[string]int{"deleted": 2}))
+	assert.Equal(t, "{\n  \"deleted\": 2\n}\n", out.String())
+}
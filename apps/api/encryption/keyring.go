@@ -0,0 +1,132 @@
+// Package encryption seals small values stored in the database with
+// AES-256-GCM, so a leaked dump or backup doesn't expose them.
+//
+// Each ciphertext records which key sealed it. A Keyring seals with its
+// current key and opens with any key it holds, so the key can be rotated by
+// moving the old one to ENCRYPTION_KEY_PREVIOUS until everything sealed with
+// it has been rewritten.
+//
+// It imports only the standard library, so the worker can open values the
+// API seals.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// KeySize is the length of a key in bytes, before base64 encoding.
+const KeySize = 32
+
+// version prefixes every ciphertext so the format can change later.
+const version byte = 1
+
+// keyIDSize is how many bytes of the key's SHA-256 identify it in a ciphertext.
+const keyIDSize = 4
+
+var (
+	// ErrUnknownKey is returned when a ciphertext was sealed with a key the
+	// keyring doesn't hold.
+	ErrUnknownKey = errors.New("ciphertext was sealed with an unknown key")
+	// ErrMalformed is returned when a ciphertext is truncated or has an
+	// unsupported version.
+	ErrMalformed = errors.New("malformed ciphertext")
+)
+
+type key struct {
+	id   [keyIDSize]byte
+	aead cipher.AEAD
+}
+
+// Keyring seals with one key and opens with any of several.
+type Keyring struct {
+	current key
+	keys    []key
+}
+
+// NewKeyring builds a keyring from base64-encoded 32-byte keys. current seals
+// new values; previous keys, empty ones skipped, only open existing ones.
+func NewKeyring(current string, previous ...string) (*Keyring, error) {
+	cur, err := parseKey(current)
+	if err != nil {
+		return nil, fmt.Errorf("current key: %w", err)
+	}
+	kr := &Keyring{current: cur, keys: []key{cur}}
+	for i, p := range previous {
+		if p == "" {
+			continue
+		}
+		k, err := parseKey(p)
+		if err != nil {
+			return nil, fmt.Errorf("previous key %d: %w", i+1, err)
+		}
+		kr.keys = append(kr.keys, k)
+	}
+	return kr, nil
+}
+
+func parseKey(encoded string) (key, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return key{}, fmt.Errorf("must be base64: %w", err)
+	}
+	if len(raw) != KeySize {
+		return key{}, fmt.Errorf("must decode to %d bytes, got %d", KeySize, len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return key{}, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return key{}, err
+	}
+	k := key{aead: aead}
+	sum := sha256.Sum256(raw)
+	copy(k.id[:], sum[:keyIDSize])
+	return k, nil
+}
+
+// Seal encrypts plaintext with the current key. aad is authenticated but not
+// stored; Open must be given the same value, which binds the ciphertext to
+// its row (say, the owner's ID) so it can't be copied to another.
+func (k *Keyring) Seal(plaintext, aad []byte) ([]byte, error) {
+	nonceSize := k.current.aead.NonceSize()
+	out := make([]byte, 1+keyIDSize+nonceSize, 1+keyIDSize+nonceSize+len(plaintext)+k.current.aead.Overhead())
+	out[0] = version
+	copy(out[1:], k.current.id[:])
+	nonce := out[1+keyIDSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return k.current.aead.Seal(out, nonce, plaintext, aad), nil
+}
+
+// Open decrypts a ciphertext from Seal with whichever key sealed it.
+func (k *Keyring) Open(ciphertext, aad []byte) ([]byte, error) {
+	if len(ciphertext) < 1+keyIDSize || ciphertext[0] != version {
+		return nil, ErrMalformed
+	}
+	id := ciphertext[1 : 1+keyIDSize]
+	for _, kk := range k.keys {
+		if string(kk.id[:]) != string(id) {
+			continue
+		}
+		rest := ciphertext[1+keyIDSize:]
+		if len(rest) < kk.aead.NonceSize() {
+			return nil, ErrMalformed
+		}
+		nonce, sealed := rest[:kk.aead.NonceSize()], rest[kk.aead.NonceSize():]
+		plaintext, err := kk.aead.Open(nil, nonce, sealed, aad)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt: %w", err)
+		}
+		return plaintext, nil
+	}
+	return nil, ErrUnknownKey
+}
@@ -0,0 +1,107 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, KeySize))
+}
+
+func TestNewKeyring(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  string
+		previous []string
+		wantErr  string
+	}{
+		{name: "success: current only", current: testKey(1)},
+		{name: "success: empty previous keys are skipped", current: testKey(1), previous: []string{"", testKey(2)}},
+		{name: "fail: empty current", current: "", wantErr: "current key: must decode to 32 bytes, got 0"},
+		{name: "fail: not base64", current: "not base64!", wantErr: "current key: must be base64"},
+		{
+			name:    "fail: short key",
+			current: base64.StdEncoding.EncodeToString([]byte("short")),
+			wantErr: "must decode to 32 bytes, got 5",
+		},
+		{name: "fail: bad previous key", current: testKey(1), previous: []string{"nope"}, wantErr: "previous key 1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kr, err := NewKeyring(tc.current, tc.previous...)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, kr)
+		})
+	}
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	kr, err := NewKeyring(testKey(1))
+	require.NoError(t, err)
+	aad := []byte("user-1")
+
+	t.Run("success: round trip", func(t *testing.T) {
+		sealed, err := kr.Seal([]byte(`{"layout":"grid"}`), aad)
+		require.NoError(t, err)
+		assert.NotContains(t, string(sealed), "grid")
+
+		again, err := kr.Seal([]byte(`{"layout":"grid"}`), aad)
+		require.NoError(t, err)
+		assert.NotEqual(t, sealed, again, "nonces are random")
+
+		opened, err := kr.Open(sealed, aad)
+		require.NoError(t, err)
+		assert.Equal(t, `{"layout":"grid"}`, string(opened))
+	})
+
+	t.Run("success: opens with a previous key after rotation", func(t *testing.T) {
+		sealed, err := kr.Seal([]byte("v"), aad)
+		require.NoError(t, err)
+
+		rotated, err := NewKeyring(testKey(2), testKey(1))
+		require.NoError(t, err)
+		opened, err := rotated.Open(sealed, aad)
+		require.NoError(t, err)
+		assert.Equal(t, "v", string(opened))
+
+		resealed, err := rotated.Seal([]byte("v"), aad)
+		require.NoError(t, err)
+		_, err = kr.Open(resealed, aad)
+		assert.ErrorIs(t, err, ErrUnknownKey, "new values use the current key")
+	})
+
+	t.Run("fail: different aad", func(t *testing.T) {
+		sealed, err := kr.Seal([]byte("v"), aad)
+		require.NoError(t, err)
+		_, err = kr.Open(sealed, []byte("user-2"))
+		assert.ErrorContains(t, err, "decrypt")
+	})
+
+	t.Run("fail: tampered", func(t *testing.T) {
+		sealed, err := kr.Seal([]byte("v"), aad)
+		require.NoError(t, err)
+		sealed[len(sealed)-1] ^= 0xFF
+		_, err = kr.Open(sealed, aad)
+		assert.ErrorContains(t, err, "decrypt")
+	})
+
+	t.Run("fail: malformed", func(t *testing.T) {
+		for _, c := range [][]byte{nil, {version}, {9, 0, 0, 0, 0, 0}} {
+			_, err := kr.Open(c, aad)
+			assert.ErrorIs(t, err, ErrMalformed)
+		}
+		sealed, err := kr.Seal([]byte("v"), aad)
+		require.NoError(t, err)
+		_, err = kr.Open(sealed[:8], aad)
+		assert.ErrorIs(t, err, ErrMalformed)
+	})
+}
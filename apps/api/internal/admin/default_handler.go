@@ -10,9 +10,11 @@ import (
 
 	"github.com/real-staging-ai/api/internal/auth"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/problem"
 	"github.com/real-staging-ai/api/internal/settings"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/modelconfig"
 )
 
 // DefaultHandler handles admin-related HTTP requests.
@@ -38,7 +40,7 @@ func (h *DefaultHandler) ListModels(c echo.Context) error {
 	models, err := h.settingsService.ListAvailableModels(ctx)
 	if err != nil {
 		h.log.Error(ctx, "failed to list models", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list models")
+		return problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to list models")
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -53,14 +55,14 @@ func (h *DefaultHandler) GetActiveModel(c echo.Context) error {
 	modelID, err := h.settingsService.GetActiveModel(ctx)
 	if err != nil {
 		h.log.Error(ctx, "failed to get active model", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get active model")
+		return problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to get active model")
 	}
 
 	// Get full model info
 	models, err := h.settingsService.ListAvailableModels(ctx)
 	if err != nil {
 		h.log.Error(ctx, "failed to list models", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get model info")
+		return problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to get model info")
 	}
 
 	var activeModel *settings.ModelInfo
@@ -72,7 +74,7 @@ func (h *DefaultHandler) GetActiveModel(c echo.Context) error {
 	}
 
 	if activeModel == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Active model not found in registry")
+		return problem.New(http.StatusNotFound, problem.CodeNotFound, "Active model not found in registry")
 	}
 
 	return c.JSON(http.StatusOK, activeModel)
@@ -84,27 +86,25 @@ func (h *DefaultHandler) UpdateActiveModel(c echo.Context) error {
 
 	var req settings.UpdateSettingRequest
 	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid request body")
 	}
 
 	// Validate required field
 	if req.Value == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "value is required")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "value is required")
 	}
 
 	// Get user UUID from Auth0 sub
 	userUUID, err := h.resolveUserUUID(c)
 	if err != nil {
 		h.log.Error(ctx, "failed to resolve user", "error", err)
-		return echo.NewHTTPError(http.StatusUnauthorized, map[string]string{
-			"message": "User not authenticated",
-		})
+		return problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not authenticated")
 	}
 
 	err = h.settingsService.UpdateActiveModel(ctx, req.Value, userUUID)
 	if err != nil {
 		h.log.Error(ctx, "failed to update active model", "error", err, "model_id", req.Value)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, err.Error())
 	}
 
 	h.log.Info(ctx, "active model updated", "model_id", req.Value, "user_uuid", userUUID)
@@ -115,6 +115,45 @@ func (h *DefaultHandler) UpdateActiveModel(c echo.Context) error {
 	})
 }
 
+// UpdateModel handles PATCH /admin/models/:id - Enables or disables a model.
+func (h *DefaultHandler) UpdateModel(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid model ID")
+	}
+
+	var req settings.UpdateModelRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid request body")
+	}
+	if req.Enabled == nil {
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "enabled is required")
+	}
+
+	// Get user UUID from Auth0 sub
+	userUUID, err := h.resolveUserUUID(c)
+	if err != nil {
+		h.log.Error(ctx, "failed to resolve user", "error", err)
+		return problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not authenticated")
+	}
+
+	model, err := h.settingsService.SetModelEnabled(ctx, modelID, *req.Enabled, userUUID)
+	switch {
+	case errors.Is(err, settings.ErrModelNotFound):
+		return problem.New(http.StatusNotFound, problem.CodeNotFound, "Model not found")
+	case errors.Is(err, settings.ErrActiveModel):
+		return problem.New(http.StatusConflict, "active_model", err.Error())
+	case err != nil:
+		h.log.Error(ctx, "failed to update model", "error", err, "model_id", modelID)
+		return problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to update model")
+	}
+
+	h.log.Info(ctx, "model updated", "model_id", modelID, "enabled", *req.Enabled, "user_uuid", userUUID)
+
+	return c.JSON(http.StatusOK, model)
+}
+
 // ListSettings handles GET /admin/settings - Lists all settings.
 func (h *DefaultHandler) ListSettings(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -122,7 +161,7 @@ func (h *DefaultHandler) ListSettings(c echo.Context) error {
 	settings, err := h.settingsService.ListSettings(ctx)
 	if err != nil {
 		h.log.Error(ctx, "failed to list settings", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list settings")
+		return problem.New(http.StatusInternalServerError, problem.CodeInternal, "Failed to list settings")
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -138,7 +177,7 @@ func (h *DefaultHandler) GetSetting(c echo.Context) error {
 	setting, err := h.settingsService.GetSetting(ctx, key)
 	if err != nil {
 		h.log.Error(ctx, "failed to get setting", "error", err, "key", key)
-		return echo.NewHTTPError(http.StatusNotFound, "Setting not found")
+		return problem.New(http.StatusNotFound, problem.CodeNotFound, "Setting not found")
 	}
 
 	return c.JSON(http.StatusOK, setting)
@@ -151,27 +190,25 @@ func (h *DefaultHandler) UpdateSetting(c echo.Context) error {
 
 	var req settings.UpdateSettingRequest
 	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid request body")
 	}
 
 	// Validate required field
 	if req.Value == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "value is required")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "value is required")
 	}
 
 	// Get user UUID from Auth0 sub
 	userUUID, err := h.resolveUserUUID(c)
 	if err != nil {
 		h.log.Error(ctx, "failed to resolve user", "error", err)
-		return echo.NewHTTPError(http.StatusUnauthorized, map[string]string{
-			"message": "User not authenticated",
-		})
+		return problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not authenticated")
 	}
 
 	err = h.settingsService.UpdateSetting(ctx, key, req.Value, userUUID)
 	if err != nil {
 		h.log.Error(ctx, "failed to update setting", "error", err, "key", key)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, err.Error())
 	}
 
 	h.log.Info(ctx, "setting updated", "key", key, "user_uuid", userUUID)
@@ -188,13 +225,13 @@ func (h *DefaultHandler) GetModelConfig(c echo.Context) error {
 	ctx := c.Request().Context()
 	modelID, err := url.PathUnescape(c.Param("id"))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid model ID")
 	}
 
 	config, err := h.settingsService.GetModelConfig(ctx, modelID)
 	if err != nil {
 		h.log.Error(ctx, "failed to get model config", "error", err, "model_id", modelID)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, err.Error())
 	}
 
 	return c.JSON(http.StatusOK, config)
@@ -205,27 +242,34 @@ func (h *DefaultHandler) UpdateModelConfig(c echo.Context) error {
 	ctx := c.Request().Context()
 	modelID, err := url.PathUnescape(c.Param("id"))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid model ID")
 	}
 
 	var req map[string]interface{}
 	if err := c.Bind(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid request body")
 	}
 
 	// Get user UUID from Auth0 sub
 	userUUID, err := h.resolveUserUUID(c)
 	if err != nil {
 		h.log.Error(ctx, "failed to resolve user", "error", err)
-		return echo.NewHTTPError(http.StatusUnauthorized, map[string]string{
-			"message": "User not authenticated",
-		})
+		return problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not authenticated")
 	}
 
 	err = h.settingsService.UpdateModelConfig(ctx, modelID, req, userUUID)
+	var invalid *modelconfig.ValidationError
+	if errors.As(err, &invalid) {
+		return problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			"The model configuration is invalid").With("validation_errors", invalid.Fields)
+	}
+	if errors.Is(err, modelconfig.ErrNoKeyring) {
+		return problem.New(http.StatusServiceUnavailable, "encryption_not_configured",
+			"Secret fields can't be saved until ENCRYPTION_KEY is configured")
+	}
 	if err != nil {
 		h.log.Error(ctx, "failed to update model config", "error", err, "model_id", modelID)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, err.Error())
 	}
 
 	h.log.Info(ctx, "model config updated", "model_id", modelID, "user_uuid", userUUID)
@@ -241,18 +285,37 @@ func (h *DefaultHandler) GetModelConfigSchema(c echo.Context) error {
 	ctx := c.Request().Context()
 	modelID, err := url.PathUnescape(c.Param("id"))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid model ID")
 	}
 
 	schema, err := h.settingsService.GetModelConfigSchema(ctx, modelID)
 	if err != nil {
 		h.log.Error(ctx, "failed to get model config schema", "error", err, "model_id", modelID)
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, err.Error())
 	}
 
 	return c.JSON(http.StatusOK, schema)
 }
 
+// GetModelConfigJSONSchema handles GET /admin/models/:id/config/jsonschema - Gets the model's
+// configuration schema as standard JSON Schema (draft 2020-12).
+func (h *DefaultHandler) GetModelConfigJSONSchema(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid model ID")
+	}
+
+	schema, err := h.settingsService.GetModelConfigSchema(ctx, modelID)
+	if err != nil {
+		h.log.Error(ctx, "failed to get model config schema", "error", err, "model_id", modelID)
+		return problem.New(http.StatusBadRequest, problem.CodeBadRequest, err.Error())
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "application/schema+json")
+	return c.JSON(http.StatusOK, schema.ToJSONSchema())
+}
+
 // resolveUserUUID looks up or creates a user based on Auth0 sub, returning the user's UUID.
 func (h *DefaultHandler) resolveUserUUID(c echo.Context) (string, error) {
 	ctx := c.Request().Context()
@@ -14,6 +14,9 @@ type Handler interface {
 	// UpdateActiveModel handles PUT /admin/models/active - Updates the active model.
 	UpdateActiveModel(c echo.Context) error
 
+	// UpdateModel handles PATCH /admin/models/:id - Enables or disables a model.
+	UpdateModel(c echo.Context) error
+
 	// ListSettings handles GET /admin/settings - Lists all settings.
 	ListSettings(c echo.Context) error
 
@@ -32,6 +35,9 @@ type Handler interface {
 	// GetModelConfigSchema handles GET /admin/models/:id/config/schema - Gets the schema for a model's configuration.
 	GetModelConfigSchema(c echo.Context) error
 
+	// GetModelConfigJSONSchema handles GET /admin/models/:id/config/jsonschema - Gets the schema as JSON Schema.
+	GetModelConfigJSONSchema(c echo.Context) error
+
 	// resolveUserUUID looks up or creates a user based on Auth0 sub, returning the user's UUID.
 	resolveUserUUID(c echo.Context) (string, error)
 }
@@ -24,6 +24,9 @@ var _ Handler = &HandlerMock{}
 //			GetModelConfigFunc: func(c echo.Context) error {
 //				panic("mock out the GetModelConfig method")
 //			},
+//			GetModelConfigJSONSchemaFunc: func(c echo.Context) error {
+//				panic("mock out the GetModelConfigJSONSchema method")
+//			},
 //			GetModelConfigSchemaFunc: func(c echo.Context) error {
 //				panic("mock out the GetModelConfigSchema method")
 //			},
@@ -39,6 +42,9 @@ var _ Handler = &HandlerMock{}
 //			UpdateActiveModelFunc: func(c echo.Context) error {
 //				panic("mock out the UpdateActiveModel method")
 //			},
+//			UpdateModelFunc: func(c echo.Context) error {
+//				panic("mock out the UpdateModel method")
+//			},
 //			UpdateModelConfigFunc: func(c echo.Context) error {
 //				panic("mock out the UpdateModelConfig method")
 //			},
@@ -61,6 +67,9 @@ type HandlerMock struct {
 	// GetModelConfigFunc mocks the GetModelConfig method.
 	GetModelConfigFunc func(c echo.Context) error
 
+	// GetModelConfigJSONSchemaFunc mocks the GetModelConfigJSONSchema method.
+	GetModelConfigJSONSchemaFunc func(c echo.Context) error
+
 	// GetModelConfigSchemaFunc mocks the GetModelConfigSchema method.
 	GetModelConfigSchemaFunc func(c echo.Context) error
 
@@ -76,6 +85,9 @@ type HandlerMock struct {
 	// UpdateActiveModelFunc mocks the UpdateActiveModel method.
 	UpdateActiveModelFunc func(c echo.Context) error
 
+	// UpdateModelFunc mocks the UpdateModel method.
+	UpdateModelFunc func(c echo.Context) error
+
 	// UpdateModelConfigFunc mocks the UpdateModelConfig method.
 	UpdateModelConfigFunc func(c echo.Context) error
 
@@ -97,6 +109,11 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// GetModelConfigJSONSchema holds details about calls to the GetModelConfigJSONSchema method.
+		GetModelConfigJSONSchema []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// GetModelConfigSchema holds details about calls to the GetModelConfigSchema method.
 		GetModelConfigSchema []struct {
 			// C is the c argument value.
@@ -122,6 +139,11 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// UpdateModel holds details about calls to the UpdateModel method.
+		UpdateModel []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// UpdateModelConfig holds details about calls to the UpdateModelConfig method.
 		UpdateModelConfig []struct {
 			// C is the c argument value.
@@ -138,16 +160,18 @@ type HandlerMock struct {
 			C echo.Context
 		}
 	}
-	lockGetActiveModel       sync.RWMutex
-	lockGetModelConfig       sync.RWMutex
-	lockGetModelConfigSchema sync.RWMutex
-	lockGetSetting           sync.RWMutex
-	lockListModels           sync.RWMutex
-	lockListSettings         sync.RWMutex
-	lockUpdateActiveModel    sync.RWMutex
-	lockUpdateModelConfig    sync.RWMutex
-	lockUpdateSetting        sync.RWMutex
-	lockresolveUserUUID      sync.RWMutex
+	lockGetActiveModel           sync.RWMutex
+	lockGetModelConfig           sync.RWMutex
+	lockGetModelConfigJSONSchema sync.RWMutex
+	lockGetModelConfigSchema     sync.RWMutex
+	lockGetSetting               sync.RWMutex
+	lockListModels               sync.RWMutex
+	lockListSettings             sync.RWMutex
+	lockUpdateActiveModel        sync.RWMutex
+	lockUpdateModel              sync.RWMutex
+	lockUpdateModelConfig        sync.RWMutex
+	lockUpdateSetting            sync.RWMutex
+	lockresolveUserUUID          sync.RWMutex
 }
 
 // GetActiveModel calls GetActiveModelFunc.
@@ -214,6 +238,38 @@ func (mock *HandlerMock) GetModelConfigCalls() []struct {
 	return calls
 }
 
+// GetModelConfigJSONSchema calls GetModelConfigJSONSchemaFunc.
+func (mock *HandlerMock) GetModelConfigJSONSchema(c echo.Context) error {
+	if mock.GetModelConfigJSONSchemaFunc == nil {
+		panic("HandlerMock.GetModelConfigJSONSchemaFunc: method is nil but Handler.GetModelConfigJSONSchema was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockGetModelConfigJSONSchema.Lock()
+	mock.calls.GetModelConfigJSONSchema = append(mock.calls.GetModelConfigJSONSchema, callInfo)
+	mock.lockGetModelConfigJSONSchema.Unlock()
+	return mock.GetModelConfigJSONSchemaFunc(c)
+}
+
+// GetModelConfigJSONSchemaCalls gets all the calls that were made to GetModelConfigJSONSchema.
+// Check the length with:
+//
+//	len(mockedHandler.GetModelConfigJSONSchemaCalls())
+func (mock *HandlerMock) GetModelConfigJSONSchemaCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockGetModelConfigJSONSchema.RLock()
+	calls = mock.calls.GetModelConfigJSONSchema
+	mock.lockGetModelConfigJSONSchema.RUnlock()
+	return calls
+}
+
 // GetModelConfigSchema calls GetModelConfigSchemaFunc.
 func (mock *HandlerMock) GetModelConfigSchema(c echo.Context) error {
 	if mock.GetModelConfigSchemaFunc == nil {
@@ -374,6 +430,38 @@ func (mock *HandlerMock) UpdateActiveModelCalls() []struct {
 	return calls
 }
 
+// UpdateModel calls UpdateModelFunc.
+func (mock *HandlerMock) UpdateModel(c echo.Context) error {
+	if mock.UpdateModelFunc == nil {
+		panic("HandlerMock.UpdateModelFunc: method is nil but Handler.UpdateModel was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockUpdateModel.Lock()
+	mock.calls.UpdateModel = append(mock.calls.UpdateModel, callInfo)
+	mock.lockUpdateModel.Unlock()
+	return mock.UpdateModelFunc(c)
+}
+
+// UpdateModelCalls gets all the calls that were made to UpdateModel.
+// Check the length with:
+//
+//	len(mockedHandler.UpdateModelCalls())
+func (mock *HandlerMock) UpdateModelCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockUpdateModel.RLock()
+	calls = mock.calls.UpdateModel
+	mock.lockUpdateModel.RUnlock()
+	return calls
+}
+
 // UpdateModelConfig calls UpdateModelConfigFunc.
 func (mock *HandlerMock) UpdateModelConfig(c echo.Context) error {
 	if mock.UpdateModelConfigFunc == nil {
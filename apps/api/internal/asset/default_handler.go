@@ -0,0 +1,123 @@
+package asset
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the image asset endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// RequestCutouts handles POST /api/v1/images/:id/cutouts.
+// It responds 202 with the cutout progress; poll ListCutouts until the status is ready or error.
+func (h *DefaultHandler) RequestCutouts(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid image ID format"})
+	}
+
+	ctx := c.Request().Context()
+	set, err := h.service.RequestCutouts(ctx, imageID)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrImageNotFound):
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Image not found"})
+		case errors.Is(err, ErrImageNotStaged):
+			return c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "conflict",
+				Message: "Image must finish staging before cut-outs can be created",
+			})
+		}
+		h.log.Error(ctx, "failed to request cutouts", "image_id", imageID, "error", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to request cut-outs",
+		})
+	}
+
+	return c.JSON(http.StatusAccepted, set)
+}
+
+// ListCutouts handles GET /api/v1/images/:id/cutouts.
+func (h *DefaultHandler) ListCutouts(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid image ID format"})
+	}
+
+	ctx := c.Request().Context()
+	set, err := h.service.GetCutouts(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, ErrImageNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Image not found"})
+		}
+		h.log.Error(ctx, "failed to list cutouts", "image_id", imageID, "error", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to list cut-outs",
+		})
+	}
+
+	return c.JSON(http.StatusOK, set)
+}
+
+// PresignAsset handles GET /api/v1/assets/:id/presign.
+// Query params:
+// - expires_in: seconds (default: 600)
+// - download: 1 to force Content-Disposition=attachment
+func (h *DefaultHandler) PresignAsset(c echo.Context) error {
+	assetID := c.Param("id")
+	if _, err := uuid.Parse(assetID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid asset ID format"})
+	}
+
+	expiresIn := int64(600)
+	if v := strings.TrimSpace(c.QueryParam("expires_in")); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			expiresIn = n
+		}
+	}
+	contentDisposition := ""
+	if c.QueryParam("download") == "1" {
+		contentDisposition = "attachment"
+	}
+
+	ctx := c.Request().Context()
+	signed, err := h.service.PresignAsset(ctx, assetID, expiresIn, contentDisposition)
+	if err != nil {
+		if errors.Is(err, ErrAssetNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Asset not found"})
+		}
+		h.log.Error(ctx, "failed to presign asset", "asset_id", assetID, "error", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to presign URL",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"url": signed})
+}
@@ -0,0 +1,154 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func newTestContext(method, target, id string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
+func TestDefaultHandler_RequestCutouts(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success: accepted", id: testImageID, wantStatus: http.StatusAccepted},
+		{name: "fail: invalid id", id: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: image not found", id: testImageID, svcErr: ErrImageNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: not staged", id: testImageID, svcErr: ErrImageNotStaged, wantStatus: http.StatusConflict},
+		{
+			name: "fail: service error", id: testImageID,
+			svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RequestCutoutsFunc: func(ctx context.Context, imageID string) (*CutoutSet, error) {
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					return &CutoutSet{ImageID: imageID, Status: CutoutStatusQueued, Cutouts: []Asset{}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			c, rec := newTestContext(http.MethodPost, "/api/v1/images/"+tc.id+"/cutouts", tc.id)
+			assert.NoError(t, h.RequestCutouts(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusAccepted {
+				assert.Contains(t, rec.Body.String(), `"status":"queued"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_ListCutouts(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success: lists cut-outs", id: testImageID, wantStatus: http.StatusOK},
+		{name: "fail: invalid id", id: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: image not found", id: testImageID, svcErr: ErrImageNotFound, wantStatus: http.StatusNotFound},
+		{
+			name: "fail: service error", id: testImageID,
+			svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetCutoutsFunc: func(ctx context.Context, imageID string) (*CutoutSet, error) {
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					return &CutoutSet{
+						ImageID: imageID, Status: CutoutStatusReady,
+						Cutouts: []Asset{{ID: "a-1", Kind: KindCutout, ContentType: "image/png"}},
+					}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			c, rec := newTestContext(http.MethodGet, "/api/v1/images/"+tc.id+"/cutouts", tc.id)
+			assert.NoError(t, h.ListCutouts(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"content_type":"image/png"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_PresignAsset(t *testing.T) {
+	const assetID = "9b2f6c1e-3f4a-4c1b-8a8e-2d7f5e6a9c10"
+	cases := []struct {
+		name        string
+		id          string
+		query       string
+		svcErr      error
+		wantStatus  int
+		wantExpires int64
+		wantDispo   string
+	}{
+		{name: "success: defaults", id: assetID, wantStatus: http.StatusOK, wantExpires: 600},
+		{
+			name: "success: custom expiry and download", id: assetID, query: "?expires_in=60&download=1",
+			wantStatus: http.StatusOK, wantExpires: 60, wantDispo: "attachment",
+		},
+		{name: "fail: invalid id", id: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: not found", id: assetID, svcErr: ErrAssetNotFound, wantStatus: http.StatusNotFound},
+		{
+			name: "fail: presign error", id: assetID,
+			svcErr: errors.New("s3 down"), wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				PresignAssetFunc: func(
+					ctx context.Context, id string, expiresInSeconds int64, contentDisposition string,
+				) (string, error) {
+					if tc.svcErr != nil {
+						return "", tc.svcErr
+					}
+					assert.Equal(t, tc.wantExpires, expiresInSeconds)
+					assert.Equal(t, tc.wantDispo, contentDisposition)
+					return "https://signed", nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			c, rec := newTestContext(http.MethodGet, "/api/v1/assets/"+tc.id+"/presign"+tc.query, tc.id)
+			assert.NoError(t, h.PresignAsset(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"url":"https://signed"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const assetColumns = `
+	id, image_id, kind, url, content_type, width, height, source_x, source_y, created_at`
+
+func scanAsset(row pgx.Row) (*Asset, error) {
+	var a Asset
+	var kind string
+	err := row.Scan(
+		&a.ID, &a.ImageID, &kind, &a.URL, &a.ContentType,
+		&a.Width, &a.Height, &a.SourceX, &a.SourceY, &a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	a.Kind = Kind(kind)
+	return &a, nil
+}
+
+// GetCutoutState returns the parent image's staging and cutout progress.
+func (r *DefaultRepository) GetCutoutState(ctx context.Context, imageID string) (*CutoutState, error) {
+	query := `
+		SELECT id, status, staged_url, COALESCE(cutout_status, ''), cutout_error
+		FROM images
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	var st CutoutState
+	var cutoutStatus string
+	err := r.db.QueryRow(ctx, query, imageID).Scan(
+		&st.ImageID, &st.ImageStatus, &st.StagedURL, &cutoutStatus, &st.CutoutError,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to get cutout state: %w", err)
+	}
+	st.CutoutStatus = CutoutStatus(cutoutStatus)
+	return &st, nil
+}
+
+// MarkCutoutsQueued records that a cutout run was requested, clearing any previous error.
+func (r *DefaultRepository) MarkCutoutsQueued(ctx context.Context, imageID string) error {
+	query := `
+		UPDATE images
+		SET cutout_status = 'queued', cutout_error = NULL, updated_at = now()
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, imageID); err != nil {
+		return fmt.Errorf("failed to mark cutouts queued: %w", err)
+	}
+	return nil
+}
+
+// MarkCutoutsFailed records that a cutout run could not be started or finished.
+func (r *DefaultRepository) MarkCutoutsFailed(ctx context.Context, imageID, errorMsg string) error {
+	query := `
+		UPDATE images
+		SET cutout_status = 'error', cutout_error = $2, updated_at = now()
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, imageID, errorMsg); err != nil {
+		return fmt.Errorf("failed to mark cutouts failed: %w", err)
+	}
+	return nil
+}
+
+// ListByImage returns an image's assets of the given kind, oldest first.
+func (r *DefaultRepository) ListByImage(ctx context.Context, imageID string, kind Kind) ([]Asset, error) {
+	query := `SELECT` + assetColumns + `
+		FROM image_assets
+		WHERE image_id = $1 AND kind = $2
+		ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.Query(ctx, query, imageID, string(kind))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []Asset{}
+	for rows.Next() {
+		a, err := scanAsset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan asset: %w", err)
+		}
+		assets = append(assets, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over asset rows: %w", err)
+	}
+	return assets, nil
+}
+
+// GetByID retrieves an asset by its ID.
+func (r *DefaultRepository) GetByID(ctx context.Context, id string) (*Asset, error) {
+	query := `SELECT` + assetColumns + `
+		FROM image_assets
+		WHERE id = $1`
+
+	a, err := scanAsset(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+	return a, nil
+}
@@ -0,0 +1,104 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultService implements Service using the image_assets table, the asynq
+// queue and S3.
+type DefaultService struct {
+	repo      Repository
+	enqueuer  queue.Enqueuer
+	s3Service storage.S3Service
+	bucket    string
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(cfg *config.Config, repo Repository, s3Service storage.S3Service) *DefaultService {
+	// Best-effort build an enqueuer from env or config; fall back to Noop if not configured.
+	var enq queue.Enqueuer
+	if e, err := queue.NewAsynqEnqueuerFromEnv(cfg); err == nil {
+		enq = e
+	} else {
+		enq = queue.NoopEnqueuer{}
+	}
+	bucket := ""
+	if cfg != nil {
+		bucket = cfg.S3.BucketName
+	}
+	return &DefaultService{repo: repo, enqueuer: enq, s3Service: s3Service, bucket: bucket}
+}
+
+// RequestCutouts queues a cutout run for a staged image.
+func (s *DefaultService) RequestCutouts(ctx context.Context, imageID string) (*CutoutSet, error) {
+	st, err := s.repo.GetCutoutState(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if st.ImageStatus != "ready" || st.StagedURL == nil || *st.StagedURL == "" {
+		return nil, ErrImageNotStaged
+	}
+	if st.CutoutStatus == CutoutStatusQueued || st.CutoutStatus == CutoutStatusProcessing {
+		return s.cutoutSet(ctx, st)
+	}
+
+	if err := s.repo.MarkCutoutsQueued(ctx, imageID); err != nil {
+		return nil, err
+	}
+	payload := queue.CutoutRunPayload{ImageID: imageID, StagedURL: *st.StagedURL}
+	if _, err := s.enqueuer.EnqueueCutoutRun(ctx, payload, nil); err != nil {
+		_ = s.repo.MarkCutoutsFailed(ctx, imageID, "failed to queue cutout run")
+		return nil, fmt.Errorf("failed to enqueue cutout run: %w", err)
+	}
+
+	st.CutoutStatus = CutoutStatusQueued
+	st.CutoutError = nil
+	return s.cutoutSet(ctx, st)
+}
+
+// GetCutouts returns an image's cutout progress and current cut-outs.
+func (s *DefaultService) GetCutouts(ctx context.Context, imageID string) (*CutoutSet, error) {
+	st, err := s.repo.GetCutoutState(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	return s.cutoutSet(ctx, st)
+}
+
+// PresignAsset returns a browser-accessible URL for an asset.
+func (s *DefaultService) PresignAsset(
+	ctx context.Context, assetID string, expiresInSeconds int64, contentDisposition string,
+) (string, error) {
+	a, err := s.repo.GetByID(ctx, assetID)
+	if err != nil {
+		return "", err
+	}
+	key, err := storage.KeyFromURL(a.URL, s.bucket)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive asset key: %w", err)
+	}
+	return s.s3Service.GeneratePresignedGetURL(ctx, key, expiresInSeconds, contentDisposition)
+}
+
+// cutoutSet pairs the cutout progress with the cut-outs currently stored.
+// Cut-outs from a previous run stay listed while a new run is in flight.
+func (s *DefaultService) cutoutSet(ctx context.Context, st *CutoutState) (*CutoutSet, error) {
+	cutouts, err := s.repo.ListByImage(ctx, st.ImageID, KindCutout)
+	if err != nil {
+		return nil, err
+	}
+	return &CutoutSet{
+		ImageID: st.ImageID,
+		Status:  st.CutoutStatus,
+		Error:   st.CutoutError,
+		Cutouts: cutouts,
+	}, nil
+}
@@ -0,0 +1,182 @@
+package asset
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+const testImageID = "550e8400-e29b-41d4-a716-446655440000"
+
+func stagedState(status CutoutStatus) *CutoutState {
+	staged := "s3://real-staging/users/u1/projects/p1/staged/" + testImageID + "-staged.jpg"
+	return &CutoutState{ImageID: testImageID, ImageStatus: "ready", StagedURL: &staged, CutoutStatus: status}
+}
+
+func okEnqueuer() *queue.EnqueuerMock {
+	return &queue.EnqueuerMock{
+		EnqueueCutoutRunFunc: func(
+			ctx context.Context, payload queue.CutoutRunPayload, opts *queue.EnqueueOpts,
+		) (string, error) {
+			return "task-1", nil
+		},
+	}
+}
+
+func listNone(ctx context.Context, imageID string, kind Kind) ([]Asset, error) {
+	return []Asset{}, nil
+}
+
+func TestDefaultService_RequestCutouts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: marks queued and enqueues staged url", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetCutoutStateFunc: func(ctx context.Context, imageID string) (*CutoutState, error) {
+				return stagedState(CutoutStatusError), nil
+			},
+			MarkCutoutsQueuedFunc: func(ctx context.Context, imageID string) error { return nil },
+			ListByImageFunc:       listNone,
+		}
+		enq := okEnqueuer()
+		svc := &DefaultService{repo: repo, enqueuer: enq}
+
+		set, err := svc.RequestCutouts(ctx, testImageID)
+		require.NoError(t, err)
+		assert.Equal(t, CutoutStatusQueued, set.Status)
+		assert.Nil(t, set.Error)
+		require.Len(t, repo.MarkCutoutsQueuedCalls(), 1)
+		require.Len(t, enq.EnqueueCutoutRunCalls(), 1)
+		payload := enq.EnqueueCutoutRunCalls()[0].Payload
+		assert.Equal(t, testImageID, payload.ImageID)
+		assert.Equal(t, *stagedState("").StagedURL, payload.StagedURL)
+	})
+
+	t.Run("success: in-flight run is not repeated", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetCutoutStateFunc: func(ctx context.Context, imageID string) (*CutoutState, error) {
+				return stagedState(CutoutStatusProcessing), nil
+			},
+			ListByImageFunc: listNone,
+		}
+		enq := okEnqueuer()
+		svc := &DefaultService{repo: repo, enqueuer: enq}
+
+		set, err := svc.RequestCutouts(ctx, testImageID)
+		require.NoError(t, err)
+		assert.Equal(t, CutoutStatusProcessing, set.Status)
+		assert.Empty(t, enq.EnqueueCutoutRunCalls())
+	})
+
+	t.Run("fail: image not staged", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetCutoutStateFunc: func(ctx context.Context, imageID string) (*CutoutState, error) {
+				return &CutoutState{ImageID: imageID, ImageStatus: "processing"}, nil
+			},
+		}
+		svc := &DefaultService{repo: repo, enqueuer: okEnqueuer()}
+
+		_, err := svc.RequestCutouts(ctx, testImageID)
+		assert.ErrorIs(t, err, ErrImageNotStaged)
+	})
+
+	t.Run("fail: enqueue error marks run failed", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetCutoutStateFunc: func(ctx context.Context, imageID string) (*CutoutState, error) {
+				return stagedState(CutoutStatusNone), nil
+			},
+			MarkCutoutsQueuedFunc: func(ctx context.Context, imageID string) error { return nil },
+			MarkCutoutsFailedFunc: func(ctx context.Context, imageID, errorMsg string) error { return nil },
+		}
+		enq := &queue.EnqueuerMock{
+			EnqueueCutoutRunFunc: func(
+				ctx context.Context, payload queue.CutoutRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "", errors.New("redis down")
+			},
+		}
+		svc := &DefaultService{repo: repo, enqueuer: enq}
+
+		_, err := svc.RequestCutouts(ctx, testImageID)
+		require.Error(t, err)
+		require.Len(t, repo.MarkCutoutsFailedCalls(), 1)
+	})
+}
+
+func TestDefaultService_GetCutouts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: returns status and cut-outs", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetCutoutStateFunc: func(ctx context.Context, imageID string) (*CutoutState, error) {
+				return stagedState(CutoutStatusReady), nil
+			},
+			ListByImageFunc: func(ctx context.Context, imageID string, kind Kind) ([]Asset, error) {
+				assert.Equal(t, KindCutout, kind)
+				return []Asset{{ID: "a-1", ImageID: imageID, Kind: KindCutout}}, nil
+			},
+		}
+		svc := &DefaultService{repo: repo}
+
+		set, err := svc.GetCutouts(ctx, testImageID)
+		require.NoError(t, err)
+		assert.Equal(t, CutoutStatusReady, set.Status)
+		require.Len(t, set.Cutouts, 1)
+		assert.Equal(t, "a-1", set.Cutouts[0].ID)
+	})
+
+	t.Run("fail: image not found", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetCutoutStateFunc: func(ctx context.Context, imageID string) (*CutoutState, error) {
+				return nil, ErrImageNotFound
+			},
+		}
+		svc := &DefaultService{repo: repo}
+
+		_, err := svc.GetCutouts(ctx, testImageID)
+		assert.ErrorIs(t, err, ErrImageNotFound)
+	})
+}
+
+func TestDefaultService_PresignAsset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: presigns the asset key", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Asset, error) {
+				return &Asset{ID: id, URL: "s3://real-staging/users/u1/projects/p1/cutouts/img/1-1.png"}, nil
+			},
+		}
+		s3 := &storage.S3ServiceMock{
+			GeneratePresignedGetURLFunc: func(
+				ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string,
+			) (string, error) {
+				assert.Equal(t, "users/u1/projects/p1/cutouts/img/1-1.png", fileKey)
+				assert.Equal(t, int64(600), expiresInSeconds)
+				assert.Equal(t, "attachment", contentDisposition)
+				return "https://signed", nil
+			},
+		}
+		svc := &DefaultService{repo: repo, s3Service: s3, bucket: "real-staging"}
+
+		url, err := svc.PresignAsset(ctx, "a-1", 600, "attachment")
+		require.NoError(t, err)
+		assert.Equal(t, "https://signed", url)
+	})
+
+	t.Run("fail: asset not found", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Asset, error) { return nil, ErrAssetNotFound },
+		}
+		svc := &DefaultService{repo: repo}
+
+		_, err := svc.PresignAsset(ctx, "a-1", 600, "")
+		assert.ErrorIs(t, err, ErrAssetNotFound)
+	})
+}
@@ -0,0 +1,17 @@
+package asset
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for image assets.
+type Handler interface {
+	// RequestCutouts handles POST /images/:id/cutouts - Queues a cutout run.
+	RequestCutouts(c echo.Context) error
+
+	// ListCutouts handles GET /images/:id/cutouts - Returns cutout progress and cut-outs.
+	ListCutouts(c echo.Context) error
+
+	// PresignAsset handles GET /assets/:id/presign - Returns a presigned download URL.
+	PresignAsset(c echo.Context) error
+}
@@ -0,0 +1,70 @@
+// Package asset manages child assets derived from an image, such as the
+// transparent-background furniture cut-outs produced by the cutout pipeline.
+package asset
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrAssetNotFound is returned when an asset does not exist.
+	ErrAssetNotFound = errors.New("asset not found")
+	// ErrImageNotFound is returned when the parent image does not exist.
+	ErrImageNotFound = errors.New("image not found")
+	// ErrImageNotStaged is returned when cut-outs are requested before staging finished.
+	ErrImageNotStaged = errors.New("image has no staged result")
+)
+
+// Kind identifies what an asset was derived for.
+type Kind string
+
+// KindCutout is a transparent-background PNG of a single staged furniture piece.
+const KindCutout Kind = "cutout"
+
+// CutoutStatus is the progress of the most recent cutout run for an image.
+type CutoutStatus string
+
+const (
+	// CutoutStatusNone means cut-outs were never requested for the image.
+	CutoutStatusNone CutoutStatus = ""
+	// CutoutStatusQueued means a cutout run is waiting for a worker.
+	CutoutStatusQueued CutoutStatus = "queued"
+	// CutoutStatusProcessing means a worker is segmenting the staged image.
+	CutoutStatusProcessing CutoutStatus = "processing"
+	// CutoutStatusReady means the cut-outs from the latest run are available.
+	CutoutStatusReady CutoutStatus = "ready"
+	// CutoutStatusError means the latest run failed; see CutoutSet.Error.
+	CutoutStatusError CutoutStatus = "error"
+)
+
+// Asset is a stored object derived from an image.
+type Asset struct {
+	ID          string    `json:"id"`
+	ImageID     string    `json:"image_id"`
+	Kind        Kind      `json:"kind"`
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	Width       int       `json:"width"`
+	Height      int       `json:"height"`
+	SourceX     int       `json:"source_x"`
+	SourceY     int       `json:"source_y"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// CutoutState is the parent image's staging and cutout progress.
+type CutoutState struct {
+	ImageID      string
+	ImageStatus  string
+	StagedURL    *string
+	CutoutStatus CutoutStatus
+	CutoutError  *string
+}
+
+// CutoutSet is the cutout progress of an image together with its current cut-outs.
+type CutoutSet struct {
+	ImageID string       `json:"image_id"`
+	Status  CutoutStatus `json:"status"`
+	Error   *string      `json:"error,omitempty"`
+	Cutouts []Asset      `json:"cutouts"`
+}
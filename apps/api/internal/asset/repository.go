@@ -0,0 +1,23 @@
+package asset
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for image assets and cutout progress.
+type Repository interface {
+	// GetCutoutState returns the parent image's staging and cutout progress.
+	GetCutoutState(ctx context.Context, imageID string) (*CutoutState, error)
+
+	// MarkCutoutsQueued records that a cutout run was requested, clearing any previous error.
+	MarkCutoutsQueued(ctx context.Context, imageID string) error
+
+	// MarkCutoutsFailed records that a cutout run could not be started or finished.
+	MarkCutoutsFailed(ctx context.Context, imageID, errorMsg string) error
+
+	// ListByImage returns an image's assets of the given kind, oldest first.
+	ListByImage(ctx context.Context, imageID string, kind Kind) ([]Asset, error)
+
+	// GetByID retrieves an asset by its ID.
+	GetByID(ctx context.Context, id string) (*Asset, error)
+}
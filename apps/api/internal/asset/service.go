@@ -0,0 +1,18 @@
+package asset
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for image assets.
+type Service interface {
+	// RequestCutouts queues a cutout run for a staged image. A run that is
+	// already queued or processing is returned as-is instead of being repeated.
+	RequestCutouts(ctx context.Context, imageID string) (*CutoutSet, error)
+
+	// GetCutouts returns an image's cutout progress and current cut-outs.
+	GetCutouts(ctx context.Context, imageID string) (*CutoutSet, error)
+
+	// PresignAsset returns a browser-accessible URL for an asset.
+	PresignAsset(ctx context.Context, assetID string, expiresInSeconds int64, contentDisposition string) (string, error)
+}
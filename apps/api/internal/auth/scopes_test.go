@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope_Grants(t *testing.T) {
+	testCases := []struct {
+		name     string
+		held     Scope
+		required Scope
+		want     bool
+	}{
+		{name: "exact match", held: ScopeImagesRead, required: ScopeImagesRead, want: true},
+		{name: "read does not grant write", held: ScopeProjectsRead, required: ScopeProjectsWrite},
+		{name: "other resource", held: ScopeImagesWrite, required: ScopeProjectsWrite},
+		{name: "resource wildcard", held: "images:*", required: ScopeImagesWrite, want: true},
+		{name: "wildcard stays within its resource", held: "images:*", required: ScopeProjectsRead},
+		{name: "admin wildcard", held: ScopeAdmin, required: ScopeAdmin, want: true},
+		{name: "admin does not grant images", held: ScopeAdmin, required: ScopeImagesRead},
+		{name: "malformed scope", held: "images", required: ScopeImagesRead},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.held.Grants(tc.required))
+		})
+	}
+}
+
+func TestIsMachineToken(t *testing.T) {
+	assert.True(t, IsMachineToken(jwt.MapClaims{"gty": "client-credentials", "sub": "abc"}))
+	assert.True(t, IsMachineToken(jwt.MapClaims{"sub": "abc123@clients"}))
+	assert.False(t, IsMachineToken(jwt.MapClaims{"sub": "auth0|user"}))
+	assert.False(t, IsMachineToken(jwt.MapClaims{}))
+}
+
+func TestTokenScopes(t *testing.T) {
+	claims := jwt.MapClaims{
+		"scope":       "images:read  projects:read",
+		"permissions": []interface{}{"billing:read", 42},
+	}
+
+	assert.Equal(t, []Scope{ScopeImagesRead, ScopeProjectsRead, ScopeBillingRead}, TokenScopes(claims))
+	assert.Empty(t, TokenScopes(jwt.MapClaims{}))
+}
+
+func TestScopeMiddleware(t *testing.T) {
+	routes := RouteScopes{
+		"GET /projects/:id":    ScopeProjectsRead,
+		"DELETE /projects/:id": ScopeProjectsWrite,
+	}
+	machine := func(scope string) *jwt.Token {
+		return &jwt.Token{Claims: jwt.MapClaims{"sub": "svc@clients", "gty": "client-credentials", "scope": scope}}
+	}
+
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		token         *jwt.Token
+		expectCalled  bool
+		expectStatus  int
+		expectWWWAuth string
+	}{
+		{
+			name:         "success: user session is not scope checked",
+			method:       http.MethodDelete,
+			path:         "/projects/:id",
+			token:        &jwt.Token{Claims: jwt.MapClaims{"sub": "auth0|user"}},
+			expectCalled: true,
+		},
+		{
+			name:         "success: no token",
+			method:       http.MethodDelete,
+			path:         "/projects/:id",
+			expectCalled: true,
+		},
+		{
+			name:         "success: machine token holds the scope",
+			method:       http.MethodGet,
+			path:         "/projects/:id",
+			token:        machine("projects:read"),
+			expectCalled: true,
+		},
+		{
+			name:         "success: wildcard scope",
+			method:       http.MethodDelete,
+			path:         "/projects/:id",
+			token:        machine("projects:*"),
+			expectCalled: true,
+		},
+		{
+			name:          "fail: read-only key cannot delete",
+			method:        http.MethodDelete,
+			path:          "/projects/:id",
+			token:         machine("projects:read images:write"),
+			expectStatus:  http.StatusForbidden,
+			expectWWWAuth: `Bearer error="insufficient_scope", scope="projects:write"`,
+		},
+		{
+			name:         "fail: route without a scope",
+			method:       http.MethodGet,
+			path:         "/unmapped",
+			token:        machine("admin:*"),
+			expectStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(tc.method, "/", nil), rec)
+			c.SetPath(tc.path)
+			if tc.token != nil {
+				c.Set("user", tc.token)
+			}
+
+			called := false
+			err := ScopeMiddleware(routes)(func(c echo.Context) error {
+				called = true
+				return nil
+			})(c)
+
+			assert.Equal(t, tc.expectCalled, called)
+			if tc.expectStatus == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var he *echo.HTTPError
+			require.True(t, errors.As(err, &he))
+			assert.Equal(t, tc.expectStatus, he.Code)
+			assert.Equal(t, tc.expectWWWAuth, rec.Header().Get(echo.HeaderWWWAuthenticate))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package batch
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the batch progress API.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// GetBatch handles GET /api/v1/batches/:id.
+func (h *DefaultHandler) GetBatch(c echo.Context) error {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid batch ID format"})
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "Invalid or missing JWT token"})
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "User not found"})
+	}
+
+	b, err := h.service.Get(c.Request().Context(), userRow.ID.String(), id)
+	if err != nil {
+		if errors.Is(err, ErrBatchNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Batch not found"})
+		}
+		h.log.Error(c.Request().Context(), "failed to get batch", "batch_id", id, "error", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get batch",
+		})
+	}
+	return c.JSON(http.StatusOK, b)
+}
@@ -0,0 +1,70 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_GetBatch(t *testing.T) {
+	userID := uuid.New()
+	batchID := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		id           string
+		serviceErr   error
+		expectedCode int
+		expectBody   string
+	}{
+		{name: "success: returns progress", id: batchID, expectedCode: http.StatusOK, expectBody: `"status":"creating"`},
+		{name: "fail: invalid id", id: "nope", expectedCode: http.StatusBadRequest},
+		{name: "fail: another user's batch", id: batchID, serviceErr: ErrBatchNotFound, expectedCode: http.StatusNotFound},
+		{
+			name: "fail: database error", id: batchID, serviceErr: errors.New("boom"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetFunc: func(ctx context.Context, uid, id string) (*Batch, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Batch{ID: id, Status: StatusCreating, Total: 1, Items: []Item{{Status: ItemPending}}}, nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepo, logging.Default()).GetBatch(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+				assert.Equal(t, userID.String(), svc.GetCalls()[0].UserID)
+			}
+		})
+	}
+}
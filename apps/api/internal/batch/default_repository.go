@@ -0,0 +1,132 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// Create inserts the batch and its pending items in one statement.
+func (r *DefaultRepository) Create(ctx context.Context, userID string, projectIDs []string) (*Batch, error) {
+	query := `
+		WITH b AS (
+			INSERT INTO image_batches (user_id, total)
+			VALUES ($1, cardinality($2::uuid[]))
+			RETURNING id, user_id, total, created_at, updated_at
+		), items AS (
+			INSERT INTO image_batch_items (batch_id, item_index, project_id)
+			SELECT b.id, t.ord - 1, t.project_id
+			FROM b, unnest($2::uuid[]) WITH ORDINALITY AS t(project_id, ord)
+		)
+		SELECT id, user_id, total, created_at, updated_at FROM b`
+
+	var b Batch
+	err := r.db.QueryRow(ctx, query, userID, projectIDs).Scan(&b.ID, &b.UserID, &b.Total, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	b.Items = make([]Item, len(projectIDs))
+	for i, projectID := range projectIDs {
+		b.Items[i] = Item{Index: i, ProjectID: projectID, Status: ItemPending}
+	}
+	b.summarize()
+	return &b, nil
+}
+
+// MarkCreated records the item's image and touches the batch.
+func (r *DefaultRepository) MarkCreated(ctx context.Context, batchID string, index int, imageID string) error {
+	query := `
+		WITH item AS (
+			UPDATE image_batch_items SET state = 'created', image_id = $3
+			WHERE batch_id = $1 AND item_index = $2
+		)
+		UPDATE image_batches SET updated_at = now() WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, batchID, index, imageID); err != nil {
+		return fmt.Errorf("failed to mark batch item created: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records the item's error and touches the batch.
+func (r *DefaultRepository) MarkFailed(ctx context.Context, batchID string, index int, message string) error {
+	query := `
+		WITH item AS (
+			UPDATE image_batch_items SET state = 'failed', error = $3
+			WHERE batch_id = $1 AND item_index = $2
+		)
+		UPDATE image_batches SET updated_at = now() WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, batchID, index, message); err != nil {
+		return fmt.Errorf("failed to mark batch item failed: %w", err)
+	}
+	return nil
+}
+
+// GetForUser reads the batch, then its items joined to their images' live status.
+func (r *DefaultRepository) GetForUser(ctx context.Context, id, userID string) (*Batch, error) {
+	query := `
+		SELECT id, user_id, total, created_at, updated_at
+		FROM image_batches
+		WHERE id = $1 AND user_id = $2`
+
+	var b Batch
+	err := r.db.QueryRow(ctx, query, id, userID).Scan(&b.ID, &b.UserID, &b.Total, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get batch: %w", err)
+	}
+
+	itemsQuery := `
+		SELECT i.item_index, i.project_id::text, i.image_id::text, i.error,
+			CASE
+				WHEN i.state <> 'created' THEN i.state
+				WHEN img.id IS NULL THEN 'deleted'
+				ELSE img.status::text
+			END
+		FROM image_batch_items i
+		LEFT JOIN images img ON img.id = i.image_id
+		WHERE i.batch_id = $1
+		ORDER BY i.item_index`
+
+	rows, err := r.db.Query(ctx, itemsQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch items: %w", err)
+	}
+	defer rows.Close()
+
+	b.Items = []Item{}
+	for rows.Next() {
+		var item Item
+		if err := rows.Scan(&item.Index, &item.ProjectID, &item.ImageID, &item.Error, &item.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan batch item: %w", err)
+		}
+		b.Items = append(b.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over batch item rows: %w", err)
+	}
+
+	b.summarize()
+	return &b, nil
+}
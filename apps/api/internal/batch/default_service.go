@@ -0,0 +1,69 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+	log  logging.Logger
+	// spawn runs a started batch; tests replace it to run synchronously.
+	spawn func(func())
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository, log logging.Logger) *DefaultService {
+	return &DefaultService{repo: repo, log: log, spawn: func(run func()) { go run() }}
+}
+
+// Start records the batch and creates its images in the background.
+// The background work is detached from the request's cancellation so it
+// outlives the response.
+func (s *DefaultService) Start(
+	ctx context.Context, userID string, projectIDs []string, create CreateFunc,
+) (*Batch, error) {
+	if len(projectIDs) == 0 {
+		return nil, fmt.Errorf("batch must contain at least one item")
+	}
+	b, err := s.repo.Create(ctx, userID, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	bg := context.WithoutCancel(ctx)
+	s.spawn(func() { s.run(bg, b.ID, len(projectIDs), create) })
+	return b, nil
+}
+
+// run creates each item's image and records the outcome. Bookkeeping failures
+// are logged; they never stop the remaining items.
+func (s *DefaultService) run(ctx context.Context, batchID string, total int, create CreateFunc) {
+	failed := 0
+	for i := 0; i < total; i++ {
+		imageID, err := create(ctx, i)
+		if err != nil {
+			failed++
+			s.log.Error(ctx, "batch item failed", "batch_id", batchID, "index", i, "error", err)
+			if err := s.repo.MarkFailed(ctx, batchID, i, "Failed to create image"); err != nil {
+				s.log.Error(ctx, "failed to record batch item failure", "batch_id", batchID, "index", i, "error", err)
+			}
+			continue
+		}
+		if err := s.repo.MarkCreated(ctx, batchID, i, imageID); err != nil {
+			s.log.Error(ctx, "failed to record batch item", "batch_id", batchID, "index", i, "error", err)
+		}
+	}
+	s.log.Info(ctx, "batch created", "batch_id", batchID, "total", total, "failed", failed)
+}
+
+// Get retrieves one of the user's batches.
+func (s *DefaultService) Get(ctx context.Context, userID, id string) (*Batch, error) {
+	return s.repo.GetForUser(ctx, id, userID)
+}
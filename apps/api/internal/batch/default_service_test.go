@@ -0,0 +1,135 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func newTestService(repo Repository) *DefaultService {
+	s := NewDefaultService(repo, logging.Default())
+	s.spawn = func(run func()) { run() }
+	return s
+}
+
+func TestDefaultService_Start(t *testing.T) {
+	projectIDs := []string{"p-0", "p-1", "p-2"}
+
+	t.Run("success: failed items do not stop the batch", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, userID string, projectIDs []string) (*Batch, error) {
+				return &Batch{ID: "batch-1", UserID: userID, Total: len(projectIDs)}, nil
+			},
+			MarkCreatedFunc: func(ctx context.Context, batchID string, index int, imageID string) error {
+				return nil
+			},
+			MarkFailedFunc: func(ctx context.Context, batchID string, index int, message string) error {
+				return nil
+			},
+		}
+		var calls []int
+		create := func(ctx context.Context, index int) (string, error) {
+			calls = append(calls, index)
+			if index == 1 {
+				return "", errors.New("insert failed")
+			}
+			return "img-" + projectIDs[index], nil
+		}
+
+		b, err := newTestService(repo).Start(context.Background(), "user-1", projectIDs, create)
+		require.NoError(t, err)
+		assert.Equal(t, "batch-1", b.ID)
+		assert.Equal(t, []int{0, 1, 2}, calls)
+
+		require.Len(t, repo.MarkCreatedCalls(), 2)
+		assert.Equal(t, 0, repo.MarkCreatedCalls()[0].Index)
+		assert.Equal(t, "img-p-2", repo.MarkCreatedCalls()[1].ImageID)
+		require.Len(t, repo.MarkFailedCalls(), 1)
+		assert.Equal(t, 1, repo.MarkFailedCalls()[0].Index)
+		assert.NotContains(t, repo.MarkFailedCalls()[0].Message, "insert failed", "internal errors are not exposed")
+	})
+
+	t.Run("success: work outlives the request context", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, userID string, projectIDs []string) (*Batch, error) {
+				return &Batch{ID: "batch-1"}, nil
+			},
+			MarkCreatedFunc: func(ctx context.Context, batchID string, index int, imageID string) error {
+				return nil
+			},
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		s := newTestService(repo)
+		s.spawn = func(run func()) {
+			cancel()
+			run()
+		}
+
+		_, err := s.Start(ctx, "user-1", projectIDs[:1], func(ctx context.Context, index int) (string, error) {
+			return "img", ctx.Err()
+		})
+		require.NoError(t, err)
+		assert.Len(t, repo.MarkCreatedCalls(), 1)
+	})
+
+	t.Run("fail: empty batch", func(t *testing.T) {
+		repo := &RepositoryMock{}
+
+		_, err := newTestService(repo).Start(context.Background(), "user-1", nil, nil)
+		assert.Error(t, err)
+		assert.Empty(t, repo.CreateCalls())
+	})
+
+	t.Run("fail: batch not recorded", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, userID string, projectIDs []string) (*Batch, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		called := false
+
+		_, err := newTestService(repo).Start(context.Background(), "user-1", projectIDs,
+			func(ctx context.Context, index int) (string, error) {
+				called = true
+				return "", nil
+			})
+		assert.Error(t, err)
+		assert.False(t, called)
+	})
+}
+
+func TestBatch_summarize(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []ItemStatus
+		want     Status
+	}{
+		{name: "success: still creating", statuses: []ItemStatus{ItemPending, itemReady}, want: StatusCreating},
+		{name: "success: processing", statuses: []ItemStatus{itemQueued, itemReady, ItemFailed}, want: StatusProcessing},
+		{name: "success: completed", statuses: []ItemStatus{itemReady, itemReady}, want: StatusCompleted},
+		{name: "success: partial", statuses: []ItemStatus{itemReady, itemError, ItemFailed}, want: StatusPartial},
+		{name: "success: failed", statuses: []ItemStatus{ItemFailed, itemError, ItemDeleted}, want: StatusFailed},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &Batch{}
+			for i, s := range tc.statuses {
+				b.Items = append(b.Items, Item{Index: i, Status: s})
+			}
+			b.summarize()
+			assert.Equal(t, tc.want, b.Status)
+		})
+	}
+
+	t.Run("success: counts by status", func(t *testing.T) {
+		b := &Batch{Items: []Item{{Status: itemReady}, {Status: itemReady}, {Status: ItemFailed}, {Status: itemProcessing}}}
+		b.summarize()
+		assert.Equal(t, Progress{Ready: 2, Failed: 1, Processing: 1}, b.Progress)
+	})
+}
@@ -0,0 +1,11 @@
+package batch
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for batch progress.
+type Handler interface {
+	// GetBatch handles GET /batches/:id - Gets a batch's progress.
+	GetBatch(c echo.Context) error
+}
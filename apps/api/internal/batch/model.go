@@ -0,0 +1,120 @@
+// Package batch tracks asynchronous batch staging requests.
+//
+// A batch records one item per requested image. Images are created in the
+// background; an item that fails to create is marked failed and the rest of
+// the batch carries on. Once an item's image exists its progress is the
+// image's own status, so polling a batch reflects staging as it happens.
+package batch
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrBatchNotFound is returned when a batch does not exist or belongs to another user.
+var ErrBatchNotFound = errors.New("batch not found")
+
+// Status summarizes a batch's progress.
+type Status string
+
+const (
+	// StatusCreating means some images have not been created yet.
+	StatusCreating Status = "creating"
+	// StatusProcessing means every image was created and some are still staging.
+	StatusProcessing Status = "processing"
+	// StatusCompleted means every image is ready.
+	StatusCompleted Status = "completed"
+	// StatusPartial means the batch finished with some failed or errored items.
+	StatusPartial Status = "partial"
+	// StatusFailed means no item produced a ready image.
+	StatusFailed Status = "failed"
+)
+
+// ItemStatus is an item's progress. Created items report their image's status
+// (queued, processing, ready or error).
+type ItemStatus string
+
+const (
+	// ItemPending means the image has not been created yet.
+	ItemPending ItemStatus = "pending"
+	// ItemFailed means the image could not be created; Error says why.
+	ItemFailed ItemStatus = "failed"
+	// ItemDeleted means the image was created and later deleted.
+	ItemDeleted ItemStatus = "deleted"
+
+	itemQueued     ItemStatus = "queued"
+	itemProcessing ItemStatus = "processing"
+	itemReady      ItemStatus = "ready"
+	itemError      ItemStatus = "error"
+	itemRejected   ItemStatus = "rejected"
+)
+
+// Batch is a batch staging request and the progress of its items.
+type Batch struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"-"`
+	Status    Status    `json:"status"`
+	Total     int       `json:"total"`
+	Progress  Progress  `json:"progress"`
+	Items     []Item    `json:"items"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Progress counts a batch's items by status.
+type Progress struct {
+	Pending    int `json:"pending"`
+	Queued     int `json:"queued"`
+	Processing int `json:"processing"`
+	Ready      int `json:"ready"`
+	Error      int `json:"error"`
+	Failed     int `json:"failed"`
+	Deleted    int `json:"deleted"`
+}
+
+// Item is one requested image. Index is its position in the original request.
+type Item struct {
+	Index     int        `json:"index"`
+	ProjectID string     `json:"project_id"`
+	ImageID   *string    `json:"image_id,omitempty"`
+	Status    ItemStatus `json:"status"`
+	Error     *string    `json:"error,omitempty"`
+}
+
+// summarize fills in the batch's progress counts and overall status from its items.
+func (b *Batch) summarize() {
+	p := Progress{}
+	for _, item := range b.Items {
+		switch item.Status {
+		case ItemPending:
+			p.Pending++
+		case itemQueued:
+			p.Queued++
+		case itemProcessing:
+			p.Processing++
+		case itemReady:
+			p.Ready++
+		case itemError, itemRejected:
+			// Quarantined uploads count as errors; the item's status tells them apart.
+			p.Error++
+		case ItemFailed:
+			p.Failed++
+		case ItemDeleted:
+			p.Deleted++
+		}
+	}
+	b.Progress = p
+
+	switch {
+	case p.Pending > 0:
+		b.Status = StatusCreating
+	case p.Queued+p.Processing > 0:
+		b.Status = StatusProcessing
+	case p.Ready == len(b.Items):
+		b.Status = StatusCompleted
+	case p.Ready == 0:
+		b.Status = StatusFailed
+	default:
+		b.Status = StatusPartial
+	}
+}
@@ -0,0 +1,20 @@
+package batch
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for batches and their items.
+type Repository interface {
+	// Create records a batch with one pending item per project ID, in order.
+	Create(ctx context.Context, userID string, projectIDs []string) (*Batch, error)
+
+	// MarkCreated records the image created for an item.
+	MarkCreated(ctx context.Context, batchID string, index int, imageID string) error
+
+	// MarkFailed records why an item's image could not be created.
+	MarkFailed(ctx context.Context, batchID string, index int, message string) error
+
+	// GetForUser retrieves a user's batch with its items' current statuses.
+	GetForUser(ctx context.Context, id, userID string) (*Batch, error)
+}
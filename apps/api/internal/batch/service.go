@@ -0,0 +1,19 @@
+package batch
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// CreateFunc creates the image for the item at index and returns its ID.
+type CreateFunc func(ctx context.Context, index int) (imageID string, err error)
+
+// Service defines the business logic for batch staging requests.
+type Service interface {
+	// Start records a batch with one item per project ID and returns it
+	// immediately. create is then called once per item, in order, in the
+	// background; an error fails only that item.
+	Start(ctx context.Context, userID string, projectIDs []string, create CreateFunc) (*Batch, error)
+
+	// Get retrieves one of the user's batches with its current progress.
+	Get(ctx context.Context, userID, id string) (*Batch, error)
+}
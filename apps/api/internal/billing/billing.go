@@ -69,10 +69,12 @@ type InvoiceDTO struct {
 }
 
 // ListResponse is a generic pagination wrapper for list endpoints.
+// NextCursor fetches the following page and is nil on the last one.
 type ListResponse[T any] struct {
-	Items  []T   `json:"items"`
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Items      []T     `json:"items"`
+	Limit      int32   `json:"limit"`
+	Offset     int32   `json:"offset"`
+	NextCursor *string `json:"next_cursor"`
 }
 
 // Pagination captures common pagination inputs.
@@ -0,0 +1,100 @@
+package billing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out billing_service_mock.go . BillingService
+
+var (
+	// ErrStripeNotConfigured reports that no Stripe key is set for the
+	// caller's account mode.
+	ErrStripeNotConfigured = errors.New("stripe not configured")
+	// ErrNoStripeCustomer reports that the user has never checked out.
+	ErrNoStripeCustomer = errors.New("user has no Stripe customer")
+	// ErrNoActiveSubscription reports that the user has no subscription to change.
+	ErrNoActiveSubscription = errors.New("no active subscription")
+	// ErrNoPaymentIntent reports that Stripe returned a subscription change
+	// needing payment without a payment intent to confirm it.
+	ErrNoPaymentIntent = errors.New("subscription change has no payment intent")
+	// ErrInvoiceNotFound reports that the user has no invoice with the given ID.
+	ErrInvoiceNotFound = errors.New("invoice not found")
+	// ErrInvoicePDFUnavailable reports that Stripe has no PDF for the invoice
+	// yet, as for a draft.
+	ErrInvoicePDFUnavailable = errors.New("invoice PDF not available")
+)
+
+// BillingService runs the Stripe side of checkout, the customer portal and
+// subscription changes for a user. The HTTP handlers resolve the user and map
+// its errors to responses.
+type BillingService interface {
+	// CreateCheckoutSession starts a subscription checkout and returns its URL.
+	CreateCheckoutSession(ctx context.Context, account Account, priceID string) (string, error)
+
+	// CreateCreditCheckout starts a one-time checkout for a credit pack and
+	// returns its URL. The credits are added by the webhook once it is paid.
+	CreateCreditCheckout(ctx context.Context, account Account, pack config.CreditPack) (string, error)
+
+	// CreatePortalSession opens the Stripe Customer Portal and returns its URL.
+	CreatePortalSession(ctx context.Context, account Account) (string, error)
+
+	// CreateSubscription creates an incomplete subscription for Stripe
+	// Elements to confirm.
+	CreateSubscription(ctx context.Context, account Account, priceID string) (*SubscriptionIntent, error)
+
+	// ChangeSubscription moves the user's active subscription to another price.
+	ChangeSubscription(ctx context.Context, account Account, priceID string) (*SubscriptionIntent, error)
+
+	// CancelSubscription cancels the user's subscription at the end of its period.
+	CancelSubscription(ctx context.Context, account Account) error
+
+	// ListPaymentMethods returns the user's saved cards.
+	ListPaymentMethods(ctx context.Context, account Account) ([]PaymentMethod, error)
+
+	// InvoicePDF returns where Stripe serves the PDF of one of the user's invoices.
+	InvoicePDF(ctx context.Context, account Account, invoiceID string) (*InvoicePDF, error)
+}
+
+// Account is the user a billing operation acts for.
+type Account struct {
+	UserID   string
+	Auth0Sub string
+	// StripeCustomerID is empty until the user first checks out.
+	StripeCustomerID string
+}
+
+// SubscriptionIntent is the outcome of creating or changing a subscription.
+type SubscriptionIntent struct {
+	SubscriptionID string
+	// ClientSecret confirms the payment with Stripe Elements. It is empty
+	// when no payment is needed.
+	ClientSecret string
+	// Downgraded is set when the subscription moved to the free plan.
+	Downgraded bool
+}
+
+// PaymentMethod is a saved card as shown on the billing page.
+type PaymentMethod struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Card      PaymentCard `json:"card"`
+	IsDefault bool        `json:"isDefault"`
+}
+
+// PaymentCard holds the displayable details of a card.
+type PaymentCard struct {
+	Brand    string `json:"brand"`
+	Last4    string `json:"last4"`
+	ExpMonth int64  `json:"expMonth"`
+	ExpYear  int64  `json:"expYear"`
+}
+
+// InvoicePDF locates the PDF of an invoice.
+type InvoicePDF struct {
+	URL string
+	// Filename names the download after the invoice number.
+	Filename string
+}
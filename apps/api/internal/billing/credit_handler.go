@@ -0,0 +1,98 @@
+package billing
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/i18n"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// creditEntriesLimit is how many recent ledger entries GetMyCredits returns.
+const creditEntriesLimit = 50
+
+// CreditsResponse is the current user's credit balance and what they can buy.
+type CreditsResponse struct {
+	Balance int32               `json:"balance"`
+	Packs   []config.CreditPack `json:"packs"`
+	Entries []credit.Entry      `json:"entries"`
+}
+
+// GetMyCredits returns the current user's credit balance, recent ledger and the packs on sale.
+// GET /api/v1/billing/credits
+func (h *DefaultHandler) GetMyCredits(c echo.Context) error {
+	ctx := c.Request().Context()
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil || auth0Sub == "" {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
+	}
+
+	userRow, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
+	}
+
+	credits := credit.NewDefaultRepository(h.db)
+	balance, err := credits.Balance(ctx, userRow.ID.String())
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to get credits"))
+	}
+	entries, err := credits.ListEntries(ctx, userRow.ID.String(), creditEntriesLimit)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to get credits"))
+	}
+
+	return c.JSON(http.StatusOK, CreditsResponse{
+		Balance: balance,
+		Packs:   h.config.Credits.Packs(),
+		Entries: entries,
+	})
+}
+
+// CreateCreditCheckout creates a one-time Stripe Checkout Session for a credit pack.
+// The credits are added by the webhook once the session is paid.
+// POST /api/v1/billing/credits/checkout
+func (h *DefaultHandler) CreateCreditCheckout(c echo.Context) error {
+	ctx := c.Request().Context()
+	var req struct {
+		Pack string `json:"pack"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request body"))
+	}
+
+	pack, ok := h.config.Credits.Pack(req.Pack)
+	if !ok {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Unknown credit pack"))
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil || auth0Sub == "" {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
+	}
+
+	userRow, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
+	}
+
+	url, err := h.billingService.CreateCreditCheckout(ctx, accountOf(userRow), pack)
+	if err != nil {
+		return h.billingError(c, err, "Failed to create checkout session: %v")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"url": url,
+	})
+}
@@ -0,0 +1,132 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestGetMyCredits(t *testing.T) {
+	t.Run("success: balance, packs and ledger", func(t *testing.T) {
+		now := time.Now()
+		db := &storage.DatabaseMock{
+			QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				if strings.Contains(sql, "credit_balances") {
+					return rowStub{scan: func(dest ...any) error {
+						*dest[0].(*int32) = 19
+						return nil
+					}}
+				}
+				return rowStub{scan: mockUserRow(now)}
+			},
+			QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				return &rowsIterStub{scans: []func(dest ...any) error{
+					func(dest ...any) error {
+						*dest[0].(*string) = "entry-1"
+						*dest[1].(*int32) = -1
+						*dest[2].(*string) = "debit"
+						return nil
+					},
+				}}, nil
+			},
+		}
+		cfg := createTestConfig()
+		cfg.Credits.Pack20PriceID = "price_credits_20"
+		h := NewDefaultHandler(db, nil, &BillingServiceMock{}, cfg)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Test-User", "auth0|testuser")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		if err := h.GetMyCredits(c); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+		var resp CreditsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Balance != 19 || len(resp.Packs) != 1 || resp.Packs[0].Code != "pack_20" {
+			t.Fatalf("unexpected credits: %+v", resp)
+		}
+		if len(resp.Entries) != 1 || resp.Entries[0].Reason != "debit" {
+			t.Fatalf("unexpected entries: %+v", resp.Entries)
+		}
+	})
+
+	t.Run("fail: balance error", func(t *testing.T) {
+		now := time.Now()
+		db := &storage.DatabaseMock{
+			QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				if strings.Contains(sql, "credit_balances") {
+					return rowStub{scan: func(dest ...any) error { return errBoom() }}
+				}
+				return rowStub{scan: mockUserRow(now)}
+			},
+		}
+		h := NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig())
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Test-User", "auth0|testuser")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		_ = h.GetMyCredits(c)
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("expected 500, got %d", rec.Code)
+		}
+	})
+}
+
+func TestCreateCreditCheckout(t *testing.T) {
+	newRequest := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Test-User", "auth0|testuser")
+		rec := httptest.NewRecorder()
+		return e.NewContext(req, rec), rec
+	}
+
+	t.Run("fail: unknown pack", func(t *testing.T) {
+		cfg := createTestConfig()
+		cfg.Credits.Pack20PriceID = "price_credits_20"
+		h := NewDefaultHandler(nil, nil, &BillingServiceMock{}, cfg)
+		c, rec := newRequest(`{"pack":"pack_50"}`)
+
+		_ = h.CreateCreditCheckout(c)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("fail: missing STRIPE_SECRET_KEY", func(t *testing.T) {
+		now := time.Now()
+		db := &storage.DatabaseMock{
+			QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+				return rowStub{scan: mockUserRow(now)}
+			},
+		}
+		cfg := createTestConfig()
+		cfg.Credits.Pack20PriceID = "price_credits_20"
+		h := NewDefaultHandler(db, nil, NewDefaultBillingService(db, &cfg.Plans, nil, nil), cfg)
+		c, rec := newRequest(`{"pack":"pack_20"}`)
+
+		_ = h.CreateCreditCheckout(c)
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Fatalf("expected 503, got %d", rec.Code)
+		}
+	})
+}
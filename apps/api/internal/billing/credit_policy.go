@@ -0,0 +1,33 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreditPolicy decides which images are paid for with credits: those created
+// past the monthly allowance of a payer without a subscription.
+type CreditPolicy struct {
+	usage  UsageService
+	payers BillingUserResolver
+}
+
+// NewCreditPolicy creates a CreditPolicy.
+func NewCreditPolicy(usage UsageService, payers BillingUserResolver) *CreditPolicy {
+	return &CreditPolicy{usage: usage, payers: payers}
+}
+
+// CreditRequired reports whether the next image created in projectID costs a
+// credit, and whose. It is asked before the image exists, so the image is
+// within the allowance while the count is below the limit.
+func (p *CreditPolicy) CreditRequired(ctx context.Context, projectID string) (string, bool, error) {
+	payerID, err := p.payers.GetBillingUserID(ctx, projectID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to resolve billing user: %w", err)
+	}
+	stats, err := p.usage.GetUsage(ctx, payerID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get usage: %w", err)
+	}
+	return payerID, !stats.HasSubscription && stats.ImagesUsed >= stats.MonthlyLimit, nil
+}
@@ -0,0 +1,75 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type payerFunc func(ctx context.Context, projectID string) (string, error)
+
+func (f payerFunc) GetBillingUserID(ctx context.Context, projectID string) (string, error) {
+	return f(ctx, projectID)
+}
+
+func TestCreditPolicy_CreditRequired(t *testing.T) {
+	payers := payerFunc(func(ctx context.Context, projectID string) (string, error) {
+		return "payer-1", nil
+	})
+
+	cases := []struct {
+		name         string
+		stats        UsageStats
+		expectCredit bool
+	}{
+		{
+			name:         "success: images past the allowance cost a credit",
+			stats:        UsageStats{ImagesUsed: 10, MonthlyLimit: 10, Credits: 5},
+			expectCredit: true,
+		},
+		{
+			name:  "success: the last image of the allowance is free",
+			stats: UsageStats{ImagesUsed: 9, MonthlyLimit: 10, Credits: 5},
+		},
+		{
+			name:  "success: subscribers never spend credits",
+			stats: UsageStats{ImagesUsed: 120, MonthlyLimit: 100, Credits: 5, HasSubscription: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			usage := &UsageServiceMock{
+				GetUsageFunc: func(ctx context.Context, userID string) (*UsageStats, error) {
+					stats := tc.stats
+					return &stats, nil
+				},
+			}
+
+			payerID, required, err := NewCreditPolicy(usage, payers).CreditRequired(context.Background(), "project-1")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if required != tc.expectCredit {
+				t.Fatalf("Expected credit required %v but got %v", tc.expectCredit, required)
+			}
+			if payerID != "payer-1" {
+				t.Fatalf("Expected payer-1 but got %q", payerID)
+			}
+			if calls := usage.GetUsageCalls(); len(calls) != 1 || calls[0].UserID != "payer-1" {
+				t.Fatalf("Expected usage of payer-1 but got %+v", calls)
+			}
+		})
+	}
+
+	t.Run("fail: billing user lookup error", func(t *testing.T) {
+		failing := payerFunc(func(ctx context.Context, projectID string) (string, error) {
+			return "", errors.New("db down")
+		})
+
+		_, _, err := NewCreditPolicy(&UsageServiceMock{}, failing).CreditRequired(context.Background(), "project-1")
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
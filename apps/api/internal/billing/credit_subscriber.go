@@ -0,0 +1,28 @@
+package billing
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/events"
+)
+
+// BillingUserResolver finds the user who pays for images in a project.
+type BillingUserResolver interface {
+	GetBillingUserID(ctx context.Context, projectID string) (string, error)
+}
+
+// RegisterCreditSubscribers gives back the credit spent on an image when it
+// fails to stage or is canceled. Credits are spent in the transaction that creates the image
+// (see CreditPolicy). The refund is safe to repeat, which matters because
+// failures reach every API instance through the job update bridge.
+func RegisterCreditSubscribers(bus events.Bus, credits credit.Repository) {
+	events.On(bus, "credit_refund", func(ctx context.Context, e events.ImageFailed) error {
+		_, err := credits.Refund(ctx, e.ImageID)
+		return err
+	})
+	events.On(bus, "credit_refund", func(ctx context.Context, e events.ImageCanceled) error {
+		_, err := credits.Refund(ctx, e.ImageID)
+		return err
+	})
+}
@@ -0,0 +1,59 @@
+package billing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestRegisterCreditSubscribers(t *testing.T) {
+	t.Run("success: failed images are refunded", func(t *testing.T) {
+		bus := events.NewDefaultBus(logging.Default())
+		credits := &credit.RepositoryMock{
+			RefundFunc: func(ctx context.Context, imageID string) (bool, error) {
+				return false, nil
+			},
+		}
+		RegisterCreditSubscribers(bus, credits)
+
+		err := bus.Publish(context.Background(), events.ImageFailed{ImageID: "image-1", Error: "timeout"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if calls := credits.RefundCalls(); len(calls) != 1 || calls[0].ImageID != "image-1" {
+			t.Fatalf("Expected one refund of image-1 but got %+v", calls)
+		}
+	})
+
+	t.Run("success: canceled images are refunded", func(t *testing.T) {
+		bus := events.NewDefaultBus(logging.Default())
+		credits := &credit.RepositoryMock{
+			RefundFunc: func(ctx context.Context, imageID string) (bool, error) {
+				return true, nil
+			},
+		}
+		RegisterCreditSubscribers(bus, credits)
+
+		err := bus.Publish(context.Background(), events.ImageCanceled{ImageID: "image-1"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if calls := credits.RefundCalls(); len(calls) != 1 || calls[0].ImageID != "image-1" {
+			t.Fatalf("Expected one refund of image-1 but got %+v", calls)
+		}
+	})
+
+	t.Run("success: created images are left to the image service", func(t *testing.T) {
+		bus := events.NewDefaultBus(logging.Default())
+		credits := &credit.RepositoryMock{}
+		RegisterCreditSubscribers(bus, credits)
+
+		err := bus.Publish(context.Background(), events.ImageCreated{ImageID: "image-1", ProjectID: "project-1"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,445 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stripe/stripe-go/v81"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	stripeLib "github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/tenant"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultBillingService implements BillingService with one Stripe client per
+// account mode.
+type DefaultBillingService struct {
+	users         user.Repository
+	accounts      tenant.Repository
+	subscriptions stripeLib.SubscriptionsRepository
+	invoices      stripeLib.InvoicesRepository
+	plans         *config.Plans
+	live          StripeClient
+	sandbox       StripeClient
+	log           logging.Logger
+	now           func() time.Time
+}
+
+// Ensure DefaultBillingService implements BillingService.
+var _ BillingService = (*DefaultBillingService)(nil)
+
+// NewDefaultBillingService creates a new DefaultBillingService. live serves
+// regular accounts and sandbox serves sandbox accounts; a nil client means
+// that mode has no Stripe key, and its calls return ErrStripeNotConfigured.
+func NewDefaultBillingService(
+	db storage.Database, plans *config.Plans, live, sandbox StripeClient,
+) *DefaultBillingService {
+	return &DefaultBillingService{
+		users:         user.NewDefaultRepository(db),
+		accounts:      tenant.NewDefaultRepository(db),
+		subscriptions: stripeLib.NewSubscriptionsRepository(db),
+		invoices:      stripeLib.NewInvoicesRepository(db),
+		plans:         plans,
+		live:          live,
+		sandbox:       sandbox,
+		log:           logging.Default(),
+		now:           time.Now,
+	}
+}
+
+// CreateCheckoutSession starts a subscription checkout. The free plan's
+// checkout collects no payment method.
+func (s *DefaultBillingService) CreateCheckoutSession(
+	ctx context.Context, account Account, priceID string,
+) (string, error) {
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return "", err
+	}
+	customerID, err := s.ensureCustomer(ctx, client, account)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL := frontendURL()
+	params := &stripe.CheckoutSessionParams{
+		Customer: stripe.String(customerID),
+		Mode:     stripe.String(string(stripe.CheckoutSessionModeSubscription)),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{
+			{
+				Price:    stripe.String(priceID),
+				Quantity: stripe.Int64(1),
+			},
+		},
+		SuccessURL: stripe.String(baseURL + "/profile?checkout=success"),
+		CancelURL:  stripe.String(baseURL + "/profile?checkout=canceled"),
+	}
+	if s.isFreePrice(priceID) {
+		params.PaymentMethodCollection = stripe.String("off")
+	}
+
+	sess, err := client.NewCheckoutSession(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create checkout session: %w", err)
+	}
+	return sess.URL, nil
+}
+
+// CreateCreditCheckout starts a one-time checkout for a credit pack. The
+// session metadata tells the webhook whom to credit and how much.
+func (s *DefaultBillingService) CreateCreditCheckout(
+	ctx context.Context, account Account, pack config.CreditPack,
+) (string, error) {
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return "", err
+	}
+	customerID, err := s.ensureCustomer(ctx, client, account)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL := frontendURL()
+	params := &stripe.CheckoutSessionParams{
+		Customer: stripe.String(customerID),
+		Mode:     stripe.String(string(stripe.CheckoutSessionModePayment)),
+		LineItems: []*stripe.CheckoutSessionLineItemParams{
+			{
+				Price:    stripe.String(pack.PriceID),
+				Quantity: stripe.Int64(1),
+			},
+		},
+		SuccessURL: stripe.String(baseURL + "/profile?credits=success"),
+		CancelURL:  stripe.String(baseURL + "/profile?credits=canceled"),
+	}
+	params.AddMetadata(credit.MetadataUserID, account.UserID)
+	params.AddMetadata(credit.MetadataPack, pack.Code)
+	params.AddMetadata(credit.MetadataCredits, strconv.Itoa(int(pack.Credits)))
+
+	sess, err := client.NewCheckoutSession(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create checkout session: %w", err)
+	}
+	return sess.URL, nil
+}
+
+// CreatePortalSession opens the Customer Portal, returning to the profile page.
+func (s *DefaultBillingService) CreatePortalSession(ctx context.Context, account Account) (string, error) {
+	if account.StripeCustomerID == "" {
+		return "", ErrNoStripeCustomer
+	}
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sess, err := client.NewPortalSession(ctx, &stripe.BillingPortalSessionParams{
+		Customer:  stripe.String(account.StripeCustomerID),
+		ReturnURL: stripe.String(frontendURL() + "/profile"),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create portal session: %w", err)
+	}
+	return sess.URL, nil
+}
+
+// CreateSubscription creates the subscription incomplete, so its first
+// invoice's payment intent can be confirmed with Elements. The free plan is
+// created without waiting for a payment.
+func (s *DefaultBillingService) CreateSubscription(
+	ctx context.Context, account Account, priceID string,
+) (*SubscriptionIntent, error) {
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	customerID, err := s.ensureCustomer(ctx, client, account)
+	if err != nil {
+		return nil, err
+	}
+
+	params := &stripe.SubscriptionParams{
+		Customer: stripe.String(customerID),
+		Items: []*stripe.SubscriptionItemsParams{
+			{
+				Price: stripe.String(priceID),
+			},
+		},
+		PaymentBehavior: stripe.String("default_incomplete"),
+		PaymentSettings: &stripe.SubscriptionPaymentSettingsParams{
+			SaveDefaultPaymentMethod: stripe.String("on_subscription"),
+		},
+		Expand: []*string{
+			stripe.String("latest_invoice.payment_intent"),
+		},
+	}
+	if s.isFreePrice(priceID) {
+		params.PaymentBehavior = stripe.String("allow_incomplete")
+	}
+
+	sub, err := client.NewSubscription(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create subscription: %w", err)
+	}
+	return &SubscriptionIntent{SubscriptionID: sub.ID, ClientSecret: clientSecret(sub)}, nil
+}
+
+// ChangeSubscription swaps the price of the active subscription's first item
+// with prorations. Moving to the free plan takes effect at once; a paid price
+// waits for its proration invoice to be paid unless it already is.
+func (s *DefaultBillingService) ChangeSubscription(
+	ctx context.Context, account Account, priceID string,
+) (*SubscriptionIntent, error) {
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.activeSubscription(ctx, account.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := client.GetSubscription(ctx, active.StripeSubscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve Stripe subscription: %w", err)
+	}
+	if current.Items == nil || len(current.Items.Data) == 0 {
+		return nil, fmt.Errorf("stripe subscription %s has no items", current.ID)
+	}
+	items := []*stripe.SubscriptionItemsParams{
+		{
+			ID:    stripe.String(current.Items.Data[0].ID),
+			Price: stripe.String(priceID),
+		},
+	}
+
+	if s.isFreePrice(priceID) {
+		_, err := client.UpdateSubscription(ctx, current.ID, &stripe.SubscriptionParams{
+			Items:             items,
+			ProrationBehavior: stripe.String("create_prorations"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to downgrade subscription: %w", err)
+		}
+		return &SubscriptionIntent{SubscriptionID: current.ID, Downgraded: true}, nil
+	}
+
+	updated, err := client.UpdateSubscription(ctx, current.ID, &stripe.SubscriptionParams{
+		Items:           items,
+		PaymentBehavior: stripe.String("default_incomplete"),
+		PaymentSettings: &stripe.SubscriptionPaymentSettingsParams{
+			SaveDefaultPaymentMethod: stripe.String("on_subscription"),
+		},
+		ProrationBehavior: stripe.String("create_prorations"),
+		Expand: []*string{
+			stripe.String("latest_invoice.payment_intent"),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade subscription: %w", err)
+	}
+
+	intent := &SubscriptionIntent{SubscriptionID: updated.ID, ClientSecret: clientSecret(updated)}
+	if intent.ClientSecret == "" &&
+		(updated.LatestInvoice == nil || updated.LatestInvoice.Status != stripe.InvoiceStatusPaid) {
+		return nil, ErrNoPaymentIntent
+	}
+	return intent, nil
+}
+
+// CancelSubscription cancels the user's most recent subscription at the end
+// of its period.
+func (s *DefaultBillingService) CancelSubscription(ctx context.Context, account Account) error {
+	subs, err := s.subscriptions.ListByUserID(ctx, account.UserID, 10, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return ErrNoActiveSubscription
+	}
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.UpdateSubscription(ctx, subs[0].StripeSubscriptionID, &stripe.SubscriptionParams{
+		CancelAtPeriodEnd: stripe.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+	return nil
+}
+
+// ListPaymentMethods returns the user's saved cards. A user who never checked
+// out has none.
+func (s *DefaultBillingService) ListPaymentMethods(ctx context.Context, account Account) ([]PaymentMethod, error) {
+	methods := []PaymentMethod{}
+	if account.StripeCustomerID == "" {
+		return methods, nil
+	}
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := client.ListPaymentMethods(ctx, &stripe.PaymentMethodListParams{
+		Customer: stripe.String(account.StripeCustomerID),
+		Type:     stripe.String(string(stripe.PaymentMethodTypeCard)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment methods: %w", err)
+	}
+	for _, pm := range cards {
+		method := PaymentMethod{
+			ID:        pm.ID,
+			Type:      string(pm.Type),
+			IsDefault: pm.Metadata["is_default"] == "true",
+		}
+		if pm.Card != nil {
+			method.Card = PaymentCard{
+				Brand:    string(pm.Card.Brand),
+				Last4:    pm.Card.Last4,
+				ExpMonth: pm.Card.ExpMonth,
+				ExpYear:  pm.Card.ExpYear,
+			}
+		}
+		methods = append(methods, method)
+	}
+	return methods, nil
+}
+
+// InvoicePDF looks the invoice up in Stripe, which signs its PDF URL.
+func (s *DefaultBillingService) InvoicePDF(
+	ctx context.Context, account Account, invoiceID string,
+) (*InvoicePDF, error) {
+	inv, err := s.invoices.GetByIDForUser(ctx, invoiceID, account.UserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	client, err := s.stripeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stripeInvoice, err := client.GetInvoice(ctx, inv.StripeInvoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve Stripe invoice: %w", err)
+	}
+	if stripeInvoice.InvoicePDF == "" {
+		return nil, ErrInvoicePDFUnavailable
+	}
+
+	name := inv.StripeInvoiceID
+	if inv.InvoiceNumber.Valid && inv.InvoiceNumber.String != "" {
+		name = inv.InvoiceNumber.String
+	}
+	return &InvoicePDF{URL: stripeInvoice.InvoicePDF, Filename: name + ".pdf"}, nil
+}
+
+// stripeFor returns the client for the caller's account mode. Sandbox
+// accounts only ever get the test-mode client, so an unset
+// STRIPE_TEST_SECRET_KEY disables their billing instead of falling back to live.
+func (s *DefaultBillingService) stripeFor(ctx context.Context) (StripeClient, error) {
+	client := s.live
+	if tenant.IsSandbox(ctx) {
+		client = s.sandbox
+	}
+	if client == nil {
+		return nil, ErrStripeNotConfigured
+	}
+	return client, nil
+}
+
+// ensureCustomer returns the user's Stripe customer, creating it on first
+// checkout. Sandbox customers are attached to a new test clock so that
+// renewals and dunning can be simulated by advancing the clock.
+func (s *DefaultBillingService) ensureCustomer(
+	ctx context.Context, client StripeClient, account Account,
+) (string, error) {
+	if account.StripeCustomerID != "" {
+		return account.StripeCustomerID, nil
+	}
+
+	params := &stripe.CustomerParams{
+		Metadata: map[string]string{
+			"user_id":   account.UserID,
+			"auth0_sub": account.Auth0Sub,
+		},
+	}
+	var testClockID string
+	if tenant.IsSandbox(ctx) {
+		clock, err := client.NewTestClock(ctx, "sandbox "+account.UserID, s.now())
+		if err != nil {
+			return "", fmt.Errorf("failed to create test clock: %w", err)
+		}
+		testClockID = clock.ID
+		params.TestClock = stripe.String(testClockID)
+		params.Metadata["account_mode"] = string(tenant.ModeSandbox)
+	}
+
+	cust, err := client.NewCustomer(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Stripe customer: %w", err)
+	}
+
+	// The customer exists in Stripe either way; failing to record it only
+	// costs a duplicate customer on the next checkout.
+	if _, err := s.users.UpdateStripeCustomerID(ctx, account.UserID, cust.ID); err != nil {
+		s.log.Warn(ctx, "failed to record Stripe customer", "user_id", account.UserID, "error", err)
+	}
+	if testClockID != "" {
+		if err := s.accounts.SetTestClock(ctx, account.UserID, testClockID); err != nil {
+			s.log.Warn(ctx, "failed to record Stripe test clock", "user_id", account.UserID, "error", err)
+		}
+	}
+	return cust.ID, nil
+}
+
+// activeSubscription returns the user's first active or trialing subscription.
+func (s *DefaultBillingService) activeSubscription(ctx context.Context, userID string) (*queries.Subscription, error) {
+	subs, err := s.subscriptions.ListByUserID(ctx, userID, 10, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve existing subscription: %w", err)
+	}
+	for _, sub := range subs {
+		if sub.Status == "active" || sub.Status == "trialing" {
+			return sub, nil
+		}
+	}
+	return nil, ErrNoActiveSubscription
+}
+
+func (s *DefaultBillingService) isFreePrice(priceID string) bool {
+	return s.plans != nil && s.plans.FreePriceID != "" && priceID == s.plans.FreePriceID
+}
+
+// clientSecret returns the secret of the payment intent behind the
+// subscription's latest invoice, if any.
+func clientSecret(sub *stripe.Subscription) string {
+	if sub.LatestInvoice == nil || sub.LatestInvoice.PaymentIntent == nil {
+		return ""
+	}
+	return sub.LatestInvoice.PaymentIntent.ClientSecret
+}
+
+// frontendURL is where Stripe sends users back to after checkout.
+func frontendURL() string {
+	if baseURL := os.Getenv("FRONTEND_URL"); baseURL != "" {
+		return baseURL
+	}
+	return "http://localhost:3000"
+}
@@ -0,0 +1,441 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	stripeLib "github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/tenant"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// subscriptionsStub lists fixed subscriptions; other methods are not used by
+// the billing service.
+type subscriptionsStub struct {
+	stripeLib.SubscriptionsRepository
+	subs []*queries.Subscription
+	err  error
+}
+
+func (s *subscriptionsStub) ListByUserID(
+	ctx context.Context, userID string, limit, offset int32,
+) ([]*queries.Subscription, error) {
+	return s.subs, s.err
+}
+
+// invoicesStub returns a fixed invoice; other methods are not used by the
+// billing service.
+type invoicesStub struct {
+	stripeLib.InvoicesRepository
+	inv *queries.Invoice
+	err error
+}
+
+func (s *invoicesStub) GetByIDForUser(ctx context.Context, id, userID string) (*queries.Invoice, error) {
+	return s.inv, s.err
+}
+
+func newTestBillingService(live, sandbox StripeClient) *DefaultBillingService {
+	return &DefaultBillingService{
+		users:         &user.RepositoryMock{},
+		accounts:      &tenant.RepositoryMock{},
+		subscriptions: &subscriptionsStub{},
+		invoices:      &invoicesStub{err: pgx.ErrNoRows},
+		plans:         &config.Plans{FreePriceID: "price_free", ProPriceID: "price_pro"},
+		live:          live,
+		sandbox:       sandbox,
+		log:           logging.Default(),
+		now:           time.Now,
+	}
+}
+
+var customerAccount = Account{UserID: "user-1", Auth0Sub: "auth0|1", StripeCustomerID: "cus_1"}
+
+func TestDefaultBillingService_stripeFor(t *testing.T) {
+	live := tenant.WithMode(context.Background(), tenant.ModeLive)
+	sandbox := tenant.WithMode(context.Background(), tenant.ModeSandbox)
+	liveClient, sandboxClient := &StripeClientMock{}, &StripeClientMock{}
+
+	t.Run("success: live accounts use the live client", func(t *testing.T) {
+		got, err := newTestBillingService(liveClient, sandboxClient).stripeFor(live)
+		require.NoError(t, err)
+		assert.Same(t, liveClient, got)
+	})
+
+	t.Run("success: sandbox accounts use the sandbox client", func(t *testing.T) {
+		got, err := newTestBillingService(liveClient, sandboxClient).stripeFor(sandbox)
+		require.NoError(t, err)
+		assert.Same(t, sandboxClient, got)
+	})
+
+	t.Run("fail: sandbox never falls back to the live client", func(t *testing.T) {
+		_, err := newTestBillingService(liveClient, nil).stripeFor(sandbox)
+		assert.ErrorIs(t, err, ErrStripeNotConfigured)
+	})
+}
+
+func TestDefaultBillingService_CreateCheckoutSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: checks out the existing customer", func(t *testing.T) {
+		var params *stripe.CheckoutSessionParams
+		client := &StripeClientMock{
+			NewCheckoutSessionFunc: func(
+				ctx context.Context, p *stripe.CheckoutSessionParams,
+			) (*stripe.CheckoutSession, error) {
+				params = p
+				return &stripe.CheckoutSession{URL: "https://checkout.stripe.com/c/1"}, nil
+			},
+		}
+
+		url, err := newTestBillingService(client, nil).CreateCheckoutSession(ctx, customerAccount, "price_pro")
+		require.NoError(t, err)
+		assert.Equal(t, "https://checkout.stripe.com/c/1", url)
+		assert.Equal(t, "cus_1", *params.Customer)
+		assert.Equal(t, "price_pro", *params.LineItems[0].Price)
+		assert.Nil(t, params.PaymentMethodCollection)
+		assert.Empty(t, client.NewCustomerCalls())
+	})
+
+	t.Run("success: the free plan collects no payment method", func(t *testing.T) {
+		client := &StripeClientMock{
+			NewCheckoutSessionFunc: func(
+				ctx context.Context, p *stripe.CheckoutSessionParams,
+			) (*stripe.CheckoutSession, error) {
+				assert.Equal(t, "off", *p.PaymentMethodCollection)
+				return &stripe.CheckoutSession{}, nil
+			},
+		}
+
+		_, err := newTestBillingService(client, nil).CreateCheckoutSession(ctx, customerAccount, "price_free")
+		require.NoError(t, err)
+	})
+
+	t.Run("success: creates and records the first customer", func(t *testing.T) {
+		client := &StripeClientMock{
+			NewCustomerFunc: func(ctx context.Context, p *stripe.CustomerParams) (*stripe.Customer, error) {
+				assert.Equal(t, "user-1", p.Metadata["user_id"])
+				assert.Nil(t, p.TestClock)
+				return &stripe.Customer{ID: "cus_new"}, nil
+			},
+			NewCheckoutSessionFunc: func(
+				ctx context.Context, p *stripe.CheckoutSessionParams,
+			) (*stripe.CheckoutSession, error) {
+				assert.Equal(t, "cus_new", *p.Customer)
+				return &stripe.CheckoutSession{}, nil
+			},
+		}
+		users := &user.RepositoryMock{
+			UpdateStripeCustomerIDFunc: func(
+				ctx context.Context, userID, stripeCustomerID string,
+			) (*queries.UpdateUserStripeCustomerIDRow, error) {
+				return &queries.UpdateUserStripeCustomerIDRow{}, nil
+			},
+		}
+		svc := newTestBillingService(client, nil)
+		svc.users = users
+
+		_, err := svc.CreateCheckoutSession(ctx, Account{UserID: "user-1", Auth0Sub: "auth0|1"}, "price_pro")
+		require.NoError(t, err)
+		require.Len(t, users.UpdateStripeCustomerIDCalls(), 1)
+		assert.Equal(t, "cus_new", users.UpdateStripeCustomerIDCalls()[0].StripeCustomerID)
+	})
+
+	t.Run("success: sandbox customers start on a test clock", func(t *testing.T) {
+		client := &StripeClientMock{
+			NewTestClockFunc: func(
+				ctx context.Context, name string, frozenTime time.Time,
+			) (*stripe.TestHelpersTestClock, error) {
+				return &stripe.TestHelpersTestClock{ID: "clock_1"}, nil
+			},
+			NewCustomerFunc: func(ctx context.Context, p *stripe.CustomerParams) (*stripe.Customer, error) {
+				assert.Equal(t, "clock_1", *p.TestClock)
+				return &stripe.Customer{ID: "cus_sandbox"}, nil
+			},
+			NewCheckoutSessionFunc: func(
+				ctx context.Context, p *stripe.CheckoutSessionParams,
+			) (*stripe.CheckoutSession, error) {
+				return &stripe.CheckoutSession{}, nil
+			},
+		}
+		accounts := &tenant.RepositoryMock{
+			SetTestClockFunc: func(ctx context.Context, userID, testClockID string) error { return nil },
+		}
+		svc := newTestBillingService(nil, client)
+		svc.users = &user.RepositoryMock{
+			UpdateStripeCustomerIDFunc: func(
+				ctx context.Context, userID, stripeCustomerID string,
+			) (*queries.UpdateUserStripeCustomerIDRow, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		svc.accounts = accounts
+		sandbox := tenant.WithMode(ctx, tenant.ModeSandbox)
+
+		_, err := svc.CreateCheckoutSession(sandbox, Account{UserID: "user-1"}, "price_pro")
+		require.NoError(t, err, "failing to record the customer doesn't fail the checkout")
+		require.Len(t, accounts.SetTestClockCalls(), 1)
+		assert.Equal(t, "clock_1", accounts.SetTestClockCalls()[0].TestClockID)
+	})
+
+	t.Run("fail: Stripe not configured", func(t *testing.T) {
+		_, err := newTestBillingService(nil, nil).CreateCheckoutSession(ctx, customerAccount, "price_pro")
+		assert.ErrorIs(t, err, ErrStripeNotConfigured)
+	})
+}
+
+func TestDefaultBillingService_CreateCreditCheckout(t *testing.T) {
+	client := &StripeClientMock{
+		NewCheckoutSessionFunc: func(ctx context.Context, p *stripe.CheckoutSessionParams) (*stripe.CheckoutSession, error) {
+			assert.Equal(t, string(stripe.CheckoutSessionModePayment), *p.Mode)
+			assert.Equal(t, "price_credits_20", *p.LineItems[0].Price)
+			assert.Equal(t, "user-1", p.Metadata["user_id"])
+			assert.Equal(t, "20", p.Metadata["credits"])
+			return &stripe.CheckoutSession{URL: "https://checkout.stripe.com/c/2"}, nil
+		},
+	}
+	pack := config.CreditPack{Code: "pack_20", Credits: 20, PriceID: "price_credits_20"}
+
+	url, err := newTestBillingService(client, nil).CreateCreditCheckout(context.Background(), customerAccount, pack)
+	require.NoError(t, err)
+	assert.Equal(t, "https://checkout.stripe.com/c/2", url)
+}
+
+func TestDefaultBillingService_CreatePortalSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: opens the portal for the customer", func(t *testing.T) {
+		client := &StripeClientMock{
+			NewPortalSessionFunc: func(
+				ctx context.Context, p *stripe.BillingPortalSessionParams,
+			) (*stripe.BillingPortalSession, error) {
+				assert.Equal(t, "cus_1", *p.Customer)
+				return &stripe.BillingPortalSession{URL: "https://billing.stripe.com/p/1"}, nil
+			},
+		}
+
+		url, err := newTestBillingService(client, nil).CreatePortalSession(ctx, customerAccount)
+		require.NoError(t, err)
+		assert.Equal(t, "https://billing.stripe.com/p/1", url)
+	})
+
+	t.Run("fail: user never checked out", func(t *testing.T) {
+		_, err := newTestBillingService(&StripeClientMock{}, nil).CreatePortalSession(ctx, Account{UserID: "user-1"})
+		assert.ErrorIs(t, err, ErrNoStripeCustomer)
+	})
+}
+
+func TestDefaultBillingService_CreateSubscription(t *testing.T) {
+	client := &StripeClientMock{
+		NewSubscriptionFunc: func(ctx context.Context, p *stripe.SubscriptionParams) (*stripe.Subscription, error) {
+			assert.Equal(t, "default_incomplete", *p.PaymentBehavior)
+			return &stripe.Subscription{
+				ID: "sub_1",
+				LatestInvoice: &stripe.Invoice{
+					PaymentIntent: &stripe.PaymentIntent{ClientSecret: "pi_secret"},
+				},
+			}, nil
+		},
+	}
+
+	svc := newTestBillingService(client, nil)
+
+	intent, err := svc.CreateSubscription(context.Background(), customerAccount, "price_pro")
+	require.NoError(t, err)
+	assert.Equal(t, &SubscriptionIntent{SubscriptionID: "sub_1", ClientSecret: "pi_secret"}, intent)
+}
+
+func TestDefaultBillingService_ChangeSubscription(t *testing.T) {
+	ctx := context.Background()
+	active := &subscriptionsStub{subs: []*queries.Subscription{
+		{StripeSubscriptionID: "sub_old", Status: "canceled"},
+		{StripeSubscriptionID: "sub_1", Status: "active"},
+	}}
+	newClient := func(updated *stripe.Subscription) *StripeClientMock {
+		return &StripeClientMock{
+			GetSubscriptionFunc: func(ctx context.Context, id string) (*stripe.Subscription, error) {
+				assert.Equal(t, "sub_1", id)
+				return &stripe.Subscription{
+					ID:    "sub_1",
+					Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{{ID: "si_1"}}},
+				}, nil
+			},
+			UpdateSubscriptionFunc: func(
+				ctx context.Context, id string, p *stripe.SubscriptionParams,
+			) (*stripe.Subscription, error) {
+				assert.Equal(t, "si_1", *p.Items[0].ID)
+				return updated, nil
+			},
+		}
+	}
+
+	t.Run("success: upgrade waits for payment", func(t *testing.T) {
+		client := newClient(&stripe.Subscription{
+			ID:            "sub_1",
+			LatestInvoice: &stripe.Invoice{PaymentIntent: &stripe.PaymentIntent{ClientSecret: "pi_secret"}},
+		})
+		svc := newTestBillingService(client, nil)
+		svc.subscriptions = active
+
+		intent, err := svc.ChangeSubscription(ctx, customerAccount, "price_pro")
+		require.NoError(t, err)
+		assert.Equal(t, "pi_secret", intent.ClientSecret)
+		assert.Equal(t, "default_incomplete", *client.UpdateSubscriptionCalls()[0].Params.PaymentBehavior)
+	})
+
+	t.Run("success: downgrade to free takes effect at once", func(t *testing.T) {
+		client := newClient(&stripe.Subscription{ID: "sub_1"})
+		svc := newTestBillingService(client, nil)
+		svc.subscriptions = active
+
+		intent, err := svc.ChangeSubscription(ctx, customerAccount, "price_free")
+		require.NoError(t, err)
+		assert.True(t, intent.Downgraded)
+		assert.Nil(t, client.UpdateSubscriptionCalls()[0].Params.PaymentBehavior)
+	})
+
+	t.Run("success: an already paid change needs no confirmation", func(t *testing.T) {
+		client := newClient(&stripe.Subscription{
+			ID:            "sub_1",
+			LatestInvoice: &stripe.Invoice{Status: stripe.InvoiceStatusPaid},
+		})
+		svc := newTestBillingService(client, nil)
+		svc.subscriptions = active
+
+		intent, err := svc.ChangeSubscription(ctx, customerAccount, "price_pro")
+		require.NoError(t, err)
+		assert.Empty(t, intent.ClientSecret)
+		assert.False(t, intent.Downgraded)
+	})
+
+	t.Run("fail: unpaid change without a payment intent", func(t *testing.T) {
+		client := newClient(&stripe.Subscription{ID: "sub_1", LatestInvoice: &stripe.Invoice{Status: "open"}})
+		svc := newTestBillingService(client, nil)
+		svc.subscriptions = active
+
+		_, err := svc.ChangeSubscription(ctx, customerAccount, "price_pro")
+		assert.ErrorIs(t, err, ErrNoPaymentIntent)
+	})
+
+	t.Run("fail: no active subscription", func(t *testing.T) {
+		svc := newTestBillingService(&StripeClientMock{}, nil)
+		svc.subscriptions = &subscriptionsStub{subs: []*queries.Subscription{{Status: "canceled"}}}
+
+		_, err := svc.ChangeSubscription(ctx, customerAccount, "price_pro")
+		assert.ErrorIs(t, err, ErrNoActiveSubscription)
+	})
+}
+
+func TestDefaultBillingService_CancelSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: cancels at the end of the period", func(t *testing.T) {
+		client := &StripeClientMock{
+			UpdateSubscriptionFunc: func(
+				ctx context.Context, id string, p *stripe.SubscriptionParams,
+			) (*stripe.Subscription, error) {
+				assert.Equal(t, "sub_1", id)
+				assert.True(t, *p.CancelAtPeriodEnd)
+				return &stripe.Subscription{ID: id}, nil
+			},
+		}
+		svc := newTestBillingService(client, nil)
+		svc.subscriptions = &subscriptionsStub{subs: []*queries.Subscription{{StripeSubscriptionID: "sub_1"}}}
+
+		require.NoError(t, svc.CancelSubscription(ctx, customerAccount))
+		assert.Len(t, client.UpdateSubscriptionCalls(), 1)
+	})
+
+	t.Run("fail: no subscription", func(t *testing.T) {
+		err := newTestBillingService(&StripeClientMock{}, nil).CancelSubscription(ctx, customerAccount)
+		assert.ErrorIs(t, err, ErrNoActiveSubscription)
+	})
+}
+
+func TestDefaultBillingService_ListPaymentMethods(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: maps saved cards", func(t *testing.T) {
+		client := &StripeClientMock{
+			ListPaymentMethodsFunc: func(
+				ctx context.Context, p *stripe.PaymentMethodListParams,
+			) ([]*stripe.PaymentMethod, error) {
+				assert.Equal(t, "cus_1", *p.Customer)
+				return []*stripe.PaymentMethod{{
+					ID:       "pm_1",
+					Type:     stripe.PaymentMethodTypeCard,
+					Card:     &stripe.PaymentMethodCard{Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030},
+					Metadata: map[string]string{"is_default": "true"},
+				}}, nil
+			},
+		}
+
+		methods, err := newTestBillingService(client, nil).ListPaymentMethods(ctx, customerAccount)
+		require.NoError(t, err)
+		assert.Equal(t, []PaymentMethod{{
+			ID:        "pm_1",
+			Type:      "card",
+			Card:      PaymentCard{Brand: "visa", Last4: "4242", ExpMonth: 12, ExpYear: 2030},
+			IsDefault: true,
+		}}, methods)
+	})
+
+	t.Run("success: a user who never checked out has none", func(t *testing.T) {
+		methods, err := newTestBillingService(nil, nil).ListPaymentMethods(ctx, Account{UserID: "user-1"})
+		require.NoError(t, err)
+		assert.Empty(t, methods)
+		assert.NotNil(t, methods)
+	})
+}
+
+func TestDefaultBillingService_InvoicePDF(t *testing.T) {
+	ctx := context.Background()
+	invoice := &queries.Invoice{
+		StripeInvoiceID: "in_1",
+		InvoiceNumber:   pgtype.Text{String: "F-1001", Valid: true},
+	}
+
+	t.Run("success: names the PDF after the invoice number", func(t *testing.T) {
+		client := &StripeClientMock{
+			GetInvoiceFunc: func(ctx context.Context, id string) (*stripe.Invoice, error) {
+				assert.Equal(t, "in_1", id)
+				return &stripe.Invoice{InvoicePDF: "https://pay.stripe.com/invoice/acct_1/pdf"}, nil
+			},
+		}
+		svc := newTestBillingService(client, nil)
+		svc.invoices = &invoicesStub{inv: invoice}
+
+		pdf, err := svc.InvoicePDF(ctx, customerAccount, "inv-1")
+		require.NoError(t, err)
+		assert.Equal(t, &InvoicePDF{URL: "https://pay.stripe.com/invoice/acct_1/pdf", Filename: "F-1001.pdf"}, pdf)
+	})
+
+	t.Run("fail: draft invoices have no PDF", func(t *testing.T) {
+		client := &StripeClientMock{
+			GetInvoiceFunc: func(ctx context.Context, id string) (*stripe.Invoice, error) {
+				return &stripe.Invoice{Status: stripe.InvoiceStatusDraft}, nil
+			},
+		}
+		svc := newTestBillingService(client, nil)
+		svc.invoices = &invoicesStub{inv: invoice}
+
+		_, err := svc.InvoicePDF(ctx, customerAccount, "inv-1")
+		assert.ErrorIs(t, err, ErrInvoicePDFUnavailable)
+	})
+
+	t.Run("fail: invoice of another user", func(t *testing.T) {
+		_, err := newTestBillingService(&StripeClientMock{}, nil).InvoicePDF(ctx, customerAccount, "inv-1")
+		assert.ErrorIs(t, err, ErrInvoiceNotFound)
+	})
+}
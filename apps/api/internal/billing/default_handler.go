@@ -1,23 +1,19 @@
 package billing
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
-	"os"
 	"strconv"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
-	"github.com/stripe/stripe-go/v81"
-	"github.com/stripe/stripe-go/v81/billingportal/session"
-	checkoutsession "github.com/stripe/stripe-go/v81/checkout/session"
-	"github.com/stripe/stripe-go/v81/customer"
-	"github.com/stripe/stripe-go/v81/paymentmethod"
-	"github.com/stripe/stripe-go/v81/subscription"
 
 	"github.com/real-staging-ai/api/internal/auth"
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/i18n"
+	"github.com/real-staging-ai/api/internal/pagination"
+	"github.com/real-staging-ai/api/internal/problem"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 	stripeLib "github.com/real-staging-ai/api/internal/stripe"
@@ -25,38 +21,43 @@ import (
 )
 
 // DefaultHandler implements the billing Handler by wrapping existing repositories
-// and user resolution logic (Auth0 sub -> ensure users row).
+// and user resolution logic (Auth0 sub -> ensure users row). Stripe calls go
+// through the billing service.
 type DefaultHandler struct {
-	db              storage.Database
-	usageService    UsageService
-	stripeSecretKey string
-	config          *config.Config
+	db             storage.Database
+	usageService   UsageService
+	billingService BillingService
+	config         *config.Config
+	httpClient     *http.Client
 }
 
+// invoicePDFTimeout bounds the download of an invoice PDF from Stripe.
+const invoicePDFTimeout = 30 * time.Second
+
 // NewDefaultHandler constructs a DefaultHandler.
 func NewDefaultHandler(
 	db storage.Database,
 	usageService UsageService,
-	stripeSecretKey string,
+	billingService BillingService,
 	cfg *config.Config,
 ) *DefaultHandler {
 	return &DefaultHandler{
-		db:              db,
-		usageService:    usageService,
-		stripeSecretKey: stripeSecretKey,
-		config:          cfg,
+		db:             db,
+		usageService:   usageService,
+		billingService: billingService,
+		config:         cfg,
+		httpClient:     &http.Client{Timeout: invoicePDFTimeout},
 	}
 }
 
-// ErrorResponse is a simple JSON error envelope for handler responses.
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-}
-
 // GetMySubscriptions returns the current user's subscriptions (paginated).
 func (h *DefaultHandler) GetMySubscriptions(c echo.Context) error {
-	limit, offset := h.parseLimitOffset(c)
+	ctx := c.Request().Context()
+	page, offset, err := h.parsePage(c)
+	if err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeInvalidCursor, i18n.T(ctx, "Invalid cursor"))
+	}
+	limit := page.Limit
 
 	// No DB configured (e.g., special test mode) — return empty list gracefully.
 	if h.db == nil {
@@ -66,10 +67,8 @@ func (h *DefaultHandler) GetMySubscriptions(c echo.Context) error {
 	// Resolve current user (Auth0 sub or test header) and ensure a users row exists.
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	uRepo := user.NewDefaultRepository(h.db)
@@ -77,10 +76,8 @@ func (h *DefaultHandler) GetMySubscriptions(c echo.Context) error {
 	if existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub); err != nil {
 		// Create user on first access
 		if newUser, createErr := uRepo.Create(c.Request().Context(), auth0Sub, "", "user"); createErr != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to resolve user"))
 		} else {
 			userID = newUser.ID.String()
 		}
@@ -89,12 +86,10 @@ func (h *DefaultHandler) GetMySubscriptions(c echo.Context) error {
 	}
 
 	subRepo := stripeLib.NewSubscriptionsRepository(h.db)
-	rows, err := subRepo.ListByUserID(c.Request().Context(), userID, limit, offset)
+	rows, next, err := subRepo.ListPageByUserID(c.Request().Context(), userID, page, offset)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to list subscriptions",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to list subscriptions"))
 	}
 
 	items := make([]SubscriptionDTO, 0, len(rows))
@@ -114,12 +109,19 @@ func (h *DefaultHandler) GetMySubscriptions(c echo.Context) error {
 		})
 	}
 
-	return c.JSON(http.StatusOK, ListResponse[SubscriptionDTO]{Items: items, Limit: limit, Offset: offset})
+	return c.JSON(http.StatusOK, ListResponse[SubscriptionDTO]{
+		Items: items, Limit: limit, Offset: offset, NextCursor: next,
+	})
 }
 
 // GetMyInvoices returns the current user's invoices (paginated).
 func (h *DefaultHandler) GetMyInvoices(c echo.Context) error {
-	limit, offset := h.parseLimitOffset(c)
+	ctx := c.Request().Context()
+	page, offset, err := h.parsePage(c)
+	if err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeInvalidCursor, i18n.T(ctx, "Invalid cursor"))
+	}
+	limit := page.Limit
 
 	// No DB configured (e.g., special test mode) — return empty list gracefully.
 	if h.db == nil {
@@ -129,10 +131,8 @@ func (h *DefaultHandler) GetMyInvoices(c echo.Context) error {
 	// Resolve current user (Auth0 sub or test header) and ensure a users row exists.
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	uRepo := user.NewDefaultRepository(h.db)
@@ -140,10 +140,8 @@ func (h *DefaultHandler) GetMyInvoices(c echo.Context) error {
 	if existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub); err != nil {
 		// Create user on first access
 		if newUser, createErr := uRepo.Create(c.Request().Context(), auth0Sub, "", "user"); createErr != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to resolve user"))
 		} else {
 			userID = newUser.ID.String()
 		}
@@ -152,12 +150,10 @@ func (h *DefaultHandler) GetMyInvoices(c echo.Context) error {
 	}
 
 	invRepo := stripeLib.NewInvoicesRepository(h.db)
-	rows, err := invRepo.ListByUserID(c.Request().Context(), userID, limit, offset)
+	rows, next, err := invRepo.ListPageByUserID(c.Request().Context(), userID, page, offset)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to list invoices",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to list invoices"))
 	}
 
 	items := make([]InvoiceDTO, 0, len(rows))
@@ -176,7 +172,9 @@ func (h *DefaultHandler) GetMyInvoices(c echo.Context) error {
 		})
 	}
 
-	return c.JSON(http.StatusOK, ListResponse[InvoiceDTO]{Items: items, Limit: limit, Offset: offset})
+	return c.JSON(http.StatusOK, ListResponse[InvoiceDTO]{
+		Items: items, Limit: limit, Offset: offset, NextCursor: next,
+	})
 }
 
 // parseLimitOffset reads limit/offset from query params and applies defaults/caps.
@@ -201,6 +199,21 @@ func (h *DefaultHandler) parseLimitOffset(c echo.Context) (int32, int32) {
 	return limit, offset
 }
 
+// parsePage reads limit, offset and cursor from query params. The offset is
+// applied after the cursor, for clients that still page by offset.
+func (h *DefaultHandler) parsePage(c echo.Context) (pagination.Page, int32, error) {
+	limit, offset := h.parseLimitOffset(c)
+	page := pagination.Page{Limit: limit}
+	if v := c.QueryParam("cursor"); v != "" {
+		after, err := pagination.Decode(v)
+		if err != nil {
+			return pagination.Page{}, 0, err
+		}
+		page.After = after
+	}
+	return page, offset, nil
+}
+
 // Helper mappers for sqlc/pgx types into DTO pointers.
 
 func uuidToString(u pgtype.UUID) string {
@@ -258,202 +271,128 @@ func formatUUIDBytes(b [16]byte) string {
 	return string(out)
 }
 
+// accountOf returns the billing account of a resolved user.
+func accountOf(u *queries.GetUserByAuth0SubRow) Account {
+	account := Account{UserID: u.ID.String(), Auth0Sub: u.Auth0Sub}
+	if u.StripeCustomerID.Valid {
+		account.StripeCustomerID = u.StripeCustomerID.String
+	}
+	return account
+}
+
+// billingError writes the response for an error from the billing service.
+// Errors it doesn't know become a 500 with fallback, formatted with the error.
+func (h *DefaultHandler) billingError(c echo.Context, err error, fallback string) error {
+	ctx := c.Request().Context()
+	switch {
+	case errors.Is(err, ErrStripeNotConfigured):
+		return problem.Write(c, http.StatusServiceUnavailable, "service_unavailable",
+			i18n.T(ctx, "Stripe not configured"))
+	case errors.Is(err, ErrNoStripeCustomer):
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest,
+			i18n.T(ctx, "No payment method on file. Please subscribe first."))
+	case errors.Is(err, ErrNoActiveSubscription):
+		return problem.Write(c, http.StatusBadRequest, "no_active_subscription",
+			i18n.T(ctx, "No active subscription found"))
+	case errors.Is(err, ErrNoPaymentIntent):
+		return problem.Write(c, http.StatusInternalServerError, "payment_failed",
+			i18n.T(ctx, "Failed to create payment intent for subscription upgrade"))
+	case errors.Is(err, ErrInvoiceNotFound):
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Invoice not found"))
+	case errors.Is(err, ErrInvoicePDFUnavailable):
+		return problem.Write(c, http.StatusNotFound, "invoice_pdf_unavailable",
+			i18n.T(ctx, "The invoice PDF is not available yet"))
+	}
+	return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, i18n.T(ctx, fallback, err))
+}
+
 // CreateCheckoutSession creates a Stripe Checkout Session for subscription signup.
 // POST /api/v1/billing/create-checkout
 func (h *DefaultHandler) CreateCheckoutSession(c echo.Context) error {
+	ctx := c.Request().Context()
 	var req struct {
 		PriceID string `json:"price_id" validate:"required"`
 	}
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request body"))
 	}
 
 	if req.PriceID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "price_id is required",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "price_id is required"))
 	}
 
 	// Resolve current user
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	// Get or create user
 	uRepo := user.NewDefaultRepository(h.db)
-	userRow, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	userRow, err := uRepo.GetByAuth0Sub(ctx, auth0Sub)
 	if err != nil {
 		// Create user on first access
-		_, createErr := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
+		_, createErr := uRepo.Create(ctx, auth0Sub, "", "user")
 		if createErr != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to resolve user"))
 		}
 		// Get the newly created user
-		userRow, err = uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+		userRow, err = uRepo.GetByAuth0Sub(ctx, auth0Sub)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user after creation",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to resolve user after creation"))
 		}
 	}
 
-	// Set Stripe API key from config
-	stripe.Key = h.stripeSecretKey
-	if stripe.Key == "" {
-		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Stripe not configured",
-		})
-	}
-
-	// Create or get Stripe customer
-	var customerID string
-	if userRow.StripeCustomerID.Valid && userRow.StripeCustomerID.String != "" {
-		customerID = userRow.StripeCustomerID.String
-	} else {
-		// Create new Stripe customer
-		customerParams := &stripe.CustomerParams{
-			Metadata: map[string]string{
-				"user_id":   userRow.ID.String(),
-				"auth0_sub": auth0Sub,
-			},
-		}
-		cust, err := customer.New(customerParams)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: fmt.Sprintf("Failed to create Stripe customer: %v", err),
-			})
-		}
-		customerID = cust.ID
-
-		// Update user with Stripe customer ID
-		if _, err := uRepo.UpdateStripeCustomerID(c.Request().Context(), userRow.ID.String(), customerID); err != nil {
-			// Log but don't fail - customer is created
-			fmt.Printf("Warning: failed to update user with Stripe customer ID: %v\n", err)
-		}
-	}
-
-	// Create checkout session
-	baseURL := os.Getenv("FRONTEND_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:3000"
-	}
-
-	params := &stripe.CheckoutSessionParams{
-		Customer: stripe.String(customerID),
-		Mode:     stripe.String(string(stripe.CheckoutSessionModeSubscription)),
-		LineItems: []*stripe.CheckoutSessionLineItemParams{
-			{
-				Price:    stripe.String(req.PriceID),
-				Quantity: stripe.Int64(1),
-			},
-		},
-		SuccessURL: stripe.String(baseURL + "/profile?checkout=success"),
-		CancelURL:  stripe.String(baseURL + "/profile?checkout=canceled"),
-	}
-
-	// For free plans, don't require payment method collection
-	if req.PriceID == h.config.Plans.FreePriceID {
-		params.PaymentMethodCollection = stripe.String("off")
-	}
-
-	sess, err := checkoutsession.New(params)
+	url, err := h.billingService.CreateCheckoutSession(ctx, accountOf(userRow), req.PriceID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: fmt.Sprintf("Failed to create checkout session: %v", err),
-		})
+		return h.billingError(c, err, "Failed to create checkout session: %v")
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
-		"url": sess.URL,
+		"url": url,
 	})
 }
 
 // CreatePortalSession creates a Stripe Customer Portal session for subscription management.
 // POST /api/v1/billing/portal
 func (h *DefaultHandler) CreatePortalSession(c echo.Context) error {
+	ctx := c.Request().Context()
 	// Resolve current user
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	// Get user
-	uRepo := user.NewDefaultRepository(h.db)
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	existingUser, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to resolve user",
-		})
-	}
-
-	if !existingUser.StripeCustomerID.Valid || existingUser.StripeCustomerID.String == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "No payment method on file. Please subscribe first.",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
 	}
 
-	// Set Stripe API key from config
-	stripe.Key = h.stripeSecretKey
-	if stripe.Key == "" {
-		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Stripe not configured",
-		})
-	}
-
-	baseURL := os.Getenv("FRONTEND_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:3000"
-	}
-
-	params := &stripe.BillingPortalSessionParams{
-		Customer:  stripe.String(existingUser.StripeCustomerID.String),
-		ReturnURL: stripe.String(baseURL + "/profile"),
-	}
-
-	sess, err := session.New(params)
+	url, err := h.billingService.CreatePortalSession(ctx, accountOf(existingUser))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: fmt.Sprintf("Failed to create portal session: %v", err),
-		})
+		return h.billingError(c, err, "Failed to create portal session: %v")
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
-		"url": sess.URL,
+		"url": url,
 	})
 }
 
 // GetMyUsage returns the current user's usage statistics.
 // GET /api/v1/billing/usage
 func (h *DefaultHandler) GetMyUsage(c echo.Context) error {
+	ctx := c.Request().Context()
 	// Resolve current user
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	// Get or create user
@@ -463,28 +402,22 @@ func (h *DefaultHandler) GetMyUsage(c echo.Context) error {
 		// Create user on first access
 		_, createErr := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
 		if createErr != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to resolve user"))
 		}
 		// Get the newly created user
 		userRow, err = uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
 		if err != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to resolve user after creation",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to resolve user after creation"))
 		}
 	}
 
 	// Get usage statistics
 	usage, err := h.usageService.GetUsage(c.Request().Context(), userRow.ID.String())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: fmt.Sprintf("Failed to get usage: %v", err),
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to get usage: %v", err))
 	}
 
 	return c.JSON(http.StatusOK, usage)
@@ -493,171 +426,60 @@ func (h *DefaultHandler) GetMyUsage(c echo.Context) error {
 // CreateSubscriptionWithElements creates a subscription and returns client secret for Elements confirmation
 // POST /api/v1/billing/create-subscription-elements
 func (h *DefaultHandler) CreateSubscriptionWithElements(c echo.Context) error {
+	ctx := c.Request().Context()
 	var req struct {
 		PriceID string `json:"price_id" validate:"required"`
 	}
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request body"))
 	}
 
 	// Resolve current user
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
-	// Get or create user
-	uRepo := user.NewDefaultRepository(h.db)
-	userRow, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	// Get user
+	userRow, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to resolve user",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
 	}
 
-	// Set Stripe API key
-	stripe.Key = h.stripeSecretKey
-	if stripe.Key == "" {
-		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Stripe not configured",
-		})
-	}
-
-	// Create or get Stripe customer
-	var customerID string
-	if userRow.StripeCustomerID.Valid && userRow.StripeCustomerID.String != "" {
-		customerID = userRow.StripeCustomerID.String
-	} else {
-		customerParams := &stripe.CustomerParams{
-			Metadata: map[string]string{
-				"user_id":   userRow.ID.String(),
-				"auth0_sub": auth0Sub,
-			},
-		}
-		cust, err := customer.New(customerParams)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: fmt.Sprintf("Failed to create Stripe customer: %v", err),
-			})
-		}
-		customerID = cust.ID
-
-		// Update user with Stripe customer ID
-		if _, err := uRepo.UpdateStripeCustomerID(c.Request().Context(), userRow.ID.String(), customerID); err != nil {
-			fmt.Printf("Warning: failed to update user with Stripe customer ID: %v\n", err)
-		}
-	}
-
-	// Create subscription with incomplete payment
-	subscriptionParams := &stripe.SubscriptionParams{
-		Customer: stripe.String(customerID),
-		Items: []*stripe.SubscriptionItemsParams{
-			{
-				Price: stripe.String(req.PriceID),
-			},
-		},
-		PaymentBehavior: stripe.String("default_incomplete"),
-		PaymentSettings: &stripe.SubscriptionPaymentSettingsParams{
-			SaveDefaultPaymentMethod: stripe.String("on_subscription"),
-		},
-		Expand: []*string{
-			stripe.String("latest_invoice.payment_intent"),
-		},
-	}
-
-	// For free plans, don't require payment method
-	if req.PriceID == h.config.Plans.FreePriceID {
-		subscriptionParams.PaymentBehavior = stripe.String("allow_incomplete")
-	}
-
-	subscription, err := subscription.New(subscriptionParams)
+	intent, err := h.billingService.CreateSubscription(ctx, accountOf(userRow), req.PriceID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: fmt.Sprintf("Failed to create subscription: %v", err),
-		})
-	}
-
-	// Extract client secret from the latest invoice
-	var clientSecret string
-	if subscription.LatestInvoice != nil && subscription.LatestInvoice.PaymentIntent != nil {
-		clientSecret = subscription.LatestInvoice.PaymentIntent.ClientSecret
+		return h.billingError(c, err, "Failed to create subscription: %v")
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"subscriptionId": subscription.ID,
-		"clientSecret":   clientSecret,
+		"subscriptionId": intent.SubscriptionID,
+		"clientSecret":   intent.ClientSecret,
 	})
 }
 
 // GetPaymentMethods returns the customer's saved payment methods
 // GET /api/v1/billing/payment-methods
 func (h *DefaultHandler) GetPaymentMethods(c echo.Context) error {
+	ctx := c.Request().Context()
 	// Resolve current user
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	// Get user
-	uRepo := user.NewDefaultRepository(h.db)
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	existingUser, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to resolve user",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
 	}
 
-	if !existingUser.StripeCustomerID.Valid || existingUser.StripeCustomerID.String == "" {
-		return c.JSON(http.StatusOK, map[string]interface{}{
-			"paymentMethods": []interface{}{},
-		})
-	}
-
-	// Set Stripe API key
-	stripe.Key = h.stripeSecretKey
-	if stripe.Key == "" {
-		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Stripe not configured",
-		})
-	}
-
-	// List payment methods
-	params := &stripe.PaymentMethodListParams{
-		Customer: stripe.String(existingUser.StripeCustomerID.String),
-		Type:     stripe.String("card"),
-	}
-
-	iterator := paymentmethod.List(params)
-	var paymentMethods []interface{}
-
-	for iterator.Next() {
-		pm := iterator.PaymentMethod()
-		paymentMethods = append(paymentMethods, map[string]interface{}{
-			"id":   pm.ID,
-			"type": pm.Type,
-			"card": map[string]interface{}{
-				"brand":    pm.Card.Brand,
-				"last4":    pm.Card.Last4,
-				"expMonth": pm.Card.ExpMonth,
-				"expYear":  pm.Card.ExpYear,
-			},
-			"isDefault": pm.Metadata["is_default"] == "true",
-		})
+	paymentMethods, err := h.billingService.ListPaymentMethods(ctx, accountOf(existingUser))
+	if err != nil {
+		return h.billingError(c, err, "Failed to list payment methods: %v")
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -668,213 +490,75 @@ func (h *DefaultHandler) GetPaymentMethods(c echo.Context) error {
 // UpgradeSubscription upgrades an existing subscription to a new price tier
 // POST /api/v1/billing/upgrade-subscription
 func (h *DefaultHandler) UpgradeSubscription(c echo.Context) error {
+	ctx := c.Request().Context()
 	var req struct {
 		PriceID string `json:"price_id" validate:"required"`
 	}
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request body",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request body"))
 	}
 
 	// Resolve current user
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	// Get user with subscription
-	uRepo := user.NewDefaultRepository(h.db)
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	existingUser, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to resolve user",
-		})
-	}
-
-	// Set Stripe API key
-	stripe.Key = h.stripeSecretKey
-	if stripe.Key == "" {
-		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Stripe not configured",
-		})
-	}
-
-	// Get user's existing subscription from database
-	subRepo := stripeLib.NewSubscriptionsRepository(h.db)
-	dbSubscriptions, err := subRepo.ListByUserID(c.Request().Context(), existingUser.ID.String(), 10, 0)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to retrieve existing subscription",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
 	}
 
-	// Find active subscription
-	var activeSubscription *queries.Subscription
-	for _, sub := range dbSubscriptions {
-		if sub.Status == "active" || sub.Status == "trialing" {
-			activeSubscription = sub
-			break
-		}
-	}
-
-	if activeSubscription == nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "no_active_subscription",
-			Message: "No active subscription found to upgrade",
-		})
-	}
-
-	// Retrieve the Stripe subscription
-	stripeSubscription, err := subscription.Get(activeSubscription.StripeSubscriptionID, nil)
+	intent, err := h.billingService.ChangeSubscription(ctx, accountOf(existingUser), req.PriceID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: fmt.Sprintf("Failed to retrieve Stripe subscription: %v", err),
-		})
+		return h.billingError(c, err, "Failed to upgrade subscription: %v")
 	}
 
-	// For free plans, downgrade immediately without payment
-	if req.PriceID == h.config.Plans.FreePriceID {
-		// Update subscription to free plan
-		_, err = subscription.Update(stripeSubscription.ID, &stripe.SubscriptionParams{
-			Items: []*stripe.SubscriptionItemsParams{
-				{
-					ID:    stripe.String(stripeSubscription.Items.Data[0].ID),
-					Price: stripe.String(req.PriceID),
-				},
-			},
-			ProrationBehavior: stripe.String("create_prorations"),
-		})
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: fmt.Sprintf("Failed to downgrade subscription: %v", err),
-			})
-		}
-
+	switch {
+	case intent.Downgraded:
 		return c.JSON(http.StatusOK, map[string]interface{}{
 			"success": true,
 			"message": "Successfully downgraded to free plan",
 		})
-	}
-
-	// For paid plans, create a subscription modification with payment
-	updatedSubscription, err := subscription.Update(stripeSubscription.ID, &stripe.SubscriptionParams{
-		Items: []*stripe.SubscriptionItemsParams{
-			{
-				ID:    stripe.String(stripeSubscription.Items.Data[0].ID),
-				Price: stripe.String(req.PriceID),
-			},
-		},
-		PaymentBehavior: stripe.String("default_incomplete"),
-		PaymentSettings: &stripe.SubscriptionPaymentSettingsParams{
-			SaveDefaultPaymentMethod: stripe.String("on_subscription"),
-		},
-		ProrationBehavior: stripe.String("create_prorations"),
-		Expand: []*string{
-			stripe.String("latest_invoice.payment_intent"),
-		},
-	})
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: fmt.Sprintf("Failed to upgrade subscription: %v", err),
+	case intent.ClientSecret == "":
+		// Subscription already paid/updated, no payment confirmation needed
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"success":        true,
+			"message":        "Subscription updated successfully",
+			"subscriptionId": intent.SubscriptionID,
 		})
 	}
 
 	// Return client secret for payment confirmation
-	var clientSecret string
-	if updatedSubscription.LatestInvoice != nil {
-		if updatedSubscription.LatestInvoice.PaymentIntent != nil {
-			clientSecret = updatedSubscription.LatestInvoice.PaymentIntent.ClientSecret
-		} else if updatedSubscription.LatestInvoice.Status == "paid" {
-			// Subscription already paid/updated, no payment confirmation needed
-			return c.JSON(http.StatusOK, map[string]interface{}{
-				"success":        true,
-				"message":        "Subscription updated successfully",
-				"subscriptionId": updatedSubscription.ID,
-			})
-		}
-	}
-
-	if clientSecret == "" {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "payment_failed",
-			Message: "Failed to create payment intent for subscription upgrade",
-		})
-	}
-
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"clientSecret":   clientSecret,
-		"subscriptionId": updatedSubscription.ID,
+		"clientSecret":   intent.ClientSecret,
+		"subscriptionId": intent.SubscriptionID,
 	})
 }
 
 // CancelSubscription cancels the user's subscription
 // POST /api/v1/billing/cancel-subscription
 func (h *DefaultHandler) CancelSubscription(c echo.Context) error {
+	ctx := c.Request().Context()
 	// Resolve current user
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil || auth0Sub == "" {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Unable to resolve current user",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
 	}
 
 	// Get user
-	uRepo := user.NewDefaultRepository(h.db)
-	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to resolve user",
-		})
-	}
-
-	// Get active subscription
-	subRepo := stripeLib.NewSubscriptionsRepository(h.db)
-	subs, err := subRepo.ListByUserID(c.Request().Context(), existingUser.ID.String(), 10, 0)
+	existingUser, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to get subscriptions",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
 	}
 
-	if len(subs) == 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "No active subscription found",
-		})
-	}
-
-	// Set Stripe API key
-	stripe.Key = h.stripeSecretKey
-	if stripe.Key == "" {
-		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
-			Error:   "service_unavailable",
-			Message: "Stripe not configured",
-		})
-	}
-
-	// Cancel subscription at period end using Update API
-	_, err = subscription.Update(subs[0].StripeSubscriptionID, &stripe.SubscriptionParams{
-		CancelAtPeriodEnd: stripe.Bool(true),
-	})
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: fmt.Sprintf("Failed to cancel subscription: %v", err),
-		})
+	if err := h.billingService.CancelSubscription(ctx, accountOf(existingUser)); err != nil {
+		return h.billingError(c, err, "Failed to cancel subscription: %v")
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -2,6 +2,7 @@ package billing
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage"
 )
 
@@ -49,6 +51,12 @@ func TestGetMySubscriptions(t *testing.T) {
 			query:          "limit=10&offset=5",
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "invalid cursor",
+			userID:         "auth0|testuser",
+			query:          "cursor=not-a-cursor",
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "no database",
 			userID:         "auth0|testuser",
@@ -56,7 +64,7 @@ func TestGetMySubscriptions(t *testing.T) {
 			expectedStatus: http.StatusOK,
 		},
 	}
-	handler := NewDefaultHandler(nil, nil, "", createTestConfig())
+	handler := NewDefaultHandler(nil, nil, &BillingServiceMock{}, createTestConfig())
 	runHandlerTableTest(t, handler.GetMySubscriptions, tests)
 }
 
@@ -80,7 +88,7 @@ func testCreateUserWhenMissing(t *testing.T, getHandler func(*DefaultHandler) fu
 			return &rowsIterStub{}, nil
 		},
 	}
-	h := NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig())
+	h := NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig())
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set("X-Test-User", "auth0|testuser")
@@ -198,7 +206,7 @@ func runHandlerTableTest(t *testing.T, handlerFunc func(echo.Context) error, tes
 
 // Unauthorized when db != nil and JWT sub is empty (no X-Test-User header)
 func TestGetMySubscriptions_DB_Unauthorized(t *testing.T) {
-	h := NewDefaultHandler(&storage.DatabaseMock{}, nil, "sk_test_fake", createTestConfig())
+	h := NewDefaultHandler(&storage.DatabaseMock{}, nil, &BillingServiceMock{}, createTestConfig())
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	rec := httptest.NewRecorder()
@@ -219,7 +227,7 @@ func TestGetMySubscriptions_DB_UserResolveError(t *testing.T) {
 			return rowStub{scan: func(dest ...any) error { return errBoom() }}
 		},
 	}
-	h := NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig())
+	h := NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig())
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set("X-Test-User", "auth0|testuser")
@@ -261,7 +269,7 @@ func testDBListError(t *testing.T, getHandler func(*storage.DatabaseMock) func(e
 
 func TestGetMySubscriptions_DB_ListError(t *testing.T) {
 	testDBListError(t, func(db *storage.DatabaseMock) func(echo.Context) error {
-		return NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig()).GetMySubscriptions
+		return NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig()).GetMySubscriptions
 	}, "limit=9999&offset=-5")
 }
 
@@ -315,7 +323,7 @@ func TestGetMySubscriptions_DB_SuccessMapping(t *testing.T) {
 			return rows, nil
 		},
 	}
-	h := NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig())
+	h := NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig())
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/?limit=100000&offset=-10", nil)
 	req.Header.Set("X-Test-User", "auth0|testuser")
@@ -333,7 +341,7 @@ func TestGetMySubscriptions_DB_SuccessMapping(t *testing.T) {
 // --- Invoices: DB-backed tests ---
 
 func TestGetMyInvoices_DB_Unauthorized(t *testing.T) {
-	h := NewDefaultHandler(&storage.DatabaseMock{}, nil, "sk_test_fake", createTestConfig())
+	h := NewDefaultHandler(&storage.DatabaseMock{}, nil, &BillingServiceMock{}, createTestConfig())
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	rec := httptest.NewRecorder()
@@ -354,7 +362,7 @@ func TestGetMyInvoices_DB_UserResolveError(t *testing.T) {
 			return rowStub{scan: func(dest ...any) error { return errBoom() }}
 		},
 	}
-	h := NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig())
+	h := NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig())
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	req.Header.Set("X-Test-User", "auth0|testuser")
@@ -371,7 +379,7 @@ func TestGetMyInvoices_DB_UserResolveError(t *testing.T) {
 
 func TestGetMyInvoices_DB_ListError(t *testing.T) {
 	testDBListError(t, func(db *storage.DatabaseMock) func(echo.Context) error {
-		return NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig()).GetMyInvoices
+		return NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig()).GetMyInvoices
 	}, "limit=0&offset=-1")
 }
 
@@ -423,7 +431,7 @@ func TestGetMyInvoices_DB_SuccessMapping(t *testing.T) {
 			return rows, nil
 		},
 	}
-	h := NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig())
+	h := NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig())
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/?limit=3&offset=2", nil)
 	req.Header.Set("X-Test-User", "auth0|testuser")
@@ -438,9 +446,65 @@ func TestGetMyInvoices_DB_SuccessMapping(t *testing.T) {
 	}
 }
 
+func TestGetMyInvoices_DB_NextCursor(t *testing.T) {
+	now := time.Now()
+	invoiceRow := func(id uuid.UUID, createdAt time.Time) func(dest ...any) error {
+		return func(dest ...any) error {
+			*dest[0].(*pgtype.UUID) = pgtype.UUID{Bytes: id, Valid: true}
+			*dest[2].(*string) = "in_" + id.String()
+			*dest[4].(*string) = "paid"
+			*dest[9].(*pgtype.Timestamptz) = pgtype.Timestamptz{Time: createdAt, Valid: true}
+			return nil
+		}
+	}
+	first := uuid.New()
+	after := pagination.Cursor{CreatedAt: now, ID: uuid.NewString()}
+
+	var queryArgs []interface{}
+	db := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return rowStub{scan: mockUserRow(now)}
+		},
+		QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+			queryArgs = args
+			return &rowsIterStub{scans: []func(dest ...any) error{
+				invoiceRow(first, now.Add(-time.Minute)),
+				invoiceRow(uuid.New(), now.Add(-2*time.Minute)),
+			}}, nil
+		},
+	}
+	h := NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig())
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/?limit=1&cursor="+after.Encode(), nil)
+	req.Header.Set("X-Test-User", "auth0|testuser")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.GetMyInvoices(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp ListResponse[InvoiceDTO]
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].ID != first.String() {
+		t.Fatalf("expected only the first invoice, got %+v", resp.Items)
+	}
+	want := pagination.Cursor{CreatedAt: now.Add(-time.Minute), ID: first.String()}.Encode()
+	if resp.NextCursor == nil || *resp.NextCursor != want {
+		t.Fatalf("expected next cursor %q, got %v", want, resp.NextCursor)
+	}
+	if got := queryArgs[2].(pgtype.UUID); uuid.UUID(got.Bytes).String() != after.ID {
+		t.Fatalf("expected the query to start after %s, got %v", after.ID, got)
+	}
+}
+
 // --- parseLimitOffset direct tests ---
 func Test_parseLimitOffset(t *testing.T) {
-	h := NewDefaultHandler(nil, nil, "", createTestConfig())
+	h := NewDefaultHandler(nil, nil, &BillingServiceMock{}, createTestConfig())
 	cases := []struct {
 		name       string
 		query      string
@@ -497,6 +561,12 @@ func TestGetMyInvoices(t *testing.T) {
 			query:          "limit=5&offset=10",
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "invalid cursor",
+			userID:         "auth0|testuser",
+			query:          "cursor=not-a-cursor",
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "no database",
 			userID:         "auth0|testuser",
@@ -505,14 +575,14 @@ func TestGetMyInvoices(t *testing.T) {
 		},
 	}
 
-	handler := NewDefaultHandler(nil, nil, "", createTestConfig())
+	handler := NewDefaultHandler(nil, nil, &BillingServiceMock{}, createTestConfig())
 	runHandlerTableTest(t, handler.GetMyInvoices, tests)
 }
 
 // Tests for CreateCheckoutSession
 func TestCreateCheckoutSession(t *testing.T) {
 	t.Run("fail: missing price_id", func(t *testing.T) {
-		h := NewDefaultHandler(nil, nil, "", createTestConfig())
+		h := NewDefaultHandler(nil, nil, &BillingServiceMock{}, createTestConfig())
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodPost, "/", nil)
 		req.Header.Set("Content-Type", "application/json")
@@ -551,7 +621,9 @@ func TestCreatePortalSession(t *testing.T) {
 				}
 			},
 		}
-		h := NewDefaultHandler(db, nil, "sk_test_fake", createTestConfig())
+		cfg := createTestConfig()
+		stripe := &StripeClientMock{}
+		h := NewDefaultHandler(db, nil, NewDefaultBillingService(db, &cfg.Plans, stripe, nil), cfg)
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodPost, "/", nil)
 		req.Header.Set("X-Test-User", "auth0|testuser")
@@ -582,7 +654,8 @@ func TestCreatePortalSession(t *testing.T) {
 				}
 			},
 		}
-		h := NewDefaultHandler(db, nil, "", createTestConfig())
+		cfg := createTestConfig()
+		h := NewDefaultHandler(db, nil, NewDefaultBillingService(db, &cfg.Plans, nil, nil), cfg)
 		e := echo.New()
 		req := httptest.NewRequest(http.MethodPost, "/", nil)
 		req.Header.Set("X-Test-User", "auth0|testuser")
@@ -7,11 +7,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/dunning"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/tenant"
 )
 
 // DefaultUsageService implements UsageService using the database.
@@ -42,31 +46,57 @@ func (s *DefaultUsageService) GetUsage(ctx context.Context, userID string) (*Usa
 
 	q := queries.New(s.db)
 
-	plan, hasSubscription, err := s.resolveUserPlan(ctx, q, userUUID)
+	sandbox, err := s.isSandbox(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	periodStart, periodEnd, err := s.getBillingPeriod(ctx, q, userUUID)
-	if err != nil {
-		return nil, err
+	var plan *queries.Plan
+	var hasSubscription bool
+	var grace *dunning.GracePeriod
+	var periodStart, periodEnd time.Time
+	if sandbox {
+		plan, periodStart, periodEnd = s.getSandboxPlan()
+	} else {
+		plan, hasSubscription, grace, err = s.resolvePlanWithGrace(ctx, q, userID, userUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		periodStart, periodEnd, err = s.getBillingPeriod(ctx, q, userUUID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	imagesUsed, err := q.CountImagesCreatedInPeriod(ctx, queries.CountImagesCreatedInPeriodParams{
 		UserID:      userUUID,
 		CreatedAt:   pgtype.Timestamptz{Time: periodStart, Valid: true},
 		CreatedAt_2: pgtype.Timestamptz{Time: periodEnd, Valid: true},
+		Sandbox:     sandbox,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	remaining := plan.MonthlyLimit - imagesUsed
-	if remaining < 0 {
-		remaining = 0
+	// Subscribers to a plan with overage may go past its limit, up to the cap;
+	// everyone else can top up with credits.
+	var overageCap int32
+	if hasSubscription {
+		overageCap = s.config.OverageCapFor(plan.Code)
+	}
+	remaining := max(plan.MonthlyLimit+overageCap-imagesUsed, 0)
+
+	var credits int32
+	if !hasSubscription {
+		credits, err = credit.NewDefaultRepository(s.db).Balance(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		remaining += credits
 	}
 
-	return &UsageStats{
+	stats := &UsageStats{
 		ImagesUsed:      imagesUsed,
 		MonthlyLimit:    plan.MonthlyLimit,
 		PlanCode:        plan.Code,
@@ -74,7 +104,103 @@ func (s *DefaultUsageService) GetUsage(ctx context.Context, userID string) (*Usa
 		PeriodEnd:       periodEnd.Format(time.RFC3339),
 		HasSubscription: hasSubscription,
 		RemainingImages: remaining,
-	}, nil
+		Credits:         credits,
+		OverageCap:      overageCap,
+		OverageImages:   max(imagesUsed-plan.MonthlyLimit, 0),
+	}
+	if grace != nil {
+		stats.PaymentPastDue = true
+		stats.GracePeriodEndsAt = grace.EndsAt(s.config.GracePeriod()).Format(time.RFC3339)
+	}
+	return stats, nil
+}
+
+// PlanCode returns the code of the plan GetUsage would report.
+func (s *DefaultUsageService) PlanCode(ctx context.Context, userID string) (string, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return "", errors.New("invalid user ID format")
+	}
+
+	sandbox, err := s.isSandbox(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if sandbox {
+		return SandboxPlanCode, nil
+	}
+
+	plan, _, _, err := s.resolvePlanWithGrace(ctx, queries.New(s.db), userID, pgtype.UUID{Bytes: uid, Valid: true})
+	if err != nil {
+		return "", err
+	}
+	return plan.Code, nil
+}
+
+// resolvePlanWithGrace determines the user's plan like resolveUserPlan, except
+// that a past-due subscription still in its grace period keeps its plan. The
+// grace period is returned when the subscription is past due.
+func (s *DefaultUsageService) resolvePlanWithGrace(
+	ctx context.Context,
+	q *queries.Queries,
+	userID string,
+	userUUID pgtype.UUID,
+) (*queries.Plan, bool, *dunning.GracePeriod, error) {
+	plan, hasSubscription, err := s.resolveUserPlan(ctx, q, userUUID)
+	if err != nil || hasSubscription {
+		return plan, hasSubscription, nil, err
+	}
+
+	grace, err := dunning.NewDefaultRepository(s.db).GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	if gracePlan := s.findPlanInGrace(grace); gracePlan != nil {
+		return gracePlan, true, grace, nil
+	}
+	return plan, false, grace, nil
+}
+
+// findPlanInGrace returns the plan of a past-due subscription whose grace
+// period is still running, or nil.
+func (s *DefaultUsageService) findPlanInGrace(grace *dunning.GracePeriod) *queries.Plan {
+	if grace == nil || grace.PriceID == nil || !time.Now().Before(grace.EndsAt(s.config.GracePeriod())) {
+		return nil
+	}
+	for _, configPlan := range s.config.GetAllPlans() {
+		if configPlan.PriceID == *grace.PriceID {
+			return &queries.Plan{
+				Code:         configPlan.Code,
+				PriceID:      configPlan.PriceID,
+				MonthlyLimit: configPlan.MonthlyLimit,
+			}
+		}
+	}
+	return nil
+}
+
+// isSandbox reports whether the user is a sandbox tenant. Users without a row yet are live.
+func (s *DefaultUsageService) isSandbox(ctx context.Context, userID string) (bool, error) {
+	account, err := tenant.NewDefaultRepository(s.db).GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, tenant.ErrAccountNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to resolve account mode: %w", err)
+	}
+	return account.Mode == tenant.ModeSandbox, nil
+}
+
+// getSandboxPlan returns the sandbox plan and its calendar-month period. Sandbox
+// tenants never have live subscriptions, so their quota is independent of Stripe.
+func (s *DefaultUsageService) getSandboxPlan() (*queries.Plan, time.Time, time.Time) {
+	now := time.Now().UTC()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	plan := &queries.Plan{
+		Code:         SandboxPlanCode,
+		MonthlyLimit: s.config.SandboxMonthlyLimit,
+	}
+	return plan, periodStart, periodStart.AddDate(0, 1, 0)
 }
 
 // resolveUserPlan determines the user's current plan and subscription status
@@ -83,15 +209,15 @@ func (s *DefaultUsageService) resolveUserPlan(
 	q *queries.Queries,
 	userUUID pgtype.UUID,
 ) (*queries.Plan, bool, error) {
-	// Get user's active plan from database
+	// Get user's active plan from database. The query returns an empty plan
+	// along with the error when there is none.
 	activePlan, err := q.GetUserActivePlan(ctx, userUUID)
-	if err != nil && err.Error() != "no rows in result set" {
-		return nil, false, err
-	}
-
-	if activePlan != nil {
+	if err == nil {
 		return activePlan, true, nil
 	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
 
 	// Try to find plan by subscription price ID
 	plan, hasSubscription, err := s.findPlanBySubscription(ctx, q, userUUID)
@@ -185,10 +311,11 @@ func (s *DefaultUsageService) getBillingPeriod(
 ) (time.Time, time.Time, error) {
 	now := time.Now().UTC()
 
-	// Try to get subscription period for all users (free and paid)
+	// Try to get subscription period for all users (free and paid); a past-due
+	// subscription keeps its period while Stripe retries the payment.
 	subs, err := q.ListSubscriptionsByUserIDAndStatuses(ctx, queries.ListSubscriptionsByUserIDAndStatusesParams{
 		UserID:  userUUID,
-		Column2: []string{"active", "trialing"},
+		Column2: []string{"active", "trialing", "past_due"},
 	})
 	if err == nil && len(subs) > 0 {
 		mostRecentSub := s.findMostRecentSubscription(subs)
@@ -204,7 +331,10 @@ func (s *DefaultUsageService) getBillingPeriod(
 	return periodStart, periodEnd, nil
 }
 
-// CanCreateImage checks if a user can create a new image based on their plan limits.
+// CanCreateImage checks if a user can create a new image based on their plan
+// limits. Subscribers to a plan with overage can go past the limit up to its
+// cap, and users without a subscription who have used their allowance can
+// still create images while they have credits.
 func (s *DefaultUsageService) CanCreateImage(ctx context.Context, userID string) (bool, error) {
 	usage, err := s.GetUsage(ctx, userID)
 	if err != nil {
@@ -212,7 +342,20 @@ func (s *DefaultUsageService) CanCreateImage(ctx context.Context, userID string)
 	}
 
 	// Check if user is under their limit
-	return usage.ImagesUsed < usage.MonthlyLimit, nil
+	if usage.ImagesUsed < usage.MonthlyLimit+usage.OverageCap {
+		return true, nil
+	}
+	return !usage.HasSubscription && usage.Credits > 0, nil
+}
+
+// RemainingImages returns the number of images the user can still create in the current period.
+func (s *DefaultUsageService) RemainingImages(ctx context.Context, userID string) (int32, error) {
+	usage, err := s.GetUsage(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return usage.RemainingImages, nil
 }
 
 // GetPlanByCode returns plan details by plan code.
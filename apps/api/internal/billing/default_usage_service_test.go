@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
 
 	"github.com/real-staging-ai/api/internal/config"
 	"github.com/real-staging-ai/api/internal/storage"
@@ -78,6 +81,309 @@ func TestDefaultUsageService_GetUsage_validation(t *testing.T) {
 	})
 }
 
+func TestDefaultUsageService_GetUsage_sandbox(t *testing.T) {
+	poolMock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer poolMock.Close()
+
+	mockDB := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
+	}
+	testPlans := &config.Plans{
+		FreePriceID:         "price_free_test",
+		SandboxMonthlyLimit: 25,
+	}
+	service := NewDefaultUsageService(mockDB, testPlans)
+
+	userID := uuid.New()
+	poolMock.ExpectQuery("FROM users").
+		WithArgs(userID.String()).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "auth0_sub", "account_mode", "stripe_customer_id", "stripe_test_clock_id", "updated_at",
+		}).AddRow(userID.String(), "auth0|sandbox", "sandbox", nil, nil, time.Now()))
+	// Only sandbox images count; no plan or subscription lookups are made.
+	poolMock.ExpectQuery("SELECT COUNT").
+		WithArgs(pgtype.UUID{Bytes: userID, Valid: true}, pgxmock.AnyArg(), pgxmock.AnyArg(), true).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int32(10)))
+	// Sandbox accounts have no subscription, so their credits count too.
+	poolMock.ExpectQuery("FROM credit_balances").
+		WithArgs(userID.String()).
+		WillReturnRows(pgxmock.NewRows([]string{"balance"}).AddRow(int32(3)))
+
+	stats, err := service.GetUsage(context.Background(), userID.String())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.PlanCode != SandboxPlanCode {
+		t.Errorf("Expected plan code %q but got %q", SandboxPlanCode, stats.PlanCode)
+	}
+	if stats.MonthlyLimit != 25 || stats.ImagesUsed != 10 || stats.RemainingImages != 18 || stats.Credits != 3 {
+		t.Errorf("Unexpected usage: %+v", stats)
+	}
+	if stats.HasSubscription {
+		t.Error("Expected sandbox usage to report no subscription")
+	}
+	if err := poolMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDefaultUsageService_CanCreateImage_credits(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		credits  int32
+		expected bool
+	}{
+		{name: "success: credits cover images past the allowance", credits: 2, expected: true},
+		{name: "success: no credits left", credits: 0, expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer poolMock.Close()
+
+			mockDB := &storage.DatabaseMock{
+				QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+					return poolMock.QueryRow(ctx, sql, args...)
+				},
+			}
+			service := NewDefaultUsageService(mockDB, &config.Plans{SandboxMonthlyLimit: 25})
+
+			userID := uuid.New()
+			poolMock.ExpectQuery("FROM users").
+				WithArgs(userID.String()).
+				WillReturnRows(pgxmock.NewRows([]string{
+					"id", "auth0_sub", "account_mode", "stripe_customer_id", "stripe_test_clock_id", "updated_at",
+				}).AddRow(userID.String(), "auth0|sandbox", "sandbox", nil, nil, time.Now()))
+			poolMock.ExpectQuery("SELECT COUNT").
+				WithArgs(pgtype.UUID{Bytes: userID, Valid: true}, pgxmock.AnyArg(), pgxmock.AnyArg(), true).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int32(25)))
+			poolMock.ExpectQuery("FROM credit_balances").
+				WithArgs(userID.String()).
+				WillReturnRows(pgxmock.NewRows([]string{"balance"}).AddRow(tc.credits))
+
+			canCreate, err := service.CanCreateImage(context.Background(), userID.String())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if canCreate != tc.expected {
+				t.Errorf("Expected CanCreateImage %v but got %v", tc.expected, canCreate)
+			}
+		})
+	}
+}
+
+func TestDefaultUsageService_CanCreateImage_overage(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		used          int32
+		meter         string
+		expected      bool
+		wantRemaining int32
+	}{
+		{name: "success: subscribers go past the limit up to the cap", used: 110, meter: "image_overage",
+			expected: true, wantRemaining: 40},
+		{name: "success: the cap is a hard limit", used: 150, meter: "image_overage", expected: false},
+		{name: "success: without a meter the limit is hard", used: 100, expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer poolMock.Close()
+
+			mockDB := &storage.DatabaseMock{
+				QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+					return poolMock.QueryRow(ctx, sql, args...)
+				},
+				QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+					return poolMock.Query(ctx, sql, args...)
+				},
+			}
+			plans := &config.Plans{OverageMeterEvent: tc.meter, OverageCap: 50, OveragePlans: []string{"pro"}}
+			service := NewDefaultUsageService(mockDB, plans)
+
+			userID := uuid.New()
+			userUUID := pgtype.UUID{Bytes: userID, Valid: true}
+			expectUsage := func() {
+				poolMock.ExpectQuery("FROM users").
+					WithArgs(userID.String()).
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "auth0_sub", "account_mode", "stripe_customer_id", "stripe_test_clock_id", "updated_at",
+					}).AddRow(userID.String(), "auth0|pro", "live", nil, nil, time.Now()))
+				poolMock.ExpectQuery("FROM plans").
+					WithArgs(userUUID).
+					WillReturnRows(pgxmock.NewRows([]string{"id", "code", "price_id", "monthly_limit"}).
+						AddRow(pgtype.UUID{Bytes: uuid.New(), Valid: true}, "pro", "price_pro", int32(100)))
+				// No subscription period on record, so the calendar month is used.
+				poolMock.ExpectQuery("FROM subscriptions").
+					WithArgs(userUUID, []string{"active", "trialing", "past_due"}).
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "user_id", "stripe_subscription_id", "status", "price_id", "current_period_start",
+						"current_period_end", "cancel_at", "canceled_at", "cancel_at_period_end", "created_at", "updated_at",
+					}))
+				poolMock.ExpectQuery("SELECT COUNT").
+					WithArgs(userUUID, pgxmock.AnyArg(), pgxmock.AnyArg(), false).
+					WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(tc.used))
+			}
+
+			expectUsage()
+			canCreate, err := service.CanCreateImage(context.Background(), userID.String())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if canCreate != tc.expected {
+				t.Errorf("Expected CanCreateImage %v but got %v", tc.expected, canCreate)
+			}
+
+			expectUsage()
+			stats, err := service.GetUsage(context.Background(), userID.String())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stats.RemainingImages != tc.wantRemaining {
+				t.Errorf("Expected %d remaining images but got %d", tc.wantRemaining, stats.RemainingImages)
+			}
+			if stats.OverageImages != tc.used-100 {
+				t.Errorf("Expected %d overage images but got %d", tc.used-100, stats.OverageImages)
+			}
+			if err := poolMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDefaultUsageService_GetUsage_gracePeriod(t *testing.T) {
+	subscriptionColumns := []string{
+		"id", "user_id", "stripe_subscription_id", "status", "price_id", "current_period_start",
+		"current_period_end", "cancel_at", "canceled_at", "cancel_at_period_end", "created_at", "updated_at",
+	}
+	for _, tc := range []struct {
+		name     string
+		pastDue  time.Duration
+		wantPlan string
+	}{
+		{name: "success: the plan is kept during the grace period", pastDue: 2 * 24 * time.Hour, wantPlan: "pro"},
+		{name: "success: the free plan applies once it ends", pastDue: 10 * 24 * time.Hour, wantPlan: "free"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer poolMock.Close()
+
+			mockDB := &storage.DatabaseMock{
+				QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+					return poolMock.QueryRow(ctx, sql, args...)
+				},
+				QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+					return poolMock.Query(ctx, sql, args...)
+				},
+			}
+			plans := &config.Plans{FreePriceID: "price_free", ProPriceID: "price_pro", GracePeriodDays: 7}
+			service := NewDefaultUsageService(mockDB, plans)
+
+			userID := uuid.New()
+			userUUID := pgtype.UUID{Bytes: userID, Valid: true}
+			pastDueSince := time.Now().Add(-tc.pastDue)
+			poolMock.ExpectQuery("FROM users").
+				WithArgs(userID.String()).
+				WillReturnRows(pgxmock.NewRows([]string{
+					"id", "auth0_sub", "account_mode", "stripe_customer_id", "stripe_test_clock_id", "updated_at",
+				}).AddRow(userID.String(), "auth0|late", "live", nil, nil, time.Now()))
+			// The past-due subscription is neither an active plan nor an active subscription.
+			poolMock.ExpectQuery("FROM plans").WithArgs(userUUID).WillReturnError(pgx.ErrNoRows)
+			poolMock.ExpectQuery("FROM subscriptions").
+				WithArgs(userUUID, []string{"active", "trialing"}).
+				WillReturnRows(pgxmock.NewRows(subscriptionColumns))
+			priceID := "price_pro"
+			poolMock.ExpectQuery("FROM subscription_dunning").
+				WithArgs(userID.String()).
+				WillReturnRows(pgxmock.NewRows([]string{
+					"subscription_id", "stripe_subscription_id", "user_id", "price_id", "past_due_since", "expired_at",
+				}).AddRow(uuid.NewString(), "sub_late", userID.String(), &priceID, pastDueSince, nil))
+			poolMock.ExpectQuery("FROM subscriptions").
+				WithArgs(userUUID, []string{"active", "trialing", "past_due"}).
+				WillReturnRows(pgxmock.NewRows(subscriptionColumns))
+			poolMock.ExpectQuery("SELECT COUNT").
+				WithArgs(userUUID, pgxmock.AnyArg(), pgxmock.AnyArg(), false).
+				WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int32(20)))
+			if tc.wantPlan == "free" {
+				poolMock.ExpectQuery("FROM credit_balances").
+					WithArgs(userID.String()).
+					WillReturnRows(pgxmock.NewRows([]string{"balance"}).AddRow(int32(0)))
+			}
+
+			stats, err := service.GetUsage(context.Background(), userID.String())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stats.PlanCode != tc.wantPlan || stats.HasSubscription != (tc.wantPlan == "pro") {
+				t.Errorf("Expected plan %q but got %+v", tc.wantPlan, stats)
+			}
+			wantEnd := pastDueSince.Add(7 * 24 * time.Hour).Format(time.RFC3339)
+			if !stats.PaymentPastDue || stats.GracePeriodEndsAt != wantEnd {
+				t.Errorf("Expected a past-due payment with grace until %s but got %+v", wantEnd, stats)
+			}
+			if err := poolMock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDefaultUsageService_PlanCode(t *testing.T) {
+	poolMock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer poolMock.Close()
+
+	mockDB := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
+	}
+	service := NewDefaultUsageService(mockDB, &config.Plans{FreePriceID: "price_free", ProPriceID: "price_pro"})
+
+	userID := uuid.New()
+	userUUID := pgtype.UUID{Bytes: userID, Valid: true}
+	poolMock.ExpectQuery("FROM users").
+		WithArgs(userID.String()).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "auth0_sub", "account_mode", "stripe_customer_id", "stripe_test_clock_id", "updated_at",
+		}).AddRow(userID.String(), "auth0|pro", "live", nil, nil, time.Now()))
+	// Only the plan is looked up; images are not counted.
+	poolMock.ExpectQuery("FROM plans").
+		WithArgs(userUUID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "code", "price_id", "monthly_limit"}).
+			AddRow(pgtype.UUID{Bytes: uuid.New(), Valid: true}, "pro", "price_pro", int32(1000)))
+
+	code, err := service.PlanCode(context.Background(), userID.String())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if code != "pro" {
+		t.Errorf("Expected plan code %q but got %q", "pro", code)
+	}
+	if err := poolMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := service.PlanCode(context.Background(), "not-a-uuid"); err == nil {
+		t.Error("Expected an error for an invalid user ID")
+	}
+}
+
 func TestDefaultUsageService_CanCreateImage_validation(t *testing.T) {
 	mockDB := &storage.DatabaseMock{}
 	testPlans := &config.Plans{
@@ -116,6 +422,28 @@ func TestDefaultUsageService_CanCreateImage_validation(t *testing.T) {
 	})
 }
 
+func TestDefaultUsageService_RemainingImages_validation(t *testing.T) {
+	mockDB := &storage.DatabaseMock{}
+	testPlans := &config.Plans{
+		FreePriceID:     "price_free_test",
+		ProPriceID:      "price_pro_test",
+		BusinessPriceID: "price_business_test",
+	}
+
+	service := NewDefaultUsageService(mockDB, testPlans)
+	ctx := context.Background()
+
+	t.Run("fail: empty userID", func(t *testing.T) {
+		remaining, err := service.RemainingImages(ctx, "")
+		if err == nil {
+			t.Fatal("Expected error for empty userID, got nil")
+		}
+		if remaining != 0 {
+			t.Errorf("Expected remaining to be 0 for error case, got %d", remaining)
+		}
+	})
+}
+
 func TestDefaultUsageService_GetPlanByCode_validation(t *testing.T) {
 	mockDB := &storage.DatabaseMock{}
 	testPlans := &config.Plans{
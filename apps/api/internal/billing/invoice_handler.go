@@ -0,0 +1,73 @@
+package billing
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/i18n"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// GetInvoicePDF sends the PDF of one of the current user's invoices, fetched
+// from Stripe so the frontend needs no Stripe key of its own. With
+// ?redirect=true it redirects to Stripe's PDF URL instead of streaming it.
+// GET /api/v1/billing/invoices/:id/pdf
+func (h *DefaultHandler) GetInvoicePDF(c echo.Context) error {
+	ctx := c.Request().Context()
+	invoiceID := c.Param("id")
+	if _, err := uuid.Parse(invoiceID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid invoice ID"))
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil || auth0Sub == "" {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Unable to resolve current user"))
+	}
+
+	userRow, err := user.NewDefaultRepository(h.db).GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to resolve user"))
+	}
+
+	pdf, err := h.billingService.InvoicePDF(ctx, accountOf(userRow), invoiceID)
+	if err != nil {
+		return h.billingError(c, err, "Failed to get invoice PDF: %v")
+	}
+
+	if redirect, _ := strconv.ParseBool(c.QueryParam("redirect")); redirect {
+		return c.Redirect(http.StatusFound, pdf.URL)
+	}
+	return h.streamInvoicePDF(c, pdf.URL, pdf.Filename)
+}
+
+// streamInvoicePDF copies the PDF at url into the response as an attachment.
+func (h *DefaultHandler) streamInvoicePDF(c echo.Context, url, filename string) error {
+	ctx := c.Request().Context()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to download invoice PDF"))
+	}
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return problem.Write(c, http.StatusBadGateway, "bad_gateway", i18n.T(ctx, "Failed to download invoice PDF"))
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return problem.Write(c, http.StatusBadGateway, "bad_gateway", i18n.T(ctx, "Failed to download invoice PDF"))
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	if resp.ContentLength > 0 {
+		c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(resp.ContentLength, 10))
+	}
+	return c.Stream(http.StatusOK, "application/pdf", resp.Body)
+}
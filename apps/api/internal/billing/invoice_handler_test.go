@@ -0,0 +1,110 @@
+package billing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestGetInvoicePDF(t *testing.T) {
+	invoiceID := uuid.NewString()
+	newCtx := func(id, query string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/billing/invoices/"+id+"/pdf"+query, nil)
+		req.Header.Set("X-Test-User", "auth0|testuser")
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return c, rec
+	}
+	db := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return rowStub{scan: mockUserRow(time.Now())}
+		},
+	}
+	serviceReturning := func(pdf *InvoicePDF, err error) *BillingServiceMock {
+		return &BillingServiceMock{
+			InvoicePDFFunc: func(ctx context.Context, account Account, id string) (*InvoicePDF, error) {
+				assert.Equal(t, invoiceID, id)
+				return pdf, err
+			},
+		}
+	}
+
+	t.Run("success: redirects to Stripe's PDF", func(t *testing.T) {
+		svc := serviceReturning(&InvoicePDF{URL: "https://pay.stripe.com/invoice/acct_1/pdf", Filename: "F-1.pdf"}, nil)
+		c, rec := newCtx(invoiceID, "?redirect=true")
+
+		require.NoError(t, NewDefaultHandler(db, nil, svc, createTestConfig()).GetInvoicePDF(c))
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "https://pay.stripe.com/invoice/acct_1/pdf", rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("fail: invalid invoice ID", func(t *testing.T) {
+		c, rec := newCtx("nope", "")
+
+		require.NoError(t, NewDefaultHandler(db, nil, &BillingServiceMock{}, createTestConfig()).GetInvoicePDF(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fail: invoice of another user", func(t *testing.T) {
+		c, rec := newCtx(invoiceID, "")
+
+		h := NewDefaultHandler(db, nil, serviceReturning(nil, ErrInvoiceNotFound), createTestConfig())
+		require.NoError(t, h.GetInvoicePDF(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("fail: Stripe not configured", func(t *testing.T) {
+		c, rec := newCtx(invoiceID, "")
+
+		h := NewDefaultHandler(db, nil, serviceReturning(nil, ErrStripeNotConfigured), createTestConfig())
+		require.NoError(t, h.GetInvoicePDF(c))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestStreamInvoicePDF(t *testing.T) {
+	newCtx := func() (echo.Context, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), rec
+	}
+
+	t.Run("success: streams the PDF as an attachment", func(t *testing.T) {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("%PDF-1.4 invoice"))
+		}))
+		defer upstream.Close()
+		c, rec := newCtx()
+
+		h := NewDefaultHandler(nil, nil, &BillingServiceMock{}, createTestConfig())
+		require.NoError(t, h.streamInvoicePDF(c, upstream.URL, "F-1001.pdf"))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="F-1001.pdf"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.True(t, strings.HasPrefix(rec.Body.String(), "%PDF"))
+	})
+
+	t.Run("fail: Stripe doesn't serve the PDF", func(t *testing.T) {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer upstream.Close()
+		c, rec := newCtx()
+
+		h := NewDefaultHandler(nil, nil, &BillingServiceMock{}, createTestConfig())
+		require.NoError(t, h.streamInvoicePDF(c, upstream.URL, "F-1001.pdf"))
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+	})
+}
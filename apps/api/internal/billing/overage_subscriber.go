@@ -0,0 +1,65 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/overage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// UserLookup finds the user whose Stripe customer is billed for overage.
+type UserLookup interface {
+	GetByID(ctx context.Context, userID string) (*queries.GetUserByIDRow, error)
+}
+
+// RegisterOverageSubscribers reports each image a subscriber creates past their
+// plan's monthly limit to the Stripe meter, and cancels the report when the
+// image fails to stage. The recorded usage makes the cancellation happen once,
+// however many API instances see the failure.
+func RegisterOverageSubscribers(
+	bus events.Bus, usage UsageService, records overage.Repository, meter overage.Meter,
+	payers BillingUserResolver, users UserLookup, log logging.Logger,
+) {
+	events.On(bus, "overage_report", func(ctx context.Context, e events.ImageCreated) error {
+		payerID, err := payers.GetBillingUserID(ctx, e.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to resolve billing user: %w", err)
+		}
+		stats, err := usage.GetUsage(ctx, payerID)
+		if err != nil {
+			return fmt.Errorf("failed to get usage: %w", err)
+		}
+		// The new image is already counted, so it is within the limit while
+		// the count hasn't passed it.
+		if stats.OverageCap == 0 || stats.ImagesUsed <= stats.MonthlyLimit {
+			return nil
+		}
+		payer, err := users.GetByID(ctx, payerID)
+		if err != nil {
+			return fmt.Errorf("failed to get billing user: %w", err)
+		}
+		if !payer.StripeCustomerID.Valid || payer.StripeCustomerID.String == "" {
+			log.Warn(ctx, "overage image for a user without a Stripe customer",
+				"user_id", payerID, "image_id", e.ImageID)
+			return nil
+		}
+		if err := meter.Report(ctx, payer.StripeCustomerID.String, e.ImageID, e.OccurredAt); err != nil {
+			return fmt.Errorf("failed to report overage: %w", err)
+		}
+		_, err = records.Record(ctx, payerID, e.ImageID, payer.StripeCustomerID.String)
+		return err
+	})
+	events.On(bus, "overage_cancel", func(ctx context.Context, e events.ImageFailed) error {
+		cancelled, err := records.Cancel(ctx, e.ImageID)
+		if err != nil || cancelled == nil {
+			return err
+		}
+		if err := meter.Cancel(ctx, e.ImageID); err != nil {
+			return fmt.Errorf("failed to cancel overage: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,160 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/overage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+type userLookupFunc func(ctx context.Context, userID string) (*queries.GetUserByIDRow, error)
+
+func (f userLookupFunc) GetByID(ctx context.Context, userID string) (*queries.GetUserByIDRow, error) {
+	return f(ctx, userID)
+}
+
+func TestRegisterOverageSubscribers(t *testing.T) {
+	payers := payerFunc(func(ctx context.Context, projectID string) (string, error) {
+		return "payer-1", nil
+	})
+	withCustomer := userLookupFunc(func(ctx context.Context, userID string) (*queries.GetUserByIDRow, error) {
+		return &queries.GetUserByIDRow{StripeCustomerID: pgtype.Text{String: "cus_1", Valid: true}}, nil
+	})
+	occurred := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name         string
+		stats        UsageStats
+		users        UserLookup
+		expectReport bool
+	}{
+		{
+			name:         "success: reports images past the limit",
+			stats:        UsageStats{ImagesUsed: 101, MonthlyLimit: 100, OverageCap: 50, HasSubscription: true},
+			users:        withCustomer,
+			expectReport: true,
+		},
+		{
+			name:  "success: the last image of the limit is not overage",
+			stats: UsageStats{ImagesUsed: 100, MonthlyLimit: 100, OverageCap: 50, HasSubscription: true},
+			users: withCustomer,
+		},
+		{
+			name:  "success: plans without overage are not reported",
+			stats: UsageStats{ImagesUsed: 11, MonthlyLimit: 10, Credits: 5},
+			users: withCustomer,
+		},
+		{
+			name:  "success: users without a Stripe customer are skipped",
+			stats: UsageStats{ImagesUsed: 101, MonthlyLimit: 100, OverageCap: 50, HasSubscription: true},
+			users: userLookupFunc(func(ctx context.Context, userID string) (*queries.GetUserByIDRow, error) {
+				return &queries.GetUserByIDRow{}, nil
+			}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			bus := events.NewDefaultBus(logging.Default())
+			usage := &UsageServiceMock{
+				GetUsageFunc: func(ctx context.Context, userID string) (*UsageStats, error) {
+					stats := tc.stats
+					return &stats, nil
+				},
+			}
+			records := &overage.RepositoryMock{
+				RecordFunc: func(ctx context.Context, userID, imageID, stripeCustomerID string) (bool, error) {
+					return true, nil
+				},
+			}
+			meter := &overage.MeterMock{
+				ReportFunc: func(ctx context.Context, stripeCustomerID, imageID string, at time.Time) error {
+					return nil
+				},
+			}
+			RegisterOverageSubscribers(bus, usage, records, meter, payers, tc.users, logging.Default())
+
+			event := events.ImageCreated{ImageID: "image-1", ProjectID: "project-1", OccurredAt: occurred}
+			if err := bus.Publish(context.Background(), event); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			reports := meter.ReportCalls()
+			if !tc.expectReport {
+				if len(reports) != 0 || len(records.RecordCalls()) != 0 {
+					t.Fatalf("Expected no overage but got %+v", reports)
+				}
+				return
+			}
+			if len(reports) != 1 || reports[0].StripeCustomerID != "cus_1" || reports[0].ImageID != "image-1" ||
+				!reports[0].At.Equal(occurred) {
+				t.Fatalf("Expected one report of image-1 for cus_1 but got %+v", reports)
+			}
+			recorded := records.RecordCalls()
+			if len(recorded) != 1 || recorded[0].UserID != "payer-1" || recorded[0].StripeCustomerID != "cus_1" {
+				t.Fatalf("Expected the report to be recorded but got %+v", recorded)
+			}
+		})
+	}
+
+	t.Run("fail: an unreported image is not recorded", func(t *testing.T) {
+		bus := events.NewDefaultBus(logging.Default())
+		usage := &UsageServiceMock{
+			GetUsageFunc: func(ctx context.Context, userID string) (*UsageStats, error) {
+				return &UsageStats{ImagesUsed: 101, MonthlyLimit: 100, OverageCap: 50, HasSubscription: true}, nil
+			},
+		}
+		records := &overage.RepositoryMock{}
+		meter := &overage.MeterMock{
+			ReportFunc: func(ctx context.Context, stripeCustomerID, imageID string, at time.Time) error {
+				return errors.New("stripe unavailable")
+			},
+		}
+		RegisterOverageSubscribers(bus, usage, records, meter, payers, withCustomer, logging.Default())
+
+		err := bus.Publish(context.Background(), events.ImageCreated{ImageID: "image-1", ProjectID: "project-1"})
+		if err == nil {
+			t.Fatal("Expected the report error")
+		}
+		if len(records.RecordCalls()) != 0 {
+			t.Fatal("Expected no usage to be recorded")
+		}
+	})
+
+	t.Run("success: failed overage images are cancelled once", func(t *testing.T) {
+		bus := events.NewDefaultBus(logging.Default())
+		cancelled := false
+		records := &overage.RepositoryMock{
+			CancelFunc: func(ctx context.Context, imageID string) (*overage.Usage, error) {
+				if cancelled {
+					return nil, nil
+				}
+				cancelled = true
+				return &overage.Usage{ImageID: imageID}, nil
+			},
+		}
+		meter := &overage.MeterMock{
+			CancelFunc: func(ctx context.Context, imageID string) error {
+				return nil
+			},
+		}
+		RegisterOverageSubscribers(bus, &UsageServiceMock{}, records, meter, payers, withCustomer, logging.Default())
+
+		for range 2 {
+			err := bus.Publish(context.Background(), events.ImageFailed{ImageID: "image-1", Error: "timeout"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if calls := meter.CancelCalls(); len(calls) != 1 || calls[0].ImageID != "image-1" {
+			t.Fatalf("Expected one cancellation of image-1 but got %+v", calls)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+	portalsession "github.com/stripe/stripe-go/v81/billingportal/session"
+	checkoutsession "github.com/stripe/stripe-go/v81/checkout/session"
+	"github.com/stripe/stripe-go/v81/customer"
+	"github.com/stripe/stripe-go/v81/invoice"
+	"github.com/stripe/stripe-go/v81/paymentmethod"
+	"github.com/stripe/stripe-go/v81/subscription"
+	"github.com/stripe/stripe-go/v81/testhelpers/testclock"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out stripe_client_mock.go . StripeClient
+
+// StripeClient is the subset of the Stripe API the billing service calls.
+type StripeClient interface {
+	NewCustomer(ctx context.Context, params *stripe.CustomerParams) (*stripe.Customer, error)
+	NewTestClock(ctx context.Context, name string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error)
+	NewCheckoutSession(ctx context.Context, params *stripe.CheckoutSessionParams) (*stripe.CheckoutSession, error)
+	NewPortalSession(
+		ctx context.Context, params *stripe.BillingPortalSessionParams,
+	) (*stripe.BillingPortalSession, error)
+	NewSubscription(ctx context.Context, params *stripe.SubscriptionParams) (*stripe.Subscription, error)
+	GetSubscription(ctx context.Context, id string) (*stripe.Subscription, error)
+	UpdateSubscription(ctx context.Context, id string, params *stripe.SubscriptionParams) (*stripe.Subscription, error)
+	ListPaymentMethods(ctx context.Context, params *stripe.PaymentMethodListParams) ([]*stripe.PaymentMethod, error)
+	GetInvoice(ctx context.Context, id string) (*stripe.Invoice, error)
+}
+
+// DefaultStripeClient calls Stripe with a fixed key. It never reads or sets the
+// package-level stripe.Key, so live and sandbox clients can serve requests
+// side by side.
+type DefaultStripeClient struct {
+	customers      customer.Client
+	clocks         testclock.Client
+	checkouts      checkoutsession.Client
+	portals        portalsession.Client
+	subscriptions  subscription.Client
+	paymentMethods paymentmethod.Client
+	invoices       invoice.Client
+}
+
+// Ensure DefaultStripeClient implements StripeClient.
+var _ StripeClient = (*DefaultStripeClient)(nil)
+
+// NewDefaultStripeClient creates a client for the given Stripe secret key.
+func NewDefaultStripeClient(secretKey string) *DefaultStripeClient {
+	backend := stripe.GetBackend(stripe.APIBackend)
+	return &DefaultStripeClient{
+		customers:      customer.Client{B: backend, Key: secretKey},
+		clocks:         testclock.Client{B: backend, Key: secretKey},
+		checkouts:      checkoutsession.Client{B: backend, Key: secretKey},
+		portals:        portalsession.Client{B: backend, Key: secretKey},
+		subscriptions:  subscription.Client{B: backend, Key: secretKey},
+		paymentMethods: paymentmethod.Client{B: backend, Key: secretKey},
+		invoices:       invoice.Client{B: backend, Key: secretKey},
+	}
+}
+
+// NewCustomer creates a customer.
+func (c *DefaultStripeClient) NewCustomer(
+	ctx context.Context, params *stripe.CustomerParams,
+) (*stripe.Customer, error) {
+	params.Context = ctx
+	return c.customers.New(params)
+}
+
+// NewTestClock creates a test clock frozen at frozenTime. Only test-mode keys
+// can create one.
+func (c *DefaultStripeClient) NewTestClock(
+	ctx context.Context, name string, frozenTime time.Time,
+) (*stripe.TestHelpersTestClock, error) {
+	params := &stripe.TestHelpersTestClockParams{
+		FrozenTime: stripe.Int64(frozenTime.Unix()),
+		Name:       stripe.String(name),
+	}
+	params.Context = ctx
+	return c.clocks.New(params)
+}
+
+// NewCheckoutSession creates a Checkout session.
+func (c *DefaultStripeClient) NewCheckoutSession(
+	ctx context.Context, params *stripe.CheckoutSessionParams,
+) (*stripe.CheckoutSession, error) {
+	params.Context = ctx
+	return c.checkouts.New(params)
+}
+
+// NewPortalSession creates a Customer Portal session.
+func (c *DefaultStripeClient) NewPortalSession(
+	ctx context.Context, params *stripe.BillingPortalSessionParams,
+) (*stripe.BillingPortalSession, error) {
+	params.Context = ctx
+	return c.portals.New(params)
+}
+
+// NewSubscription creates a subscription.
+func (c *DefaultStripeClient) NewSubscription(
+	ctx context.Context, params *stripe.SubscriptionParams,
+) (*stripe.Subscription, error) {
+	params.Context = ctx
+	return c.subscriptions.New(params)
+}
+
+// GetSubscription retrieves a subscription.
+func (c *DefaultStripeClient) GetSubscription(ctx context.Context, id string) (*stripe.Subscription, error) {
+	params := &stripe.SubscriptionParams{}
+	params.Context = ctx
+	return c.subscriptions.Get(id, params)
+}
+
+// UpdateSubscription updates a subscription.
+func (c *DefaultStripeClient) UpdateSubscription(
+	ctx context.Context, id string, params *stripe.SubscriptionParams,
+) (*stripe.Subscription, error) {
+	params.Context = ctx
+	return c.subscriptions.Update(id, params)
+}
+
+// ListPaymentMethods returns every payment method matching params, following
+// Stripe's pagination.
+func (c *DefaultStripeClient) ListPaymentMethods(
+	ctx context.Context, params *stripe.PaymentMethodListParams,
+) ([]*stripe.PaymentMethod, error) {
+	params.Context = ctx
+	var methods []*stripe.PaymentMethod
+	iter := c.paymentMethods.List(params)
+	for iter.Next() {
+		methods = append(methods, iter.PaymentMethod())
+	}
+	return methods, iter.Err()
+}
+
+// GetInvoice retrieves an invoice.
+func (c *DefaultStripeClient) GetInvoice(ctx context.Context, id string) (*stripe.Invoice, error) {
+	params := &stripe.InvoiceParams{}
+	params.Context = ctx
+	return c.invoices.Get(id, params)
+}
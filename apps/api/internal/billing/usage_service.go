@@ -2,6 +2,9 @@ package billing
 
 import "context"
 
+// SandboxPlanCode is the plan code reported for sandbox accounts.
+const SandboxPlanCode = "sandbox"
+
 //go:generate go run github.com/matryer/moq@v0.5.3 -out usage_service_mock.go . UsageService
 
 // UsageService provides methods to check and enforce usage limits.
@@ -11,9 +14,20 @@ type UsageService interface {
 	GetUsage(ctx context.Context, userID string) (*UsageStats, error)
 
 	// CanCreateImage checks if a user can create a new image based on their plan limits.
-	// Returns true if user is under their limit, false otherwise.
+	// Returns true if user is under their limit, including any overage their
+	// plan allows, or, without a subscription, has credits left; false otherwise.
 	CanCreateImage(ctx context.Context, userID string) (bool, error)
 
+	// RemainingImages returns how many more images the user may create in the
+	// current billing period, counting overage for subscribers and credits for
+	// users without a subscription.
+	// Used to precheck bulk operations before any work is queued.
+	RemainingImages(ctx context.Context, userID string) (int32, error)
+
+	// PlanCode returns the code of the user's current plan (free, pro,
+	// business, sandbox) without counting their usage.
+	PlanCode(ctx context.Context, userID string) (string, error)
+
 	// GetPlanByCode returns plan details by plan code (free, pro, business).
 	GetPlanByCode(ctx context.Context, code string) (*PlanInfo, error)
 }
@@ -22,11 +36,18 @@ type UsageService interface {
 type UsageStats struct {
 	ImagesUsed      int32  `json:"images_used"`      // Number of images created in current period
 	MonthlyLimit    int32  `json:"monthly_limit"`    // Monthly limit for the plan
-	PlanCode        string `json:"plan_code"`        // Plan code (free, pro, business)
+	PlanCode        string `json:"plan_code"`        // Plan code (free, pro, business, sandbox)
 	PeriodStart     string `json:"period_start"`     // ISO 8601 date of period start
 	PeriodEnd       string `json:"period_end"`       // ISO 8601 date of period end
 	HasSubscription bool   `json:"has_subscription"` // Whether user has active subscription
-	RemainingImages int32  `json:"remaining_images"` // Remaining images in current period
+	RemainingImages int32  `json:"remaining_images"` // Remaining images in current period, overage and credits included
+	Credits         int32  `json:"credits"`          // Image credits; only spent without a subscription
+	OverageCap      int32  `json:"overage_cap"`      // Images allowed past the monthly limit, billed as overage
+	OverageImages   int32  `json:"overage_images"`   // Images past the monthly limit so far this period
+	// PaymentPastDue reports that a renewal payment failed. The plan is kept
+	// until GracePeriodEndsAt (ISO 8601) while Stripe retries the charge.
+	PaymentPastDue    bool   `json:"payment_past_due"`
+	GracePeriodEndsAt string `json:"grace_period_ends_at,omitempty"`
 }
 
 // PlanInfo represents details about a subscription plan.
@@ -27,6 +27,12 @@ var _ UsageService = &UsageServiceMock{}
 //			GetUsageFunc: func(ctx context.Context, userID string) (*UsageStats, error) {
 //				panic("mock out the GetUsage method")
 //			},
+//			PlanCodeFunc: func(ctx context.Context, userID string) (string, error) {
+//				panic("mock out the PlanCode method")
+//			},
+//			RemainingImagesFunc: func(ctx context.Context, userID string) (int32, error) {
+//				panic("mock out the RemainingImages method")
+//			},
 //		}
 //
 //		// use mockedUsageService in code that requires UsageService
@@ -43,6 +49,12 @@ type UsageServiceMock struct {
 	// GetUsageFunc mocks the GetUsage method.
 	GetUsageFunc func(ctx context.Context, userID string) (*UsageStats, error)
 
+	// PlanCodeFunc mocks the PlanCode method.
+	PlanCodeFunc func(ctx context.Context, userID string) (string, error)
+
+	// RemainingImagesFunc mocks the RemainingImages method.
+	RemainingImagesFunc func(ctx context.Context, userID string) (int32, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CanCreateImage holds details about calls to the CanCreateImage method.
@@ -66,10 +78,26 @@ type UsageServiceMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// PlanCode holds details about calls to the PlanCode method.
+		PlanCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// RemainingImages holds details about calls to the RemainingImages method.
+		RemainingImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
 	}
-	lockCanCreateImage sync.RWMutex
-	lockGetPlanByCode  sync.RWMutex
-	lockGetUsage       sync.RWMutex
+	lockCanCreateImage  sync.RWMutex
+	lockGetPlanByCode   sync.RWMutex
+	lockGetUsage        sync.RWMutex
+	lockPlanCode        sync.RWMutex
+	lockRemainingImages sync.RWMutex
 }
 
 // CanCreateImage calls CanCreateImageFunc.
@@ -179,3 +207,75 @@ func (mock *UsageServiceMock) GetUsageCalls() []struct {
 	mock.lockGetUsage.RUnlock()
 	return calls
 }
+
+// PlanCode calls PlanCodeFunc.
+func (mock *UsageServiceMock) PlanCode(ctx context.Context, userID string) (string, error) {
+	if mock.PlanCodeFunc == nil {
+		panic("UsageServiceMock.PlanCodeFunc: method is nil but UsageService.PlanCode was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockPlanCode.Lock()
+	mock.calls.PlanCode = append(mock.calls.PlanCode, callInfo)
+	mock.lockPlanCode.Unlock()
+	return mock.PlanCodeFunc(ctx, userID)
+}
+
+// PlanCodeCalls gets all the calls that were made to PlanCode.
+// Check the length with:
+//
+//	len(mockedUsageService.PlanCodeCalls())
+func (mock *UsageServiceMock) PlanCodeCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockPlanCode.RLock()
+	calls = mock.calls.PlanCode
+	mock.lockPlanCode.RUnlock()
+	return calls
+}
+
+// RemainingImages calls RemainingImagesFunc.
+func (mock *UsageServiceMock) RemainingImages(ctx context.Context, userID string) (int32, error) {
+	if mock.RemainingImagesFunc == nil {
+		panic("UsageServiceMock.RemainingImagesFunc: method is nil but UsageService.RemainingImages was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRemainingImages.Lock()
+	mock.calls.RemainingImages = append(mock.calls.RemainingImages, callInfo)
+	mock.lockRemainingImages.Unlock()
+	return mock.RemainingImagesFunc(ctx, userID)
+}
+
+// RemainingImagesCalls gets all the calls that were made to RemainingImages.
+// Check the length with:
+//
+//	len(mockedUsageService.RemainingImagesCalls())
+func (mock *UsageServiceMock) RemainingImagesCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockRemainingImages.RLock()
+	calls = mock.calls.RemainingImages
+	mock.lockRemainingImages.RUnlock()
+	return calls
+}
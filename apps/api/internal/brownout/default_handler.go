@@ -0,0 +1,35 @@
+package brownout
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the provider status endpoint.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// GetStatus handles GET /api/v1/status - Reports whether a provider outage is
+// delaying staging, with the banner to show. It needs no authentication.
+func (h *DefaultHandler) GetStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+	status, err := h.service.Status(ctx)
+	if err != nil {
+		h.log.Error(ctx, "failed to get provider status", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get status")
+	}
+	return c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,61 @@
+package brownout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_GetStatus(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      *Status
+		serviceErr  error
+		wantStatus  int
+		wantContain string
+	}{
+		{
+			name:        "success: incident",
+			status:      &Status{Incident: true, Banner: "Delayed."},
+			wantStatus:  http.StatusOK,
+			wantContain: `"banner":"Delayed."`,
+		},
+		{name: "success: no incident", status: &Status{}, wantStatus: http.StatusOK, wantContain: `"incident":false`},
+		{name: "fail: service error", serviceErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				StatusFunc: func(ctx context.Context) (*Status, error) {
+					return tc.status, tc.serviceErr
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := h.GetStatus(c)
+			if tc.wantStatus != http.StatusOK {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, tc.wantStatus, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.wantContain)
+		})
+	}
+}
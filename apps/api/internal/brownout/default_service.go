@@ -0,0 +1,129 @@
+package brownout
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/i18n"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/settings"
+)
+
+// cacheTTL is how long the incident flag is reused before it is read again,
+// so image creation doesn't add two settings reads to every request.
+const cacheTTL = 15 * time.Second
+
+// DefaultService implements Service over the settings table.
+type DefaultService struct {
+	repo settings.Repository
+	log  logging.Logger
+	now  func() time.Time
+
+	mu        sync.Mutex
+	fetchedAt time.Time
+	incident  settings.Incident
+	active    string
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo settings.Repository, log logging.Logger) *DefaultService {
+	return &DefaultService{repo: repo, log: log, now: time.Now}
+}
+
+// Status returns the incident flag, with the admin's message or a default
+// banner during an incident.
+func (s *DefaultService) Status(ctx context.Context) (*Status, error) {
+	incident, _, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !incident.Active {
+		return &Status{}, nil
+	}
+
+	status := &Status{
+		Incident:        true,
+		Models:          incident.Models,
+		Since:           incident.Since,
+		EarliestStartAt: s.future(incident.RetryAt),
+		Banner:          incident.Message,
+	}
+	if status.Banner == "" {
+		status.Banner = i18n.T(ctx,
+			"Our AI provider is having an outage. New images are accepted and will be staged automatically once it recovers.")
+	}
+	return status, nil
+}
+
+// DelayFor reports a delay when an incident affects modelID. A flag that can't
+// be read is logged and treated as no incident, so a settings outage doesn't
+// mislabel every image.
+func (s *DefaultService) DelayFor(ctx context.Context, modelID *string) *Delay {
+	incident, active, err := s.load(ctx)
+	if err != nil {
+		s.log.Warn(ctx, "failed to read provider incident", "error", err)
+		return nil
+	}
+	if !incident.Active {
+		return nil
+	}
+
+	if len(incident.Models) > 0 {
+		model := active
+		if modelID != nil && *modelID != "" {
+			model = *modelID
+		}
+		if !slices.Contains(incident.Models, model) {
+			return nil
+		}
+	}
+	return &Delay{
+		Reason:          ReasonProviderIncident,
+		Since:           incident.Since,
+		EarliestStartAt: s.future(incident.RetryAt),
+	}
+}
+
+// load returns the incident flag and the active model, reading them again
+// once the cached copy is older than cacheTTL.
+func (s *DefaultService) load(ctx context.Context) (settings.Incident, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.fetchedAt.IsZero() && s.now().Sub(s.fetchedAt) < cacheTTL {
+		return s.incident, s.active, nil
+	}
+
+	setting, err := s.repo.GetByKey(ctx, settings.KeyProviderIncident)
+	if err != nil {
+		return settings.Incident{}, "", err
+	}
+	incident, err := settings.ParseIncident(setting.Value)
+	if err != nil {
+		return settings.Incident{}, "", err
+	}
+	var active string
+	if incident.Active && len(incident.Models) > 0 {
+		activeSetting, err := s.repo.GetByKey(ctx, "active_model")
+		if err != nil {
+			return settings.Incident{}, "", err
+		}
+		active = activeSetting.Value
+	}
+
+	s.incident, s.active, s.fetchedAt = incident, active, s.now()
+	return incident, active, nil
+}
+
+// future returns t when it is still ahead, and nil otherwise.
+func (s *DefaultService) future(t *time.Time) *time.Time {
+	if t == nil || !t.After(s.now()) {
+		return nil
+	}
+	return t
+}
@@ -0,0 +1,142 @@
+package brownout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/settings"
+)
+
+func newTestService(values map[string]string, err error) (*DefaultService, *settings.RepositoryMock, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &settings.RepositoryMock{
+		GetByKeyFunc: func(ctx context.Context, key string) (*settings.Setting, error) {
+			if err != nil {
+				return nil, err
+			}
+			return &settings.Setting{Key: key, Value: values[key]}, nil
+		},
+	}
+	s := NewDefaultService(repo, logging.Default())
+	s.now = func() time.Time { return now }
+	return s, repo, &now
+}
+
+func TestDefaultService_DelayFor(t *testing.T) {
+	ctx := context.Background()
+	flux := "black-forest-labs/flux-kontext-max"
+	qwen := "qwen/qwen-image-edit"
+
+	cases := []struct {
+		name      string
+		incident  string
+		modelID   *string
+		wantDelay bool
+	}{
+		{name: "success: no incident", incident: `{"active":false}`, modelID: &flux},
+		{name: "success: incident for every model", incident: `{"active":true}`, modelID: &flux, wantDelay: true},
+		{name: "success: incident for the model", incident: `{"active":true,"models":["` + flux + `"]}`,
+			modelID: &flux, wantDelay: true},
+		{name: "success: incident for another model", incident: `{"active":true,"models":["` + qwen + `"]}`,
+			modelID: &flux},
+		{name: "success: active model affected", incident: `{"active":true,"models":["` + qwen + `"]}`,
+			wantDelay: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, _, _ := newTestService(map[string]string{
+				settings.KeyProviderIncident: tc.incident,
+				"active_model":               qwen,
+			}, nil)
+
+			delay := s.DelayFor(ctx, tc.modelID)
+
+			if !tc.wantDelay {
+				assert.Nil(t, delay)
+				return
+			}
+			require.NotNil(t, delay)
+			assert.Equal(t, ReasonProviderIncident, delay.Reason)
+		})
+	}
+
+	t.Run("success: estimated start only while ahead", func(t *testing.T) {
+		s, _, now := newTestService(map[string]string{
+			settings.KeyProviderIncident: `{"active":true,"since":"2026-01-01T11:50:00Z","retry_at":"2026-01-01T12:05:00Z"}`,
+		}, nil)
+
+		delay := s.DelayFor(ctx, nil)
+		require.NotNil(t, delay)
+		require.NotNil(t, delay.EarliestStartAt)
+		assert.Equal(t, now.Add(5*time.Minute), *delay.EarliestStartAt)
+		assert.Equal(t, now.Add(-10*time.Minute), *delay.Since)
+
+		*now = now.Add(10 * time.Minute)
+		delay = s.DelayFor(ctx, nil)
+		require.NotNil(t, delay)
+		assert.Nil(t, delay.EarliestStartAt)
+	})
+
+	t.Run("success: read errors are no incident", func(t *testing.T) {
+		s, _, _ := newTestService(nil, errors.New("db down"))
+		assert.Nil(t, s.DelayFor(ctx, &flux))
+	})
+
+	t.Run("success: the flag is cached", func(t *testing.T) {
+		s, repo, now := newTestService(map[string]string{settings.KeyProviderIncident: `{"active":true}`}, nil)
+
+		s.DelayFor(ctx, nil)
+		s.DelayFor(ctx, nil)
+		assert.Len(t, repo.GetByKeyCalls(), 1)
+
+		*now = now.Add(cacheTTL)
+		s.DelayFor(ctx, nil)
+		assert.Len(t, repo.GetByKeyCalls(), 2)
+	})
+}
+
+func TestDefaultService_Status(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: no incident", func(t *testing.T) {
+		s, _, _ := newTestService(map[string]string{settings.KeyProviderIncident: `{"active":false}`}, nil)
+
+		status, err := s.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &Status{}, status)
+	})
+
+	t.Run("success: default banner", func(t *testing.T) {
+		s, _, _ := newTestService(map[string]string{settings.KeyProviderIncident: `{"active":true,"models":["m"]}`}, nil)
+
+		status, err := s.Status(ctx)
+		require.NoError(t, err)
+		assert.True(t, status.Incident)
+		assert.Equal(t, []string{"m"}, status.Models)
+		assert.Contains(t, status.Banner, "outage")
+	})
+
+	t.Run("success: admin message", func(t *testing.T) {
+		s, _, _ := newTestService(map[string]string{
+			settings.KeyProviderIncident: `{"active":true,"source":"admin","message":"Scheduled maintenance."}`,
+		}, nil)
+
+		status, err := s.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Scheduled maintenance.", status.Banner)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		s, _, _ := newTestService(nil, errors.New("db down"))
+
+		_, err := s.Status(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,10 @@
+package brownout
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the provider status endpoint.
+type Handler interface {
+	GetStatus(c echo.Context) error
+}
@@ -0,0 +1,34 @@
+// Package brownout tells users when staging is held up by a model provider
+// outage.
+//
+// Workers raise the provider_incident setting when a model's circuit breaker
+// opens and clear it once a probe run succeeds; admins can also set it by hand.
+// This package turns the flag into the public status banner and into the
+// "accepted, delayed" state new images are created in meanwhile.
+package brownout
+
+import "time"
+
+// ReasonProviderIncident is the Delay reason while a model provider is down.
+const ReasonProviderIncident = "provider_incident"
+
+// Delay explains why a new image won't start staging right away. The image
+// is still queued and stages on its own once the provider recovers.
+type Delay struct {
+	Reason string     `json:"reason"`
+	Since  *time.Time `json:"since,omitempty"`
+	// EarliestStartAt is when the provider is next tried, so no sooner than
+	// when the image can start. It is omitted while a retry is under way.
+	EarliestStartAt *time.Time `json:"earliest_start_at,omitempty"`
+}
+
+// Status is the public provider status.
+type Status struct {
+	Incident bool `json:"incident"`
+	// Models are the affected models; empty during an incident means all.
+	Models          []string   `json:"models,omitempty"`
+	Since           *time.Time `json:"since,omitempty"`
+	EarliestStartAt *time.Time `json:"earliest_start_at,omitempty"`
+	// Banner is the text to show users during an incident.
+	Banner string `json:"banner,omitempty"`
+}
@@ -0,0 +1,15 @@
+package brownout
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service reports provider incidents.
+type Service interface {
+	// Status returns the current provider status.
+	Status(ctx context.Context) (*Status, error)
+
+	// DelayFor returns why an image staged with modelID would be delayed, or nil
+	// when it wouldn't. A nil modelID means the active model.
+	DelayFor(ctx context.Context, modelID *string) *Delay
+}
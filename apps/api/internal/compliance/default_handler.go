@@ -0,0 +1,129 @@
+package compliance
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/pagination"
+)
+
+// DefaultHandler serves the admin review queue endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ListReviews handles GET /admin/compliance/reviews - Lists the review queue.
+// Supports status, decision, limit and offset query parameters.
+func (h *DefaultHandler) ListReviews(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter := ListFilter{
+		Status:   ReviewStatus(c.QueryParam("status")),
+		Decision: Decision(c.QueryParam("decision")),
+	}
+	switch filter.Status {
+	case "", ReviewPending, ReviewCleared, ReviewUpheld:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "status must be one of: pending, cleared, upheld")
+	}
+	switch filter.Decision {
+	case "", DecisionRejected, DecisionFlagged:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "decision must be one of: rejected, flagged")
+	}
+
+	var err error
+	if filter.Limit, err = pagination.IntParam(c, "limit"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "limit must be an integer")
+	}
+	if filter.Offset, err = pagination.IntParam(c, "offset"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "offset must be an integer")
+	}
+
+	reviews, err := h.service.ListReviews(ctx, filter)
+	if err != nil {
+		h.log.Error(ctx, "failed to list prompt reviews", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list prompt reviews")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"reviews": reviews,
+	})
+}
+
+// GetReview handles GET /admin/compliance/reviews/:id - Gets a single review.
+func (h *DefaultHandler) GetReview(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	review, err := h.service.GetReview(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrReviewNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Prompt review not found")
+		}
+		h.log.Error(ctx, "failed to get prompt review", "error", err, "review_id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get prompt review")
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+// ResolveReview handles PATCH /admin/compliance/reviews/:id - Clears or upholds a review.
+func (h *DefaultHandler) ResolveReview(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	var req ResolveRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	reviewer, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	review, err := h.service.ResolveReview(ctx, id, reviewer, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidReview):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrReviewNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "Prompt review not found")
+		}
+		h.log.Error(ctx, "failed to resolve prompt review", "error", err, "review_id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve prompt review")
+	}
+
+	h.log.Info(ctx, "prompt review resolved", "review_id", id, "status", review.Status, "reviewed_by", reviewer)
+	return c.JSON(http.StatusOK, review)
+}
+
+// Scan handles POST /admin/compliance/scan - Dry-runs the scanner on a piece of text.
+// Nothing is recorded; use it to check how term-list changes behave.
+func (h *DefaultHandler) Scan(c echo.Context) error {
+	var req ScanRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	result := h.service.Screen(c.Request().Context(), req.Text)
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"rejected":   result.Rejected(),
+		"flagged":    result.Flagged(),
+		"violations": result.Violations,
+	})
+}
@@ -0,0 +1,137 @@
+package compliance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_ListReviews(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		listErr    error
+		wantStatus int
+		wantFilter ListFilter
+	}{
+		{
+			name:       "success: passes filters",
+			query:      "?status=pending&decision=flagged&limit=5&offset=10",
+			wantStatus: http.StatusOK,
+			wantFilter: ListFilter{Status: ReviewPending, Decision: DecisionFlagged, Limit: 5, Offset: 10},
+		},
+		{name: "fail: invalid status", query: "?status=open", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid decision", query: "?decision=maybe", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid offset", query: "?offset=abc", wantStatus: http.StatusBadRequest},
+		{name: "fail: service error", listErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListReviewsFunc: func(ctx context.Context, filter ListFilter) ([]Review, error) {
+					assert.Equal(t, tc.wantFilter, filter)
+					return []Review{}, tc.listErr
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/compliance/reviews"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			err := h.ListReviews(e.NewContext(req, rec))
+
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"reviews":[]`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_ResolveReview(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		resolveErr error
+		wantStatus int
+	}{
+		{name: "success: clears a review", body: `{"status":"cleared"}`, wantStatus: http.StatusOK},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name: "fail: invalid status", body: `{"status":"pending"}`,
+			resolveErr: ErrInvalidReview, wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fail: not found", body: `{"status":"upheld"}`,
+			resolveErr: ErrReviewNotFound, wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ResolveReviewFunc: func(ctx context.Context, id, reviewedBy string, req ResolveRequest) (*Review, error) {
+					assert.Equal(t, "review-1", id)
+					assert.Equal(t, "auth0|admin", reviewedBy)
+					if tc.resolveErr != nil {
+						return nil, tc.resolveErr
+					}
+					return &Review{ID: id, Status: req.Status}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/compliance/reviews/review-1",
+				strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|admin")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("review-1")
+
+			err := h.ResolveReview(c)
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"status":"cleared"`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_Scan(t *testing.T) {
+	h := NewDefaultHandler(NewDefaultService(NewScanner(config.Compliance{}), &RepositoryMock{}), logging.Default())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/compliance/scan",
+		strings.NewReader(`{"text":"Bright kitchen, adults only"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.Scan(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"rejected":true`)
+	assert.Contains(t, rec.Body.String(), `"rule":"children_exclusion"`)
+}
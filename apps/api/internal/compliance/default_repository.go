@@ -0,0 +1,129 @@
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const reviewColumns = `
+	id, user_id, project_id, image_id, prompt, decision, violations, status,
+	reviewed_by, review_note, created_at, reviewed_at`
+
+func scanReview(row pgx.Row) (*Review, error) {
+	var r Review
+	var violations []byte
+	err := row.Scan(
+		&r.ID, &r.UserID, &r.ProjectID, &r.ImageID, &r.Prompt, &r.Decision, &violations, &r.Status,
+		&r.ReviewedBy, &r.ReviewNote, &r.CreatedAt, &r.ReviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(violations, &r.Violations); err != nil {
+		return nil, fmt.Errorf("failed to decode violations: %w", err)
+	}
+	return &r, nil
+}
+
+// Create inserts a review.
+func (r *DefaultRepository) Create(ctx context.Context, review *Review) (*Review, error) {
+	violations, err := json.Marshal(review.Violations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode violations: %w", err)
+	}
+
+	query := `
+		INSERT INTO prompt_reviews (user_id, project_id, image_id, prompt, decision, violations)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING` + reviewColumns
+
+	created, err := scanReview(r.db.QueryRow(ctx, query,
+		review.UserID, review.ProjectID, review.ImageID, review.Prompt, string(review.Decision), violations,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prompt review: %w", err)
+	}
+	return created, nil
+}
+
+// GetByID retrieves a review.
+func (r *DefaultRepository) GetByID(ctx context.Context, id string) (*Review, error) {
+	query := `SELECT` + reviewColumns + ` FROM prompt_reviews WHERE id = $1`
+
+	review, err := scanReview(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to get prompt review: %w", err)
+	}
+	return review, nil
+}
+
+// List returns reviews matching the filter, oldest first.
+func (r *DefaultRepository) List(ctx context.Context, filter ListFilter) ([]Review, error) {
+	query := `SELECT` + reviewColumns + `
+		FROM prompt_reviews
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR decision = $2)
+		ORDER BY created_at ASC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(ctx, query, string(filter.Status), string(filter.Decision), filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prompt review: %w", err)
+		}
+		reviews = append(reviews, *review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt review rows: %w", err)
+	}
+	return reviews, nil
+}
+
+// Resolve records an admin's decision on a review.
+func (r *DefaultRepository) Resolve(
+	ctx context.Context, id string, status ReviewStatus, reviewedBy string, note *string,
+) (*Review, error) {
+	query := `
+		UPDATE prompt_reviews
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = now()
+		WHERE id = $1
+		RETURNING` + reviewColumns
+
+	review, err := scanReview(r.db.QueryRow(ctx, query, id, string(status), reviewedBy, note))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to resolve prompt review: %w", err)
+	}
+	return review, nil
+}
@@ -0,0 +1,61 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultService implements Service with a Scanner and the prompt_reviews table.
+type DefaultService struct {
+	scanner *Scanner
+	repo    Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(scanner *Scanner, repo Repository) *DefaultService {
+	return &DefaultService{scanner: scanner, repo: repo}
+}
+
+// Screen scans prompt text against the fair-housing rules.
+func (s *DefaultService) Screen(_ context.Context, text string) Result {
+	return s.scanner.Scan(text)
+}
+
+// Record queues a screened prompt for admin review.
+func (s *DefaultService) Record(ctx context.Context, review *Review) (*Review, error) {
+	if review == nil || len(review.Violations) == 0 {
+		return nil, fmt.Errorf("%w: a review needs at least one violation", ErrInvalidReview)
+	}
+	return s.repo.Create(ctx, review)
+}
+
+// ListReviews returns reviews matching the filter, oldest first.
+func (s *DefaultService) ListReviews(ctx context.Context, filter ListFilter) ([]Review, error) {
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// GetReview retrieves a single review.
+func (s *DefaultService) GetReview(ctx context.Context, id string) (*Review, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ResolveReview clears or upholds a review on behalf of an admin.
+func (s *DefaultService) ResolveReview(
+	ctx context.Context, id, reviewedBy string, req ResolveRequest,
+) (*Review, error) {
+	switch req.Status {
+	case ReviewCleared, ReviewUpheld:
+	default:
+		return nil, fmt.Errorf("%w: status must be one of: cleared, upheld", ErrInvalidReview)
+	}
+	return s.repo.Resolve(ctx, id, req.Status, reviewedBy, req.Note)
+}
@@ -0,0 +1,92 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestDefaultService_Record(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: stores the review", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, r *Review) (*Review, error) {
+				created := *r
+				created.ID = "review-1"
+				created.Status = ReviewPending
+				return &created, nil
+			},
+		}
+		svc := NewDefaultService(NewScanner(config.Compliance{}), repo)
+
+		review, err := svc.Record(ctx, &Review{
+			Prompt:     "adults only",
+			Decision:   DecisionRejected,
+			Violations: []Violation{{Rule: "children_exclusion", Action: ActionReject}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "review-1", review.ID)
+		assert.Len(t, repo.CreateCalls(), 1)
+	})
+
+	t.Run("fail: rejects a review without violations", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		svc := NewDefaultService(NewScanner(config.Compliance{}), repo)
+
+		_, err := svc.Record(ctx, &Review{Prompt: "clean"})
+		assert.ErrorIs(t, err, ErrInvalidReview)
+		assert.Empty(t, repo.CreateCalls())
+	})
+}
+
+func TestDefaultService_ListReviews(t *testing.T) {
+	repo := &RepositoryMock{
+		ListFunc: func(ctx context.Context, filter ListFilter) ([]Review, error) {
+			assert.Equal(t, 50, filter.Limit)
+			assert.Equal(t, 0, filter.Offset)
+			return []Review{}, nil
+		},
+	}
+	svc := NewDefaultService(NewScanner(config.Compliance{}), repo)
+
+	_, err := svc.ListReviews(context.Background(), ListFilter{Limit: 1000, Offset: -1})
+	require.NoError(t, err)
+}
+
+func TestDefaultService_ResolveReview(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: upholds a review", func(t *testing.T) {
+		note := "steering language"
+		repo := &RepositoryMock{
+			ResolveFunc: func(
+				ctx context.Context, id string, status ReviewStatus, reviewedBy string, n *string,
+			) (*Review, error) {
+				assert.Equal(t, "review-1", id)
+				assert.Equal(t, ReviewUpheld, status)
+				assert.Equal(t, "auth0|admin", reviewedBy)
+				assert.Equal(t, &note, n)
+				return &Review{ID: id, Status: status}, nil
+			},
+		}
+		svc := NewDefaultService(NewScanner(config.Compliance{}), repo)
+
+		review, err := svc.ResolveReview(ctx, "review-1", "auth0|admin", ResolveRequest{Status: ReviewUpheld, Note: &note})
+		require.NoError(t, err)
+		assert.Equal(t, ReviewUpheld, review.Status)
+	})
+
+	t.Run("fail: pending is not a resolution", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		svc := NewDefaultService(NewScanner(config.Compliance{}), repo)
+
+		_, err := svc.ResolveReview(ctx, "review-1", "auth0|admin", ResolveRequest{Status: ReviewPending})
+		assert.ErrorIs(t, err, ErrInvalidReview)
+		assert.Empty(t, repo.ResolveCalls())
+	})
+}
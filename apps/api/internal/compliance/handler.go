@@ -0,0 +1,20 @@
+package compliance
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin HTTP endpoints for the fair-housing review queue.
+type Handler interface {
+	// ListReviews handles GET /admin/compliance/reviews - Lists the review queue.
+	ListReviews(c echo.Context) error
+
+	// GetReview handles GET /admin/compliance/reviews/:id - Gets a single review.
+	GetReview(c echo.Context) error
+
+	// ResolveReview handles PATCH /admin/compliance/reviews/:id - Clears or upholds a review.
+	ResolveReview(c echo.Context) error
+
+	// Scan handles POST /admin/compliance/scan - Dry-runs the scanner on a piece of text.
+	Scan(c echo.Context) error
+}
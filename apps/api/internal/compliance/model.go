@@ -0,0 +1,132 @@
+// Package compliance screens user-supplied staging prompts for fair-housing
+// violations: language that states a preference for, limits, or steers
+// toward buyers and renters by a protected class.
+//
+// Explicit exclusions are rejected before an image is created. Softer
+// steering language is staged but queued for admin review. Both outcomes are
+// recorded in prompt_reviews so false positives can be cleared and the term
+// lists tuned.
+package compliance
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrReviewNotFound is returned when a review does not exist.
+	ErrReviewNotFound = errors.New("prompt review not found")
+	// ErrInvalidReview is returned when a resolution has an unsupported status.
+	ErrInvalidReview = errors.New("invalid prompt review")
+)
+
+// ErrorCode is the error code returned to clients whose prompt is rejected.
+const ErrorCode = "fair_housing_violation"
+
+// Action is what happens to a prompt that matches a rule.
+type Action string
+
+const (
+	// ActionReject blocks the request.
+	ActionReject Action = "reject"
+	// ActionFlag lets the request through and queues it for review.
+	ActionFlag Action = "flag"
+)
+
+// Category is the fair-housing protected class a rule guards.
+type Category string
+
+const (
+	CategoryRace           Category = "race_color"
+	CategoryReligion       Category = "religion"
+	CategoryNationalOrigin Category = "national_origin"
+	CategorySex            Category = "sex"
+	CategoryFamilialStatus Category = "familial_status"
+	CategoryDisability     Category = "disability"
+	// CategoryCustom covers terms added through configuration.
+	CategoryCustom Category = "custom"
+)
+
+// Violation is one rule match in a prompt.
+type Violation struct {
+	Rule     string   `json:"rule"`
+	Category Category `json:"category"`
+	Action   Action   `json:"action"`
+	Match    string   `json:"match"`
+	Message  string   `json:"message"`
+}
+
+// Result is the outcome of scanning a prompt.
+type Result struct {
+	Violations []Violation `json:"violations"`
+}
+
+// Rejected reports whether any violation blocks the request.
+func (r Result) Rejected() bool {
+	for _, v := range r.Violations {
+		if v.Action == ActionReject {
+			return true
+		}
+	}
+	return false
+}
+
+// Flagged reports whether the prompt matched any rule.
+func (r Result) Flagged() bool {
+	return len(r.Violations) > 0
+}
+
+// Decision records what happened to a screened prompt.
+type Decision string
+
+const (
+	DecisionRejected Decision = "rejected"
+	DecisionFlagged  Decision = "flagged"
+)
+
+// ReviewStatus is where a review sits in the admin queue.
+type ReviewStatus string
+
+const (
+	// ReviewPending is awaiting an admin.
+	ReviewPending ReviewStatus = "pending"
+	// ReviewCleared marks a false positive.
+	ReviewCleared ReviewStatus = "cleared"
+	// ReviewUpheld confirms the violation.
+	ReviewUpheld ReviewStatus = "upheld"
+)
+
+// Review is a screened prompt in the admin review queue.
+type Review struct {
+	ID         string       `json:"id"`
+	UserID     *string      `json:"user_id,omitempty"`
+	ProjectID  *string      `json:"project_id,omitempty"`
+	ImageID    *string      `json:"image_id,omitempty"`
+	Prompt     string       `json:"prompt"`
+	Decision   Decision     `json:"decision"`
+	Violations []Violation  `json:"violations"`
+	Status     ReviewStatus `json:"status"`
+	ReviewedBy *string      `json:"reviewed_by,omitempty"`
+	ReviewNote *string      `json:"review_note,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ReviewedAt *time.Time   `json:"reviewed_at,omitempty"`
+}
+
+// ListFilter narrows the review queue.
+type ListFilter struct {
+	Status   ReviewStatus
+	Decision Decision
+	Limit    int
+	Offset   int
+}
+
+// ScanRequest asks for a dry-run scan of a prompt.
+type ScanRequest struct {
+	Text string `json:"text"`
+}
+
+// ResolveRequest closes a review.
+type ResolveRequest struct {
+	Status ReviewStatus `json:"status"`
+	Note   *string      `json:"note,omitempty"`
+}
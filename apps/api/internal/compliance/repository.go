@@ -0,0 +1,20 @@
+package compliance
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for the prompt review queue.
+type Repository interface {
+	// Create inserts a review.
+	Create(ctx context.Context, r *Review) (*Review, error)
+
+	// GetByID retrieves a review.
+	GetByID(ctx context.Context, id string) (*Review, error)
+
+	// List returns reviews matching the filter, oldest first.
+	List(ctx context.Context, filter ListFilter) ([]Review, error)
+
+	// Resolve records an admin's decision on a review.
+	Resolve(ctx context.Context, id string, status ReviewStatus, reviewedBy string, note *string) (*Review, error)
+}
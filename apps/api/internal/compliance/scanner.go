@@ -0,0 +1,160 @@
+package compliance
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+// Rule matches one kind of fair-housing violation.
+type Rule struct {
+	ID       string
+	Category Category
+	Action   Action
+	Message  string
+	pattern  *regexp.Regexp
+}
+
+// Colors are only matched when they clearly describe people, so "no white walls"
+// or "black area rug" stay clean.
+const (
+	colorPattern  = `whites?|blacks?`
+	groupsPattern = `caucasians?|african[\s-]+americans?|asians?|hispanics?|latin(?:o|a|x)s?|mexicans?|arabs?|` +
+		`immigrants?|foreigners?|christians?|catholics?|protestants?|jews|jewish|muslims?|hindus?|mormons?`
+	excludedPattern = `whites|blacks|caucasians|asians|hispanics|latin(?:o|a)s|latinx|mexicans|arabs|` +
+		`immigrants|foreigners|christians|catholics|protestants|jews|muslims|hindus|mormons`
+	peoplePattern    = `people|buyers?|renters?|tenants?|residents?|neighbou?rs?|households?|homeowners?`
+	placesPattern    = `famil(?:y|ies)|neighbou?rhoods?|communit(?:y|ies)|couples?|` + peoplePattern
+	lifestagePattern = `singles?|couples?|empty[\s-]+nesters?|young\s+professionals?|bachelors?|retirees?|` +
+		`newlyweds?|seniors?|famil(?:y|ies)|mature\s+(?:adults?|persons?)|students?`
+)
+
+// builtinRules are phrased after HUD's advertising guidance: explicit
+// exclusions are rejected, descriptions of residents rather than the property
+// are flagged. Plain design words ("white walls", "family room") never match.
+var builtinRules = []Rule{
+	{
+		ID:       "group_exclusion",
+		Category: CategoryRace,
+		Action:   ActionReject,
+		Message:  "States a preference for or excludes people by race, religion or national origin",
+		pattern:  regexp.MustCompile(`(?i)\b(?:(?:` + excludedPattern + `)\s+only|no\s+(?:` + excludedPattern + `))\b`),
+	},
+	{
+		ID:       "language_exclusion",
+		Category: CategoryNationalOrigin,
+		Action:   ActionReject,
+		Message:  "Limits occupancy by language or national origin",
+		pattern:  regexp.MustCompile(`(?i)\b(?:english[\s-]+(?:speakers?\s+)?only|must\s+speak\s+english)\b`),
+	},
+	{
+		ID:       "children_exclusion",
+		Category: CategoryFamilialStatus,
+		Action:   ActionReject,
+		Message:  "Excludes households with children",
+		pattern: regexp.MustCompile(
+			`(?i)\b(?:no\s+(?:children|kids|families|minors)|adults?[\s-]+only|child[\s-]*free|` +
+				`not\s+suitable\s+for\s+(?:children|kids|families))\b`),
+	},
+	{
+		ID:       "sex_exclusion",
+		Category: CategorySex,
+		Action:   ActionReject,
+		Message:  "Limits occupancy by sex",
+		pattern:  regexp.MustCompile(`(?i)\b(?:(?:men|women|males?|females?)\s+only|no\s+(?:men|women|males|females))\b`),
+	},
+	{
+		ID:       "disability_exclusion",
+		Category: CategoryDisability,
+		Action:   ActionReject,
+		Message:  "Excludes people with disabilities",
+		pattern: regexp.MustCompile(
+			`(?i)\b(?:no\s+(?:wheelchairs?|handicapped|disabled)|able[\s-]+bodied(?:\s+\w+)?\s+only|` +
+				`not\s+suitable\s+for\s+(?:the\s+)?(?:handicapped|disabled))\b`),
+	},
+	{
+		ID:       "demographic_description",
+		Category: CategoryRace,
+		Action:   ActionFlag,
+		Message:  "Describes residents or neighbors by race, religion or national origin",
+		pattern: regexp.MustCompile(
+			`(?i)\b(?:(?:` + colorPattern + `)\s+(?:` + peoplePattern + `)|` +
+				`(?:` + groupsPattern + `|ethnic|integrated|segregated)\s+(?:` + placesPattern + `))\b`),
+	},
+	{
+		ID:       "lifestage_steering",
+		Category: CategoryFamilialStatus,
+		Action:   ActionFlag,
+		Message:  "Steers toward buyers by household type instead of describing the property",
+		pattern: regexp.MustCompile(
+			`(?i)\b(?:perfect|ideal|great|suited|designed|made)\s+for\s+(?:` + lifestagePattern + `)\b`),
+	},
+	{
+		ID:       "exclusive_area",
+		Category: CategoryRace,
+		Action:   ActionFlag,
+		Message:  "Exclusivity language about the neighborhood can signal steering",
+		pattern:  regexp.MustCompile(`(?i)\b(?:exclusive|restricted|private)\s+(?:neighbou?rhood|community|enclave)\b`),
+	},
+}
+
+// Scanner checks prompt text against the built-in rules and any configured terms.
+type Scanner struct {
+	rules []Rule
+}
+
+// NewScanner builds a scanner from the built-in rules plus the term lists in cfg.
+func NewScanner(cfg config.Compliance) *Scanner {
+	rules := make([]Rule, 0, len(builtinRules)+len(cfg.RejectTerms)+len(cfg.FlagTerms))
+	rules = append(rules, builtinRules...)
+	rules = append(rules, termRules(cfg.RejectTerms, ActionReject)...)
+	rules = append(rules, termRules(cfg.FlagTerms, ActionFlag)...)
+	return &Scanner{rules: rules}
+}
+
+// termRules turns configured phrases into whole-word, case-insensitive rules.
+// Words may be separated by any run of whitespace or hyphens.
+func termRules(terms []string, action Action) []Rule {
+	rules := make([]Rule, 0, len(terms))
+	for _, term := range terms {
+		words := strings.Fields(strings.ToLower(term))
+		if len(words) == 0 {
+			continue
+		}
+		id := "term:" + strings.Join(words, " ")
+		for i, w := range words {
+			words[i] = regexp.QuoteMeta(w)
+		}
+		rules = append(rules, Rule{
+			ID:       id,
+			Category: CategoryCustom,
+			Action:   action,
+			Message:  "Matches a configured fair-housing term",
+			pattern:  regexp.MustCompile(`(?i)\b` + strings.Join(words, `[\s-]+`) + `\b`),
+		})
+	}
+	return rules
+}
+
+// Scan returns every rule the text matches. A rule is reported once, with its first match.
+func (s *Scanner) Scan(text string) Result {
+	result := Result{Violations: []Violation{}}
+	if strings.TrimSpace(text) == "" {
+		return result
+	}
+	for _, r := range s.rules {
+		match := r.pattern.FindString(text)
+		if match == "" {
+			continue
+		}
+		result.Violations = append(result.Violations, Violation{
+			Rule:     r.ID,
+			Category: r.Category,
+			Action:   r.Action,
+			Match:    match,
+			Message:  r.Message,
+		})
+	}
+	return result
+}
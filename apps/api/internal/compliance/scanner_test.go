@@ -0,0 +1,83 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestScanner_Scan(t *testing.T) {
+	scanner := NewScanner(config.Compliance{
+		RejectTerms: []string{"no section 8"},
+		FlagTerms:   []string{"walk to church"},
+	})
+
+	cases := []struct {
+		name      string
+		text      string
+		wantRules []string
+		rejected  bool
+	}{
+		{name: "success: clean design prompt", text: "Modern living room with white walls and a black area rug"},
+		{name: "success: family room is a room", text: "Cozy family room with a sectional sofa"},
+		{name: "success: empty text", text: "   "},
+		{
+			name:      "fail: excludes children",
+			text:      "Minimalist condo, adults only, no kids allowed",
+			wantRules: []string{"children_exclusion"},
+			rejected:  true,
+		},
+		{
+			name:      "fail: excludes by religion",
+			text:      "Stage it for Christians only",
+			wantRules: []string{"group_exclusion"},
+			rejected:  true,
+		},
+		{
+			name:      "fail: excludes by disability",
+			text:      "No wheelchairs, lots of stairs",
+			wantRules: []string{"disability_exclusion"},
+			rejected:  true,
+		},
+		{
+			name:      "fail: flags demographic description",
+			text:      "Warm decor for the Jewish neighborhood",
+			wantRules: []string{"demographic_description"},
+		},
+		{
+			name:      "fail: flags life-stage steering",
+			text:      "Scandinavian loft, perfect for young professionals",
+			wantRules: []string{"lifestage_steering"},
+		},
+		{
+			name:      "fail: configured reject term across hyphen",
+			text:      "Luxury finishes, no Section-8",
+			wantRules: []string{"term:no section 8"},
+			rejected:  true,
+		},
+		{
+			name:      "fail: configured flag term",
+			text:      "Traditional style, walk to church",
+			wantRules: []string{"term:walk to church"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := scanner.Scan(tc.text)
+
+			rules := []string{}
+			for _, v := range result.Violations {
+				rules = append(rules, v.Rule)
+			}
+			if tc.wantRules == nil {
+				tc.wantRules = []string{}
+			}
+			assert.Equal(t, tc.wantRules, rules)
+			assert.Equal(t, tc.rejected, result.Rejected())
+			assert.Equal(t, len(tc.wantRules) > 0, result.Flagged())
+		})
+	}
+}
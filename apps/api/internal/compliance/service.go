@@ -0,0 +1,24 @@
+package compliance
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service screens prompts and manages the review queue.
+type Service interface {
+	// Screen scans prompt text against the fair-housing rules. A result without
+	// violations means the text is clean.
+	Screen(ctx context.Context, text string) Result
+
+	// Record queues a screened prompt for admin review.
+	Record(ctx context.Context, review *Review) (*Review, error)
+
+	// ListReviews returns reviews matching the filter, oldest first.
+	ListReviews(ctx context.Context, filter ListFilter) ([]Review, error)
+
+	// GetReview retrieves a single review.
+	GetReview(ctx context.Context, id string) (*Review, error)
+
+	// ResolveReview clears or upholds a review on behalf of an admin.
+	ResolveReview(ctx context.Context, id, reviewedBy string, req ResolveRequest) (*Review, error)
+}
@@ -3,27 +3,41 @@ package config
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+
+	"github.com/real-staging-ai/api/encryption"
 )
 
 // Config represents the application configuration.
 
 type Config struct {
-	App     App     `yaml:"app"`
-	Auth0   Auth0   `yaml:"auth0"`
-	DB      DB      `yaml:"db"`
-	Job     Job     `yaml:"job"`
-	Logging Logging `yaml:"logging"`
-	OTEL    OTEL    `yaml:"otel"`
-	Plans   Plans   `yaml:"plans"`
-	Redis   Redis   `yaml:"redis"`
-	S3      S3      `yaml:"s3"`
-	Stripe  Stripe  `yaml:"stripe"`
-	Worker  Worker  `yaml:"worker"`
+	App         App         `yaml:"app"`
+	Auth0       Auth0       `yaml:"auth0"`
+	Compliance  Compliance  `yaml:"compliance"`
+	CORS        CORS        `yaml:"cors"`
+	Credits     Credits     `yaml:"credits"`
+	DB          DB          `yaml:"db"`
+	Encryption  Encryption  `yaml:"encryption"`
+	ErrorImages ErrorImages `yaml:"error_images"`
+	Job         Job         `yaml:"job"`
+	Logging     Logging     `yaml:"logging"`
+	OTEL        OTEL        `yaml:"otel"`
+	Plans       Plans       `yaml:"plans"`
+	RateLimit   RateLimit   `yaml:"rate_limit"`
+	Redis       Redis       `yaml:"redis"`
+	Replicate   Replicate   `yaml:"replicate"`
+	S3          S3          `yaml:"s3"`
+	Stripe      Stripe      `yaml:"stripe"`
+	Support     Support     `yaml:"support"`
+	Trash       Trash       `yaml:"trash"`
+	Worker      Worker      `yaml:"worker"`
 }
 
 type App struct {
@@ -38,6 +52,62 @@ type Auth0 struct {
 	GrantType    string `yaml:"grant_type" env:"AUTH0_GRANT_TYPE" env-default:"client_credentials"`
 }
 
+// Compliance configures the fair-housing scan of custom prompts. The term lists
+// extend the built-in rules; each entry is a phrase matched as whole words.
+type Compliance struct {
+	Enabled     bool     `yaml:"enabled" env:"COMPLIANCE_SCAN_ENABLED" env-default:"true"`
+	RejectTerms []string `yaml:"reject_terms" env:"COMPLIANCE_REJECT_TERMS" env-separator:","`
+	FlagTerms   []string `yaml:"flag_terms" env:"COMPLIANCE_FLAG_TERMS" env-separator:","`
+}
+
+// CORS lists the browser origins allowed to call the API. Each entry is an
+// exact origin ("https://app.example.com"), a subdomain wildcard
+// ("https://*.example.com"), or "*" on its own to allow any origin.
+type CORS struct {
+	//nolint:lll // struct tag
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" env-separator:"," env-default:"http://localhost:3000,http://localhost:3001"`
+}
+
+// Validate rejects origins that could never match a browser's Origin header,
+// so a typo fails at startup instead of silently blocking the frontend.
+func (c *CORS) Validate() error {
+	if len(c.AllowedOrigins) == 0 {
+		return fmt.Errorf("CORS_ALLOWED_ORIGINS must list at least one origin")
+	}
+	for i, origin := range c.AllowedOrigins {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		c.AllowedOrigins[i] = origin
+		if origin == "*" {
+			if len(c.AllowedOrigins) > 1 {
+				return fmt.Errorf("CORS_ALLOWED_ORIGINS: \"*\" cannot be combined with other origins")
+			}
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			return fmt.Errorf("CORS_ALLOWED_ORIGINS: %q: %w", origin, err)
+		}
+	}
+	return nil
+}
+
+// validateOrigin checks for scheme://host[:port] with an optional "*." label
+// leading the host.
+func validateOrigin(origin string) error {
+	u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+	if err != nil {
+		return err
+	}
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return fmt.Errorf("scheme must be http or https")
+	case u.Hostname() == "" || strings.Contains(u.Host, "*"):
+		return fmt.Errorf("host must be a name, optionally starting with \"*.\"")
+	case u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil:
+		return fmt.Errorf("origin must not include a path, query or credentials")
+	}
+	return nil
+}
+
 type DB struct {
 	URL      string `yaml:"url" env:"DATABASE_URL"` // Full connection URL (takes precedence)
 	Database string `yaml:"pgdatabase" env:"PGDATABASE" env-default:"realstaging"`
@@ -46,6 +116,33 @@ type DB struct {
 	Port     int    `yaml:"pgport" env:"PGPORT" env-default:"5432"`
 	User     string `yaml:"pguser" env:"PGUSER" env-default:"postgres"`
 	SSLMode  string `yaml:"pgsslmode" env:"PGSSLMODE" env-default:"disable"`
+	// SlowQueryMS logs queries that take at least this many milliseconds.
+	// Zero turns slow-query logging off; query metrics are always recorded.
+	SlowQueryMS int `yaml:"slow_query_ms" env:"DB_SLOW_QUERY_MS" env-default:"250"`
+}
+
+// SlowQueryThreshold returns the slow-query threshold, or 0 when logging is off.
+func (d DB) SlowQueryThreshold() time.Duration {
+	if d.SlowQueryMS <= 0 {
+		return 0
+	}
+	return time.Duration(d.SlowQueryMS) * time.Millisecond
+}
+
+// Encryption holds the keys that seal sensitive values stored in the database,
+// such as user preferences. Keys are 32 random bytes, base64-encoded. To
+// rotate, set a new Key and move the old one to PreviousKeys.
+type Encryption struct {
+	Key          string   `yaml:"key" env:"ENCRYPTION_KEY"`
+	PreviousKeys []string `yaml:"previous_keys" env:"ENCRYPTION_KEY_PREVIOUS" env-separator:","`
+}
+
+// Keyring builds the keyring, or returns nil when no key is configured.
+func (e Encryption) Keyring() (*encryption.Keyring, error) {
+	if e.Key == "" {
+		return nil, nil
+	}
+	return encryption.NewKeyring(e.Key, e.PreviousKeys...)
 }
 
 type Job struct {
@@ -65,6 +162,47 @@ type Plans struct {
 	FreePriceID     string `yaml:"free_price_id" env:"STRIPE_PRICE_FREE"`
 	ProPriceID      string `yaml:"pro_price_id" env:"STRIPE_PRICE_PRO"`
 	BusinessPriceID string `yaml:"business_price_id" env:"STRIPE_PRICE_BUSINESS"`
+	// SandboxMonthlyLimit caps images per calendar month for sandbox accounts.
+	SandboxMonthlyLimit int32 `yaml:"sandbox_monthly_limit" env:"SANDBOX_MONTHLY_IMAGE_LIMIT" env-default:"100"`
+
+	// OverageMeterEvent is the event name of the Stripe billing meter that
+	// images past a plan's monthly limit are reported to. Overage is off, and
+	// the limit hard, while it is empty.
+	OverageMeterEvent string `yaml:"overage_meter_event" env:"STRIPE_OVERAGE_METER_EVENT"`
+	// OverageCap is how many images past the monthly limit a subscriber may
+	// create in a billing period.
+	OverageCap int32 `yaml:"overage_cap" env:"OVERAGE_IMAGE_CAP" env-default:"100"`
+	// OveragePlans are the codes of the plans whose subscriptions carry the
+	// metered overage price.
+	OveragePlans []string `yaml:"overage_plans" env:"OVERAGE_PLANS" env-separator:"," env-default:"pro,business"`
+
+	// GracePeriodDays is how long a subscriber whose renewal payment failed
+	// keeps their plan while Stripe retries the charge. Zero ends access as
+	// soon as the subscription is past due.
+	GracePeriodDays int `yaml:"grace_period_days" env:"BILLING_GRACE_PERIOD_DAYS" env-default:"7"`
+}
+
+// GracePeriod returns the grace period of past-due subscriptions, or 0 when
+// there is none.
+func (p *Plans) GracePeriod() time.Duration {
+	if p.GracePeriodDays <= 0 {
+		return 0
+	}
+	return time.Duration(p.GracePeriodDays) * 24 * time.Hour
+}
+
+// OverageCapFor returns how many images past its monthly limit a subscriber
+// to the plan may create, or 0 when the plan has no overage.
+func (p *Plans) OverageCapFor(code string) int32 {
+	if p.OverageMeterEvent == "" || p.OverageCap <= 0 {
+		return 0
+	}
+	for _, c := range p.OveragePlans {
+		if strings.TrimSpace(c) == code {
+			return p.OverageCap
+		}
+	}
+	return 0
 }
 
 // GetPriceIDByCode returns the price ID for a given plan code
@@ -132,6 +270,98 @@ type PlanDefinition struct {
 	MonthlyLimit int32
 }
 
+// Credits configures the packs of image credits sold through Stripe Checkout
+// one-time payments to users without a subscription. A pack is only offered
+// when its price ID is set.
+type Credits struct {
+	Pack20PriceID string `yaml:"pack_20_price_id" env:"STRIPE_PRICE_CREDITS_20"`
+	Pack50PriceID string `yaml:"pack_50_price_id" env:"STRIPE_PRICE_CREDITS_50"`
+}
+
+// CreditPack is a pack of image credits and the Stripe price it sells at.
+type CreditPack struct {
+	Code    string `json:"code"`
+	Credits int32  `json:"credits"`
+	PriceID string `json:"price_id"`
+}
+
+// Packs returns the packs that have a price, smallest first.
+func (c Credits) Packs() []CreditPack {
+	packs := []CreditPack{}
+	for _, p := range []CreditPack{
+		{Code: "pack_20", Credits: 20, PriceID: c.Pack20PriceID},
+		{Code: "pack_50", Credits: 50, PriceID: c.Pack50PriceID},
+	} {
+		if p.PriceID != "" {
+			packs = append(packs, p)
+		}
+	}
+	return packs
+}
+
+// Pack returns the priced pack with the given code.
+func (c Credits) Pack(code string) (CreditPack, bool) {
+	for _, p := range c.Packs() {
+		if p.Code == code {
+			return p, true
+		}
+	}
+	return CreditPack{}, false
+}
+
+// RateLimit caps authenticated requests per user with a token bucket that
+// holds a minute's worth of requests and refills continuously. Zero
+// RequestsPerMinute disables the limit.
+type RateLimit struct {
+	// RequestsPerMinute applies to plans without a limit of their own, like
+	// sandbox.
+	RequestsPerMinute int `yaml:"requests_per_minute" env:"RATE_LIMIT_PER_MINUTE" env-default:"120"`
+	// FreePerMinute, ProPerMinute and BusinessPerMinute override it for
+	// their plan when set.
+	FreePerMinute     int `yaml:"free_per_minute" env:"RATE_LIMIT_FREE_PER_MINUTE" env-default:"60"`
+	ProPerMinute      int `yaml:"pro_per_minute" env:"RATE_LIMIT_PRO_PER_MINUTE" env-default:"300"`
+	BusinessPerMinute int `yaml:"business_per_minute" env:"RATE_LIMIT_BUSINESS_PER_MINUTE" env-default:"600"`
+	// ExemptPaths are URL path prefixes that are never limited, such as the
+	// internal admin routes operators and workers call.
+	ExemptPaths []string `yaml:"exempt_paths" env:"RATE_LIMIT_EXEMPT_PATHS" env-separator:"," env-default:"/api/v1/admin/"`
+}
+
+// Enabled reports whether requests are limited at all.
+func (r RateLimit) Enabled() bool {
+	return r.RequestsPerMinute > 0
+}
+
+// PerMinuteFor returns the requests per minute allowed on the plan, or 0 when
+// limiting is off.
+func (r RateLimit) PerMinuteFor(planCode string) int {
+	if !r.Enabled() {
+		return 0
+	}
+	var limit int
+	switch planCode {
+	case "free":
+		limit = r.FreePerMinute
+	case "pro":
+		limit = r.ProPerMinute
+	case "business":
+		limit = r.BusinessPerMinute
+	}
+	if limit > 0 {
+		return limit
+	}
+	return r.RequestsPerMinute
+}
+
+// Exempt reports whether requests to path are never limited.
+func (r RateLimit) Exempt(path string) bool {
+	for _, prefix := range r.ExemptPaths {
+		if prefix = strings.TrimSpace(prefix); prefix != "" && strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 type Redis struct {
 	Host string `yaml:"host" env:"REDIS_HOST"`
 	Port string `yaml:"port" env:"REDIS_PORT" env-default:"6379"`
@@ -145,6 +375,14 @@ func (r Redis) Addr() string {
 	return r.Host + ":" + r.Port
 }
 
+// Replicate configures read-only calls to the Replicate API, used to list the
+// upstream versions an admin can pin a model to and to attach prediction logs
+// to support tickets.
+type Replicate struct {
+	APIToken string `yaml:"api_token" env:"REPLICATE_API_TOKEN"`
+	BaseURL  string `yaml:"base_url" env:"REPLICATE_BASE_URL" env-default:"https://api.replicate.com/v1"`
+}
+
 type S3 struct {
 	AccessKey      string `yaml:"access_key" env:"S3_ACCESS_KEY"`
 	BucketName     string `yaml:"bucket_name" env:"S3_BUCKET_NAME" env-default:"real-staging"`
@@ -158,6 +396,56 @@ type S3 struct {
 type Stripe struct {
 	SecretKey     string `yaml:"secret_key" env:"STRIPE_SECRET_KEY"`
 	WebhookSecret string `yaml:"webhook_secret" env:"STRIPE_WEBHOOK_SECRET"`
+	// TestSecretKey is the Stripe test-mode key used for sandbox accounts. Sandbox
+	// billing is disabled when it is empty; it never falls back to SecretKey.
+	TestSecretKey     string `yaml:"test_secret_key" env:"STRIPE_TEST_SECRET_KEY"`
+	TestWebhookSecret string `yaml:"test_webhook_secret" env:"STRIPE_TEST_WEBHOOK_SECRET"`
+	// TestClocksEnabled exposes the test-only admin endpoints that create and
+	// advance Stripe test clocks. Keep it off in production.
+	TestClocksEnabled bool `yaml:"test_clocks_enabled" env:"STRIPE_TEST_CLOCKS_ENABLED"`
+}
+
+// Support configures where support tickets are forwarded. Tickets are only
+// stored when WebhookURL is empty.
+type Support struct {
+	WebhookURL string `yaml:"webhook_url" env:"SUPPORT_WEBHOOK_URL"`
+}
+
+// Trash controls how long deleted images can be restored before they and their
+// files are removed for good. Zero keeps them forever.
+type Trash struct {
+	RetentionDays int `yaml:"retention_days" env:"IMAGE_TRASH_RETENTION_DAYS" env-default:"30"`
+}
+
+// Retention returns the retention period, or 0 when purging is disabled.
+func (t Trash) Retention() time.Duration {
+	if t.RetentionDays <= 0 {
+		return 0
+	}
+	return time.Duration(t.RetentionDays) * 24 * time.Hour
+}
+
+// ErrorImages controls the cleanup of images that failed to stage. Images left
+// in error for RetentionDays are moved to the trash; their owners are emailed
+// NoticeDays beforehand. Zero RetentionDays keeps them forever.
+type ErrorImages struct {
+	RetentionDays int `yaml:"retention_days" env:"ERROR_IMAGE_RETENTION_DAYS" env-default:"0"`
+	NoticeDays    int `yaml:"notice_days" env:"ERROR_IMAGE_NOTICE_DAYS" env-default:"7"`
+}
+
+// Retention returns how long an image may stay in error, or 0 when the
+// cleanup is disabled.
+func (e ErrorImages) Retention() time.Duration {
+	if e.RetentionDays <= 0 {
+		return 0
+	}
+	return time.Duration(e.RetentionDays) * 24 * time.Hour
+}
+
+// Notice returns how long before the cleanup owners are told. It is capped at
+// the retention period, in which case owners hear as soon as it starts.
+func (e ErrorImages) Notice() time.Duration {
+	return min(time.Duration(max(e.NoticeDays, 0))*24*time.Hour, e.Retention())
 }
 
 type Worker struct {
@@ -218,6 +506,14 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid plans configuration: %w", err)
 	}
 
+	if err := cfg.CORS.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cors configuration: %w", err)
+	}
+
+	if _, err := cfg.Encryption.Keyring(); err != nil {
+		return nil, fmt.Errorf("invalid encryption configuration: %w", err)
+	}
+
 	return cfg, nil
 }
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 func TestDatabaseURL(t *testing.T) {
@@ -78,3 +79,109 @@ func TestDatabaseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestTrashRetention(t *testing.T) {
+	tests := []struct {
+		name string
+		days int
+		want time.Duration
+	}{
+		{name: "success: days to duration", days: 30, want: 30 * 24 * time.Hour},
+		{name: "success: zero disables purging", days: 0, want: 0},
+		{name: "success: negative disables purging", days: -1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Trash{RetentionDays: tt.days}.Retention()
+			if got != tt.want {
+				t.Errorf("Retention() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorImages(t *testing.T) {
+	tests := []struct {
+		name          string
+		retentionDays int
+		noticeDays    int
+		wantRetention time.Duration
+		wantNotice    time.Duration
+	}{
+		{name: "success: days to durations", retentionDays: 30, noticeDays: 7,
+			wantRetention: 30 * 24 * time.Hour, wantNotice: 7 * 24 * time.Hour},
+		{name: "success: notice capped at retention", retentionDays: 3, noticeDays: 7,
+			wantRetention: 3 * 24 * time.Hour, wantNotice: 3 * 24 * time.Hour},
+		{name: "success: zero disables cleanup", retentionDays: 0, noticeDays: 7},
+		{name: "success: negative notice sends it on the day", retentionDays: 30, noticeDays: -1,
+			wantRetention: 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := ErrorImages{RetentionDays: tt.retentionDays, NoticeDays: tt.noticeDays}
+			if got := cfg.Retention(); got != tt.wantRetention {
+				t.Errorf("Retention() = %v, want %v", got, tt.wantRetention)
+			}
+			if got := cfg.Notice(); got != tt.wantNotice {
+				t.Errorf("Notice() = %v, want %v", got, tt.wantNotice)
+			}
+		})
+	}
+}
+
+func TestDBSlowQueryThreshold(t *testing.T) {
+	tests := []struct {
+		name string
+		ms   int
+		want time.Duration
+	}{
+		{name: "success: milliseconds to duration", ms: 250, want: 250 * time.Millisecond},
+		{name: "success: zero disables logging", ms: 0, want: 0},
+		{name: "success: negative disables logging", ms: -5, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DB{SlowQueryMS: tt.ms}.SlowQueryThreshold()
+			if got != tt.want {
+				t.Errorf("SlowQueryThreshold() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	limits := RateLimit{
+		RequestsPerMinute: 120, FreePerMinute: 60, ProPerMinute: 300, ExemptPaths: []string{" /api/v1/admin/"},
+	}
+
+	tests := []struct {
+		name     string
+		limits   RateLimit
+		planCode string
+		want     int
+	}{
+		{name: "success: plan with its own limit", limits: limits, planCode: "pro", want: 300},
+		{name: "success: free plan", limits: limits, planCode: "free", want: 60},
+		{name: "success: plan without its own limit", limits: limits, planCode: "business", want: 120},
+		{name: "success: sandbox uses the default", limits: limits, planCode: "sandbox", want: 120},
+		{name: "success: zero default disables every plan", limits: RateLimit{ProPerMinute: 300}, planCode: "pro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limits.PerMinuteFor(tt.planCode); got != tt.want {
+				t.Errorf("PerMinuteFor(%q) = %d, want %d", tt.planCode, got, tt.want)
+			}
+		})
+	}
+
+	if !limits.Exempt("/api/v1/admin/models") {
+		t.Error("admin routes should be exempt")
+	}
+	if limits.Exempt("/api/v1/images") {
+		t.Error("image routes should be limited")
+	}
+}
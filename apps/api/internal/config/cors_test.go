@@ -0,0 +1,75 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORS_Validate(t *testing.T) {
+	cases := []struct {
+		name    string
+		origins []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "success: exact origins, trimmed",
+			origins: []string{" https://app.realstaging.ai/", "http://localhost:3000"},
+			want:    []string{"https://app.realstaging.ai", "http://localhost:3000"},
+		},
+		{name: "success: subdomain wildcard", origins: []string{"https://*.realstaging.ai"}},
+		{name: "success: any origin", origins: []string{"*"}},
+		{name: "fail: empty", origins: nil, wantErr: "at least one origin"},
+		{name: "fail: any origin mixed with others", origins: []string{"*", "https://a.com"}, wantErr: "cannot be combined"},
+		{name: "fail: missing scheme", origins: []string{"app.realstaging.ai"}, wantErr: "scheme"},
+		{name: "fail: path", origins: []string{"https://app.realstaging.ai/app"}, wantErr: "path"},
+		{name: "fail: wildcard inside host", origins: []string{"https://app.*.ai"}, wantErr: "host"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := CORS{AllowedOrigins: tc.origins}
+			err := c.Validate()
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tc.want != nil {
+				assert.Equal(t, tc.want, c.AllowedOrigins)
+			}
+		})
+	}
+}
+
+func TestConfig_LoadCORS(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	t.Setenv("STRIPE_PRICE_FREE", "price_free_test")
+	t.Setenv("STRIPE_PRICE_PRO", "price_pro_test")
+	t.Setenv("STRIPE_PRICE_BUSINESS", "price_business_test")
+
+	t.Run("success: defaults to local frontends", func(t *testing.T) {
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http://localhost:3000", "http://localhost:3001"}, cfg.CORS.AllowedOrigins)
+	})
+
+	t.Run("success: reads comma-separated origins", func(t *testing.T) {
+		t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.realstaging.ai, https://*.realstaging.ai")
+
+		cfg, err := Load()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"https://app.realstaging.ai", "https://*.realstaging.ai"}, cfg.CORS.AllowedOrigins)
+	})
+
+	t.Run("fail: invalid origin stops startup", func(t *testing.T) {
+		t.Setenv("CORS_ALLOWED_ORIGINS", "app.realstaging.ai")
+
+		_, err := Load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cors configuration")
+	})
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryption_Keyring(t *testing.T) {
+	const key = "rPVL6YdZl8Rtvpiypu8VxN9EbCaJh0o312Hth8LL5Ag="
+
+	t.Run("success: no key configured", func(t *testing.T) {
+		kr, err := Encryption{}.Keyring()
+		require.NoError(t, err)
+		assert.Nil(t, kr)
+	})
+
+	t.Run("success: current and previous keys", func(t *testing.T) {
+		kr, err := Encryption{Key: key, PreviousKeys: []string{"lh0u4rW6nQ2XQX+iEaiXFLnF7UmWmeDj4rOTR+0ttgM="}}.Keyring()
+		require.NoError(t, err)
+		assert.NotNil(t, kr)
+	})
+
+	t.Run("fail: key of the wrong size", func(t *testing.T) {
+		_, err := Encryption{Key: "c2hvcnQ="}.Keyring()
+		assert.ErrorContains(t, err, "must decode to 32 bytes")
+	})
+
+	t.Run("fail: bad previous key", func(t *testing.T) {
+		_, err := Encryption{Key: key, PreviousKeys: []string{"nope"}}.Keyring()
+		assert.ErrorContains(t, err, "previous key 1")
+	})
+}
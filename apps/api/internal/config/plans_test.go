@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -271,3 +272,34 @@ func TestConfig_LoadWithPlans(t *testing.T) {
 		assert.Equal(t, cfg.Plans.BusinessPriceID, plansConfig.BusinessPriceID)
 	})
 }
+
+func TestCredits_Packs(t *testing.T) {
+	credits := Credits{Pack50PriceID: "price_credits_50"}
+
+	assert.Equal(t, []CreditPack{{Code: "pack_50", Credits: 50, PriceID: "price_credits_50"}}, credits.Packs())
+
+	pack, ok := credits.Pack("pack_50")
+	require.True(t, ok)
+	assert.Equal(t, int32(50), pack.Credits)
+
+	_, ok = credits.Pack("pack_20")
+	assert.False(t, ok, "packs without a price are not sold")
+	assert.Empty(t, Credits{}.Packs())
+}
+
+func TestPlans_OverageCapFor(t *testing.T) {
+	plans := &Plans{OverageMeterEvent: "image_overage", OverageCap: 50, OveragePlans: []string{"pro", " business"}}
+
+	assert.Equal(t, int32(50), plans.OverageCapFor("pro"))
+	assert.Equal(t, int32(50), plans.OverageCapFor("business"))
+	assert.Zero(t, plans.OverageCapFor("free"), "plans without the metered price keep a hard limit")
+
+	plans.OverageMeterEvent = ""
+	assert.Zero(t, plans.OverageCapFor("pro"), "overage is off without a meter")
+}
+
+func TestPlans_GracePeriod(t *testing.T) {
+	assert.Equal(t, 7*24*time.Hour, (&Plans{GracePeriodDays: 7}).GracePeriod())
+	assert.Zero(t, (&Plans{}).GracePeriod())
+	assert.Zero(t, (&Plans{GracePeriodDays: -1}).GracePeriod())
+}
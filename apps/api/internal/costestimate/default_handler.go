@@ -0,0 +1,57 @@
+package costestimate
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the cost estimate endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// GetEstimate handles GET /api/v1/models/:id/cost-estimate - Prices staging
+// ?images= images (1 by default) with the model before they are submitted.
+func (h *DefaultHandler) GetEstimate(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+	}
+
+	images := 1
+	if raw := c.QueryParam("images"); raw != "" {
+		if images, err = strconv.Atoi(raw); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidImages.Error())
+		}
+	}
+
+	est, err := h.service.Estimate(ctx, modelID, images)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUnknownModel):
+			return echo.NewHTTPError(http.StatusNotFound, "Model not found")
+		case errors.Is(err, ErrInvalidImages):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		h.log.Error(ctx, "failed to estimate staging cost", "error", err, "model_id", modelID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to estimate cost")
+	}
+
+	return c.JSON(http.StatusOK, est)
+}
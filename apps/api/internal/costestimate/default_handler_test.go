@@ -0,0 +1,77 @@
+package costestimate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_GetEstimate(t *testing.T) {
+	cases := []struct {
+		name        string
+		query       string
+		serviceErr  error
+		wantImages  int
+		wantStatus  int
+		wantContain string
+	}{
+		{name: "success: one image by default", wantImages: 1, wantStatus: http.StatusOK, wantContain: `"credits":1`},
+		{name: "success: several images", query: "?images=5", wantImages: 5, wantStatus: http.StatusOK},
+		{name: "fail: images not a number", query: "?images=five", wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: images out of range",
+			query:      "?images=51",
+			wantImages: 51,
+			serviceErr: ErrInvalidImages,
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "fail: unknown model", wantImages: 1, serviceErr: ErrUnknownModel, wantStatus: http.StatusNotFound},
+		{
+			name:       "fail: service error",
+			wantImages: 1,
+			serviceErr: errors.New("db down"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				EstimateFunc: func(ctx context.Context, id string, images int) (*Estimate, error) {
+					assert.Equal(t, modelID, id)
+					assert.Equal(t, tc.wantImages, images)
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Estimate{ModelID: id, Images: images, Credits: images}, nil
+				},
+			}
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("black-forest-labs%2Fflux-kontext-pro")
+
+			err := NewDefaultHandler(svc, logging.Default()).GetEstimate(c)
+
+			if tc.wantStatus != http.StatusOK {
+				var httpErr *echo.HTTPError
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, tc.wantStatus, httpErr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.wantContain)
+		})
+	}
+}
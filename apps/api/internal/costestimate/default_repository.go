@@ -0,0 +1,42 @@
+package costestimate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// RecentCost averages the cost the worker recorded on ready events. Pinned
+// runs record the model as "<model_id>:<version>", and runs without a recorded
+// cost are left out.
+func (r *DefaultRepository) RecentCost(ctx context.Context, modelID string, since time.Time) (*RecentCost, error) {
+	query := `
+		SELECT count(*), COALESCE(avg(cost_usd), 0)::float8
+		FROM image_events
+		WHERE source = 'worker'
+		  AND status = 'ready'
+		  AND cost_usd > 0
+		  AND split_part(model_used, ':', 1) = $1
+		  AND created_at >= $2`
+
+	var rc RecentCost
+	if err := r.db.QueryRow(ctx, query, modelID, since).Scan(&rc.Runs, &rc.AvgUSD); err != nil {
+		return nil, fmt.Errorf("failed to average recent staging costs: %w", err)
+	}
+	return &rc, nil
+}
@@ -0,0 +1,59 @@
+package costestimate
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/settings"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// Estimate prices the images at the average of the model's recent runs when
+// there are enough of them, and at its list price otherwise.
+func (s *DefaultService) Estimate(ctx context.Context, modelID string, images int) (*Estimate, error) {
+	listPrice, ok := settings.ModelCostPerImage(modelID)
+	if !ok {
+		return nil, ErrUnknownModel
+	}
+	if images < 1 || images > MaxImages {
+		return nil, ErrInvalidImages
+	}
+
+	recent, err := s.repo.RecentCost(ctx, modelID, time.Now().Add(-recentWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	est := &Estimate{
+		ModelID:         modelID,
+		Images:          images,
+		CostPerImageUSD: listPrice,
+		Credits:         images * CreditsPerImage,
+		Basis:           BasisListPrice,
+		SampleSize:      recent.Runs,
+	}
+	if recent.Runs >= minRecentRuns {
+		est.CostPerImageUSD = roundUSD(recent.AvgUSD)
+		est.Basis = BasisRecentRuns
+	}
+	est.CostUSD = roundUSD(est.CostPerImageUSD * float64(images))
+	return est, nil
+}
+
+// roundUSD rounds to the hundredth of a cent that costs are stored with.
+func roundUSD(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
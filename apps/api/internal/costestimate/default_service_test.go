@@ -0,0 +1,92 @@
+package costestimate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/settings"
+)
+
+const modelID = "black-forest-labs/flux-kontext-pro"
+
+func TestDefaultService_Estimate(t *testing.T) {
+	settings.SetCatalog([]settings.ModelInfo{
+		{ID: modelID, CostPerImageUSD: 0.04, Enabled: true},
+		{ID: "acme/retired", CostPerImageUSD: 0.02},
+	})
+
+	cases := []struct {
+		name    string
+		modelID string
+		images  int
+		recent  *RecentCost
+		repoErr error
+		want    *Estimate
+		wantErr error
+	}{
+		{
+			name:    "success: list price without recorded runs",
+			modelID: modelID,
+			images:  3,
+			recent:  &RecentCost{},
+			want: &Estimate{
+				ModelID: modelID, Images: 3, CostPerImageUSD: 0.04, CostUSD: 0.12, Credits: 3, Basis: BasisListPrice,
+			},
+		},
+		{
+			name:    "success: list price while too few runs are recorded",
+			modelID: modelID,
+			images:  1,
+			recent:  &RecentCost{Runs: minRecentRuns - 1, AvgUSD: 0.05},
+			want: &Estimate{
+				ModelID: modelID, Images: 1, CostPerImageUSD: 0.04, CostUSD: 0.04, Credits: 1, Basis: BasisListPrice,
+				SampleSize: minRecentRuns - 1,
+			},
+		},
+		{
+			name:    "success: recent runs refine the estimate",
+			modelID: modelID,
+			images:  2,
+			recent:  &RecentCost{Runs: 40, AvgUSD: 0.04125},
+			want: &Estimate{
+				ModelID: modelID, Images: 2, CostPerImageUSD: 0.0413, CostUSD: 0.0826, Credits: 2,
+				Basis: BasisRecentRuns, SampleSize: 40,
+			},
+		},
+		{name: "fail: unknown model", modelID: "acme/painter", images: 1, wantErr: ErrUnknownModel},
+		{name: "fail: disabled model", modelID: "acme/retired", images: 1, wantErr: ErrUnknownModel},
+		{name: "fail: no images", modelID: modelID, images: 0, wantErr: ErrInvalidImages},
+		{name: "fail: too many images", modelID: modelID, images: MaxImages + 1, wantErr: ErrInvalidImages},
+		{name: "fail: repository error", modelID: modelID, images: 1, repoErr: errors.New("db down")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				RecentCostFunc: func(ctx context.Context, id string, since time.Time) (*RecentCost, error) {
+					assert.Equal(t, tc.modelID, id)
+					assert.WithinDuration(t, time.Now().Add(-recentWindow), since, time.Minute)
+					return tc.recent, tc.repoErr
+				},
+			}
+
+			got, err := NewDefaultService(repo).Estimate(context.Background(), tc.modelID, tc.images)
+
+			switch {
+			case tc.wantErr != nil:
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, repo.RecentCostCalls())
+			case tc.repoErr != nil:
+				assert.ErrorIs(t, err, tc.repoErr)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
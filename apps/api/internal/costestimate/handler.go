@@ -0,0 +1,10 @@
+package costestimate
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the cost estimate endpoints.
+type Handler interface {
+	GetEstimate(c echo.Context) error
+}
@@ -0,0 +1,60 @@
+// Package costestimate tells users what staging will cost before they submit.
+//
+// Estimates start from each model's list price in the settings catalog. Once
+// the worker has recorded enough actual costs for a model's recent runs, their
+// average is used instead, so estimates follow what Replicate really charges.
+package costestimate
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrUnknownModel is returned for models that aren't in the catalog.
+	ErrUnknownModel = errors.New("unknown model")
+	// ErrInvalidImages is returned when the image count is out of range.
+	ErrInvalidImages = errors.New("images must be between 1 and 50")
+)
+
+const (
+	// MaxImages matches the largest batch a user can submit at once.
+	MaxImages = 50
+	// CreditsPerImage is what each staged image counts against the user's plan.
+	CreditsPerImage = 1
+
+	// minRecentRuns is how many recorded runs a model needs before their
+	// average replaces the list price.
+	minRecentRuns = 20
+	// recentWindow is how far back recorded runs are averaged.
+	recentWindow = 30 * 24 * time.Hour
+)
+
+// Basis says where an estimate's per-image cost came from.
+type Basis string
+
+const (
+	// BasisListPrice is the model's list price from the catalog.
+	BasisListPrice Basis = "list_price"
+	// BasisRecentRuns is the average actual cost of the model's recent runs.
+	BasisRecentRuns Basis = "recent_runs"
+)
+
+// Estimate is the expected cost of staging a number of images with a model.
+type Estimate struct {
+	ModelID         string  `json:"model_id"`
+	Images          int     `json:"images"`
+	CostPerImageUSD float64 `json:"cost_per_image_usd"`
+	CostUSD         float64 `json:"cost_usd"`
+	Credits         int     `json:"credits"`
+	Basis           Basis   `json:"basis"`
+	// SampleSize is how many recent runs the recorded average covers; it is
+	// reported even when too few to use.
+	SampleSize int `json:"sample_size"`
+}
+
+// RecentCost summarizes the actual costs the worker recorded for a model.
+type RecentCost struct {
+	Runs   int
+	AvgUSD float64
+}
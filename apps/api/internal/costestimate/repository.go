@@ -0,0 +1,15 @@
+package costestimate
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for recorded staging costs.
+type Repository interface {
+	// RecentCost averages the actual cost of the model's successful runs since
+	// the given time, across all of its versions.
+	RecentCost(ctx context.Context, modelID string, since time.Time) (*RecentCost, error)
+}
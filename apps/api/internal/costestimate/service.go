@@ -0,0 +1,11 @@
+package costestimate
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for staging cost estimates.
+type Service interface {
+	// Estimate prices staging the given number of images with the model.
+	Estimate(ctx context.Context, modelID string, images int) (*Estimate, error)
+}
@@ -0,0 +1,104 @@
+package credential
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// CodeNotConfigured is the problem code returned when keys can't be saved
+// because the API has no encryption key.
+const CodeNotConfigured = "encryption_not_configured"
+
+// DefaultHandler serves the user credentials endpoints.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// ListCredentials handles GET /api/v1/user/credentials.
+func (h *DefaultHandler) ListCredentials(c echo.Context) error {
+	userID, p := h.currentUserID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	creds, err := h.service.List(c.Request().Context(), userID)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list credentials")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"credentials": creds})
+}
+
+// SaveCredential handles POST /api/v1/user/credentials.
+func (h *DefaultHandler) SaveCredential(c echo.Context) error {
+	userID, p := h.currentUserID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	var req SaveRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	cred, err := h.service.Save(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to save credential")
+	}
+	return c.JSON(http.StatusOK, cred)
+}
+
+// DeleteCredential handles DELETE /api/v1/user/credentials/:provider.
+func (h *DefaultHandler) DeleteCredential(c echo.Context) error {
+	userID, p := h.currentUserID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	if err := h.service.Delete(c.Request().Context(), userID, c.Param("provider")); err != nil {
+		return h.serviceError(c, err, "Failed to delete credential")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *DefaultHandler) currentUserID(c echo.Context) (string, *problem.Problem) {
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not found")
+	}
+	return userRow.ID.String(), nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, ErrInvalidCredential):
+		return problem.Write(c, http.StatusUnprocessableEntity, problem.CodeValidationFailed, err.Error())
+	case errors.Is(err, ErrNotFound):
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "No key is stored for this provider")
+	case errors.Is(err, ErrNotConfigured):
+		return problem.Write(c, http.StatusServiceUnavailable, CodeNotConfigured,
+			"Keys can't be saved until ENCRYPTION_KEY is configured")
+	}
+	h.log.Error(c.Request().Context(), "credential request failed", "error", err)
+	return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, message)
+}
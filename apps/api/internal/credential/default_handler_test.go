@@ -0,0 +1,157 @@
+package credential
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newTestHandler(svc Service, userErr error) *DefaultHandler {
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			if userErr != nil {
+				return nil, userErr
+			}
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: uuid.MustParse(testUserID), Valid: true}}, nil
+		},
+	}
+	return NewDefaultHandler(svc, userRepo, logging.Default())
+}
+
+func newRequestContext(method, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/api/v1/user/credentials", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_ListCredentials(t *testing.T) {
+	cases := []struct {
+		name       string
+		userErr    error
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success: lists masked keys", wantStatus: http.StatusOK},
+		{name: "fail: unknown user", userErr: errors.New("no rows"), wantStatus: http.StatusUnauthorized},
+		{name: "fail: service error", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListFunc: func(ctx context.Context, userID string) ([]Credential, error) {
+					assert.Equal(t, testUserID, userID)
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					return []Credential{{Provider: "openai", Hint: "1234", UpdatedAt: testNow}}, nil
+				},
+			}
+			c, rec := newRequestContext(http.MethodGet, "")
+
+			require.NoError(t, newTestHandler(svc, tc.userErr).ListCredentials(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"credentials":[{"provider":"openai","hint":"1234"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_SaveCredential(t *testing.T) {
+	valid := fmt.Sprintf(`{"provider":"openai","api_key":%q}`, testKey)
+
+	cases := []struct {
+		name       string
+		body       string
+		svcErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "success: returns the masked key", body: valid, wantStatus: http.StatusOK, wantBody: `"hint":"1234"`},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: invalid key",
+			body:       valid,
+			svcErr:     fmt.Errorf("%w: OpenAI API keys start with sk-", ErrInvalidCredential),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "start with sk-",
+		},
+		{
+			name:       "fail: not configured",
+			body:       valid,
+			svcErr:     ErrNotConfigured,
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   CodeNotConfigured,
+		},
+		{name: "fail: service error", body: valid, svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				SaveFunc: func(ctx context.Context, userID string, req SaveRequest) (*Credential, error) {
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					assert.Equal(t, SaveRequest{Provider: "openai", APIKey: testKey}, req)
+					return &Credential{Provider: req.Provider, Hint: hint(req.APIKey)}, nil
+				},
+			}
+			c, rec := newRequestContext(http.MethodPost, tc.body)
+
+			require.NoError(t, newTestHandler(svc, nil).SaveCredential(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.NotContains(t, rec.Body.String(), testKey)
+			if tc.wantBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_DeleteCredential(t *testing.T) {
+	cases := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success: removes the key", wantStatus: http.StatusNoContent},
+		{name: "fail: no key stored", svcErr: ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: service error", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DeleteFunc: func(ctx context.Context, userID, provider string) error {
+					assert.Equal(t, testUserID, userID)
+					assert.Equal(t, "openai", provider)
+					return tc.svcErr
+				},
+			}
+			c, rec := newRequestContext(http.MethodDelete, "")
+			c.SetParamNames("provider")
+			c.SetParamValues("openai")
+
+			require.NoError(t, newTestHandler(svc, nil).DeleteCredential(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package credential
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// List returns the user's stored keys.
+func (r *DefaultRepository) List(ctx context.Context, userID string) ([]Record, error) {
+	query := `
+		SELECT provider, ciphertext, hint, created_at, updated_at
+		FROM user_credentials
+		WHERE user_id = $1
+		ORDER BY provider`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credentials: %w", err)
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		var rec Record
+		if err := rows.Scan(&rec.Provider, &rec.Ciphertext, &rec.Hint, &rec.CreatedAt, &rec.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan credential: %w", err)
+		}
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over credential rows: %w", err)
+	}
+	return records, nil
+}
+
+// Upsert replaces the key in place, so its created_at is kept.
+func (r *DefaultRepository) Upsert(ctx context.Context, userID string, rec Record) (*Record, error) {
+	query := `
+		INSERT INTO user_credentials (user_id, provider, ciphertext, hint)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, provider)
+		DO UPDATE SET ciphertext = EXCLUDED.ciphertext, hint = EXCLUDED.hint, updated_at = now()
+		RETURNING provider, ciphertext, hint, created_at, updated_at`
+
+	var saved Record
+	err := r.db.QueryRow(ctx, query, userID, rec.Provider, rec.Ciphertext, rec.Hint).
+		Scan(&saved.Provider, &saved.Ciphertext, &saved.Hint, &saved.CreatedAt, &saved.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save credential: %w", err)
+	}
+	return &saved, nil
+}
+
+// Delete removes the user's key for the provider.
+func (r *DefaultRepository) Delete(ctx context.Context, userID, provider string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM user_credentials WHERE user_id = $1 AND provider = $2`, userID, provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package credential
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/real-staging-ai/api/encryption"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo    Repository
+	keyring *encryption.Keyring
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. keyring may be nil when no
+// encryption key is configured; keys can then be listed and deleted but not
+// saved.
+func NewDefaultService(repo Repository, keyring *encryption.Keyring) *DefaultService {
+	return &DefaultService{repo: repo, keyring: keyring}
+}
+
+// List masks every stored key. Providers no longer in Providers are left out.
+func (s *DefaultService) List(ctx context.Context, userID string) ([]Credential, error) {
+	records, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	creds := []Credential{}
+	for _, rec := range records {
+		if slices.Contains(Providers, rec.Provider) {
+			creds = append(creds, rec.credential())
+		}
+	}
+	return creds, nil
+}
+
+// Save checks the key's shape, not that the provider accepts it: a revoked
+// key shows up as a failed job, like a revoked key in the model config.
+func (s *DefaultService) Save(ctx context.Context, userID string, req SaveRequest) (*Credential, error) {
+	key := strings.TrimSpace(req.APIKey)
+	if err := validate(req.Provider, key); err != nil {
+		return nil, err
+	}
+	if s.keyring == nil {
+		return nil, ErrNotConfigured
+	}
+
+	ciphertext, err := s.keyring.Seal([]byte(key), aad(userID, req.Provider))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt %s key: %w", req.Provider, err)
+	}
+	rec, err := s.repo.Upsert(ctx, userID, Record{Provider: req.Provider, Ciphertext: ciphertext, Hint: hint(key)})
+	if err != nil {
+		return nil, err
+	}
+	cred := rec.credential()
+	return &cred, nil
+}
+
+// Delete removes the user's key for the provider.
+func (s *DefaultService) Delete(ctx context.Context, userID, provider string) error {
+	if !slices.Contains(Providers, provider) {
+		return ErrNotFound
+	}
+	return s.repo.Delete(ctx, userID, provider)
+}
+
+// validate checks the provider is known and the key looks like one of its keys.
+func validate(provider, key string) error {
+	if !slices.Contains(Providers, provider) {
+		return fmt.Errorf("%w: provider must be one of: %s", ErrInvalidCredential, strings.Join(Providers, ", "))
+	}
+	switch {
+	case key == "":
+		return fmt.Errorf("%w: api_key is required", ErrInvalidCredential)
+	case len(key) < minKeyLength || len(key) > maxKeyLength:
+		return fmt.Errorf("%w: api_key must be %d to %d characters", ErrInvalidCredential, minKeyLength, maxKeyLength)
+	case strings.ContainsAny(key, " \t\r\n"):
+		return fmt.Errorf("%w: api_key must not contain whitespace", ErrInvalidCredential)
+	case provider == ProviderOpenAI && !strings.HasPrefix(key, "sk-"):
+		return fmt.Errorf("%w: OpenAI API keys start with sk-", ErrInvalidCredential)
+	}
+	return nil
+}
+
+// hint returns the last four characters of key, which validate has checked
+// is long enough that they don't give it away.
+func hint(key string) string {
+	return key[len(key)-4:]
+}
+
+// aad binds a ciphertext to its owner and provider, so rows can't be swapped
+// between users or providers in the database. The worker builds the same
+// value to open keys.
+func aad(userID, provider string) []byte {
+	return []byte("user_credentials:" + userID + ":" + provider)
+}
@@ -0,0 +1,118 @@
+package credential
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/encryption"
+)
+
+const (
+	testUserID = "6f1c2a9e-5b7d-4a3c-9e8f-1a2b3c4d5e6f"
+	testKey    = "sk-proj-abcdefghijklmnop1234"
+)
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func testKeyring(t *testing.T) *encryption.Keyring {
+	t.Helper()
+	kr, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, encryption.KeySize)))
+	require.NoError(t, err)
+	return kr
+}
+
+func TestDefaultService_Save(t *testing.T) {
+	ctx := context.Background()
+	kr := testKeyring(t)
+	echoUpsert := func(ctx context.Context, userID string, rec Record) (*Record, error) {
+		rec.CreatedAt, rec.UpdatedAt = testNow, testNow
+		return &rec, nil
+	}
+
+	t.Run("success: stores the key sealed", func(t *testing.T) {
+		var stored Record
+		repo := &RepositoryMock{UpsertFunc: func(ctx context.Context, userID string, rec Record) (*Record, error) {
+			assert.Equal(t, testUserID, userID)
+			stored = rec
+			return echoUpsert(ctx, userID, rec)
+		}}
+
+		req := SaveRequest{Provider: "openai", APIKey: " " + testKey + "\n"}
+		cred, err := NewDefaultService(repo, kr).Save(ctx, testUserID, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Credential{Provider: "openai", Hint: "1234", CreatedAt: testNow, UpdatedAt: testNow}, cred)
+		assert.NotContains(t, string(stored.Ciphertext), testKey)
+		plaintext, err := kr.Open(stored.Ciphertext, aad(testUserID, "openai"))
+		require.NoError(t, err)
+		assert.Equal(t, testKey, string(plaintext))
+
+		_, err = kr.Open(stored.Ciphertext, aad("another-user", "openai"))
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: no keyring", func(t *testing.T) {
+		req := SaveRequest{Provider: "openai", APIKey: testKey}
+		_, err := NewDefaultService(&RepositoryMock{}, nil).Save(ctx, testUserID, req)
+		assert.ErrorIs(t, err, ErrNotConfigured)
+	})
+
+	invalid := []struct {
+		name string
+		req  SaveRequest
+		want string
+	}{
+		{name: "fail: unknown provider", req: SaveRequest{Provider: "anthropic", APIKey: testKey}, want: "provider"},
+		{name: "fail: missing key", req: SaveRequest{Provider: "openai", APIKey: "  "}, want: "api_key is required"},
+		{name: "fail: short key", req: SaveRequest{Provider: "openai", APIKey: "sk-1234"}, want: "20 to 512"},
+		{
+			name: "fail: key with spaces",
+			req:  SaveRequest{Provider: "openai", APIKey: "sk-abcdefgh ijklmnop1234"},
+			want: "whitespace",
+		},
+		{
+			name: "fail: not an OpenAI key",
+			req:  SaveRequest{Provider: "openai", APIKey: "r8_abcdefghijklmnop1234"},
+			want: "sk-",
+		},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewDefaultService(&RepositoryMock{}, kr).Save(ctx, testUserID, tc.req)
+
+			assert.ErrorIs(t, err, ErrInvalidCredential)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestDefaultService_List(t *testing.T) {
+	repo := &RepositoryMock{ListFunc: func(ctx context.Context, userID string) ([]Record, error) {
+		return []Record{
+			{Provider: "openai", Ciphertext: []byte("sealed"), Hint: "1234", UpdatedAt: testNow},
+			{Provider: "retired", Ciphertext: []byte("sealed"), Hint: "9999"},
+		}, nil
+	}}
+
+	creds, err := NewDefaultService(repo, nil).List(context.Background(), testUserID)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Credential{{Provider: "openai", Hint: "1234", UpdatedAt: testNow}}, creds)
+}
+
+func TestDefaultService_Delete(t *testing.T) {
+	repo := &RepositoryMock{DeleteFunc: func(ctx context.Context, userID, provider string) error {
+		return ErrNotFound
+	}}
+	svc := NewDefaultService(repo, nil)
+
+	assert.ErrorIs(t, svc.Delete(context.Background(), testUserID, "openai"), ErrNotFound)
+	assert.ErrorIs(t, svc.Delete(context.Background(), testUserID, "unknown"), ErrNotFound)
+	assert.Len(t, repo.DeleteCalls(), 1)
+}
@@ -0,0 +1,17 @@
+package credential
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for users' provider API keys.
+type Handler interface {
+	// ListCredentials handles GET /user/credentials - Lists the user's keys, masked.
+	ListCredentials(c echo.Context) error
+
+	// SaveCredential handles POST /user/credentials - Stores a key for a provider.
+	SaveCredential(c echo.Context) error
+
+	// DeleteCredential handles DELETE /user/credentials/:provider - Removes a key.
+	DeleteCredential(c echo.Context) error
+}
@@ -0,0 +1,64 @@
+// Package credential stores API keys users bring for the providers that run
+// staging models, so each user of a deployment can stage with GPT Image on
+// their own OpenAI account instead of one key shared through the model
+// config.
+//
+// Keys are encrypted at rest with the API's encryption keyring. The API never
+// returns them: responses show only a hint of the last four characters. The
+// worker decrypts a key when it runs a job for the key's owner.
+package credential
+
+import (
+	"errors"
+	"time"
+)
+
+// ProviderOpenAI is the provider of the GPT Image models.
+const ProviderOpenAI = "openai"
+
+// Providers lists the providers users can store a key for.
+var Providers = []string{ProviderOpenAI}
+
+// Bounds on a submitted key's length. Provider keys fall well inside them.
+const (
+	minKeyLength = 20
+	maxKeyLength = 512
+)
+
+var (
+	// ErrNotConfigured is returned when no encryption key is configured.
+	ErrNotConfigured = errors.New("credential storage is not configured")
+	// ErrNotFound is returned when the user has no key for the provider.
+	ErrNotFound = errors.New("credential not found")
+	// ErrInvalidCredential is returned when a save request fails validation.
+	ErrInvalidCredential = errors.New("invalid credential")
+)
+
+// Credential describes a stored key without revealing it.
+type Credential struct {
+	Provider string `json:"provider"`
+	// Hint is the key's last four characters, to tell keys apart.
+	Hint      string    `json:"hint"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SaveRequest is the body of POST /api/v1/user/credentials. It replaces the
+// user's key for the provider, if any.
+type SaveRequest struct {
+	Provider string `json:"provider"`
+	APIKey   string `json:"api_key"`
+}
+
+// Record is one encrypted key as stored.
+type Record struct {
+	Provider   string
+	Ciphertext []byte
+	Hint       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (r Record) credential() Credential {
+	return Credential{Provider: r.Provider, Hint: r.Hint, CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt}
+}
@@ -0,0 +1,18 @@
+package credential
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for encrypted provider keys.
+type Repository interface {
+	// List returns the user's stored keys, ordered by provider.
+	List(ctx context.Context, userID string) ([]Record, error)
+
+	// Upsert stores the user's key for rec.Provider and returns the stored row.
+	Upsert(ctx context.Context, userID string, rec Record) (*Record, error)
+
+	// Delete removes the user's key for the provider. Returns ErrNotFound when
+	// there is none.
+	Delete(ctx context.Context, userID, provider string) error
+}
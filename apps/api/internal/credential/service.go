@@ -0,0 +1,20 @@
+package credential
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for users' provider API keys.
+type Service interface {
+	// List returns the user's stored keys, masked, ordered by provider.
+	List(ctx context.Context, userID string) ([]Credential, error)
+
+	// Save encrypts and stores the key, replacing the user's key for the
+	// provider. Returns ErrInvalidCredential for an unknown provider or a
+	// malformed key and ErrNotConfigured when no encryption key is set.
+	Save(ctx context.Context, userID string, req SaveRequest) (*Credential, error)
+
+	// Delete removes the user's key for the provider. Returns ErrNotFound
+	// when there is none.
+	Delete(ctx context.Context, userID, provider string) error
+}
@@ -0,0 +1,140 @@
+package credit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+//
+// Each change inserts its ledger entry and applies it to the balance in one
+// statement, so the two can't disagree. The ledger's unique indexes make
+// repeated purchases, debits and refunds no-ops.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// Balance returns the user's current balance.
+func (r *DefaultRepository) Balance(ctx context.Context, userID string) (int32, error) {
+	var balance int32
+	err := r.db.QueryRow(ctx,
+		`SELECT COALESCE((SELECT balance FROM credit_balances WHERE user_id = $1), 0)`, userID,
+	).Scan(&balance)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get credit balance: %w", err)
+	}
+	return balance, nil
+}
+
+// ListEntries returns the user's ledger, newest first.
+func (r *DefaultRepository) ListEntries(ctx context.Context, userID string, limit int32) ([]Entry, error) {
+	query := `
+		SELECT id::text, delta, reason, image_id::text, stripe_checkout_session_id, created_at
+		FROM credit_ledger
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2`
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []Entry{}
+	for rows.Next() {
+		var e Entry
+		var reason string
+		if err := rows.Scan(&e.ID, &e.Delta, &reason, &e.ImageID, &e.CheckoutSessionID, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan credit entry: %w", err)
+		}
+		e.Reason = Reason(reason)
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over credit entry rows: %w", err)
+	}
+	return entries, nil
+}
+
+// AddPurchase records the purchase and adds its credits, creating the balance on first purchase.
+func (r *DefaultRepository) AddPurchase(
+	ctx context.Context, userID string, credits int32, checkoutSessionID string,
+) (bool, error) {
+	query := `
+		WITH purchase AS (
+			INSERT INTO credit_ledger (user_id, delta, reason, stripe_checkout_session_id)
+			VALUES ($1, $2, 'purchase', $3)
+			ON CONFLICT (stripe_checkout_session_id) WHERE stripe_checkout_session_id IS NOT NULL DO NOTHING
+			RETURNING user_id, delta
+		)
+		INSERT INTO credit_balances (user_id, balance)
+		SELECT user_id, delta FROM purchase
+		ON CONFLICT (user_id) DO UPDATE
+		SET balance = credit_balances.balance + EXCLUDED.balance, updated_at = now()
+		RETURNING balance`
+	return r.apply(ctx, "add credit purchase", query, userID, credits, checkoutSessionID)
+}
+
+// Debit records the debit and takes the credit off the balance.
+func (r *DefaultRepository) Debit(ctx context.Context, userID, imageID string) (bool, error) {
+	query := `
+		WITH debit AS (
+			INSERT INTO credit_ledger (user_id, image_id, delta, reason)
+			SELECT user_id, $2, -1, 'debit' FROM credit_balances WHERE user_id = $1 AND balance > 0
+			ON CONFLICT (image_id, reason) WHERE image_id IS NOT NULL DO NOTHING
+			RETURNING user_id
+		)
+		UPDATE credit_balances b
+		SET balance = b.balance - 1, updated_at = now()
+		FROM debit
+		WHERE b.user_id = debit.user_id
+		RETURNING b.balance`
+	debited, err := r.apply(ctx, "debit credit", query, userID, imageID)
+	if storage.IsCheckViolation(err) {
+		// Another image took the last credit first.
+		return false, nil
+	}
+	return debited, err
+}
+
+// Refund records the refund and gives the credit back to whoever was debited.
+func (r *DefaultRepository) Refund(ctx context.Context, imageID string) (bool, error) {
+	query := `
+		WITH refund AS (
+			INSERT INTO credit_ledger (user_id, image_id, delta, reason)
+			SELECT user_id, image_id, 1, 'refund' FROM credit_ledger WHERE image_id = $1 AND reason = 'debit'
+			ON CONFLICT (image_id, reason) WHERE image_id IS NOT NULL DO NOTHING
+			RETURNING user_id
+		)
+		UPDATE credit_balances b
+		SET balance = b.balance + 1, updated_at = now()
+		FROM refund
+		WHERE b.user_id = refund.user_id
+		RETURNING b.balance`
+	return r.apply(ctx, "refund credit", query, imageID)
+}
+
+// apply runs a statement that returns the new balance when it changed anything.
+func (r *DefaultRepository) apply(ctx context.Context, action, query string, args ...any) (bool, error) {
+	var balance int32
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&balance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to %s: %w", action, err)
+	}
+	return true, nil
+}
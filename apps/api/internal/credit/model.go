@@ -0,0 +1,42 @@
+// Package credit keeps the image credit balances of users who pay per image
+// instead of subscribing.
+//
+// Credits are bought in packs through Stripe Checkout. Once a user without a
+// subscription has used their free monthly allowance, each image they create
+// spends one credit, and a credit spent on an image that fails to stage is
+// given back. Every change is recorded in a ledger next to the balance.
+package credit
+
+import "time"
+
+// Checkout Session metadata keys of credit pack purchases, read back by the
+// Stripe webhook when the payment completes.
+const (
+	MetadataUserID  = "user_id"
+	MetadataPack    = "credit_pack"
+	MetadataCredits = "credits"
+)
+
+// Reason says why a balance changed.
+type Reason string
+
+const (
+	// ReasonPurchase adds the credits of a paid Checkout Session.
+	ReasonPurchase Reason = "purchase"
+	// ReasonDebit spends a credit on a new image.
+	ReasonDebit Reason = "debit"
+	// ReasonRefund returns the credit spent on an image that failed to stage.
+	ReasonRefund Reason = "refund"
+)
+
+// Entry is one change to a user's balance.
+type Entry struct {
+	ID     string `json:"id"`
+	Delta  int32  `json:"delta"`
+	Reason Reason `json:"reason"`
+	// ImageID is the image a debit paid for or a refund returned.
+	ImageID *string `json:"image_id,omitempty"`
+	// CheckoutSessionID is the Stripe Checkout Session a purchase was paid with.
+	CheckoutSessionID *string   `json:"stripe_checkout_session_id,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
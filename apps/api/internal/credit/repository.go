@@ -0,0 +1,26 @@
+package credit
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for credit balances and their ledger.
+type Repository interface {
+	// Balance returns the user's credits; 0 when they never bought any.
+	Balance(ctx context.Context, userID string) (int32, error)
+
+	// ListEntries returns the user's most recent ledger entries, newest first.
+	ListEntries(ctx context.Context, userID string, limit int32) ([]Entry, error)
+
+	// AddPurchase adds the credits bought with a Checkout Session. It reports
+	// false, changing nothing, when the session was already credited.
+	AddPurchase(ctx context.Context, userID string, credits int32, checkoutSessionID string) (bool, error)
+
+	// Debit spends one of the user's credits on the image. It reports false,
+	// changing nothing, when the user has no credits or the image was already debited.
+	Debit(ctx context.Context, userID, imageID string) (bool, error)
+
+	// Refund returns the credit debited for the image. It reports false,
+	// changing nothing, when the image wasn't debited or was already refunded.
+	Refund(ctx context.Context, imageID string) (bool, error)
+}
@@ -0,0 +1,243 @@
+// Package crop computes aspect-ratio crop suggestions that keep the most
+// visually salient part of an image in frame.
+//
+// Saliency is estimated with a cheap heuristic rather than a model: the image
+// is downsampled to a small luminance grid, gradient magnitude is used as a
+// proxy for detail (furniture, fixtures, windows) and a mild center bias keeps
+// crops from drifting onto busy edges such as ceilings or floor trim. For each
+// requested ratio the largest crop of that aspect that fits is slid along the
+// free axis and the window retaining the most saliency wins.
+package crop
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// maxGridSide bounds the long side of the saliency grid.
+const maxGridSide = 128
+
+// samplesPerCell bounds how many pixels are read per grid cell on each axis.
+const samplesPerCell = 4
+
+// centerBias is how strongly saliency is attenuated toward the image corners.
+const centerBias = 0.5
+
+// Ratio is a target aspect ratio such as 16:9.
+type Ratio struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+var (
+	// Ratio16x9 is the widescreen listing hero aspect.
+	Ratio16x9 = Ratio{Name: "16:9", Width: 16, Height: 9}
+	// Ratio4x3 is the classic MLS photo aspect.
+	Ratio4x3 = Ratio{Name: "4:3", Width: 4, Height: 3}
+	// Ratio1x1 is the square thumbnail aspect used by social and grid views.
+	Ratio1x1 = Ratio{Name: "1:1", Width: 1, Height: 1}
+)
+
+// DefaultRatios are the ratios suggested when the caller does not ask for specific ones.
+var DefaultRatios = []Ratio{Ratio16x9, Ratio4x3, Ratio1x1}
+
+// ParseRatio parses a ratio in W:H form, e.g. "16:9".
+func ParseRatio(s string) (Ratio, error) {
+	var w, h int
+	if _, err := fmt.Sscanf(s, "%d:%d", &w, &h); err != nil || w <= 0 || h <= 0 {
+		return Ratio{}, fmt.Errorf("invalid aspect ratio %q", s)
+	}
+	return Ratio{Name: fmt.Sprintf("%d:%d", w, h), Width: w, Height: h}, nil
+}
+
+// Rect is a rectangle expressed as fractions (0..1) of the image dimensions,
+// so it can be applied to any rendition of the same image.
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Suggestion is a crop rectangle for one aspect ratio.
+type Suggestion struct {
+	// Ratio is the aspect ratio name, e.g. "16:9".
+	Ratio string `json:"ratio"`
+	// X, Y, Width and Height are in source pixels.
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Normalized is the same rectangle relative to the image size.
+	Normalized Rect `json:"normalized"`
+	// Score is the share of the image's saliency retained by the crop (0..1).
+	Score float64 `json:"score"`
+}
+
+// Suggest returns one crop suggestion per ratio, in the order given.
+func Suggest(img image.Image, ratios []Ratio) []Suggestion {
+	b := img.Bounds()
+	if b.Dx() <= 0 || b.Dy() <= 0 {
+		return nil
+	}
+	if len(ratios) == 0 {
+		ratios = DefaultRatios
+	}
+
+	m := newSaliencyMap(img)
+	out := make([]Suggestion, 0, len(ratios))
+	for _, r := range ratios {
+		out = append(out, m.best(r))
+	}
+	return out
+}
+
+// saliencyMap is a downsampled saliency grid with a summed-area table.
+type saliencyMap struct {
+	width, height int     // source image size in pixels
+	gw, gh        int     // grid size in cells
+	cell          float64 // source pixels per grid cell
+	sum           []float64
+}
+
+func newSaliencyMap(img image.Image) *saliencyMap {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	cell := math.Max(1, float64(max(w, h))/maxGridSide)
+	gw := max(1, int(math.Round(float64(w)/cell)))
+	gh := max(1, int(math.Round(float64(h)/cell)))
+
+	lum := make([]float64, gw*gh)
+	for gy := 0; gy < gh; gy++ {
+		for gx := 0; gx < gw; gx++ {
+			lum[gy*gw+gx] = cellLuminance(img, b, gx, gy, cell)
+		}
+	}
+
+	m := &saliencyMap{width: w, height: h, gw: gw, gh: gh, cell: cell, sum: make([]float64, (gw+1)*(gh+1))}
+	for gy := 0; gy < gh; gy++ {
+		for gx := 0; gx < gw; gx++ {
+			v := gradient(lum, gw, gh, gx, gy) * bias(gx, gy, gw, gh)
+			s := gw + 1
+			m.sum[(gy+1)*s+gx+1] = v + m.sum[gy*s+gx+1] + m.sum[(gy+1)*s+gx] - m.sum[gy*s+gx]
+		}
+	}
+	return m
+}
+
+// cellLuminance averages the luminance of a few pixels inside a grid cell.
+func cellLuminance(img image.Image, b image.Rectangle, gx, gy int, cell float64) float64 {
+	x0, y0 := float64(gx)*cell, float64(gy)*cell
+	steps := min(samplesPerCell, int(math.Ceil(cell)))
+	var total float64
+	var n int
+	for sy := 0; sy < steps; sy++ {
+		py := b.Min.Y + min(b.Dy()-1, int(y0+(float64(sy)+0.5)*cell/float64(steps)))
+		for sx := 0; sx < steps; sx++ {
+			px := b.Min.X + min(b.Dx()-1, int(x0+(float64(sx)+0.5)*cell/float64(steps)))
+			r, g, bl, _ := img.At(px, py).RGBA()
+			total += (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)) / 0xffff
+			n++
+		}
+	}
+	return total / float64(n)
+}
+
+// gradient returns the central-difference gradient magnitude at a grid cell.
+func gradient(lum []float64, gw, gh, x, y int) float64 {
+	at := func(x, y int) float64 {
+		x = min(max(x, 0), gw-1)
+		y = min(max(y, 0), gh-1)
+		return lum[y*gw+x]
+	}
+	dx := at(x+1, y) - at(x-1, y)
+	dy := at(x, y+1) - at(x, y-1)
+	return math.Hypot(dx, dy)
+}
+
+// bias attenuates saliency with distance from the image center.
+func bias(x, y, gw, gh int) float64 {
+	dx := (float64(x)+0.5)/float64(gw)*2 - 1
+	dy := (float64(y)+0.5)/float64(gh)*2 - 1
+	return 1 - centerBias*(dx*dx+dy*dy)/2
+}
+
+// area returns the saliency inside grid cells [x0,x1) x [y0,y1).
+func (m *saliencyMap) area(x0, y0, x1, y1 int) float64 {
+	s := m.gw + 1
+	return m.sum[y1*s+x1] - m.sum[y0*s+x1] - m.sum[y1*s+x0] + m.sum[y0*s+x0]
+}
+
+// best finds the largest crop of ratio r that retains the most saliency.
+func (m *saliencyMap) best(r Ratio) Suggestion {
+	cw, ch := m.width, m.height
+	if m.width*r.Height > m.height*r.Width {
+		cw = m.height * r.Width / r.Height
+	} else {
+		ch = m.width * r.Height / r.Width
+	}
+	cw, ch = max(cw, 1), max(ch, 1)
+
+	total := m.area(0, 0, m.gw, m.gh)
+	horizontal := cw < m.width
+	span, free := m.gh, m.width-cw
+	window := int(math.Round(float64(cw) / m.cell))
+	cells := m.gw
+	if !horizontal {
+		span, free = m.gw, m.height-ch
+		window = int(math.Round(float64(ch) / m.cell))
+		cells = m.gh
+	}
+	window = min(max(window, 1), cells)
+
+	// Start from the centered window so ties (e.g. flat images) stay centered.
+	bestPos := (cells - window) / 2
+	bestScore := m.windowScore(horizontal, bestPos, window, span)
+	for pos := 0; pos <= cells-window; pos++ {
+		if s := m.windowScore(horizontal, pos, window, span); s > bestScore {
+			bestPos, bestScore = pos, s
+		}
+	}
+
+	offset := 0
+	if free > 0 && cells > window {
+		offset = int(math.Round(float64(bestPos) / float64(cells-window) * float64(free)))
+		offset = min(max(offset, 0), free)
+	}
+	x, y := 0, 0
+	if horizontal {
+		x = offset
+	} else {
+		y = offset
+	}
+
+	// A featureless image has no saliency to retain; fall back to the area share.
+	score := float64(cw*ch) / float64(m.width*m.height)
+	if total > 0 {
+		score = bestScore / total
+	}
+	return Suggestion{
+		Ratio:  r.Name,
+		X:      x,
+		Y:      y,
+		Width:  cw,
+		Height: ch,
+		Normalized: Rect{
+			X:      float64(x) / float64(m.width),
+			Y:      float64(y) / float64(m.height),
+			Width:  float64(cw) / float64(m.width),
+			Height: float64(ch) / float64(m.height),
+		},
+		Score: math.Round(score*1000) / 1000,
+	}
+}
+
+func (m *saliencyMap) windowScore(horizontal bool, pos, window, span int) float64 {
+	if horizontal {
+		return m.area(pos, 0, pos+window, span)
+	}
+	return m.area(0, pos, span, pos+window)
+}
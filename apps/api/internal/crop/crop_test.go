@@ -0,0 +1,117 @@
+package crop
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkerAt returns a flat grey image with a high-contrast checkerboard patch.
+func checkerAt(w, h int, patch image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+			if (image.Point{X: x, Y: y}).In(patch) && ((x/8)+(y/8))%2 == 0 {
+				c = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+			} else if (image.Point{X: x, Y: y}).In(patch) {
+				c = color.RGBA{A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestSuggest(t *testing.T) {
+	t.Run("success: default ratios keep aspect and stay in bounds", func(t *testing.T) {
+		img := checkerAt(800, 600, image.Rect(300, 200, 500, 400))
+
+		got := Suggest(img, nil)
+		require.Len(t, got, 3)
+		for i, r := range DefaultRatios {
+			s := got[i]
+			assert.Equal(t, r.Name, s.Ratio)
+			assert.InDelta(t, float64(r.Width)/float64(r.Height), float64(s.Width)/float64(s.Height), 0.01)
+			assert.GreaterOrEqual(t, s.X, 0)
+			assert.GreaterOrEqual(t, s.Y, 0)
+			assert.LessOrEqual(t, s.X+s.Width, 800)
+			assert.LessOrEqual(t, s.Y+s.Height, 600)
+			assert.InDelta(t, float64(s.Width)/800, s.Normalized.Width, 1e-9)
+		}
+		// 4:3 on a 4:3 image is the full frame.
+		assert.Equal(t, Suggestion{
+			Ratio: "4:3", Width: 800, Height: 600,
+			Normalized: Rect{Width: 1, Height: 1}, Score: 1,
+		}, got[1])
+	})
+
+	t.Run("success: square crop follows salient region to the right", func(t *testing.T) {
+		img := checkerAt(1200, 400, image.Rect(950, 100, 1150, 300))
+
+		got := Suggest(img, []Ratio{Ratio1x1})
+		require.Len(t, got, 1)
+		s := got[0]
+		assert.Equal(t, 400, s.Width)
+		assert.Equal(t, 400, s.Height)
+		assert.Equal(t, 0, s.Y)
+		assert.LessOrEqual(t, s.X, 950)
+		assert.GreaterOrEqual(t, s.X+s.Width, 1150)
+		assert.Greater(t, s.Score, 0.9)
+	})
+
+	t.Run("success: widescreen crop on portrait follows salient region to the top", func(t *testing.T) {
+		img := checkerAt(600, 1200, image.Rect(100, 40, 500, 280))
+
+		got := Suggest(img, []Ratio{Ratio16x9})
+		require.Len(t, got, 1)
+		s := got[0]
+		assert.Equal(t, 600, s.Width)
+		assert.Equal(t, 337, s.Height)
+		assert.Equal(t, 0, s.X)
+		assert.LessOrEqual(t, s.Y, 40)
+		assert.GreaterOrEqual(t, s.Y+s.Height, 280)
+	})
+
+	t.Run("success: featureless image is centered", func(t *testing.T) {
+		img := checkerAt(1600, 900, image.Rectangle{})
+
+		got := Suggest(img, []Ratio{Ratio1x1})
+		require.Len(t, got, 1)
+		assert.Equal(t, 350, got[0].X)
+		assert.InDelta(t, 0.5625, got[0].Score, 0.001)
+	})
+
+	t.Run("success: empty image yields no suggestions", func(t *testing.T) {
+		assert.Nil(t, Suggest(image.NewRGBA(image.Rectangle{}), nil))
+	})
+}
+
+func TestParseRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Ratio
+		wantErr bool
+	}{
+		{name: "success: widescreen", in: "16:9", want: Ratio16x9},
+		{name: "success: square", in: "1:1", want: Ratio1x1},
+		{name: "fail: missing height", in: "16", wantErr: true},
+		{name: "fail: zero", in: "0:1", wantErr: true},
+		{name: "fail: garbage", in: "wide", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRatio(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package deadletter
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/pagination"
+	"github.com/real-staging-ai/api/internal/queue"
+)
+
+// DefaultHandler serves the admin dead letter endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ListDeadLetters handles GET /admin/dead-letters - Lists dead letters.
+// Supports task_type, image_id, limit and offset query parameters.
+func (h *DefaultHandler) ListDeadLetters(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter := ListFilter{TaskType: c.QueryParam("task_type"), ImageID: c.QueryParam("image_id")}
+	switch filter.TaskType {
+	case "", queue.TaskTypeStageRun, queue.TaskTypeDeclutterRun:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "task_type must be one of: stage:run, declutter:run")
+	}
+
+	var err error
+	if filter.Limit, err = pagination.IntParam(c, "limit"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "limit must be an integer")
+	}
+	if filter.Offset, err = pagination.IntParam(c, "offset"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "offset must be an integer")
+	}
+
+	letters, err := h.service.ListDeadLetters(ctx, filter)
+	if err != nil {
+		h.log.Error(ctx, "failed to list dead letters", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list dead letters")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"dead_letters": letters,
+	})
+}
+
+// GetDeadLetter handles GET /admin/dead-letters/:id - Gets a single dead letter.
+func (h *DefaultHandler) GetDeadLetter(c echo.Context) error {
+	d, err := h.service.GetDeadLetter(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return h.serviceError(c, err, "Failed to get dead letter")
+	}
+
+	return c.JSON(http.StatusOK, d)
+}
+
+// RetryDeadLetter handles POST /admin/dead-letters/:id/retry - Runs a dead letter's task again.
+func (h *DefaultHandler) RetryDeadLetter(c echo.Context) error {
+	retry, err := h.service.RetryDeadLetter(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotRetryable):
+			return echo.NewHTTPError(http.StatusConflict,
+				"The image was deleted, canceled or staged since and cannot be retried")
+		case errors.Is(err, ErrInvalidPayload):
+			return echo.NewHTTPError(http.StatusConflict, "The dead letter's payload is not a staging job")
+		}
+		return h.serviceError(c, err, "Failed to retry dead letter")
+	}
+
+	return c.JSON(http.StatusOK, retry)
+}
+
+// DiscardDeadLetter handles DELETE /admin/dead-letters/:id - Removes a dead letter.
+func (h *DefaultHandler) DiscardDeadLetter(c echo.Context) error {
+	if err := h.service.DiscardDeadLetter(c.Request().Context(), c.Param("id")); err != nil {
+		return h.serviceError(c, err, "Failed to discard dead letter")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// serviceError maps the errors every endpoint shares to a response.
+func (h *DefaultHandler) serviceError(c echo.Context, err error, msg string) error {
+	if errors.Is(err, ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "Dead letter not found")
+	}
+	h.log.Error(c.Request().Context(), "dead letter request failed", "error", err, "dead_letter_id", c.Param("id"))
+	return echo.NewHTTPError(http.StatusInternalServerError, msg)
+}
@@ -0,0 +1,187 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_ListDeadLetters(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		listErr    error
+		wantStatus int
+		wantFilter ListFilter
+	}{
+		{
+			name:       "success: passes filters",
+			query:      "?task_type=declutter:run&image_id=img-1&limit=5&offset=10",
+			wantStatus: http.StatusOK,
+			wantFilter: ListFilter{TaskType: "declutter:run", ImageID: "img-1", Limit: 5, Offset: 10},
+		},
+		{name: "fail: invalid task type", query: "?task_type=delivery:send", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid limit", query: "?limit=abc", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid offset", query: "?offset=abc", wantStatus: http.StatusBadRequest},
+		{name: "fail: service error", listErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListDeadLettersFunc: func(ctx context.Context, filter ListFilter) ([]DeadLetter, error) {
+					assert.Equal(t, tc.wantFilter, filter)
+					return []DeadLetter{}, tc.listErr
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/dead-letters"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			err := h.ListDeadLetters(e.NewContext(req, rec))
+
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"dead_letters":[]`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_GetDeadLetter(t *testing.T) {
+	cases := []struct {
+		name       string
+		getErr     error
+		wantStatus int
+	}{
+		{name: "success: returns dead letter", wantStatus: http.StatusOK},
+		{name: "fail: not found", getErr: ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: service error", getErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetDeadLetterFunc: func(ctx context.Context, id string) (*DeadLetter, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &DeadLetter{ID: id, TaskType: "stage:run", Payload: []byte(`{}`)}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("dl-1")
+			err := h.GetDeadLetter(c)
+
+			if tc.wantStatus != http.StatusOK {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, tc.wantStatus, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, rec.Body.String(), `"id":"dl-1"`)
+		})
+	}
+}
+
+func TestDefaultHandler_RetryDeadLetter(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success: retries", wantStatus: http.StatusOK},
+		{name: "fail: not found", err: ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: image not retryable", err: ErrNotRetryable, wantStatus: http.StatusConflict},
+		{name: "fail: invalid payload", err: ErrInvalidPayload, wantStatus: http.StatusConflict},
+		{name: "fail: service error", err: errors.New("redis down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RetryDeadLetterFunc: func(ctx context.Context, id string) (*Retry, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Retry{ImageID: "img-1", TaskID: "job-2"}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("dl-1")
+			err := h.RetryDeadLetter(c)
+
+			if tc.wantStatus != http.StatusOK {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, tc.wantStatus, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"image_id":"img-1","task_id":"job-2"}`, rec.Body.String())
+		})
+	}
+}
+
+func TestDefaultHandler_DiscardDeadLetter(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success: discards", wantStatus: http.StatusNoContent},
+		{name: "fail: not found", err: ErrNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: service error", err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DiscardDeadLetterFunc: func(ctx context.Context, id string) error { return tc.err },
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("dl-1")
+			err := h.DiscardDeadLetter(c)
+
+			if tc.wantStatus != http.StatusNoContent {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, tc.wantStatus, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, "dl-1", svc.DiscardDeadLetterCalls()[0].ID)
+		})
+	}
+}
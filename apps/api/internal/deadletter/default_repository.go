@@ -0,0 +1,118 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const deadLetterColumns = `
+	id, task_id, task_type, queue, image_id::text, payload, error, attempts, failed_at`
+
+func scanDeadLetter(row pgx.Row) (*DeadLetter, error) {
+	var d DeadLetter
+	var payload []byte
+	err := row.Scan(
+		&d.ID, &d.TaskID, &d.TaskType, &d.Queue, &d.ImageID, &payload, &d.Error, &d.Attempts, &d.FailedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return &d, nil
+}
+
+// List returns dead letters matching the filter, newest first.
+func (r *DefaultRepository) List(ctx context.Context, filter ListFilter) ([]DeadLetter, error) {
+	query := `SELECT` + deadLetterColumns + `
+		FROM dead_letters
+		WHERE ($1 = '' OR task_type = $1)
+		  AND ($2 = '' OR image_id::text = $2)
+		ORDER BY failed_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(ctx, query, filter.TaskType, filter.ImageID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := []DeadLetter{}
+	for rows.Next() {
+		d, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+		}
+		letters = append(letters, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over dead letter rows: %w", err)
+	}
+	return letters, nil
+}
+
+// GetByID retrieves a dead letter by its ID.
+func (r *DefaultRepository) GetByID(ctx context.Context, id string) (*DeadLetter, error) {
+	query := `SELECT` + deadLetterColumns + `
+		FROM dead_letters
+		WHERE id::text = $1`
+
+	d, err := scanDeadLetter(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return d, nil
+}
+
+// Delete removes a dead letter.
+func (r *DefaultRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM dead_letters WHERE id::text = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RequeueImage sets the image back to queued and records the change in its history.
+func (r *DefaultRepository) RequeueImage(ctx context.Context, imageID string) error {
+	query := `
+		WITH requeued AS (
+			UPDATE images SET status = 'queued', error = NULL, error_code = NULL, updated_at = NOW()
+			WHERE id::text = $1 AND deleted_at IS NULL AND status IN ('queued', 'processing', 'error')
+			RETURNING id, status, prompt
+		)
+		INSERT INTO image_events (image_id, status, source, prompt)
+		SELECT id, status, 'api', prompt FROM requeued`
+
+	tag, err := r.db.Exec(ctx, query, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to requeue image: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotRetryable
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/queueadmin"
+)
+
+// DefaultService implements Service using the dead_letters table and the asynq queue.
+type DefaultService struct {
+	repo     Repository
+	jobRepo  job.Repository
+	enqueuer queue.Enqueuer
+	tasks    queueadmin.Service
+	log      logging.Logger
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. Archived tasks are removed
+// from the queue through tasks along with their dead letters.
+func NewDefaultService(
+	cfg *config.Config, repo Repository, jobRepo job.Repository, tasks queueadmin.Service,
+) *DefaultService {
+	// Best-effort build an enqueuer from env or config; fall back to Noop if not configured.
+	var enq queue.Enqueuer
+	if e, err := queue.NewAsynqEnqueuerFromEnv(cfg); err == nil {
+		enq = e
+	} else {
+		enq = queue.NoopEnqueuer{}
+	}
+	return &DefaultService{
+		repo:     repo,
+		jobRepo:  jobRepo,
+		enqueuer: enq,
+		tasks:    tasks,
+		log:      logging.NewDefaultLogger(),
+	}
+}
+
+// ListDeadLetters returns the dead letters matching the filter.
+func (s *DefaultService) ListDeadLetters(ctx context.Context, filter ListFilter) ([]DeadLetter, error) {
+	if filter.Limit <= 0 || filter.Limit > MaxLimit {
+		filter.Limit = DefaultLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// GetDeadLetter retrieves a single dead letter.
+func (s *DefaultService) GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// RetryDeadLetter requeues the dead letter's image and queues its payload as
+// a new task with a fresh job, so the image can be canceled again. The dead
+// letter is only removed once the task is queued; a retry that fails halfway
+// can be repeated.
+func (s *DefaultService) RetryDeadLetter(ctx context.Context, id string) (*Retry, error) {
+	letter, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload queue.StageRunPayload
+	if err := json.Unmarshal(letter.Payload, &payload); err != nil || payload.ImageID == "" {
+		return nil, ErrInvalidPayload
+	}
+	if letter.ImageID == nil {
+		return nil, ErrNotRetryable
+	}
+
+	if err := s.repo.RequeueImage(ctx, *letter.ImageID); err != nil {
+		return nil, err
+	}
+	taskType, enqueue := queue.TaskTypeStageRun, s.enqueuer.EnqueueStageRun
+	if letter.TaskType == queue.TaskTypeDeclutterRun {
+		taskType, enqueue = queue.TaskTypeDeclutterRun, s.enqueuer.EnqueueDeclutterRun
+	}
+	jobRow, err := s.jobRepo.CreateJob(ctx, *letter.ImageID, taskType, letter.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	var opts *queue.EnqueueOpts
+	if jobRow != nil && jobRow.ID.Valid {
+		opts = &queue.EnqueueOpts{TaskID: uuid.UUID(jobRow.ID.Bytes).String(), Retry: -1}
+	}
+	taskID, err := enqueue(ctx, payload, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue %s: %w", taskType, err)
+	}
+
+	if err := s.remove(ctx, letter); err != nil {
+		return nil, err
+	}
+	return &Retry{ImageID: *letter.ImageID, TaskID: taskID}, nil
+}
+
+// DiscardDeadLetter removes the dead letter without running it again.
+func (s *DefaultService) DiscardDeadLetter(ctx context.Context, id string) error {
+	letter, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.remove(ctx, letter)
+}
+
+// remove deletes the dead letter and the archived task asynq keeps for it.
+// Failing to delete the task is only logged: it no longer runs and asynq
+// drops archived tasks on its own.
+func (s *DefaultService) remove(ctx context.Context, letter *DeadLetter) error {
+	if err := s.repo.Delete(ctx, letter.ID); err != nil {
+		return err
+	}
+	err := s.tasks.DeleteTask(ctx, letter.Queue, letter.TaskID)
+	if err != nil && !errors.Is(err, queueadmin.ErrTaskNotFound) && !errors.Is(err, queueadmin.ErrUnavailable) {
+		s.log.Warn(ctx, "failed to delete archived task", "task_id", letter.TaskID, "error", err)
+	}
+	return nil
+}
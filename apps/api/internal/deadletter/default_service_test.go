@@ -0,0 +1,227 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/queueadmin"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func newTestService(
+	repo Repository, jobRepo job.Repository, enq queue.Enqueuer, tasks queueadmin.Service,
+) *DefaultService {
+	return &DefaultService{repo: repo, jobRepo: jobRepo, enqueuer: enq, tasks: tasks, log: logging.Default()}
+}
+
+func testLetter(taskType string) *DeadLetter {
+	imageID := "img-1"
+	return &DeadLetter{
+		ID:       "dl-1",
+		TaskID:   "job-1",
+		TaskType: taskType,
+		Queue:    "critical",
+		ImageID:  &imageID,
+		Payload:  []byte(`{"image_id":"img-1","original_url":"s3://o.jpg","priority":"critical"}`),
+		Error:    "provider down",
+	}
+}
+
+func okTasks() *queueadmin.ServiceMock {
+	return &queueadmin.ServiceMock{
+		DeleteTaskFunc: func(ctx context.Context, queue, id string) error { return nil },
+	}
+}
+
+func TestDefaultService_ListDeadLetters(t *testing.T) {
+	cases := []struct {
+		name      string
+		filter    ListFilter
+		wantLimit int
+	}{
+		{name: "success: default limit", filter: ListFilter{}, wantLimit: DefaultLimit},
+		{name: "success: keeps limit", filter: ListFilter{Limit: 10}, wantLimit: 10},
+		{name: "success: caps limit", filter: ListFilter{Limit: 1000}, wantLimit: DefaultLimit},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				ListFunc: func(ctx context.Context, filter ListFilter) ([]DeadLetter, error) {
+					assert.Equal(t, tc.wantLimit, filter.Limit)
+					return []DeadLetter{}, nil
+				},
+			}
+			_, err := newTestService(repo, nil, nil, nil).ListDeadLetters(context.Background(), tc.filter)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDefaultService_RetryDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	jobID := uuid.New()
+
+	t.Run("success: requeues the image and removes the dead letter", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc:      func(ctx context.Context, id string) (*DeadLetter, error) { return testLetter("stage:run"), nil },
+			RequeueImageFunc: func(ctx context.Context, imageID string) error { return nil },
+			DeleteFunc:       func(ctx context.Context, id string) error { return nil },
+		}
+		jobRepo := &job.RepositoryMock{
+			CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+				assert.Equal(t, "img-1", imageID)
+				assert.Equal(t, queue.TaskTypeStageRun, jobType)
+				return &queries.Job{ID: pgtype.UUID{Bytes: jobID, Valid: true}}, nil
+			},
+		}
+		enq := &queue.EnqueuerMock{
+			EnqueueStageRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				assert.Equal(t, "img-1", payload.ImageID)
+				assert.Equal(t, queue.PriorityCritical, payload.Priority)
+				assert.Equal(t, jobID.String(), opts.TaskID)
+				return opts.TaskID, nil
+			},
+		}
+		tasks := okTasks()
+
+		retry, err := newTestService(repo, jobRepo, enq, tasks).RetryDeadLetter(ctx, "dl-1")
+		require.NoError(t, err)
+		assert.Equal(t, &Retry{ImageID: "img-1", TaskID: jobID.String()}, retry)
+		require.Len(t, repo.DeleteCalls(), 1)
+		require.Len(t, tasks.DeleteTaskCalls(), 1)
+		assert.Equal(t, "critical", tasks.DeleteTaskCalls()[0].Queue)
+		assert.Equal(t, "job-1", tasks.DeleteTaskCalls()[0].ID)
+	})
+
+	t.Run("success: retries declutter jobs as declutter:run", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*DeadLetter, error) {
+				return testLetter("declutter:run"), nil
+			},
+			RequeueImageFunc: func(ctx context.Context, imageID string) error { return nil },
+			DeleteFunc:       func(ctx context.Context, id string) error { return nil },
+		}
+		jobRepo := &job.RepositoryMock{
+			CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+				return &queries.Job{}, nil
+			},
+		}
+		enq := &queue.EnqueuerMock{
+			EnqueueDeclutterRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "task-2", nil
+			},
+		}
+		tasks := &queueadmin.ServiceMock{
+			DeleteTaskFunc: func(ctx context.Context, queue, id string) error { return queueadmin.ErrTaskNotFound },
+		}
+
+		retry, err := newTestService(repo, jobRepo, enq, tasks).RetryDeadLetter(ctx, "dl-1")
+		require.NoError(t, err)
+		assert.Equal(t, "task-2", retry.TaskID)
+		assert.Len(t, enq.EnqueueDeclutterRunCalls(), 1)
+	})
+
+	t.Run("fail: image no longer retryable", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc:      func(ctx context.Context, id string) (*DeadLetter, error) { return testLetter("stage:run"), nil },
+			RequeueImageFunc: func(ctx context.Context, imageID string) error { return ErrNotRetryable },
+		}
+
+		_, err := newTestService(repo, nil, nil, nil).RetryDeadLetter(ctx, "dl-1")
+		assert.ErrorIs(t, err, ErrNotRetryable)
+	})
+
+	t.Run("fail: image purged", func(t *testing.T) {
+		letter := testLetter("stage:run")
+		letter.ImageID = nil
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*DeadLetter, error) { return letter, nil },
+		}
+
+		_, err := newTestService(repo, nil, nil, nil).RetryDeadLetter(ctx, "dl-1")
+		assert.ErrorIs(t, err, ErrNotRetryable)
+	})
+
+	t.Run("fail: payload is not a staging job", func(t *testing.T) {
+		letter := testLetter("stage:run")
+		letter.Payload = []byte(`"not json"`)
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*DeadLetter, error) { return letter, nil },
+		}
+
+		_, err := newTestService(repo, nil, nil, nil).RetryDeadLetter(ctx, "dl-1")
+		assert.ErrorIs(t, err, ErrInvalidPayload)
+	})
+
+	t.Run("fail: enqueue error keeps the dead letter", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc:      func(ctx context.Context, id string) (*DeadLetter, error) { return testLetter("stage:run"), nil },
+			RequeueImageFunc: func(ctx context.Context, imageID string) error { return nil },
+		}
+		jobRepo := &job.RepositoryMock{
+			CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+				return &queries.Job{}, nil
+			},
+		}
+		enq := &queue.EnqueuerMock{
+			EnqueueStageRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "", errors.New("redis down")
+			},
+		}
+
+		_, err := newTestService(repo, jobRepo, enq, nil).RetryDeadLetter(ctx, "dl-1")
+		assert.ErrorContains(t, err, "failed to enqueue stage:run")
+		assert.Empty(t, repo.DeleteCalls())
+	})
+
+	t.Run("fail: not found", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*DeadLetter, error) { return nil, ErrNotFound },
+		}
+
+		_, err := newTestService(repo, nil, nil, nil).RetryDeadLetter(ctx, "dl-1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestDefaultService_DiscardDeadLetter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: removes the dead letter and its archived task", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*DeadLetter, error) { return testLetter("stage:run"), nil },
+			DeleteFunc:  func(ctx context.Context, id string) error { return nil },
+		}
+		tasks := &queueadmin.ServiceMock{
+			DeleteTaskFunc: func(ctx context.Context, queue, id string) error { return errors.New("redis down") },
+		}
+
+		require.NoError(t, newTestService(repo, nil, nil, tasks).DiscardDeadLetter(ctx, "dl-1"))
+		assert.Equal(t, "dl-1", repo.DeleteCalls()[0].ID)
+		assert.Len(t, tasks.DeleteTaskCalls(), 1)
+	})
+
+	t.Run("fail: not found", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*DeadLetter, error) { return nil, ErrNotFound },
+		}
+
+		assert.ErrorIs(t, newTestService(repo, nil, nil, nil).DiscardDeadLetter(ctx, "dl-1"), ErrNotFound)
+	})
+}
@@ -0,0 +1,20 @@
+package deadletter
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin HTTP endpoints for dead letters.
+type Handler interface {
+	// ListDeadLetters handles GET /admin/dead-letters - Lists dead letters.
+	ListDeadLetters(c echo.Context) error
+
+	// GetDeadLetter handles GET /admin/dead-letters/:id - Gets a single dead letter.
+	GetDeadLetter(c echo.Context) error
+
+	// RetryDeadLetter handles POST /admin/dead-letters/:id/retry - Runs a dead letter's task again.
+	RetryDeadLetter(c echo.Context) error
+
+	// DiscardDeadLetter handles DELETE /admin/dead-letters/:id - Removes a dead letter.
+	DiscardDeadLetter(c echo.Context) error
+}
@@ -0,0 +1,55 @@
+// Package deadletter exposes staging tasks that failed for good to admins:
+// the worker records stage:run and declutter:run tasks that used up their
+// retries, and admins list, inspect, retry or discard them.
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when a dead letter does not exist.
+	ErrNotFound = errors.New("dead letter not found")
+	// ErrNotRetryable is returned when retrying a dead letter whose image was
+	// deleted, canceled or has been staged since.
+	ErrNotRetryable = errors.New("dead letter cannot be retried")
+	// ErrInvalidPayload is returned when retrying a dead letter whose payload
+	// is not a staging job.
+	ErrInvalidPayload = errors.New("dead letter payload is not a staging job")
+)
+
+const (
+	// DefaultLimit is the number of dead letters listed when no limit is given.
+	DefaultLimit = 50
+	// MaxLimit caps the limit of a list request.
+	MaxLimit = 200
+)
+
+// DeadLetter is a staging task the worker gave up on.
+type DeadLetter struct {
+	ID       string          `json:"id"`
+	TaskID   string          `json:"task_id"`
+	TaskType string          `json:"task_type"`
+	Queue    string          `json:"queue"`
+	ImageID  *string         `json:"image_id,omitempty"`
+	Payload  json.RawMessage `json:"payload"`
+	Error    string          `json:"error"`
+	Attempts int             `json:"attempts"`
+	FailedAt time.Time       `json:"failed_at"`
+}
+
+// ListFilter narrows a dead letter query. Empty fields are ignored.
+type ListFilter struct {
+	TaskType string
+	ImageID  string
+	Limit    int
+	Offset   int
+}
+
+// Retry describes the task a retried dead letter was queued as.
+type Retry struct {
+	ImageID string `json:"image_id"`
+	TaskID  string `json:"task_id"`
+}
@@ -0,0 +1,22 @@
+package deadletter
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for dead letters.
+type Repository interface {
+	// List returns dead letters matching the filter, newest first.
+	List(ctx context.Context, filter ListFilter) ([]DeadLetter, error)
+
+	// GetByID retrieves a dead letter by its ID.
+	GetByID(ctx context.Context, id string) (*DeadLetter, error)
+
+	// Delete removes a dead letter.
+	Delete(ctx context.Context, id string) error
+
+	// RequeueImage sets the image back to queued, unless it was deleted or
+	// is canceled, ready or rejected, and records the change in its history.
+	// Returns ErrNotRetryable when the image can't be requeued.
+	RequeueImage(ctx context.Context, imageID string) error
+}
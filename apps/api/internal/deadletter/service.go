@@ -0,0 +1,21 @@
+package deadletter
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service lists, retries and discards dead letters.
+type Service interface {
+	// ListDeadLetters returns the dead letters matching the filter.
+	ListDeadLetters(ctx context.Context, filter ListFilter) ([]DeadLetter, error)
+
+	// GetDeadLetter retrieves a single dead letter.
+	GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+
+	// RetryDeadLetter requeues the dead letter's image and queues its payload
+	// as a new task, then removes the dead letter.
+	RetryDeadLetter(ctx context.Context, id string) (*Retry, error)
+
+	// DiscardDeadLetter removes the dead letter without running it again.
+	DiscardDeadLetter(ctx context.Context, id string) error
+}
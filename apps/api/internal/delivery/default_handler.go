@@ -0,0 +1,104 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/pagination"
+)
+
+// DefaultHandler serves the admin delivery log endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ListDeliveries handles GET /admin/deliveries - Lists the delivery log.
+// Supports destination, channel, status, limit and offset query parameters.
+func (h *DefaultHandler) ListDeliveries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter := ListFilter{
+		Destination: c.QueryParam("destination"),
+		Channel:     Channel(c.QueryParam("channel")),
+		Status:      Status(c.QueryParam("status")),
+	}
+	switch filter.Channel {
+	case "", ChannelWebhook, ChannelEmail:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "channel must be one of: webhook, email")
+	}
+	switch filter.Status {
+	case "", StatusPending, StatusRetrying, StatusDelivered, StatusFailed:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "status must be one of: pending, retrying, delivered, failed")
+	}
+
+	var err error
+	if filter.Limit, err = pagination.IntParam(c, "limit"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "limit must be an integer")
+	}
+	if filter.Offset, err = pagination.IntParam(c, "offset"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "offset must be an integer")
+	}
+
+	deliveries, err := h.service.ListDeliveries(ctx, filter)
+	if err != nil {
+		h.log.Error(ctx, "failed to list deliveries", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list deliveries")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"deliveries": deliveries,
+	})
+}
+
+// GetDelivery handles GET /admin/deliveries/:id - Gets a single delivery.
+func (h *DefaultHandler) GetDelivery(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+
+	d, err := h.service.GetDelivery(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrDeliveryNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Delivery not found")
+		}
+		h.log.Error(ctx, "failed to get delivery", "error", err, "delivery_id", id)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get delivery")
+	}
+
+	return c.JSON(http.StatusOK, d)
+}
+
+// ListDestinations handles GET /admin/deliveries/destinations - Summarizes deliveries per destination.
+func (h *DefaultHandler) ListDestinations(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	channel := Channel(c.QueryParam("channel"))
+	switch channel {
+	case "", ChannelWebhook, ChannelEmail:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "channel must be one of: webhook, email")
+	}
+
+	summaries, err := h.service.ListDestinations(ctx, channel)
+	if err != nil {
+		h.log.Error(ctx, "failed to list delivery destinations", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list delivery destinations")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"destinations": summaries,
+	})
+}
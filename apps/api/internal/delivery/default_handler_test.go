@@ -0,0 +1,138 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_ListDeliveries(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		listErr    error
+		wantStatus int
+		wantFilter ListFilter
+	}{
+		{
+			name:       "success: passes filters",
+			query:      "?destination=https://example.com/hook&channel=webhook&status=failed&limit=5&offset=10",
+			wantStatus: http.StatusOK,
+			wantFilter: ListFilter{
+				Destination: "https://example.com/hook", Channel: ChannelWebhook, Status: StatusFailed, Limit: 5, Offset: 10,
+			},
+		},
+		{name: "fail: invalid channel", query: "?channel=sms", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid status", query: "?status=lost", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid limit", query: "?limit=abc", wantStatus: http.StatusBadRequest},
+		{name: "fail: service error", listErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListDeliveriesFunc: func(ctx context.Context, filter ListFilter) ([]Delivery, error) {
+					assert.Equal(t, tc.wantFilter, filter)
+					return []Delivery{}, tc.listErr
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/deliveries"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			err := h.ListDeliveries(e.NewContext(req, rec))
+
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"deliveries":[]`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_GetDelivery(t *testing.T) {
+	cases := []struct {
+		name       string
+		getErr     error
+		wantStatus int
+	}{
+		{name: "success: returns delivery", wantStatus: http.StatusOK},
+		{name: "fail: not found", getErr: ErrDeliveryNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: service error", getErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetDeliveryFunc: func(ctx context.Context, id string) (*Delivery, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Delivery{ID: id, Status: StatusDelivered}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("d-1")
+			err := h.GetDelivery(c)
+
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Contains(t, rec.Body.String(), `"status":"delivered"`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_ListDestinations(t *testing.T) {
+	t.Run("success: returns summaries", func(t *testing.T) {
+		svc := &ServiceMock{
+			ListDestinationsFunc: func(ctx context.Context, channel Channel) ([]DestinationSummary, error) {
+				assert.Equal(t, ChannelEmail, channel)
+				return []DestinationSummary{{Destination: "user@example.com", Channel: ChannelEmail, Total: 2}}, nil
+			},
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/?channel=email", nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.ListDestinations(e.NewContext(req, rec)))
+		assert.Contains(t, rec.Body.String(), `"destination":"user@example.com"`)
+	})
+
+	t.Run("fail: invalid channel", func(t *testing.T) {
+		h := NewDefaultHandler(&ServiceMock{}, logging.Default())
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/?channel=fax", nil)
+		rec := httptest.NewRecorder()
+		err := h.ListDestinations(e.NewContext(req, rec))
+		var he *echo.HTTPError
+		require.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
@@ -0,0 +1,205 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const deliveryColumns = `
+	id, channel, destination, event_type, webhook_endpoint_id, subject, payload, status, attempts, max_attempts,
+	last_error, last_status_code, enqueued_at, last_attempt_at, delivered_at, created_at, updated_at`
+
+func scanDelivery(row pgx.Row) (*Delivery, error) {
+	var d Delivery
+	var channel, status string
+	var payload []byte
+	err := row.Scan(
+		&d.ID, &channel, &d.Destination, &d.EventType, &d.EndpointID, &d.Subject, &payload, &status,
+		&d.Attempts, &d.MaxAttempts, &d.LastError, &d.LastStatusCode,
+		&d.EnqueuedAt, &d.LastAttemptAt, &d.DeliveredAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Channel = Channel(channel)
+	d.Status = Status(status)
+	d.Payload = payload
+	return &d, nil
+}
+
+// Create inserts a new pending delivery.
+func (r *DefaultRepository) Create(ctx context.Context, d *Delivery) (*Delivery, error) {
+	query := `
+		INSERT INTO outbound_deliveries (
+			channel, destination, event_type, webhook_endpoint_id, subject, payload, max_attempts
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING` + deliveryColumns
+
+	maxAttempts := d.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	payload := []byte(d.Payload)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	created, err := scanDelivery(r.db.QueryRow(ctx, query,
+		string(d.Channel), d.Destination, d.EventType, d.EndpointID, d.Subject, payload, maxAttempts,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delivery: %w", err)
+	}
+	return created, nil
+}
+
+// GetByID retrieves a delivery by its ID.
+func (r *DefaultRepository) GetByID(ctx context.Context, id string) (*Delivery, error) {
+	query := `SELECT` + deliveryColumns + `
+		FROM outbound_deliveries
+		WHERE id = $1`
+
+	d, err := scanDelivery(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get delivery: %w", err)
+	}
+	return d, nil
+}
+
+// List returns deliveries matching the filter, newest first.
+func (r *DefaultRepository) List(ctx context.Context, filter ListFilter) ([]Delivery, error) {
+	query := `SELECT` + deliveryColumns + `
+		FROM outbound_deliveries
+		WHERE ($1 = '' OR destination = $1)
+		  AND ($2 = '' OR channel = $2)
+		  AND ($3 = '' OR status = $3)
+		  AND ($4 = '' OR webhook_endpoint_id::text = $4)
+		ORDER BY created_at DESC
+		LIMIT $5 OFFSET $6`
+
+	rows, err := r.db.Query(ctx, query,
+		filter.Destination, string(filter.Channel), string(filter.Status), filter.EndpointID,
+		filter.Limit, filter.Offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over delivery rows: %w", err)
+	}
+	return deliveries, nil
+}
+
+// SummarizeByDestination aggregates delivery outcomes per destination.
+func (r *DefaultRepository) SummarizeByDestination(ctx context.Context, channel Channel) ([]DestinationSummary, error) {
+	query := `
+		SELECT destination, channel,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'delivered'),
+		       COUNT(*) FILTER (WHERE status = 'failed'),
+		       COUNT(*) FILTER (WHERE status IN ('pending', 'retrying')),
+		       MAX(last_attempt_at)
+		FROM outbound_deliveries
+		WHERE ($1 = '' OR channel = $1)
+		GROUP BY destination, channel
+		ORDER BY destination ASC`
+
+	rows, err := r.db.Query(ctx, query, string(channel))
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []DestinationSummary{}
+	for rows.Next() {
+		var s DestinationSummary
+		var ch string
+		if err := rows.Scan(
+			&s.Destination, &ch, &s.Total, &s.Delivered, &s.Failed, &s.Pending, &s.LastAttemptAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan delivery summary: %w", err)
+		}
+		s.Channel = Channel(ch)
+		summaries = append(summaries, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over delivery summary rows: %w", err)
+	}
+	return summaries, nil
+}
+
+// MarkEnqueued records that a delivery was handed to the queue.
+func (r *DefaultRepository) MarkEnqueued(ctx context.Context, id string) error {
+	query := `
+		UPDATE outbound_deliveries
+		SET enqueued_at = now(), updated_at = now()
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark delivery enqueued: %w", err)
+	}
+	return nil
+}
+
+// ListUnenqueued returns pending deliveries created before the cutoff that never reached the queue.
+func (r *DefaultRepository) ListUnenqueued(
+	ctx context.Context, createdBefore time.Time, limit int,
+) ([]Delivery, error) {
+	query := `SELECT` + deliveryColumns + `
+		FROM outbound_deliveries
+		WHERE status = 'pending' AND enqueued_at IS NULL AND created_at < $1
+		ORDER BY created_at ASC
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unenqueued deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		deliveries = append(deliveries, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over delivery rows: %w", err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,219 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/queue"
+)
+
+// DefaultService implements Service using the outbox table and the asynq queue.
+//
+// Each send is written to outbound_deliveries before it is enqueued, so a
+// Redis outage only delays delivery: RelayPending picks up rows that never
+// reached the queue.
+type DefaultService struct {
+	repo     Repository
+	enqueuer queue.Enqueuer
+	now      func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(cfg *config.Config, repo Repository) *DefaultService {
+	// Best-effort build an enqueuer from env or config; fall back to Noop if not configured.
+	var enq queue.Enqueuer
+	if e, err := queue.NewAsynqEnqueuerFromEnv(cfg); err == nil {
+		enq = e
+	} else {
+		enq = queue.NoopEnqueuer{}
+	}
+	return &DefaultService{repo: repo, enqueuer: enq, now: time.Now}
+}
+
+// EnqueueWebhook records a webhook delivery in the outbox and queues it for sending.
+func (s *DefaultService) EnqueueWebhook(
+	ctx context.Context, endpoint, eventType string, payload interface{},
+) (*Delivery, error) {
+	return s.enqueueWebhook(ctx, nil, endpoint, eventType, payload)
+}
+
+// EnqueueEndpointWebhook records a webhook delivery for a registered webhook endpoint.
+func (s *DefaultService) EnqueueEndpointWebhook(
+	ctx context.Context, endpointID, endpoint, eventType string, payload interface{},
+) (*Delivery, error) {
+	if endpointID == "" {
+		return nil, fmt.Errorf("webhook endpoint ID is required")
+	}
+	return s.enqueueWebhook(ctx, &endpointID, endpoint, eventType, payload)
+}
+
+func (s *DefaultService) enqueueWebhook(
+	ctx context.Context, endpointID *string, endpoint, eventType string, payload interface{},
+) (*Delivery, error) {
+	if err := validateWebhookURL(endpoint); err != nil {
+		return nil, err
+	}
+	if eventType == "" {
+		return nil, fmt.Errorf("event type is required")
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	return s.record(ctx, &Delivery{
+		Channel:     ChannelWebhook,
+		Destination: endpoint,
+		EventType:   eventType,
+		EndpointID:  endpointID,
+		Payload:     body,
+		MaxAttempts: DefaultMaxAttempts,
+	})
+}
+
+// EnqueueEmail records an email delivery in the outbox and queues it for sending.
+func (s *DefaultService) EnqueueEmail(ctx context.Context, to, eventType, subject, body string) (*Delivery, error) {
+	if _, err := mail.ParseAddress(to); err != nil {
+		return nil, fmt.Errorf("invalid email address: %w", err)
+	}
+	if eventType == "" {
+		return nil, fmt.Errorf("event type is required")
+	}
+	payload, err := json.Marshal(EmailPayload{Body: body})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal email payload: %w", err)
+	}
+
+	return s.record(ctx, &Delivery{
+		Channel:     ChannelEmail,
+		Destination: to,
+		EventType:   eventType,
+		Subject:     &subject,
+		Payload:     payload,
+		MaxAttempts: DefaultMaxAttempts,
+	})
+}
+
+// GetDelivery retrieves a single delivery record.
+func (s *DefaultService) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListDeliveries returns the delivery log matching the filter.
+func (s *DefaultService) ListDeliveries(ctx context.Context, filter ListFilter) ([]Delivery, error) {
+	if filter.Limit <= 0 || filter.Limit > 200 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// ListDestinations returns per-destination delivery summaries.
+func (s *DefaultService) ListDestinations(ctx context.Context, channel Channel) ([]DestinationSummary, error) {
+	return s.repo.SummarizeByDestination(ctx, channel)
+}
+
+// RelayPending re-queues deliveries older than the grace period that never reached the queue.
+// It returns the number of deliveries handed to the queue.
+func (s *DefaultService) RelayPending(ctx context.Context, grace time.Duration, limit int) (int, error) {
+	pending, err := s.repo.ListUnenqueued(ctx, s.now().Add(-grace), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	relayed := 0
+	for i := range pending {
+		if err := s.enqueue(ctx, &pending[i]); err != nil {
+			return relayed, err
+		}
+		relayed++
+	}
+	return relayed, nil
+}
+
+// record writes the outbox row and then attempts to enqueue it. A failed
+// enqueue is logged but not returned: the row stays pending for the relay.
+func (s *DefaultService) record(ctx context.Context, d *Delivery) (*Delivery, error) {
+	log := logging.NewDefaultLogger()
+
+	created, err := s.repo.Create(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enqueue(ctx, created); err != nil {
+		log.Warn(ctx, "delivery enqueue failed; relay will retry",
+			"delivery_id", created.ID, "channel", string(created.Channel), "error", err)
+	}
+	return created, nil
+}
+
+func (s *DefaultService) enqueue(ctx context.Context, d *Delivery) error {
+	maxAttempts := d.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	opts := &queue.EnqueueOpts{
+		Queue: queueFor(d.Channel),
+		// asynq counts retries after the first attempt.
+		Retry: maxAttempts - 1,
+	}
+	if _, err := s.enqueuer.EnqueueDelivery(ctx, queue.DeliveryPayload{DeliveryID: d.ID}, opts); err != nil {
+		return fmt.Errorf("failed to enqueue delivery: %w", err)
+	}
+	if err := s.repo.MarkEnqueued(ctx, d.ID); err != nil {
+		return err
+	}
+	now := s.now()
+	d.EnqueuedAt = &now
+	return nil
+}
+
+func queueFor(channel Channel) string {
+	if channel == ChannelEmail {
+		return queue.QueueEmails
+	}
+	return queue.QueueWebhooks
+}
+
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("invalid webhook URL: %q", raw)
+	}
+	return nil
+}
+
+// RunRelay periodically re-queues deliveries that were recorded but never
+// reached the queue, until ctx is cancelled.
+func RunRelay(ctx context.Context, svc Service, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := svc.RelayPending(ctx, interval, 100)
+			if err != nil {
+				log.Error(ctx, "delivery relay failed", "error", err)
+				continue
+			}
+			if n > 0 {
+				log.Info(ctx, "delivery relay re-queued deliveries", "count", n)
+			}
+		}
+	}
+}
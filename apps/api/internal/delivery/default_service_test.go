@@ -0,0 +1,242 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/queue"
+)
+
+func newTestService(repo Repository, enq queue.Enqueuer) *DefaultService {
+	return &DefaultService{
+		repo:     repo,
+		enqueuer: enq,
+		now:      func() time.Time { return time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC) },
+	}
+}
+
+func okEnqueuer() *queue.EnqueuerMock {
+	return &queue.EnqueuerMock{
+		EnqueueDeliveryFunc: func(
+			ctx context.Context, payload queue.DeliveryPayload, opts *queue.EnqueueOpts,
+		) (string, error) {
+			return "task-1", nil
+		},
+	}
+}
+
+func createEcho() func(ctx context.Context, d *Delivery) (*Delivery, error) {
+	return func(ctx context.Context, d *Delivery) (*Delivery, error) {
+		created := *d
+		created.ID = "d-1"
+		created.Status = StatusPending
+		return &created, nil
+	}
+}
+
+func TestDefaultService_EnqueueWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: records outbox row and enqueues on webhooks queue", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc:       createEcho(),
+			MarkEnqueuedFunc: func(ctx context.Context, id string) error { return nil },
+		}
+		enq := okEnqueuer()
+		svc := newTestService(repo, enq)
+
+		d, err := svc.EnqueueWebhook(ctx, "https://example.com/hook", "image.ready", map[string]string{"id": "img"})
+		require.NoError(t, err)
+		assert.Equal(t, "d-1", d.ID)
+		assert.Equal(t, ChannelWebhook, d.Channel)
+		assert.JSONEq(t, `{"id":"img"}`, string(d.Payload))
+		assert.NotNil(t, d.EnqueuedAt)
+
+		require.Len(t, enq.EnqueueDeliveryCalls(), 1)
+		call := enq.EnqueueDeliveryCalls()[0]
+		assert.Equal(t, "d-1", call.Payload.DeliveryID)
+		assert.Equal(t, queue.QueueWebhooks, call.Opts.Queue)
+		assert.Equal(t, DefaultMaxAttempts-1, call.Opts.Retry)
+		require.Len(t, repo.MarkEnqueuedCalls(), 1)
+	})
+
+	t.Run("success: enqueue failure leaves row pending for relay", func(t *testing.T) {
+		repo := &RepositoryMock{CreateFunc: createEcho()}
+		enq := &queue.EnqueuerMock{
+			EnqueueDeliveryFunc: func(
+				ctx context.Context, payload queue.DeliveryPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "", errors.New("redis down")
+			},
+		}
+		svc := newTestService(repo, enq)
+
+		d, err := svc.EnqueueWebhook(ctx, "https://example.com/hook", "image.ready", nil)
+		require.NoError(t, err)
+		assert.Nil(t, d.EnqueuedAt)
+		assert.Empty(t, repo.MarkEnqueuedCalls())
+	})
+
+	t.Run("fail: invalid URL", func(t *testing.T) {
+		svc := newTestService(&RepositoryMock{}, okEnqueuer())
+		_, err := svc.EnqueueWebhook(ctx, "ftp://example.com", "image.ready", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: missing event type", func(t *testing.T) {
+		svc := newTestService(&RepositoryMock{}, okEnqueuer())
+		_, err := svc.EnqueueWebhook(ctx, "https://example.com/hook", "", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, d *Delivery) (*Delivery, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		enq := okEnqueuer()
+		svc := newTestService(repo, enq)
+
+		_, err := svc.EnqueueWebhook(ctx, "https://example.com/hook", "image.ready", nil)
+		assert.Error(t, err)
+		assert.Empty(t, enq.EnqueueDeliveryCalls())
+	})
+}
+
+func TestDefaultService_EnqueueEndpointWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: links the delivery to the endpoint", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc:       createEcho(),
+			MarkEnqueuedFunc: func(ctx context.Context, id string) error { return nil },
+		}
+		svc := newTestService(repo, okEnqueuer())
+
+		d, err := svc.EnqueueEndpointWebhook(ctx, "ep-1", "https://example.com/hook", "image.ready", nil)
+		require.NoError(t, err)
+		require.NotNil(t, d.EndpointID)
+		assert.Equal(t, "ep-1", *d.EndpointID)
+		assert.Equal(t, ChannelWebhook, d.Channel)
+	})
+
+	t.Run("fail: missing endpoint ID", func(t *testing.T) {
+		svc := newTestService(&RepositoryMock{}, okEnqueuer())
+		_, err := svc.EnqueueEndpointWebhook(ctx, "", "https://example.com/hook", "image.ready", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_EnqueueEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: stores subject and body and uses emails queue", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc:       createEcho(),
+			MarkEnqueuedFunc: func(ctx context.Context, id string) error { return nil },
+		}
+		enq := okEnqueuer()
+		svc := newTestService(repo, enq)
+
+		d, err := svc.EnqueueEmail(ctx, "user@example.com", "billing.receipt", "Your receipt", "Thanks!")
+		require.NoError(t, err)
+		assert.Equal(t, ChannelEmail, d.Channel)
+		require.NotNil(t, d.Subject)
+		assert.Equal(t, "Your receipt", *d.Subject)
+
+		var body EmailPayload
+		require.NoError(t, json.Unmarshal(d.Payload, &body))
+		assert.Equal(t, "Thanks!", body.Body)
+		assert.Equal(t, queue.QueueEmails, enq.EnqueueDeliveryCalls()[0].Opts.Queue)
+	})
+
+	t.Run("fail: invalid address", func(t *testing.T) {
+		svc := newTestService(&RepositoryMock{}, okEnqueuer())
+		_, err := svc.EnqueueEmail(ctx, "not-an-email", "billing.receipt", "s", "b")
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_ListDeliveries(t *testing.T) {
+	cases := []struct {
+		name      string
+		filter    ListFilter
+		wantLimit int
+	}{
+		{name: "success: default limit", filter: ListFilter{}, wantLimit: 50},
+		{name: "success: caps large limit", filter: ListFilter{Limit: 1000}, wantLimit: 50},
+		{name: "success: keeps explicit limit", filter: ListFilter{Limit: 10}, wantLimit: 10},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got ListFilter
+			repo := &RepositoryMock{
+				ListFunc: func(ctx context.Context, filter ListFilter) ([]Delivery, error) {
+					got = filter
+					return []Delivery{}, nil
+				},
+			}
+			svc := newTestService(repo, okEnqueuer())
+
+			_, err := svc.ListDeliveries(context.Background(), tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantLimit, got.Limit)
+		})
+	}
+}
+
+func TestDefaultService_RelayPending(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: re-queues unenqueued rows", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListUnenqueuedFunc: func(ctx context.Context, createdBefore time.Time, limit int) ([]Delivery, error) {
+				assert.Equal(t, time.Date(2025, 1, 1, 11, 59, 0, 0, time.UTC), createdBefore)
+				return []Delivery{
+					{ID: "a", Channel: ChannelWebhook, MaxAttempts: 3},
+					{ID: "b", Channel: ChannelEmail},
+				}, nil
+			},
+			MarkEnqueuedFunc: func(ctx context.Context, id string) error { return nil },
+		}
+		enq := okEnqueuer()
+		svc := newTestService(repo, enq)
+
+		n, err := svc.RelayPending(ctx, time.Minute, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		calls := enq.EnqueueDeliveryCalls()
+		require.Len(t, calls, 2)
+		assert.Equal(t, 2, calls[0].Opts.Retry)
+		assert.Equal(t, queue.QueueEmails, calls[1].Opts.Queue)
+	})
+
+	t.Run("fail: stops on enqueue error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListUnenqueuedFunc: func(ctx context.Context, createdBefore time.Time, limit int) ([]Delivery, error) {
+				return []Delivery{{ID: "a"}, {ID: "b"}}, nil
+			},
+		}
+		enq := &queue.EnqueuerMock{
+			EnqueueDeliveryFunc: func(
+				ctx context.Context, payload queue.DeliveryPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "", errors.New("redis down")
+			},
+		}
+		svc := newTestService(repo, enq)
+
+		n, err := svc.RelayPending(ctx, time.Minute, 10)
+		assert.Error(t, err)
+		assert.Equal(t, 0, n)
+		assert.Len(t, enq.EnqueueDeliveryCalls(), 1)
+	})
+}
@@ -0,0 +1,17 @@
+package delivery
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin HTTP endpoints for inspecting deliveries.
+type Handler interface {
+	// ListDeliveries handles GET /admin/deliveries - Lists the delivery log.
+	ListDeliveries(c echo.Context) error
+
+	// GetDelivery handles GET /admin/deliveries/:id - Gets a single delivery.
+	GetDelivery(c echo.Context) error
+
+	// ListDestinations handles GET /admin/deliveries/destinations - Summarizes deliveries per destination.
+	ListDestinations(c echo.Context) error
+}
@@ -0,0 +1,83 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrDeliveryNotFound is returned when a delivery does not exist.
+var ErrDeliveryNotFound = errors.New("delivery not found")
+
+// Channel identifies how a delivery is sent.
+type Channel string
+
+const (
+	ChannelWebhook Channel = "webhook"
+	ChannelEmail   Channel = "email"
+)
+
+// Status is the lifecycle state of a delivery.
+type Status string
+
+const (
+	// StatusPending means the delivery has not been attempted yet.
+	StatusPending Status = "pending"
+	// StatusRetrying means at least one attempt failed and the worker will retry.
+	StatusRetrying Status = "retrying"
+	// StatusDelivered means the destination accepted the delivery.
+	StatusDelivered Status = "delivered"
+	// StatusFailed means every attempt was used without success.
+	StatusFailed Status = "failed"
+)
+
+// DefaultMaxAttempts is the number of send attempts before a delivery is marked failed.
+const DefaultMaxAttempts = 8
+
+// Delivery is an outbox record for a single outbound webhook or email. The
+// worker updates it after every attempt, so it also serves as the delivery log.
+type Delivery struct {
+	ID             string          `json:"id"`
+	Channel        Channel         `json:"channel"`
+	Destination    string          `json:"destination"`
+	EventType      string          `json:"event_type"`
+	EndpointID     *string         `json:"webhook_endpoint_id,omitempty"`
+	Subject        *string         `json:"subject,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         Status          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	LastError      *string         `json:"last_error,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	EnqueuedAt     *time.Time      `json:"enqueued_at,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// DestinationSummary aggregates delivery outcomes for one destination.
+type DestinationSummary struct {
+	Destination   string     `json:"destination"`
+	Channel       Channel    `json:"channel"`
+	Total         int        `json:"total"`
+	Delivered     int        `json:"delivered"`
+	Failed        int        `json:"failed"`
+	Pending       int        `json:"pending"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+}
+
+// ListFilter narrows a delivery log query. Empty fields are ignored.
+type ListFilter struct {
+	Destination string
+	EndpointID  string
+	Channel     Channel
+	Status      Status
+	Limit       int
+	Offset      int
+}
+
+// EmailPayload is the stored body of an email delivery.
+type EmailPayload struct {
+	Body string `json:"body"`
+}
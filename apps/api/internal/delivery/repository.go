@@ -0,0 +1,29 @@
+package delivery
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for the outbound delivery outbox.
+type Repository interface {
+	// Create inserts a new pending delivery.
+	Create(ctx context.Context, d *Delivery) (*Delivery, error)
+
+	// GetByID retrieves a delivery by its ID.
+	GetByID(ctx context.Context, id string) (*Delivery, error)
+
+	// List returns deliveries matching the filter, newest first.
+	List(ctx context.Context, filter ListFilter) ([]Delivery, error)
+
+	// SummarizeByDestination aggregates delivery outcomes per destination.
+	SummarizeByDestination(ctx context.Context, channel Channel) ([]DestinationSummary, error)
+
+	// MarkEnqueued records that a delivery was handed to the queue.
+	MarkEnqueued(ctx context.Context, id string) error
+
+	// ListUnenqueued returns pending deliveries created before the cutoff that never reached the queue.
+	ListUnenqueued(ctx context.Context, createdBefore time.Time, limit int) ([]Delivery, error)
+}
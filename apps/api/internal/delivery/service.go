@@ -0,0 +1,35 @@
+package delivery
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for outbound webhooks and emails.
+type Service interface {
+	// EnqueueWebhook records a webhook delivery in the outbox and queues it for sending.
+	EnqueueWebhook(ctx context.Context, endpoint, eventType string, payload interface{}) (*Delivery, error)
+
+	// EnqueueEndpointWebhook records a webhook delivery for a registered webhook endpoint.
+	// The worker signs it with the endpoint's secret and it appears in the endpoint's history.
+	EnqueueEndpointWebhook(
+		ctx context.Context, endpointID, endpoint, eventType string, payload interface{},
+	) (*Delivery, error)
+
+	// EnqueueEmail records an email delivery in the outbox and queues it for sending.
+	EnqueueEmail(ctx context.Context, to, eventType, subject, body string) (*Delivery, error)
+
+	// GetDelivery retrieves a single delivery record.
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+
+	// ListDeliveries returns the delivery log matching the filter.
+	ListDeliveries(ctx context.Context, filter ListFilter) ([]Delivery, error)
+
+	// ListDestinations returns per-destination delivery summaries.
+	ListDestinations(ctx context.Context, channel Channel) ([]DestinationSummary, error)
+
+	// RelayPending re-queues deliveries older than the grace period that never reached the queue.
+	RelayPending(ctx context.Context, grace time.Duration, limit int) (int, error)
+}
@@ -0,0 +1,106 @@
+package dunning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// Track inserts or removes the subscription's row in one statement.
+func (r *DefaultRepository) Track(ctx context.Context, subscriptionID, userID, status string) error {
+	_, err := r.db.Exec(ctx, `
+		WITH ended AS (
+			DELETE FROM subscription_dunning WHERE subscription_id = $1 AND $3 <> 'past_due'
+		)
+		INSERT INTO subscription_dunning (subscription_id, user_id)
+		SELECT $1, $2 WHERE $3 = 'past_due'
+		ON CONFLICT (subscription_id) DO NOTHING`,
+		subscriptionID, userID, status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to track subscription dunning: %w", err)
+	}
+	return nil
+}
+
+// GetByUserID returns the user's latest grace period.
+func (r *DefaultRepository) GetByUserID(ctx context.Context, userID string) (*GracePeriod, error) {
+	var g GracePeriod
+	err := r.db.QueryRow(ctx, `
+		SELECT d.subscription_id::text, s.stripe_subscription_id, d.user_id::text, s.price_id,
+			d.past_due_since, d.expired_at
+		FROM subscription_dunning d
+		JOIN subscriptions s ON s.id = d.subscription_id
+		WHERE d.user_id = $1
+		ORDER BY d.past_due_since DESC
+		LIMIT 1`,
+		userID,
+	).Scan(&g.SubscriptionID, &g.StripeSubscriptionID, &g.UserID, &g.PriceID, &g.PastDueSince, &g.ExpiredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get grace period: %w", err)
+	}
+	return &g, nil
+}
+
+// MarkExpired claims the rows with SKIP LOCKED so concurrent API instances
+// never announce the same grace period.
+func (r *DefaultRepository) MarkExpired(
+	ctx context.Context, startedBefore time.Time, limit int,
+) ([]GracePeriod, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT subscription_id FROM subscription_dunning
+			WHERE expired_at IS NULL AND past_due_since < $1
+			ORDER BY past_due_since
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE subscription_dunning d
+		SET expired_at = now()
+		FROM due, subscriptions s
+		WHERE d.subscription_id = due.subscription_id AND s.id = d.subscription_id
+		RETURNING d.subscription_id::text, s.stripe_subscription_id, d.user_id::text, s.price_id,
+			d.past_due_since, d.expired_at`,
+		startedBefore, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark grace periods expired: %w", err)
+	}
+	defer rows.Close()
+
+	expired := []GracePeriod{}
+	for rows.Next() {
+		var g GracePeriod
+		if err := rows.Scan(
+			&g.SubscriptionID, &g.StripeSubscriptionID, &g.UserID, &g.PriceID, &g.PastDueSince, &g.ExpiredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan grace period: %w", err)
+		}
+		expired = append(expired, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over grace period rows: %w", err)
+	}
+	return expired, nil
+}
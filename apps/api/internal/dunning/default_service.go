@@ -0,0 +1,82 @@
+package dunning
+
+import (
+	"context"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// batchSize is how many grace periods are ended per query.
+const batchSize = 100
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo  Repository
+	bus   events.Bus
+	grace time.Duration
+	now   func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService that announces ended grace
+// periods of the given length on bus.
+func NewDefaultService(repo Repository, bus events.Bus, grace time.Duration) *DefaultService {
+	return &DefaultService{repo: repo, bus: bus, grace: grace, now: time.Now}
+}
+
+// ExpireGracePeriods publishes a SubscriptionGraceExpired event for each grace
+// period marked expired. The mark comes first, so a failing subscriber loses
+// its notification rather than every instance repeating it.
+func (s *DefaultService) ExpireGracePeriods(ctx context.Context) (int, error) {
+	now := s.now()
+	ended := 0
+	for {
+		expired, err := s.repo.MarkExpired(ctx, now.Add(-s.grace), batchSize)
+		if err != nil {
+			return ended, err
+		}
+		for _, g := range expired {
+			err := s.bus.Publish(ctx, events.SubscriptionGraceExpired{
+				UserID:               g.UserID,
+				StripeSubscriptionID: g.StripeSubscriptionID,
+				PastDueSince:         g.PastDueSince,
+				GraceEndedAt:         g.EndsAt(s.grace),
+				OccurredAt:           now.UTC(),
+			})
+			if err != nil {
+				logging.Default().Warn(ctx, "grace period expiry notification failed",
+					"stripe_subscription_id", g.StripeSubscriptionID, "error", err)
+			}
+		}
+		ended += len(expired)
+		if len(expired) < batchSize {
+			return ended, nil
+		}
+	}
+}
+
+// RunExpiry runs ExpireGracePeriods every interval until ctx is cancelled.
+func RunExpiry(ctx context.Context, svc Service, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ended, err := svc.ExpireGracePeriods(ctx)
+			if err != nil {
+				log.Error(ctx, "grace period expiry failed", "error", err)
+			}
+			if ended > 0 {
+				log.Info(ctx, "grace periods expired", "count", ended)
+			}
+		}
+	}
+}
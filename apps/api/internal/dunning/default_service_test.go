@@ -0,0 +1,89 @@
+package dunning
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/events"
+)
+
+func TestDefaultService_ExpireGracePeriods(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	grace := 7 * 24 * time.Hour
+	pastDueSince := now.Add(-8 * 24 * time.Hour)
+
+	t.Run("success: announces each expired grace period", func(t *testing.T) {
+		var before time.Time
+		repo := &RepositoryMock{
+			MarkExpiredFunc: func(ctx context.Context, startedBefore time.Time, limit int) ([]GracePeriod, error) {
+				before = startedBefore
+				return []GracePeriod{{UserID: "user-1", StripeSubscriptionID: "sub_1", PastDueSince: pastDueSince}}, nil
+			},
+		}
+		var published []events.Event
+		bus := &events.BusMock{
+			PublishFunc: func(ctx context.Context, event events.Event) error {
+				published = append(published, event)
+				return nil
+			},
+		}
+		svc := NewDefaultService(repo, bus, grace)
+		svc.now = func() time.Time { return now }
+
+		ended, err := svc.ExpireGracePeriods(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if ended != 1 {
+			t.Fatalf("Expected 1 grace period to end but got %d", ended)
+		}
+		if !before.Equal(now.Add(-grace)) {
+			t.Errorf("Expected grace periods started before %s but got %s", now.Add(-grace), before)
+		}
+		want := events.SubscriptionGraceExpired{
+			UserID:               "user-1",
+			StripeSubscriptionID: "sub_1",
+			PastDueSince:         pastDueSince,
+			GraceEndedAt:         pastDueSince.Add(grace),
+			OccurredAt:           now,
+		}
+		if len(published) != 1 || published[0] != want {
+			t.Fatalf("Expected %+v but got %+v", want, published)
+		}
+	})
+
+	t.Run("success: a failing subscriber doesn't stop the others", func(t *testing.T) {
+		repo := &RepositoryMock{
+			MarkExpiredFunc: func(ctx context.Context, startedBefore time.Time, limit int) ([]GracePeriod, error) {
+				return []GracePeriod{{UserID: "user-1"}, {UserID: "user-2"}}, nil
+			},
+		}
+		bus := &events.BusMock{
+			PublishFunc: func(ctx context.Context, event events.Event) error {
+				return errors.New("redis unavailable")
+			},
+		}
+
+		ended, err := NewDefaultService(repo, bus, grace).ExpireGracePeriods(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if ended != 2 || len(bus.PublishCalls()) != 2 {
+			t.Fatalf("Expected both grace periods announced but got %d", len(bus.PublishCalls()))
+		}
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			MarkExpiredFunc: func(ctx context.Context, startedBefore time.Time, limit int) ([]GracePeriod, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		if _, err := NewDefaultService(repo, &events.BusMock{}, grace).ExpireGracePeriods(context.Background()); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
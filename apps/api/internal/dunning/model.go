@@ -0,0 +1,28 @@
+// Package dunning keeps subscribers whose renewal payment failed on their plan
+// for a grace period while Stripe retries the charge.
+//
+// Stripe marks such a subscription past_due. Without a grace period it would
+// count as missing and the user would drop to the free plan at once; instead
+// the Stripe webhook records when it became past due, usage keeps the plan
+// until the grace period ends, and the end is announced once on the bus so the
+// user's billing stream hears about it.
+package dunning
+
+import "time"
+
+// GracePeriod is a past-due subscription's grace period.
+type GracePeriod struct {
+	SubscriptionID       string
+	StripeSubscriptionID string
+	UserID               string
+	// PriceID is the subscription's price, which identifies the plan kept.
+	PriceID      *string
+	PastDueSince time.Time
+	// ExpiredAt is when the end of the grace period was announced.
+	ExpiredAt *time.Time
+}
+
+// EndsAt returns when the grace period of the given length ends.
+func (g GracePeriod) EndsAt(grace time.Duration) time.Time {
+	return g.PastDueSince.Add(grace)
+}
@@ -0,0 +1,24 @@
+package dunning
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for the grace periods of past-due subscriptions.
+type Repository interface {
+	// Track follows a subscription's status: past_due starts its grace period,
+	// keeping the start of one already running, and any other status ends it.
+	Track(ctx context.Context, subscriptionID, userID, status string) error
+
+	// GetByUserID returns the grace period of the user's most recently past-due
+	// subscription, or nil when none of their subscriptions is past due.
+	GetByUserID(ctx context.Context, userID string) (*GracePeriod, error)
+
+	// MarkExpired marks up to limit unannounced grace periods that started
+	// before the given time as announced and returns them. A grace period is
+	// returned by one call only.
+	MarkExpired(ctx context.Context, startedBefore time.Time, limit int) ([]GracePeriod, error)
+}
@@ -0,0 +1,12 @@
+package dunning
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service ends the grace periods of past-due subscriptions.
+type Service interface {
+	// ExpireGracePeriods announces the end of every grace period that has run
+	// out and returns how many ended.
+	ExpireGracePeriods(ctx context.Context) (int, error)
+}
@@ -0,0 +1,22 @@
+// Package echotest builds echo contexts for handler tests.
+package echotest
+
+import (
+	"net/http/httptest"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewContext returns a context for a JSON request with body and the given path
+// params, and the recorder its response is written to.
+func NewContext(method, body string, names, values []string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames(names...)
+	c.SetParamValues(values...)
+	return c, rec
+}
@@ -0,0 +1,127 @@
+package errorcleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// eligible picks live errored images the cleanup may touch. Locked projects
+// are kept like the trash purge keeps them. An admin reviewing a flagged
+// prompt, support answering a ticket and anyone reading a rating still need
+// the image they were raised about.
+const eligible = `
+	i.status = 'error'
+	AND i.deleted_at IS NULL
+	AND NOT p.locked
+	AND NOT EXISTS (SELECT 1 FROM prompt_reviews r WHERE r.image_id = i.id)
+	AND NOT EXISTS (SELECT 1 FROM support_tickets t WHERE t.image_id = i.id)
+	AND NOT EXISTS (SELECT 1 FROM image_feedback f WHERE f.image_id = i.id)`
+
+// MarkNotified stamps cleanup_notified_at in one statement, oldest failure
+// first. A notice older than the image's last update was about an earlier
+// failure, so the image is picked again. Rows are locked as they are picked so
+// concurrent runs don't notify twice.
+func (r *DefaultRepository) MarkNotified(ctx context.Context, erroredBefore time.Time, limit int) ([]Image, error) {
+	query := `
+		WITH due AS (
+			SELECT i.id
+			FROM images i
+			JOIN projects p ON p.id = i.project_id
+			WHERE ` + eligible + `
+			  AND i.updated_at < $1
+			  AND (i.cleanup_notified_at IS NULL OR i.cleanup_notified_at < i.updated_at)
+			ORDER BY i.updated_at
+			LIMIT $2
+			FOR UPDATE OF i SKIP LOCKED
+		)
+		UPDATE images i SET cleanup_notified_at = now()
+		FROM projects p
+		LEFT JOIN users u ON u.id = p.user_id
+		WHERE i.id IN (SELECT id FROM due) AND p.id = i.project_id
+		RETURNING i.id, i.project_id, p.name, COALESCE(u.email, ''), COALESCE(i.error, '')`
+
+	rows, err := r.db.Query(ctx, query, erroredBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark errored images: %w", err)
+	}
+	defer rows.Close()
+
+	marked := []Image{}
+	for rows.Next() {
+		var id, projectID pgtype.UUID
+		var img Image
+		if err := rows.Scan(&id, &projectID, &img.ProjectName, &img.OwnerEmail, &img.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan errored image: %w", err)
+		}
+		img.ID = uuid.UUID(id.Bytes).String()
+		img.ProjectID = uuid.UUID(projectID.Bytes).String()
+		marked = append(marked, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to mark errored images: %w", err)
+	}
+	return marked, nil
+}
+
+// ClearNotified resets cleanup_notified_at on the images.
+func (r *DefaultRepository) ClearNotified(ctx context.Context, imageIDs []string) error {
+	ids := make([]uuid.UUID, 0, len(imageIDs))
+	for _, id := range imageIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return fmt.Errorf("invalid image ID: %w", err)
+		}
+		ids = append(ids, parsed)
+	}
+
+	query := `UPDATE images SET cleanup_notified_at = NULL WHERE id = ANY($1)`
+	if _, err := r.db.Exec(ctx, query, ids); err != nil {
+		return fmt.Errorf("failed to clear cleanup notices: %w", err)
+	}
+	return nil
+}
+
+// TrashNotified soft-deletes the images like a user delete does, so they can
+// be restored until the trash retention purges them. An image updated after
+// its notice (retried, say) is left for a new notice.
+func (r *DefaultRepository) TrashNotified(ctx context.Context, notifiedBefore time.Time, limit int) (int, error) {
+	query := `
+		WITH due AS (
+			SELECT i.id
+			FROM images i
+			JOIN projects p ON p.id = i.project_id
+			WHERE ` + eligible + `
+			  AND i.cleanup_notified_at < $1
+			  AND i.updated_at <= i.cleanup_notified_at
+			ORDER BY i.cleanup_notified_at
+			LIMIT $2
+			FOR UPDATE OF i SKIP LOCKED
+		)
+		UPDATE images SET deleted_at = now(), updated_at = now()
+		WHERE id IN (SELECT id FROM due)`
+
+	tag, err := r.db.Exec(ctx, query, notifiedBefore, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to trash errored images: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,158 @@
+package errorcleanup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// NoticeEventType tags the notice emails in the delivery log.
+const NoticeEventType = "images.error_cleanup_notice"
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo   Repository
+	emails delivery.Service
+	now    func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService that queues notices through
+// the delivery outbox.
+func NewDefaultService(repo Repository, emails delivery.Service) *DefaultService {
+	return &DefaultService{repo: repo, emails: emails, now: time.Now}
+}
+
+// Cleanup sends notices in batches, one email per owner per batch, then trashes
+// the images whose notice is older than opts.Notice. Owners whose email can't be
+// queued have their notices withdrawn so the next run tries again; owners
+// without an email address are skipped and their images still go to the trash.
+func (s *DefaultService) Cleanup(ctx context.Context, opts Options) (*Result, error) {
+	if opts.Retention <= 0 {
+		return nil, fmt.Errorf("retention must be positive")
+	}
+	if opts.Notice < 0 || opts.Notice > opts.Retention {
+		return nil, fmt.Errorf("notice must be between zero and the retention period")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+
+	now := s.now()
+	result := &Result{}
+	for {
+		marked, err := s.repo.MarkNotified(ctx, now.Add(opts.Notice-opts.Retention), opts.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		emails, err := s.notify(ctx, marked, now.Add(opts.Notice))
+		if err != nil {
+			return result, err
+		}
+		result.Notified += len(marked)
+		result.Emails += emails
+		if len(marked) < opts.BatchSize {
+			break
+		}
+	}
+
+	for {
+		trashed, err := s.repo.TrashNotified(ctx, now.Add(-opts.Notice), opts.BatchSize)
+		result.Trashed += trashed
+		if err != nil {
+			return result, err
+		}
+		if trashed < opts.BatchSize {
+			break
+		}
+	}
+	return result, nil
+}
+
+// notify emails each owner the list of their images in marked.
+func (s *DefaultService) notify(ctx context.Context, marked []Image, trashAt time.Time) (int, error) {
+	log := logging.Default()
+	byOwner := map[string][]Image{}
+	for _, img := range marked {
+		if img.OwnerEmail == "" {
+			log.Warn(ctx, "errored image owner has no email, skipping cleanup notice", "image_id", img.ID)
+			continue
+		}
+		byOwner[img.OwnerEmail] = append(byOwner[img.OwnerEmail], img)
+	}
+
+	owners := make([]string, 0, len(byOwner))
+	for owner := range byOwner {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	for sent, owner := range owners {
+		subject, body := noticeEmail(byOwner[owner], trashAt)
+		if _, err := s.emails.EnqueueEmail(ctx, owner, NoticeEventType, subject, body); err != nil {
+			// This owner and the ones after haven't been told.
+			var ids []string
+			for _, unsent := range owners[sent:] {
+				for _, img := range byOwner[unsent] {
+					ids = append(ids, img.ID)
+				}
+			}
+			if clearErr := s.repo.ClearNotified(ctx, ids); clearErr != nil {
+				log.Error(ctx, "failed to withdraw cleanup notices", "count", len(ids), "error", clearErr)
+			}
+			return sent, fmt.Errorf("failed to queue cleanup notice: %w", err)
+		}
+	}
+	return len(owners), nil
+}
+
+// noticeEmail lists the images by project.
+func noticeEmail(images []Image, trashAt time.Time) (subject, body string) {
+	subject = "Images that failed to stage will be moved to the trash"
+	if len(images) == 1 {
+		subject = "An image that failed to stage will be moved to the trash"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The following images failed to stage and will be moved to the trash on %s.\n",
+		trashAt.UTC().Format("January 2, 2006"))
+	b.WriteString("Images in the trash can still be restored from their project for a while afterwards.\n")
+	for _, img := range images {
+		fmt.Fprintf(&b, "\n- %s: image %s", img.ProjectName, img.ID)
+		if img.Error != "" {
+			fmt.Fprintf(&b, " (%s)", img.Error)
+		}
+	}
+	b.WriteString("\n")
+	return subject, b.String()
+}
+
+// RunCleanup runs Cleanup every interval until ctx is cancelled.
+func RunCleanup(ctx context.Context, svc Service, opts Options, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := svc.Cleanup(ctx, opts)
+			if err != nil {
+				log.Error(ctx, "error image cleanup failed", "error", err)
+			}
+			if result != nil && (result.Notified > 0 || result.Trashed > 0) {
+				log.Info(ctx, "error image cleanup", "notified", result.Notified, "emails", result.Emails,
+					"trashed", result.Trashed)
+			}
+		}
+	}
+}
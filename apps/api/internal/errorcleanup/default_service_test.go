@@ -0,0 +1,162 @@
+package errorcleanup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+)
+
+func newTestService(repo Repository, emails delivery.Service, now time.Time) *DefaultService {
+	svc := NewDefaultService(repo, emails)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func queueEmails() *delivery.ServiceMock {
+	return &delivery.ServiceMock{
+		EnqueueEmailFunc: func(ctx context.Context, to, eventType, subject, body string) (*delivery.Delivery, error) {
+			return &delivery.Delivery{Destination: to}, nil
+		},
+	}
+}
+
+func TestDefaultService_Cleanup(t *testing.T) {
+	now := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	opts := Options{Retention: 30 * 24 * time.Hour, Notice: 7 * 24 * time.Hour, BatchSize: 2}
+
+	t.Run("success: notifies each owner once and trashes expired notices", func(t *testing.T) {
+		batches := [][]Image{
+			{
+				{ID: "img-1", ProjectName: "Main St", OwnerEmail: "ann@example.com", Error: "model timeout"},
+				{ID: "img-2", ProjectName: "Oak Ave", OwnerEmail: "bob@example.com"},
+			},
+			{
+				{ID: "img-3", ProjectName: "Main St", OwnerEmail: "ann@example.com"},
+			},
+		}
+		repo := &RepositoryMock{
+			MarkNotifiedFunc: func(ctx context.Context, erroredBefore time.Time, limit int) ([]Image, error) {
+				batch := batches[0]
+				batches = batches[1:]
+				return batch, nil
+			},
+			TrashNotifiedFunc: func(ctx context.Context, notifiedBefore time.Time, limit int) (int, error) {
+				return 1, nil
+			},
+		}
+		emails := queueEmails()
+
+		result, err := newTestService(repo, emails, now).Cleanup(context.Background(), opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Result{Notified: 3, Emails: 3, Trashed: 1}, result)
+		require.Len(t, repo.MarkNotifiedCalls(), 2)
+		assert.Equal(t, now.Add(-23*24*time.Hour), repo.MarkNotifiedCalls()[0].ErroredBefore)
+		assert.Equal(t, 2, repo.MarkNotifiedCalls()[0].Limit)
+		assert.Equal(t, now.Add(-7*24*time.Hour), repo.TrashNotifiedCalls()[0].NotifiedBefore)
+
+		calls := emails.EnqueueEmailCalls()
+		require.Len(t, calls, 3)
+		assert.Equal(t, "ann@example.com", calls[0].To)
+		assert.Equal(t, NoticeEventType, calls[0].EventType)
+		assert.Equal(t, "An image that failed to stage will be moved to the trash", calls[0].Subject)
+		assert.Contains(t, calls[0].Body, "moved to the trash on May 17, 2026")
+		assert.Contains(t, calls[0].Body, "- Main St: image img-1 (model timeout)")
+		assert.Equal(t, "bob@example.com", calls[1].To)
+	})
+
+	t.Run("success: owners without an email are skipped", func(t *testing.T) {
+		repo := &RepositoryMock{
+			MarkNotifiedFunc: func(ctx context.Context, erroredBefore time.Time, limit int) ([]Image, error) {
+				return []Image{{ID: "img-1", ProjectName: "Main St"}}, nil
+			},
+			TrashNotifiedFunc: func(ctx context.Context, notifiedBefore time.Time, limit int) (int, error) {
+				return 0, nil
+			},
+		}
+		emails := queueEmails()
+
+		result, err := newTestService(repo, emails, now).Cleanup(context.Background(), opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Result{Notified: 1}, result)
+		assert.Empty(t, emails.EnqueueEmailCalls())
+	})
+
+	t.Run("success: trashes in batches", func(t *testing.T) {
+		left := 5
+		repo := &RepositoryMock{
+			MarkNotifiedFunc: func(ctx context.Context, erroredBefore time.Time, limit int) ([]Image, error) {
+				return nil, nil
+			},
+			TrashNotifiedFunc: func(ctx context.Context, notifiedBefore time.Time, limit int) (int, error) {
+				n := min(left, limit)
+				left -= n
+				return n, nil
+			},
+		}
+
+		result, err := newTestService(repo, queueEmails(), now).Cleanup(context.Background(), opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Trashed)
+		assert.Len(t, repo.TrashNotifiedCalls(), 3)
+	})
+
+	t.Run("fail: email not queued withdraws the unsent notices", func(t *testing.T) {
+		repo := &RepositoryMock{
+			MarkNotifiedFunc: func(ctx context.Context, erroredBefore time.Time, limit int) ([]Image, error) {
+				return []Image{
+					{ID: "img-1", OwnerEmail: "ann@example.com"},
+					{ID: "img-2", OwnerEmail: "bob@example.com"},
+				}, nil
+			},
+			ClearNotifiedFunc: func(ctx context.Context, imageIDs []string) error {
+				return nil
+			},
+		}
+		emails := &delivery.ServiceMock{
+			EnqueueEmailFunc: func(ctx context.Context, to, eventType, subject, body string) (*delivery.Delivery, error) {
+				if to == "bob@example.com" {
+					return nil, errors.New("db down")
+				}
+				return &delivery.Delivery{}, nil
+			},
+		}
+
+		_, err := newTestService(repo, emails, now).Cleanup(context.Background(), opts)
+
+		assert.ErrorContains(t, err, "failed to queue cleanup notice")
+		require.Len(t, repo.ClearNotifiedCalls(), 1)
+		assert.Equal(t, []string{"img-2"}, repo.ClearNotifiedCalls()[0].ImageIDs)
+		assert.Empty(t, repo.TrashNotifiedCalls(), "nothing is trashed on a failed run")
+	})
+
+	t.Run("fail: mark error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			MarkNotifiedFunc: func(ctx context.Context, erroredBefore time.Time, limit int) ([]Image, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		_, err := newTestService(repo, queueEmails(), now).Cleanup(context.Background(), opts)
+
+		assert.ErrorContains(t, err, "db down")
+	})
+
+	t.Run("fail: invalid options", func(t *testing.T) {
+		svc := newTestService(&RepositoryMock{}, queueEmails(), now)
+
+		_, err := svc.Cleanup(context.Background(), Options{})
+		assert.ErrorContains(t, err, "retention must be positive")
+
+		_, err = svc.Cleanup(context.Background(), Options{Retention: time.Hour, Notice: 2 * time.Hour})
+		assert.ErrorContains(t, err, "notice must be between zero and the retention period")
+	})
+}
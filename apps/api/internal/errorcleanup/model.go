@@ -0,0 +1,40 @@
+// Package errorcleanup moves images that have been stuck in error for too long
+// to the trash, after emailing their owners a notice.
+package errorcleanup
+
+import "time"
+
+// DefaultBatchSize bounds how many images one statement marks or trashes.
+const DefaultBatchSize = 100
+
+// Options configures a cleanup run.
+type Options struct {
+	// Retention is how long an image may stay in error before it is trashed.
+	Retention time.Duration
+	// Notice is how long before that its owner is emailed. Images are only
+	// trashed once a notice has been out for this long, so one that was
+	// missed while the cleanup was off still gets the full notice period.
+	Notice time.Duration
+	// BatchSize bounds each statement; DefaultBatchSize when zero.
+	BatchSize int
+}
+
+// Image is an errored image whose owner is being told it will be trashed.
+type Image struct {
+	ID          string
+	ProjectID   string
+	ProjectName string
+	// OwnerEmail is the project creator's email; empty when they have none.
+	OwnerEmail string
+	Error      string
+}
+
+// Result summarizes a cleanup run.
+type Result struct {
+	// Notified counts images whose owners were told about the cleanup.
+	Notified int `json:"notified"`
+	// Emails counts the notice emails queued, one per owner.
+	Emails int `json:"emails"`
+	// Trashed counts images moved to the trash.
+	Trashed int `json:"trashed"`
+}
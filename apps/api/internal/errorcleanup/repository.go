@@ -0,0 +1,26 @@
+package errorcleanup
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository selects and trashes errored images. Images in locked projects,
+// images under fair-housing prompt review and images with a support ticket or
+// a rating are never touched.
+type Repository interface {
+	// MarkNotified records a notice for up to limit live images that have been
+	// in error since before erroredBefore and have no notice for their current
+	// failure, and returns them with their owners.
+	MarkNotified(ctx context.Context, erroredBefore time.Time, limit int) ([]Image, error)
+
+	// ClearNotified withdraws the notices of the images, so the next run
+	// notifies their owners again.
+	ClearNotified(ctx context.Context, imageIDs []string) error
+
+	// TrashNotified moves up to limit images to the trash whose notice went
+	// out before notifiedBefore and that are still in the error it was about.
+	TrashNotified(ctx context.Context, notifiedBefore time.Time, limit int) (int, error)
+}
@@ -0,0 +1,12 @@
+package errorcleanup
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service cleans up images left in error.
+type Service interface {
+	// Cleanup notifies the owners of images about to reach opts.Retention in
+	// error, then trashes the images whose notice period has passed.
+	Cleanup(ctx context.Context, opts Options) (*Result, error)
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+type subscriber struct {
+	id      uint64
+	name    string
+	handler Handler
+}
+
+// DefaultBus is the in-memory Bus implementation.
+type DefaultBus struct {
+	mu     sync.RWMutex
+	nextID uint64
+	subs   map[Type][]subscriber
+	log    logging.Logger
+}
+
+// NewDefaultBus creates an empty bus. A nil logger falls back to logging.Default().
+func NewDefaultBus(log logging.Logger) *DefaultBus {
+	return &DefaultBus{
+		subs: make(map[Type][]subscriber),
+		log:  log,
+	}
+}
+
+// Subscribe registers handler for eventType and returns an unsubscribe function.
+func (b *DefaultBus) Subscribe(eventType Type, name string, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subs[eventType] = append(b.subs[eventType], subscriber{id: id, name: name, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		current := b.subs[eventType]
+		for i, s := range current {
+			if s.id == id {
+				b.subs[eventType] = append(current[:i:i], current[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers event to each subscriber in registration order.
+func (b *DefaultBus) Publish(ctx context.Context, event Event) error {
+	if event == nil {
+		return errors.New("event cannot be nil")
+	}
+
+	b.mu.RLock()
+	subs := append([]subscriber(nil), b.subs[event.EventType()]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subs {
+		if err := b.dispatch(ctx, s, event); err != nil {
+			b.logger().Error(ctx, "event subscriber failed",
+				"event_type", string(event.EventType()),
+				"subscriber", s.name,
+				"error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dispatch invokes a single subscriber, converting panics into errors so one
+// misbehaving subscriber cannot take down the publisher.
+func (b *DefaultBus) dispatch(ctx context.Context, s subscriber, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return s.handler(ctx, event)
+}
+
+func (b *DefaultBus) logger() logging.Logger {
+	if b.log != nil {
+		return b.log
+	}
+	return logging.Default()
+}
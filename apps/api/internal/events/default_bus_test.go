@@ -0,0 +1,175 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func quietLogger() *logging.LoggerMock {
+	return &logging.LoggerMock{
+		ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+		InfoFunc:  func(ctx context.Context, msg string, keysAndValues ...any) {},
+	}
+}
+
+func TestDefaultBus_Publish(t *testing.T) {
+	tests := []struct {
+		name        string
+		subscribe   func(bus *DefaultBus, calls *[]string)
+		event       Event
+		wantCalls   []string
+		expectError bool
+	}{
+		{
+			name: "success: delivers to subscribers of the event type in order",
+			subscribe: func(bus *DefaultBus, calls *[]string) {
+				bus.Subscribe(TypeImageCreated, "a", func(ctx context.Context, e Event) error {
+					*calls = append(*calls, "a")
+					return nil
+				})
+				bus.Subscribe(TypeImageCreated, "b", func(ctx context.Context, e Event) error {
+					*calls = append(*calls, "b")
+					return nil
+				})
+				bus.Subscribe(TypeImageReady, "other", func(ctx context.Context, e Event) error {
+					*calls = append(*calls, "other")
+					return nil
+				})
+			},
+			event:     ImageCreated{ImageID: "img-1"},
+			wantCalls: []string{"a", "b"},
+		},
+		{
+			name: "success: no subscribers",
+			subscribe: func(bus *DefaultBus, calls *[]string) {
+			},
+			event: SubscriptionChanged{UserID: "u"},
+		},
+		{
+			name: "fail: failing subscriber does not stop the others",
+			subscribe: func(bus *DefaultBus, calls *[]string) {
+				bus.Subscribe(TypeImageReady, "broken", func(ctx context.Context, e Event) error {
+					*calls = append(*calls, "broken")
+					return errors.New("boom")
+				})
+				bus.Subscribe(TypeImageReady, "healthy", func(ctx context.Context, e Event) error {
+					*calls = append(*calls, "healthy")
+					return nil
+				})
+			},
+			event:       ImageReady{ImageID: "img-1"},
+			wantCalls:   []string{"broken", "healthy"},
+			expectError: true,
+		},
+		{
+			name: "fail: panicking subscriber is isolated",
+			subscribe: func(bus *DefaultBus, calls *[]string) {
+				bus.Subscribe(TypeImageFailed, "panics", func(ctx context.Context, e Event) error {
+					panic("unexpected")
+				})
+				bus.Subscribe(TypeImageFailed, "healthy", func(ctx context.Context, e Event) error {
+					*calls = append(*calls, "healthy")
+					return nil
+				})
+			},
+			event:       ImageFailed{ImageID: "img-1"},
+			wantCalls:   []string{"healthy"},
+			expectError: true,
+		},
+		{
+			name:        "fail: nil event",
+			subscribe:   func(bus *DefaultBus, calls *[]string) {},
+			event:       nil,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewDefaultBus(quietLogger())
+			var calls []string
+			tt.subscribe(bus, &calls)
+
+			err := bus.Publish(context.Background(), tt.event)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestDefaultBus_Unsubscribe(t *testing.T) {
+	bus := NewDefaultBus(quietLogger())
+	count := 0
+	unsubscribe := bus.Subscribe(TypeImageCreated, "counter", func(ctx context.Context, e Event) error {
+		count++
+		return nil
+	})
+
+	require.NoError(t, bus.Publish(context.Background(), ImageCreated{}))
+	unsubscribe()
+	require.NoError(t, bus.Publish(context.Background(), ImageCreated{}))
+
+	assert.Equal(t, 1, count)
+}
+
+func TestOn(t *testing.T) {
+	bus := NewDefaultBus(quietLogger())
+	var got SubscriptionChanged
+	On(bus, "typed", func(ctx context.Context, e SubscriptionChanged) error {
+		got = e
+		return nil
+	})
+
+	want := SubscriptionChanged{UserID: "u1", Status: "active", Reason: "updated"}
+	require.NoError(t, bus.Publish(context.Background(), want))
+	assert.Equal(t, want, got)
+}
+
+func TestTranslateJobUpdate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		channel string
+		payload string
+		want    Event
+	}{
+		{
+			name:    "success: ready",
+			channel: "jobs:image:img-1",
+			payload: `{"status":"ready"}`,
+			want:    ImageReady{ImageID: "img-1", OccurredAt: now},
+		},
+		{
+			name:    "success: error",
+			channel: "jobs:image:img-1",
+			payload: `{"status":"error","error":"model failed"}`,
+			want:    ImageFailed{ImageID: "img-1", Error: "model failed", OccurredAt: now},
+		},
+		{
+			name:    "success: processing",
+			channel: "jobs:image:img-1",
+			payload: `{"status":"processing"}`,
+			want:    ImageProcessing{ImageID: "img-1", OccurredAt: now},
+		},
+		{name: "success: queued is ignored", channel: "jobs:image:img-1", payload: `{"status":"queued"}`},
+		{name: "fail: unknown channel", channel: "other:img-1", payload: `{"status":"ready"}`},
+		{name: "fail: malformed payload", channel: "jobs:image:img-1", payload: `not-json`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TranslateJobUpdate(tt.channel, []byte(tt.payload), now))
+		})
+	}
+}
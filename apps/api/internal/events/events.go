@@ -0,0 +1,172 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out events_mock.go . Bus
+
+// Type identifies a domain event, e.g. "image.created".
+type Type string
+
+const (
+	// TypeImageCreated is published after an image row is persisted and its job enqueued.
+	TypeImageCreated Type = "image.created"
+	// TypeImageProcessing is published when the worker starts staging an image.
+	TypeImageProcessing Type = "image.processing"
+	// TypeImageReady is published when the worker reports a staged image as ready.
+	TypeImageReady Type = "image.ready"
+	// TypeImageFailed is published when the worker reports a staging error.
+	TypeImageFailed Type = "image.failed"
+	// TypeImageCanceled is published when the owner cancels an image before it is staged.
+	TypeImageCanceled Type = "image.canceled"
+	// TypeSubscriptionChanged is published whenever a Stripe subscription is persisted.
+	TypeSubscriptionChanged Type = "subscription.changed"
+	// TypeInvoiceChanged is published whenever a Stripe invoice is persisted.
+	TypeInvoiceChanged Type = "invoice.changed"
+	// TypeCheckoutCompleted is published when a Stripe Checkout Session for a known user completes.
+	TypeCheckoutCompleted Type = "checkout.completed"
+	// TypeSubscriptionGraceExpired is published when a past-due subscription's grace period ends.
+	TypeSubscriptionGraceExpired Type = "subscription.grace_expired"
+)
+
+// Event is implemented by every typed domain event carried on the Bus.
+type Event interface {
+	EventType() Type
+}
+
+// Handler reacts to a published event. Returned errors are logged and reported
+// back to the publisher but never prevent other subscribers from running.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus is an in-process publish/subscribe dispatcher for domain events.
+//
+// Side effects such as notifications, analytics, outbound webhooks and SSE
+// fan-out register as subscribers rather than being called directly from
+// handlers and services.
+type Bus interface {
+	// Subscribe registers handler for eventType under a descriptive name used in
+	// logs. The returned function removes the subscription.
+	Subscribe(eventType Type, name string, handler Handler) func()
+	// Publish synchronously delivers event to every subscriber of its type.
+	// A failing or panicking subscriber does not affect the others; their
+	// errors are joined into the returned error.
+	Publish(ctx context.Context, event Event) error
+}
+
+// ImageCreated is published after an image is created and queued for staging.
+type ImageCreated struct {
+	ImageID    string    `json:"image_id"`
+	ProjectID  string    `json:"project_id"`
+	RoomType   *string   `json:"room_type,omitempty"`
+	Style      *string   `json:"style,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (ImageCreated) EventType() Type { return TypeImageCreated }
+
+// ImageProcessing is published when a worker picks up an image for staging.
+type ImageProcessing struct {
+	ImageID    string    `json:"image_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (ImageProcessing) EventType() Type { return TypeImageProcessing }
+
+// ImageReady is published when a staged image becomes available.
+type ImageReady struct {
+	ImageID    string    `json:"image_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (ImageReady) EventType() Type { return TypeImageReady }
+
+// ImageFailed is published when staging an image fails.
+type ImageFailed struct {
+	ImageID    string    `json:"image_id"`
+	Error      string    `json:"error,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (ImageFailed) EventType() Type { return TypeImageFailed }
+
+// ImageCanceled is published when an image's staging job is canceled.
+type ImageCanceled struct {
+	ImageID    string    `json:"image_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (ImageCanceled) EventType() Type { return TypeImageCanceled }
+
+// SubscriptionChanged is published when a subscription is created, updated or canceled.
+type SubscriptionChanged struct {
+	UserID               string    `json:"user_id"`
+	StripeSubscriptionID string    `json:"stripe_subscription_id"`
+	Status               string    `json:"status"`
+	PriceID              *string   `json:"price_id,omitempty"`
+	Reason               string    `json:"reason"` // created, updated, deleted
+	OccurredAt           time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (SubscriptionChanged) EventType() Type { return TypeSubscriptionChanged }
+
+// InvoiceChanged is published when an invoice is paid or its payment fails.
+type InvoiceChanged struct {
+	UserID               string    `json:"user_id"`
+	StripeInvoiceID      string    `json:"stripe_invoice_id"`
+	StripeSubscriptionID *string   `json:"stripe_subscription_id,omitempty"`
+	Status               string    `json:"status"`
+	AmountDue            int32     `json:"amount_due"`
+	AmountPaid           int32     `json:"amount_paid"`
+	Currency             *string   `json:"currency,omitempty"`
+	Reason               string    `json:"reason"` // paid, payment_succeeded, payment_failed
+	OccurredAt           time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (InvoiceChanged) EventType() Type { return TypeInvoiceChanged }
+
+// CheckoutCompleted is published when a user completes a Checkout Session,
+// either starting a subscription or buying a credit pack.
+type CheckoutCompleted struct {
+	UserID                  string    `json:"user_id"`
+	StripeCheckoutSessionID string    `json:"stripe_checkout_session_id"`
+	Mode                    string    `json:"mode"` // subscription, payment
+	OccurredAt              time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (CheckoutCompleted) EventType() Type { return TypeCheckoutCompleted }
+
+// SubscriptionGraceExpired is published once when a subscription has been past
+// due for the whole grace period and its user loses the plan.
+type SubscriptionGraceExpired struct {
+	UserID               string    `json:"user_id"`
+	StripeSubscriptionID string    `json:"stripe_subscription_id"`
+	PastDueSince         time.Time `json:"past_due_since"`
+	GraceEndedAt         time.Time `json:"grace_ended_at"`
+	OccurredAt           time.Time `json:"occurred_at"`
+}
+
+// EventType implements Event.
+func (SubscriptionGraceExpired) EventType() Type { return TypeSubscriptionGraceExpired }
+
+// On registers a handler that receives a concrete event type, sparing
+// subscribers the type assertion. The event type is derived from E.
+func On[E Event](bus Bus, name string, fn func(ctx context.Context, event E) error) func() {
+	var zero E
+	return bus.Subscribe(zero.EventType(), name, func(ctx context.Context, event Event) error {
+		typed, ok := event.(E)
+		if !ok {
+			return nil
+		}
+		return fn(ctx, typed)
+	})
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// jobUpdateChannelPrefix matches the per-image channels the worker publishes
+// status updates on (jobs:image:{image_id}).
+const jobUpdateChannelPrefix = "jobs:image:"
+
+// jobUpdateMessage is the status-only payload published by the worker.
+type jobUpdateMessage struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// TranslateJobUpdate converts a worker job update into a domain event. It
+// returns nil for statuses that have no corresponding event (e.g. queued).
+func TranslateJobUpdate(channel string, payload []byte, now time.Time) Event {
+	imageID := strings.TrimPrefix(channel, jobUpdateChannelPrefix)
+	if imageID == "" || imageID == channel {
+		return nil
+	}
+
+	var msg jobUpdateMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return nil
+	}
+
+	switch msg.Status {
+	case "processing":
+		return ImageProcessing{ImageID: imageID, OccurredAt: now}
+	case "ready":
+		return ImageReady{ImageID: imageID, OccurredAt: now}
+	case "error":
+		return ImageFailed{ImageID: imageID, Error: msg.Error, OccurredAt: now}
+	default:
+		return nil
+	}
+}
+
+// RunJobUpdateBridge subscribes to every per-image worker channel and
+// republishes terminal statuses on bus until ctx is cancelled.
+func RunJobUpdateBridge(ctx context.Context, rdb *redis.Client, bus Bus) {
+	log := logging.Default()
+	sub := rdb.PSubscribe(ctx, jobUpdateChannelPrefix+"*")
+	defer func() {
+		if err := sub.Close(); err != nil {
+			log.Warn(ctx, "job update bridge: close subscription", "error", err)
+		}
+	}()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			event := TranslateJobUpdate(msg.Channel, []byte(msg.Payload), time.Now().UTC())
+			if event == nil {
+				continue
+			}
+			// Subscriber failures are logged by the bus; the bridge keeps running.
+			_ = bus.Publish(ctx, event)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// RegisterLogSubscribers records every domain event as a structured log line,
+// giving analytics pipelines that tail the logs a single, consistent feed.
+func RegisterLogSubscribers(bus Bus, log logging.Logger) {
+	for _, t := range []Type{
+		TypeImageCreated, TypeImageProcessing, TypeImageReady, TypeImageFailed, TypeImageCanceled,
+		TypeSubscriptionChanged, TypeInvoiceChanged, TypeCheckoutCompleted, TypeSubscriptionGraceExpired,
+	} {
+		bus.Subscribe(t, "log", func(ctx context.Context, event Event) error {
+			log.Info(ctx, "domain event", "event_type", string(event.EventType()), "event", event)
+			return nil
+		})
+	}
+}
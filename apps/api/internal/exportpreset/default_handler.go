@@ -0,0 +1,164 @@
+package exportpreset
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/org"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// CodeDuplicateName is the problem code for a preset name conflict.
+const CodeDuplicateName = "export_preset_exists"
+
+// DefaultHandler serves the export preset API.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// CreatePreset handles POST /api/v1/orgs/:id/export-presets.
+func (h *DefaultHandler) CreatePreset(c echo.Context) error {
+	userID, orgID, p := h.orgRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	var req CreatePresetRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	preset, err := h.service.CreatePreset(c.Request().Context(), userID, orgID, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to create export preset")
+	}
+	h.log.Info(c.Request().Context(), "export preset created", "org_id", orgID, "preset_id", preset.ID)
+	return c.JSON(http.StatusCreated, preset)
+}
+
+// ListPresets handles GET /api/v1/orgs/:id/export-presets.
+func (h *DefaultHandler) ListPresets(c echo.Context) error {
+	userID, orgID, p := h.orgRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	presets, err := h.service.ListPresets(c.Request().Context(), userID, orgID)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list export presets")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"presets": presets})
+}
+
+// GetPreset handles GET /api/v1/orgs/:id/export-presets/:preset_id.
+func (h *DefaultHandler) GetPreset(c echo.Context) error {
+	userID, orgID, id, p := h.presetRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	preset, err := h.service.GetPreset(c.Request().Context(), userID, orgID, id)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to get export preset")
+	}
+	return c.JSON(http.StatusOK, preset)
+}
+
+// UpdatePreset handles PATCH /api/v1/orgs/:id/export-presets/:preset_id.
+func (h *DefaultHandler) UpdatePreset(c echo.Context) error {
+	userID, orgID, id, p := h.presetRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	var req UpdatePresetRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	preset, err := h.service.UpdatePreset(c.Request().Context(), userID, orgID, id, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to update export preset")
+	}
+	h.log.Info(c.Request().Context(), "export preset updated", "org_id", orgID, "preset_id", preset.ID)
+	return c.JSON(http.StatusOK, preset)
+}
+
+// DeletePreset handles DELETE /api/v1/orgs/:id/export-presets/:preset_id.
+func (h *DefaultHandler) DeletePreset(c echo.Context) error {
+	userID, orgID, id, p := h.presetRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	if err := h.service.DeletePreset(c.Request().Context(), userID, orgID, id); err != nil {
+		return h.serviceError(c, err, "Failed to delete export preset")
+	}
+	h.log.Info(c.Request().Context(), "export preset deleted", "org_id", orgID, "preset_id", id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// presetRequest validates :id and :preset_id and resolves the caller.
+func (h *DefaultHandler) presetRequest(c echo.Context) (string, string, string, *problem.Problem) {
+	id := c.Param("preset_id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", "", "", problem.New(http.StatusBadRequest, problem.CodeBadRequest,
+			"Invalid export preset ID format")
+	}
+	userID, orgID, p := h.orgRequest(c)
+	if p != nil {
+		return "", "", "", p
+	}
+	return userID, orgID, id, nil
+}
+
+// orgRequest validates :id and resolves the caller; membership and role are
+// enforced by the service.
+func (h *DefaultHandler) orgRequest(c echo.Context) (string, string, *problem.Problem) {
+	orgID := c.Param("id")
+	if _, err := uuid.Parse(orgID); err != nil {
+		return "", "", problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid organization ID format")
+	}
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return "", "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not found")
+	}
+	return userRow.ID.String(), orgID, nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, org.ErrOrgNotFound):
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Organization not found")
+	case errors.Is(err, ErrForbidden):
+		return problem.Write(c, http.StatusForbidden, problem.CodeForbidden, err.Error())
+	case errors.Is(err, ErrPresetNotFound):
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Export preset not found")
+	case errors.Is(err, ErrInvalidPreset):
+		return problem.Write(c, http.StatusUnprocessableEntity, problem.CodeValidationFailed, err.Error())
+	case errors.Is(err, ErrDuplicateName):
+		return problem.Write(c, http.StatusConflict, CodeDuplicateName, err.Error())
+	}
+	h.log.Error(c.Request().Context(), "export preset request failed", "error", err)
+	return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, message)
+}
@@ -0,0 +1,204 @@
+package exportpreset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/echotest"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/org"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newTestHandler(svc Service) *DefaultHandler {
+	userID := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	return NewDefaultHandler(svc, userRepo, logging.Default())
+}
+
+func TestDefaultHandler_CreatePreset(t *testing.T) {
+	orgID := uuid.NewString()
+	body := `{"name":"mls","width":1024,"mls_banner":true}`
+
+	cases := []struct {
+		name         string
+		orgID        string
+		body         string
+		createErr    error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: returns the preset",
+			orgID:        orgID,
+			body:         body,
+			expectedCode: http.StatusCreated,
+			expectBody:   `"mls_banner":true`,
+		},
+		{name: "fail: invalid org id", orgID: "nope", body: body, expectedCode: http.StatusBadRequest},
+		{name: "fail: malformed body", orgID: orgID, body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: not a member",
+			orgID:        orgID,
+			body:         body,
+			createErr:    org.ErrOrgNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: member role",
+			orgID:        orgID,
+			body:         body,
+			createErr:    ErrForbidden,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: validation",
+			orgID:        orgID,
+			body:         body,
+			createErr:    ErrInvalidPreset,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:         "fail: duplicate name",
+			orgID:        orgID,
+			body:         body,
+			createErr:    ErrDuplicateName,
+			expectedCode: http.StatusConflict,
+			expectBody:   CodeDuplicateName,
+		},
+		{
+			name:         "fail: service error",
+			orgID:        orgID,
+			body:         body,
+			createErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreatePresetFunc: func(
+					ctx context.Context, userID, orgID string, req CreatePresetRequest,
+				) (*Preset, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Preset{
+						ID: "preset-1", OrgID: orgID, Name: req.Name, Width: req.Width, MLSBanner: req.MLSBanner,
+					}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodPost, tc.body, []string{"id"}, []string{tc.orgID})
+
+			require.NoError(t, newTestHandler(svc).CreatePreset(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_ListPresets(t *testing.T) {
+	svc := &ServiceMock{
+		ListPresetsFunc: func(ctx context.Context, userID, orgID string) ([]Preset, error) {
+			return []Preset{{ID: "preset-1", OrgID: orgID, Name: "mls"}}, nil
+		},
+	}
+	c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{uuid.NewString()})
+
+	require.NoError(t, newTestHandler(svc).ListPresets(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"presets":[{"id":"preset-1"`)
+}
+
+func TestDefaultHandler_GetPreset(t *testing.T) {
+	id := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		id           string
+		getErr       error
+		expectedCode int
+	}{
+		{name: "success: returns the preset", id: id, expectedCode: http.StatusOK},
+		{name: "fail: invalid id", id: "nope", expectedCode: http.StatusBadRequest},
+		{name: "fail: not found", id: id, getErr: ErrPresetNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetPresetFunc: func(ctx context.Context, userID, orgID, gotID string) (*Preset, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Preset{ID: gotID, OrgID: orgID, Name: "mls"}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodGet, "", []string{"id", "preset_id"},
+				[]string{uuid.NewString(), tc.id})
+
+			require.NoError(t, newTestHandler(svc).GetPreset(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_UpdatePreset(t *testing.T) {
+	svc := &ServiceMock{
+		UpdatePresetFunc: func(
+			ctx context.Context, userID, orgID, id string, req UpdatePresetRequest,
+		) (*Preset, error) {
+			require.NotNil(t, req.Width)
+			assert.Equal(t, 0, *req.Width)
+			assert.Nil(t, req.Name)
+			return &Preset{ID: id, OrgID: orgID, Name: "mls"}, nil
+		},
+	}
+	c, rec := echotest.NewContext(http.MethodPatch, `{"width":0}`, []string{"id", "preset_id"},
+		[]string{uuid.NewString(), uuid.NewString()})
+
+	require.NoError(t, newTestHandler(svc).UpdatePreset(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), `"width"`)
+}
+
+func TestDefaultHandler_DeletePreset(t *testing.T) {
+	cases := []struct {
+		name         string
+		deleteErr    error
+		expectedCode int
+	}{
+		{name: "success: deleted", expectedCode: http.StatusNoContent},
+		{name: "fail: member role", deleteErr: ErrForbidden, expectedCode: http.StatusForbidden},
+		{name: "fail: not found", deleteErr: ErrPresetNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DeletePresetFunc: func(ctx context.Context, userID, orgID, id string) error { return tc.deleteErr },
+			}
+			c, rec := echotest.NewContext(http.MethodDelete, "", []string{"id", "preset_id"},
+				[]string{uuid.NewString(), uuid.NewString()})
+
+			require.NoError(t, newTestHandler(svc).DeletePreset(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
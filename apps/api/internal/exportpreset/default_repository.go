@@ -0,0 +1,138 @@
+package exportpreset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// presetColumns are the export_presets columns scanPreset reads.
+const presetColumns = ` id, org_id, name, width, height, format, quality, watermark_text, watermark_position,
+	mls_banner, created_at, updated_at`
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+func scanPreset(row pgx.Row) (*Preset, error) {
+	var p Preset
+	if err := row.Scan(&p.ID, &p.OrgID, &p.Name, &p.Width, &p.Height, &p.Format, &p.Quality, &p.WatermarkText,
+		&p.WatermarkPosition, &p.MLSBanner, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Create inserts a new preset.
+func (r *DefaultRepository) Create(ctx context.Context, p *Preset) (*Preset, error) {
+	query := `
+		INSERT INTO export_presets (org_id, name, width, height, format, quality, watermark_text,
+			watermark_position, mls_banner)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING` + presetColumns
+
+	created, err := scanPreset(r.db.QueryRow(ctx, query, p.OrgID, p.Name, p.Width, p.Height, p.Format, p.Quality,
+		p.WatermarkText, p.WatermarkPosition, p.MLSBanner))
+	if err != nil {
+		if storage.IsUniqueViolation(err) {
+			return nil, ErrDuplicateName
+		}
+		return nil, fmt.Errorf("failed to create export preset: %w", err)
+	}
+	return created, nil
+}
+
+// GetByID retrieves one of an organization's presets.
+func (r *DefaultRepository) GetByID(ctx context.Context, orgID, id string) (*Preset, error) {
+	query := `SELECT` + presetColumns + ` FROM export_presets WHERE org_id = $1 AND id = $2`
+	return r.get(ctx, query, orgID, id)
+}
+
+// GetByName retrieves one of an organization's presets by name.
+func (r *DefaultRepository) GetByName(ctx context.Context, orgID, name string) (*Preset, error) {
+	query := `SELECT` + presetColumns + ` FROM export_presets WHERE org_id = $1 AND name = $2`
+	return r.get(ctx, query, orgID, name)
+}
+
+func (r *DefaultRepository) get(ctx context.Context, query string, args ...interface{}) (*Preset, error) {
+	p, err := scanPreset(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPresetNotFound
+		}
+		return nil, fmt.Errorf("failed to get export preset: %w", err)
+	}
+	return p, nil
+}
+
+// List returns an organization's presets ordered by name.
+func (r *DefaultRepository) List(ctx context.Context, orgID string) ([]Preset, error) {
+	query := `SELECT` + presetColumns + ` FROM export_presets WHERE org_id = $1 ORDER BY name ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export presets: %w", err)
+	}
+	defer rows.Close()
+
+	presets := []Preset{}
+	for rows.Next() {
+		p, err := scanPreset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan export preset: %w", err)
+		}
+		presets = append(presets, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over export preset rows: %w", err)
+	}
+	return presets, nil
+}
+
+// Update persists every field of a preset.
+func (r *DefaultRepository) Update(ctx context.Context, p *Preset) (*Preset, error) {
+	query := `
+		UPDATE export_presets
+		SET name = $3, width = $4, height = $5, format = $6, quality = $7, watermark_text = $8,
+			watermark_position = $9, mls_banner = $10, updated_at = now()
+		WHERE org_id = $1 AND id = $2
+		RETURNING` + presetColumns
+
+	updated, err := scanPreset(r.db.QueryRow(ctx, query, p.OrgID, p.ID, p.Name, p.Width, p.Height, p.Format,
+		p.Quality, p.WatermarkText, p.WatermarkPosition, p.MLSBanner))
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, ErrPresetNotFound
+		case storage.IsUniqueViolation(err):
+			return nil, ErrDuplicateName
+		}
+		return nil, fmt.Errorf("failed to update export preset: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete removes one of an organization's presets.
+func (r *DefaultRepository) Delete(ctx context.Context, orgID, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM export_presets WHERE org_id = $1 AND id = $2`, orgID, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete export preset: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPresetNotFound
+	}
+	return nil
+}
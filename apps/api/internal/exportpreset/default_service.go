@@ -0,0 +1,140 @@
+package exportpreset
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/org"
+)
+
+// DefaultService implements Service on top of the preset table and the
+// organization memberships.
+type DefaultService struct {
+	repo Repository
+	orgs org.Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository, orgs org.Repository) *DefaultService {
+	return &DefaultService{repo: repo, orgs: orgs}
+}
+
+// CreatePreset adds a preset to an organization.
+func (s *DefaultService) CreatePreset(
+	ctx context.Context, userID, orgID string, req CreatePresetRequest,
+) (*Preset, error) {
+	if err := s.authorize(ctx, userID, orgID, org.RoleAdmin); err != nil {
+		return nil, err
+	}
+	p := &Preset{
+		OrgID:             orgID,
+		Name:              req.Name,
+		Width:             req.Width,
+		Height:            req.Height,
+		Format:            req.Format,
+		Quality:           req.Quality,
+		WatermarkText:     req.WatermarkText,
+		WatermarkPosition: req.WatermarkPosition,
+		MLSBanner:         req.MLSBanner,
+	}
+	if err := p.normalize(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, p)
+}
+
+// ListPresets returns an organization's presets ordered by name.
+func (s *DefaultService) ListPresets(ctx context.Context, userID, orgID string) ([]Preset, error) {
+	if err := s.authorize(ctx, userID, orgID, org.RoleMember); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, orgID)
+}
+
+// GetPreset retrieves one of an organization's presets.
+func (s *DefaultService) GetPreset(ctx context.Context, userID, orgID, id string) (*Preset, error) {
+	if err := s.authorize(ctx, userID, orgID, org.RoleMember); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, orgID, id)
+}
+
+// UpdatePreset changes one of an organization's presets.
+func (s *DefaultService) UpdatePreset(
+	ctx context.Context, userID, orgID, id string, req UpdatePresetRequest,
+) (*Preset, error) {
+	if err := s.authorize(ctx, userID, orgID, org.RoleAdmin); err != nil {
+		return nil, err
+	}
+	p, err := s.repo.GetByID(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Width != nil {
+		p.Width = req.Width
+		if *req.Width == 0 {
+			p.Width = nil
+		}
+	}
+	if req.Height != nil {
+		p.Height = req.Height
+		if *req.Height == 0 {
+			p.Height = nil
+		}
+	}
+	if req.Format != nil {
+		p.Format = *req.Format
+	}
+	if req.Quality != nil {
+		p.Quality = *req.Quality
+	}
+	if req.WatermarkText != nil {
+		p.WatermarkText = req.WatermarkText
+		if *req.WatermarkText == "" {
+			p.WatermarkText = nil
+		}
+	}
+	if req.WatermarkPosition != nil {
+		p.WatermarkPosition = *req.WatermarkPosition
+	}
+	if req.MLSBanner != nil {
+		p.MLSBanner = *req.MLSBanner
+	}
+	if err := p.normalize(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Update(ctx, p)
+}
+
+// DeletePreset removes one of an organization's presets.
+func (s *DefaultService) DeletePreset(ctx context.Context, userID, orgID, id string) error {
+	if err := s.authorize(ctx, userID, orgID, org.RoleAdmin); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, orgID, id)
+}
+
+// ResolvePreset finds an organization's preset by name.
+func (s *DefaultService) ResolvePreset(ctx context.Context, orgID, name string) (*Preset, error) {
+	return s.repo.GetByName(ctx, orgID, name)
+}
+
+// authorize checks that the user belongs to the organization with at least
+// the required role. Non-members get org.ErrOrgNotFound.
+func (s *DefaultService) authorize(ctx context.Context, userID, orgID string, required org.Role) error {
+	o, err := s.orgs.GetForMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !o.Role.AtLeast(required) {
+		return ErrForbidden
+	}
+	return nil
+}
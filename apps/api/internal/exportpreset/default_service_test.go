@@ -0,0 +1,255 @@
+package exportpreset
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/org"
+)
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+
+func echoCreate(ctx context.Context, p *Preset) (*Preset, error) {
+	created := *p
+	created.ID = "preset-1"
+	return &created, nil
+}
+
+// orgsWithRole returns an org repository in which every caller has role.
+func orgsWithRole(role org.Role) *org.RepositoryMock {
+	return &org.RepositoryMock{
+		GetForMemberFunc: func(ctx context.Context, id, userID string) (*org.Organization, error) {
+			return &org.Organization{ID: id, Role: role}, nil
+		},
+	}
+}
+
+func TestDefaultService_CreatePreset(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		role      org.Role
+		req       CreatePresetRequest
+		want      *Preset
+		expectErr error
+	}{
+		{
+			name: "success: defaults",
+			role: org.RoleAdmin,
+			req:  CreatePresetRequest{Name: " mls-1024 ", Width: intPtr(1024)},
+			want: &Preset{
+				OrgID: "org-1", Name: "mls-1024", Width: intPtr(1024), Format: FormatJPEG, Quality: DefaultQuality,
+				WatermarkPosition: "bottom_right",
+			},
+		},
+		{
+			name: "success: every field",
+			role: org.RoleOwner,
+			req: CreatePresetRequest{
+				Name: "print", Width: intPtr(3000), Height: intPtr(2000), Format: FormatPNG, Quality: 80,
+				WatermarkText: strPtr(" Acme Realty "), WatermarkPosition: "top_left", MLSBanner: true,
+			},
+			want: &Preset{
+				OrgID: "org-1", Name: "print", Width: intPtr(3000), Height: intPtr(2000), Format: FormatPNG,
+				Quality: 80, WatermarkText: strPtr("Acme Realty"), WatermarkPosition: "top_left", MLSBanner: true,
+			},
+		},
+		{
+			name:      "fail: members can't create presets",
+			role:      org.RoleMember,
+			req:       CreatePresetRequest{Name: "mls"},
+			expectErr: ErrForbidden,
+		},
+		{
+			name:      "fail: name with spaces",
+			role:      org.RoleAdmin,
+			req:       CreatePresetRequest{Name: "MLS 1024"},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: name too long",
+			role:      org.RoleAdmin,
+			req:       CreatePresetRequest{Name: strings.Repeat("a", MaxNameLength+1)},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: width out of range",
+			role:      org.RoleAdmin,
+			req:       CreatePresetRequest{Name: "huge", Width: intPtr(MaxDimension + 1)},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: unsupported format",
+			role:      org.RoleAdmin,
+			req:       CreatePresetRequest{Name: "webp", Format: "webp"},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: quality out of range",
+			role:      org.RoleAdmin,
+			req:       CreatePresetRequest{Name: "q", Quality: 101},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: blank watermark text",
+			role:      org.RoleAdmin,
+			req:       CreatePresetRequest{Name: "mark", WatermarkText: strPtr(" ")},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: unknown watermark position",
+			role:      org.RoleAdmin,
+			req:       CreatePresetRequest{Name: "mark", WatermarkPosition: "middle"},
+			expectErr: ErrInvalidPreset,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{CreateFunc: echoCreate}
+			svc := NewDefaultService(repo, orgsWithRole(tc.role))
+
+			got, err := svc.CreatePreset(ctx, "user-1", "org-1", tc.req)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, repo.CreateCalls())
+				return
+			}
+			require.NoError(t, err)
+			tc.want.ID = "preset-1"
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("fail: not a member", func(t *testing.T) {
+		orgs := &org.RepositoryMock{
+			GetForMemberFunc: func(ctx context.Context, id, userID string) (*org.Organization, error) {
+				return nil, org.ErrOrgNotFound
+			},
+		}
+		_, err := NewDefaultService(&RepositoryMock{}, orgs).CreatePreset(ctx, "user-1", "org-1",
+			CreatePresetRequest{Name: "mls"})
+		assert.ErrorIs(t, err, org.ErrOrgNotFound)
+	})
+}
+
+func TestDefaultService_ListPresets(t *testing.T) {
+	repo := &RepositoryMock{
+		ListFunc: func(ctx context.Context, orgID string) ([]Preset, error) {
+			return []Preset{{ID: "preset-1", OrgID: orgID, Name: "mls"}}, nil
+		},
+	}
+
+	presets, err := NewDefaultService(repo, orgsWithRole(org.RoleMember)).
+		ListPresets(context.Background(), "user-1", "org-1")
+	require.NoError(t, err)
+	require.Len(t, presets, 1)
+	assert.Equal(t, "org-1", repo.ListCalls()[0].OrgID)
+}
+
+func TestDefaultService_UpdatePreset(t *testing.T) {
+	ctx := context.Background()
+	existing := func() *Preset {
+		return &Preset{
+			ID: "preset-1", OrgID: "org-1", Name: "mls", Width: intPtr(1024), Height: intPtr(768),
+			Format: FormatJPEG, Quality: 85, WatermarkText: strPtr("Acme"), WatermarkPosition: "bottom_right",
+		}
+	}
+
+	cases := []struct {
+		name      string
+		role      org.Role
+		req       UpdatePresetRequest
+		check     func(t *testing.T, p *Preset)
+		expectErr error
+	}{
+		{
+			name: "success: zero bounds and empty text clear them",
+			role: org.RoleAdmin,
+			req:  UpdatePresetRequest{Width: intPtr(0), Height: intPtr(0), WatermarkText: strPtr("")},
+			check: func(t *testing.T, p *Preset) {
+				assert.Nil(t, p.Width)
+				assert.Nil(t, p.Height)
+				assert.Nil(t, p.WatermarkText)
+				assert.Equal(t, 85, p.Quality)
+			},
+		},
+		{
+			name: "success: changes format and banner",
+			role: org.RoleOwner,
+			req:  UpdatePresetRequest{Format: strPtr(FormatPNG), MLSBanner: func() *bool { b := true; return &b }()},
+			check: func(t *testing.T, p *Preset) {
+				assert.Equal(t, FormatPNG, p.Format)
+				assert.True(t, p.MLSBanner)
+				assert.Equal(t, intPtr(1024), p.Width)
+			},
+		},
+		{
+			name:      "fail: members can't change presets",
+			role:      org.RoleMember,
+			req:       UpdatePresetRequest{Name: strPtr("other")},
+			expectErr: ErrForbidden,
+		},
+		{
+			name:      "fail: invalid change",
+			role:      org.RoleAdmin,
+			req:       UpdatePresetRequest{Quality: intPtr(-1)},
+			expectErr: ErrInvalidPreset,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				GetByIDFunc: func(ctx context.Context, orgID, id string) (*Preset, error) { return existing(), nil },
+				UpdateFunc:  func(ctx context.Context, p *Preset) (*Preset, error) { return p, nil },
+			}
+			got, err := NewDefaultService(repo, orgsWithRole(tc.role)).
+				UpdatePreset(ctx, "user-1", "org-1", "preset-1", tc.req)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, repo.UpdateCalls())
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, got)
+		})
+	}
+}
+
+func TestDefaultService_DeletePreset(t *testing.T) {
+	t.Run("success: admin deletes", func(t *testing.T) {
+		repo := &RepositoryMock{DeleteFunc: func(ctx context.Context, orgID, id string) error { return nil }}
+		err := NewDefaultService(repo, orgsWithRole(org.RoleAdmin)).
+			DeletePreset(context.Background(), "user-1", "org-1", "preset-1")
+		require.NoError(t, err)
+		require.Len(t, repo.DeleteCalls(), 1)
+		assert.Equal(t, "org-1", repo.DeleteCalls()[0].OrgID)
+	})
+
+	t.Run("fail: members can't delete", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		err := NewDefaultService(repo, orgsWithRole(org.RoleMember)).
+			DeletePreset(context.Background(), "user-1", "org-1", "preset-1")
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+}
+
+func TestDefaultService_ResolvePreset(t *testing.T) {
+	repo := &RepositoryMock{
+		GetByNameFunc: func(ctx context.Context, orgID, name string) (*Preset, error) {
+			return nil, ErrPresetNotFound
+		},
+	}
+
+	_, err := NewDefaultService(repo, &org.RepositoryMock{}).ResolvePreset(context.Background(), "org-1", "mls")
+	assert.ErrorIs(t, err, ErrPresetNotFound)
+	require.Len(t, repo.GetByNameCalls(), 1)
+	assert.Equal(t, "mls", repo.GetByNameCalls()[0].Name)
+}
@@ -0,0 +1,24 @@
+package exportpreset
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for an organization's export presets.
+// Members may list and read presets; owners and admins may change them.
+type Handler interface {
+	// CreatePreset handles POST /orgs/:id/export-presets - Adds a preset.
+	CreatePreset(c echo.Context) error
+
+	// ListPresets handles GET /orgs/:id/export-presets - Lists the organization's presets.
+	ListPresets(c echo.Context) error
+
+	// GetPreset handles GET /orgs/:id/export-presets/:preset_id - Gets a preset.
+	GetPreset(c echo.Context) error
+
+	// UpdatePreset handles PATCH /orgs/:id/export-presets/:preset_id - Changes a preset.
+	UpdatePreset(c echo.Context) error
+
+	// DeletePreset handles DELETE /orgs/:id/export-presets/:preset_id - Deletes a preset.
+	DeletePreset(c echo.Context) error
+}
@@ -0,0 +1,147 @@
+// Package exportpreset lets organizations define named export settings.
+//
+// A preset fixes the size, file format, watermark and MLS disclosure banner of
+// exported images, such as "mls-1024". Owners and admins of an organization
+// manage its presets; project downloads reference one by name, so every agent
+// exporting a project shared with the organization gets the same files.
+package exportpreset
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/api/watermark"
+)
+
+// Limits on presets.
+const (
+	MaxNameLength          = 64
+	MaxDimension           = 8000
+	MaxWatermarkTextLength = 64
+)
+
+// Formats exported images can be encoded in.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+// Formats lists the accepted values of Preset.Format.
+var Formats = []string{FormatJPEG, FormatPNG}
+
+// DefaultQuality is the JPEG quality used when a preset doesn't set one.
+const DefaultQuality = 90
+
+var (
+	// ErrPresetNotFound is returned when a preset does not exist in the organization.
+	ErrPresetNotFound = errors.New("export preset not found")
+	// ErrInvalidPreset is returned when a preset's settings are invalid.
+	ErrInvalidPreset = errors.New("invalid export preset")
+	// ErrDuplicateName is returned when another preset of the organization has the name.
+	ErrDuplicateName = errors.New("an export preset with this name already exists")
+	// ErrForbidden is returned when the caller's organization role may not change presets.
+	ErrForbidden = errors.New("only organization owners and admins can manage export presets")
+)
+
+// namePattern keeps preset names usable as a query parameter value.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Preset is an organization's named set of export settings.
+type Preset struct {
+	ID    string `json:"id"`
+	OrgID string `json:"org_id"`
+	Name  string `json:"name"`
+	// Width and Height bound the exported image, which is scaled down to fit
+	// inside them and never scaled up. Nil leaves that side unbounded.
+	Width  *int   `json:"width,omitempty"`
+	Height *int   `json:"height,omitempty"`
+	Format string `json:"format"`
+	// Quality is the JPEG quality, from 1 to 100. PNG exports ignore it.
+	Quality int `json:"quality"`
+	// WatermarkText, when set, is drawn at WatermarkPosition.
+	WatermarkText     *string `json:"watermark_text,omitempty"`
+	WatermarkPosition string  `json:"watermark_position"`
+	// MLSBanner adds a "Virtually Staged" band across the bottom of the image.
+	MLSBanner bool      `json:"mls_banner"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreatePresetRequest creates a preset. An empty Format exports JPEG, a zero
+// Quality uses DefaultQuality and an empty WatermarkPosition is bottom_right.
+type CreatePresetRequest struct {
+	Name              string  `json:"name"`
+	Width             *int    `json:"width,omitempty"`
+	Height            *int    `json:"height,omitempty"`
+	Format            string  `json:"format,omitempty"`
+	Quality           int     `json:"quality,omitempty"`
+	WatermarkText     *string `json:"watermark_text,omitempty"`
+	WatermarkPosition string  `json:"watermark_position,omitempty"`
+	MLSBanner         bool    `json:"mls_banner"`
+}
+
+// UpdatePresetRequest changes a preset. Nil fields are left unchanged; a zero
+// Width or Height removes that bound and an empty WatermarkText removes the
+// watermark.
+type UpdatePresetRequest struct {
+	Name              *string `json:"name,omitempty"`
+	Width             *int    `json:"width,omitempty"`
+	Height            *int    `json:"height,omitempty"`
+	Format            *string `json:"format,omitempty"`
+	Quality           *int    `json:"quality,omitempty"`
+	WatermarkText     *string `json:"watermark_text,omitempty"`
+	WatermarkPosition *string `json:"watermark_position,omitempty"`
+	MLSBanner         *bool   `json:"mls_banner,omitempty"`
+}
+
+// normalize fills in defaults and checks every field.
+func (p *Preset) normalize() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if len(p.Name) > MaxNameLength || !namePattern.MatchString(p.Name) {
+		return fmt.Errorf("%w: name must be 1 to %d lowercase letters, digits and dashes",
+			ErrInvalidPreset, MaxNameLength)
+	}
+	for _, side := range []struct {
+		name  string
+		value *int
+	}{{"width", p.Width}, {"height", p.Height}} {
+		if side.value != nil && (*side.value < 1 || *side.value > MaxDimension) {
+			return fmt.Errorf("%w: %s must be between 1 and %d", ErrInvalidPreset, side.name, MaxDimension)
+		}
+	}
+
+	if p.Format == "" {
+		p.Format = FormatJPEG
+	}
+	if !slices.Contains(Formats, p.Format) {
+		return fmt.Errorf("%w: format must be one of: %s", ErrInvalidPreset, strings.Join(Formats, ", "))
+	}
+	if p.Quality == 0 {
+		p.Quality = DefaultQuality
+	}
+	if p.Quality < 1 || p.Quality > 100 {
+		return fmt.Errorf("%w: quality must be between 1 and 100", ErrInvalidPreset)
+	}
+
+	if p.WatermarkText != nil {
+		text := strings.TrimSpace(*p.WatermarkText)
+		if text == "" || utf8.RuneCountInString(text) > MaxWatermarkTextLength {
+			return fmt.Errorf("%w: watermark_text must be 1 to %d characters", ErrInvalidPreset,
+				MaxWatermarkTextLength)
+		}
+		p.WatermarkText = &text
+	}
+	if p.WatermarkPosition == "" {
+		p.WatermarkPosition = string(watermark.BottomRight)
+	}
+	if !watermark.Position(p.WatermarkPosition).Valid() {
+		return fmt.Errorf("%w: watermark_position must be one of: top_left, top_right, bottom_left, "+
+			"bottom_right, center", ErrInvalidPreset)
+	}
+	return nil
+}
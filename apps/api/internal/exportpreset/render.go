@@ -0,0 +1,80 @@
+package exportpreset
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // staged images may be stored as WebP
+
+	"github.com/real-staging-ai/api/watermark"
+)
+
+// Extension is the file extension of images exported with the preset.
+func (p *Preset) Extension() string {
+	if p.Format == FormatPNG {
+		return ".png"
+	}
+	return ".jpg"
+}
+
+// Render decodes data and returns it as the preset describes: scaled down to
+// fit its bounds, watermarked, with the MLS banner, in its format.
+func (p *Preset) Render(data []byte) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	size := fit(src.Bounds().Size(), p.Width, p.Height)
+	dst := image.NewRGBA(image.Rectangle{Max: size})
+	if size == src.Bounds().Size() {
+		draw.Draw(dst, dst.Bounds(), src, src.Bounds().Min, draw.Src)
+	} else {
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Src, nil)
+	}
+
+	if p.WatermarkText != nil {
+		opts := watermark.DefaultOptions()
+		opts.Text = *p.WatermarkText
+		opts.Position = watermark.Position(p.WatermarkPosition)
+		if err := watermark.Draw(dst, opts); err != nil {
+			return nil, err
+		}
+	}
+	if p.MLSBanner {
+		if err := watermark.Banner(dst, watermark.DefaultText); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if p.Format == FormatPNG {
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: p.Quality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", p.Format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// fit scales size down, keeping its aspect ratio, until it is no larger than
+// the given bounds. A nil bound leaves that side free.
+func fit(size image.Point, width, height *int) image.Point {
+	scale := 1.0
+	if width != nil && size.X > *width {
+		scale = min(scale, float64(*width)/float64(size.X))
+	}
+	if height != nil && size.Y > *height {
+		scale = min(scale, float64(*height)/float64(size.Y))
+	}
+	if scale == 1 {
+		return size
+	}
+	return image.Pt(max(int(float64(size.X)*scale+0.5), 1), max(int(float64(size.Y)*scale+0.5), 1))
+}
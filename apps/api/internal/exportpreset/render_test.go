@@ -0,0 +1,85 @@
+package exportpreset
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodedPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 120, G: 160, B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestFit(t *testing.T) {
+	cases := []struct {
+		name   string
+		width  *int
+		height *int
+		want   image.Point
+	}{
+		{name: "success: no bounds", want: image.Pt(2000, 1000)},
+		{name: "success: width bound", width: intPtr(1000), want: image.Pt(1000, 500)},
+		{name: "success: height bound", height: intPtr(250), want: image.Pt(500, 250)},
+		{name: "success: tighter bound wins", width: intPtr(1000), height: intPtr(100), want: image.Pt(200, 100)},
+		{name: "success: never scales up", width: intPtr(4000), height: intPtr(4000), want: image.Pt(2000, 1000)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, fit(image.Pt(2000, 1000), tc.width, tc.height))
+		})
+	}
+}
+
+func TestPreset_Render(t *testing.T) {
+	src := encodedPNG(t, 400, 200)
+
+	t.Run("success: scales and encodes as JPEG", func(t *testing.T) {
+		p := &Preset{Width: intPtr(200), Format: FormatJPEG, Quality: DefaultQuality}
+
+		out, err := p.Render(src)
+		require.NoError(t, err)
+		img, err := jpeg.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, image.Pt(200, 100), img.Bounds().Size())
+		assert.Equal(t, ".jpg", p.Extension())
+	})
+
+	t.Run("success: watermark and banner change the pixels", func(t *testing.T) {
+		plain := &Preset{Format: FormatPNG}
+		marked := &Preset{
+			Format: FormatPNG, WatermarkText: strPtr("Acme Realty"), WatermarkPosition: "bottom_right", MLSBanner: true,
+		}
+
+		plainOut, err := plain.Render(src)
+		require.NoError(t, err)
+		markedOut, err := marked.Render(src)
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(markedOut))
+		require.NoError(t, err)
+		assert.Equal(t, image.Pt(400, 200), img.Bounds().Size())
+		assert.NotEqual(t, plainOut, markedOut)
+		assert.Equal(t, ".png", marked.Extension())
+	})
+
+	t.Run("fail: not an image", func(t *testing.T) {
+		_, err := (&Preset{Format: FormatPNG}).Render([]byte("nope"))
+		assert.Error(t, err)
+	})
+}
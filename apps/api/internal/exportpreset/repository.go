@@ -0,0 +1,27 @@
+package exportpreset
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for export presets. Every read and write is
+// scoped to an organization.
+type Repository interface {
+	// Create inserts a new preset. Returns ErrDuplicateName if another preset of the organization has the name.
+	Create(ctx context.Context, p *Preset) (*Preset, error)
+
+	// GetByID retrieves one of an organization's presets.
+	GetByID(ctx context.Context, orgID, id string) (*Preset, error)
+
+	// GetByName retrieves one of an organization's presets by name.
+	GetByName(ctx context.Context, orgID, name string) (*Preset, error)
+
+	// List returns an organization's presets ordered by name.
+	List(ctx context.Context, orgID string) ([]Preset, error)
+
+	// Update persists every field of a preset.
+	Update(ctx context.Context, p *Preset) (*Preset, error)
+
+	// Delete removes one of an organization's presets.
+	Delete(ctx context.Context, orgID, id string) error
+}
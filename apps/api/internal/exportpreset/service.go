@@ -0,0 +1,30 @@
+package exportpreset
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for export presets. Methods that take a
+// userID enforce that user's role in the organization: members may read
+// presets, owners and admins may change them.
+type Service interface {
+	// CreatePreset adds a preset to an organization.
+	CreatePreset(ctx context.Context, userID, orgID string, req CreatePresetRequest) (*Preset, error)
+
+	// ListPresets returns an organization's presets ordered by name.
+	ListPresets(ctx context.Context, userID, orgID string) ([]Preset, error)
+
+	// GetPreset retrieves one of an organization's presets.
+	GetPreset(ctx context.Context, userID, orgID, id string) (*Preset, error)
+
+	// UpdatePreset changes one of an organization's presets.
+	UpdatePreset(ctx context.Context, userID, orgID, id string, req UpdatePresetRequest) (*Preset, error)
+
+	// DeletePreset removes one of an organization's presets.
+	DeletePreset(ctx context.Context, userID, orgID, id string) error
+
+	// ResolvePreset finds an organization's preset by name for an export. The
+	// caller has already been allowed to export the project the preset is
+	// applied to, so membership isn't checked again.
+	ResolvePreset(ctx context.Context, orgID, name string) (*Preset, error)
+}
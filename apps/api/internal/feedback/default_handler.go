@@ -0,0 +1,139 @@
+package feedback
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// CodeImageNotReady is the problem code for rating an image that hasn't been staged.
+const CodeImageNotReady = "image_not_ready"
+
+// DefaultHandler serves the image feedback endpoints.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// Submit handles POST /api/v1/images/:id/feedback.
+func (h *DefaultHandler) Submit(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid image ID format")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
+	}
+	ctx := c.Request().Context()
+	userRow, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "User not found")
+	}
+
+	var req SubmitRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	feedback, err := h.service.Submit(ctx, userRow.ID.String(), imageID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrImageNotFound):
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Image not found")
+		case errors.Is(err, ErrImageNotReady):
+			return problem.Write(c, http.StatusConflict, CodeImageNotReady, err.Error())
+		case errors.Is(err, ErrInvalidRating), errors.Is(err, ErrCommentTooLong):
+			return problem.Write(c, http.StatusUnprocessableEntity, problem.CodeValidationFailed, err.Error())
+		}
+		h.log.Error(ctx, "failed to save image feedback", "image_id", imageID, "error", err)
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to save feedback")
+	}
+	return c.JSON(http.StatusOK, feedback)
+}
+
+// ModelQuality handles GET /admin/feedback/models - Compares ratings across
+// models. Supports interval, from and to query parameters.
+func (h *DefaultHandler) ModelQuality(c echo.Context) error {
+	ctx := c.Request().Context()
+	q, err := parseQuery(c)
+	if err != nil {
+		return err
+	}
+
+	models, err := h.service.ModelQuality(ctx, q)
+	if err != nil {
+		return h.queryError(c, "failed to summarize model feedback", err)
+	}
+	return c.JSON(http.StatusOK, map[string]any{"models": models})
+}
+
+// PromptQuality handles GET /admin/feedback/prompts - Compares ratings across
+// prompts. Supports interval, from and to query parameters.
+func (h *DefaultHandler) PromptQuality(c echo.Context) error {
+	ctx := c.Request().Context()
+	q, err := parseQuery(c)
+	if err != nil {
+		return err
+	}
+
+	prompts, err := h.service.PromptQuality(ctx, q)
+	if err != nil {
+		return h.queryError(c, "failed to summarize prompt feedback", err)
+	}
+	return c.JSON(http.StatusOK, map[string]any{"prompts": prompts})
+}
+
+// queryError maps a quality report error to its HTTP error.
+func (h *DefaultHandler) queryError(c echo.Context, msg string, err error) error {
+	if errors.Is(err, ErrInvalidQuery) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	h.log.Error(c.Request().Context(), msg, "error", err)
+	return echo.NewHTTPError(http.StatusInternalServerError, "Failed to summarize feedback")
+}
+
+// parseQuery reads a quality report's query parameters. from and to are
+// RFC 3339 timestamps or dates, read as midnight UTC.
+func parseQuery(c echo.Context) (Query, error) {
+	q := Query{Interval: Interval(c.QueryParam("interval"))}
+	var err error
+	if q.From, err = timeQueryParam(c, "from"); err != nil {
+		return q, echo.NewHTTPError(http.StatusBadRequest, "from must be a date or RFC 3339 timestamp")
+	}
+	if q.To, err = timeQueryParam(c, "to"); err != nil {
+		return q, echo.NewHTTPError(http.StatusBadRequest, "to must be a date or RFC 3339 timestamp")
+	}
+	return q, nil
+}
+
+// timeQueryParam parses the named query parameter, returning the zero time
+// when it is absent.
+func timeQueryParam(c echo.Context, name string) (time.Time, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
@@ -0,0 +1,190 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_Submit(t *testing.T) {
+	owner := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: owner, Valid: true}}, nil
+		},
+	}
+
+	cases := []struct {
+		name         string
+		imageID      string
+		body         string
+		submitErr    error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: rating saved",
+			imageID:      imageID,
+			body:         `{"rating":5,"comment":"Perfect"}`,
+			expectedCode: http.StatusOK,
+			expectBody:   `"rating":5`,
+		},
+		{name: "fail: invalid image id", imageID: "nope", expectedCode: http.StatusBadRequest},
+		{name: "fail: malformed body", imageID: imageID, body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: image not found",
+			imageID:      imageID,
+			body:         `{"rating":3}`,
+			submitErr:    ErrImageNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: image not staged",
+			imageID:      imageID,
+			body:         `{"rating":3}`,
+			submitErr:    ErrImageNotReady,
+			expectedCode: http.StatusConflict,
+			expectBody:   "image_not_ready",
+		},
+		{
+			name:         "fail: validation",
+			imageID:      imageID,
+			body:         `{"rating":9}`,
+			submitErr:    ErrInvalidRating,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "rating must be between 1 and 5",
+		},
+		{
+			name:         "fail: service error",
+			imageID:      imageID,
+			body:         `{"rating":3}`,
+			submitErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				SubmitFunc: func(ctx context.Context, userID, imageID string, req SubmitRequest) (*Feedback, error) {
+					assert.Equal(t, owner.String(), userID)
+					if tc.submitErr != nil {
+						return nil, tc.submitErr
+					}
+					return &Feedback{ID: "feedback-1", ImageID: imageID, Rating: req.Rating, Comment: req.Comment}, nil
+				},
+			}
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepo, logging.Default()).Submit(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedCode >= http.StatusBadRequest {
+				assert.Equal(t, problem.MediaType, rec.Header().Get(echo.HeaderContentType))
+			}
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_ModelQuality(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		serviceErr error
+		wantStatus int
+		wantQuery  Query
+	}{
+		{
+			name:       "success: passes the query",
+			query:      "?interval=day&from=2026-01-01&to=2026-02-01T12:00:00Z",
+			wantStatus: http.StatusOK,
+			wantQuery: Query{
+				Interval: IntervalDay,
+				From:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{name: "success: no parameters", wantStatus: http.StatusOK},
+		{name: "fail: invalid from", query: "?from=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid to", query: "?to=2026-13-01", wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: invalid query",
+			query:      "?interval=year",
+			serviceErr: ErrInvalidQuery,
+			wantStatus: http.StatusBadRequest,
+			wantQuery:  Query{Interval: "year"},
+		},
+		{name: "fail: service error", serviceErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ModelQualityFunc: func(ctx context.Context, q Query) ([]ModelQuality, error) {
+					assert.Equal(t, tc.wantQuery, q)
+					return []ModelQuality{}, tc.serviceErr
+				},
+			}
+			h := NewDefaultHandler(svc, &user.RepositoryMock{}, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/feedback/models"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			err := h.ModelQuality(e.NewContext(req, rec))
+
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"models":[]`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_PromptQuality(t *testing.T) {
+	variant := "warm"
+	svc := &ServiceMock{
+		PromptQualityFunc: func(ctx context.Context, q Query) ([]PromptQuality, error) {
+			return []PromptQuality{{
+				RoomType: "bedroom", Style: "modern", Variant: &variant,
+				Stats: Stats{Ratings: 2, AverageRating: 4.5, Distribution: [5]int{0, 0, 0, 1, 1}},
+			}}, nil
+		},
+	}
+	h := NewDefaultHandler(svc, &user.RepositoryMock{}, logging.Default())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/feedback/prompts", nil)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.PromptQuality(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"variant":"warm","ratings":2,"average_rating":4.5,"distribution":[0,0,0,1,1]`)
+}
@@ -0,0 +1,163 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// GetImageStatus reads the image through its project so other users' images are not found.
+func (r *DefaultRepository) GetImageStatus(ctx context.Context, userID, imageID string) (string, error) {
+	query := `
+		SELECT i.status::text
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		WHERE i.id = $1 AND p.user_id = $2 AND i.deleted_at IS NULL`
+
+	var status string
+	if err := r.db.QueryRow(ctx, query, imageID, userID).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrImageNotFound
+		}
+		return "", fmt.Errorf("failed to get image: %w", err)
+	}
+	return status, nil
+}
+
+// Upsert copies the image's model, room type, style and variant into the
+// rating. A new rating of the same image refreshes the copy too.
+func (r *DefaultRepository) Upsert(
+	ctx context.Context, userID, imageID string, req SubmitRequest,
+) (*Feedback, error) {
+	query := `
+		INSERT INTO image_feedback (
+			image_id, user_id, rating, comment, model_used, room_type, style, custom_prompt, prompt_variant_id
+		)
+		SELECT i.id, $2, $3, $4, i.model_used, i.room_type, i.style, COALESCE(i.prompt, '') <> '', i.prompt_variant_id
+		FROM images i
+		WHERE i.id = $1
+		ON CONFLICT (image_id, user_id) DO UPDATE SET
+			rating = EXCLUDED.rating,
+			comment = EXCLUDED.comment,
+			model_used = EXCLUDED.model_used,
+			room_type = EXCLUDED.room_type,
+			style = EXCLUDED.style,
+			custom_prompt = EXCLUDED.custom_prompt,
+			prompt_variant_id = EXCLUDED.prompt_variant_id,
+			updated_at = now()
+		RETURNING id::text, image_id::text, rating, comment, model_used, room_type, style, created_at, updated_at`
+
+	var f Feedback
+	err := r.db.QueryRow(ctx, query, imageID, userID, req.Rating, req.Comment).Scan(
+		&f.ID, &f.ImageID, &f.Rating, &f.Comment, &f.ModelUsed, &f.RoomType, &f.Style, &f.CreatedAt, &f.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to save image feedback: %w", err)
+	}
+	return &f, nil
+}
+
+// statsColumns aggregates a group of ratings into the columns scanned by scanStats.
+const statsColumns = `
+	count(*),
+	avg(f.rating)::float8,
+	count(*) FILTER (WHERE f.rating = 1),
+	count(*) FILTER (WHERE f.rating = 2),
+	count(*) FILTER (WHERE f.rating = 3),
+	count(*) FILTER (WHERE f.rating = 4),
+	count(*) FILTER (WHERE f.rating = 5)`
+
+// statsDest returns the scan destinations of statsColumns.
+func statsDest(s *Stats) []any {
+	return []any{
+		&s.Ratings, &s.AverageRating,
+		&s.Distribution[0], &s.Distribution[1], &s.Distribution[2], &s.Distribution[3], &s.Distribution[4],
+	}
+}
+
+// ModelQuality groups ratings by the UTC period they were first given in and
+// the model that staged the image. Ratings of images without a model are left out.
+func (r *DefaultRepository) ModelQuality(ctx context.Context, q Query) ([]ModelQuality, error) {
+	query := `
+		SELECT date_trunc($1, f.created_at AT TIME ZONE 'UTC') AS period, f.model_used,` + statsColumns + `
+		FROM image_feedback f
+		WHERE f.created_at >= $2 AND f.created_at < $3 AND f.model_used IS NOT NULL
+		GROUP BY period, f.model_used
+		ORDER BY period, f.model_used`
+
+	rows, err := r.db.Query(ctx, query, string(q.Interval), q.From, q.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize model feedback: %w", err)
+	}
+	defer rows.Close()
+
+	result := []ModelQuality{}
+	for rows.Next() {
+		var m ModelQuality
+		if err := rows.Scan(append([]any{&m.Period, &m.Model}, statsDest(&m.Stats)...)...); err != nil {
+			return nil, fmt.Errorf("failed to scan model feedback: %w", err)
+		}
+		result = append(result, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over model feedback rows: %w", err)
+	}
+	return result, nil
+}
+
+// PromptQuality groups ratings by the UTC period they were first given in,
+// the image's room type and style, and its experiment variant. A missing room
+// type or style counts as the worker's default, "default" and "modern".
+func (r *DefaultRepository) PromptQuality(ctx context.Context, q Query) ([]PromptQuality, error) {
+	query := `
+		SELECT date_trunc($1, f.created_at AT TIME ZONE 'UTC') AS period,
+			COALESCE(NULLIF(f.room_type, ''), 'default') AS room_type,
+			COALESCE(NULLIF(f.style, ''), 'modern') AS style,
+			v.experiment_id::text, v.id::text, v.name,` + statsColumns + `
+		FROM image_feedback f
+		LEFT JOIN prompt_experiment_variants v ON v.id = f.prompt_variant_id
+		WHERE f.created_at >= $2 AND f.created_at < $3 AND NOT f.custom_prompt
+		GROUP BY period, 2, 3, v.experiment_id, v.id, v.name
+		ORDER BY period, 2, 3, v.name NULLS FIRST`
+
+	rows, err := r.db.Query(ctx, query, string(q.Interval), q.From, q.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize prompt feedback: %w", err)
+	}
+	defer rows.Close()
+
+	result := []PromptQuality{}
+	for rows.Next() {
+		var p PromptQuality
+		dest := append([]any{&p.Period, &p.RoomType, &p.Style, &p.ExperimentID, &p.VariantID, &p.Variant},
+			statsDest(&p.Stats)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt feedback: %w", err)
+		}
+		result = append(result, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt feedback rows: %w", err)
+	}
+	return result, nil
+}
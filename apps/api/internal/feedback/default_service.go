@@ -0,0 +1,91 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/api/internal/image"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo, now: time.Now}
+}
+
+// Submit validates the rating and trims the comment; a blank comment is
+// stored as none.
+func (s *DefaultService) Submit(ctx context.Context, userID, imageID string, req SubmitRequest) (*Feedback, error) {
+	if req.Rating < MinRating || req.Rating > MaxRating {
+		return nil, ErrInvalidRating
+	}
+	if req.Comment != nil {
+		comment := strings.TrimSpace(*req.Comment)
+		switch {
+		case comment == "":
+			req.Comment = nil
+		case utf8.RuneCountInString(comment) > MaxCommentLength:
+			return nil, ErrCommentTooLong
+		default:
+			req.Comment = &comment
+		}
+	}
+
+	status, err := s.repo.GetImageStatus(ctx, userID, imageID)
+	if err != nil {
+		return nil, err
+	}
+	if status != image.StatusReady.String() {
+		return nil, ErrImageNotReady
+	}
+	return s.repo.Upsert(ctx, userID, imageID, req)
+}
+
+// ModelQuality fills in the query's defaults and summarizes it.
+func (s *DefaultService) ModelQuality(ctx context.Context, q Query) ([]ModelQuality, error) {
+	q, err := s.resolve(q)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ModelQuality(ctx, q)
+}
+
+// PromptQuality fills in the query's defaults and summarizes it.
+func (s *DefaultService) PromptQuality(ctx context.Context, q Query) ([]PromptQuality, error) {
+	q, err := s.resolve(q)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.PromptQuality(ctx, q)
+}
+
+// resolve fills in the query's zero fields and validates it.
+func (s *DefaultService) resolve(q Query) (Query, error) {
+	if q.Interval == "" {
+		q.Interval = IntervalWeek
+	}
+	if !q.Interval.Valid() {
+		return q, fmt.Errorf("%w: interval must be one of: day, week, month", ErrInvalidQuery)
+	}
+	if q.To.IsZero() {
+		q.To = s.now()
+	}
+	if q.From.IsZero() {
+		q.From = q.To.Add(-DefaultWindow)
+	}
+	if !q.From.Before(q.To) {
+		return q, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+	return q, nil
+}
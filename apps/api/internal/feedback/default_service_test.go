@@ -0,0 +1,161 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID  = "11111111-1111-1111-1111-111111111111"
+	imageID = "22222222-2222-2222-2222-222222222222"
+)
+
+func strPtr(s string) *string { return &s }
+
+func newRepo(status string) *RepositoryMock {
+	return &RepositoryMock{
+		GetImageStatusFunc: func(ctx context.Context, userID, imageID string) (string, error) {
+			if status == "" {
+				return "", ErrImageNotFound
+			}
+			return status, nil
+		},
+		UpsertFunc: func(ctx context.Context, userID, imageID string, req SubmitRequest) (*Feedback, error) {
+			return &Feedback{ID: "feedback-1", ImageID: imageID, Rating: req.Rating, Comment: req.Comment}, nil
+		},
+	}
+}
+
+func TestDefaultService_Submit(t *testing.T) {
+	t.Run("success: trims the comment", func(t *testing.T) {
+		repo := newRepo("ready")
+
+		got, err := NewDefaultService(repo).Submit(context.Background(), userID, imageID,
+			SubmitRequest{Rating: 4, Comment: strPtr("  Lovely sofa.  ")})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, got.Rating)
+		assert.Equal(t, "Lovely sofa.", *got.Comment)
+		require.Len(t, repo.UpsertCalls(), 1)
+		assert.Equal(t, userID, repo.UpsertCalls()[0].UserID)
+	})
+
+	t.Run("success: a blank comment is stored as none", func(t *testing.T) {
+		got, err := NewDefaultService(newRepo("ready")).Submit(context.Background(), userID, imageID,
+			SubmitRequest{Rating: 1, Comment: strPtr("   ")})
+
+		require.NoError(t, err)
+		assert.Nil(t, got.Comment)
+	})
+
+	cases := []struct {
+		name    string
+		status  string
+		req     SubmitRequest
+		wantErr error
+	}{
+		{name: "fail: rating too low", status: "ready", req: SubmitRequest{Rating: 0}, wantErr: ErrInvalidRating},
+		{name: "fail: rating too high", status: "ready", req: SubmitRequest{Rating: 6}, wantErr: ErrInvalidRating},
+		{
+			name:    "fail: comment too long",
+			status:  "ready",
+			req:     SubmitRequest{Rating: 3, Comment: strPtr(strings.Repeat("é", MaxCommentLength+1))},
+			wantErr: ErrCommentTooLong,
+		},
+		{name: "fail: image not found", req: SubmitRequest{Rating: 3}, wantErr: ErrImageNotFound},
+		{name: "fail: image not staged", status: "processing", req: SubmitRequest{Rating: 3}, wantErr: ErrImageNotReady},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo(tc.status)
+
+			_, err := NewDefaultService(repo).Submit(context.Background(), userID, imageID, tc.req)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Empty(t, repo.UpsertCalls())
+		})
+	}
+}
+
+func TestDefaultService_ModelQuality(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	newService := func(repo Repository) *DefaultService {
+		svc := NewDefaultService(repo)
+		svc.now = func() time.Time { return now }
+		return svc
+	}
+
+	t.Run("success: defaults to weekly periods over the last 90 days", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ModelQualityFunc: func(ctx context.Context, q Query) ([]ModelQuality, error) {
+				return []ModelQuality{{Model: "qwen/qwen-image-edit"}}, nil
+			},
+		}
+
+		got, err := newService(repo).ModelQuality(context.Background(), Query{})
+
+		require.NoError(t, err)
+		assert.Len(t, got, 1)
+		q := repo.ModelQualityCalls()[0].Q
+		assert.Equal(t, IntervalWeek, q.Interval)
+		assert.Equal(t, now, q.To)
+		assert.Equal(t, now.Add(-DefaultWindow), q.From)
+	})
+
+	t.Run("success: keeps the given range", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ModelQualityFunc: func(ctx context.Context, q Query) ([]ModelQuality, error) { return nil, nil },
+		}
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := newService(repo).ModelQuality(context.Background(), Query{Interval: IntervalDay, From: from, To: to})
+
+		require.NoError(t, err)
+		assert.Equal(t, Query{Interval: IntervalDay, From: from, To: to}, repo.ModelQualityCalls()[0].Q)
+	})
+
+	t.Run("fail: unknown interval", func(t *testing.T) {
+		_, err := newService(&RepositoryMock{}).ModelQuality(context.Background(), Query{Interval: "year"})
+		assert.ErrorIs(t, err, ErrInvalidQuery)
+	})
+
+	t.Run("fail: from after to", func(t *testing.T) {
+		_, err := newService(&RepositoryMock{}).ModelQuality(context.Background(),
+			Query{From: now, To: now.Add(-time.Hour)})
+		assert.ErrorIs(t, err, ErrInvalidQuery)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ModelQualityFunc: func(ctx context.Context, q Query) ([]ModelQuality, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		_, err := newService(repo).ModelQuality(context.Background(), Query{})
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_PromptQuality(t *testing.T) {
+	repo := &RepositoryMock{
+		PromptQualityFunc: func(ctx context.Context, q Query) ([]PromptQuality, error) {
+			return []PromptQuality{{RoomType: "bedroom", Style: "modern"}}, nil
+		},
+	}
+
+	got, err := NewDefaultService(repo).PromptQuality(context.Background(), Query{Interval: IntervalMonth})
+
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, IntervalMonth, repo.PromptQualityCalls()[0].Q.Interval)
+
+	_, err = NewDefaultService(repo).PromptQuality(context.Background(), Query{Interval: "hour"})
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
@@ -0,0 +1,12 @@
+package feedback
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP handlers for image feedback.
+type Handler interface {
+	Submit(c echo.Context) error
+	ModelQuality(c echo.Context) error
+	PromptQuality(c echo.Context) error
+}
@@ -0,0 +1,113 @@
+// Package feedback lets users rate their staged images and lets admins compare
+// the ratings by model and by prompt over time.
+//
+// A rating is 1 to 5 with an optional comment, one per user and image, so each
+// restaged variant is rated on its own. It keeps a copy of how the image was
+// staged (model, room type, style, experiment variant) so the comparisons
+// survive the image being deleted.
+package feedback
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// MinRating and MaxRating bound a rating.
+	MinRating = 1
+	MaxRating = 5
+	// MaxCommentLength is the longest comment a rating accepts, in characters.
+	MaxCommentLength = 2000
+	// DefaultWindow is how far back quality reports look when no start is given.
+	DefaultWindow = 90 * 24 * time.Hour
+)
+
+var (
+	// ErrImageNotFound is returned when the image does not exist or belongs to another user.
+	ErrImageNotFound = errors.New("image not found")
+	// ErrImageNotReady is returned when rating an image that hasn't been staged.
+	ErrImageNotReady = errors.New("only staged images can be rated")
+	// ErrInvalidRating is returned for a rating outside MinRating and MaxRating.
+	ErrInvalidRating = errors.New("rating must be between 1 and 5")
+	// ErrCommentTooLong is returned when the comment exceeds MaxCommentLength.
+	ErrCommentTooLong = errors.New("comment must be at most 2000 characters")
+	// ErrInvalidQuery is returned for a quality report with an unknown interval
+	// or a start that isn't before its end.
+	ErrInvalidQuery = errors.New("invalid feedback query")
+)
+
+// Interval is the length of the periods a quality report is split into.
+type Interval string
+
+// Report intervals.
+const (
+	IntervalDay   Interval = "day"
+	IntervalWeek  Interval = "week"
+	IntervalMonth Interval = "month"
+)
+
+// Valid reports whether i is a known interval.
+func (i Interval) Valid() bool {
+	switch i {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return true
+	}
+	return false
+}
+
+// SubmitRequest is the body of a rating.
+type SubmitRequest struct {
+	Rating  int     `json:"rating"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+// Feedback is a user's rating of one staged image.
+type Feedback struct {
+	ID      string  `json:"id"`
+	ImageID string  `json:"image_id"`
+	Rating  int     `json:"rating"`
+	Comment *string `json:"comment,omitempty"`
+	// ModelUsed, RoomType and Style are the image's when it was rated.
+	ModelUsed *string   `json:"model_used,omitempty"`
+	RoomType  *string   `json:"room_type,omitempty"`
+	Style     *string   `json:"style,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Query selects the ratings a quality report covers: those given from From
+// up to, but not including, To, split into periods of Interval. Zero fields
+// default to weekly periods over the DefaultWindow up to now.
+type Query struct {
+	Interval Interval
+	From     time.Time
+	To       time.Time
+}
+
+// Stats summarizes a group of ratings. Distribution counts the ratings of
+// each value, from 1 to 5.
+type Stats struct {
+	Ratings       int     `json:"ratings"`
+	AverageRating float64 `json:"average_rating"`
+	Distribution  [5]int  `json:"distribution"`
+}
+
+// ModelQuality is the ratings of one model's images in one period.
+type ModelQuality struct {
+	Period time.Time `json:"period"`
+	Model  string    `json:"model"`
+	Stats
+}
+
+// PromptQuality is the ratings of images staged with one room type and
+// style's prompt in one period. Images staged in a prompt experiment are
+// grouped by variant; VariantID is nil for the others.
+type PromptQuality struct {
+	Period       time.Time `json:"period"`
+	RoomType     string    `json:"room_type"`
+	Style        string    `json:"style"`
+	ExperimentID *string   `json:"experiment_id,omitempty"`
+	VariantID    *string   `json:"variant_id,omitempty"`
+	Variant      *string   `json:"variant,omitempty"`
+	Stats
+}
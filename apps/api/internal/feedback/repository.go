@@ -0,0 +1,21 @@
+package feedback
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for image feedback.
+type Repository interface {
+	// GetImageStatus returns the image's status. Returns ErrImageNotFound if
+	// the image is missing, trashed or not in one of the user's projects.
+	GetImageStatus(ctx context.Context, userID, imageID string) (string, error)
+	// Upsert stores the user's rating of the image, replacing an earlier one,
+	// with a copy of how the image was staged.
+	Upsert(ctx context.Context, userID, imageID string, req SubmitRequest) (*Feedback, error)
+	// ModelQuality summarizes the query's ratings per period and model.
+	ModelQuality(ctx context.Context, q Query) ([]ModelQuality, error)
+	// PromptQuality summarizes the query's ratings per period, room type,
+	// style and experiment variant, leaving out images staged with a custom
+	// prompt.
+	PromptQuality(ctx context.Context, q Query) ([]PromptQuality, error)
+}
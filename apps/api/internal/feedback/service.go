@@ -0,0 +1,18 @@
+package feedback
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for image feedback.
+type Service interface {
+	// Submit rates one of the user's staged images, replacing the user's
+	// earlier rating of it. Returns ErrImageNotFound if the image is missing
+	// or belongs to another user, ErrImageNotReady if it isn't staged, and
+	// ErrInvalidRating or ErrCommentTooLong for an unusable request.
+	Submit(ctx context.Context, userID, imageID string, req SubmitRequest) (*Feedback, error)
+	// ModelQuality compares ratings across models over time.
+	ModelQuality(ctx context.Context, q Query) ([]ModelQuality, error)
+	// PromptQuality compares ratings across prompts over time.
+	PromptQuality(ctx context.Context, q Query) ([]PromptQuality, error)
+}
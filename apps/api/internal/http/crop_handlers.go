@@ -0,0 +1,138 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/crop"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+const (
+	// maxCropSourceBytes caps how much of an object is read when computing crops.
+	maxCropSourceBytes = 50 << 20
+	// maxCropSourcePixels rejects images that would be too expensive to decode.
+	maxCropSourcePixels = 50_000_000
+)
+
+// CropSuggestionsResponse is returned by GET /api/v1/images/:id/crops.
+type CropSuggestionsResponse struct {
+	ImageID string            `json:"image_id"`
+	Kind    string            `json:"kind"`
+	Width   int               `json:"width"`
+	Height  int               `json:"height"`
+	Crops   []crop.Suggestion `json:"crops"`
+}
+
+// cropSuggestionsHandler handles GET /api/v1/images/:id/crops
+// Query params:
+// - kind: staged|original (default: staged)
+// - ratios: comma-separated W:H list (default: 16:9,4:3,1:1)
+func (s *Server) cropSuggestionsHandler(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "invalid image id format"})
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(c.QueryParam("kind")))
+	if kind == "" {
+		kind = "staged"
+	}
+	if kind != "staged" && kind != "original" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "kind must be staged or original"})
+	}
+
+	var ratios []crop.Ratio
+	if v := strings.TrimSpace(c.QueryParam("ratios")); v != "" {
+		for _, part := range strings.Split(v, ",") {
+			r, err := crop.ParseRatio(strings.TrimSpace(part))
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: err.Error()})
+			}
+			ratios = append(ratios, r)
+		}
+	}
+
+	ctx := c.Request().Context()
+	img, err := s.imageService.GetImageByID(ctx, imageID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "image not found"})
+	}
+
+	rawURL := img.OriginalURL
+	if kind == "staged" {
+		if img.StagedURL == nil || *img.StagedURL == "" {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "image has no staged_url"})
+		}
+		rawURL = *img.StagedURL
+	}
+
+	bucket := ""
+	if s.config != nil {
+		bucket = s.config.S3.BucketName
+	}
+	fileKey, err := storage.KeyFromURL(rawURL, bucket)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "could not derive file key"})
+	}
+
+	decoded, err := s.loadImage(c, fileKey)
+	if err != nil {
+		s.log.Error(ctx, "failed to load image for crop suggestions", "image_id", imageID, "key", fileKey, "error", err)
+		return c.JSON(http.StatusUnprocessableEntity,
+			ErrorResponse{Error: "unprocessable_entity", Message: "image could not be decoded"})
+	}
+
+	b := decoded.Bounds()
+	// The stored object for a given image and kind never changes in place, so
+	// clients may cache the result and skip recomputation on revisit.
+	c.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	return c.JSON(http.StatusOK, CropSuggestionsResponse{
+		ImageID: imageID,
+		Kind:    kind,
+		Width:   b.Dx(),
+		Height:  b.Dy(),
+		Crops:   crop.Suggest(decoded, ratios),
+	})
+}
+
+// loadImage downloads and decodes an object, rejecting oversized images
+// before the full decode.
+func (s *Server) loadImage(c echo.Context, fileKey string) (image.Image, error) {
+	body, err := s.s3Service.DownloadFile(c.Request().Context(), fileKey)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxCropSourceBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	if len(data) > maxCropSourceBytes {
+		return nil, fmt.Errorf("object exceeds %d bytes", maxCropSourceBytes)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxCropSourcePixels {
+		return nil, fmt.Errorf("image is %dx%d, exceeds %d pixels", cfg.Width, cfg.Height, maxCropSourcePixels)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+	imagePkg "github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestServer_cropSuggestionsHandler(t *testing.T) {
+	const imageID = "550e8400-e29b-41d4-a716-446655440000"
+	staged := "https://real-staging.s3.amazonaws.com/users/u1/projects/p1/staged/" + imageID + "-staged.jpg"
+
+	testCases := []struct {
+		name           string
+		imageID        string
+		query          string
+		image          *imagePkg.Image
+		getErr         error
+		object         []byte
+		downloadErr    error
+		expectedStatus int
+		expectedKey    string
+		validate       func(*testing.T, CropSuggestionsResponse)
+	}{
+		{
+			name:           "success: default ratios for staged image",
+			imageID:        imageID,
+			image:          &imagePkg.Image{OriginalURL: "s3://real-staging/original.jpg", StagedURL: &staged},
+			object:         testPNG(t, 160, 90),
+			expectedStatus: http.StatusOK,
+			expectedKey:    "users/u1/projects/p1/staged/" + imageID + "-staged.jpg",
+			validate: func(t *testing.T, resp CropSuggestionsResponse) {
+				assert.Equal(t, "staged", resp.Kind)
+				assert.Equal(t, 160, resp.Width)
+				assert.Equal(t, 90, resp.Height)
+				require.Len(t, resp.Crops, 3)
+				assert.Equal(t, "16:9", resp.Crops[0].Ratio)
+				assert.Equal(t, 160, resp.Crops[0].Width)
+				assert.Equal(t, "1:1", resp.Crops[2].Ratio)
+				assert.Equal(t, 90, resp.Crops[2].Width)
+			},
+		},
+		{
+			name:           "success: requested ratios for original image",
+			imageID:        imageID,
+			query:          "?kind=original&ratios=3:2",
+			image:          &imagePkg.Image{OriginalURL: "s3://real-staging/original.jpg"},
+			object:         testPNG(t, 120, 120),
+			expectedStatus: http.StatusOK,
+			expectedKey:    "original.jpg",
+			validate: func(t *testing.T, resp CropSuggestionsResponse) {
+				require.Len(t, resp.Crops, 1)
+				assert.Equal(t, "3:2", resp.Crops[0].Ratio)
+				assert.Equal(t, 120, resp.Crops[0].Width)
+				assert.Equal(t, 80, resp.Crops[0].Height)
+			},
+		},
+		{
+			name:           "fail: invalid image id",
+			imageID:        "nope",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: invalid kind",
+			imageID:        imageID,
+			query:          "?kind=thumbnail",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: invalid ratio",
+			imageID:        imageID,
+			query:          "?ratios=16:9,wide",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: image not found",
+			imageID:        imageID,
+			getErr:         errors.New("not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "fail: image not staged yet",
+			imageID:        imageID,
+			image:          &imagePkg.Image{OriginalURL: "s3://real-staging/original.jpg"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "fail: download error",
+			imageID:        imageID,
+			image:          &imagePkg.Image{OriginalURL: "s3://real-staging/original.jpg", StagedURL: &staged},
+			downloadErr:    errors.New("boom"),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "fail: object is not an image",
+			imageID:        imageID,
+			image:          &imagePkg.Image{OriginalURL: "s3://real-staging/original.jpg", StagedURL: &staged},
+			object:         []byte("not an image"),
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotKey string
+			s := &Server{
+				log: &logging.LoggerMock{
+					ErrorFunc: func(ctx context.Context, msg string, keysAndValues ...any) {},
+				},
+				config: &config.Config{S3: config.S3{BucketName: "real-staging"}},
+				imageService: &imagePkg.ServiceMock{
+					GetImageByIDFunc: func(ctx context.Context, id string) (*imagePkg.Image, error) {
+						return tc.image, tc.getErr
+					},
+				},
+				s3Service: &storage.S3ServiceMock{
+					DownloadFileFunc: func(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+						gotKey = fileKey
+						if tc.downloadErr != nil {
+							return nil, tc.downloadErr
+						}
+						return io.NopCloser(bytes.NewReader(tc.object)), nil
+					},
+				},
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/images/"+tc.imageID+"/crops"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			require.NoError(t, s.cropSuggestionsHandler(c))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedKey != "" {
+				assert.Equal(t, tc.expectedKey, gotKey)
+			}
+			if tc.validate != nil {
+				var resp CropSuggestionsResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tc.imageID, resp.ImageID)
+				assert.Equal(t, "private, max-age=3600", rec.Header().Get("Cache-Control"))
+				tc.validate(t, resp)
+			}
+		})
+	}
+}
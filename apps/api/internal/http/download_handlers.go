@@ -1,7 +1,7 @@
 package http
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"net/url"
 	"os"
@@ -9,10 +9,14 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/project"
 )
 
-// deleteImageHandler handles DELETE requests to remove an image from both database and S3 storage.
+// deleteImageHandler handles DELETE requests by moving an image to its project's trash.
 func (s *Server) deleteImageHandler(c echo.Context) error {
 	imageID := c.Param("id")
 	if imageID == "" {
@@ -26,33 +30,27 @@ func (s *Server) deleteImageHandler(c echo.Context) error {
 
 	ctx := c.Request().Context()
 
-	// First, get the image to retrieve S3 keys
 	img, err := s.imageService.GetImageByID(ctx, imageID)
 	if err != nil {
 		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "image not found"})
 	}
 
-	// Only attempt S3 deletion if image is not in queued state
-	// Queued images have a presigned upload URL but file was never actually uploaded
-	if img.Status != "queued" {
-		// Delete from S3 (original image)
-		if img.OriginalURL != "" {
-			if err := s.s3Service.DeleteFile(ctx, img.OriginalURL); err != nil {
-				// Log error but don't fail - file might already be deleted
-				fmt.Printf("Warning: failed to delete original image from S3 (%s): %v\n", img.OriginalURL, err)
-			}
-		}
-
-		// Delete from S3 (staged image if exists)
-		if img.StagedURL != nil && *img.StagedURL != "" {
-			if err := s.s3Service.DeleteFile(ctx, *img.StagedURL); err != nil {
-				// Log error but don't fail - file might already be deleted
-				fmt.Printf("Warning: failed to delete staged image from S3 (%s): %v\n", *img.StagedURL, err)
-			}
-		}
+	proj, err := project.NewDefaultRepository(s.db).GetProjectByID(ctx, img.ProjectID.String())
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "failed to delete image",
+		})
+	}
+	if proj != nil && proj.Locked {
+		return c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "project_locked",
+			Message: "project is locked; unlock it before deleting images",
+		})
 	}
 
-	// Delete from database
+	// Move the image to the trash. Its files stay in S3 until the retention
+	// job purges it, so it can be restored until then.
 	if err := s.imageService.DeleteImage(ctx, imageID); err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
@@ -66,6 +64,7 @@ func (s *Server) deleteImageHandler(c echo.Context) error {
 // presignImageDownloadHandler handles GET /api/v1/images/:id/presign
 // Query params:
 // - kind: original|staged (default: original)
+// - size: small|medium|large presigns that thumbnail instead, or the full-size file until it exists
 // - expires_in: seconds (default: 600)
 // - download: 1 to force Content-Disposition=attachment
 func (s *Server) presignImageDownloadHandler(c echo.Context) error {
@@ -78,6 +77,13 @@ func (s *Server) presignImageDownloadHandler(c echo.Context) error {
 	if kind == "" {
 		kind = "original"
 	}
+	size := strings.ToLower(strings.TrimSpace(c.QueryParam("size")))
+	switch size {
+	case "", image.ThumbnailSmall, image.ThumbnailMedium, image.ThumbnailLarge:
+	default:
+		return c.JSON(http.StatusBadRequest,
+			ErrorResponse{Error: "bad_request", Message: "size must be small, medium or large"})
+	}
 	expiresIn := int64(600)
 	if v := strings.TrimSpace(c.QueryParam("expires_in")); v != "" {
 		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
@@ -103,6 +109,9 @@ func (s *Server) presignImageDownloadHandler(c echo.Context) error {
 	} else {
 		rawURL = img.OriginalURL
 	}
+	if thumb := img.Thumbnails.URL(kind, size); thumb != "" {
+		rawURL = thumb
+	}
 
 	u, err := url.Parse(rawURL)
 	if err != nil || u.Path == "" {
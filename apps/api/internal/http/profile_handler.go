@@ -8,6 +8,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/i18n"
 	"github.com/real-staging-ai/api/internal/logging"
 	"github.com/real-staging-ai/api/internal/user"
 )
@@ -106,6 +107,9 @@ func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
 
 	// Update profile
 	updated, err := h.profileService.UpdateProfile(ctx, currentProfile.ID, &req)
+	if errors.Is(err, i18n.ErrUnsupportedLanguage) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Unsupported preferences.language")
+	}
 	if err != nil {
 		h.log.Error(ctx, "failed to update profile", "error", err, "user_id", currentProfile.ID)
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update profile")
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/real-staging-ai/api/internal/i18n"
 	"github.com/real-staging-ai/api/internal/logging"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 	"github.com/real-staging-ai/api/internal/user"
@@ -312,6 +314,29 @@ func TestProfileHandler_UpdateProfile(t *testing.T) {
 				assert.Contains(t, body, "Invalid request body")
 			},
 		},
+		{
+			name:     "fail: unsupported language",
+			auth0Sub: "auth0|12345",
+			requestBody: map[string]interface{}{
+				"preferences": map[string]string{"language": "tlh"},
+			},
+			setupMock: func(service *user.ProfileServiceMock, repo *user.RepositoryMock) {
+				repo.GetByAuth0SubFunc = func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{}, nil
+				}
+				service.GetProfileByAuth0SubFunc = func(ctx context.Context, auth0Sub string) (*user.ProfileResponse, error) {
+					return &user.ProfileResponse{ID: "550e8400-e29b-41d4-a716-446655440000", Role: "user"}, nil
+				}
+				//nolint:lll // Test setup with long function signature
+				service.UpdateProfileFunc = func(ctx context.Context, userID string, req *user.ProfileUpdateRequest) (*user.ProfileResponse, error) {
+					return nil, fmt.Errorf("invalid profile update request: %w", i18n.ErrUnsupportedLanguage)
+				}
+			},
+			expectedStatus: http.StatusBadRequest,
+			validateResp: func(t *testing.T, body string) {
+				assert.Contains(t, body, "Unsupported preferences.language")
+			},
+		},
 		{
 			name:     "fail: update service returns error",
 			auth0Sub: "auth0|12345",
@@ -0,0 +1,286 @@
+package http
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/exportpreset"
+	imagePkg "github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/org"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+const (
+	// zipFetchConcurrency is how many objects are downloaded from S3 ahead of
+	// the one being written to the archive.
+	zipFetchConcurrency = 4
+	// maxZipEntryBytes caps the size of a single file in a project download.
+	maxZipEntryBytes = 50 << 20
+)
+
+// zipEntry is a file in a project download and the object it is read from.
+// Render, when set, converts the object before it is added.
+type zipEntry struct {
+	Name   string
+	Key    string
+	Render func(data []byte) ([]byte, error)
+}
+
+// projectDownloadHandler handles GET /api/v1/projects/:id/download by streaming
+// a ZIP of the project's ready staged images, oldest first.
+// Query params:
+// - originals: true to also include each staged image's original under originals/
+// - preset: name of an export preset of the project's organization to render staged images with
+func (s *Server) projectDownloadHandler(c echo.Context) error {
+	projectID := c.Param("id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid project ID format")
+	}
+
+	originals := false
+	if v := strings.TrimSpace(c.QueryParam("originals")); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "originals must be true or false")
+		}
+		originals = b
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
+	}
+
+	ctx := c.Request().Context()
+	caller, err := user.NewDefaultRepository(s.db).GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "User not found")
+	}
+	proj, err := project.NewDefaultRepository(s.db).GetProjectByIDAndUserID(ctx, projectID, caller.ID.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Project not found")
+	}
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get project")
+	}
+
+	var preset *exportpreset.Preset
+	if name := strings.TrimSpace(c.QueryParam("preset")); name != "" {
+		if proj.OrgID == nil {
+			return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest,
+				"Export presets only apply to organization projects")
+		}
+		presets := exportpreset.NewDefaultService(
+			exportpreset.NewDefaultRepository(s.db), org.NewDefaultRepository(s.db),
+		)
+		preset, err = presets.ResolvePreset(ctx, *proj.OrgID, name)
+		if errors.Is(err, exportpreset.ErrPresetNotFound) {
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Export preset not found")
+		}
+		if err != nil {
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get export preset")
+		}
+	}
+
+	images, err := s.imageService.ListReadyImages(ctx, projectID)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get images")
+	}
+	bucket := ""
+	if s.config != nil {
+		bucket = s.config.S3.BucketName
+	}
+	entries, err := projectZipEntries(images, bucket, originals, preset)
+	if err != nil {
+		s.log.Error(ctx, "failed to list project download files", "project_id", projectID, "error", err)
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to prepare download")
+	}
+	if len(entries) == 0 {
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Project has no staged images to download")
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/zip")
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", archiveName(proj.Name)))
+	res.WriteHeader(http.StatusOK)
+
+	// The status is sent; a failure now can only cut the archive short, which
+	// leaves it without its central directory so clients reject it.
+	if err := writeProjectZip(ctx, res, s.s3Service, entries); err != nil {
+		s.log.Error(ctx, "project download failed", "project_id", projectID, "error", err)
+	}
+	return nil
+}
+
+// projectZipEntries names the files of a project download: staged images at
+// the top level, numbered in order, and with originals the source of each
+// under originals/ with the name of the first image staged from it. A preset
+// renders the staged images; originals are left as they were uploaded.
+func projectZipEntries(
+	images []*imagePkg.Image, bucket string, originals bool, preset *exportpreset.Preset,
+) ([]zipEntry, error) {
+	var staged, sources []zipEntry
+	seen := make(map[string]bool)
+	for i, img := range images {
+		if img.StagedURL == nil {
+			continue
+		}
+		key, err := storage.KeyFromURL(*img.StagedURL, bucket)
+		if err != nil {
+			return nil, fmt.Errorf("image %s: %w", img.ID, err)
+		}
+		base := fmt.Sprintf("%03d-%s", i+1, imageLabel(img))
+		entry := zipEntry{Name: base + path.Ext(key), Key: key}
+		if preset != nil {
+			entry.Name = base + preset.Extension()
+			entry.Render = preset.Render
+		}
+		staged = append(staged, entry)
+
+		if !originals || img.OriginalURL == "" || seen[img.OriginalURL] {
+			continue
+		}
+		seen[img.OriginalURL] = true
+		key, err = storage.KeyFromURL(img.OriginalURL, bucket)
+		if err != nil {
+			return nil, fmt.Errorf("image %s original: %w", img.ID, err)
+		}
+		sources = append(sources, zipEntry{Name: "originals/" + base + path.Ext(key), Key: key})
+	}
+	return append(staged, sources...), nil
+}
+
+// imageLabel describes an image by room type and style, falling back to its ID.
+func imageLabel(img *imagePkg.Image) string {
+	var parts []string
+	for _, p := range []*string{img.RoomType, img.Style} {
+		if p != nil && *p != "" {
+			parts = append(parts, safeFileName(*p))
+		}
+	}
+	if len(parts) == 0 {
+		return img.ID.String()
+	}
+	return strings.Join(parts, "-")
+}
+
+// archiveName is the download file name for a project.
+func archiveName(projectName string) string {
+	name := safeFileName(projectName)
+	if name == "" {
+		name = "project"
+	}
+	return name + ".zip"
+}
+
+// safeFileName keeps letters, digits, dots, dashes and underscores, turning
+// runs of anything else into a single dash.
+func safeFileName(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			b.WriteRune(r)
+			dash = false
+		case !dash:
+			b.WriteRune('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-.")
+}
+
+// fetchedObject is the body of a downloaded object, or why it failed.
+type fetchedObject struct {
+	data []byte
+	err  error
+}
+
+// writeProjectZip writes entries to w as a ZIP archive in order. Objects are
+// downloaded and rendered up to zipFetchConcurrency ahead of the one being
+// written, so at most that many are held in memory. Images are already
+// compressed, so they are stored as they are.
+func writeProjectZip(ctx context.Context, w io.Writer, s3 storage.S3Service, entries []zipEntry) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]chan fetchedObject, len(entries))
+	for i := range results {
+		results[i] = make(chan fetchedObject, 1)
+	}
+	slots := make(chan struct{}, zipFetchConcurrency)
+	go func() {
+		for i, entry := range entries {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func() {
+				data, err := fetchObject(ctx, s3, entry.Key)
+				if err == nil && entry.Render != nil {
+					if data, err = entry.Render(data); err != nil {
+						err = fmt.Errorf("failed to render: %w", err)
+					}
+				}
+				results[i] <- fetchedObject{data: data, err: err}
+			}()
+		}
+	}()
+
+	zw := zip.NewWriter(w)
+	for i, entry := range entries {
+		var obj fetchedObject
+		select {
+		case obj = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if obj.err != nil {
+			return fmt.Errorf("failed to download %s: %w", entry.Key, obj.err)
+		}
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: entry.Name, Method: zip.Store})
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", entry.Name, err)
+		}
+		if _, err := f.Write(obj.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.Name, err)
+		}
+		<-slots
+	}
+	return zw.Close()
+}
+
+// fetchObject reads a whole object, refusing ones over maxZipEntryBytes.
+func fetchObject(ctx context.Context, s3 storage.S3Service, key string) ([]byte, error) {
+	body, err := s3.DownloadFile(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxZipEntryBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	if len(data) > maxZipEntryBytes {
+		return nil, fmt.Errorf("object exceeds %d bytes", maxZipEntryBytes)
+	}
+	return data, nil
+}
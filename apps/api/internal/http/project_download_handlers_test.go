@@ -0,0 +1,228 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/exportpreset"
+	imagePkg "github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestServer_projectDownloadHandler_validation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		projectID string
+		query     string
+	}{
+		{name: "fail: invalid project id", projectID: "nope"},
+		{name: "fail: invalid originals flag", projectID: uuid.NewString(), query: "?originals=maybe"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{}
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/"+tc.projectID+"/download"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.projectID)
+
+			require.NoError(t, s.projectDownloadHandler(c))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestProjectZipEntries(t *testing.T) {
+	str := func(s string) *string { return &s }
+	original := "https://real-staging.s3.amazonaws.com/users/u1/originals/kitchen.png"
+	images := []*imagePkg.Image{
+		{
+			ID:          uuid.New(),
+			OriginalURL: original,
+			StagedURL:   str("s3://real-staging/users/u1/staged/a.jpg"),
+			RoomType:    str("living_room"),
+			Style:       str("modern"),
+		},
+		{
+			ID:          uuid.New(),
+			OriginalURL: original,
+			StagedURL:   str("s3://real-staging/users/u1/staged/b.jpg"),
+			RoomType:    str("living_room"),
+			Style:       str("scandinavian"),
+		},
+		{
+			ID:          uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+			OriginalURL: "s3://real-staging/users/u1/originals/bath.jpeg",
+			StagedURL:   str("s3://real-staging/users/u1/staged/c.webp"),
+		},
+	}
+
+	t.Run("success: staged only", func(t *testing.T) {
+		entries, err := projectZipEntries(images, "real-staging", false, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []zipEntry{
+			{Name: "001-living_room-modern.jpg", Key: "users/u1/staged/a.jpg"},
+			{Name: "002-living_room-scandinavian.jpg", Key: "users/u1/staged/b.jpg"},
+			{Name: "003-550e8400-e29b-41d4-a716-446655440000.webp", Key: "users/u1/staged/c.webp"},
+		}, entries)
+	})
+
+	t.Run("success: originals once each", func(t *testing.T) {
+		entries, err := projectZipEntries(images, "real-staging", true, nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 5)
+		assert.Equal(t, []zipEntry{
+			{Name: "originals/001-living_room-modern.png", Key: "users/u1/originals/kitchen.png"},
+			{Name: "originals/003-550e8400-e29b-41d4-a716-446655440000.jpeg", Key: "users/u1/originals/bath.jpeg"},
+		}, entries[3:])
+	})
+
+	t.Run("success: preset renders staged images only", func(t *testing.T) {
+		preset := &exportpreset.Preset{Format: exportpreset.FormatPNG}
+		entries, err := projectZipEntries(images, "real-staging", true, preset)
+		require.NoError(t, err)
+		require.Len(t, entries, 5)
+		for _, entry := range entries[:3] {
+			assert.Equal(t, ".png", path.Ext(entry.Name))
+			assert.NotNil(t, entry.Render)
+		}
+		assert.Equal(t, "001-living_room-modern.png", entries[0].Name)
+		assert.Equal(t, "users/u1/staged/a.jpg", entries[0].Key)
+		for _, entry := range entries[3:] {
+			assert.Nil(t, entry.Render)
+		}
+		assert.Equal(t, "originals/003-550e8400-e29b-41d4-a716-446655440000.jpeg", entries[4].Name)
+	})
+
+	t.Run("fail: staged URL without a key", func(t *testing.T) {
+		images := []*imagePkg.Image{{StagedURL: str("s3://real-staging")}}
+		_, err := projectZipEntries(images, "real-staging", false, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestArchiveName(t *testing.T) {
+	assert.Equal(t, "Downtown-Condo-Listings.zip", archiveName("Downtown Condo Listings"))
+	assert.Equal(t, "12-Main-St.-Unit-4.zip", archiveName(" 12 Main St. / Unit #4 "))
+	assert.Equal(t, "project.zip", archiveName("日本語"))
+}
+
+func TestWriteProjectZip(t *testing.T) {
+	entries := make([]zipEntry, 10)
+	for i := range entries {
+		entries[i] = zipEntry{Name: fmt.Sprintf("%03d.jpg", i+1), Key: fmt.Sprintf("staged/%d.jpg", i)}
+	}
+
+	t.Run("success: entries in order with bounded downloads", func(t *testing.T) {
+		var started atomic.Int32
+		gate := make(chan struct{})
+		s3 := &storage.S3ServiceMock{
+			DownloadFileFunc: func(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+				started.Add(1)
+				if fileKey == entries[0].Key {
+					<-gate
+				}
+				return io.NopCloser(bytes.NewReader([]byte("data:" + fileKey))), nil
+			},
+		}
+		var buf bytes.Buffer
+		done := make(chan error, 1)
+		go func() { done <- writeProjectZip(context.Background(), &buf, s3, entries) }()
+
+		// Until the first file arrives, only the first few are downloaded.
+		assert.Eventually(t, func() bool { return started.Load() == zipFetchConcurrency },
+			time.Second, time.Millisecond)
+		assert.Never(t, func() bool { return started.Load() > zipFetchConcurrency },
+			50*time.Millisecond, time.Millisecond)
+		close(gate)
+		require.NoError(t, <-done)
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		require.Len(t, zr.File, len(entries))
+		for i, f := range zr.File {
+			assert.Equal(t, entries[i].Name, f.Name)
+			assert.Equal(t, zip.Store, f.Method)
+			rc, err := f.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			assert.Equal(t, "data:"+entries[i].Key, string(data))
+		}
+		assert.Equal(t, int32(len(entries)), started.Load())
+	})
+
+	t.Run("success: rendered entries", func(t *testing.T) {
+		s3 := &storage.S3ServiceMock{
+			DownloadFileFunc: func(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader([]byte("data"))), nil
+			},
+		}
+		rendered := []zipEntry{
+			{Name: "001.png", Key: "staged/0.jpg", Render: func(data []byte) ([]byte, error) {
+				return append([]byte("rendered:"), data...), nil
+			}},
+			{Name: "originals/001.jpg", Key: "originals/0.jpg"},
+		}
+		var buf bytes.Buffer
+		require.NoError(t, writeProjectZip(context.Background(), &buf, s3, rendered))
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		require.Len(t, zr.File, 2)
+		for i, want := range []string{"rendered:data", "data"} {
+			rc, err := zr.File[i].Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			assert.Equal(t, want, string(data))
+		}
+	})
+
+	t.Run("fail: render error stops the archive", func(t *testing.T) {
+		s3 := &storage.S3ServiceMock{
+			DownloadFileFunc: func(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader([]byte("data"))), nil
+			},
+		}
+		broken := []zipEntry{{Name: "001.png", Key: "staged/0.jpg", Render: func(data []byte) ([]byte, error) {
+			return nil, errors.New("decode image: unknown format")
+		}}}
+		err := writeProjectZip(context.Background(), io.Discard, s3, broken)
+		assert.ErrorContains(t, err, "failed to render")
+	})
+
+	t.Run("fail: download error stops the archive", func(t *testing.T) {
+		s3 := &storage.S3ServiceMock{
+			DownloadFileFunc: func(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+				if fileKey == "staged/3.jpg" {
+					return nil, errors.New("NoSuchKey")
+				}
+				return io.NopCloser(bytes.NewReader([]byte("data"))), nil
+			},
+		}
+		var buf bytes.Buffer
+		err := writeProjectZip(context.Background(), &buf, s3, entries)
+		assert.ErrorContains(t, err, "failed to download staged/3.jpg")
+		_, err = zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		assert.Error(t, err, "a cut-short archive has no central directory")
+	})
+}
@@ -0,0 +1,178 @@
+package http
+
+import "github.com/real-staging-ai/api/internal/auth"
+
+// routeScopes is the scope an API key or service token needs for each
+// protected route. A route missing from this table is closed to machine
+// tokens, so new routes must be added here when they are registered.
+var routeScopes = auth.RouteScopes{
+	// Projects
+	"POST /api/v1/projects":                      auth.ScopeProjectsWrite,
+	"GET /api/v1/projects":                       auth.ScopeProjectsRead,
+	"GET /api/v1/projects/:id":                   auth.ScopeProjectsRead,
+	"PUT /api/v1/projects/:id":                   auth.ScopeProjectsWrite,
+	"DELETE /api/v1/projects/:id":                auth.ScopeProjectsWrite,
+	"POST /api/v1/projects/:id/lock":             auth.ScopeProjectsWrite,
+	"POST /api/v1/projects/:id/unlock":           auth.ScopeProjectsWrite,
+	"GET /api/v1/projects/:project_id/cost":      auth.ScopeProjectsRead,
+	"POST /api/v1/projects/:id/share":            auth.ScopeProjectsWrite,
+	"GET /api/v1/projects/:id/share":             auth.ScopeProjectsRead,
+	"DELETE /api/v1/projects/:id/share/:link_id": auth.ScopeProjectsWrite,
+
+	// Images
+	"POST /api/v1/uploads/presign":                    auth.ScopeImagesWrite,
+	"POST /api/v1/images":                             auth.ScopeImagesWrite,
+	"POST /api/v1/images/batch":                       auth.ScopeImagesWrite,
+	"GET /api/v1/batches/:id":                         auth.ScopeImagesRead,
+	"GET /api/v1/images/:id":                          auth.ScopeImagesRead,
+	"GET /api/v1/images/:id/presign":                  auth.ScopeImagesRead,
+	"GET /api/v1/images/:id/crops":                    auth.ScopeImagesRead,
+	"GET /api/v1/images/search":                       auth.ScopeImagesRead,
+	"POST /api/v1/images/:id/restage":                 auth.ScopeImagesWrite,
+	"POST /api/v1/images/:id/reroll":                  auth.ScopeImagesWrite,
+	"DELETE /api/v1/images/:id":                       auth.ScopeImagesWrite,
+	"POST /api/v1/images/:id/restore":                 auth.ScopeImagesWrite,
+	"PUT /api/v1/images/:id/approval":                 auth.ScopeImagesWrite,
+	"DELETE /api/v1/images/:id/job":                   auth.ScopeImagesWrite,
+	"GET /api/v1/projects/:id/trash":                  auth.ScopeImagesRead,
+	"GET /api/v1/projects/:id/download":               auth.ScopeImagesRead,
+	"GET /api/v1/projects/:project_id/images":         auth.ScopeImagesRead,
+	"GET /api/v1/projects/:project_id/images/grouped": auth.ScopeImagesRead,
+	"POST /api/v1/projects/:project_id/restyle":       auth.ScopeImagesWrite,
+	"POST /api/v1/projects/:project_id/duplicate":     auth.ScopeImagesWrite,
+	"POST /api/v1/images/:id/cutouts":                 auth.ScopeImagesWrite,
+	"GET /api/v1/images/:id/cutouts":                  auth.ScopeImagesRead,
+	"GET /api/v1/assets/:id/presign":                  auth.ScopeImagesRead,
+	"GET /api/v1/images/:id/history":                  auth.ScopeImagesRead,
+	"GET /api/v1/images/:id/lineage":                  auth.ScopeImagesRead,
+	"POST /api/v1/images/:id/support-ticket":          auth.ScopeImagesWrite,
+	"POST /api/v1/images/:id/feedback":                auth.ScopeImagesWrite,
+	"GET /api/v1/events":                              auth.ScopeImagesRead,
+	"GET /api/v1/ws":                                  auth.ScopeImagesRead,
+	"GET /api/v1/models/:id/cost-estimate":            auth.ScopeImagesRead,
+
+	// Project webhooks
+	"POST /api/v1/projects/:project_id/webhooks": auth.ScopeProjectsWrite,
+	"GET /api/v1/projects/:project_id/webhooks":  auth.ScopeProjectsRead,
+	"GET /api/v1/webhooks/:id":                   auth.ScopeProjectsRead,
+	"PATCH /api/v1/webhooks/:id":                 auth.ScopeProjectsWrite,
+	"DELETE /api/v1/webhooks/:id":                auth.ScopeProjectsWrite,
+	"GET /api/v1/webhooks/:id/deliveries":        auth.ScopeProjectsRead,
+
+	// Prompt templates
+	"POST /api/v1/prompt-templates":       auth.ScopeImagesWrite,
+	"GET /api/v1/prompt-templates":        auth.ScopeImagesRead,
+	"GET /api/v1/prompt-templates/:id":    auth.ScopeImagesRead,
+	"PATCH /api/v1/prompt-templates/:id":  auth.ScopeImagesWrite,
+	"DELETE /api/v1/prompt-templates/:id": auth.ScopeImagesWrite,
+
+	// Style presets
+	"GET /api/v1/style-presets":     auth.ScopeImagesRead,
+	"GET /api/v1/style-presets/:id": auth.ScopeImagesRead,
+
+	// Organizations
+	"POST /api/v1/orgs":                                  auth.ScopeOrgsWrite,
+	"GET /api/v1/orgs":                                   auth.ScopeOrgsRead,
+	"GET /api/v1/orgs/:id":                               auth.ScopeOrgsRead,
+	"PATCH /api/v1/orgs/:id":                             auth.ScopeOrgsWrite,
+	"DELETE /api/v1/orgs/:id":                            auth.ScopeOrgsWrite,
+	"GET /api/v1/orgs/:id/members":                       auth.ScopeOrgsRead,
+	"PATCH /api/v1/orgs/:id/members/:user_id":            auth.ScopeOrgsWrite,
+	"DELETE /api/v1/orgs/:id/members/:user_id":           auth.ScopeOrgsWrite,
+	"POST /api/v1/orgs/:id/invitations":                  auth.ScopeOrgsWrite,
+	"GET /api/v1/orgs/:id/invitations":                   auth.ScopeOrgsRead,
+	"DELETE /api/v1/orgs/:id/invitations/:invitation_id": auth.ScopeOrgsWrite,
+	"POST /api/v1/invitations/accept":                    auth.ScopeOrgsWrite,
+	"GET /api/v1/orgs/:id/projects":                      auth.ScopeOrgsRead,
+	"PUT /api/v1/orgs/:id/projects/:project_id":          auth.ScopeOrgsWrite,
+	"DELETE /api/v1/orgs/:id/projects/:project_id":       auth.ScopeOrgsWrite,
+	"POST /api/v1/orgs/:id/export-presets":               auth.ScopeOrgsWrite,
+	"GET /api/v1/orgs/:id/export-presets":                auth.ScopeOrgsRead,
+	"GET /api/v1/orgs/:id/export-presets/:preset_id":     auth.ScopeOrgsRead,
+	"PATCH /api/v1/orgs/:id/export-presets/:preset_id":   auth.ScopeOrgsWrite,
+	"DELETE /api/v1/orgs/:id/export-presets/:preset_id":  auth.ScopeOrgsWrite,
+
+	// Billing
+	"GET /api/v1/billing/subscriptions":                 auth.ScopeBillingRead,
+	"GET /api/v1/billing/invoices":                      auth.ScopeBillingRead,
+	"GET /api/v1/billing/invoices/:id/pdf":              auth.ScopeBillingRead,
+	"GET /api/v1/billing/usage":                         auth.ScopeBillingRead,
+	"GET /api/v1/billing/events":                        auth.ScopeBillingRead,
+	"GET /api/v1/billing/payment-methods":               auth.ScopeBillingRead,
+	"GET /api/v1/billing/credits":                       auth.ScopeBillingRead,
+	"POST /api/v1/billing/create-checkout":              auth.ScopeBillingWrite,
+	"POST /api/v1/billing/portal":                       auth.ScopeBillingWrite,
+	"POST /api/v1/billing/create-subscription-elements": auth.ScopeBillingWrite,
+	"POST /api/v1/billing/upgrade-subscription":         auth.ScopeBillingWrite,
+	"POST /api/v1/billing/cancel-subscription":          auth.ScopeBillingWrite,
+	"POST /api/v1/billing/credits/checkout":             auth.ScopeBillingWrite,
+
+	// Profile
+	"GET /api/v1/user/profile":                  auth.ScopeProfileRead,
+	"PATCH /api/v1/user/profile":                auth.ScopeProfileWrite,
+	"GET /api/v1/user/preferences":              auth.ScopeProfileRead,
+	"PUT /api/v1/user/preferences":              auth.ScopeProfileWrite,
+	"GET /api/v1/user/credentials":              auth.ScopeProfileRead,
+	"POST /api/v1/user/credentials":             auth.ScopeProfileWrite,
+	"DELETE /api/v1/user/credentials/:provider": auth.ScopeProfileWrite,
+
+	// Admin
+	"GET /api/v1/admin/models":                        auth.ScopeAdmin,
+	"GET /api/v1/admin/models/active":                 auth.ScopeAdmin,
+	"PUT /api/v1/admin/models/active":                 auth.ScopeAdmin,
+	"PATCH /api/v1/admin/models/:id":                  auth.ScopeAdmin,
+	"GET /api/v1/admin/models/:id/config":             auth.ScopeAdmin,
+	"PUT /api/v1/admin/models/:id/config":             auth.ScopeAdmin,
+	"GET /api/v1/admin/models/:id/config/schema":      auth.ScopeAdmin,
+	"GET /api/v1/admin/models/:id/config/jsonschema":  auth.ScopeAdmin,
+	"GET /api/v1/admin/settings":                      auth.ScopeAdmin,
+	"GET /api/v1/admin/settings/:key":                 auth.ScopeAdmin,
+	"PUT /api/v1/admin/settings/:key":                 auth.ScopeAdmin,
+	"GET /api/v1/admin/models/versions":               auth.ScopeAdmin,
+	"GET /api/v1/admin/models/:id/versions":           auth.ScopeAdmin,
+	"PUT /api/v1/admin/models/:id/version":            auth.ScopeAdmin,
+	"POST /api/v1/admin/models/:id/canary":            auth.ScopeAdmin,
+	"GET /api/v1/admin/models/:id/canary":             auth.ScopeAdmin,
+	"POST /api/v1/admin/models/:id/canary/promote":    auth.ScopeAdmin,
+	"POST /api/v1/admin/models/:id/rollback":          auth.ScopeAdmin,
+	"GET /api/v1/admin/prompts":                       auth.ScopeAdmin,
+	"GET /api/v1/admin/prompts/export":                auth.ScopeAdmin,
+	"POST /api/v1/admin/prompts/import":               auth.ScopeAdmin,
+	"GET /api/v1/admin/prompts/:room/:style":          auth.ScopeAdmin,
+	"PUT /api/v1/admin/prompts/:room/:style":          auth.ScopeAdmin,
+	"DELETE /api/v1/admin/prompts/:room/:style":       auth.ScopeAdmin,
+	"GET /api/v1/admin/prompts/:room/:style/versions": auth.ScopeAdmin,
+	"POST /api/v1/admin/experiments":                  auth.ScopeAdmin,
+	"GET /api/v1/admin/experiments":                   auth.ScopeAdmin,
+	"GET /api/v1/admin/experiments/:id":               auth.ScopeAdmin,
+	"POST /api/v1/admin/experiments/:id/stop":         auth.ScopeAdmin,
+	"GET /api/v1/admin/experiments/:id/report":        auth.ScopeAdmin,
+	"POST /api/v1/admin/style-presets":                auth.ScopeAdmin,
+	"PATCH /api/v1/admin/style-presets/:id":           auth.ScopeAdmin,
+	"DELETE /api/v1/admin/style-presets/:id":          auth.ScopeAdmin,
+	"GET /api/v1/admin/feedback/models":               auth.ScopeAdmin,
+	"GET /api/v1/admin/feedback/prompts":              auth.ScopeAdmin,
+	"GET /api/v1/admin/deliveries":                    auth.ScopeAdmin,
+	"GET /api/v1/admin/deliveries/destinations":       auth.ScopeAdmin,
+	"GET /api/v1/admin/deliveries/:id":                auth.ScopeAdmin,
+	"GET /api/v1/admin/slo":                           auth.ScopeAdmin,
+	"PUT /api/v1/admin/slo/:name":                     auth.ScopeAdmin,
+	"DELETE /api/v1/admin/slo/:name":                  auth.ScopeAdmin,
+	"GET /api/v1/admin/users/:id/account-mode":        auth.ScopeAdmin,
+	"PUT /api/v1/admin/users/:id/account-mode":        auth.ScopeAdmin,
+	"GET /api/v1/admin/compliance/reviews":            auth.ScopeAdmin,
+	"GET /api/v1/admin/compliance/reviews/:id":        auth.ScopeAdmin,
+	"PATCH /api/v1/admin/compliance/reviews/:id":      auth.ScopeAdmin,
+	"POST /api/v1/admin/compliance/scan":              auth.ScopeAdmin,
+	"GET /api/v1/admin/users/:id/test-clock":          auth.ScopeAdmin,
+	"POST /api/v1/admin/users/:id/test-clock":         auth.ScopeAdmin,
+	"POST /api/v1/admin/users/:id/test-clock/advance": auth.ScopeAdmin,
+	"DELETE /api/v1/admin/users/:id/test-clock":       auth.ScopeAdmin,
+	"GET /api/v1/admin/queue/tasks":                   auth.ScopeAdmin,
+	"POST /api/v1/admin/queue/tasks/:id/requeue":      auth.ScopeAdmin,
+	"DELETE /api/v1/admin/queue/tasks/:id":            auth.ScopeAdmin,
+	"GET /api/v1/admin/dead-letters":                  auth.ScopeAdmin,
+	"GET /api/v1/admin/dead-letters/:id":              auth.ScopeAdmin,
+	"POST /api/v1/admin/dead-letters/:id/retry":       auth.ScopeAdmin,
+	"DELETE /api/v1/admin/dead-letters/:id":           auth.ScopeAdmin,
+}
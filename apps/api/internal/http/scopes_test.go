@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// publicRoutes are served under /api/v1 without JWT authentication.
+var publicRoutes = map[string]bool{
+	"POST /api/v1/stripe/webhook": true,
+	"GET /api/v1/docs":            true,
+	"GET /api/v1/docs/*":          true,
+	"GET /api/v1/status":          true,
+	"GET /api/v1/shared/:token":   true,
+}
+
+func TestRouteScopes(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Stripe.TestClocksEnabled = true
+	db := &storage.DatabaseMock{PoolFunc: func() storage.PgxPool { return nil }}
+	s := NewServer(cfg, context.Background(), logging.Default(), db, nil, nil, nil)
+
+	registered := map[string]bool{}
+	for _, r := range s.echo.Routes() {
+		if !strings.HasPrefix(r.Path, "/api/v1/") || r.Method == echo.RouteNotFound {
+			continue
+		}
+		key := r.Method + " " + r.Path
+		registered[key] = true
+		if !publicRoutes[key] {
+			_, ok := routeScopes.Required(r.Method, r.Path)
+			assert.True(t, ok, "protected route %s has no scope", key)
+		}
+	}
+
+	t.Run("no stale entries", func(t *testing.T) {
+		for key := range routeScopes {
+			assert.True(t, registered[key], "scope entry %s matches no route", key)
+		}
+	})
+
+	t.Run("destructive routes need write scopes", func(t *testing.T) {
+		for key, want := range map[string]auth.Scope{
+			"DELETE /api/v1/projects/:id":               auth.ScopeProjectsWrite,
+			"DELETE /api/v1/images/:id":                 auth.ScopeImagesWrite,
+			"DELETE /api/v1/orgs/:id":                   auth.ScopeOrgsWrite,
+			"POST /api/v1/billing/cancel-subscription":  auth.ScopeBillingWrite,
+			"DELETE /api/v1/admin/slo/:name":            auth.ScopeAdmin,
+			"GET /api/v1/projects/:project_id/images":   auth.ScopeImagesRead,
+			"GET /api/v1/billing/subscriptions":         auth.ScopeBillingRead,
+			"PUT /api/v1/admin/models/active":           auth.ScopeAdmin,
+			"POST /api/v1/projects/:project_id/restyle": auth.ScopeImagesWrite,
+		} {
+			assert.Equal(t, want, routeScopes[key], key)
+		}
+	})
+
+	t.Run("read-only scopes grant no write route", func(t *testing.T) {
+		readOnly := []auth.Scope{
+			auth.ScopeImagesRead, auth.ScopeProjectsRead, auth.ScopeOrgsRead,
+			auth.ScopeBillingRead, auth.ScopeProfileRead,
+		}
+		for key, required := range routeScopes {
+			if strings.HasPrefix(key, http.MethodGet+" ") {
+				continue
+			}
+			for _, held := range readOnly {
+				assert.False(t, held.Grants(required), "%s grants %s", held, key)
+			}
+		}
+	})
+}
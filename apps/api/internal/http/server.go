@@ -5,23 +5,59 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 
+	"github.com/real-staging-ai/api/encryption"
 	adminLib "github.com/real-staging-ai/api/internal/admin"
+	"github.com/real-staging-ai/api/internal/asset"
 	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/batch"
 	"github.com/real-staging-ai/api/internal/billing"
+	"github.com/real-staging-ai/api/internal/brownout"
+	"github.com/real-staging-ai/api/internal/compliance"
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/costestimate"
+	"github.com/real-staging-ai/api/internal/credential"
+	"github.com/real-staging-ai/api/internal/deadletter"
+	"github.com/real-staging-ai/api/internal/delivery"
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/exportpreset"
+	"github.com/real-staging-ai/api/internal/feedback"
+	"github.com/real-staging-ai/api/internal/i18n"
+	"github.com/real-staging-ai/api/internal/idempotency"
 	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/imageevent"
+	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/internal/lineage"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/modelversion"
+	"github.com/real-staging-ai/api/internal/org"
+	"github.com/real-staging-ai/api/internal/preference"
+	"github.com/real-staging-ai/api/internal/problem"
 	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/promptexperiment"
+	"github.com/real-staging-ai/api/internal/promptlib"
+	"github.com/real-staging-ai/api/internal/prompttemplate"
+	"github.com/real-staging-ai/api/internal/queueadmin"
+	"github.com/real-staging-ai/api/internal/ratelimit"
 	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/internal/sharelink"
+	"github.com/real-staging-ai/api/internal/slo"
 	"github.com/real-staging-ai/api/internal/sse"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/stripe"
+	"github.com/real-staging-ai/api/internal/stylepreset"
+	"github.com/real-staging-ai/api/internal/supportticket"
+	"github.com/real-staging-ai/api/internal/tenant"
+	"github.com/real-staging-ai/api/internal/testclock"
 	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/internal/webhook"
 	webdocs "github.com/real-staging-ai/api/web"
+	"github.com/real-staging-ai/api/webhookauth"
 )
 
 // Server holds the dependencies for the HTTP server.
@@ -30,12 +66,14 @@ type Server struct {
 	log                 logging.Logger
 	echo                *echo.Echo
 	db                  storage.Database
+	bus                 events.Bus
 	s3Service           storage.S3Service
 	imageService        image.Service
 	subscriptionChecker billing.SubscriptionChecker
 	authConfig          *auth.Auth0Config
 	pubsub              PubSub
 	config              *config.Config
+	sloRecorder         *slo.Recorder
 }
 
 // NewServer creates and configures a new Echo server.
@@ -44,25 +82,37 @@ func NewServer(
 	ctx context.Context,
 	log logging.Logger,
 	db storage.Database,
+	bus events.Bus,
 	imageService image.Service,
 	s3Service storage.S3Service,
 ) *Server {
 	e := echo.New()
+	// Errors handlers and middleware return are written as problem details
+	e.HTTPErrorHandler = problem.ErrorHandler(log)
 
 	// Add OpenTelemetry middleware
 	e.Use(otelecho.Middleware("real-staging-api"))
 
+	// Per-route SLO metrics; sits outside the request logger so it sees final status codes
+	sloRecorder := slo.NewRecorder()
+	e.Use(sloRecorder.Middleware())
+
 	// Add other middleware
 	e.Use(RequestLoggerMiddleware()) // Custom JSON logger with proper log levels for Render
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"http://localhost:3000", "http://localhost:3001"},
+		AllowOrigins: cfg.CORS.AllowedOrigins,
 		AllowMethods: []string{
 			http.MethodGet, http.MethodHead, http.MethodPut,
 			http.MethodPatch, http.MethodPost, http.MethodDelete,
 		},
 		AllowHeaders: []string{
 			echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization,
+			idempotency.HeaderKey,
+		},
+		ExposeHeaders: []string{
+			ratelimit.HeaderLimit, ratelimit.HeaderRemaining, ratelimit.HeaderReset, ratelimit.HeaderUsageRemaining,
+			echo.HeaderRetryAfter, idempotency.HeaderReplayed, echo.HeaderContentDisposition,
 		},
 	}))
 
@@ -79,8 +129,34 @@ func NewServer(
 	// Initialize project repository for ownership verification
 	projectRepo := project.NewDefaultRepository(db)
 
+	// Fair-housing scan of custom prompts; the review queue stays available when scanning is off
+	complianceService := compliance.NewDefaultService(
+		compliance.NewScanner(cfg.Compliance), compliance.NewDefaultRepository(db),
+	)
+	var screener image.PromptScreener
+	if cfg.Compliance.Enabled {
+		screener = complianceService
+	}
+
+	// Async batch requests are tracked in the database and created in the background
+	batchService := batch.NewDefaultService(batch.NewDefaultRepository(db), log)
+
+	// Users' preferred staging models apply to images in projects without one
+	preferenceService := newPreferenceService(cfg, db)
+
+	// Images created while a provider outage is open are reported as delayed
+	providerStatus := brownout.NewDefaultService(settings.NewDefaultRepository(db.Pool()), log)
+
+	// Image requests may reference one of the caller's prompt templates or an admin's style preset
+	templateService := prompttemplate.NewDefaultService(prompttemplate.NewDefaultRepository(db))
+	presetService := stylepreset.NewDefaultService(stylepreset.NewDefaultRepository(db))
+
 	// Initialize image handler with usage checking
-	imgHandler := image.NewDefaultHandler(imageService, usageService, userRepo, projectRepo)
+	imgHandler := image.NewDefaultHandler(
+		imageService, usageService, userRepo, projectRepo, screener, batchService, preferenceService, providerStatus,
+		templateService, presetService,
+	)
+	batchHandler := batch.NewDefaultHandler(batchService, userRepo, log)
 
 	// Initialize Pub/Sub (Redis) if configured
 	var ps PubSub
@@ -92,6 +168,7 @@ func NewServer(
 		ctx:                 ctx,
 		log:                 log,
 		db:                  db,
+		bus:                 bus,
 		s3Service:           s3Service,
 		imageService:        imageService,
 		subscriptionChecker: subscriptionChecker,
@@ -99,6 +176,7 @@ func NewServer(
 		authConfig:          authConfig,
 		pubsub:              ps,
 		config:              cfg,
+		sloRecorder:         sloRecorder,
 	}
 
 	// Health check route
@@ -108,83 +186,346 @@ func NewServer(
 	api := e.Group("/api/v1")
 
 	// Public routes (no authentication required)
+	webhookReplay := newWebhookReplayCache(cfg)
 	api.POST("/stripe/webhook", func(c echo.Context) error {
-		sh := stripe.NewDefaultHandler(s.db)
+		sh := stripe.NewDefaultHandlerWithReplayCache(s.db, s.bus, webhookReplay)
 		return sh.Webhook(c)
 	})
+	statusHandler := brownout.NewDefaultHandler(providerStatus, logging.Default())
+	api.GET("/status", statusHandler.GetStatus, i18n.Middleware(nil, logging.Default()))
+
+	// Public project galleries; the link token is the credential
+	shareHandler := sharelink.NewDefaultHandler(
+		sharelink.NewDefaultService(sharelink.NewDefaultRepository(s.db), projectRepo, imageService, s3Service,
+			cfg.S3.BucketName, logging.Default()),
+		userRepo, projectRepo, logging.Default(),
+	)
+	api.GET("/shared/:token", shareHandler.GetGallery)
 
 	// Protected routes (require JWT authentication)
 	protected := api.Group("")
 	protected.Use(auth.JWTMiddleware(s.authConfig))
+	protected.Use(auth.ScopeMiddleware(routeScopes))
+	protected.Use(tenant.Middleware(tenant.NewDefaultRepository(s.db), logging.Default()))
+	protected.Use(i18n.Middleware(i18n.NewDefaultRepository(s.db), logging.Default()))
+
+	// Per-user rate limit by plan and remaining-quota headers
+	var limiter ratelimit.Limiter
+	if addr := cfg.Redis.Addr(); cfg.RateLimit.Enabled() && addr != "" {
+		limiter = ratelimit.NewRedisLimiter(redis.NewClient(&redis.Options{Addr: addr}))
+	}
+	protected.Use(ratelimit.Middleware(limiter, cfg.RateLimit, usageService, userRepo, logging.Default()))
 
 	// Project routes
 	ph := project.NewDefaultHandler(s.db)
 	protected.POST("/projects", ph.Create)
 	protected.GET("/projects", ph.List)
 	protected.GET("/projects/:id", ph.GetByID)
+	protected.PUT("/projects/:id", ph.Update)
 	protected.DELETE("/projects/:id", ph.Delete)
+	protected.POST("/projects/:id/lock", ph.Lock)
+	protected.POST("/projects/:id/unlock", ph.Unlock)
+	protected.POST("/projects/:id/share", shareHandler.CreateLink)
+	protected.GET("/projects/:id/share", shareHandler.ListLinks)
+	protected.DELETE("/projects/:id/share/:link_id", shareHandler.RevokeLink)
 
 	// Upload routes
 	protected.POST("/uploads/presign", s.presignUploadHandler)
 
-	// Image routes
-	protected.POST("/images", imgHandler.CreateImage)
-	protected.POST("/images/batch", imgHandler.BatchCreateImages)
+	// Image routes. Creates and checkouts retried with the same
+	// Idempotency-Key run once.
+	idempotent := idempotency.Middleware(idempotency.NewDefaultRepository(s.db), logging.Default())
+	protected.POST("/images", imgHandler.CreateImage, idempotent)
+	protected.POST("/images/batch", imgHandler.BatchCreateImages, idempotent)
+	protected.GET("/batches/:id", batchHandler.GetBatch)
+	protected.GET("/images/search", imgHandler.SearchImages)
 	protected.GET("/images/:id", imgHandler.GetImage)
 	protected.GET("/images/:id/presign", s.presignImageDownloadHandler)
+	protected.GET("/images/:id/crops", s.cropSuggestionsHandler)
+	protected.POST("/images/:id/restage", imgHandler.RestageImage)
+	protected.POST("/images/:id/reroll", imgHandler.RerollImage)
 	protected.DELETE("/images/:id", s.deleteImageHandler)
+	protected.POST("/images/:id/restore", imgHandler.RestoreImage)
+	protected.PUT("/images/:id/approval", imgHandler.SetImageApproval)
+	protected.DELETE("/images/:id/job", imgHandler.CancelJob)
+	protected.GET("/projects/:id/trash", imgHandler.ListTrash)
+	protected.GET("/projects/:id/download", s.projectDownloadHandler)
 	protected.GET("/projects/:project_id/images", imgHandler.GetProjectImages)
 	protected.GET("/projects/:project_id/images/grouped", imgHandler.GetGroupedProjectImages)
 	protected.GET("/projects/:project_id/cost", imgHandler.GetProjectCost)
+	protected.POST("/projects/:project_id/restyle", imgHandler.RestyleProject)
+	protected.POST("/projects/:project_id/duplicate", imgHandler.DuplicateProject)
+
+	// Image asset routes (furniture cut-outs)
+	assetService := asset.NewDefaultService(cfg, asset.NewDefaultRepository(s.db), s.s3Service)
+	assetHandler := asset.NewDefaultHandler(assetService, logging.Default())
+	protected.POST("/images/:id/cutouts", assetHandler.RequestCutouts)
+	protected.GET("/images/:id/cutouts", assetHandler.ListCutouts)
+	protected.GET("/assets/:id/presign", assetHandler.PresignAsset)
+
+	// Image staging history
+	historyHandler := imageevent.NewDefaultHandler(
+		imageevent.NewDefaultService(imageevent.NewDefaultRepository(s.db)), logging.Default())
+	protected.GET("/images/:id/history", historyHandler.GetHistory)
+
+	// Image lineage graph
+	lineageHandler := lineage.NewDefaultHandler(
+		lineage.NewDefaultService(lineage.NewDefaultRepository(s.db)), logging.Default())
+	protected.GET("/images/:id/lineage", lineageHandler.GetLineage)
+
+	// Staging cost estimates shown before a user submits
+	costHandler := costestimate.NewDefaultHandler(
+		costestimate.NewDefaultService(costestimate.NewDefaultRepository(s.db)), logging.Default())
+	protected.GET("/models/:id/cost-estimate", costHandler.GetEstimate)
+
+	// Support tickets opened from an image, with its staging context attached
+	supportService := supportticket.NewDefaultService(
+		supportticket.NewDefaultRepository(s.db), supportticket.NewReplicateLogs(cfg.Replicate),
+		delivery.NewDefaultService(cfg, delivery.NewDefaultRepository(s.db)), cfg.Support.WebhookURL, logging.Default(),
+	)
+	supportHandler := supportticket.NewDefaultHandler(supportService, userRepo, logging.Default())
+	protected.POST("/images/:id/support-ticket", supportHandler.Create)
+
+	// Ratings of staged images
+	feedbackHandler := feedback.NewDefaultHandler(
+		feedback.NewDefaultService(feedback.NewDefaultRepository(s.db)), userRepo, logging.Default(),
+	)
+	protected.POST("/images/:id/feedback", feedbackHandler.Submit)
+
+	// Per-project webhook endpoints for image lifecycle events
+	webhookService := webhook.NewDefaultService(
+		webhook.NewDefaultRepository(s.db), delivery.NewDefaultService(cfg, delivery.NewDefaultRepository(s.db)),
+	)
+	webhookHandler := webhook.NewDefaultHandler(webhookService, userRepo, projectRepo, logging.Default())
+	protected.POST("/projects/:project_id/webhooks", webhookHandler.CreateEndpoint)
+	protected.GET("/projects/:project_id/webhooks", webhookHandler.ListEndpoints)
+	protected.GET("/webhooks/:id", webhookHandler.GetEndpoint)
+	protected.PATCH("/webhooks/:id", webhookHandler.UpdateEndpoint)
+	protected.DELETE("/webhooks/:id", webhookHandler.DeleteEndpoint)
+	protected.GET("/webhooks/:id/deliveries", webhookHandler.ListDeliveries)
+
+	// Prompt template routes
+	templateHandler := prompttemplate.NewDefaultHandler(
+		prompttemplate.NewDefaultService(prompttemplate.NewDefaultRepository(s.db)), userRepo, logging.Default(),
+	)
+	protected.POST("/prompt-templates", templateHandler.CreateTemplate)
+	protected.GET("/prompt-templates", templateHandler.ListTemplates)
+	protected.GET("/prompt-templates/:id", templateHandler.GetTemplate)
+	protected.PATCH("/prompt-templates/:id", templateHandler.UpdateTemplate)
+	protected.DELETE("/prompt-templates/:id", templateHandler.DeleteTemplate)
+
+	// Style presets; admins define them under /admin/style-presets
+	presetHandler := stylepreset.NewDefaultHandler(presetService, logging.Default())
+	protected.GET("/style-presets", presetHandler.ListPresets)
+	protected.GET("/style-presets/:id", presetHandler.GetPreset)
+
+	// Organizations: members, invitations and shared projects
+	orgHandler := org.NewDefaultHandler(
+		org.NewDefaultService(org.NewDefaultRepository(s.db), projectRepo), userRepo, logging.Default(),
+	)
+	protected.POST("/orgs", orgHandler.CreateOrg)
+	protected.GET("/orgs", orgHandler.ListOrgs)
+	protected.GET("/orgs/:id", orgHandler.GetOrg)
+	protected.PATCH("/orgs/:id", orgHandler.UpdateOrg)
+	protected.DELETE("/orgs/:id", orgHandler.DeleteOrg)
+	protected.GET("/orgs/:id/members", orgHandler.ListMembers)
+	protected.PATCH("/orgs/:id/members/:user_id", orgHandler.UpdateMember)
+	protected.DELETE("/orgs/:id/members/:user_id", orgHandler.RemoveMember)
+	protected.POST("/orgs/:id/invitations", orgHandler.CreateInvitation)
+	protected.GET("/orgs/:id/invitations", orgHandler.ListInvitations)
+	protected.DELETE("/orgs/:id/invitations/:invitation_id", orgHandler.RevokeInvitation)
+	protected.POST("/invitations/accept", orgHandler.AcceptInvitation)
+	protected.GET("/orgs/:id/projects", orgHandler.ListProjects)
+	protected.PUT("/orgs/:id/projects/:project_id", orgHandler.ShareProject)
+	protected.DELETE("/orgs/:id/projects/:project_id", orgHandler.UnshareProject)
+
+	// Organization export presets, applied with ?preset= on project downloads
+	exportPresetHandler := exportpreset.NewDefaultHandler(
+		exportpreset.NewDefaultService(exportpreset.NewDefaultRepository(s.db), org.NewDefaultRepository(s.db)),
+		userRepo, logging.Default(),
+	)
+	protected.POST("/orgs/:id/export-presets", exportPresetHandler.CreatePreset)
+	protected.GET("/orgs/:id/export-presets", exportPresetHandler.ListPresets)
+	protected.GET("/orgs/:id/export-presets/:preset_id", exportPresetHandler.GetPreset)
+	protected.PATCH("/orgs/:id/export-presets/:preset_id", exportPresetHandler.UpdatePreset)
+	protected.DELETE("/orgs/:id/export-presets/:preset_id", exportPresetHandler.DeletePreset)
 
 	// SSE routes
 	protected.GET("/events", func(c echo.Context) error {
 		cfg := sse.Config{
 			SubscribeTimeout: 2000000000,
 		}
-		h, err := sse.NewDefaultHandlerFromEnv(cfg)
+		h, err := sse.NewDefaultHandlerFromEnv(cfg, sse.NewDefaultRepository(s.db))
 		if err != nil {
 			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
 		}
 		return h.Events(c)
 	})
+	// WebSocket alternative for clients behind proxies that buffer SSE
+	protected.GET("/ws", func(c echo.Context) error {
+		cfg := sse.Config{
+			SubscribeTimeout: 2000000000,
+		}
+		h, err := sse.NewDefaultHandlerFromEnv(cfg, sse.NewDefaultRepository(s.db))
+		if err != nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+		}
+		return h.WebSocket(c)
+	})
 
 	// Billing routes
-	bh := billing.NewDefaultHandler(s.db, usageService, cfg.Stripe.SecretKey, cfg)
+	bh := billing.NewDefaultHandler(s.db, usageService, newBillingService(cfg, s.db), cfg)
+	billingEvents := func(c echo.Context) error {
+		cfg := sse.Config{
+			SubscribeTimeout: 2000000000,
+		}
+		h, err := sse.NewDefaultHandlerFromEnv(cfg, sse.NewDefaultRepository(s.db))
+		if err != nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+		}
+		return h.BillingEvents(c)
+	}
 	protected.GET("/billing/subscriptions", bh.GetMySubscriptions)
 	protected.GET("/billing/invoices", bh.GetMyInvoices)
+	protected.GET("/billing/invoices/:id/pdf", bh.GetInvoicePDF)
 	protected.GET("/billing/usage", bh.GetMyUsage)
-	protected.POST("/billing/create-checkout", bh.CreateCheckoutSession)
+	protected.GET("/billing/events", billingEvents)
+	protected.POST("/billing/create-checkout", bh.CreateCheckoutSession, idempotent)
 	protected.POST("/billing/portal", bh.CreatePortalSession)
 
 	// Elements-based billing routes
-	protected.POST("/billing/create-subscription-elements", bh.CreateSubscriptionWithElements)
+	protected.POST("/billing/create-subscription-elements", bh.CreateSubscriptionWithElements, idempotent)
 	protected.GET("/billing/payment-methods", bh.GetPaymentMethods)
 	protected.POST("/billing/upgrade-subscription", bh.UpgradeSubscription)
 	protected.POST("/billing/cancel-subscription", bh.CancelSubscription)
 
+	// Image credits for users without a subscription
+	protected.GET("/billing/credits", bh.GetMyCredits)
+	protected.POST("/billing/credits/checkout", bh.CreateCreditCheckout, idempotent)
+
 	// User profile routes
 	profileService := user.NewDefaultProfileService(userRepo)
 	profileHandler := NewProfileHandler(profileService, userRepo, logging.Default())
 	protected.GET("/user/profile", profileHandler.GetProfile)
 	protected.PATCH("/user/profile", profileHandler.UpdateProfile)
+	preferenceHandler := preference.NewDefaultHandler(preferenceService, userRepo, logging.Default())
+	protected.GET("/user/preferences", preferenceHandler.GetPreferences)
+	protected.PUT("/user/preferences", preferenceHandler.UpdatePreferences)
+	credentialHandler := newCredentialHandler(cfg, s.db, userRepo)
+	protected.GET("/user/credentials", credentialHandler.ListCredentials)
+	protected.POST("/user/credentials", credentialHandler.SaveCredential)
+	protected.DELETE("/user/credentials/:provider", credentialHandler.DeleteCredential)
 
 	// Admin routes (feature-flagged)
 	admin := protected.Group("/admin")
 	// Admin settings routes
 	settingsRepo := settings.NewDefaultRepository(s.db.Pool())
-	settingsService := settings.NewDefaultService(settingsRepo)
+	settingsService := settings.NewDefaultService(settingsRepo, encryptionKeyring(cfg))
 	adminHandler := adminLib.NewDefaultHandler(settingsService, s.db, logging.Default())
 	admin.GET("/models", adminHandler.ListModels)
 	admin.GET("/models/active", adminHandler.GetActiveModel)
 	admin.PUT("/models/active", adminHandler.UpdateActiveModel)
+	admin.PATCH("/models/:id", adminHandler.UpdateModel)
 	admin.GET("/models/:id/config", adminHandler.GetModelConfig)
 	admin.PUT("/models/:id/config", adminHandler.UpdateModelConfig)
 	admin.GET("/models/:id/config/schema", adminHandler.GetModelConfigSchema)
+	admin.GET("/models/:id/config/jsonschema", adminHandler.GetModelConfigJSONSchema)
 	admin.GET("/settings", adminHandler.ListSettings)
 	admin.GET("/settings/:key", adminHandler.GetSetting)
 	admin.PUT("/settings/:key", adminHandler.UpdateSetting)
 
+	// Model version pinning routes
+	modelVersionService := modelversion.NewDefaultService(
+		modelversion.NewDefaultRepository(s.db), modelversion.NewReplicateUpstream(cfg.Replicate),
+	)
+	modelVersionHandler := modelversion.NewDefaultHandler(modelVersionService, logging.Default())
+	admin.GET("/models/versions", modelVersionHandler.ListPins)
+	admin.GET("/models/:id/versions", modelVersionHandler.ListVersions)
+	admin.PUT("/models/:id/version", modelVersionHandler.PinVersion)
+	admin.POST("/models/:id/canary", modelVersionHandler.StartCanary)
+	admin.GET("/models/:id/canary", modelVersionHandler.CompareCanary)
+	admin.POST("/models/:id/canary/promote", modelVersionHandler.PromoteCanary)
+	admin.POST("/models/:id/rollback", modelVersionHandler.Rollback)
+
+	// Prompt library editing and export/import
+	promptLibHandler := promptlib.NewDefaultHandler(
+		promptlib.NewDefaultService(promptlib.NewDefaultRepository(s.db)), logging.Default(),
+	)
+	admin.GET("/prompts", promptLibHandler.ListPrompts)
+	admin.GET("/prompts/export", promptLibHandler.Export)
+	admin.POST("/prompts/import", promptLibHandler.Import)
+	admin.GET("/prompts/:room/:style", promptLibHandler.GetPrompt)
+	admin.PUT("/prompts/:room/:style", promptLibHandler.SetPrompt)
+	admin.DELETE("/prompts/:room/:style", promptLibHandler.DeletePrompt)
+	admin.GET("/prompts/:room/:style/versions", promptLibHandler.ListVersions)
+
+	// Prompt A/B experiments
+	experimentHandler := promptexperiment.NewDefaultHandler(
+		promptexperiment.NewDefaultService(promptexperiment.NewDefaultRepository(s.db)), logging.Default(),
+	)
+	admin.POST("/experiments", experimentHandler.CreateExperiment)
+	admin.GET("/experiments", experimentHandler.ListExperiments)
+	admin.GET("/experiments/:id", experimentHandler.GetExperiment)
+	admin.POST("/experiments/:id/stop", experimentHandler.StopExperiment)
+	admin.GET("/experiments/:id/report", experimentHandler.GetReport)
+
+	// Style preset routes
+	admin.POST("/style-presets", presetHandler.CreatePreset)
+	admin.PATCH("/style-presets/:id", presetHandler.UpdatePreset)
+	admin.DELETE("/style-presets/:id", presetHandler.DeletePreset)
+
+	// Image ratings compared by model and prompt
+	admin.GET("/feedback/models", feedbackHandler.ModelQuality)
+	admin.GET("/feedback/prompts", feedbackHandler.PromptQuality)
+
+	// Outbound delivery log routes
+	deliveryService := delivery.NewDefaultService(cfg, delivery.NewDefaultRepository(s.db))
+	deliveryHandler := delivery.NewDefaultHandler(deliveryService, logging.Default())
+	admin.GET("/deliveries", deliveryHandler.ListDeliveries)
+	admin.GET("/deliveries/destinations", deliveryHandler.ListDestinations)
+	admin.GET("/deliveries/:id", deliveryHandler.GetDelivery)
+
+	// SLO routes
+	sloHandler := slo.NewDefaultHandler(slo.NewDefaultService(slo.NewDefaultRepository(s.db)), logging.Default())
+	admin.GET("/slo", sloHandler.ListStatuses)
+	admin.PUT("/slo/:name", sloHandler.PutObjective)
+	admin.DELETE("/slo/:name", sloHandler.DeleteObjective)
+
+	// Account mode routes
+	tenantService := tenant.NewDefaultService(tenant.NewDefaultRepository(s.db))
+	tenantHandler := tenant.NewDefaultHandler(tenantService, logging.Default())
+	admin.GET("/users/:id/account-mode", tenantHandler.GetAccountMode)
+	admin.PUT("/users/:id/account-mode", tenantHandler.SetAccountMode)
+
+	// Fair-housing review queue routes
+	complianceHandler := compliance.NewDefaultHandler(complianceService, logging.Default())
+	admin.GET("/compliance/reviews", complianceHandler.ListReviews)
+	admin.GET("/compliance/reviews/:id", complianceHandler.GetReview)
+	admin.PATCH("/compliance/reviews/:id", complianceHandler.ResolveReview)
+	admin.POST("/compliance/scan", complianceHandler.Scan)
+
+	// Stripe test clock routes (test-only, off unless explicitly enabled)
+	if cfg.Stripe.TestClocksEnabled {
+		clockHandler := testclock.NewDefaultHandler(newTestClockService(cfg, s.db), logging.Default())
+		admin.GET("/users/:id/test-clock", clockHandler.GetClock)
+		admin.POST("/users/:id/test-clock", clockHandler.CreateClock)
+		admin.POST("/users/:id/test-clock/advance", clockHandler.AdvanceClock)
+		admin.DELETE("/users/:id/test-clock", clockHandler.DeleteClock)
+	}
+
+	// Queue inspection routes
+	queueHandler := queueadmin.NewDefaultHandler(newQueueAdminService(cfg), logging.Default())
+	admin.GET("/queue/tasks", queueHandler.ListTasks)
+	admin.POST("/queue/tasks/:id/requeue", queueHandler.RequeueTask)
+	admin.DELETE("/queue/tasks/:id", queueHandler.DeleteTask)
+
+	// Dead letter routes
+	deadLetterHandler := deadletter.NewDefaultHandler(newDeadLetterService(cfg, s.db), logging.Default())
+	admin.GET("/dead-letters", deadLetterHandler.ListDeadLetters)
+	admin.GET("/dead-letters/:id", deadLetterHandler.GetDeadLetter)
+	admin.POST("/dead-letters/:id/retry", deadLetterHandler.RetryDeadLetter)
+	admin.DELETE("/dead-letters/:id", deadLetterHandler.DiscardDeadLetter)
+
 	// Serve API documentation (embedded)
 	webdocs.RegisterRoutes(e)
 
@@ -200,6 +541,7 @@ func NewTestServer(
 	imageService image.Service,
 ) *Server {
 	e := echo.New()
+	e.HTTPErrorHandler = problem.ErrorHandler(log)
 
 	// Add basic middleware (no Auth0 for testing)
 	e.Use(RequestLoggerMiddleware()) // Custom JSON logger with proper log levels for Render
@@ -214,12 +556,39 @@ func NewTestServer(
 	// Initialize project repository for ownership verification
 	projectRepo := project.NewDefaultRepository(db)
 
+	// Fair-housing scan of custom prompts; the review queue stays available when scanning is off
+	complianceService := compliance.NewDefaultService(
+		compliance.NewScanner(cfg.Compliance), compliance.NewDefaultRepository(db),
+	)
+	var screener image.PromptScreener
+	if cfg.Compliance.Enabled {
+		screener = complianceService
+	}
+
+	// Async batch requests are tracked in the database and created in the background
+	batchService := batch.NewDefaultService(batch.NewDefaultRepository(db), log)
+
+	// Users' preferred staging models apply to images in projects without one
+	preferenceService := newPreferenceService(cfg, db)
+
+	// Images created while a provider outage is open are reported as delayed
+	providerStatus := brownout.NewDefaultService(settings.NewDefaultRepository(db.Pool()), log)
+
+	// Image requests may reference one of the caller's prompt templates or an admin's style preset
+	templateService := prompttemplate.NewDefaultService(prompttemplate.NewDefaultRepository(db))
+	presetService := stylepreset.NewDefaultService(stylepreset.NewDefaultRepository(db))
+
 	// Initialize image handler with usage checking
-	imgHandler := image.NewDefaultHandler(imageService, usageService, userRepo, projectRepo)
+	imgHandler := image.NewDefaultHandler(
+		imageService, usageService, userRepo, projectRepo, screener, batchService, preferenceService, providerStatus,
+		templateService, presetService,
+	)
+	batchHandler := batch.NewDefaultHandler(batchService, userRepo, log)
 
 	s := &Server{
 		log:                 log,
 		db:                  db,
+		bus:                 events.NewDefaultBus(log),
 		s3Service:           s3Service,
 		imageService:        imageService,
 		subscriptionChecker: subscriptionChecker,
@@ -236,9 +605,22 @@ func NewTestServer(
 
 	// All routes are public for testing
 	api.POST("/stripe/webhook", func(c echo.Context) error {
-		sh := stripe.NewDefaultHandler(s.db)
+		sh := stripe.NewDefaultHandler(s.db, s.bus)
 		return sh.Webhook(c)
 	})
+	statusHandler := brownout.NewDefaultHandler(providerStatus, logging.Default())
+	api.GET("/status", statusHandler.GetStatus, i18n.Middleware(nil, logging.Default()))
+	shareHandler := sharelink.NewDefaultHandler(
+		sharelink.NewDefaultService(sharelink.NewDefaultRepository(s.db), projectRepo, imageService, s3Service,
+			cfg.S3.BucketName, logging.Default()),
+		userRepo, projectRepo, logging.Default(),
+	)
+	api.GET("/shared/:token", shareHandler.GetGallery)
+
+	// Resolve sandbox/live account mode for every route below
+	api.Use(tenant.Middleware(tenant.NewDefaultRepository(s.db), logging.Default()))
+	api.Use(i18n.Middleware(i18n.NewDefaultRepository(s.db), logging.Default()))
+	api.Use(ratelimit.Middleware(nil, cfg.RateLimit, usageService, userRepo, logging.Default()))
 
 	// Project routes (no auth required for testing)
 	ph := project.NewDefaultHandler(s.db)
@@ -247,78 +629,331 @@ func NewTestServer(
 	api.GET("/projects/:id", withTestUser(ph.GetByID))
 	api.PUT("/projects/:id", withTestUser(ph.Update))
 	api.DELETE("/projects/:id", withTestUser(ph.Delete))
+	api.POST("/projects/:id/lock", withTestUser(ph.Lock))
+	api.POST("/projects/:id/unlock", withTestUser(ph.Unlock))
+	api.POST("/projects/:id/share", withTestUser(shareHandler.CreateLink))
+	api.GET("/projects/:id/share", withTestUser(shareHandler.ListLinks))
+	api.DELETE("/projects/:id/share/:link_id", withTestUser(shareHandler.RevokeLink))
 
 	// Upload routes
 	api.POST("/uploads/presign", withTestUser(s.presignUploadHandler))
 
 	// Image routes
-	api.POST("/images", withTestUser(imgHandler.CreateImage))
+	idempotent := idempotency.Middleware(idempotency.NewDefaultRepository(s.db), logging.Default())
+	api.POST("/images", withTestUser(imgHandler.CreateImage), idempotent)
+	api.POST("/images/batch", withTestUser(imgHandler.BatchCreateImages), idempotent)
+	api.GET("/batches/:id", withTestUser(batchHandler.GetBatch))
+	api.GET("/images/search", withTestUser(imgHandler.SearchImages))
 	api.GET("/images/:id", withTestUser(imgHandler.GetImage))
 	api.GET("/images/:id/presign", withTestUser(s.presignImageDownloadHandler))
+	api.GET("/images/:id/crops", withTestUser(s.cropSuggestionsHandler))
+	api.POST("/images/:id/restage", withTestUser(imgHandler.RestageImage))
+	api.POST("/images/:id/reroll", withTestUser(imgHandler.RerollImage))
 	api.DELETE("/images/:id", withTestUser(s.deleteImageHandler))
+	api.POST("/images/:id/restore", withTestUser(imgHandler.RestoreImage))
+	api.PUT("/images/:id/approval", withTestUser(imgHandler.SetImageApproval))
+	api.DELETE("/images/:id/job", withTestUser(imgHandler.CancelJob))
+	api.GET("/projects/:id/trash", withTestUser(imgHandler.ListTrash))
+	api.GET("/projects/:id/download", withTestUser(s.projectDownloadHandler))
 	api.GET("/projects/:project_id/images", withTestUser(imgHandler.GetProjectImages))
 	api.GET("/projects/:project_id/images/grouped", withTestUser(imgHandler.GetGroupedProjectImages))
 	api.GET("/projects/:project_id/cost", withTestUser(imgHandler.GetProjectCost))
+	api.POST("/projects/:project_id/restyle", withTestUser(imgHandler.RestyleProject))
+	api.POST("/projects/:project_id/duplicate", withTestUser(imgHandler.DuplicateProject))
+
+	// Image asset routes (test server)
+	assetService := asset.NewDefaultService(cfg, asset.NewDefaultRepository(s.db), s.s3Service)
+	assetHandler := asset.NewDefaultHandler(assetService, logging.Default())
+	api.POST("/images/:id/cutouts", withTestUser(assetHandler.RequestCutouts))
+	api.GET("/images/:id/cutouts", withTestUser(assetHandler.ListCutouts))
+	api.GET("/assets/:id/presign", withTestUser(assetHandler.PresignAsset))
+
+	// Image staging history
+	historyHandler := imageevent.NewDefaultHandler(
+		imageevent.NewDefaultService(imageevent.NewDefaultRepository(s.db)), logging.Default())
+	api.GET("/images/:id/history", withTestUser(historyHandler.GetHistory))
+
+	// Image lineage graph
+	lineageHandler := lineage.NewDefaultHandler(
+		lineage.NewDefaultService(lineage.NewDefaultRepository(s.db)), logging.Default())
+	api.GET("/images/:id/lineage", withTestUser(lineageHandler.GetLineage))
+
+	// Staging cost estimates shown before a user submits
+	costHandler := costestimate.NewDefaultHandler(
+		costestimate.NewDefaultService(costestimate.NewDefaultRepository(s.db)), logging.Default())
+	api.GET("/models/:id/cost-estimate", withTestUser(costHandler.GetEstimate))
+
+	// Support tickets opened from an image, with its staging context attached
+	supportService := supportticket.NewDefaultService(
+		supportticket.NewDefaultRepository(s.db), supportticket.NewReplicateLogs(cfg.Replicate),
+		delivery.NewDefaultService(cfg, delivery.NewDefaultRepository(s.db)), cfg.Support.WebhookURL, logging.Default(),
+	)
+	supportHandler := supportticket.NewDefaultHandler(supportService, userRepo, logging.Default())
+	api.POST("/images/:id/support-ticket", withTestUser(supportHandler.Create))
+
+	// Ratings of staged images (test server)
+	feedbackHandler := feedback.NewDefaultHandler(
+		feedback.NewDefaultService(feedback.NewDefaultRepository(s.db)), userRepo, logging.Default(),
+	)
+	api.POST("/images/:id/feedback", withTestUser(feedbackHandler.Submit))
+
+	// Webhook endpoint routes (test server)
+	webhookService := webhook.NewDefaultService(
+		webhook.NewDefaultRepository(s.db), delivery.NewDefaultService(cfg, delivery.NewDefaultRepository(s.db)),
+	)
+	webhookHandler := webhook.NewDefaultHandler(webhookService, userRepo, projectRepo, logging.Default())
+	api.POST("/projects/:project_id/webhooks", withTestUser(webhookHandler.CreateEndpoint))
+	api.GET("/projects/:project_id/webhooks", withTestUser(webhookHandler.ListEndpoints))
+	api.GET("/webhooks/:id", withTestUser(webhookHandler.GetEndpoint))
+	api.PATCH("/webhooks/:id", withTestUser(webhookHandler.UpdateEndpoint))
+	api.DELETE("/webhooks/:id", withTestUser(webhookHandler.DeleteEndpoint))
+	api.GET("/webhooks/:id/deliveries", withTestUser(webhookHandler.ListDeliveries))
+
+	// Prompt template routes
+	templateHandler := prompttemplate.NewDefaultHandler(
+		prompttemplate.NewDefaultService(prompttemplate.NewDefaultRepository(s.db)), userRepo, logging.Default(),
+	)
+	api.POST("/prompt-templates", withTestUser(templateHandler.CreateTemplate))
+	api.GET("/prompt-templates", withTestUser(templateHandler.ListTemplates))
+	api.GET("/prompt-templates/:id", withTestUser(templateHandler.GetTemplate))
+	api.PATCH("/prompt-templates/:id", withTestUser(templateHandler.UpdateTemplate))
+	api.DELETE("/prompt-templates/:id", withTestUser(templateHandler.DeleteTemplate))
+
+	// Style preset routes (test server)
+	presetHandler := stylepreset.NewDefaultHandler(presetService, logging.Default())
+	api.GET("/style-presets", withTestUser(presetHandler.ListPresets))
+	api.GET("/style-presets/:id", withTestUser(presetHandler.GetPreset))
+
+	// Organization routes (test server)
+	orgHandler := org.NewDefaultHandler(
+		org.NewDefaultService(org.NewDefaultRepository(s.db), projectRepo), userRepo, logging.Default(),
+	)
+	api.POST("/orgs", withTestUser(orgHandler.CreateOrg))
+	api.GET("/orgs", withTestUser(orgHandler.ListOrgs))
+	api.GET("/orgs/:id", withTestUser(orgHandler.GetOrg))
+	api.PATCH("/orgs/:id", withTestUser(orgHandler.UpdateOrg))
+	api.DELETE("/orgs/:id", withTestUser(orgHandler.DeleteOrg))
+	api.GET("/orgs/:id/members", withTestUser(orgHandler.ListMembers))
+	api.PATCH("/orgs/:id/members/:user_id", withTestUser(orgHandler.UpdateMember))
+	api.DELETE("/orgs/:id/members/:user_id", withTestUser(orgHandler.RemoveMember))
+	api.POST("/orgs/:id/invitations", withTestUser(orgHandler.CreateInvitation))
+	api.GET("/orgs/:id/invitations", withTestUser(orgHandler.ListInvitations))
+	api.DELETE("/orgs/:id/invitations/:invitation_id", withTestUser(orgHandler.RevokeInvitation))
+	api.POST("/invitations/accept", withTestUser(orgHandler.AcceptInvitation))
+	api.GET("/orgs/:id/projects", withTestUser(orgHandler.ListProjects))
+	api.PUT("/orgs/:id/projects/:project_id", withTestUser(orgHandler.ShareProject))
+	api.DELETE("/orgs/:id/projects/:project_id", withTestUser(orgHandler.UnshareProject))
+
+	// Organization export presets, applied with ?preset= on project downloads
+	exportPresetHandler := exportpreset.NewDefaultHandler(
+		exportpreset.NewDefaultService(exportpreset.NewDefaultRepository(s.db), org.NewDefaultRepository(s.db)),
+		userRepo, logging.Default(),
+	)
+	api.POST("/orgs/:id/export-presets", withTestUser(exportPresetHandler.CreatePreset))
+	api.GET("/orgs/:id/export-presets", withTestUser(exportPresetHandler.ListPresets))
+	api.GET("/orgs/:id/export-presets/:preset_id", withTestUser(exportPresetHandler.GetPreset))
+	api.PATCH("/orgs/:id/export-presets/:preset_id", withTestUser(exportPresetHandler.UpdatePreset))
+	api.DELETE("/orgs/:id/export-presets/:preset_id", withTestUser(exportPresetHandler.DeletePreset))
 
 	// SSE routes
 	api.GET("/events", func(c echo.Context) error {
 		cfg := sse.Config{
 			SubscribeTimeout: 2000000000,
 		}
-		h, err := sse.NewDefaultHandlerFromEnv(cfg)
+		h, err := sse.NewDefaultHandlerFromEnv(cfg, sse.NewDefaultRepository(s.db))
 		if err != nil {
 			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
 		}
 		return h.Events(c)
 	})
+	// WebSocket alternative for clients behind proxies that buffer SSE
+	api.GET("/ws", func(c echo.Context) error {
+		cfg := sse.Config{
+			SubscribeTimeout: 2000000000,
+		}
+		h, err := sse.NewDefaultHandlerFromEnv(cfg, sse.NewDefaultRepository(s.db))
+		if err != nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+		}
+		return h.WebSocket(c)
+	})
 
 	// Billing routes (public in test server)
-	bh := billing.NewDefaultHandler(s.db, usageService, cfg.Stripe.SecretKey, cfg)
+	bh := billing.NewDefaultHandler(s.db, usageService, newBillingService(cfg, s.db), cfg)
+	billingEvents := func(c echo.Context) error {
+		cfg := sse.Config{
+			SubscribeTimeout: 2000000000,
+		}
+		h, err := sse.NewDefaultHandlerFromEnv(cfg, sse.NewDefaultRepository(s.db))
+		if err != nil {
+			return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+		}
+		return h.BillingEvents(c)
+	}
 	api.GET("/billing/subscriptions", withTestUser(bh.GetMySubscriptions))
 	api.GET("/billing/invoices", withTestUser(bh.GetMyInvoices))
+	api.GET("/billing/invoices/:id/pdf", withTestUser(bh.GetInvoicePDF))
 	api.GET("/billing/usage", withTestUser(bh.GetMyUsage))
-	api.POST("/billing/create-checkout", withTestUser(bh.CreateCheckoutSession))
+	api.GET("/billing/events", withTestUser(billingEvents))
+	api.POST("/billing/create-checkout", withTestUser(bh.CreateCheckoutSession), idempotent)
 	api.POST("/billing/portal", withTestUser(bh.CreatePortalSession))
 
 	// Elements-based billing routes (test server)
-	api.POST("/billing/create-subscription-elements", withTestUser(bh.CreateSubscriptionWithElements))
+	api.POST("/billing/create-subscription-elements", withTestUser(bh.CreateSubscriptionWithElements), idempotent)
 	api.GET("/billing/payment-methods", withTestUser(bh.GetPaymentMethods))
 	api.POST("/billing/upgrade-subscription", withTestUser(bh.UpgradeSubscription))
 	api.POST("/billing/cancel-subscription", withTestUser(bh.CancelSubscription))
 
+	// Image credits (test server)
+	api.GET("/billing/credits", withTestUser(bh.GetMyCredits))
+	api.POST("/billing/credits/checkout", withTestUser(bh.CreateCreditCheckout), idempotent)
+
 	// User profile routes (test server)
 	profileService := user.NewDefaultProfileService(userRepo)
 	profileHandler := NewProfileHandler(profileService, userRepo, logging.Default())
 	api.GET("/user/profile", withTestUser(profileHandler.GetProfile))
 	api.PATCH("/user/profile", withTestUser(profileHandler.UpdateProfile))
+	preferenceHandler := preference.NewDefaultHandler(preferenceService, userRepo, logging.Default())
+	api.GET("/user/preferences", withTestUser(preferenceHandler.GetPreferences))
+	api.PUT("/user/preferences", withTestUser(preferenceHandler.UpdatePreferences))
+	credentialHandler := newCredentialHandler(cfg, s.db, userRepo)
+	api.GET("/user/credentials", withTestUser(credentialHandler.ListCredentials))
+	api.POST("/user/credentials", withTestUser(credentialHandler.SaveCredential))
+	api.DELETE("/user/credentials/:provider", withTestUser(credentialHandler.DeleteCredential))
 
 	// Admin routes (public in test server, feature-flagged)
 	admin := api.Group("/admin")
 	// Admin settings routes (test server)
 	settingsRepo := settings.NewDefaultRepository(s.db.Pool())
-	settingsService := settings.NewDefaultService(settingsRepo)
+	settingsService := settings.NewDefaultService(settingsRepo, encryptionKeyring(cfg))
+	if err := settings.LoadCatalog(context.Background(), settingsRepo); err != nil {
+		log.Error(context.Background(), "failed to load model catalog", "error", err)
+	}
+	if err := promptlib.LoadCatalog(context.Background(), promptlib.NewDefaultRepository(s.db)); err != nil {
+		log.Error(context.Background(), "failed to load prompt catalog", "error", err)
+	}
 	adminHandler := adminLib.NewDefaultHandler(settingsService, s.db, logging.Default())
 	admin.GET("/models", withTestUser(adminHandler.ListModels))
 	admin.GET("/models/active", withTestUser(adminHandler.GetActiveModel))
 	admin.PUT("/models/active", withTestUser(adminHandler.UpdateActiveModel))
+	admin.PATCH("/models/:id", withTestUser(adminHandler.UpdateModel))
 	admin.GET("/models/:id/config", withTestUser(adminHandler.GetModelConfig))
 	admin.PUT("/models/:id/config", withTestUser(adminHandler.UpdateModelConfig))
 	admin.GET("/models/:id/config/schema", withTestUser(adminHandler.GetModelConfigSchema))
+	admin.GET("/models/:id/config/jsonschema", withTestUser(adminHandler.GetModelConfigJSONSchema))
 	admin.GET("/settings", withTestUser(adminHandler.ListSettings))
 	admin.GET("/settings/:key", withTestUser(adminHandler.GetSetting))
 	admin.PUT("/settings/:key", withTestUser(adminHandler.UpdateSetting))
 
+	// Model version pinning routes (test server)
+	modelVersionService := modelversion.NewDefaultService(
+		modelversion.NewDefaultRepository(s.db), modelversion.NewReplicateUpstream(cfg.Replicate),
+	)
+	modelVersionHandler := modelversion.NewDefaultHandler(modelVersionService, logging.Default())
+	admin.GET("/models/versions", withTestUser(modelVersionHandler.ListPins))
+	admin.GET("/models/:id/versions", withTestUser(modelVersionHandler.ListVersions))
+	admin.PUT("/models/:id/version", withTestUser(modelVersionHandler.PinVersion))
+	admin.POST("/models/:id/canary", withTestUser(modelVersionHandler.StartCanary))
+	admin.GET("/models/:id/canary", withTestUser(modelVersionHandler.CompareCanary))
+	admin.POST("/models/:id/canary/promote", withTestUser(modelVersionHandler.PromoteCanary))
+	admin.POST("/models/:id/rollback", withTestUser(modelVersionHandler.Rollback))
+
+	promptLibHandler := promptlib.NewDefaultHandler(
+		promptlib.NewDefaultService(promptlib.NewDefaultRepository(s.db)), logging.Default(),
+	)
+	admin.GET("/prompts", withTestUser(promptLibHandler.ListPrompts))
+	admin.GET("/prompts/export", withTestUser(promptLibHandler.Export))
+	admin.POST("/prompts/import", withTestUser(promptLibHandler.Import))
+	admin.GET("/prompts/:room/:style", withTestUser(promptLibHandler.GetPrompt))
+	admin.PUT("/prompts/:room/:style", withTestUser(promptLibHandler.SetPrompt))
+	admin.DELETE("/prompts/:room/:style", withTestUser(promptLibHandler.DeletePrompt))
+	admin.GET("/prompts/:room/:style/versions", withTestUser(promptLibHandler.ListVersions))
+
+	// Prompt A/B experiments (test server)
+	experimentHandler := promptexperiment.NewDefaultHandler(
+		promptexperiment.NewDefaultService(promptexperiment.NewDefaultRepository(s.db)), logging.Default(),
+	)
+	admin.POST("/experiments", withTestUser(experimentHandler.CreateExperiment))
+	admin.GET("/experiments", withTestUser(experimentHandler.ListExperiments))
+	admin.GET("/experiments/:id", withTestUser(experimentHandler.GetExperiment))
+	admin.POST("/experiments/:id/stop", withTestUser(experimentHandler.StopExperiment))
+	admin.GET("/experiments/:id/report", withTestUser(experimentHandler.GetReport))
+	admin.POST("/style-presets", withTestUser(presetHandler.CreatePreset))
+	admin.PATCH("/style-presets/:id", withTestUser(presetHandler.UpdatePreset))
+	admin.DELETE("/style-presets/:id", withTestUser(presetHandler.DeletePreset))
+
+	// Image ratings compared by model and prompt (test server)
+	admin.GET("/feedback/models", withTestUser(feedbackHandler.ModelQuality))
+	admin.GET("/feedback/prompts", withTestUser(feedbackHandler.PromptQuality))
+
+	// Outbound delivery log routes (test server)
+	deliveryService := delivery.NewDefaultService(cfg, delivery.NewDefaultRepository(s.db))
+	deliveryHandler := delivery.NewDefaultHandler(deliveryService, logging.Default())
+	admin.GET("/deliveries", withTestUser(deliveryHandler.ListDeliveries))
+	admin.GET("/deliveries/destinations", withTestUser(deliveryHandler.ListDestinations))
+	admin.GET("/deliveries/:id", withTestUser(deliveryHandler.GetDelivery))
+
+	// SLO routes (test server)
+	sloHandler := slo.NewDefaultHandler(slo.NewDefaultService(slo.NewDefaultRepository(s.db)), logging.Default())
+	admin.GET("/slo", withTestUser(sloHandler.ListStatuses))
+	admin.PUT("/slo/:name", withTestUser(sloHandler.PutObjective))
+	admin.DELETE("/slo/:name", withTestUser(sloHandler.DeleteObjective))
+
+	// Account mode routes (test server)
+	tenantService := tenant.NewDefaultService(tenant.NewDefaultRepository(s.db))
+	tenantHandler := tenant.NewDefaultHandler(tenantService, logging.Default())
+	admin.GET("/users/:id/account-mode", withTestUser(tenantHandler.GetAccountMode))
+	admin.PUT("/users/:id/account-mode", withTestUser(tenantHandler.SetAccountMode))
+
+	// Fair-housing review queue routes (test server)
+	complianceHandler := compliance.NewDefaultHandler(complianceService, logging.Default())
+	admin.GET("/compliance/reviews", withTestUser(complianceHandler.ListReviews))
+	admin.GET("/compliance/reviews/:id", withTestUser(complianceHandler.GetReview))
+	admin.PATCH("/compliance/reviews/:id", withTestUser(complianceHandler.ResolveReview))
+	admin.POST("/compliance/scan", withTestUser(complianceHandler.Scan))
+
+	// Stripe test clock routes (test server)
+	clockHandler := testclock.NewDefaultHandler(newTestClockService(cfg, s.db), logging.Default())
+	admin.GET("/users/:id/test-clock", withTestUser(clockHandler.GetClock))
+	admin.POST("/users/:id/test-clock", withTestUser(clockHandler.CreateClock))
+	admin.POST("/users/:id/test-clock/advance", withTestUser(clockHandler.AdvanceClock))
+	admin.DELETE("/users/:id/test-clock", withTestUser(clockHandler.DeleteClock))
+
+	// Queue inspection routes (test server)
+	queueHandler := queueadmin.NewDefaultHandler(newQueueAdminService(cfg), logging.Default())
+	admin.GET("/queue/tasks", withTestUser(queueHandler.ListTasks))
+	admin.POST("/queue/tasks/:id/requeue", withTestUser(queueHandler.RequeueTask))
+	admin.DELETE("/queue/tasks/:id", withTestUser(queueHandler.DeleteTask))
+
+	// Dead letter routes (test server)
+	deadLetterHandler := deadletter.NewDefaultHandler(newDeadLetterService(cfg, s.db), logging.Default())
+	admin.GET("/dead-letters", withTestUser(deadLetterHandler.ListDeadLetters))
+	admin.GET("/dead-letters/:id", withTestUser(deadLetterHandler.GetDeadLetter))
+	admin.POST("/dead-letters/:id/retry", withTestUser(deadLetterHandler.RetryDeadLetter))
+	admin.DELETE("/dead-letters/:id", withTestUser(deadLetterHandler.DiscardDeadLetter))
+
 	// Serve API documentation (embedded)
 	webdocs.RegisterRoutes(e)
 
 	return s
 }
 
+// SLORecorder returns the recorder collecting per-route SLO metrics, or nil for test servers.
+func (s *Server) SLORecorder() *slo.Recorder {
+	return s.sloRecorder
+}
+
 // Start starts the HTTP server.
 func (s *Server) Start(addr string) error {
 	return s.echo.Start(addr)
 }
 
+// Shutdown stops accepting connections and waits for in-flight requests to
+// finish until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
+
 // ServeHTTP implements the http.Handler interface.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.echo.ServeHTTP(w, r)
@@ -332,6 +967,83 @@ func (s *Server) healthCheck(c echo.Context) error {
 	})
 }
 
+// newBillingService builds the billing service with a Stripe client per account
+// mode. A mode without a key gets no client, so its billing reports itself
+// unconfigured; sandbox accounts never fall back to the live key.
+func newBillingService(cfg *config.Config, db storage.Database) *billing.DefaultBillingService {
+	var live, sandbox billing.StripeClient
+	if cfg.Stripe.SecretKey != "" {
+		live = billing.NewDefaultStripeClient(cfg.Stripe.SecretKey)
+	}
+	if cfg.Stripe.TestSecretKey != "" {
+		sandbox = billing.NewDefaultStripeClient(cfg.Stripe.TestSecretKey)
+	}
+	return billing.NewDefaultBillingService(db, &cfg.Plans, live, sandbox)
+}
+
+// newTestClockService builds the test clock service. Without a Stripe test-mode
+// key the service reports itself unconfigured rather than touching live Stripe.
+func newTestClockService(cfg *config.Config, db storage.Database) *testclock.DefaultService {
+	var client testclock.StripeClient
+	if cfg.Stripe.TestSecretKey != "" {
+		client = testclock.NewDefaultStripeClient(cfg.Stripe.TestSecretKey)
+	}
+	return testclock.NewDefaultService(tenant.NewDefaultRepository(db), client)
+}
+
+// newQueueAdminService builds the queue inspection service. Without a Redis
+// address the service reports itself unconfigured.
+func newQueueAdminService(cfg *config.Config) *queueadmin.DefaultService {
+	var inspector queueadmin.Inspector
+	if addr := cfg.Redis.Addr(); addr != "" {
+		inspector = asynq.NewInspector(asynq.RedisClientOpt{Addr: addr})
+	}
+	return queueadmin.NewDefaultService(inspector)
+}
+
+// newDeadLetterService builds the dead letter service, which removes archived
+// tasks through the queue inspection service.
+func newDeadLetterService(cfg *config.Config, db storage.Database) *deadletter.DefaultService {
+	return deadletter.NewDefaultService(
+		cfg, deadletter.NewDefaultRepository(db), job.NewDefaultRepository(db), newQueueAdminService(cfg),
+	)
+}
+
+// encryptionKeyring returns the keyring that seals values stored in the
+// database, or nil when no usable key is configured.
+func encryptionKeyring(cfg *config.Config) *encryption.Keyring {
+	keyring, err := cfg.Encryption.Keyring()
+	if err != nil {
+		logging.Default().Error(context.Background(), "invalid encryption key; encrypted storage disabled", "error", err)
+	}
+	return keyring
+}
+
+// newPreferenceService builds the user preference service. Without a usable
+// encryption key the service reports itself unconfigured.
+func newPreferenceService(cfg *config.Config, db storage.Database) *preference.DefaultService {
+	return preference.NewDefaultService(preference.NewDefaultRepository(db), encryptionKeyring(cfg))
+}
+
+// newCredentialHandler builds the handler for users' provider API keys.
+// Without a usable encryption key, keys can't be saved.
+func newCredentialHandler(
+	cfg *config.Config, db storage.Database, userRepo user.Repository,
+) *credential.DefaultHandler {
+	service := credential.NewDefaultService(credential.NewDefaultRepository(db), encryptionKeyring(cfg))
+	return credential.NewDefaultHandler(service, userRepo, logging.Default())
+}
+
+// newWebhookReplayCache returns the cache that stops signed webhook requests
+// from being replayed, or nil when no Redis address is configured.
+func newWebhookReplayCache(cfg *config.Config) webhookauth.ReplayCache {
+	addr := cfg.Redis.Addr()
+	if addr == "" {
+		return nil
+	}
+	return webhookauth.NewRedisReplayCache(redis.NewClient(&redis.Options{Addr: addr}))
+}
+
 // withTestUser ensures an X-Test-User header is present for test-only servers.
 // It defaults to the seeded test user to keep integration tests deterministic.
 func withTestUser(h echo.HandlerFunc) echo.HandlerFunc {
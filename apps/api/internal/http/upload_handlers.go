@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 
@@ -38,8 +39,19 @@ type PresignUploadRequest struct {
 	Filename    string `json:"filename" validate:"required,min=1,max=255"`
 	ContentType string `json:"content_type" validate:"required"`
 	FileSize    int64  `json:"file_size" validate:"required,min=1,max=10485760"`
+	// ProjectID scopes the object key to the project; optional for backwards compatibility.
+	ProjectID string `json:"project_id,omitempty"`
+	// Purpose is "original" (the default) for a photo to stage, or "mask" for a
+	// PNG that limits staging to its white areas. Masks need a project.
+	Purpose string `json:"purpose,omitempty"`
 }
 
+// Upload purposes.
+const (
+	UploadPurposeOriginal = "original"
+	UploadPurposeMask     = "mask"
+)
+
 type PresignUploadResponse struct {
 	UploadURL string `json:"upload_url"`
 	FileKey   string `json:"file_key"`
@@ -103,13 +115,19 @@ func (s *Server) presignUploadHandler(c echo.Context) error {
 	// creating images if they exceed their monthly limit.
 
 	// Generate presigned upload URL using injected S3 service
-	result, err := s.s3Service.GeneratePresignedUploadURL(
-		c.Request().Context(),
-		userID,
-		req.Filename,
-		req.ContentType,
-		req.FileSize,
-	)
+	var result *storage.PresignedUploadResult
+	if req.Purpose == UploadPurposeMask {
+		result, err = s.s3Service.GeneratePresignedMaskUploadURL(c.Request().Context(), userID, req.ProjectID, req.Filename)
+	} else {
+		result, err = s.s3Service.GeneratePresignedUploadURL(
+			c.Request().Context(),
+			userID,
+			req.ProjectID,
+			req.Filename,
+			req.ContentType,
+			req.FileSize,
+		)
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_server_error",
@@ -176,6 +194,39 @@ func validatePresignUploadRequest(req *PresignUploadRequest) []ValidationErrorDe
 		})
 	}
 
+	// Validate project ID (used as an S3 key segment)
+	if req.ProjectID != "" {
+		if _, err := uuid.Parse(req.ProjectID); err != nil {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   "project_id",
+				Message: "project_id must be a valid UUID",
+			})
+		}
+	}
+
+	// Validate purpose; masks are PNGs kept with their project
+	switch req.Purpose {
+	case "", UploadPurposeOriginal:
+	case UploadPurposeMask:
+		if req.ContentType != "" && req.ContentType != "image/png" {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   "content_type",
+				Message: "masks must be image/png",
+			})
+		}
+		if req.ProjectID == "" {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   "project_id",
+				Message: "project_id is required for masks",
+			})
+		}
+	default:
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "purpose",
+			Message: "purpose must be original or mask",
+		})
+	}
+
 	// Validate content type matches file extension
 	if req.Filename != "" && req.ContentType != "" {
 		ext := strings.ToLower(filepath.Ext(req.Filename))
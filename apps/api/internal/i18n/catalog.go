@@ -0,0 +1,36 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"strings"
+)
+
+// locales holds one JSON object per language, mapping English messages to
+// their translations.
+//
+//go:embed locales/*.json
+var locales embed.FS
+
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	out := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := locales.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic("i18n: invalid catalog " + entry.Name() + ": " + err.Error())
+		}
+		out[strings.TrimSuffix(entry.Name(), ".json")] = catalog
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+)
+
+type languageKey struct{}
+
+// WithLanguage returns a copy of ctx carrying lang.
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// FromContext returns the language stored in ctx, defaulting to DefaultLanguage.
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(languageKey{}).(string); ok {
+		return lang
+	}
+	return DefaultLanguage
+}
+
+// T translates msg into the language of ctx. With args, the translation is
+// used as a fmt format, so catalog entries must keep the verbs of msg.
+func T(ctx context.Context, msg string, args ...any) string {
+	if translated, ok := catalogs[FromContext(ctx)][msg]; ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestT(t *testing.T) {
+	es := WithLanguage(context.Background(), "es")
+
+	t.Run("success: translates into the context language", func(t *testing.T) {
+		assert.Equal(t, "Imagen no encontrada", T(es, "Image not found"))
+	})
+
+	t.Run("success: formats translated messages", func(t *testing.T) {
+		assert.Equal(t, "máximo 3 imágenes por cambio de estilo, el proyecto necesita 5",
+			T(es, "maximum %d images per restyle, project needs %d", 3, 5))
+	})
+
+	t.Run("success: unknown messages stay english", func(t *testing.T) {
+		assert.Equal(t, "Something new", T(es, "Something new"))
+	})
+
+	t.Run("success: english by default", func(t *testing.T) {
+		assert.Equal(t, "Image not found", T(context.Background(), "Image not found"))
+	})
+
+	t.Run("success: messages without args are not formatted", func(t *testing.T) {
+		assert.Equal(t, "100% done", T(context.Background(), "100% done"))
+	})
+}
+
+var verbs = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// Every catalog must translate the same messages and keep their fmt verbs in
+// order, or T would garble formatted messages.
+func TestCatalogs(t *testing.T) {
+	reference := catalogs["es"]
+	assert.NotEmpty(t, reference)
+
+	for lang, catalog := range catalogs {
+		t.Run(lang, func(t *testing.T) {
+			for msg, translated := range catalog {
+				assert.Equal(t, verbs.FindAllString(msg, -1), verbs.FindAllString(translated, -1), msg)
+				assert.NotEmpty(t, translated, msg)
+			}
+			for msg := range reference {
+				assert.Contains(t, catalog, msg)
+			}
+			assert.Len(t, catalog, len(reference))
+		})
+	}
+}
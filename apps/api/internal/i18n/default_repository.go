@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// PreferredLanguage reads preferences.language from the users row.
+func (r *DefaultRepository) PreferredLanguage(ctx context.Context, auth0Sub string) (string, error) {
+	query := `SELECT COALESCE(preferences->>'language', '') FROM users WHERE auth0_sub = $1`
+
+	var lang string
+	if err := r.db.QueryRow(ctx, query, auth0Sub).Scan(&lang); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get preferred language: %w", err)
+	}
+	return lang, nil
+}
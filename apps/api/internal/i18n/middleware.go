@@ -0,0 +1,47 @@
+package i18n
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// Middleware resolves the request language and stores it in the request
+// context. A saved preference wins over Accept-Language; a failed preference
+// lookup is logged and falls through to the header rather than failing the
+// request. repo may be nil to negotiate from the header alone.
+func Middleware(repo Repository, log logging.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			lang := ""
+			if repo != nil {
+				if auth0Sub, err := auth.GetUserIDOrDefault(c); err == nil && auth0Sub != "" {
+					saved, err := repo.PreferredLanguage(req.Context(), auth0Sub)
+					if err != nil {
+						log.Warn(req.Context(), "failed to load language preference", "error", err)
+					} else if IsSupported(saved) {
+						lang = saved
+					}
+				}
+			}
+			if lang == "" {
+				lang = Negotiate(req.Header.Get(headerAcceptLanguage))
+			}
+			if lang == "" {
+				lang = DefaultLanguage
+			}
+
+			c.Response().Header().Add(echo.HeaderVary, headerAcceptLanguage)
+			c.Response().Header().Set(headerContentLanguage, lang)
+			c.SetRequest(req.WithContext(WithLanguage(req.Context(), lang)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestMiddleware(t *testing.T) {
+	cases := []struct {
+		name      string
+		saved     string
+		lookupErr error
+		header    string
+		user      string
+		want      string
+	}{
+		{name: "success: saved preference wins", saved: "fr", header: "es", user: "auth0|u", want: "fr"},
+		{name: "success: header without preference", header: "es-MX", user: "auth0|u", want: "es"},
+		{
+			name:  "success: unsupported preference falls back to header",
+			saved: "xx", header: "es", user: "auth0|u", want: "es",
+		},
+		{
+			name:      "success: lookup error falls back to header",
+			lookupErr: errors.New("db down"), header: "fr", user: "auth0|u", want: "fr",
+		},
+		{name: "success: english fallback", header: "de", user: "auth0|u", want: "en"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				PreferredLanguageFunc: func(ctx context.Context, auth0Sub string) (string, error) {
+					return tc.saved, tc.lookupErr
+				},
+			}
+
+			var got string
+			next := func(c echo.Context) error {
+				got = FromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept-Language", tc.header)
+			if tc.user != "" {
+				req.Header.Set("X-Test-User", tc.user)
+			}
+			rec := httptest.NewRecorder()
+
+			err := Middleware(repo, logging.Default())(next)(echo.New().NewContext(req, rec))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.want, rec.Header().Get("Content-Language"))
+			assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+		})
+	}
+}
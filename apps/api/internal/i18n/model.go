@@ -0,0 +1,26 @@
+// Package i18n localizes user-facing API text. The language of a request is
+// taken from the caller's saved preference, then the Accept-Language header,
+// and falls back to English. Messages are looked up by their English text, so
+// a string missing from a catalog is simply served in English. Prompts sent to
+// the staging model stay in English; the request language is passed on with
+// each staging job, and the worker translates the image's error messages and
+// default watermark into it.
+package i18n
+
+import "errors"
+
+// DefaultLanguage is served when no supported language is requested.
+const DefaultLanguage = "en"
+
+// ErrUnsupportedLanguage is returned when a preference names a language
+// without a catalog.
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// IsSupported reports whether lang is English or has a catalog.
+func IsSupported(lang string) bool {
+	if lang == DefaultLanguage {
+		return true
+	}
+	_, ok := catalogs[lang]
+	return ok
+}
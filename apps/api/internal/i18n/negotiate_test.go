@@ -0,0 +1,33 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "success: exact match", header: "es", want: "es"},
+		{name: "success: region subtag is ignored", header: "fr-CA", want: "fr"},
+		{name: "success: highest quality wins", header: "es;q=0.5, fr;q=0.9, en;q=0.1", want: "fr"},
+		{name: "success: ties keep header order", header: "fr, es", want: "fr"},
+		{name: "success: unsupported languages are skipped", header: "de-DE, ja;q=0.8, es;q=0.2", want: "es"},
+		{name: "success: english is supported", header: "en-US,en;q=0.9", want: "en"},
+		{name: "success: case insensitive", header: "ES-mx", want: "es"},
+		{name: "fail: empty header", header: "", want: ""},
+		{name: "fail: wildcard only", header: "*", want: ""},
+		{name: "fail: zero quality is refused", header: "fr;q=0", want: ""},
+		{name: "fail: malformed quality is skipped", header: "fr;q=abc", want: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Negotiate(tc.header))
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package i18n
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository reads saved language preferences.
+type Repository interface {
+	// PreferredLanguage returns the language saved in the user's preferences,
+	// or "" when the user has none or does not exist yet.
+	PreferredLanguage(ctx context.Context, auth0Sub string) (string, error)
+}
@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// Claim inserts the key, or takes over an expired or abandoned one in the same
+// statement, so two concurrent requests can't both claim it.
+func (r *DefaultRepository) Claim(ctx context.Context, owner, key, requestHash string) (*Record, error) {
+	claim := `
+		INSERT INTO idempotency_keys (owner, key, request_hash, locked_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner, key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			response_status = NULL,
+			response_content_type = NULL,
+			response_body = NULL,
+			locked_at = EXCLUDED.locked_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < $4
+		   OR (idempotency_keys.response_status IS NULL
+		       AND idempotency_keys.locked_at < $6)
+		RETURNING true`
+
+	now := time.Now()
+	var claimed bool
+	err := r.db.QueryRow(ctx, claim, owner, key, requestHash, now, now.Add(TTL), now.Add(-LockTimeout)).Scan(&claimed)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	existing := `
+		SELECT request_hash, response_status, response_content_type, response_body
+		FROM idempotency_keys
+		WHERE owner = $1 AND key = $2`
+
+	var rec Record
+	var status *int32
+	var contentType *string
+	var body []byte
+	err = r.db.QueryRow(ctx, existing, owner, key).Scan(&rec.RequestHash, &status, &contentType, &body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Released between the two statements: report it as still held,
+		// the client's next retry claims it.
+		return &Record{RequestHash: requestHash}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if status != nil {
+		rec.Response = &Response{Status: int(*status), Body: body}
+		if contentType != nil {
+			rec.Response.ContentType = *contentType
+		}
+	}
+	return &rec, nil
+}
+
+// Complete records the response on the key.
+func (r *DefaultRepository) Complete(ctx context.Context, owner, key string, resp Response) error {
+	query := `
+		UPDATE idempotency_keys
+		SET response_status = $3, response_content_type = $4, response_body = $5
+		WHERE owner = $1 AND key = $2`
+
+	if _, err := r.db.Exec(ctx, query, owner, key, resp.Status, resp.ContentType, resp.Body); err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Release deletes the key.
+func (r *DefaultRepository) Release(ctx context.Context, owner, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE owner = $1 AND key = $2`
+	if _, err := r.db.Exec(ctx, query, owner, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes keys whose expiry is before the given time.
+func (r *DefaultRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,150 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/tenant"
+)
+
+// Middleware runs requests carrying an Idempotency-Key header at most once per
+// caller and key. A retry of a completed request gets its response again,
+// marked with Idempotent-Replayed; a retry while it still runs gets 409, and
+// reusing the key for another endpoint or body gets 422. Requests without the
+// header are untouched.
+//
+// Only successful responses are kept: a failed request changed nothing, so
+// its key is released and a retry runs it again.
+func Middleware(repo Repository, log logging.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > MaxKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest,
+					"Idempotency-Key must be at most "+strconv.Itoa(MaxKeyLength)+" characters")
+			}
+			owner, err := auth.GetUserIDOrDefault(c)
+			if err != nil || owner == "" {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+
+			hash, err := requestHash(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body")
+			}
+
+			existing, err := repo.Claim(ctx, owner, key, hash)
+			if err != nil {
+				log.Error(ctx, "failed to claim idempotency key", "error", err)
+				return echo.NewHTTPError(http.StatusServiceUnavailable, "Failed to check Idempotency-Key")
+			}
+			if existing != nil {
+				return replay(c, existing, hash)
+			}
+
+			rec := &recorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = rec
+			err = next(c)
+
+			// The request is done whether or not the client is still there.
+			ctx = context.WithoutCancel(ctx)
+			res := c.Response()
+			if err != nil || !res.Committed || res.Status < 200 || res.Status >= 300 {
+				if relErr := repo.Release(ctx, owner, key); relErr != nil {
+					log.Error(ctx, "failed to release idempotency key", "error", relErr)
+				}
+				return err
+			}
+
+			stored := Response{
+				Status:      res.Status,
+				ContentType: res.Header().Get(echo.HeaderContentType),
+				Body:        rec.body.Bytes(),
+			}
+			if err := repo.Complete(ctx, owner, key, stored); err != nil {
+				log.Error(ctx, "failed to store idempotent response", "error", err)
+			}
+			return nil
+		}
+	}
+}
+
+// replay answers a request whose key another request has claimed.
+func replay(c echo.Context, existing *Record, hash string) error {
+	if existing.RequestHash != hash {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity,
+			"Idempotency-Key was already used for a different request")
+	}
+	if existing.Response == nil {
+		c.Response().Header().Set("Retry-After", "1")
+		return echo.NewHTTPError(http.StatusConflict, "A request with this Idempotency-Key is still in progress")
+	}
+	c.Response().Header().Set(HeaderReplayed, "true")
+	return c.Blob(existing.Response.Status, existing.Response.ContentType, existing.Response.Body)
+}
+
+// requestHash identifies the endpoint and body of a request, and the account
+// mode, so a sandbox request never replays a live response. The body is put
+// back for the handler.
+func requestHash(c echo.Context) (string, error) {
+	req := c.Request()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	if tenant.IsSandbox(req.Context()) {
+		h.Write([]byte("sandbox\n"))
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// recorder keeps a copy of the response body as it is written.
+type recorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// RunPurge deletes expired keys every interval until ctx is cancelled.
+func RunPurge(ctx context.Context, repo Repository, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := repo.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				log.Error(ctx, "idempotency key purge failed", "error", err)
+			} else if n > 0 {
+				log.Info(ctx, "idempotency keys purged", "count", n)
+			}
+		}
+	}
+}
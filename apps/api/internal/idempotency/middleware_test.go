@@ -0,0 +1,203 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestMiddleware(t *testing.T) {
+	const body = `{"project_id":"p1"}`
+	hashOf := func(path, body string) string {
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)), nil)
+		hash, err := requestHash(c)
+		require.NoError(t, err)
+		return hash
+	}
+	created := func(c echo.Context) error {
+		return c.JSON(http.StatusCreated, map[string]string{"id": "img-1"})
+	}
+
+	cases := []struct {
+		name         string
+		key          string
+		handler      echo.HandlerFunc
+		claimed      *Record
+		claimErr     error
+		wantStatus   int
+		wantBody     string
+		wantReplayed bool
+		wantRun      bool
+		wantComplete bool
+		wantRelease  bool
+	}{
+		{
+			name:       "success: no key runs the request",
+			handler:    created,
+			wantStatus: http.StatusCreated,
+			wantRun:    true,
+		},
+		{
+			name:         "success: first use stores the response",
+			key:          "key-1",
+			handler:      created,
+			wantStatus:   http.StatusCreated,
+			wantBody:     `{"id":"img-1"}`,
+			wantRun:      true,
+			wantComplete: true,
+		},
+		{
+			name:    "success: retry replays the stored response",
+			key:     "key-1",
+			handler: created,
+			claimed: &Record{
+				RequestHash: hashOf("/api/v1/images", body),
+				Response: &Response{
+					Status:      http.StatusCreated,
+					ContentType: echo.MIMEApplicationJSON,
+					Body:        []byte(`{"id":"img-1"}`),
+				},
+			},
+			wantStatus:   http.StatusCreated,
+			wantBody:     `{"id":"img-1"}`,
+			wantReplayed: true,
+		},
+		{
+			name: "success: failed request releases the key",
+			key:  "key-1",
+			handler: func(c echo.Context) error {
+				return c.JSON(http.StatusPaymentRequired, map[string]string{"error": "usage_limit_exceeded"})
+			},
+			wantStatus:  http.StatusPaymentRequired,
+			wantRun:     true,
+			wantRelease: true,
+		},
+		{
+			name: "success: handler error releases the key",
+			key:  "key-1",
+			handler: func(c echo.Context) error {
+				return echo.NewHTTPError(http.StatusBadRequest, "bad")
+			},
+			wantStatus:  http.StatusBadRequest,
+			wantRun:     true,
+			wantRelease: true,
+		},
+		{
+			name:       "fail: retry while the first request runs",
+			key:        "key-1",
+			handler:    created,
+			claimed:    &Record{RequestHash: hashOf("/api/v1/images", body)},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "fail: key reused with another body",
+			key:        "key-1",
+			handler:    created,
+			claimed:    &Record{RequestHash: hashOf("/api/v1/images", `{"project_id":"p2"}`)},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "fail: key reused on another endpoint",
+			key:        "key-1",
+			handler:    created,
+			claimed:    &Record{RequestHash: hashOf("/api/v1/billing/create-checkout", body)},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "fail: key too long",
+			key:        strings.Repeat("k", MaxKeyLength+1),
+			handler:    created,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "fail: store unavailable",
+			key:        "key-1",
+			handler:    created,
+			claimErr:   errors.New("db down"),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				ClaimFunc: func(ctx context.Context, owner, key, requestHash string) (*Record, error) {
+					assert.Equal(t, "auth0|testuser", owner)
+					assert.Equal(t, tc.key, key)
+					return tc.claimed, tc.claimErr
+				},
+				CompleteFunc: func(ctx context.Context, owner, key string, resp Response) error { return nil },
+				ReleaseFunc:  func(ctx context.Context, owner, key string) error { return nil },
+			}
+			ran := false
+			handler := func(c echo.Context) error {
+				ran = true
+				got, err := io.ReadAll(c.Request().Body)
+				require.NoError(t, err)
+				assert.Equal(t, body, string(got), "the handler still reads the body")
+				return tc.handler(c)
+			}
+
+			e := echo.New()
+			e.POST("/api/v1/images", handler, Middleware(repo, logging.Default()))
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/images", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			if tc.key != "" {
+				req.Header.Set(HeaderKey, tc.key)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantBody != "" {
+				assert.JSONEq(t, tc.wantBody, rec.Body.String())
+			}
+			assert.Equal(t, tc.wantReplayed, rec.Header().Get(HeaderReplayed) == "true")
+			assert.Equal(t, tc.wantRun, ran)
+			require.Equal(t, tc.wantComplete, len(repo.CompleteCalls()) == 1)
+			if tc.wantComplete {
+				stored := repo.CompleteCalls()[0].Resp
+				assert.Equal(t, http.StatusCreated, stored.Status)
+				assert.Equal(t, echo.MIMEApplicationJSON, stored.ContentType)
+				assert.JSONEq(t, `{"id":"img-1"}`, string(stored.Body))
+			}
+			assert.Equal(t, tc.wantRelease, len(repo.ReleaseCalls()) == 1)
+		})
+	}
+}
+
+func TestRunPurge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	purged := make(chan time.Time, 1)
+	repo := &RepositoryMock{
+		DeleteExpiredFunc: func(ctx context.Context, before time.Time) (int64, error) {
+			select {
+			case purged <- before:
+			default:
+			}
+			return 3, nil
+		},
+	}
+
+	go RunPurge(ctx, repo, time.Millisecond)
+
+	select {
+	case before := <-purged:
+		assert.WithinDuration(t, time.Now(), before, time.Second)
+	case <-time.After(time.Second):
+		t.Fatal("expired keys were not purged")
+	}
+}
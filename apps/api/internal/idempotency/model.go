@@ -0,0 +1,39 @@
+// Package idempotency lets clients retry POST requests that create jobs or
+// checkout sessions without doing the work twice. A request carrying an
+// Idempotency-Key header is run once per caller and key; retries get the
+// stored response back.
+package idempotency
+
+import "time"
+
+// Request and response headers.
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+)
+
+const (
+	// MaxKeyLength bounds the keys clients may send.
+	MaxKeyLength = 255
+	// TTL is how long a key is remembered after its first use.
+	TTL = 24 * time.Hour
+	// LockTimeout is how long a request holds its key. A key still held
+	// after that belongs to a request that never finished, as when the
+	// instance died, and is claimed by the next retry.
+	LockTimeout = time.Minute
+)
+
+// Record is the stored state of a key that another request has claimed.
+type Record struct {
+	// RequestHash identifies the endpoint and body the key was first used with.
+	RequestHash string
+	// Response is nil while the first request is still running.
+	Response *Response
+}
+
+// Response is a successful response kept for replay.
+type Response struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
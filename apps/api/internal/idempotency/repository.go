@@ -0,0 +1,27 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository stores idempotency keys per owner.
+type Repository interface {
+	// Claim takes the key for a request with the given hash. It returns nil
+	// when the key is now held by the caller, and the existing record when
+	// another request holds it or has completed it. Expired keys and keys
+	// held for longer than LockTimeout are claimed again.
+	Claim(ctx context.Context, owner, key, requestHash string) (*Record, error)
+
+	// Complete stores the response of the request holding the key.
+	Complete(ctx context.Context, owner, key string, resp Response) error
+
+	// Release forgets the key, so a retry runs the request again.
+	Release(ctx context.Context, owner, key string) error
+
+	// DeleteExpired removes keys that expired before the given time and
+	// returns how many were removed.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
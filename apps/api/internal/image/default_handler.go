@@ -2,65 +2,154 @@ package image
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 
 	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/batch"
+	"github.com/real-staging-ai/api/internal/brownout"
+	"github.com/real-staging-ai/api/internal/compliance"
+	"github.com/real-staging-ai/api/internal/i18n"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/pagination"
+	"github.com/real-staging-ai/api/internal/problem"
 	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/promptlib"
+	"github.com/real-staging-ai/api/internal/prompttemplate"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/internal/sse"
+	"github.com/real-staging-ai/api/internal/stylepreset"
 	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/modelconfig"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out usage_checker_mock.go . UsageChecker
+//go:generate go run github.com/matryer/moq@v0.5.3 -out prompt_screener_mock.go . PromptScreener
+//go:generate go run github.com/matryer/moq@v0.5.3 -out model_preferences_mock.go . ModelPreferences
+//go:generate go run github.com/matryer/moq@v0.5.3 -out prompt_templates_mock.go . PromptTemplates
+//go:generate go run github.com/matryer/moq@v0.5.3 -out style_presets_mock.go . StylePresets
 
-// UsageChecker provides methods to check if a user can create images.
+// UsageChecker provides methods to check if a user can create images, and
+// the plan their images are queued by.
 type UsageChecker interface {
 	CanCreateImage(ctx context.Context, userID string) (bool, error)
+	RemainingImages(ctx context.Context, userID string) (int32, error)
+	PlanCode(ctx context.Context, userID string) (string, error)
 }
 
+// PromptScreener checks custom prompts for fair-housing violations and queues
+// matches for admin review.
+type PromptScreener interface {
+	Screen(ctx context.Context, text string) compliance.Result
+	Record(ctx context.Context, review *compliance.Review) (*compliance.Review, error)
+}
+
+// ModelPreferences looks up the staging model a user prefers; "" means none.
+type ModelPreferences interface {
+	PreferredModel(ctx context.Context, userID string) (string, error)
+}
+
+// PromptTemplates renders a user's prompt templates for a room type and style.
+type PromptTemplates interface {
+	RenderTemplate(ctx context.Context, userID, id string, roomType, style *string) (string, error)
+}
+
+// StylePresets looks up the style presets admins define.
+type StylePresets interface {
+	GetPreset(ctx context.Context, id string) (*stylepreset.Preset, error)
+}
+
+// ValidModes lists the modes accepted by create requests.
+var ValidModes = []Mode{ModeStage, ModeDeclutter, ModeBoth}
+
 // DefaultHandler contains the HTTP handlers for image operations.
 type DefaultHandler struct {
 	service      Service
 	usageChecker UsageChecker
 	userRepo     user.Repository
 	projectRepo  project.Repository
+	screener     PromptScreener
+	batches      batch.Service
+	models       ModelPreferences
+	providers    brownout.Service
+	templates    PromptTemplates
+	presets      StylePresets
 }
 
-// NewDefaultHandler creates a new Handler instance.
+// NewDefaultHandler creates a new Handler instance. screener may be nil to skip
+// the fair-housing scan of custom prompts, batches may be nil to disable async
+// batch requests, models may be nil to ignore users' preferred models and
+// providers may be nil to never report images as delayed by a provider outage,
+// templates may be nil to reject requests that reference a prompt template and
+// presets may be nil to reject requests that reference a style preset.
 func NewDefaultHandler(
 	service Service,
 	usageChecker UsageChecker,
 	userRepo user.Repository,
 	projectRepo project.Repository,
+	screener PromptScreener,
+	batches batch.Service,
+	models ModelPreferences,
+	providers brownout.Service,
+	templates PromptTemplates,
+	presets StylePresets,
 ) *DefaultHandler {
 	return &DefaultHandler{
 		service:      service,
 		usageChecker: usageChecker,
 		userRepo:     userRepo,
 		projectRepo:  projectRepo,
+		screener:     screener,
+		batches:      batches,
+		models:       models,
+		providers:    providers,
+		templates:    templates,
+		presets:      presets,
 	}
 }
 
 // CreateImage handles POST /api/v1/images requests.
 func (h *DefaultHandler) CreateImage(c echo.Context) error {
+	ctx := c.Request().Context()
 	var req CreateImageRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request format"))
 	}
 
 	// Validate request
-	if validationErrs := h.validateCreateImageRequest(&req); len(validationErrs) > 0 {
-		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
-			Error:            "validation_failed",
-			Message:          "The provided data is invalid",
-			ValidationErrors: validationErrs,
-		})
+	if validationErrs := h.validateCreateImageRequest(ctx, &req); len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).With("validation_errors", validationErrs))
+	}
+
+	// Fill in a referenced preset or template, then screen the prompt before spending quota on it
+	presetField := func(int) string { return "preset_id" }
+	if validationErrs, p := h.applyPresets(c, []*CreateImageRequest{&req}, presetField); p != nil {
+		return problem.Send(c, p)
+	} else if len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).With("validation_errors", validationErrs))
+	}
+	templateField := func(int) string { return "template_id" }
+	if validationErrs, p := h.applyTemplates(c, []*CreateImageRequest{&req}, templateField); p != nil {
+		return problem.Send(c, p)
+	} else if len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).With("validation_errors", validationErrs))
+	}
+	screened, rejected := h.screenPrompts(c, []CreateImageRequest{req})
+	if rejected {
+		h.recordScreenings(c, screened, compliance.DecisionRejected, nil)
+		return problem.Send(c, promptViolation(ctx, screened, false))
 	}
 
 	// Check usage limits if usage checker is configured
@@ -71,67 +160,71 @@ func (h *DefaultHandler) CreateImage(c echo.Context) error {
 			// Get user from database
 			userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
 			if err == nil {
-				// Check if user can create image
-				canCreate, err := h.usageChecker.CanCreateImage(c.Request().Context(), userRow.ID.String())
+				// Check if whoever pays for the project can create an image
+				payerID := h.billingUserID(c.Request().Context(), req.ProjectID.String(), userRow.ID.String())
+				canCreate, err := h.usageChecker.CanCreateImage(c.Request().Context(), payerID)
 				if err == nil && !canCreate {
-					return c.JSON(http.StatusPaymentRequired, ErrorResponse{
-						Error:   "usage_limit_exceeded",
-						Message: "You have reached your monthly image limit. Please upgrade your plan to continue.",
-					})
+					return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+						i18n.T(ctx, "You have reached your monthly image limit. Please upgrade your plan to continue."))
 				}
 			}
 		}
 	}
 
 	// Create the image
-	img, err := h.service.CreateImage(c.Request().Context(), &req)
+	reqs := []CreateImageRequest{req}
+	h.resolveModels(c, reqs)
+	h.resolvePriorities(c, reqs)
+	if validationErrs := validateMasks(ctx, reqs, func(int) string { return "mask_url" }); len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).With("validation_errors", validationErrs))
+	}
+	img, err := h.service.CreateImage(c.Request().Context(), &reqs[0])
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to create image",
-		})
+		if errors.Is(err, ErrNoCredits) {
+			return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+				i18n.T(ctx, "You have reached your monthly image limit. Please upgrade your plan to continue."))
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to create image"))
 	}
+	h.recordScreenings(c, screened, compliance.DecisionFlagged, map[int]string{0: img.ID.String()})
 
+	if h.delayImage(ctx, img, reqs[0].ModelID) {
+		return c.JSON(http.StatusAccepted, img)
+	}
 	return c.JSON(http.StatusCreated, img)
 }
 
 // BatchCreateImages handles POST /api/v1/images/batch requests.
 func (h *DefaultHandler) BatchCreateImages(c echo.Context) error {
+	ctx := c.Request().Context()
 	var req BatchCreateImagesRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request format"))
 	}
 
 	// Validate batch request
 	if len(req.Images) == 0 {
-		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
-			Error:   "validation_failed",
-			Message: "At least one image is required",
-			ValidationErrors: []ValidationErrorDetail{{
-				Field:   "images",
-				Message: "images array cannot be empty",
-			}},
-		})
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "At least one image is required")).
+			With("validation_errors", []ValidationErrorDetail{
+				{Field: "images", Message: i18n.T(ctx, "images array cannot be empty")},
+			}))
 	}
 
 	if len(req.Images) > 50 {
-		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
-			Error:   "validation_failed",
-			Message: "Too many images",
-			ValidationErrors: []ValidationErrorDetail{{
-				Field:   "images",
-				Message: "maximum 50 images per batch request",
-			}},
-		})
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "Too many images")).
+			With("validation_errors", []ValidationErrorDetail{
+				{Field: "images", Message: i18n.T(ctx, "maximum 50 images per batch request")},
+			}))
 	}
 
 	// Validate each image request
 	var allValidationErrors []ValidationErrorDetail
 	for i, imgReq := range req.Images {
-		if errors := h.validateCreateImageRequest(&imgReq); len(errors) > 0 {
+		if errors := h.validateCreateImageRequest(ctx, &imgReq); len(errors) > 0 {
 			for _, err := range errors {
 				allValidationErrors = append(allValidationErrors, ValidationErrorDetail{
 					Field:   fmt.Sprintf("images[%d].%s", i, err.Field),
@@ -142,11 +235,34 @@ func (h *DefaultHandler) BatchCreateImages(c echo.Context) error {
 	}
 
 	if len(allValidationErrors) > 0 {
-		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
-			Error:            "validation_failed",
-			Message:          "One or more images have invalid data",
-			ValidationErrors: allValidationErrors,
-		})
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "One or more images have invalid data")).With("validation_errors", allValidationErrors))
+	}
+
+	reqPtrs := make([]*CreateImageRequest, len(req.Images))
+	for i := range req.Images {
+		reqPtrs[i] = &req.Images[i]
+	}
+	presetField := func(i int) string { return fmt.Sprintf("images[%d].preset_id", i) }
+	if validationErrs, p := h.applyPresets(c, reqPtrs, presetField); p != nil {
+		return problem.Send(c, p)
+	} else if len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "One or more images have invalid data")).With("validation_errors", validationErrs))
+	}
+	templateField := func(i int) string { return fmt.Sprintf("images[%d].template_id", i) }
+	if validationErrs, p := h.applyTemplates(c, reqPtrs, templateField); p != nil {
+		return problem.Send(c, p)
+	} else if len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "One or more images have invalid data")).With("validation_errors", validationErrs))
+	}
+
+	// Screen custom prompts; one rejected prompt rejects the whole batch
+	screened, rejected := h.screenPrompts(c, req.Images)
+	if rejected {
+		h.recordScreenings(c, screened, compliance.DecisionRejected, nil)
+		return problem.Send(c, promptViolation(ctx, screened, true))
 	}
 
 	// Check usage limits for batch if usage checker is configured
@@ -157,29 +273,54 @@ func (h *DefaultHandler) BatchCreateImages(c echo.Context) error {
 			// Get user from database
 			userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
 			if err == nil {
-				// Check if user can create image (checks overall limit)
-				canCreate, err := h.usageChecker.CanCreateImage(c.Request().Context(), userRow.ID.String())
-				if err == nil && !canCreate {
-					return c.JSON(http.StatusPaymentRequired, ErrorResponse{
-						Error:   "usage_limit_exceeded",
-						Message: "You have reached your monthly image limit. Please upgrade your plan to continue.",
-					})
+				// Check each paying user can create images (checks overall limit);
+				// shared projects in the batch may bill different organizations
+				checked := map[string]bool{}
+				for _, imgReq := range req.Images {
+					payerID := h.billingUserID(c.Request().Context(), imgReq.ProjectID.String(), userRow.ID.String())
+					if checked[payerID] {
+						continue
+					}
+					checked[payerID] = true
+					canCreate, err := h.usageChecker.CanCreateImage(c.Request().Context(), payerID)
+					if err == nil && !canCreate {
+						return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+							i18n.T(ctx, "You have reached your monthly image limit. Please upgrade your plan to continue."))
+					}
 				}
 			}
 		}
 	}
 
+	h.resolveModels(c, req.Images)
+	h.resolvePriorities(c, req.Images)
+	maskField := func(i int) string { return fmt.Sprintf("images[%d].mask_url", i) }
+	if validationErrs := validateMasks(ctx, req.Images, maskField); len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "One or more images have invalid data")).With("validation_errors", validationErrs))
+	}
+	if req.Async {
+		return h.startAsyncBatch(c, req.Images, screened)
+	}
+
 	// Create the images in batch
 	response, err := h.service.BatchCreateImages(c.Request().Context(), req.Images)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to create images",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to create images"))
+	}
+	h.recordScreenings(c, screened, compliance.DecisionFlagged, createdImageIDs(response, len(req.Images)))
+	delayed := false
+	for i, img := range createdImages(response, len(req.Images)) {
+		delayed = h.delayImage(ctx, img, req.Images[i].ModelID) || delayed
 	}
 
-	// Return 207 Multi-Status if partial success, 201 if all success
+	// Return 207 Multi-Status if partial success, 201 if all success (202 if
+	// a provider outage delays any of them)
 	statusCode := http.StatusCreated
+	if delayed {
+		statusCode = http.StatusAccepted
+	}
 	if response.Failed > 0 && response.Success > 0 {
 		statusCode = http.StatusMultiStatus
 	} else if response.Failed > 0 {
@@ -189,173 +330,1230 @@ func (h *DefaultHandler) BatchCreateImages(c echo.Context) error {
 	return c.JSON(statusCode, response)
 }
 
+// startAsyncBatch records a batch, returns 202 with it, and creates the images
+// in the background. Each image's outcome is recorded on its batch item.
+func (h *DefaultHandler) startAsyncBatch(c echo.Context, reqs []CreateImageRequest, screened []screenedPrompt) error {
+	ctx := c.Request().Context()
+	if h.batches == nil || h.userRepo == nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest,
+			i18n.T(ctx, "Async batches are not enabled"))
+	}
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Invalid or missing JWT token"))
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, i18n.T(ctx, "User not found"))
+	}
+	userID := userRow.ID.String()
+
+	projectIDs := make([]string, len(reqs))
+	for i, req := range reqs {
+		projectIDs[i] = req.ProjectID.String()
+	}
+	flagged := make(map[int]screenedPrompt, len(screened))
+	for _, sp := range screened {
+		flagged[sp.index] = sp
+	}
+
+	create := func(ctx context.Context, i int) (string, error) {
+		img, err := h.service.CreateImage(ctx, &reqs[i])
+		if err != nil {
+			return "", err
+		}
+		imageID := img.ID.String()
+		if sp, ok := flagged[i]; ok {
+			h.recordReviews(ctx, &userID, []screenedPrompt{sp}, compliance.DecisionFlagged, map[int]string{i: imageID})
+		}
+		return imageID, nil
+	}
+
+	b, err := h.batches.Start(c.Request().Context(), userID, projectIDs, create)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to start batch"))
+	}
+
+	statusURL := "/api/v1/batches/" + b.ID
+	c.Response().Header().Set(echo.HeaderLocation, statusURL)
+	return c.JSON(http.StatusAccepted, AsyncBatchResponse{Batch: b, StatusURL: statusURL})
+}
+
+// createdImages maps request indexes to the images a batch created. Images
+// come back in request order with failed indexes skipped.
+func createdImages(resp *BatchCreateImagesResponse, total int) map[int]*Image {
+	failed := make(map[int]bool, len(resp.Errors))
+	for _, e := range resp.Errors {
+		failed[e.Index] = true
+	}
+	imgs := make(map[int]*Image, len(resp.Images))
+	next := 0
+	for i := 0; i < total && next < len(resp.Images); i++ {
+		if failed[i] {
+			continue
+		}
+		imgs[i] = resp.Images[next]
+		next++
+	}
+	return imgs
+}
+
+// createdImageIDs maps request indexes to the IDs of the images a batch created.
+func createdImageIDs(resp *BatchCreateImagesResponse, total int) map[int]string {
+	ids := make(map[int]string, len(resp.Images))
+	for i, img := range createdImages(resp, total) {
+		ids[i] = img.ID.String()
+	}
+	return ids
+}
+
 // GetImage handles GET /api/v1/images/{id} requests.
 func (h *DefaultHandler) GetImage(c echo.Context) error {
+	ctx := c.Request().Context()
 	imageID := c.Param("id")
 	if imageID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Image ID is required",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Image ID is required"))
 	}
 
 	// Validate UUID format
 	if _, err := uuid.Parse(imageID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid image ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid image ID format"))
 	}
 
 	img, err := h.service.GetImageByID(c.Request().Context(), imageID)
 	if err != nil {
 		// Check if it's a not found error
 		if err.Error() == "no rows in result set" {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Image not found",
-			})
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found"))
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to get image",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, i18n.T(ctx, "Failed to get image"))
 	}
 
 	return c.JSON(http.StatusOK, img)
 }
 
+// RestageImage handles POST /api/v1/images/{id}/restage requests.
+// It queues a new variant of the image with a different style, prompt or seed,
+// reusing the stored original instead of requiring a new upload.
+func (h *DefaultHandler) RestageImage(c echo.Context) error {
+	ctx := c.Request().Context()
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid image ID format"))
+	}
+
+	var req RestageImageRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request format"))
+	}
+
+	userID, source, proj, errResp := h.restageSource(c, imageID)
+	if errResp != nil {
+		return problem.Send(c, errResp)
+	}
+	projectID := source.ProjectID.String()
+
+	createReq := CreateImageRequest{
+		ProjectID:   source.ProjectID,
+		OriginalURL: source.OriginalURL,
+		RoomType:    req.RoomType,
+		Style:       req.Style,
+		Seed:        req.Seed,
+		Prompt:      req.Prompt,
+		ModelID:     req.ModelID,
+		Mode:        source.Mode,
+	}
+	if validationErrs := h.validateCreateImageRequest(ctx, &createReq); len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).With("validation_errors", validationErrs))
+	}
+
+	// Only a new prompt is screened; an inherited one was screened when first submitted.
+	screened, rejected := h.screenPrompts(c, []CreateImageRequest{createReq})
+	if rejected {
+		h.recordScreenings(c, screened, compliance.DecisionRejected, nil)
+		return problem.Send(c, promptViolation(ctx, screened, false))
+	}
+
+	if h.usageChecker != nil {
+		payerID := h.billingUserID(c.Request().Context(), projectID, userID)
+		canCreate, err := h.usageChecker.CanCreateImage(c.Request().Context(), payerID)
+		if err == nil && !canCreate {
+			return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+				i18n.T(ctx, "You have reached your monthly image limit. Please upgrade your plan to continue."))
+		}
+	}
+
+	resolved := []CreateImageRequest{createReq}
+	h.resolveModels(c, resolved, proj)
+	h.resolvePriorities(c, resolved)
+	req.ModelID, req.Priority = resolved[0].ModelID, resolved[0].Priority
+	img, err := h.service.RestageImage(c.Request().Context(), imageID, &req)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found"))
+		}
+		if errors.Is(err, ErrNoCredits) {
+			return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+				i18n.T(ctx, "You have reached your monthly image limit. Please upgrade your plan to continue."))
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to restage image"))
+	}
+	h.recordScreenings(c, screened, compliance.DecisionFlagged, map[int]string{0: img.ID.String()})
+
+	if h.delayImage(ctx, img, req.ModelID) {
+		return c.JSON(http.StatusAccepted, img)
+	}
+	return c.JSON(http.StatusCreated, img)
+}
+
+// RerollImage handles POST /api/v1/images/{id}/reroll requests. It stages the
+// image again as a new variant with the same room type, style, prompt and
+// model, changing only the seed: the one given, or a random one.
+func (h *DefaultHandler) RerollImage(c echo.Context) error {
+	ctx := c.Request().Context()
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid image ID format"))
+	}
+
+	var req RerollImageRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request format"))
+	}
+
+	userID, source, proj, errResp := h.restageSource(c, imageID)
+	if errResp != nil {
+		return problem.Send(c, errResp)
+	}
+	projectID := source.ProjectID.String()
+
+	createReq := CreateImageRequest{
+		ProjectID:   source.ProjectID,
+		OriginalURL: source.OriginalURL,
+		Seed:        req.Seed,
+	}
+	if validationErrs := h.validateCreateImageRequest(ctx, &createReq); len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).With("validation_errors", validationErrs))
+	}
+
+	if h.usageChecker != nil {
+		payerID := h.billingUserID(ctx, projectID, userID)
+		canCreate, err := h.usageChecker.CanCreateImage(ctx, payerID)
+		if err == nil && !canCreate {
+			return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+				i18n.T(ctx, "You have reached your monthly image limit. Please upgrade your plan to continue."))
+		}
+	}
+
+	// Keep the model that staged the source; one that never ran gets the
+	// model a restage would.
+	resolved := []CreateImageRequest{createReq}
+	h.resolvePriorities(c, resolved)
+	req.ModelID, req.Priority = stagedWith(source), resolved[0].Priority
+	if req.ModelID == nil {
+		h.resolveModels(c, resolved, proj)
+		req.ModelID = resolved[0].ModelID
+	}
+	img, err := h.service.RerollImage(ctx, imageID, &req)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found"))
+		}
+		if errors.Is(err, ErrNoCredits) {
+			return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+				i18n.T(ctx, "You have reached your monthly image limit. Please upgrade your plan to continue."))
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to reroll image"))
+	}
+
+	if h.delayImage(ctx, img, req.ModelID) {
+		return c.JSON(http.StatusAccepted, img)
+	}
+	return c.JSON(http.StatusCreated, img)
+}
+
+// restageSource loads the image a restage or reroll starts from, with the
+// caller's user ID and the image's project, or the problem to send. Images in
+// projects the caller can't access are reported as missing.
+func (h *DefaultHandler) restageSource(
+	c echo.Context, imageID string,
+) (string, *Image, *project.Project, *problem.Problem) {
+	ctx := c.Request().Context()
+	userID, errResp := h.callerID(c)
+	if errResp != nil {
+		return "", nil, nil, errResp
+	}
+
+	notFound := problem.New(http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found"))
+
+	source, err := h.service.GetImageByID(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil, nil, notFound
+		}
+		return "", nil, nil, problem.New(http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to get image"))
+	}
+
+	proj, err := h.projectRepo.GetProjectByIDAndUserID(ctx, source.ProjectID.String(), userID)
+	if err != nil {
+		return "", nil, nil, notFound
+	}
+	if source.Status == StatusRejected {
+		return "", nil, nil, problem.New(http.StatusConflict, "image_rejected",
+			i18n.T(ctx, "The image's original was quarantined by the malware scanner"))
+	}
+	return userID, source, proj, nil
+}
+
+// stagedWith returns the model that staged img, without the version it ran,
+// or nil if no model has run it.
+func stagedWith(img *Image) *string {
+	if img.ModelUsed == nil || *img.ModelUsed == "" {
+		return nil
+	}
+	modelID, _, _ := strings.Cut(*img.ModelUsed, ":")
+	return &modelID
+}
+
 // GetProjectImages handles GET /api/v1/projects/{project_id}/images requests.
 func (h *DefaultHandler) GetProjectImages(c echo.Context) error {
+	ctx := c.Request().Context()
 	projectID := c.Param("project_id")
 	if projectID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Project ID is required",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Project ID is required"))
 	}
 
 	// Validate UUID format
 	if _, err := uuid.Parse(projectID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid project ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid project ID format"))
 	}
 
-	images, err := h.service.GetImagesByProjectID(c.Request().Context(), projectID)
+	page, err := pagination.FromRequest(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to get images",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeInvalidCursor, i18n.T(ctx, "Invalid cursor"))
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"images": images,
-	})
+	response, err := h.service.GetImagesByProjectID(c.Request().Context(), projectID, page)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, i18n.T(ctx, "Failed to get images"))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// maxSearchLength bounds the q parameter of an image search.
+const maxSearchLength = 200
+
+// SearchImages handles GET /api/v1/images/search requests. The q parameter is
+// matched against the prompt, room type, style and model of the caller's
+// images across projects; "seed 42" in it matches the seed exactly.
+func (h *DefaultHandler) SearchImages(c echo.Context) error {
+	ctx := c.Request().Context()
+	q := strings.TrimSpace(c.QueryParam("q"))
+	if q == "" {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Search query is required"))
+	}
+	if len([]rune(q)) > maxSearchLength {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest,
+			i18n.T(ctx, "Search query must be at most %d characters", maxSearchLength))
+	}
+
+	page, err := pagination.FromRequest(c)
+	if err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeInvalidCursor, i18n.T(ctx, "Invalid cursor"))
+	}
+
+	userID, errResp := h.callerID(c)
+	if errResp != nil {
+		return problem.Send(c, errResp)
+	}
+
+	response, err := h.service.SearchImages(ctx, userID, ParseSearchQuery(q), page)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to search images"))
+	}
+
+	return c.JSON(http.StatusOK, response)
 }
 
 // GetGroupedProjectImages handles GET /api/v1/projects/{project_id}/images/grouped requests.
 func (h *DefaultHandler) GetGroupedProjectImages(c echo.Context) error {
+	ctx := c.Request().Context()
 	projectID := c.Param("project_id")
 	if projectID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Project ID is required",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Project ID is required"))
 	}
 
 	// Validate UUID format
 	if _, err := uuid.Parse(projectID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid project ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid project ID format"))
 	}
 
 	// Verify user owns the project
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Invalid or missing JWT token"))
 	}
 
 	user, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User not found",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, i18n.T(ctx, "User not found"))
 	}
 
 	// Verify project belongs to user by attempting to fetch it
 	_, err = h.projectRepo.GetProjectByIDAndUserID(c.Request().Context(), projectID, user.ID.String())
 	if err != nil {
-		return c.JSON(http.StatusForbidden, ErrorResponse{
-			Error:   "forbidden",
-			Message: "Project not found or access denied",
-		})
+		return problem.Write(c, http.StatusForbidden, problem.CodeForbidden,
+			i18n.T(ctx, "Project not found or access denied"))
 	}
 
-	response, err := h.service.GetGroupedProjectImages(c.Request().Context(), projectID)
+	page, err := pagination.FromRequest(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to get grouped images",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeInvalidCursor, i18n.T(ctx, "Invalid cursor"))
+	}
+
+	response, err := h.service.GetGroupedProjectImages(c.Request().Context(), projectID, page)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to get grouped images"))
 	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// maxRestyleImages bounds a single project restyle so its progress fits in one batch SSE stream.
+const maxRestyleImages = sse.MaxStreamImages
+
+// RestyleProject handles POST /api/v1/projects/{project_id}/restyle requests.
+// It creates a new variant in the requested style for every ready image in the project,
+// keeping room types and seeds, after checking the whole batch against the user's quota.
+func (h *DefaultHandler) RestyleProject(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectID := c.Param("project_id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid project ID format"))
+	}
+
+	var req RestyleProjectRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request format"))
+	}
+	if !promptlib.IsStyle(req.Style) {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).
+			With("validation_errors", []ValidationErrorDetail{{
+				Field:   "style",
+				Message: i18n.T(ctx, "style must be one of: %s", strings.Join(promptlib.Styles(), ", ")),
+			}}))
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Invalid or missing JWT token"))
+	}
+
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, i18n.T(ctx, "User not found"))
+	}
+
+	proj, err := h.projectRepo.GetProjectByIDAndUserID(c.Request().Context(), projectID, userRow.ID.String())
+	if err != nil {
+		return problem.Write(c, http.StatusForbidden, problem.CodeForbidden,
+			i18n.T(ctx, "Project not found or access denied"))
+	}
+	if proj.Locked {
+		return problem.Write(c, http.StatusConflict, "project_locked",
+			i18n.T(ctx, "Project is locked; unlock it before restyling"))
+	}
+
+	reqs, skipped, err := h.service.PlanProjectRestyle(c.Request().Context(), projectID, req.Style)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to plan project restyle"))
+	}
+
+	response := &RestyleProjectResponse{
+		ProjectID: uuid.MustParse(projectID),
+		Style:     req.Style,
+		Images:    []*Image{},
+		Skipped:   skipped,
+	}
+	if len(reqs) == 0 {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	if len(reqs) > maxRestyleImages {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "Too many images")).
+			With("validation_errors", []ValidationErrorDetail{{
+				Field:   "project_id",
+				Message: i18n.T(ctx, "maximum %d images per restyle, project needs %d", maxRestyleImages, len(reqs)),
+			}}))
+	}
+
+	// Check the whole batch against the quota up front so a restyle never stops halfway.
+	if h.usageChecker != nil {
+		payerID := h.billingUserID(c.Request().Context(), projectID, userRow.ID.String())
+		remaining, err := h.usageChecker.RemainingImages(c.Request().Context(), payerID)
+		if err == nil && int(remaining) < len(reqs) {
+			return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+				i18n.T(ctx, "Restyling this project needs %d images but only %d remain this month. "+
+					"Please upgrade your plan to continue.", len(reqs), remaining))
+		}
+	}
+
+	h.resolveModels(c, reqs, proj)
+	h.resolvePriorities(c, reqs)
+	created, err := h.service.BatchCreateImages(c.Request().Context(), reqs)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to create restyled images"))
+	}
+
+	ids := make([]string, len(created.Images))
+	for i, img := range created.Images {
+		ids[i] = img.ID.String()
+	}
+	for i, img := range createdImages(created, len(reqs)) {
+		h.delayImage(ctx, img, reqs[i].ModelID)
+	}
+	response.Images = created.Images
+	response.EventsURL = "/api/v1/events?image_ids=" + strings.Join(ids, ",")
+
+	return c.JSON(http.StatusAccepted, response)
+}
+
+// maxProjectNameLength matches the project name validation.
+const maxProjectNameLength = 100
+
+// DuplicateProject handles POST /api/v1/projects/{project_id}/duplicate requests.
+// It creates a copy of the project owned by the caller with the same settings and,
+// unless include_images is false, re-stages the project's ready images into it. The
+// whole batch is checked against the quota before the copy is created.
+func (h *DefaultHandler) DuplicateProject(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectID := c.Param("project_id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid project ID format"))
+	}
+
+	var req DuplicateProjectRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request format"))
+	}
+	var validationErrs []ValidationErrorDetail
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxProjectNameLength {
+			validationErrs = append(validationErrs, ValidationErrorDetail{
+				Field:   "name",
+				Message: i18n.T(ctx, "name must be between 1 and 100 characters"),
+			})
+		}
+		req.Name = &name
+	}
+	if req.Style != nil && !promptlib.IsStyle(*req.Style) {
+		validationErrs = append(validationErrs, ValidationErrorDetail{
+			Field:   "style",
+			Message: i18n.T(ctx, "style must be one of: %s", strings.Join(promptlib.Styles(), ", ")),
+		})
+	}
+	includeImages := req.IncludeImages == nil || *req.IncludeImages
+	if req.Style != nil && !includeImages {
+		validationErrs = append(validationErrs, ValidationErrorDetail{
+			Field:   "style",
+			Message: i18n.T(ctx, "style requires include_images"),
+		})
+	}
+	if len(validationErrs) > 0 {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "The provided data is invalid")).With("validation_errors", validationErrs))
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Invalid or missing JWT token"))
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, i18n.T(ctx, "User not found"))
+	}
+	userID := userRow.ID.String()
+
+	source, err := h.projectRepo.GetProjectByIDAndUserID(ctx, projectID, userID)
+	if err != nil {
+		return problem.Write(c, http.StatusForbidden, problem.CodeForbidden,
+			i18n.T(ctx, "Project not found or access denied"))
+	}
+
+	var reqs []CreateImageRequest
+	skipped := 0
+	if includeImages {
+		reqs, skipped, err = h.service.PlanProjectDuplicate(ctx, projectID, req.Style)
+		if err != nil {
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to plan project duplication"))
+		}
+	}
+	if len(reqs) > maxRestyleImages {
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			i18n.T(ctx, "Too many images")).
+			With("validation_errors", []ValidationErrorDetail{{
+				Field:   "project_id",
+				Message: i18n.T(ctx, "maximum %d images per duplicate, project needs %d", maxRestyleImages, len(reqs)),
+			}}))
+	}
+	// The copy is a personal project, so the caller pays for its images.
+	if len(reqs) > 0 && h.usageChecker != nil {
+		remaining, err := h.usageChecker.RemainingImages(ctx, userID)
+		if err == nil && int(remaining) < len(reqs) {
+			return problem.Write(c, http.StatusPaymentRequired, "usage_limit_exceeded",
+				i18n.T(ctx, "Duplicating this project needs %d images but only %d remain this month. "+
+					"Please upgrade your plan to continue.", len(reqs), remaining))
+		}
+	}
+
+	copied, err := h.copyProject(ctx, source, userID, duplicateName(source.Name, req.Name))
+	if err != nil {
+		logging.Default().Error(ctx, "duplicate project: copy failed", "project_id", projectID, "error", err)
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to duplicate project"))
+	}
+
+	response := &DuplicateProjectResponse{Project: copied, Images: []*Image{}, Skipped: skipped}
+	if len(reqs) == 0 {
+		return c.JSON(http.StatusCreated, response)
+	}
+
+	copyID := uuid.MustParse(copied.ID)
+	for i := range reqs {
+		reqs[i].ProjectID = copyID
+	}
+	h.resolveModels(c, reqs, copied)
+	h.resolvePriorities(c, reqs)
+	created, err := h.service.BatchCreateImages(ctx, reqs)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to create duplicated images"))
+	}
+
+	ids := make([]string, len(created.Images))
+	for i, img := range created.Images {
+		ids[i] = img.ID.String()
+	}
+	for i, img := range createdImages(created, len(reqs)) {
+		h.delayImage(ctx, img, reqs[i].ModelID)
+	}
+	response.Images = created.Images
+	response.EventsURL = "/api/v1/events?image_ids=" + strings.Join(ids, ",")
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// copyProject creates a project for userID carrying source's watermark, model and
+// locale. A copy whose settings can't be applied is removed again.
+func (h *DefaultHandler) copyProject(
+	ctx context.Context, source *project.Project, userID, name string,
+) (*project.Project, error) {
+	copied, err := h.projectRepo.CreateProject(ctx, &project.Project{Name: name}, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !source.Watermark && source.ModelID == nil && source.Locale == nil {
+		return copied, nil
+	}
+
+	watermark := source.Watermark
+	updated, err := h.projectRepo.UpdateProjectByUserID(
+		ctx, copied.ID, userID, name, &watermark, source.ModelID, source.Locale,
+	)
+	if err != nil {
+		if delErr := h.projectRepo.DeleteProjectByUserID(ctx, copied.ID, userID); delErr != nil {
+			logging.Default().Warn(ctx, "duplicate project: failed to remove incomplete copy",
+				"project_id", copied.ID, "error", delErr)
+		}
+		return nil, fmt.Errorf("failed to copy project settings: %w", err)
+	}
+	return updated, nil
+}
+
+// duplicateName returns the requested name, or the source name marked as a copy
+// and cut to the project name limit.
+func duplicateName(source string, requested *string) string {
+	if requested != nil {
+		return *requested
+	}
+	name := []rune(source + " (copy)")
+	if len(name) > maxProjectNameLength {
+		name = append(name[:maxProjectNameLength-len(" (copy)")], []rune(" (copy)")...)
+	}
+	return string(name)
+}
+
 // DeleteImage handles DELETE /api/v1/images/{id} requests.
 func (h *DefaultHandler) DeleteImage(c echo.Context) error {
+	ctx := c.Request().Context()
 	imageID := c.Param("id")
 	if imageID == "" {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Image ID is required",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Image ID is required"))
 	}
 
 	// Validate UUID format
 	if _, err := uuid.Parse(imageID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid image ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid image ID format"))
 	}
 
 	err := h.service.DeleteImage(c.Request().Context(), imageID)
 	if err != nil {
 		// Check if it's a not found error
 		if err.Error() == "no rows in result set" {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Image not found",
-			})
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found"))
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to delete image",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to delete image"))
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
-// validateCreateImageRequest validates the create image request.
-func (h *DefaultHandler) validateCreateImageRequest(req *CreateImageRequest) []ValidationErrorDetail {
+// ListTrash handles GET /api/v1/projects/{id}/trash requests. It lists the
+// project's deleted images that can still be restored.
+func (h *DefaultHandler) ListTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectID := c.Param("id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid project ID format"))
+	}
+
+	userID, errResp := h.callerID(c)
+	if errResp != nil {
+		return problem.Send(c, errResp)
+	}
+
+	if _, err := h.projectRepo.GetProjectByIDAndUserID(ctx, projectID, userID); err != nil {
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Project not found"))
+	}
+
+	images, err := h.service.ListTrash(ctx, projectID)
+	if err != nil {
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to list deleted images"))
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"images": images,
+	})
+}
+
+// RestoreImage handles POST /api/v1/images/{id}/restore requests. It takes a
+// deleted image back out of the trash.
+func (h *DefaultHandler) RestoreImage(c echo.Context) error {
+	ctx := c.Request().Context()
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid image ID format"))
+	}
+
+	userID, errResp := h.callerID(c)
+	if errResp != nil {
+		return problem.Send(c, errResp)
+	}
+
+	notFound := problem.New(http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found in trash"))
+
+	deleted, err := h.service.GetDeletedImageByID(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Send(c, notFound)
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to restore image"))
+	}
+
+	// Images in projects the caller can't access are reported as missing.
+	if _, err := h.projectRepo.GetProjectByIDAndUserID(ctx, deleted.ProjectID.String(), userID); err != nil {
+		return problem.Send(c, notFound)
+	}
+
+	restored, err := h.service.RestoreImage(ctx, imageID)
+	if err != nil {
+		// Restored or purged since the lookup.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Send(c, notFound)
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to restore image"))
+	}
+
+	return c.JSON(http.StatusOK, restored)
+}
+
+// SetImageApproval handles PUT /api/v1/images/{id}/approval requests. The
+// owner approves or rejects a ready image's staged result, or clears their
+// verdict with null.
+func (h *DefaultHandler) SetImageApproval(c echo.Context) error {
+	ctx := c.Request().Context()
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid image ID format"))
+	}
+
+	var req ImageApproval
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid request format"))
+	}
+
+	userID, errResp := h.callerID(c)
+	if errResp != nil {
+		return problem.Send(c, errResp)
+	}
+
+	notFound := problem.New(http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found"))
+
+	img, err := h.service.GetImageByID(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Send(c, notFound)
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, i18n.T(ctx, "Failed to get image"))
+	}
+
+	// Images in projects the caller can't access are reported as missing.
+	if _, err := h.projectRepo.GetProjectByIDAndUserID(ctx, img.ProjectID.String(), userID); err != nil {
+		return problem.Send(c, notFound)
+	}
+	if img.Status != StatusReady {
+		return problem.Write(c, http.StatusConflict, "image_not_ready",
+			i18n.T(ctx, "Only staged images can be approved or rejected"))
+	}
+
+	if err := h.service.SetImageApproval(ctx, imageID, req.Approved); err != nil {
+		// Deleted since the lookup.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Send(c, notFound)
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to save approval"))
+	}
+
+	return c.JSON(http.StatusOK, ImageApproval{ImageID: img.ID, Approved: req.Approved})
+}
+
+// CancelJob handles DELETE /api/v1/images/{id}/job requests. The owner stops
+// the staging of a queued or processing image, which is set to canceled.
+func (h *DefaultHandler) CancelJob(c echo.Context) error {
+	ctx := c.Request().Context()
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid image ID format"))
+	}
+
+	userID, errResp := h.callerID(c)
+	if errResp != nil {
+		return problem.Send(c, errResp)
+	}
+
+	notFound := problem.New(http.StatusNotFound, problem.CodeNotFound, i18n.T(ctx, "Image not found"))
+
+	img, err := h.service.GetImageByID(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Send(c, notFound)
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, i18n.T(ctx, "Failed to get image"))
+	}
+
+	// Images in projects the caller can't access are reported as missing.
+	if _, err := h.projectRepo.GetProjectByIDAndUserID(ctx, img.ProjectID.String(), userID); err != nil {
+		return problem.Send(c, notFound)
+	}
+
+	jobFinished := problem.New(http.StatusConflict, "job_finished",
+		i18n.T(ctx, "Only queued or processing images can be canceled"))
+	if img.Status != StatusQueued && img.Status != StatusProcessing {
+		return problem.Send(c, jobFinished)
+	}
+
+	canceled, err := h.service.CancelJob(ctx, imageID)
+	if err != nil {
+		// Finished or deleted since the lookup.
+		if errors.Is(err, ErrJobFinished) {
+			return problem.Send(c, jobFinished)
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to cancel job"))
+	}
+
+	return c.JSON(http.StatusOK, canceled)
+}
+
+// callerID resolves the authenticated caller's user ID, or the 401 problem to
+// send.
+func (h *DefaultHandler) callerID(c echo.Context) (string, *problem.Problem) {
+	ctx := c.Request().Context()
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized,
+			i18n.T(ctx, "Invalid or missing JWT token"))
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, i18n.T(ctx, "User not found"))
+	}
+	return userRow.ID.String(), nil
+}
+
+// billingUserID returns whose quota images in the project count against: the
+// organization's billing user for shared projects, otherwise the caller.
+func (h *DefaultHandler) billingUserID(ctx context.Context, projectID, callerID string) string {
+	if h.projectRepo == nil {
+		return callerID
+	}
+	payerID, err := h.projectRepo.GetBillingUserID(ctx, projectID)
+	if err != nil {
+		return callerID
+	}
+	return payerID
+}
+
+// resolveModels sets ModelID on the requests that don't name a model: the
+// project's model wins over the caller's preferred one. Requests left without
+// a model, including when a lookup fails, get the global active model. Projects
+// the caller already loaded are passed as known to save the lookup.
+func (h *DefaultHandler) resolveModels(c echo.Context, reqs []CreateImageRequest, known ...*project.Project) {
+	ctx := c.Request().Context()
+	log := logging.Default()
+	projectModels := map[uuid.UUID]*string{}
+	for _, p := range known {
+		if id, err := uuid.Parse(p.ID); err == nil {
+			projectModels[id] = p.ModelID
+		}
+	}
+	var preferred *string
+	preferenceLoaded := false
+
+	for i := range reqs {
+		if reqs[i].ModelID != nil {
+			continue
+		}
+		projectID := reqs[i].ProjectID
+		model, ok := projectModels[projectID]
+		if !ok && h.projectRepo != nil {
+			p, err := h.projectRepo.GetProjectByID(ctx, projectID.String())
+			if err != nil {
+				log.Warn(ctx, "failed to look up project model", "project_id", projectID.String(), "error", err)
+			} else {
+				model = p.ModelID
+			}
+			projectModels[projectID] = model
+		}
+		if model == nil {
+			if !preferenceLoaded {
+				preferred = h.preferredModel(c)
+				preferenceLoaded = true
+			}
+			model = preferred
+		}
+		reqs[i].ModelID = model
+	}
+}
+
+// resolvePriorities sets the queue priority of each request from the plan of
+// whoever pays for its project (see queue.PriorityForPlan), looking each payer
+// up once. Without a usage checker, or when the caller can't be identified,
+// requests keep no priority; a failed plan lookup gives the default one.
+func (h *DefaultHandler) resolvePriorities(c echo.Context, reqs []CreateImageRequest) {
+	if h.usageChecker == nil || h.userRepo == nil {
+		return
+	}
+	ctx := c.Request().Context()
+	callerID, errResp := h.callerID(c)
+	if errResp != nil {
+		return
+	}
+
+	priorities := map[string]queue.Priority{}
+	for i := range reqs {
+		payerID := h.billingUserID(ctx, reqs[i].ProjectID.String(), callerID)
+		priority, ok := priorities[payerID]
+		if !ok {
+			plan, err := h.usageChecker.PlanCode(ctx, payerID)
+			if err != nil {
+				logging.Default().Warn(ctx, "resolve priority: plan lookup failed", "user_id", payerID, "error", err)
+			}
+			priority = queue.PriorityForPlan(plan)
+			priorities[payerID] = priority
+		}
+		reqs[i].Priority = priority
+	}
+}
+
+// delayImage sets Delay on a newly queued img when a provider outage holds up
+// its model, and reports whether it did. Sandbox images never wait on a
+// provider.
+func (h *DefaultHandler) delayImage(ctx context.Context, img *Image, modelID *string) bool {
+	if h.providers == nil || img.Sandbox {
+		return false
+	}
+	img.Delay = h.providers.DelayFor(ctx, modelID)
+	return img.Delay != nil
+}
+
+// preferredModel returns the caller's preferred model, or nil.
+func (h *DefaultHandler) preferredModel(c echo.Context) *string {
+	if h.models == nil || h.userRepo == nil {
+		return nil
+	}
+	ctx := c.Request().Context()
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil || auth0Sub == "" {
+		return nil
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return nil
+	}
+	model, err := h.models.PreferredModel(ctx, userRow.ID.String())
+	if err != nil {
+		logging.Default().Warn(ctx, "failed to look up preferred model", "user_id", userRow.ID.String(), "error", err)
+		return nil
+	}
+	if model == "" {
+		return nil
+	}
+	return &model
+}
+
+// applyTemplates sets the prompt of each request that references a prompt
+// template to what the caller's template renders for the request's room type
+// and style. Templates that don't exist or aren't the caller's are reported as
+// validation errors on field(i), where i is the request's index.
+func (h *DefaultHandler) applyTemplates(
+	c echo.Context, reqs []*CreateImageRequest, field func(int) string,
+) ([]ValidationErrorDetail, *problem.Problem) {
+	ctx := c.Request().Context()
+	var validationErrs []ValidationErrorDetail
+	userID := ""
+	for i, req := range reqs {
+		if req.TemplateID == nil {
+			continue
+		}
+		if h.templates == nil || h.userRepo == nil {
+			validationErrs = append(validationErrs, ValidationErrorDetail{
+				Field: field(i), Message: i18n.T(ctx, "prompt templates are not enabled"),
+			})
+			continue
+		}
+		if userID == "" {
+			auth0Sub, err := auth.GetUserIDOrDefault(c)
+			if err != nil {
+				return nil, problem.New(http.StatusUnauthorized, problem.CodeUnauthorized,
+					i18n.T(ctx, "Invalid or missing JWT token"))
+			}
+			userRow, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub)
+			if err != nil {
+				return nil, problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, i18n.T(ctx, "User not found"))
+			}
+			userID = userRow.ID.String()
+		}
+
+		prompt, err := h.templates.RenderTemplate(ctx, userID, *req.TemplateID, req.RoomType, req.Style)
+		if errors.Is(err, prompttemplate.ErrTemplateNotFound) {
+			validationErrs = append(validationErrs, ValidationErrorDetail{
+				Field: field(i), Message: i18n.T(ctx, "template_id does not match any of your prompt templates"),
+			})
+			continue
+		}
+		if err != nil {
+			logging.Default().Error(ctx, "failed to render prompt template",
+				"template_id", *req.TemplateID, "error", err)
+			return nil, problem.New(http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to load prompt template"))
+		}
+		req.Prompt = &prompt
+	}
+	return validationErrs, nil
+}
+
+// applyPresets sets the model, config overrides and, when the preset has a
+// template, the prompt of each request that references a style preset. The
+// template is rendered for the request's room type and style like a prompt
+// template; declutter requests take no prompt, so theirs is left unset.
+// Presets that don't exist or whose model is no longer available are reported
+// as validation errors on field(i), where i is the request's index.
+func (h *DefaultHandler) applyPresets(
+	c echo.Context, reqs []*CreateImageRequest, field func(int) string,
+) ([]ValidationErrorDetail, *problem.Problem) {
+	ctx := c.Request().Context()
+	var validationErrs []ValidationErrorDetail
+	for i, req := range reqs {
+		if req.PresetID == nil {
+			continue
+		}
+		if h.presets == nil {
+			validationErrs = append(validationErrs, ValidationErrorDetail{
+				Field: field(i), Message: i18n.T(ctx, "style presets are not enabled"),
+			})
+			continue
+		}
+
+		preset, err := h.presets.GetPreset(ctx, *req.PresetID)
+		if errors.Is(err, stylepreset.ErrPresetNotFound) {
+			validationErrs = append(validationErrs, ValidationErrorDetail{
+				Field: field(i), Message: i18n.T(ctx, "preset_id does not match any style preset"),
+			})
+			continue
+		}
+		if err != nil {
+			logging.Default().Error(ctx, "failed to load style preset", "preset_id", *req.PresetID, "error", err)
+			return nil, problem.New(http.StatusInternalServerError, problem.CodeInternal,
+				i18n.T(ctx, "Failed to load style preset"))
+		}
+		if !settings.IsAvailableModel(preset.ModelID) {
+			validationErrs = append(validationErrs, ValidationErrorDetail{
+				Field: field(i), Message: i18n.T(ctx, "the style preset's model is not available"),
+			})
+			continue
+		}
+
+		modelID := preset.ModelID
+		req.ModelID = &modelID
+		if len(preset.ConfigOverrides) > 0 {
+			req.ModelConfig = preset.ConfigOverrides
+		}
+		if req.Mode != ModeDeclutter {
+			req.Prompt = preset.Render(req.RoomType, req.Style)
+		}
+	}
+	return validationErrs, nil
+}
+
+// screenedPrompt is a request prompt that matched at least one fair-housing rule.
+type screenedPrompt struct {
+	index  int
+	req    CreateImageRequest
+	result compliance.Result
+}
+
+// screenPrompts scans each request's custom prompt and returns the ones that
+// matched a rule, and whether any match must be rejected.
+func (h *DefaultHandler) screenPrompts(c echo.Context, reqs []CreateImageRequest) ([]screenedPrompt, bool) {
+	if h.screener == nil {
+		return nil, false
+	}
+	var screened []screenedPrompt
+	rejected := false
+	for i, req := range reqs {
+		if req.Prompt == nil {
+			continue
+		}
+		result := h.screener.Screen(c.Request().Context(), *req.Prompt)
+		if !result.Flagged() {
+			continue
+		}
+		screened = append(screened, screenedPrompt{index: i, req: req, result: result})
+		rejected = rejected || result.Rejected()
+	}
+	return screened, rejected
+}
+
+// recordScreenings queues screened prompts for admin review. imageIDs maps
+// request indexes to created images and is nil when the request was rejected.
+// Failures are logged; they never change the response.
+func (h *DefaultHandler) recordScreenings(
+	c echo.Context, screened []screenedPrompt, decision compliance.Decision, imageIDs map[int]string,
+) {
+	if len(screened) == 0 {
+		return
+	}
+	ctx := c.Request().Context()
+
+	var userID *string
+	if h.userRepo != nil {
+		if auth0Sub, err := auth.GetUserIDOrDefault(c); err == nil && auth0Sub != "" {
+			if userRow, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub); err == nil {
+				id := userRow.ID.String()
+				userID = &id
+			}
+		}
+	}
+
+	h.recordReviews(ctx, userID, screened, decision, imageIDs)
+}
+
+// recordReviews records screened prompts for userID, linking each to its
+// created image when there is one.
+func (h *DefaultHandler) recordReviews(
+	ctx context.Context,
+	userID *string,
+	screened []screenedPrompt,
+	decision compliance.Decision,
+	imageIDs map[int]string,
+) {
+	log := logging.NewDefaultLogger()
+	for _, sp := range screened {
+		projectID := sp.req.ProjectID.String()
+		review := &compliance.Review{
+			UserID:     userID,
+			ProjectID:  &projectID,
+			Prompt:     *sp.req.Prompt,
+			Decision:   decision,
+			Violations: sp.result.Violations,
+		}
+		if imageID, ok := imageIDs[sp.index]; ok {
+			review.ImageID = &imageID
+		}
+		if _, err := h.screener.Record(ctx, review); err != nil {
+			log.Error(ctx, "failed to record prompt review", "error", err, "project_id", projectID)
+		}
+	}
+}
+
+// promptViolation is the 422 problem listing the rejected prompts' violations.
+// Batch fields are prefixed with the image index, matching validation errors.
+func promptViolation(
+	ctx context.Context, screened []screenedPrompt, batched bool,
+) *problem.Problem {
+	violations := []PromptViolation{}
+	for _, sp := range screened {
+		field := "prompt"
+		if batched {
+			field = fmt.Sprintf("images[%d].prompt", sp.index)
+		}
+		for _, v := range sp.result.Violations {
+			violations = append(violations, PromptViolation{Field: field, Violation: v})
+		}
+	}
+	return problem.New(http.StatusUnprocessableEntity, compliance.ErrorCode,
+		i18n.T(ctx, "The prompt contains language that may violate fair-housing rules. "+
+			"Describe the property, not who should live there.")).
+		With("violations", violations)
+}
+
+// validateCreateImageRequest validates the create image request. Messages are
+// localized for ctx.
+func (h *DefaultHandler) validateCreateImageRequest(
+	ctx context.Context, req *CreateImageRequest,
+) []ValidationErrorDetail {
 	var errors []ValidationErrorDetail
 
 	// Validate project ID
 	if req.ProjectID == uuid.Nil {
 		errors = append(errors, ValidationErrorDetail{
 			Field:   "project_id",
-			Message: "project_id is required",
+			Message: i18n.T(ctx, "project_id is required"),
 		})
 	}
 
@@ -363,32 +1561,26 @@ func (h *DefaultHandler) validateCreateImageRequest(req *CreateImageRequest) []V
 	if req.OriginalURL == "" {
 		errors = append(errors, ValidationErrorDetail{
 			Field:   "original_url",
-			Message: "original_url is required",
+			Message: i18n.T(ctx, "original_url is required"),
 		})
 	}
 
 	// Validate room type if provided
 	if req.RoomType != nil {
-		validRoomTypes := []string{"living_room", "bedroom", "kitchen", "bathroom",
-			"dining_room", "office", "entryway", "outdoor"}
-		isValid := slices.Contains(validRoomTypes, *req.RoomType)
-		if !isValid {
+		if !promptlib.IsRoomType(*req.RoomType) {
 			errors = append(errors, ValidationErrorDetail{
-				Field: "room_type",
-				Message: "room_type must be one of: " +
-					"living_room, bedroom, kitchen, bathroom, dining_room, office, entryway, outdoor",
+				Field:   "room_type",
+				Message: i18n.T(ctx, "room_type must be one of: %s", strings.Join(promptlib.RoomTypes(), ", ")),
 			})
 		}
 	}
 
 	// Validate style if provided
 	if req.Style != nil {
-		validStyles := []string{"modern", "contemporary", "traditional", "industrial", "scandinavian"}
-		isValid := slices.Contains(validStyles, *req.Style)
-		if !isValid {
+		if !promptlib.IsStyle(*req.Style) {
 			errors = append(errors, ValidationErrorDetail{
 				Field:   "style",
-				Message: "style must be one of: modern, contemporary, traditional, industrial, scandinavian",
+				Message: i18n.T(ctx, "style must be one of: %s", strings.Join(promptlib.Styles(), ", ")),
 			})
 		}
 	}
@@ -398,33 +1590,104 @@ func (h *DefaultHandler) validateCreateImageRequest(req *CreateImageRequest) []V
 		if *req.Seed < 1 || *req.Seed > 4294967295 {
 			errors = append(errors, ValidationErrorDetail{
 				Field:   "seed",
-				Message: "seed must be between 1 and 4294967295",
+				Message: i18n.T(ctx, "seed must be between 1 and 4294967295"),
+			})
+		}
+	}
+
+	// Validate template if provided; it stands in for the prompt
+	if req.TemplateID != nil {
+		if _, err := uuid.Parse(*req.TemplateID); err != nil {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   "template_id",
+				Message: i18n.T(ctx, "template_id must be a valid UUID"),
+			})
+		} else if req.Prompt != nil {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   "template_id",
+				Message: i18n.T(ctx, "template_id cannot be combined with prompt"),
+			})
+		}
+	}
+
+	// Validate preset if provided; it stands in for the model and prompt
+	if req.PresetID != nil {
+		if _, err := uuid.Parse(*req.PresetID); err != nil {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   "preset_id",
+				Message: i18n.T(ctx, "preset_id must be a valid UUID"),
+			})
+		} else if req.Prompt != nil || req.TemplateID != nil || req.ModelID != nil {
+			errors = append(errors, ValidationErrorDetail{
+				Field:   "preset_id",
+				Message: i18n.T(ctx, "preset_id cannot be combined with prompt, template_id or model_id"),
 			})
 		}
 	}
 
+	// Validate model if provided
+	if req.ModelID != nil && !settings.IsAvailableModel(*req.ModelID) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "model_id",
+			Message: i18n.T(ctx, "model_id must be one of: %s", strings.Join(settings.AvailableModelIDs(), ", ")),
+		})
+	}
+
+	// Validate mode if provided; emptying a room has no use for a staging prompt
+	if req.Mode != "" && !slices.Contains(ValidModes, req.Mode) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "mode",
+			Message: i18n.T(ctx, "mode must be one of: stage, declutter, both"),
+		})
+	} else if req.Mode == ModeDeclutter && (req.Prompt != nil || req.TemplateID != nil) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "mode",
+			Message: i18n.T(ctx, "declutter mode cannot be combined with prompt or template_id"),
+		})
+	}
+
+	// Validate mask if provided; which model it goes to is checked once models are resolved
+	if req.MaskURL != nil && strings.TrimSpace(*req.MaskURL) == "" {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "mask_url",
+			Message: i18n.T(ctx, "mask_url cannot be empty"),
+		})
+	}
+
+	return errors
+}
+
+// validateMasks checks that every request with a mask runs on a model that
+// can use it. Requests left on the global model are checked by the worker.
+func validateMasks(ctx context.Context, reqs []CreateImageRequest, field func(int) string) []ValidationErrorDetail {
+	var errors []ValidationErrorDetail
+	for i, req := range reqs {
+		if req.MaskURL == nil || req.ModelID == nil || modelconfig.Inpaints(*req.ModelID) {
+			continue
+		}
+		errors = append(errors, ValidationErrorDetail{
+			Field:   field(i),
+			Message: i18n.T(ctx, "mask_url needs a model that supports masks; %s doesn't", *req.ModelID),
+		})
+	}
 	return errors
 }
 
 // GetProjectCost handles GET /api/v1/projects/:project_id/cost requests.
 func (h *DefaultHandler) GetProjectCost(c echo.Context) error {
+	ctx := c.Request().Context()
 	projectID := c.Param("project_id")
 
 	// Validate project ID format
 	if _, err := uuid.Parse(projectID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid project ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, i18n.T(ctx, "Invalid project ID format"))
 	}
 
 	// Get cost summary
 	summary, err := h.service.GetProjectCostSummary(c.Request().Context(), projectID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to retrieve cost summary",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal,
+			i18n.T(ctx, "Failed to retrieve cost summary"))
 	}
 
 	return c.JSON(http.StatusOK, summary)
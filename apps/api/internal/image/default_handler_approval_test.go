@@ -0,0 +1,123 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetImageApproval(t *testing.T) {
+	imageID := uuid.New()
+	approved := true
+
+	testCases := []struct {
+		name         string
+		imageID      string
+		body         string
+		status       Status
+		getErr       error
+		projectErr   error
+		setErr       error
+		expectedCode int
+		expectBody   string
+		expectSet    *bool
+	}{
+		{
+			name:         "success: approves a staged image",
+			imageID:      imageID.String(),
+			body:         `{"approved":true}`,
+			status:       StatusReady,
+			expectedCode: http.StatusOK,
+			expectBody:   `"approved":true`,
+			expectSet:    &approved,
+		},
+		{
+			name:         "success: null clears the verdict",
+			imageID:      imageID.String(),
+			body:         `{"approved":null}`,
+			status:       StatusReady,
+			expectedCode: http.StatusOK,
+			expectBody:   `"approved":null`,
+		},
+		{name: "fail: invalid image id", imageID: "nope", body: `{}`, expectedCode: http.StatusBadRequest},
+		{name: "fail: malformed body", imageID: imageID.String(), body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: image not found",
+			imageID:      imageID.String(),
+			body:         `{"approved":true}`,
+			getErr:       pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: project not accessible",
+			imageID:      imageID.String(),
+			body:         `{"approved":true}`,
+			status:       StatusReady,
+			projectErr:   pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: image not staged yet",
+			imageID:      imageID.String(),
+			body:         `{"approved":false}`,
+			status:       StatusProcessing,
+			expectedCode: http.StatusConflict,
+			expectBody:   `image_not_ready`,
+		},
+		{
+			name:         "fail: service error",
+			imageID:      imageID.String(),
+			body:         `{"approved":true}`,
+			status:       StatusReady,
+			setErr:       errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/images/"+tc.imageID+"/approval",
+				strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			serviceMock := &ServiceMock{
+				GetImageByIDFunc: func(ctx context.Context, id string) (*Image, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Image{ID: imageID, ProjectID: uuid.New(), Status: tc.status}, nil
+				},
+				SetImageApprovalFunc: func(ctx context.Context, id string, approved *bool) error {
+					return tc.setErr
+				},
+			}
+			userRepo, projectRepo := trashRepos(uuid.New(), tc.projectErr)
+
+			handler := NewDefaultHandler(serviceMock, nil, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.SetImageApproval(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.expectedCode == http.StatusOK {
+				require.Len(t, serviceMock.SetImageApprovalCalls(), 1)
+				assert.Equal(t, tc.expectSet, serviceMock.SetImageApprovalCalls()[0].Approved)
+			}
+		})
+	}
+}
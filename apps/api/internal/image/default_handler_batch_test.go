@@ -3,16 +3,29 @@ package image
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/batch"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
 )
 
+// validationProblem is the part of a validation_failed problem the tests check.
+type validationProblem struct {
+	Code             string                  `json:"code"`
+	ValidationErrors []ValidationErrorDetail `json:"validation_errors"`
+}
+
 func TestBatchCreateImages_Success(t *testing.T) {
 	e := echo.New()
 	projectID := uuid.New()
@@ -66,7 +79,7 @@ func TestBatchCreateImages_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewDefaultHandler(serviceMock, nil, nil, nil)
+	handler := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	err := handler.BatchCreateImages(c)
 
 	assert.NoError(t, err)
@@ -125,7 +138,7 @@ func TestBatchCreateImages_PartialSuccess(t *testing.T) {
 		},
 	}
 
-	handler := NewDefaultHandler(serviceMock, nil, nil, nil)
+	handler := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	err := handler.BatchCreateImages(c)
 
 	assert.NoError(t, err)
@@ -154,16 +167,16 @@ func TestBatchCreateImages_EmptyRequest(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	serviceMock := &ServiceMock{}
-	handler := NewDefaultHandler(serviceMock, nil, nil, nil)
+	handler := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	err := handler.BatchCreateImages(c)
 
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 
-	var response ValidationErrorResponse
+	var response validationProblem
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "validation_failed", response.Error)
+	assert.Equal(t, "validation_failed", response.Code)
 	assert.Contains(t, response.ValidationErrors[0].Field, "images")
 }
 
@@ -188,16 +201,16 @@ func TestBatchCreateImages_TooManyImages(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	serviceMock := &ServiceMock{}
-	handler := NewDefaultHandler(serviceMock, nil, nil, nil)
+	handler := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	err := handler.BatchCreateImages(c)
 
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 
-	var response ValidationErrorResponse
+	var response validationProblem
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "validation_failed", response.Error)
+	assert.Equal(t, "validation_failed", response.Code)
 	assert.Contains(t, response.ValidationErrors[0].Message, "maximum 50")
 }
 
@@ -220,16 +233,16 @@ func TestBatchCreateImages_InvalidImageData(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	serviceMock := &ServiceMock{}
-	handler := NewDefaultHandler(serviceMock, nil, nil, nil)
+	handler := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	err := handler.BatchCreateImages(c)
 
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
 
-	var response ValidationErrorResponse
+	var response validationProblem
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, "validation_failed", response.Error)
+	assert.Equal(t, "validation_failed", response.Code)
 	assert.Greater(t, len(response.ValidationErrors), 0)
 	// Should have validation errors for images[0].project_id and images[0].original_url
 	hasProjectIDError := false
@@ -246,6 +259,92 @@ func TestBatchCreateImages_InvalidImageData(t *testing.T) {
 	assert.True(t, hasOriginalURLError)
 }
 
+func TestBatchCreateImages_Async(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+	body := `{"async":true,"images":[` +
+		`{"project_id":"` + projectID.String() + `","original_url":"https://example.com/a.jpg"},` +
+		`{"project_id":"` + projectID.String() + `","original_url":"https://example.com/b.jpg"}]}`
+
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/images/batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+
+	t.Run("success: accepted with a batch to poll", func(t *testing.T) {
+		imageID := uuid.New()
+		serviceMock := &ServiceMock{
+			CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+				if req.OriginalURL == "https://example.com/b.jpg" {
+					return nil, errors.New("insert failed")
+				}
+				return &Image{ID: imageID, ProjectID: req.ProjectID}, nil
+			},
+		}
+		var results []string
+		batches := &batch.ServiceMock{
+			StartFunc: func(
+				ctx context.Context, uid string, projectIDs []string, create batch.CreateFunc,
+			) (*batch.Batch, error) {
+				for i := range projectIDs {
+					id, err := create(ctx, i)
+					if err != nil {
+						id = "failed"
+					}
+					results = append(results, id)
+				}
+				return &batch.Batch{ID: "batch-1", Status: batch.StatusCreating, Total: len(projectIDs)}, nil
+			},
+		}
+		c, rec := newContext()
+
+		err := NewDefaultHandler(serviceMock, nil, userRepo, nil, nil, batches, nil, nil, nil, nil).BatchCreateImages(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Equal(t, "/api/v1/batches/batch-1", rec.Header().Get(echo.HeaderLocation))
+		assert.Contains(t, rec.Body.String(), `"status_url":"/api/v1/batches/batch-1"`)
+		assert.Contains(t, rec.Body.String(), `"id":"batch-1"`)
+
+		call := batches.StartCalls()[0]
+		assert.Equal(t, userID.String(), call.UserID)
+		assert.Equal(t, []string{projectID.String(), projectID.String()}, call.ProjectIDs)
+		assert.Equal(t, []string{imageID.String(), "failed"}, results)
+		assert.Empty(t, serviceMock.BatchCreateImagesCalls())
+	})
+
+	t.Run("fail: async batches not enabled", func(t *testing.T) {
+		serviceMock := &ServiceMock{}
+		c, rec := newContext()
+
+		err := NewDefaultHandler(serviceMock, nil, userRepo, nil, nil, nil, nil, nil, nil, nil).BatchCreateImages(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, serviceMock.BatchCreateImagesCalls())
+	})
+
+	t.Run("fail: batch not recorded", func(t *testing.T) {
+		batches := &batch.ServiceMock{
+			StartFunc: func(
+				ctx context.Context, uid string, projectIDs []string, create batch.CreateFunc,
+			) (*batch.Batch, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		c, rec := newContext()
+
+		err := NewDefaultHandler(&ServiceMock{}, nil, userRepo, nil, nil, batches, nil, nil, nil, nil).BatchCreateImages(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
@@ -0,0 +1,123 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_CreateImage_BillsProjectPayer(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	callerID := uuid.New()
+	orgBillingID := uuid.NewString()
+
+	testCases := []struct {
+		name         string
+		billingErr   error
+		canCreate    bool
+		createErr    error
+		expectedCode int
+		wantPayer    string
+		wantPriority queue.Priority
+	}{
+		{
+			name:         "success: shared project checks the org billing user's quota",
+			canCreate:    true,
+			expectedCode: http.StatusCreated,
+			wantPayer:    orgBillingID,
+			wantPriority: queue.PriorityCritical,
+		},
+		{
+			name:         "fail: org billing user is out of images",
+			expectedCode: http.StatusPaymentRequired,
+			wantPayer:    orgBillingID,
+		},
+		{
+			name:         "fail: the last credit is taken while the image is created",
+			canCreate:    true,
+			createErr:    fmt.Errorf("failed to create image: %w", ErrNoCredits),
+			expectedCode: http.StatusPaymentRequired,
+			wantPayer:    orgBillingID,
+		},
+		{
+			name:         "success: falls back to the caller when the payer lookup fails",
+			billingErr:   assert.AnError,
+			canCreate:    true,
+			expectedCode: http.StatusCreated,
+			wantPayer:    callerID.String(),
+			wantPriority: queue.PriorityLow,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Image{ID: uuid.New()}, nil
+				},
+			}
+			usageMock := &UsageCheckerMock{
+				CanCreateImageFunc: func(ctx context.Context, userID string) (bool, error) {
+					return tc.canCreate, nil
+				},
+				PlanCodeFunc: func(ctx context.Context, userID string) (string, error) {
+					if userID == orgBillingID {
+						return "business", nil
+					}
+					return "free", nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: callerID, Valid: true}}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetProjectByIDFunc: func(ctx context.Context, id string) (*project.Project, error) {
+					return &project.Project{ID: id}, nil
+				},
+				GetBillingUserIDFunc: func(ctx context.Context, id string) (string, error) {
+					assert.Equal(t, projectID, id)
+					if tc.billingErr != nil {
+						return "", tc.billingErr
+					}
+					return orgBillingID, nil
+				},
+			}
+			h := NewDefaultHandler(serviceMock, usageMock, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+
+			body := `{"project_id": "` + projectID + `", "original_url": "http://example.com/image.jpg"}`
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|member")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			require.Len(t, usageMock.CanCreateImageCalls(), 1)
+			assert.Equal(t, tc.wantPayer, usageMock.CanCreateImageCalls()[0].UserID)
+			if tc.expectedCode == http.StatusCreated {
+				require.Len(t, serviceMock.CreateImageCalls(), 1)
+				assert.Equal(t, tc.wantPriority, serviceMock.CreateImageCalls()[0].Req.Priority)
+			}
+		})
+	}
+}
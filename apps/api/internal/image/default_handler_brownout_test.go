@@ -0,0 +1,112 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/brownout"
+)
+
+func TestDefaultHandler_CreateImage_providerIncident(t *testing.T) {
+	body := `{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "original_url": "http://example.com/image.jpg"}`
+
+	cases := []struct {
+		name      string
+		sandbox   bool
+		delay     *brownout.Delay
+		wantCode  int
+		wantDelay bool
+	}{
+		{
+			name:      "success: accepted as delayed",
+			delay:     &brownout.Delay{Reason: brownout.ReasonProviderIncident},
+			wantCode:  http.StatusAccepted,
+			wantDelay: true,
+		},
+		{name: "success: no incident", wantCode: http.StatusCreated},
+		{
+			name:     "success: sandbox images aren't delayed",
+			sandbox:  true,
+			delay:    &brownout.Delay{Reason: brownout.ReasonProviderIncident},
+			wantCode: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), Status: StatusQueued, Sandbox: tc.sandbox}, nil
+				},
+			}
+			providers := &brownout.ServiceMock{
+				DelayForFunc: func(ctx context.Context, modelID *string) *brownout.Delay {
+					return tc.delay
+				},
+			}
+			h := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, providers, nil, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.wantCode, rec.Code)
+
+			var img Image
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &img))
+			assert.Equal(t, tc.wantDelay, img.Delay != nil)
+		})
+	}
+}
+
+func TestDefaultHandler_BatchCreateImages_providerIncident(t *testing.T) {
+	affected := "qwen/qwen-image-edit"
+	useModels(affected)
+	body := `{"images": [` +
+		`{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "original_url": "http://example.com/1.jpg"},` +
+		`{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "original_url": "http://example.com/2.jpg",` +
+		` "model_id": "` + affected + `"}]}`
+
+	serviceMock := &ServiceMock{
+		BatchCreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error) {
+			return &BatchCreateImagesResponse{
+				Images:  []*Image{{ID: uuid.New()}, {ID: uuid.New()}},
+				Success: 2,
+			}, nil
+		},
+	}
+	providers := &brownout.ServiceMock{
+		DelayForFunc: func(ctx context.Context, modelID *string) *brownout.Delay {
+			if modelID != nil && *modelID == affected {
+				return &brownout.Delay{Reason: brownout.ReasonProviderIncident}
+			}
+			return nil
+		},
+	}
+	h := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, providers, nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.BatchCreateImages(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var resp BatchCreateImagesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Images, 2)
+	assert.Nil(t, resp.Images[0].Delay)
+	assert.NotNil(t, resp.Images[1].Delay)
+}
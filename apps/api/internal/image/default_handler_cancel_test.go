@@ -0,0 +1,121 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelJob(t *testing.T) {
+	imageID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		imageID      string
+		status       Status
+		getErr       error
+		projectErr   error
+		cancelErr    error
+		expectedCode int
+		expectBody   string
+		expectCancel bool
+	}{
+		{
+			name:         "success: cancels a queued image",
+			imageID:      imageID.String(),
+			status:       StatusQueued,
+			expectedCode: http.StatusOK,
+			expectBody:   `"status":"canceled"`,
+			expectCancel: true,
+		},
+		{
+			name:         "success: cancels a processing image",
+			imageID:      imageID.String(),
+			status:       StatusProcessing,
+			expectedCode: http.StatusOK,
+			expectCancel: true,
+		},
+		{name: "fail: invalid image id", imageID: "nope", expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: image not found",
+			imageID:      imageID.String(),
+			getErr:       pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: project not accessible",
+			imageID:      imageID.String(),
+			status:       StatusQueued,
+			projectErr:   pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: job already finished",
+			imageID:      imageID.String(),
+			status:       StatusReady,
+			expectedCode: http.StatusConflict,
+			expectBody:   `job_finished`,
+		},
+		{
+			name:         "fail: job finished since the lookup",
+			imageID:      imageID.String(),
+			status:       StatusProcessing,
+			cancelErr:    ErrJobFinished,
+			expectedCode: http.StatusConflict,
+			expectBody:   `job_finished`,
+			expectCancel: true,
+		},
+		{
+			name:         "fail: service error",
+			imageID:      imageID.String(),
+			status:       StatusQueued,
+			cancelErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+			expectCancel: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/images/"+tc.imageID+"/job", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			serviceMock := &ServiceMock{
+				GetImageByIDFunc: func(ctx context.Context, id string) (*Image, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Image{ID: imageID, ProjectID: uuid.New(), Status: tc.status}, nil
+				},
+				CancelJobFunc: func(ctx context.Context, id string) (*Image, error) {
+					if tc.cancelErr != nil {
+						return nil, tc.cancelErr
+					}
+					return &Image{ID: imageID, Status: StatusCanceled}, nil
+				},
+			}
+			userRepo, projectRepo := trashRepos(uuid.New(), tc.projectErr)
+
+			handler := NewDefaultHandler(serviceMock, nil, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.CancelJob(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			assert.Equal(t, tc.expectCancel, len(serviceMock.CancelJobCalls()) == 1)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/compliance"
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func newScreenerMock() *PromptScreenerMock {
+	scanner := compliance.NewScanner(config.Compliance{})
+	return &PromptScreenerMock{
+		ScreenFunc: func(ctx context.Context, text string) compliance.Result {
+			return scanner.Scan(text)
+		},
+		RecordFunc: func(ctx context.Context, review *compliance.Review) (*compliance.Review, error) {
+			return review, nil
+		},
+	}
+}
+
+func TestDefaultHandler_CreateImage_PromptScreening(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+
+	testCases := []struct {
+		name         string
+		prompt       string
+		expectedCode int
+		wantCreate   bool
+		wantDecision compliance.Decision
+	}{
+		{
+			name:         "success: clean prompt is not recorded",
+			prompt:       "Bright modern living room with white walls",
+			expectedCode: http.StatusCreated,
+			wantCreate:   true,
+		},
+		{
+			name:         "success: flagged prompt is staged and queued for review",
+			prompt:       "Cozy bedroom, perfect for empty nesters",
+			expectedCode: http.StatusCreated,
+			wantCreate:   true,
+			wantDecision: compliance.DecisionFlagged,
+		},
+		{
+			name:         "fail: rejected prompt is never staged",
+			prompt:       "Sleek kitchen for a building with no children allowed",
+			expectedCode: http.StatusUnprocessableEntity,
+			wantDecision: compliance.DecisionRejected,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageID := uuid.New()
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: imageID}, nil
+				},
+			}
+			screener := newScreenerMock()
+			h := NewDefaultHandler(serviceMock, nil, nil, nil, screener, nil, nil, nil, nil, nil)
+
+			body := `{"project_id": "` + projectID + `", "original_url": "http://example.com/image.jpg", ` +
+				`"prompt": "` + tc.prompt + `"}`
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.wantCreate, len(serviceMock.CreateImageCalls()) == 1)
+
+			records := screener.RecordCalls()
+			if tc.wantDecision == "" {
+				assert.Empty(t, records)
+				return
+			}
+			require.Len(t, records, 1)
+			review := records[0].Review
+			assert.Equal(t, tc.wantDecision, review.Decision)
+			assert.Equal(t, projectID, *review.ProjectID)
+			if tc.wantCreate {
+				require.NotNil(t, review.ImageID)
+				assert.Equal(t, imageID.String(), *review.ImageID)
+			} else {
+				assert.Nil(t, review.ImageID)
+				assert.Contains(t, rec.Body.String(), `"error":"fair_housing_violation"`)
+				assert.Contains(t, rec.Body.String(), `"field":"prompt"`)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_BatchCreateImages_PromptScreening(t *testing.T) {
+	serviceMock := &ServiceMock{}
+	screener := newScreenerMock()
+	h := NewDefaultHandler(serviceMock, nil, nil, nil, screener, nil, nil, nil, nil, nil)
+
+	body := `{"images": [` +
+		`{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "original_url": "http://example.com/1.jpg", ` +
+		`"prompt": "Warm scandinavian dining room"},` +
+		`{"project_id": "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11", "original_url": "http://example.com/2.jpg", ` +
+		`"prompt": "Elegant den, Christians only"}]}`
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.BatchCreateImages(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"images[1].prompt"`)
+	assert.Empty(t, serviceMock.BatchCreateImagesCalls())
+	require.Len(t, screener.RecordCalls(), 1)
+	assert.Equal(t, compliance.DecisionRejected, screener.RecordCalls()[0].Review.Decision)
+}
@@ -0,0 +1,282 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDuplicateProject(t *testing.T) {
+	projectID := uuid.New()
+	copyID := uuid.New()
+	userID := uuid.New()
+	model := "bytedance/seedream-4"
+
+	twoPlanned := func(ctx context.Context, projectID string, style *string) ([]CreateImageRequest, int, error) {
+		pid := uuid.MustParse(projectID)
+		return []CreateImageRequest{
+			{ProjectID: pid, OriginalURL: "https://example.com/a.jpg", Style: style},
+			{ProjectID: pid, OriginalURL: "https://example.com/b.jpg", Style: style},
+		}, 1, nil
+	}
+
+	testCases := []struct {
+		name          string
+		projectID     string
+		body          string
+		source        project.Project
+		projectErr    error
+		createErr     error
+		updateErr     error
+		remaining     int32
+		plan          func(ctx context.Context, projectID string, style *string) ([]CreateImageRequest, int, error)
+		expectedCode  int
+		expectName    string
+		expectStyle   *string
+		expectCreate  bool
+		expectSetting bool
+		expectDelete  bool
+		expectBody    string
+	}{
+		{
+			name:         "success: copies the project and re-stages its images",
+			projectID:    projectID.String(),
+			body:         `{}`,
+			source:       project.Project{Name: "Downtown Condo"},
+			remaining:    10,
+			plan:         twoPlanned,
+			expectedCode: http.StatusCreated,
+			expectName:   "Downtown Condo (copy)",
+			expectCreate: true,
+		},
+		{
+			name:          "success: new name and style, settings copied",
+			projectID:     projectID.String(),
+			body:          `{"name":" Condo Industrial ","style":"industrial"}`,
+			source:        project.Project{Name: "Downtown Condo", Watermark: true, ModelID: &model},
+			remaining:     10,
+			plan:          twoPlanned,
+			expectedCode:  http.StatusCreated,
+			expectName:    "Condo Industrial",
+			expectStyle:   stringPtr("industrial"),
+			expectCreate:  true,
+			expectSetting: true,
+		},
+		{
+			name:         "success: template without images",
+			projectID:    projectID.String(),
+			body:         `{"include_images":false}`,
+			source:       project.Project{Name: "Downtown Condo"},
+			expectedCode: http.StatusCreated,
+			expectName:   "Downtown Condo (copy)",
+			expectBody:   `"images":[]`,
+		},
+		{
+			name:         "fail: invalid project id",
+			projectID:    "not-a-uuid",
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: invalid style",
+			projectID:    projectID.String(),
+			body:         `{"style":"baroque"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:         "fail: blank name",
+			projectID:    projectID.String(),
+			body:         `{"name":"  "}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   `"field":"name"`,
+		},
+		{
+			name:         "fail: style without images",
+			projectID:    projectID.String(),
+			body:         `{"style":"modern","include_images":false}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "style requires include_images",
+		},
+		{
+			name:         "fail: project not accessible",
+			projectID:    projectID.String(),
+			body:         `{}`,
+			projectErr:   errors.New("not found"),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: quota below batch size",
+			projectID:    projectID.String(),
+			body:         `{}`,
+			remaining:    1,
+			plan:         twoPlanned,
+			expectedCode: http.StatusPaymentRequired,
+			expectBody:   "usage_limit_exceeded",
+		},
+		{
+			name:      "fail: plan error",
+			projectID: projectID.String(),
+			body:      `{}`,
+			plan: func(ctx context.Context, projectID string, style *string) ([]CreateImageRequest, int, error) {
+				return nil, 0, errors.New("db down")
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "fail: project create error",
+			projectID:    projectID.String(),
+			body:         `{}`,
+			remaining:    10,
+			plan:         twoPlanned,
+			createErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:          "fail: settings copy error removes the copy",
+			projectID:     projectID.String(),
+			body:          `{}`,
+			source:        project.Project{Name: "Downtown Condo", Watermark: true},
+			remaining:     10,
+			plan:          twoPlanned,
+			updateErr:     errors.New("db down"),
+			expectedCode:  http.StatusInternalServerError,
+			expectSetting: true,
+			expectDelete:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/"+tc.projectID+"/duplicate",
+				strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			serviceMock := &ServiceMock{
+				PlanProjectDuplicateFunc: tc.plan,
+				BatchCreateImagesFunc: func(
+					ctx context.Context, reqs []CreateImageRequest,
+				) (*BatchCreateImagesResponse, error) {
+					resp := &BatchCreateImagesResponse{Success: len(reqs)}
+					for _, r := range reqs {
+						resp.Images = append(resp.Images, &Image{
+							ID: uuid.New(), ProjectID: r.ProjectID, OriginalURL: r.OriginalURL,
+							Style: r.Style, Status: StatusQueued,
+						})
+					}
+					return resp, nil
+				},
+			}
+			usageMock := &UsageCheckerMock{
+				RemainingImagesFunc: func(ctx context.Context, userID string) (int32, error) {
+					return tc.remaining, nil
+				},
+				PlanCodeFunc: func(ctx context.Context, userID string) (string, error) {
+					return "pro", nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetBillingUserIDFunc: func(ctx context.Context, projectID string) (string, error) {
+					return userID.String(), nil
+				},
+				GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+					if tc.projectErr != nil {
+						return nil, tc.projectErr
+					}
+					source := tc.source
+					source.ID = projectID
+					return &source, nil
+				},
+				CreateProjectFunc: func(ctx context.Context, p *project.Project, gotUserID string) (*project.Project, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					assert.Equal(t, userID.String(), gotUserID)
+					return &project.Project{ID: copyID.String(), Name: p.Name, UserID: gotUserID}, nil
+				},
+				UpdateProjectByUserIDFunc: func(
+					ctx context.Context, projectID, userID, name string, watermark *bool, modelID, locale *string,
+				) (*project.Project, error) {
+					if tc.updateErr != nil {
+						return nil, tc.updateErr
+					}
+					assert.Equal(t, copyID.String(), projectID)
+					return &project.Project{
+						ID: projectID, Name: name, UserID: userID, Watermark: *watermark, ModelID: modelID, Locale: locale,
+					}, nil
+				},
+				DeleteProjectByUserIDFunc: func(ctx context.Context, projectID, userID string) error {
+					return nil
+				},
+			}
+
+			handler := NewDefaultHandler(serviceMock, usageMock, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.DuplicateProject(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			assert.Equal(t, tc.expectSetting, len(projectRepo.UpdateProjectByUserIDCalls()) == 1)
+			assert.Equal(t, tc.expectDelete, len(projectRepo.DeleteProjectByUserIDCalls()) == 1)
+			if tc.expectName != "" {
+				var resp DuplicateProjectResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, tc.expectName, resp.Project.Name)
+				assert.Equal(t, copyID.String(), resp.Project.ID)
+				if tc.expectSetting {
+					assert.True(t, resp.Project.Watermark)
+					assert.Equal(t, &model, resp.Project.ModelID)
+				}
+			}
+			if tc.expectCreate {
+				require.Len(t, serviceMock.BatchCreateImagesCalls(), 1)
+				for _, r := range serviceMock.BatchCreateImagesCalls()[0].Reqs {
+					assert.Equal(t, copyID, r.ProjectID)
+					assert.Equal(t, tc.expectStyle, r.Style)
+				}
+				var resp DuplicateProjectResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Len(t, resp.Images, 2)
+				assert.Equal(t, 1, resp.Skipped)
+				assert.Contains(t, resp.EventsURL, resp.Images[0].ID.String()+","+resp.Images[1].ID.String())
+			} else {
+				assert.Empty(t, serviceMock.BatchCreateImagesCalls())
+			}
+		})
+	}
+}
+
+func TestDuplicateName(t *testing.T) {
+	requested := "Condo Industrial"
+	assert.Equal(t, "Condo Industrial", duplicateName("Downtown Condo", &requested))
+	assert.Equal(t, "Downtown Condo (copy)", duplicateName("Downtown Condo", nil))
+
+	long := duplicateName(strings.Repeat("é", maxProjectNameLength), nil)
+	assert.Equal(t, maxProjectNameLength, len([]rune(long)))
+	assert.True(t, strings.HasSuffix(long, " (copy)"))
+}
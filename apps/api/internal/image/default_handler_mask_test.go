@@ -0,0 +1,89 @@
+package image
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+)
+
+func TestDefaultHandler_CreateImage_Mask(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	const mask = "https://bucket.s3.amazonaws.com/users/u1/projects/p1/masks/sofa.png"
+	inpaint, edit := "stability-ai/stable-image-inpaint", "black-forest-labs/flux-kontext-pro"
+	useModels(inpaint, edit)
+
+	testCases := []struct {
+		name         string
+		extra        string
+		projectModel *string
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: inpainting model takes the mask",
+			extra:        `, "mask_url": "` + mask + `", "model_id": "` + inpaint + `"`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "success: the global model is left to the worker",
+			extra:        `, "mask_url": "` + mask + `"`,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "fail: project's model can't use a mask",
+			extra:        `, "mask_url": "` + mask + `"`,
+			projectModel: &edit,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "mask_url needs a model that supports masks",
+		},
+		{
+			name:         "fail: empty mask",
+			extra:        `, "mask_url": " "`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "mask_url cannot be empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), MaskURL: req.MaskURL}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetProjectByIDFunc: func(ctx context.Context, id string) (*project.Project, error) {
+					return &project.Project{ID: id, ModelID: tc.projectModel}, nil
+				},
+			}
+			h := NewDefaultHandler(serviceMock, nil, nil, projectRepo, nil, nil, nil, nil, nil, nil)
+
+			body := `{"project_id": "` + projectID + `", "original_url": "http://example.com/image.jpg"` + tc.extra + `}`
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.expectedCode != http.StatusCreated {
+				assert.Empty(t, serviceMock.CreateImageCalls())
+				return
+			}
+			require.Len(t, serviceMock.CreateImageCalls(), 1)
+			assert.Equal(t, mask, *serviceMock.CreateImageCalls()[0].Req.MaskURL)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package image
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+)
+
+func TestDefaultHandler_CreateImage_Mode(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+
+	testCases := []struct {
+		name         string
+		extra        string
+		expectedCode int
+		wantMode     Mode
+		expectBody   string
+	}{
+		{
+			name:         "success: declutter",
+			extra:        `, "mode": "declutter"`,
+			expectedCode: http.StatusCreated,
+			wantMode:     ModeDeclutter,
+		},
+		{
+			name:         "success: both keeps the style",
+			extra:        `, "mode": "both", "style": "scandinavian"`,
+			expectedCode: http.StatusCreated,
+			wantMode:     ModeBoth,
+		},
+		{
+			name:         "fail: unknown mode",
+			extra:        `, "mode": "demolish"`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "mode must be one of: stage, declutter, both",
+		},
+		{
+			name:         "fail: declutter with a prompt",
+			extra:        `, "mode": "declutter", "prompt": "Remove everything but the piano"`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "declutter mode cannot be combined with prompt or template_id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), Mode: req.Mode}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetProjectByIDFunc: func(ctx context.Context, id string) (*project.Project, error) {
+					return &project.Project{ID: id}, nil
+				},
+			}
+			h := NewDefaultHandler(serviceMock, nil, nil, projectRepo, nil, nil, nil, nil, nil, nil)
+
+			body := `{"project_id": "` + projectID + `", "original_url": "http://example.com/image.jpg"` + tc.extra + `}`
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.expectedCode != http.StatusCreated {
+				assert.Empty(t, serviceMock.CreateImageCalls())
+				return
+			}
+			require.Len(t, serviceMock.CreateImageCalls(), 1)
+			assert.Equal(t, tc.wantMode, serviceMock.CreateImageCalls()[0].Req.Mode)
+			assert.Contains(t, rec.Body.String(), `"mode":"`+string(tc.wantMode)+`"`)
+		})
+	}
+}
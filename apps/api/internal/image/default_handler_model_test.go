@@ -0,0 +1,186 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// useModels sets the model catalog to enabled models with the given IDs.
+func useModels(ids ...string) {
+	models := make([]settings.ModelInfo, len(ids))
+	for i, id := range ids {
+		models[i] = settings.ModelInfo{ID: id, Enabled: true}
+	}
+	settings.SetCatalog(models)
+}
+
+func TestDefaultHandler_CreateImage_ResolvesModel(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	callerID := uuid.New()
+	projectModel, userModel := "black-forest-labs/flux-kontext-pro", "bytedance/seedream-4"
+	useModels(projectModel, userModel, "openai/gpt-image-1")
+
+	testCases := []struct {
+		name          string
+		requestModel  string
+		projectModel  *string
+		userModel     string
+		userErr       error
+		expectedCode  int
+		wantModel     *string
+		wantPrefCalls int
+	}{
+		{
+			name:         "success: the job's model wins",
+			requestModel: `, "model_id": "openai/gpt-image-1"`,
+			projectModel: &projectModel,
+			userModel:    userModel,
+			expectedCode: http.StatusCreated,
+			wantModel:    stringPtr("openai/gpt-image-1"),
+		},
+		{
+			name:         "success: the project's model wins over the user's",
+			projectModel: &projectModel,
+			userModel:    userModel,
+			expectedCode: http.StatusCreated,
+			wantModel:    &projectModel,
+		},
+		{
+			name:          "success: the user's model applies to projects without one",
+			userModel:     userModel,
+			expectedCode:  http.StatusCreated,
+			wantModel:     &userModel,
+			wantPrefCalls: 1,
+		},
+		{
+			name:          "success: no override leaves the global model",
+			expectedCode:  http.StatusCreated,
+			wantPrefCalls: 1,
+		},
+		{
+			name:          "success: a failed preference lookup falls back to the global model",
+			userErr:       assert.AnError,
+			expectedCode:  http.StatusCreated,
+			wantPrefCalls: 1,
+		},
+		{
+			name:         "fail: unknown model",
+			requestModel: `, "model_id": "acme/painter"`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New()}, nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: callerID, Valid: true}}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetProjectByIDFunc: func(ctx context.Context, id string) (*project.Project, error) {
+					return &project.Project{ID: id, ModelID: tc.projectModel}, nil
+				},
+			}
+			models := &ModelPreferencesMock{
+				PreferredModelFunc: func(ctx context.Context, userID string) (string, error) {
+					assert.Equal(t, callerID.String(), userID)
+					return tc.userModel, tc.userErr
+				},
+			}
+			h := NewDefaultHandler(serviceMock, nil, userRepo, projectRepo, nil, nil, models, nil, nil, nil)
+
+			body := `{"project_id": "` + projectID + `", "original_url": "http://example.com/image.jpg"` +
+				tc.requestModel + `}`
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|member")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Len(t, models.PreferredModelCalls(), tc.wantPrefCalls)
+			if tc.expectedCode != http.StatusCreated {
+				assert.Empty(t, serviceMock.CreateImageCalls())
+				return
+			}
+			require.Len(t, serviceMock.CreateImageCalls(), 1)
+			assert.Equal(t, tc.wantModel, serviceMock.CreateImageCalls()[0].Req.ModelID)
+		})
+	}
+}
+
+func TestDefaultHandler_BatchCreateImages_ResolvesModelOncePerProject(t *testing.T) {
+	pinned, unpinned := uuid.New(), uuid.New()
+	projectModel, userModel := "black-forest-labs/flux-kontext-pro", "bytedance/seedream-4"
+	useModels(projectModel, userModel, "openai/gpt-image-1")
+
+	serviceMock := &ServiceMock{
+		BatchCreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error) {
+			return &BatchCreateImagesResponse{Success: len(reqs)}, nil
+		},
+	}
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil
+		},
+	}
+	projectRepo := &project.RepositoryMock{
+		GetProjectByIDFunc: func(ctx context.Context, id string) (*project.Project, error) {
+			if id == pinned.String() {
+				return &project.Project{ID: id, ModelID: &projectModel}, nil
+			}
+			return &project.Project{ID: id}, nil
+		},
+	}
+	models := &ModelPreferencesMock{
+		PreferredModelFunc: func(ctx context.Context, userID string) (string, error) {
+			return userModel, nil
+		},
+	}
+	h := NewDefaultHandler(serviceMock, nil, userRepo, projectRepo, nil, nil, models, nil, nil, nil)
+
+	image := func(projectID uuid.UUID, extra string) string {
+		return `{"project_id": "` + projectID.String() + `", "original_url": "http://example.com/a.jpg"` + extra + `}`
+	}
+	body := `{"images": [` + image(pinned, "") + `, ` + image(unpinned, "") + `, ` + image(pinned, "") + `, ` +
+		image(unpinned, `, "model_id": "openai/gpt-image-1"`) + `]}`
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", "auth0|member")
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.BatchCreateImages(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	require.Len(t, serviceMock.BatchCreateImagesCalls(), 1)
+	reqs := serviceMock.BatchCreateImagesCalls()[0].Reqs
+	require.Len(t, reqs, 4)
+	assert.Equal(t, &projectModel, reqs[0].ModelID)
+	assert.Equal(t, &userModel, reqs[1].ModelID)
+	assert.Equal(t, &projectModel, reqs[2].ModelID)
+	assert.Equal(t, stringPtr("openai/gpt-image-1"), reqs[3].ModelID)
+	assert.Len(t, projectRepo.GetProjectByIDCalls(), 2)
+	assert.Len(t, models.PreferredModelCalls(), 1)
+}
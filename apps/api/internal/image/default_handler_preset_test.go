@@ -0,0 +1,149 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/stylepreset"
+)
+
+func TestDefaultHandler_CreateImage_StylePreset(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	const presetModel = "black-forest-labs/flux-kontext-max"
+	presetID := uuid.NewString()
+	useModels(presetModel)
+	template := "A luxurious {style} {room} with marble accents"
+	request := func(extra string) string {
+		return `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+			`"room_type":"living_room","style":"modern","preset_id":"` + presetID + `"` + extra + `}`
+	}
+
+	testCases := []struct {
+		name         string
+		body         string
+		preset       *stylepreset.Preset
+		getErr       error
+		noPresets    bool
+		expectedCode int
+		expectPrompt *string
+		expectBody   string
+	}{
+		{
+			name: "success: preset sets the model, prompt and config",
+			body: request(""),
+			preset: &stylepreset.Preset{
+				ModelID: presetModel, PromptTemplate: &template,
+				ConfigOverrides: map[string]interface{}{"output_format": "png"},
+			},
+			expectedCode: http.StatusCreated,
+			expectPrompt: stringPtr("A luxurious modern living room with marble accents"),
+		},
+		{
+			name:         "success: preset without a template leaves the prompt to the library",
+			body:         request(""),
+			preset:       &stylepreset.Preset{ModelID: presetModel, ConfigOverrides: map[string]interface{}{}},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "success: declutter takes no prompt",
+			body:         request(`,"mode":"declutter"`),
+			preset:       &stylepreset.Preset{ModelID: presetModel, PromptTemplate: &template},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "fail: preset combined with a model",
+			body:         request(`,"model_id":"` + presetModel + `"`),
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "preset_id cannot be combined with prompt, template_id or model_id",
+		},
+		{
+			name: "fail: invalid preset id",
+			body: `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+				`"preset_id":"luxury"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "preset_id must be a valid UUID",
+		},
+		{
+			name:         "fail: unknown preset",
+			body:         request(""),
+			getErr:       stylepreset.ErrPresetNotFound,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   `"field":"preset_id"`,
+		},
+		{
+			name:         "fail: preset model no longer available",
+			body:         request(""),
+			preset:       &stylepreset.Preset{ModelID: "acme/retired"},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "the style preset's model is not available",
+		},
+		{
+			name:         "fail: presets not enabled",
+			body:         request(""),
+			noPresets:    true,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "style presets are not enabled",
+		},
+		{
+			name:         "fail: lookup error",
+			body:         request(""),
+			getErr:       errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), Prompt: req.Prompt}, nil
+				},
+			}
+			presetsMock := &StylePresetsMock{
+				GetPresetFunc: func(ctx context.Context, id string) (*stylepreset.Preset, error) {
+					assert.Equal(t, presetID, id)
+					return tc.preset, tc.getErr
+				},
+			}
+			var presets StylePresets = presetsMock
+			if tc.noPresets {
+				presets = nil
+			}
+			h := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, presets)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.expectedCode != http.StatusCreated {
+				assert.Empty(t, serviceMock.CreateImageCalls())
+				return
+			}
+			require.Len(t, serviceMock.CreateImageCalls(), 1)
+			got := serviceMock.CreateImageCalls()[0].Req
+			require.NotNil(t, got.ModelID)
+			assert.Equal(t, presetModel, *got.ModelID)
+			assert.Equal(t, tc.expectPrompt, got.Prompt)
+			if len(tc.preset.ConfigOverrides) > 0 {
+				assert.Equal(t, tc.preset.ConfigOverrides, got.ModelConfig)
+			} else {
+				assert.Nil(t, got.ModelConfig)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestRerollImage(t *testing.T) {
+	imageID := uuid.New()
+	projectID := uuid.New()
+	userID := uuid.New()
+	str := func(s string) *string { return &s }
+
+	testCases := []struct {
+		name         string
+		imageID      string
+		body         string
+		modelUsed    *string
+		projectModel *string
+		sourceErr    error
+		sourceStatus Status
+		projectErr   error
+		canCreate    bool
+		rerollErr    error
+		expectedCode int
+		expectReroll bool
+		wantModel    *string
+		expectBody   string
+	}{
+		{
+			name:         "success: keeps the model that staged the source",
+			imageID:      imageID.String(),
+			body:         `{"seed":7}`,
+			modelUsed:    str("black-forest-labs/flux-kontext-max:abc123"),
+			projectModel: str("qwen/qwen-image-edit"),
+			canCreate:    true,
+			expectedCode: http.StatusCreated,
+			expectReroll: true,
+			wantModel:    str("black-forest-labs/flux-kontext-max"),
+			expectBody:   `"seed":7`,
+		},
+		{
+			name:         "success: source never staged uses the project's model",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			projectModel: str("qwen/qwen-image-edit"),
+			canCreate:    true,
+			expectedCode: http.StatusCreated,
+			expectReroll: true,
+			wantModel:    str("qwen/qwen-image-edit"),
+		},
+		{
+			name:         "fail: invalid image id",
+			imageID:      "not-a-uuid",
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: source image not found",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			sourceErr:    pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: project not owned",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			projectErr:   errors.New("not found"),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: original quarantined",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			sourceStatus: StatusRejected,
+			expectedCode: http.StatusConflict,
+			expectBody:   "image_rejected",
+		},
+		{
+			name:         "fail: seed out of range",
+			imageID:      imageID.String(),
+			body:         `{"seed":0}`,
+			canCreate:    true,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:         "fail: usage limit reached",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			canCreate:    false,
+			expectedCode: http.StatusPaymentRequired,
+			expectBody:   "usage_limit_exceeded",
+		},
+		{
+			name:         "fail: service error",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			canCreate:    true,
+			rerollErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+			expectReroll: true,
+		},
+		{
+			name:         "fail: no credits left when the image is created",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			canCreate:    true,
+			rerollErr:    fmt.Errorf("failed to create image: %w", ErrNoCredits),
+			expectedCode: http.StatusPaymentRequired,
+			expectReroll: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/images/"+tc.imageID+"/reroll",
+				strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			serviceMock := &ServiceMock{
+				GetImageByIDFunc: func(ctx context.Context, id string) (*Image, error) {
+					if tc.sourceErr != nil {
+						return nil, tc.sourceErr
+					}
+					status := StatusReady
+					if tc.sourceStatus != "" {
+						status = tc.sourceStatus
+					}
+					return &Image{
+						ID: imageID, ProjectID: projectID, OriginalURL: "https://example.com/a.jpg", Status: status,
+						ModelUsed: tc.modelUsed,
+					}, nil
+				},
+				RerollImageFunc: func(ctx context.Context, id string, r *RerollImageRequest) (*Image, error) {
+					if tc.rerollErr != nil {
+						return nil, tc.rerollErr
+					}
+					return &Image{
+						ID: uuid.New(), ProjectID: projectID, OriginalURL: "https://example.com/a.jpg",
+						Seed: r.Seed, Status: StatusQueued,
+					}, nil
+				},
+			}
+			usageMock := &UsageCheckerMock{
+				CanCreateImageFunc: func(ctx context.Context, userID string) (bool, error) {
+					return tc.canCreate, nil
+				},
+				PlanCodeFunc: func(ctx context.Context, userID string) (string, error) {
+					return "pro", nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+					if tc.projectErr != nil {
+						return nil, tc.projectErr
+					}
+					return &project.Project{ID: projectID, ModelID: tc.projectModel}, nil
+				},
+				GetBillingUserIDFunc: func(ctx context.Context, projectID string) (string, error) {
+					return userID.String(), nil
+				},
+			}
+
+			handler := NewDefaultHandler(serviceMock, usageMock, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.RerollImage(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if !tc.expectReroll {
+				assert.Empty(t, serviceMock.RerollImageCalls())
+				return
+			}
+			calls := serviceMock.RerollImageCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, tc.imageID, calls[0].ImageID)
+			if tc.wantModel != nil {
+				assert.Equal(t, tc.wantModel, calls[0].Req.ModelID)
+			}
+		})
+	}
+}
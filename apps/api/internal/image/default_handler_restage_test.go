@@ -0,0 +1,217 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/compliance"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestRestageImage(t *testing.T) {
+	imageID := uuid.New()
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		imageID       string
+		body          string
+		sourceErr     error
+		sourceStatus  Status
+		projectErr    error
+		canCreate     bool
+		screen        compliance.Result
+		restageErr    error
+		expectedCode  int
+		expectRestage bool
+		expectBody    string
+	}{
+		{
+			name:          "success: queues variant",
+			imageID:       imageID.String(),
+			body:          `{"style":"industrial","seed":7}`,
+			canCreate:     true,
+			expectedCode:  http.StatusCreated,
+			expectRestage: true,
+			expectBody:    `"status":"queued"`,
+		},
+		{
+			name:          "success: empty body inherits everything",
+			imageID:       imageID.String(),
+			body:          `{}`,
+			canCreate:     true,
+			expectedCode:  http.StatusCreated,
+			expectRestage: true,
+		},
+		{
+			name:         "fail: invalid image id",
+			imageID:      "not-a-uuid",
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: source image not found",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			sourceErr:    pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: original quarantined",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			sourceStatus: StatusRejected,
+			canCreate:    true,
+			expectedCode: http.StatusConflict,
+			expectBody:   "image_rejected",
+		},
+		{
+			name:         "fail: project not owned",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			projectErr:   errors.New("not found"),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: invalid style",
+			imageID:      imageID.String(),
+			body:         `{"style":"baroque"}`,
+			canCreate:    true,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:      "fail: prompt rejected",
+			imageID:   imageID.String(),
+			body:      `{"prompt":"perfect for young families only"}`,
+			canCreate: true,
+			screen: compliance.Result{Violations: []compliance.Violation{
+				{Rule: "families_only", Category: compliance.CategoryFamilialStatus, Action: compliance.ActionReject},
+			}},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   compliance.ErrorCode,
+		},
+		{
+			name:         "fail: usage limit reached",
+			imageID:      imageID.String(),
+			body:         `{}`,
+			canCreate:    false,
+			expectedCode: http.StatusPaymentRequired,
+			expectBody:   "usage_limit_exceeded",
+		},
+		{
+			name:          "fail: service error",
+			imageID:       imageID.String(),
+			body:          `{}`,
+			canCreate:     true,
+			restageErr:    errors.New("db down"),
+			expectedCode:  http.StatusInternalServerError,
+			expectRestage: true,
+		},
+		{
+			name:          "fail: no credits left when the image is created",
+			imageID:       imageID.String(),
+			body:          `{}`,
+			canCreate:     true,
+			restageErr:    fmt.Errorf("failed to create image: %w", ErrNoCredits),
+			expectedCode:  http.StatusPaymentRequired,
+			expectRestage: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/images/"+tc.imageID+"/restage",
+				strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			serviceMock := &ServiceMock{
+				GetImageByIDFunc: func(ctx context.Context, id string) (*Image, error) {
+					if tc.sourceErr != nil {
+						return nil, tc.sourceErr
+					}
+					status := StatusReady
+					if tc.sourceStatus != "" {
+						status = tc.sourceStatus
+					}
+					return &Image{
+						ID: imageID, ProjectID: projectID, OriginalURL: "https://example.com/a.jpg", Status: status,
+					}, nil
+				},
+				RestageImageFunc: func(ctx context.Context, id string, r *RestageImageRequest) (*Image, error) {
+					if tc.restageErr != nil {
+						return nil, tc.restageErr
+					}
+					return &Image{
+						ID: uuid.New(), ProjectID: projectID, OriginalURL: "https://example.com/a.jpg",
+						Style: r.Style, Seed: r.Seed, Status: StatusQueued,
+					}, nil
+				},
+			}
+			usageMock := &UsageCheckerMock{
+				CanCreateImageFunc: func(ctx context.Context, userID string) (bool, error) {
+					return tc.canCreate, nil
+				},
+				PlanCodeFunc: func(ctx context.Context, userID string) (string, error) {
+					return "pro", nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+					if tc.projectErr != nil {
+						return nil, tc.projectErr
+					}
+					return &project.Project{ID: projectID}, nil
+				},
+				GetBillingUserIDFunc: func(ctx context.Context, projectID string) (string, error) {
+					return userID.String(), nil
+				},
+			}
+			screener := &PromptScreenerMock{
+				ScreenFunc: func(ctx context.Context, prompt string) compliance.Result { return tc.screen },
+				RecordFunc: func(ctx context.Context, review *compliance.Review) (*compliance.Review, error) {
+					return review, nil
+				},
+			}
+
+			handler := NewDefaultHandler(serviceMock, usageMock, userRepo, projectRepo, screener, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.RestageImage(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.expectRestage {
+				require.Len(t, serviceMock.RestageImageCalls(), 1)
+				assert.Equal(t, tc.imageID, serviceMock.RestageImageCalls()[0].ImageID)
+			} else {
+				assert.Empty(t, serviceMock.RestageImageCalls())
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestRestyleProject(t *testing.T) {
+	projectID := uuid.New()
+	userID := uuid.New()
+
+	twoPlanned := func(ctx context.Context, projectID string, style string) ([]CreateImageRequest, int, error) {
+		pid := uuid.MustParse(projectID)
+		return []CreateImageRequest{
+			{ProjectID: pid, OriginalURL: "https://example.com/a.jpg", Style: &style},
+			{ProjectID: pid, OriginalURL: "https://example.com/b.jpg", Style: &style},
+		}, 1, nil
+	}
+
+	testCases := []struct {
+		name         string
+		projectID    string
+		body         string
+		projectErr   error
+		locked       bool
+		remaining    int32
+		plan         func(ctx context.Context, projectID string, style string) ([]CreateImageRequest, int, error)
+		expectedCode int
+		expectCreate bool
+		expectBody   string
+	}{
+		{
+			name:         "success: creates variants and returns batch stream",
+			projectID:    projectID.String(),
+			body:         `{"style":"industrial"}`,
+			remaining:    10,
+			plan:         twoPlanned,
+			expectedCode: http.StatusAccepted,
+			expectCreate: true,
+			expectBody:   "/api/v1/events?image_ids=",
+		},
+		{
+			name:      "success: nothing to restyle",
+			projectID: projectID.String(),
+			body:      `{"style":"industrial"}`,
+			remaining: 10,
+			plan: func(ctx context.Context, projectID string, style string) ([]CreateImageRequest, int, error) {
+				return nil, 3, nil
+			},
+			expectedCode: http.StatusOK,
+			expectBody:   `"skipped":3`,
+		},
+		{
+			name:         "fail: invalid project id",
+			projectID:    "not-a-uuid",
+			body:         `{"style":"industrial"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: invalid style",
+			projectID:    projectID.String(),
+			body:         `{"style":"baroque"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:         "fail: project not owned",
+			projectID:    projectID.String(),
+			body:         `{"style":"industrial"}`,
+			projectErr:   errors.New("not found"),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: project locked",
+			projectID:    projectID.String(),
+			body:         `{"style":"industrial"}`,
+			locked:       true,
+			remaining:    10,
+			plan:         twoPlanned,
+			expectedCode: http.StatusConflict,
+			expectBody:   "project_locked",
+		},
+		{
+			name:         "fail: quota below batch size",
+			projectID:    projectID.String(),
+			body:         `{"style":"industrial"}`,
+			remaining:    1,
+			plan:         twoPlanned,
+			expectedCode: http.StatusPaymentRequired,
+			expectBody:   "usage_limit_exceeded",
+		},
+		{
+			name:      "fail: plan error",
+			projectID: projectID.String(),
+			body:      `{"style":"industrial"}`,
+			plan: func(ctx context.Context, projectID string, style string) ([]CreateImageRequest, int, error) {
+				return nil, 0, errors.New("db down")
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/"+tc.projectID+"/restyle",
+				strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_id")
+			c.SetParamValues(tc.projectID)
+
+			serviceMock := &ServiceMock{
+				PlanProjectRestyleFunc: tc.plan,
+				BatchCreateImagesFunc: func(
+					ctx context.Context, reqs []CreateImageRequest,
+				) (*BatchCreateImagesResponse, error) {
+					resp := &BatchCreateImagesResponse{Success: len(reqs)}
+					for _, r := range reqs {
+						resp.Images = append(resp.Images, &Image{
+							ID: uuid.New(), ProjectID: r.ProjectID, OriginalURL: r.OriginalURL,
+							Style: r.Style, Status: StatusQueued,
+						})
+					}
+					return resp, nil
+				},
+			}
+			usageMock := &UsageCheckerMock{
+				RemainingImagesFunc: func(ctx context.Context, userID string) (int32, error) {
+					return tc.remaining, nil
+				},
+				PlanCodeFunc: func(ctx context.Context, userID string) (string, error) {
+					return "pro", nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			projectRepo := &project.RepositoryMock{
+				GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+					if tc.projectErr != nil {
+						return nil, tc.projectErr
+					}
+					return &project.Project{ID: projectID, Locked: tc.locked}, nil
+				},
+				GetBillingUserIDFunc: func(ctx context.Context, projectID string) (string, error) {
+					return userID.String(), nil
+				},
+			}
+
+			handler := NewDefaultHandler(serviceMock, usageMock, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.RestyleProject(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.expectCreate {
+				require.Len(t, serviceMock.BatchCreateImagesCalls(), 1)
+				var resp RestyleProjectResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Len(t, resp.Images, 2)
+				assert.Equal(t, 1, resp.Skipped)
+				assert.Equal(t, "industrial", resp.Style)
+				assert.Contains(t, resp.EventsURL, resp.Images[0].ID.String()+","+resp.Images[1].ID.String())
+			} else {
+				assert.Empty(t, serviceMock.BatchCreateImagesCalls())
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/pagination"
+)
+
+func TestSearchImages(t *testing.T) {
+	userID := uuid.New()
+	next := "next-page"
+
+	testCases := []struct {
+		name         string
+		query        url.Values
+		searchErr    error
+		expectedCode int
+		expectBody   string
+		wantQuery    SearchQuery
+	}{
+		{
+			name:         "success: searches the caller's images",
+			query:        url.Values{"q": {" scandinavian bedroom seed 42 "}, "limit": {"10"}},
+			expectedCode: http.StatusOK,
+			expectBody:   `"next_cursor":"next-page"`,
+			wantQuery:    ParseSearchQuery("scandinavian bedroom seed 42"),
+		},
+		{
+			name:         "fail: missing query",
+			query:        url.Values{"q": {"  "}},
+			expectedCode: http.StatusBadRequest,
+			expectBody:   "Search query is required",
+		},
+		{
+			name:         "fail: query too long",
+			query:        url.Values{"q": {strings.Repeat("a", maxSearchLength+1)}},
+			expectedCode: http.StatusBadRequest,
+			expectBody:   "at most 200 characters",
+		},
+		{
+			name:         "fail: invalid cursor",
+			query:        url.Values{"q": {"kitchen"}, "cursor": {"nope"}},
+			expectedCode: http.StatusBadRequest,
+			expectBody:   "invalid_cursor",
+		},
+		{
+			name:         "fail: service error",
+			query:        url.Values{"q": {"kitchen"}},
+			searchErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/images/search?"+tc.query.Encode(), nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			serviceMock := &ServiceMock{
+				SearchImagesFunc: func(
+					ctx context.Context, gotUserID string, query SearchQuery, page pagination.Page,
+				) (*ImageSearchResponse, error) {
+					if tc.searchErr != nil {
+						return nil, tc.searchErr
+					}
+					assert.Equal(t, userID.String(), gotUserID)
+					assert.Equal(t, tc.wantQuery, query)
+					assert.Equal(t, int32(10), page.Limit)
+					return &ImageSearchResponse{Images: []*Image{{ID: uuid.New()}}, NextCursor: &next}, nil
+				},
+			}
+			userRepo, projectRepo := trashRepos(userID, nil)
+
+			handler := NewDefaultHandler(serviceMock, nil, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.SearchImages(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
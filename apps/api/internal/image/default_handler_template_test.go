@@ -0,0 +1,189 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/prompttemplate"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_CreateImage_PromptTemplate(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	templateID := uuid.NewString()
+	userID := uuid.New()
+
+	testCases := []struct {
+		name         string
+		body         string
+		renderErr    error
+		noTemplates  bool
+		expectedCode int
+		expectPrompt string
+		expectBody   string
+	}{
+		{
+			name: "success: template renders the prompt",
+			body: `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+				`"room_type":"living_room","style":"industrial","template_id":"` + templateID + `"}`,
+			expectedCode: http.StatusCreated,
+			expectPrompt: "An industrial living_room with warm light",
+		},
+		{
+			name: "fail: template of another user",
+			body: `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+				`"template_id":"` + templateID + `"}`,
+			renderErr:    prompttemplate.ErrTemplateNotFound,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   `"field":"template_id"`,
+		},
+		{
+			name: "fail: template and prompt together",
+			body: `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+				`"prompt":"A bright modern room","template_id":"` + templateID + `"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "template_id cannot be combined with prompt",
+		},
+		{
+			name: "fail: invalid template id",
+			body: `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+				`"template_id":"cozy"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "template_id must be a valid UUID",
+		},
+		{
+			name: "fail: templates not enabled",
+			body: `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+				`"template_id":"` + templateID + `"}`,
+			noTemplates:  true,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "prompt templates are not enabled",
+		},
+		{
+			name: "fail: render error",
+			body: `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+				`"template_id":"` + templateID + `"}`,
+			renderErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceMock := &ServiceMock{
+				CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
+					return &Image{ID: uuid.New(), Prompt: req.Prompt}, nil
+				},
+			}
+			userRepo := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+			templates := &PromptTemplatesMock{
+				RenderTemplateFunc: func(
+					ctx context.Context, gotUserID, id string, roomType, style *string,
+				) (string, error) {
+					assert.Equal(t, userID.String(), gotUserID)
+					assert.Equal(t, templateID, id)
+					if tc.renderErr != nil {
+						return "", tc.renderErr
+					}
+					return "An " + *style + " " + *roomType + " with warm light", nil
+				},
+			}
+			var tpl PromptTemplates = templates
+			if tc.noTemplates {
+				tpl = nil
+			}
+			h := NewDefaultHandler(serviceMock, nil, userRepo, nil, nil, nil, nil, nil, tpl, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.CreateImage(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.expectPrompt == "" {
+				assert.Empty(t, serviceMock.CreateImageCalls())
+				return
+			}
+			require.Len(t, serviceMock.CreateImageCalls(), 1)
+			assert.Equal(t, tc.expectPrompt, *serviceMock.CreateImageCalls()[0].Req.Prompt)
+		})
+	}
+}
+
+func TestDefaultHandler_BatchCreateImages_PromptTemplate(t *testing.T) {
+	const projectID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	known, unknown := uuid.NewString(), uuid.NewString()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}, nil
+		},
+	}
+	templates := &PromptTemplatesMock{
+		RenderTemplateFunc: func(ctx context.Context, userID, id string, roomType, style *string) (string, error) {
+			if id != known {
+				return "", prompttemplate.ErrTemplateNotFound
+			}
+			return "A cozy modern room with warm light", nil
+		},
+	}
+	item := func(templateID string) string {
+		return `{"project_id":"` + projectID + `","original_url":"http://example.com/a.jpg",` +
+			`"template_id":"` + templateID + `"}`
+	}
+
+	t.Run("success: every template is rendered", func(t *testing.T) {
+		serviceMock := &ServiceMock{
+			BatchCreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error) {
+				return &BatchCreateImagesResponse{Success: len(reqs)}, nil
+			},
+		}
+		h := NewDefaultHandler(serviceMock, nil, userRepo, nil, nil, nil, nil, nil, templates, nil)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/",
+			strings.NewReader(`{"images":[`+item(known)+`,`+item(known)+`]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, h.BatchCreateImages(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		require.Len(t, serviceMock.BatchCreateImagesCalls(), 1)
+		for _, r := range serviceMock.BatchCreateImagesCalls()[0].Reqs {
+			require.NotNil(t, r.Prompt)
+			assert.Equal(t, "A cozy modern room with warm light", *r.Prompt)
+		}
+	})
+
+	t.Run("fail: unknown template is reported by index", func(t *testing.T) {
+		serviceMock := &ServiceMock{}
+		h := NewDefaultHandler(serviceMock, nil, userRepo, nil, nil, nil, nil, nil, templates, nil)
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/",
+			strings.NewReader(`{"images":[`+item(known)+`,`+item(unknown)+`]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, h.BatchCreateImages(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"images[1].template_id"`)
+		assert.Empty(t, serviceMock.BatchCreateImagesCalls())
+	})
+}
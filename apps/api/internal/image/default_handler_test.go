@@ -3,14 +3,21 @@ package image
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/i18n"
+	"github.com/real-staging-ai/api/internal/pagination"
+	"github.com/real-staging-ai/api/internal/promptlib"
 )
 
 func TestDefaultHandler_CreateImage(t *testing.T) {
@@ -74,7 +81,7 @@ func TestDefaultHandler_CreateImage(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock, nil, nil, nil)
+			h := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			if assert.NoError(t, h.CreateImage(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -146,7 +153,7 @@ func TestDefaultHandler_GetImage(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock, nil, nil, nil)
+			h := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			if assert.NoError(t, h.GetImage(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -156,9 +163,11 @@ func TestDefaultHandler_GetImage(t *testing.T) {
 }
 
 func TestDefaultHandler_GetProjectImages(t *testing.T) {
+	cursor := pagination.Cursor{CreatedAt: time.Now(), ID: uuid.New().String()}
 	testCases := []struct {
 		name         string
 		projectID    string
+		query        string
 		setupMock    func(*ServiceMock)
 		expectedCode int
 	}{
@@ -166,12 +175,38 @@ func TestDefaultHandler_GetProjectImages(t *testing.T) {
 			name:      "success: get project images",
 			projectID: uuid.New().String(),
 			setupMock: func(mock *ServiceMock) {
-				mock.GetImagesByProjectIDFunc = func(ctx context.Context, projectID string) ([]*Image, error) {
-					return []*Image{}, nil
+				mock.GetImagesByProjectIDFunc = func(
+					ctx context.Context, projectID string, page pagination.Page,
+				) (*ProjectImagesResponse, error) {
+					return &ProjectImagesResponse{Images: []*Image{}}, nil
+				}
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:      "success: limit and cursor select the page",
+			projectID: uuid.New().String(),
+			query:     "?limit=500&cursor=" + cursor.Encode(),
+			setupMock: func(mock *ServiceMock) {
+				mock.GetImagesByProjectIDFunc = func(
+					ctx context.Context, projectID string, page pagination.Page,
+				) (*ProjectImagesResponse, error) {
+					assert.Equal(t, pagination.MaxLimit, page.Limit)
+					require.NotNil(t, page.After)
+					assert.Equal(t, cursor.ID, page.After.ID)
+					assert.True(t, cursor.CreatedAt.Equal(page.After.CreatedAt))
+					return &ProjectImagesResponse{Images: []*Image{}}, nil
 				}
 			},
 			expectedCode: http.StatusOK,
 		},
+		{
+			name:         "fail: bad request - invalid cursor",
+			projectID:    uuid.New().String(),
+			query:        "?cursor=not-a-cursor",
+			setupMock:    func(mock *ServiceMock) {},
+			expectedCode: http.StatusBadRequest,
+		},
 		{
 			name:         "fail: bad request - missing project ID",
 			projectID:    "",
@@ -188,7 +223,9 @@ func TestDefaultHandler_GetProjectImages(t *testing.T) {
 			name:      "fail: service error",
 			projectID: uuid.New().String(),
 			setupMock: func(mock *ServiceMock) {
-				mock.GetImagesByProjectIDFunc = func(ctx context.Context, projectID string) ([]*Image, error) {
+				mock.GetImagesByProjectIDFunc = func(
+					ctx context.Context, projectID string, page pagination.Page,
+				) (*ProjectImagesResponse, error) {
 					return nil, errors.New("service error")
 				}
 			},
@@ -199,7 +236,7 @@ func TestDefaultHandler_GetProjectImages(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req := httptest.NewRequest(http.MethodGet, "/"+tc.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("project_id")
@@ -208,7 +245,7 @@ func TestDefaultHandler_GetProjectImages(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock, nil, nil, nil)
+			h := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			if assert.NoError(t, h.GetProjectImages(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -280,7 +317,7 @@ func TestDefaultHandler_DeleteImage(t *testing.T) {
 			serviceMock := &ServiceMock{}
 			tc.setupMock(serviceMock)
 
-			h := NewDefaultHandler(serviceMock, nil, nil, nil)
+			h := NewDefaultHandler(serviceMock, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			if assert.NoError(t, h.DeleteImage(c)) {
 				assert.Equal(t, tc.expectedCode, rec.Code)
@@ -365,8 +402,8 @@ func TestDefaultHandler_validateCreateImageRequest(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			h := NewDefaultHandler(nil, nil, nil, nil)
-			errs := h.validateCreateImageRequest(tc.req)
+			h := NewDefaultHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			errs := h.validateCreateImageRequest(context.Background(), tc.req)
 			if tc.expectError {
 				assert.NotEmpty(t, errs)
 			} else {
@@ -375,3 +412,74 @@ func TestDefaultHandler_validateCreateImageRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultHandler_LocalizedErrors(t *testing.T) {
+	t.Run("success: error message follows the request language", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(i18n.WithLanguage(req.Context(), "es"))
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("invalid-uuid")
+
+		h := NewDefaultHandler(&ServiceMock{}, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		require.NoError(t, h.GetImage(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "bad_request", body["code"])
+		assert.Equal(t, "Formato de ID de imagen no válido", body["detail"])
+	})
+
+	t.Run("success: validation details are localized", func(t *testing.T) {
+		ctx := i18n.WithLanguage(context.Background(), "fr")
+		room := "attic"
+
+		h := NewDefaultHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		errs := h.validateCreateImageRequest(ctx, &CreateImageRequest{
+			ProjectID: uuid.New(), OriginalURL: "http://example.com/image.jpg", RoomType: &room,
+		})
+
+		require.Len(t, errs, 1)
+		assert.Equal(t, "room_type", errs[0].Field)
+		assert.Equal(t, "room_type doit être l'une des valeurs suivantes : "+
+			"living_room, bedroom, kitchen, bathroom, dining_room, office, entryway, outdoor", errs[0].Message)
+	})
+}
+
+func TestDefaultHandler_validateCreateImageRequest_Catalog(t *testing.T) {
+	promptlib.SetRoomTypes([]string{"garage", "kitchen"})
+	promptlib.SetStyles([]string{"japandi", "modern"})
+	t.Cleanup(func() {
+		promptlib.SetRoomTypes(nil)
+		promptlib.SetStyles(nil)
+	})
+
+	h := NewDefaultHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	validate := func(roomType, style string) []ValidationErrorDetail {
+		return h.validateCreateImageRequest(context.Background(), &CreateImageRequest{
+			ProjectID: uuid.New(), OriginalURL: "http://example.com/image.jpg", RoomType: &roomType, Style: &style,
+		})
+	}
+
+	t.Run("success: a published room type and style are accepted", func(t *testing.T) {
+		assert.Empty(t, validate("garage", "japandi"))
+	})
+
+	t.Run("fail: a room type without published prompts lists the published ones", func(t *testing.T) {
+		errs := validate("attic", "modern")
+
+		require.Len(t, errs, 1)
+		assert.Equal(t, "room_type", errs[0].Field)
+		assert.Equal(t, "room_type must be one of: garage, kitchen", errs[0].Message)
+	})
+
+	t.Run("fail: a style without published prompts lists the published ones", func(t *testing.T) {
+		errs := validate("kitchen", "industrial")
+
+		require.Len(t, errs, 1)
+		assert.Equal(t, "style", errs[0].Field)
+		assert.Equal(t, "style must be one of: japandi, modern", errs[0].Message)
+	})
+}
@@ -0,0 +1,189 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func trashRepos(userID uuid.UUID, projectErr error) (*user.RepositoryMock, *project.RepositoryMock) {
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	projectRepo := &project.RepositoryMock{
+		GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+			if projectErr != nil {
+				return nil, projectErr
+			}
+			return &project.Project{ID: projectID}, nil
+		},
+	}
+	return userRepo, projectRepo
+}
+
+func TestListTrash(t *testing.T) {
+	projectID := uuid.New()
+	deletedAt := time.Now().Add(-time.Hour)
+
+	testCases := []struct {
+		name         string
+		projectID    string
+		projectErr   error
+		listErr      error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: lists deleted images",
+			projectID:    projectID.String(),
+			expectedCode: http.StatusOK,
+			expectBody:   `"deleted_at"`,
+		},
+		{name: "fail: invalid project id", projectID: "nope", expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: project not accessible",
+			projectID:    projectID.String(),
+			projectErr:   pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: service error",
+			projectID:    projectID.String(),
+			listErr:      errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/"+tc.projectID+"/trash", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.projectID)
+
+			serviceMock := &ServiceMock{
+				ListTrashFunc: func(ctx context.Context, projectID string) ([]*Image, error) {
+					if tc.listErr != nil {
+						return nil, tc.listErr
+					}
+					return []*Image{{ID: uuid.New(), Status: StatusReady, DeletedAt: &deletedAt}}, nil
+				},
+			}
+			userRepo, projectRepo := trashRepos(uuid.New(), tc.projectErr)
+
+			handler := NewDefaultHandler(serviceMock, nil, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.ListTrash(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
+
+func TestRestoreImage(t *testing.T) {
+	imageID := uuid.New()
+	projectID := uuid.New()
+
+	testCases := []struct {
+		name          string
+		imageID       string
+		getErr        error
+		projectErr    error
+		restoreErr    error
+		expectedCode  int
+		expectRestore bool
+	}{
+		{
+			name:          "success: restores image",
+			imageID:       imageID.String(),
+			expectedCode:  http.StatusOK,
+			expectRestore: true,
+		},
+		{name: "fail: invalid image id", imageID: "nope", expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: not in trash",
+			imageID:      imageID.String(),
+			getErr:       pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: project not accessible",
+			imageID:      imageID.String(),
+			projectErr:   pgx.ErrNoRows,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:          "fail: purged since lookup",
+			imageID:       imageID.String(),
+			restoreErr:    pgx.ErrNoRows,
+			expectedCode:  http.StatusNotFound,
+			expectRestore: true,
+		},
+		{
+			name:          "fail: service error",
+			imageID:       imageID.String(),
+			restoreErr:    errors.New("db down"),
+			expectedCode:  http.StatusInternalServerError,
+			expectRestore: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/images/"+tc.imageID+"/restore", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			deletedAt := time.Now()
+			serviceMock := &ServiceMock{
+				GetDeletedImageByIDFunc: func(ctx context.Context, id string) (*Image, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Image{ID: imageID, ProjectID: projectID, Status: StatusReady, DeletedAt: &deletedAt}, nil
+				},
+				RestoreImageFunc: func(ctx context.Context, id string) (*Image, error) {
+					if tc.restoreErr != nil {
+						return nil, tc.restoreErr
+					}
+					return &Image{ID: imageID, ProjectID: projectID, Status: StatusReady}, nil
+				},
+			}
+			userRepo, projectRepo := trashRepos(uuid.New(), tc.projectErr)
+
+			handler := NewDefaultHandler(serviceMock, nil, userRepo, projectRepo, nil, nil, nil, nil, nil, nil)
+			require.NoError(t, handler.RestoreImage(c))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectRestore {
+				require.Len(t, serviceMock.RestoreImageCalls(), 1)
+				assert.Equal(t, tc.imageID, serviceMock.RestoreImageCalls()[0].ImageID)
+			} else {
+				assert.Empty(t, serviceMock.RestoreImageCalls())
+			}
+		})
+	}
+}
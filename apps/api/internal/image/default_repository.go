@@ -2,11 +2,17 @@ package image
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/pagination"
+	"github.com/real-staging-ai/api/internal/project"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
@@ -24,9 +30,10 @@ func NewDefaultRepository(db storage.Database) *DefaultRepository {
 	return &DefaultRepository{db: db}
 }
 
-// CreateImage creates a new image in the database.
+// CreateImage creates a new image in the database, staged in the given mode.
 func (r *DefaultRepository) CreateImage(
 	ctx context.Context, projectID string, originalURL string, roomType, style *string, seed *int64, prompt *string,
+	mode string,
 ) (*queries.Image, error) {
 	q := queries.New(r.db)
 
@@ -61,6 +68,7 @@ func (r *DefaultRepository) CreateImage(
 		Style:       styleText,
 		Seed:        seedInt8,
 		Prompt:      promptText,
+		Mode:        mode,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create image: %w", err)
@@ -79,27 +87,45 @@ func (r *DefaultRepository) CreateImage(
 		Error:       row.Error,
 		CreatedAt:   row.CreatedAt,
 		UpdatedAt:   row.UpdatedAt,
+		Sandbox:     row.Sandbox,
+		Mode:        row.Mode,
 	}
 
 	return image, nil
 }
 
-// GetImageByID retrieves a specific image by its ID.
-func (r *DefaultRepository) GetImageByID(ctx context.Context, imageID string) (*queries.Image, error) {
+// CreateImageVariant creates a new image from a live source image, sharing its
+// original and taking a reference on it.
+func (r *DefaultRepository) CreateImageVariant(
+	ctx context.Context, sourceImageID string, roomType, style *string, seed *int64, prompt *string,
+) (*queries.Image, error) {
 	q := queries.New(r.db)
 
-	imageUUID, err := uuid.Parse(imageID)
+	sourceUUID, err := uuid.Parse(sourceImageID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid image ID: %w", err)
 	}
 
-	row, err := q.GetImageByID(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	params := queries.CreateImageVariantParams{ID: pgtype.UUID{Bytes: sourceUUID, Valid: true}}
+	if roomType != nil {
+		params.RoomType = pgtype.Text{String: *roomType, Valid: true}
+	}
+	if style != nil {
+		params.Style = pgtype.Text{String: *style, Valid: true}
+	}
+	if seed != nil {
+		params.Seed = pgtype.Int8{Int64: *seed, Valid: true}
+	}
+	if prompt != nil {
+		params.Prompt = pgtype.Text{String: *prompt, Valid: true}
+	}
+
+	row, err := q.CreateImageVariant(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get image: %w", err)
+		return nil, fmt.Errorf("failed to create image variant: %w", err)
 	}
 
-	// Convert GetImageByIDRow to Image
-	image := &queries.Image{
+	return &queries.Image{
 		ID:          row.ID,
 		ProjectID:   row.ProjectID,
 		OriginalUrl: row.OriginalUrl,
@@ -107,10 +133,82 @@ func (r *DefaultRepository) GetImageByID(ctx context.Context, imageID string) (*
 		RoomType:    row.RoomType,
 		Style:       row.Style,
 		Seed:        row.Seed,
+		Prompt:      row.Prompt,
 		Status:      row.Status,
 		Error:       row.Error,
 		CreatedAt:   row.CreatedAt,
 		UpdatedAt:   row.UpdatedAt,
+		Sandbox:     row.Sandbox,
+		MaskUrl:     row.MaskUrl,
+		Mode:        row.Mode,
+	}, nil
+}
+
+// WithCredit creates the image and debits the credit in one transaction. A
+// concurrent create that takes the user's last credit first makes the debit
+// report false, which rolls this image back.
+func (r *DefaultRepository) WithCredit(
+	ctx context.Context, userID string, create func(repo Repository) (*queries.Image, error),
+) (*queries.Image, error) {
+	var created *queries.Image
+	err := storage.InTx(ctx, r.db, func(tx storage.Database) error {
+		img, err := create(NewDefaultRepository(tx))
+		if err != nil {
+			return err
+		}
+		debited, err := credit.NewDefaultRepository(tx).Debit(ctx, userID, img.ID.String())
+		if err != nil {
+			return err
+		}
+		if !debited {
+			return ErrNoCredits
+		}
+		created = img
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetImageByID retrieves a specific image by its ID.
+func (r *DefaultRepository) GetImageByID(ctx context.Context, imageID string) (*queries.Image, error) {
+	q := queries.New(r.db)
+
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	row, err := q.GetImageByID(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	// Convert GetImageByIDRow to Image
+	image := &queries.Image{
+		ID:                 row.ID,
+		ProjectID:          row.ProjectID,
+		OriginalUrl:        row.OriginalUrl,
+		StagedUrl:          row.StagedUrl,
+		RoomType:           row.RoomType,
+		Style:              row.Style,
+		Seed:               row.Seed,
+		Prompt:             row.Prompt,
+		Status:             row.Status,
+		Error:              row.Error,
+		CreatedAt:          row.CreatedAt,
+		UpdatedAt:          row.UpdatedAt,
+		Blurhash:           row.Blurhash,
+		ErrorCode:          row.ErrorCode,
+		ModelUsed:          row.ModelUsed,
+		PrimaryImageID:     row.PrimaryImageID,
+		MaskUrl:            row.MaskUrl,
+		Mode:               row.Mode,
+		DetectedRoomType:   row.DetectedRoomType,
+		RoomTypeConfidence: row.RoomTypeConfidence,
+		StagedContentType:  row.StagedContentType,
 	}
 
 	return image, nil
@@ -134,23 +232,143 @@ func (r *DefaultRepository) GetImagesByProjectID(ctx context.Context, projectID
 	images := make([]*queries.Image, len(rows))
 	for i, row := range rows {
 		images[i] = &queries.Image{
-			ID:          row.ID,
-			ProjectID:   row.ProjectID,
-			OriginalUrl: row.OriginalUrl,
-			StagedUrl:   row.StagedUrl,
-			RoomType:    row.RoomType,
-			Style:       row.Style,
-			Seed:        row.Seed,
-			Status:      row.Status,
-			Error:       row.Error,
-			CreatedAt:   row.CreatedAt,
-			UpdatedAt:   row.UpdatedAt,
+			ID:                row.ID,
+			ProjectID:         row.ProjectID,
+			OriginalUrl:       row.OriginalUrl,
+			StagedUrl:         row.StagedUrl,
+			RoomType:          row.RoomType,
+			Style:             row.Style,
+			Seed:              row.Seed,
+			Status:            row.Status,
+			Error:             row.Error,
+			CreatedAt:         row.CreatedAt,
+			UpdatedAt:         row.UpdatedAt,
+			Blurhash:          row.Blurhash,
+			ErrorCode:         row.ErrorCode,
+			StagedContentType: row.StagedContentType,
 		}
 	}
 
 	return images, nil
 }
 
+// pageColumns are the image columns the listing query reads, in scanPagedImage order.
+const pageColumns = `
+	id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error,
+	created_at, updated_at, sandbox, blurhash, error_code, original_image_id, model_used,
+	processing_time_ms, replicate_prediction_id, primary_image_id, staged_content_type`
+
+func scanPagedImage(row pgx.Row) (*queries.Image, error) {
+	var img queries.Image
+	err := row.Scan(
+		&img.ID, &img.ProjectID, &img.OriginalUrl, &img.StagedUrl, &img.RoomType, &img.Style, &img.Seed,
+		&img.Prompt, &img.Status, &img.Error, &img.CreatedAt, &img.UpdatedAt, &img.Sandbox, &img.Blurhash,
+		&img.ErrorCode, &img.OriginalImageID, &img.ModelUsed, &img.ProcessingTimeMs, &img.ReplicatePredictionID,
+		&img.PrimaryImageID, &img.StagedContentType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &img, nil
+}
+
+// ListImagesByProjectID lists a page of a project's live images, newest first.
+func (r *DefaultRepository) ListImagesByProjectID(
+	ctx context.Context, projectID string, page pagination.Page,
+) ([]*queries.Image, *string, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	query := `SELECT` + pageColumns + `
+		FROM images
+		WHERE project_id = $1 AND deleted_at IS NULL
+		  AND ($2::timestamptz IS NULL OR (created_at, id) < ($2, $3::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4`
+
+	afterTime, afterID := page.AfterArgs()
+	rows, err := r.db.Query(ctx, query, projectUUID, afterTime, afterID, page.Fetch())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	defer rows.Close()
+
+	images := []*queries.Image{}
+	for rows.Next() {
+		img, err := scanPagedImage(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan image: %w", err)
+		}
+		images = append(images, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating over image rows: %w", err)
+	}
+
+	images, next := pagination.Trim(images, page, func(img *queries.Image) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: img.CreatedAt.Time, ID: formatUUID(img.ID.Bytes)}
+	})
+	return images, next, nil
+}
+
+// searchDocument is the text image search matches. It must stay identical to
+// the expression of the idx_images_search index.
+const searchDocument = `to_tsvector('english', coalesce(prompt, '') || ' ' ||
+	translate(coalesce(room_type, '') || ' ' || coalesce(style, '') || ' ' || coalesce(model_used, ''), '_/-', '   '))`
+
+// SearchImages lists a page of the images matching query in the projects a
+// user can access, newest first.
+func (r *DefaultRepository) SearchImages(
+	ctx context.Context, userID string, query SearchQuery, page pagination.Page,
+) ([]*queries.Image, *string, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	stmt := `SELECT` + pageColumns + `
+		FROM images
+		WHERE deleted_at IS NULL
+		  AND project_id IN (
+			SELECT p.id FROM projects p
+			WHERE p.user_id = $1 OR EXISTS (
+				SELECT 1 FROM organization_members m
+				WHERE m.org_id = p.org_id AND m.user_id = $1
+			)
+		  )
+		  AND ($2::text = '' OR ` + searchDocument + ` @@ websearch_to_tsquery('english', $2))
+		  AND ($3::bigint IS NULL OR seed = $3)
+		  AND ($4::timestamptz IS NULL OR (created_at, id) < ($4, $5::uuid))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $6`
+
+	afterTime, afterID := page.AfterArgs()
+	rows, err := r.db.Query(ctx, stmt, userUUID, query.Terms, query.Seed, afterTime, afterID, page.Fetch())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search images: %w", err)
+	}
+	defer rows.Close()
+
+	images := []*queries.Image{}
+	for rows.Next() {
+		img, err := scanPagedImage(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan image: %w", err)
+		}
+		images = append(images, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating over image rows: %w", err)
+	}
+
+	images, next := pagination.Trim(images, page, func(img *queries.Image) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: img.CreatedAt.Time, ID: formatUUID(img.ID.Bytes)}
+	})
+	return images, next, nil
+}
+
 // UpdateImageStatus updates an image's processing status.
 func (r *DefaultRepository) UpdateImageStatus(
 	ctx context.Context, imageID string, status string,
@@ -282,7 +500,8 @@ func (r *DefaultRepository) DeleteImage(ctx context.Context, imageID string) err
 	return nil
 }
 
-// DeleteImagesByProjectID deletes all images for a specific project.
+// DeleteImagesByProjectID deletes all images for a specific project. It
+// returns project.ErrProjectLocked when the project is locked.
 func (r *DefaultRepository) DeleteImagesByProjectID(ctx context.Context, projectID string) error {
 	q := queries.New(r.db)
 
@@ -291,6 +510,15 @@ func (r *DefaultRepository) DeleteImagesByProjectID(ctx context.Context, project
 		return fmt.Errorf("invalid project ID: %w", err)
 	}
 
+	// The delete itself skips locked projects; checking first tells the caller why.
+	proj, err := q.GetProjectByID(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if err == nil && proj.Locked {
+		return project.ErrProjectLocked
+	}
+
 	err = q.DeleteImagesByProjectID(ctx, pgtype.UUID{Bytes: projectUUID, Valid: true})
 	if err != nil {
 		return fmt.Errorf("failed to delete images: %w", err)
@@ -400,3 +628,250 @@ func (r *DefaultRepository) GetProjectCostSummary(ctx context.Context, projectID
 
 	return &summary, nil
 }
+
+// trashColumns are the image columns the trash queries read, in scanTrashedImage order.
+const trashColumns = `
+	id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error,
+	created_at, updated_at, deleted_at, sandbox, blurhash, error_code, staged_content_type`
+
+func scanTrashedImage(row pgx.Row) (*queries.Image, error) {
+	var img queries.Image
+	err := row.Scan(
+		&img.ID, &img.ProjectID, &img.OriginalUrl, &img.StagedUrl, &img.RoomType, &img.Style, &img.Seed,
+		&img.Prompt, &img.Status, &img.Error, &img.CreatedAt, &img.UpdatedAt, &img.DeletedAt, &img.Sandbox,
+		&img.Blurhash, &img.ErrorCode, &img.StagedContentType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &img, nil
+}
+
+// ListDeletedImagesByProjectID lists a project's soft-deleted images.
+func (r *DefaultRepository) ListDeletedImagesByProjectID(
+	ctx context.Context, projectID string,
+) ([]*queries.Image, error) {
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	query := `SELECT` + trashColumns + `
+		FROM images
+		WHERE project_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC`
+
+	rows, err := r.db.Query(ctx, query, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted images: %w", err)
+	}
+	defer rows.Close()
+
+	images := []*queries.Image{}
+	for rows.Next() {
+		img, err := scanTrashedImage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deleted image: %w", err)
+		}
+		images = append(images, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over deleted image rows: %w", err)
+	}
+	return images, nil
+}
+
+// GetDeletedImageByID retrieves a soft-deleted image.
+func (r *DefaultRepository) GetDeletedImageByID(ctx context.Context, imageID string) (*queries.Image, error) {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	query := `SELECT` + trashColumns + ` FROM images WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	img, err := scanTrashedImage(r.db.QueryRow(ctx, query, imageUUID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted image: %w", err)
+	}
+	return img, nil
+}
+
+// RestoreImage clears the image's deleted_at. A restored error image starts
+// its cleanup period over, notice included.
+func (r *DefaultRepository) RestoreImage(ctx context.Context, imageID string) (*queries.Image, error) {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	query := `
+		UPDATE images SET deleted_at = NULL, cleanup_notified_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING` + trashColumns
+
+	img, err := scanTrashedImage(r.db.QueryRow(ctx, query, imageUUID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore image: %w", err)
+	}
+	return img, nil
+}
+
+// SetImageApproval stores the verdict on a live image.
+func (r *DefaultRepository) SetImageApproval(ctx context.Context, imageID string, approved *bool) error {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	query := `UPDATE images SET approved = $2 WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, imageUUID, approved)
+	if err != nil {
+		return fmt.Errorf("failed to set image approval: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SetImageMask stores the mask that limits the image's staging.
+func (r *DefaultRepository) SetImageMask(ctx context.Context, imageID, maskURL string) error {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	query := `UPDATE images SET mask_url = $2, updated_at = now() WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, imageUUID, maskURL)
+	if err != nil {
+		return fmt.Errorf("failed to set image mask: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CancelImage sets the image to canceled and appends the image_events row in
+// the same statement, so the worker can't finish the image in between.
+func (r *DefaultRepository) CancelImage(ctx context.Context, imageID string) (*queries.Image, error) {
+	imageUUID, err := uuid.Parse(imageID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	query := `
+		WITH canceled AS (
+			UPDATE images SET status = 'canceled', updated_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL AND status IN ('queued', 'processing')
+			RETURNING *
+		), event AS (
+			INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, processing_time_ms)
+			SELECT id, status, 'api', prompt, model_used, cost_usd, processing_time_ms FROM canceled
+		)
+		SELECT` + pageColumns + `
+		FROM canceled`
+
+	img, err := scanPagedImage(r.db.QueryRow(ctx, query, imageUUID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel image: %w", err)
+	}
+	return img, nil
+}
+
+// PurgeDeletedImages hard-deletes expired images from the trash in one
+// statement. Rows are locked as they are picked, so a restore racing the purge
+// either wins or waits and then finds nothing to restore. Cut-outs, jobs and
+// history go with the image through their foreign keys; their files are
+// collected before the rows disappear.
+func (r *DefaultRepository) PurgeDeletedImages(
+	ctx context.Context, deletedBefore, createdBefore time.Time, limit int,
+) ([]PurgedImage, error) {
+	query := `
+		WITH expired AS (
+			SELECT i.id
+			FROM images i
+			JOIN projects p ON p.id = i.project_id
+			WHERE i.deleted_at < $1
+			  AND i.created_at < $2
+			  AND NOT p.locked
+			ORDER BY i.deleted_at
+			LIMIT $3
+			FOR UPDATE OF i SKIP LOCKED
+		), purged AS (
+			DELETE FROM images i
+			WHERE i.id IN (SELECT id FROM expired)
+			RETURNING i.id, i.original_image_id, i.staged_url,
+				CASE WHEN i.original_image_id IS NULL AND NOT EXISTS (
+					SELECT 1 FROM images o
+					WHERE o.original_url = i.original_url AND o.id NOT IN (SELECT id FROM expired)
+				) THEN i.original_url END AS own_original_url
+		)
+		SELECT purged.id, purged.original_image_id, purged.staged_url, purged.own_original_url,
+			COALESCE(array_agg(a.url) FILTER (WHERE a.url IS NOT NULL), '{}')::text[]
+		FROM purged
+		LEFT JOIN image_assets a ON a.image_id = purged.id
+		GROUP BY purged.id, purged.original_image_id, purged.staged_url, purged.own_original_url`
+
+	rows, err := r.db.Query(ctx, query, deletedBefore, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge deleted images: %w", err)
+	}
+	defer rows.Close()
+
+	purged := []PurgedImage{}
+	for rows.Next() {
+		var (
+			id, originalImageID       pgtype.UUID
+			stagedURL, ownOriginalURL pgtype.Text
+			assetURLs                 []string
+		)
+		if err := rows.Scan(&id, &originalImageID, &stagedURL, &ownOriginalURL, &assetURLs); err != nil {
+			return nil, fmt.Errorf("failed to scan purged image: %w", err)
+		}
+
+		p := PurgedImage{ID: id.Bytes}
+		if originalImageID.Valid {
+			p.OriginalImageID = formatUUID(originalImageID.Bytes)
+		}
+		for _, f := range []pgtype.Text{stagedURL, ownOriginalURL} {
+			if f.Valid && f.String != "" {
+				p.Files = append(p.Files, f.String)
+			}
+		}
+		p.Files = append(p.Files, assetURLs...)
+		purged = append(purged, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over purged image rows: %w", err)
+	}
+	return purged, nil
+}
+
+// ListThumbnails returns the thumbnails of the images' files.
+func (r *DefaultRepository) ListThumbnails(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+	query := `
+		SELECT image_id::text, source, size, url
+		FROM image_assets
+		WHERE image_id = ANY($1::uuid[]) AND kind = 'thumbnail'`
+
+	rows, err := r.db.Query(ctx, query, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list thumbnails: %w", err)
+	}
+	defer rows.Close()
+
+	thumbs := []Thumbnail{}
+	for rows.Next() {
+		var t Thumbnail
+		if err := rows.Scan(&t.ImageID, &t.Source, &t.Size, &t.URL); err != nil {
+			return nil, fmt.Errorf("failed to scan thumbnail: %w", err)
+		}
+		thumbs = append(thumbs, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over thumbnail rows: %w", err)
+	}
+	return thumbs, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
@@ -62,11 +64,13 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 						pgtype.Text{String: "modern", Valid: true},
 						pgtype.Int8{Int64: 123, Valid: true},
 						pgtype.Text{},
+						"declutter",
 					).
 					WillReturnRows(
 						pgxmock.NewRows([]string{
 							"id", "project_id", "original_url", "staged_url",
 							"room_type", "style", "seed", "prompt", "status", "error", "created_at", "updated_at", "deleted_at",
+							"sandbox", "mode",
 						}).
 							AddRow(
 								pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -77,6 +81,7 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 								pgtype.Int8{Int64: 123, Valid: true},
 								pgtype.Text{},
 								"queued", pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{},
+								false, "declutter",
 							))
 			},
 			expectError: false,
@@ -104,6 +109,7 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 						pgtype.Text{String: "modern", Valid: true},
 						pgtype.Int8{Int64: 123, Valid: true},
 						pgtype.Text{},
+						"declutter",
 					).
 					WillReturnError(errors.New("db error"))
 			},
@@ -114,12 +120,13 @@ func TestDefaultRepository_CreateImage(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMock(poolMock)
-			_, err := repo.CreateImage(ctx, tc.projectID, tc.originalURL, tc.roomType, tc.style, tc.seed, nil)
+			img, err := repo.CreateImage(ctx, tc.projectID, tc.originalURL, tc.roomType, tc.style, tc.seed, nil, "declutter")
 
 			if tc.expectError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, "declutter", img.Mode)
 			}
 			assert.NoError(t, poolMock.ExpectationsWereMet())
 		})
@@ -143,10 +150,18 @@ func TestDefaultRepository_GetImageByID(t *testing.T) {
 	imageID := uuid.New()
 
 	testCases := []struct {
-		name        string
-		imageID     string
-		setupMock   func(mock pgxmock.PgxPoolIface)
-		expectError bool
+		name          string
+		imageID       string
+		setupMock     func(mock pgxmock.PgxPoolIface)
+		expectError   bool
+		wantBlurhash  string
+		wantErrorCode string
+		wantPrompt    string
+		wantModelUsed string
+		wantMaskURL   string
+		wantMode      string
+		wantDetected  string
+		wantStagedCT  string
 	}{
 		{
 			name:    "success: get image by id",
@@ -160,6 +175,8 @@ func TestDefaultRepository_GetImageByID(t *testing.T) {
 						pgxmock.NewRows([]string{
 							"id", "project_id", "original_url", "staged_url",
 							"room_type", "style", "seed", "prompt", "status", "error", "created_at", "updated_at", "deleted_at",
+							"blurhash", "error_code", "model_used", "primary_image_id", "mask_url", "mode",
+							"detected_room_type", "room_type_confidence", "staged_content_type",
 						}).
 							AddRow(
 								pgtype.UUID{Bytes: uuid.New(), Valid: true},
@@ -168,11 +185,28 @@ func TestDefaultRepository_GetImageByID(t *testing.T) {
 								pgtype.Text{String: "living_room", Valid: true},
 								pgtype.Text{String: "modern", Valid: true},
 								pgtype.Int8{Int64: 123, Valid: true},
-								pgtype.Text{},
+								pgtype.Text{String: "bright and airy staging", Valid: true},
 								"queued", pgtype.Text{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{},
+								pgtype.Text{String: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", Valid: true},
+								pgtype.Text{String: "corrupt_image", Valid: true},
+								pgtype.Text{String: "qwen/qwen-image-edit:abc123", Valid: true},
+								pgtype.UUID{},
+								pgtype.Text{String: "https://x/masks/sofa.png", Valid: true},
+								"both",
+								pgtype.Text{String: "bedroom", Valid: true},
+								pgtype.Float8{Float64: 0.87, Valid: true},
+								pgtype.Text{String: "image/webp", Valid: true},
 							))
 			},
-			expectError: false,
+			expectError:   false,
+			wantBlurhash:  "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+			wantErrorCode: "corrupt_image",
+			wantPrompt:    "bright and airy staging",
+			wantModelUsed: "qwen/qwen-image-edit:abc123",
+			wantMaskURL:   "https://x/masks/sofa.png",
+			wantMode:      "both",
+			wantDetected:  "bedroom",
+			wantStagedCT:  "image/webp",
 		},
 		{
 			name:        "fail: invalid image ID",
@@ -221,12 +255,20 @@ func TestDefaultRepository_GetImageByID(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.setupMock(poolMock)
-			_, err := repo.GetImageByID(ctx, tc.imageID)
+			img, err := repo.GetImageByID(ctx, tc.imageID)
 
 			if tc.expectError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tc.wantBlurhash, img.Blurhash.String)
+				assert.Equal(t, tc.wantErrorCode, img.ErrorCode.String)
+				assert.Equal(t, tc.wantPrompt, img.Prompt.String)
+				assert.Equal(t, tc.wantModelUsed, img.ModelUsed.String)
+				assert.Equal(t, tc.wantMaskURL, img.MaskUrl.String)
+				assert.Equal(t, tc.wantMode, img.Mode)
+				assert.Equal(t, tc.wantDetected, img.DetectedRoomType.String)
+				assert.Equal(t, tc.wantStagedCT, img.StagedContentType.String)
 			}
 			assert.NoError(t, poolMock.ExpectationsWereMet())
 		})
@@ -304,6 +346,162 @@ func TestDefaultRepository_GetImagesByProjectID(t *testing.T) {
 	}
 }
 
+func TestDefaultRepository_ListImagesByProjectID(t *testing.T) {
+	ctx := context.Background()
+	projectID := uuid.New()
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{
+		"id", "project_id", "original_url", "staged_url", "room_type", "style", "seed", "prompt", "status", "error",
+		"created_at", "updated_at", "sandbox", "blurhash", "error_code", "original_image_id", "model_used",
+		"processing_time_ms", "replicate_prediction_id", "primary_image_id", "staged_content_type",
+	}
+	row := func(id uuid.UUID, at time.Time) []any {
+		return []any{
+			pgtype.UUID{Bytes: id, Valid: true}, pgtype.UUID{Bytes: projectID, Valid: true},
+			pgtype.Text{String: "https://x/a.jpg", Valid: true}, pgtype.Text{}, pgtype.Text{}, pgtype.Text{},
+			pgtype.Int8{}, pgtype.Text{}, queries.ImageStatusReady, pgtype.Text{},
+			pgtype.Timestamptz{Time: at, Valid: true}, pgtype.Timestamptz{Time: at, Valid: true}, false,
+			pgtype.Text{}, pgtype.Text{}, pgtype.UUID{}, pgtype.Text{}, pgtype.Int4{}, pgtype.Text{},
+			pgtype.UUID{}, pgtype.Text{String: "image/webp", Valid: true},
+		}
+	}
+	first, second := uuid.New(), uuid.New()
+	after := &pagination.Cursor{CreatedAt: created.Add(time.Hour), ID: uuid.New().String()}
+
+	testCases := []struct {
+		name      string
+		page      pagination.Page
+		rows      [][]any
+		wantLen   int
+		wantNext  *pagination.Cursor
+		wantAfter bool
+	}{
+		{
+			name:    "success: last page has no cursor",
+			page:    pagination.Page{Limit: 2},
+			rows:    [][]any{row(first, created)},
+			wantLen: 1,
+		},
+		{
+			name:      "success: extra row yields the next cursor",
+			page:      pagination.Page{Limit: 1, After: after},
+			rows:      [][]any{row(first, created), row(second, created.Add(-time.Minute))},
+			wantLen:   1,
+			wantNext:  &pagination.Cursor{CreatedAt: created, ID: first.String()},
+			wantAfter: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer poolMock.Close()
+			repo := NewDefaultRepository(&storage.DatabaseMock{
+				QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+					return poolMock.Query(ctx, sql, args...)
+				},
+			})
+
+			afterTime, afterID := tc.page.AfterArgs()
+			rows := pgxmock.NewRows(columns)
+			for _, r := range tc.rows {
+				rows.AddRow(r...)
+			}
+			poolMock.ExpectQuery(`FROM images\s+WHERE project_id = \$1 AND deleted_at IS NULL\s+`+
+				`AND \(\$2::timestamptz IS NULL OR \(created_at, id\) < \(\$2, \$3::uuid\)\)\s+`+
+				`ORDER BY created_at DESC, id DESC\s+LIMIT \$4`).
+				WithArgs(projectID, afterTime, afterID, tc.page.Limit+1).
+				WillReturnRows(rows)
+
+			images, next, err := repo.ListImagesByProjectID(ctx, projectID.String(), tc.page)
+			require.NoError(t, err)
+			require.Len(t, images, tc.wantLen)
+			assert.Equal(t, "image/webp", images[0].StagedContentType.String)
+			assert.Equal(t, tc.wantAfter, afterTime != nil)
+			if tc.wantNext == nil {
+				assert.Nil(t, next)
+			} else {
+				require.NotNil(t, next)
+				got, err := pagination.Decode(*next)
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantNext.ID, got.ID)
+				assert.True(t, tc.wantNext.CreatedAt.Equal(got.CreatedAt))
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("fail: invalid project ID", func(t *testing.T) {
+		repo := NewDefaultRepository(&storage.DatabaseMock{})
+		_, _, err := repo.ListImagesByProjectID(ctx, "invalid-uuid", pagination.First())
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultRepository_SearchImages(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	seed := int64(42)
+	columns := []string{
+		"id", "project_id", "original_url", "staged_url", "room_type", "style", "seed", "prompt", "status", "error",
+		"created_at", "updated_at", "sandbox", "blurhash", "error_code", "original_image_id", "model_used",
+		"processing_time_ms", "replicate_prediction_id", "primary_image_id", "staged_content_type",
+	}
+	now := time.Now()
+
+	t.Run("success: passes the terms, seed and page", func(t *testing.T) {
+		poolMock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer poolMock.Close()
+		repo := NewDefaultRepository(&storage.DatabaseMock{
+			QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				return poolMock.Query(ctx, sql, args...)
+			},
+		})
+
+		page := pagination.Page{Limit: 20}
+		afterTime, afterID := page.AfterArgs()
+		rows := pgxmock.NewRows(columns).AddRow(
+			pgtype.UUID{Bytes: uuid.New(), Valid: true}, pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			pgtype.Text{String: "https://x/a.jpg", Valid: true}, pgtype.Text{},
+			pgtype.Text{String: "bedroom", Valid: true}, pgtype.Text{String: "scandinavian", Valid: true},
+			pgtype.Int8{Int64: seed, Valid: true}, pgtype.Text{}, queries.ImageStatusReady, pgtype.Text{},
+			pgtype.Timestamptz{Time: now, Valid: true}, pgtype.Timestamptz{Time: now, Valid: true}, false,
+			pgtype.Text{}, pgtype.Text{}, pgtype.UUID{}, pgtype.Text{}, pgtype.Int4{}, pgtype.Text{},
+			pgtype.UUID{}, pgtype.Text{String: "image/png", Valid: true},
+		)
+		poolMock.ExpectQuery(`websearch_to_tsquery\('english', \$2\)\)\s+AND \(\$3::bigint IS NULL OR seed = \$3\)`).
+			WithArgs(userID, "scandinavian bedroom", &seed, afterTime, afterID, int32(21)).
+			WillReturnRows(rows)
+
+		query := SearchQuery{Terms: "scandinavian bedroom", Seed: &seed}
+		images, next, err := repo.SearchImages(ctx, userID.String(), query, page)
+		require.NoError(t, err)
+		require.Len(t, images, 1)
+		assert.Equal(t, "scandinavian", images[0].Style.String)
+		assert.Equal(t, "image/png", images[0].StagedContentType.String)
+		assert.Nil(t, next)
+		assert.NoError(t, poolMock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		repo := NewDefaultRepository(&storage.DatabaseMock{
+			QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				return nil, errors.New("db down")
+			},
+		})
+		_, _, err := repo.SearchImages(ctx, userID.String(), SearchQuery{Terms: "kitchen"}, pagination.First())
+		assert.ErrorContains(t, err, "failed to search images")
+	})
+
+	t.Run("fail: invalid user ID", func(t *testing.T) {
+		repo := NewDefaultRepository(&storage.DatabaseMock{})
+		_, _, err := repo.SearchImages(ctx, "invalid-uuid", SearchQuery{Terms: "kitchen"}, pagination.First())
+		assert.Error(t, err)
+	})
+}
+
 func TestDefaultRepository_UpdateImageStatus(t *testing.T) {
 	ctx := context.Background()
 	poolMock, err := pgxmock.NewPool()
@@ -670,11 +868,24 @@ func TestDefaultRepository_DeleteImagesByProjectID(t *testing.T) {
 		ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
 			return poolMock.Exec(ctx, sql, args...)
 		},
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			return poolMock.QueryRow(ctx, sql, args...)
+		},
 	}
 
 	repo := NewDefaultRepository(dbMock)
 
 	projectID := uuid.New()
+	expectProject := func(locked bool) {
+		poolMock.ExpectQuery(
+			"SELECT id, name, user_id, created_at, org_id, locked, watermark, model_id, locale FROM projects").
+			WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"id", "name", "user_id", "created_at", "org_id", "locked", "watermark", "model_id", "locale",
+			}).
+				AddRow(pgtype.UUID{Bytes: projectID, Valid: true}, "Listing", pgtype.UUID{}, pgtype.Timestamptz{},
+					pgtype.UUID{}, locked, false, pgtype.Text{}, pgtype.Text{}))
+	}
 
 	testCases := []struct {
 		name        string
@@ -687,6 +898,7 @@ func TestDefaultRepository_DeleteImagesByProjectID(t *testing.T) {
 			name:      "success: delete images by project id",
 			projectID: projectID.String(),
 			setupMock: func() {
+				expectProject(false)
 				poolMock.ExpectExec("DELETE FROM images WHERE project_id = \\$1").
 					WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}).
 					WillReturnResult(pgxmock.NewResult("DELETE", 2))
@@ -704,6 +916,7 @@ func TestDefaultRepository_DeleteImagesByProjectID(t *testing.T) {
 			name:      "fail: query error",
 			projectID: projectID.String(),
 			setupMock: func() {
+				expectProject(false)
 				poolMock.ExpectExec("DELETE FROM images WHERE project_id = \\$1").
 					WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}).
 					WillReturnError(errors.New("database error"))
@@ -711,10 +924,20 @@ func TestDefaultRepository_DeleteImagesByProjectID(t *testing.T) {
 			expectError: true,
 			errorMsg:    "failed to delete images",
 		},
+		{
+			name:      "fail: project locked",
+			projectID: projectID.String(),
+			setupMock: func() {
+				expectProject(true)
+			},
+			expectError: true,
+			errorMsg:    "project is locked",
+		},
 		{
 			name:      "success: no images found (0 rows affected)",
 			projectID: projectID.String(),
 			setupMock: func() {
+				expectProject(false)
 				poolMock.ExpectExec("DELETE FROM images WHERE project_id = \\$1").
 					WithArgs(pgtype.UUID{Bytes: projectID, Valid: true}).
 					WillReturnResult(pgxmock.NewResult("DELETE", 0))
@@ -740,3 +963,208 @@ func TestDefaultRepository_DeleteImagesByProjectID(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultRepository_RestoreImage(t *testing.T) {
+	ctx := context.Background()
+	imageID := uuid.New()
+	projectID := uuid.New()
+
+	testCases := []struct {
+		name      string
+		setupMock func(mock pgxmock.PgxPoolIface)
+		expectErr error
+	}{
+		{
+			name: "success: restores deleted image",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`UPDATE images SET deleted_at = NULL`).
+					WithArgs(imageID).
+					WillReturnRows(pgxmock.NewRows([]string{
+						"id", "project_id", "original_url", "staged_url", "room_type", "style", "seed", "prompt",
+						"status", "error", "created_at", "updated_at", "deleted_at", "sandbox", "blurhash",
+						"error_code", "staged_content_type",
+					}).AddRow(
+						pgtype.UUID{Bytes: imageID, Valid: true}, pgtype.UUID{Bytes: projectID, Valid: true},
+						pgtype.Text{String: "s3://bucket/a.jpg", Valid: true}, pgtype.Text{}, pgtype.Text{},
+						pgtype.Text{}, pgtype.Int8{}, pgtype.Text{}, queries.ImageStatusReady, pgtype.Text{},
+						pgtype.Timestamptz{}, pgtype.Timestamptz{}, pgtype.Timestamptz{}, false, pgtype.Text{},
+						pgtype.Text{}, pgtype.Text{String: "image/webp", Valid: true},
+					))
+			},
+		},
+		{
+			name: "fail: not in trash",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`UPDATE images SET deleted_at = NULL`).
+					WithArgs(imageID).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectErr: pgx.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer poolMock.Close()
+			tc.setupMock(poolMock)
+
+			repo := NewDefaultRepository(&storage.DatabaseMock{
+				QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+					return poolMock.QueryRow(ctx, sql, args...)
+				},
+			})
+
+			img, err := repo.RestoreImage(ctx, imageID.String())
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, imageID, uuid.UUID(img.ID.Bytes))
+				assert.Equal(t, queries.ImageStatusReady, img.Status)
+				assert.Equal(t, "image/webp", img.StagedContentType.String)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_CancelImage(t *testing.T) {
+	ctx := context.Background()
+	imageID := uuid.New()
+	columns := []string{
+		"id", "project_id", "original_url", "staged_url", "room_type", "style", "seed", "prompt", "status", "error",
+		"created_at", "updated_at", "sandbox", "blurhash", "error_code", "original_image_id", "model_used",
+		"processing_time_ms", "replicate_prediction_id", "primary_image_id", "staged_content_type",
+	}
+
+	testCases := []struct {
+		name      string
+		setupMock func(mock pgxmock.PgxPoolIface)
+		expectErr error
+	}{
+		{
+			name: "success: cancels the image and records the event",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`(?s)status = 'canceled'.*status IN \('queued', 'processing'\).*INSERT INTO image_events`).
+					WithArgs(imageID).
+					WillReturnRows(pgxmock.NewRows(columns).AddRow(
+						pgtype.UUID{Bytes: imageID, Valid: true}, pgtype.UUID{Bytes: uuid.New(), Valid: true},
+						pgtype.Text{String: "https://x/a.jpg", Valid: true}, pgtype.Text{}, pgtype.Text{}, pgtype.Text{},
+						pgtype.Int8{}, pgtype.Text{}, queries.ImageStatusCanceled, pgtype.Text{},
+						pgtype.Timestamptz{}, pgtype.Timestamptz{}, false, pgtype.Text{}, pgtype.Text{}, pgtype.UUID{},
+						pgtype.Text{}, pgtype.Int4{}, pgtype.Text{String: "pred-1", Valid: true}, pgtype.UUID{},
+						pgtype.Text{},
+					))
+			},
+		},
+		{
+			name: "fail: job already finished",
+			setupMock: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`status = 'canceled'`).
+					WithArgs(imageID).
+					WillReturnError(pgx.ErrNoRows)
+			},
+			expectErr: pgx.ErrNoRows,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer poolMock.Close()
+			tc.setupMock(poolMock)
+
+			repo := NewDefaultRepository(&storage.DatabaseMock{
+				QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+					return poolMock.QueryRow(ctx, sql, args...)
+				},
+			})
+
+			img, err := repo.CancelImage(ctx, imageID.String())
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, queries.ImageStatusCanceled, img.Status)
+				assert.Equal(t, "pred-1", img.ReplicatePredictionID.String)
+			}
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDefaultRepository_PurgeDeletedImages(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	repo := NewDefaultRepository(&storage.DatabaseMock{
+		QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+			return poolMock.Query(ctx, sql, args...)
+		},
+	})
+
+	deletedBefore := time.Now().Add(-30 * 24 * time.Hour)
+	createdBefore := time.Now().Add(-31 * 24 * time.Hour)
+	shared, legacy := uuid.New(), uuid.New()
+	originalID := uuid.New()
+
+	poolMock.ExpectQuery(`DELETE FROM images i`).
+		WithArgs(deletedBefore, createdBefore, 100).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "original_image_id", "staged_url", "own_original_url", "assets"}).
+			AddRow(
+				pgtype.UUID{Bytes: shared, Valid: true}, pgtype.UUID{Bytes: originalID, Valid: true},
+				pgtype.Text{String: "s3://bucket/staged.jpg", Valid: true}, pgtype.Text{},
+				[]string{"s3://bucket/cutout.png"},
+			).
+			AddRow(
+				pgtype.UUID{Bytes: legacy, Valid: true}, pgtype.UUID{},
+				pgtype.Text{}, pgtype.Text{String: "s3://bucket/legacy.jpg", Valid: true}, []string{},
+			))
+
+	purged, err := repo.PurgeDeletedImages(ctx, deletedBefore, createdBefore, 100)
+
+	require.NoError(t, err)
+	assert.Equal(t, []PurgedImage{
+		{
+			ID:              shared,
+			OriginalImageID: originalID.String(),
+			Files:           []string{"s3://bucket/staged.jpg", "s3://bucket/cutout.png"},
+		},
+		{ID: legacy, Files: []string{"s3://bucket/legacy.jpg"}},
+	}, purged)
+	assert.NoError(t, poolMock.ExpectationsWereMet())
+}
+
+func TestDefaultRepository_ListThumbnails(t *testing.T) {
+	ctx := context.Background()
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+
+	repo := NewDefaultRepository(&storage.DatabaseMock{
+		QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+			return poolMock.Query(ctx, sql, args...)
+		},
+	})
+
+	ids := []string{uuid.New().String(), uuid.New().String()}
+	poolMock.ExpectQuery(`kind = 'thumbnail'`).
+		WithArgs(ids).
+		WillReturnRows(pgxmock.NewRows([]string{"image_id", "source", "size", "url"}).
+			AddRow(ids[0], "original", "small", "s3://bucket/original-small.jpg").
+			AddRow(ids[1], "staged", "large", "s3://bucket/staged-large.jpg"))
+
+	thumbs, err := repo.ListThumbnails(ctx, ids)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Thumbnail{
+		{ImageID: ids[0], Source: "original", Size: "small", URL: "s3://bucket/original-small.jpg"},
+		{ImageID: ids[1], Source: "staged", Size: "large", URL: "s3://bucket/staged-large.jpg"},
+	}, thumbs)
+	assert.NoError(t, poolMock.ExpectationsWereMet())
+}
@@ -3,12 +3,25 @@ package image
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/i18n"
 	"github.com/real-staging-ai/api/internal/job"
 	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/queueadmin"
+	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
@@ -20,20 +33,52 @@ type OriginalImageService interface {
 	DecrementReferenceAndCleanup(ctx context.Context, originalImageID string) (bool, error)
 }
 
+//go:generate go run github.com/matryer/moq@v0.5.3 -out task_remover_mock.go . TaskRemover
+
+// TaskRemover removes tasks from the queue before a worker picks them up.
+type TaskRemover interface {
+	// DeleteTask removes the task. An empty queue searches every queue.
+	DeleteTask(ctx context.Context, queue, id string) error
+}
+
+// ErrJobFinished is returned when canceling the job of an image that is no
+// longer queued or processing.
+var ErrJobFinished = errors.New("image's staging job has already finished")
+
+// ErrNoCredits is returned when a new image must be paid for with a credit and
+// the paying user has none left. Nothing is created.
+var ErrNoCredits = errors.New("no image credits left")
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out credit_policy_mock.go . CreditPolicy
+
+// CreditPolicy decides which new images are paid for with a credit.
+type CreditPolicy interface {
+	// CreditRequired returns the user who pays for images in the project and
+	// whether their next image must spend one of their credits.
+	CreditRequired(ctx context.Context, projectID string) (payerID string, required bool, err error)
+}
+
 // DefaultService handles business logic for image operations.
 type DefaultService struct {
 	imageRepo            Repository
 	jobRepo              job.Repository
 	enqueuer             queue.Enqueuer
 	originalImageService OriginalImageService
+	bus                  events.Bus
+	tasks                TaskRemover
+	predictions          PredictionCanceler
+	credits              CreditPolicy
 }
 
-// NewDefaultService creates a new DefaultService instance.
+// NewDefaultService creates a new DefaultService instance. Without a credit
+// policy no image is paid for with credits.
 func NewDefaultService(
 	cfg *config.Config,
 	imageRepo Repository,
 	jobRepo job.Repository,
 	originalImageService OriginalImageService,
+	bus events.Bus,
+	credits CreditPolicy,
 ) *DefaultService {
 	// Best-effort build an enqueuer from env or config; fall back to Noop if not configured.
 	var enq queue.Enqueuer
@@ -42,11 +87,20 @@ func NewDefaultService(
 	} else {
 		enq = queue.NoopEnqueuer{}
 	}
+	// Canceled jobs are taken off the queue when Redis is configured.
+	var inspector queueadmin.Inspector
+	if addr := cfg.Redis.Addr(); addr != "" {
+		inspector = asynq.NewInspector(asynq.RedisClientOpt{Addr: addr})
+	}
 	return &DefaultService{
 		imageRepo:            imageRepo,
 		jobRepo:              jobRepo,
 		enqueuer:             enq,
 		originalImageService: originalImageService,
+		bus:                  bus,
+		tasks:                queueadmin.NewDefaultService(inspector),
+		predictions:          NewReplicatePredictions(cfg.Replicate),
+		credits:              credits,
 	}
 }
 
@@ -59,16 +113,24 @@ func (s *DefaultService) CreateImage(ctx context.Context, req *CreateImageReques
 		return nil, err
 	}
 
+	mode := req.Mode
+	if mode == "" {
+		mode = ModeStage
+	}
+
 	// Create the image in the database
-	dbImage, err := s.imageRepo.CreateImage(
-		ctx,
-		req.ProjectID.String(),
-		req.OriginalURL,
-		req.RoomType,
-		req.Style,
-		req.Seed,
-		req.Prompt,
-	)
+	dbImage, err := s.createPaid(ctx, req.ProjectID.String(), func(repo Repository) (*queries.Image, error) {
+		return repo.CreateImage(
+			ctx,
+			req.ProjectID.String(),
+			req.OriginalURL,
+			req.RoomType,
+			req.Style,
+			req.Seed,
+			req.Prompt,
+			string(mode),
+		)
+	})
 	if err != nil {
 		log.Error(ctx, "create image: repo failure",
 			"project_id", req.ProjectID.String(),
@@ -79,6 +141,61 @@ func (s *DefaultService) CreateImage(ctx context.Context, req *CreateImageReques
 
 	// Convert database image to domain image
 	domainImage := s.convertToImage(dbImage)
+	if req.MaskURL != nil {
+		if err := s.imageRepo.SetImageMask(ctx, domainImage.ID.String(), *req.MaskURL); err != nil {
+			log.Error(ctx, "create image: set mask failed", "image_id", domainImage.ID.String(), "error", err)
+			s.failUnqueued(ctx, domainImage.ID.String())
+			return nil, fmt.Errorf("failed to set image mask: %w", err)
+		}
+		domainImage.MaskURL = req.MaskURL
+	}
+	if err := s.queueImage(ctx, domainImage, req.ModelID, req.ModelConfig, req.Priority); err != nil {
+		s.failUnqueued(ctx, domainImage.ID.String())
+		return nil, err
+	}
+
+	return domainImage, nil
+}
+
+// createPaid runs create on the repository, inside a transaction that spends
+// one of the payer's credits when the credit policy says the image needs one.
+func (s *DefaultService) createPaid(
+	ctx context.Context, projectID string, create func(repo Repository) (*queries.Image, error),
+) (*queries.Image, error) {
+	if s.credits == nil {
+		return create(s.imageRepo)
+	}
+	payerID, required, err := s.credits.CreditRequired(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check credits: %w", err)
+	}
+	if !required {
+		return create(s.imageRepo)
+	}
+	return s.imageRepo.WithCredit(ctx, payerID, create)
+}
+
+// queueImage records the staging job for a newly created image, enqueues it
+// and announces the image. Images whose mode declutters run as declutter:run
+// jobs. A nil modelID leaves the model to the worker's global setting,
+// modelConfig overrides fields of the admin's config for the model, and
+// priority picks the queue the job waits in.
+func (s *DefaultService) queueImage(
+	ctx context.Context, domainImage *Image, modelID *string, modelConfig map[string]interface{},
+	priority queue.Priority,
+) error {
+	log := logging.NewDefaultLogger()
+
+	taskType, enqueue := queue.TaskTypeStageRun, s.enqueuer.EnqueueStageRun
+	var mode Mode
+	if domainImage.Mode.Declutters() {
+		taskType, enqueue = queue.TaskTypeDeclutterRun, s.enqueuer.EnqueueDeclutterRun
+		mode = domainImage.Mode
+	}
+	var language string
+	if lang := i18n.FromContext(ctx); lang != i18n.DefaultLanguage {
+		language = lang
+	}
 
 	// Create job payload
 	payload := JobPayload{
@@ -88,38 +205,162 @@ func (s *DefaultService) CreateImage(ctx context.Context, req *CreateImageReques
 		Style:       domainImage.Style,
 		Seed:        domainImage.Seed,
 		Prompt:      domainImage.Prompt,
+		ModelID:     modelID,
+		Sandbox:     domainImage.Sandbox,
+		MaskURL:     domainImage.MaskURL,
+		Mode:        mode,
+		ModelConfig: modelConfig,
+		Language:    language,
+		Priority:    priority,
 	}
 	payloadJSON, err := jsonMarshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal job payload: %w", err)
+		return fmt.Errorf("failed to marshal job payload: %w", err)
 	}
 
 	// Create a job for processing the image (persist metadata)
-	_, err = s.jobRepo.CreateJob(ctx, domainImage.ID.String(), "stage:run", payloadJSON)
+	jobRow, err := s.jobRepo.CreateJob(ctx, domainImage.ID.String(), taskType, payloadJSON)
 	if err != nil {
 		log.Error(ctx, "create image: job create failed", "image_id", domainImage.ID.String(), "error", err)
-		return nil, fmt.Errorf("failed to create job: %w", err)
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	// The task takes the job's ID, so CancelJob can find it in the queue.
+	var opts *queue.EnqueueOpts
+	if jobRow != nil && jobRow.ID.Valid {
+		opts = &queue.EnqueueOpts{TaskID: uuid.UUID(jobRow.ID.Bytes).String(), Retry: -1}
 	}
 
 	// Enqueue processing task to the queue
-	log.Info(ctx, "enqueue stage:run", "image_id", domainImage.ID.String())
-	if _, err := s.enqueuer.EnqueueStageRun(ctx, queue.StageRunPayload{
+	log.Info(ctx, "enqueue "+taskType, "image_id", domainImage.ID.String())
+	if _, err := enqueue(ctx, queue.StageRunPayload{
 		ImageID:     domainImage.ID.String(),
 		OriginalURL: domainImage.OriginalURL,
 		RoomType:    domainImage.RoomType,
 		Style:       domainImage.Style,
 		Seed:        domainImage.Seed,
 		Prompt:      domainImage.Prompt,
-	}, nil); err != nil {
-		log.Error(ctx, "enqueue stage:run failed", "image_id", domainImage.ID.String(), "error", err)
-		return nil, fmt.Errorf("failed to enqueue stage:run: %w", err)
+		ModelID:     modelID,
+		Sandbox:     domainImage.Sandbox,
+		MaskURL:     domainImage.MaskURL,
+		Mode:        string(mode),
+		ModelConfig: modelConfig,
+		Language:    language,
+		Priority:    priority,
+	}, opts); err != nil {
+		log.Error(ctx, "enqueue "+taskType+" failed", "image_id", domainImage.ID.String(), "error", err)
+		return fmt.Errorf("failed to enqueue %s: %w", taskType, err)
+	}
+	s.publish(ctx, events.ImageCreated{
+		ImageID:    domainImage.ID.String(),
+		ProjectID:  domainImage.ProjectID.String(),
+		RoomType:   domainImage.RoomType,
+		Style:      domainImage.Style,
+		OccurredAt: time.Now().UTC(),
+	})
+
+	return nil
+}
+
+// unqueuedError is recorded on an image whose job could not be queued.
+const unqueuedError = "failed to queue image for staging"
+
+// failUnqueued marks an image created without a queued job as errored and
+// announces the failure, so subscribers refund the credit spent on it.
+func (s *DefaultService) failUnqueued(ctx context.Context, imageID string) {
+	if _, err := s.imageRepo.UpdateImageWithError(ctx, imageID, unqueuedError); err != nil {
+		logging.Default().Error(ctx, "mark unqueued image failed", "image_id", imageID, "error", err)
+	}
+	s.publish(ctx, events.ImageFailed{ImageID: imageID, Error: unqueuedError, OccurredAt: time.Now().UTC()})
+}
+
+// RestageImage creates a new variant of an existing image and queues it. Room
+// type, style and prompt not set in req are kept from the source image; the
+// seed is only reused when req sets it. Returns pgx.ErrNoRows if the source
+// image doesn't exist.
+func (s *DefaultService) RestageImage(ctx context.Context, imageID string, req *RestageImageRequest) (*Image, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	source, err := s.imageRepo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source image: %w", err)
+	}
+	src := s.convertToImage(source)
+
+	roomType, style, prompt := req.RoomType, req.Style, req.Prompt
+	if roomType == nil {
+		roomType = src.RoomType
+	}
+	if style == nil {
+		style = src.Style
+	}
+	if prompt == nil {
+		prompt = src.Prompt
+	}
+
+	dbImage, err := s.createPaid(ctx, src.ProjectID.String(), func(repo Repository) (*queries.Image, error) {
+		return repo.CreateImageVariant(ctx, imageID, roomType, style, req.Seed, prompt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image variant: %w", err)
+	}
+
+	domainImage := s.convertToImage(dbImage)
+	if err := s.queueImage(ctx, domainImage, req.ModelID, nil, req.Priority); err != nil {
+		s.failUnqueued(ctx, domainImage.ID.String())
+		return nil, err
+	}
+	return domainImage, nil
+}
+
+// RerollImage creates a new variant of an existing image with its room type,
+// style and prompt and queues it with req's seed, or a random one when req
+// has none, on req's model. Returns pgx.ErrNoRows if the source image doesn't
+// exist.
+func (s *DefaultService) RerollImage(ctx context.Context, imageID string, req *RerollImageRequest) (*Image, error) {
+	if req == nil {
+		return nil, fmt.Errorf("request cannot be nil")
+	}
+
+	source, err := s.imageRepo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source image: %w", err)
+	}
+	src := s.convertToImage(source)
+
+	seed := req.Seed
+	if seed == nil {
+		random := rand.Int64N(MaxSeed) + 1
+		seed = &random
+	}
+
+	dbImage, err := s.createPaid(ctx, src.ProjectID.String(), func(repo Repository) (*queries.Image, error) {
+		return repo.CreateImageVariant(ctx, imageID, src.RoomType, src.Style, seed, src.Prompt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image variant: %w", err)
 	}
-	log.Info(ctx, "image enqueued", "image_id", domainImage.ID.String())
 
+	domainImage := s.convertToImage(dbImage)
+	if err := s.queueImage(ctx, domainImage, req.ModelID, nil, req.Priority); err != nil {
+		s.failUnqueued(ctx, domainImage.ID.String())
+		return nil, err
+	}
 	return domainImage, nil
 }
 
-// BatchCreateImages creates multiple images in a single transaction.
+// publish emits a domain event. Subscriber failures are logged by the bus and
+// never fail the originating operation.
+func (s *DefaultService) publish(ctx context.Context, event events.Event) {
+	if s.bus == nil {
+		return
+	}
+	_ = s.bus.Publish(ctx, event)
+}
+
+// BatchCreateImages creates multiple images. A failed image is reported in
+// Errors by its request index and does not stop the rest of the batch.
 func (s *DefaultService) BatchCreateImages(
 	ctx context.Context, reqs []CreateImageRequest,
 ) (*BatchCreateImagesResponse, error) {
@@ -138,11 +379,17 @@ func (s *DefaultService) BatchCreateImages(
 				"index", i,
 				"project_id", req.ProjectID.String(),
 				"error", err)
-			return nil, fmt.Errorf("failed to create image at index %d: %w", i, err)
-		} else {
-			response.Images = append(response.Images, img)
+			message := "Failed to create image"
+			if errors.Is(err, ErrNoCredits) {
+				message = "No image credits left"
+			}
+			response.Errors = append(response.Errors, BatchImageError{Index: i, Message: message})
+			continue
 		}
+		response.Images = append(response.Images, img)
 	}
+	response.Success = len(response.Images)
+	response.Failed = len(response.Errors)
 
 	log.Info(ctx, "batch create completed",
 		"total", len(reqs),
@@ -162,43 +409,110 @@ func (s *DefaultService) GetImageByID(ctx context.Context, imageID string) (*Ima
 		return nil, fmt.Errorf("failed to get image: %w", err)
 	}
 
-	return s.convertToImage(dbImage), nil
+	image := s.convertToImage(dbImage)
+	thumbs, err := s.thumbnailsByImage(ctx, []string{image.ID.String()})
+	if err != nil {
+		return nil, err
+	}
+	image.Thumbnails = thumbs[image.ID.String()]
+	return image, nil
 }
 
-// GetImagesByProjectID retrieves all images for a specific project.
-func (s *DefaultService) GetImagesByProjectID(ctx context.Context, projectID string) ([]*Image, error) {
+// GetImagesByProjectID retrieves a page of a project's images.
+func (s *DefaultService) GetImagesByProjectID(
+	ctx context.Context, projectID string, page pagination.Page,
+) (*ProjectImagesResponse, error) {
 	if projectID == "" {
 		return nil, fmt.Errorf("project ID cannot be empty")
 	}
 
-	dbImages, err := s.imageRepo.GetImagesByProjectID(ctx, projectID)
+	dbImages, next, err := s.imageRepo.ListImagesByProjectID(ctx, projectID, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get images: %w", err)
 	}
 
+	images, err := s.withThumbnails(ctx, dbImages)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectImagesResponse{Images: images, NextCursor: next}, nil
+}
+
+// SearchImages returns a page of the images matching query in the projects
+// the user can access, newest first.
+func (s *DefaultService) SearchImages(
+	ctx context.Context, userID string, query SearchQuery, page pagination.Page,
+) (*ImageSearchResponse, error) {
+	dbImages, next, err := s.imageRepo.SearchImages(ctx, userID, query, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search images: %w", err)
+	}
+
+	images, err := s.withThumbnails(ctx, dbImages)
+	if err != nil {
+		return nil, err
+	}
+	return &ImageSearchResponse{Images: images, NextCursor: next}, nil
+}
+
+// withThumbnails converts the images and attaches their thumbnails.
+func (s *DefaultService) withThumbnails(ctx context.Context, dbImages []*queries.Image) ([]*Image, error) {
 	images := make([]*Image, len(dbImages))
+	ids := make([]string, len(dbImages))
 	for i, dbImage := range dbImages {
 		images[i] = s.convertToImage(dbImage)
+		ids[i] = images[i].ID.String()
 	}
 
+	thumbs, err := s.thumbnailsByImage(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range images {
+		img.Thumbnails = thumbs[img.ID.String()]
+	}
 	return images, nil
 }
 
-// GetGroupedProjectImages retrieves images grouped by original_image_id.
+// thumbnailsByImage loads the images' thumbnails, keyed by image ID. Images
+// without any are missing from the map.
+func (s *DefaultService) thumbnailsByImage(ctx context.Context, imageIDs []string) (map[string]*Thumbnails, error) {
+	if len(imageIDs) == 0 {
+		return map[string]*Thumbnails{}, nil
+	}
+	thumbs, err := s.imageRepo.ListThumbnails(ctx, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thumbnails: %w", err)
+	}
+	return groupThumbnails(thumbs), nil
+}
+
+// GetGroupedProjectImages retrieves a page of images grouped by original_image_id.
+// Groups are ordered by their newest variant.
 func (s *DefaultService) GetGroupedProjectImages(
-	ctx context.Context, projectID string,
+	ctx context.Context, projectID string, page pagination.Page,
 ) (*GroupedProjectImagesResponse, error) {
 	if projectID == "" {
 		return nil, fmt.Errorf("project ID cannot be empty")
 	}
 
-	dbImages, err := s.imageRepo.GetImagesByProjectID(ctx, projectID)
+	dbImages, next, err := s.imageRepo.ListImagesByProjectID(ctx, projectID, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get images: %w", err)
 	}
 
+	ids := make([]string, len(dbImages))
+	for i, dbImage := range dbImages {
+		ids[i] = formatUUID(dbImage.ID.Bytes)
+	}
+	thumbs, err := s.thumbnailsByImage(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
 	// Group images by original_image_id (or by original_url if no original_image_id)
 	groupMap := make(map[string]*GroupedImage)
+	images := []*GroupedImage{}
 	for _, dbImage := range dbImages {
 		// Use original_image_id as key if available, else use original_url
 		var groupKey string
@@ -242,25 +556,33 @@ func (s *DefaultService) GetGroupedProjectImages(
 			}
 
 			groupMap[groupKey] = group
+			images = append(images, group)
 		}
 
 		// Add variant to group
 		variant := &ImageVariant{
-			ID:        dbImage.ID.Bytes,
-			Status:    Status(dbImage.Status),
-			CreatedAt: dbImage.CreatedAt.Time,
-			UpdatedAt: dbImage.UpdatedAt.Time,
+			ID:         dbImage.ID.Bytes,
+			Status:     Status(dbImage.Status),
+			Thumbnails: thumbs[formatUUID(dbImage.ID.Bytes)],
+			CreatedAt:  dbImage.CreatedAt.Time,
+			UpdatedAt:  dbImage.UpdatedAt.Time,
 		}
 
 		if dbImage.Style.Valid {
 			variant.Style = &dbImage.Style.String
 		}
+		if dbImage.Seed.Valid {
+			variant.Seed = &dbImage.Seed.Int64
+		}
 		if dbImage.StagedUrl.Valid {
 			variant.StagedURL = &dbImage.StagedUrl.String
 		}
 		if dbImage.Error.Valid {
 			variant.Error = &dbImage.Error.String
 		}
+		if dbImage.ErrorCode.Valid {
+			variant.ErrorCode = &dbImage.ErrorCode.String
+		}
 		// TODO: Convert pgtype.Numeric to float64 for CostUSD when needed
 		// if dbImage.CostUsd.Valid { variant.CostUSD = ... }
 		if dbImage.ProcessingTimeMs.Valid {
@@ -270,6 +592,10 @@ func (s *DefaultService) GetGroupedProjectImages(
 		if dbImage.ModelUsed.Valid {
 			variant.ModelUsed = &dbImage.ModelUsed.String
 		}
+		if dbImage.PrimaryImageID.Valid {
+			primaryID := uuid.UUID(dbImage.PrimaryImageID.Bytes)
+			variant.PrimaryImageID = &primaryID
+		}
 		if dbImage.ReplicatePredictionID.Valid {
 			variant.ReplicatePredictionID = &dbImage.ReplicatePredictionID.String
 		}
@@ -277,13 +603,7 @@ func (s *DefaultService) GetGroupedProjectImages(
 		groupMap[groupKey].Variants = append(groupMap[groupKey].Variants, variant)
 	}
 
-	// Convert map to slice
-	images := make([]*GroupedImage, 0, len(groupMap))
-	for _, group := range groupMap {
-		images = append(images, group)
-	}
-
-	return &GroupedProjectImagesResponse{Images: images}, nil
+	return &GroupedProjectImagesResponse{Images: images, NextCursor: next}, nil
 }
 
 // UpdateImageStatus updates an image's processing status.
@@ -336,40 +656,157 @@ func (s *DefaultService) UpdateImageWithError(ctx context.Context, imageID strin
 	return s.convertToImage(dbImage), nil
 }
 
-// DeleteImage deletes an image from the database and decrements the original image reference.
-// If this is the last reference to the original image, the original is also deleted from S3 and database.
+// DeleteImage moves an image to the trash. It keeps its files and its reference
+// on the original, so RestoreImage can bring it back; PurgeTrash deletes it for
+// good and releases the original once the retention period has passed.
 func (s *DefaultService) DeleteImage(ctx context.Context, imageID string) error {
-	log := logging.Default()
 	if imageID == "" {
 		return fmt.Errorf("image ID cannot be empty")
 	}
 
-	// Get the original_image_id before soft-deleting the image
-	originalImageID, err := s.imageRepo.GetOriginalImageID(ctx, imageID)
+	// Soft delete the image (marks as deleted but keeps for billing/usage tracking).
+	err := s.imageRepo.DeleteImage(ctx, imageID)
 	if err != nil {
-		return fmt.Errorf("failed to get original image ID: %w", err)
+		return fmt.Errorf("failed to delete image: %w", err)
 	}
 
-	// Soft delete the image (marks as deleted but keeps for billing/usage tracking)
-	err = s.imageRepo.DeleteImage(ctx, imageID)
+	return nil
+}
+
+// ListReadyImages returns a project's ready images that have a staged file,
+// oldest first.
+func (s *DefaultService) ListReadyImages(ctx context.Context, projectID string) ([]*Image, error) {
+	dbImages, err := s.imageRepo.GetImagesByProjectID(ctx, projectID)
 	if err != nil {
-		return fmt.Errorf("failed to delete image: %w", err)
+		return nil, fmt.Errorf("failed to get images: %w", err)
 	}
 
-	// If the image has an associated original, decrement its reference count
-	// and clean up if this was the last reference
-	if originalImageID != "" && s.originalImageService != nil {
-		deleted, err := s.originalImageService.DecrementReferenceAndCleanup(ctx, originalImageID)
-		if err != nil {
-			// Log the error but don't fail the deletion
-			// The orphaned original can be cleaned up later by a background job
-			log.Warn(ctx, "failed to decrement original image reference", "original_id", originalImageID, "error", err)
-		} else if deleted {
-			log.Info(ctx, "deleted unreferenced original image", "original_id", originalImageID)
+	images := []*Image{}
+	for _, dbImage := range slices.Backward(dbImages) {
+		if Status(dbImage.Status) != StatusReady || !dbImage.StagedUrl.Valid || dbImage.StagedUrl.String == "" {
+			continue
 		}
+		images = append(images, s.convertToImage(dbImage))
 	}
+	return images, nil
+}
 
-	return nil
+// ListTrash returns a project's soft-deleted images.
+func (s *DefaultService) ListTrash(ctx context.Context, projectID string) ([]*Image, error) {
+	dbImages, err := s.imageRepo.ListDeletedImagesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make([]*Image, len(dbImages))
+	for i, dbImage := range dbImages {
+		images[i] = s.convertToImage(dbImage)
+	}
+	return images, nil
+}
+
+// GetDeletedImageByID retrieves an image in the trash.
+func (s *DefaultService) GetDeletedImageByID(ctx context.Context, imageID string) (*Image, error) {
+	dbImage, err := s.imageRepo.GetDeletedImageByID(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToImage(dbImage), nil
+}
+
+// RestoreImage takes an image back out of the trash.
+func (s *DefaultService) RestoreImage(ctx context.Context, imageID string) (*Image, error) {
+	dbImage, err := s.imageRepo.RestoreImage(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToImage(dbImage), nil
+}
+
+// SetImageApproval stores the owner's verdict on the image.
+func (s *DefaultService) SetImageApproval(ctx context.Context, imageID string, approved *bool) error {
+	return s.imageRepo.SetImageApproval(ctx, imageID, approved)
+}
+
+// CancelJob sets the image to canceled first, so a worker that picks its job
+// up afterwards skips it and one already staging it discards the result. Then
+// it stops what would still cost money: the queued task is removed, or the
+// running prediction canceled. Failures there are logged; the image stays
+// canceled either way. Subscribers refund the credit the image took.
+func (s *DefaultService) CancelJob(ctx context.Context, imageID string) (*Image, error) {
+	log := logging.Default()
+
+	dbImage, err := s.imageRepo.CancelImage(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrJobFinished
+		}
+		return nil, err
+	}
+
+	if err := s.removeQueuedTask(ctx, imageID); err != nil {
+		log.Warn(ctx, "cancel job: remove queued task failed", "image_id", imageID, "error", err)
+	}
+	// The worker records the prediction on the image as soon as it starts one.
+	if dbImage.ReplicatePredictionID.Valid && dbImage.ReplicatePredictionID.String != "" && s.predictions != nil {
+		predictionID := dbImage.ReplicatePredictionID.String
+		if err := s.predictions.Cancel(ctx, predictionID); err != nil {
+			log.Warn(ctx, "cancel job: cancel prediction failed",
+				"image_id", imageID, "prediction_id", predictionID, "error", err)
+		}
+	}
+	s.publish(ctx, events.ImageCanceled{ImageID: imageID, OccurredAt: time.Now().UTC()})
+	return s.convertToImage(dbImage), nil
+}
+
+// removeQueuedTask deletes the task of the image's latest job, which was
+// enqueued with the job's ID. A task a worker has started, or one already gone,
+// is left alone.
+func (s *DefaultService) removeQueuedTask(ctx context.Context, imageID string) error {
+	if s.jobRepo == nil || s.tasks == nil {
+		return nil
+	}
+	jobs, err := s.jobRepo.GetJobsByImageID(ctx, imageID)
+	if err != nil {
+		return fmt.Errorf("failed to get jobs: %w", err)
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+	err = s.tasks.DeleteTask(ctx, "", uuid.UUID(jobs[0].ID.Bytes).String())
+	if errors.Is(err, queueadmin.ErrTaskActive) || errors.Is(err, queueadmin.ErrTaskNotFound) {
+		return nil
+	}
+	return err
+}
+
+// usageWindow is the longest billing period. Usage counts deleted images too,
+// so an image is only purged once the period it was created in has closed.
+const usageWindow = 31 * 24 * time.Hour
+
+// PurgeTrash hard-deletes expired images, then drops each one's reference on
+// its original, deleting originals nothing uses any more.
+func (s *DefaultService) PurgeTrash(ctx context.Context, retention time.Duration, limit int) ([]PurgedImage, error) {
+	log := logging.Default()
+	now := time.Now()
+
+	purged, err := s.imageRepo.PurgeDeletedImages(ctx, now.Add(-retention), now.Add(-usageWindow), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range purged {
+		if p.OriginalImageID == "" || s.originalImageService == nil {
+			continue
+		}
+		if _, err := s.originalImageService.DecrementReferenceAndCleanup(ctx, p.OriginalImageID); err != nil {
+			// The image is already gone; an original left at zero references is
+			// removed by the orphaned-originals cleanup.
+			log.Warn(ctx, "failed to release original of purged image",
+				"image_id", p.ID, "original_id", p.OriginalImageID, "error", err)
+		}
+	}
+	return purged, nil
 }
 
 // convertToImage converts a database image to a domain image.
@@ -387,6 +824,7 @@ func (s *DefaultService) convertToImage(dbImage *queries.Image) *Image {
 		Status:      Status(dbImage.Status),
 		CreatedAt:   dbImage.CreatedAt.Time,
 		UpdatedAt:   dbImage.UpdatedAt.Time,
+		Sandbox:     dbImage.Sandbox,
 	}
 
 	if dbImage.StagedUrl.Valid {
@@ -413,6 +851,41 @@ func (s *DefaultService) convertToImage(dbImage *queries.Image) *Image {
 		image.Error = &dbImage.Error.String
 	}
 
+	if dbImage.ErrorCode.Valid {
+		image.ErrorCode = &dbImage.ErrorCode.String
+	}
+
+	if dbImage.DeletedAt.Valid {
+		image.DeletedAt = &dbImage.DeletedAt.Time
+	}
+
+	if dbImage.Blurhash.Valid {
+		image.Blurhash = &dbImage.Blurhash.String
+	}
+
+	if dbImage.PrimaryImageID.Valid {
+		primaryID := uuid.UUID(dbImage.PrimaryImageID.Bytes)
+		image.PrimaryImageID = &primaryID
+	}
+
+	if dbImage.MaskUrl.Valid {
+		image.MaskURL = &dbImage.MaskUrl.String
+	}
+
+	image.Mode = Mode(dbImage.Mode)
+
+	if dbImage.DetectedRoomType.Valid {
+		image.DetectedRoomType = &dbImage.DetectedRoomType.String
+	}
+
+	if dbImage.RoomTypeConfidence.Valid {
+		image.RoomTypeConfidence = &dbImage.RoomTypeConfidence.Float64
+	}
+
+	if dbImage.StagedContentType.Valid {
+		image.StagedContentType = &dbImage.StagedContentType.String
+	}
+
 	return image
 }
 
@@ -420,3 +893,211 @@ func (s *DefaultService) convertToImage(dbImage *queries.Image) *Image {
 func (s *DefaultService) GetProjectCostSummary(ctx context.Context, projectID string) (*ProjectCostSummary, error) {
 	return s.imageRepo.GetProjectCostSummary(ctx, projectID)
 }
+
+// PlanProjectRestyle builds one CreateImageRequest per variant group (images sharing an
+// original_url) that has at least one ready image and no queued, processing or ready
+// variant in the target style. The newest ready image supplies the room type and seed;
+// custom prompts are dropped because they usually describe the previous style. Groups
+// whose original was quarantined are skipped.
+func (s *DefaultService) PlanProjectRestyle(
+	ctx context.Context, projectID string, style string,
+) ([]CreateImageRequest, int, error) {
+	if projectID == "" {
+		return nil, 0, fmt.Errorf("project ID cannot be empty")
+	}
+	if style == "" {
+		return nil, 0, fmt.Errorf("style cannot be empty")
+	}
+
+	dbImages, err := s.imageRepo.GetImagesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get images: %w", err)
+	}
+
+	type restyleGroup struct {
+		source      *queries.Image
+		hasTarget   bool
+		quarantined bool
+	}
+	var order []string
+	groups := make(map[string]*restyleGroup)
+	for _, dbImage := range dbImages {
+		if !dbImage.OriginalUrl.Valid || dbImage.OriginalUrl.String == "" {
+			continue
+		}
+		key := dbImage.OriginalUrl.String
+		g, ok := groups[key]
+		if !ok {
+			g = &restyleGroup{}
+			groups[key] = g
+			order = append(order, key)
+		}
+
+		status := Status(dbImage.Status)
+		if status == StatusRejected {
+			g.quarantined = true
+		}
+		if dbImage.Style.Valid && dbImage.Style.String == style && status != StatusError && status != StatusCanceled {
+			g.hasTarget = true
+		}
+		if status == StatusReady &&
+			(g.source == nil || dbImage.UpdatedAt.Time.After(g.source.UpdatedAt.Time)) {
+			g.source = dbImage
+		}
+	}
+
+	var reqs []CreateImageRequest
+	skipped := 0
+	for _, key := range order {
+		g := groups[key]
+		if g.source == nil || g.hasTarget || g.quarantined {
+			skipped++
+			continue
+		}
+
+		src := s.convertToImage(g.source)
+		targetStyle := style
+		reqs = append(reqs, CreateImageRequest{
+			ProjectID:   src.ProjectID,
+			OriginalURL: src.OriginalURL,
+			RoomType:    src.RoomType,
+			Style:       &targetStyle,
+			Seed:        src.Seed,
+		})
+	}
+
+	return reqs, skipped, nil
+}
+
+// PlanProjectDuplicate builds the create requests that re-stage a project's images into a
+// copy of it. Without a style every ready image is planned again with its own room type,
+// style, prompt and seed. With a style each variant group is planned once from its newest
+// ready image in that style, dropping custom prompts as PlanProjectRestyle does. Groups
+// without a ready image or whose original was quarantined are skipped. The requests keep
+// the source ProjectID; callers point them at the copy.
+func (s *DefaultService) PlanProjectDuplicate(
+	ctx context.Context, projectID string, style *string,
+) ([]CreateImageRequest, int, error) {
+	if projectID == "" {
+		return nil, 0, fmt.Errorf("project ID cannot be empty")
+	}
+
+	dbImages, err := s.imageRepo.GetImagesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get images: %w", err)
+	}
+
+	type duplicateGroup struct {
+		ready       []*queries.Image
+		quarantined bool
+	}
+	var order []string
+	groups := make(map[string]*duplicateGroup)
+	// Images come newest first; walk them oldest first so the copy keeps the upload order.
+	for _, dbImage := range slices.Backward(dbImages) {
+		if !dbImage.OriginalUrl.Valid || dbImage.OriginalUrl.String == "" {
+			continue
+		}
+		key := dbImage.OriginalUrl.String
+		g, ok := groups[key]
+		if !ok {
+			g = &duplicateGroup{}
+			groups[key] = g
+			order = append(order, key)
+		}
+
+		switch Status(dbImage.Status) {
+		case StatusRejected:
+			g.quarantined = true
+		case StatusReady:
+			g.ready = append(g.ready, dbImage)
+		}
+	}
+
+	var reqs []CreateImageRequest
+	skipped := 0
+	for _, key := range order {
+		g := groups[key]
+		if len(g.ready) == 0 || g.quarantined {
+			skipped++
+			continue
+		}
+
+		if style != nil {
+			newest := g.ready[0]
+			for _, dbImage := range g.ready[1:] {
+				if dbImage.UpdatedAt.Time.After(newest.UpdatedAt.Time) {
+					newest = dbImage
+				}
+			}
+			src := s.convertToImage(newest)
+			targetStyle := *style
+			reqs = append(reqs, CreateImageRequest{
+				ProjectID:   src.ProjectID,
+				OriginalURL: src.OriginalURL,
+				RoomType:    src.RoomType,
+				Style:       &targetStyle,
+				Seed:        src.Seed,
+			})
+			continue
+		}
+
+		for _, dbImage := range g.ready {
+			src := s.convertToImage(dbImage)
+			reqs = append(reqs, CreateImageRequest{
+				ProjectID:   src.ProjectID,
+				OriginalURL: src.OriginalURL,
+				RoomType:    src.RoomType,
+				Style:       src.Style,
+				Seed:        src.Seed,
+				Prompt:      src.Prompt,
+			})
+		}
+	}
+
+	return reqs, skipped, nil
+}
+
+// trashPurgeBatch bounds how many images one purge statement deletes.
+const trashPurgeBatch = 100
+
+// RunTrashRetention periodically purges images that have been in the trash
+// longer than retention and deletes their files from S3, until ctx is cancelled.
+func RunTrashRetention(
+	ctx context.Context, svc Service, files storage.S3Service, retention, interval time.Duration,
+) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			total := 0
+			for {
+				purged, err := svc.PurgeTrash(ctx, retention, trashPurgeBatch)
+				if err != nil {
+					log.Error(ctx, "trash retention failed", "error", err)
+					break
+				}
+				for _, p := range purged {
+					for _, f := range p.Files {
+						// The row is gone, so a file left behind is only wasted storage.
+						if err := files.DeleteFile(ctx, f); err != nil {
+							log.Warn(ctx, "failed to delete purged image file", "image_id", p.ID, "file", f, "error", err)
+						}
+					}
+				}
+				total += len(purged)
+				if len(purged) < trashPurgeBatch {
+					break
+				}
+			}
+			if total > 0 {
+				log.Info(ctx, "trash retention purged images", "count", total)
+			}
+		}
+	}
+}
@@ -4,15 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/real-staging-ai/api/internal/billing"
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/i18n"
 	"github.com/real-staging-ai/api/internal/job"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/originalimage"
+	"github.com/real-staging-ai/api/internal/pagination"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/queueadmin"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
@@ -36,7 +48,7 @@ func TestNewDefaultService(t *testing.T) {
 	t.Run("success: create new default service", func(t *testing.T) {
 		imageRepo := &RepositoryMock{}
 		jobRepo := &job.RepositoryMock{}
-		service := NewDefaultService(cfg, imageRepo, jobRepo, nil)
+		service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
 		assert.NotNil(t, service)
 	})
 }
@@ -53,6 +65,7 @@ func TestDefaultService_CreateImage(t *testing.T) {
 		setupMocks    func(*RepositoryMock, *job.RepositoryMock)
 		expectedImage *Image
 		expectedErr   error
+		expectFailed  bool
 	}{
 		{
 			name: "success: create image",
@@ -67,6 +80,7 @@ func TestDefaultService_CreateImage(t *testing.T) {
 					roomType, style *string,
 					seed *int64,
 					prompt *string,
+					mode string,
 				) (*queries.Image, error) {
 					return &queries.Image{
 						ID:          pgtype.UUID{Bytes: imageID, Valid: true},
@@ -110,6 +124,7 @@ func TestDefaultService_CreateImage(t *testing.T) {
 					roomType, style *string,
 					seed *int64,
 					prompt *string,
+					mode string,
 				) (*queries.Image, error) {
 					return nil, errors.New("db error")
 				}
@@ -130,7 +145,8 @@ func TestDefaultService_CreateImage(t *testing.T) {
 					return nil, errors.New("job error")
 				}
 			},
-			expectedErr: errors.New("failed to create job: job error"),
+			expectedErr:  errors.New("failed to create job: job error"),
+			expectFailed: true,
 		},
 		{
 			name: "fail: json marshal error",
@@ -146,17 +162,26 @@ func TestDefaultService_CreateImage(t *testing.T) {
 					return nil, errors.New("json marshal error")
 				}
 			},
-			expectedErr: errors.New("failed to marshal job payload: json marshal error"),
+			expectedErr:  errors.New("failed to marshal job payload: json marshal error"),
+			expectFailed: true,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			imageRepo := &RepositoryMock{}
+			imageRepo := &RepositoryMock{
+				UpdateImageWithErrorFunc: func(ctx context.Context, imageID, errorMsg string) (*queries.Image, error) {
+					return &queries.Image{}, nil
+				},
+			}
 			jobRepo := &job.RepositoryMock{}
 			tc.setupMocks(imageRepo, jobRepo)
 
-			service := NewDefaultService(cfg, imageRepo, jobRepo, nil)
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+			bus := &events.BusMock{
+				PublishFunc: func(ctx context.Context, event events.Event) error { return nil },
+			}
+			service.bus = bus
 
 			if tc.name == "fail: json marshal error" {
 				jsonMarshal = func(v interface{}) ([]byte, error) {
@@ -179,11 +204,483 @@ func TestDefaultService_CreateImage(t *testing.T) {
 				assert.Equal(t, tc.expectedImage.ProjectID, image.ProjectID)
 				assert.Equal(t, tc.expectedImage.OriginalURL, image.OriginalURL)
 				assert.Equal(t, tc.expectedImage.Status, image.Status)
+				require.Len(t, bus.PublishCalls(), 1)
+				created, ok := bus.PublishCalls()[0].Event.(events.ImageCreated)
+				require.True(t, ok)
+				assert.Equal(t, image.ID.String(), created.ImageID)
+				assert.Equal(t, image.ProjectID.String(), created.ProjectID)
+				return
+			}
+			if !tc.expectFailed {
+				assert.Empty(t, imageRepo.UpdateImageWithErrorCalls())
+				assert.Empty(t, bus.PublishCalls())
+				return
+			}
+			require.Len(t, imageRepo.UpdateImageWithErrorCalls(), 1)
+			assert.Equal(t, imageID.String(), imageRepo.UpdateImageWithErrorCalls()[0].ImageID)
+			require.Len(t, bus.PublishCalls(), 1)
+			failed, ok := bus.PublishCalls()[0].Event.(events.ImageFailed)
+			require.True(t, ok)
+			assert.Equal(t, imageID.String(), failed.ImageID)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_sandbox(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID := uuid.New()
+
+	imageRepo := &RepositoryMock{
+		CreateImageFunc: func(
+			ctx context.Context, projectID, originalURL string, roomType, style *string, seed *int64, prompt *string,
+			mode string,
+		) (*queries.Image, error) {
+			return &queries.Image{
+				ID:          pgtype.UUID{Bytes: imageID, Valid: true},
+				OriginalUrl: pgtype.Text{String: originalURL, Valid: true},
+				Status:      queries.ImageStatusQueued,
+				Sandbox:     true,
+			}, nil
+		},
+	}
+	var payload JobPayload
+	jobRepo := &job.RepositoryMock{
+		CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+			require.NoError(t, json.Unmarshal(payloadJSON, &payload))
+			return &queries.Job{}, nil
+		},
+	}
+	service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+	service.bus = &events.BusMock{
+		PublishFunc: func(ctx context.Context, event events.Event) error { return nil },
+	}
+
+	img, err := service.CreateImage(context.Background(), &CreateImageRequest{
+		ProjectID:   uuid.New(),
+		OriginalURL: "http://example.com/image.jpg",
+	})
+	require.NoError(t, err)
+	assert.True(t, img.Sandbox)
+	assert.True(t, payload.Sandbox)
+}
+
+func TestDefaultService_CreateImage_credits(t *testing.T) {
+	cfg := setupTestConfig(t)
+	projectID := uuid.New()
+	imageID := uuid.New()
+	req := &CreateImageRequest{ProjectID: projectID, OriginalURL: "http://example.com/image.jpg"}
+
+	testCases := []struct {
+		name         string
+		required     bool
+		creditErr    error
+		expectCredit bool
+		expectedErr  error
+	}{
+		{name: "success: images within the allowance are created without a credit"},
+		{name: "success: images past the allowance spend a credit", required: true, expectCredit: true},
+		{
+			name: "fail: no credits left", required: true, creditErr: ErrNoCredits, expectCredit: true,
+			expectedErr: ErrNoCredits,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+			}
+			mockSuccessfulImageCreation(imageID, projectID)(imageRepo, jobRepo)
+			imageRepo.WithCreditFunc = func(
+				ctx context.Context, userID string, create func(repo Repository) (*queries.Image, error),
+			) (*queries.Image, error) {
+				if tc.creditErr != nil {
+					return nil, tc.creditErr
+				}
+				return create(imageRepo)
+			}
+			credits := &CreditPolicyMock{
+				CreditRequiredFunc: func(ctx context.Context, projectID string) (string, bool, error) {
+					return "payer-1", tc.required, nil
+				},
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, credits)
+			service.bus = &events.BusMock{
+				PublishFunc: func(ctx context.Context, event events.Event) error { return nil },
+			}
+
+			image, err := service.CreateImage(context.Background(), req)
+
+			require.Len(t, credits.CreditRequiredCalls(), 1)
+			assert.Equal(t, projectID.String(), credits.CreditRequiredCalls()[0].ProjectID)
+			if tc.expectCredit {
+				require.Len(t, imageRepo.WithCreditCalls(), 1)
+				assert.Equal(t, "payer-1", imageRepo.WithCreditCalls()[0].UserID)
+			} else {
+				assert.Empty(t, imageRepo.WithCreditCalls())
+			}
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, image)
+				assert.Empty(t, jobRepo.CreateJobCalls(), "images without a credit are not queued")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, imageID, image.ID)
+			assert.Len(t, imageRepo.CreateImageCalls(), 1)
+		})
+	}
+
+	t.Run("fail: credit policy error", func(t *testing.T) {
+		credits := &CreditPolicyMock{
+			CreditRequiredFunc: func(ctx context.Context, projectID string) (string, bool, error) {
+				return "", false, errors.New("db error")
+			},
+		}
+		imageRepo := &RepositoryMock{}
+		service := NewDefaultService(cfg, imageRepo, &job.RepositoryMock{}, nil, nil, credits)
+
+		_, err := service.CreateImage(context.Background(), req)
+		assert.EqualError(t, err, "failed to create image: failed to check credits: db error")
+		assert.Empty(t, imageRepo.CreateImageCalls())
+	})
+
+	t.Run("fail: the credit is refunded when the image can't be queued", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			UpdateImageWithErrorFunc: func(ctx context.Context, imageID, errorMsg string) (*queries.Image, error) {
+				return &queries.Image{}, nil
+			},
+		}
+		jobRepo := &job.RepositoryMock{
+			CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+				return &queries.Job{}, nil
+			},
+		}
+		mockSuccessfulImageCreation(imageID, projectID)(imageRepo, jobRepo)
+		imageRepo.WithCreditFunc = func(
+			ctx context.Context, userID string, create func(repo Repository) (*queries.Image, error),
+		) (*queries.Image, error) {
+			return create(imageRepo)
+		}
+		credits := &CreditPolicyMock{
+			CreditRequiredFunc: func(ctx context.Context, projectID string) (string, bool, error) {
+				return "payer-1", true, nil
+			},
+		}
+		ledger := &credit.RepositoryMock{
+			RefundFunc: func(ctx context.Context, imageID string) (bool, error) { return true, nil },
+		}
+		bus := events.NewDefaultBus(logging.Default())
+		billing.RegisterCreditSubscribers(bus, ledger)
+		service := NewDefaultService(cfg, imageRepo, jobRepo, nil, bus, credits)
+		service.enqueuer = &queue.EnqueuerMock{
+			EnqueueStageRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "", errors.New("redis down")
+			},
+		}
+
+		_, err := service.CreateImage(context.Background(), req)
+
+		assert.EqualError(t, err, "failed to enqueue stage:run: redis down")
+		require.Len(t, imageRepo.UpdateImageWithErrorCalls(), 1)
+		assert.Equal(t, imageID.String(), imageRepo.UpdateImageWithErrorCalls()[0].ImageID)
+		require.Len(t, ledger.RefundCalls(), 1)
+		assert.Equal(t, imageID.String(), ledger.RefundCalls()[0].ImageID)
+	})
+}
+
+func TestDefaultService_RestageImage(t *testing.T) {
+	cfg := setupTestConfig(t)
+	projectID := uuid.New()
+	sourceID := uuid.New()
+	variantID := uuid.New()
+
+	source := &queries.Image{
+		ID:          pgtype.UUID{Bytes: sourceID, Valid: true},
+		ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+		OriginalUrl: pgtype.Text{String: "http://example.com/image.jpg", Valid: true},
+		RoomType:    pgtype.Text{String: "bedroom", Valid: true},
+		Style:       pgtype.Text{String: "modern", Valid: true},
+		Seed:        pgtype.Int8{Int64: 42, Valid: true},
+		Prompt:      pgtype.Text{String: "bright and airy staging", Valid: true},
+		Status:      queries.ImageStatusReady,
+	}
+	str := func(s string) *string { return &s }
+	i64 := func(v int64) *int64 { return &v }
+
+	testCases := []struct {
+		name         string
+		req          *RestageImageRequest
+		sourceErr    error
+		variantErr   error
+		wantRoomType *string
+		wantStyle    *string
+		wantSeed     *int64
+		wantPrompt   *string
+		expectedErr  string
+	}{
+		{
+			name:         "success: inherits room type, style and prompt but not seed",
+			req:          &RestageImageRequest{},
+			wantRoomType: str("bedroom"),
+			wantStyle:    str("modern"),
+			wantPrompt:   str("bright and airy staging"),
+		},
+		{
+			name:         "success: overrides style, prompt and seed",
+			req:          &RestageImageRequest{Style: str("industrial"), Prompt: str("exposed brick loft"), Seed: i64(7)},
+			wantRoomType: str("bedroom"),
+			wantStyle:    str("industrial"),
+			wantSeed:     i64(7),
+			wantPrompt:   str("exposed brick loft"),
+		},
+		{
+			name:        "fail: nil request",
+			expectedErr: "request cannot be nil",
+		},
+		{
+			name:        "fail: source not found",
+			req:         &RestageImageRequest{},
+			sourceErr:   pgx.ErrNoRows,
+			expectedErr: "failed to get source image: no rows in result set",
+		},
+		{
+			name:        "fail: variant create error",
+			req:         &RestageImageRequest{},
+			variantErr:  errors.New("db error"),
+			expectedErr: "failed to create image variant: db error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				GetImageByIDFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
+					if tc.sourceErr != nil {
+						return nil, tc.sourceErr
+					}
+					return source, nil
+				},
+				CreateImageVariantFunc: func(
+					ctx context.Context, sourceImageID string, roomType, style *string, seed *int64, prompt *string,
+				) (*queries.Image, error) {
+					if tc.variantErr != nil {
+						return nil, tc.variantErr
+					}
+					variant := *source
+					variant.ID = pgtype.UUID{Bytes: variantID, Valid: true}
+					variant.Status = queries.ImageStatusQueued
+					variant.Seed = pgtype.Int8{}
+					if seed != nil {
+						variant.Seed = pgtype.Int8{Int64: *seed, Valid: true}
+					}
+					variant.Style = pgtype.Text{String: *style, Valid: true}
+					variant.Prompt = pgtype.Text{String: *prompt, Valid: true}
+					return &variant, nil
+				},
 			}
+			var payload JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					require.NoError(t, json.Unmarshal(payloadJSON, &payload))
+					return &queries.Job{}, nil
+				},
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+			service.bus = &events.BusMock{
+				PublishFunc: func(ctx context.Context, event events.Event) error { return nil },
+			}
+
+			img, err := service.RestageImage(context.Background(), sourceID.String(), tc.req)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				assert.Empty(t, jobRepo.CreateJobCalls())
+				return
+			}
+			require.NoError(t, err)
+
+			calls := imageRepo.CreateImageVariantCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, sourceID.String(), calls[0].SourceImageID)
+			assert.Equal(t, tc.wantRoomType, calls[0].RoomType)
+			assert.Equal(t, tc.wantStyle, calls[0].Style)
+			assert.Equal(t, tc.wantSeed, calls[0].Seed)
+			assert.Equal(t, tc.wantPrompt, calls[0].Prompt)
+
+			assert.Equal(t, variantID, img.ID)
+			require.Len(t, jobRepo.CreateJobCalls(), 1)
+			assert.Equal(t, "stage:run", jobRepo.CreateJobCalls()[0].JobType)
+			assert.Equal(t, variantID, payload.ImageID)
+			assert.Equal(t, tc.wantStyle, payload.Style)
 		})
 	}
 }
 
+func TestDefaultService_RerollImage(t *testing.T) {
+	cfg := setupTestConfig(t)
+	projectID := uuid.New()
+	sourceID := uuid.New()
+	variantID := uuid.New()
+
+	source := &queries.Image{
+		ID:          pgtype.UUID{Bytes: sourceID, Valid: true},
+		ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+		OriginalUrl: pgtype.Text{String: "http://example.com/image.jpg", Valid: true},
+		RoomType:    pgtype.Text{String: "bedroom", Valid: true},
+		Style:       pgtype.Text{String: "modern", Valid: true},
+		Seed:        pgtype.Int8{Int64: 42, Valid: true},
+		Prompt:      pgtype.Text{String: "bright and airy staging", Valid: true},
+		Status:      queries.ImageStatusReady,
+	}
+	model := "black-forest-labs/flux-kontext-max"
+	i64 := func(v int64) *int64 { return &v }
+
+	testCases := []struct {
+		name        string
+		req         *RerollImageRequest
+		sourceErr   error
+		variantErr  error
+		wantSeed    *int64
+		expectedErr string
+	}{
+		{
+			name:     "success: keeps everything but the given seed",
+			req:      &RerollImageRequest{Seed: i64(7), ModelID: &model},
+			wantSeed: i64(7),
+		},
+		{
+			name: "success: picks a random seed",
+			req:  &RerollImageRequest{ModelID: &model},
+		},
+		{
+			name:        "fail: nil request",
+			expectedErr: "request cannot be nil",
+		},
+		{
+			name:        "fail: source not found",
+			req:         &RerollImageRequest{},
+			sourceErr:   pgx.ErrNoRows,
+			expectedErr: "failed to get source image: no rows in result set",
+		},
+		{
+			name:        "fail: variant create error",
+			req:         &RerollImageRequest{},
+			variantErr:  errors.New("db error"),
+			expectedErr: "failed to create image variant: db error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				GetImageByIDFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
+					if tc.sourceErr != nil {
+						return nil, tc.sourceErr
+					}
+					return source, nil
+				},
+				CreateImageVariantFunc: func(
+					ctx context.Context, sourceImageID string, roomType, style *string, seed *int64, prompt *string,
+				) (*queries.Image, error) {
+					if tc.variantErr != nil {
+						return nil, tc.variantErr
+					}
+					variant := *source
+					variant.ID = pgtype.UUID{Bytes: variantID, Valid: true}
+					variant.Status = queries.ImageStatusQueued
+					variant.Seed = pgtype.Int8{Int64: *seed, Valid: true}
+					return &variant, nil
+				},
+			}
+			var payload JobPayload
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					require.NoError(t, json.Unmarshal(payloadJSON, &payload))
+					return &queries.Job{}, nil
+				},
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+			service.bus = &events.BusMock{
+				PublishFunc: func(ctx context.Context, event events.Event) error { return nil },
+			}
+
+			img, err := service.RerollImage(context.Background(), sourceID.String(), tc.req)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				assert.Empty(t, jobRepo.CreateJobCalls())
+				return
+			}
+			require.NoError(t, err)
+
+			calls := imageRepo.CreateImageVariantCalls()
+			require.Len(t, calls, 1)
+			assert.Equal(t, sourceID.String(), calls[0].SourceImageID)
+			assert.Equal(t, "bedroom", *calls[0].RoomType)
+			assert.Equal(t, "modern", *calls[0].Style)
+			assert.Equal(t, "bright and airy staging", *calls[0].Prompt)
+			require.NotNil(t, calls[0].Seed)
+			if tc.wantSeed != nil {
+				assert.Equal(t, *tc.wantSeed, *calls[0].Seed)
+			} else {
+				assert.True(t, *calls[0].Seed >= 1 && *calls[0].Seed <= MaxSeed, "seed %d out of range", *calls[0].Seed)
+			}
+
+			assert.Equal(t, variantID, img.ID)
+			assert.Equal(t, calls[0].Seed, img.Seed)
+			require.Len(t, jobRepo.CreateJobCalls(), 1)
+			assert.Equal(t, calls[0].Seed, payload.Seed)
+			assert.Equal(t, &model, payload.ModelID)
+		})
+	}
+}
+
+func TestDefaultService_BatchCreateImages_PartialFailure(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	imageRepo := &RepositoryMock{
+		CreateImageFunc: func(
+			ctx context.Context, projectID, originalURL string, roomType, style *string, seed *int64, prompt *string,
+			mode string,
+		) (*queries.Image, error) {
+			if originalURL == "http://example.com/broken.jpg" {
+				return nil, errors.New("insert failed")
+			}
+			return &queries.Image{
+				ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				OriginalUrl: pgtype.Text{String: originalURL, Valid: true},
+				Status:      queries.ImageStatusQueued,
+			}, nil
+		},
+	}
+	jobRepo := &job.RepositoryMock{
+		CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+			return &queries.Job{}, nil
+		},
+	}
+	service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+	service.bus = &events.BusMock{
+		PublishFunc: func(ctx context.Context, event events.Event) error { return nil },
+	}
+
+	projectID := uuid.New()
+	resp, err := service.BatchCreateImages(context.Background(), []CreateImageRequest{
+		{ProjectID: projectID, OriginalURL: "http://example.com/a.jpg"},
+		{ProjectID: projectID, OriginalURL: "http://example.com/broken.jpg"},
+		{ProjectID: projectID, OriginalURL: "http://example.com/c.jpg"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.Success)
+	assert.Equal(t, 1, resp.Failed)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, 1, resp.Errors[0].Index)
+	assert.Len(t, imageRepo.CreateImageCalls(), 3, "a failure must not stop the remaining images")
+}
+
 func TestDefaultService_GetImageByID(t *testing.T) {
 	cfg := setupTestConfig(t)
 
@@ -213,6 +710,19 @@ func TestDefaultService_GetImageByID(t *testing.T) {
 						Style:       pgtype.Text{String: "modern", Valid: true},
 						Seed:        pgtype.Int8{Int64: 123, Valid: true},
 						Error:       pgtype.Text{String: "some error", Valid: true},
+						Blurhash:    pgtype.Text{String: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", Valid: true},
+						ErrorCode:   pgtype.Text{String: "corrupt_image", Valid: true},
+
+						DetectedRoomType:   pgtype.Text{String: "bedroom", Valid: true},
+						RoomTypeConfidence: pgtype.Float8{Float64: 0.87, Valid: true},
+						StagedContentType:  pgtype.Text{String: "image/webp", Valid: true},
+					}, nil
+				}
+				imageRepo.ListThumbnailsFunc = func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+					assert.Equal(t, []string{imageID.String()}, imageIDs)
+					return []Thumbnail{
+						{ImageID: imageID.String(), Source: "original", Size: "large", URL: "s3://b/original-large.jpg"},
+						{ImageID: imageID.String(), Source: "staged", Size: "small", URL: "s3://b/staged-small.jpg"},
 					}, nil
 				}
 			},
@@ -237,6 +747,9 @@ func TestDefaultService_GetImageByID(t *testing.T) {
 						UpdatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
 					}, nil
 				}
+				imageRepo.ListThumbnailsFunc = func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+					return []Thumbnail{}, nil
+				}
 			},
 			expectedImage: &Image{
 				ID:          imageID,
@@ -261,6 +774,19 @@ func TestDefaultService_GetImageByID(t *testing.T) {
 			},
 			expectedErr: errors.New("failed to get image: db error"),
 		},
+		{
+			name:    "fail: thumbnail error",
+			imageID: imageID.String(),
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.GetImageByIDFunc = func(ctx context.Context, imageIDStr string) (*queries.Image, error) {
+					return &queries.Image{ID: pgtype.UUID{Bytes: imageID, Valid: true}}, nil
+				}
+				imageRepo.ListThumbnailsFunc = func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedErr: errors.New("failed to get thumbnails: db error"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -268,7 +794,7 @@ func TestDefaultService_GetImageByID(t *testing.T) {
 			imageRepo := &RepositoryMock{}
 			tc.setupMocks(imageRepo)
 
-			service := NewDefaultService(cfg, imageRepo, nil, nil)
+			service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
 			image, err := service.GetImageByID(context.Background(), tc.imageID)
 
 			if tc.expectedErr != nil {
@@ -286,12 +812,26 @@ func TestDefaultService_GetImageByID(t *testing.T) {
 					assert.NotNil(t, image.Style)
 					assert.NotNil(t, image.Seed)
 					assert.NotNil(t, image.Error)
+					assert.Equal(t, "LEHV6nWB2yk8pyo0adR*.7kCMdnj", *image.Blurhash)
+					assert.Equal(t, "corrupt_image", *image.ErrorCode)
+					assert.Equal(t, "bedroom", *image.DetectedRoomType)
+					assert.Equal(t, 0.87, *image.RoomTypeConfidence)
+					assert.Equal(t, "image/webp", *image.StagedContentType)
+					assert.Equal(t, &Thumbnails{
+						Original: &ThumbnailSet{Large: "s3://b/original-large.jpg"},
+						Staged:   &ThumbnailSet{Small: "s3://b/staged-small.jpg"},
+					}, image.Thumbnails)
 				} else {
 					assert.Nil(t, image.StagedURL)
 					assert.Nil(t, image.RoomType)
 					assert.Nil(t, image.Style)
 					assert.Nil(t, image.Seed)
 					assert.Nil(t, image.Error)
+					assert.Nil(t, image.Blurhash)
+					assert.Nil(t, image.ErrorCode)
+					assert.Nil(t, image.DetectedRoomType)
+					assert.Nil(t, image.StagedContentType)
+					assert.Nil(t, image.Thumbnails)
 				}
 			}
 		})
@@ -302,29 +842,32 @@ func TestDefaultService_GetImagesByProjectID(t *testing.T) {
 	cfg := setupTestConfig(t)
 
 	projectID := uuid.New()
+	next := "next-page"
 
 	testCases := []struct {
 		name           string
 		projectID      string
 		setupMocks     func(*RepositoryMock)
-		expectedImages []*Image
+		expectedImages int
+		expectedNext   *string
 		expectedErr    error
 	}{
 		{
 			name:      "success: get images by project id",
 			projectID: projectID.String(),
 			setupMocks: func(imageRepo *RepositoryMock) {
-				imageRepo.GetImagesByProjectIDFunc = func(ctx context.Context, projectID string) ([]*queries.Image, error) {
-					return []*queries.Image{
-							{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}},
-						},
-						nil
+				imageRepo.ListImagesByProjectIDFunc = func(
+					ctx context.Context, projectID string, page pagination.Page,
+				) ([]*queries.Image, *string, error) {
+					assert.Equal(t, pagination.DefaultLimit, page.Limit)
+					return []*queries.Image{{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}}, &next, nil
+				}
+				imageRepo.ListThumbnailsFunc = func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+					return []Thumbnail{}, nil
 				}
 			},
-			expectedImages: []*Image{
-				{ID: uuid.New()},
-			},
-			expectedErr: nil,
+			expectedImages: 1,
+			expectedNext:   &next,
 		},
 		{
 			name:        "fail: empty project id",
@@ -336,12 +879,29 @@ func TestDefaultService_GetImagesByProjectID(t *testing.T) {
 			name:      "fail: db error",
 			projectID: projectID.String(),
 			setupMocks: func(imageRepo *RepositoryMock) {
-				imageRepo.GetImagesByProjectIDFunc = func(ctx context.Context, projectID string) ([]*queries.Image, error) {
-					return nil, errors.New("db error")
+				imageRepo.ListImagesByProjectIDFunc = func(
+					ctx context.Context, projectID string, page pagination.Page,
+				) ([]*queries.Image, *string, error) {
+					return nil, nil, errors.New("db error")
 				}
 			},
 			expectedErr: errors.New("failed to get images: db error"),
 		},
+		{
+			name:      "fail: thumbnail error",
+			projectID: projectID.String(),
+			setupMocks: func(imageRepo *RepositoryMock) {
+				imageRepo.ListImagesByProjectIDFunc = func(
+					ctx context.Context, projectID string, page pagination.Page,
+				) ([]*queries.Image, *string, error) {
+					return []*queries.Image{{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}}, nil, nil
+				}
+				imageRepo.ListThumbnailsFunc = func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+					return nil, errors.New("db error")
+				}
+			},
+			expectedErr: errors.New("failed to get thumbnails: db error"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -349,20 +909,188 @@ func TestDefaultService_GetImagesByProjectID(t *testing.T) {
 			imageRepo := &RepositoryMock{}
 			tc.setupMocks(imageRepo)
 
-			service := NewDefaultService(cfg, imageRepo, nil, nil)
-			images, err := service.GetImagesByProjectID(context.Background(), tc.projectID)
+			service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+			resp, err := service.GetImagesByProjectID(context.Background(), tc.projectID, pagination.First())
 
 			if tc.expectedErr != nil {
 				assert.EqualError(t, err, tc.expectedErr.Error())
-				assert.Nil(t, images)
+				assert.Nil(t, resp)
 			} else {
 				assert.NoError(t, err)
-				assert.Len(t, images, len(tc.expectedImages))
+				assert.Len(t, resp.Images, tc.expectedImages)
+				assert.Equal(t, tc.expectedNext, resp.NextCursor)
 			}
 		})
 	}
 }
 
+func TestDefaultService_ListReadyImages(t *testing.T) {
+	cfg := setupTestConfig(t)
+	projectID := uuid.New().String()
+	dbImage := func(status queries.ImageStatus, staged string) *queries.Image {
+		return &queries.Image{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			OriginalUrl: pgtype.Text{String: "http://example.com/original.jpg", Valid: true},
+			StagedUrl:   pgtype.Text{String: staged, Valid: staged != ""},
+			Status:      status,
+		}
+	}
+
+	t.Run("success: ready images oldest first", func(t *testing.T) {
+		newest := dbImage(queries.ImageStatusReady, "http://example.com/newest.jpg")
+		oldest := dbImage(queries.ImageStatusReady, "http://example.com/oldest.jpg")
+		imageRepo := &RepositoryMock{
+			GetImagesByProjectIDFunc: func(ctx context.Context, gotProjectID string) ([]*queries.Image, error) {
+				assert.Equal(t, projectID, gotProjectID)
+				return []*queries.Image{
+					newest,
+					dbImage(queries.ImageStatusProcessing, ""),
+					dbImage(queries.ImageStatusReady, ""),
+					dbImage(queries.ImageStatusError, "http://example.com/failed.jpg"),
+					oldest,
+				}, nil
+			},
+		}
+
+		images, err := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil).ListReadyImages(context.Background(), projectID)
+		require.NoError(t, err)
+		require.Len(t, images, 2)
+		assert.Equal(t, uuid.UUID(oldest.ID.Bytes), images[0].ID)
+		assert.Equal(t, uuid.UUID(newest.ID.Bytes), images[1].ID)
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*queries.Image, error) {
+				return nil, errors.New("db error")
+			},
+		}
+
+		_, err := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil).ListReadyImages(context.Background(), projectID)
+		assert.EqualError(t, err, "failed to get images: db error")
+	})
+}
+
+func TestDefaultService_SearchImages(t *testing.T) {
+	cfg := setupTestConfig(t)
+	userID := uuid.New().String()
+	query := SearchQuery{Terms: "bedroom"}
+	next := "next-page"
+
+	t.Run("success: converts the matches", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			SearchImagesFunc: func(
+				ctx context.Context, gotUserID string, got SearchQuery, page pagination.Page,
+			) ([]*queries.Image, *string, error) {
+				assert.Equal(t, userID, gotUserID)
+				assert.Equal(t, query, got)
+				return []*queries.Image{{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}}}, &next, nil
+			},
+			ListThumbnailsFunc: func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+				return []Thumbnail{}, nil
+			},
+		}
+
+		resp, err := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil).SearchImages(
+			context.Background(), userID, query, pagination.First())
+		require.NoError(t, err)
+		assert.Len(t, resp.Images, 1)
+		assert.Equal(t, &next, resp.NextCursor)
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			SearchImagesFunc: func(
+				ctx context.Context, userID string, query SearchQuery, page pagination.Page,
+			) ([]*queries.Image, *string, error) {
+				return nil, nil, errors.New("db error")
+			},
+		}
+
+		_, err := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil).SearchImages(
+			context.Background(), userID, query, pagination.First())
+		assert.EqualError(t, err, "failed to search images: db error")
+	})
+}
+
+func TestDefaultService_GetGroupedProjectImages(t *testing.T) {
+	cfg := setupTestConfig(t)
+	projectID := uuid.New()
+	next := "next-page"
+	variant := func(url string) *queries.Image {
+		return &queries.Image{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+			OriginalUrl: pgtype.Text{String: url, Valid: true},
+			Status:      queries.ImageStatusReady,
+		}
+	}
+
+	t.Run("success: groups keep the order of their newest variant", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			ListImagesByProjectIDFunc: func(
+				ctx context.Context, projectID string, page pagination.Page,
+			) ([]*queries.Image, *string, error) {
+				return []*queries.Image{
+					variant("https://x/b.jpg"), variant("https://x/a.jpg"), variant("https://x/b.jpg"),
+				}, &next, nil
+			},
+			ListThumbnailsFunc: func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+				return []Thumbnail{}, nil
+			},
+		}
+
+		service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+		resp, err := service.GetGroupedProjectImages(context.Background(), projectID.String(), pagination.First())
+		require.NoError(t, err)
+
+		require.Len(t, resp.Images, 2)
+		assert.Equal(t, "https://x/b.jpg", resp.Images[0].OriginalURL)
+		assert.Len(t, resp.Images[0].Variants, 2)
+		assert.Equal(t, "https://x/a.jpg", resp.Images[1].OriginalURL)
+		assert.Equal(t, &next, resp.NextCursor)
+	})
+
+	t.Run("success: extra outputs link to the job's image", func(t *testing.T) {
+		primary := variant("https://x/a.jpg")
+		extra := variant("https://x/a.jpg")
+		extra.PrimaryImageID = primary.ID
+		imageRepo := &RepositoryMock{
+			ListImagesByProjectIDFunc: func(
+				ctx context.Context, projectID string, page pagination.Page,
+			) ([]*queries.Image, *string, error) {
+				return []*queries.Image{extra, primary}, nil, nil
+			},
+			ListThumbnailsFunc: func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+				return []Thumbnail{}, nil
+			},
+		}
+
+		service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+		resp, err := service.GetGroupedProjectImages(context.Background(), projectID.String(), pagination.First())
+		require.NoError(t, err)
+
+		require.Len(t, resp.Images, 1)
+		require.Len(t, resp.Images[0].Variants, 2)
+		require.NotNil(t, resp.Images[0].Variants[0].PrimaryImageID)
+		assert.Equal(t, uuid.UUID(primary.ID.Bytes), *resp.Images[0].Variants[0].PrimaryImageID)
+		assert.Nil(t, resp.Images[0].Variants[1].PrimaryImageID)
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			ListImagesByProjectIDFunc: func(
+				ctx context.Context, projectID string, page pagination.Page,
+			) ([]*queries.Image, *string, error) {
+				return nil, nil, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+		_, err := service.GetGroupedProjectImages(context.Background(), projectID.String(), pagination.First())
+		assert.EqualError(t, err, "failed to get images: db error")
+	})
+}
+
 func TestDefaultService_UpdateImageStatus(t *testing.T) {
 	cfg := setupTestConfig(t)
 
@@ -414,7 +1142,7 @@ func TestDefaultService_UpdateImageStatus(t *testing.T) {
 			imageRepo := &RepositoryMock{}
 			tc.setupMocks(imageRepo)
 
-			service := NewDefaultService(cfg, imageRepo, nil, nil)
+			service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
 			image, err := service.UpdateImageStatus(context.Background(), tc.imageID, tc.status)
 
 			if tc.expectedErr != nil {
@@ -490,7 +1218,7 @@ func TestDefaultService_UpdateImageWithStagedURL(t *testing.T) {
 			imageRepo := &RepositoryMock{}
 			tc.setupMocks(imageRepo)
 
-			service := NewDefaultService(cfg, imageRepo, nil, nil)
+			service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
 			image, err := service.UpdateImageWithStagedURL(context.Background(), tc.imageID, tc.stagedURL)
 
 			if tc.expectedErr != nil {
@@ -562,7 +1290,7 @@ func TestDefaultService_UpdateImageWithError(t *testing.T) {
 			imageRepo := &RepositoryMock{}
 			tc.setupMocks(imageRepo)
 
-			service := NewDefaultService(cfg, imageRepo, nil, nil)
+			service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
 			image, err := service.UpdateImageWithError(context.Background(), tc.imageID, tc.errorMsg)
 
 			if tc.expectedErr != nil {
@@ -591,9 +1319,6 @@ func TestDefaultService_DeleteImage(t *testing.T) {
 			name:    "success: delete image",
 			imageID: imageID.String(),
 			setupMocks: func(imageRepo *RepositoryMock) {
-				imageRepo.GetOriginalImageIDFunc = func(ctx context.Context, imageID string) (string, error) {
-					return "", nil // No original_image_id for this image
-				}
 				imageRepo.DeleteImageFunc = func(ctx context.Context, imageID string) error {
 					return nil
 				}
@@ -610,9 +1335,6 @@ func TestDefaultService_DeleteImage(t *testing.T) {
 			name:    "fail: db error",
 			imageID: imageID.String(),
 			setupMocks: func(imageRepo *RepositoryMock) {
-				imageRepo.GetOriginalImageIDFunc = func(ctx context.Context, imageID string) (string, error) {
-					return "", nil
-				}
 				imageRepo.DeleteImageFunc = func(ctx context.Context, imageID string) error {
 					return errors.New("db error")
 				}
@@ -626,7 +1348,7 @@ func TestDefaultService_DeleteImage(t *testing.T) {
 			imageRepo := &RepositoryMock{}
 			tc.setupMocks(imageRepo)
 
-			service := NewDefaultService(cfg, imageRepo, nil, nil)
+			service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
 			err := service.DeleteImage(context.Background(), tc.imageID)
 
 			if tc.expectedErr != nil {
@@ -638,6 +1360,80 @@ func TestDefaultService_DeleteImage(t *testing.T) {
 	}
 }
 
+func TestDefaultService_PurgeTrash(t *testing.T) {
+	cfg := setupTestConfig(t)
+	originalID := uuid.New().String()
+
+	t.Run("success: releases originals of purged images", func(t *testing.T) {
+		var gotDeletedBefore, gotCreatedBefore time.Time
+		imageRepo := &RepositoryMock{
+			PurgeDeletedImagesFunc: func(
+				ctx context.Context, deletedBefore, createdBefore time.Time, limit int,
+			) ([]PurgedImage, error) {
+				gotDeletedBefore, gotCreatedBefore = deletedBefore, createdBefore
+				return []PurgedImage{
+					{ID: uuid.New(), OriginalImageID: originalID, Files: []string{"s3://bucket/staged.jpg"}},
+					{ID: uuid.New(), Files: []string{"s3://bucket/legacy.jpg"}},
+				}, nil
+			},
+		}
+		originals := &originalimage.ServiceMock{
+			DecrementReferenceAndCleanupFunc: func(ctx context.Context, id string) (bool, error) {
+				return false, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil, originals, nil, nil)
+
+		purged, err := service.PurgeTrash(context.Background(), 7*24*time.Hour, 50)
+
+		require.NoError(t, err)
+		assert.Len(t, purged, 2)
+		assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), gotDeletedBefore, time.Minute)
+		// Images are kept for the whole billing period they count towards.
+		assert.WithinDuration(t, time.Now().Add(-usageWindow), gotCreatedBefore, time.Minute)
+		assert.Equal(t, 50, imageRepo.PurgeDeletedImagesCalls()[0].Limit)
+		require.Len(t, originals.DecrementReferenceAndCleanupCalls(), 1)
+		assert.Equal(t, originalID, originals.DecrementReferenceAndCleanupCalls()[0].OriginalImageID)
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			PurgeDeletedImagesFunc: func(
+				ctx context.Context, deletedBefore, createdBefore time.Time, limit int,
+			) ([]PurgedImage, error) {
+				return nil, errors.New("db error")
+			},
+		}
+
+		_, err := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil).PurgeTrash(context.Background(), time.Hour, 50)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_RestoreImage(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID := uuid.New()
+
+	imageRepo := &RepositoryMock{
+		RestoreImageFunc: func(ctx context.Context, id string) (*queries.Image, error) {
+			if id != imageID.String() {
+				return nil, pgx.ErrNoRows
+			}
+			return &queries.Image{ID: pgtype.UUID{Bytes: imageID, Valid: true}, Status: queries.ImageStatusReady}, nil
+		},
+	}
+	service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+
+	img, err := service.RestoreImage(context.Background(), imageID.String())
+	require.NoError(t, err)
+	assert.Equal(t, imageID, img.ID)
+	assert.Nil(t, img.DeletedAt)
+
+	_, err = service.RestoreImage(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
 // Helper to create a successful image creation mock
 func mockSuccessfulImageCreation(imageID, projectID uuid.UUID) func(*RepositoryMock, *job.RepositoryMock) {
 	return func(imageRepo *RepositoryMock, _ *job.RepositoryMock) {
@@ -647,6 +1443,7 @@ func mockSuccessfulImageCreation(imageID, projectID uuid.UUID) func(*RepositoryM
 			roomType, style *string,
 			seed *int64,
 			prompt *string,
+			mode string,
 		) (*queries.Image, error) {
 			return &queries.Image{
 				ID:          pgtype.UUID{Bytes: imageID, Valid: true},
@@ -659,3 +1456,553 @@ func mockSuccessfulImageCreation(imageID, projectID uuid.UUID) func(*RepositoryM
 		}
 	}
 }
+
+func TestDefaultService_PlanProjectRestyle(t *testing.T) {
+	cfg := setupTestConfig(t)
+	projectID := uuid.New()
+	now := time.Now()
+
+	img := func(url, style string, status queries.ImageStatus, updated time.Time, seed int64) *queries.Image {
+		return &queries.Image{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+			OriginalUrl: pgtype.Text{String: url, Valid: true},
+			RoomType:    pgtype.Text{String: "bedroom", Valid: true},
+			Style:       pgtype.Text{String: style, Valid: true},
+			Seed:        pgtype.Int8{Int64: seed, Valid: true},
+			Prompt:      pgtype.Text{String: "a custom modern prompt", Valid: true},
+			Status:      status,
+			UpdatedAt:   pgtype.Timestamptz{Time: updated, Valid: true},
+		}
+	}
+
+	t.Run("success: plans one variant per eligible group", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*queries.Image, error) {
+				return []*queries.Image{
+					// a: two ready variants, newest wins
+					img("https://x/a.jpg", "modern", queries.ImageStatusReady, now.Add(-time.Hour), 1),
+					img("https://x/a.jpg", "traditional", queries.ImageStatusReady, now, 2),
+					// b: already has a queued industrial variant
+					img("https://x/b.jpg", "modern", queries.ImageStatusReady, now, 3),
+					img("https://x/b.jpg", "industrial", queries.ImageStatusQueued, now, 3),
+					// c: no ready image yet
+					img("https://x/c.jpg", "modern", queries.ImageStatusProcessing, now, 4),
+					// d: failed industrial attempt does not block a retry
+					img("https://x/d.jpg", "modern", queries.ImageStatusReady, now, 5),
+					img("https://x/d.jpg", "industrial", queries.ImageStatusError, now, 5),
+					// e: a later upload of the same original was quarantined
+					img("https://x/e.jpg", "modern", queries.ImageStatusReady, now, 6),
+					img("https://x/e.jpg", "modern", queries.ImageStatusRejected, now, 6),
+				}, nil
+			},
+		}
+
+		service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+		reqs, skipped, err := service.PlanProjectRestyle(context.Background(), projectID.String(), "industrial")
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, skipped)
+		require.Len(t, reqs, 2)
+		assert.Equal(t, "https://x/a.jpg", reqs[0].OriginalURL)
+		assert.Equal(t, int64(2), *reqs[0].Seed)
+		assert.Equal(t, "https://x/d.jpg", reqs[1].OriginalURL)
+		for _, r := range reqs {
+			assert.Equal(t, projectID, r.ProjectID)
+			assert.Equal(t, "industrial", *r.Style)
+			assert.Equal(t, "bedroom", *r.RoomType)
+			assert.Nil(t, r.Prompt)
+		}
+	})
+
+	t.Run("fail: empty style", func(t *testing.T) {
+		service := NewDefaultService(cfg, &RepositoryMock{}, nil, nil, nil, nil)
+		_, _, err := service.PlanProjectRestyle(context.Background(), projectID.String(), "")
+		assert.EqualError(t, err, "style cannot be empty")
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		imageRepo := &RepositoryMock{
+			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*queries.Image, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+		_, _, err := service.PlanProjectRestyle(context.Background(), projectID.String(), "industrial")
+		assert.EqualError(t, err, "failed to get images: db error")
+	})
+}
+
+func TestDefaultService_PlanProjectDuplicate(t *testing.T) {
+	cfg := setupTestConfig(t)
+	projectID := uuid.New()
+	now := time.Now()
+
+	img := func(url, style string, status queries.ImageStatus, updated time.Time, seed int64) *queries.Image {
+		return &queries.Image{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+			OriginalUrl: pgtype.Text{String: url, Valid: true},
+			RoomType:    pgtype.Text{String: "bedroom", Valid: true},
+			Style:       pgtype.Text{String: style, Valid: true},
+			Seed:        pgtype.Int8{Int64: seed, Valid: true},
+			Prompt:      pgtype.Text{String: "a custom " + style + " prompt", Valid: true},
+			Status:      status,
+			UpdatedAt:   pgtype.Timestamptz{Time: updated, Valid: true},
+		}
+	}
+	// Newest first, as the repository returns them.
+	imageRepo := &RepositoryMock{
+		GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*queries.Image, error) {
+			return []*queries.Image{
+				// c: quarantined
+				img("https://x/c.jpg", "modern", queries.ImageStatusRejected, now, 5),
+				img("https://x/c.jpg", "modern", queries.ImageStatusReady, now, 5),
+				// b: no ready image yet
+				img("https://x/b.jpg", "modern", queries.ImageStatusProcessing, now, 4),
+				// a: two ready variants and a failed one
+				img("https://x/a.jpg", "industrial", queries.ImageStatusError, now, 3),
+				img("https://x/a.jpg", "traditional", queries.ImageStatusReady, now, 2),
+				img("https://x/a.jpg", "modern", queries.ImageStatusReady, now.Add(-time.Hour), 1),
+			}, nil
+		},
+	}
+
+	t.Run("success: copies every ready variant", func(t *testing.T) {
+		service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+		reqs, skipped, err := service.PlanProjectDuplicate(context.Background(), projectID.String(), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, skipped)
+		require.Len(t, reqs, 2)
+		assert.Equal(t, "modern", *reqs[0].Style)
+		assert.Equal(t, int64(1), *reqs[0].Seed)
+		assert.Equal(t, "a custom modern prompt", *reqs[0].Prompt)
+		assert.Equal(t, "traditional", *reqs[1].Style)
+		for _, r := range reqs {
+			assert.Equal(t, projectID, r.ProjectID)
+			assert.Equal(t, "https://x/a.jpg", r.OriginalURL)
+			assert.Equal(t, "bedroom", *r.RoomType)
+		}
+	})
+
+	t.Run("success: one image per group in the new style", func(t *testing.T) {
+		service := NewDefaultService(cfg, imageRepo, nil, nil, nil, nil)
+		style := "scandinavian"
+		reqs, skipped, err := service.PlanProjectDuplicate(context.Background(), projectID.String(), &style)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, skipped)
+		require.Len(t, reqs, 1)
+		assert.Equal(t, "scandinavian", *reqs[0].Style)
+		assert.Equal(t, int64(2), *reqs[0].Seed)
+		assert.Nil(t, reqs[0].Prompt)
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		failing := &RepositoryMock{
+			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*queries.Image, error) {
+				return nil, errors.New("db error")
+			},
+		}
+		service := NewDefaultService(cfg, failing, nil, nil, nil, nil)
+		_, _, err := service.PlanProjectDuplicate(context.Background(), projectID.String(), nil)
+		assert.EqualError(t, err, "failed to get images: db error")
+	})
+}
+
+func TestDefaultService_CreateImage_ModelID(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID, projectID := uuid.New(), uuid.New()
+	model := "bytedance/seedream-4"
+
+	testCases := []struct {
+		name        string
+		modelID     *string
+		modelConfig map[string]interface{}
+	}{
+		{name: "success: the picked model is sent to the worker", modelID: &model},
+		{name: "success: no model leaves the global one", modelID: nil},
+		{
+			name:        "success: a preset's config overrides are sent to the worker",
+			modelID:     &model,
+			modelConfig: map[string]interface{}{"size": "4K", "max_images": 2.0},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+			}
+			mockSuccessfulImageCreation(imageID, projectID)(imageRepo, jobRepo)
+			enqueuer := &queue.EnqueuerMock{
+				EnqueueStageRunFunc: func(
+					ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+				) (string, error) {
+					return "task-1", nil
+				},
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+			service.enqueuer = enqueuer
+
+			_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID: projectID, OriginalURL: "http://example.com/image.jpg", ModelID: tc.modelID,
+				ModelConfig: tc.modelConfig,
+			})
+			require.NoError(t, err)
+
+			require.Len(t, enqueuer.EnqueueStageRunCalls(), 1)
+			assert.Equal(t, tc.modelID, enqueuer.EnqueueStageRunCalls()[0].Payload.ModelID)
+			assert.Equal(t, tc.modelConfig, enqueuer.EnqueueStageRunCalls()[0].Payload.ModelConfig)
+			var payload JobPayload
+			require.NoError(t, json.Unmarshal(jobRepo.CreateJobCalls()[0].PayloadJSON, &payload))
+			assert.Equal(t, tc.modelID, payload.ModelID)
+			assert.Equal(t, tc.modelConfig, payload.ModelConfig)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_Mask(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID, projectID := uuid.New(), uuid.New()
+	mask := "https://bucket.s3.amazonaws.com/users/u1/projects/p1/masks/sofa.png"
+
+	newService := func(setMaskErr error) (*DefaultService, *RepositoryMock, *queue.EnqueuerMock) {
+		imageRepo := &RepositoryMock{
+			SetImageMaskFunc: func(ctx context.Context, imageID, maskURL string) error { return setMaskErr },
+			UpdateImageWithErrorFunc: func(ctx context.Context, imageID, errorMsg string) (*queries.Image, error) {
+				return &queries.Image{}, nil
+			},
+		}
+		jobRepo := &job.RepositoryMock{
+			CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+				return &queries.Job{}, nil
+			},
+		}
+		mockSuccessfulImageCreation(imageID, projectID)(imageRepo, jobRepo)
+		enqueuer := &queue.EnqueuerMock{
+			EnqueueStageRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "task-1", nil
+			},
+		}
+		service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+		service.enqueuer = enqueuer
+		return service, imageRepo, enqueuer
+	}
+
+	t.Run("success: the mask is stored and sent to the worker", func(t *testing.T) {
+		service, imageRepo, enqueuer := newService(nil)
+
+		img, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID: projectID, OriginalURL: "http://example.com/image.jpg", MaskURL: &mask,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, imageRepo.SetImageMaskCalls(), 1)
+		assert.Equal(t, imageID.String(), imageRepo.SetImageMaskCalls()[0].ImageID)
+		assert.Equal(t, mask, imageRepo.SetImageMaskCalls()[0].MaskURL)
+		assert.Equal(t, &mask, img.MaskURL)
+		require.Len(t, enqueuer.EnqueueStageRunCalls(), 1)
+		assert.Equal(t, &mask, enqueuer.EnqueueStageRunCalls()[0].Payload.MaskURL)
+	})
+
+	t.Run("fail: mask not stored", func(t *testing.T) {
+		service, imageRepo, enqueuer := newService(errors.New("db error"))
+
+		_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+			ProjectID: projectID, OriginalURL: "http://example.com/image.jpg", MaskURL: &mask,
+		})
+		assert.EqualError(t, err, "failed to set image mask: db error")
+		assert.Empty(t, enqueuer.EnqueueStageRunCalls())
+		require.Len(t, imageRepo.UpdateImageWithErrorCalls(), 1)
+		assert.Equal(t, imageID.String(), imageRepo.UpdateImageWithErrorCalls()[0].ImageID)
+	})
+}
+
+func TestDefaultService_CreateImage_Language(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID, projectID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "success: english is left out", ctx: context.Background(), want: ""},
+		{name: "success: request language is sent", ctx: i18n.WithLanguage(context.Background(), "fr"), want: "fr"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+			}
+			mockSuccessfulImageCreation(imageID, projectID)(imageRepo, jobRepo)
+			enqueuer := &queue.EnqueuerMock{
+				EnqueueStageRunFunc: func(
+					ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+				) (string, error) {
+					return "task-1", nil
+				},
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+			service.enqueuer = enqueuer
+
+			_, err := service.CreateImage(tc.ctx, &CreateImageRequest{
+				ProjectID: projectID, OriginalURL: "http://example.com/image.jpg",
+			})
+			require.NoError(t, err)
+
+			require.Len(t, jobRepo.CreateJobCalls(), 1)
+			var payload JobPayload
+			require.NoError(t, json.Unmarshal(jobRepo.CreateJobCalls()[0].PayloadJSON, &payload))
+			assert.Equal(t, tc.want, payload.Language)
+			require.Len(t, enqueuer.EnqueueStageRunCalls(), 1)
+			assert.Equal(t, tc.want, enqueuer.EnqueueStageRunCalls()[0].Payload.Language)
+		})
+	}
+}
+
+func TestDefaultService_CreateImage_Priority(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID, projectID := uuid.New(), uuid.New()
+
+	imageRepo := &RepositoryMock{}
+	jobRepo := &job.RepositoryMock{
+		CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+			return &queries.Job{}, nil
+		},
+	}
+	mockSuccessfulImageCreation(imageID, projectID)(imageRepo, jobRepo)
+	enqueuer := &queue.EnqueuerMock{
+		EnqueueStageRunFunc: func(
+			ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+		) (string, error) {
+			return "task-1", nil
+		},
+	}
+	service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+	service.enqueuer = enqueuer
+
+	_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+		ProjectID: projectID, OriginalURL: "http://example.com/image.jpg", Priority: queue.PriorityCritical,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, jobRepo.CreateJobCalls(), 1)
+	assert.Contains(t, string(jobRepo.CreateJobCalls()[0].PayloadJSON), `"priority":"critical"`)
+	require.Len(t, enqueuer.EnqueueStageRunCalls(), 1)
+	assert.Equal(t, queue.PriorityCritical, enqueuer.EnqueueStageRunCalls()[0].Payload.Priority)
+}
+
+func TestDefaultService_CreateImage_TaskID(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID, projectID, jobID := uuid.New(), uuid.New(), uuid.New()
+
+	imageRepo := &RepositoryMock{}
+	jobRepo := &job.RepositoryMock{}
+	mockSuccessfulImageCreation(imageID, projectID)(imageRepo, jobRepo)
+	jobRepo.CreateJobFunc = func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+		return &queries.Job{ID: pgtype.UUID{Bytes: jobID, Valid: true}}, nil
+	}
+	enqueuer := &queue.EnqueuerMock{
+		EnqueueStageRunFunc: func(
+			ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+		) (string, error) {
+			return jobID.String(), nil
+		},
+	}
+	service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+	service.enqueuer = enqueuer
+
+	_, err := service.CreateImage(context.Background(), &CreateImageRequest{
+		ProjectID: projectID, OriginalURL: "http://example.com/image.jpg",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, enqueuer.EnqueueStageRunCalls(), 1)
+	assert.Equal(t, &queue.EnqueueOpts{TaskID: jobID.String(), Retry: -1}, enqueuer.EnqueueStageRunCalls()[0].Opts)
+}
+
+func TestDefaultService_CreateImage_Mode(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID, projectID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name         string
+		mode         Mode
+		wantMode     string
+		wantTaskType string
+	}{
+		{name: "success: no mode stages", wantMode: "stage", wantTaskType: "stage:run"},
+		{name: "success: declutter", mode: ModeDeclutter, wantMode: "declutter", wantTaskType: "declutter:run"},
+		{name: "success: both declutters first", mode: ModeBoth, wantMode: "both", wantTaskType: "declutter:run"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				CreateImageFunc: func(
+					ctx context.Context, projectIDStr, originalURL string, roomType, style *string, seed *int64,
+					prompt *string, mode string,
+				) (*queries.Image, error) {
+					return &queries.Image{
+						ID:          pgtype.UUID{Bytes: imageID, Valid: true},
+						ProjectID:   pgtype.UUID{Bytes: projectID, Valid: true},
+						OriginalUrl: pgtype.Text{String: originalURL, Valid: true},
+						Status:      queries.ImageStatusQueued,
+						Mode:        mode,
+					}, nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{
+				CreateJobFunc: func(ctx context.Context, imageID, jobType string, payloadJSON []byte) (*queries.Job, error) {
+					return &queries.Job{}, nil
+				},
+			}
+			enqueue := func(ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts) (string, error) {
+				return "task-1", nil
+			}
+			enqueuer := &queue.EnqueuerMock{EnqueueStageRunFunc: enqueue, EnqueueDeclutterRunFunc: enqueue}
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, nil, nil)
+			service.enqueuer = enqueuer
+
+			img, err := service.CreateImage(context.Background(), &CreateImageRequest{
+				ProjectID: projectID, OriginalURL: "http://example.com/image.jpg", Mode: tc.mode,
+			})
+			require.NoError(t, err)
+
+			require.Len(t, imageRepo.CreateImageCalls(), 1)
+			assert.Equal(t, tc.wantMode, imageRepo.CreateImageCalls()[0].Mode)
+			assert.Equal(t, Mode(tc.wantMode), img.Mode)
+			require.Len(t, jobRepo.CreateJobCalls(), 1)
+			assert.Equal(t, tc.wantTaskType, jobRepo.CreateJobCalls()[0].JobType)
+			if tc.wantTaskType == queue.TaskTypeStageRun {
+				require.Len(t, enqueuer.EnqueueStageRunCalls(), 1)
+				assert.Empty(t, enqueuer.EnqueueStageRunCalls()[0].Payload.Mode)
+				assert.Empty(t, enqueuer.EnqueueDeclutterRunCalls())
+				return
+			}
+			require.Len(t, enqueuer.EnqueueDeclutterRunCalls(), 1)
+			assert.Equal(t, tc.wantMode, enqueuer.EnqueueDeclutterRunCalls()[0].Payload.Mode)
+			assert.Empty(t, enqueuer.EnqueueStageRunCalls())
+		})
+	}
+}
+
+func TestDefaultService_CancelJob(t *testing.T) {
+	cfg := setupTestConfig(t)
+	imageID, jobID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name          string
+		cancelErr     error
+		predictionID  string
+		deleteErr     error
+		predictionErr error
+		expectErr     error
+		expectDelete  bool
+		expectCancel  bool
+	}{
+		{
+			name:         "success: removes the queued task",
+			expectDelete: true,
+		},
+		{
+			name:         "success: cancels the running prediction",
+			predictionID: "pred-1",
+			deleteErr:    queueadmin.ErrTaskActive,
+			expectDelete: true,
+			expectCancel: true,
+		},
+		{
+			name:          "success: failures to stop the job keep the image canceled",
+			predictionID:  "pred-1",
+			deleteErr:     errors.New("redis down"),
+			predictionErr: errors.New("replicate down"),
+			expectDelete:  true,
+			expectCancel:  true,
+		},
+		{
+			name:      "fail: job already finished",
+			cancelErr: fmt.Errorf("failed to cancel image: %w", pgx.ErrNoRows),
+			expectErr: ErrJobFinished,
+		},
+		{
+			name:      "fail: database error",
+			cancelErr: errors.New("db down"),
+			expectErr: errors.New("db down"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageRepo := &RepositoryMock{
+				CancelImageFunc: func(ctx context.Context, id string) (*queries.Image, error) {
+					if tc.cancelErr != nil {
+						return nil, tc.cancelErr
+					}
+					return &queries.Image{
+						ID:                    pgtype.UUID{Bytes: imageID, Valid: true},
+						Status:                queries.ImageStatusCanceled,
+						ReplicatePredictionID: pgtype.Text{String: tc.predictionID, Valid: tc.predictionID != ""},
+					}, nil
+				},
+			}
+			jobRepo := &job.RepositoryMock{
+				GetJobsByImageIDFunc: func(ctx context.Context, id string) ([]*queries.Job, error) {
+					return []*queries.Job{{ID: pgtype.UUID{Bytes: jobID, Valid: true}}}, nil
+				},
+			}
+			tasks := &TaskRemoverMock{
+				DeleteTaskFunc: func(ctx context.Context, queue, id string) error { return tc.deleteErr },
+			}
+			predictions := &PredictionCancelerMock{
+				CancelFunc: func(ctx context.Context, predictionID string) error { return tc.predictionErr },
+			}
+			bus := &events.BusMock{
+				PublishFunc: func(ctx context.Context, event events.Event) error { return nil },
+			}
+			service := NewDefaultService(cfg, imageRepo, jobRepo, nil, bus, nil)
+			service.tasks = tasks
+			service.predictions = predictions
+
+			img, err := service.CancelJob(context.Background(), imageID.String())
+
+			if tc.expectErr != nil {
+				assert.EqualError(t, err, tc.expectErr.Error())
+				assert.Nil(t, img)
+				assert.Empty(t, bus.PublishCalls())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, StatusCanceled, img.Status)
+				require.Len(t, bus.PublishCalls(), 1)
+				canceled, ok := bus.PublishCalls()[0].Event.(events.ImageCanceled)
+				require.True(t, ok, "the canceled image's credit is refunded")
+				assert.Equal(t, imageID.String(), canceled.ImageID)
+			}
+			if tc.expectDelete {
+				require.Len(t, tasks.DeleteTaskCalls(), 1)
+				assert.Equal(t, "", tasks.DeleteTaskCalls()[0].Queue)
+				assert.Equal(t, jobID.String(), tasks.DeleteTaskCalls()[0].ID)
+			} else {
+				assert.Empty(t, tasks.DeleteTaskCalls())
+			}
+			if tc.expectCancel {
+				require.Len(t, predictions.CancelCalls(), 1)
+				assert.Equal(t, tc.predictionID, predictions.CancelCalls()[0].PredictionID)
+			} else {
+				assert.Empty(t, predictions.CancelCalls())
+			}
+		})
+	}
+}
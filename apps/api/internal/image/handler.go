@@ -2,13 +2,9 @@ package image
 
 import (
 	"github.com/labstack/echo/v4"
-)
 
-// ErrorResponse represents an error response.
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-}
+	"github.com/real-staging-ai/api/internal/compliance"
+)
 
 // ValidationErrorDetail represents a validation error for a specific field.
 type ValidationErrorDetail struct {
@@ -16,11 +12,10 @@ type ValidationErrorDetail struct {
 	Message string `json:"message"`
 }
 
-// ValidationErrorResponse represents a validation error response.
-type ValidationErrorResponse struct {
-	Error            string                  `json:"error"`
-	Message          string                  `json:"message"`
-	ValidationErrors []ValidationErrorDetail `json:"validation_errors"`
+// PromptViolation is a fair-housing rule matched by one of the request's prompts.
+type PromptViolation struct {
+	Field string `json:"field"`
+	compliance.Violation
 }
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
@@ -28,8 +23,17 @@ type ValidationErrorResponse struct {
 type Handler interface {
 	CreateImage(c echo.Context) error
 	GetImage(c echo.Context) error
+	RestageImage(c echo.Context) error
+	RerollImage(c echo.Context) error
 	GetProjectImages(c echo.Context) error
 	GetGroupedProjectImages(c echo.Context) error
+	SearchImages(c echo.Context) error
 	DeleteImage(c echo.Context) error
 	GetProjectCost(c echo.Context) error
+	RestyleProject(c echo.Context) error
+	DuplicateProject(c echo.Context) error
+	ListTrash(c echo.Context) error
+	RestoreImage(c echo.Context) error
+	SetImageApproval(c echo.Context) error
+	CancelJob(c echo.Context) error
 }
@@ -18,12 +18,18 @@ var _ Handler = &HandlerMock{}
 //
 //		// make and configure a mocked Handler
 //		mockedHandler := &HandlerMock{
+//			CancelJobFunc: func(c echo.Context) error {
+//				panic("mock out the CancelJob method")
+//			},
 //			CreateImageFunc: func(c echo.Context) error {
 //				panic("mock out the CreateImage method")
 //			},
 //			DeleteImageFunc: func(c echo.Context) error {
 //				panic("mock out the DeleteImage method")
 //			},
+//			DuplicateProjectFunc: func(c echo.Context) error {
+//				panic("mock out the DuplicateProject method")
+//			},
 //			GetGroupedProjectImagesFunc: func(c echo.Context) error {
 //				panic("mock out the GetGroupedProjectImages method")
 //			},
@@ -36,6 +42,27 @@ var _ Handler = &HandlerMock{}
 //			GetProjectImagesFunc: func(c echo.Context) error {
 //				panic("mock out the GetProjectImages method")
 //			},
+//			ListTrashFunc: func(c echo.Context) error {
+//				panic("mock out the ListTrash method")
+//			},
+//			RerollImageFunc: func(c echo.Context) error {
+//				panic("mock out the RerollImage method")
+//			},
+//			RestageImageFunc: func(c echo.Context) error {
+//				panic("mock out the RestageImage method")
+//			},
+//			RestoreImageFunc: func(c echo.Context) error {
+//				panic("mock out the RestoreImage method")
+//			},
+//			RestyleProjectFunc: func(c echo.Context) error {
+//				panic("mock out the RestyleProject method")
+//			},
+//			SearchImagesFunc: func(c echo.Context) error {
+//				panic("mock out the SearchImages method")
+//			},
+//			SetImageApprovalFunc: func(c echo.Context) error {
+//				panic("mock out the SetImageApproval method")
+//			},
 //		}
 //
 //		// use mockedHandler in code that requires Handler
@@ -43,12 +70,18 @@ var _ Handler = &HandlerMock{}
 //
 //	}
 type HandlerMock struct {
+	// CancelJobFunc mocks the CancelJob method.
+	CancelJobFunc func(c echo.Context) error
+
 	// CreateImageFunc mocks the CreateImage method.
 	CreateImageFunc func(c echo.Context) error
 
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(c echo.Context) error
 
+	// DuplicateProjectFunc mocks the DuplicateProject method.
+	DuplicateProjectFunc func(c echo.Context) error
+
 	// GetGroupedProjectImagesFunc mocks the GetGroupedProjectImages method.
 	GetGroupedProjectImagesFunc func(c echo.Context) error
 
@@ -61,8 +94,34 @@ type HandlerMock struct {
 	// GetProjectImagesFunc mocks the GetProjectImages method.
 	GetProjectImagesFunc func(c echo.Context) error
 
+	// ListTrashFunc mocks the ListTrash method.
+	ListTrashFunc func(c echo.Context) error
+
+	// RerollImageFunc mocks the RerollImage method.
+	RerollImageFunc func(c echo.Context) error
+
+	// RestageImageFunc mocks the RestageImage method.
+	RestageImageFunc func(c echo.Context) error
+
+	// RestoreImageFunc mocks the RestoreImage method.
+	RestoreImageFunc func(c echo.Context) error
+
+	// RestyleProjectFunc mocks the RestyleProject method.
+	RestyleProjectFunc func(c echo.Context) error
+
+	// SearchImagesFunc mocks the SearchImages method.
+	SearchImagesFunc func(c echo.Context) error
+
+	// SetImageApprovalFunc mocks the SetImageApproval method.
+	SetImageApprovalFunc func(c echo.Context) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CancelJob holds details about calls to the CancelJob method.
+		CancelJob []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// CreateImage holds details about calls to the CreateImage method.
 		CreateImage []struct {
 			// C is the c argument value.
@@ -73,6 +132,11 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// DuplicateProject holds details about calls to the DuplicateProject method.
+		DuplicateProject []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// GetGroupedProjectImages holds details about calls to the GetGroupedProjectImages method.
 		GetGroupedProjectImages []struct {
 			// C is the c argument value.
@@ -93,13 +157,89 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// ListTrash holds details about calls to the ListTrash method.
+		ListTrash []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// RerollImage holds details about calls to the RerollImage method.
+		RerollImage []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// RestageImage holds details about calls to the RestageImage method.
+		RestageImage []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// RestoreImage holds details about calls to the RestoreImage method.
+		RestoreImage []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// RestyleProject holds details about calls to the RestyleProject method.
+		RestyleProject []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// SearchImages holds details about calls to the SearchImages method.
+		SearchImages []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// SetImageApproval holds details about calls to the SetImageApproval method.
+		SetImageApproval []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 	}
+	lockCancelJob               sync.RWMutex
 	lockCreateImage             sync.RWMutex
 	lockDeleteImage             sync.RWMutex
+	lockDuplicateProject        sync.RWMutex
 	lockGetGroupedProjectImages sync.RWMutex
 	lockGetImage                sync.RWMutex
 	lockGetProjectCost          sync.RWMutex
 	lockGetProjectImages        sync.RWMutex
+	lockListTrash               sync.RWMutex
+	lockRerollImage             sync.RWMutex
+	lockRestageImage            sync.RWMutex
+	lockRestoreImage            sync.RWMutex
+	lockRestyleProject          sync.RWMutex
+	lockSearchImages            sync.RWMutex
+	lockSetImageApproval        sync.RWMutex
+}
+
+// CancelJob calls CancelJobFunc.
+func (mock *HandlerMock) CancelJob(c echo.Context) error {
+	if mock.CancelJobFunc == nil {
+		panic("HandlerMock.CancelJobFunc: method is nil but Handler.CancelJob was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockCancelJob.Lock()
+	mock.calls.CancelJob = append(mock.calls.CancelJob, callInfo)
+	mock.lockCancelJob.Unlock()
+	return mock.CancelJobFunc(c)
+}
+
+// CancelJobCalls gets all the calls that were made to CancelJob.
+// Check the length with:
+//
+//	len(mockedHandler.CancelJobCalls())
+func (mock *HandlerMock) CancelJobCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockCancelJob.RLock()
+	calls = mock.calls.CancelJob
+	mock.lockCancelJob.RUnlock()
+	return calls
 }
 
 // CreateImage calls CreateImageFunc.
@@ -166,6 +306,38 @@ func (mock *HandlerMock) DeleteImageCalls() []struct {
 	return calls
 }
 
+// DuplicateProject calls DuplicateProjectFunc.
+func (mock *HandlerMock) DuplicateProject(c echo.Context) error {
+	if mock.DuplicateProjectFunc == nil {
+		panic("HandlerMock.DuplicateProjectFunc: method is nil but Handler.DuplicateProject was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockDuplicateProject.Lock()
+	mock.calls.DuplicateProject = append(mock.calls.DuplicateProject, callInfo)
+	mock.lockDuplicateProject.Unlock()
+	return mock.DuplicateProjectFunc(c)
+}
+
+// DuplicateProjectCalls gets all the calls that were made to DuplicateProject.
+// Check the length with:
+//
+//	len(mockedHandler.DuplicateProjectCalls())
+func (mock *HandlerMock) DuplicateProjectCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockDuplicateProject.RLock()
+	calls = mock.calls.DuplicateProject
+	mock.lockDuplicateProject.RUnlock()
+	return calls
+}
+
 // GetGroupedProjectImages calls GetGroupedProjectImagesFunc.
 func (mock *HandlerMock) GetGroupedProjectImages(c echo.Context) error {
 	if mock.GetGroupedProjectImagesFunc == nil {
@@ -293,3 +465,227 @@ func (mock *HandlerMock) GetProjectImagesCalls() []struct {
 	mock.lockGetProjectImages.RUnlock()
 	return calls
 }
+
+// ListTrash calls ListTrashFunc.
+func (mock *HandlerMock) ListTrash(c echo.Context) error {
+	if mock.ListTrashFunc == nil {
+		panic("HandlerMock.ListTrashFunc: method is nil but Handler.ListTrash was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockListTrash.Lock()
+	mock.calls.ListTrash = append(mock.calls.ListTrash, callInfo)
+	mock.lockListTrash.Unlock()
+	return mock.ListTrashFunc(c)
+}
+
+// ListTrashCalls gets all the calls that were made to ListTrash.
+// Check the length with:
+//
+//	len(mockedHandler.ListTrashCalls())
+func (mock *HandlerMock) ListTrashCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockListTrash.RLock()
+	calls = mock.calls.ListTrash
+	mock.lockListTrash.RUnlock()
+	return calls
+}
+
+// RerollImage calls RerollImageFunc.
+func (mock *HandlerMock) RerollImage(c echo.Context) error {
+	if mock.RerollImageFunc == nil {
+		panic("HandlerMock.RerollImageFunc: method is nil but Handler.RerollImage was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockRerollImage.Lock()
+	mock.calls.RerollImage = append(mock.calls.RerollImage, callInfo)
+	mock.lockRerollImage.Unlock()
+	return mock.RerollImageFunc(c)
+}
+
+// RerollImageCalls gets all the calls that were made to RerollImage.
+// Check the length with:
+//
+//	len(mockedHandler.RerollImageCalls())
+func (mock *HandlerMock) RerollImageCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockRerollImage.RLock()
+	calls = mock.calls.RerollImage
+	mock.lockRerollImage.RUnlock()
+	return calls
+}
+
+// RestageImage calls RestageImageFunc.
+func (mock *HandlerMock) RestageImage(c echo.Context) error {
+	if mock.RestageImageFunc == nil {
+		panic("HandlerMock.RestageImageFunc: method is nil but Handler.RestageImage was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockRestageImage.Lock()
+	mock.calls.RestageImage = append(mock.calls.RestageImage, callInfo)
+	mock.lockRestageImage.Unlock()
+	return mock.RestageImageFunc(c)
+}
+
+// RestageImageCalls gets all the calls that were made to RestageImage.
+// Check the length with:
+//
+//	len(mockedHandler.RestageImageCalls())
+func (mock *HandlerMock) RestageImageCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockRestageImage.RLock()
+	calls = mock.calls.RestageImage
+	mock.lockRestageImage.RUnlock()
+	return calls
+}
+
+// RestoreImage calls RestoreImageFunc.
+func (mock *HandlerMock) RestoreImage(c echo.Context) error {
+	if mock.RestoreImageFunc == nil {
+		panic("HandlerMock.RestoreImageFunc: method is nil but Handler.RestoreImage was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockRestoreImage.Lock()
+	mock.calls.RestoreImage = append(mock.calls.RestoreImage, callInfo)
+	mock.lockRestoreImage.Unlock()
+	return mock.RestoreImageFunc(c)
+}
+
+// RestoreImageCalls gets all the calls that were made to RestoreImage.
+// Check the length with:
+//
+//	len(mockedHandler.RestoreImageCalls())
+func (mock *HandlerMock) RestoreImageCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockRestoreImage.RLock()
+	calls = mock.calls.RestoreImage
+	mock.lockRestoreImage.RUnlock()
+	return calls
+}
+
+// RestyleProject calls RestyleProjectFunc.
+func (mock *HandlerMock) RestyleProject(c echo.Context) error {
+	if mock.RestyleProjectFunc == nil {
+		panic("HandlerMock.RestyleProjectFunc: method is nil but Handler.RestyleProject was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockRestyleProject.Lock()
+	mock.calls.RestyleProject = append(mock.calls.RestyleProject, callInfo)
+	mock.lockRestyleProject.Unlock()
+	return mock.RestyleProjectFunc(c)
+}
+
+// RestyleProjectCalls gets all the calls that were made to RestyleProject.
+// Check the length with:
+//
+//	len(mockedHandler.RestyleProjectCalls())
+func (mock *HandlerMock) RestyleProjectCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockRestyleProject.RLock()
+	calls = mock.calls.RestyleProject
+	mock.lockRestyleProject.RUnlock()
+	return calls
+}
+
+// SearchImages calls SearchImagesFunc.
+func (mock *HandlerMock) SearchImages(c echo.Context) error {
+	if mock.SearchImagesFunc == nil {
+		panic("HandlerMock.SearchImagesFunc: method is nil but Handler.SearchImages was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockSearchImages.Lock()
+	mock.calls.SearchImages = append(mock.calls.SearchImages, callInfo)
+	mock.lockSearchImages.Unlock()
+	return mock.SearchImagesFunc(c)
+}
+
+// SearchImagesCalls gets all the calls that were made to SearchImages.
+// Check the length with:
+//
+//	len(mockedHandler.SearchImagesCalls())
+func (mock *HandlerMock) SearchImagesCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockSearchImages.RLock()
+	calls = mock.calls.SearchImages
+	mock.lockSearchImages.RUnlock()
+	return calls
+}
+
+// SetImageApproval calls SetImageApprovalFunc.
+func (mock *HandlerMock) SetImageApproval(c echo.Context) error {
+	if mock.SetImageApprovalFunc == nil {
+		panic("HandlerMock.SetImageApprovalFunc: method is nil but Handler.SetImageApproval was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockSetImageApproval.Lock()
+	mock.calls.SetImageApproval = append(mock.calls.SetImageApproval, callInfo)
+	mock.lockSetImageApproval.Unlock()
+	return mock.SetImageApprovalFunc(c)
+}
+
+// SetImageApprovalCalls gets all the calls that were made to SetImageApproval.
+// Check the length with:
+//
+//	len(mockedHandler.SetImageApprovalCalls())
+func (mock *HandlerMock) SetImageApprovalCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockSetImageApproval.RLock()
+	calls = mock.calls.SetImageApproval
+	mock.lockSetImageApproval.RUnlock()
+	return calls
+}
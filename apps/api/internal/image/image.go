@@ -5,6 +5,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/api/internal/batch"
+	"github.com/real-staging-ai/api/internal/brownout"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/queue"
 )
 
 // Status represents the processing status of an image.
@@ -19,6 +24,12 @@ const (
 	StatusReady Status = "ready"
 	// StatusError indicates an error occurred during processing.
 	StatusError Status = "error"
+	// StatusRejected indicates the original was quarantined by the malware
+	// scanner. Unlike StatusError it is final: the image can't be restaged.
+	StatusRejected Status = "rejected"
+	// StatusCanceled indicates the owner canceled the image's staging job
+	// before it finished.
+	StatusCanceled Status = "canceled"
 )
 
 // String returns the string representation of the status.
@@ -26,36 +37,177 @@ func (s Status) String() string {
 	return string(s)
 }
 
+// Mode says what a staging job does with the furniture already in the photo.
+type Mode string
+
+const (
+	// ModeStage adds staged furniture to the photo as it is.
+	ModeStage Mode = "stage"
+	// ModeDeclutter removes the furniture and personal items in the photo and
+	// leaves the room empty.
+	ModeDeclutter Mode = "declutter"
+	// ModeBoth removes the furniture and personal items in the photo and
+	// stages the emptied room.
+	ModeBoth Mode = "both"
+)
+
+// Declutters reports whether jobs in mode m remove what is in the photo.
+func (m Mode) Declutters() bool {
+	return m == ModeDeclutter || m == ModeBoth
+}
+
 // Image represents a staging image in the system.
 type Image struct {
-	CostUSD               *float64  `json:"cost_usd,omitempty"`
-	CreatedAt             time.Time `json:"created_at"`
-	Error                 *string   `json:"error,omitempty"`
-	ID                    uuid.UUID `json:"id"`
-	ModelUsed             *string   `json:"model_used,omitempty"`
-	OriginalURL           string    `json:"original_url"`
-	ProcessingTimeMs      *int      `json:"processing_time_ms,omitempty"`
-	ProjectID             uuid.UUID `json:"project_id"`
-	Prompt                *string   `json:"prompt,omitempty"`
-	ReplicatePredictionID *string   `json:"replicate_prediction_id,omitempty"`
-	RoomType              *string   `json:"room_type,omitempty"`
-	Seed                  *int64    `json:"seed,omitempty"`
-	StagedURL             *string   `json:"staged_url,omitempty"`
-	Status                Status    `json:"status"`
-	Style                 *string   `json:"style,omitempty"`
-	UpdatedAt             time.Time `json:"updated_at"`
+	Blurhash              *string         `json:"blurhash,omitempty"`
+	CostUSD               *float64        `json:"cost_usd,omitempty"`
+	CreatedAt             time.Time       `json:"created_at"`
+	Delay                 *brownout.Delay `json:"delay,omitempty"`
+	DeletedAt             *time.Time      `json:"deleted_at,omitempty"`
+	DetectedRoomType      *string         `json:"detected_room_type,omitempty"`
+	Error                 *string         `json:"error,omitempty"`
+	ErrorCode             *string         `json:"error_code,omitempty"`
+	ID                    uuid.UUID       `json:"id"`
+	MaskURL               *string         `json:"mask_url,omitempty"`
+	Mode                  Mode            `json:"mode,omitempty"`
+	ModelUsed             *string         `json:"model_used,omitempty"`
+	OriginalURL           string          `json:"original_url"`
+	PrimaryImageID        *uuid.UUID      `json:"primary_image_id,omitempty"`
+	ProcessingTimeMs      *int            `json:"processing_time_ms,omitempty"`
+	ProjectID             uuid.UUID       `json:"project_id"`
+	Prompt                *string         `json:"prompt,omitempty"`
+	ReplicatePredictionID *string         `json:"replicate_prediction_id,omitempty"`
+	RoomType              *string         `json:"room_type,omitempty"`
+	RoomTypeConfidence    *float64        `json:"room_type_confidence,omitempty"`
+	Sandbox               bool            `json:"sandbox,omitempty"`
+	Seed                  *int64          `json:"seed,omitempty"`
+	StagedContentType     *string         `json:"staged_content_type,omitempty"`
+	StagedURL             *string         `json:"staged_url,omitempty"`
+	Status                Status          `json:"status"`
+	Style                 *string         `json:"style,omitempty"`
+	Thumbnails            *Thumbnails     `json:"thumbnails,omitempty"`
+	UpdatedAt             time.Time       `json:"updated_at"`
+}
+
+// Thumbnail sizes, smallest first. The worker scales each so its longest
+// side is at most 256, 640 and 1280 pixels respectively.
+const (
+	ThumbnailSmall  = "small"
+	ThumbnailMedium = "medium"
+	ThumbnailLarge  = "large"
+)
+
+// Thumbnail sources: which of the image's files a thumbnail was made from.
+const (
+	ThumbnailSourceOriginal = "original"
+	ThumbnailSourceStaged   = "staged"
+)
+
+// ThumbnailSet holds the URLs of one file's thumbnails by size.
+type ThumbnailSet struct {
+	Small  string `json:"small,omitempty"`
+	Medium string `json:"medium,omitempty"`
+	Large  string `json:"large,omitempty"`
+}
+
+// Thumbnails are the downscaled JPEG copies the worker makes of an image's
+// original and of its staged result. A source is left out until its
+// thumbnails exist, so clients fall back to the full-size file.
+type Thumbnails struct {
+	Original *ThumbnailSet `json:"original,omitempty"`
+	Staged   *ThumbnailSet `json:"staged,omitempty"`
+}
+
+// URL returns the stored URL of the thumbnail of source in size, or "" when
+// there is none.
+func (t *Thumbnails) URL(source, size string) string {
+	if t == nil {
+		return ""
+	}
+	set := t.Original
+	if source == ThumbnailSourceStaged {
+		set = t.Staged
+	}
+	if set == nil {
+		return ""
+	}
+	switch size {
+	case ThumbnailSmall:
+		return set.Small
+	case ThumbnailMedium:
+		return set.Medium
+	case ThumbnailLarge:
+		return set.Large
+	default:
+		return ""
+	}
+}
+
+// Thumbnail is a stored thumbnail of one of an image's files.
+type Thumbnail struct {
+	ImageID string
+	Source  string
+	Size    string
+	URL     string
+}
+
+// groupThumbnails collects thumbnails by image ID.
+func groupThumbnails(thumbs []Thumbnail) map[string]*Thumbnails {
+	byImage := make(map[string]*Thumbnails)
+	for _, th := range thumbs {
+		t := byImage[th.ImageID]
+		if t == nil {
+			t = &Thumbnails{}
+			byImage[th.ImageID] = t
+		}
+		set := &t.Original
+		if th.Source == ThumbnailSourceStaged {
+			set = &t.Staged
+		}
+		if *set == nil {
+			*set = &ThumbnailSet{}
+		}
+		switch th.Size {
+		case ThumbnailSmall:
+			(*set).Small = th.URL
+		case ThumbnailMedium:
+			(*set).Medium = th.URL
+		case ThumbnailLarge:
+			(*set).Large = th.URL
+		}
+	}
+	return byImage
 }
 
 // CreateImageRequest represents the request to create a new staging image.
 type CreateImageRequest struct {
 	ProjectID   uuid.UUID `json:"project_id" validate:"required"`
 	OriginalURL string    `json:"original_url" validate:"required,url"`
-	//nolint:lll // struct tags are long
-	RoomType *string `json:"room_type,omitempty" validate:"omitempty,oneof=living_room bedroom kitchen bathroom dining_room office"`
-	//nolint:lll // struct tags are long
-	Style  *string `json:"style,omitempty" validate:"omitempty,oneof=modern contemporary traditional industrial scandinavian"`
-	Seed   *int64  `json:"seed,omitempty" validate:"omitempty,min=1,max=4294967295"`
-	Prompt *string `json:"prompt,omitempty" validate:"omitempty,min=10,max=2000"`
+	RoomType    *string   `json:"room_type,omitempty" validate:"omitempty"`
+	Style       *string   `json:"style,omitempty" validate:"omitempty"`
+	Seed        *int64    `json:"seed,omitempty" validate:"omitempty,min=1,max=4294967295"`
+	Prompt      *string   `json:"prompt,omitempty" validate:"omitempty,min=10,max=2000"`
+	// TemplateID stages with one of the caller's prompt templates instead of
+	// Prompt; its placeholders are filled from RoomType and Style.
+	TemplateID *string `json:"template_id,omitempty"`
+	// ModelID picks the staging model for this job. Without it the project's
+	// model is used, then the user's preferred model, then the global one.
+	ModelID *string `json:"model_id,omitempty"`
+	// MaskURL is a PNG, uploaded with the mask presign purpose, that limits
+	// staging to its white areas. Only inpainting models accept one.
+	MaskURL *string `json:"mask_url,omitempty" validate:"omitempty,url"`
+	// Mode is stage, declutter or both; empty stages. Declutter jobs take no
+	// prompt or template.
+	Mode Mode `json:"mode,omitempty"`
+	// PresetID stages with an admin's style preset, which sets the model, the
+	// prompt when it has a template, and overrides of the model's config. It
+	// can't be combined with ModelID, Prompt or TemplateID.
+	PresetID *string `json:"preset_id,omitempty"`
+	// ModelConfig holds the overrides of the preset named by PresetID once
+	// the handler has resolved it.
+	ModelConfig map[string]interface{} `json:"-"`
+	// Priority queues the image's job ahead of or behind others; the handler
+	// sets it from the plan of whoever pays for the project.
+	Priority queue.Priority `json:"-"`
 }
 
 // JobPayload represents the payload for image processing jobs.
@@ -66,6 +218,29 @@ type JobPayload struct {
 	Style       *string   `json:"style,omitempty"`
 	Seed        *int64    `json:"seed,omitempty"`
 	Prompt      *string   `json:"prompt,omitempty"`
+	ModelID     *string   `json:"model_id,omitempty"`
+	Sandbox     bool      `json:"sandbox,omitempty"`
+	MaskURL     *string   `json:"mask_url,omitempty"`
+	Mode        Mode      `json:"mode,omitempty"`
+	// ModelConfig overrides fields of the admin's config for the model.
+	ModelConfig map[string]interface{} `json:"model_config,omitempty"`
+	// Language is the request's language when it isn't English.
+	Language string `json:"language,omitempty"`
+	// Priority is the queue priority the job was enqueued at; empty is the
+	// default priority.
+	Priority queue.Priority `json:"priority,omitempty"`
+}
+
+// PurgedImage is an image permanently removed from the trash, with what it
+// leaves behind in storage.
+type PurgedImage struct {
+	ID uuid.UUID
+	// OriginalImageID is the shared original the image held a reference on;
+	// empty for images uploaded before originals were deduplicated.
+	OriginalImageID string
+	// Files are the S3 objects only this image used: its staged result, its
+	// cut-outs and, for older images, an original no other image shares.
+	Files []string
 }
 
 // ProjectCostSummary represents cost aggregation for a project.
@@ -85,8 +260,18 @@ type UpdateCostRequest struct {
 }
 
 // BatchCreateImagesRequest represents a batch request to create multiple images.
+// With Async set, the images are created in the background and the response is
+// a batch to poll instead of the created images.
 type BatchCreateImagesRequest struct {
 	Images []CreateImageRequest `json:"images" validate:"required,min=1,max=50,dive"`
+	Async  bool                 `json:"async,omitempty"`
+}
+
+// AsyncBatchResponse is returned when an async batch is accepted.
+// StatusURL is where to poll the batch's progress.
+type AsyncBatchResponse struct {
+	*batch.Batch
+	StatusURL string `json:"status_url"`
 }
 
 // BatchCreateImagesResponse represents the response for batch image creation.
@@ -105,17 +290,21 @@ type BatchImageError struct {
 
 // ImageVariant represents a single style variant of an original image.
 type ImageVariant struct {
-	ID                    uuid.UUID `json:"id"`
-	Style                 *string   `json:"style,omitempty"`
-	Status                Status    `json:"status"`
-	StagedURL             *string   `json:"staged_url,omitempty"`
-	Error                 *string   `json:"error,omitempty"`
-	CostUSD               *float64  `json:"cost_usd,omitempty"`
-	ProcessingTimeMs      *int      `json:"processing_time_ms,omitempty"`
-	ModelUsed             *string   `json:"model_used,omitempty"`
-	ReplicatePredictionID *string   `json:"replicate_prediction_id,omitempty"`
-	CreatedAt             time.Time `json:"created_at"`
-	UpdatedAt             time.Time `json:"updated_at"`
+	ID                    uuid.UUID   `json:"id"`
+	Style                 *string     `json:"style,omitempty"`
+	Seed                  *int64      `json:"seed,omitempty"`
+	Status                Status      `json:"status"`
+	StagedURL             *string     `json:"staged_url,omitempty"`
+	Error                 *string     `json:"error,omitempty"`
+	ErrorCode             *string     `json:"error_code,omitempty"`
+	CostUSD               *float64    `json:"cost_usd,omitempty"`
+	ProcessingTimeMs      *int        `json:"processing_time_ms,omitempty"`
+	ModelUsed             *string     `json:"model_used,omitempty"`
+	ReplicatePredictionID *string     `json:"replicate_prediction_id,omitempty"`
+	PrimaryImageID        *uuid.UUID  `json:"primary_image_id,omitempty"`
+	Thumbnails            *Thumbnails `json:"thumbnails,omitempty"`
+	CreatedAt             time.Time   `json:"created_at"`
+	UpdatedAt             time.Time   `json:"updated_at"`
 }
 
 // GroupedImage represents an original image with all its style variants.
@@ -128,7 +317,98 @@ type GroupedImage struct {
 	Variants        []*ImageVariant `json:"variants"`
 }
 
-// GroupedProjectImagesResponse represents the grouped images response.
+// ProjectImagesResponse is a page of a project's images, newest first.
+type ProjectImagesResponse struct {
+	Images []*Image `json:"images"`
+	// NextCursor fetches the next page; nil on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
+
+// ImageSearchResponse is a page of search results, newest first.
+type ImageSearchResponse struct {
+	Images []*Image `json:"images"`
+	// NextCursor fetches the next page; nil on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
+
+// GroupedProjectImagesResponse represents the grouped images response. Groups
+// are built from one page of images, newest first, so a group whose variants
+// span pages appears on each of them; clients merge groups by
+// original_image_id, or original_url when it is unset.
 type GroupedProjectImagesResponse struct {
 	Images []*GroupedImage `json:"images"`
+	// NextCursor fetches the next page; nil on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
+
+// ImageApproval is the owner's verdict on an image's staged result: true
+// approved, false rejected, null not given. Prompt experiments compare
+// approval rates across their variants.
+type ImageApproval struct {
+	ImageID  uuid.UUID `json:"image_id,omitempty"`
+	Approved *bool     `json:"approved"`
+}
+
+// RestageImageRequest represents a request to re-stage an existing image as a new
+// variant of the same original. Room type, style and prompt default to the source
+// image's; a seed is only reused when given.
+type RestageImageRequest struct {
+	RoomType *string `json:"room_type,omitempty" validate:"omitempty"`
+	Style    *string `json:"style,omitempty" validate:"omitempty"`
+	Seed     *int64  `json:"seed,omitempty" validate:"omitempty,min=1,max=4294967295"`
+	Prompt   *string `json:"prompt,omitempty" validate:"omitempty,min=10,max=2000"`
+	// ModelID picks the staging model; it is not inherited from the source
+	// image, so the project's or user's model applies when omitted.
+	ModelID *string `json:"model_id,omitempty"`
+	// Priority is the queue priority of the new job; the handler sets it.
+	Priority queue.Priority `json:"-"`
+}
+
+// MaxSeed is the largest seed a request may set.
+const MaxSeed = 4294967295
+
+// RerollImageRequest represents a request to stage an image again with a new
+// seed, keeping its room type, style, prompt and model. Without a seed a
+// random one is picked; the new variant reports the seed either way.
+type RerollImageRequest struct {
+	Seed *int64 `json:"seed,omitempty" validate:"omitempty,min=1,max=4294967295"`
+	// ModelID is the model that staged the source image; the handler sets it.
+	ModelID *string `json:"-"`
+	// Priority is the queue priority of the new job; the handler sets it.
+	Priority queue.Priority `json:"-"`
+}
+
+// RestyleProjectRequest represents a request to re-stage every ready image in a project
+// with a different style.
+type RestyleProjectRequest struct {
+	Style string `json:"style" validate:"required"`
+}
+
+// RestyleProjectResponse represents the result of a project restyle.
+// EventsURL streams progress for all created variants over a single SSE connection.
+type RestyleProjectResponse struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	Style     string    `json:"style"`
+	Images    []*Image  `json:"images"`
+	Skipped   int       `json:"skipped"`
+	EventsURL string    `json:"events_url,omitempty"`
+}
+
+// DuplicateProjectRequest represents a request to copy a project. The copy keeps the
+// project's watermark, model and locale settings. Name defaults to the source name with
+// " (copy)" appended. Unless IncludeImages is false, the ready images are re-staged into
+// the copy, in Style when it is set.
+type DuplicateProjectRequest struct {
+	Name          *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Style         *string `json:"style,omitempty" validate:"omitempty"`
+	IncludeImages *bool   `json:"include_images,omitempty"`
+}
+
+// DuplicateProjectResponse represents the result of a project duplication.
+// EventsURL streams progress for all re-staged images over a single SSE connection.
+type DuplicateProjectResponse struct {
+	Project   *project.Project `json:"project"`
+	Images    []*Image         `json:"images"`
+	Skipped   int              `json:"skipped"`
+	EventsURL string           `json:"events_url,omitempty"`
 }
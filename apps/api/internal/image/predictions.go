@@ -0,0 +1,68 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out predictions_mock.go . PredictionCanceler
+
+// ErrPredictionsNotConfigured is returned when no Replicate API token is configured.
+var ErrPredictionsNotConfigured = errors.New("replicate api token not configured")
+
+// PredictionCanceler stops model predictions that are still running.
+type PredictionCanceler interface {
+	// Cancel stops the prediction. Canceling a finished prediction does nothing.
+	Cancel(ctx context.Context, predictionID string) error
+}
+
+// ReplicatePredictions cancels predictions through the Replicate HTTP API.
+type ReplicatePredictions struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// Ensure ReplicatePredictions implements PredictionCanceler.
+var _ PredictionCanceler = (*ReplicatePredictions)(nil)
+
+// NewReplicatePredictions creates a ReplicatePredictions from the Replicate config.
+func NewReplicatePredictions(cfg config.Replicate) *ReplicatePredictions {
+	return &ReplicatePredictions{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		token:   cfg.APIToken,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Cancel asks Replicate to stop the prediction.
+func (p *ReplicatePredictions) Cancel(ctx context.Context, predictionID string) error {
+	if p.token == "" {
+		return ErrPredictionsNotConfigured
+	}
+
+	endpoint := fmt.Sprintf("%s/predictions/%s/cancel", p.baseURL, url.PathEscape(predictionID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build cancel request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to cancel prediction: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to cancel prediction: replicate returned %d", resp.StatusCode)
+	}
+	return nil
+}
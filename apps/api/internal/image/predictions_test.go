@@ -0,0 +1,42 @@
+package image
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestReplicatePredictions_Cancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer r8_test", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/predictions/pred-1/cancel":
+			_, _ = w.Write([]byte(`{"id":"pred-1","status":"canceled"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	p := NewReplicatePredictions(config.Replicate{APIToken: "r8_test", BaseURL: srv.URL + "/"})
+
+	t.Run("success: cancels the prediction", func(t *testing.T) {
+		require.NoError(t, p.Cancel(context.Background(), "pred-1"))
+	})
+
+	t.Run("fail: unknown prediction", func(t *testing.T) {
+		err := p.Cancel(context.Background(), "pred-missing")
+		assert.ErrorContains(t, err, "replicate returned 404")
+	})
+
+	t.Run("fail: no token", func(t *testing.T) {
+		err := NewReplicatePredictions(config.Replicate{BaseURL: srv.URL}).Cancel(context.Background(), "pred-1")
+		assert.ErrorIs(t, err, ErrPredictionsNotConfigured)
+	})
+}
@@ -2,7 +2,9 @@ package image
 
 import (
 	"context"
+	"time"
 
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
@@ -10,7 +12,7 @@ import (
 
 // Repository defines the interface for image data access operations.
 type Repository interface {
-	// CreateImage creates a new image in the database.
+	// CreateImage creates a new image in the database, staged in the given mode.
 	CreateImage(
 		ctx context.Context,
 		projectID string,
@@ -18,6 +20,26 @@ type Repository interface {
 		roomType, style *string,
 		seed *int64,
 		prompt *string,
+		mode string,
+	) (*queries.Image, error)
+
+	// CreateImageVariant creates a new image from a live source image with the
+	// given staging options. The variant shares the source's original and takes a
+	// reference on it. Returns pgx.ErrNoRows if the source doesn't exist or was deleted.
+	CreateImageVariant(
+		ctx context.Context,
+		sourceImageID string,
+		roomType, style *string,
+		seed *int64,
+		prompt *string,
+	) (*queries.Image, error)
+
+	// WithCredit runs create in a transaction and spends one of the user's
+	// credits on the image it returns, so the image and the debit commit
+	// together. Returns ErrNoCredits, creating nothing, when the user has no
+	// credits left.
+	WithCredit(
+		ctx context.Context, userID string, create func(repo Repository) (*queries.Image, error),
 	) (*queries.Image, error)
 
 	// GetImageByID retrieves a specific image by its ID.
@@ -26,6 +48,17 @@ type Repository interface {
 	// GetImagesByProjectID retrieves all images for a specific project.
 	GetImagesByProjectID(ctx context.Context, projectID string) ([]*queries.Image, error)
 
+	// ListImagesByProjectID lists a page of a project's images, newest first,
+	// and the cursor of the next page, nil on the last one.
+	ListImagesByProjectID(ctx context.Context, projectID string, page pagination.Page) ([]*queries.Image, *string, error)
+
+	// SearchImages lists a page of the live images, newest first, in projects
+	// the user created or shares through an organization, that match the query,
+	// and the cursor of the next page, nil on the last one.
+	SearchImages(
+		ctx context.Context, userID string, query SearchQuery, page pagination.Page,
+	) ([]*queries.Image, *string, error)
+
 	// UpdateImageStatus updates an image's processing status.
 	UpdateImageStatus(ctx context.Context, imageID string, status string) (*queries.Image, error)
 
@@ -41,7 +74,40 @@ type Repository interface {
 	// The image is marked as deleted but kept for usage tracking.
 	DeleteImage(ctx context.Context, imageID string) error
 
-	// DeleteImagesByProjectID deletes all images for a specific project.
+	// ListDeletedImagesByProjectID lists a project's soft-deleted images, most
+	// recently deleted first.
+	ListDeletedImagesByProjectID(ctx context.Context, projectID string) ([]*queries.Image, error)
+
+	// GetDeletedImageByID retrieves a soft-deleted image. Returns pgx.ErrNoRows
+	// if the image doesn't exist or isn't deleted.
+	GetDeletedImageByID(ctx context.Context, imageID string) (*queries.Image, error)
+
+	// RestoreImage undoes a soft delete. Returns pgx.ErrNoRows if the image
+	// isn't deleted (or has already been purged).
+	RestoreImage(ctx context.Context, imageID string) (*queries.Image, error)
+
+	// SetImageApproval records the owner's verdict on the staged result; nil
+	// clears it. Returns pgx.ErrNoRows if the image doesn't exist or is deleted.
+	SetImageApproval(ctx context.Context, imageID string, approved *bool) error
+
+	// SetImageMask limits the image's staging to the region maskURL marks.
+	// Returns pgx.ErrNoRows if the image doesn't exist or is deleted.
+	SetImageMask(ctx context.Context, imageID, maskURL string) error
+
+	// CancelImage sets a queued or processing image to canceled and records
+	// the change in its history. Returns pgx.ErrNoRows if the image doesn't
+	// exist, is deleted or is in any other status.
+	CancelImage(ctx context.Context, imageID string) (*queries.Image, error)
+
+	// PurgeDeletedImages permanently deletes up to limit images soft-deleted
+	// before deletedBefore and created before createdBefore, skipping locked
+	// projects, and reports what each left in storage.
+	PurgeDeletedImages(
+		ctx context.Context, deletedBefore, createdBefore time.Time, limit int,
+	) ([]PurgedImage, error)
+
+	// DeleteImagesByProjectID deletes all images for a specific project. It
+	// returns project.ErrProjectLocked when the project is locked.
 	DeleteImagesByProjectID(ctx context.Context, projectID string) error
 
 	// GetOriginalImageID retrieves the original_image_id for an image.
@@ -60,4 +126,7 @@ type Repository interface {
 
 	// GetProjectCostSummary retrieves cost summary for a project.
 	GetProjectCostSummary(ctx context.Context, projectID string) (*ProjectCostSummary, error)
+
+	// ListThumbnails returns the thumbnails of the images' files.
+	ListThumbnails(ctx context.Context, imageIDs []string) ([]Thumbnail, error)
 }
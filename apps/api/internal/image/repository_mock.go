@@ -5,8 +5,10 @@ package image
 
 import (
 	"context"
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 	"sync"
+	"time"
 )
 
 // Ensure, that RepositoryMock does implement Repository.
@@ -19,15 +21,24 @@ var _ Repository = &RepositoryMock{}
 //
 //		// make and configure a mocked Repository
 //		mockedRepository := &RepositoryMock{
-//			CreateImageFunc: func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, prompt *string) (*queries.Image, error) {
+//			CancelImageFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
+//				panic("mock out the CancelImage method")
+//			},
+//			CreateImageFunc: func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, prompt *string, mode string) (*queries.Image, error) {
 //				panic("mock out the CreateImage method")
 //			},
+//			CreateImageVariantFunc: func(ctx context.Context, sourceImageID string, roomType *string, style *string, seed *int64, prompt *string) (*queries.Image, error) {
+//				panic("mock out the CreateImageVariant method")
+//			},
 //			DeleteImageFunc: func(ctx context.Context, imageID string) error {
 //				panic("mock out the DeleteImage method")
 //			},
 //			DeleteImagesByProjectIDFunc: func(ctx context.Context, projectID string) error {
 //				panic("mock out the DeleteImagesByProjectID method")
 //			},
+//			GetDeletedImageByIDFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
+//				panic("mock out the GetDeletedImageByID method")
+//			},
 //			GetImageByIDFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
 //				panic("mock out the GetImageByID method")
 //			},
@@ -40,6 +51,30 @@ var _ Repository = &RepositoryMock{}
 //			GetProjectCostSummaryFunc: func(ctx context.Context, projectID string) (*ProjectCostSummary, error) {
 //				panic("mock out the GetProjectCostSummary method")
 //			},
+//			ListDeletedImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*queries.Image, error) {
+//				panic("mock out the ListDeletedImagesByProjectID method")
+//			},
+//			ListImagesByProjectIDFunc: func(ctx context.Context, projectID string, page pagination.Page) ([]*queries.Image, *string, error) {
+//				panic("mock out the ListImagesByProjectID method")
+//			},
+//			ListThumbnailsFunc: func(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+//				panic("mock out the ListThumbnails method")
+//			},
+//			PurgeDeletedImagesFunc: func(ctx context.Context, deletedBefore time.Time, createdBefore time.Time, limit int) ([]PurgedImage, error) {
+//				panic("mock out the PurgeDeletedImages method")
+//			},
+//			RestoreImageFunc: func(ctx context.Context, imageID string) (*queries.Image, error) {
+//				panic("mock out the RestoreImage method")
+//			},
+//			SearchImagesFunc: func(ctx context.Context, userID string, query SearchQuery, page pagination.Page) ([]*queries.Image, *string, error) {
+//				panic("mock out the SearchImages method")
+//			},
+//			SetImageApprovalFunc: func(ctx context.Context, imageID string, approved *bool) error {
+//				panic("mock out the SetImageApproval method")
+//			},
+//			SetImageMaskFunc: func(ctx context.Context, imageID string, maskURL string) error {
+//				panic("mock out the SetImageMask method")
+//			},
 //			UpdateImageCostFunc: func(ctx context.Context, imageID string, costUSD float64, modelUsed string, processingTimeMs int, predictionID string) error {
 //				panic("mock out the UpdateImageCost method")
 //			},
@@ -52,6 +87,9 @@ var _ Repository = &RepositoryMock{}
 //			UpdateImageWithStagedURLFunc: func(ctx context.Context, imageID string, stagedURL string, status string) (*queries.Image, error) {
 //				panic("mock out the UpdateImageWithStagedURL method")
 //			},
+//			WithCreditFunc: func(ctx context.Context, userID string, create func(repo Repository) (*queries.Image, error)) (*queries.Image, error) {
+//				panic("mock out the WithCredit method")
+//			},
 //		}
 //
 //		// use mockedRepository in code that requires Repository
@@ -59,8 +97,14 @@ var _ Repository = &RepositoryMock{}
 //
 //	}
 type RepositoryMock struct {
+	// CancelImageFunc mocks the CancelImage method.
+	CancelImageFunc func(ctx context.Context, imageID string) (*queries.Image, error)
+
 	// CreateImageFunc mocks the CreateImage method.
-	CreateImageFunc func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, prompt *string) (*queries.Image, error)
+	CreateImageFunc func(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, prompt *string, mode string) (*queries.Image, error)
+
+	// CreateImageVariantFunc mocks the CreateImageVariant method.
+	CreateImageVariantFunc func(ctx context.Context, sourceImageID string, roomType *string, style *string, seed *int64, prompt *string) (*queries.Image, error)
 
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(ctx context.Context, imageID string) error
@@ -68,6 +112,9 @@ type RepositoryMock struct {
 	// DeleteImagesByProjectIDFunc mocks the DeleteImagesByProjectID method.
 	DeleteImagesByProjectIDFunc func(ctx context.Context, projectID string) error
 
+	// GetDeletedImageByIDFunc mocks the GetDeletedImageByID method.
+	GetDeletedImageByIDFunc func(ctx context.Context, imageID string) (*queries.Image, error)
+
 	// GetImageByIDFunc mocks the GetImageByID method.
 	GetImageByIDFunc func(ctx context.Context, imageID string) (*queries.Image, error)
 
@@ -80,6 +127,30 @@ type RepositoryMock struct {
 	// GetProjectCostSummaryFunc mocks the GetProjectCostSummary method.
 	GetProjectCostSummaryFunc func(ctx context.Context, projectID string) (*ProjectCostSummary, error)
 
+	// ListDeletedImagesByProjectIDFunc mocks the ListDeletedImagesByProjectID method.
+	ListDeletedImagesByProjectIDFunc func(ctx context.Context, projectID string) ([]*queries.Image, error)
+
+	// ListImagesByProjectIDFunc mocks the ListImagesByProjectID method.
+	ListImagesByProjectIDFunc func(ctx context.Context, projectID string, page pagination.Page) ([]*queries.Image, *string, error)
+
+	// ListThumbnailsFunc mocks the ListThumbnails method.
+	ListThumbnailsFunc func(ctx context.Context, imageIDs []string) ([]Thumbnail, error)
+
+	// PurgeDeletedImagesFunc mocks the PurgeDeletedImages method.
+	PurgeDeletedImagesFunc func(ctx context.Context, deletedBefore time.Time, createdBefore time.Time, limit int) ([]PurgedImage, error)
+
+	// RestoreImageFunc mocks the RestoreImage method.
+	RestoreImageFunc func(ctx context.Context, imageID string) (*queries.Image, error)
+
+	// SearchImagesFunc mocks the SearchImages method.
+	SearchImagesFunc func(ctx context.Context, userID string, query SearchQuery, page pagination.Page) ([]*queries.Image, *string, error)
+
+	// SetImageApprovalFunc mocks the SetImageApproval method.
+	SetImageApprovalFunc func(ctx context.Context, imageID string, approved *bool) error
+
+	// SetImageMaskFunc mocks the SetImageMask method.
+	SetImageMaskFunc func(ctx context.Context, imageID string, maskURL string) error
+
 	// UpdateImageCostFunc mocks the UpdateImageCost method.
 	UpdateImageCostFunc func(ctx context.Context, imageID string, costUSD float64, modelUsed string, processingTimeMs int, predictionID string) error
 
@@ -92,8 +163,18 @@ type RepositoryMock struct {
 	// UpdateImageWithStagedURLFunc mocks the UpdateImageWithStagedURL method.
 	UpdateImageWithStagedURLFunc func(ctx context.Context, imageID string, stagedURL string, status string) (*queries.Image, error)
 
+	// WithCreditFunc mocks the WithCredit method.
+	WithCreditFunc func(ctx context.Context, userID string, create func(repo Repository) (*queries.Image, error)) (*queries.Image, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CancelImage holds details about calls to the CancelImage method.
+		CancelImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
 		// CreateImage holds details about calls to the CreateImage method.
 		CreateImage []struct {
 			// Ctx is the ctx argument value.
@@ -110,6 +191,23 @@ type RepositoryMock struct {
 			Seed *int64
 			// Prompt is the prompt argument value.
 			Prompt *string
+			// Mode is the mode argument value.
+			Mode string
+		}
+		// CreateImageVariant holds details about calls to the CreateImageVariant method.
+		CreateImageVariant []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SourceImageID is the sourceImageID argument value.
+			SourceImageID string
+			// RoomType is the roomType argument value.
+			RoomType *string
+			// Style is the style argument value.
+			Style *string
+			// Seed is the seed argument value.
+			Seed *int64
+			// Prompt is the prompt argument value.
+			Prompt *string
 		}
 		// DeleteImage holds details about calls to the DeleteImage method.
 		DeleteImage []struct {
@@ -125,6 +223,13 @@ type RepositoryMock struct {
 			// ProjectID is the projectID argument value.
 			ProjectID string
 		}
+		// GetDeletedImageByID holds details about calls to the GetDeletedImageByID method.
+		GetDeletedImageByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
 		// GetImageByID holds details about calls to the GetImageByID method.
 		GetImageByID []struct {
 			// Ctx is the ctx argument value.
@@ -153,6 +258,76 @@ type RepositoryMock struct {
 			// ProjectID is the projectID argument value.
 			ProjectID string
 		}
+		// ListDeletedImagesByProjectID holds details about calls to the ListDeletedImagesByProjectID method.
+		ListDeletedImagesByProjectID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// ListImagesByProjectID holds details about calls to the ListImagesByProjectID method.
+		ListImagesByProjectID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Page is the page argument value.
+			Page pagination.Page
+		}
+		// ListThumbnails holds details about calls to the ListThumbnails method.
+		ListThumbnails []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
+		// PurgeDeletedImages holds details about calls to the PurgeDeletedImages method.
+		PurgeDeletedImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// DeletedBefore is the deletedBefore argument value.
+			DeletedBefore time.Time
+			// CreatedBefore is the createdBefore argument value.
+			CreatedBefore time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// RestoreImage holds details about calls to the RestoreImage method.
+		RestoreImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
+		// SearchImages holds details about calls to the SearchImages method.
+		SearchImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Query is the query argument value.
+			Query SearchQuery
+			// Page is the page argument value.
+			Page pagination.Page
+		}
+		// SetImageApproval holds details about calls to the SetImageApproval method.
+		SetImageApproval []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// Approved is the approved argument value.
+			Approved *bool
+		}
+		// SetImageMask holds details about calls to the SetImageMask method.
+		SetImageMask []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// MaskURL is the maskURL argument value.
+			MaskURL string
+		}
 		// UpdateImageCost holds details about calls to the UpdateImageCost method.
 		UpdateImageCost []struct {
 			// Ctx is the ctx argument value.
@@ -197,22 +372,79 @@ type RepositoryMock struct {
 			// Status is the status argument value.
 			Status string
 		}
+		// WithCredit holds details about calls to the WithCredit method.
+		WithCredit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Create is the create argument value.
+			Create func(repo Repository) (*queries.Image, error)
+		}
+	}
+	lockCancelImage                  sync.RWMutex
+	lockCreateImage                  sync.RWMutex
+	lockCreateImageVariant           sync.RWMutex
+	lockDeleteImage                  sync.RWMutex
+	lockDeleteImagesByProjectID      sync.RWMutex
+	lockGetDeletedImageByID          sync.RWMutex
+	lockGetImageByID                 sync.RWMutex
+	lockGetImagesByProjectID         sync.RWMutex
+	lockGetOriginalImageID           sync.RWMutex
+	lockGetProjectCostSummary        sync.RWMutex
+	lockListDeletedImagesByProjectID sync.RWMutex
+	lockListImagesByProjectID        sync.RWMutex
+	lockListThumbnails               sync.RWMutex
+	lockPurgeDeletedImages           sync.RWMutex
+	lockRestoreImage                 sync.RWMutex
+	lockSearchImages                 sync.RWMutex
+	lockSetImageApproval             sync.RWMutex
+	lockSetImageMask                 sync.RWMutex
+	lockUpdateImageCost              sync.RWMutex
+	lockUpdateImageStatus            sync.RWMutex
+	lockUpdateImageWithError         sync.RWMutex
+	lockUpdateImageWithStagedURL     sync.RWMutex
+	lockWithCredit                   sync.RWMutex
+}
+
+// CancelImage calls CancelImageFunc.
+func (mock *RepositoryMock) CancelImage(ctx context.Context, imageID string) (*queries.Image, error) {
+	if mock.CancelImageFunc == nil {
+		panic("RepositoryMock.CancelImageFunc: method is nil but Repository.CancelImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
 	}
-	lockCreateImage              sync.RWMutex
-	lockDeleteImage              sync.RWMutex
-	lockDeleteImagesByProjectID  sync.RWMutex
-	lockGetImageByID             sync.RWMutex
-	lockGetImagesByProjectID     sync.RWMutex
-	lockGetOriginalImageID       sync.RWMutex
-	lockGetProjectCostSummary    sync.RWMutex
-	lockUpdateImageCost          sync.RWMutex
-	lockUpdateImageStatus        sync.RWMutex
-	lockUpdateImageWithError     sync.RWMutex
-	lockUpdateImageWithStagedURL sync.RWMutex
+	mock.lockCancelImage.Lock()
+	mock.calls.CancelImage = append(mock.calls.CancelImage, callInfo)
+	mock.lockCancelImage.Unlock()
+	return mock.CancelImageFunc(ctx, imageID)
+}
+
+// CancelImageCalls gets all the calls that were made to CancelImage.
+// Check the length with:
+//
+//	len(mockedRepository.CancelImageCalls())
+func (mock *RepositoryMock) CancelImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockCancelImage.RLock()
+	calls = mock.calls.CancelImage
+	mock.lockCancelImage.RUnlock()
+	return calls
 }
 
 // CreateImage calls CreateImageFunc.
-func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, prompt *string) (*queries.Image, error) {
+func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, originalURL string, roomType *string, style *string, seed *int64, prompt *string, mode string) (*queries.Image, error) {
 	if mock.CreateImageFunc == nil {
 		panic("RepositoryMock.CreateImageFunc: method is nil but Repository.CreateImage was just called")
 	}
@@ -224,6 +456,7 @@ func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, o
 		Style       *string
 		Seed        *int64
 		Prompt      *string
+		Mode        string
 	}{
 		Ctx:         ctx,
 		ProjectID:   projectID,
@@ -232,11 +465,12 @@ func (mock *RepositoryMock) CreateImage(ctx context.Context, projectID string, o
 		Style:       style,
 		Seed:        seed,
 		Prompt:      prompt,
+		Mode:        mode,
 	}
 	mock.lockCreateImage.Lock()
 	mock.calls.CreateImage = append(mock.calls.CreateImage, callInfo)
 	mock.lockCreateImage.Unlock()
-	return mock.CreateImageFunc(ctx, projectID, originalURL, roomType, style, seed, prompt)
+	return mock.CreateImageFunc(ctx, projectID, originalURL, roomType, style, seed, prompt, mode)
 }
 
 // CreateImageCalls gets all the calls that were made to CreateImage.
@@ -251,6 +485,7 @@ func (mock *RepositoryMock) CreateImageCalls() []struct {
 	Style       *string
 	Seed        *int64
 	Prompt      *string
+	Mode        string
 } {
 	var calls []struct {
 		Ctx         context.Context
@@ -260,6 +495,7 @@ func (mock *RepositoryMock) CreateImageCalls() []struct {
 		Style       *string
 		Seed        *int64
 		Prompt      *string
+		Mode        string
 	}
 	mock.lockCreateImage.RLock()
 	calls = mock.calls.CreateImage
@@ -267,6 +503,58 @@ func (mock *RepositoryMock) CreateImageCalls() []struct {
 	return calls
 }
 
+// CreateImageVariant calls CreateImageVariantFunc.
+func (mock *RepositoryMock) CreateImageVariant(ctx context.Context, sourceImageID string, roomType *string, style *string, seed *int64, prompt *string) (*queries.Image, error) {
+	if mock.CreateImageVariantFunc == nil {
+		panic("RepositoryMock.CreateImageVariantFunc: method is nil but Repository.CreateImageVariant was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		SourceImageID string
+		RoomType      *string
+		Style         *string
+		Seed          *int64
+		Prompt        *string
+	}{
+		Ctx:           ctx,
+		SourceImageID: sourceImageID,
+		RoomType:      roomType,
+		Style:         style,
+		Seed:          seed,
+		Prompt:        prompt,
+	}
+	mock.lockCreateImageVariant.Lock()
+	mock.calls.CreateImageVariant = append(mock.calls.CreateImageVariant, callInfo)
+	mock.lockCreateImageVariant.Unlock()
+	return mock.CreateImageVariantFunc(ctx, sourceImageID, roomType, style, seed, prompt)
+}
+
+// CreateImageVariantCalls gets all the calls that were made to CreateImageVariant.
+// Check the length with:
+//
+//	len(mockedRepository.CreateImageVariantCalls())
+func (mock *RepositoryMock) CreateImageVariantCalls() []struct {
+	Ctx           context.Context
+	SourceImageID string
+	RoomType      *string
+	Style         *string
+	Seed          *int64
+	Prompt        *string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		SourceImageID string
+		RoomType      *string
+		Style         *string
+		Seed          *int64
+		Prompt        *string
+	}
+	mock.lockCreateImageVariant.RLock()
+	calls = mock.calls.CreateImageVariant
+	mock.lockCreateImageVariant.RUnlock()
+	return calls
+}
+
 // DeleteImage calls DeleteImageFunc.
 func (mock *RepositoryMock) DeleteImage(ctx context.Context, imageID string) error {
 	if mock.DeleteImageFunc == nil {
@@ -339,6 +627,42 @@ func (mock *RepositoryMock) DeleteImagesByProjectIDCalls() []struct {
 	return calls
 }
 
+// GetDeletedImageByID calls GetDeletedImageByIDFunc.
+func (mock *RepositoryMock) GetDeletedImageByID(ctx context.Context, imageID string) (*queries.Image, error) {
+	if mock.GetDeletedImageByIDFunc == nil {
+		panic("RepositoryMock.GetDeletedImageByIDFunc: method is nil but Repository.GetDeletedImageByID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockGetDeletedImageByID.Lock()
+	mock.calls.GetDeletedImageByID = append(mock.calls.GetDeletedImageByID, callInfo)
+	mock.lockGetDeletedImageByID.Unlock()
+	return mock.GetDeletedImageByIDFunc(ctx, imageID)
+}
+
+// GetDeletedImageByIDCalls gets all the calls that were made to GetDeletedImageByID.
+// Check the length with:
+//
+//	len(mockedRepository.GetDeletedImageByIDCalls())
+func (mock *RepositoryMock) GetDeletedImageByIDCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockGetDeletedImageByID.RLock()
+	calls = mock.calls.GetDeletedImageByID
+	mock.lockGetDeletedImageByID.RUnlock()
+	return calls
+}
+
 // GetImageByID calls GetImageByIDFunc.
 func (mock *RepositoryMock) GetImageByID(ctx context.Context, imageID string) (*queries.Image, error) {
 	if mock.GetImageByIDFunc == nil {
@@ -483,6 +807,322 @@ func (mock *RepositoryMock) GetProjectCostSummaryCalls() []struct {
 	return calls
 }
 
+// ListDeletedImagesByProjectID calls ListDeletedImagesByProjectIDFunc.
+func (mock *RepositoryMock) ListDeletedImagesByProjectID(ctx context.Context, projectID string) ([]*queries.Image, error) {
+	if mock.ListDeletedImagesByProjectIDFunc == nil {
+		panic("RepositoryMock.ListDeletedImagesByProjectIDFunc: method is nil but Repository.ListDeletedImagesByProjectID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListDeletedImagesByProjectID.Lock()
+	mock.calls.ListDeletedImagesByProjectID = append(mock.calls.ListDeletedImagesByProjectID, callInfo)
+	mock.lockListDeletedImagesByProjectID.Unlock()
+	return mock.ListDeletedImagesByProjectIDFunc(ctx, projectID)
+}
+
+// ListDeletedImagesByProjectIDCalls gets all the calls that were made to ListDeletedImagesByProjectID.
+// Check the length with:
+//
+//	len(mockedRepository.ListDeletedImagesByProjectIDCalls())
+func (mock *RepositoryMock) ListDeletedImagesByProjectIDCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockListDeletedImagesByProjectID.RLock()
+	calls = mock.calls.ListDeletedImagesByProjectID
+	mock.lockListDeletedImagesByProjectID.RUnlock()
+	return calls
+}
+
+// ListImagesByProjectID calls ListImagesByProjectIDFunc.
+func (mock *RepositoryMock) ListImagesByProjectID(ctx context.Context, projectID string, page pagination.Page) ([]*queries.Image, *string, error) {
+	if mock.ListImagesByProjectIDFunc == nil {
+		panic("RepositoryMock.ListImagesByProjectIDFunc: method is nil but Repository.ListImagesByProjectID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		Page      pagination.Page
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		Page:      page,
+	}
+	mock.lockListImagesByProjectID.Lock()
+	mock.calls.ListImagesByProjectID = append(mock.calls.ListImagesByProjectID, callInfo)
+	mock.lockListImagesByProjectID.Unlock()
+	return mock.ListImagesByProjectIDFunc(ctx, projectID, page)
+}
+
+// ListImagesByProjectIDCalls gets all the calls that were made to ListImagesByProjectID.
+// Check the length with:
+//
+//	len(mockedRepository.ListImagesByProjectIDCalls())
+func (mock *RepositoryMock) ListImagesByProjectIDCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	Page      pagination.Page
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		Page      pagination.Page
+	}
+	mock.lockListImagesByProjectID.RLock()
+	calls = mock.calls.ListImagesByProjectID
+	mock.lockListImagesByProjectID.RUnlock()
+	return calls
+}
+
+// ListThumbnails calls ListThumbnailsFunc.
+func (mock *RepositoryMock) ListThumbnails(ctx context.Context, imageIDs []string) ([]Thumbnail, error) {
+	if mock.ListThumbnailsFunc == nil {
+		panic("RepositoryMock.ListThumbnailsFunc: method is nil but Repository.ListThumbnails was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ImageIDs []string
+	}{
+		Ctx:      ctx,
+		ImageIDs: imageIDs,
+	}
+	mock.lockListThumbnails.Lock()
+	mock.calls.ListThumbnails = append(mock.calls.ListThumbnails, callInfo)
+	mock.lockListThumbnails.Unlock()
+	return mock.ListThumbnailsFunc(ctx, imageIDs)
+}
+
+// ListThumbnailsCalls gets all the calls that were made to ListThumbnails.
+// Check the length with:
+//
+//	len(mockedRepository.ListThumbnailsCalls())
+func (mock *RepositoryMock) ListThumbnailsCalls() []struct {
+	Ctx      context.Context
+	ImageIDs []string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ImageIDs []string
+	}
+	mock.lockListThumbnails.RLock()
+	calls = mock.calls.ListThumbnails
+	mock.lockListThumbnails.RUnlock()
+	return calls
+}
+
+// PurgeDeletedImages calls PurgeDeletedImagesFunc.
+func (mock *RepositoryMock) PurgeDeletedImages(ctx context.Context, deletedBefore time.Time, createdBefore time.Time, limit int) ([]PurgedImage, error) {
+	if mock.PurgeDeletedImagesFunc == nil {
+		panic("RepositoryMock.PurgeDeletedImagesFunc: method is nil but Repository.PurgeDeletedImages was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+		CreatedBefore time.Time
+		Limit         int
+	}{
+		Ctx:           ctx,
+		DeletedBefore: deletedBefore,
+		CreatedBefore: createdBefore,
+		Limit:         limit,
+	}
+	mock.lockPurgeDeletedImages.Lock()
+	mock.calls.PurgeDeletedImages = append(mock.calls.PurgeDeletedImages, callInfo)
+	mock.lockPurgeDeletedImages.Unlock()
+	return mock.PurgeDeletedImagesFunc(ctx, deletedBefore, createdBefore, limit)
+}
+
+// PurgeDeletedImagesCalls gets all the calls that were made to PurgeDeletedImages.
+// Check the length with:
+//
+//	len(mockedRepository.PurgeDeletedImagesCalls())
+func (mock *RepositoryMock) PurgeDeletedImagesCalls() []struct {
+	Ctx           context.Context
+	DeletedBefore time.Time
+	CreatedBefore time.Time
+	Limit         int
+} {
+	var calls []struct {
+		Ctx           context.Context
+		DeletedBefore time.Time
+		CreatedBefore time.Time
+		Limit         int
+	}
+	mock.lockPurgeDeletedImages.RLock()
+	calls = mock.calls.PurgeDeletedImages
+	mock.lockPurgeDeletedImages.RUnlock()
+	return calls
+}
+
+// RestoreImage calls RestoreImageFunc.
+func (mock *RepositoryMock) RestoreImage(ctx context.Context, imageID string) (*queries.Image, error) {
+	if mock.RestoreImageFunc == nil {
+		panic("RepositoryMock.RestoreImageFunc: method is nil but Repository.RestoreImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockRestoreImage.Lock()
+	mock.calls.RestoreImage = append(mock.calls.RestoreImage, callInfo)
+	mock.lockRestoreImage.Unlock()
+	return mock.RestoreImageFunc(ctx, imageID)
+}
+
+// RestoreImageCalls gets all the calls that were made to RestoreImage.
+// Check the length with:
+//
+//	len(mockedRepository.RestoreImageCalls())
+func (mock *RepositoryMock) RestoreImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockRestoreImage.RLock()
+	calls = mock.calls.RestoreImage
+	mock.lockRestoreImage.RUnlock()
+	return calls
+}
+
+// SearchImages calls SearchImagesFunc.
+func (mock *RepositoryMock) SearchImages(ctx context.Context, userID string, query SearchQuery, page pagination.Page) ([]*queries.Image, *string, error) {
+	if mock.SearchImagesFunc == nil {
+		panic("RepositoryMock.SearchImagesFunc: method is nil but Repository.SearchImages was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Query  SearchQuery
+		Page   pagination.Page
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Query:  query,
+		Page:   page,
+	}
+	mock.lockSearchImages.Lock()
+	mock.calls.SearchImages = append(mock.calls.SearchImages, callInfo)
+	mock.lockSearchImages.Unlock()
+	return mock.SearchImagesFunc(ctx, userID, query, page)
+}
+
+// SearchImagesCalls gets all the calls that were made to SearchImages.
+// Check the length with:
+//
+//	len(mockedRepository.SearchImagesCalls())
+func (mock *RepositoryMock) SearchImagesCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Query  SearchQuery
+	Page   pagination.Page
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Query  SearchQuery
+		Page   pagination.Page
+	}
+	mock.lockSearchImages.RLock()
+	calls = mock.calls.SearchImages
+	mock.lockSearchImages.RUnlock()
+	return calls
+}
+
+// SetImageApproval calls SetImageApprovalFunc.
+func (mock *RepositoryMock) SetImageApproval(ctx context.Context, imageID string, approved *bool) error {
+	if mock.SetImageApprovalFunc == nil {
+		panic("RepositoryMock.SetImageApprovalFunc: method is nil but Repository.SetImageApproval was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ImageID  string
+		Approved *bool
+	}{
+		Ctx:      ctx,
+		ImageID:  imageID,
+		Approved: approved,
+	}
+	mock.lockSetImageApproval.Lock()
+	mock.calls.SetImageApproval = append(mock.calls.SetImageApproval, callInfo)
+	mock.lockSetImageApproval.Unlock()
+	return mock.SetImageApprovalFunc(ctx, imageID, approved)
+}
+
+// SetImageApprovalCalls gets all the calls that were made to SetImageApproval.
+// Check the length with:
+//
+//	len(mockedRepository.SetImageApprovalCalls())
+func (mock *RepositoryMock) SetImageApprovalCalls() []struct {
+	Ctx      context.Context
+	ImageID  string
+	Approved *bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ImageID  string
+		Approved *bool
+	}
+	mock.lockSetImageApproval.RLock()
+	calls = mock.calls.SetImageApproval
+	mock.lockSetImageApproval.RUnlock()
+	return calls
+}
+
+// SetImageMask calls SetImageMaskFunc.
+func (mock *RepositoryMock) SetImageMask(ctx context.Context, imageID string, maskURL string) error {
+	if mock.SetImageMaskFunc == nil {
+		panic("RepositoryMock.SetImageMaskFunc: method is nil but Repository.SetImageMask was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		MaskURL string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		MaskURL: maskURL,
+	}
+	mock.lockSetImageMask.Lock()
+	mock.calls.SetImageMask = append(mock.calls.SetImageMask, callInfo)
+	mock.lockSetImageMask.Unlock()
+	return mock.SetImageMaskFunc(ctx, imageID, maskURL)
+}
+
+// SetImageMaskCalls gets all the calls that were made to SetImageMask.
+// Check the length with:
+//
+//	len(mockedRepository.SetImageMaskCalls())
+func (mock *RepositoryMock) SetImageMaskCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	MaskURL string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		MaskURL string
+	}
+	mock.lockSetImageMask.RLock()
+	calls = mock.calls.SetImageMask
+	mock.lockSetImageMask.RUnlock()
+	return calls
+}
+
 // UpdateImageCost calls UpdateImageCostFunc.
 func (mock *RepositoryMock) UpdateImageCost(ctx context.Context, imageID string, costUSD float64, modelUsed string, processingTimeMs int, predictionID string) error {
 	if mock.UpdateImageCostFunc == nil {
@@ -658,3 +1298,43 @@ func (mock *RepositoryMock) UpdateImageWithStagedURLCalls() []struct {
 	mock.lockUpdateImageWithStagedURL.RUnlock()
 	return calls
 }
+
+// WithCredit calls WithCreditFunc.
+func (mock *RepositoryMock) WithCredit(ctx context.Context, userID string, create func(repo Repository) (*queries.Image, error)) (*queries.Image, error) {
+	if mock.WithCreditFunc == nil {
+		panic("RepositoryMock.WithCreditFunc: method is nil but Repository.WithCredit was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Create func(repo Repository) (*queries.Image, error)
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Create: create,
+	}
+	mock.lockWithCredit.Lock()
+	mock.calls.WithCredit = append(mock.calls.WithCredit, callInfo)
+	mock.lockWithCredit.Unlock()
+	return mock.WithCreditFunc(ctx, userID, create)
+}
+
+// WithCreditCalls gets all the calls that were made to WithCredit.
+// Check the length with:
+//
+//	len(mockedRepository.WithCreditCalls())
+func (mock *RepositoryMock) WithCreditCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Create func(repo Repository) (*queries.Image, error)
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Create func(repo Repository) (*queries.Image, error)
+	}
+	mock.lockWithCredit.RLock()
+	calls = mock.calls.WithCredit
+	mock.lockWithCredit.RUnlock()
+	return calls
+}
@@ -0,0 +1,32 @@
+package image
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SearchQuery is a parsed image search. Terms are matched, in web search
+// syntax, against the prompt, room type, style and model of the images; Seed,
+// when set, must match exactly.
+type SearchQuery struct {
+	Terms string
+	Seed  *int64
+}
+
+// seedTerm finds "seed 42", "seed:42" or "seed=42" in a search.
+var seedTerm = regexp.MustCompile(`(?i)\bseed\s*[:=]?\s*(\d+)\b`)
+
+// ParseSearchQuery takes the seed, if any, out of q and keeps the rest as
+// full-text terms.
+func ParseSearchQuery(q string) SearchQuery {
+	var query SearchQuery
+	if m := seedTerm.FindStringSubmatchIndex(q); m != nil {
+		if seed, err := strconv.ParseInt(q[m[2]:m[3]], 10, 64); err == nil {
+			query.Seed = &seed
+			q = q[:m[0]] + " " + q[m[1]:]
+		}
+	}
+	query.Terms = strings.Join(strings.Fields(q), " ")
+	return query
+}
@@ -0,0 +1,54 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	seed := func(n int64) *int64 { return &n }
+
+	cases := []struct {
+		name string
+		q    string
+		want SearchQuery
+	}{
+		{
+			name: "success: terms only",
+			q:    "scandinavian  bedroom",
+			want: SearchQuery{Terms: "scandinavian bedroom"},
+		},
+		{
+			name: "success: seed taken out of the terms",
+			q:    "scandinavian bedroom with seed 42",
+			want: SearchQuery{Terms: "scandinavian bedroom with", Seed: seed(42)},
+		},
+		{
+			name: "success: seed with a colon, any case",
+			q:    "Seed:7 kitchen",
+			want: SearchQuery{Terms: "kitchen", Seed: seed(7)},
+		},
+		{
+			name: "success: seed alone",
+			q:    "seed=123",
+			want: SearchQuery{Seed: seed(123)},
+		},
+		{
+			name: "success: a word containing seed is a term",
+			q:    "seedream 4",
+			want: SearchQuery{Terms: "seedream 4"},
+		},
+		{
+			name: "success: a seed too large is a term",
+			q:    "seed 99999999999999999999",
+			want: SearchQuery{Terms: "seed 99999999999999999999"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ParseSearchQuery(tc.q))
+		})
+	}
+}
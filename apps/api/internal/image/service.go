@@ -2,7 +2,9 @@ package image
 
 import (
 	"context"
+	"time"
 
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
@@ -12,13 +14,60 @@ import (
 type Service interface {
 	CreateImage(ctx context.Context, req *CreateImageRequest) (*Image, error)
 	BatchCreateImages(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error)
+	// RestageImage queues a new variant of an existing image, sharing its original.
+	RestageImage(ctx context.Context, imageID string, req *RestageImageRequest) (*Image, error)
+	// RerollImage queues a new variant of an existing image that differs from
+	// it only in its seed.
+	RerollImage(ctx context.Context, imageID string, req *RerollImageRequest) (*Image, error)
 	GetImageByID(ctx context.Context, imageID string) (*Image, error)
-	GetImagesByProjectID(ctx context.Context, projectID string) ([]*Image, error)
-	GetGroupedProjectImages(ctx context.Context, projectID string) (*GroupedProjectImagesResponse, error)
+	// GetImagesByProjectID returns a page of a project's images, newest first.
+	GetImagesByProjectID(ctx context.Context, projectID string, page pagination.Page) (*ProjectImagesResponse, error)
+	// GetGroupedProjectImages returns a page of a project's images grouped by original.
+	GetGroupedProjectImages(
+		ctx context.Context, projectID string, page pagination.Page,
+	) (*GroupedProjectImagesResponse, error)
+	// SearchImages returns a page of the images matching query in the projects
+	// the user created or shares through an organization, newest first.
+	SearchImages(
+		ctx context.Context, userID string, query SearchQuery, page pagination.Page,
+	) (*ImageSearchResponse, error)
+	// ListReadyImages returns a project's ready images that have a staged file,
+	// oldest first.
+	ListReadyImages(ctx context.Context, projectID string) ([]*Image, error)
 	UpdateImageStatus(ctx context.Context, imageID string, status Status) (*Image, error)
 	UpdateImageWithStagedURL(ctx context.Context, imageID string, stagedURL string) (*Image, error)
 	UpdateImageWithError(ctx context.Context, imageID string, errorMsg string) (*Image, error)
 	DeleteImage(ctx context.Context, imageID string) error
 	GetProjectCostSummary(ctx context.Context, projectID string) (*ProjectCostSummary, error)
+	// ListTrash returns a project's soft-deleted images, most recently deleted first.
+	ListTrash(ctx context.Context, projectID string) ([]*Image, error)
+	// GetDeletedImageByID retrieves an image in the trash. Returns pgx.ErrNoRows otherwise.
+	GetDeletedImageByID(ctx context.Context, imageID string) (*Image, error)
+	// RestoreImage takes an image back out of the trash. Returns pgx.ErrNoRows if
+	// it isn't in the trash.
+	RestoreImage(ctx context.Context, imageID string) (*Image, error)
+	// SetImageApproval records whether the owner approves the staged result;
+	// nil clears the verdict. Returns pgx.ErrNoRows if the image is gone.
+	SetImageApproval(ctx context.Context, imageID string, approved *bool) error
+	// CancelJob cancels the image's queued or processing staging job and
+	// returns the canceled image. Returns ErrJobFinished if the image is in
+	// any other status.
+	CancelJob(ctx context.Context, imageID string) (*Image, error)
+	// PurgeTrash permanently deletes up to limit images that have been in the
+	// trash longer than retention and releases their originals. It returns the
+	// purged images so the caller can remove their files.
+	PurgeTrash(ctx context.Context, retention time.Duration, limit int) ([]PurgedImage, error)
+	// PlanProjectRestyle returns the create requests needed to restyle a project: one per
+	// variant group with a ready image and no live variant in style yet. Skipped counts the
+	// groups left out.
+	PlanProjectRestyle(
+		ctx context.Context, projectID string, style string,
+	) (reqs []CreateImageRequest, skipped int, err error)
+	// PlanProjectDuplicate returns the create requests that re-stage a project's ready images
+	// into a copy of it, in style when it is set. The requests still carry the source project
+	// ID. Skipped counts the variant groups left out.
+	PlanProjectDuplicate(
+		ctx context.Context, projectID string, style *string,
+	) (reqs []CreateImageRequest, skipped int, err error)
 	convertToImage(dbImage *queries.Image) *Image
 }
@@ -5,8 +5,10 @@ package image
 
 import (
 	"context"
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 	"sync"
+	"time"
 )
 
 // Ensure, that ServiceMock does implement Service.
@@ -22,24 +24,60 @@ var _ Service = &ServiceMock{}
 //			BatchCreateImagesFunc: func(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error) {
 //				panic("mock out the BatchCreateImages method")
 //			},
+//			CancelJobFunc: func(ctx context.Context, imageID string) (*Image, error) {
+//				panic("mock out the CancelJob method")
+//			},
 //			CreateImageFunc: func(ctx context.Context, req *CreateImageRequest) (*Image, error) {
 //				panic("mock out the CreateImage method")
 //			},
 //			DeleteImageFunc: func(ctx context.Context, imageID string) error {
 //				panic("mock out the DeleteImage method")
 //			},
-//			GetGroupedProjectImagesFunc: func(ctx context.Context, projectID string) (*GroupedProjectImagesResponse, error) {
+//			GetDeletedImageByIDFunc: func(ctx context.Context, imageID string) (*Image, error) {
+//				panic("mock out the GetDeletedImageByID method")
+//			},
+//			GetGroupedProjectImagesFunc: func(ctx context.Context, projectID string, page pagination.Page) (*GroupedProjectImagesResponse, error) {
 //				panic("mock out the GetGroupedProjectImages method")
 //			},
 //			GetImageByIDFunc: func(ctx context.Context, imageID string) (*Image, error) {
 //				panic("mock out the GetImageByID method")
 //			},
-//			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string) ([]*Image, error) {
+//			GetImagesByProjectIDFunc: func(ctx context.Context, projectID string, page pagination.Page) (*ProjectImagesResponse, error) {
 //				panic("mock out the GetImagesByProjectID method")
 //			},
 //			GetProjectCostSummaryFunc: func(ctx context.Context, projectID string) (*ProjectCostSummary, error) {
 //				panic("mock out the GetProjectCostSummary method")
 //			},
+//			ListReadyImagesFunc: func(ctx context.Context, projectID string) ([]*Image, error) {
+//				panic("mock out the ListReadyImages method")
+//			},
+//			ListTrashFunc: func(ctx context.Context, projectID string) ([]*Image, error) {
+//				panic("mock out the ListTrash method")
+//			},
+//			PlanProjectDuplicateFunc: func(ctx context.Context, projectID string, style *string) ([]CreateImageRequest, int, error) {
+//				panic("mock out the PlanProjectDuplicate method")
+//			},
+//			PlanProjectRestyleFunc: func(ctx context.Context, projectID string, style string) ([]CreateImageRequest, int, error) {
+//				panic("mock out the PlanProjectRestyle method")
+//			},
+//			PurgeTrashFunc: func(ctx context.Context, retention time.Duration, limit int) ([]PurgedImage, error) {
+//				panic("mock out the PurgeTrash method")
+//			},
+//			RerollImageFunc: func(ctx context.Context, imageID string, req *RerollImageRequest) (*Image, error) {
+//				panic("mock out the RerollImage method")
+//			},
+//			RestageImageFunc: func(ctx context.Context, imageID string, req *RestageImageRequest) (*Image, error) {
+//				panic("mock out the RestageImage method")
+//			},
+//			RestoreImageFunc: func(ctx context.Context, imageID string) (*Image, error) {
+//				panic("mock out the RestoreImage method")
+//			},
+//			SearchImagesFunc: func(ctx context.Context, userID string, query SearchQuery, page pagination.Page) (*ImageSearchResponse, error) {
+//				panic("mock out the SearchImages method")
+//			},
+//			SetImageApprovalFunc: func(ctx context.Context, imageID string, approved *bool) error {
+//				panic("mock out the SetImageApproval method")
+//			},
 //			UpdateImageStatusFunc: func(ctx context.Context, imageID string, status Status) (*Image, error) {
 //				panic("mock out the UpdateImageStatus method")
 //			},
@@ -62,24 +100,60 @@ type ServiceMock struct {
 	// BatchCreateImagesFunc mocks the BatchCreateImages method.
 	BatchCreateImagesFunc func(ctx context.Context, reqs []CreateImageRequest) (*BatchCreateImagesResponse, error)
 
+	// CancelJobFunc mocks the CancelJob method.
+	CancelJobFunc func(ctx context.Context, imageID string) (*Image, error)
+
 	// CreateImageFunc mocks the CreateImage method.
 	CreateImageFunc func(ctx context.Context, req *CreateImageRequest) (*Image, error)
 
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(ctx context.Context, imageID string) error
 
+	// GetDeletedImageByIDFunc mocks the GetDeletedImageByID method.
+	GetDeletedImageByIDFunc func(ctx context.Context, imageID string) (*Image, error)
+
 	// GetGroupedProjectImagesFunc mocks the GetGroupedProjectImages method.
-	GetGroupedProjectImagesFunc func(ctx context.Context, projectID string) (*GroupedProjectImagesResponse, error)
+	GetGroupedProjectImagesFunc func(ctx context.Context, projectID string, page pagination.Page) (*GroupedProjectImagesResponse, error)
 
 	// GetImageByIDFunc mocks the GetImageByID method.
 	GetImageByIDFunc func(ctx context.Context, imageID string) (*Image, error)
 
 	// GetImagesByProjectIDFunc mocks the GetImagesByProjectID method.
-	GetImagesByProjectIDFunc func(ctx context.Context, projectID string) ([]*Image, error)
+	GetImagesByProjectIDFunc func(ctx context.Context, projectID string, page pagination.Page) (*ProjectImagesResponse, error)
 
 	// GetProjectCostSummaryFunc mocks the GetProjectCostSummary method.
 	GetProjectCostSummaryFunc func(ctx context.Context, projectID string) (*ProjectCostSummary, error)
 
+	// ListReadyImagesFunc mocks the ListReadyImages method.
+	ListReadyImagesFunc func(ctx context.Context, projectID string) ([]*Image, error)
+
+	// ListTrashFunc mocks the ListTrash method.
+	ListTrashFunc func(ctx context.Context, projectID string) ([]*Image, error)
+
+	// PlanProjectDuplicateFunc mocks the PlanProjectDuplicate method.
+	PlanProjectDuplicateFunc func(ctx context.Context, projectID string, style *string) ([]CreateImageRequest, int, error)
+
+	// PlanProjectRestyleFunc mocks the PlanProjectRestyle method.
+	PlanProjectRestyleFunc func(ctx context.Context, projectID string, style string) ([]CreateImageRequest, int, error)
+
+	// PurgeTrashFunc mocks the PurgeTrash method.
+	PurgeTrashFunc func(ctx context.Context, retention time.Duration, limit int) ([]PurgedImage, error)
+
+	// RerollImageFunc mocks the RerollImage method.
+	RerollImageFunc func(ctx context.Context, imageID string, req *RerollImageRequest) (*Image, error)
+
+	// RestageImageFunc mocks the RestageImage method.
+	RestageImageFunc func(ctx context.Context, imageID string, req *RestageImageRequest) (*Image, error)
+
+	// RestoreImageFunc mocks the RestoreImage method.
+	RestoreImageFunc func(ctx context.Context, imageID string) (*Image, error)
+
+	// SearchImagesFunc mocks the SearchImages method.
+	SearchImagesFunc func(ctx context.Context, userID string, query SearchQuery, page pagination.Page) (*ImageSearchResponse, error)
+
+	// SetImageApprovalFunc mocks the SetImageApproval method.
+	SetImageApprovalFunc func(ctx context.Context, imageID string, approved *bool) error
+
 	// UpdateImageStatusFunc mocks the UpdateImageStatus method.
 	UpdateImageStatusFunc func(ctx context.Context, imageID string, status Status) (*Image, error)
 
@@ -101,6 +175,13 @@ type ServiceMock struct {
 			// Reqs is the reqs argument value.
 			Reqs []CreateImageRequest
 		}
+		// CancelJob holds details about calls to the CancelJob method.
+		CancelJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
 		// CreateImage holds details about calls to the CreateImage method.
 		CreateImage []struct {
 			// Ctx is the ctx argument value.
@@ -115,12 +196,21 @@ type ServiceMock struct {
 			// ImageID is the imageID argument value.
 			ImageID string
 		}
+		// GetDeletedImageByID holds details about calls to the GetDeletedImageByID method.
+		GetDeletedImageByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
 		// GetGroupedProjectImages holds details about calls to the GetGroupedProjectImages method.
 		GetGroupedProjectImages []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ProjectID is the projectID argument value.
 			ProjectID string
+			// Page is the page argument value.
+			Page pagination.Page
 		}
 		// GetImageByID holds details about calls to the GetImageByID method.
 		GetImageByID []struct {
@@ -135,6 +225,8 @@ type ServiceMock struct {
 			Ctx context.Context
 			// ProjectID is the projectID argument value.
 			ProjectID string
+			// Page is the page argument value.
+			Page pagination.Page
 		}
 		// GetProjectCostSummary holds details about calls to the GetProjectCostSummary method.
 		GetProjectCostSummary []struct {
@@ -143,6 +235,92 @@ type ServiceMock struct {
 			// ProjectID is the projectID argument value.
 			ProjectID string
 		}
+		// ListReadyImages holds details about calls to the ListReadyImages method.
+		ListReadyImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// ListTrash holds details about calls to the ListTrash method.
+		ListTrash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
+		// PlanProjectDuplicate holds details about calls to the PlanProjectDuplicate method.
+		PlanProjectDuplicate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Style is the style argument value.
+			Style *string
+		}
+		// PlanProjectRestyle holds details about calls to the PlanProjectRestyle method.
+		PlanProjectRestyle []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Style is the style argument value.
+			Style string
+		}
+		// PurgeTrash holds details about calls to the PurgeTrash method.
+		PurgeTrash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Retention is the retention argument value.
+			Retention time.Duration
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// RerollImage holds details about calls to the RerollImage method.
+		RerollImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// Req is the req argument value.
+			Req *RerollImageRequest
+		}
+		// RestageImage holds details about calls to the RestageImage method.
+		RestageImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// Req is the req argument value.
+			Req *RestageImageRequest
+		}
+		// RestoreImage holds details about calls to the RestoreImage method.
+		RestoreImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+		}
+		// SearchImages holds details about calls to the SearchImages method.
+		SearchImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Query is the query argument value.
+			Query SearchQuery
+			// Page is the page argument value.
+			Page pagination.Page
+		}
+		// SetImageApproval holds details about calls to the SetImageApproval method.
+		SetImageApproval []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ImageID is the imageID argument value.
+			ImageID string
+			// Approved is the approved argument value.
+			Approved *bool
+		}
 		// UpdateImageStatus holds details about calls to the UpdateImageStatus method.
 		UpdateImageStatus []struct {
 			// Ctx is the ctx argument value.
@@ -177,12 +355,24 @@ type ServiceMock struct {
 		}
 	}
 	lockBatchCreateImages        sync.RWMutex
+	lockCancelJob                sync.RWMutex
 	lockCreateImage              sync.RWMutex
 	lockDeleteImage              sync.RWMutex
+	lockGetDeletedImageByID      sync.RWMutex
 	lockGetGroupedProjectImages  sync.RWMutex
 	lockGetImageByID             sync.RWMutex
 	lockGetImagesByProjectID     sync.RWMutex
 	lockGetProjectCostSummary    sync.RWMutex
+	lockListReadyImages          sync.RWMutex
+	lockListTrash                sync.RWMutex
+	lockPlanProjectDuplicate     sync.RWMutex
+	lockPlanProjectRestyle       sync.RWMutex
+	lockPurgeTrash               sync.RWMutex
+	lockRerollImage              sync.RWMutex
+	lockRestageImage             sync.RWMutex
+	lockRestoreImage             sync.RWMutex
+	lockSearchImages             sync.RWMutex
+	lockSetImageApproval         sync.RWMutex
 	lockUpdateImageStatus        sync.RWMutex
 	lockUpdateImageWithError     sync.RWMutex
 	lockUpdateImageWithStagedURL sync.RWMutex
@@ -225,6 +415,42 @@ func (mock *ServiceMock) BatchCreateImagesCalls() []struct {
 	return calls
 }
 
+// CancelJob calls CancelJobFunc.
+func (mock *ServiceMock) CancelJob(ctx context.Context, imageID string) (*Image, error) {
+	if mock.CancelJobFunc == nil {
+		panic("ServiceMock.CancelJobFunc: method is nil but Service.CancelJob was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockCancelJob.Lock()
+	mock.calls.CancelJob = append(mock.calls.CancelJob, callInfo)
+	mock.lockCancelJob.Unlock()
+	return mock.CancelJobFunc(ctx, imageID)
+}
+
+// CancelJobCalls gets all the calls that were made to CancelJob.
+// Check the length with:
+//
+//	len(mockedService.CancelJobCalls())
+func (mock *ServiceMock) CancelJobCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockCancelJob.RLock()
+	calls = mock.calls.CancelJob
+	mock.lockCancelJob.RUnlock()
+	return calls
+}
+
 // CreateImage calls CreateImageFunc.
 func (mock *ServiceMock) CreateImage(ctx context.Context, req *CreateImageRequest) (*Image, error) {
 	if mock.CreateImageFunc == nil {
@@ -297,22 +523,60 @@ func (mock *ServiceMock) DeleteImageCalls() []struct {
 	return calls
 }
 
+// GetDeletedImageByID calls GetDeletedImageByIDFunc.
+func (mock *ServiceMock) GetDeletedImageByID(ctx context.Context, imageID string) (*Image, error) {
+	if mock.GetDeletedImageByIDFunc == nil {
+		panic("ServiceMock.GetDeletedImageByIDFunc: method is nil but Service.GetDeletedImageByID was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockGetDeletedImageByID.Lock()
+	mock.calls.GetDeletedImageByID = append(mock.calls.GetDeletedImageByID, callInfo)
+	mock.lockGetDeletedImageByID.Unlock()
+	return mock.GetDeletedImageByIDFunc(ctx, imageID)
+}
+
+// GetDeletedImageByIDCalls gets all the calls that were made to GetDeletedImageByID.
+// Check the length with:
+//
+//	len(mockedService.GetDeletedImageByIDCalls())
+func (mock *ServiceMock) GetDeletedImageByIDCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockGetDeletedImageByID.RLock()
+	calls = mock.calls.GetDeletedImageByID
+	mock.lockGetDeletedImageByID.RUnlock()
+	return calls
+}
+
 // GetGroupedProjectImages calls GetGroupedProjectImagesFunc.
-func (mock *ServiceMock) GetGroupedProjectImages(ctx context.Context, projectID string) (*GroupedProjectImagesResponse, error) {
+func (mock *ServiceMock) GetGroupedProjectImages(ctx context.Context, projectID string, page pagination.Page) (*GroupedProjectImagesResponse, error) {
 	if mock.GetGroupedProjectImagesFunc == nil {
 		panic("ServiceMock.GetGroupedProjectImagesFunc: method is nil but Service.GetGroupedProjectImages was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
 		ProjectID string
+		Page      pagination.Page
 	}{
 		Ctx:       ctx,
 		ProjectID: projectID,
+		Page:      page,
 	}
 	mock.lockGetGroupedProjectImages.Lock()
 	mock.calls.GetGroupedProjectImages = append(mock.calls.GetGroupedProjectImages, callInfo)
 	mock.lockGetGroupedProjectImages.Unlock()
-	return mock.GetGroupedProjectImagesFunc(ctx, projectID)
+	return mock.GetGroupedProjectImagesFunc(ctx, projectID, page)
 }
 
 // GetGroupedProjectImagesCalls gets all the calls that were made to GetGroupedProjectImages.
@@ -322,10 +586,12 @@ func (mock *ServiceMock) GetGroupedProjectImages(ctx context.Context, projectID
 func (mock *ServiceMock) GetGroupedProjectImagesCalls() []struct {
 	Ctx       context.Context
 	ProjectID string
+	Page      pagination.Page
 } {
 	var calls []struct {
 		Ctx       context.Context
 		ProjectID string
+		Page      pagination.Page
 	}
 	mock.lockGetGroupedProjectImages.RLock()
 	calls = mock.calls.GetGroupedProjectImages
@@ -370,21 +636,23 @@ func (mock *ServiceMock) GetImageByIDCalls() []struct {
 }
 
 // GetImagesByProjectID calls GetImagesByProjectIDFunc.
-func (mock *ServiceMock) GetImagesByProjectID(ctx context.Context, projectID string) ([]*Image, error) {
+func (mock *ServiceMock) GetImagesByProjectID(ctx context.Context, projectID string, page pagination.Page) (*ProjectImagesResponse, error) {
 	if mock.GetImagesByProjectIDFunc == nil {
 		panic("ServiceMock.GetImagesByProjectIDFunc: method is nil but Service.GetImagesByProjectID was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
 		ProjectID string
+		Page      pagination.Page
 	}{
 		Ctx:       ctx,
 		ProjectID: projectID,
+		Page:      page,
 	}
 	mock.lockGetImagesByProjectID.Lock()
 	mock.calls.GetImagesByProjectID = append(mock.calls.GetImagesByProjectID, callInfo)
 	mock.lockGetImagesByProjectID.Unlock()
-	return mock.GetImagesByProjectIDFunc(ctx, projectID)
+	return mock.GetImagesByProjectIDFunc(ctx, projectID, page)
 }
 
 // GetImagesByProjectIDCalls gets all the calls that were made to GetImagesByProjectID.
@@ -394,10 +662,12 @@ func (mock *ServiceMock) GetImagesByProjectID(ctx context.Context, projectID str
 func (mock *ServiceMock) GetImagesByProjectIDCalls() []struct {
 	Ctx       context.Context
 	ProjectID string
+	Page      pagination.Page
 } {
 	var calls []struct {
 		Ctx       context.Context
 		ProjectID string
+		Page      pagination.Page
 	}
 	mock.lockGetImagesByProjectID.RLock()
 	calls = mock.calls.GetImagesByProjectID
@@ -441,6 +711,398 @@ func (mock *ServiceMock) GetProjectCostSummaryCalls() []struct {
 	return calls
 }
 
+// ListReadyImages calls ListReadyImagesFunc.
+func (mock *ServiceMock) ListReadyImages(ctx context.Context, projectID string) ([]*Image, error) {
+	if mock.ListReadyImagesFunc == nil {
+		panic("ServiceMock.ListReadyImagesFunc: method is nil but Service.ListReadyImages was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListReadyImages.Lock()
+	mock.calls.ListReadyImages = append(mock.calls.ListReadyImages, callInfo)
+	mock.lockListReadyImages.Unlock()
+	return mock.ListReadyImagesFunc(ctx, projectID)
+}
+
+// ListReadyImagesCalls gets all the calls that were made to ListReadyImages.
+// Check the length with:
+//
+//	len(mockedService.ListReadyImagesCalls())
+func (mock *ServiceMock) ListReadyImagesCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockListReadyImages.RLock()
+	calls = mock.calls.ListReadyImages
+	mock.lockListReadyImages.RUnlock()
+	return calls
+}
+
+// ListTrash calls ListTrashFunc.
+func (mock *ServiceMock) ListTrash(ctx context.Context, projectID string) ([]*Image, error) {
+	if mock.ListTrashFunc == nil {
+		panic("ServiceMock.ListTrashFunc: method is nil but Service.ListTrash was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockListTrash.Lock()
+	mock.calls.ListTrash = append(mock.calls.ListTrash, callInfo)
+	mock.lockListTrash.Unlock()
+	return mock.ListTrashFunc(ctx, projectID)
+}
+
+// ListTrashCalls gets all the calls that were made to ListTrash.
+// Check the length with:
+//
+//	len(mockedService.ListTrashCalls())
+func (mock *ServiceMock) ListTrashCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockListTrash.RLock()
+	calls = mock.calls.ListTrash
+	mock.lockListTrash.RUnlock()
+	return calls
+}
+
+// PlanProjectDuplicate calls PlanProjectDuplicateFunc.
+func (mock *ServiceMock) PlanProjectDuplicate(ctx context.Context, projectID string, style *string) ([]CreateImageRequest, int, error) {
+	if mock.PlanProjectDuplicateFunc == nil {
+		panic("ServiceMock.PlanProjectDuplicateFunc: method is nil but Service.PlanProjectDuplicate was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		Style     *string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		Style:     style,
+	}
+	mock.lockPlanProjectDuplicate.Lock()
+	mock.calls.PlanProjectDuplicate = append(mock.calls.PlanProjectDuplicate, callInfo)
+	mock.lockPlanProjectDuplicate.Unlock()
+	return mock.PlanProjectDuplicateFunc(ctx, projectID, style)
+}
+
+// PlanProjectDuplicateCalls gets all the calls that were made to PlanProjectDuplicate.
+// Check the length with:
+//
+//	len(mockedService.PlanProjectDuplicateCalls())
+func (mock *ServiceMock) PlanProjectDuplicateCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	Style     *string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		Style     *string
+	}
+	mock.lockPlanProjectDuplicate.RLock()
+	calls = mock.calls.PlanProjectDuplicate
+	mock.lockPlanProjectDuplicate.RUnlock()
+	return calls
+}
+
+// PlanProjectRestyle calls PlanProjectRestyleFunc.
+func (mock *ServiceMock) PlanProjectRestyle(ctx context.Context, projectID string, style string) ([]CreateImageRequest, int, error) {
+	if mock.PlanProjectRestyleFunc == nil {
+		panic("ServiceMock.PlanProjectRestyleFunc: method is nil but Service.PlanProjectRestyle was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		Style     string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		Style:     style,
+	}
+	mock.lockPlanProjectRestyle.Lock()
+	mock.calls.PlanProjectRestyle = append(mock.calls.PlanProjectRestyle, callInfo)
+	mock.lockPlanProjectRestyle.Unlock()
+	return mock.PlanProjectRestyleFunc(ctx, projectID, style)
+}
+
+// PlanProjectRestyleCalls gets all the calls that were made to PlanProjectRestyle.
+// Check the length with:
+//
+//	len(mockedService.PlanProjectRestyleCalls())
+func (mock *ServiceMock) PlanProjectRestyleCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	Style     string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		Style     string
+	}
+	mock.lockPlanProjectRestyle.RLock()
+	calls = mock.calls.PlanProjectRestyle
+	mock.lockPlanProjectRestyle.RUnlock()
+	return calls
+}
+
+// PurgeTrash calls PurgeTrashFunc.
+func (mock *ServiceMock) PurgeTrash(ctx context.Context, retention time.Duration, limit int) ([]PurgedImage, error) {
+	if mock.PurgeTrashFunc == nil {
+		panic("ServiceMock.PurgeTrashFunc: method is nil but Service.PurgeTrash was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		Retention time.Duration
+		Limit     int
+	}{
+		Ctx:       ctx,
+		Retention: retention,
+		Limit:     limit,
+	}
+	mock.lockPurgeTrash.Lock()
+	mock.calls.PurgeTrash = append(mock.calls.PurgeTrash, callInfo)
+	mock.lockPurgeTrash.Unlock()
+	return mock.PurgeTrashFunc(ctx, retention, limit)
+}
+
+// PurgeTrashCalls gets all the calls that were made to PurgeTrash.
+// Check the length with:
+//
+//	len(mockedService.PurgeTrashCalls())
+func (mock *ServiceMock) PurgeTrashCalls() []struct {
+	Ctx       context.Context
+	Retention time.Duration
+	Limit     int
+} {
+	var calls []struct {
+		Ctx       context.Context
+		Retention time.Duration
+		Limit     int
+	}
+	mock.lockPurgeTrash.RLock()
+	calls = mock.calls.PurgeTrash
+	mock.lockPurgeTrash.RUnlock()
+	return calls
+}
+
+// RerollImage calls RerollImageFunc.
+func (mock *ServiceMock) RerollImage(ctx context.Context, imageID string, req *RerollImageRequest) (*Image, error) {
+	if mock.RerollImageFunc == nil {
+		panic("ServiceMock.RerollImageFunc: method is nil but Service.RerollImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		Req     *RerollImageRequest
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		Req:     req,
+	}
+	mock.lockRerollImage.Lock()
+	mock.calls.RerollImage = append(mock.calls.RerollImage, callInfo)
+	mock.lockRerollImage.Unlock()
+	return mock.RerollImageFunc(ctx, imageID, req)
+}
+
+// RerollImageCalls gets all the calls that were made to RerollImage.
+// Check the length with:
+//
+//	len(mockedService.RerollImageCalls())
+func (mock *ServiceMock) RerollImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	Req     *RerollImageRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		Req     *RerollImageRequest
+	}
+	mock.lockRerollImage.RLock()
+	calls = mock.calls.RerollImage
+	mock.lockRerollImage.RUnlock()
+	return calls
+}
+
+// RestageImage calls RestageImageFunc.
+func (mock *ServiceMock) RestageImage(ctx context.Context, imageID string, req *RestageImageRequest) (*Image, error) {
+	if mock.RestageImageFunc == nil {
+		panic("ServiceMock.RestageImageFunc: method is nil but Service.RestageImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+		Req     *RestageImageRequest
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+		Req:     req,
+	}
+	mock.lockRestageImage.Lock()
+	mock.calls.RestageImage = append(mock.calls.RestageImage, callInfo)
+	mock.lockRestageImage.Unlock()
+	return mock.RestageImageFunc(ctx, imageID, req)
+}
+
+// RestageImageCalls gets all the calls that were made to RestageImage.
+// Check the length with:
+//
+//	len(mockedService.RestageImageCalls())
+func (mock *ServiceMock) RestageImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+	Req     *RestageImageRequest
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+		Req     *RestageImageRequest
+	}
+	mock.lockRestageImage.RLock()
+	calls = mock.calls.RestageImage
+	mock.lockRestageImage.RUnlock()
+	return calls
+}
+
+// RestoreImage calls RestoreImageFunc.
+func (mock *ServiceMock) RestoreImage(ctx context.Context, imageID string) (*Image, error) {
+	if mock.RestoreImageFunc == nil {
+		panic("ServiceMock.RestoreImageFunc: method is nil but Service.RestoreImage was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ImageID string
+	}{
+		Ctx:     ctx,
+		ImageID: imageID,
+	}
+	mock.lockRestoreImage.Lock()
+	mock.calls.RestoreImage = append(mock.calls.RestoreImage, callInfo)
+	mock.lockRestoreImage.Unlock()
+	return mock.RestoreImageFunc(ctx, imageID)
+}
+
+// RestoreImageCalls gets all the calls that were made to RestoreImage.
+// Check the length with:
+//
+//	len(mockedService.RestoreImageCalls())
+func (mock *ServiceMock) RestoreImageCalls() []struct {
+	Ctx     context.Context
+	ImageID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ImageID string
+	}
+	mock.lockRestoreImage.RLock()
+	calls = mock.calls.RestoreImage
+	mock.lockRestoreImage.RUnlock()
+	return calls
+}
+
+// SearchImages calls SearchImagesFunc.
+func (mock *ServiceMock) SearchImages(ctx context.Context, userID string, query SearchQuery, page pagination.Page) (*ImageSearchResponse, error) {
+	if mock.SearchImagesFunc == nil {
+		panic("ServiceMock.SearchImagesFunc: method is nil but Service.SearchImages was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Query  SearchQuery
+		Page   pagination.Page
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Query:  query,
+		Page:   page,
+	}
+	mock.lockSearchImages.Lock()
+	mock.calls.SearchImages = append(mock.calls.SearchImages, callInfo)
+	mock.lockSearchImages.Unlock()
+	return mock.SearchImagesFunc(ctx, userID, query, page)
+}
+
+// SearchImagesCalls gets all the calls that were made to SearchImages.
+// Check the length with:
+//
+//	len(mockedService.SearchImagesCalls())
+func (mock *ServiceMock) SearchImagesCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Query  SearchQuery
+	Page   pagination.Page
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Query  SearchQuery
+		Page   pagination.Page
+	}
+	mock.lockSearchImages.RLock()
+	calls = mock.calls.SearchImages
+	mock.lockSearchImages.RUnlock()
+	return calls
+}
+
+// SetImageApproval calls SetImageApprovalFunc.
+func (mock *ServiceMock) SetImageApproval(ctx context.Context, imageID string, approved *bool) error {
+	if mock.SetImageApprovalFunc == nil {
+		panic("ServiceMock.SetImageApprovalFunc: method is nil but Service.SetImageApproval was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ImageID  string
+		Approved *bool
+	}{
+		Ctx:      ctx,
+		ImageID:  imageID,
+		Approved: approved,
+	}
+	mock.lockSetImageApproval.Lock()
+	mock.calls.SetImageApproval = append(mock.calls.SetImageApproval, callInfo)
+	mock.lockSetImageApproval.Unlock()
+	return mock.SetImageApprovalFunc(ctx, imageID, approved)
+}
+
+// SetImageApprovalCalls gets all the calls that were made to SetImageApproval.
+// Check the length with:
+//
+//	len(mockedService.SetImageApprovalCalls())
+func (mock *ServiceMock) SetImageApprovalCalls() []struct {
+	Ctx      context.Context
+	ImageID  string
+	Approved *bool
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ImageID  string
+		Approved *bool
+	}
+	mock.lockSetImageApproval.RLock()
+	calls = mock.calls.SetImageApproval
+	mock.lockSetImageApproval.RUnlock()
+	return calls
+}
+
 // UpdateImageStatus calls UpdateImageStatusFunc.
 func (mock *ServiceMock) UpdateImageStatus(ctx context.Context, imageID string, status Status) (*Image, error) {
 	if mock.UpdateImageStatusFunc == nil {
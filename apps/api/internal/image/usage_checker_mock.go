@@ -21,6 +21,12 @@ var _ UsageChecker = &UsageCheckerMock{}
 //			CanCreateImageFunc: func(ctx context.Context, userID string) (bool, error) {
 //				panic("mock out the CanCreateImage method")
 //			},
+//			PlanCodeFunc: func(ctx context.Context, userID string) (string, error) {
+//				panic("mock out the PlanCode method")
+//			},
+//			RemainingImagesFunc: func(ctx context.Context, userID string) (int32, error) {
+//				panic("mock out the RemainingImages method")
+//			},
 //		}
 //
 //		// use mockedUsageChecker in code that requires UsageChecker
@@ -31,6 +37,12 @@ type UsageCheckerMock struct {
 	// CanCreateImageFunc mocks the CanCreateImage method.
 	CanCreateImageFunc func(ctx context.Context, userID string) (bool, error)
 
+	// PlanCodeFunc mocks the PlanCode method.
+	PlanCodeFunc func(ctx context.Context, userID string) (string, error)
+
+	// RemainingImagesFunc mocks the RemainingImages method.
+	RemainingImagesFunc func(ctx context.Context, userID string) (int32, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CanCreateImage holds details about calls to the CanCreateImage method.
@@ -40,8 +52,24 @@ type UsageCheckerMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// PlanCode holds details about calls to the PlanCode method.
+		PlanCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// RemainingImages holds details about calls to the RemainingImages method.
+		RemainingImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+		}
 	}
-	lockCanCreateImage sync.RWMutex
+	lockCanCreateImage  sync.RWMutex
+	lockPlanCode        sync.RWMutex
+	lockRemainingImages sync.RWMutex
 }
 
 // CanCreateImage calls CanCreateImageFunc.
@@ -79,3 +107,75 @@ func (mock *UsageCheckerMock) CanCreateImageCalls() []struct {
 	mock.lockCanCreateImage.RUnlock()
 	return calls
 }
+
+// PlanCode calls PlanCodeFunc.
+func (mock *UsageCheckerMock) PlanCode(ctx context.Context, userID string) (string, error) {
+	if mock.PlanCodeFunc == nil {
+		panic("UsageCheckerMock.PlanCodeFunc: method is nil but UsageChecker.PlanCode was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockPlanCode.Lock()
+	mock.calls.PlanCode = append(mock.calls.PlanCode, callInfo)
+	mock.lockPlanCode.Unlock()
+	return mock.PlanCodeFunc(ctx, userID)
+}
+
+// PlanCodeCalls gets all the calls that were made to PlanCode.
+// Check the length with:
+//
+//	len(mockedUsageChecker.PlanCodeCalls())
+func (mock *UsageCheckerMock) PlanCodeCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockPlanCode.RLock()
+	calls = mock.calls.PlanCode
+	mock.lockPlanCode.RUnlock()
+	return calls
+}
+
+// RemainingImages calls RemainingImagesFunc.
+func (mock *UsageCheckerMock) RemainingImages(ctx context.Context, userID string) (int32, error) {
+	if mock.RemainingImagesFunc == nil {
+		panic("UsageCheckerMock.RemainingImagesFunc: method is nil but UsageChecker.RemainingImages was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockRemainingImages.Lock()
+	mock.calls.RemainingImages = append(mock.calls.RemainingImages, callInfo)
+	mock.lockRemainingImages.Unlock()
+	return mock.RemainingImagesFunc(ctx, userID)
+}
+
+// RemainingImagesCalls gets all the calls that were made to RemainingImages.
+// Check the length with:
+//
+//	len(mockedUsageChecker.RemainingImagesCalls())
+func (mock *UsageCheckerMock) RemainingImagesCalls() []struct {
+	Ctx    context.Context
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+	}
+	mock.lockRemainingImages.RLock()
+	calls = mock.calls.RemainingImages
+	mock.lockRemainingImages.RUnlock()
+	return calls
+}
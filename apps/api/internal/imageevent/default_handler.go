@@ -0,0 +1,54 @@
+package imageevent
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the image history endpoint.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// GetHistory handles GET /api/v1/images/:id/history.
+func (h *DefaultHandler) GetHistory(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid image ID format"})
+	}
+
+	ctx := c.Request().Context()
+	history, err := h.service.History(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, ErrImageNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Image not found"})
+		}
+		h.log.Error(ctx, "failed to get image history", "image_id", imageID, "error", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get image history",
+		})
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,64 @@
+package imageevent
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+const testImageID = "5d1f3c55-3c9e-4b84-9d55-0b4a5fd6b6a1"
+
+func TestDefaultHandler_GetHistory(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success: returns history", id: testImageID, wantStatus: http.StatusOK},
+		{name: "fail: invalid id", id: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: image not found", id: testImageID, svcErr: ErrImageNotFound, wantStatus: http.StatusNotFound},
+		{
+			name: "fail: service error", id: testImageID,
+			svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				HistoryFunc: func(ctx context.Context, imageID string) (*History, error) {
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					return &History{ImageID: imageID, Events: []Event{
+						{ImageID: imageID, Status: "queued", Source: SourceAPI, CreatedAt: time.Now()},
+					}}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/images/"+tc.id+"/history", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+
+			require.NoError(t, h.GetHistory(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"source":"api"`)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package imageevent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// AppendSnapshot copies the image's current state into a new event.
+func (r *DefaultRepository) AppendSnapshot(ctx context.Context, imageID string, source Source) error {
+	query := `
+		INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, processing_time_ms, error)
+		SELECT id, status, $2, prompt, model_used, cost_usd, processing_time_ms, error
+		FROM images
+		WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, query, imageID, string(source))
+	if err != nil {
+		return fmt.Errorf("failed to append image event: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrImageNotFound
+	}
+	return nil
+}
+
+// ListByImage returns the image's events in the order they were recorded.
+func (r *DefaultRepository) ListByImage(ctx context.Context, imageID string) ([]Event, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT true FROM images WHERE id = $1 AND deleted_at IS NULL`, imageID,
+	).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	query := `
+		SELECT id, image_id, status::text, source, prompt, model_used,
+			cost_usd::float8, processing_time_ms, error, created_at
+		FROM image_events
+		WHERE image_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list image events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []Event{}
+	for rows.Next() {
+		var e Event
+		var source string
+		if err := rows.Scan(
+			&e.ID, &e.ImageID, &e.Status, &source, &e.Prompt, &e.ModelUsed,
+			&e.CostUSD, &e.ProcessingTimeMs, &e.Error, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan image event: %w", err)
+		}
+		e.Source = Source(source)
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over image event rows: %w", err)
+	}
+	return events, nil
+}
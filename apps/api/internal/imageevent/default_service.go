@@ -0,0 +1,30 @@
+package imageevent
+
+import "context"
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// Record appends the image's current state to its history.
+func (s *DefaultService) Record(ctx context.Context, imageID string, source Source) error {
+	return s.repo.AppendSnapshot(ctx, imageID, source)
+}
+
+// History returns the image's events, oldest first.
+func (s *DefaultService) History(ctx context.Context, imageID string) (*History, error) {
+	events, err := s.repo.ListByImage(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+	return &History{ImageID: imageID, Events: events}, nil
+}
@@ -0,0 +1,76 @@
+package imageevent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultService_History(t *testing.T) {
+	cases := []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{name: "success: wraps events"},
+		{name: "fail: image not found", repoErr: ErrImageNotFound, wantErr: ErrImageNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				ListByImageFunc: func(ctx context.Context, imageID string) ([]Event, error) {
+					if tc.repoErr != nil {
+						return nil, tc.repoErr
+					}
+					return []Event{
+						{ImageID: imageID, Status: "queued", Source: SourceAPI},
+						{ImageID: imageID, Status: "processing", Source: SourceWorker},
+					}, nil
+				},
+			}
+
+			history, err := NewDefaultService(repo).History(context.Background(), testImageID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testImageID, history.ImageID)
+			assert.Len(t, history.Events, 2)
+		})
+	}
+}
+
+func TestRegisterSubscribers(t *testing.T) {
+	bus := events.NewDefaultBus(logging.Default())
+	repo := &RepositoryMock{
+		AppendSnapshotFunc: func(ctx context.Context, imageID string, source Source) error {
+			if imageID == "missing" {
+				return ErrImageNotFound
+			}
+			return nil
+		},
+	}
+	RegisterSubscribers(bus, NewDefaultService(repo))
+
+	require.NoError(t, bus.Publish(context.Background(), events.ImageCreated{
+		ImageID: testImageID, OccurredAt: time.Now(),
+	}))
+	require.NoError(t, bus.Publish(context.Background(), events.ImageReady{ImageID: testImageID}))
+
+	calls := repo.AppendSnapshotCalls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, testImageID, calls[0].ImageID)
+	assert.Equal(t, SourceAPI, calls[0].Source)
+
+	err := bus.Publish(context.Background(), events.ImageCreated{ImageID: "missing"})
+	assert.True(t, errors.Is(err, ErrImageNotFound))
+}
@@ -0,0 +1,10 @@
+package imageevent
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP handlers for image history.
+type Handler interface {
+	GetHistory(c echo.Context) error
+}
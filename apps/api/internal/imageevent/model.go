@@ -0,0 +1,46 @@
+// Package imageevent records the staging history of each image.
+//
+// Every status transition appends an event holding the image's status, prompt,
+// model, cost and processing time at that moment, so the history shows how an
+// image got to its current state even after later re-runs overwrite the row.
+package imageevent
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrImageNotFound is returned when the image does not exist or was deleted.
+var ErrImageNotFound = errors.New("image not found")
+
+// Source identifies who recorded an event.
+type Source string
+
+const (
+	// SourceAPI marks events recorded by the API, e.g. when an image is created.
+	SourceAPI Source = "api"
+	// SourceWorker marks events recorded by the worker while staging.
+	SourceWorker Source = "worker"
+	// SourceReconcile marks corrections made by storage reconciliation.
+	SourceReconcile Source = "reconcile"
+)
+
+// Event is one entry in an image's history.
+type Event struct {
+	ID               string    `json:"id"`
+	ImageID          string    `json:"image_id"`
+	Status           string    `json:"status"`
+	Source           Source    `json:"source"`
+	Prompt           *string   `json:"prompt,omitempty"`
+	ModelUsed        *string   `json:"model_used,omitempty"`
+	CostUSD          *float64  `json:"cost_usd,omitempty"`
+	ProcessingTimeMs *int      `json:"processing_time_ms,omitempty"`
+	Error            *string   `json:"error,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// History is an image's events, oldest first.
+type History struct {
+	ImageID string  `json:"image_id"`
+	Events  []Event `json:"events"`
+}
@@ -0,0 +1,16 @@
+package imageevent
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for image events.
+type Repository interface {
+	// AppendSnapshot records the image's current status, prompt, model, cost and
+	// processing time as a new event. Returns ErrImageNotFound if the image is missing.
+	AppendSnapshot(ctx context.Context, imageID string, source Source) error
+
+	// ListByImage returns an image's events, oldest first. Returns
+	// ErrImageNotFound if the image is missing or deleted.
+	ListByImage(ctx context.Context, imageID string) ([]Event, error)
+}
@@ -0,0 +1,14 @@
+package imageevent
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for image history.
+type Service interface {
+	// Record appends the image's current state to its history.
+	Record(ctx context.Context, imageID string, source Source) error
+
+	// History returns the image's events, oldest first.
+	History(ctx context.Context, imageID string) (*History, error)
+}
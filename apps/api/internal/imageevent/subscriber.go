@@ -0,0 +1,15 @@
+package imageevent
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/events"
+)
+
+// RegisterSubscribers starts an image's history when it is created. Later
+// transitions are appended by the worker in the same statement that applies them.
+func RegisterSubscribers(bus events.Bus, svc Service) {
+	events.On(bus, "image_history", func(ctx context.Context, e events.ImageCreated) error {
+		return svc.Record(ctx, e.ImageID, SourceAPI)
+	})
+}
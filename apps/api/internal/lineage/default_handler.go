@@ -0,0 +1,54 @@
+package lineage
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the image lineage endpoint.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// GetLineage handles GET /api/v1/images/:id/lineage.
+func (h *DefaultHandler) GetLineage(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid image ID format"})
+	}
+
+	ctx := c.Request().Context()
+	graph, err := h.service.Lineage(ctx, imageID)
+	if err != nil {
+		if errors.Is(err, ErrImageNotFound) {
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Image not found"})
+		}
+		h.log.Error(ctx, "failed to get image lineage", "image_id", imageID, "error", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to get image lineage",
+		})
+	}
+
+	return c.JSON(http.StatusOK, graph)
+}
@@ -0,0 +1,68 @@
+package lineage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_GetLineage(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success: returns graph", id: testImageID, wantStatus: http.StatusOK},
+		{name: "fail: invalid id", id: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: image not found", id: testImageID, svcErr: ErrImageNotFound, wantStatus: http.StatusNotFound},
+		{
+			name: "fail: service error", id: testImageID,
+			svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				LineageFunc: func(ctx context.Context, imageID string) (*Graph, error) {
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					return &Graph{
+						ImageID: imageID, RootID: testOriginalID,
+						Nodes: []Node{
+							{ID: testOriginalID, Type: NodeOriginal},
+							{ID: imageID, Type: NodeImage, Status: "ready"},
+						},
+						Edges:            []Edge{{From: testOriginalID, To: imageID, Relation: RelationStaged}},
+						RemovedWithImage: []string{},
+					}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/images/"+tc.id+"/lineage", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+
+			require.NoError(t, h.GetLineage(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"relation":"staged"`)
+				assert.Contains(t, rec.Body.String(), `"removed_with_image":[]`)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package lineage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// GetImage returns a live image.
+func (r *DefaultRepository) GetImage(ctx context.Context, imageID string) (*Image, error) {
+	query := `
+		SELECT id::text, project_id::text, original_image_id::text, status::text, created_at, deleted_at
+		FROM images
+		WHERE id = $1 AND deleted_at IS NULL`
+	var img Image
+	err := r.db.QueryRow(ctx, query, imageID).Scan(
+		&img.ID, &img.ProjectID, &img.OriginalImageID, &img.Status, &img.CreatedAt, &img.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	return &img, nil
+}
+
+// GetOriginal returns an uploaded original.
+func (r *DefaultRepository) GetOriginal(ctx context.Context, originalID string) (*Original, error) {
+	var o Original
+	err := r.db.QueryRow(ctx,
+		`SELECT id::text, created_at FROM original_images WHERE id = $1`, originalID,
+	).Scan(&o.ID, &o.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get original image: %w", err)
+	}
+	return &o, nil
+}
+
+// ListVariants returns the project's images staged from the original.
+func (r *DefaultRepository) ListVariants(ctx context.Context, originalID, projectID string) ([]Image, error) {
+	query := `
+		SELECT id::text, project_id::text, original_image_id::text, status::text, created_at, deleted_at
+		FROM images
+		WHERE original_image_id = $1 AND project_id = $2
+		ORDER BY created_at, id`
+	rows, err := r.db.Query(ctx, query, originalID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+	defer rows.Close()
+
+	images := []Image{}
+	for rows.Next() {
+		var img Image
+		if err := rows.Scan(
+			&img.ID, &img.ProjectID, &img.OriginalImageID, &img.Status, &img.CreatedAt, &img.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan variant: %w", err)
+		}
+		images = append(images, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over variant rows: %w", err)
+	}
+	return images, nil
+}
+
+// ListAssets returns the assets derived from the images.
+func (r *DefaultRepository) ListAssets(ctx context.Context, imageIDs []string) ([]Asset, error) {
+	query := `
+		SELECT id::text, image_id::text, kind, created_at
+		FROM image_assets
+		WHERE image_id = ANY($1::uuid[])
+		ORDER BY created_at, id`
+	rows, err := r.db.Query(ctx, query, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []Asset{}
+	for rows.Next() {
+		var a Asset
+		if err := rows.Scan(&a.ID, &a.ImageID, &a.Kind, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan asset: %w", err)
+		}
+		assets = append(assets, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over asset rows: %w", err)
+	}
+	return assets, nil
+}
+
+// CountOriginalReferences counts the images that use the original.
+func (r *DefaultRepository) CountOriginalReferences(ctx context.Context, originalID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx,
+		`SELECT count(*) FROM images WHERE original_image_id = $1`, originalID,
+	).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count original references: %w", err)
+	}
+	return n, nil
+}
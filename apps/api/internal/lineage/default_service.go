@@ -0,0 +1,83 @@
+package lineage
+
+import "context"
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// Lineage builds the graph from the image's original, every variant staged
+// from that original in the same project (trashed ones included, since they
+// can still be restored) and the assets derived from those variants. Variants
+// in other projects share the original but are left out of the graph; they
+// only keep the original from being listed in RemovedWithImage.
+func (s *DefaultService) Lineage(ctx context.Context, imageID string) (*Graph, error) {
+	img, err := s.repo.GetImage(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &Graph{
+		ImageID:          img.ID,
+		RootID:           img.ID,
+		Nodes:            []Node{},
+		Edges:            []Edge{},
+		RemovedWithImage: []string{},
+	}
+
+	variants := []Image{*img}
+	originalRemoved := false
+	if img.OriginalImageID != nil {
+		original, err := s.repo.GetOriginal(ctx, *img.OriginalImageID)
+		if err != nil {
+			return nil, err
+		}
+		if variants, err = s.repo.ListVariants(ctx, original.ID, img.ProjectID); err != nil {
+			return nil, err
+		}
+		refs, err := s.repo.CountOriginalReferences(ctx, original.ID)
+		if err != nil {
+			return nil, err
+		}
+		originalRemoved = refs <= 1
+		graph.RootID = original.ID
+		graph.Nodes = append(graph.Nodes, Node{ID: original.ID, Type: NodeOriginal, CreatedAt: original.CreatedAt})
+	}
+
+	imageIDs := make([]string, len(variants))
+	for i, v := range variants {
+		imageIDs[i] = v.ID
+		graph.Nodes = append(graph.Nodes, Node{
+			ID: v.ID, Type: NodeImage, Status: v.Status, CreatedAt: v.CreatedAt, DeletedAt: v.DeletedAt,
+		})
+		if img.OriginalImageID != nil {
+			graph.Edges = append(graph.Edges, Edge{From: *img.OriginalImageID, To: v.ID, Relation: RelationStaged})
+		}
+	}
+
+	assets, err := s.repo.ListAssets(ctx, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assets {
+		graph.Nodes = append(graph.Nodes, Node{ID: a.ID, Type: NodeAsset, Kind: a.Kind, CreatedAt: a.CreatedAt})
+		graph.Edges = append(graph.Edges, Edge{From: a.ImageID, To: a.ID, Relation: RelationDerived})
+		if a.ImageID == img.ID {
+			graph.RemovedWithImage = append(graph.RemovedWithImage, a.ID)
+		}
+	}
+	if originalRemoved {
+		graph.RemovedWithImage = append(graph.RemovedWithImage, graph.RootID)
+	}
+
+	return graph, nil
+}
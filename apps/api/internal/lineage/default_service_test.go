@@ -0,0 +1,111 @@
+package lineage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testImageID    = "5d1f3c55-3c9e-4b84-9d55-0b4a5fd6b6a1"
+	testVariantID  = "9b2e6a10-7c4d-4f3e-8a1b-2c3d4e5f6a7b"
+	testOriginalID = "0e8f7d6c-5b4a-4392-8170-6f5e4d3c2b1a"
+	testProjectID  = "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+)
+
+func TestDefaultService_Lineage(t *testing.T) {
+	now := time.Now()
+	originalID := testOriginalID
+	deleted := now.Add(-time.Hour)
+
+	repoWith := func(img *Image, refs int) *RepositoryMock {
+		return &RepositoryMock{
+			GetImageFunc: func(ctx context.Context, imageID string) (*Image, error) {
+				if img == nil {
+					return nil, ErrImageNotFound
+				}
+				return img, nil
+			},
+			GetOriginalFunc: func(ctx context.Context, id string) (*Original, error) {
+				return &Original{ID: id, CreatedAt: now}, nil
+			},
+			ListVariantsFunc: func(ctx context.Context, origID, projectID string) ([]Image, error) {
+				assert.Equal(t, testProjectID, projectID)
+				return []Image{
+					*img,
+					{ID: testVariantID, ProjectID: testProjectID, OriginalImageID: &originalID, Status: "ready",
+						DeletedAt: &deleted},
+				}, nil
+			},
+			ListAssetsFunc: func(ctx context.Context, imageIDs []string) ([]Asset, error) {
+				var assets []Asset
+				for _, id := range imageIDs {
+					assets = append(assets, Asset{ID: "asset-of-" + id, ImageID: id, Kind: "cutout"})
+				}
+				return assets, nil
+			},
+			CountOriginalReferencesFunc: func(ctx context.Context, id string) (int, error) {
+				return refs, nil
+			},
+		}
+	}
+	staged := &Image{ID: testImageID, ProjectID: testProjectID, OriginalImageID: &originalID, Status: "ready"}
+
+	t.Run("success: original, variants and assets", func(t *testing.T) {
+		graph, err := NewDefaultService(repoWith(staged, 3)).Lineage(context.Background(), testImageID)
+		require.NoError(t, err)
+
+		assert.Equal(t, testOriginalID, graph.RootID)
+		assert.Equal(t, []NodeType{NodeOriginal, NodeImage, NodeImage, NodeAsset, NodeAsset}, nodeTypes(graph))
+		assert.Equal(t, &deleted, graph.Nodes[2].DeletedAt, "trashed variants stay in the graph")
+		assert.Equal(t, []Edge{
+			{From: testOriginalID, To: testImageID, Relation: RelationStaged},
+			{From: testOriginalID, To: testVariantID, Relation: RelationStaged},
+			{From: testImageID, To: "asset-of-" + testImageID, Relation: RelationDerived},
+			{From: testVariantID, To: "asset-of-" + testVariantID, Relation: RelationDerived},
+		}, graph.Edges)
+		assert.Equal(t, []string{"asset-of-" + testImageID}, graph.RemovedWithImage,
+			"the original is shared with other images")
+	})
+
+	t.Run("success: last reference takes the original with it", func(t *testing.T) {
+		graph, err := NewDefaultService(repoWith(staged, 1)).Lineage(context.Background(), testImageID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"asset-of-" + testImageID, testOriginalID}, graph.RemovedWithImage)
+	})
+
+	t.Run("success: legacy image without a tracked original", func(t *testing.T) {
+		legacy := &Image{ID: testImageID, ProjectID: testProjectID, Status: "ready"}
+		graph, err := NewDefaultService(repoWith(legacy, 0)).Lineage(context.Background(), testImageID)
+		require.NoError(t, err)
+		assert.Equal(t, testImageID, graph.RootID)
+		assert.Equal(t, []NodeType{NodeImage, NodeAsset}, nodeTypes(graph))
+		assert.Equal(t, []Edge{{From: testImageID, To: "asset-of-" + testImageID, Relation: RelationDerived}}, graph.Edges)
+	})
+
+	t.Run("fail: image not found", func(t *testing.T) {
+		_, err := NewDefaultService(repoWith(nil, 0)).Lineage(context.Background(), testImageID)
+		assert.ErrorIs(t, err, ErrImageNotFound)
+	})
+
+	t.Run("fail: asset lookup error", func(t *testing.T) {
+		repo := repoWith(staged, 1)
+		repo.ListAssetsFunc = func(ctx context.Context, imageIDs []string) ([]Asset, error) {
+			return nil, errors.New("db down")
+		}
+		_, err := NewDefaultService(repo).Lineage(context.Background(), testImageID)
+		assert.EqualError(t, err, "db down")
+	})
+}
+
+func nodeTypes(g *Graph) []NodeType {
+	types := make([]NodeType, len(g.Nodes))
+	for i, n := range g.Nodes {
+		types[i] = n.Type
+	}
+	return types
+}
@@ -0,0 +1,10 @@
+package lineage
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP handlers for image lineage.
+type Handler interface {
+	GetLineage(c echo.Context) error
+}
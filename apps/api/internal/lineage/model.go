@@ -0,0 +1,93 @@
+// Package lineage describes how an image's files derive from one another as a
+// graph: the uploaded original, the staged variants made from it and the
+// assets (such as cut-outs) derived from each variant.
+//
+// The graph also lists what purging an image takes with it, so clients can
+// warn before a deletion removes downstream assets.
+package lineage
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrImageNotFound is returned when the image does not exist or was deleted.
+var ErrImageNotFound = errors.New("image not found")
+
+// NodeType is what a lineage node stands for.
+type NodeType string
+
+const (
+	// NodeOriginal is an uploaded original, shared by every variant staged from it.
+	NodeOriginal NodeType = "original"
+	// NodeImage is a staged variant.
+	NodeImage NodeType = "image"
+	// NodeAsset is a file derived from a staged variant; Kind says which.
+	NodeAsset NodeType = "asset"
+)
+
+// Relation labels an edge by how its target was derived from its source.
+type Relation string
+
+const (
+	// RelationStaged links an original to a variant staged from it.
+	RelationStaged Relation = "staged"
+	// RelationDerived links a variant to an asset made from it.
+	RelationDerived Relation = "derived"
+)
+
+// Node is one file in the graph.
+type Node struct {
+	ID   string   `json:"id"`
+	Type NodeType `json:"type"`
+	// Kind is the asset kind, e.g. "cutout"; empty for other nodes.
+	Kind string `json:"kind,omitempty"`
+	// Status is the staging status of an image node.
+	Status    string     `json:"status,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Edge points from a node to one derived from it.
+type Edge struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Relation Relation `json:"relation"`
+}
+
+// Graph is the lineage of the requested image.
+type Graph struct {
+	ImageID string `json:"image_id"`
+	// RootID is the original's node, or the image itself for images uploaded
+	// before originals were tracked.
+	RootID string `json:"root_id"`
+	Nodes  []Node `json:"nodes"`
+	Edges  []Edge `json:"edges"`
+	// RemovedWithImage are the nodes purging the image also removes: its
+	// assets, and its original when no other image uses it.
+	RemovedWithImage []string `json:"removed_with_image"`
+}
+
+// Image is a staged variant as stored.
+type Image struct {
+	ID              string
+	ProjectID       string
+	OriginalImageID *string
+	Status          string
+	CreatedAt       time.Time
+	DeletedAt       *time.Time
+}
+
+// Original is an uploaded original as stored.
+type Original struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+// Asset is a derived asset as stored.
+type Asset struct {
+	ID        string
+	ImageID   string
+	Kind      string
+	CreatedAt time.Time
+}
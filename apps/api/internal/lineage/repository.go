@@ -0,0 +1,25 @@
+package lineage
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for image lineage.
+type Repository interface {
+	// GetImage returns an image. Returns ErrImageNotFound if it is missing or deleted.
+	GetImage(ctx context.Context, imageID string) (*Image, error)
+
+	// GetOriginal returns an uploaded original.
+	GetOriginal(ctx context.Context, originalID string) (*Original, error)
+
+	// ListVariants returns the project's images staged from the original,
+	// including ones in the trash, oldest first.
+	ListVariants(ctx context.Context, originalID, projectID string) ([]Image, error)
+
+	// ListAssets returns the assets derived from the images, oldest first.
+	ListAssets(ctx context.Context, imageIDs []string) ([]Asset, error)
+
+	// CountOriginalReferences counts the images in any project, trashed ones
+	// included, that use the original.
+	CountOriginalReferences(ctx context.Context, originalID string) (int, error)
+}
@@ -0,0 +1,12 @@
+package lineage
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for image lineage.
+type Service interface {
+	// Lineage returns the graph of files related to the image within its
+	// project. Returns ErrImageNotFound if the image is missing or deleted.
+	Lineage(ctx context.Context, imageID string) (*Graph, error)
+}
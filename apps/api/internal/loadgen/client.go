@@ -0,0 +1,230 @@
+package loadgen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// opError is a failed call, labelled with a short cause for the report.
+type opError struct {
+	cause string
+	err   error
+}
+
+func (e *opError) Error() string { return e.cause + ": " + e.err.Error() }
+
+func (e *opError) Unwrap() error { return e.err }
+
+// causeOf labels err for the report: the HTTP status, "timeout" or
+// "transport".
+func causeOf(err error) string {
+	var oe *opError
+	if errors.As(err, &oe) {
+		return oe.cause
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return "transport"
+}
+
+// client calls the API as a given user.
+type client struct {
+	base string
+	http *http.Client
+}
+
+// authorize adds the user's credentials to req.
+func authorize(req *http.Request, u User) {
+	if u.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+u.Token)
+	}
+	if u.TestUser != "" {
+		req.Header.Set("X-Test-User", u.TestUser)
+	}
+}
+
+// call sends a JSON request to path and decodes a 2xx JSON response into out,
+// when out is non-nil.
+func (c *client) call(ctx context.Context, u User, method, path string, body, out any) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	authorize(req, u)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &opError{
+			cause: fmt.Sprintf("http_%d", resp.StatusCode),
+			err:   fmt.Errorf("%s %s: %s", method, path, strings.TrimSpace(string(msg))),
+		}
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return &opError{cause: "decode", err: err}
+	}
+	return nil
+}
+
+type projectResponse struct {
+	ID string `json:"id"`
+}
+
+func (c *client) createProject(ctx context.Context, u User, name string) (string, error) {
+	var p projectResponse
+	if err := c.call(ctx, u, http.MethodPost, "/api/v1/projects", map[string]string{"name": name}, &p); err != nil {
+		return "", err
+	}
+	return p.ID, nil
+}
+
+type presignResponse struct {
+	UploadURL string `json:"upload_url"`
+}
+
+func (c *client) presign(ctx context.Context, u User, projectID, filename string, size int) (string, error) {
+	req := map[string]any{
+		"filename":     filename,
+		"content_type": "image/jpeg",
+		"file_size":    size,
+		"project_id":   projectID,
+	}
+	var p presignResponse
+	if err := c.call(ctx, u, http.MethodPost, "/api/v1/uploads/presign", req, &p); err != nil {
+		return "", err
+	}
+	return p.UploadURL, nil
+}
+
+// upload PUTs data to a presigned URL, as the browser does. It returns the
+// object's URL without the signature, which is what images are created with.
+func (c *client) upload(ctx context.Context, uploadURL string, data []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "image/jpeg")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", &opError{cause: fmt.Sprintf("http_%d", resp.StatusCode), err: errors.New("PUT upload")}
+	}
+
+	u, err := url.Parse(uploadURL)
+	if err != nil {
+		return "", err
+	}
+	return u.Scheme + "://" + u.Host + u.Path, nil
+}
+
+type imageResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (c *client) createImage(ctx context.Context, u User, projectID, originalURL string) (*imageResponse, error) {
+	req := map[string]string{"project_id": projectID, "original_url": originalURL}
+	var img imageResponse
+	if err := c.call(ctx, u, http.MethodPost, "/api/v1/images", req, &img); err != nil {
+		return nil, err
+	}
+	return &img, nil
+}
+
+func (c *client) getImage(ctx context.Context, u User, imageID string) (*imageResponse, error) {
+	var img imageResponse
+	if err := c.call(ctx, u, http.MethodGet, "/api/v1/images/"+url.PathEscape(imageID), nil, &img); err != nil {
+		return nil, err
+	}
+	return &img, nil
+}
+
+// stream is an open SSE connection.
+type stream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// openStream opens the image's event stream. It returns once the response
+// headers arrive.
+func (c *client) openStream(ctx context.Context, u User, imageID string) (*stream, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		c.base+"/api/v1/events?image_id="+url.QueryEscape(imageID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	authorize(req, u)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, &opError{cause: fmt.Sprintf("http_%d", resp.StatusCode), err: errors.New("GET events")}
+	}
+	return &stream{body: resp.Body, scanner: bufio.NewScanner(resp.Body)}, nil
+}
+
+// nextStatus reads events until a job_update carries a status, and returns it.
+func (s *stream) nextStatus() (string, error) {
+	event := ""
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:") && event == "job_update":
+			var data struct {
+				Status string `json:"status"`
+			}
+			if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &data); err != nil {
+				return "", &opError{cause: "decode", err: err}
+			}
+			if data.Status != "" {
+				return data.Status, nil
+			}
+		case line == "":
+			event = ""
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", &opError{cause: "stream_closed", err: io.ErrUnexpectedEOF}
+}
+
+func (s *stream) Close() error { return s.body.Close() }
@@ -0,0 +1,208 @@
+// Package loadgen drives synthetic traffic against a running API the way the
+// web app does (presign, upload to storage, create, then poll or stream until
+// the image is ready) and reports latency and errors per operation, for
+// capacity planning ahead of busy launch weeks.
+//
+// Traffic is open-loop: iterations start at the configured rate whether or
+// not earlier ones have finished, up to a cap on in-flight iterations, so a
+// slow target shows up as rising latency and dropped iterations rather than
+// as a quietly lower request rate.
+package loadgen
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scenario is one kind of user iteration.
+type Scenario string
+
+const (
+	// ScenarioUpload uploads a photo and stages it: presign, PUT to storage,
+	// create the image, then watch it until it is ready or fails.
+	ScenarioUpload Scenario = "upload"
+	// ScenarioBrowse lists the user's projects and the images in one.
+	ScenarioBrowse Scenario = "browse"
+	// ScenarioPoll fetches an image the run created earlier, as a gallery
+	// refreshing a card does. It browses instead until one exists.
+	ScenarioPoll Scenario = "poll"
+)
+
+// Watch is how upload iterations wait for staging to finish.
+type Watch string
+
+const (
+	// WatchPoll polls GET /images/{id}.
+	WatchPoll Watch = "poll"
+	// WatchSSE holds a GET /events?image_id= stream open.
+	WatchSSE Watch = "sse"
+)
+
+// Mix weights the scenarios iterations are drawn from.
+type Mix map[Scenario]int
+
+// DefaultMix is mostly browsing with occasional uploads, like the web app.
+var DefaultMix = Mix{ScenarioUpload: 1, ScenarioBrowse: 7, ScenarioPoll: 2}
+
+// ParseMix parses a mix such as "upload=1,browse=7,poll=2". Scenarios left
+// out get no traffic.
+func ParseMix(s string) (Mix, error) {
+	mix := Mix{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, weight, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("mix entry %q: want scenario=weight", part)
+		}
+		sc := Scenario(strings.TrimSpace(name))
+		switch sc {
+		case ScenarioUpload, ScenarioBrowse, ScenarioPoll:
+		default:
+			return nil, fmt.Errorf("mix entry %q: unknown scenario %q", part, sc)
+		}
+		w, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("mix entry %q: weight must be a non-negative integer", part)
+		}
+		mix[sc] = w
+	}
+	if mix.total() == 0 {
+		return nil, errors.New("mix has no scenario with a positive weight")
+	}
+	return mix, nil
+}
+
+func (m Mix) total() int {
+	n := 0
+	for _, w := range m {
+		n += w
+	}
+	return n
+}
+
+// pick returns the scenario the weighted draw r, in [0, total), falls on.
+func (m Mix) pick(r int) Scenario {
+	names := make([]Scenario, 0, len(m))
+	for sc := range m {
+		names = append(names, sc)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	for _, sc := range names {
+		if r < m[sc] {
+			return sc
+		}
+		r -= m[sc]
+	}
+	return names[len(names)-1]
+}
+
+// String formats the mix as ParseMix accepts it.
+func (m Mix) String() string {
+	parts := make([]string, 0, len(m))
+	for sc, w := range m {
+		parts = append(parts, fmt.Sprintf("%s=%d", sc, w))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// User is one synthetic user. Token is sent as a bearer token; TestUser, when
+// set, is sent as X-Test-User to targets that honour it, so one token can
+// stand in for many users locally.
+type User struct {
+	Token    string
+	TestUser string
+}
+
+// Config controls a run.
+type Config struct {
+	// BaseURL is the API root, e.g. http://localhost:8080.
+	BaseURL string
+	Users   []User
+	// RPS is how many iterations start per second.
+	RPS      float64
+	Duration time.Duration
+	// MaxInFlight caps concurrent iterations; starts beyond it are dropped.
+	MaxInFlight int
+	Mix         Mix
+	Watch       Watch
+	// PollInterval spaces GET /images/{id} calls while watching an upload.
+	PollInterval time.Duration
+	// ReadyTimeout bounds how long an upload is watched before it counts as
+	// timed out.
+	ReadyTimeout time.Duration
+	// ImageWidth and ImageHeight size the generated JPEG uploads.
+	ImageWidth  int
+	ImageHeight int
+	// Seed makes the scenario draw repeatable; 0 picks one from the clock.
+	Seed uint64
+}
+
+// Defaults for Config fields left zero.
+const (
+	DefaultRPS          = 5
+	DefaultDuration     = time.Minute
+	DefaultMaxInFlight  = 200
+	DefaultPollInterval = 2 * time.Second
+	DefaultReadyTimeout = 3 * time.Minute
+	DefaultImageWidth   = 1600
+	DefaultImageHeight  = 1200
+	// MaxRPS is well past what one load generator process can sustain.
+	MaxRPS = 10000
+)
+
+// withDefaults fills zero fields and checks the rest.
+func (c Config) withDefaults() (Config, error) {
+	if c.BaseURL == "" {
+		return c, errors.New("base URL is required")
+	}
+	c.BaseURL = strings.TrimRight(c.BaseURL, "/")
+	if len(c.Users) == 0 {
+		return c, errors.New("at least one user is required")
+	}
+	if c.RPS == 0 {
+		c.RPS = DefaultRPS
+	}
+	if c.RPS < 0 || c.RPS > MaxRPS {
+		return c, fmt.Errorf("rps must be between 0 and %d", MaxRPS)
+	}
+	if c.Duration <= 0 {
+		c.Duration = DefaultDuration
+	}
+	if c.MaxInFlight <= 0 {
+		c.MaxInFlight = DefaultMaxInFlight
+	}
+	if c.Mix == nil {
+		c.Mix = DefaultMix
+	}
+	if c.Mix.total() == 0 {
+		return c, errors.New("mix has no scenario with a positive weight")
+	}
+	switch c.Watch {
+	case "":
+		c.Watch = WatchPoll
+	case WatchPoll, WatchSSE:
+	default:
+		return c, fmt.Errorf("unknown watch mode %q", c.Watch)
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+	if c.ReadyTimeout <= 0 {
+		c.ReadyTimeout = DefaultReadyTimeout
+	}
+	if c.ImageWidth <= 0 {
+		c.ImageWidth = DefaultImageWidth
+	}
+	if c.ImageHeight <= 0 {
+		c.ImageHeight = DefaultImageHeight
+	}
+	return c, nil
+}
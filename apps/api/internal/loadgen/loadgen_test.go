@@ -0,0 +1,91 @@
+package loadgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMix(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		expect    Mix
+		expectErr string
+	}{
+		{
+			name:   "success: all scenarios",
+			input:  "upload=1, browse=7,poll=2",
+			expect: Mix{ScenarioUpload: 1, ScenarioBrowse: 7, ScenarioPoll: 2},
+		},
+		{
+			name:   "success: zero weights are kept",
+			input:  "upload=3,browse=0",
+			expect: Mix{ScenarioUpload: 3, ScenarioBrowse: 0},
+		},
+		{name: "fail: missing weight", input: "upload", expectErr: "want scenario=weight"},
+		{name: "fail: unknown scenario", input: "checkout=1", expectErr: `unknown scenario "checkout"`},
+		{name: "fail: negative weight", input: "upload=-1", expectErr: "non-negative integer"},
+		{name: "fail: nothing to run", input: "upload=0", expectErr: "no scenario with a positive weight"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mix, err := ParseMix(tc.input)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, mix)
+		})
+	}
+}
+
+func TestMix_Pick(t *testing.T) {
+	mix := Mix{ScenarioUpload: 1, ScenarioBrowse: 2, ScenarioPoll: 0}
+	got := map[Scenario]int{}
+	for r := 0; r < mix.total(); r++ {
+		got[mix.pick(r)]++
+	}
+	assert.Equal(t, map[Scenario]int{ScenarioBrowse: 2, ScenarioUpload: 1}, got)
+	assert.Equal(t, "browse=2,poll=0,upload=1", mix.String())
+}
+
+func TestConfig_WithDefaults(t *testing.T) {
+	t.Run("success: fills zero fields", func(t *testing.T) {
+		cfg, err := Config{BaseURL: "http://localhost:8080/", Users: []User{{Token: "t"}}}.withDefaults()
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080", cfg.BaseURL)
+		assert.Equal(t, float64(DefaultRPS), cfg.RPS)
+		assert.Equal(t, DefaultMix, cfg.Mix)
+		assert.Equal(t, WatchPoll, cfg.Watch)
+		assert.Equal(t, DefaultReadyTimeout, cfg.ReadyTimeout)
+	})
+
+	testCases := []struct {
+		name      string
+		cfg       Config
+		expectErr string
+	}{
+		{name: "fail: no base URL", cfg: Config{Users: []User{{}}}, expectErr: "base URL is required"},
+		{name: "fail: no users", cfg: Config{BaseURL: "http://x"}, expectErr: "at least one user"},
+		{
+			name:      "fail: rps out of range",
+			cfg:       Config{BaseURL: "http://x", Users: []User{{}}, RPS: MaxRPS + 1},
+			expectErr: "rps must be between",
+		},
+		{
+			name:      "fail: unknown watch mode",
+			cfg:       Config{BaseURL: "http://x", Users: []User{{}}, Watch: "websocket"},
+			expectErr: `unknown watch mode "websocket"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.cfg.withDefaults()
+			assert.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package loadgen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Operation names recorded in reports.
+const (
+	OpPresign      = "presign"
+	OpUpload       = "upload"
+	OpCreateImage  = "create_image"
+	OpGetImage     = "get_image"
+	OpListProjects = "list_projects"
+	OpListImages   = "list_images"
+	OpCreateProj   = "create_project"
+	OpStream       = "stream_connect"
+	// OpTimeToReady spans creating an image to seeing it ready.
+	OpTimeToReady = "time_to_ready"
+)
+
+// OpStats summarises one operation's samples.
+type OpStats struct {
+	Name   string         `json:"name"`
+	Count  int            `json:"count"`
+	Errors int            `json:"errors"`
+	P50    time.Duration  `json:"p50_ns"`
+	P90    time.Duration  `json:"p90_ns"`
+	P99    time.Duration  `json:"p99_ns"`
+	Max    time.Duration  `json:"max_ns"`
+	Causes map[string]int `json:"causes,omitempty"`
+}
+
+// ErrorRate is the fraction of samples that failed.
+func (s OpStats) ErrorRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Count)
+}
+
+// Report is the outcome of a run.
+type Report struct {
+	Elapsed   time.Duration `json:"elapsed_ns"`
+	TargetRPS float64       `json:"target_rps"`
+	// Started counts iterations begun; Dropped counts starts skipped because
+	// MaxInFlight iterations were already running.
+	Started   int              `json:"started"`
+	Dropped   int              `json:"dropped"`
+	Scenarios map[Scenario]int `json:"scenarios"`
+	Ops       []OpStats        `json:"operations"`
+}
+
+// AchievedRPS is the rate iterations actually started at.
+func (r *Report) AchievedRPS() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Started) / r.Elapsed.Seconds()
+}
+
+// WriteText writes the report as an aligned table.
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "elapsed %s, target %.1f it/s, achieved %.1f it/s, started %d, dropped %d\n",
+		r.Elapsed.Round(time.Millisecond), r.TargetRPS, r.AchievedRPS(), r.Started, r.Dropped)
+
+	names := make([]string, 0, len(r.Scenarios))
+	for sc := range r.Scenarios {
+		names = append(names, string(sc))
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		fmt.Fprintf(w, "  %s: %d\n", n, r.Scenarios[Scenario(n)])
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\tcount\terrors\terr%\tp50\tp90\tp99\tmax\t")
+	for _, op := range r.Ops {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n", op.Name, op.Count, op.Errors, 100*op.ErrorRate(),
+			ms(op.P50), ms(op.P90), ms(op.P99), ms(op.Max))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, op := range r.Ops {
+		causes := make([]string, 0, len(op.Causes))
+		for c := range op.Causes {
+			causes = append(causes, c)
+		}
+		sort.Strings(causes)
+		for _, c := range causes {
+			fmt.Fprintf(w, "%s error %s: %d\n", op.Name, c, op.Causes[c])
+		}
+	}
+	return nil
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.0fms", float64(d)/float64(time.Millisecond))
+}
+
+// recorder collects samples from concurrent iterations.
+type recorder struct {
+	mu        sync.Mutex
+	samples   map[string][]time.Duration
+	errors    map[string]map[string]int
+	scenarios map[Scenario]int
+	started   int
+	dropped   int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		samples:   map[string][]time.Duration{},
+		errors:    map[string]map[string]int{},
+		scenarios: map[Scenario]int{},
+	}
+}
+
+// record adds one sample. A non-empty cause marks it failed; failed samples
+// count towards the error rate but not the latency percentiles.
+func (r *recorder) record(op string, d time.Duration, cause string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.samples[op]; !ok {
+		r.samples[op] = nil
+	}
+	if cause == "" {
+		r.samples[op] = append(r.samples[op], d)
+		return
+	}
+	if r.errors[op] == nil {
+		r.errors[op] = map[string]int{}
+	}
+	r.errors[op][cause]++
+}
+
+func (r *recorder) start(sc Scenario) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started++
+	r.scenarios[sc]++
+}
+
+func (r *recorder) drop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dropped++
+}
+
+// report summarises everything recorded so far.
+func (r *recorder) report(elapsed time.Duration, targetRPS float64) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := &Report{
+		Elapsed:   elapsed,
+		TargetRPS: targetRPS,
+		Started:   r.started,
+		Dropped:   r.dropped,
+		Scenarios: make(map[Scenario]int, len(r.scenarios)),
+		Ops:       make([]OpStats, 0, len(r.samples)),
+	}
+	for sc, n := range r.scenarios {
+		rep.Scenarios[sc] = n
+	}
+	for op, ds := range r.samples {
+		sorted := append([]time.Duration(nil), ds...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		s := OpStats{Name: op, Count: len(sorted)}
+		for cause, n := range r.errors[op] {
+			if s.Causes == nil {
+				s.Causes = map[string]int{}
+			}
+			s.Causes[cause] = n
+			s.Errors += n
+			s.Count += n
+		}
+		if len(sorted) > 0 {
+			s.P50 = percentile(sorted, 50)
+			s.P90 = percentile(sorted, 90)
+			s.P99 = percentile(sorted, 99)
+			s.Max = sorted[len(sorted)-1]
+		}
+		rep.Ops = append(rep.Ops, s)
+	}
+	sort.Slice(rep.Ops, func(i, j int) bool { return rep.Ops[i].Name < rep.Ops[j].Name })
+	return rep
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted samples.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
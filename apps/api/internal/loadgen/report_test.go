@@ -0,0 +1,51 @@
+package loadgen
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(samples, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(samples, 99))
+	assert.Equal(t, 7*time.Millisecond, percentile([]time.Duration{7 * time.Millisecond}, 99))
+}
+
+func TestRecorder_Report(t *testing.T) {
+	rec := newRecorder()
+	rec.start(ScenarioUpload)
+	rec.start(ScenarioBrowse)
+	rec.drop()
+	rec.record(OpPresign, 30*time.Millisecond, "")
+	rec.record(OpPresign, 10*time.Millisecond, "")
+	rec.record(OpPresign, 0, "http_503")
+	rec.record(OpTimeToReady, time.Minute, "timeout")
+
+	rep := rec.report(2*time.Second, 5)
+
+	assert.Equal(t, 2, rep.Started)
+	assert.Equal(t, 1, rep.Dropped)
+	assert.InDelta(t, 1.0, rep.AchievedRPS(), 0.001)
+	assert.Equal(t, map[Scenario]int{ScenarioUpload: 1, ScenarioBrowse: 1}, rep.Scenarios)
+	require.Len(t, rep.Ops, 2)
+	assert.Equal(t, OpStats{
+		Name: OpPresign, Count: 3, Errors: 1,
+		P50: 10 * time.Millisecond, P90: 30 * time.Millisecond, P99: 30 * time.Millisecond, Max: 30 * time.Millisecond,
+		Causes: map[string]int{"http_503": 1},
+	}, rep.Ops[0])
+	assert.Equal(t, OpStats{Name: OpTimeToReady, Count: 1, Errors: 1, Causes: map[string]int{"timeout": 1}}, rep.Ops[1])
+
+	var out strings.Builder
+	require.NoError(t, rep.WriteText(&out))
+	assert.Contains(t, out.String(), "started 2, dropped 1")
+	assert.Contains(t, out.String(), "presign error http_503: 1")
+	assert.Contains(t, out.String(), "33.3")
+}
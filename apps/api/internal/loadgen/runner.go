@@ -0,0 +1,325 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxTrackedImages bounds how many created images per user poll iterations
+// choose from.
+const maxTrackedImages = 50
+
+// Runner drives one load test.
+type Runner struct {
+	cfg    Config
+	client *client
+	rec    *recorder
+	photo  []byte
+
+	mu       sync.Mutex
+	projects []string   // per user, indexed like cfg.Users
+	images   [][]string // per user, newest last
+	uploads  int
+}
+
+// NewRunner checks cfg, fills its defaults and prepares the photo uploads
+// send. httpClient may be nil to use a client without an overall timeout;
+// calls are bounded by their context instead.
+func NewRunner(cfg Config, httpClient *http.Client) (*Runner, error) {
+	cfg, err := cfg.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	photo, err := syntheticPhoto(cfg.ImageWidth, cfg.ImageHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{
+		cfg:      cfg,
+		client:   &client{base: cfg.BaseURL, http: httpClient},
+		rec:      newRecorder(),
+		photo:    photo,
+		projects: make([]string, len(cfg.Users)),
+		images:   make([][]string, len(cfg.Users)),
+	}, nil
+}
+
+// Run creates a project for each user, then starts iterations at cfg.RPS
+// until cfg.Duration elapses or ctx is cancelled. It waits for in-flight
+// iterations before reporting; uploads still being watched give up after
+// ReadyTimeout.
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	for i, u := range r.cfg.Users {
+		start := time.Now()
+		id, err := r.client.createProject(ctx, u, fmt.Sprintf("loadgen %s #%d", start.UTC().Format(time.RFC3339), i))
+		r.rec.record(OpCreateProj, time.Since(start), causeIf(err))
+		if err != nil {
+			return nil, fmt.Errorf("create project for user %d: %w", i, err)
+		}
+		r.projects[i] = id
+	}
+
+	seed := r.cfg.Seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	rng := rand.New(rand.NewPCG(seed, seed))
+	total := r.cfg.Mix.total()
+
+	slots := make(chan struct{}, r.cfg.MaxInFlight)
+	var wg sync.WaitGroup
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / r.cfg.RPS))
+	defer ticker.Stop()
+	deadline := time.NewTimer(r.cfg.Duration)
+	defer deadline.Stop()
+
+	begin := time.Now()
+loop:
+	for n := 0; ; n++ {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-deadline.C:
+			break loop
+		case <-ticker.C:
+		}
+
+		sc := r.cfg.Mix.pick(rng.IntN(total))
+		user := n % len(r.cfg.Users)
+		select {
+		case slots <- struct{}{}:
+		default:
+			r.rec.drop()
+			continue
+		}
+		r.rec.start(sc)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			r.iterate(ctx, sc, user)
+		}()
+	}
+	wg.Wait()
+	return r.rec.report(time.Since(begin), r.cfg.RPS), nil
+}
+
+// iterate runs one scenario as the user.
+func (r *Runner) iterate(ctx context.Context, sc Scenario, user int) {
+	switch sc {
+	case ScenarioUpload:
+		r.upload(ctx, user)
+	case ScenarioPoll:
+		if id, ok := r.pickImage(user); ok {
+			r.timed(ctx, OpGetImage, func(ctx context.Context) error {
+				_, err := r.client.getImage(ctx, r.cfg.Users[user], id)
+				return err
+			})
+			return
+		}
+		r.browse(ctx, user)
+	default:
+		r.browse(ctx, user)
+	}
+}
+
+func (r *Runner) browse(ctx context.Context, user int) {
+	u := r.cfg.Users[user]
+	r.timed(ctx, OpListProjects, func(ctx context.Context) error {
+		return r.client.call(ctx, u, http.MethodGet, "/api/v1/projects", nil, nil)
+	})
+	r.timed(ctx, OpListImages, func(ctx context.Context) error {
+		return r.client.call(ctx, u, http.MethodGet, "/api/v1/projects/"+r.projects[user]+"/images", nil, nil)
+	})
+}
+
+// upload walks the web app's upload flow and watches the image until it is
+// ready, failed or ReadyTimeout passes.
+func (r *Runner) upload(ctx context.Context, user int) {
+	u := r.cfg.Users[user]
+	photo, n := r.nextPhoto()
+
+	var uploadURL, originalURL string
+	var img *imageResponse
+	ok := r.timed(ctx, OpPresign, func(ctx context.Context) (err error) {
+		uploadURL, err = r.client.presign(ctx, u, r.projects[user], fmt.Sprintf("loadgen-%d.jpg", n), len(photo))
+		return err
+	}) && r.timed(ctx, OpUpload, func(ctx context.Context) (err error) {
+		originalURL, err = r.client.upload(ctx, uploadURL, photo)
+		return err
+	}) && r.timed(ctx, OpCreateImage, func(ctx context.Context) (err error) {
+		img, err = r.client.createImage(ctx, u, r.projects[user], originalURL)
+		return err
+	})
+	if !ok {
+		return
+	}
+	r.trackImage(user, img.ID)
+
+	created := time.Now()
+	watchCtx, cancel := context.WithTimeout(ctx, r.cfg.ReadyTimeout)
+	defer cancel()
+	var status string
+	var err error
+	if r.cfg.Watch == WatchSSE {
+		status, err = r.watchStream(watchCtx, u, img.ID)
+	} else {
+		status, err = r.watchPoll(watchCtx, u, img.ID)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	switch {
+	case err != nil:
+		r.rec.record(OpTimeToReady, time.Since(created), causeOf(err))
+	case status == "error":
+		r.rec.record(OpTimeToReady, time.Since(created), "staging_error")
+	default:
+		r.rec.record(OpTimeToReady, time.Since(created), "")
+	}
+}
+
+// watchPoll polls the image until its status is ready or error.
+func (r *Runner) watchPoll(ctx context.Context, u User, imageID string) (string, error) {
+	t := time.NewTicker(r.cfg.PollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-t.C:
+		}
+		var img *imageResponse
+		r.timed(ctx, OpGetImage, func(ctx context.Context) (err error) {
+			img, err = r.client.getImage(ctx, u, imageID)
+			return err
+		})
+		if img != nil && (img.Status == "ready" || img.Status == "error" || img.Status == "rejected") {
+			return img.Status, nil
+		}
+	}
+}
+
+// watchStream holds the image's event stream open until a ready or error
+// update arrives. Staging can finish before the stream subscribes, so the
+// image is fetched once after connecting too.
+func (r *Runner) watchStream(ctx context.Context, u User, imageID string) (string, error) {
+	var s *stream
+	var err error
+	if !r.timed(ctx, OpStream, func(ctx context.Context) error {
+		s, err = r.client.openStream(ctx, u, imageID)
+		return err
+	}) {
+		return "", err
+	}
+	defer func() { _ = s.Close() }()
+
+	var img *imageResponse
+	r.timed(ctx, OpGetImage, func(ctx context.Context) (err error) {
+		img, err = r.client.getImage(ctx, u, imageID)
+		return err
+	})
+	if img != nil && (img.Status == "ready" || img.Status == "error" || img.Status == "rejected") {
+		return img.Status, nil
+	}
+	for {
+		status, err := s.nextStatus()
+		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", err
+		}
+		if status == "ready" || status == "error" || status == "rejected" {
+			return status, nil
+		}
+	}
+}
+
+// timed runs call and records its latency under op, unless ctx was cancelled
+// by the caller. It reports whether call succeeded.
+func (r *Runner) timed(ctx context.Context, op string, call func(context.Context) error) bool {
+	start := time.Now()
+	err := call(ctx)
+	if ctx.Err() != nil && err != nil {
+		return false
+	}
+	r.rec.record(op, time.Since(start), causeIf(err))
+	return err == nil
+}
+
+func causeIf(err error) string {
+	if err == nil {
+		return ""
+	}
+	return causeOf(err)
+}
+
+// nextPhoto returns the photo for the next upload, made unique with a JPEG
+// comment so storage and original de-duplication treat each as a new file.
+func (r *Runner) nextPhoto() ([]byte, int) {
+	r.mu.Lock()
+	r.uploads++
+	n := r.uploads
+	r.mu.Unlock()
+
+	comment := []byte(fmt.Sprintf("loadgen %d %d", time.Now().UnixNano(), n))
+	out := make([]byte, 0, len(r.photo)+4+len(comment))
+	out = append(out, r.photo[:2]...) // SOI
+	out = append(out, 0xFF, 0xFE, byte((len(comment)+2)>>8), byte(len(comment)+2))
+	out = append(out, comment...)
+	out = append(out, r.photo[2:]...)
+	return out, n
+}
+
+func (r *Runner) trackImage(user int, id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := append(r.images[user], id)
+	if len(ids) > maxTrackedImages {
+		ids = ids[len(ids)-maxTrackedImages:]
+	}
+	r.images[user] = ids
+}
+
+func (r *Runner) pickImage(user int) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids := r.images[user]
+	if len(ids) == 0 {
+		return "", false
+	}
+	return ids[rand.IntN(len(ids))], true
+}
+
+// syntheticPhoto encodes a w×h JPEG with smooth gradients, which compresses
+// about as well as a room photo.
+func syntheticPhoto(w, h int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{
+				R: uint8(255 * x / w),
+				G: uint8(255 * y / h),
+				B: uint8((x ^ y) & 0x3F),
+				A: 255,
+			})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("encode synthetic photo: %w", err)
+	}
+	return buf.Bytes(), nil
+}
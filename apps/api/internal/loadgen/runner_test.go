@@ -0,0 +1,203 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI serves the endpoints loadgen calls. Images report processing on
+// the first GET and ready after that; the event stream sends ready at once.
+type fakeAPI struct {
+	t *testing.T
+
+	mu      sync.Mutex
+	images  int
+	gets    map[string]int
+	uploads [][]byte
+	users   map[string]bool
+	srv     *httptest.Server
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	f := &fakeAPI{t: t, gets: map[string]int{}, users: map[string]bool{}}
+	f.srv = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		assert.Equal(f.t, "Bearer tok", r.Header.Get("Authorization"))
+		f.users[r.Header.Get("X-Test-User")] = true
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/projects":
+		_, _ = fmt.Fprint(w, `{"id":"proj-1"}`)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/uploads/presign":
+		var req map[string]any
+		assert.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(f.t, "proj-1", req["project_id"])
+		_, _ = fmt.Fprintf(w, `{"upload_url":"%s/bucket/%s?X-Amz-Signature=abc"}`, f.srv.URL, req["filename"])
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/bucket/"):
+		body, _ := io.ReadAll(r.Body)
+		f.uploads = append(f.uploads, body)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v1/images":
+		var req map[string]string
+		assert.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+		assert.NotContains(f.t, req["original_url"], "Signature", "images are created with the unsigned URL")
+		f.images++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id":"img-%d","status":"queued"}`, f.images)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/images/"):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/images/")
+		f.gets[id]++
+		status := "processing"
+		if f.gets[id] > 1 {
+			status = "ready"
+		}
+		_, _ = fmt.Fprintf(w, `{"id":"%s","status":"%s"}`, id, status)
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/events":
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "event: connected\ndata: {\"message\":\"Connected\"}\n\n")
+		_, _ = fmt.Fprint(w, "event: job_update\ndata: {\"status\":\"processing\"}\n\n")
+		_, _ = fmt.Fprint(w, "event: job_update\ndata: {\"status\":\"ready\"}\n\n")
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/projects":
+		_, _ = fmt.Fprint(w, `{"projects":[]}`)
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/projects/proj-1/images":
+		w.WriteHeader(http.StatusServiceUnavailable)
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func opByName(t *testing.T, rep *Report, name string) OpStats {
+	t.Helper()
+	for _, op := range rep.Ops {
+		if op.Name == name {
+			return op
+		}
+	}
+	t.Fatalf("no %s operation in report", name)
+	return OpStats{}
+}
+
+func TestRunner_Run(t *testing.T) {
+	for _, watch := range []Watch{WatchPoll, WatchSSE} {
+		t.Run("success: uploads watched with "+string(watch), func(t *testing.T) {
+			api := newFakeAPI(t)
+			runner, err := NewRunner(Config{
+				BaseURL:      api.srv.URL,
+				Users:        []User{{Token: "tok", TestUser: "loadgen|0"}, {Token: "tok", TestUser: "loadgen|1"}},
+				RPS:          50,
+				Duration:     100 * time.Millisecond,
+				Mix:          Mix{ScenarioUpload: 1},
+				Watch:        watch,
+				PollInterval: time.Millisecond,
+				ImageWidth:   32,
+				ImageHeight:  24,
+				Seed:         1,
+			}, nil)
+			require.NoError(t, err)
+
+			rep, err := runner.Run(context.Background())
+			require.NoError(t, err)
+
+			require.Positive(t, rep.Started)
+			assert.Equal(t, map[Scenario]int{ScenarioUpload: rep.Started}, rep.Scenarios)
+			for _, name := range []string{OpPresign, OpUpload, OpCreateImage, OpTimeToReady} {
+				op := opByName(t, rep, name)
+				assert.Equal(t, rep.Started, op.Count, name)
+				assert.Zero(t, op.Errors, name)
+			}
+			assert.Equal(t, map[string]bool{"loadgen|0": true, "loadgen|1": true}, api.users)
+
+			require.Len(t, api.uploads, rep.Started)
+			_, err = jpeg.DecodeConfig(bytes.NewReader(api.uploads[0]))
+			require.NoError(t, err, "uploads are valid JPEGs")
+			if len(api.uploads) > 1 {
+				assert.NotEqual(t, api.uploads[0], api.uploads[1], "each upload is a distinct file")
+			}
+		})
+	}
+
+	t.Run("success: browse errors are counted by cause", func(t *testing.T) {
+		api := newFakeAPI(t)
+		runner, err := NewRunner(Config{
+			BaseURL:  api.srv.URL,
+			Users:    []User{{Token: "tok"}},
+			RPS:      50,
+			Duration: 60 * time.Millisecond,
+			Mix:      Mix{ScenarioBrowse: 1, ScenarioPoll: 1},
+			Seed:     1,
+		}, nil)
+		require.NoError(t, err)
+
+		rep, err := runner.Run(context.Background())
+		require.NoError(t, err)
+
+		require.Positive(t, rep.Started)
+		assert.Zero(t, opByName(t, rep, OpListProjects).Errors)
+		images := opByName(t, rep, OpListImages)
+		assert.Equal(t, rep.Started, images.Count, "poll browses until an image exists")
+		assert.Equal(t, map[string]int{"http_503": rep.Started}, images.Causes)
+	})
+
+	t.Run("fail: project creation is rejected", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		runner, err := NewRunner(Config{BaseURL: srv.URL, Users: []User{{Token: "bad"}}}, nil)
+		require.NoError(t, err)
+		_, err = runner.Run(context.Background())
+		assert.ErrorContains(t, err, "create project for user 0: http_401")
+	})
+}
+
+func TestRunner_Run_DropsWhenSaturated(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			_, _ = fmt.Fprint(w, `{"id":"proj-1"}`)
+			return
+		}
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	runner, err := NewRunner(Config{
+		BaseURL:     srv.URL,
+		Users:       []User{{}},
+		RPS:         200,
+		Duration:    50 * time.Millisecond,
+		MaxInFlight: 1,
+		Mix:         Mix{ScenarioBrowse: 1},
+	}, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	rep, err := runner.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rep.Started)
+	assert.Positive(t, rep.Dropped)
+}
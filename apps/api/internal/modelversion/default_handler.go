@@ -0,0 +1,197 @@
+package modelversion
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the admin model version endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ListPins handles GET /admin/models/versions - Lists every pinned model.
+func (h *DefaultHandler) ListPins(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	pins, err := h.service.ListPins(ctx)
+	if err != nil {
+		h.log.Error(ctx, "failed to list model version pins", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to list model versions")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"pins": pins,
+	})
+}
+
+// ListVersions handles GET /admin/models/:id/versions - Shows the model's pin
+// and the newer versions available upstream.
+func (h *DefaultHandler) ListVersions(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+	}
+
+	versions, err := h.service.Versions(ctx, modelID)
+	if err != nil {
+		return h.fail(c, err, "failed to list model versions", modelID)
+	}
+
+	return c.JSON(http.StatusOK, versions)
+}
+
+// PinVersion handles PUT /admin/models/:id/version - Pins the model to a version.
+func (h *DefaultHandler) PinVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+	}
+
+	var req PinRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	pin, err := h.service.Pin(ctx, modelID, req.Version, auth0Sub)
+	if err != nil {
+		return h.fail(c, err, "failed to pin model version", modelID)
+	}
+
+	h.log.Info(ctx, "model version pinned", "model_id", modelID, "version", pin.Version, "auth0_sub", auth0Sub)
+	return c.JSON(http.StatusOK, pin)
+}
+
+// StartCanary handles POST /admin/models/:id/canary - Starts a canary or
+// changes its percentage.
+func (h *DefaultHandler) StartCanary(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+	}
+
+	var req CanaryRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	pin, err := h.service.StartCanary(ctx, modelID, req, auth0Sub)
+	if err != nil {
+		return h.fail(c, err, "failed to start canary", modelID)
+	}
+
+	h.log.Info(ctx, "model version canary set", "model_id", modelID,
+		"version", req.Version, "percent", req.Percent, "auth0_sub", auth0Sub)
+	return c.JSON(http.StatusOK, pin)
+}
+
+// CompareCanary handles GET /admin/models/:id/canary - Compares the canary
+// with the pinned version.
+func (h *DefaultHandler) CompareCanary(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+	}
+
+	comparison, err := h.service.CompareCanary(ctx, modelID)
+	if err != nil {
+		return h.fail(c, err, "failed to compare canary", modelID)
+	}
+
+	return c.JSON(http.StatusOK, comparison)
+}
+
+// PromoteCanary handles POST /admin/models/:id/canary/promote - Rolls the
+// canary forward to the pinned version.
+func (h *DefaultHandler) PromoteCanary(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	pin, err := h.service.Promote(ctx, modelID, auth0Sub)
+	if err != nil {
+		return h.fail(c, err, "failed to promote canary", modelID)
+	}
+
+	h.log.Info(ctx, "model version canary promoted", "model_id", modelID, "version", pin.Version, "auth0_sub", auth0Sub)
+	return c.JSON(http.StatusOK, pin)
+}
+
+// Rollback handles POST /admin/models/:id/rollback - Ends the canary, or
+// restores the previous version if none is running.
+func (h *DefaultHandler) Rollback(c echo.Context) error {
+	ctx := c.Request().Context()
+	modelID, err := url.PathUnescape(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid model ID")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	pin, err := h.service.Rollback(ctx, modelID, auth0Sub)
+	if err != nil {
+		return h.fail(c, err, "failed to roll back model version", modelID)
+	}
+
+	h.log.Info(ctx, "model version rolled back", "model_id", modelID, "version", pin.Version, "auth0_sub", auth0Sub)
+	return c.JSON(http.StatusOK, pin)
+}
+
+// fail maps service errors to HTTP errors, logging the unexpected ones.
+func (h *DefaultHandler) fail(c echo.Context, err error, msg, modelID string) error {
+	switch {
+	case errors.Is(err, ErrInvalidVersion):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrPinNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Model version not pinned")
+	case errors.Is(err, ErrUnknownModel):
+		return echo.NewHTTPError(http.StatusNotFound, "Model not found upstream")
+	case errors.Is(err, ErrNoCanary):
+		return echo.NewHTTPError(http.StatusConflict, "No canary in progress")
+	case errors.Is(err, ErrNothingToRollBack):
+		return echo.NewHTTPError(http.StatusConflict, "Nothing to roll back")
+	case errors.Is(err, ErrUpstreamNotConfigured):
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Replicate API token not configured")
+	}
+	h.log.Error(c.Request().Context(), msg, "error", err, "model_id", modelID)
+	return echo.NewHTTPError(http.StatusInternalServerError, "Model version request failed")
+}
@@ -0,0 +1,147 @@
+package modelversion
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func newContext(method, path, body, id string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c, rec
+}
+
+func TestDefaultHandler_PinVersion(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		pinErr     error
+		wantStatus int
+	}{
+		{name: "success: pins version", body: `{"version":"` + v1 + `"}`, wantStatus: http.StatusOK},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: invalid version",
+			body:       `{"version":"latest"}`,
+			pinErr:     ErrInvalidVersion,
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "fail: unknown model", body: `{}`, pinErr: ErrUnknownModel, wantStatus: http.StatusNotFound},
+		{
+			name:       "fail: upstream not configured",
+			body:       `{}`,
+			pinErr:     ErrUpstreamNotConfigured,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{name: "fail: service error", body: `{}`, pinErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				PinFunc: func(ctx context.Context, id, version, updatedBy string) (*Pin, error) {
+					assert.Equal(t, modelID, id)
+					if tc.pinErr != nil {
+						return nil, tc.pinErr
+					}
+					return &Pin{ModelID: id, Version: version}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+			c, rec := newContext(http.MethodPut, "/", tc.body, "qwen%2Fqwen-image-edit")
+
+			err := h.PinVersion(c)
+
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), v1)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_CompareCanary(t *testing.T) {
+	t.Run("success: returns comparison", func(t *testing.T) {
+		svc := &ServiceMock{
+			CompareCanaryFunc: func(ctx context.Context, id string) (*Comparison, error) {
+				return &Comparison{ModelID: id, CanaryPercent: 10, Canary: Outcome{Version: v2, Ready: 3}}, nil
+			},
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+		c, rec := newContext(http.MethodGet, "/", "", "qwen%2Fqwen-image-edit")
+
+		require.NoError(t, h.CompareCanary(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"canary_percent":10`)
+	})
+
+	t.Run("fail: no canary", func(t *testing.T) {
+		svc := &ServiceMock{
+			CompareCanaryFunc: func(ctx context.Context, id string) (*Comparison, error) { return nil, ErrNoCanary },
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+		c, _ := newContext(http.MethodGet, "/", "", "qwen%2Fqwen-image-edit")
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.CompareCanary(c), &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+	})
+}
+
+func TestDefaultHandler_Rollback(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success: rolls back", wantStatus: http.StatusOK},
+		{name: "fail: nothing to roll back", err: ErrNothingToRollBack, wantStatus: http.StatusConflict},
+		{name: "fail: not pinned", err: ErrPinNotFound, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RollbackFunc: func(ctx context.Context, id, updatedBy string) (*Pin, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Pin{ModelID: id, Version: v1}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+			c, rec := newContext(http.MethodPost, "/", "", "qwen%2Fqwen-image-edit")
+
+			err := h.Rollback(c)
+
+			if tc.err == nil {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package modelversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const pinColumns = `
+	model_id, version, canary_version, canary_percent, canary_started_at,
+	previous_version, updated_at, updated_by`
+
+func scanPin(row pgx.Row) (*Pin, error) {
+	var p Pin
+	err := row.Scan(
+		&p.ModelID, &p.Version, &p.CanaryVersion, &p.CanaryPercent, &p.CanaryStartedAt,
+		&p.PreviousVersion, &p.UpdatedAt, &p.UpdatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Get retrieves a model's pin.
+func (r *DefaultRepository) Get(ctx context.Context, modelID string) (*Pin, error) {
+	query := `SELECT` + pinColumns + ` FROM model_version_pins WHERE model_id = $1`
+
+	p, err := scanPin(r.db.QueryRow(ctx, query, modelID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPinNotFound
+		}
+		return nil, fmt.Errorf("failed to get model version pin: %w", err)
+	}
+	return p, nil
+}
+
+// List returns every pin ordered by model ID.
+func (r *DefaultRepository) List(ctx context.Context) ([]Pin, error) {
+	query := `SELECT` + pinColumns + ` FROM model_version_pins ORDER BY model_id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list model version pins: %w", err)
+	}
+	defer rows.Close()
+
+	pins := []Pin{}
+	for rows.Next() {
+		p, err := scanPin(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan model version pin: %w", err)
+		}
+		pins = append(pins, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over model version pin rows: %w", err)
+	}
+	return pins, nil
+}
+
+// Pin upserts the model's version. Re-pinning the current version keeps the
+// previous one so a rollback target isn't lost.
+func (r *DefaultRepository) Pin(ctx context.Context, modelID, version, updatedBy string) (*Pin, error) {
+	query := `
+		INSERT INTO model_version_pins (model_id, version, updated_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (model_id) DO UPDATE SET
+			previous_version = CASE
+				WHEN model_version_pins.version = EXCLUDED.version THEN model_version_pins.previous_version
+				ELSE model_version_pins.version
+			END,
+			version = EXCLUDED.version,
+			canary_version = NULL,
+			canary_percent = 0,
+			canary_started_at = NULL,
+			updated_at = now(),
+			updated_by = EXCLUDED.updated_by
+		RETURNING` + pinColumns
+
+	p, err := scanPin(r.db.QueryRow(ctx, query, modelID, version, updatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pin model version: %w", err)
+	}
+	return p, nil
+}
+
+// SetCanary routes percent of the model's jobs to version.
+func (r *DefaultRepository) SetCanary(
+	ctx context.Context, modelID, version string, percent int, updatedBy string,
+) (*Pin, error) {
+	query := `
+		UPDATE model_version_pins SET
+			canary_started_at = CASE
+				WHEN canary_version IS DISTINCT FROM $2 THEN now()
+				ELSE canary_started_at
+			END,
+			canary_version = $2,
+			canary_percent = $3,
+			updated_at = now(),
+			updated_by = $4
+		WHERE model_id = $1
+		RETURNING` + pinColumns
+
+	p, err := scanPin(r.db.QueryRow(ctx, query, modelID, version, percent, updatedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPinNotFound
+		}
+		return nil, fmt.Errorf("failed to set canary: %w", err)
+	}
+	return p, nil
+}
+
+// Promote makes the canary the pinned version.
+func (r *DefaultRepository) Promote(ctx context.Context, modelID, updatedBy string) (*Pin, error) {
+	query := `
+		UPDATE model_version_pins SET
+			previous_version = version,
+			version = canary_version,
+			canary_version = NULL,
+			canary_percent = 0,
+			canary_started_at = NULL,
+			updated_at = now(),
+			updated_by = $2
+		WHERE model_id = $1 AND canary_version IS NOT NULL
+		RETURNING` + pinColumns
+
+	p, err := scanPin(r.db.QueryRow(ctx, query, modelID, updatedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoCanary
+		}
+		return nil, fmt.Errorf("failed to promote canary: %w", err)
+	}
+	return p, nil
+}
+
+// Rollback ends a running canary, or otherwise restores the previous version.
+func (r *DefaultRepository) Rollback(ctx context.Context, modelID, updatedBy string) (*Pin, error) {
+	query := `
+		UPDATE model_version_pins SET
+			version = CASE WHEN canary_version IS NULL THEN previous_version ELSE version END,
+			previous_version = CASE WHEN canary_version IS NULL THEN NULL ELSE previous_version END,
+			canary_version = NULL,
+			canary_percent = 0,
+			canary_started_at = NULL,
+			updated_at = now(),
+			updated_by = $2
+		WHERE model_id = $1 AND (canary_version IS NOT NULL OR previous_version IS NOT NULL)
+		RETURNING` + pinColumns
+
+	p, err := scanPin(r.db.QueryRow(ctx, query, modelID, updatedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNothingToRollBack
+		}
+		return nil, fmt.Errorf("failed to roll back model version: %w", err)
+	}
+	return p, nil
+}
+
+// Outcomes tallies ready and error events the worker recorded for each
+// version. The worker records the model as "<model_id>:<version>".
+func (r *DefaultRepository) Outcomes(
+	ctx context.Context, modelID string, versions []string, since time.Time,
+) (map[string]Outcome, error) {
+	query := `
+		SELECT split_part(model_used, ':', 2),
+			count(*) FILTER (WHERE status = 'ready'),
+			count(*) FILTER (WHERE status = 'error'),
+			avg(processing_time_ms) FILTER (WHERE status = 'ready')::float8
+		FROM image_events
+		WHERE source = 'worker'
+		  AND status IN ('ready', 'error')
+		  AND model_used IN (SELECT $1 || ':' || v FROM unnest($2::text[]) AS v)
+		  AND created_at >= $3
+		GROUP BY 1`
+
+	rows, err := r.db.Query(ctx, query, modelID, versions, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tally model version outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	outcomes := make(map[string]Outcome, len(versions))
+	for rows.Next() {
+		var o Outcome
+		if err := rows.Scan(&o.Version, &o.Ready, &o.Failed, &o.AvgProcessingMs); err != nil {
+			return nil, fmt.Errorf("failed to scan model version outcome: %w", err)
+		}
+		outcomes[o.Version] = o
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over model version outcome rows: %w", err)
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,145 @@
+package modelversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo     Repository
+	upstream Upstream
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository, upstream Upstream) *DefaultService {
+	return &DefaultService{repo: repo, upstream: upstream}
+}
+
+// ListPins returns every pinned model.
+func (s *DefaultService) ListPins(ctx context.Context) ([]Pin, error) {
+	return s.repo.List(ctx)
+}
+
+// Versions returns the model's pin and the upstream versions published after
+// the pinned one.
+func (s *DefaultService) Versions(ctx context.Context, modelID string) (*Versions, error) {
+	pin, err := s.repo.Get(ctx, modelID)
+	if err != nil && !errors.Is(err, ErrPinNotFound) {
+		return nil, err
+	}
+
+	upstream, err := s.upstream.ListVersions(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Versions{ModelID: modelID, Pin: pin, Newer: upstream}
+	if pin == nil {
+		return out, nil
+	}
+	for i, v := range upstream {
+		if v.ID == pin.Version {
+			out.Newer = upstream[:i]
+			break
+		}
+	}
+	return out, nil
+}
+
+// Pin fixes the model to a version that exists upstream.
+func (s *DefaultService) Pin(ctx context.Context, modelID, version, updatedBy string) (*Pin, error) {
+	if err := s.checkVersion(ctx, modelID, version); err != nil {
+		return nil, err
+	}
+	return s.repo.Pin(ctx, modelID, version, updatedBy)
+}
+
+// StartCanary validates the request and starts or adjusts the canary.
+func (s *DefaultService) StartCanary(
+	ctx context.Context, modelID string, req CanaryRequest, updatedBy string,
+) (*Pin, error) {
+	if req.Percent < 1 || req.Percent > 100 {
+		return nil, fmt.Errorf("%w: percent must be between 1 and 100", ErrInvalidVersion)
+	}
+
+	pin, err := s.repo.Get(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+	if pin.Version == req.Version {
+		return nil, fmt.Errorf("%w: canary must differ from the pinned version", ErrInvalidVersion)
+	}
+	if err := s.checkVersion(ctx, modelID, req.Version); err != nil {
+		return nil, err
+	}
+	return s.repo.SetCanary(ctx, modelID, req.Version, req.Percent, updatedBy)
+}
+
+// CompareCanary tallies both versions' runs since the canary started.
+func (s *DefaultService) CompareCanary(ctx context.Context, modelID string) (*Comparison, error) {
+	pin, err := s.repo.Get(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+	if pin.CanaryVersion == nil || pin.CanaryStartedAt == nil {
+		return nil, ErrNoCanary
+	}
+
+	canary := *pin.CanaryVersion
+	outcomes, err := s.repo.Outcomes(ctx, modelID, []string{pin.Version, canary}, *pin.CanaryStartedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Comparison{
+		ModelID:       modelID,
+		CanaryPercent: pin.CanaryPercent,
+		Since:         *pin.CanaryStartedAt,
+		Pinned:        summarize(pin.Version, outcomes[pin.Version]),
+		Canary:        summarize(canary, outcomes[canary]),
+	}, nil
+}
+
+// Promote rolls the canary forward.
+func (s *DefaultService) Promote(ctx context.Context, modelID, updatedBy string) (*Pin, error) {
+	return s.repo.Promote(ctx, modelID, updatedBy)
+}
+
+// Rollback ends the canary or restores the previous version.
+func (s *DefaultService) Rollback(ctx context.Context, modelID, updatedBy string) (*Pin, error) {
+	return s.repo.Rollback(ctx, modelID, updatedBy)
+}
+
+// checkVersion rejects malformed versions and versions the model doesn't publish.
+func (s *DefaultService) checkVersion(ctx context.Context, modelID, version string) error {
+	if !versionPattern.MatchString(version) {
+		return fmt.Errorf("%w: expected a 64-character hex version ID", ErrInvalidVersion)
+	}
+
+	upstream, err := s.upstream.ListVersions(ctx, modelID)
+	if err != nil {
+		return err
+	}
+	for _, v := range upstream {
+		if v.ID == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not a published version of %s", ErrInvalidVersion, version, modelID)
+}
+
+// summarize fills in the version and success rate of a tally. A version with
+// no finished runs has no rate.
+func summarize(version string, o Outcome) Outcome {
+	o.Version = version
+	if total := o.Ready + o.Failed; total > 0 {
+		rate := float64(o.Ready) / float64(total)
+		o.SuccessRate = &rate
+	}
+	return o
+}
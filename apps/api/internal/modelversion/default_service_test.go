@@ -0,0 +1,210 @@
+package modelversion
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modelID = "qwen/qwen-image-edit"
+
+var (
+	v1 = strings.Repeat("a", 64)
+	v2 = strings.Repeat("b", 64)
+	v3 = strings.Repeat("c", 64)
+)
+
+func upstreamWith(versions ...string) *UpstreamMock {
+	return &UpstreamMock{
+		ListVersionsFunc: func(ctx context.Context, modelID string) ([]Version, error) {
+			out := make([]Version, len(versions))
+			for i, v := range versions {
+				out[i] = Version{ID: v}
+			}
+			return out, nil
+		},
+	}
+}
+
+func TestDefaultService_Versions(t *testing.T) {
+	t.Run("success: lists versions newer than the pin", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetFunc: func(ctx context.Context, id string) (*Pin, error) {
+				return &Pin{ModelID: id, Version: v2}, nil
+			},
+		}
+		svc := NewDefaultService(repo, upstreamWith(v3, v2, v1))
+
+		got, err := svc.Versions(context.Background(), modelID)
+
+		require.NoError(t, err)
+		require.NotNil(t, got.Pin)
+		assert.Equal(t, []Version{{ID: v3}}, got.Newer)
+	})
+
+	t.Run("success: unpinned model lists every version", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetFunc: func(ctx context.Context, id string) (*Pin, error) { return nil, ErrPinNotFound },
+		}
+		svc := NewDefaultService(repo, upstreamWith(v2, v1))
+
+		got, err := svc.Versions(context.Background(), modelID)
+
+		require.NoError(t, err)
+		assert.Nil(t, got.Pin)
+		assert.Len(t, got.Newer, 2)
+	})
+
+	t.Run("fail: upstream error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetFunc: func(ctx context.Context, id string) (*Pin, error) { return nil, ErrPinNotFound },
+		}
+		upstream := &UpstreamMock{
+			ListVersionsFunc: func(ctx context.Context, modelID string) ([]Version, error) {
+				return nil, ErrUpstreamNotConfigured
+			},
+		}
+
+		_, err := NewDefaultService(repo, upstream).Versions(context.Background(), modelID)
+
+		assert.ErrorIs(t, err, ErrUpstreamNotConfigured)
+	})
+}
+
+func TestDefaultService_Pin(t *testing.T) {
+	cases := []struct {
+		name    string
+		version string
+		wantErr error
+	}{
+		{name: "success: pins published version", version: v1},
+		{name: "fail: malformed version", version: "latest", wantErr: ErrInvalidVersion},
+		{name: "fail: unpublished version", version: v3, wantErr: ErrInvalidVersion},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				PinFunc: func(ctx context.Context, id, version, updatedBy string) (*Pin, error) {
+					return &Pin{ModelID: id, Version: version}, nil
+				},
+			}
+			svc := NewDefaultService(repo, upstreamWith(v2, v1))
+
+			_, err := svc.Pin(context.Background(), modelID, tc.version, "auth0|admin")
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, repo.PinCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, repo.PinCalls(), 1)
+			assert.Equal(t, "auth0|admin", repo.PinCalls()[0].UpdatedBy)
+		})
+	}
+}
+
+func TestDefaultService_StartCanary(t *testing.T) {
+	cases := []struct {
+		name    string
+		req     CanaryRequest
+		getErr  error
+		wantErr error
+	}{
+		{name: "success: starts canary", req: CanaryRequest{Version: v2, Percent: 10}},
+		{name: "fail: percent out of range", req: CanaryRequest{Version: v2, Percent: 0}, wantErr: ErrInvalidVersion},
+		{name: "fail: canary is pinned version", req: CanaryRequest{Version: v1, Percent: 10}, wantErr: ErrInvalidVersion},
+		{name: "fail: unpublished version", req: CanaryRequest{Version: v3, Percent: 10}, wantErr: ErrInvalidVersion},
+		{
+			name:    "fail: model not pinned",
+			req:     CanaryRequest{Version: v2, Percent: 10},
+			getErr:  ErrPinNotFound,
+			wantErr: ErrPinNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				GetFunc: func(ctx context.Context, id string) (*Pin, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Pin{ModelID: id, Version: v1}, nil
+				},
+				SetCanaryFunc: func(ctx context.Context, id, version string, percent int, updatedBy string) (*Pin, error) {
+					return &Pin{ModelID: id, Version: v1, CanaryVersion: &version, CanaryPercent: percent}, nil
+				},
+			}
+			svc := NewDefaultService(repo, upstreamWith(v2, v1))
+
+			_, err := svc.StartCanary(context.Background(), modelID, tc.req, "auth0|admin")
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, repo.SetCanaryCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, repo.SetCanaryCalls(), 1)
+			assert.Equal(t, 10, repo.SetCanaryCalls()[0].Percent)
+		})
+	}
+}
+
+func TestDefaultService_CompareCanary(t *testing.T) {
+	started := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success: compares both versions since the canary started", func(t *testing.T) {
+		canary := v2
+		avg := 4200.0
+		repo := &RepositoryMock{
+			GetFunc: func(ctx context.Context, id string) (*Pin, error) {
+				return &Pin{ModelID: id, Version: v1, CanaryVersion: &canary, CanaryPercent: 25, CanaryStartedAt: &started}, nil
+			},
+			OutcomesFunc: func(
+				ctx context.Context, id string, versions []string, since time.Time,
+			) (map[string]Outcome, error) {
+				assert.Equal(t, []string{v1, v2}, versions)
+				assert.Equal(t, started, since)
+				return map[string]Outcome{v1: {Version: v1, Ready: 9, Failed: 1, AvgProcessingMs: &avg}}, nil
+			},
+		}
+
+		got, err := NewDefaultService(repo, upstreamWith()).CompareCanary(context.Background(), modelID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 25, got.CanaryPercent)
+		require.NotNil(t, got.Pinned.SuccessRate)
+		assert.InDelta(t, 0.9, *got.Pinned.SuccessRate, 1e-9)
+		assert.Equal(t, v2, got.Canary.Version)
+		assert.Nil(t, got.Canary.SuccessRate)
+	})
+
+	t.Run("fail: no canary running", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetFunc: func(ctx context.Context, id string) (*Pin, error) { return &Pin{ModelID: id, Version: v1}, nil },
+		}
+
+		_, err := NewDefaultService(repo, upstreamWith()).CompareCanary(context.Background(), modelID)
+
+		assert.ErrorIs(t, err, ErrNoCanary)
+		assert.Empty(t, repo.OutcomesCalls())
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetFunc: func(ctx context.Context, id string) (*Pin, error) { return nil, errors.New("db down") },
+		}
+
+		_, err := NewDefaultService(repo, upstreamWith()).CompareCanary(context.Background(), modelID)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,16 @@
+package modelversion
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin endpoints for model version pinning.
+type Handler interface {
+	ListPins(c echo.Context) error
+	ListVersions(c echo.Context) error
+	PinVersion(c echo.Context) error
+	StartCanary(c echo.Context) error
+	CompareCanary(c echo.Context) error
+	PromoteCanary(c echo.Context) error
+	Rollback(c echo.Context) error
+}
@@ -0,0 +1,88 @@
+// Package modelversion pins AI models to exact Replicate versions.
+//
+// Without a pin the worker stages with whatever version a model's name resolves
+// to upstream, so an upstream release can change results overnight. A pin fixes
+// the version; upgrades go through a canary that serves a share of jobs until
+// an admin compares its results with the pinned version and promotes or rolls
+// it back.
+package modelversion
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	// ErrPinNotFound is returned when a model has no pinned version.
+	ErrPinNotFound = errors.New("model version not pinned")
+	// ErrInvalidVersion is returned for malformed or unknown versions and canary percentages.
+	ErrInvalidVersion = errors.New("invalid model version")
+	// ErrNoCanary is returned when a canary action needs a canary that isn't running.
+	ErrNoCanary = errors.New("no canary in progress")
+	// ErrNothingToRollBack is returned when there is neither a canary nor a previous version.
+	ErrNothingToRollBack = errors.New("nothing to roll back")
+	// ErrUnknownModel is returned when the model does not exist upstream.
+	ErrUnknownModel = errors.New("unknown model")
+	// ErrUpstreamNotConfigured is returned when no Replicate API token is set.
+	ErrUpstreamNotConfigured = errors.New("replicate api token not configured")
+)
+
+// versionPattern matches a Replicate version ID.
+var versionPattern = regexp.MustCompile(`^[a-f0-9]{64}$`)
+
+// Pin is a model's pinned version and any canary rollout in progress.
+type Pin struct {
+	ModelID         string     `json:"model_id"`
+	Version         string     `json:"version"`
+	CanaryVersion   *string    `json:"canary_version,omitempty"`
+	CanaryPercent   int        `json:"canary_percent"`
+	CanaryStartedAt *time.Time `json:"canary_started_at,omitempty"`
+	PreviousVersion *string    `json:"previous_version,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	UpdatedBy       *string    `json:"updated_by,omitempty"`
+}
+
+// Version is a model version published upstream.
+type Version struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Versions is a model's pin with the upstream versions published after it,
+// newest first. Newer lists every upstream version when the model isn't pinned.
+type Versions struct {
+	ModelID string    `json:"model_id"`
+	Pin     *Pin      `json:"pin,omitempty"`
+	Newer   []Version `json:"newer"`
+}
+
+// Outcome summarizes the staging runs one version finished.
+type Outcome struct {
+	Version         string   `json:"version"`
+	Ready           int      `json:"ready"`
+	Failed          int      `json:"failed"`
+	SuccessRate     *float64 `json:"success_rate,omitempty"`
+	AvgProcessingMs *float64 `json:"avg_processing_ms,omitempty"`
+}
+
+// Comparison sets a canary's results against the pinned version's over the
+// same period, since the canary started.
+type Comparison struct {
+	ModelID       string    `json:"model_id"`
+	CanaryPercent int       `json:"canary_percent"`
+	Since         time.Time `json:"since"`
+	Pinned        Outcome   `json:"pinned"`
+	Canary        Outcome   `json:"canary"`
+}
+
+// PinRequest pins a model to a version.
+type PinRequest struct {
+	Version string `json:"version"`
+}
+
+// CanaryRequest starts or adjusts a canary.
+type CanaryRequest struct {
+	Version string `json:"version"`
+	Percent int    `json:"percent"`
+}
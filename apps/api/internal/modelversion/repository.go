@@ -0,0 +1,36 @@
+package modelversion
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for model version pins.
+type Repository interface {
+	// Get retrieves a model's pin. Returns ErrPinNotFound if the model isn't pinned.
+	Get(ctx context.Context, modelID string) (*Pin, error)
+
+	// List returns every pin ordered by model ID.
+	List(ctx context.Context) ([]Pin, error)
+
+	// Pin sets a model's version, keeping the replaced one as the previous
+	// version and ending any canary.
+	Pin(ctx context.Context, modelID, version, updatedBy string) (*Pin, error)
+
+	// SetCanary routes percent of a pinned model's jobs to version. The start
+	// time is kept when only the percentage changes. Returns ErrPinNotFound if
+	// the model isn't pinned.
+	SetCanary(ctx context.Context, modelID, version string, percent int, updatedBy string) (*Pin, error)
+
+	// Promote makes the canary the pinned version. Returns ErrNoCanary if none is running.
+	Promote(ctx context.Context, modelID, updatedBy string) (*Pin, error)
+
+	// Rollback ends a running canary, or otherwise restores the previous
+	// version. Returns ErrNothingToRollBack if there is neither.
+	Rollback(ctx context.Context, modelID, updatedBy string) (*Pin, error)
+
+	// Outcomes tallies the worker's finished runs of each model version since the given time.
+	Outcomes(ctx context.Context, modelID string, versions []string, since time.Time) (map[string]Outcome, error)
+}
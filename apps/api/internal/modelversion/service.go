@@ -0,0 +1,31 @@
+package modelversion
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for pinning and upgrading model versions.
+type Service interface {
+	// ListPins returns every pinned model.
+	ListPins(ctx context.Context) ([]Pin, error)
+
+	// Versions returns the model's pin and the upstream versions published after it.
+	Versions(ctx context.Context, modelID string) (*Versions, error)
+
+	// Pin fixes the model to an upstream version, ending any canary.
+	Pin(ctx context.Context, modelID, version, updatedBy string) (*Pin, error)
+
+	// StartCanary sends percent (1-100) of the model's jobs to version. Calling
+	// it again for the running canary only changes the percentage.
+	StartCanary(ctx context.Context, modelID string, req CanaryRequest, updatedBy string) (*Pin, error)
+
+	// CompareCanary reports the canary's results next to the pinned version's.
+	CompareCanary(ctx context.Context, modelID string) (*Comparison, error)
+
+	// Promote rolls forward: the canary becomes the pinned version.
+	Promote(ctx context.Context, modelID, updatedBy string) (*Pin, error)
+
+	// Rollback ends the canary, or if none is running restores the version
+	// replaced by the last promotion.
+	Rollback(ctx context.Context, modelID, updatedBy string) (*Pin, error)
+}
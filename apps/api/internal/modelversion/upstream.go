@@ -0,0 +1,80 @@
+package modelversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out upstream_mock.go . Upstream
+
+// Upstream lists the versions published for a model.
+type Upstream interface {
+	// ListVersions returns the model's versions, newest first. Returns
+	// ErrUnknownModel if the model doesn't exist.
+	ListVersions(ctx context.Context, modelID string) ([]Version, error)
+}
+
+// ReplicateUpstream lists model versions through the Replicate HTTP API.
+type ReplicateUpstream struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// Ensure ReplicateUpstream implements Upstream.
+var _ Upstream = (*ReplicateUpstream)(nil)
+
+// NewReplicateUpstream creates a ReplicateUpstream from the Replicate config.
+func NewReplicateUpstream(cfg config.Replicate) *ReplicateUpstream {
+	return &ReplicateUpstream{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		token:   cfg.APIToken,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ListVersions returns the first page of the model's versions, which Replicate
+// orders newest first. That covers every release an admin would upgrade to.
+func (u *ReplicateUpstream) ListVersions(ctx context.Context, modelID string) ([]Version, error) {
+	if u.token == "" {
+		return nil, ErrUpstreamNotConfigured
+	}
+	owner, name, ok := strings.Cut(modelID, "/")
+	if !ok || owner == "" || name == "" {
+		return nil, ErrUnknownModel
+	}
+
+	url := fmt.Sprintf("%s/models/%s/%s/versions", u.baseURL, owner, name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build versions request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+u.token)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list model versions: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrUnknownModel
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to list model versions: replicate returned %d", resp.StatusCode)
+	}
+
+	var page struct {
+		Results []Version `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode model versions: %w", err)
+	}
+	return page.Results, nil
+}
@@ -0,0 +1,55 @@
+package modelversion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestReplicateUpstream_ListVersions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer r8_test", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/models/qwen/qwen-image-edit/versions":
+			_, _ = w.Write([]byte(`{"results":[
+				{"id":"` + v2 + `","created_at":"2026-09-01T00:00:00Z"},
+				{"id":"` + v1 + `","created_at":"2026-08-01T00:00:00Z"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	u := NewReplicateUpstream(config.Replicate{APIToken: "r8_test", BaseURL: srv.URL + "/"})
+
+	t.Run("success: lists versions newest first", func(t *testing.T) {
+		versions, err := u.ListVersions(context.Background(), modelID)
+
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		assert.Equal(t, v2, versions[0].ID)
+		assert.Equal(t, 2026, versions[0].CreatedAt.Year())
+	})
+
+	t.Run("fail: unknown model", func(t *testing.T) {
+		_, err := u.ListVersions(context.Background(), "acme/missing")
+		assert.ErrorIs(t, err, ErrUnknownModel)
+	})
+
+	t.Run("fail: model id without owner", func(t *testing.T) {
+		_, err := u.ListVersions(context.Background(), "qwen-image-edit")
+		assert.ErrorIs(t, err, ErrUnknownModel)
+	})
+
+	t.Run("fail: no token", func(t *testing.T) {
+		_, err := NewReplicateUpstream(config.Replicate{BaseURL: srv.URL}).ListVersions(context.Background(), modelID)
+		assert.ErrorIs(t, err, ErrUpstreamNotConfigured)
+	})
+}
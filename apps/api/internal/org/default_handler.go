@@ -0,0 +1,335 @@
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the organization API.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// paramLabels names path parameters in "Invalid ... ID format" messages.
+var paramLabels = map[string]string{
+	"id":            "organization",
+	"user_id":       "user",
+	"invitation_id": "invitation",
+	"project_id":    "project",
+}
+
+// CreateOrg handles POST /api/v1/orgs.
+func (h *DefaultHandler) CreateOrg(c echo.Context) error {
+	userID, _, errResp := h.request(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	var req CreateOrgRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	o, err := h.service.CreateOrg(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to create organization")
+	}
+	return c.JSON(http.StatusCreated, o)
+}
+
+// ListOrgs handles GET /api/v1/orgs.
+func (h *DefaultHandler) ListOrgs(c echo.Context) error {
+	userID, _, errResp := h.request(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	orgs, err := h.service.ListOrgs(c.Request().Context(), userID)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list organizations")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"organizations": orgs})
+}
+
+// GetOrg handles GET /api/v1/orgs/:id.
+func (h *DefaultHandler) GetOrg(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	o, err := h.service.GetOrg(c.Request().Context(), userID, ids[0])
+	if err != nil {
+		return h.serviceError(c, err, "Failed to get organization")
+	}
+	return c.JSON(http.StatusOK, o)
+}
+
+// UpdateOrg handles PATCH /api/v1/orgs/:id.
+func (h *DefaultHandler) UpdateOrg(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	var req UpdateOrgRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	o, err := h.service.UpdateOrg(c.Request().Context(), userID, ids[0], req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to update organization")
+	}
+	return c.JSON(http.StatusOK, o)
+}
+
+// DeleteOrg handles DELETE /api/v1/orgs/:id.
+func (h *DefaultHandler) DeleteOrg(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	if err := h.service.DeleteOrg(c.Request().Context(), userID, ids[0]); err != nil {
+		return h.serviceError(c, err, "Failed to delete organization")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListMembers handles GET /api/v1/orgs/:id/members.
+func (h *DefaultHandler) ListMembers(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	members, err := h.service.ListMembers(c.Request().Context(), userID, ids[0])
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list members")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"members": members})
+}
+
+// UpdateMember handles PATCH /api/v1/orgs/:id/members/:user_id.
+func (h *DefaultHandler) UpdateMember(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id", "user_id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	var req UpdateMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	m, err := h.service.UpdateMember(c.Request().Context(), userID, ids[0], ids[1], req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to update member")
+	}
+	return c.JSON(http.StatusOK, m)
+}
+
+// RemoveMember handles DELETE /api/v1/orgs/:id/members/:user_id.
+func (h *DefaultHandler) RemoveMember(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id", "user_id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	if err := h.service.RemoveMember(c.Request().Context(), userID, ids[0], ids[1]); err != nil {
+		return h.serviceError(c, err, "Failed to remove member")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateInvitation handles POST /api/v1/orgs/:id/invitations.
+// The response is the only time the invitation token is returned.
+func (h *DefaultHandler) CreateInvitation(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	var req CreateInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	inv, err := h.service.CreateInvitation(c.Request().Context(), userID, ids[0], req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to create invitation")
+	}
+	return c.JSON(http.StatusCreated, inv)
+}
+
+// ListInvitations handles GET /api/v1/orgs/:id/invitations.
+func (h *DefaultHandler) ListInvitations(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	invitations, err := h.service.ListInvitations(c.Request().Context(), userID, ids[0])
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list invitations")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"invitations": invitations})
+}
+
+// RevokeInvitation handles DELETE /api/v1/orgs/:id/invitations/:invitation_id.
+func (h *DefaultHandler) RevokeInvitation(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id", "invitation_id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	if err := h.service.RevokeInvitation(c.Request().Context(), userID, ids[0], ids[1]); err != nil {
+		return h.serviceError(c, err, "Failed to revoke invitation")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AcceptInvitation handles POST /api/v1/invitations/accept.
+// The token travels in the body so it never lands in access logs.
+func (h *DefaultHandler) AcceptInvitation(c echo.Context) error {
+	userID, _, errResp := h.request(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	var req AcceptInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	m, err := h.service.AcceptInvitation(c.Request().Context(), userID, req.Token)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to accept invitation")
+	}
+	return c.JSON(http.StatusOK, m)
+}
+
+// ListProjects handles GET /api/v1/orgs/:id/projects.
+func (h *DefaultHandler) ListProjects(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	projects, err := h.service.ListProjects(c.Request().Context(), userID, ids[0])
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list organization projects")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"projects": projects})
+}
+
+// ShareProject handles PUT /api/v1/orgs/:id/projects/:project_id.
+func (h *DefaultHandler) ShareProject(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id", "project_id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	p, err := h.service.ShareProject(c.Request().Context(), userID, ids[0], ids[1])
+	if err != nil {
+		return h.serviceError(c, err, "Failed to share project")
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+// UnshareProject handles DELETE /api/v1/orgs/:id/projects/:project_id.
+func (h *DefaultHandler) UnshareProject(c echo.Context) error {
+	userID, ids, errResp := h.request(c, "id", "project_id")
+	if errResp != nil {
+		return errResp()
+	}
+
+	p, err := h.service.UnshareProject(c.Request().Context(), userID, ids[0], ids[1])
+	if err != nil {
+		return h.serviceError(c, err, "Failed to unshare project")
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+// request validates the named UUID path parameters and resolves the caller's user ID.
+func (h *DefaultHandler) request(c echo.Context, params ...string) (string, []string, func() error) {
+	ids := make([]string, len(params))
+	for i, name := range params {
+		ids[i] = c.Param(name)
+		if _, err := uuid.Parse(ids[i]); err != nil {
+			message := "Invalid " + paramLabels[name] + " ID format"
+			return "", nil, func() error {
+				return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: message})
+			}
+		}
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", nil, func() error {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Invalid or missing JWT token",
+			})
+		}
+	}
+
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return "", nil, func() error {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "User not found"})
+		}
+	}
+	return userRow.ID.String(), ids, nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, ErrOrgNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Organization not found"})
+	case errors.Is(err, ErrMemberNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Member not found"})
+	case errors.Is(err, ErrInvitationNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "not_found",
+			Message: "Invitation not found, expired or already used",
+		})
+	case errors.Is(err, ErrProjectNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Project not found"})
+	case errors.Is(err, ErrForbidden):
+		return c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "forbidden",
+			Message: "Your organization role does not allow this action",
+		})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	case errors.Is(err, ErrLastOwner), errors.Is(err, ErrBillingMember), errors.Is(err, ErrInvitationExists):
+		return c.JSON(http.StatusConflict, ErrorResponse{Error: "conflict", Message: err.Error()})
+	}
+	h.log.Error(c.Request().Context(), "organization request failed", "error", err)
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal_server_error", Message: message})
+}
@@ -0,0 +1,156 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/echotest"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newTestHandler(svc Service) *DefaultHandler {
+	userID := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	return NewDefaultHandler(svc, userRepo, logging.Default())
+}
+
+func TestDefaultHandler_UpdateMember(t *testing.T) {
+	orgID := uuid.NewString()
+	memberID := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		orgID        string
+		memberID     string
+		body         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{
+			name: "success: role updated", orgID: orgID, memberID: memberID, body: `{"role":"admin"}`,
+			expectedCode: http.StatusOK,
+		},
+		{name: "fail: invalid org id", orgID: "nope", memberID: memberID, expectedCode: http.StatusBadRequest},
+		{name: "fail: invalid user id", orgID: orgID, memberID: "nope", expectedCode: http.StatusBadRequest},
+		{name: "fail: malformed body", orgID: orgID, memberID: memberID, body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name: "fail: not a member", orgID: orgID, memberID: memberID, body: `{"role":"admin"}`,
+			serviceErr: ErrOrgNotFound, expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "fail: role too low", orgID: orgID, memberID: memberID, body: `{"role":"admin"}`,
+			serviceErr: ErrForbidden, expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "fail: unknown role", orgID: orgID, memberID: memberID, body: `{"role":"boss"}`,
+			serviceErr: ErrInvalidRequest, expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "fail: last owner", orgID: orgID, memberID: memberID, body: `{"role":"member"}`,
+			serviceErr: ErrLastOwner, expectedCode: http.StatusConflict,
+		},
+		{
+			name: "fail: database error", orgID: orgID, memberID: memberID, body: `{"role":"admin"}`,
+			serviceErr: errors.New("boom"), expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				UpdateMemberFunc: func(
+					ctx context.Context, userID, orgID, memberID string, req UpdateMemberRequest,
+				) (*Member, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Member{OrgID: orgID, UserID: memberID, Role: req.Role}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodPatch, tc.body, []string{"id", "user_id"}, []string{tc.orgID, tc.memberID})
+
+			require.NoError(t, newTestHandler(svc).UpdateMember(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectedCode == http.StatusBadRequest {
+				assert.Empty(t, svc.UpdateMemberCalls())
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_CreateInvitation(t *testing.T) {
+	orgID := uuid.NewString()
+
+	t.Run("success: returns the token once", func(t *testing.T) {
+		svc := &ServiceMock{
+			CreateInvitationFunc: func(
+				ctx context.Context, userID, orgID string, req CreateInvitationRequest,
+			) (*Invitation, error) {
+				return &Invitation{ID: "inv-1", OrgID: orgID, Email: req.Email, Role: RoleMember, Token: "inv_abc"}, nil
+			},
+		}
+		c, rec := echotest.NewContext(http.MethodPost, `{"email":"agent@example.com"}`, []string{"id"}, []string{orgID})
+
+		require.NoError(t, newTestHandler(svc).CreateInvitation(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"token":"inv_abc"`)
+		assert.NotContains(t, rec.Body.String(), "token_hash")
+	})
+
+	t.Run("fail: pending invitation", func(t *testing.T) {
+		svc := &ServiceMock{
+			CreateInvitationFunc: func(
+				ctx context.Context, userID, orgID string, req CreateInvitationRequest,
+			) (*Invitation, error) {
+				return nil, ErrInvitationExists
+			},
+		}
+		c, rec := echotest.NewContext(http.MethodPost, `{"email":"agent@example.com"}`, []string{"id"}, []string{orgID})
+
+		require.NoError(t, newTestHandler(svc).CreateInvitation(c))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestDefaultHandler_AcceptInvitation(t *testing.T) {
+	cases := []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+	}{
+		{name: "success: joined", expectedCode: http.StatusOK},
+		{name: "fail: expired or used", serviceErr: ErrInvitationNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				AcceptInvitationFunc: func(ctx context.Context, userID, token string) (*Member, error) {
+					if tc.serviceErr != nil {
+						return nil, tc.serviceErr
+					}
+					return &Member{UserID: userID, Role: RoleMember}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodPost, `{"token":"inv_abc"}`, nil, nil)
+
+			require.NoError(t, newTestHandler(svc).AcceptInvitation(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			require.Len(t, svc.AcceptInvitationCalls(), 1)
+			assert.Equal(t, "inv_abc", svc.AcceptInvitationCalls()[0].Token)
+		})
+	}
+}
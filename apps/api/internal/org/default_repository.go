@@ -0,0 +1,345 @@
+package org
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const orgColumns = `
+	o.id, o.name, o.billing_user_id, m.role, o.created_at, o.updated_at`
+
+func scanOrg(row pgx.Row) (*Organization, error) {
+	var o Organization
+	if err := row.Scan(&o.ID, &o.Name, &o.BillingUserID, &o.Role, &o.CreatedAt, &o.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+const memberColumns = `
+	m.org_id, m.user_id, u.email, u.full_name, m.role, m.created_at`
+
+func scanMember(row pgx.Row) (*Member, error) {
+	var m Member
+	if err := row.Scan(&m.OrgID, &m.UserID, &m.Email, &m.FullName, &m.Role, &m.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+const invitationColumns = `
+	id, org_id, email, role, token_hash, invited_by, expires_at, created_at`
+
+func scanInvitation(row pgx.Row) (*Invitation, error) {
+	var inv Invitation
+	err := row.Scan(
+		&inv.ID, &inv.OrgID, &inv.Email, &inv.Role, &inv.TokenHash, &inv.InvitedBy, &inv.ExpiresAt, &inv.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// Create inserts an organization with ownerID as its first owner and billing user.
+func (r *DefaultRepository) Create(ctx context.Context, name, ownerID string) (*Organization, error) {
+	query := `
+		WITH o AS (
+			INSERT INTO organizations (name, billing_user_id)
+			VALUES ($1, $2)
+			RETURNING id, name, billing_user_id, created_at, updated_at
+		), m AS (
+			INSERT INTO organization_members (org_id, user_id, role)
+			SELECT id, $2, 'owner' FROM o
+			RETURNING role
+		)
+		SELECT` + orgColumns + `
+		FROM o, m`
+
+	created, err := scanOrg(r.db.QueryRow(ctx, query, name, ownerID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+	return created, nil
+}
+
+// GetForMember retrieves an organization with the member's role.
+func (r *DefaultRepository) GetForMember(ctx context.Context, id, userID string) (*Organization, error) {
+	query := `SELECT` + orgColumns + `
+		FROM organizations o
+		JOIN organization_members m ON m.org_id = o.id
+		WHERE o.id = $1 AND m.user_id = $2`
+
+	o, err := scanOrg(r.db.QueryRow(ctx, query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrgNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return o, nil
+}
+
+// ListForMember returns the user's organizations, oldest membership first.
+func (r *DefaultRepository) ListForMember(ctx context.Context, userID string) ([]Organization, error) {
+	query := `SELECT` + orgColumns + `
+		FROM organizations o
+		JOIN organization_members m ON m.org_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY m.created_at ASC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := []Organization{}
+	for rows.Next() {
+		o, err := scanOrg(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over organization rows: %w", err)
+	}
+	return orgs, nil
+}
+
+// Update persists the name and billing user. The returned organization has no caller role.
+func (r *DefaultRepository) Update(ctx context.Context, o *Organization) (*Organization, error) {
+	query := `
+		UPDATE organizations
+		SET name = $2, billing_user_id = $3, updated_at = now()
+		WHERE id = $1
+		RETURNING id, name, billing_user_id, created_at, updated_at`
+
+	var updated Organization
+	err := r.db.QueryRow(ctx, query, o.ID, o.Name, o.BillingUserID).Scan(
+		&updated.ID, &updated.Name, &updated.BillingUserID, &updated.CreatedAt, &updated.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrgNotFound
+		}
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+	return &updated, nil
+}
+
+// Delete removes an organization; its projects revert to their creators.
+func (r *DefaultRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM organizations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOrgNotFound
+	}
+	return nil
+}
+
+// GetMember retrieves a single membership.
+func (r *DefaultRepository) GetMember(ctx context.Context, orgID, userID string) (*Member, error) {
+	query := `SELECT` + memberColumns + `
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1 AND m.user_id = $2`
+
+	m, err := scanMember(r.db.QueryRow(ctx, query, orgID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return m, nil
+}
+
+// ListMembers returns an organization's members, owners first.
+func (r *DefaultRepository) ListMembers(ctx context.Context, orgID string) ([]Member, error) {
+	query := `SELECT` + memberColumns + `
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.org_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 ELSE 2 END, m.created_at ASC`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []Member{}
+	for rows.Next() {
+		m, err := scanMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over organization member rows: %w", err)
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes a member's role.
+func (r *DefaultRepository) UpdateMemberRole(ctx context.Context, orgID, userID string, role Role) (*Member, error) {
+	query := `
+		UPDATE organization_members AS m
+		SET role = $3
+		FROM users u
+		WHERE u.id = m.user_id AND m.org_id = $1 AND m.user_id = $2
+		RETURNING` + memberColumns
+
+	m, err := scanMember(r.db.QueryRow(ctx, query, orgID, userID, string(role)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to update organization member: %w", err)
+	}
+	return m, nil
+}
+
+// RemoveMember deletes a membership.
+func (r *DefaultRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	query := `DELETE FROM organization_members WHERE org_id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+// CountOwners returns the number of owners in an organization.
+func (r *DefaultRepository) CountOwners(ctx context.Context, orgID string) (int, error) {
+	query := `SELECT COUNT(*) FROM organization_members WHERE org_id = $1 AND role = 'owner'`
+
+	var count int
+	if err := r.db.QueryRow(ctx, query, orgID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count organization owners: %w", err)
+	}
+	return count, nil
+}
+
+// CreateInvitation inserts an invitation, replacing an expired one for the same email.
+func (r *DefaultRepository) CreateInvitation(ctx context.Context, inv *Invitation) (*Invitation, error) {
+	expired := `
+		DELETE FROM organization_invitations
+		WHERE org_id = $1 AND lower(email) = lower($2) AND accepted_at IS NULL AND expires_at <= now()`
+	if _, err := r.db.Exec(ctx, expired, inv.OrgID, inv.Email); err != nil {
+		return nil, fmt.Errorf("failed to clear expired invitation: %w", err)
+	}
+
+	query := `
+		INSERT INTO organization_invitations (org_id, email, role, token_hash, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING` + invitationColumns
+
+	created, err := scanInvitation(r.db.QueryRow(ctx, query,
+		inv.OrgID, inv.Email, string(inv.Role), inv.TokenHash, inv.InvitedBy, inv.ExpiresAt,
+	))
+	if err != nil {
+		if storage.IsUniqueViolation(err) {
+			return nil, ErrInvitationExists
+		}
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+	return created, nil
+}
+
+// ListInvitations returns an organization's open invitations, newest first.
+func (r *DefaultRepository) ListInvitations(ctx context.Context, orgID string) ([]Invitation, error) {
+	query := `SELECT` + invitationColumns + `
+		FROM organization_invitations
+		WHERE org_id = $1 AND accepted_at IS NULL AND expires_at > now()
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []Invitation{}
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, *inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over invitation rows: %w", err)
+	}
+	return invitations, nil
+}
+
+// DeleteInvitation revokes an open invitation.
+func (r *DefaultRepository) DeleteInvitation(ctx context.Context, orgID, id string) error {
+	query := `DELETE FROM organization_invitations WHERE id = $1 AND org_id = $2 AND accepted_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query, id, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation marks the invitation accepted and adds the user in one statement,
+// so a token can only ever be redeemed once.
+func (r *DefaultRepository) AcceptInvitation(ctx context.Context, tokenHash, userID string) (*Member, error) {
+	query := `
+		WITH inv AS (
+			UPDATE organization_invitations
+			SET accepted_at = now(), accepted_by = $2
+			WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > now()
+			RETURNING org_id, role
+		), m AS (
+			INSERT INTO organization_members AS om (org_id, user_id, role)
+			SELECT org_id, $2, role FROM inv
+			ON CONFLICT (org_id, user_id) DO UPDATE SET role = om.role
+			RETURNING om.org_id, om.user_id, om.role, om.created_at
+		)
+		SELECT` + memberColumns + `
+		FROM m
+		JOIN users u ON u.id = m.user_id`
+
+	m, err := scanMember(r.db.QueryRow(ctx, query, tokenHash, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+	return m, nil
+}
@@ -0,0 +1,360 @@
+package org
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/project"
+)
+
+const (
+	// invitationTTL is how long an invitation token can be redeemed.
+	invitationTTL = 7 * 24 * time.Hour
+	// tokenPrefix marks invitation tokens so they are recognisable when pasted around.
+	tokenPrefix = "inv_"
+	// maxNameLength matches the project name limit.
+	maxNameLength = 100
+)
+
+// DefaultService implements Service on top of the organization tables and the project repository.
+type DefaultService struct {
+	repo     Repository
+	projects project.Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository, projects project.Repository) *DefaultService {
+	return &DefaultService{repo: repo, projects: projects}
+}
+
+// CreateOrg creates an organization with the caller as owner and billing user.
+func (s *DefaultService) CreateOrg(ctx context.Context, userID string, req CreateOrgRequest) (*Organization, error) {
+	name, err := validateName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, name, userID)
+}
+
+// ListOrgs returns the organizations the caller belongs to.
+func (s *DefaultService) ListOrgs(ctx context.Context, userID string) ([]Organization, error) {
+	return s.repo.ListForMember(ctx, userID)
+}
+
+// GetOrg retrieves an organization the caller belongs to.
+func (s *DefaultService) GetOrg(ctx context.Context, userID, orgID string) (*Organization, error) {
+	return s.repo.GetForMember(ctx, orgID, userID)
+}
+
+// UpdateOrg renames an organization or reassigns its billing user.
+// Admins may rename; only owners may move billing, and only to another owner.
+func (s *DefaultService) UpdateOrg(
+	ctx context.Context, userID, orgID string, req UpdateOrgRequest,
+) (*Organization, error) {
+	o, err := s.repo.GetForMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !o.Role.AtLeast(RoleAdmin) {
+		return nil, ErrForbidden
+	}
+
+	if req.Name != nil {
+		if o.Name, err = validateName(*req.Name); err != nil {
+			return nil, err
+		}
+	}
+	if req.BillingUserID != nil {
+		if o.Role != RoleOwner {
+			return nil, ErrForbidden
+		}
+		target, err := s.repo.GetMember(ctx, orgID, *req.BillingUserID)
+		if err != nil && !errors.Is(err, ErrMemberNotFound) {
+			return nil, err
+		}
+		if target == nil || target.Role != RoleOwner {
+			return nil, fmt.Errorf("%w: billing_user_id must be an owner of the organization", ErrInvalidRequest)
+		}
+		o.BillingUserID = req.BillingUserID
+	}
+
+	updated, err := s.repo.Update(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	updated.Role = o.Role
+	return updated, nil
+}
+
+// DeleteOrg deletes an organization. Only owners may delete.
+func (s *DefaultService) DeleteOrg(ctx context.Context, userID, orgID string) error {
+	if _, err := s.requireRole(ctx, orgID, userID, RoleOwner); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, orgID)
+}
+
+// ListMembers returns an organization's members to any member.
+func (s *DefaultService) ListMembers(ctx context.Context, userID, orgID string) ([]Member, error) {
+	if _, err := s.requireRole(ctx, orgID, userID, RoleMember); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(ctx, orgID)
+}
+
+// UpdateMember changes a member's role. Admins manage admins and members;
+// granting or revoking ownership needs an owner, and the last owner and the
+// billing user cannot be demoted.
+func (s *DefaultService) UpdateMember(
+	ctx context.Context, userID, orgID, memberID string, req UpdateMemberRequest,
+) (*Member, error) {
+	if !req.Role.Valid() {
+		return nil, fmt.Errorf("%w: role must be one of owner, admin, member", ErrInvalidRequest)
+	}
+	o, err := s.repo.GetForMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !o.Role.AtLeast(RoleAdmin) {
+		return nil, ErrForbidden
+	}
+	target, err := s.repo.GetMember(ctx, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if (target.Role == RoleOwner || req.Role == RoleOwner) && o.Role != RoleOwner {
+		return nil, ErrForbidden
+	}
+	if target.Role == RoleOwner && req.Role != RoleOwner {
+		if err := s.checkOwnerLeaving(ctx, o, memberID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.UpdateMemberRole(ctx, orgID, memberID, req.Role)
+}
+
+// RemoveMember removes a member. Anyone may leave; removing someone else needs
+// an admin, and removing an owner needs an owner.
+func (s *DefaultService) RemoveMember(ctx context.Context, userID, orgID, memberID string) error {
+	o, err := s.repo.GetForMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if memberID != userID && !o.Role.AtLeast(RoleAdmin) {
+		return ErrForbidden
+	}
+	target, err := s.repo.GetMember(ctx, orgID, memberID)
+	if err != nil {
+		return err
+	}
+	if target.Role == RoleOwner {
+		if memberID != userID && o.Role != RoleOwner {
+			return ErrForbidden
+		}
+		if err := s.checkOwnerLeaving(ctx, o, memberID); err != nil {
+			return err
+		}
+	}
+	return s.repo.RemoveMember(ctx, orgID, memberID)
+}
+
+// CreateInvitation invites an email address as an admin or member. Owners are
+// promoted after they join so ownership is never handed out by link.
+func (s *DefaultService) CreateInvitation(
+	ctx context.Context, userID, orgID string, req CreateInvitationRequest,
+) (*Invitation, error) {
+	if _, err := s.requireRole(ctx, orgID, userID, RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	addr, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, fmt.Errorf("%w: email must be a valid address", ErrInvalidRequest)
+	}
+	role := req.Role
+	if role == "" {
+		role = RoleMember
+	}
+	if role != RoleAdmin && role != RoleMember {
+		return nil, fmt.Errorf("%w: role must be admin or member", ErrInvalidRequest)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	inv, err := s.repo.CreateInvitation(ctx, &Invitation{
+		OrgID:     orgID,
+		Email:     addr.Address,
+		Role:      role,
+		TokenHash: hashToken(token),
+		InvitedBy: &userID,
+		ExpiresAt: time.Now().Add(invitationTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	inv.Token = token
+	return inv, nil
+}
+
+// ListInvitations returns an organization's open invitations.
+func (s *DefaultService) ListInvitations(ctx context.Context, userID, orgID string) ([]Invitation, error) {
+	if _, err := s.requireRole(ctx, orgID, userID, RoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.repo.ListInvitations(ctx, orgID)
+}
+
+// RevokeInvitation cancels an open invitation.
+func (s *DefaultService) RevokeInvitation(ctx context.Context, userID, orgID, invitationID string) error {
+	if _, err := s.requireRole(ctx, orgID, userID, RoleAdmin); err != nil {
+		return err
+	}
+	return s.repo.DeleteInvitation(ctx, orgID, invitationID)
+}
+
+// AcceptInvitation adds the caller to the invitation's organization. The token
+// is the credential; it works once and only until it expires.
+func (s *DefaultService) AcceptInvitation(ctx context.Context, userID, token string) (*Member, error) {
+	token = strings.TrimSpace(token)
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, ErrInvitationNotFound
+	}
+	return s.repo.AcceptInvitation(ctx, hashToken(token), userID)
+}
+
+// ListProjects returns the projects shared with an organization.
+func (s *DefaultService) ListProjects(ctx context.Context, userID, orgID string) ([]project.Project, error) {
+	if _, err := s.requireRole(ctx, orgID, userID, RoleMember); err != nil {
+		return nil, err
+	}
+	return s.projects.GetProjectsByOrgID(ctx, orgID)
+}
+
+// ShareProject shares one of the caller's own projects with the organization.
+// A project belongs to at most one organization; sharing it again moves it.
+func (s *DefaultService) ShareProject(
+	ctx context.Context, userID, orgID, projectID string,
+) (*project.Project, error) {
+	if _, err := s.requireRole(ctx, orgID, userID, RoleMember); err != nil {
+		return nil, err
+	}
+	p, err := s.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if p.UserID != userID {
+		return nil, ErrProjectNotFound
+	}
+	return s.setProjectOrg(ctx, projectID, &orgID)
+}
+
+// UnshareProject returns a shared project to its creator. The creator or an
+// org admin may unshare.
+func (s *DefaultService) UnshareProject(
+	ctx context.Context, userID, orgID, projectID string,
+) (*project.Project, error) {
+	caller, err := s.requireRole(ctx, orgID, userID, RoleMember)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.getProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if p.OrgID == nil || *p.OrgID != orgID {
+		return nil, ErrProjectNotFound
+	}
+	if p.UserID != userID && !caller.Role.AtLeast(RoleAdmin) {
+		return nil, ErrForbidden
+	}
+	return s.setProjectOrg(ctx, projectID, nil)
+}
+
+// requireRole returns the caller's membership if it grants at least required.
+// Non-members get ErrOrgNotFound so the organization's existence is not revealed.
+func (s *DefaultService) requireRole(ctx context.Context, orgID, userID string, required Role) (*Member, error) {
+	m, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, ErrMemberNotFound) {
+			return nil, ErrOrgNotFound
+		}
+		return nil, err
+	}
+	if !m.Role.AtLeast(required) {
+		return nil, ErrForbidden
+	}
+	return m, nil
+}
+
+// checkOwnerLeaving guards demoting or removing an owner.
+func (s *DefaultService) checkOwnerLeaving(ctx context.Context, o *Organization, memberID string) error {
+	if o.BillingUserID != nil && *o.BillingUserID == memberID {
+		return ErrBillingMember
+	}
+	owners, err := s.repo.CountOwners(ctx, o.ID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+func (s *DefaultService) getProject(ctx context.Context, projectID string) (*project.Project, error) {
+	p, err := s.projects.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *DefaultService) setProjectOrg(ctx context.Context, projectID string, orgID *string) (*project.Project, error) {
+	p, err := s.projects.SetProjectOrg(ctx, projectID, orgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProjectNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxNameLength {
+		return "", fmt.Errorf("%w: name must be between 1 and %d characters", ErrInvalidRequest, maxNameLength)
+	}
+	return name, nil
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	return tokenPrefix + hex.EncodeToString(buf), nil
+}
+
+// hashToken is what the database stores and looks invitations up by.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
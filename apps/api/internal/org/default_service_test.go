@@ -0,0 +1,407 @@
+package org
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/project"
+)
+
+const (
+	testOrgID   = "11111111-1111-1111-1111-111111111111"
+	ownerID     = "22222222-2222-2222-2222-222222222222"
+	adminID     = "33333333-3333-3333-3333-333333333333"
+	memberID    = "44444444-4444-4444-4444-444444444444"
+	outsiderID  = "55555555-5555-5555-5555-555555555555"
+	testProject = "66666666-6666-6666-6666-666666666666"
+)
+
+// newRepo returns a repository mock for an org with one owner (the billing
+// user), one admin and one member. owners overrides the owner count.
+func newRepo(owners int) *RepositoryMock {
+	roles := map[string]Role{ownerID: RoleOwner, adminID: RoleAdmin, memberID: RoleMember}
+	billing := ownerID
+	return &RepositoryMock{
+		GetMemberFunc: func(ctx context.Context, orgID, userID string) (*Member, error) {
+			role, ok := roles[userID]
+			if !ok {
+				return nil, ErrMemberNotFound
+			}
+			return &Member{OrgID: orgID, UserID: userID, Role: role}, nil
+		},
+		GetForMemberFunc: func(ctx context.Context, id, userID string) (*Organization, error) {
+			role, ok := roles[userID]
+			if !ok {
+				return nil, ErrOrgNotFound
+			}
+			return &Organization{ID: id, Name: "Acme Realty", BillingUserID: &billing, Role: role}, nil
+		},
+		CountOwnersFunc: func(ctx context.Context, orgID string) (int, error) {
+			return owners, nil
+		},
+		UpdateFunc: func(ctx context.Context, o *Organization) (*Organization, error) {
+			updated := *o
+			updated.Role = ""
+			return &updated, nil
+		},
+		UpdateMemberRoleFunc: func(ctx context.Context, orgID, userID string, role Role) (*Member, error) {
+			return &Member{OrgID: orgID, UserID: userID, Role: role}, nil
+		},
+		RemoveMemberFunc: func(ctx context.Context, orgID, userID string) error {
+			return nil
+		},
+		CreateInvitationFunc: func(ctx context.Context, inv *Invitation) (*Invitation, error) {
+			created := *inv
+			created.ID = "inv-1"
+			return &created, nil
+		},
+	}
+}
+
+func TestDefaultService_CreateOrg(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: trims the name and makes the caller owner", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, name, userID string) (*Organization, error) {
+				return &Organization{ID: testOrgID, Name: name, BillingUserID: &userID, Role: RoleOwner}, nil
+			},
+		}
+		svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+		o, err := svc.CreateOrg(ctx, ownerID, CreateOrgRequest{Name: "  Acme Realty "})
+		require.NoError(t, err)
+		assert.Equal(t, "Acme Realty", o.Name)
+		require.Len(t, repo.CreateCalls(), 1)
+		assert.Equal(t, ownerID, repo.CreateCalls()[0].OwnerID)
+	})
+
+	t.Run("fail: name is required", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+		_, err := svc.CreateOrg(ctx, ownerID, CreateOrgRequest{Name: " "})
+		assert.ErrorIs(t, err, ErrInvalidRequest)
+		assert.Empty(t, repo.CreateCalls())
+	})
+}
+
+func TestDefaultService_UpdateOrg(t *testing.T) {
+	newName := "Acme Homes"
+	toAdmin := adminID
+	toOwner := ownerID
+
+	cases := []struct {
+		name    string
+		caller  string
+		req     UpdateOrgRequest
+		wantErr error
+	}{
+		{name: "success: admin renames", caller: adminID, req: UpdateOrgRequest{Name: &newName}},
+		{name: "success: owner keeps billing with an owner", caller: ownerID, req: UpdateOrgRequest{BillingUserID: &toOwner}},
+		{name: "fail: member cannot rename", caller: memberID, req: UpdateOrgRequest{Name: &newName}, wantErr: ErrForbidden},
+		{
+			name: "fail: admin cannot move billing", caller: adminID,
+			req: UpdateOrgRequest{BillingUserID: &toOwner}, wantErr: ErrForbidden,
+		},
+		{
+			name: "fail: billing must go to an owner", caller: ownerID,
+			req: UpdateOrgRequest{BillingUserID: &toAdmin}, wantErr: ErrInvalidRequest,
+		},
+		{
+			name: "fail: outsider sees no org", caller: outsiderID,
+			req: UpdateOrgRequest{Name: &newName}, wantErr: ErrOrgNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo(1)
+			svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+			o, err := svc.UpdateOrg(context.Background(), tc.caller, testOrgID, tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, repo.UpdateCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, o.Role, "the caller's role is kept on the response")
+			require.Len(t, repo.UpdateCalls(), 1)
+		})
+	}
+}
+
+func TestDefaultService_UpdateMember(t *testing.T) {
+	cases := []struct {
+		name    string
+		caller  string
+		target  string
+		role    Role
+		owners  int
+		wantErr error
+	}{
+		{name: "success: admin demotes an admin", caller: adminID, target: adminID, role: RoleMember, owners: 1},
+		{name: "success: owner promotes to owner", caller: ownerID, target: memberID, role: RoleOwner, owners: 1},
+		{name: "fail: unknown role", caller: ownerID, target: memberID, role: "boss", wantErr: ErrInvalidRequest},
+		{
+			name: "fail: member cannot change roles", caller: memberID, target: memberID, role: RoleAdmin,
+			wantErr: ErrForbidden,
+		},
+		{
+			name: "fail: admin cannot grant ownership", caller: adminID, target: memberID, role: RoleOwner,
+			wantErr: ErrForbidden,
+		},
+		{
+			name: "fail: admin cannot demote an owner", caller: adminID, target: ownerID, role: RoleAdmin,
+			wantErr: ErrForbidden,
+		},
+		{
+			name: "fail: billing user stays owner", caller: ownerID, target: ownerID, role: RoleAdmin, owners: 2,
+			wantErr: ErrBillingMember,
+		},
+		{name: "fail: unknown member", caller: ownerID, target: outsiderID, role: RoleAdmin, wantErr: ErrMemberNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo(tc.owners)
+			svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+			m, err := svc.UpdateMember(context.Background(), tc.caller, testOrgID, tc.target, UpdateMemberRequest{Role: tc.role})
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, repo.UpdateMemberRoleCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.role, m.Role)
+		})
+	}
+}
+
+func TestDefaultService_RemoveMember(t *testing.T) {
+	cases := []struct {
+		name    string
+		caller  string
+		target  string
+		owners  int
+		wantErr error
+	}{
+		{name: "success: member leaves", caller: memberID, target: memberID, owners: 1},
+		{name: "success: admin removes a member", caller: adminID, target: memberID, owners: 1},
+		{name: "fail: member cannot remove others", caller: memberID, target: adminID, wantErr: ErrForbidden},
+		{name: "fail: admin cannot remove an owner", caller: adminID, target: ownerID, owners: 2, wantErr: ErrForbidden},
+		{name: "fail: billing owner cannot leave", caller: ownerID, target: ownerID, owners: 2, wantErr: ErrBillingMember},
+		{name: "fail: outsider", caller: outsiderID, target: memberID, wantErr: ErrOrgNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo(tc.owners)
+			svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+			err := svc.RemoveMember(context.Background(), tc.caller, testOrgID, tc.target)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, repo.RemoveMemberCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, repo.RemoveMemberCalls(), 1)
+		})
+	}
+}
+
+func TestDefaultService_RemoveMember_LastOwner(t *testing.T) {
+	repo := newRepo(1)
+	repo.GetForMemberFunc = func(ctx context.Context, id, userID string) (*Organization, error) {
+		return &Organization{ID: id, Role: RoleOwner}, nil
+	}
+	svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+	err := svc.RemoveMember(context.Background(), ownerID, testOrgID, ownerID)
+	assert.ErrorIs(t, err, ErrLastOwner)
+	assert.Empty(t, repo.RemoveMemberCalls())
+}
+
+func TestDefaultService_CreateInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: stores only the token hash", func(t *testing.T) {
+		repo := newRepo(1)
+		svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+		inv, err := svc.CreateInvitation(ctx, adminID, testOrgID, CreateInvitationRequest{Email: " agent@example.com "})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(inv.Token, tokenPrefix))
+		assert.Equal(t, RoleMember, inv.Role)
+		assert.Equal(t, "agent@example.com", inv.Email)
+
+		stored := repo.CreateInvitationCalls()[0].Inv
+		assert.Empty(t, stored.Token)
+		assert.Equal(t, hashToken(inv.Token), stored.TokenHash)
+		assert.Equal(t, adminID, *stored.InvitedBy)
+	})
+
+	cases := []struct {
+		name    string
+		caller  string
+		req     CreateInvitationRequest
+		wantErr error
+	}{
+		{
+			name: "fail: invalid email", caller: adminID, req: CreateInvitationRequest{Email: "nope"},
+			wantErr: ErrInvalidRequest,
+		},
+		{
+			name: "fail: owners are promoted, not invited", caller: ownerID,
+			req: CreateInvitationRequest{Email: "a@example.com", Role: RoleOwner}, wantErr: ErrInvalidRequest,
+		},
+		{
+			name: "fail: members cannot invite", caller: memberID,
+			req: CreateInvitationRequest{Email: "a@example.com"}, wantErr: ErrForbidden,
+		},
+		{
+			name: "fail: outsiders cannot invite", caller: outsiderID,
+			req: CreateInvitationRequest{Email: "a@example.com"}, wantErr: ErrOrgNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newRepo(1)
+			svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+			_, err := svc.CreateInvitation(ctx, tc.caller, testOrgID, tc.req)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Empty(t, repo.CreateInvitationCalls())
+		})
+	}
+}
+
+func TestDefaultService_AcceptInvitation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: looks the invitation up by hash", func(t *testing.T) {
+		repo := &RepositoryMock{
+			AcceptInvitationFunc: func(ctx context.Context, tokenHash, userID string) (*Member, error) {
+				return &Member{OrgID: testOrgID, UserID: userID, Role: RoleMember}, nil
+			},
+		}
+		svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+		m, err := svc.AcceptInvitation(ctx, memberID, "inv_abc")
+		require.NoError(t, err)
+		assert.Equal(t, testOrgID, m.OrgID)
+		assert.Equal(t, hashToken("inv_abc"), repo.AcceptInvitationCalls()[0].TokenHash)
+	})
+
+	t.Run("fail: malformed token never reaches the database", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		svc := NewDefaultService(repo, &project.RepositoryMock{})
+
+		_, err := svc.AcceptInvitation(ctx, memberID, "whsec_abc")
+		assert.ErrorIs(t, err, ErrInvitationNotFound)
+		assert.Empty(t, repo.AcceptInvitationCalls())
+	})
+}
+
+func TestDefaultService_ShareProject(t *testing.T) {
+	cases := []struct {
+		name       string
+		caller     string
+		creator    string
+		projectErr error
+		wantErr    error
+	}{
+		{name: "success: member shares own project", caller: memberID, creator: memberID},
+		{name: "fail: cannot share someone else's project", caller: adminID, creator: memberID, wantErr: ErrProjectNotFound},
+		{name: "fail: unknown project", caller: memberID, projectErr: pgx.ErrNoRows, wantErr: ErrProjectNotFound},
+		{name: "fail: outsider", caller: outsiderID, creator: outsiderID, wantErr: ErrOrgNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			projects := &project.RepositoryMock{
+				GetProjectByIDFunc: func(ctx context.Context, projectID string) (*project.Project, error) {
+					if tc.projectErr != nil {
+						return nil, tc.projectErr
+					}
+					return &project.Project{ID: projectID, UserID: tc.creator}, nil
+				},
+				SetProjectOrgFunc: func(ctx context.Context, projectID string, orgID *string) (*project.Project, error) {
+					return &project.Project{ID: projectID, UserID: tc.creator, OrgID: orgID}, nil
+				},
+			}
+			svc := NewDefaultService(newRepo(1), projects)
+
+			p, err := svc.ShareProject(context.Background(), tc.caller, testOrgID, testProject)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, projects.SetProjectOrgCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, p.OrgID)
+			assert.Equal(t, testOrgID, *p.OrgID)
+		})
+	}
+}
+
+func TestDefaultService_UnshareProject(t *testing.T) {
+	otherOrg := "77777777-7777-7777-7777-777777777777"
+	sharedOrg := testOrgID
+
+	cases := []struct {
+		name    string
+		caller  string
+		orgID   *string
+		wantErr error
+	}{
+		{name: "success: creator unshares", caller: memberID, orgID: &sharedOrg},
+		{name: "success: admin unshares a member's project", caller: adminID, orgID: &sharedOrg},
+		{name: "fail: project is shared with another org", caller: adminID, orgID: &otherOrg, wantErr: ErrProjectNotFound},
+		{name: "fail: project is not shared", caller: memberID, wantErr: ErrProjectNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			projects := &project.RepositoryMock{
+				GetProjectByIDFunc: func(ctx context.Context, projectID string) (*project.Project, error) {
+					return &project.Project{ID: projectID, UserID: memberID, OrgID: tc.orgID}, nil
+				},
+				SetProjectOrgFunc: func(ctx context.Context, projectID string, orgID *string) (*project.Project, error) {
+					return &project.Project{ID: projectID, UserID: memberID, OrgID: orgID}, nil
+				},
+			}
+			svc := NewDefaultService(newRepo(1), projects)
+
+			p, err := svc.UnshareProject(context.Background(), tc.caller, testOrgID, testProject)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, projects.SetProjectOrgCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, p.OrgID)
+		})
+	}
+}
+
+func TestDefaultService_UnshareProject_MemberCannotUnshareOthers(t *testing.T) {
+	sharedOrg := testOrgID
+	projects := &project.RepositoryMock{
+		GetProjectByIDFunc: func(ctx context.Context, projectID string) (*project.Project, error) {
+			return &project.Project{ID: projectID, UserID: adminID, OrgID: &sharedOrg}, nil
+		},
+	}
+	svc := NewDefaultService(newRepo(1), projects)
+
+	_, err := svc.UnshareProject(context.Background(), memberID, testOrgID, testProject)
+	assert.ErrorIs(t, err, ErrForbidden)
+}
@@ -0,0 +1,53 @@
+package org
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for organizations, members, invitations and shared projects.
+type Handler interface {
+	// CreateOrg handles POST /orgs - Creates an organization owned by the caller.
+	CreateOrg(c echo.Context) error
+
+	// ListOrgs handles GET /orgs - Lists the caller's organizations.
+	ListOrgs(c echo.Context) error
+
+	// GetOrg handles GET /orgs/:id - Gets an organization.
+	GetOrg(c echo.Context) error
+
+	// UpdateOrg handles PATCH /orgs/:id - Renames an organization or reassigns billing.
+	UpdateOrg(c echo.Context) error
+
+	// DeleteOrg handles DELETE /orgs/:id - Deletes an organization.
+	DeleteOrg(c echo.Context) error
+
+	// ListMembers handles GET /orgs/:id/members - Lists members.
+	ListMembers(c echo.Context) error
+
+	// UpdateMember handles PATCH /orgs/:id/members/:user_id - Changes a member's role.
+	UpdateMember(c echo.Context) error
+
+	// RemoveMember handles DELETE /orgs/:id/members/:user_id - Removes a member or leaves.
+	RemoveMember(c echo.Context) error
+
+	// CreateInvitation handles POST /orgs/:id/invitations - Invites an email address.
+	CreateInvitation(c echo.Context) error
+
+	// ListInvitations handles GET /orgs/:id/invitations - Lists open invitations.
+	ListInvitations(c echo.Context) error
+
+	// RevokeInvitation handles DELETE /orgs/:id/invitations/:invitation_id - Revokes an invitation.
+	RevokeInvitation(c echo.Context) error
+
+	// AcceptInvitation handles POST /invitations/accept - Joins an organization with a token.
+	AcceptInvitation(c echo.Context) error
+
+	// ListProjects handles GET /orgs/:id/projects - Lists shared projects.
+	ListProjects(c echo.Context) error
+
+	// ShareProject handles PUT /orgs/:id/projects/:project_id - Shares a project.
+	ShareProject(c echo.Context) error
+
+	// UnshareProject handles DELETE /orgs/:id/projects/:project_id - Unshares a project.
+	UnshareProject(c echo.Context) error
+}
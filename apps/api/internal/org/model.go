@@ -0,0 +1,125 @@
+// Package org lets brokerages group users into organizations that share
+// projects and a billing owner.
+//
+// Projects stay owned by their creator; sharing one with an organization gives
+// every member access to it, and owners and admins can rename or delete it.
+// Images created in a shared project count against the organization's billing
+// user instead of the creator.
+package org
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrOrgNotFound is returned when an organization does not exist or the caller is not a member.
+	ErrOrgNotFound = errors.New("organization not found")
+	// ErrMemberNotFound is returned when a user is not a member of the organization.
+	ErrMemberNotFound = errors.New("organization member not found")
+	// ErrInvitationNotFound is returned for unknown, expired, revoked or already accepted invitations.
+	ErrInvitationNotFound = errors.New("invitation not found or expired")
+	// ErrProjectNotFound is returned when a project does not exist or cannot be shared by the caller.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrForbidden is returned when the caller's role does not allow the action.
+	ErrForbidden = errors.New("insufficient organization role")
+	// ErrInvalidRequest is returned when a name, email or role fails validation.
+	ErrInvalidRequest = errors.New("invalid organization request")
+	// ErrLastOwner is returned when a change would leave the organization without an owner.
+	ErrLastOwner = errors.New("an organization must keep at least one owner")
+	// ErrBillingMember is returned when removing or demoting the billing user before reassigning billing.
+	ErrBillingMember = errors.New("reassign billing before removing or demoting the billing user")
+	// ErrInvitationExists is returned when the email already has an open invitation to the organization.
+	ErrInvitationExists = errors.New("an invitation for this email is already pending")
+)
+
+// Role is a member's role within an organization.
+type Role string
+
+const (
+	// RoleOwner can do everything, including deleting the organization and managing billing.
+	RoleOwner Role = "owner"
+	// RoleAdmin manages members, invitations and shared projects.
+	RoleAdmin Role = "admin"
+	// RoleMember can see and work in the organization's shared projects.
+	RoleMember Role = "member"
+)
+
+// roleRank orders roles so permission checks can compare them.
+var roleRank = map[Role]int{RoleMember: 1, RoleAdmin: 2, RoleOwner: 3}
+
+// Valid reports whether r is a known role.
+func (r Role) Valid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// AtLeast reports whether r grants at least the permissions of required.
+func (r Role) AtLeast(required Role) bool {
+	return roleRank[r] >= roleRank[required]
+}
+
+// Organization is a group of users sharing projects and billing.
+// Role is the caller's own role and is only set on user-facing reads.
+type Organization struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	BillingUserID *string   `json:"billing_user_id,omitempty"`
+	Role          Role      `json:"role,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Member is a user's membership in an organization.
+type Member struct {
+	OrgID     string    `json:"org_id"`
+	UserID    string    `json:"user_id"`
+	Email     *string   `json:"email,omitempty"`
+	FullName  *string   `json:"full_name,omitempty"`
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Invitation lets the holder of its token join an organization.
+//
+// Token is only populated in the response that creates the invitation; the
+// database stores its SHA-256 hash.
+type Invitation struct {
+	ID        string    `json:"id"`
+	OrgID     string    `json:"org_id"`
+	Email     string    `json:"email"`
+	Role      Role      `json:"role"`
+	Token     string    `json:"token,omitempty"`
+	TokenHash string    `json:"-"`
+	InvitedBy *string   `json:"invited_by,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateOrgRequest creates an organization owned and billed to the caller.
+type CreateOrgRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateOrgRequest changes an organization. Nil fields are left unchanged.
+// BillingUserID must name an owner of the organization.
+type UpdateOrgRequest struct {
+	Name          *string `json:"name,omitempty"`
+	BillingUserID *string `json:"billing_user_id,omitempty"`
+}
+
+// UpdateMemberRequest changes a member's role.
+type UpdateMemberRequest struct {
+	Role Role `json:"role"`
+}
+
+// CreateInvitationRequest invites an email address. An empty Role invites a member.
+type CreateInvitationRequest struct {
+	Email string `json:"email"`
+	Role  Role   `json:"role,omitempty"`
+}
+
+// AcceptInvitationRequest redeems an invitation token for the caller.
+type AcceptInvitationRequest struct {
+	Token string `json:"token"`
+}
@@ -0,0 +1,52 @@
+package org
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for organizations, memberships and invitations.
+// Organization reads are scoped to a member so non-members never see an org.
+type Repository interface {
+	// Create inserts an organization with ownerID as its first owner and billing user.
+	Create(ctx context.Context, name, ownerID string) (*Organization, error)
+
+	// GetForMember retrieves an organization with the member's role.
+	GetForMember(ctx context.Context, id, userID string) (*Organization, error)
+
+	// ListForMember returns the user's organizations, oldest membership first.
+	ListForMember(ctx context.Context, userID string) ([]Organization, error)
+
+	// Update persists the name and billing user.
+	Update(ctx context.Context, o *Organization) (*Organization, error)
+
+	// Delete removes an organization; its projects revert to their creators.
+	Delete(ctx context.Context, id string) error
+
+	// GetMember retrieves a single membership.
+	GetMember(ctx context.Context, orgID, userID string) (*Member, error)
+
+	// ListMembers returns an organization's members with their profile details.
+	ListMembers(ctx context.Context, orgID string) ([]Member, error)
+
+	// UpdateMemberRole changes a member's role.
+	UpdateMemberRole(ctx context.Context, orgID, userID string, role Role) (*Member, error)
+
+	// RemoveMember deletes a membership.
+	RemoveMember(ctx context.Context, orgID, userID string) error
+
+	// CountOwners returns the number of owners in an organization.
+	CountOwners(ctx context.Context, orgID string) (int, error)
+
+	// CreateInvitation inserts an invitation, replacing an expired one for the same email.
+	CreateInvitation(ctx context.Context, inv *Invitation) (*Invitation, error)
+
+	// ListInvitations returns an organization's open invitations, newest first.
+	ListInvitations(ctx context.Context, orgID string) ([]Invitation, error)
+
+	// DeleteInvitation revokes an open invitation.
+	DeleteInvitation(ctx context.Context, orgID, id string) error
+
+	// AcceptInvitation marks the invitation with tokenHash accepted and adds the
+	// user with the invited role. An existing membership keeps its role.
+	AcceptInvitation(ctx context.Context, tokenHash, userID string) (*Member, error)
+}
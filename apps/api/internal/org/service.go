@@ -0,0 +1,58 @@
+package org
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/project"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for organizations. Every method takes the
+// calling user's ID and enforces that user's role in the organization.
+type Service interface {
+	// CreateOrg creates an organization with the caller as owner and billing user.
+	CreateOrg(ctx context.Context, userID string, req CreateOrgRequest) (*Organization, error)
+
+	// ListOrgs returns the organizations the caller belongs to.
+	ListOrgs(ctx context.Context, userID string) ([]Organization, error)
+
+	// GetOrg retrieves an organization the caller belongs to.
+	GetOrg(ctx context.Context, userID, orgID string) (*Organization, error)
+
+	// UpdateOrg renames an organization (admin) or reassigns its billing user (owner).
+	UpdateOrg(ctx context.Context, userID, orgID string, req UpdateOrgRequest) (*Organization, error)
+
+	// DeleteOrg deletes an organization (owner). Shared projects revert to their creators.
+	DeleteOrg(ctx context.Context, userID, orgID string) error
+
+	// ListMembers returns an organization's members.
+	ListMembers(ctx context.Context, userID, orgID string) ([]Member, error)
+
+	// UpdateMember changes a member's role (admin; owner to grant or revoke ownership).
+	UpdateMember(ctx context.Context, userID, orgID, memberID string, req UpdateMemberRequest) (*Member, error)
+
+	// RemoveMember removes a member (admin), or lets the caller leave when memberID is their own.
+	RemoveMember(ctx context.Context, userID, orgID, memberID string) error
+
+	// CreateInvitation invites an email address (admin) and returns the invitation with its token.
+	CreateInvitation(ctx context.Context, userID, orgID string, req CreateInvitationRequest) (*Invitation, error)
+
+	// ListInvitations returns an organization's open invitations (admin).
+	ListInvitations(ctx context.Context, userID, orgID string) ([]Invitation, error)
+
+	// RevokeInvitation cancels an open invitation (admin).
+	RevokeInvitation(ctx context.Context, userID, orgID, invitationID string) error
+
+	// AcceptInvitation adds the caller to the invitation's organization.
+	AcceptInvitation(ctx context.Context, userID, token string) (*Member, error)
+
+	// ListProjects returns the projects shared with an organization.
+	ListProjects(ctx context.Context, userID, orgID string) ([]project.Project, error)
+
+	// ShareProject shares one of the caller's own projects with an organization they belong to.
+	ShareProject(ctx context.Context, userID, orgID, projectID string) (*project.Project, error)
+
+	// UnshareProject returns a shared project to its creator (the creator or an admin).
+	UnshareProject(ctx context.Context, userID, orgID, projectID string) (*project.Project, error)
+}
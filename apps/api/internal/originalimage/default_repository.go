@@ -2,10 +2,12 @@ package originalimage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/real-staging-ai/api/internal/storage"
@@ -95,27 +97,36 @@ func (r *DefaultRepository) IncrementReferenceCount(ctx context.Context, id stri
 		return fmt.Errorf("invalid original image ID: %w", err)
 	}
 
-	if err := q.IncrementReferenceCount(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true}); err != nil {
+	rows, err := q.IncrementReferenceCount(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	if err != nil {
 		return fmt.Errorf("failed to increment reference count: %w", err)
 	}
+	if rows == 0 {
+		return ErrOriginalImageNotFound
+	}
 
 	return nil
 }
 
-// DecrementReferenceCount decrements the reference count for an original image.
-func (r *DefaultRepository) DecrementReferenceCount(ctx context.Context, id string) error {
+// DecrementReferenceCount decrements the reference count for an original image
+// and returns the count left.
+func (r *DefaultRepository) DecrementReferenceCount(ctx context.Context, id string) (int, error) {
 	q := queries.New(r.db)
 
 	imageUUID, err := uuid.Parse(id)
 	if err != nil {
-		return fmt.Errorf("invalid original image ID: %w", err)
+		return 0, fmt.Errorf("invalid original image ID: %w", err)
 	}
 
-	if err := q.DecrementReferenceCount(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true}); err != nil {
-		return fmt.Errorf("failed to decrement reference count: %w", err)
+	remaining, err := q.DecrementReferenceCount(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrOriginalImageNotFound
+		}
+		return 0, fmt.Errorf("failed to decrement reference count: %w", err)
 	}
 
-	return nil
+	return int(remaining), nil
 }
 
 // ListOrphanedOriginalImages lists original images with zero references older than the given duration.
@@ -158,6 +169,29 @@ func (r *DefaultRepository) DeleteOriginalImage(ctx context.Context, id string)
 	return nil
 }
 
+// DeleteUnreferencedOriginalImage deletes an original image record if nothing
+// references it and returns its S3 key.
+func (r *DefaultRepository) DeleteUnreferencedOriginalImage(
+	ctx context.Context, id string,
+) (s3Key string, deleted bool, err error) {
+	q := queries.New(r.db)
+
+	imageUUID, err := uuid.Parse(id)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid original image ID: %w", err)
+	}
+
+	s3Key, err = q.DeleteUnreferencedOriginalImage(ctx, pgtype.UUID{Bytes: imageUUID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to delete original image: %w", err)
+	}
+
+	return s3Key, true, nil
+}
+
 // GetOriginalImageStats retrieves statistics about original images.
 func (r *DefaultRepository) GetOriginalImageStats(ctx context.Context) (*OriginalImageStats, error) {
 	q := queries.New(r.db)
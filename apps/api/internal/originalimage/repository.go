@@ -2,11 +2,15 @@ package originalimage
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
+// ErrOriginalImageNotFound is returned when an original image has already been deleted.
+var ErrOriginalImageNotFound = errors.New("original image not found")
+
 //go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
 
 // Repository defines the interface for original image data access operations.
@@ -27,11 +31,14 @@ type Repository interface {
 	GetOriginalImageByHash(ctx context.Context, contentHash string) (*queries.OriginalImage, error)
 
 	// IncrementReferenceCount increments the reference count for an original image.
+	// It returns ErrOriginalImageNotFound if the original was deleted concurrently,
+	// in which case the caller must store the original again.
 	IncrementReferenceCount(ctx context.Context, id string) error
 
-	// DecrementReferenceCount decrements the reference count for an original image.
-	// If the reference count reaches 0, the image becomes eligible for cleanup.
-	DecrementReferenceCount(ctx context.Context, id string) error
+	// DecrementReferenceCount atomically decrements the reference count for an
+	// original image and returns the count left. At 0 the image becomes eligible
+	// for cleanup.
+	DecrementReferenceCount(ctx context.Context, id string) (int, error)
 
 	// ListOrphanedOriginalImages lists original images with zero references older than the given duration.
 	ListOrphanedOriginalImages(
@@ -43,6 +50,11 @@ type Repository interface {
 	// DeleteOriginalImage permanently deletes an original image record.
 	DeleteOriginalImage(ctx context.Context, id string) error
 
+	// DeleteUnreferencedOriginalImage permanently deletes an original image record
+	// if its reference count is still 0 and returns its S3 key. deleted is false
+	// when the original was re-referenced (or already deleted) in the meantime.
+	DeleteUnreferencedOriginalImage(ctx context.Context, id string) (s3Key string, deleted bool, err error)
+
 	// GetOriginalImageStats retrieves statistics about original images.
 	GetOriginalImageStats(ctx context.Context) (*OriginalImageStats, error)
 }
@@ -23,12 +23,15 @@ var _ Repository = &RepositoryMock{}
 //			CreateOriginalImageFunc: func(ctx context.Context, contentHash string, s3Key string, fileSize int64, mimeType string, width *int, height *int) (*queries.OriginalImage, error) {
 //				panic("mock out the CreateOriginalImage method")
 //			},
-//			DecrementReferenceCountFunc: func(ctx context.Context, id string) error {
+//			DecrementReferenceCountFunc: func(ctx context.Context, id string) (int, error) {
 //				panic("mock out the DecrementReferenceCount method")
 //			},
 //			DeleteOriginalImageFunc: func(ctx context.Context, id string) error {
 //				panic("mock out the DeleteOriginalImage method")
 //			},
+//			DeleteUnreferencedOriginalImageFunc: func(ctx context.Context, id string) (string, bool, error) {
+//				panic("mock out the DeleteUnreferencedOriginalImage method")
+//			},
 //			GetOriginalImageByHashFunc: func(ctx context.Context, contentHash string) (*queries.OriginalImage, error) {
 //				panic("mock out the GetOriginalImageByHash method")
 //			},
@@ -55,11 +58,14 @@ type RepositoryMock struct {
 	CreateOriginalImageFunc func(ctx context.Context, contentHash string, s3Key string, fileSize int64, mimeType string, width *int, height *int) (*queries.OriginalImage, error)
 
 	// DecrementReferenceCountFunc mocks the DecrementReferenceCount method.
-	DecrementReferenceCountFunc func(ctx context.Context, id string) error
+	DecrementReferenceCountFunc func(ctx context.Context, id string) (int, error)
 
 	// DeleteOriginalImageFunc mocks the DeleteOriginalImage method.
 	DeleteOriginalImageFunc func(ctx context.Context, id string) error
 
+	// DeleteUnreferencedOriginalImageFunc mocks the DeleteUnreferencedOriginalImage method.
+	DeleteUnreferencedOriginalImageFunc func(ctx context.Context, id string) (string, bool, error)
+
 	// GetOriginalImageByHashFunc mocks the GetOriginalImageByHash method.
 	GetOriginalImageByHashFunc func(ctx context.Context, contentHash string) (*queries.OriginalImage, error)
 
@@ -108,6 +114,13 @@ type RepositoryMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// DeleteUnreferencedOriginalImage holds details about calls to the DeleteUnreferencedOriginalImage method.
+		DeleteUnreferencedOriginalImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
 		// GetOriginalImageByHash holds details about calls to the GetOriginalImageByHash method.
 		GetOriginalImageByHash []struct {
 			// Ctx is the ctx argument value.
@@ -144,14 +157,15 @@ type RepositoryMock struct {
 			Limit int
 		}
 	}
-	lockCreateOriginalImage        sync.RWMutex
-	lockDecrementReferenceCount    sync.RWMutex
-	lockDeleteOriginalImage        sync.RWMutex
-	lockGetOriginalImageByHash     sync.RWMutex
-	lockGetOriginalImageByID       sync.RWMutex
-	lockGetOriginalImageStats      sync.RWMutex
-	lockIncrementReferenceCount    sync.RWMutex
-	lockListOrphanedOriginalImages sync.RWMutex
+	lockCreateOriginalImage             sync.RWMutex
+	lockDecrementReferenceCount         sync.RWMutex
+	lockDeleteOriginalImage             sync.RWMutex
+	lockDeleteUnreferencedOriginalImage sync.RWMutex
+	lockGetOriginalImageByHash          sync.RWMutex
+	lockGetOriginalImageByID            sync.RWMutex
+	lockGetOriginalImageStats           sync.RWMutex
+	lockIncrementReferenceCount         sync.RWMutex
+	lockListOrphanedOriginalImages      sync.RWMutex
 }
 
 // CreateOriginalImage calls CreateOriginalImageFunc.
@@ -211,7 +225,7 @@ func (mock *RepositoryMock) CreateOriginalImageCalls() []struct {
 }
 
 // DecrementReferenceCount calls DecrementReferenceCountFunc.
-func (mock *RepositoryMock) DecrementReferenceCount(ctx context.Context, id string) error {
+func (mock *RepositoryMock) DecrementReferenceCount(ctx context.Context, id string) (int, error) {
 	if mock.DecrementReferenceCountFunc == nil {
 		panic("RepositoryMock.DecrementReferenceCountFunc: method is nil but Repository.DecrementReferenceCount was just called")
 	}
@@ -282,6 +296,42 @@ func (mock *RepositoryMock) DeleteOriginalImageCalls() []struct {
 	return calls
 }
 
+// DeleteUnreferencedOriginalImage calls DeleteUnreferencedOriginalImageFunc.
+func (mock *RepositoryMock) DeleteUnreferencedOriginalImage(ctx context.Context, id string) (string, bool, error) {
+	if mock.DeleteUnreferencedOriginalImageFunc == nil {
+		panic("RepositoryMock.DeleteUnreferencedOriginalImageFunc: method is nil but Repository.DeleteUnreferencedOriginalImage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteUnreferencedOriginalImage.Lock()
+	mock.calls.DeleteUnreferencedOriginalImage = append(mock.calls.DeleteUnreferencedOriginalImage, callInfo)
+	mock.lockDeleteUnreferencedOriginalImage.Unlock()
+	return mock.DeleteUnreferencedOriginalImageFunc(ctx, id)
+}
+
+// DeleteUnreferencedOriginalImageCalls gets all the calls that were made to DeleteUnreferencedOriginalImage.
+// Check the length with:
+//
+//	len(mockedRepository.DeleteUnreferencedOriginalImageCalls())
+func (mock *RepositoryMock) DeleteUnreferencedOriginalImageCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockDeleteUnreferencedOriginalImage.RLock()
+	calls = mock.calls.DeleteUnreferencedOriginalImage
+	mock.lockDeleteUnreferencedOriginalImage.RUnlock()
+	return calls
+}
+
 // GetOriginalImageByHash calls GetOriginalImageByHashFunc.
 func (mock *RepositoryMock) GetOriginalImageByHash(ctx context.Context, contentHash string) (*queries.OriginalImage, error) {
 	if mock.GetOriginalImageByHashFunc == nil {
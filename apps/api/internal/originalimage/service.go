@@ -40,38 +40,24 @@ func NewDefaultService(repo Repository, s3Service storage.S3Service) *DefaultSer
 }
 
 // DecrementReferenceAndCleanup decrements the reference count and cleans up if needed.
+// The decrement and the delete are each a single statement, and the delete only
+// succeeds while the count is still 0, so an upload that references the original
+// between the two keeps it alive. The database row goes first: S3 is only
+// touched once nothing can reference the file any more.
 func (s *DefaultService) DecrementReferenceAndCleanup(ctx context.Context, originalImageID string) (bool, error) {
 	if originalImageID == "" {
 		return false, fmt.Errorf("original image ID cannot be empty")
 	}
 
-	// Get the original image before decrementing to check reference count
-	original, err := s.repo.GetOriginalImageByID(ctx, originalImageID)
+	remaining, err := s.repo.DecrementReferenceCount(ctx, originalImageID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get original image: %w", err)
-	}
-
-	// Decrement the reference count
-	if err := s.repo.DecrementReferenceCount(ctx, originalImageID); err != nil {
 		return false, fmt.Errorf("failed to decrement reference count: %w", err)
 	}
-
-	// If this was the last reference, clean up the original
-	if original.ReferenceCount <= 1 {
-		// Delete from S3
-		// Ignore S3 deletion errors - database cleanup is more important
-		// The orphaned file can be cleaned up later by background job
-		_ = s.s3Service.DeleteFile(ctx, original.S3Key)
-
-		// Delete from database
-		if err := s.repo.DeleteOriginalImage(ctx, originalImageID); err != nil {
-			return false, fmt.Errorf("failed to delete original image: %w", err)
-		}
-
-		return true, nil
+	if remaining > 0 {
+		return false, nil
 	}
 
-	return false, nil
+	return s.deleteIfUnreferenced(ctx, originalImageID)
 }
 
 // CleanupOrphanedOriginals finds and deletes orphaned original images.
@@ -85,27 +71,38 @@ func (s *DefaultService) CleanupOrphanedOriginals(
 
 	deletedCount := 0
 	for _, original := range orphaned {
-		// Delete from S3
-		if deleteErr := s.s3Service.DeleteFile(ctx, original.S3Key); deleteErr != nil {
+		deleted, err := s.deleteIfUnreferenced(ctx, formatUUID(original.ID.Bytes))
+		if err != nil {
 			// Log but continue with other deletions
 			// TODO: Add logging when logger is available
 			continue
 		}
-
-		// Delete from database
-		originalID := formatUUID(original.ID.Bytes)
-		if err := s.repo.DeleteOriginalImage(ctx, originalID); err != nil {
-			// Log but continue with other deletions
-			// TODO: Add logging when logger is available
-			continue
+		if deleted {
+			deletedCount++
 		}
-
-		deletedCount++
 	}
 
 	return deletedCount, nil
 }
 
+// deleteIfUnreferenced deletes the original's record and then its S3 object,
+// unless it has been referenced again.
+func (s *DefaultService) deleteIfUnreferenced(ctx context.Context, originalImageID string) (bool, error) {
+	s3Key, deleted, err := s.repo.DeleteUnreferencedOriginalImage(ctx, originalImageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete original image: %w", err)
+	}
+	if !deleted {
+		return false, nil
+	}
+
+	// Ignore S3 deletion errors - the record is gone, so nothing can reference
+	// the object again and the leftover file is only wasted storage
+	_ = s.s3Service.DeleteFile(ctx, s3Key)
+
+	return true, nil
+}
+
 // GetStats retrieves statistics about original images.
 func (s *DefaultService) GetStats(ctx context.Context) (*OriginalImageStats, error) {
 	stats, err := s.repo.GetOriginalImageStats(ctx)
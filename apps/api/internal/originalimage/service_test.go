@@ -0,0 +1,207 @@
+package originalimage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+const testOriginalID = "6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b"
+
+func TestDefaultService_DecrementReferenceAndCleanup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: keeps an original that is still referenced", func(t *testing.T) {
+		repo := &RepositoryMock{
+			DecrementReferenceCountFunc: func(ctx context.Context, id string) (int, error) { return 2, nil },
+		}
+		s3 := &storage.S3ServiceMock{}
+
+		deleted, err := NewDefaultService(repo, s3).DecrementReferenceAndCleanup(ctx, testOriginalID)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Empty(t, repo.DeleteUnreferencedOriginalImageCalls())
+		assert.Empty(t, s3.DeleteFileCalls())
+	})
+
+	t.Run("success: deletes the record, then the file, at zero", func(t *testing.T) {
+		var order []string
+		repo := &RepositoryMock{
+			DecrementReferenceCountFunc: func(ctx context.Context, id string) (int, error) { return 0, nil },
+			DeleteUnreferencedOriginalImageFunc: func(ctx context.Context, id string) (string, bool, error) {
+				order = append(order, "db")
+				return "originals/abc.jpg", true, nil
+			},
+		}
+		s3 := &storage.S3ServiceMock{
+			DeleteFileFunc: func(ctx context.Context, fileKey string) error {
+				order = append(order, "s3:"+fileKey)
+				return nil
+			},
+		}
+
+		deleted, err := NewDefaultService(repo, s3).DecrementReferenceAndCleanup(ctx, testOriginalID)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, []string{"db", "s3:originals/abc.jpg"}, order)
+	})
+
+	t.Run("success: keeps an original re-referenced before the delete", func(t *testing.T) {
+		repo := &RepositoryMock{
+			DecrementReferenceCountFunc: func(ctx context.Context, id string) (int, error) { return 0, nil },
+			DeleteUnreferencedOriginalImageFunc: func(ctx context.Context, id string) (string, bool, error) {
+				return "", false, nil
+			},
+		}
+		s3 := &storage.S3ServiceMock{}
+
+		deleted, err := NewDefaultService(repo, s3).DecrementReferenceAndCleanup(ctx, testOriginalID)
+		require.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Empty(t, s3.DeleteFileCalls())
+	})
+
+	t.Run("fail: decrement error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			DecrementReferenceCountFunc: func(ctx context.Context, id string) (int, error) {
+				return 0, errors.New("db down")
+			},
+		}
+
+		_, err := NewDefaultService(repo, &storage.S3ServiceMock{}).DecrementReferenceAndCleanup(ctx, testOriginalID)
+		assert.ErrorContains(t, err, "failed to decrement reference count")
+	})
+
+	t.Run("fail: empty ID", func(t *testing.T) {
+		_, err := NewDefaultService(&RepositoryMock{}, &storage.S3ServiceMock{}).DecrementReferenceAndCleanup(ctx, "")
+		assert.Error(t, err)
+	})
+}
+
+// fakeRepository mimics the row-level semantics of the reference counting
+// queries: each statement is atomic, like a single UPDATE or DELETE holding the
+// row lock.
+type fakeRepository struct {
+	RepositoryMock
+	mu      sync.Mutex
+	count   int
+	exists  bool
+	deletes int
+}
+
+func newFakeRepository(count int) *fakeRepository {
+	f := &fakeRepository{count: count, exists: true}
+	f.IncrementReferenceCountFunc = func(ctx context.Context, id string) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !f.exists {
+			return ErrOriginalImageNotFound
+		}
+		f.count++
+		return nil
+	}
+	f.DecrementReferenceCountFunc = func(ctx context.Context, id string) (int, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !f.exists {
+			return 0, ErrOriginalImageNotFound
+		}
+		f.count = max(f.count-1, 0)
+		return f.count, nil
+	}
+	f.DeleteUnreferencedOriginalImageFunc = func(ctx context.Context, id string) (string, bool, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if !f.exists || f.count != 0 {
+			return "", false, nil
+		}
+		f.exists = false
+		f.deletes++
+		return "originals/abc.jpg", true, nil
+	}
+	return f
+}
+
+func TestDefaultService_ConcurrentCreateAndDelete(t *testing.T) {
+	ctx := context.Background()
+
+	for run := 0; run < 50; run++ {
+		// One image references the original. Each round deletes it while a new
+		// upload of the same content references the original concurrently.
+		repo := newFakeRepository(1)
+		var s3Deletes int
+		var s3mu sync.Mutex
+		s3 := &storage.S3ServiceMock{
+			DeleteFileFunc: func(ctx context.Context, fileKey string) error {
+				s3mu.Lock()
+				defer s3mu.Unlock()
+				s3Deletes++
+				return nil
+			},
+		}
+		svc := NewDefaultService(repo, s3)
+
+		var wg sync.WaitGroup
+		var created bool
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := svc.DecrementReferenceAndCleanup(ctx, testOriginalID)
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			created = repo.IncrementReferenceCount(ctx, testOriginalID) == nil
+		}()
+		wg.Wait()
+
+		repo.mu.Lock()
+		if created {
+			// The upload won: the original must survive with its one reference.
+			assert.True(t, repo.exists, "run %d: referenced original was deleted", run)
+			assert.Equal(t, 1, repo.count)
+			assert.Zero(t, s3Deletes)
+		} else {
+			// The delete won: the upload saw the original gone and must store it again.
+			assert.False(t, repo.exists)
+			assert.Equal(t, 1, repo.deletes)
+			assert.Equal(t, 1, s3Deletes)
+		}
+		repo.mu.Unlock()
+	}
+}
+
+func TestDefaultService_CleanupOrphanedOriginals(t *testing.T) {
+	ctx := context.Background()
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	t.Run("success: skips originals referenced since they were listed", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListOrphanedOriginalImagesFunc: func(
+				ctx context.Context, _ time.Duration, limit int,
+			) ([]*queries.OriginalImage, error) {
+				return []*queries.OriginalImage{{ID: id}, {ID: id}}, nil
+			},
+		}
+		calls := 0
+		repo.DeleteUnreferencedOriginalImageFunc = func(ctx context.Context, _ string) (string, bool, error) {
+			calls++
+			return "originals/abc.jpg", calls == 1, nil
+		}
+		s3 := &storage.S3ServiceMock{DeleteFileFunc: func(ctx context.Context, fileKey string) error { return nil }}
+
+		n, err := NewDefaultService(repo, s3).CleanupOrphanedOriginals(ctx, time.Hour, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.Len(t, s3.DeleteFileCalls(), 1)
+	})
+}
@@ -0,0 +1,57 @@
+package overage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// Record inserts the usage unless the image already has one.
+func (r *DefaultRepository) Record(ctx context.Context, userID, imageID, stripeCustomerID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO overage_usage (image_id, user_id, stripe_customer_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (image_id) DO NOTHING`,
+		imageID, userID, stripeCustomerID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record overage usage: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Cancel sets cancelled_at once, so only one caller gets the usage back.
+func (r *DefaultRepository) Cancel(ctx context.Context, imageID string) (*Usage, error) {
+	var u Usage
+	err := r.db.QueryRow(ctx, `
+		UPDATE overage_usage
+		SET cancelled_at = now()
+		WHERE image_id = $1 AND cancelled_at IS NULL
+		RETURNING image_id::text, user_id::text, stripe_customer_id, created_at, cancelled_at`,
+		imageID,
+	).Scan(&u.ImageID, &u.UserID, &u.StripeCustomerID, &u.CreatedAt, &u.CancelledAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to cancel overage usage: %w", err)
+	}
+	return &u, nil
+}
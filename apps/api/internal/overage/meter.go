@@ -0,0 +1,77 @@
+package overage
+
+import (
+	"context"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/billing/meterevent"
+	"github.com/stripe/stripe-go/v81/billing/metereventadjustment"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out meter_mock.go . Meter
+
+// Meter reports overage images to Stripe. Events are identified by the image
+// ID, so Stripe ignores a repeated report of the same image.
+type Meter interface {
+	// Report bills one image to the customer at the given time, or now when
+	// it is zero.
+	Report(ctx context.Context, stripeCustomerID, imageID string, at time.Time) error
+
+	// Cancel withdraws the image's report. Stripe only accepts cancellations
+	// within 24 hours of the report.
+	Cancel(ctx context.Context, imageID string) error
+}
+
+// StripeMeter reports to a Stripe billing meter with a fixed live-mode key. It
+// never reads or sets the package-level stripe.Key.
+type StripeMeter struct {
+	eventName   string
+	events      meterevent.Client
+	adjustments metereventadjustment.Client
+}
+
+// Ensure StripeMeter implements Meter.
+var _ Meter = (*StripeMeter)(nil)
+
+// NewStripeMeter creates a meter reporting eventName events with secretKey.
+func NewStripeMeter(secretKey, eventName string) *StripeMeter {
+	backend := stripe.GetBackend(stripe.APIBackend)
+	return &StripeMeter{
+		eventName:   eventName,
+		events:      meterevent.Client{B: backend, Key: secretKey},
+		adjustments: metereventadjustment.Client{B: backend, Key: secretKey},
+	}
+}
+
+// Report sends a meter event worth one image.
+func (m *StripeMeter) Report(ctx context.Context, stripeCustomerID, imageID string, at time.Time) error {
+	params := &stripe.BillingMeterEventParams{
+		EventName:  stripe.String(m.eventName),
+		Identifier: stripe.String(imageID),
+		Payload: map[string]string{
+			"stripe_customer_id": stripeCustomerID,
+			"value":              "1",
+		},
+	}
+	if !at.IsZero() {
+		params.Timestamp = stripe.Int64(at.Unix())
+	}
+	params.Context = ctx
+	_, err := m.events.New(params)
+	return err
+}
+
+// Cancel sends a cancel adjustment for the image's meter event.
+func (m *StripeMeter) Cancel(ctx context.Context, imageID string) error {
+	params := &stripe.BillingMeterEventAdjustmentParams{
+		EventName: stripe.String(m.eventName),
+		Type:      stripe.String("cancel"),
+		Cancel: &stripe.BillingMeterEventAdjustmentCancelParams{
+			Identifier: stripe.String(imageID),
+		},
+	}
+	params.Context = ctx
+	_, err := m.adjustments.New(params)
+	return err
+}
@@ -0,0 +1,21 @@
+// Package overage bills subscribers for images created past their plan's
+// monthly limit.
+//
+// Plans configured for overage let their subscribers keep creating images up
+// to a cap instead of being stopped at the limit. Each image past the limit is
+// reported to a Stripe billing meter, which prices it through a metered price
+// on the subscription, and is recorded here so the usage can be cancelled when
+// the image fails to stage.
+package overage
+
+import "time"
+
+// Usage is an image billed as overage.
+type Usage struct {
+	ImageID          string    `json:"image_id"`
+	UserID           string    `json:"user_id"`
+	StripeCustomerID string    `json:"stripe_customer_id"`
+	CreatedAt        time.Time `json:"created_at"`
+	// CancelledAt is when the usage was cancelled because the image failed.
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
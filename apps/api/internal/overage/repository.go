@@ -0,0 +1,17 @@
+package overage
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for the images billed as overage.
+type Repository interface {
+	// Record remembers that the image was reported as the customer's overage.
+	// It reports false, changing nothing, when the image was already recorded.
+	Record(ctx context.Context, userID, imageID, stripeCustomerID string) (bool, error)
+
+	// Cancel marks the image's usage cancelled and returns it. It returns nil,
+	// changing nothing, when the image wasn't billed as overage or was already
+	// cancelled.
+	Cancel(ctx context.Context, imageID string) (*Usage, error)
+}
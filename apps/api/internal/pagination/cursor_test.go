@@ -0,0 +1,38 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	t.Run("success: round trip keeps microseconds", func(t *testing.T) {
+		want := Cursor{CreatedAt: time.Date(2026, 3, 1, 12, 30, 0, 123456000, time.UTC), ID: uuid.New().String()}
+
+		got, err := Decode(want.Encode())
+		require.NoError(t, err)
+		assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+		assert.Equal(t, want.ID, got.ID)
+	})
+
+	cases := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "fail: not base64", cursor: "%%%"},
+		{name: "fail: no separator", cursor: base64.RawURLEncoding.EncodeToString([]byte("2026-03-01T12:00:00Z"))},
+		{name: "fail: bad time", cursor: base64.RawURLEncoding.EncodeToString([]byte("yesterday|" + uuid.NewString()))},
+		{name: "fail: bad id", cursor: base64.RawURLEncoding.EncodeToString([]byte("2026-03-01T12:00:00Z|42"))},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(tc.cursor)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package pagination
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// FromRequest reads the page from the limit and cursor query parameters.
+// A missing or unusable limit is DefaultLimit and a larger one is capped to
+// MaxLimit; an invalid cursor is ErrInvalidCursor.
+func FromRequest(c echo.Context) (Page, error) {
+	page := First()
+	if v := c.QueryParam("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			// #nosec G115 -- Value is capped to MaxLimit
+			page.Limit = int32(min(n, int(MaxLimit)))
+		}
+	}
+	if v := c.QueryParam("cursor"); v != "" {
+		after, err := Decode(v)
+		if err != nil {
+			return Page{}, err
+		}
+		page.After = after
+	}
+	return page, nil
+}
+
+// IntParam reads an integer query parameter, returning 0 when it is absent
+// and the strconv error when it is not a number. Offset-paged listings use it
+// for their limit and offset parameters.
+func IntParam(c echo.Context, name string) (int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
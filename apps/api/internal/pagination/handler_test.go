@@ -0,0 +1,76 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromRequest(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), ID: uuid.NewString()}
+
+	cases := []struct {
+		name      string
+		query     string
+		wantLimit int32
+		wantAfter bool
+		wantErr   error
+	}{
+		{name: "success: defaults", query: "", wantLimit: DefaultLimit},
+		{name: "success: limit", query: "?limit=10", wantLimit: 10},
+		{name: "success: limit is capped", query: "?limit=1000", wantLimit: MaxLimit},
+		{name: "success: unusable limit is ignored", query: "?limit=-3", wantLimit: DefaultLimit},
+		{name: "success: cursor", query: "?cursor=" + cursor.Encode(), wantLimit: DefaultLimit, wantAfter: true},
+		{name: "fail: invalid cursor", query: "?cursor=abc", wantErr: ErrInvalidCursor},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/"+tc.query, nil), httptest.NewRecorder())
+
+			page, err := FromRequest(c)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantLimit, page.Limit)
+			assert.Equal(t, tc.wantAfter, page.After != nil)
+			if tc.wantAfter {
+				assert.Equal(t, cursor.ID, page.After.ID)
+			}
+		})
+	}
+}
+
+func TestIntParam(t *testing.T) {
+	cases := []struct {
+		name    string
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{name: "success: absent is zero", query: "", want: 0},
+		{name: "success: number", query: "?offset=40", want: 40},
+		{name: "success: negative is returned as is", query: "?offset=-1", want: -1},
+		{name: "fail: not a number", query: "?offset=ten", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/"+tc.query, nil), httptest.NewRecorder())
+
+			got, err := IntParam(c, "offset")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package pagination pages list endpoints with opaque cursors. A cursor holds
+// the creation time and ID of the last item of a page, so the next page starts
+// right after it however many items were added in between.
+package pagination
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// DefaultLimit is the page size when the request doesn't set one.
+	DefaultLimit int32 = 50
+	// MaxLimit is the largest page size; larger limits are capped to it.
+	MaxLimit int32 = 100
+)
+
+// ErrInvalidCursor is returned for a cursor the API didn't issue.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of an item in a list sorted newest first, by
+// creation time then ID.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Page selects up to Limit items after a cursor.
+type Page struct {
+	Limit int32
+	// After is the last item of the previous page, nil for the first page.
+	After *Cursor
+}
+
+// First is the first page with the default size.
+func First() Page {
+	return Page{Limit: DefaultLimit}
+}
+
+// AfterArgs returns the cursor's creation time and ID as query arguments,
+// both nil on the first page. Queries filter with
+// `($n::timestamptz IS NULL OR (created_at, id) < ($n, $m::uuid))`.
+func (p Page) AfterArgs() (*time.Time, *string) {
+	if p.After == nil {
+		return nil, nil
+	}
+	return &p.After.CreatedAt, &p.After.ID
+}
+
+// AfterParams returns the cursor as sqlc query parameters, both NULL on the
+// first page.
+func (p Page) AfterParams() (pgtype.Timestamptz, pgtype.UUID) {
+	if p.After == nil {
+		return pgtype.Timestamptz{}, pgtype.UUID{}
+	}
+	id, err := uuid.Parse(p.After.ID)
+	return pgtype.Timestamptz{Time: p.After.CreatedAt, Valid: true}, pgtype.UUID{Bytes: id, Valid: err == nil}
+}
+
+// Fetch is the number of rows to query: one more than the page, to tell
+// whether another page follows.
+func (p Page) Fetch() int32 {
+	return p.Limit + 1
+}
+
+// Trim cuts rows queried with Fetch to the page and returns the cursor of the
+// next page, or nil when this is the last one.
+func Trim[T any](rows []T, p Page, cursor func(T) Cursor) ([]T, *string) {
+	if int32(len(rows)) <= p.Limit {
+		return rows, nil
+	}
+	rows = rows[:p.Limit]
+	next := cursor(rows[len(rows)-1]).Encode()
+	return rows, &next
+}
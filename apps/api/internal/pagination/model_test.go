@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrim(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	type item struct {
+		id string
+		at time.Time
+	}
+	cursorOf := func(i item) Cursor { return Cursor{CreatedAt: i.at, ID: i.id} }
+	items := []item{
+		{id: "00000000-0000-0000-0000-000000000003", at: created},
+		{id: "00000000-0000-0000-0000-000000000002", at: created.Add(-time.Minute)},
+		{id: "00000000-0000-0000-0000-000000000001", at: created.Add(-2 * time.Minute)},
+	}
+
+	t.Run("success: short page is the last", func(t *testing.T) {
+		got, next := Trim(items, Page{Limit: 3}, cursorOf)
+		assert.Len(t, got, 3)
+		assert.Nil(t, next)
+	})
+
+	t.Run("success: extra row is cut and gives the cursor", func(t *testing.T) {
+		got, next := Trim(items, Page{Limit: 2}, cursorOf)
+		assert.Equal(t, items[:2], got)
+		require.NotNil(t, next)
+		c, err := Decode(*next)
+		require.NoError(t, err)
+		assert.Equal(t, items[1].id, c.ID)
+		assert.True(t, items[1].at.Equal(c.CreatedAt))
+	})
+}
+
+func TestPage_AfterArgs(t *testing.T) {
+	t.Run("success: first page has no cursor", func(t *testing.T) {
+		at, id := First().AfterArgs()
+		assert.Nil(t, at)
+		assert.Nil(t, id)
+	})
+
+	t.Run("success: later page filters after the cursor", func(t *testing.T) {
+		after := &Cursor{CreatedAt: time.Now(), ID: "00000000-0000-0000-0000-000000000001"}
+		at, id := Page{Limit: 10, After: after}.AfterArgs()
+		assert.Equal(t, after.CreatedAt, *at)
+		assert.Equal(t, after.ID, *id)
+		assert.Equal(t, int32(11), Page{Limit: 10}.Fetch())
+	})
+}
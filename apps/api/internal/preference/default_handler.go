@@ -0,0 +1,127 @@
+package preference
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// maxRequestBytes bounds the PUT body. It leaves room for whitespace around
+// preferences that fit MaxTotalBytes once compacted.
+const maxRequestBytes = 4 * MaxTotalBytes
+
+// DefaultHandler serves the user preferences endpoints.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string       `json:"error"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// GetPreferences handles GET /api/v1/user/preferences.
+func (h *DefaultHandler) GetPreferences(c echo.Context) error {
+	userID, errResp := h.currentUserID(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	resp, err := h.service.Get(c.Request().Context(), userID)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to get preferences")
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpdatePreferences handles PUT /api/v1/user/preferences.
+func (h *DefaultHandler) UpdatePreferences(c echo.Context) error {
+	userID, errResp := h.currentUserID(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxRequestBytes)
+	var req UpdateRequest
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error:   "payload_too_large",
+				Message: "Request body is too large",
+			})
+		}
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+	if len(req.Preferences) == 0 {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "bad_request",
+			Message: "preferences must name at least one namespace",
+		})
+	}
+
+	resp, err := h.service.Update(c.Request().Context(), userID, req.Preferences)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to update preferences")
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *DefaultHandler) currentUserID(c echo.Context) (string, func() error) {
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", func() error {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Invalid or missing JWT token",
+			})
+		}
+	}
+
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return "", func() error {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "User not found"})
+		}
+	}
+	return userRow.ID.String(), nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	var invalid *ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Error:   "validation_failed",
+			Message: "Preferences do not match their namespace's schema",
+			Fields:  invalid.Fields,
+		})
+	case errors.Is(err, ErrTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Error: "payload_too_large", Message: err.Error()})
+	case errors.Is(err, ErrNotConfigured):
+		return c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "Preference storage is not configured",
+		})
+	}
+	h.log.Error(c.Request().Context(), message, "error", err)
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal_server_error", Message: message})
+}
@@ -0,0 +1,136 @@
+package preference
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newTestHandler(svc Service, userErr error) *DefaultHandler {
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			if userErr != nil {
+				return nil, userErr
+			}
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: uuid.MustParse(testUserID), Valid: true}}, nil
+		},
+	}
+	return NewDefaultHandler(svc, userRepo, logging.Default())
+}
+
+func newRequestContext(method, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/api/v1/user/preferences", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_GetPreferences(t *testing.T) {
+	cases := []struct {
+		name       string
+		userErr    error
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "success: returns preferences", wantStatus: http.StatusOK},
+		{name: "fail: unknown user", userErr: errors.New("no rows"), wantStatus: http.StatusUnauthorized},
+		{name: "fail: not configured", svcErr: ErrNotConfigured, wantStatus: http.StatusServiceUnavailable},
+		{name: "fail: service error", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetFunc: func(ctx context.Context, userID string) (*Response, error) {
+					assert.Equal(t, testUserID, userID)
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					return &Response{Preferences: Preferences{"gallery": json.RawMessage(`{"layout":"grid"}`)}}, nil
+				},
+			}
+			c, rec := newRequestContext(http.MethodGet, "")
+
+			require.NoError(t, newTestHandler(svc, tc.userErr).GetPreferences(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantStatus == http.StatusOK {
+				assert.JSONEq(t, `{"preferences":{"gallery":{"layout":"grid"}}}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_UpdatePreferences(t *testing.T) {
+	valid := `{"preferences":{"gallery":{"layout":"list"}}}`
+
+	cases := []struct {
+		name       string
+		body       string
+		svcErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "success: returns the stored set", body: valid, wantStatus: http.StatusOK, wantBody: `"layout":"list"`},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "fail: no namespaces", body: `{"preferences":{}}`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: body over the request limit",
+			body:       fmt.Sprintf(`{"preferences":{"ui":{"notes":%q}}}`, strings.Repeat("x", maxRequestBytes)),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "fail: schema violations",
+			body: valid,
+			svcErr: &ValidationError{Fields: []FieldError{
+				{Field: "gallery.layout", Message: "must be one of: grid, list, compare"},
+			}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `"fields":[{"field":"gallery.layout","message":"must be one of: grid, list, compare"}]`,
+		},
+		{
+			name:       "fail: namespace too large",
+			body:       valid,
+			svcErr:     fmt.Errorf("%w: ui is 5000 bytes, the limit is 4096", ErrTooLarge),
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   "ui is 5000 bytes",
+		},
+		{name: "fail: not configured", body: valid, svcErr: ErrNotConfigured, wantStatus: http.StatusServiceUnavailable},
+		{name: "fail: service error", body: valid, svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				UpdateFunc: func(ctx context.Context, userID string, prefs Preferences) (*Response, error) {
+					if tc.svcErr != nil {
+						return nil, tc.svcErr
+					}
+					return &Response{Preferences: prefs}, nil
+				},
+			}
+			c, rec := newRequestContext(http.MethodPut, tc.body)
+
+			require.NoError(t, newTestHandler(svc, nil).UpdatePreferences(c))
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			if tc.wantBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package preference
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// List returns the user's stored namespaces.
+func (r *DefaultRepository) List(ctx context.Context, userID string) ([]Record, error) {
+	query := `
+		SELECT namespace, ciphertext, updated_at
+		FROM user_preferences
+		WHERE user_id = $1
+		ORDER BY namespace`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preferences: %w", err)
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		var rec Record
+		if err := rows.Scan(&rec.Namespace, &rec.Ciphertext, &rec.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan preference: %w", err)
+		}
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over preference rows: %w", err)
+	}
+	return records, nil
+}
+
+// Save upserts and deletes in a single statement, so a request that changes
+// several namespaces applies all of its changes or none.
+func (r *DefaultRepository) Save(ctx context.Context, userID string, records []Record, remove []string) error {
+	namespaces := make([]string, len(records))
+	ciphertexts := make([][]byte, len(records))
+	for i, rec := range records {
+		namespaces[i] = rec.Namespace
+		ciphertexts[i] = rec.Ciphertext
+	}
+	if remove == nil {
+		remove = []string{}
+	}
+
+	query := `
+		WITH removed AS (
+			DELETE FROM user_preferences
+			WHERE user_id = $1 AND namespace = ANY($2::text[])
+		)
+		INSERT INTO user_preferences (user_id, namespace, ciphertext)
+		SELECT $1, u.namespace, u.ciphertext
+		FROM unnest($3::text[], $4::bytea[]) AS u(namespace, ciphertext)
+		ON CONFLICT (user_id, namespace)
+		DO UPDATE SET ciphertext = EXCLUDED.ciphertext, updated_at = now()`
+	if _, err := r.db.Exec(ctx, query, userID, remove, namespaces, ciphertexts); err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
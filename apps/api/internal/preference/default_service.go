@@ -0,0 +1,176 @@
+package preference
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/real-staging-ai/api/encryption"
+	"github.com/real-staging-ai/api/internal/settings"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo    Repository
+	keyring *encryption.Keyring
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. keyring may be nil when no
+// encryption key is configured; every call then returns ErrNotConfigured.
+func NewDefaultService(repo Repository, keyring *encryption.Keyring) *DefaultService {
+	return &DefaultService{repo: repo, keyring: keyring}
+}
+
+// Get decrypts the user's stored namespaces. Namespaces no longer in
+// Namespaces are left out.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Response, error) {
+	if s.keyring == nil {
+		return nil, ErrNotConfigured
+	}
+	records, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Response{Preferences: Preferences{}}
+	for _, rec := range records {
+		if _, ok := Namespaces[rec.Namespace]; !ok {
+			continue
+		}
+		plaintext, err := s.keyring.Open(rec.Ciphertext, aad(userID, rec.Namespace))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s preferences: %w", rec.Namespace, err)
+		}
+		resp.Preferences[rec.Namespace] = plaintext
+		if resp.UpdatedAt == nil || rec.UpdatedAt.After(*resp.UpdatedAt) {
+			updatedAt := rec.UpdatedAt
+			resp.UpdatedAt = &updatedAt
+		}
+	}
+	return resp, nil
+}
+
+// Update validates every namespace before storing any, so one bad namespace
+// leaves the others unchanged too.
+func (s *DefaultService) Update(ctx context.Context, userID string, prefs Preferences) (*Response, error) {
+	if s.keyring == nil {
+		return nil, ErrNotConfigured
+	}
+
+	namespaces := make([]string, 0, len(prefs))
+	for ns := range prefs {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	var fieldErrs []FieldError
+	set := Preferences{}
+	var remove []string
+	for _, ns := range namespaces {
+		schema, ok := Namespaces[ns]
+		if !ok {
+			fieldErrs = append(fieldErrs, FieldError{Field: ns, Message: "unknown namespace"})
+			continue
+		}
+		raw := prefs[ns]
+		if raw == nil || string(raw) == "null" {
+			remove = append(remove, ns)
+			continue
+		}
+		if errs := schema.validate(ns, raw); len(errs) > 0 {
+			fieldErrs = append(fieldErrs, errs...)
+			continue
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			fieldErrs = append(fieldErrs, FieldError{Field: ns, Message: "must be a JSON object"})
+			continue
+		}
+		if compact.Len() > MaxNamespaceBytes {
+			return nil, fmt.Errorf("%w: %s is %d bytes, the limit is %d",
+				ErrTooLarge, ns, compact.Len(), MaxNamespaceBytes)
+		}
+		set[ns] = compact.Bytes()
+	}
+	if len(fieldErrs) > 0 {
+		return nil, &ValidationError{Fields: fieldErrs}
+	}
+
+	current, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	for ns, raw := range current.Preferences {
+		if _, changed := prefs[ns]; !changed {
+			total += len(raw)
+		}
+	}
+	for _, raw := range set {
+		total += len(raw)
+	}
+	if total > MaxTotalBytes {
+		return nil, fmt.Errorf("%w: preferences would total %d bytes, the limit is %d",
+			ErrTooLarge, total, MaxTotalBytes)
+	}
+
+	records := make([]Record, 0, len(set))
+	for _, ns := range namespaces {
+		raw, ok := set[ns]
+		if !ok {
+			continue
+		}
+		ciphertext, err := s.keyring.Seal(raw, aad(userID, ns))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt %s preferences: %w", ns, err)
+		}
+		records = append(records, Record{Namespace: ns, Ciphertext: ciphertext})
+	}
+	if err := s.repo.Save(ctx, userID, records, remove); err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, userID)
+}
+
+// PreferredModel reads model_id from the staging namespace. A model that has
+// since left the catalog counts as no preference.
+func (s *DefaultService) PreferredModel(ctx context.Context, userID string) (string, error) {
+	if s.keyring == nil {
+		return "", nil
+	}
+	records, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	for _, rec := range records {
+		if rec.Namespace != "staging" {
+			continue
+		}
+		plaintext, err := s.keyring.Open(rec.Ciphertext, aad(userID, rec.Namespace))
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt %s preferences: %w", rec.Namespace, err)
+		}
+		var staging struct {
+			ModelID string `json:"model_id"`
+		}
+		if err := json.Unmarshal(plaintext, &staging); err != nil {
+			return "", fmt.Errorf("failed to decode %s preferences: %w", rec.Namespace, err)
+		}
+		if !settings.IsAvailableModel(staging.ModelID) {
+			return "", nil
+		}
+		return staging.ModelID, nil
+	}
+	return "", nil
+}
+
+// aad binds a ciphertext to its owner and namespace, so rows can't be
+// swapped between users or namespaces in the database.
+func aad(userID, namespace string) []byte {
+	return []byte("user_preferences:" + userID + ":" + namespace)
+}
@@ -0,0 +1,268 @@
+package preference
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/encryption"
+	"github.com/real-staging-ai/api/internal/settings"
+)
+
+const testUserID = "6f1c2a9e-5b7d-4a3c-9e8f-1a2b3c4d5e6f"
+
+func testKeyring(t *testing.T) *encryption.Keyring {
+	t.Helper()
+	kr, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, encryption.KeySize)))
+	require.NoError(t, err)
+	return kr
+}
+
+// memoryRepo is a RepositoryMock backed by a map, keeping ciphertexts as the
+// service wrote them.
+func memoryRepo(t *testing.T, now time.Time) (*RepositoryMock, map[string][]byte) {
+	t.Helper()
+	stored := map[string][]byte{}
+	repo := &RepositoryMock{
+		ListFunc: func(ctx context.Context, userID string) ([]Record, error) {
+			assert.Equal(t, testUserID, userID)
+			var out []Record
+			for ns, c := range stored {
+				out = append(out, Record{Namespace: ns, Ciphertext: c, UpdatedAt: now})
+			}
+			return out, nil
+		},
+		SaveFunc: func(ctx context.Context, userID string, records []Record, remove []string) error {
+			for _, ns := range remove {
+				delete(stored, ns)
+			}
+			for _, r := range records {
+				stored[r.Namespace] = r.Ciphertext
+			}
+			return nil
+		},
+	}
+	return repo, stored
+}
+
+func TestDefaultService_Update(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success: stores namespaces encrypted and returns the full set", func(t *testing.T) {
+		repo, stored := memoryRepo(t, now)
+		kr := testKeyring(t)
+		svc := NewDefaultService(repo, kr)
+
+		_, err := svc.Update(context.Background(), testUserID, Preferences{
+			"gallery": json.RawMessage(`{"layout": "grid", "page_size": 24}`),
+		})
+		require.NoError(t, err)
+		resp, err := svc.Update(context.Background(), testUserID, Preferences{
+			"staging": json.RawMessage(`{"default_style":"scandinavian"}`),
+			"ui":      json.RawMessage(`{"sidebar":{"collapsed":true}}`),
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"layout":"grid","page_size":24}`, string(resp.Preferences["gallery"]))
+		assert.JSONEq(t, `{"default_style":"scandinavian"}`, string(resp.Preferences["staging"]))
+		assert.JSONEq(t, `{"sidebar":{"collapsed":true}}`, string(resp.Preferences["ui"]))
+		require.NotNil(t, resp.UpdatedAt)
+		assert.Equal(t, now, *resp.UpdatedAt)
+
+		require.Len(t, stored, 3)
+		assert.NotContains(t, string(stored["staging"]), "scandinavian", "stored encrypted")
+		_, err = kr.Open(stored["staging"], aad("another-user", "staging"))
+		assert.Error(t, err, "ciphertexts are bound to their owner")
+	})
+
+	t.Run("success: null deletes a namespace", func(t *testing.T) {
+		repo, stored := memoryRepo(t, now)
+		svc := NewDefaultService(repo, testKeyring(t))
+
+		_, err := svc.Update(context.Background(), testUserID, Preferences{
+			"gallery":       json.RawMessage(`{"layout":"list"}`),
+			"notifications": json.RawMessage(`{"email_on_ready":false}`),
+		})
+		require.NoError(t, err)
+		resp, err := svc.Update(context.Background(), testUserID, Preferences{"gallery": json.RawMessage(`null`)})
+		require.NoError(t, err)
+
+		assert.NotContains(t, resp.Preferences, "gallery")
+		assert.Contains(t, resp.Preferences, "notifications")
+		assert.NotContains(t, stored, "gallery")
+	})
+
+	t.Run("fail: schema violations are reported per field and nothing is saved", func(t *testing.T) {
+		repo, _ := memoryRepo(t, now)
+		svc := NewDefaultService(repo, testKeyring(t))
+
+		_, err := svc.Update(context.Background(), testUserID, Preferences{
+			"gallery":       json.RawMessage(`{"layout":"carousel","page_size":1000,"columns":3}`),
+			"notifications": json.RawMessage(`{"email_on_ready":"yes","email_on_error":null}`),
+			"staging":       json.RawMessage(`{"default_style":"modern"}`),
+			"theme":         json.RawMessage(`{"dark":true}`),
+			"ui":            json.RawMessage(`["not","an","object"]`),
+		})
+
+		var invalid *ValidationError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, []FieldError{
+			{Field: "gallery.columns", Message: "unknown preference"},
+			{Field: "gallery.layout", Message: "must be one of: grid, list, compare"},
+			{Field: "gallery.page_size", Message: "must be between 10 and 100"},
+			{Field: "notifications.email_on_error", Message: "must not be null"},
+			{Field: "notifications.email_on_ready", Message: "must be a boolean"},
+			{Field: "theme", Message: "unknown namespace"},
+			{Field: "ui", Message: "must be a JSON object"},
+		}, invalid.Fields)
+		assert.Empty(t, repo.SaveCalls())
+	})
+
+	t.Run("fail: namespace over its size limit", func(t *testing.T) {
+		repo, _ := memoryRepo(t, now)
+		svc := NewDefaultService(repo, testKeyring(t))
+
+		big := `{"notes":"` + strings.Repeat("x", MaxNamespaceBytes) + `"}`
+		_, err := svc.Update(context.Background(), testUserID, Preferences{"ui": json.RawMessage(big)})
+		assert.ErrorIs(t, err, ErrTooLarge)
+		assert.ErrorContains(t, err, "ui is 4108 bytes, the limit is 4096")
+	})
+
+	t.Run("fail: total over the size limit", func(t *testing.T) {
+		repo, _ := memoryRepo(t, now)
+		kr := testKeyring(t)
+		svc := NewDefaultService(repo, kr)
+
+		// Start from a stored namespace just under the total limit.
+		stored := []byte(`{"notes":"` + strings.Repeat("y", MaxTotalBytes-40) + `"}`)
+		ciphertext, err := kr.Seal(stored, aad(testUserID, "ui"))
+		require.NoError(t, err)
+		repo.ListFunc = func(ctx context.Context, userID string) ([]Record, error) {
+			return []Record{{Namespace: "ui", Ciphertext: ciphertext, UpdatedAt: now}}, nil
+		}
+
+		_, err = svc.Update(context.Background(), testUserID, Preferences{
+			"gallery": json.RawMessage(`{"layout":"grid","sort":"newest","page_size":50,"show_originals":true}`),
+		})
+		assert.ErrorIs(t, err, ErrTooLarge)
+		assert.Empty(t, repo.SaveCalls())
+
+		_, err = svc.Update(context.Background(), testUserID, Preferences{
+			"ui":      json.RawMessage(`{}`),
+			"gallery": json.RawMessage(`{"layout":"grid"}`),
+		})
+		assert.NoError(t, err, "replacing the large namespace frees its space")
+	})
+
+	t.Run("fail: not configured", func(t *testing.T) {
+		svc := NewDefaultService(&RepositoryMock{}, nil)
+		_, err := svc.Update(context.Background(), testUserID, Preferences{"ui": json.RawMessage(`{}`)})
+		assert.ErrorIs(t, err, ErrNotConfigured)
+	})
+
+	t.Run("fail: save error", func(t *testing.T) {
+		repo, _ := memoryRepo(t, now)
+		repo.SaveFunc = func(ctx context.Context, userID string, records []Record, remove []string) error {
+			return errors.New("db down")
+		}
+		svc := NewDefaultService(repo, testKeyring(t))
+		_, err := svc.Update(context.Background(), testUserID, Preferences{"ui": json.RawMessage(`{}`)})
+		assert.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestDefaultService_Get(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("success: empty before the first save", func(t *testing.T) {
+		repo, _ := memoryRepo(t, now)
+		resp, err := NewDefaultService(repo, testKeyring(t)).Get(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, &Response{Preferences: Preferences{}}, resp)
+	})
+
+	t.Run("success: retired namespaces are left out", func(t *testing.T) {
+		kr := testKeyring(t)
+		c, err := kr.Seal([]byte(`{}`), aad(testUserID, "retired"))
+		require.NoError(t, err)
+		repo := &RepositoryMock{ListFunc: func(ctx context.Context, userID string) ([]Record, error) {
+			return []Record{{Namespace: "retired", Ciphertext: c, UpdatedAt: now}}, nil
+		}}
+		resp, err := NewDefaultService(repo, kr).Get(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Empty(t, resp.Preferences)
+	})
+
+	t.Run("fail: sealed with an unknown key", func(t *testing.T) {
+		other, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, 32)))
+		require.NoError(t, err)
+		c, err := other.Seal([]byte(`{}`), aad(testUserID, "ui"))
+		require.NoError(t, err)
+		repo := &RepositoryMock{ListFunc: func(ctx context.Context, userID string) ([]Record, error) {
+			return []Record{{Namespace: "ui", Ciphertext: c, UpdatedAt: now}}, nil
+		}}
+		_, err = NewDefaultService(repo, testKeyring(t)).Get(context.Background(), testUserID)
+		assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+	})
+}
+
+func TestDefaultService_PreferredModel(t *testing.T) {
+	settings.SetCatalog([]settings.ModelInfo{
+		{ID: "bytedance/seedream-4", Enabled: true},
+		{ID: "acme/retired"},
+	})
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sealed := func(t *testing.T, kr *encryption.Keyring, staging string) *RepositoryMock {
+		c, err := kr.Seal([]byte(staging), aad(testUserID, "staging"))
+		require.NoError(t, err)
+		return &RepositoryMock{ListFunc: func(ctx context.Context, userID string) ([]Record, error) {
+			return []Record{{Namespace: "staging", Ciphertext: c, UpdatedAt: now}}, nil
+		}}
+	}
+
+	t.Run("success: returns the picked model", func(t *testing.T) {
+		kr := testKeyring(t)
+		repo := sealed(t, kr, `{"default_style":"modern","model_id":"bytedance/seedream-4"}`)
+		model, err := NewDefaultService(repo, kr).PreferredModel(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, "bytedance/seedream-4", model)
+	})
+
+	t.Run("success: no model picked", func(t *testing.T) {
+		kr := testKeyring(t)
+		model, err := NewDefaultService(sealed(t, kr, `{"default_style":"modern"}`), kr).
+			PreferredModel(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Empty(t, model)
+	})
+
+	t.Run("success: a model no longer offered is ignored", func(t *testing.T) {
+		kr := testKeyring(t)
+		model, err := NewDefaultService(sealed(t, kr, `{"model_id":"acme/retired"}`), kr).
+			PreferredModel(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Empty(t, model)
+	})
+
+	t.Run("success: storage not configured", func(t *testing.T) {
+		model, err := NewDefaultService(&RepositoryMock{}, nil).PreferredModel(context.Background(), testUserID)
+		require.NoError(t, err)
+		assert.Empty(t, model)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{ListFunc: func(ctx context.Context, userID string) ([]Record, error) {
+			return nil, errors.New("db down")
+		}}
+		_, err := NewDefaultService(repo, testKeyring(t)).PreferredModel(context.Background(), testUserID)
+		assert.ErrorContains(t, err, "db down")
+	})
+}
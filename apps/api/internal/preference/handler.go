@@ -0,0 +1,11 @@
+package preference
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP handlers for user preferences.
+type Handler interface {
+	GetPreferences(c echo.Context) error
+	UpdatePreferences(c echo.Context) error
+}
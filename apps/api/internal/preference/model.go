@@ -0,0 +1,196 @@
+// Package preference stores per-user UI settings (default staging style,
+// gallery layout, notification toggles) server-side, so they follow users
+// across browsers and devices instead of living in localStorage.
+//
+// Preferences are grouped into namespaces. Each namespace has a schema that
+// its JSON object is validated against, and is encrypted at rest with the
+// API's encryption keyring.
+package preference
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/promptlib"
+	"github.com/real-staging-ai/api/internal/settings"
+)
+
+// Size limits, measured on the compact JSON of each namespace.
+const (
+	// MaxNamespaceBytes bounds one namespace's object.
+	MaxNamespaceBytes = 4 << 10
+	// MaxTotalBytes bounds all of a user's namespaces together.
+	MaxTotalBytes = 16 << 10
+)
+
+var (
+	// ErrNotConfigured is returned when no encryption key is configured.
+	ErrNotConfigured = errors.New("preference storage is not configured")
+	// ErrTooLarge is returned when a namespace or the whole set is over its size limit.
+	ErrTooLarge = errors.New("preferences too large")
+)
+
+// ValidationError lists what is wrong with submitted preferences.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// FieldError is one invalid value. Field is "namespace" or "namespace.key".
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+	return "invalid preferences: " + strings.Join(msgs, "; ")
+}
+
+// Preferences maps each namespace the user has set to its JSON object.
+type Preferences map[string]json.RawMessage
+
+// Response is the body of GET and PUT /api/v1/user/preferences.
+type Response struct {
+	Preferences Preferences `json:"preferences"`
+	// UpdatedAt is when any namespace last changed; nil before the first save.
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateRequest is the body of PUT /api/v1/user/preferences. Each namespace
+// given replaces the stored one; null deletes it. Namespaces left out are
+// kept.
+type UpdateRequest struct {
+	Preferences Preferences `json:"preferences"`
+}
+
+// Record is one encrypted namespace as stored.
+type Record struct {
+	Namespace  string
+	Ciphertext []byte
+	UpdatedAt  time.Time
+}
+
+// Kind is the JSON type a preference key holds.
+type Kind string
+
+const (
+	KindString Kind = "string"
+	KindBool   Kind = "boolean"
+	KindInt    Kind = "integer"
+)
+
+// Field describes one key of a namespace.
+type Field struct {
+	Kind Kind
+	// Enum, for strings, lists the accepted values.
+	Enum []string
+	// EnumFunc, when set, lists them instead, for values that change at run
+	// time.
+	EnumFunc func() []string
+	// Min and Max bound integers.
+	Min, Max int
+}
+
+// Schema describes a namespace. An open schema accepts any JSON object,
+// for client-side state the API doesn't interpret.
+type Schema struct {
+	Fields map[string]Field
+	Open   bool
+}
+
+// Namespaces are the namespaces users can store. Others are rejected.
+var Namespaces = map[string]Schema{
+	"staging": {Fields: map[string]Field{
+		"default_style":     {Kind: KindString, EnumFunc: promptlib.Styles},
+		"default_room_type": {Kind: KindString, EnumFunc: promptlib.RoomTypes},
+		"model_id":          {Kind: KindString, EnumFunc: settings.AvailableModelIDs},
+	}},
+	"gallery": {Fields: map[string]Field{
+		"layout":         {Kind: KindString, Enum: []string{"grid", "list", "compare"}},
+		"sort":           {Kind: KindString, Enum: []string{"newest", "oldest", "status"}},
+		"page_size":      {Kind: KindInt, Min: 10, Max: 100},
+		"show_originals": {Kind: KindBool},
+	}},
+	"notifications": {Fields: map[string]Field{
+		"email_on_ready":   {Kind: KindBool},
+		"email_on_error":   {Kind: KindBool},
+		"browser_on_ready": {Kind: KindBool},
+		"weekly_summary":   {Kind: KindBool},
+	}},
+	"ui": {Open: true},
+}
+
+// validate checks raw against the namespace's schema and returns the
+// problems found, prefixed with the namespace.
+func (s Schema) validate(namespace string, raw json.RawMessage) []FieldError {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil || obj == nil {
+		return []FieldError{{Field: namespace, Message: "must be a JSON object"}}
+	}
+	if s.Open {
+		return nil
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []FieldError
+	for _, k := range keys {
+		name := namespace + "." + k
+		f, ok := s.Fields[k]
+		if !ok {
+			errs = append(errs, FieldError{Field: name, Message: "unknown preference"})
+			continue
+		}
+		if msg := f.check(obj[k]); msg != "" {
+			errs = append(errs, FieldError{Field: name, Message: msg})
+		}
+	}
+	return errs
+}
+
+// check returns why v is not a valid value for f, or "".
+func (f Field) check(v json.RawMessage) string {
+	if string(v) == "null" {
+		return "must not be null"
+	}
+	switch f.Kind {
+	case KindString:
+		var s string
+		if err := json.Unmarshal(v, &s); err != nil {
+			return "must be a string"
+		}
+		enum, restricted := f.Enum, len(f.Enum) > 0
+		if f.EnumFunc != nil {
+			enum, restricted = f.EnumFunc(), true
+		}
+		if restricted && !slices.Contains(enum, s) {
+			return "must be one of: " + strings.Join(enum, ", ")
+		}
+	case KindBool:
+		var b bool
+		if err := json.Unmarshal(v, &b); err != nil {
+			return "must be a boolean"
+		}
+	case KindInt:
+		var n int
+		if err := json.Unmarshal(v, &n); err != nil {
+			return "must be an integer"
+		}
+		if n < f.Min || n > f.Max {
+			return fmt.Sprintf("must be between %d and %d", f.Min, f.Max)
+		}
+	}
+	return ""
+}
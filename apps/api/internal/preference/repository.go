@@ -0,0 +1,14 @@
+package preference
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for encrypted preferences.
+type Repository interface {
+	// List returns the user's stored namespaces, ordered by namespace.
+	List(ctx context.Context, userID string) ([]Record, error)
+
+	// Save upserts records and deletes the namespaces in remove, atomically.
+	Save(ctx context.Context, userID string, records []Record, remove []string) error
+}
@@ -0,0 +1,22 @@
+package preference
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for user preferences.
+type Service interface {
+	// Get returns the user's preferences. Returns ErrNotConfigured when no
+	// encryption key is set.
+	Get(ctx context.Context, userID string) (*Response, error)
+
+	// Update validates and stores the given namespaces, deleting those set to
+	// null, and returns the full set. Returns a *ValidationError for values
+	// that don't match their namespace's schema and ErrTooLarge when a size
+	// limit is exceeded.
+	Update(ctx context.Context, userID string, prefs Preferences) (*Response, error)
+
+	// PreferredModel returns the staging model the user picked in the staging
+	// namespace, or "" when they haven't picked one or storage isn't configured.
+	PreferredModel(ctx context.Context, userID string) (string, error)
+}
@@ -0,0 +1,79 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// Write sends a problem with the given status, code and detail.
+func Write(c echo.Context, status int, code, detail string) error {
+	return Send(c, New(status, code, detail))
+}
+
+// Send sends p, filling in the request path as its instance.
+func Send(c echo.Context, p *Problem) error {
+	if p.Instance == "" {
+		p.Instance = c.Request().URL.Path
+	}
+	body, err := p.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return c.Blob(p.Status, MediaType, body)
+}
+
+// From converts err into a problem. Problems are kept; echo.HTTPErrors, as
+// returned by middleware and the router, get the generic code of their
+// status. Any other error is an internal error whose text is not disclosed.
+func From(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		if inner, ok := he.Message.(*Problem); ok {
+			return inner
+		}
+		detail := http.StatusText(he.Code)
+		switch m := he.Message.(type) {
+		case string:
+			detail = m
+		case error:
+			detail = m.Error()
+		case nil:
+		default:
+			detail = fmt.Sprint(m)
+		}
+		return New(he.Code, CodeFor(he.Code), detail)
+	}
+	return New(http.StatusInternalServerError, CodeInternal, "Internal server error")
+}
+
+// ErrorHandler is the Echo error handler: it writes every error a handler or
+// middleware returns as a problem, and logs server errors.
+func ErrorHandler(log logging.Logger) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+		p := From(err)
+		if p.Status >= http.StatusInternalServerError {
+			log.Error(c.Request().Context(), "request failed", "error", err, "path", c.Request().URL.Path)
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(p.Status)
+		} else {
+			err = Send(c, p)
+		}
+		if err != nil {
+			log.Error(c.Request().Context(), "failed to write error response", "error", err)
+		}
+	}
+}
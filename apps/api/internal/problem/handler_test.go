@@ -0,0 +1,134 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestFrom(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "success: problem is kept",
+			err:        New(http.StatusPaymentRequired, "usage_limit_exceeded", "Limit reached"),
+			wantStatus: http.StatusPaymentRequired,
+			wantCode:   "usage_limit_exceeded",
+			wantDetail: "Limit reached",
+		},
+		{
+			name:       "success: wrapped problem is kept",
+			err:        fmt.Errorf("create image: %w", New(http.StatusConflict, "conflict", "Already exists")),
+			wantStatus: http.StatusConflict,
+			wantCode:   "conflict",
+			wantDetail: "Already exists",
+		},
+		{
+			name:       "success: echo error with a message",
+			err:        echo.NewHTTPError(http.StatusUnauthorized, "missing or malformed jwt"),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   CodeUnauthorized,
+			wantDetail: "missing or malformed jwt",
+		},
+		{
+			name:       "success: router error",
+			err:        echo.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+			wantDetail: "Not Found",
+		},
+		{
+			name:       "success: echo error with a map message",
+			err:        echo.NewHTTPError(http.StatusBadRequest, map[string]string{"message": "bad"}),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeBadRequest,
+			wantDetail: "map[message:bad]",
+		},
+		{
+			name:       "fail: other errors are not disclosed",
+			err:        errors.New("pq: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+			wantDetail: "Internal server error",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := From(tc.err)
+			assert.Equal(t, tc.wantStatus, p.Status)
+			assert.Equal(t, tc.wantCode, p.Code)
+			assert.Equal(t, tc.wantDetail, p.Detail)
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	newServer := func(handler echo.HandlerFunc) *echo.Echo {
+		e := echo.New()
+		e.HTTPErrorHandler = ErrorHandler(logging.Default())
+		e.Any("/api/v1/things", handler)
+		return e
+	}
+
+	t.Run("success: returned problem is written", func(t *testing.T) {
+		e := newServer(func(c echo.Context) error {
+			return New(http.StatusNotFound, CodeNotFound, "Thing not found")
+		})
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/things", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MediaType, rec.Header().Get(echo.HeaderContentType))
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, CodeNotFound, body["code"])
+		assert.Equal(t, "Thing not found", body["detail"])
+		assert.Equal(t, "/api/v1/things", body["instance"])
+	})
+
+	t.Run("success: unknown route is a problem", func(t *testing.T) {
+		e := newServer(func(c echo.Context) error { return nil })
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MediaType, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("success: HEAD gets no body", func(t *testing.T) {
+		e := newServer(func(c echo.Context) error {
+			return errors.New("boom")
+		})
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/api/v1/things", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("success: committed response is left alone", func(t *testing.T) {
+		e := newServer(func(c echo.Context) error {
+			_ = c.String(http.StatusOK, "partial")
+			return errors.New("stream broke")
+		})
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/things", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "partial", rec.Body.String())
+	})
+}
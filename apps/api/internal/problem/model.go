@@ -0,0 +1,93 @@
+// Package problem writes API errors as RFC 7807 problem details, with a
+// machine-readable code clients can switch on. Handlers write problems with
+// Write or return them as errors; ErrorHandler turns every other error that
+// reaches Echo into one.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// MediaType is the content type of problem responses.
+const MediaType = "application/problem+json"
+
+// Codes shared by several handlers. Handlers may use codes of their own.
+const (
+	CodeBadRequest       = "bad_request"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"
+	CodeValidationFailed = "validation_failed"
+	CodeInternal         = "internal_server_error"
+	CodeInvalidCursor    = "invalid_cursor"
+)
+
+// Problem is an API error. It is an error itself, so handlers can return it.
+type Problem struct {
+	// Type is "about:blank": problems are told apart by Code.
+	Type string `json:"type"`
+	// Title is the HTTP status text.
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Detail is the human-readable, localized explanation.
+	Detail string `json:"detail,omitempty"`
+	// Instance is the path of the request that failed.
+	Instance string `json:"instance,omitempty"`
+	// Code is the machine-readable error code, like "usage_limit_exceeded".
+	Code string `json:"code"`
+	// Extensions are extra members, like the invalid fields of a request.
+	Extensions map[string]any `json:"-"`
+}
+
+// New returns a problem with the given status, code and detail.
+func New(status int, code, detail string) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Code: code}
+}
+
+// With adds an extension member to p and returns p.
+func (p *Problem) With(name string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = map[string]any{}
+	}
+	p.Extensions[name] = value
+	return p
+}
+
+// Error returns the code and detail.
+func (p *Problem) Error() string {
+	return p.Code + ": " + p.Detail
+}
+
+// MarshalJSON writes the standard members, the extensions and, for clients
+// written against the earlier error shape, the code as "error" and the detail
+// as "message".
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	body := make(map[string]any, len(p.Extensions)+8)
+	for k, v := range p.Extensions {
+		body[k] = v
+	}
+	body["type"] = p.Type
+	body["title"] = p.Title
+	body["status"] = p.Status
+	body["code"] = p.Code
+	body["error"] = p.Code
+	body["message"] = p.Detail
+	if p.Detail != "" {
+		body["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		body["instance"] = p.Instance
+	}
+	return json.Marshal(body)
+}
+
+// CodeFor returns the generic code of an HTTP status, like "not_found" for 404.
+func CodeFor(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return CodeInternal
+	}
+	return strings.ToLower(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
@@ -0,0 +1,62 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	t.Run("success: standard members, extensions and legacy aliases", func(t *testing.T) {
+		p := New(http.StatusUnprocessableEntity, CodeValidationFailed, "The provided data is invalid").
+			With("validation_errors", []map[string]string{{"field": "name", "message": "name is required"}})
+		p.Instance = "/api/v1/projects"
+
+		body, err := json.Marshal(p)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "The provided data is invalid",
+			"instance": "/api/v1/projects",
+			"code": "validation_failed",
+			"error": "validation_failed",
+			"message": "The provided data is invalid",
+			"validation_errors": [{"field": "name", "message": "name is required"}]
+		}`, string(body))
+	})
+
+	t.Run("success: standard members win over extensions", func(t *testing.T) {
+		p := New(http.StatusNotFound, CodeNotFound, "Project not found").With("status", 200)
+
+		body, err := json.Marshal(p)
+		require.NoError(t, err)
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Equal(t, float64(http.StatusNotFound), got["status"])
+		assert.NotContains(t, got, "instance")
+	})
+}
+
+func TestCodeFor(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		want   string
+	}{
+		{name: "success: not found", status: http.StatusNotFound, want: CodeNotFound},
+		{name: "success: multi-word status", status: http.StatusTooManyRequests, want: "too_many_requests"},
+		{name: "success: hyphenated status", status: http.StatusNonAuthoritativeInfo, want: "non_authoritative_information"},
+		{name: "success: apostrophe is dropped", status: http.StatusTeapot, want: "im_a_teapot"},
+		{name: "fail: unknown status", status: 599, want: CodeInternal},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, CodeFor(tc.status))
+		})
+	}
+}
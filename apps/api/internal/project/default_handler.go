@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -12,6 +14,9 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/pagination"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/settings"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/user"
 )
@@ -30,54 +35,34 @@ func NewDefaultHandler(db storage.Database) *DefaultHandler {
 // Ensure DefaultHandler implements Handler.
 var _ Handler = (*DefaultHandler)(nil)
 
-// ErrorResponse represents an error response.
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-}
-
 // ValidationErrorDetail represents a validation error for a specific field.
 type ValidationErrorDetail struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
-// ValidationErrorResponse represents a validation error response.
-type ValidationErrorResponse struct {
-	Error            string                  `json:"error"`
-	Message          string                  `json:"message"`
-	ValidationErrors []ValidationErrorDetail `json:"validation_errors"`
-}
-
 // ProjectListResponse is the response envelope for list endpoints.
 type ProjectListResponse struct {
 	Projects []Project `json:"projects"`
+	// NextCursor fetches the next page; nil on the last page.
+	NextCursor *string `json:"next_cursor"`
 }
 
 // Create handles POST /api/v1/projects
 func (h *DefaultHandler) Create(c echo.Context) error {
 	var req CreateRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
 	}
 
 	if validationErrs := validateCreateProjectRequest(&req); len(validationErrs) > 0 {
-		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
-			Error:            "validation_failed",
-			Message:          "The provided data is invalid",
-			ValidationErrors: validationErrs,
-		})
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			"The provided data is invalid").With("validation_errors", validationErrs))
 	}
 
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
 	}
 
 	uRepo := user.NewDefaultRepository(h.db)
@@ -90,18 +75,12 @@ func (h *DefaultHandler) Create(c echo.Context) error {
 			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
 			if err != nil {
 				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
+				return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to create user")
 			}
 			userID = newUser.ID
 		} else {
 			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get user")
 		}
 	} else {
 		userID = existingUser.ID
@@ -112,29 +91,22 @@ func (h *DefaultHandler) Create(c echo.Context) error {
 	repo := NewDefaultRepository(h.db)
 	created, err := repo.CreateProject(c.Request().Context(), &p, userID.String())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   fmt.Sprintf("internal_server_error > %v", err),
-			Message: "Failed to create project",
-		})
+		return problem.Write(c, http.StatusInternalServerError, fmt.Sprintf("internal_server_error > %v", err),
+			"Failed to create project")
 	}
 
 	return c.JSON(http.StatusCreated, created)
 }
 
-// List handles GET /api/v1/projects
+// List handles GET /api/v1/projects. The q, status, sort and order query
+// parameters search, filter and order the listing; see parseListFilter.
 func (h *DefaultHandler) List(c echo.Context) error {
 	if h.db == nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
 	}
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
 	}
 
 	uRepo := user.NewDefaultRepository(h.db)
@@ -147,33 +119,75 @@ func (h *DefaultHandler) List(c echo.Context) error {
 			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
 			if err != nil {
 				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
+				return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to create user")
 			}
 			userID = newUser.ID
 		} else {
 			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get user")
 		}
 	} else {
 		userID = existingUser.ID
 	}
 
+	filter, detail := parseListFilter(c)
+	if detail != "" {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, detail)
+	}
+	page, err := pagination.FromRequest(c)
+	if err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeInvalidCursor, "Invalid cursor")
+	}
+
 	repo := NewDefaultRepository(h.db)
-	projects, err := repo.GetProjectsByUserID(c.Request().Context(), userID.String())
+	projects, next, err := repo.ListProjectsByUserID(c.Request().Context(), userID.String(), filter, page)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to retrieve projects",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to retrieve projects")
 	}
 
-	return c.JSON(http.StatusOK, ProjectListResponse{Projects: projects})
+	return c.JSON(http.StatusOK, ProjectListResponse{Projects: projects, NextCursor: next})
+}
+
+// maxSearchLength bounds the q parameter to the longest possible project name.
+const maxSearchLength = 100
+
+// parseListFilter reads the listing filter from the query: q searches names,
+// status takes a comma-separated list of image statuses, sort is created_at or
+// updated_at and order is asc or desc. It returns the problem detail of an
+// invalid parameter, or "".
+func parseListFilter(c echo.Context) (ListFilter, string) {
+	filter := ListFilter{Name: strings.TrimSpace(c.QueryParam("q"))}
+	if len([]rune(filter.Name)) > maxSearchLength {
+		return ListFilter{}, fmt.Sprintf("Search must be at most %d characters", maxSearchLength)
+	}
+
+	if v := c.QueryParam("status"); v != "" {
+		for _, status := range strings.Split(v, ",") {
+			status = strings.TrimSpace(status)
+			if !slices.Contains(ImageStatuses, status) {
+				return ListFilter{}, "Invalid status filter: " + status
+			}
+			if !slices.Contains(filter.Statuses, status) {
+				filter.Statuses = append(filter.Statuses, status)
+			}
+		}
+	}
+
+	switch sort := c.QueryParam("sort"); sort {
+	case "", SortCreatedAt, SortUpdatedAt:
+		filter.Sort = sort
+	default:
+		return ListFilter{}, "Invalid sort: must be created_at or updated_at"
+	}
+
+	switch c.QueryParam("order") {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return ListFilter{}, "Invalid order: must be asc or desc"
+	}
+	return filter, ""
 }
 
 // GetByID handles GET /api/v1/projects/:id
@@ -181,18 +195,12 @@ func (h *DefaultHandler) GetByID(c echo.Context) error {
 	projectID := c.Param("id")
 
 	if _, err := uuid.Parse(projectID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid project ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid project ID format")
 	}
 
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
 	}
 
 	uRepo := user.NewDefaultRepository(h.db)
@@ -205,18 +213,12 @@ func (h *DefaultHandler) GetByID(c echo.Context) error {
 			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
 			if err != nil {
 				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
+				return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to create user")
 			}
 			userID = newUser.ID
 		} else {
 			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get user")
 		}
 	} else {
 		userID = existingUser.ID
@@ -226,15 +228,9 @@ func (h *DefaultHandler) GetByID(c echo.Context) error {
 	p, err := repo.GetProjectByIDAndUserID(c.Request().Context(), projectID, userID.String())
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Project not found",
-			})
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Project not found")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to retrieve project",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to retrieve project")
 	}
 
 	return c.JSON(http.StatusOK, p)
@@ -245,34 +241,22 @@ func (h *DefaultHandler) Update(c echo.Context) error {
 	projectID := c.Param("id")
 
 	if _, err := uuid.Parse(projectID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid project ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid project ID format")
 	}
 
 	var req UpdateRequest
 	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid request format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
 	}
 
 	if validationErrs := validateUpdateProjectRequest(&req); len(validationErrs) > 0 {
-		return c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
-			Error:            "validation_failed",
-			Message:          "The provided data is invalid",
-			ValidationErrors: validationErrs,
-		})
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			"The provided data is invalid").With("validation_errors", validationErrs))
 	}
 
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
 	}
 
 	uRepo := user.NewDefaultRepository(h.db)
@@ -285,36 +269,26 @@ func (h *DefaultHandler) Update(c echo.Context) error {
 			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
 			if err != nil {
 				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
+				return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to create user")
 			}
 			userID = newUser.ID
 		} else {
 			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get user")
 		}
 	} else {
 		userID = existingUser.ID
 	}
 
 	repo := NewDefaultRepository(h.db)
-	updated, err := repo.UpdateProjectByUserID(c.Request().Context(), projectID, userID.String(), req.Name)
+	updated, err := repo.UpdateProjectByUserID(
+		c.Request().Context(), projectID, userID.String(), req.Name, req.Watermark, req.ModelID, req.Locale,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Project not found",
-			})
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Project not found")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to update project",
-		})
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to update project")
 	}
 
 	return c.JSON(http.StatusOK, updated)
@@ -325,18 +299,12 @@ func (h *DefaultHandler) Delete(c echo.Context) error {
 	projectID := c.Param("id")
 
 	if _, err := uuid.Parse(projectID); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "bad_request",
-			Message: "Invalid project ID format",
-		})
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid project ID format")
 	}
 
 	auth0Sub, err := auth.GetUserIDOrDefault(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid or missing JWT token",
-		})
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
 	}
 
 	uRepo := user.NewDefaultRepository(h.db)
@@ -349,18 +317,12 @@ func (h *DefaultHandler) Delete(c echo.Context) error {
 			newUser, err := uRepo.Create(c.Request().Context(), auth0Sub, "", "user")
 			if err != nil {
 				c.Logger().Errorf("Failed to create user: %v", err)
-				return c.JSON(http.StatusInternalServerError, ErrorResponse{
-					Error:   "internal_server_error",
-					Message: "Failed to create user",
-				})
+				return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to create user")
 			}
 			userID = newUser.ID
 		} else {
 			c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
-			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to get user",
-			})
+			return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get user")
 		}
 	} else {
 		userID = existingUser.ID
@@ -369,20 +331,66 @@ func (h *DefaultHandler) Delete(c echo.Context) error {
 	repo := NewDefaultRepository(h.db)
 	if err := repo.DeleteProjectByUserID(c.Request().Context(), projectID, userID.String()); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error:   "not_found",
-				Message: "Project not found",
-			})
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Project not found")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "internal_server_error",
-			Message: "Failed to delete project",
-		})
+		if errors.Is(err, ErrProjectLocked) {
+			return problem.Write(c, http.StatusConflict, "project_locked", "Project is locked; unlock it before deleting")
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to delete project")
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// Lock handles POST /api/v1/projects/:id/lock
+func (h *DefaultHandler) Lock(c echo.Context) error {
+	return h.setLocked(c, true)
+}
+
+// Unlock handles POST /api/v1/projects/:id/unlock
+func (h *DefaultHandler) Unlock(c echo.Context) error {
+	return h.setLocked(c, false)
+}
+
+// setLocked applies the lock flag for the project creator or an owner or
+// admin of the organization the project is shared with.
+func (h *DefaultHandler) setLocked(c echo.Context, locked bool) error {
+	projectID := c.Param("id")
+
+	if _, err := uuid.Parse(projectID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid project ID format")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return problem.Write(c, http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
+	}
+
+	uRepo := user.NewDefaultRepository(h.db)
+	existingUser, err := uRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// A user with no account row cannot own or administer any project.
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Project not found")
+		}
+		c.Logger().Errorf("Failed to get user by auth0 sub: %v", err)
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to get user")
+	}
+
+	repo := NewDefaultRepository(h.db)
+	updated, err := repo.SetProjectLockedByUserID(
+		c.Request().Context(), projectID, existingUser.ID.String(), locked,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Project not found")
+		}
+		return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "Failed to update project lock")
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
 // Validation helpers
 
 func validateCreateProjectRequest(req *CreateRequest) []ValidationErrorDetail {
@@ -404,6 +412,9 @@ func validateCreateProjectRequest(req *CreateRequest) []ValidationErrorDetail {
 	return errors
 }
 
+// localePattern matches the language-region tags the worker localizes prompts for.
+var localePattern = regexp.MustCompile(`^[a-z]{2}-[A-Z]{2}$`)
+
 func validateUpdateProjectRequest(req *UpdateRequest) []ValidationErrorDetail {
 	var errors []ValidationErrorDetail
 
@@ -420,5 +431,19 @@ func validateUpdateProjectRequest(req *UpdateRequest) []ValidationErrorDetail {
 		})
 	}
 
+	if req.ModelID != nil && *req.ModelID != "" && !settings.IsAvailableModel(*req.ModelID) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "model_id",
+			Message: "model_id must be one of: " + strings.Join(settings.AvailableModelIDs(), ", "),
+		})
+	}
+
+	if req.Locale != nil && *req.Locale != "" && !localePattern.MatchString(*req.Locale) {
+		errors = append(errors, ValidationErrorDetail{
+			Field:   "locale",
+			Message: "locale must be a language-region tag such as en-GB",
+		})
+	}
+
 	return errors
 }
@@ -3,6 +3,7 @@ package project
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/real-staging-ai/api/internal/settings"
 	"github.com/real-staging-ai/api/internal/storage"
 )
 
@@ -245,6 +247,62 @@ func TestDefaultHandler_List(t *testing.T) {
 	}
 }
 
+func TestParseListFilter(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		want       ListFilter
+		wantDetail string
+	}{
+		{
+			name: "success: defaults",
+		},
+		{
+			name:  "success: all parameters",
+			query: "q=+Beach+&status=ready,%20error,ready&sort=updated_at&order=asc",
+			want: ListFilter{
+				Name: "Beach", Statuses: []string{"ready", "error"}, Sort: SortUpdatedAt, Ascending: true,
+			},
+		},
+		{
+			name:  "success: explicit defaults",
+			query: "sort=created_at&order=desc",
+			want:  ListFilter{Sort: SortCreatedAt},
+		},
+		{
+			name:       "fail: unknown status",
+			query:      "status=ready,done",
+			wantDetail: "Invalid status filter: done",
+		},
+		{
+			name:       "fail: unknown sort",
+			query:      "sort=name",
+			wantDetail: "Invalid sort: must be created_at or updated_at",
+		},
+		{
+			name:       "fail: unknown order",
+			query:      "order=up",
+			wantDetail: "Invalid order: must be asc or desc",
+		},
+		{
+			name:       "fail: search too long",
+			query:      "q=" + strings.Repeat("a", maxSearchLength+1),
+			wantDetail: "Search must be at most 100 characters",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/projects?"+tc.query, nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			got, detail := parseListFilter(c)
+			assert.Equal(t, tc.wantDetail, detail)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestDefaultHandler_Update(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -325,6 +383,267 @@ func TestDefaultHandler_Delete(t *testing.T) {
 	}
 }
 
+func TestDefaultHandler_DeleteLocked(t *testing.T) {
+	e := echo.New()
+	projectID := uuid.New().String()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/projects/"+projectID, nil)
+	req.Header.Set("X-Test-User", "auth0|testuser")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(projectID)
+
+	h := NewDefaultHandler(newDBMockForProjectMutation(func(sql string, dest ...any) error {
+		if !strings.Contains(sql, "WITH target") {
+			return errors.New("unexpected query")
+		}
+		*dest[0].(*bool) = true
+		*dest[1].(*bool) = false
+		return nil
+	}))
+
+	assert.NoError(t, h.Delete(c))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "project_locked")
+}
+
+func TestDefaultHandler_Lock(t *testing.T) {
+	cases := []struct {
+		name           string
+		projectID      string
+		unlock         bool
+		projectErr     error
+		wantStatusCode int
+		contains       string
+	}{
+		{
+			name:           "success: lock",
+			projectID:      uuid.New().String(),
+			wantStatusCode: http.StatusOK,
+			contains:       `"locked":true`,
+		},
+		{
+			name:           "success: unlock",
+			projectID:      uuid.New().String(),
+			unlock:         true,
+			wantStatusCode: http.StatusOK,
+			contains:       `"locked":false`,
+		},
+		{
+			name:           "fail: bad request - invalid uuid",
+			projectID:      "invalid-uuid",
+			wantStatusCode: http.StatusBadRequest,
+			contains:       "Invalid project ID format",
+		},
+		{
+			name:           "fail: not owner or org admin",
+			projectID:      uuid.New().String(),
+			projectErr:     pgx.ErrNoRows,
+			wantStatusCode: http.StatusNotFound,
+			contains:       "Project not found",
+		},
+		{
+			name:           "fail: database error",
+			projectID:      uuid.New().String(),
+			projectErr:     errors.New("db down"),
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/projects/"+tc.projectID+"/lock", nil)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.projectID)
+
+			h := NewDefaultHandler(newDBMockForProjectMutation(func(sql string, dest ...any) error {
+				if tc.projectErr != nil {
+					return tc.projectErr
+				}
+				*dest[0].(*string) = tc.projectID
+				*dest[4].(*bool) = !tc.unlock
+				return nil
+			}))
+
+			var err error
+			if tc.unlock {
+				err = h.Unlock(c)
+			} else {
+				err = h.Lock(c)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			if tc.contains != "" {
+				assert.Contains(t, rec.Body.String(), tc.contains)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_UpdateWatermark(t *testing.T) {
+	on := true
+	cases := []struct {
+		name          string
+		body          string
+		wantWatermark *bool
+		contains      string
+	}{
+		{name: "success: turn the watermark on", body: `{"name":"Listing","watermark":true}`, wantWatermark: &on,
+			contains: `"watermark":true`},
+		{name: "success: omitted keeps the current setting", body: `{"name":"Listing"}`, contains: `"watermark":false`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID := uuid.New().String()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/projects/"+projectID, bytes.NewBufferString(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(projectID)
+
+			db := newDBMockForProjectMutation(func(sql string, dest ...any) error {
+				if !strings.Contains(sql, "UPDATE projects") {
+					return errors.New("unexpected query")
+				}
+				*dest[0].(*string) = projectID
+				*dest[5].(*bool) = tc.wantWatermark != nil && *tc.wantWatermark
+				return nil
+			})
+
+			assert.NoError(t, NewDefaultHandler(db).Update(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tc.contains)
+
+			calls := db.QueryRowCalls()
+			update := calls[len(calls)-1]
+			assert.Equal(t, tc.wantWatermark, update.Args[3])
+		})
+	}
+}
+
+func TestDefaultHandler_UpdateModel(t *testing.T) {
+	model := "bytedance/seedream-4"
+	settings.SetCatalog([]settings.ModelInfo{{ID: model, Enabled: true}})
+	empty := ""
+	cases := []struct {
+		name           string
+		body           string
+		wantStatusCode int
+		wantModelID    *string
+		contains       string
+	}{
+		{name: "success: pin a model", body: `{"name":"Listing","model_id":"bytedance/seedream-4"}`,
+			wantStatusCode: http.StatusOK, wantModelID: &model, contains: `"model_id":"bytedance/seedream-4"`},
+		{name: "success: empty clears the model", body: `{"name":"Listing","model_id":""}`,
+			wantStatusCode: http.StatusOK, wantModelID: &empty},
+		{name: "fail: unknown model", body: `{"name":"Listing","model_id":"acme/painter"}`,
+			wantStatusCode: http.StatusUnprocessableEntity, contains: "model_id must be one of"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID := uuid.New().String()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/projects/"+projectID, bytes.NewBufferString(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(projectID)
+
+			db := newDBMockForProjectMutation(func(sql string, dest ...any) error {
+				if !strings.Contains(sql, "UPDATE projects") {
+					return errors.New("unexpected query")
+				}
+				*dest[0].(*string) = projectID
+				if tc.wantModelID != nil && *tc.wantModelID != "" {
+					*dest[6].(**string) = tc.wantModelID
+				}
+				return nil
+			})
+
+			assert.NoError(t, NewDefaultHandler(db).Update(c))
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			if tc.contains != "" {
+				assert.Contains(t, rec.Body.String(), tc.contains)
+			}
+			if tc.wantModelID == nil {
+				return
+			}
+			calls := db.QueryRowCalls()
+			update := calls[len(calls)-1]
+			assert.Equal(t, tc.wantModelID, update.Args[4])
+		})
+	}
+}
+
+func TestDefaultHandler_UpdateLocale(t *testing.T) {
+	locale := "en-GB"
+	empty := ""
+	cases := []struct {
+		name           string
+		body           string
+		wantStatusCode int
+		wantLocale     *string
+		contains       string
+	}{
+		{name: "success: set the locale", body: `{"name":"Listing","locale":"en-GB"}`,
+			wantStatusCode: http.StatusOK, wantLocale: &locale, contains: `"locale":"en-GB"`},
+		{name: "success: empty clears the locale", body: `{"name":"Listing","locale":""}`,
+			wantStatusCode: http.StatusOK, wantLocale: &empty},
+		{name: "fail: language only", body: `{"name":"Listing","locale":"en"}`,
+			wantStatusCode: http.StatusUnprocessableEntity, contains: "locale must be a language-region tag"},
+		{name: "fail: wrong case", body: `{"name":"Listing","locale":"EN-gb"}`,
+			wantStatusCode: http.StatusUnprocessableEntity, contains: "locale must be a language-region tag"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			projectID := uuid.New().String()
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/projects/"+projectID, bytes.NewBufferString(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-Test-User", "auth0|testuser")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(projectID)
+
+			db := newDBMockForProjectMutation(func(sql string, dest ...any) error {
+				if !strings.Contains(sql, "UPDATE projects") {
+					return errors.New("unexpected query")
+				}
+				*dest[0].(*string) = projectID
+				if tc.wantLocale != nil && *tc.wantLocale != "" {
+					*dest[7].(**string) = tc.wantLocale
+				}
+				return nil
+			})
+
+			assert.NoError(t, NewDefaultHandler(db).Update(c))
+			assert.Equal(t, tc.wantStatusCode, rec.Code)
+			if tc.contains != "" {
+				assert.Contains(t, rec.Body.String(), tc.contains)
+			}
+			if tc.wantLocale == nil {
+				return
+			}
+			calls := db.QueryRowCalls()
+			update := calls[len(calls)-1]
+			assert.Equal(t, tc.wantLocale, update.Args[5])
+		})
+	}
+}
+
 // ---------------------- DB Mock helpers ----------------------
 
 type fakeRow struct {
@@ -522,3 +841,23 @@ func newDBMockForGetProjectByID_NotFound() *storage.DatabaseMock {
 		},
 	}
 }
+
+// user exists; every project query is answered by scanProject
+func newDBMockForProjectMutation(scanProject func(sql string, dest ...any) error) *storage.DatabaseMock {
+	userID := uuid.New()
+
+	return &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+			if strings.Contains(sql, "FROM users") && strings.Contains(sql, "auth0_sub") {
+				return fakeRow{scan: func(dest ...any) error {
+					if u, ok := dest[0].(*pgtype.UUID); ok {
+						u.Bytes = userID
+						u.Valid = true
+					}
+					return nil
+				}}
+			}
+			return fakeRow{scan: func(dest ...any) error { return scanProject(sql, dest...) }}
+		},
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage"
 )
 
@@ -50,7 +51,7 @@ func (s *DefaultRepository) CreateProject(ctx context.Context, p *Project, userI
 // TODO: Filter by user_id when auth middleware is implemented.
 func (s *DefaultRepository) GetProjects(ctx context.Context) ([]Project, error) {
 	query := `
-		SELECT id, name, user_id, created_at
+		SELECT id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
 		FROM projects
 		ORDER BY created_at DESC
 	`
@@ -63,7 +64,7 @@ func (s *DefaultRepository) GetProjects(ctx context.Context) ([]Project, error)
 	var projects []Project
 	for rows.Next() {
 		var p Project
-		err := rows.Scan(&p.ID, &p.Name, &p.UserID, &p.CreatedAt)
+		err := rows.Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("unable to scan project: %w", err)
 		}
@@ -80,7 +81,7 @@ func (s *DefaultRepository) GetProjects(ctx context.Context) ([]Project, error)
 // GetProjectsByUserID retrieves all projects for a specific user.
 func (s *DefaultRepository) GetProjectsByUserID(ctx context.Context, userID string) ([]Project, error) {
 	query := `
-		SELECT id, name, user_id, created_at
+		SELECT id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
 		FROM projects
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -94,7 +95,7 @@ func (s *DefaultRepository) GetProjectsByUserID(ctx context.Context, userID stri
 	var projects []Project
 	for rows.Next() {
 		var p Project
-		err := rows.Scan(&p.ID, &p.Name, &p.UserID, &p.CreatedAt)
+		err := rows.Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("unable to scan project: %w", err)
 		}
@@ -108,16 +109,29 @@ func (s *DefaultRepository) GetProjectsByUserID(ctx context.Context, userID stri
 	return projects, nil
 }
 
-// GetProjectByIDAndUserID retrieves a specific project by its ID and user ID.
+// ListProjectsByUserID lists a page of the projects a user created that match
+// the filter, through the sqlc search query.
+func (s *DefaultRepository) ListProjectsByUserID(
+	ctx context.Context, userID string, filter ListFilter, page pagination.Page,
+) ([]Project, *string, error) {
+	return NewDefaultStorageSQLc(s.db).ListProjectsByUserID(ctx, userID, filter, page)
+}
+
+// GetProjectByIDAndUserID retrieves a project the user created or that is
+// shared with an organization the user belongs to.
 func (s *DefaultRepository) GetProjectByIDAndUserID(ctx context.Context, projectID, userID string) (*Project, error) {
 	query := `
-		SELECT id, name, user_id, created_at
+		SELECT id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
 		FROM projects
-		WHERE id = $1 AND user_id = $2
+		WHERE id = $1 AND (user_id = $2 OR EXISTS (
+			SELECT 1 FROM organization_members m
+			WHERE m.org_id = projects.org_id AND m.user_id = $2
+		))
 	`
 
 	var p Project
-	err := s.db.QueryRow(ctx, query, projectID, userID).Scan(&p.ID, &p.Name, &p.UserID, &p.CreatedAt)
+	err := s.db.QueryRow(ctx, query, projectID, userID).
+		Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, pgx.ErrNoRows
@@ -128,22 +142,40 @@ func (s *DefaultRepository) GetProjectByIDAndUserID(ctx context.Context, project
 	return &p, nil
 }
 
-// DeleteProjectByUserID deletes a project from the database with user ownership verification.
+// DeleteProjectByUserID deletes a project the user created, or one shared with
+// an organization where the user is an owner or admin. Locked projects are
+// kept and reported as ErrProjectLocked.
 func (s *DefaultRepository) DeleteProjectByUserID(ctx context.Context, projectID, userID string) error {
+	return s.deleteUnlocked(ctx, `id = $1 AND (user_id = $2 OR EXISTS (
+		SELECT 1 FROM organization_members m
+		WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+	))`, projectID, userID)
+}
+
+// deleteUnlocked deletes the project matched by filter unless it is locked.
+// The lock is re-checked by the DELETE itself so a concurrent lock wins.
+func (s *DefaultRepository) deleteUnlocked(ctx context.Context, filter string, args ...any) error {
 	query := `
-		DELETE FROM projects
-		WHERE id = $1 AND user_id = $2
+		WITH target AS (
+			SELECT id FROM projects WHERE ` + filter + `
+		), deleted AS (
+			DELETE FROM projects
+			WHERE id IN (SELECT id FROM target) AND NOT locked
+			RETURNING id
+		)
+		SELECT EXISTS (SELECT 1 FROM target), EXISTS (SELECT 1 FROM deleted)
 	`
 
-	result, err := s.db.Exec(ctx, query, projectID, userID)
-	if err != nil {
+	var found, deleted bool
+	if err := s.db.QueryRow(ctx, query, args...).Scan(&found, &deleted); err != nil {
 		return fmt.Errorf("unable to delete project: %w", err)
 	}
-
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
+	if !found {
 		return pgx.ErrNoRows
 	}
+	if !deleted {
+		return ErrProjectLocked
+	}
 
 	return nil
 }
@@ -169,13 +201,14 @@ func (s *DefaultRepository) CountProjectsByUserID(ctx context.Context, userID st
 // TODO: Add user_id filtering when auth middleware is implemented.
 func (s *DefaultRepository) GetProjectByID(ctx context.Context, projectID string) (*Project, error) {
 	query := `
-		SELECT id, name, user_id, created_at
+		SELECT id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
 		FROM projects
 		WHERE id = $1
 	`
 
 	var p Project
-	err := s.db.QueryRow(ctx, query, projectID).Scan(&p.ID, &p.Name, &p.UserID, &p.CreatedAt)
+	err := s.db.QueryRow(ctx, query, projectID).
+		Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, pgx.ErrNoRows
@@ -186,19 +219,28 @@ func (s *DefaultRepository) GetProjectByID(ctx context.Context, projectID string
 	return &p, nil
 }
 
-// UpdateProjectByUserID updates an existing project's name with user ownership verification.
+// UpdateProjectByUserID updates the name, and the watermark flag, model and
+// locale when given, of a project the user created, or of one shared with an
+// organization where the user is an owner or admin. An empty modelID or
+// locale clears it.
 func (s *DefaultRepository) UpdateProjectByUserID(
-	ctx context.Context, projectID, userID, name string,
+	ctx context.Context, projectID, userID, name string, watermark *bool, modelID, locale *string,
 ) (*Project, error) {
 	query := `
 		UPDATE projects
-		SET name = $3
-		WHERE id = $1 AND user_id = $2
-		RETURNING id, name, user_id, created_at
+		SET name = $3, watermark = COALESCE($4, watermark),
+			model_id = CASE WHEN $5::text IS NULL THEN model_id ELSE NULLIF($5::text, '') END,
+			locale = CASE WHEN $6::text IS NULL THEN locale ELSE NULLIF($6::text, '') END
+		WHERE id = $1 AND (user_id = $2 OR EXISTS (
+			SELECT 1 FROM organization_members m
+			WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+		))
+		RETURNING id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
 	`
 
 	var p Project
-	err := s.db.QueryRow(ctx, query, projectID, userID, name).Scan(&p.ID, &p.Name, &p.UserID, &p.CreatedAt)
+	err := s.db.QueryRow(ctx, query, projectID, userID, name, watermark, modelID, locale).
+		Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, pgx.ErrNoRows
@@ -209,6 +251,34 @@ func (s *DefaultRepository) UpdateProjectByUserID(
 	return &p, nil
 }
 
+// SetProjectLockedByUserID locks or unlocks a project the user created, or one
+// shared with an organization where the user is an owner or admin.
+func (s *DefaultRepository) SetProjectLockedByUserID(
+	ctx context.Context, projectID, userID string, locked bool,
+) (*Project, error) {
+	query := `
+		UPDATE projects
+		SET locked = $3
+		WHERE id = $1 AND (user_id = $2 OR EXISTS (
+			SELECT 1 FROM organization_members m
+			WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+		))
+		RETURNING id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
+	`
+
+	var p Project
+	err := s.db.QueryRow(ctx, query, projectID, userID, locked).
+		Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("unable to set project lock: %w", err)
+	}
+
+	return &p, nil
+}
+
 // UpdateProject updates an existing project's name.
 // TODO: Add user_id filtering when auth middleware is implemented.
 func (s *DefaultRepository) UpdateProject(ctx context.Context, projectID, name string) (*Project, error) {
@@ -216,11 +286,12 @@ func (s *DefaultRepository) UpdateProject(ctx context.Context, projectID, name s
 		UPDATE projects
 		SET name = $2
 		WHERE id = $1
-		RETURNING id, name, user_id, created_at
+		RETURNING id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
 	`
 
 	var p Project
-	err := s.db.QueryRow(ctx, query, projectID, name).Scan(&p.ID, &p.Name, &p.UserID, &p.CreatedAt)
+	err := s.db.QueryRow(ctx, query, projectID, name).
+		Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, pgx.ErrNoRows
@@ -231,23 +302,83 @@ func (s *DefaultRepository) UpdateProject(ctx context.Context, projectID, name s
 	return &p, nil
 }
 
-// DeleteProject deletes a project from the database.
+// DeleteProject deletes a project from the database unless it is locked.
 // TODO: Add user_id filtering when auth middleware is implemented.
 func (s *DefaultRepository) DeleteProject(ctx context.Context, projectID string) error {
+	return s.deleteUnlocked(ctx, "id = $1", projectID)
+}
+
+// GetProjectsByOrgID retrieves all projects shared with an organization.
+func (s *DefaultRepository) GetProjectsByOrgID(ctx context.Context, orgID string) ([]Project, error) {
+	query := `
+		SELECT id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
+		FROM projects
+		WHERE org_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := s.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get projects for organization: %w", err)
+	}
+	defer rows.Close()
+
+	projects := []Project{}
+	for rows.Next() {
+		var p Project
+		err := rows.Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to scan project: %w", err)
+		}
+		projects = append(projects, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over project rows: %w", err)
+	}
+
+	return projects, nil
+}
+
+// SetProjectOrg shares a project with an organization, or unshares it when orgID is nil.
+func (s *DefaultRepository) SetProjectOrg(ctx context.Context, projectID string, orgID *string) (*Project, error) {
 	query := `
-		DELETE FROM projects
+		UPDATE projects
+		SET org_id = $2
 		WHERE id = $1
+		RETURNING id, name, user_id, org_id, locked, watermark, model_id, locale, created_at
 	`
 
-	result, err := s.db.Exec(ctx, query, projectID)
+	var p Project
+	err := s.db.QueryRow(ctx, query, projectID, orgID).
+		Scan(&p.ID, &p.Name, &p.UserID, &p.OrgID, &p.Locked, &p.Watermark, &p.ModelID, &p.Locale, &p.CreatedAt)
 	if err != nil {
-		return fmt.Errorf("unable to delete project: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("unable to set project organization: %w", err)
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return pgx.ErrNoRows
+	return &p, nil
+}
+
+// GetBillingUserID returns the organization's billing user for shared projects,
+// falling back to the creator when the project is unshared or the org has no billing user.
+func (s *DefaultRepository) GetBillingUserID(ctx context.Context, projectID string) (string, error) {
+	query := `
+		SELECT COALESCE(o.billing_user_id, p.user_id)
+		FROM projects p
+		LEFT JOIN organizations o ON o.id = p.org_id
+		WHERE p.id = $1
+	`
+
+	var userID string
+	err := s.db.QueryRow(ctx, query, projectID).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", pgx.ErrNoRows
+		}
+		return "", fmt.Errorf("unable to get project billing user: %w", err)
 	}
 
-	return nil
+	return userID, nil
 }
@@ -64,7 +64,7 @@ func (s *DefaultService) CreateProjectWithUpload(
 
 	// Generate upload URL
 	uploadResult, err := s.s3Service.GeneratePresignedUploadURL(
-		ctx, req.UserID, filename, contentType, fileSize)
+		ctx, req.UserID, createdProject.ID, filename, contentType, fileSize)
 	if err != nil {
 		// Project was created but upload URL failed - in a real system you might
 		// want to handle this differently
@@ -123,7 +123,7 @@ func (s *DefaultService) UpdateProject(ctx context.Context, projectID, userID, n
 		return nil, fmt.Errorf("project name is required")
 	}
 
-	updatedProject, err := s.projectRepo.UpdateProjectByUserID(ctx, projectID, userID, newName)
+	updatedProject, err := s.projectRepo.UpdateProjectByUserID(ctx, projectID, userID, newName, nil, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update project: %w", err)
 	}
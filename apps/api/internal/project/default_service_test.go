@@ -143,11 +143,11 @@ func TestProjectService_CreateProjectWithUpload(t *testing.T) {
 					}, nil
 				}
 				s3Mock.GeneratePresignedUploadURLFunc = func(
-					ctx context.Context, userID string, filename string, contentType string, fileSize int64,
+					ctx context.Context, userID string, projectID string, filename string, contentType string, fileSize int64,
 				) (*storage.PresignedUploadResult, error) {
 					return &storage.PresignedUploadResult{
 						UploadURL: "https://s3.example.com/upload-url",
-						FileKey:   "uploads/user123/test-uuid.jpg",
+						FileKey:   "users/user123/projects/project-456/originals/test-uuid.jpg",
 						ExpiresIn: 900,
 					}, nil
 				}
@@ -157,7 +157,7 @@ func TestProjectService_CreateProjectWithUpload(t *testing.T) {
 				assert.Equal(t, "project-456", result.Project.ID)
 				assert.Equal(t, "Upload Test Project", result.Project.Name)
 				assert.Equal(t, "https://s3.example.com/upload-url", result.UploadURL)
-				assert.Equal(t, "uploads/user123/test-uuid.jpg", result.FileKey)
+				assert.Equal(t, "users/user123/projects/project-456/originals/test-uuid.jpg", result.FileKey)
 			},
 		},
 		{
@@ -180,7 +180,7 @@ func TestProjectService_CreateProjectWithUpload(t *testing.T) {
 					}, nil
 				}
 				s3Mock.GeneratePresignedUploadURLFunc = func(
-					ctx context.Context, userID string, filename string, contentType string, fileSize int64,
+					ctx context.Context, userID string, projectID string, filename string, contentType string, fileSize int64,
 				) (*storage.PresignedUploadResult, error) {
 					return nil, errors.New("AWS credentials not configured")
 				}
@@ -452,7 +452,7 @@ func TestProjectService_UpdateProject(t *testing.T) {
 			newName:   "Updated Project Name",
 			setupMock: func(mock *project.RepositoryMock) {
 				mock.UpdateProjectByUserIDFunc = func(
-					ctx context.Context, projectID string, userID string, newName string,
+					ctx context.Context, projectID string, userID string, newName string, watermark *bool, modelID, locale *string,
 				) (*project.Project, error) {
 					return &project.Project{
 						ID:     "proj123",
@@ -508,7 +508,7 @@ func TestProjectService_UpdateProject(t *testing.T) {
 			newName:   "New Name",
 			setupMock: func(mock *project.RepositoryMock) {
 				mock.UpdateProjectByUserIDFunc = func(
-					ctx context.Context, projectID string, userID string, newName string,
+					ctx context.Context, projectID string, userID string, newName string, watermark *bool, modelID, locale *string,
 				) (*project.Project, error) {
 					return nil, errors.New("database error")
 				}
@@ -724,7 +724,7 @@ func TestProjectService_ComplexScenarios(t *testing.T) {
 
 		s3ServiceMock := &storage.S3ServiceMock{}
 		s3ServiceMock.GeneratePresignedUploadURLFunc = func(
-			ctx context.Context, userID string, filename string, contentType string, fileSize int64,
+			ctx context.Context, userID string, projectID string, filename string, contentType string, fileSize int64,
 		) (*storage.PresignedUploadResult, error) {
 			return &storage.PresignedUploadResult{
 				UploadURL: "https://upload.url",
@@ -760,7 +760,7 @@ func TestProjectService_ComplexScenarios(t *testing.T) {
 
 		// Step 3: Update the project
 		projectRepositoryMock.UpdateProjectByUserIDFunc = func(
-			ctx context.Context, projectID string, userID string, newName string,
+			ctx context.Context, projectID string, userID string, newName string, watermark *bool, modelID, locale *string,
 		) (*project.Project, error) {
 			return &project.Project{
 				ID:     projectID,
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
@@ -116,6 +118,74 @@ func (s *DefaultStorageSQLc) GetProjectsByUserID(ctx context.Context, userID str
 	return projects, nil
 }
 
+// ListProjectsByUserID lists a page of the projects a user created that match
+// the filter. The cursor carries the time of the sort column.
+func (s *DefaultStorageSQLc) ListProjectsByUserID(
+	ctx context.Context, userID string, filter ListFilter, page pagination.Page,
+) ([]Project, *string, error) {
+	userUUIDType, err := toPGUUID(userID, "user")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sortBy := SortCreatedAt
+	if filter.Sort == SortUpdatedAt {
+		sortBy = SortUpdatedAt
+	}
+	params := queries.SearchProjectsByUserIDParams{
+		UserID:    userUUIDType,
+		Statuses:  filter.Statuses,
+		Ascending: filter.Ascending,
+		SortBy:    sortBy,
+		Limit:     page.Fetch(),
+	}
+	if filter.Name != "" {
+		params.NamePattern = pgtype.Text{String: "%" + likeEscaper.Replace(filter.Name) + "%", Valid: true}
+	}
+	params.AfterAt, params.AfterID = page.AfterParams()
+
+	results, err := s.queries.SearchProjectsByUserID(ctx, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to list projects for user: %w", err)
+	}
+
+	projects := make([]Project, 0, len(results))
+	for _, result := range results {
+		updatedAt := result.UpdatedAt.Time
+		projects = append(projects, Project{
+			ID:        uuid.UUID(result.ID.Bytes).String(),
+			Name:      result.Name,
+			UserID:    uuid.UUID(result.UserID.Bytes).String(),
+			OrgID:     optionalUUID(result.OrgID),
+			Locked:    result.Locked,
+			Watermark: result.Watermark,
+			ModelID:   optionalText(result.ModelID),
+			Locale:    optionalText(result.Locale),
+			CreatedAt: result.CreatedAt.Time,
+			UpdatedAt: &updatedAt,
+			ImageCounts: &ImageCounts{
+				Queued:     result.QueuedCount,
+				Processing: result.ProcessingCount,
+				Ready:      result.ReadyCount,
+				Error:      result.ErrorCount,
+				Rejected:   result.RejectedCount,
+				Canceled:   result.CanceledCount,
+			},
+		})
+	}
+
+	projects, next := pagination.Trim(projects, page, func(p Project) pagination.Cursor {
+		if sortBy == SortUpdatedAt {
+			return pagination.Cursor{CreatedAt: *p.UpdatedAt, ID: p.ID}
+		}
+		return pagination.Cursor{CreatedAt: p.CreatedAt, ID: p.ID}
+	})
+	return projects, next, nil
+}
+
+// likeEscaper escapes the LIKE wildcards in a search term so they match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // GetProjectByID retrieves a specific project by its ID.
 // TODO: Add user_id filtering when auth middleware is implemented.
 func (s *DefaultStorageSQLc) GetProjectByID(ctx context.Context, projectID string) (*Project, error) {
@@ -142,26 +212,126 @@ func (s *DefaultStorageSQLc) GetProjectByID(ctx context.Context, projectID strin
 		ID:        uuid.UUID(result.ID.Bytes).String(),
 		Name:      result.Name,
 		UserID:    uuid.UUID(result.UserID.Bytes).String(),
+		OrgID:     optionalUUID(result.OrgID),
+		Locked:    result.Locked,
+		Watermark: result.Watermark,
+		ModelID:   optionalText(result.ModelID),
+		Locale:    optionalText(result.Locale),
 		CreatedAt: result.CreatedAt.Time,
 	}
 
 	return p, nil
 }
 
-// GetProjectByIDAndUserID retrieves a specific project by its ID and user ID.
+// GetProjectByIDAndUserID retrieves a project the user created or that is
+// shared with an organization the user belongs to.
 func (s *DefaultStorageSQLc) GetProjectByIDAndUserID(ctx context.Context, projectID, userID string) (*Project, error) {
-	// For now, we'll get the p and check the user ID manually
-	// This could be optimized with a dedicated query if needed
-	p, err := s.GetProjectByID(ctx, projectID)
+	projectUUIDType, err := toPGUUID(projectID, "project")
+	if err != nil {
+		return nil, err
+	}
+	userUUIDType, err := toPGUUID(userID, "user")
 	if err != nil {
 		return nil, err
 	}
 
-	if p.UserID != userID {
-		return nil, pgx.ErrNoRows // Project not found for this user
+	result, err := s.queries.GetProjectByIDForMember(ctx, queries.GetProjectByIDForMemberParams{
+		ID:     projectUUIDType,
+		UserID: userUUIDType,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("unable to get project by ID and user ID: %w", err)
 	}
 
-	return p, nil
+	return &Project{
+		ID:        uuid.UUID(result.ID.Bytes).String(),
+		Name:      result.Name,
+		UserID:    uuid.UUID(result.UserID.Bytes).String(),
+		OrgID:     optionalUUID(result.OrgID),
+		Locked:    result.Locked,
+		Watermark: result.Watermark,
+		ModelID:   optionalText(result.ModelID),
+		Locale:    optionalText(result.Locale),
+		CreatedAt: result.CreatedAt.Time,
+	}, nil
+}
+
+// GetProjectsByOrgID retrieves all projects shared with an organization.
+func (s *DefaultStorageSQLc) GetProjectsByOrgID(ctx context.Context, orgID string) ([]Project, error) {
+	orgUUIDType, err := toPGUUID(orgID, "organization")
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.queries.GetProjectsByOrgID(ctx, orgUUIDType)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get projects for organization: %w", err)
+	}
+
+	projects := make([]Project, 0, len(results))
+	for _, result := range results {
+		projects = append(projects, Project{
+			ID:        uuid.UUID(result.ID.Bytes).String(),
+			Name:      result.Name,
+			UserID:    uuid.UUID(result.UserID.Bytes).String(),
+			OrgID:     optionalUUID(result.OrgID),
+			CreatedAt: result.CreatedAt.Time,
+		})
+	}
+
+	return projects, nil
+}
+
+// SetProjectOrg shares a project with an organization, or unshares it when orgID is nil.
+func (s *DefaultStorageSQLc) SetProjectOrg(ctx context.Context, projectID string, orgID *string) (*Project, error) {
+	projectUUIDType, err := toPGUUID(projectID, "project")
+	if err != nil {
+		return nil, err
+	}
+	var orgUUIDType pgtype.UUID
+	if orgID != nil {
+		if orgUUIDType, err = toPGUUID(*orgID, "organization"); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.queries.SetProjectOrg(ctx, queries.SetProjectOrgParams{ID: projectUUIDType, OrgID: orgUUIDType})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("unable to set project organization: %w", err)
+	}
+
+	return &Project{
+		ID:        uuid.UUID(result.ID.Bytes).String(),
+		Name:      result.Name,
+		UserID:    uuid.UUID(result.UserID.Bytes).String(),
+		OrgID:     optionalUUID(result.OrgID),
+		CreatedAt: result.CreatedAt.Time,
+	}, nil
+}
+
+// GetBillingUserID returns the organization's billing user for shared projects,
+// otherwise the project creator.
+func (s *DefaultStorageSQLc) GetBillingUserID(ctx context.Context, projectID string) (string, error) {
+	projectUUIDType, err := toPGUUID(projectID, "project")
+	if err != nil {
+		return "", err
+	}
+
+	userID, err := s.queries.GetProjectBillingUserID(ctx, projectUUIDType)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", pgx.ErrNoRows
+		}
+		return "", fmt.Errorf("unable to get project billing user: %w", err)
+	}
+
+	return uuid.UUID(userID.Bytes).String(), nil
 }
 
 // UpdateProject updates an existing project's name.
@@ -201,9 +371,10 @@ func (s *DefaultStorageSQLc) UpdateProject(ctx context.Context, projectID, name
 	return p, nil
 }
 
-// UpdateProjectByUserID updates an existing project's name with user ownership verification.
+// UpdateProjectByUserID updates an existing project's name, and its watermark
+// flag, model and locale unless they are nil, with user ownership verification.
 func (s *DefaultStorageSQLc) UpdateProjectByUserID(
-	ctx context.Context, projectID, userID, name string,
+	ctx context.Context, projectID, userID, name string, watermark *bool, modelID, locale *string,
 ) (*Project, error) {
 	projectUUID, err := uuid.Parse(projectID)
 	if err != nil {
@@ -232,6 +403,15 @@ func (s *DefaultStorageSQLc) UpdateProjectByUserID(
 		UserID: userUUIDType,
 		Name:   name,
 	}
+	if watermark != nil {
+		params.Watermark = pgtype.Bool{Bool: *watermark, Valid: true}
+	}
+	if modelID != nil {
+		params.ModelID = pgtype.Text{String: *modelID, Valid: true}
+	}
+	if locale != nil {
+		params.Locale = pgtype.Text{String: *locale, Valid: true}
+	}
 
 	result, err := s.queries.UpdateProjectByUserID(ctx, params)
 	if err != nil {
@@ -245,13 +425,70 @@ func (s *DefaultStorageSQLc) UpdateProjectByUserID(
 		ID:        uuid.UUID(result.ID.Bytes).String(),
 		Name:      result.Name,
 		UserID:    uuid.UUID(result.UserID.Bytes).String(),
+		Watermark: result.Watermark,
+		ModelID:   optionalText(result.ModelID),
+		Locale:    optionalText(result.Locale),
 		CreatedAt: result.CreatedAt.Time,
 	}
 
 	return p, nil
 }
 
-// DeleteProject deletes a project from the database.
+// SetProjectLockedByUserID locks or unlocks a project with user ownership verification.
+func (s *DefaultStorageSQLc) SetProjectLockedByUserID(
+	ctx context.Context, projectID, userID string, locked bool,
+) (*Project, error) {
+	projectUUIDType, err := toPGUUID(projectID, "project")
+	if err != nil {
+		return nil, err
+	}
+	userUUIDType, err := toPGUUID(userID, "user")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.queries.SetProjectLockedByUserID(ctx, queries.SetProjectLockedByUserIDParams{
+		ID:     projectUUIDType,
+		UserID: userUUIDType,
+		Locked: locked,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("unable to set project lock: %w", err)
+	}
+
+	return &Project{
+		ID:        uuid.UUID(result.ID.Bytes).String(),
+		Name:      result.Name,
+		UserID:    uuid.UUID(result.UserID.Bytes).String(),
+		OrgID:     optionalUUID(result.OrgID),
+		Locked:    result.Locked,
+		Watermark: result.Watermark,
+		ModelID:   optionalText(result.ModelID),
+		Locale:    optionalText(result.Locale),
+		CreatedAt: result.CreatedAt.Time,
+	}, nil
+}
+
+// rejectLocked returns ErrProjectLocked when the project exists and is locked.
+// The delete queries skip locked projects too; this only surfaces the reason.
+func (s *DefaultStorageSQLc) rejectLocked(ctx context.Context, projectID pgtype.UUID) error {
+	result, err := s.queries.GetProjectByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("unable to get project by ID: %w", err)
+	}
+	if result.Locked {
+		return ErrProjectLocked
+	}
+	return nil
+}
+
+// DeleteProject deletes a project from the database unless it is locked.
 // TODO: Add user_id filtering when auth middleware is implemented.
 func (s *DefaultStorageSQLc) DeleteProject(ctx context.Context, projectID string) error {
 	projectUUID, err := uuid.Parse(projectID)
@@ -265,6 +502,10 @@ func (s *DefaultStorageSQLc) DeleteProject(ctx context.Context, projectID string
 		return fmt.Errorf("failed to convert project ID to pgtype.UUID: %w", err)
 	}
 
+	if err := s.rejectLocked(ctx, projectUUIDType); err != nil {
+		return err
+	}
+
 	err = s.queries.DeleteProject(ctx, projectUUIDType)
 	if err != nil {
 		return fmt.Errorf("unable to delete project: %w", err)
@@ -301,6 +542,10 @@ func (s *DefaultStorageSQLc) DeleteProjectByUserID(ctx context.Context, projectI
 		return fmt.Errorf("failed to convert user ID to pgtype.UUID: %w", err)
 	}
 
+	if err := s.rejectLocked(ctx, projectUUIDType); err != nil {
+		return err
+	}
+
 	params := queries.DeleteProjectByUserIDParams{
 		ID:     projectUUIDType,
 		UserID: userUUIDType,
@@ -334,3 +579,29 @@ func (s *DefaultStorageSQLc) CountProjectsByUserID(ctx context.Context, userID s
 
 	return count, nil
 }
+
+// toPGUUID parses id into a pgtype.UUID; kind names the ID in the error.
+func toPGUUID(id, kind string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("invalid %s ID format: %w", kind, err)
+	}
+	return pgtype.UUID{Bytes: parsed, Valid: true}, nil
+}
+
+// optionalUUID converts a nullable UUID column to a string pointer.
+func optionalUUID(id pgtype.UUID) *string {
+	if !id.Valid {
+		return nil
+	}
+	s := uuid.UUID(id.Bytes).String()
+	return &s
+}
+
+// optionalText converts a nullable text column to a string pointer.
+func optionalText(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
@@ -14,4 +14,6 @@ type Handler interface {
 	GetByID(c echo.Context) error
 	Update(c echo.Context) error
 	Delete(c echo.Context) error
+	Lock(c echo.Context) error
+	Unlock(c echo.Context) error
 }
@@ -30,6 +30,12 @@ var _ Handler = &HandlerMock{}
 //			ListFunc: func(c echo.Context) error {
 //				panic("mock out the List method")
 //			},
+//			LockFunc: func(c echo.Context) error {
+//				panic("mock out the Lock method")
+//			},
+//			UnlockFunc: func(c echo.Context) error {
+//				panic("mock out the Unlock method")
+//			},
 //			UpdateFunc: func(c echo.Context) error {
 //				panic("mock out the Update method")
 //			},
@@ -52,6 +58,12 @@ type HandlerMock struct {
 	// ListFunc mocks the List method.
 	ListFunc func(c echo.Context) error
 
+	// LockFunc mocks the Lock method.
+	LockFunc func(c echo.Context) error
+
+	// UnlockFunc mocks the Unlock method.
+	UnlockFunc func(c echo.Context) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(c echo.Context) error
 
@@ -77,6 +89,16 @@ type HandlerMock struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// Lock holds details about calls to the Lock method.
+		Lock []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+		// Unlock holds details about calls to the Unlock method.
+		Unlock []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// C is the c argument value.
@@ -87,6 +109,8 @@ type HandlerMock struct {
 	lockDelete  sync.RWMutex
 	lockGetByID sync.RWMutex
 	lockList    sync.RWMutex
+	lockLock    sync.RWMutex
+	lockUnlock  sync.RWMutex
 	lockUpdate  sync.RWMutex
 }
 
@@ -218,6 +242,70 @@ func (mock *HandlerMock) ListCalls() []struct {
 	return calls
 }
 
+// Lock calls LockFunc.
+func (mock *HandlerMock) Lock(c echo.Context) error {
+	if mock.LockFunc == nil {
+		panic("HandlerMock.LockFunc: method is nil but Handler.Lock was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockLock.Lock()
+	mock.calls.Lock = append(mock.calls.Lock, callInfo)
+	mock.lockLock.Unlock()
+	return mock.LockFunc(c)
+}
+
+// LockCalls gets all the calls that were made to Lock.
+// Check the length with:
+//
+//	len(mockedHandler.LockCalls())
+func (mock *HandlerMock) LockCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockLock.RLock()
+	calls = mock.calls.Lock
+	mock.lockLock.RUnlock()
+	return calls
+}
+
+// Unlock calls UnlockFunc.
+func (mock *HandlerMock) Unlock(c echo.Context) error {
+	if mock.UnlockFunc == nil {
+		panic("HandlerMock.UnlockFunc: method is nil but Handler.Unlock was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockUnlock.Lock()
+	mock.calls.Unlock = append(mock.calls.Unlock, callInfo)
+	mock.lockUnlock.Unlock()
+	return mock.UnlockFunc(c)
+}
+
+// UnlockCalls gets all the calls that were made to Unlock.
+// Check the length with:
+//
+//	len(mockedHandler.UnlockCalls())
+func (mock *HandlerMock) UnlockCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockUnlock.RLock()
+	calls = mock.calls.Unlock
+	mock.lockUnlock.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *HandlerMock) Update(c echo.Context) error {
 	if mock.UpdateFunc == nil {
@@ -2,15 +2,66 @@
 package project
 
 import (
+	"errors"
 	"time"
 )
 
-// Project represents a user's project.
+// ErrProjectLocked is returned when a destructive operation targets a locked project.
+var ErrProjectLocked = errors.New("project is locked")
+
+// Project represents a user's project. UserID is always the creator; OrgID
+// shares the project with the members of that organization. A locked project
+// protects published assets: its images cannot be deleted or restyled and the
+// project cannot be deleted until it is unlocked. Watermark marks images
+// staged in the project as virtually staged, as many MLS rules require.
+// ModelID pins the staging model for the project's images; nil leaves the
+// choice to the user's preference or the global active model. Locale, a tag
+// such as "en-GB", adapts the furniture sizes named in stage prompts.
+// UpdatedAt and ImageCounts are only filled in by project listings.
 type Project struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name" validate:"required,min=1,max=100"`
-	UserID    string    `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name" validate:"required,min=1,max=100"`
+	UserID      string       `json:"user_id"`
+	OrgID       *string      `json:"org_id,omitempty"`
+	Locked      bool         `json:"locked"`
+	Watermark   bool         `json:"watermark"`
+	ModelID     *string      `json:"model_id,omitempty"`
+	Locale      *string      `json:"locale,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   *time.Time   `json:"updated_at,omitempty"`
+	ImageCounts *ImageCounts `json:"image_counts,omitempty"`
+}
+
+// ImageCounts counts a project's images, outside the trash, by status.
+type ImageCounts struct {
+	Queued     int64 `json:"queued"`
+	Processing int64 `json:"processing"`
+	Ready      int64 `json:"ready"`
+	Error      int64 `json:"error"`
+	Rejected   int64 `json:"rejected"`
+	Canceled   int64 `json:"canceled"`
+}
+
+// Sort orders for project listings.
+const (
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+)
+
+// ImageStatuses are the image statuses a project listing can filter by.
+var ImageStatuses = []string{"queued", "processing", "ready", "error", "rejected", "canceled"}
+
+// ListFilter narrows and orders a project listing. The zero value lists every
+// project, newest first.
+type ListFilter struct {
+	// Name matches projects whose name contains it, ignoring case.
+	Name string
+	// Statuses keeps projects with an image in any of these statuses.
+	Statuses []string
+	// Sort is SortCreatedAt or SortUpdatedAt; empty sorts by creation.
+	Sort string
+	// Ascending lists the oldest first.
+	Ascending bool
 }
 
 // CreateRequest represents the input for creating a project.
@@ -22,4 +73,10 @@ type CreateRequest struct {
 // UpdateRequest represents the request payload for updating a project.
 type UpdateRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=100"`
+	// Watermark turns the staged-image watermark on or off; omitted keeps it.
+	Watermark *bool `json:"watermark,omitempty"`
+	// ModelID pins the staging model; omitted keeps it and "" clears it.
+	ModelID *string `json:"model_id,omitempty"`
+	// Locale sets the prompt locale; omitted keeps it and "" clears it.
+	Locale *string `json:"locale,omitempty"`
 }
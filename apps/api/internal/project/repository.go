@@ -2,6 +2,8 @@ package project
 
 import (
 	"context"
+
+	"github.com/real-staging-ai/api/internal/pagination"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
@@ -17,22 +19,52 @@ type Repository interface {
 	// GetProjectsByUserID retrieves all projects for a specific user.
 	GetProjectsByUserID(ctx context.Context, userID string) ([]Project, error)
 
+	// ListProjectsByUserID lists a page of the user's projects matching the
+	// filter, with their image counts, and the cursor of the next page, nil on
+	// the last one.
+	ListProjectsByUserID(
+		ctx context.Context, userID string, filter ListFilter, page pagination.Page,
+	) ([]Project, *string, error)
+
 	// GetProjectByID retrieves a specific project by its ID.
 	GetProjectByID(ctx context.Context, projectID string) (*Project, error)
 
-	// GetProjectByIDAndUserID retrieves a specific project by its ID and user ID.
+	// GetProjectByIDAndUserID retrieves a project the user can access: one they
+	// created or one shared with an organization they belong to.
 	GetProjectByIDAndUserID(ctx context.Context, projectID, userID string) (*Project, error)
 
+	// GetProjectsByOrgID retrieves all projects shared with an organization.
+	GetProjectsByOrgID(ctx context.Context, orgID string) ([]Project, error)
+
+	// SetProjectOrg shares a project with an organization, or unshares it when orgID is nil.
+	SetProjectOrg(ctx context.Context, projectID string, orgID *string) (*Project, error)
+
+	// GetBillingUserID returns the user whose plan pays for images in the project:
+	// the organization's billing user for shared projects, otherwise the creator.
+	GetBillingUserID(ctx context.Context, projectID string) (string, error)
+
 	// UpdateProject updates an existing project's name.
 	UpdateProject(ctx context.Context, projectID, name string) (*Project, error)
 
-	// UpdateProjectByUserID updates an existing project's name with user ownership verification.
-	UpdateProjectByUserID(ctx context.Context, projectID, userID, name string) (*Project, error)
+	// UpdateProjectByUserID updates a project's name, and its watermark flag,
+	// model and locale unless they are nil, if the user created it or is an
+	// owner or admin of the organization it is shared with. An empty modelID or
+	// locale clears it.
+	UpdateProjectByUserID(
+		ctx context.Context, projectID, userID, name string, watermark *bool, modelID, locale *string,
+	) (*Project, error)
+
+	// SetProjectLockedByUserID locks or unlocks a project if the user created it
+	// or is an owner or admin of the organization it is shared with.
+	SetProjectLockedByUserID(ctx context.Context, projectID, userID string, locked bool) (*Project, error)
 
-	// DeleteProject deletes a project from the database.
+	// DeleteProject deletes a project from the database. It returns
+	// ErrProjectLocked when the project is locked.
 	DeleteProject(ctx context.Context, projectID string) error
 
-	// DeleteProjectByUserID deletes a project from the database with user ownership verification.
+	// DeleteProjectByUserID deletes a project if the user created it or is an
+	// owner or admin of the organization it is shared with. It returns
+	// ErrProjectLocked when the project is locked.
 	DeleteProjectByUserID(ctx context.Context, projectID, userID string) error
 
 	// CountProjectsByUserID returns the number of projects for a specific user.
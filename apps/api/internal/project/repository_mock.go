@@ -5,6 +5,7 @@ package project
 
 import (
 	"context"
+	"github.com/real-staging-ai/api/internal/pagination"
 	"sync"
 )
 
@@ -30,6 +31,9 @@ var _ Repository = &RepositoryMock{}
 //			DeleteProjectByUserIDFunc: func(ctx context.Context, projectID string, userID string) error {
 //				panic("mock out the DeleteProjectByUserID method")
 //			},
+//			GetBillingUserIDFunc: func(ctx context.Context, projectID string) (string, error) {
+//				panic("mock out the GetBillingUserID method")
+//			},
 //			GetProjectByIDFunc: func(ctx context.Context, projectID string) (*Project, error) {
 //				panic("mock out the GetProjectByID method")
 //			},
@@ -39,13 +43,25 @@ var _ Repository = &RepositoryMock{}
 //			GetProjectsFunc: func(ctx context.Context) ([]Project, error) {
 //				panic("mock out the GetProjects method")
 //			},
+//			GetProjectsByOrgIDFunc: func(ctx context.Context, orgID string) ([]Project, error) {
+//				panic("mock out the GetProjectsByOrgID method")
+//			},
 //			GetProjectsByUserIDFunc: func(ctx context.Context, userID string) ([]Project, error) {
 //				panic("mock out the GetProjectsByUserID method")
 //			},
+//			ListProjectsByUserIDFunc: func(ctx context.Context, userID string, filter ListFilter, page pagination.Page) ([]Project, *string, error) {
+//				panic("mock out the ListProjectsByUserID method")
+//			},
+//			SetProjectLockedByUserIDFunc: func(ctx context.Context, projectID string, userID string, locked bool) (*Project, error) {
+//				panic("mock out the SetProjectLockedByUserID method")
+//			},
+//			SetProjectOrgFunc: func(ctx context.Context, projectID string, orgID *string) (*Project, error) {
+//				panic("mock out the SetProjectOrg method")
+//			},
 //			UpdateProjectFunc: func(ctx context.Context, projectID string, name string) (*Project, error) {
 //				panic("mock out the UpdateProject method")
 //			},
-//			UpdateProjectByUserIDFunc: func(ctx context.Context, projectID string, userID string, name string) (*Project, error) {
+//			UpdateProjectByUserIDFunc: func(ctx context.Context, projectID string, userID string, name string, watermark *bool, modelID *string, locale *string) (*Project, error) {
 //				panic("mock out the UpdateProjectByUserID method")
 //			},
 //		}
@@ -67,6 +83,9 @@ type RepositoryMock struct {
 	// DeleteProjectByUserIDFunc mocks the DeleteProjectByUserID method.
 	DeleteProjectByUserIDFunc func(ctx context.Context, projectID string, userID string) error
 
+	// GetBillingUserIDFunc mocks the GetBillingUserID method.
+	GetBillingUserIDFunc func(ctx context.Context, projectID string) (string, error)
+
 	// GetProjectByIDFunc mocks the GetProjectByID method.
 	GetProjectByIDFunc func(ctx context.Context, projectID string) (*Project, error)
 
@@ -76,14 +95,26 @@ type RepositoryMock struct {
 	// GetProjectsFunc mocks the GetProjects method.
 	GetProjectsFunc func(ctx context.Context) ([]Project, error)
 
+	// GetProjectsByOrgIDFunc mocks the GetProjectsByOrgID method.
+	GetProjectsByOrgIDFunc func(ctx context.Context, orgID string) ([]Project, error)
+
 	// GetProjectsByUserIDFunc mocks the GetProjectsByUserID method.
 	GetProjectsByUserIDFunc func(ctx context.Context, userID string) ([]Project, error)
 
+	// ListProjectsByUserIDFunc mocks the ListProjectsByUserID method.
+	ListProjectsByUserIDFunc func(ctx context.Context, userID string, filter ListFilter, page pagination.Page) ([]Project, *string, error)
+
+	// SetProjectLockedByUserIDFunc mocks the SetProjectLockedByUserID method.
+	SetProjectLockedByUserIDFunc func(ctx context.Context, projectID string, userID string, locked bool) (*Project, error)
+
+	// SetProjectOrgFunc mocks the SetProjectOrg method.
+	SetProjectOrgFunc func(ctx context.Context, projectID string, orgID *string) (*Project, error)
+
 	// UpdateProjectFunc mocks the UpdateProject method.
 	UpdateProjectFunc func(ctx context.Context, projectID string, name string) (*Project, error)
 
 	// UpdateProjectByUserIDFunc mocks the UpdateProjectByUserID method.
-	UpdateProjectByUserIDFunc func(ctx context.Context, projectID string, userID string, name string) (*Project, error)
+	UpdateProjectByUserIDFunc func(ctx context.Context, projectID string, userID string, name string, watermark *bool, modelID *string, locale *string) (*Project, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -119,6 +150,13 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// GetBillingUserID holds details about calls to the GetBillingUserID method.
+		GetBillingUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+		}
 		// GetProjectByID holds details about calls to the GetProjectByID method.
 		GetProjectByID []struct {
 			// Ctx is the ctx argument value.
@@ -140,6 +178,13 @@ type RepositoryMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// GetProjectsByOrgID holds details about calls to the GetProjectsByOrgID method.
+		GetProjectsByOrgID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrgID is the orgID argument value.
+			OrgID string
+		}
 		// GetProjectsByUserID holds details about calls to the GetProjectsByUserID method.
 		GetProjectsByUserID []struct {
 			// Ctx is the ctx argument value.
@@ -147,6 +192,37 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// ListProjectsByUserID holds details about calls to the ListProjectsByUserID method.
+		ListProjectsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// Filter is the filter argument value.
+			Filter ListFilter
+			// Page is the page argument value.
+			Page pagination.Page
+		}
+		// SetProjectLockedByUserID holds details about calls to the SetProjectLockedByUserID method.
+		SetProjectLockedByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// UserID is the userID argument value.
+			UserID string
+			// Locked is the locked argument value.
+			Locked bool
+		}
+		// SetProjectOrg holds details about calls to the SetProjectOrg method.
+		SetProjectOrg []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// OrgID is the orgID argument value.
+			OrgID *string
+		}
 		// UpdateProject holds details about calls to the UpdateProject method.
 		UpdateProject []struct {
 			// Ctx is the ctx argument value.
@@ -166,18 +242,29 @@ type RepositoryMock struct {
 			UserID string
 			// Name is the name argument value.
 			Name string
+			// Watermark is the watermark argument value.
+			Watermark *bool
+			// ModelID is the modelID argument value.
+			ModelID *string
+			// Locale is the locale argument value.
+			Locale *string
 		}
 	}
-	lockCountProjectsByUserID   sync.RWMutex
-	lockCreateProject           sync.RWMutex
-	lockDeleteProject           sync.RWMutex
-	lockDeleteProjectByUserID   sync.RWMutex
-	lockGetProjectByID          sync.RWMutex
-	lockGetProjectByIDAndUserID sync.RWMutex
-	lockGetProjects             sync.RWMutex
-	lockGetProjectsByUserID     sync.RWMutex
-	lockUpdateProject           sync.RWMutex
-	lockUpdateProjectByUserID   sync.RWMutex
+	lockCountProjectsByUserID    sync.RWMutex
+	lockCreateProject            sync.RWMutex
+	lockDeleteProject            sync.RWMutex
+	lockDeleteProjectByUserID    sync.RWMutex
+	lockGetBillingUserID         sync.RWMutex
+	lockGetProjectByID           sync.RWMutex
+	lockGetProjectByIDAndUserID  sync.RWMutex
+	lockGetProjects              sync.RWMutex
+	lockGetProjectsByOrgID       sync.RWMutex
+	lockGetProjectsByUserID      sync.RWMutex
+	lockListProjectsByUserID     sync.RWMutex
+	lockSetProjectLockedByUserID sync.RWMutex
+	lockSetProjectOrg            sync.RWMutex
+	lockUpdateProject            sync.RWMutex
+	lockUpdateProjectByUserID    sync.RWMutex
 }
 
 // CountProjectsByUserID calls CountProjectsByUserIDFunc.
@@ -332,6 +419,42 @@ func (mock *RepositoryMock) DeleteProjectByUserIDCalls() []struct {
 	return calls
 }
 
+// GetBillingUserID calls GetBillingUserIDFunc.
+func (mock *RepositoryMock) GetBillingUserID(ctx context.Context, projectID string) (string, error) {
+	if mock.GetBillingUserIDFunc == nil {
+		panic("RepositoryMock.GetBillingUserIDFunc: method is nil but Repository.GetBillingUserID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+	}
+	mock.lockGetBillingUserID.Lock()
+	mock.calls.GetBillingUserID = append(mock.calls.GetBillingUserID, callInfo)
+	mock.lockGetBillingUserID.Unlock()
+	return mock.GetBillingUserIDFunc(ctx, projectID)
+}
+
+// GetBillingUserIDCalls gets all the calls that were made to GetBillingUserID.
+// Check the length with:
+//
+//	len(mockedRepository.GetBillingUserIDCalls())
+func (mock *RepositoryMock) GetBillingUserIDCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+	}
+	mock.lockGetBillingUserID.RLock()
+	calls = mock.calls.GetBillingUserID
+	mock.lockGetBillingUserID.RUnlock()
+	return calls
+}
+
 // GetProjectByID calls GetProjectByIDFunc.
 func (mock *RepositoryMock) GetProjectByID(ctx context.Context, projectID string) (*Project, error) {
 	if mock.GetProjectByIDFunc == nil {
@@ -440,6 +563,42 @@ func (mock *RepositoryMock) GetProjectsCalls() []struct {
 	return calls
 }
 
+// GetProjectsByOrgID calls GetProjectsByOrgIDFunc.
+func (mock *RepositoryMock) GetProjectsByOrgID(ctx context.Context, orgID string) ([]Project, error) {
+	if mock.GetProjectsByOrgIDFunc == nil {
+		panic("RepositoryMock.GetProjectsByOrgIDFunc: method is nil but Repository.GetProjectsByOrgID was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		OrgID string
+	}{
+		Ctx:   ctx,
+		OrgID: orgID,
+	}
+	mock.lockGetProjectsByOrgID.Lock()
+	mock.calls.GetProjectsByOrgID = append(mock.calls.GetProjectsByOrgID, callInfo)
+	mock.lockGetProjectsByOrgID.Unlock()
+	return mock.GetProjectsByOrgIDFunc(ctx, orgID)
+}
+
+// GetProjectsByOrgIDCalls gets all the calls that were made to GetProjectsByOrgID.
+// Check the length with:
+//
+//	len(mockedRepository.GetProjectsByOrgIDCalls())
+func (mock *RepositoryMock) GetProjectsByOrgIDCalls() []struct {
+	Ctx   context.Context
+	OrgID string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		OrgID string
+	}
+	mock.lockGetProjectsByOrgID.RLock()
+	calls = mock.calls.GetProjectsByOrgID
+	mock.lockGetProjectsByOrgID.RUnlock()
+	return calls
+}
+
 // GetProjectsByUserID calls GetProjectsByUserIDFunc.
 func (mock *RepositoryMock) GetProjectsByUserID(ctx context.Context, userID string) ([]Project, error) {
 	if mock.GetProjectsByUserIDFunc == nil {
@@ -476,6 +635,134 @@ func (mock *RepositoryMock) GetProjectsByUserIDCalls() []struct {
 	return calls
 }
 
+// ListProjectsByUserID calls ListProjectsByUserIDFunc.
+func (mock *RepositoryMock) ListProjectsByUserID(ctx context.Context, userID string, filter ListFilter, page pagination.Page) ([]Project, *string, error) {
+	if mock.ListProjectsByUserIDFunc == nil {
+		panic("RepositoryMock.ListProjectsByUserIDFunc: method is nil but Repository.ListProjectsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID string
+		Filter ListFilter
+		Page   pagination.Page
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Filter: filter,
+		Page:   page,
+	}
+	mock.lockListProjectsByUserID.Lock()
+	mock.calls.ListProjectsByUserID = append(mock.calls.ListProjectsByUserID, callInfo)
+	mock.lockListProjectsByUserID.Unlock()
+	return mock.ListProjectsByUserIDFunc(ctx, userID, filter, page)
+}
+
+// ListProjectsByUserIDCalls gets all the calls that were made to ListProjectsByUserID.
+// Check the length with:
+//
+//	len(mockedRepository.ListProjectsByUserIDCalls())
+func (mock *RepositoryMock) ListProjectsByUserIDCalls() []struct {
+	Ctx    context.Context
+	UserID string
+	Filter ListFilter
+	Page   pagination.Page
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID string
+		Filter ListFilter
+		Page   pagination.Page
+	}
+	mock.lockListProjectsByUserID.RLock()
+	calls = mock.calls.ListProjectsByUserID
+	mock.lockListProjectsByUserID.RUnlock()
+	return calls
+}
+
+// SetProjectLockedByUserID calls SetProjectLockedByUserIDFunc.
+func (mock *RepositoryMock) SetProjectLockedByUserID(ctx context.Context, projectID string, userID string, locked bool) (*Project, error) {
+	if mock.SetProjectLockedByUserIDFunc == nil {
+		panic("RepositoryMock.SetProjectLockedByUserIDFunc: method is nil but Repository.SetProjectLockedByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		Locked    bool
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		UserID:    userID,
+		Locked:    locked,
+	}
+	mock.lockSetProjectLockedByUserID.Lock()
+	mock.calls.SetProjectLockedByUserID = append(mock.calls.SetProjectLockedByUserID, callInfo)
+	mock.lockSetProjectLockedByUserID.Unlock()
+	return mock.SetProjectLockedByUserIDFunc(ctx, projectID, userID, locked)
+}
+
+// SetProjectLockedByUserIDCalls gets all the calls that were made to SetProjectLockedByUserID.
+// Check the length with:
+//
+//	len(mockedRepository.SetProjectLockedByUserIDCalls())
+func (mock *RepositoryMock) SetProjectLockedByUserIDCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	UserID    string
+	Locked    bool
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		UserID    string
+		Locked    bool
+	}
+	mock.lockSetProjectLockedByUserID.RLock()
+	calls = mock.calls.SetProjectLockedByUserID
+	mock.lockSetProjectLockedByUserID.RUnlock()
+	return calls
+}
+
+// SetProjectOrg calls SetProjectOrgFunc.
+func (mock *RepositoryMock) SetProjectOrg(ctx context.Context, projectID string, orgID *string) (*Project, error) {
+	if mock.SetProjectOrgFunc == nil {
+		panic("RepositoryMock.SetProjectOrgFunc: method is nil but Repository.SetProjectOrg was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		ProjectID string
+		OrgID     *string
+	}{
+		Ctx:       ctx,
+		ProjectID: projectID,
+		OrgID:     orgID,
+	}
+	mock.lockSetProjectOrg.Lock()
+	mock.calls.SetProjectOrg = append(mock.calls.SetProjectOrg, callInfo)
+	mock.lockSetProjectOrg.Unlock()
+	return mock.SetProjectOrgFunc(ctx, projectID, orgID)
+}
+
+// SetProjectOrgCalls gets all the calls that were made to SetProjectOrg.
+// Check the length with:
+//
+//	len(mockedRepository.SetProjectOrgCalls())
+func (mock *RepositoryMock) SetProjectOrgCalls() []struct {
+	Ctx       context.Context
+	ProjectID string
+	OrgID     *string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		ProjectID string
+		OrgID     *string
+	}
+	mock.lockSetProjectOrg.RLock()
+	calls = mock.calls.SetProjectOrg
+	mock.lockSetProjectOrg.RUnlock()
+	return calls
+}
+
 // UpdateProject calls UpdateProjectFunc.
 func (mock *RepositoryMock) UpdateProject(ctx context.Context, projectID string, name string) (*Project, error) {
 	if mock.UpdateProjectFunc == nil {
@@ -517,7 +804,7 @@ func (mock *RepositoryMock) UpdateProjectCalls() []struct {
 }
 
 // UpdateProjectByUserID calls UpdateProjectByUserIDFunc.
-func (mock *RepositoryMock) UpdateProjectByUserID(ctx context.Context, projectID string, userID string, name string) (*Project, error) {
+func (mock *RepositoryMock) UpdateProjectByUserID(ctx context.Context, projectID string, userID string, name string, watermark *bool, modelID *string, locale *string) (*Project, error) {
 	if mock.UpdateProjectByUserIDFunc == nil {
 		panic("RepositoryMock.UpdateProjectByUserIDFunc: method is nil but Repository.UpdateProjectByUserID was just called")
 	}
@@ -526,16 +813,22 @@ func (mock *RepositoryMock) UpdateProjectByUserID(ctx context.Context, projectID
 		ProjectID string
 		UserID    string
 		Name      string
+		Watermark *bool
+		ModelID   *string
+		Locale    *string
 	}{
 		Ctx:       ctx,
 		ProjectID: projectID,
 		UserID:    userID,
 		Name:      name,
+		Watermark: watermark,
+		ModelID:   modelID,
+		Locale:    locale,
 	}
 	mock.lockUpdateProjectByUserID.Lock()
 	mock.calls.UpdateProjectByUserID = append(mock.calls.UpdateProjectByUserID, callInfo)
 	mock.lockUpdateProjectByUserID.Unlock()
-	return mock.UpdateProjectByUserIDFunc(ctx, projectID, userID, name)
+	return mock.UpdateProjectByUserIDFunc(ctx, projectID, userID, name, watermark, modelID, locale)
 }
 
 // UpdateProjectByUserIDCalls gets all the calls that were made to UpdateProjectByUserID.
@@ -547,12 +840,18 @@ func (mock *RepositoryMock) UpdateProjectByUserIDCalls() []struct {
 	ProjectID string
 	UserID    string
 	Name      string
+	Watermark *bool
+	ModelID   *string
+	Locale    *string
 } {
 	var calls []struct {
 		Ctx       context.Context
 		ProjectID string
 		UserID    string
 		Name      string
+		Watermark *bool
+		ModelID   *string
+		Locale    *string
 	}
 	mock.lockUpdateProjectByUserID.RLock()
 	calls = mock.calls.UpdateProjectByUserID
@@ -11,7 +11,9 @@ type StorageSQLc interface {
 	GetProjectByID(ctx context.Context, projectID string) (*Project, error)
 	GetProjectByIDAndUserID(ctx context.Context, projectID, userID string) (*Project, error)
 	UpdateProject(ctx context.Context, projectID, name string) (*Project, error)
-	UpdateProjectByUserID(ctx context.Context, projectID, userID, name string) (*Project, error)
+	UpdateProjectByUserID(
+		ctx context.Context, projectID, userID, name string, watermark *bool, modelID, locale *string,
+	) (*Project, error)
 	DeleteProject(ctx context.Context, projectID string) error
 	DeleteProjectByUserID(ctx context.Context, projectID, userID string) error
 	CountProjectsByUserID(ctx context.Context, userID string) (int64, error)
@@ -45,7 +45,7 @@ var _ StorageSQLc = &StorageSQLcMock{}
 //			UpdateProjectFunc: func(ctx context.Context, projectID string, name string) (*Project, error) {
 //				panic("mock out the UpdateProject method")
 //			},
-//			UpdateProjectByUserIDFunc: func(ctx context.Context, projectID string, userID string, name string) (*Project, error) {
+//			UpdateProjectByUserIDFunc: func(ctx context.Context, projectID string, userID string, name string, watermark *bool, modelID *string, locale *string) (*Project, error) {
 //				panic("mock out the UpdateProjectByUserID method")
 //			},
 //		}
@@ -83,7 +83,7 @@ type StorageSQLcMock struct {
 	UpdateProjectFunc func(ctx context.Context, projectID string, name string) (*Project, error)
 
 	// UpdateProjectByUserIDFunc mocks the UpdateProjectByUserID method.
-	UpdateProjectByUserIDFunc func(ctx context.Context, projectID string, userID string, name string) (*Project, error)
+	UpdateProjectByUserIDFunc func(ctx context.Context, projectID string, userID string, name string, watermark *bool, modelID *string, locale *string) (*Project, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -166,6 +166,12 @@ type StorageSQLcMock struct {
 			UserID string
 			// Name is the name argument value.
 			Name string
+			// Watermark is the watermark argument value.
+			Watermark *bool
+			// ModelID is the modelID argument value.
+			ModelID *string
+			// Locale is the locale argument value.
+			Locale *string
 		}
 	}
 	lockCountProjectsByUserID   sync.RWMutex
@@ -517,7 +523,7 @@ func (mock *StorageSQLcMock) UpdateProjectCalls() []struct {
 }
 
 // UpdateProjectByUserID calls UpdateProjectByUserIDFunc.
-func (mock *StorageSQLcMock) UpdateProjectByUserID(ctx context.Context, projectID string, userID string, name string) (*Project, error) {
+func (mock *StorageSQLcMock) UpdateProjectByUserID(ctx context.Context, projectID string, userID string, name string, watermark *bool, modelID *string, locale *string) (*Project, error) {
 	if mock.UpdateProjectByUserIDFunc == nil {
 		panic("StorageSQLcMock.UpdateProjectByUserIDFunc: method is nil but StorageSQLc.UpdateProjectByUserID was just called")
 	}
@@ -526,16 +532,22 @@ func (mock *StorageSQLcMock) UpdateProjectByUserID(ctx context.Context, projectI
 		ProjectID string
 		UserID    string
 		Name      string
+		Watermark *bool
+		ModelID   *string
+		Locale    *string
 	}{
 		Ctx:       ctx,
 		ProjectID: projectID,
 		UserID:    userID,
 		Name:      name,
+		Watermark: watermark,
+		ModelID:   modelID,
+		Locale:    locale,
 	}
 	mock.lockUpdateProjectByUserID.Lock()
 	mock.calls.UpdateProjectByUserID = append(mock.calls.UpdateProjectByUserID, callInfo)
 	mock.lockUpdateProjectByUserID.Unlock()
-	return mock.UpdateProjectByUserIDFunc(ctx, projectID, userID, name)
+	return mock.UpdateProjectByUserIDFunc(ctx, projectID, userID, name, watermark, modelID, locale)
 }
 
 // UpdateProjectByUserIDCalls gets all the calls that were made to UpdateProjectByUserID.
@@ -547,12 +559,18 @@ func (mock *StorageSQLcMock) UpdateProjectByUserIDCalls() []struct {
 	ProjectID string
 	UserID    string
 	Name      string
+	Watermark *bool
+	ModelID   *string
+	Locale    *string
 } {
 	var calls []struct {
 		Ctx       context.Context
 		ProjectID string
 		UserID    string
 		Name      string
+		Watermark *bool
+		ModelID   *string
+		Locale    *string
 	}
 	mock.lockUpdateProjectByUserID.RLock()
 	calls = mock.calls.UpdateProjectByUserID
@@ -0,0 +1,123 @@
+package promptexperiment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the admin prompt experiment endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// CreateExperiment handles POST /admin/experiments - Starts an experiment.
+func (h *DefaultHandler) CreateExperiment(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req CreateExperimentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	experiment, err := h.service.CreateExperiment(ctx, req, auth0Sub)
+	if err != nil {
+		return h.fail(c, err, "create prompt experiment")
+	}
+
+	h.log.Info(ctx, "prompt experiment started", "experiment_id", experiment.ID,
+		"room_type", experiment.RoomType, "style", experiment.Style, "auth0_sub", auth0Sub)
+	return c.JSON(http.StatusCreated, experiment)
+}
+
+// ListExperiments handles GET /admin/experiments - Lists every experiment, newest first.
+func (h *DefaultHandler) ListExperiments(c echo.Context) error {
+	experiments, err := h.service.ListExperiments(c.Request().Context())
+	if err != nil {
+		return h.fail(c, err, "list prompt experiments")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"experiments": experiments})
+}
+
+// GetExperiment handles GET /admin/experiments/:id - Returns an experiment.
+func (h *DefaultHandler) GetExperiment(c echo.Context) error {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid experiment ID")
+	}
+
+	experiment, err := h.service.GetExperiment(c.Request().Context(), id)
+	if err != nil {
+		return h.fail(c, err, "get prompt experiment")
+	}
+	return c.JSON(http.StatusOK, experiment)
+}
+
+// StopExperiment handles POST /admin/experiments/:id/stop - Ends a running experiment.
+func (h *DefaultHandler) StopExperiment(c echo.Context) error {
+	ctx := c.Request().Context()
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid experiment ID")
+	}
+
+	experiment, err := h.service.StopExperiment(ctx, id)
+	if err != nil {
+		return h.fail(c, err, "stop prompt experiment")
+	}
+
+	h.log.Info(ctx, "prompt experiment stopped", "experiment_id", id)
+	return c.JSON(http.StatusOK, experiment)
+}
+
+// GetReport handles GET /admin/experiments/:id/report - Compares the
+// completion, error and approval rates of the experiment's variants.
+func (h *DefaultHandler) GetReport(c echo.Context) error {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid experiment ID")
+	}
+
+	report, err := h.service.GetReport(c.Request().Context(), id)
+	if err != nil {
+		return h.fail(c, err, "report on prompt experiment")
+	}
+	return c.JSON(http.StatusOK, report)
+}
+
+// fail maps a service error to its response, logging unexpected ones as
+// failing to do action.
+func (h *DefaultHandler) fail(c echo.Context, err error, action string) error {
+	switch {
+	case errors.Is(err, ErrInvalidExperiment):
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, ErrExperimentNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Prompt experiment not found")
+	case errors.Is(err, ErrAlreadyRunning):
+		return echo.NewHTTPError(http.StatusConflict,
+			"A prompt experiment is already running for this room type and style")
+	case errors.Is(err, ErrNotRunning):
+		return echo.NewHTTPError(http.StatusConflict, "Prompt experiment is not running")
+	}
+	h.log.Error(c.Request().Context(), "failed to "+action, "error", err)
+	return echo.NewHTTPError(http.StatusInternalServerError, "Failed to "+action)
+}
@@ -0,0 +1,174 @@
+package promptexperiment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+const experimentID = "6f9619ff-8b86-4011-b42d-00c04fc964ff"
+
+func newContext(method, target, body, id string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if id != "" {
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+	}
+	return c, rec
+}
+
+// status returns the response code, or the code of the handler's HTTP error.
+func status(t *testing.T, rec *httptest.ResponseRecorder, err error) int {
+	t.Helper()
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	require.NoError(t, err)
+	return rec.Code
+}
+
+func TestDefaultHandler_CreateExperiment(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCalled bool
+	}{
+		{
+			name:       "success: starts the experiment",
+			body:       `{"name":"Warm","room_type":"bedroom","style":"modern","variants":[]}`,
+			wantStatus: http.StatusCreated,
+			wantCalled: true,
+		},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: invalid experiment",
+			body:       `{}`,
+			err:        ErrInvalidExperiment,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalled: true,
+		},
+		{
+			name:       "fail: already running",
+			body:       `{}`,
+			err:        ErrAlreadyRunning,
+			wantStatus: http.StatusConflict,
+			wantCalled: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateExperimentFunc: func(
+					ctx context.Context, req CreateExperimentRequest, createdBy string,
+				) (*Experiment, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Experiment{ID: experimentID, Name: req.Name, Status: StatusRunning}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/admin/experiments", tc.body, "")
+
+			err := NewDefaultHandler(svc, logging.Default()).CreateExperiment(c)
+
+			assert.Equal(t, tc.wantStatus, status(t, rec, err))
+			assert.Equal(t, tc.wantCalled, len(svc.CreateExperimentCalls()) == 1)
+		})
+	}
+}
+
+func TestDefaultHandler_ListExperiments(t *testing.T) {
+	svc := &ServiceMock{
+		ListExperimentsFunc: func(ctx context.Context) ([]Experiment, error) {
+			return []Experiment{{ID: experimentID, Status: StatusRunning, Variants: []Variant{}}}, nil
+		},
+	}
+	c, rec := newContext(http.MethodGet, "/api/v1/admin/experiments", "", "")
+
+	require.NoError(t, NewDefaultHandler(svc, logging.Default()).ListExperiments(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"experiments":[{"id":"`+experimentID)
+}
+
+func TestDefaultHandler_StopExperiment(t *testing.T) {
+	cases := []struct {
+		name       string
+		id         string
+		err        error
+		wantStatus int
+	}{
+		{name: "success: stops the experiment", id: experimentID, wantStatus: http.StatusOK},
+		{name: "fail: invalid ID", id: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: not found", id: experimentID, err: ErrExperimentNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: already stopped", id: experimentID, err: ErrNotRunning, wantStatus: http.StatusConflict},
+		{name: "fail: service error", id: experimentID, err: errors.New("db down"),
+			wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				StopExperimentFunc: func(ctx context.Context, id string) (*Experiment, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Experiment{ID: id, Status: StatusStopped}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/admin/experiments/"+tc.id+"/stop", "", tc.id)
+
+			err := NewDefaultHandler(svc, logging.Default()).StopExperiment(c)
+
+			assert.Equal(t, tc.wantStatus, status(t, rec, err))
+		})
+	}
+}
+
+func TestDefaultHandler_GetReport(t *testing.T) {
+	t.Run("success: returns the report", func(t *testing.T) {
+		completion := 0.5
+		svc := &ServiceMock{
+			GetReportFunc: func(ctx context.Context, id string) (*Report, error) {
+				return &Report{
+					Experiment: &Experiment{ID: id},
+					Variants:   []VariantReport{{VariantID: "v1", Assigned: 2, Completed: 1, CompletionRate: &completion}},
+				}, nil
+			},
+		}
+		c, rec := newContext(http.MethodGet, "/api/v1/admin/experiments/"+experimentID+"/report", "", experimentID)
+
+		require.NoError(t, NewDefaultHandler(svc, logging.Default()).GetReport(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"completion_rate":0.5`)
+	})
+
+	t.Run("fail: not found", func(t *testing.T) {
+		svc := &ServiceMock{
+			GetReportFunc: func(ctx context.Context, id string) (*Report, error) { return nil, ErrExperimentNotFound },
+		}
+		c, rec := newContext(http.MethodGet, "/api/v1/admin/experiments/"+experimentID+"/report", "", experimentID)
+
+		err := NewDefaultHandler(svc, logging.Default()).GetReport(c)
+
+		assert.Equal(t, http.StatusNotFound, status(t, rec, err))
+	})
+}
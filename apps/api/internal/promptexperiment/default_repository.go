@@ -0,0 +1,229 @@
+package promptexperiment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// experimentColumns are the prompt_experiments columns scanExperiment reads.
+const experimentColumns = ` id, name, room_type, style, status, created_at, created_by, stopped_at`
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+func scanExperiment(row pgx.Row) (*Experiment, error) {
+	var e Experiment
+	if err := row.Scan(&e.ID, &e.Name, &e.RoomType, &e.Style, &e.Status,
+		&e.CreatedAt, &e.CreatedBy, &e.StoppedAt); err != nil {
+		return nil, err
+	}
+	e.Variants = []Variant{}
+	return &e, nil
+}
+
+// Create inserts the experiment and its variants in one statement.
+func (r *DefaultRepository) Create(
+	ctx context.Context, req CreateExperimentRequest, createdBy string,
+) (*Experiment, error) {
+	type row struct {
+		Name     string `json:"name"`
+		Prompt   string `json:"prompt"`
+		Weight   int    `json:"weight"`
+		Position int    `json:"position"`
+	}
+	input := make([]row, 0, len(req.Variants))
+	for i, v := range req.Variants {
+		weight := 1
+		if v.Weight != nil {
+			weight = *v.Weight
+		}
+		input = append(input, row{Name: v.Name, Prompt: v.Prompt, Weight: weight, Position: i})
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode prompt experiment variants: %w", err)
+	}
+
+	query := `
+		WITH experiment AS (
+			INSERT INTO prompt_experiments (name, room_type, style, created_by)
+			VALUES ($1, $2, $3, $4)
+			RETURNING` + experimentColumns + `
+		), variants AS (
+			INSERT INTO prompt_experiment_variants (experiment_id, name, prompt, weight, position)
+			SELECT e.id, v.name, v.prompt, v.weight, v.position
+			FROM experiment e,
+				jsonb_to_recordset($5::jsonb) AS v(name TEXT, prompt TEXT, weight INTEGER, position INTEGER)
+		)
+		SELECT` + experimentColumns + ` FROM experiment`
+
+	e, err := scanExperiment(r.db.QueryRow(ctx, query, req.Name, req.RoomType, req.Style, createdBy, payload))
+	if err != nil {
+		if storage.IsUniqueViolation(err) {
+			return nil, ErrAlreadyRunning
+		}
+		return nil, fmt.Errorf("failed to create prompt experiment: %w", err)
+	}
+	// The variants CTE's rows aren't visible to this statement, so read them back.
+	return r.withVariants(ctx, e)
+}
+
+// GetByID returns the experiment with its variants.
+func (r *DefaultRepository) GetByID(ctx context.Context, id string) (*Experiment, error) {
+	query := `SELECT` + experimentColumns + ` FROM prompt_experiments WHERE id = $1`
+
+	e, err := scanExperiment(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExperimentNotFound
+		}
+		return nil, fmt.Errorf("failed to get prompt experiment: %w", err)
+	}
+	return r.withVariants(ctx, e)
+}
+
+// List returns every experiment with its variants.
+func (r *DefaultRepository) List(ctx context.Context) ([]Experiment, error) {
+	query := `SELECT` + experimentColumns + ` FROM prompt_experiments ORDER BY created_at DESC, id`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt experiments: %w", err)
+	}
+	defer rows.Close()
+
+	experiments := []Experiment{}
+	ids := []string{}
+	for rows.Next() {
+		e, err := scanExperiment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prompt experiment: %w", err)
+		}
+		experiments = append(experiments, *e)
+		ids = append(ids, e.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt experiment rows: %w", err)
+	}
+
+	variants, err := r.variants(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range experiments {
+		if v, ok := variants[experiments[i].ID]; ok {
+			experiments[i].Variants = v
+		}
+	}
+	return experiments, nil
+}
+
+// Stop marks a running experiment stopped.
+func (r *DefaultRepository) Stop(ctx context.Context, id string) (*Experiment, error) {
+	query := `
+		UPDATE prompt_experiments SET status = 'stopped', stopped_at = now()
+		WHERE id = $1 AND status = 'running'
+		RETURNING` + experimentColumns
+
+	e, err := scanExperiment(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotRunning
+		}
+		return nil, fmt.Errorf("failed to stop prompt experiment: %w", err)
+	}
+	return r.withVariants(ctx, e)
+}
+
+// Outcomes counts each variant's images by final status and verdict. Deleted
+// images still count: they were staged with the variant all the same.
+func (r *DefaultRepository) Outcomes(ctx context.Context, experimentID string) (map[string]Outcome, error) {
+	query := `
+		SELECT v.id,
+			count(i.id),
+			count(i.id) FILTER (WHERE i.status = 'ready'),
+			count(i.id) FILTER (WHERE i.status = 'error'),
+			count(i.id) FILTER (WHERE i.approved),
+			count(i.id) FILTER (WHERE NOT i.approved)
+		FROM prompt_experiment_variants v
+		LEFT JOIN images i ON i.prompt_variant_id = v.id
+		WHERE v.experiment_id = $1
+		GROUP BY v.id`
+
+	rows, err := r.db.Query(ctx, query, experimentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tally prompt experiment outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	outcomes := map[string]Outcome{}
+	for rows.Next() {
+		var id string
+		var o Outcome
+		if err := rows.Scan(&id, &o.Assigned, &o.Ready, &o.Failed, &o.Approved, &o.Rejected); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt experiment outcome: %w", err)
+		}
+		outcomes[id] = o
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt experiment outcome rows: %w", err)
+	}
+	return outcomes, nil
+}
+
+// withVariants loads the experiment's variants into it.
+func (r *DefaultRepository) withVariants(ctx context.Context, e *Experiment) (*Experiment, error) {
+	variants, err := r.variants(ctx, []string{e.ID})
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := variants[e.ID]; ok {
+		e.Variants = v
+	}
+	return e, nil
+}
+
+// variants returns the experiments' variants in definition order, keyed by experiment ID.
+func (r *DefaultRepository) variants(ctx context.Context, experimentIDs []string) (map[string][]Variant, error) {
+	query := `
+		SELECT experiment_id, id, name, prompt, weight
+		FROM prompt_experiment_variants
+		WHERE experiment_id = ANY($1::uuid[])
+		ORDER BY experiment_id, position`
+
+	rows, err := r.db.Query(ctx, query, experimentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt experiment variants: %w", err)
+	}
+	defer rows.Close()
+
+	variants := make(map[string][]Variant, len(experimentIDs))
+	for rows.Next() {
+		var experimentID string
+		var v Variant
+		if err := rows.Scan(&experimentID, &v.ID, &v.Name, &v.Prompt, &v.Weight); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt experiment variant: %w", err)
+		}
+		variants[experimentID] = append(variants[experimentID], v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt experiment variant rows: %w", err)
+	}
+	return variants, nil
+}
@@ -0,0 +1,86 @@
+package promptexperiment
+
+import "context"
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// CreateExperiment starts the experiment after validating it.
+func (s *DefaultService) CreateExperiment(
+	ctx context.Context, req CreateExperimentRequest, createdBy string,
+) (*Experiment, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, req, createdBy)
+}
+
+// ListExperiments returns every experiment.
+func (s *DefaultService) ListExperiments(ctx context.Context) ([]Experiment, error) {
+	return s.repo.List(ctx)
+}
+
+// GetExperiment returns the experiment.
+func (s *DefaultService) GetExperiment(ctx context.Context, id string) (*Experiment, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// StopExperiment stops a running experiment, telling a missing experiment
+// apart from one that has already stopped.
+func (s *DefaultService) StopExperiment(ctx context.Context, id string) (*Experiment, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.Stop(ctx, id)
+}
+
+// GetReport turns each variant's outcome into rates.
+func (s *DefaultService) GetReport(ctx context.Context, id string) (*Report, error) {
+	experiment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	outcomes, err := s.repo.Outcomes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Experiment: experiment, Variants: make([]VariantReport, 0, len(experiment.Variants))}
+	for _, v := range experiment.Variants {
+		o := outcomes[v.ID]
+		report.Variants = append(report.Variants, VariantReport{
+			VariantID:      v.ID,
+			Name:           v.Name,
+			Weight:         v.Weight,
+			Assigned:       o.Assigned,
+			Completed:      o.Ready,
+			Errored:        o.Failed,
+			Pending:        o.Assigned - o.Ready - o.Failed,
+			Approved:       o.Approved,
+			Rejected:       o.Rejected,
+			CompletionRate: rate(o.Ready, o.Assigned),
+			ErrorRate:      rate(o.Failed, o.Assigned),
+			ApprovalRate:   rate(o.Approved, o.Approved+o.Rejected),
+		})
+	}
+	return report, nil
+}
+
+// rate returns n/total, or nil when total is zero.
+func rate(n, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	r := float64(n) / float64(total)
+	return &r
+}
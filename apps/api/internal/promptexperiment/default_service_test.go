@@ -0,0 +1,160 @@
+package promptexperiment
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/promptlib"
+)
+
+func validRequest() CreateExperimentRequest {
+	return CreateExperimentRequest{
+		Name:     " Warmer bedrooms ",
+		RoomType: "bedroom",
+		Style:    "modern",
+		Variants: []CreateVariantRequest{
+			{Name: "control", Prompt: "Stage a modern bedroom."},
+			{Name: "warm", Prompt: "Stage a warm, modern bedroom."},
+		},
+	}
+}
+
+func TestDefaultService_CreateExperiment(t *testing.T) {
+	t.Run("success: trims names and stores the experiment", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, req CreateExperimentRequest, createdBy string) (*Experiment, error) {
+				return &Experiment{ID: "e1", Name: req.Name, Status: StatusRunning}, nil
+			},
+		}
+
+		got, err := NewDefaultService(repo).CreateExperiment(context.Background(), validRequest(), "admin")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Warmer bedrooms", got.Name)
+		require.Len(t, repo.CreateCalls(), 1)
+		assert.Equal(t, "admin", repo.CreateCalls()[0].CreatedBy)
+	})
+
+	t.Run("fail: invalid definitions", func(t *testing.T) {
+		weight := func(w int) *int { return &w }
+		cases := map[string]func(r *CreateExperimentRequest){
+			"no name":           func(r *CreateExperimentRequest) { r.Name = " " },
+			"long name":         func(r *CreateExperimentRequest) { r.Name = strings.Repeat("a", MaxNameLength+1) },
+			"bad room type":     func(r *CreateExperimentRequest) { r.RoomType = "Bedroom" },
+			"bad style":         func(r *CreateExperimentRequest) { r.Style = "" },
+			"one variant":       func(r *CreateExperimentRequest) { r.Variants = r.Variants[:1] },
+			"duplicate variant": func(r *CreateExperimentRequest) { r.Variants[1].Name = "control " },
+			"unnamed variant":   func(r *CreateExperimentRequest) { r.Variants[0].Name = "" },
+			"empty prompt":      func(r *CreateExperimentRequest) { r.Variants[0].Prompt = "  " },
+			"long prompt": func(r *CreateExperimentRequest) {
+				r.Variants[0].Prompt = strings.Repeat("a", promptlib.MaxPromptLength+1)
+			},
+			"zero weight":  func(r *CreateExperimentRequest) { r.Variants[0].Weight = weight(0) },
+			"heavy weight": func(r *CreateExperimentRequest) { r.Variants[0].Weight = weight(MaxWeight + 1) },
+		}
+		for name, mutate := range cases {
+			t.Run(name, func(t *testing.T) {
+				repo := &RepositoryMock{}
+				req := validRequest()
+				mutate(&req)
+
+				_, err := NewDefaultService(repo).CreateExperiment(context.Background(), req, "admin")
+
+				assert.ErrorIs(t, err, ErrInvalidExperiment)
+				assert.Empty(t, repo.CreateCalls())
+			})
+		}
+	})
+
+	t.Run("fail: already running", func(t *testing.T) {
+		repo := &RepositoryMock{
+			CreateFunc: func(ctx context.Context, req CreateExperimentRequest, createdBy string) (*Experiment, error) {
+				return nil, ErrAlreadyRunning
+			},
+		}
+
+		_, err := NewDefaultService(repo).CreateExperiment(context.Background(), validRequest(), "admin")
+
+		assert.ErrorIs(t, err, ErrAlreadyRunning)
+	})
+}
+
+func TestDefaultService_StopExperiment(t *testing.T) {
+	t.Run("success: stops the experiment", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Experiment, error) {
+				return &Experiment{ID: id, Status: StatusRunning}, nil
+			},
+			StopFunc: func(ctx context.Context, id string) (*Experiment, error) {
+				return &Experiment{ID: id, Status: StatusStopped}, nil
+			},
+		}
+
+		got, err := NewDefaultService(repo).StopExperiment(context.Background(), "e1")
+
+		require.NoError(t, err)
+		assert.Equal(t, StatusStopped, got.Status)
+	})
+
+	t.Run("fail: not found", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Experiment, error) { return nil, ErrExperimentNotFound },
+		}
+
+		_, err := NewDefaultService(repo).StopExperiment(context.Background(), "e1")
+
+		assert.ErrorIs(t, err, ErrExperimentNotFound)
+		assert.Empty(t, repo.StopCalls())
+	})
+}
+
+func TestDefaultService_GetReport(t *testing.T) {
+	experiment := &Experiment{ID: "e1", Variants: []Variant{
+		{ID: "v1", Name: "control", Weight: 1},
+		{ID: "v2", Name: "warm", Weight: 3},
+	}}
+
+	t.Run("success: computes rates per variant", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Experiment, error) { return experiment, nil },
+			OutcomesFunc: func(ctx context.Context, experimentID string) (map[string]Outcome, error) {
+				return map[string]Outcome{"v1": {Assigned: 10, Ready: 8, Failed: 1, Approved: 3, Rejected: 1}}, nil
+			},
+		}
+
+		got, err := NewDefaultService(repo).GetReport(context.Background(), "e1")
+
+		require.NoError(t, err)
+		require.Len(t, got.Variants, 2)
+		control := got.Variants[0]
+		assert.Equal(t, 1, control.Pending)
+		assert.InDelta(t, 0.8, *control.CompletionRate, 1e-9)
+		assert.InDelta(t, 0.1, *control.ErrorRate, 1e-9)
+		assert.InDelta(t, 0.75, *control.ApprovalRate, 1e-9)
+
+		warm := got.Variants[1]
+		assert.Equal(t, "warm", warm.Name)
+		assert.Equal(t, 3, warm.Weight)
+		assert.Zero(t, warm.Assigned)
+		assert.Nil(t, warm.CompletionRate)
+		assert.Nil(t, warm.ApprovalRate)
+	})
+
+	t.Run("fail: outcome error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Experiment, error) { return experiment, nil },
+			OutcomesFunc: func(ctx context.Context, experimentID string) (map[string]Outcome, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		_, err := NewDefaultService(repo).GetReport(context.Background(), "e1")
+
+		assert.Error(t, err)
+	})
+}
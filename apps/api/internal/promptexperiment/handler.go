@@ -0,0 +1,14 @@
+package promptexperiment
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin endpoints for prompt experiments.
+type Handler interface {
+	CreateExperiment(c echo.Context) error
+	ListExperiments(c echo.Context) error
+	GetExperiment(c echo.Context) error
+	StopExperiment(c echo.Context) error
+	GetReport(c echo.Context) error
+}
@@ -0,0 +1,162 @@
+// Package promptexperiment runs A/B tests of staging prompts.
+//
+// An experiment defines two or more prompt variants for a room type and style.
+// While it runs, the worker assigns each stage job for that room type and style
+// one variant, weighted by the variants' weights, stages with its prompt and
+// tags the image with the experiment and variant. A report compares how often
+// each variant's images finished, failed and were approved by their owners.
+package promptexperiment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/promptlib"
+)
+
+// Experiment statuses.
+const (
+	StatusRunning = "running"
+	StatusStopped = "stopped"
+)
+
+// Limits on an experiment's definition.
+const (
+	MaxNameLength        = 100
+	MaxVariantNameLength = 50
+	MinVariants          = 2
+	MaxVariants          = 10
+	MaxWeight            = 100
+)
+
+var (
+	// ErrExperimentNotFound is returned when an experiment does not exist.
+	ErrExperimentNotFound = errors.New("prompt experiment not found")
+	// ErrInvalidExperiment is returned when an experiment's definition is invalid.
+	ErrInvalidExperiment = errors.New("invalid prompt experiment")
+	// ErrAlreadyRunning is returned when the room type and style already have
+	// a running experiment.
+	ErrAlreadyRunning = errors.New("a prompt experiment is already running for this room type and style")
+	// ErrNotRunning is returned when stopping an experiment that has stopped.
+	ErrNotRunning = errors.New("prompt experiment is not running")
+)
+
+// Variant is one prompt an experiment tries. Weight is its share of jobs
+// relative to the other variants'.
+type Variant struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Prompt string `json:"prompt"`
+	Weight int    `json:"weight"`
+}
+
+// Experiment is an A/B test of prompts for a room type and style.
+type Experiment struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	RoomType  string     `json:"room_type"`
+	Style     string     `json:"style"`
+	Status    string     `json:"status"`
+	Variants  []Variant  `json:"variants"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy *string    `json:"created_by,omitempty"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+}
+
+// CreateVariantRequest defines a variant. Weight defaults to 1.
+type CreateVariantRequest struct {
+	Name   string `json:"name"`
+	Prompt string `json:"prompt"`
+	Weight *int   `json:"weight,omitempty"`
+}
+
+// CreateExperimentRequest starts an experiment.
+type CreateExperimentRequest struct {
+	Name     string                 `json:"name"`
+	RoomType string                 `json:"room_type"`
+	Style    string                 `json:"style"`
+	Variants []CreateVariantRequest `json:"variants"`
+}
+
+// Outcome tallies the images assigned a variant. Approved and Rejected count
+// the owners' verdicts; images without one count in neither.
+type Outcome struct {
+	Assigned int
+	Ready    int
+	Failed   int
+	Approved int
+	Rejected int
+}
+
+// VariantReport is a variant's outcome with its rates. CompletionRate and
+// ErrorRate are shares of the assigned images; ApprovalRate is the share of
+// verdicts that approved. A rate is omitted while its denominator is zero.
+type VariantReport struct {
+	VariantID      string   `json:"variant_id"`
+	Name           string   `json:"name"`
+	Weight         int      `json:"weight"`
+	Assigned       int      `json:"assigned"`
+	Completed      int      `json:"completed"`
+	Errored        int      `json:"errored"`
+	Pending        int      `json:"pending"`
+	Approved       int      `json:"approved"`
+	Rejected       int      `json:"rejected"`
+	CompletionRate *float64 `json:"completion_rate,omitempty"`
+	ErrorRate      *float64 `json:"error_rate,omitempty"`
+	ApprovalRate   *float64 `json:"approval_rate,omitempty"`
+}
+
+// Report compares an experiment's variants.
+type Report struct {
+	Experiment *Experiment     `json:"experiment"`
+	Variants   []VariantReport `json:"variants"`
+}
+
+// validate checks the request, trimming its names in place.
+func (req *CreateExperimentRequest) validate() error {
+	req.Name = strings.TrimSpace(req.Name)
+	switch n := len([]rune(req.Name)); {
+	case n == 0:
+		return fmt.Errorf("%w: name is required", ErrInvalidExperiment)
+	case n > MaxNameLength:
+		return fmt.Errorf("%w: name exceeds %d characters", ErrInvalidExperiment, MaxNameLength)
+	}
+	if !promptlib.ValidKey(req.RoomType) {
+		return fmt.Errorf("%w: invalid room_type %q", ErrInvalidExperiment, req.RoomType)
+	}
+	if !promptlib.ValidKey(req.Style) {
+		return fmt.Errorf("%w: invalid style %q", ErrInvalidExperiment, req.Style)
+	}
+	if len(req.Variants) < MinVariants || len(req.Variants) > MaxVariants {
+		return fmt.Errorf("%w: an experiment needs %d to %d variants", ErrInvalidExperiment, MinVariants, MaxVariants)
+	}
+
+	seen := make(map[string]bool, len(req.Variants))
+	for i := range req.Variants {
+		v := &req.Variants[i]
+		v.Name = strings.TrimSpace(v.Name)
+		switch n := len([]rune(v.Name)); {
+		case n == 0:
+			return fmt.Errorf("%w: variants[%d]: name is required", ErrInvalidExperiment, i)
+		case n > MaxVariantNameLength:
+			return fmt.Errorf("%w: variants[%d]: name exceeds %d characters", ErrInvalidExperiment, i, MaxVariantNameLength)
+		case seen[v.Name]:
+			return fmt.Errorf("%w: variants[%d]: duplicate name %q", ErrInvalidExperiment, i, v.Name)
+		}
+		seen[v.Name] = true
+
+		switch n := len([]rune(v.Prompt)); {
+		case strings.TrimSpace(v.Prompt) == "":
+			return fmt.Errorf("%w: variants[%d]: prompt is empty", ErrInvalidExperiment, i)
+		case n > promptlib.MaxPromptLength:
+			return fmt.Errorf("%w: variants[%d]: prompt exceeds %d characters", ErrInvalidExperiment, i,
+				promptlib.MaxPromptLength)
+		}
+		if v.Weight != nil && (*v.Weight < 1 || *v.Weight > MaxWeight) {
+			return fmt.Errorf("%w: variants[%d]: weight must be between 1 and %d", ErrInvalidExperiment, i, MaxWeight)
+		}
+	}
+	return nil
+}
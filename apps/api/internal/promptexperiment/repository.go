@@ -0,0 +1,26 @@
+package promptexperiment
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for prompt experiments.
+type Repository interface {
+	// Create stores a running experiment with its variants, in request order.
+	// Returns ErrAlreadyRunning if the room type and style have one running.
+	Create(ctx context.Context, req CreateExperimentRequest, createdBy string) (*Experiment, error)
+
+	// GetByID returns the experiment. Returns ErrExperimentNotFound if it doesn't exist.
+	GetByID(ctx context.Context, id string) (*Experiment, error)
+
+	// List returns every experiment, newest first.
+	List(ctx context.Context) ([]Experiment, error)
+
+	// Stop ends a running experiment; its images keep their tags. Returns
+	// ErrNotRunning if it isn't running.
+	Stop(ctx context.Context, id string) (*Experiment, error)
+
+	// Outcomes tallies the images assigned each of the experiment's variants,
+	// keyed by variant ID.
+	Outcomes(ctx context.Context, experimentID string) (map[string]Outcome, error)
+}
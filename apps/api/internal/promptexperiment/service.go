@@ -0,0 +1,28 @@
+package promptexperiment
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for prompt experiments.
+type Service interface {
+	// CreateExperiment validates and starts an experiment. Returns
+	// ErrInvalidExperiment for a bad definition and ErrAlreadyRunning when
+	// the room type and style have an experiment running.
+	CreateExperiment(ctx context.Context, req CreateExperimentRequest, createdBy string) (*Experiment, error)
+
+	// ListExperiments returns every experiment, newest first.
+	ListExperiments(ctx context.Context) ([]Experiment, error)
+
+	// GetExperiment returns the experiment. Returns ErrExperimentNotFound if
+	// it doesn't exist.
+	GetExperiment(ctx context.Context, id string) (*Experiment, error)
+
+	// StopExperiment ends the experiment so jobs go back to the override or
+	// built-in prompt. Returns ErrExperimentNotFound or ErrNotRunning.
+	StopExperiment(ctx context.Context, id string) (*Experiment, error)
+
+	// GetReport compares the experiment's variants. Returns
+	// ErrExperimentNotFound if it doesn't exist.
+	GetReport(ctx context.Context, id string) (*Report, error)
+}
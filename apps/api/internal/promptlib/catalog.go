@@ -0,0 +1,109 @@
+package promptlib
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultRoomTypes and DefaultStyles are the room types and styles every
+// worker release has had prompts for. They are accepted until the published
+// library has been loaded, so a fresh deployment takes requests before its
+// first worker starts.
+var (
+	DefaultRoomTypes = []string{
+		"living_room", "bedroom", "kitchen", "bathroom", "dining_room", "office", "entryway", "outdoor",
+	}
+	DefaultStyles = []string{"contemporary", "industrial", "modern", "scandinavian", "traditional"}
+)
+
+// roomTypes and styles are the room types and styles as last loaded from the
+// prompts the worker publishes, in name order.
+var (
+	roomTypes atomic.Pointer[[]string]
+	styles    atomic.Pointer[[]string]
+)
+
+// SetRoomTypes replaces the accepted room types. Servers load them with
+// LoadCatalog; tests set the room types they need. An empty list restores
+// DefaultRoomTypes.
+func SetRoomTypes(list []string) {
+	if len(list) == 0 {
+		list = DefaultRoomTypes
+	}
+	roomTypes.Store(&list)
+}
+
+// SetStyles replaces the accepted styles. Servers load them with LoadCatalog;
+// tests set the styles they need. An empty list restores DefaultStyles.
+func SetStyles(list []string) {
+	if len(list) == 0 {
+		list = DefaultStyles
+	}
+	styles.Store(&list)
+}
+
+// LoadCatalog replaces the accepted room types and styles with those the
+// worker has published prompts for.
+func LoadCatalog(ctx context.Context, repo Repository) error {
+	rooms, err := repo.ListRoomTypes(ctx)
+	if err != nil {
+		return err
+	}
+	list, err := repo.ListStyles(ctx)
+	if err != nil {
+		return err
+	}
+	SetRoomTypes(rooms)
+	SetStyles(list)
+	return nil
+}
+
+// RunCatalogRefresh reloads the accepted room types and styles every interval
+// until ctx is done, so those added in a worker release are accepted without
+// restarting the API.
+func RunCatalogRefresh(ctx context.Context, repo Repository, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := LoadCatalog(ctx, repo); err != nil {
+				log.Error(ctx, "prompt catalog refresh failed", "error", err)
+			}
+		}
+	}
+}
+
+// RoomTypes returns the room types images can be created with.
+func RoomTypes() []string {
+	if list := roomTypes.Load(); list != nil {
+		return *list
+	}
+	return DefaultRoomTypes
+}
+
+// IsRoomType reports whether roomType is one of RoomTypes.
+func IsRoomType(roomType string) bool {
+	return slices.Contains(RoomTypes(), roomType)
+}
+
+// Styles returns the staging styles images can be created with.
+func Styles() []string {
+	if list := styles.Load(); list != nil {
+		return *list
+	}
+	return DefaultStyles
+}
+
+// IsStyle reports whether style is one of Styles.
+func IsStyle(style string) bool {
+	return slices.Contains(Styles(), style)
+}
@@ -0,0 +1,59 @@
+package promptlib
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	t.Cleanup(func() {
+		SetRoomTypes(nil)
+		SetStyles(nil)
+	})
+
+	catalogRepo := func(roomTypes, styles []string, roomErr, styleErr error) *RepositoryMock {
+		return &RepositoryMock{
+			ListRoomTypesFunc: func(ctx context.Context) ([]string, error) { return roomTypes, roomErr },
+			ListStylesFunc:    func(ctx context.Context) ([]string, error) { return styles, styleErr },
+		}
+	}
+
+	t.Run("success: published room types and styles replace the defaults", func(t *testing.T) {
+		repo := catalogRepo([]string{"garage", "kitchen"}, []string{"japandi", "modern"}, nil, nil)
+
+		require.NoError(t, LoadCatalog(context.Background(), repo))
+
+		assert.Equal(t, []string{"garage", "kitchen"}, RoomTypes())
+		assert.True(t, IsRoomType("garage"))
+		assert.False(t, IsRoomType("bedroom"))
+		assert.Equal(t, []string{"japandi", "modern"}, Styles())
+		assert.True(t, IsStyle("japandi"))
+		assert.False(t, IsStyle("industrial"))
+	})
+
+	t.Run("success: nothing published restores the defaults", func(t *testing.T) {
+		require.NoError(t, LoadCatalog(context.Background(), catalogRepo([]string{}, []string{}, nil, nil)))
+
+		assert.Equal(t, DefaultRoomTypes, RoomTypes())
+		assert.True(t, IsRoomType("bedroom"))
+		assert.Equal(t, DefaultStyles, Styles())
+		assert.True(t, IsStyle("industrial"))
+	})
+
+	t.Run("fail: repository error keeps the current catalog", func(t *testing.T) {
+		SetRoomTypes([]string{"garage"})
+		SetStyles([]string{"coastal"})
+
+		err := LoadCatalog(context.Background(),
+			catalogRepo([]string{"attic"}, nil, nil, errors.New("db down")))
+
+		require.Error(t, err)
+		assert.Equal(t, []string{"garage"}, RoomTypes())
+		assert.Equal(t, []string{"coastal"}, Styles())
+		assert.False(t, IsStyle(""))
+	})
+}
@@ -0,0 +1,171 @@
+package promptlib
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the admin prompt library endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ListPrompts handles GET /admin/prompts - Lists the prompt each room type
+// and style is staged with.
+func (h *DefaultHandler) ListPrompts(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	prompts, err := h.service.ListPrompts(ctx)
+	if err != nil {
+		return h.promptError(c, err, "list prompts")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"prompts": prompts})
+}
+
+// GetPrompt handles GET /admin/prompts/:room/:style - Returns the prompt the
+// room type and style is staged with, alongside its built-in.
+func (h *DefaultHandler) GetPrompt(c echo.Context) error {
+	prompt, err := h.service.GetPrompt(c.Request().Context(), c.Param("room"), c.Param("style"))
+	if err != nil {
+		return h.promptError(c, err, "get prompt")
+	}
+	return c.JSON(http.StatusOK, prompt)
+}
+
+// SetPrompt handles PUT /admin/prompts/:room/:style - Creates or replaces the
+// room type and style's override.
+func (h *DefaultHandler) SetPrompt(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req SetPromptRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	prompt, err := h.service.SetPrompt(ctx, c.Param("room"), c.Param("style"), req, auth0Sub)
+	if err != nil {
+		return h.promptError(c, err, "set prompt")
+	}
+
+	h.log.Info(ctx, "prompt override set", "room_type", prompt.RoomType, "style", prompt.Style,
+		"version", prompt.Version, "auth0_sub", auth0Sub)
+	return c.JSON(http.StatusOK, prompt)
+}
+
+// DeletePrompt handles DELETE /admin/prompts/:room/:style - Removes the
+// override so the built-in prompt applies again.
+func (h *DefaultHandler) DeletePrompt(c echo.Context) error {
+	ctx := c.Request().Context()
+	roomType, style := c.Param("room"), c.Param("style")
+
+	if err := h.service.DeletePrompt(ctx, roomType, style); err != nil {
+		return h.promptError(c, err, "delete prompt")
+	}
+
+	h.log.Info(ctx, "prompt override deleted", "room_type", roomType, "style", style)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListVersions handles GET /admin/prompts/:room/:style/versions - Lists every
+// text the override has had, newest first.
+func (h *DefaultHandler) ListVersions(c echo.Context) error {
+	versions, err := h.service.ListVersions(c.Request().Context(), c.Param("room"), c.Param("style"))
+	if err != nil {
+		return h.promptError(c, err, "list prompt versions")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"versions": versions})
+}
+
+// promptError maps a prompt editing error to its response, logging
+// unexpected ones as failing to do action.
+func (h *DefaultHandler) promptError(c echo.Context, err error, action string) error {
+	switch {
+	case errors.Is(err, ErrInvalidPrompt):
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, ErrPromptNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Prompt not found")
+	case errors.Is(err, ErrVersionConflict):
+		return echo.NewHTTPError(http.StatusConflict, "Prompt override has changed; reload it and try again")
+	}
+	h.log.Error(c.Request().Context(), "failed to "+action, "error", err)
+	return echo.NewHTTPError(http.StatusInternalServerError, "Failed to "+action)
+}
+
+// Export handles GET /admin/prompts/export - Downloads the prompt library as a bundle.
+func (h *DefaultHandler) Export(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	bundle, err := h.service.Export(ctx)
+	if err != nil {
+		h.log.Error(ctx, "failed to export prompts", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to export prompts")
+	}
+
+	filename := fmt.Sprintf("prompts-%s.json", bundle.ExportedAt.Format("20060102T150405Z"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.JSON(http.StatusOK, bundle)
+}
+
+// Import handles POST /admin/prompts/import - Replaces the overrides with a
+// bundle's. With ?dry_run=true it only reports the changes.
+func (h *DefaultHandler) Import(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	dryRun := false
+	if v := c.QueryParam("dry_run"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "dry_run must be true or false")
+		}
+		dryRun = parsed
+	}
+
+	var bundle Bundle
+	if err := c.Bind(&bundle); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User not authenticated")
+	}
+
+	result, err := h.service.Import(ctx, bundle, dryRun, auth0Sub)
+	if err != nil {
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+				"message":  "Invalid prompt bundle",
+				"problems": verr.Problems,
+			})
+		}
+		h.log.Error(ctx, "failed to import prompts", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to import prompts")
+	}
+
+	if !dryRun {
+		h.log.Info(ctx, "prompts imported", "changes", len(result.Changes), "auth0_sub", auth0Sub)
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,294 @@
+package promptlib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func newContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestDefaultHandler_Export(t *testing.T) {
+	t.Run("success: downloads the bundle", func(t *testing.T) {
+		svc := &ServiceMock{
+			ExportFunc: func(ctx context.Context) (*Bundle, error) {
+				return &Bundle{
+					FormatVersion: BundleFormatVersion,
+					ExportedAt:    time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC),
+					Prompts:       []Entry{{RoomType: "bedroom", Style: "modern", Source: SourceBuiltin, Prompt: "x"}},
+				}, nil
+			},
+		}
+		c, rec := newContext(http.MethodGet, "/api/v1/admin/prompts/export", "")
+
+		require.NoError(t, NewDefaultHandler(svc, logging.Default()).Export(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="prompts-20261016T093000Z.json"`,
+			rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Contains(t, rec.Body.String(), `"format_version":1`)
+	})
+
+	t.Run("fail: service error", func(t *testing.T) {
+		svc := &ServiceMock{
+			ExportFunc: func(ctx context.Context) (*Bundle, error) { return nil, errors.New("db down") },
+		}
+		c, _ := newContext(http.MethodGet, "/api/v1/admin/prompts/export", "")
+
+		err := NewDefaultHandler(svc, logging.Default()).Export(c)
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+	})
+}
+
+func TestDefaultHandler_Import(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		body       string
+		importErr  error
+		wantDryRun bool
+		wantStatus int
+		wantBody   string
+		wantCalled bool
+	}{
+		{
+			name:       "success: applies the bundle",
+			body:       `{"format_version":1,"prompts":[]}`,
+			wantStatus: http.StatusOK,
+			wantCalled: true,
+		},
+		{
+			name:       "success: dry run",
+			query:      "?dry_run=true",
+			body:       `{"format_version":1,"prompts":[]}`,
+			wantDryRun: true,
+			wantStatus: http.StatusOK,
+			wantBody:   `"dry_run":true`,
+			wantCalled: true,
+		},
+		{name: "fail: invalid dry_run", query: "?dry_run=maybe", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: invalid bundle",
+			body:       `{"format_version":2}`,
+			importErr:  &ValidationError{Problems: []string{"format_version 2 is not supported (want 1)"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "format_version 2 is not supported",
+			wantCalled: true,
+		},
+		{
+			name:       "fail: service error",
+			body:       `{"format_version":1}`,
+			importErr:  errors.New("db down"),
+			wantStatus: http.StatusInternalServerError,
+			wantCalled: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ImportFunc: func(ctx context.Context, bundle Bundle, dryRun bool, updatedBy string) (*ImportResult, error) {
+					assert.Equal(t, tc.wantDryRun, dryRun)
+					if tc.importErr != nil {
+						return nil, tc.importErr
+					}
+					return &ImportResult{DryRun: dryRun, Changes: []Change{}, Warnings: []string{}}, nil
+				},
+			}
+			c, rec := newContext(http.MethodPost, "/api/v1/admin/prompts/import"+tc.query, tc.body)
+
+			err := NewDefaultHandler(svc, logging.Default()).Import(c)
+
+			status := rec.Code
+			var he *echo.HTTPError
+			if errors.As(err, &he) {
+				status = he.Code
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantStatus, status)
+			if tc.wantBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.wantBody)
+			}
+			assert.Equal(t, tc.wantCalled, len(svc.ImportCalls()) == 1)
+		})
+	}
+}
+
+// promptContext routes target through a context with the room and style params set.
+func promptContext(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	c, rec := newContext(method, target, body)
+	c.SetParamNames("room", "style")
+	c.SetParamValues("bedroom", "modern")
+	return c, rec
+}
+
+// status returns the response code, or the code of the handler's HTTP error.
+func status(t *testing.T, rec *httptest.ResponseRecorder, err error) int {
+	t.Helper()
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	require.NoError(t, err)
+	return rec.Code
+}
+
+func TestDefaultHandler_ListPrompts(t *testing.T) {
+	svc := &ServiceMock{
+		ListPromptsFunc: func(ctx context.Context) ([]Prompt, error) {
+			return []Prompt{{RoomType: "bedroom", Style: "modern", Source: SourceBuiltin, Prompt: "x"}}, nil
+		},
+	}
+	c, rec := newContext(http.MethodGet, "/api/v1/admin/prompts", "")
+
+	require.NoError(t, NewDefaultHandler(svc, logging.Default()).ListPrompts(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"prompts":[{"room_type":"bedroom"`)
+}
+
+func TestDefaultHandler_GetPrompt(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success: returns the prompt", wantStatus: http.StatusOK},
+		{name: "fail: not found", err: ErrPromptNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: service error", err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetPromptFunc: func(ctx context.Context, roomType, style string) (*Prompt, error) {
+					assert.Equal(t, "bedroom", roomType)
+					assert.Equal(t, "modern", style)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Prompt{RoomType: roomType, Style: style, Source: SourceBuiltin, Prompt: "x"}, nil
+				},
+			}
+			c, rec := promptContext(http.MethodGet, "/api/v1/admin/prompts/bedroom/modern", "")
+
+			err := NewDefaultHandler(svc, logging.Default()).GetPrompt(c)
+
+			assert.Equal(t, tc.wantStatus, status(t, rec, err))
+		})
+	}
+}
+
+func TestDefaultHandler_SetPrompt(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantCalled bool
+	}{
+		{
+			name:       "success: sets the override",
+			body:       `{"prompt":"Tuned.","version":3}`,
+			wantStatus: http.StatusOK,
+			wantCalled: true,
+		},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: invalid prompt",
+			body:       `{"prompt":""}`,
+			err:        ErrInvalidPrompt,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalled: true,
+		},
+		{
+			name:       "fail: version conflict",
+			body:       `{"prompt":"Tuned.","version":2}`,
+			err:        ErrVersionConflict,
+			wantStatus: http.StatusConflict,
+			wantCalled: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				SetPromptFunc: func(
+					ctx context.Context, roomType, style string, req SetPromptRequest, updatedBy string,
+				) (*Prompt, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					assert.Equal(t, "Tuned.", req.Prompt)
+					assert.Equal(t, 3, *req.Version)
+					return &Prompt{RoomType: roomType, Style: style, Source: SourceOverride, Prompt: req.Prompt, Version: 4}, nil
+				},
+			}
+			c, rec := promptContext(http.MethodPut, "/api/v1/admin/prompts/bedroom/modern", tc.body)
+
+			err := NewDefaultHandler(svc, logging.Default()).SetPrompt(c)
+
+			assert.Equal(t, tc.wantStatus, status(t, rec, err))
+			assert.Equal(t, tc.wantCalled, len(svc.SetPromptCalls()) == 1)
+		})
+	}
+}
+
+func TestDefaultHandler_DeletePrompt(t *testing.T) {
+	t.Run("success: removes the override", func(t *testing.T) {
+		svc := &ServiceMock{
+			DeletePromptFunc: func(ctx context.Context, roomType, style string) error { return nil },
+		}
+		c, rec := promptContext(http.MethodDelete, "/api/v1/admin/prompts/bedroom/modern", "")
+
+		require.NoError(t, NewDefaultHandler(svc, logging.Default()).DeletePrompt(c))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("fail: no override", func(t *testing.T) {
+		svc := &ServiceMock{
+			DeletePromptFunc: func(ctx context.Context, roomType, style string) error { return ErrPromptNotFound },
+		}
+		c, rec := promptContext(http.MethodDelete, "/api/v1/admin/prompts/bedroom/modern", "")
+
+		err := NewDefaultHandler(svc, logging.Default()).DeletePrompt(c)
+
+		assert.Equal(t, http.StatusNotFound, status(t, rec, err))
+	})
+}
+
+func TestDefaultHandler_ListVersions(t *testing.T) {
+	svc := &ServiceMock{
+		ListVersionsFunc: func(ctx context.Context, roomType, style string) ([]OverrideVersion, error) {
+			return []OverrideVersion{{Version: 2, Prompt: "Tuned."}, {Version: 1, Prompt: "First."}}, nil
+		},
+	}
+	c, rec := promptContext(http.MethodGet, "/api/v1/admin/prompts/bedroom/modern/versions", "")
+
+	require.NoError(t, NewDefaultHandler(svc, logging.Default()).ListVersions(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"versions":[{"version":2`)
+}
@@ -0,0 +1,277 @@
+package promptlib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// ListBuiltins returns the worker's published prompts.
+func (r *DefaultRepository) ListBuiltins(ctx context.Context) ([]Builtin, error) {
+	query := `SELECT room_type, style, prompt, updated_at FROM prompt_builtins ORDER BY room_type, style`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list built-in prompts: %w", err)
+	}
+	defer rows.Close()
+
+	builtins := []Builtin{}
+	for rows.Next() {
+		var b Builtin
+		if err := rows.Scan(&b.RoomType, &b.Style, &b.Prompt, &b.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan built-in prompt: %w", err)
+		}
+		builtins = append(builtins, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over built-in prompt rows: %w", err)
+	}
+	return builtins, nil
+}
+
+// ListRoomTypes returns the room types of the worker's published prompts.
+func (r *DefaultRepository) ListRoomTypes(ctx context.Context) ([]string, error) {
+	query := `SELECT DISTINCT room_type FROM prompt_builtins WHERE room_type <> 'default' ORDER BY room_type`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt room types: %w", err)
+	}
+	defer rows.Close()
+
+	roomTypes := []string{}
+	for rows.Next() {
+		var roomType string
+		if err := rows.Scan(&roomType); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt room type: %w", err)
+		}
+		roomTypes = append(roomTypes, roomType)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt room type rows: %w", err)
+	}
+	return roomTypes, nil
+}
+
+// ListStyles returns the styles of the worker's published prompts.
+func (r *DefaultRepository) ListStyles(ctx context.Context) ([]string, error) {
+	query := `SELECT DISTINCT style FROM prompt_builtins WHERE style <> 'default' ORDER BY style`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt styles: %w", err)
+	}
+	defer rows.Close()
+
+	styles := []string{}
+	for rows.Next() {
+		var style string
+		if err := rows.Scan(&style); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt style: %w", err)
+		}
+		styles = append(styles, style)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt style rows: %w", err)
+	}
+	return styles, nil
+}
+
+// ListOverrides returns every override.
+func (r *DefaultRepository) ListOverrides(ctx context.Context) ([]Override, error) {
+	query := `
+		SELECT room_type, style, prompt, version, updated_at, updated_by
+		FROM prompt_overrides
+		ORDER BY room_type, style`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := []Override{}
+	for rows.Next() {
+		var o Override
+		if err := rows.Scan(&o.RoomType, &o.Style, &o.Prompt, &o.Version, &o.UpdatedAt, &o.UpdatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt override: %w", err)
+		}
+		overrides = append(overrides, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt override rows: %w", err)
+	}
+	return overrides, nil
+}
+
+// GetBuiltin returns the worker's prompt for the room type and style.
+func (r *DefaultRepository) GetBuiltin(ctx context.Context, roomType, style string) (*Builtin, error) {
+	query := `SELECT room_type, style, prompt, updated_at FROM prompt_builtins WHERE room_type = $1 AND style = $2`
+
+	var b Builtin
+	err := r.db.QueryRow(ctx, query, roomType, style).Scan(&b.RoomType, &b.Style, &b.Prompt, &b.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPromptNotFound
+		}
+		return nil, fmt.Errorf("failed to get built-in prompt: %w", err)
+	}
+	return &b, nil
+}
+
+// GetOverride returns the override for the room type and style.
+func (r *DefaultRepository) GetOverride(ctx context.Context, roomType, style string) (*Override, error) {
+	query := `
+		SELECT room_type, style, prompt, version, updated_at, updated_by
+		FROM prompt_overrides
+		WHERE room_type = $1 AND style = $2`
+
+	var o Override
+	err := r.db.QueryRow(ctx, query, roomType, style).
+		Scan(&o.RoomType, &o.Style, &o.Prompt, &o.Version, &o.UpdatedAt, &o.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPromptNotFound
+		}
+		return nil, fmt.Errorf("failed to get prompt override: %w", err)
+	}
+	return &o, nil
+}
+
+// SetOverride upserts the override in one statement so the version check and
+// the write can't interleave with another admin's edit. The insert is skipped
+// when a version other than 0 is expected, and the update when the version
+// doesn't match or the text is unchanged; either way no row comes back. The
+// database numbers the version and records it in prompt_override_versions.
+func (r *DefaultRepository) SetOverride(
+	ctx context.Context, roomType, style, prompt, updatedBy string, version *int,
+) (*Override, error) {
+	query := `
+		INSERT INTO prompt_overrides (room_type, style, prompt, updated_by)
+		SELECT $1, $2, $3, $4
+		WHERE $5::int IS NULL OR $5::int = 0
+		ON CONFLICT (room_type, style) DO UPDATE SET
+			prompt = EXCLUDED.prompt,
+			version = prompt_overrides.version + 1,
+			updated_at = now(),
+			updated_by = EXCLUDED.updated_by
+		WHERE prompt_overrides.prompt IS DISTINCT FROM EXCLUDED.prompt
+			AND ($5::int IS NULL OR prompt_overrides.version = $5::int)
+		RETURNING room_type, style, prompt, version, updated_at, updated_by`
+
+	var o Override
+	err := r.db.QueryRow(ctx, query, roomType, style, prompt, updatedBy, version).
+		Scan(&o.RoomType, &o.Style, &o.Prompt, &o.Version, &o.UpdatedAt, &o.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrVersionConflict
+		}
+		return nil, fmt.Errorf("failed to set prompt override: %w", err)
+	}
+	return &o, nil
+}
+
+// DeleteOverride removes the override for the room type and style.
+func (r *DefaultRepository) DeleteOverride(ctx context.Context, roomType, style string) error {
+	query := `DELETE FROM prompt_overrides WHERE room_type = $1 AND style = $2`
+
+	tag, err := r.db.Exec(ctx, query, roomType, style)
+	if err != nil {
+		return fmt.Errorf("failed to delete prompt override: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPromptNotFound
+	}
+	return nil
+}
+
+// ListOverrideVersions returns the override's recorded versions, newest first.
+func (r *DefaultRepository) ListOverrideVersions(
+	ctx context.Context, roomType, style string,
+) ([]OverrideVersion, error) {
+	query := `
+		SELECT version, prompt, created_at, created_by
+		FROM prompt_override_versions
+		WHERE room_type = $1 AND style = $2
+		ORDER BY version DESC`
+
+	rows, err := r.db.Query(ctx, query, roomType, style)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt override versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []OverrideVersion{}
+	for rows.Next() {
+		var v OverrideVersion
+		if err := rows.Scan(&v.Version, &v.Prompt, &v.CreatedAt, &v.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt override version: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt override version rows: %w", err)
+	}
+	return versions, nil
+}
+
+// ReplaceOverrides swaps in the new set of overrides in one statement, so a
+// failed import leaves the current set untouched.
+func (r *DefaultRepository) ReplaceOverrides(ctx context.Context, overrides []Override, updatedBy string) error {
+	type row struct {
+		RoomType string `json:"room_type"`
+		Style    string `json:"style"`
+		Prompt   string `json:"prompt"`
+	}
+	input := make([]row, 0, len(overrides))
+	for _, o := range overrides {
+		input = append(input, row{RoomType: o.RoomType, Style: o.Style, Prompt: o.Prompt})
+	}
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to encode prompt overrides: %w", err)
+	}
+
+	query := `
+		WITH input AS (
+			SELECT room_type, style, prompt
+			FROM jsonb_to_recordset($1::jsonb) AS x(room_type TEXT, style TEXT, prompt TEXT)
+		), removed AS (
+			DELETE FROM prompt_overrides o
+			WHERE NOT EXISTS (
+				SELECT 1 FROM input i WHERE i.room_type = o.room_type AND i.style = o.style
+			)
+		)
+		INSERT INTO prompt_overrides (room_type, style, prompt, updated_by)
+		SELECT room_type, style, prompt, $2 FROM input
+		ON CONFLICT (room_type, style) DO UPDATE SET
+			prompt = EXCLUDED.prompt,
+			version = prompt_overrides.version + 1,
+			updated_at = now(),
+			updated_by = EXCLUDED.updated_by
+		WHERE prompt_overrides.prompt IS DISTINCT FROM EXCLUDED.prompt`
+
+	if _, err := r.db.Exec(ctx, query, payload, updatedBy); err != nil {
+		return fmt.Errorf("failed to replace prompt overrides: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package promptlib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// key identifies a prompt by room type and style.
+type key struct {
+	roomType string
+	style    string
+}
+
+func (k key) String() string {
+	return k.roomType + "/" + k.style
+}
+
+// effective builds the prompt the worker stages k with from its built-in and
+// override, either of which may be nil.
+func effective(k key, b *Builtin, o *Override) Prompt {
+	p := Prompt{RoomType: k.roomType, Style: k.style}
+	if b != nil {
+		text := b.Prompt
+		p.Source, p.Prompt, p.Builtin = SourceBuiltin, b.Prompt, &text
+	}
+	if o != nil {
+		updatedAt := o.UpdatedAt
+		p.Source, p.Prompt = SourceOverride, o.Prompt
+		p.Version, p.UpdatedAt, p.UpdatedBy = o.Version, &updatedAt, o.UpdatedBy
+	}
+	return p
+}
+
+// ListPrompts merges the built-ins with the overrides, including overrides
+// for room types and styles the worker has no built-in for.
+func (s *DefaultService) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	builtins, err := s.repo.ListBuiltins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.repo.ListOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overridden := make(map[key]*Override, len(overrides))
+	for i := range overrides {
+		overridden[key{overrides[i].RoomType, overrides[i].Style}] = &overrides[i]
+	}
+	prompts := make([]Prompt, 0, len(builtins)+len(overrides))
+	for i := range builtins {
+		k := key{builtins[i].RoomType, builtins[i].Style}
+		prompts = append(prompts, effective(k, &builtins[i], overridden[k]))
+		delete(overridden, k)
+	}
+	for k, o := range overridden {
+		prompts = append(prompts, effective(k, nil, o))
+	}
+	sort.Slice(prompts, func(i, j int) bool {
+		a, b := prompts[i], prompts[j]
+		if a.RoomType != b.RoomType {
+			return a.RoomType < b.RoomType
+		}
+		return a.Style < b.Style
+	})
+	return prompts, nil
+}
+
+// GetPrompt looks up the room type and style's built-in and override.
+func (s *DefaultService) GetPrompt(ctx context.Context, roomType, style string) (*Prompt, error) {
+	b, o, err := s.lookup(ctx, roomType, style)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil && o == nil {
+		return nil, ErrPromptNotFound
+	}
+	p := effective(key{roomType, style}, b, o)
+	return &p, nil
+}
+
+// SetPrompt writes the override unless it already has the text, in which
+// case nothing changes and req.Version isn't checked.
+func (s *DefaultService) SetPrompt(
+	ctx context.Context, roomType, style string, req SetPromptRequest, updatedBy string,
+) (*Prompt, error) {
+	if err := validatePrompt(roomType, style, req.Prompt); err != nil {
+		return nil, err
+	}
+
+	b, o, err := s.lookup(ctx, roomType, style)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil || o.Prompt != req.Prompt {
+		o, err = s.repo.SetOverride(ctx, roomType, style, req.Prompt, updatedBy, req.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+	p := effective(key{roomType, style}, b, o)
+	return &p, nil
+}
+
+// DeletePrompt removes the override; its versions stay listed.
+func (s *DefaultService) DeletePrompt(ctx context.Context, roomType, style string) error {
+	return s.repo.DeleteOverride(ctx, roomType, style)
+}
+
+// ListVersions returns the override's versions, which outlive the override itself.
+func (s *DefaultService) ListVersions(ctx context.Context, roomType, style string) ([]OverrideVersion, error) {
+	return s.repo.ListOverrideVersions(ctx, roomType, style)
+}
+
+// lookup returns the room type and style's built-in and override, each nil
+// when missing.
+func (s *DefaultService) lookup(ctx context.Context, roomType, style string) (*Builtin, *Override, error) {
+	b, err := s.repo.GetBuiltin(ctx, roomType, style)
+	if err != nil && !errors.Is(err, ErrPromptNotFound) {
+		return nil, nil, err
+	}
+	o, err := s.repo.GetOverride(ctx, roomType, style)
+	if err != nil && !errors.Is(err, ErrPromptNotFound) {
+		return nil, nil, err
+	}
+	return b, o, nil
+}
+
+// Export lists the built-ins followed, per room type and style, by any override.
+func (s *DefaultService) Export(ctx context.Context) (*Bundle, error) {
+	builtins, err := s.repo.ListBuiltins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.repo.ListOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(builtins)+len(overrides))
+	for _, b := range builtins {
+		entries = append(entries, Entry{RoomType: b.RoomType, Style: b.Style, Source: SourceBuiltin, Prompt: b.Prompt})
+	}
+	for _, o := range overrides {
+		updatedAt := o.UpdatedAt
+		entries = append(entries, Entry{
+			RoomType:  o.RoomType,
+			Style:     o.Style,
+			Source:    SourceOverride,
+			Prompt:    o.Prompt,
+			Version:   o.Version,
+			UpdatedAt: &updatedAt,
+			UpdatedBy: o.UpdatedBy,
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.RoomType != b.RoomType {
+			return a.RoomType < b.RoomType
+		}
+		if a.Style != b.Style {
+			return a.Style < b.Style
+		}
+		return a.Source < b.Source
+	})
+
+	return &Bundle{FormatVersion: BundleFormatVersion, ExportedAt: time.Now().UTC(), Prompts: entries}, nil
+}
+
+// Import diffs the bundle's overrides against the current ones and, unless
+// dryRun is set, applies them. Built-in entries are only compared with this
+// environment's built-ins: they ship with the worker and can't be imported.
+func (s *DefaultService) Import(
+	ctx context.Context, bundle Bundle, dryRun bool, updatedBy string,
+) (*ImportResult, error) {
+	if err := validate(bundle); err != nil {
+		return nil, err
+	}
+
+	builtins, err := s.repo.ListBuiltins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.repo.ListOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{DryRun: dryRun, Changes: []Change{}, Warnings: importWarnings(bundle, builtins)}
+
+	existing := make(map[key]Override, len(current))
+	for _, o := range current {
+		existing[key{o.RoomType, o.Style}] = o
+	}
+
+	var desired []Override
+	for _, e := range bundle.Prompts {
+		if e.Source != SourceOverride {
+			continue
+		}
+		k := key{e.RoomType, e.Style}
+		desired = append(desired, Override{RoomType: e.RoomType, Style: e.Style, Prompt: e.Prompt})
+
+		old, ok := existing[k]
+		delete(existing, k)
+		switch {
+		case !ok:
+			result.Changes = append(result.Changes, Change{
+				RoomType: e.RoomType, Style: e.Style, Action: ActionCreate, NewPrompt: &e.Prompt,
+			})
+		case old.Prompt != e.Prompt:
+			result.Changes = append(result.Changes, Change{
+				RoomType: e.RoomType, Style: e.Style, Action: ActionUpdate,
+				FromVersion: old.Version, OldPrompt: &old.Prompt, NewPrompt: &e.Prompt,
+			})
+		default:
+			result.Unchanged++
+		}
+	}
+	for _, old := range existing {
+		result.Changes = append(result.Changes, Change{
+			RoomType: old.RoomType, Style: old.Style, Action: ActionDelete,
+			FromVersion: old.Version, OldPrompt: &old.Prompt,
+		})
+	}
+	sort.Slice(result.Changes, func(i, j int) bool {
+		a, b := result.Changes[i], result.Changes[j]
+		if a.RoomType != b.RoomType {
+			return a.RoomType < b.RoomType
+		}
+		return a.Style < b.Style
+	})
+
+	if dryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+	if err := s.repo.ReplaceOverrides(ctx, desired, updatedBy); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// validate collects every problem in the bundle rather than stopping at the first.
+func validate(bundle Bundle) error {
+	var problems []string
+	if bundle.FormatVersion != BundleFormatVersion {
+		problems = append(problems, fmt.Sprintf("format_version %d is not supported (want %d)",
+			bundle.FormatVersion, BundleFormatVersion))
+	}
+
+	seen := make(map[string]bool, len(bundle.Prompts))
+	for i, e := range bundle.Prompts {
+		at := fmt.Sprintf("prompts[%d]", i)
+		if !keyPattern.MatchString(e.RoomType) {
+			problems = append(problems, fmt.Sprintf("%s: invalid room_type %q", at, e.RoomType))
+		}
+		if !keyPattern.MatchString(e.Style) {
+			problems = append(problems, fmt.Sprintf("%s: invalid style %q", at, e.Style))
+		}
+		if e.Source != SourceBuiltin && e.Source != SourceOverride {
+			problems = append(problems, fmt.Sprintf("%s: source must be %q or %q", at, SourceBuiltin, SourceOverride))
+		}
+		if e.Source == SourceOverride {
+			switch n := len([]rune(e.Prompt)); {
+			case n == 0:
+				problems = append(problems, fmt.Sprintf("%s: prompt is empty", at))
+			case n > MaxPromptLength:
+				problems = append(problems, fmt.Sprintf("%s: prompt exceeds %d characters", at, MaxPromptLength))
+			}
+		}
+
+		id := e.RoomType + "/" + e.Style + "/" + e.Source
+		if seen[id] {
+			problems = append(problems, fmt.Sprintf("%s: duplicate %s prompt for %s/%s", at, e.Source, e.RoomType, e.Style))
+		}
+		seen[id] = true
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validatePrompt checks an override with the same rules validate applies to
+// a bundle's overrides.
+func validatePrompt(roomType, style, prompt string) error {
+	switch n := len([]rune(prompt)); {
+	case !keyPattern.MatchString(roomType):
+		return fmt.Errorf("%w: invalid room_type %q", ErrInvalidPrompt, roomType)
+	case !keyPattern.MatchString(style):
+		return fmt.Errorf("%w: invalid style %q", ErrInvalidPrompt, style)
+	case strings.TrimSpace(prompt) == "":
+		return fmt.Errorf("%w: prompt is empty", ErrInvalidPrompt)
+	case n > MaxPromptLength:
+		return fmt.Errorf("%w: prompt exceeds %d characters", ErrInvalidPrompt, MaxPromptLength)
+	}
+	return nil
+}
+
+// importWarnings flags bundles exported against different built-ins, which
+// would make the same overrides stage differently here.
+func importWarnings(bundle Bundle, builtins []Builtin) []string {
+	warnings := []string{}
+	if len(builtins) == 0 {
+		return append(warnings, "no built-in prompts are published in this environment; has the worker started?")
+	}
+
+	local := make(map[key]string, len(builtins))
+	for _, b := range builtins {
+		local[key{b.RoomType, b.Style}] = b.Prompt
+	}
+	for _, e := range bundle.Prompts {
+		k := key{e.RoomType, e.Style}
+		text, ok := local[k]
+		switch {
+		case e.Source == SourceBuiltin && !ok:
+			warnings = append(warnings, fmt.Sprintf("built-in prompt %s does not exist in this environment", k))
+		case e.Source == SourceBuiltin && text != e.Prompt:
+			warnings = append(warnings, fmt.Sprintf("built-in prompt %s differs from this environment's", k))
+		case e.Source == SourceOverride && !ok:
+			warnings = append(warnings, fmt.Sprintf("override %s has no built-in prompt in this environment", k))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,311 @@
+package promptlib
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func repoWith(builtins []Builtin, overrides []Override) *RepositoryMock {
+	return &RepositoryMock{
+		ListBuiltinsFunc:  func(ctx context.Context) ([]Builtin, error) { return builtins, nil },
+		ListOverridesFunc: func(ctx context.Context) ([]Override, error) { return overrides, nil },
+		ReplaceOverridesFunc: func(ctx context.Context, overrides []Override, updatedBy string) error {
+			return nil
+		},
+	}
+}
+
+var builtins = []Builtin{
+	{RoomType: "bedroom", Style: "modern", Prompt: "Stage a modern bedroom."},
+	{RoomType: "kitchen", Style: "modern", Prompt: "Stage a modern kitchen."},
+}
+
+func TestDefaultService_Export(t *testing.T) {
+	t.Run("success: overrides follow their built-in", func(t *testing.T) {
+		repo := repoWith(builtins, []Override{{RoomType: "bedroom", Style: "modern", Prompt: "Tuned.", Version: 3}})
+
+		got, err := NewDefaultService(repo).Export(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, BundleFormatVersion, got.FormatVersion)
+		require.Len(t, got.Prompts, 3)
+		assert.Equal(t, SourceBuiltin, got.Prompts[0].Source)
+		assert.Equal(t, SourceOverride, got.Prompts[1].Source)
+		assert.Equal(t, "bedroom", got.Prompts[1].RoomType)
+		assert.Equal(t, 3, got.Prompts[1].Version)
+		assert.Equal(t, "kitchen", got.Prompts[2].RoomType)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListBuiltinsFunc: func(ctx context.Context) ([]Builtin, error) { return nil, errors.New("db down") },
+		}
+
+		_, err := NewDefaultService(repo).Export(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_Import(t *testing.T) {
+	current := []Override{
+		{RoomType: "bedroom", Style: "modern", Prompt: "Old bedroom.", Version: 2},
+		{RoomType: "kitchen", Style: "modern", Prompt: "Same kitchen.", Version: 1},
+		{RoomType: "office", Style: "modern", Prompt: "Stale office.", Version: 4},
+	}
+	bundle := Bundle{FormatVersion: BundleFormatVersion, Prompts: []Entry{
+		{RoomType: "bedroom", Style: "modern", Source: SourceBuiltin, Prompt: "Stage a modern bedroom."},
+		{RoomType: "bedroom", Style: "modern", Source: SourceOverride, Prompt: "New bedroom."},
+		{RoomType: "kitchen", Style: "modern", Source: SourceOverride, Prompt: "Same kitchen."},
+		{RoomType: "bathroom", Style: "modern", Source: SourceOverride, Prompt: "New bathroom."},
+	}}
+
+	t.Run("success: dry run reports the diff without writing", func(t *testing.T) {
+		repo := repoWith(builtins, current)
+
+		got, err := NewDefaultService(repo).Import(context.Background(), bundle, true, "admin")
+
+		require.NoError(t, err)
+		assert.True(t, got.DryRun)
+		assert.Equal(t, 1, got.Unchanged)
+		require.Len(t, got.Changes, 3)
+		assert.Equal(t, Change{
+			RoomType: "bathroom", Style: "modern", Action: ActionCreate, NewPrompt: &bundle.Prompts[3].Prompt,
+		}, got.Changes[0])
+		assert.Equal(t, ActionUpdate, got.Changes[1].Action)
+		assert.Equal(t, 2, got.Changes[1].FromVersion)
+		assert.Equal(t, "Old bedroom.", *got.Changes[1].OldPrompt)
+		assert.Equal(t, ActionDelete, got.Changes[2].Action)
+		assert.Equal(t, "office", got.Changes[2].RoomType)
+		assert.Equal(t, []string{"override bathroom/modern has no built-in prompt in this environment"}, got.Warnings)
+		assert.Empty(t, repo.ReplaceOverridesCalls())
+	})
+
+	t.Run("success: applies the bundle's overrides", func(t *testing.T) {
+		repo := repoWith(builtins, current)
+
+		got, err := NewDefaultService(repo).Import(context.Background(), bundle, false, "admin")
+
+		require.NoError(t, err)
+		assert.False(t, got.DryRun)
+		require.Len(t, repo.ReplaceOverridesCalls(), 1)
+		call := repo.ReplaceOverridesCalls()[0]
+		assert.Equal(t, "admin", call.UpdatedBy)
+		assert.Equal(t, []Override{
+			{RoomType: "bedroom", Style: "modern", Prompt: "New bedroom."},
+			{RoomType: "kitchen", Style: "modern", Prompt: "Same kitchen."},
+			{RoomType: "bathroom", Style: "modern", Prompt: "New bathroom."},
+		}, call.Overrides)
+	})
+
+	t.Run("success: identical bundle writes nothing", func(t *testing.T) {
+		repo := repoWith(builtins, current[1:2])
+		same := Bundle{FormatVersion: BundleFormatVersion, Prompts: bundle.Prompts[2:3]}
+
+		got, err := NewDefaultService(repo).Import(context.Background(), same, false, "admin")
+
+		require.NoError(t, err)
+		assert.Empty(t, got.Changes)
+		assert.Empty(t, repo.ReplaceOverridesCalls())
+	})
+
+	t.Run("success: warns about differing built-ins", func(t *testing.T) {
+		repo := repoWith(builtins, nil)
+		other := Bundle{FormatVersion: BundleFormatVersion, Prompts: []Entry{
+			{RoomType: "bedroom", Style: "modern", Source: SourceBuiltin, Prompt: "Older bedroom wording."},
+		}}
+
+		got, err := NewDefaultService(repo).Import(context.Background(), other, true, "admin")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"built-in prompt bedroom/modern differs from this environment's"}, got.Warnings)
+	})
+
+	t.Run("fail: invalid bundle lists every problem", func(t *testing.T) {
+		repo := repoWith(builtins, current)
+		invalid := Bundle{FormatVersion: 2, Prompts: []Entry{
+			{RoomType: "Bedroom", Style: "modern", Source: SourceOverride, Prompt: "x"},
+			{RoomType: "kitchen", Style: "modern", Source: "manual", Prompt: "x"},
+			{RoomType: "kitchen", Style: "modern", Source: SourceOverride, Prompt: ""},
+			{RoomType: "kitchen", Style: "modern", Source: SourceOverride, Prompt: strings.Repeat("a", MaxPromptLength+1)},
+		}}
+
+		_, err := NewDefaultService(repo).Import(context.Background(), invalid, false, "admin")
+
+		var verr *ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.ErrorIs(t, err, ErrInvalidBundle)
+		assert.Len(t, verr.Problems, 6)
+		assert.Empty(t, repo.ListOverridesCalls())
+	})
+}
+
+// lookupRepo serves the single-prompt lookups from builtins and overrides.
+func lookupRepo(builtins []Builtin, overrides []Override) *RepositoryMock {
+	repo := repoWith(builtins, overrides)
+	repo.GetBuiltinFunc = func(ctx context.Context, roomType, style string) (*Builtin, error) {
+		for _, b := range builtins {
+			if b.RoomType == roomType && b.Style == style {
+				return &b, nil
+			}
+		}
+		return nil, ErrPromptNotFound
+	}
+	repo.GetOverrideFunc = func(ctx context.Context, roomType, style string) (*Override, error) {
+		for _, o := range overrides {
+			if o.RoomType == roomType && o.Style == style {
+				return &o, nil
+			}
+		}
+		return nil, ErrPromptNotFound
+	}
+	repo.SetOverrideFunc = func(
+		ctx context.Context, roomType, style, prompt, updatedBy string, version *int,
+	) (*Override, error) {
+		return &Override{RoomType: roomType, Style: style, Prompt: prompt, Version: 7, UpdatedBy: &updatedBy}, nil
+	}
+	return repo
+}
+
+func TestDefaultService_ListPrompts(t *testing.T) {
+	t.Run("success: overrides replace their built-in", func(t *testing.T) {
+		repo := repoWith(builtins, []Override{
+			{RoomType: "bedroom", Style: "modern", Prompt: "Tuned.", Version: 3},
+			{RoomType: "attic", Style: "modern", Prompt: "Attic.", Version: 1},
+		})
+
+		got, err := NewDefaultService(repo).ListPrompts(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, "attic", got[0].RoomType)
+		assert.Equal(t, SourceOverride, got[0].Source)
+		assert.Nil(t, got[0].Builtin)
+		assert.Equal(t, SourceOverride, got[1].Source)
+		assert.Equal(t, "Tuned.", got[1].Prompt)
+		assert.Equal(t, "Stage a modern bedroom.", *got[1].Builtin)
+		assert.Equal(t, 3, got[1].Version)
+		assert.Equal(t, SourceBuiltin, got[2].Source)
+		assert.Equal(t, "Stage a modern kitchen.", got[2].Prompt)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListBuiltinsFunc: func(ctx context.Context) ([]Builtin, error) { return nil, errors.New("db down") },
+		}
+
+		_, err := NewDefaultService(repo).ListPrompts(context.Background())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_GetPrompt(t *testing.T) {
+	overrides := []Override{{RoomType: "bedroom", Style: "modern", Prompt: "Tuned.", Version: 3}}
+
+	t.Run("success: built-in without override", func(t *testing.T) {
+		got, err := NewDefaultService(lookupRepo(builtins, overrides)).GetPrompt(context.Background(), "kitchen", "modern")
+
+		require.NoError(t, err)
+		assert.Equal(t, SourceBuiltin, got.Source)
+		assert.Equal(t, "Stage a modern kitchen.", got.Prompt)
+		assert.Zero(t, got.Version)
+	})
+
+	t.Run("success: override wins", func(t *testing.T) {
+		got, err := NewDefaultService(lookupRepo(builtins, overrides)).GetPrompt(context.Background(), "bedroom", "modern")
+
+		require.NoError(t, err)
+		assert.Equal(t, SourceOverride, got.Source)
+		assert.Equal(t, "Tuned.", got.Prompt)
+		assert.Equal(t, "Stage a modern bedroom.", *got.Builtin)
+		assert.Equal(t, 3, got.Version)
+	})
+
+	t.Run("fail: unknown room type and style", func(t *testing.T) {
+		_, err := NewDefaultService(lookupRepo(builtins, overrides)).GetPrompt(context.Background(), "attic", "modern")
+
+		assert.ErrorIs(t, err, ErrPromptNotFound)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := lookupRepo(builtins, overrides)
+		repo.GetOverrideFunc = func(ctx context.Context, roomType, style string) (*Override, error) {
+			return nil, errors.New("db down")
+		}
+
+		_, err := NewDefaultService(repo).GetPrompt(context.Background(), "bedroom", "modern")
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrPromptNotFound)
+	})
+}
+
+func TestDefaultService_SetPrompt(t *testing.T) {
+	overrides := []Override{{RoomType: "bedroom", Style: "modern", Prompt: "Tuned.", Version: 3}}
+	version := func(v int) *int { return &v }
+
+	t.Run("success: writes the override with the expected version", func(t *testing.T) {
+		repo := lookupRepo(builtins, overrides)
+
+		got, err := NewDefaultService(repo).SetPrompt(context.Background(), "bedroom", "modern",
+			SetPromptRequest{Prompt: "Tuned again.", Version: version(3)}, "admin")
+
+		require.NoError(t, err)
+		require.Len(t, repo.SetOverrideCalls(), 1)
+		call := repo.SetOverrideCalls()[0]
+		assert.Equal(t, "Tuned again.", call.Prompt)
+		assert.Equal(t, "admin", call.UpdatedBy)
+		assert.Equal(t, 3, *call.Version)
+		assert.Equal(t, SourceOverride, got.Source)
+		assert.Equal(t, 7, got.Version)
+		assert.Equal(t, "Stage a modern bedroom.", *got.Builtin)
+	})
+
+	t.Run("success: unchanged text writes nothing", func(t *testing.T) {
+		repo := lookupRepo(builtins, overrides)
+
+		got, err := NewDefaultService(repo).SetPrompt(context.Background(), "bedroom", "modern",
+			SetPromptRequest{Prompt: "Tuned.", Version: version(1)}, "admin")
+
+		require.NoError(t, err)
+		assert.Empty(t, repo.SetOverrideCalls())
+		assert.Equal(t, 3, got.Version)
+	})
+
+	t.Run("fail: invalid key or text", func(t *testing.T) {
+		for _, tc := range []struct{ roomType, style, prompt string }{
+			{"Bedroom", "modern", "x"},
+			{"bedroom", "mid-century", "x"},
+			{"bedroom", "modern", "  "},
+			{"bedroom", "modern", strings.Repeat("a", MaxPromptLength+1)},
+		} {
+			repo := lookupRepo(builtins, overrides)
+
+			_, err := NewDefaultService(repo).SetPrompt(context.Background(), tc.roomType, tc.style,
+				SetPromptRequest{Prompt: tc.prompt}, "admin")
+
+			assert.ErrorIs(t, err, ErrInvalidPrompt)
+			assert.Empty(t, repo.SetOverrideCalls())
+		}
+	})
+
+	t.Run("fail: version conflict", func(t *testing.T) {
+		repo := lookupRepo(builtins, overrides)
+		repo.SetOverrideFunc = func(
+			ctx context.Context, roomType, style, prompt, updatedBy string, version *int,
+		) (*Override, error) {
+			return nil, ErrVersionConflict
+		}
+
+		_, err := NewDefaultService(repo).SetPrompt(context.Background(), "bedroom", "modern",
+			SetPromptRequest{Prompt: "Tuned again.", Version: version(2)}, "admin")
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+	})
+}
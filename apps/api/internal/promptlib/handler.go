@@ -0,0 +1,16 @@
+package promptlib
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin endpoints for the prompt library.
+type Handler interface {
+	ListPrompts(c echo.Context) error
+	GetPrompt(c echo.Context) error
+	SetPrompt(c echo.Context) error
+	DeletePrompt(c echo.Context) error
+	ListVersions(c echo.Context) error
+	Export(c echo.Context) error
+	Import(c echo.Context) error
+}
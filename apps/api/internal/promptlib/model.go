@@ -0,0 +1,157 @@
+// Package promptlib manages the staging prompt library.
+//
+// The worker publishes its built-in prompts on startup and stages with an
+// admin override instead whenever one exists for the room type and style.
+// Admins edit overrides one at a time, and every text an override has had is
+// kept as a numbered version. A bundle is a versioned JSON snapshot of both
+// built-ins and overrides, so prompts tuned in staging can be exported there
+// and imported into production as the exact same set of overrides.
+package promptlib
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// BundleFormatVersion is the bundle layout this package reads and writes.
+const BundleFormatVersion = 1
+
+// MaxPromptLength caps an override's length in characters.
+const MaxPromptLength = 8000
+
+// Entry sources.
+const (
+	SourceBuiltin  = "builtin"
+	SourceOverride = "override"
+)
+
+// Change actions.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+var (
+	// ErrInvalidBundle is returned when a bundle fails validation.
+	ErrInvalidBundle = errors.New("invalid prompt bundle")
+	// ErrInvalidPrompt is returned when a room type, style or prompt text is invalid.
+	ErrInvalidPrompt = errors.New("invalid prompt")
+	// ErrPromptNotFound is returned when a room type and style have no prompt.
+	ErrPromptNotFound = errors.New("prompt not found")
+	// ErrVersionConflict is returned when an override changed since the
+	// version the admin edited.
+	ErrVersionConflict = errors.New("prompt override has changed")
+)
+
+// keyPattern matches room type and style identifiers.
+var keyPattern = regexp.MustCompile(`^[a-z][a-z_]{0,49}$`)
+
+// ValidKey reports whether s is a valid room type or style identifier.
+func ValidKey(s string) bool {
+	return keyPattern.MatchString(s)
+}
+
+// Builtin is a prompt shipped with the worker.
+type Builtin struct {
+	RoomType  string
+	Style     string
+	Prompt    string
+	UpdatedAt time.Time
+}
+
+// Override is an admin's replacement for a room type and style's prompt.
+// Version counts the changes made to it in this environment.
+type Override struct {
+	RoomType  string
+	Style     string
+	Prompt    string
+	Version   int
+	UpdatedAt time.Time
+	UpdatedBy *string
+}
+
+// OverrideVersion is one text an override has had, newest version first when listed.
+type OverrideVersion struct {
+	Version   int       `json:"version"`
+	Prompt    string    `json:"prompt"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy *string   `json:"created_by,omitempty"`
+}
+
+// Prompt is what the worker stages a room type and style with: the override
+// when there is one, otherwise the built-in. Builtin is the built-in text
+// either way; Version, UpdatedAt and UpdatedBy describe the override.
+type Prompt struct {
+	RoomType  string     `json:"room_type"`
+	Style     string     `json:"style"`
+	Source    string     `json:"source"`
+	Prompt    string     `json:"prompt"`
+	Builtin   *string    `json:"builtin,omitempty"`
+	Version   int        `json:"version,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy *string    `json:"updated_by,omitempty"`
+}
+
+// SetPromptRequest is the body of PUT /admin/prompts/:room/:style.
+type SetPromptRequest struct {
+	Prompt string `json:"prompt"`
+	// Version, when set, must be the override's current version, or 0 when
+	// there is none yet, so two admins can't overwrite each other's edits.
+	Version *int `json:"version,omitempty"`
+}
+
+// Entry is one prompt in a bundle. Version, UpdatedAt and UpdatedBy describe
+// overrides in the exporting environment and are ignored on import.
+type Entry struct {
+	RoomType  string     `json:"room_type"`
+	Style     string     `json:"style"`
+	Source    string     `json:"source"`
+	Prompt    string     `json:"prompt"`
+	Version   int        `json:"version,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	UpdatedBy *string    `json:"updated_by,omitempty"`
+}
+
+// Bundle is a snapshot of the prompt library, ordered by room type, style and
+// source.
+type Bundle struct {
+	FormatVersion int       `json:"format_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Prompts       []Entry   `json:"prompts"`
+}
+
+// Change is a difference between the imported overrides and the current ones.
+type Change struct {
+	RoomType    string  `json:"room_type"`
+	Style       string  `json:"style"`
+	Action      string  `json:"action"`
+	FromVersion int     `json:"from_version,omitempty"`
+	OldPrompt   *string `json:"old_prompt,omitempty"`
+	NewPrompt   *string `json:"new_prompt,omitempty"`
+}
+
+// ImportResult describes an import. With DryRun set nothing was written.
+type ImportResult struct {
+	DryRun    bool     `json:"dry_run"`
+	Changes   []Change `json:"changes"`
+	Unchanged int      `json:"unchanged"`
+	Warnings  []string `json:"warnings"`
+}
+
+// ValidationError lists every problem found in a bundle.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidBundle, strings.Join(e.Problems, "; "))
+}
+
+// Unwrap lets errors.Is match ErrInvalidBundle.
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidBundle
+}
@@ -0,0 +1,48 @@
+package promptlib
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for the prompt library.
+type Repository interface {
+	// ListBuiltins returns the worker's published prompts ordered by room type and style.
+	ListBuiltins(ctx context.Context) ([]Builtin, error)
+
+	// ListRoomTypes returns the room types the worker has published built-in
+	// prompts for, in name order, without the "default" fallback.
+	ListRoomTypes(ctx context.Context) ([]string, error)
+
+	// ListStyles returns the styles the worker has published built-in prompts
+	// for, in name order, without the "default" fallback.
+	ListStyles(ctx context.Context) ([]string, error)
+
+	// ListOverrides returns every override ordered by room type and style.
+	ListOverrides(ctx context.Context) ([]Override, error)
+
+	// GetBuiltin returns the worker's prompt for the room type and style.
+	// Returns ErrPromptNotFound if the worker has none.
+	GetBuiltin(ctx context.Context, roomType, style string) (*Builtin, error)
+
+	// GetOverride returns the override for the room type and style.
+	// Returns ErrPromptNotFound if there is none.
+	GetOverride(ctx context.Context, roomType, style string) (*Override, error)
+
+	// SetOverride creates the override or replaces its text, incrementing its
+	// version. With version set the write only happens if the override is
+	// still at that version (0 meaning it doesn't exist); otherwise, or if the
+	// text is unchanged, it returns ErrVersionConflict.
+	SetOverride(ctx context.Context, roomType, style, prompt, updatedBy string, version *int) (*Override, error)
+
+	// DeleteOverride removes the override so the built-in applies again. Its
+	// versions are kept. Returns ErrPromptNotFound if there is none.
+	DeleteOverride(ctx context.Context, roomType, style string) error
+
+	// ListOverrideVersions returns every text the override has had, newest first.
+	ListOverrideVersions(ctx context.Context, roomType, style string) ([]OverrideVersion, error)
+
+	// ReplaceOverrides makes overrides the complete set: missing ones are
+	// deleted, new ones created, and changed ones rewritten with their version
+	// incremented. Only RoomType, Style and Prompt are read from overrides.
+	ReplaceOverrides(ctx context.Context, overrides []Override, updatedBy string) error
+}
@@ -0,0 +1,37 @@
+package promptlib
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for editing, exporting and importing prompts.
+type Service interface {
+	// ListPrompts returns the prompt each room type and style is staged with,
+	// ordered by room type and style.
+	ListPrompts(ctx context.Context) ([]Prompt, error)
+
+	// GetPrompt returns the prompt the room type and style is staged with.
+	// Returns ErrPromptNotFound if it has neither a built-in nor an override.
+	GetPrompt(ctx context.Context, roomType, style string) (*Prompt, error)
+
+	// SetPrompt overrides the room type and style's prompt. Returns
+	// ErrInvalidPrompt for a bad key or text, and ErrVersionConflict when
+	// req.Version is set and no longer current.
+	SetPrompt(ctx context.Context, roomType, style string, req SetPromptRequest, updatedBy string) (*Prompt, error)
+
+	// DeletePrompt removes the override so the built-in prompt applies again.
+	// Returns ErrPromptNotFound if there is no override.
+	DeletePrompt(ctx context.Context, roomType, style string) error
+
+	// ListVersions returns every text the room type and style's override has
+	// had, newest first.
+	ListVersions(ctx context.Context, roomType, style string) ([]OverrideVersion, error)
+
+	// Export returns every built-in prompt and override as a bundle.
+	Export(ctx context.Context) (*Bundle, error)
+
+	// Import validates the bundle and makes its overrides the complete set,
+	// reporting what changed. With dryRun set only the diff is computed.
+	// Returns a *ValidationError if the bundle is invalid.
+	Import(ctx context.Context, bundle Bundle, dryRun bool, updatedBy string) (*ImportResult, error)
+}
@@ -0,0 +1,155 @@
+package prompttemplate
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// Problem codes for template conflicts.
+const (
+	CodeDuplicateName    = "prompt_template_exists"
+	CodeTooManyTemplates = "prompt_template_limit"
+)
+
+// DefaultHandler serves the prompt template API.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// CreateTemplate handles POST /api/v1/prompt-templates.
+func (h *DefaultHandler) CreateTemplate(c echo.Context) error {
+	userID, p := h.currentUserID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	var req CreateTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	t, err := h.service.CreateTemplate(c.Request().Context(), userID, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to create prompt template")
+	}
+	return c.JSON(http.StatusCreated, t)
+}
+
+// ListTemplates handles GET /api/v1/prompt-templates.
+func (h *DefaultHandler) ListTemplates(c echo.Context) error {
+	userID, p := h.currentUserID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	templates, err := h.service.ListTemplates(c.Request().Context(), userID)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list prompt templates")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"templates": templates})
+}
+
+// GetTemplate handles GET /api/v1/prompt-templates/:id.
+func (h *DefaultHandler) GetTemplate(c echo.Context) error {
+	userID, id, p := h.templateRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	t, err := h.service.GetTemplate(c.Request().Context(), userID, id)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to get prompt template")
+	}
+	return c.JSON(http.StatusOK, t)
+}
+
+// UpdateTemplate handles PATCH /api/v1/prompt-templates/:id.
+func (h *DefaultHandler) UpdateTemplate(c echo.Context) error {
+	userID, id, p := h.templateRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	var req UpdateTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	t, err := h.service.UpdateTemplate(c.Request().Context(), userID, id, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to update prompt template")
+	}
+	return c.JSON(http.StatusOK, t)
+}
+
+// DeleteTemplate handles DELETE /api/v1/prompt-templates/:id.
+func (h *DefaultHandler) DeleteTemplate(c echo.Context) error {
+	userID, id, p := h.templateRequest(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	if err := h.service.DeleteTemplate(c.Request().Context(), userID, id); err != nil {
+		return h.serviceError(c, err, "Failed to delete prompt template")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// templateRequest validates :id and resolves the caller; ownership is enforced
+// by the service, which only finds templates belonging to the user.
+func (h *DefaultHandler) templateRequest(c echo.Context) (string, string, *problem.Problem) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", "", problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid prompt template ID format")
+	}
+	userID, p := h.currentUserID(c)
+	if p != nil {
+		return "", "", p
+	}
+	return userID, id, nil
+}
+
+func (h *DefaultHandler) currentUserID(c echo.Context) (string, *problem.Problem) {
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not found")
+	}
+	return userRow.ID.String(), nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, ErrTemplateNotFound):
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Prompt template not found")
+	case errors.Is(err, ErrInvalidTemplate):
+		return problem.Write(c, http.StatusUnprocessableEntity, problem.CodeValidationFailed, err.Error())
+	case errors.Is(err, ErrDuplicateName):
+		return problem.Write(c, http.StatusConflict, CodeDuplicateName, err.Error())
+	case errors.Is(err, ErrTooManyTemplates):
+		return problem.Write(c, http.StatusConflict, CodeTooManyTemplates, err.Error())
+	}
+	h.log.Error(c.Request().Context(), "prompt template request failed", "error", err)
+	return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, message)
+}
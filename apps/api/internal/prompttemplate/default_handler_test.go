@@ -0,0 +1,177 @@
+package prompttemplate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/echotest"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newTestHandler(svc Service) *DefaultHandler {
+	userID := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	return NewDefaultHandler(svc, userRepo, logging.Default())
+}
+
+func TestDefaultHandler_CreateTemplate(t *testing.T) {
+	cases := []struct {
+		name         string
+		body         string
+		createErr    error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: returns the template",
+			body:         `{"name":"Cozy","body":"A cozy {style} {room}"}`,
+			expectedCode: http.StatusCreated,
+			expectBody:   `"name":"Cozy"`,
+		},
+		{name: "fail: malformed body", body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: validation",
+			body:         `{"name":"Cozy","body":"{nope}"}`,
+			createErr:    ErrInvalidTemplate,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:         "fail: duplicate name",
+			body:         `{"name":"Cozy","body":"A cozy {style} {room}"}`,
+			createErr:    ErrDuplicateName,
+			expectedCode: http.StatusConflict,
+			expectBody:   CodeDuplicateName,
+		},
+		{
+			name:         "fail: too many templates",
+			body:         `{"name":"Cozy","body":"A cozy {style} {room}"}`,
+			createErr:    ErrTooManyTemplates,
+			expectedCode: http.StatusConflict,
+			expectBody:   CodeTooManyTemplates,
+		},
+		{
+			name:         "fail: service error",
+			body:         `{"name":"Cozy","body":"A cozy {style} {room}"}`,
+			createErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateTemplateFunc: func(ctx context.Context, userID string, req CreateTemplateRequest) (*Template, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Template{ID: "tpl-1", UserID: userID, Name: req.Name, Body: req.Body}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodPost, tc.body, nil, nil)
+
+			require.NoError(t, newTestHandler(svc).CreateTemplate(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			assert.NotContains(t, rec.Body.String(), "user_id")
+		})
+	}
+}
+
+func TestDefaultHandler_ListTemplates(t *testing.T) {
+	svc := &ServiceMock{
+		ListTemplatesFunc: func(ctx context.Context, userID string) ([]Template, error) {
+			return []Template{{ID: "tpl-1", Name: "Cozy"}}, nil
+		},
+	}
+	c, rec := echotest.NewContext(http.MethodGet, "", nil, nil)
+
+	require.NoError(t, newTestHandler(svc).ListTemplates(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"templates":[{"id":"tpl-1"`)
+}
+
+func TestDefaultHandler_GetTemplate(t *testing.T) {
+	id := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		id           string
+		getErr       error
+		expectedCode int
+	}{
+		{name: "success: returns the template", id: id, expectedCode: http.StatusOK},
+		{name: "fail: invalid id", id: "nope", expectedCode: http.StatusBadRequest},
+		{name: "fail: not found", id: id, getErr: ErrTemplateNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetTemplateFunc: func(ctx context.Context, userID, gotID string) (*Template, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Template{ID: gotID, Name: "Cozy"}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{tc.id})
+
+			require.NoError(t, newTestHandler(svc).GetTemplate(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_UpdateTemplate(t *testing.T) {
+	svc := &ServiceMock{
+		UpdateTemplateFunc: func(ctx context.Context, userID, id string, req UpdateTemplateRequest) (*Template, error) {
+			require.NotNil(t, req.Name)
+			assert.Nil(t, req.Body)
+			return &Template{ID: id, Name: *req.Name}, nil
+		},
+	}
+	c, rec := echotest.NewContext(http.MethodPatch, `{"name":"Cozy evening"}`, []string{"id"}, []string{uuid.NewString()})
+
+	require.NoError(t, newTestHandler(svc).UpdateTemplate(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"Cozy evening"`)
+}
+
+func TestDefaultHandler_DeleteTemplate(t *testing.T) {
+	cases := []struct {
+		name         string
+		deleteErr    error
+		expectedCode int
+	}{
+		{name: "success: deleted", expectedCode: http.StatusNoContent},
+		{name: "fail: not found", deleteErr: ErrTemplateNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DeleteTemplateFunc: func(ctx context.Context, userID, id string) error { return tc.deleteErr },
+			}
+			c, rec := echotest.NewContext(http.MethodDelete, "", []string{"id"}, []string{uuid.NewString()})
+
+			require.NoError(t, newTestHandler(svc).DeleteTemplate(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
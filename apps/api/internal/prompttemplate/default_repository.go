@@ -0,0 +1,137 @@
+package prompttemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const templateColumns = ` id, user_id, name, body, created_at, updated_at`
+
+func scanTemplate(row pgx.Row) (*Template, error) {
+	var t Template
+	if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.Body, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create inserts a new template.
+func (r *DefaultRepository) Create(ctx context.Context, t *Template) (*Template, error) {
+	query := `
+		INSERT INTO prompt_templates (user_id, name, body)
+		VALUES ($1, $2, $3)
+		RETURNING` + templateColumns
+
+	created, err := scanTemplate(r.db.QueryRow(ctx, query, t.UserID, t.Name, t.Body))
+	if err != nil {
+		if storage.IsUniqueViolation(err) {
+			return nil, ErrDuplicateName
+		}
+		return nil, fmt.Errorf("failed to create prompt template: %w", err)
+	}
+	return created, nil
+}
+
+// GetByID retrieves a template owned by the user.
+func (r *DefaultRepository) GetByID(ctx context.Context, id, userID string) (*Template, error) {
+	query := `SELECT` + templateColumns + `
+		FROM prompt_templates
+		WHERE id = $1 AND user_id = $2`
+
+	t, err := scanTemplate(r.db.QueryRow(ctx, query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get prompt template: %w", err)
+	}
+	return t, nil
+}
+
+// List returns the user's templates ordered by name.
+func (r *DefaultRepository) List(ctx context.Context, userID string) ([]Template, error) {
+	query := `SELECT` + templateColumns + `
+		FROM prompt_templates
+		WHERE user_id = $1
+		ORDER BY name ASC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []Template{}
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prompt template: %w", err)
+		}
+		templates = append(templates, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over prompt template rows: %w", err)
+	}
+	return templates, nil
+}
+
+// Count returns the number of templates the user has.
+func (r *DefaultRepository) Count(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `SELECT count(*) FROM prompt_templates WHERE user_id = $1`, userID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count prompt templates: %w", err)
+	}
+	return n, nil
+}
+
+// Update persists a template's name and body.
+func (r *DefaultRepository) Update(ctx context.Context, t *Template) (*Template, error) {
+	query := `
+		UPDATE prompt_templates
+		SET name = $3, body = $4, updated_at = now()
+		WHERE id = $1 AND user_id = $2
+		RETURNING` + templateColumns
+
+	updated, err := scanTemplate(r.db.QueryRow(ctx, query, t.ID, t.UserID, t.Name, t.Body))
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, ErrTemplateNotFound
+		case storage.IsUniqueViolation(err):
+			return nil, ErrDuplicateName
+		}
+		return nil, fmt.Errorf("failed to update prompt template: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete removes a template owned by the user.
+func (r *DefaultRepository) Delete(ctx context.Context, id, userID string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM prompt_templates WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete prompt template: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package prompttemplate
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultService implements Service on top of the template table.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// CreateTemplate adds a template to the user's library.
+func (s *DefaultService) CreateTemplate(
+	ctx context.Context, userID string, req CreateTemplateRequest,
+) (*Template, error) {
+	name, err := normalizeName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateBody(req.Body); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.Count(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxTemplatesPerUser {
+		return nil, fmt.Errorf("%w: at most %d templates per user", ErrTooManyTemplates, MaxTemplatesPerUser)
+	}
+
+	return s.repo.Create(ctx, &Template{UserID: userID, Name: name, Body: req.Body})
+}
+
+// ListTemplates returns the user's templates ordered by name.
+func (s *DefaultService) ListTemplates(ctx context.Context, userID string) ([]Template, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// GetTemplate retrieves one of the user's templates.
+func (s *DefaultService) GetTemplate(ctx context.Context, userID, id string) (*Template, error) {
+	return s.repo.GetByID(ctx, id, userID)
+}
+
+// UpdateTemplate renames a template or changes its body.
+func (s *DefaultService) UpdateTemplate(
+	ctx context.Context, userID, id string, req UpdateTemplateRequest,
+) (*Template, error) {
+	t, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name, err := normalizeName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = name
+	}
+	if req.Body != nil {
+		if err := ValidateBody(*req.Body); err != nil {
+			return nil, err
+		}
+		t.Body = *req.Body
+	}
+
+	return s.repo.Update(ctx, t)
+}
+
+// DeleteTemplate removes a template. Images already staged from it keep their prompt.
+func (s *DefaultService) DeleteTemplate(ctx context.Context, userID, id string) error {
+	return s.repo.Delete(ctx, id, userID)
+}
+
+// RenderTemplate returns the prompt one of the user's templates produces for
+// a room type and style.
+func (s *DefaultService) RenderTemplate(
+	ctx context.Context, userID, id string, roomType, style *string,
+) (string, error) {
+	t, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return "", err
+	}
+	return t.Render(roomType, style), nil
+}
@@ -0,0 +1,139 @@
+package prompttemplate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func echoCreate(ctx context.Context, t *Template) (*Template, error) {
+	created := *t
+	created.ID = "tpl-1"
+	return &created, nil
+}
+
+func TestDefaultService_CreateTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		req       CreateTemplateRequest
+		count     int
+		expectErr error
+	}{
+		{
+			name: "success: trims the name",
+			req:  CreateTemplateRequest{Name: "  Cozy  ", Body: "A cozy {style} {room} with warm light"},
+		},
+		{
+			name:      "fail: blank name",
+			req:       CreateTemplateRequest{Name: " ", Body: "A cozy {style} {room}"},
+			expectErr: ErrInvalidTemplate,
+		},
+		{
+			name:      "fail: name too long",
+			req:       CreateTemplateRequest{Name: strings.Repeat("a", MaxNameLength+1), Body: "A cozy {room}"},
+			expectErr: ErrInvalidTemplate,
+		},
+		{
+			name:      "fail: body too short",
+			req:       CreateTemplateRequest{Name: "Short", Body: "  {room}  "},
+			expectErr: ErrInvalidTemplate,
+		},
+		{
+			name:      "fail: body too long",
+			req:       CreateTemplateRequest{Name: "Long", Body: strings.Repeat("a", MaxBodyLength+1)},
+			expectErr: ErrInvalidTemplate,
+		},
+		{
+			name:      "fail: unknown placeholder",
+			req:       CreateTemplateRequest{Name: "Typo", Body: "A cozy {syle} {room}"},
+			expectErr: ErrInvalidTemplate,
+		},
+		{
+			name:      "fail: template limit",
+			req:       CreateTemplateRequest{Name: "Cozy", Body: "A cozy {style} {room}"},
+			count:     MaxTemplatesPerUser,
+			expectErr: ErrTooManyTemplates,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				CountFunc:  func(ctx context.Context, userID string) (int, error) { return tc.count, nil },
+				CreateFunc: echoCreate,
+			}
+
+			created, err := NewDefaultService(repo).CreateTemplate(ctx, "u-1", tc.req)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, repo.CreateCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Cozy", created.Name)
+			assert.Equal(t, "u-1", created.UserID)
+		})
+	}
+}
+
+func TestDefaultService_UpdateTemplate(t *testing.T) {
+	ctx := context.Background()
+	stored := func(ctx context.Context, id, userID string) (*Template, error) {
+		return &Template{ID: id, UserID: userID, Name: "Cozy", Body: "A cozy {style} {room}"}, nil
+	}
+	echoUpdate := func(ctx context.Context, t *Template) (*Template, error) { return t, nil }
+
+	t.Run("success: renames and keeps the body", func(t *testing.T) {
+		repo := &RepositoryMock{GetByIDFunc: stored, UpdateFunc: echoUpdate}
+		name := "Cozy evening"
+
+		updated, err := NewDefaultService(repo).UpdateTemplate(ctx, "u-1", "tpl-1", UpdateTemplateRequest{Name: &name})
+		require.NoError(t, err)
+		assert.Equal(t, "Cozy evening", updated.Name)
+		assert.Equal(t, "A cozy {style} {room}", updated.Body)
+	})
+
+	t.Run("fail: invalid body", func(t *testing.T) {
+		repo := &RepositoryMock{GetByIDFunc: stored}
+		body := "A {colour} room"
+
+		_, err := NewDefaultService(repo).UpdateTemplate(ctx, "u-1", "tpl-1", UpdateTemplateRequest{Body: &body})
+		assert.ErrorIs(t, err, ErrInvalidTemplate)
+		assert.Empty(t, repo.UpdateCalls())
+	})
+
+	t.Run("fail: not found", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id, userID string) (*Template, error) {
+				return nil, ErrTemplateNotFound
+			},
+		}
+
+		_, err := NewDefaultService(repo).UpdateTemplate(ctx, "u-1", "tpl-1", UpdateTemplateRequest{})
+		assert.ErrorIs(t, err, ErrTemplateNotFound)
+	})
+}
+
+func TestDefaultService_RenderTemplate(t *testing.T) {
+	ctx := context.Background()
+	repo := &RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id, userID string) (*Template, error) {
+			return &Template{ID: id, Body: "A cozy {style} {room}; keep the {room} bright"}, nil
+		},
+	}
+	svc := NewDefaultService(repo)
+	room, style := "living_room", "scandinavian"
+
+	prompt, err := svc.RenderTemplate(ctx, "u-1", "tpl-1", &room, &style)
+	require.NoError(t, err)
+	assert.Equal(t, "A cozy scandinavian living room; keep the living room bright", prompt)
+
+	prompt, err = svc.RenderTemplate(ctx, "u-1", "tpl-1", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "A cozy modern room; keep the room bright", prompt)
+}
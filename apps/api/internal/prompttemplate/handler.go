@@ -0,0 +1,23 @@
+package prompttemplate
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for a user's prompt templates.
+type Handler interface {
+	// CreateTemplate handles POST /prompt-templates - Adds a template.
+	CreateTemplate(c echo.Context) error
+
+	// ListTemplates handles GET /prompt-templates - Lists the caller's templates.
+	ListTemplates(c echo.Context) error
+
+	// GetTemplate handles GET /prompt-templates/:id - Gets a template.
+	GetTemplate(c echo.Context) error
+
+	// UpdateTemplate handles PATCH /prompt-templates/:id - Renames a template or changes its body.
+	UpdateTemplate(c echo.Context) error
+
+	// DeleteTemplate handles DELETE /prompt-templates/:id - Deletes a template.
+	DeleteTemplate(c echo.Context) error
+}
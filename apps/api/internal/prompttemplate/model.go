@@ -0,0 +1,113 @@
+// Package prompttemplate lets users keep a library of named staging prompts.
+//
+// A template's body may contain {room} and {style} placeholders. Image
+// requests reference a template by ID instead of sending prompt text, and the
+// placeholders are filled in from the request's room type and style before the
+// prompt is screened and staged like any custom prompt.
+package prompttemplate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Limits on templates. The body bounds match a custom prompt's so a rendered
+// template is always a valid one.
+const (
+	MaxNameLength       = 100
+	MinBodyLength       = 10
+	MaxBodyLength       = 2000
+	MaxTemplatesPerUser = 100
+)
+
+// Placeholders a template body may contain.
+const (
+	PlaceholderRoom  = "{room}"
+	PlaceholderStyle = "{style}"
+)
+
+// Values the placeholders take when the image request leaves room type or
+// style unset; the worker stages such images as a modern room too.
+const (
+	defaultRoom  = "room"
+	defaultStyle = "modern"
+)
+
+var (
+	// ErrTemplateNotFound is returned when a template does not exist or belongs to another user.
+	ErrTemplateNotFound = errors.New("prompt template not found")
+	// ErrInvalidTemplate is returned when a name or body fails validation.
+	ErrInvalidTemplate = errors.New("invalid prompt template")
+	// ErrDuplicateName is returned when the user already has a template with the name.
+	ErrDuplicateName = errors.New("a prompt template with this name already exists")
+	// ErrTooManyTemplates is returned when the user already has MaxTemplatesPerUser templates.
+	ErrTooManyTemplates = errors.New("prompt template limit reached")
+)
+
+// placeholderPattern matches anything shaped like a placeholder.
+var placeholderPattern = regexp.MustCompile(`\{[^{}\s]*\}`)
+
+// Template is a user's named prompt.
+type Template struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"-"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateTemplateRequest creates a template.
+type CreateTemplateRequest struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+}
+
+// UpdateTemplateRequest changes a template. Nil fields are left unchanged.
+type UpdateTemplateRequest struct {
+	Name *string `json:"name,omitempty"`
+	Body *string `json:"body,omitempty"`
+}
+
+// Render fills the template's placeholders from a room type and style. A nil
+// room type or style renders as the worker's defaults.
+func (t *Template) Render(roomType, style *string) string {
+	room, sty := defaultRoom, defaultStyle
+	if roomType != nil && *roomType != "" {
+		room = strings.ReplaceAll(*roomType, "_", " ")
+	}
+	if style != nil && *style != "" {
+		sty = *style
+	}
+	return strings.NewReplacer(PlaceholderRoom, room, PlaceholderStyle, sty).Replace(t.Body)
+}
+
+// normalizeName trims a template name and checks its length.
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return "", fmt.Errorf("%w: name must be between 1 and %d characters", ErrInvalidTemplate, MaxNameLength)
+	}
+	return name, nil
+}
+
+// ValidateBody checks a body's length and that it only uses known placeholders.
+// Other prompts with placeholders, such as style presets', use the same rules.
+func ValidateBody(body string) error {
+	n := utf8.RuneCountInString(strings.TrimSpace(body))
+	if n < MinBodyLength || utf8.RuneCountInString(body) > MaxBodyLength {
+		return fmt.Errorf("%w: body must be between %d and %d characters",
+			ErrInvalidTemplate, MinBodyLength, MaxBodyLength)
+	}
+	for _, p := range placeholderPattern.FindAllString(body, -1) {
+		if p != PlaceholderRoom && p != PlaceholderStyle {
+			return fmt.Errorf("%w: unknown placeholder %s; use %s or %s",
+				ErrInvalidTemplate, p, PlaceholderRoom, PlaceholderStyle)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package prompttemplate
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for prompt templates. Every lookup is scoped
+// by user ID so one user can never see another's templates.
+type Repository interface {
+	// Create inserts a new template. Returns ErrDuplicateName if the user already has one with the name.
+	Create(ctx context.Context, t *Template) (*Template, error)
+
+	// GetByID retrieves a template owned by the user.
+	GetByID(ctx context.Context, id, userID string) (*Template, error)
+
+	// List returns the user's templates ordered by name.
+	List(ctx context.Context, userID string) ([]Template, error)
+
+	// Count returns the number of templates the user has.
+	Count(ctx context.Context, userID string) (int, error)
+
+	// Update persists a template's name and body.
+	Update(ctx context.Context, t *Template) (*Template, error)
+
+	// Delete removes a template owned by the user.
+	Delete(ctx context.Context, id, userID string) error
+}
@@ -0,0 +1,27 @@
+package prompttemplate
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for prompt templates.
+type Service interface {
+	// CreateTemplate adds a template to the user's library.
+	CreateTemplate(ctx context.Context, userID string, req CreateTemplateRequest) (*Template, error)
+
+	// ListTemplates returns the user's templates ordered by name.
+	ListTemplates(ctx context.Context, userID string) ([]Template, error)
+
+	// GetTemplate retrieves one of the user's templates.
+	GetTemplate(ctx context.Context, userID, id string) (*Template, error)
+
+	// UpdateTemplate renames a template or changes its body.
+	UpdateTemplate(ctx context.Context, userID, id string, req UpdateTemplateRequest) (*Template, error)
+
+	// DeleteTemplate removes a template. Images already staged from it keep their prompt.
+	DeleteTemplate(ctx context.Context, userID, id string) error
+
+	// RenderTemplate returns the prompt one of the user's templates produces for
+	// a room type and style.
+	RenderTemplate(ctx context.Context, userID, id string, roomType, style *string) (string, error)
+}
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/real-staging-ai/api/internal/config"
 	"github.com/real-staging-ai/api/internal/logging"
@@ -20,7 +21,64 @@ import (
 // TaskTypeStageRun is the queue task type for running the staging pipeline.
 const TaskTypeStageRun = "stage:run"
 
-// StageRunPayload is the contract for a stage:run task payload.
+// TaskTypeDeclutterRun is the queue task type for the staging pipeline run in
+// a mode that removes the furniture already in the photo.
+const TaskTypeDeclutterRun = "declutter:run"
+
+// TaskTypeDeliverySend is the queue task type for sending an outbound webhook or email.
+const TaskTypeDeliverySend = "delivery:send"
+
+// TaskTypeCutoutRun is the queue task type for the cutout pipeline, which
+// segments a staged image into transparent-background furniture pieces.
+const TaskTypeCutoutRun = "cutout:run"
+
+// Dedicated queues for outbound deliveries so a slow webhook receiver or SMTP
+// server never starves staging jobs.
+const (
+	QueueWebhooks = "webhooks"
+	QueueEmails   = "emails"
+)
+
+// Priority ranks staging jobs. Each priority has its own queue, which workers
+// poll with configurable weights, so higher priorities are served first
+// without starving the others.
+type Priority string
+
+// Priorities, highest first. Jobs without one are PriorityDefault.
+const (
+	PriorityCritical Priority = "critical"
+	PriorityDefault  Priority = "default"
+	PriorityLow      Priority = "low"
+)
+
+// Queues for staging jobs above and below the default priority. Default jobs
+// use the enqueuer's default queue.
+const (
+	QueueCritical = "critical"
+	QueueLow      = "low"
+)
+
+// PriorityForPlan returns the priority of staging jobs paid for on the plan:
+// business jobs are critical and free ones low. Other plans, and an unknown
+// plan, get the default priority.
+func PriorityForPlan(planCode string) Priority {
+	switch planCode {
+	case "business":
+		return PriorityCritical
+	case "free":
+		return PriorityLow
+	default:
+		return PriorityDefault
+	}
+}
+
+// DeliveryPayload is the contract for a delivery:send task payload. The
+// delivery row (outbox record) holds the destination and body.
+type DeliveryPayload struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+// StageRunPayload is the contract for a stage:run or declutter:run task payload.
 //
 // The fields align with the worker's processor expectations for Phase 1.
 type StageRunPayload struct {
@@ -30,6 +88,33 @@ type StageRunPayload struct {
 	Style       *string `json:"style,omitempty"`
 	Seed        *int64  `json:"seed,omitempty"`
 	Prompt      *string `json:"prompt,omitempty"`
+	// ModelID overrides the global active model; empty uses it.
+	ModelID *string `json:"model_id,omitempty"`
+	// Sandbox routes the job to the fake staging provider.
+	Sandbox bool `json:"sandbox,omitempty"`
+	// MaskURL limits staging to the white areas of a PNG mask; empty stages
+	// the whole photo.
+	MaskURL *string `json:"mask_url,omitempty"`
+	// Mode is declutter or both on declutter:run tasks and empty on stage:run
+	// ones. The worker also requeues declutter jobs by it.
+	Mode string `json:"mode,omitempty"`
+	// ModelConfig overrides fields of the admin's config for the model, as
+	// set by the style preset the image was created from.
+	ModelConfig map[string]interface{} `json:"model_config,omitempty"`
+	// Language is the language the image was requested in, when it isn't
+	// English. The worker translates the image's error messages and default
+	// watermark into it and tells the model what language a custom prompt is
+	// written in.
+	Language string `json:"language,omitempty"`
+	// Priority picks the queue the task is put on; empty is PriorityDefault.
+	// The worker requeues the task on the same queue.
+	Priority Priority `json:"priority,omitempty"`
+}
+
+// CutoutRunPayload is the contract for a cutout:run task payload.
+type CutoutRunPayload struct {
+	ImageID   string `json:"image_id"`
+	StagedURL string `json:"staged_url"`
 }
 
 // EnqueueOpts controls per-task enqueue behavior (queue, retries, schedule, etc.).
@@ -52,13 +137,31 @@ type EnqueueOpts struct {
 	// Deadline sets the absolute deadline for the task.
 	// Zero time means "not set".
 	Deadline time.Time
+
+	// TaskID sets the task's ID, so it can be found again without keeping the
+	// ID the queue returns. Empty lets the queue generate one.
+	TaskID string
 }
 
+//go:generate go run github.com/matryer/moq@v0.5.3 -out enqueuer_mock.go . Enqueuer
+
 // Enqueuer defines the interface for enqueuing background jobs from the API.
 type Enqueuer interface {
 	// EnqueueStageRun enqueues a stage:run task with the given payload.
 	// Returns the task ID assigned by the queue backend.
 	EnqueueStageRun(ctx context.Context, payload StageRunPayload, opts *EnqueueOpts) (string, error)
+
+	// EnqueueDeclutterRun enqueues a declutter:run task with the given payload.
+	// Returns the task ID assigned by the queue backend.
+	EnqueueDeclutterRun(ctx context.Context, payload StageRunPayload, opts *EnqueueOpts) (string, error)
+
+	// EnqueueDelivery enqueues a delivery:send task for an outbox record.
+	// Returns the task ID assigned by the queue backend.
+	EnqueueDelivery(ctx context.Context, payload DeliveryPayload, opts *EnqueueOpts) (string, error)
+
+	// EnqueueCutoutRun enqueues a cutout:run task for a staged image.
+	// Returns the task ID assigned by the queue backend.
+	EnqueueCutoutRun(ctx context.Context, payload CutoutRunPayload, opts *EnqueueOpts) (string, error)
 }
 
 // AsynqEnqueuer implements Enqueuer using Redis + asynq.
@@ -103,6 +206,25 @@ func (e *AsynqEnqueuer) EnqueueStageRun(
 	ctx, span := tracer.Start(ctx, "queue.EnqueueStageRun")
 	defer span.End()
 
+	return e.enqueueImageRun(ctx, span, TaskTypeStageRun, payload, opts)
+}
+
+// EnqueueDeclutterRun enqueues a declutter run job.
+func (e *AsynqEnqueuer) EnqueueDeclutterRun(
+	ctx context.Context, payload StageRunPayload, opts *EnqueueOpts,
+) (string, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.EnqueueDeclutterRun")
+	defer span.End()
+
+	return e.enqueueImageRun(ctx, span, TaskTypeDeclutterRun, payload, opts)
+}
+
+// enqueueImageRun enqueues a task of taskType that runs the staging pipeline
+// for one image.
+func (e *AsynqEnqueuer) enqueueImageRun(
+	ctx context.Context, span trace.Span, taskType string, payload StageRunPayload, opts *EnqueueOpts,
+) (string, error) {
 	log := logging.NewDefaultLogger()
 
 	// Basic validation to catch obvious mistakes early.
@@ -110,19 +232,19 @@ func (e *AsynqEnqueuer) EnqueueStageRun(
 		err := errors.New("payload.image_id is required")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		log.Error(ctx, "enqueue validation failed", "task_type", TaskTypeStageRun, "image_id", payload.ImageID, "error", err)
+		log.Error(ctx, "enqueue validation failed", "task_type", taskType, "image_id", payload.ImageID, "error", err)
 		return "", err
 	}
 	if payload.OriginalURL == "" {
 		err := errors.New("payload.original_url is required")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		log.Error(ctx, "enqueue validation failed", "task_type", TaskTypeStageRun, "image_id", payload.ImageID, "error", err)
+		log.Error(ctx, "enqueue validation failed", "task_type", taskType, "image_id", payload.ImageID, "error", err)
 		return "", err
 	}
 
 	span.SetAttributes(
-		attribute.String("queue.task_type", TaskTypeStageRun),
+		attribute.String("queue.task_type", taskType),
 		attribute.String("image.id", payload.ImageID),
 	)
 
@@ -130,54 +252,173 @@ func (e *AsynqEnqueuer) EnqueueStageRun(
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "marshal payload")
-		log.Error(ctx, "marshal payload failed", "task_type", TaskTypeStageRun, "image_id", payload.ImageID, "error", err)
+		log.Error(ctx, "marshal payload failed", "task_type", taskType, "image_id", payload.ImageID, "error", err)
 		return "", fmt.Errorf("marshal payload: %w", err)
 	}
 
-	task := asynq.NewTask(TaskTypeStageRun, b)
+	task := asynq.NewTask(taskType, b)
 
-	// Map our generic EnqueueOpts to asynq options.
-	selectedQueue := e.defaultQueue
-	asynqOpts := []asynq.Option{asynq.Queue(selectedQueue)}
-	if opts != nil {
-		if opts.Queue != "" {
-			selectedQueue = opts.Queue
-			asynqOpts[0] = asynq.Queue(selectedQueue)
-		}
-		if opts.Retry >= 0 {
-			asynqOpts = append(asynqOpts, asynq.MaxRetry(opts.Retry))
-		}
-		if opts.Timeout > 0 {
-			asynqOpts = append(asynqOpts, asynq.Timeout(opts.Timeout))
-		}
-		if !opts.ProcessAt.IsZero() {
-			asynqOpts = append(asynqOpts, asynq.ProcessAt(opts.ProcessAt))
-		}
-		if !opts.Deadline.IsZero() {
-			asynqOpts = append(asynqOpts, asynq.Deadline(opts.Deadline))
-		}
-	}
-
-	log.Info(ctx, "enqueue attempt", "task_type", TaskTypeStageRun, "image_id", payload.ImageID, "queue", selectedQueue)
+	// Map our generic EnqueueOpts to asynq options; a queue set there wins
+	// over the payload's priority.
+	selectedQueue, asynqOpts := e.asynqOptions(priorityOptions(payload.Priority, opts))
+
+	log.Info(ctx, "enqueue attempt", "task_type", taskType, "image_id", payload.ImageID, "queue", selectedQueue)
 	info, err := e.client.EnqueueContext(ctx, task, asynqOpts...)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "enqueue error")
 		log.Error(ctx, "enqueue failed",
-			"task_type", TaskTypeStageRun,
+			"task_type", taskType,
 			"image_id", payload.ImageID,
 			"queue", selectedQueue,
 			"error", err)
-		return "", fmt.Errorf("enqueue stage:run: %w", err)
+		return "", fmt.Errorf("enqueue %s: %w", taskType, err)
+	}
+	span.SetAttributes(
+		attribute.String("queue.id", info.ID),
+		attribute.String("queue.name", selectedQueue),
+	)
+	log.Info(ctx, "enqueued "+taskType, "image_id", payload.ImageID, "queue", selectedQueue, "task_id", info.ID)
+	return info.ID, nil
+}
+
+// EnqueueDelivery enqueues a delivery:send job.
+func (e *AsynqEnqueuer) EnqueueDelivery(
+	ctx context.Context, payload DeliveryPayload, opts *EnqueueOpts,
+) (string, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.EnqueueDelivery")
+	defer span.End()
+
+	if payload.DeliveryID == "" {
+		err := errors.New("payload.delivery_id is required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+	span.SetAttributes(
+		attribute.String("queue.task_type", TaskTypeDeliverySend),
+		attribute.String("delivery.id", payload.DeliveryID),
+	)
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "marshal payload")
+		return "", fmt.Errorf("marshal payload: %w", err)
+	}
+
+	selectedQueue, asynqOpts := e.asynqOptions(opts)
+	info, err := e.client.EnqueueContext(ctx, asynq.NewTask(TaskTypeDeliverySend, b), asynqOpts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "enqueue error")
+		return "", fmt.Errorf("enqueue delivery:send: %w", err)
+	}
+	span.SetAttributes(
+		attribute.String("queue.id", info.ID),
+		attribute.String("queue.name", selectedQueue),
+	)
+	return info.ID, nil
+}
+
+// EnqueueCutoutRun enqueues a cutout:run job.
+func (e *AsynqEnqueuer) EnqueueCutoutRun(
+	ctx context.Context, payload CutoutRunPayload, opts *EnqueueOpts,
+) (string, error) {
+	tracer := otel.Tracer("real-staging-api/queue")
+	ctx, span := tracer.Start(ctx, "queue.EnqueueCutoutRun")
+	defer span.End()
+
+	if payload.ImageID == "" {
+		err := errors.New("payload.image_id is required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+	if payload.StagedURL == "" {
+		err := errors.New("payload.staged_url is required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+	span.SetAttributes(
+		attribute.String("queue.task_type", TaskTypeCutoutRun),
+		attribute.String("image.id", payload.ImageID),
+	)
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "marshal payload")
+		return "", fmt.Errorf("marshal payload: %w", err)
+	}
+
+	selectedQueue, asynqOpts := e.asynqOptions(opts)
+	info, err := e.client.EnqueueContext(ctx, asynq.NewTask(TaskTypeCutoutRun, b), asynqOpts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "enqueue error")
+		return "", fmt.Errorf("enqueue cutout:run: %w", err)
 	}
 	span.SetAttributes(
 		attribute.String("queue.id", info.ID),
 		attribute.String("queue.name", selectedQueue),
 	)
-	log.Info(ctx, "enqueued stage:run", "image_id", payload.ImageID, "queue", selectedQueue, "task_id", info.ID)
 	return info.ID, nil
 }
 
+// priorityOptions returns opts with the queue of priority when opts names no
+// queue of its own.
+func priorityOptions(priority Priority, opts *EnqueueOpts) *EnqueueOpts {
+	var q string
+	switch priority {
+	case PriorityCritical:
+		q = QueueCritical
+	case PriorityLow:
+		q = QueueLow
+	default:
+		return opts
+	}
+	if opts == nil {
+		return &EnqueueOpts{Queue: q, Retry: -1}
+	}
+	if opts.Queue != "" {
+		return opts
+	}
+	withQueue := *opts
+	withQueue.Queue = q
+	return &withQueue
+}
+
+// asynqOptions maps our generic EnqueueOpts to asynq options and returns the selected queue.
+func (e *AsynqEnqueuer) asynqOptions(opts *EnqueueOpts) (string, []asynq.Option) {
+	selectedQueue := e.defaultQueue
+	if opts != nil && opts.Queue != "" {
+		selectedQueue = opts.Queue
+	}
+	asynqOpts := []asynq.Option{asynq.Queue(selectedQueue)}
+	if opts == nil {
+		return selectedQueue, asynqOpts
+	}
+	if opts.Retry >= 0 {
+		asynqOpts = append(asynqOpts, asynq.MaxRetry(opts.Retry))
+	}
+	if opts.Timeout > 0 {
+		asynqOpts = append(asynqOpts, asynq.Timeout(opts.Timeout))
+	}
+	if !opts.ProcessAt.IsZero() {
+		asynqOpts = append(asynqOpts, asynq.ProcessAt(opts.ProcessAt))
+	}
+	if !opts.Deadline.IsZero() {
+		asynqOpts = append(asynqOpts, asynq.Deadline(opts.Deadline))
+	}
+	if opts.TaskID != "" {
+		asynqOpts = append(asynqOpts, asynq.TaskID(opts.TaskID))
+	}
+	return selectedQueue, asynqOpts
+}
+
 // Close releases the underlying asynq client resources.
 func (e *AsynqEnqueuer) Close() error {
 	return e.client.Close()
@@ -190,3 +431,18 @@ type NoopEnqueuer struct{}
 func (NoopEnqueuer) EnqueueStageRun(_ context.Context, _ StageRunPayload, _ *EnqueueOpts) (string, error) {
 	return "noop", nil
 }
+
+// EnqueueDeclutterRun implements Enqueuer by returning a static ID without side effects.
+func (NoopEnqueuer) EnqueueDeclutterRun(_ context.Context, _ StageRunPayload, _ *EnqueueOpts) (string, error) {
+	return "noop", nil
+}
+
+// EnqueueDelivery implements Enqueuer by returning a static ID without side effects.
+func (NoopEnqueuer) EnqueueDelivery(_ context.Context, _ DeliveryPayload, _ *EnqueueOpts) (string, error) {
+	return "noop", nil
+}
+
+// EnqueueCutoutRun implements Enqueuer by returning a static ID without side effects.
+func (NoopEnqueuer) EnqueueCutoutRun(_ context.Context, _ CutoutRunPayload, _ *EnqueueOpts) (string, error) {
+	return "noop", nil
+}
@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityForPlan(t *testing.T) {
+	assert.Equal(t, PriorityCritical, PriorityForPlan("business"))
+	assert.Equal(t, PriorityDefault, PriorityForPlan("pro"))
+	assert.Equal(t, PriorityLow, PriorityForPlan("free"))
+	assert.Equal(t, PriorityDefault, PriorityForPlan("sandbox"))
+	assert.Equal(t, PriorityDefault, PriorityForPlan(""))
+}
+
+func TestPriorityOptions(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		priority Priority
+		opts     *EnqueueOpts
+		want     *EnqueueOpts
+	}{
+		{name: "success: default priority keeps nil options", priority: PriorityDefault},
+		{name: "success: no priority keeps the options", opts: &EnqueueOpts{Retry: 2}, want: &EnqueueOpts{Retry: 2}},
+		{name: "success: critical queue", priority: PriorityCritical, want: &EnqueueOpts{Queue: QueueCritical, Retry: -1}},
+		{
+			name: "success: low queue keeps the other options", priority: PriorityLow,
+			opts: &EnqueueOpts{Retry: 1, Deadline: deadline},
+			want: &EnqueueOpts{Queue: QueueLow, Retry: 1, Deadline: deadline},
+		},
+		{
+			name: "success: an explicit queue wins", priority: PriorityLow,
+			opts: &EnqueueOpts{Queue: "maintenance"}, want: &EnqueueOpts{Queue: "maintenance"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, priorityOptions(tt.priority, tt.opts))
+		})
+	}
+}
+
+func TestAsynqEnqueuer_asynqOptions(t *testing.T) {
+	e := &AsynqEnqueuer{defaultQueue: "default"}
+
+	t.Run("success: nil options use the default queue", func(t *testing.T) {
+		q, opts := e.asynqOptions(nil)
+		assert.Equal(t, "default", q)
+		assert.Equal(t, []asynq.Option{asynq.Queue("default")}, opts)
+	})
+
+	t.Run("success: task ID is set", func(t *testing.T) {
+		q, opts := e.asynqOptions(&EnqueueOpts{Queue: QueueLow, Retry: -1, TaskID: "job-1"})
+		assert.Equal(t, QueueLow, q)
+		assert.Equal(t, []asynq.Option{asynq.Queue(QueueLow), asynq.TaskID("job-1")}, opts)
+	})
+}
@@ -0,0 +1,94 @@
+package queueadmin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/pagination"
+)
+
+// DefaultHandler serves the admin queue inspection endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ListTasks handles GET /admin/queue/tasks - Lists tasks in a state.
+// Requires a state query parameter; supports queue, page and page_size.
+func (h *DefaultHandler) ListTasks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	filter := ListFilter{State: State(c.QueryParam("state")), Queue: c.QueryParam("queue")}
+	var err error
+	if filter.Page, err = pagination.IntParam(c, "page"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "page must be an integer")
+	}
+	if filter.PageSize, err = pagination.IntParam(c, "page_size"); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "page_size must be an integer")
+	}
+
+	tasks, err := h.service.ListTasks(ctx, filter)
+	if err != nil {
+		if errors.Is(err, ErrInvalidState) {
+			return echo.NewHTTPError(http.StatusBadRequest,
+				"state must be one of: pending, active, scheduled, retry, dead")
+		}
+		return h.serviceError(c, err, "Failed to list tasks")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"tasks": tasks,
+	})
+}
+
+// RequeueTask handles POST /admin/queue/tasks/:id/requeue - Runs a failed or scheduled task now.
+func (h *DefaultHandler) RequeueTask(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	task, err := h.service.RequeueTask(ctx, c.QueryParam("queue"), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, ErrNotRequeueable) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return h.serviceError(c, err, "Failed to requeue task")
+	}
+
+	return c.JSON(http.StatusOK, task)
+}
+
+// DeleteTask handles DELETE /admin/queue/tasks/:id - Removes a task.
+func (h *DefaultHandler) DeleteTask(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := h.service.DeleteTask(ctx, c.QueryParam("queue"), c.Param("id")); err != nil {
+		if errors.Is(err, ErrTaskActive) {
+			return echo.NewHTTPError(http.StatusConflict, "Task is being processed and cannot be deleted")
+		}
+		return h.serviceError(c, err, "Failed to delete task")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// serviceError maps the errors every endpoint shares to a response.
+func (h *DefaultHandler) serviceError(c echo.Context, err error, msg string) error {
+	switch {
+	case errors.Is(err, ErrTaskNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "Task not found")
+	case errors.Is(err, ErrUnavailable):
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Queue inspection is not configured")
+	}
+	h.log.Error(c.Request().Context(), "queue admin request failed", "error", err, "task_id", c.Param("id"))
+	return echo.NewHTTPError(http.StatusInternalServerError, msg)
+}
@@ -0,0 +1,147 @@
+package queueadmin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_ListTasks(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		listErr    error
+		wantStatus int
+		wantFilter ListFilter
+	}{
+		{
+			name:       "success: passes the filter",
+			query:      "?state=retry&queue=default&page=2&page_size=10",
+			wantStatus: http.StatusOK,
+			wantFilter: ListFilter{State: StateRetry, Queue: "default", Page: 2, PageSize: 10},
+		},
+		{name: "fail: bad page", query: "?state=retry&page=x", wantStatus: http.StatusBadRequest},
+		{name: "fail: invalid state", query: "?state=nope", listErr: ErrInvalidState, wantStatus: http.StatusBadRequest},
+		{
+			name: "fail: not configured", query: "?state=dead", listErr: ErrUnavailable,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{name: "fail: service error", query: "?state=dead", listErr: errors.New("redis down"),
+			wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ListTasksFunc: func(ctx context.Context, filter ListFilter) ([]Task, error) {
+					return []Task{{ID: "t1", State: StateRetry}}, tc.listErr
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/queue/tasks"+tc.query, nil)
+			err := h.ListTasks(e.NewContext(req, rec))
+
+			if tc.wantStatus != http.StatusOK {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, tc.wantStatus, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantFilter, svc.ListTasksCalls()[0].Filter)
+			assert.Contains(t, rec.Body.String(), `"id":"t1"`)
+		})
+	}
+}
+
+func TestDefaultHandler_RequeueTask(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success: requeues", wantStatus: http.StatusOK},
+		{name: "fail: not found", err: ErrTaskNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: pending task", err: ErrNotRequeueable, wantStatus: http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RequeueTaskFunc: func(ctx context.Context, queue, id string) (*Task, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &Task{ID: id, Queue: queue, State: StatePending}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/?queue=default", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("t1")
+			err := h.RequeueTask(c)
+
+			if tc.wantStatus != http.StatusOK {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, tc.wantStatus, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "default", svc.RequeueTaskCalls()[0].Queue)
+			assert.Contains(t, rec.Body.String(), `"state":"pending"`)
+		})
+	}
+}
+
+func TestDefaultHandler_DeleteTask(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success: deletes", wantStatus: http.StatusNoContent},
+		{name: "fail: active task", err: ErrTaskActive, wantStatus: http.StatusConflict},
+		{name: "fail: service error", err: errors.New("redis down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DeleteTaskFunc: func(ctx context.Context, queue, id string) error { return tc.err },
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues("t1")
+			err := h.DeleteTask(c)
+
+			if tc.wantStatus != http.StatusNoContent {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, tc.wantStatus, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, "t1", svc.DeleteTaskCalls()[0].ID)
+		})
+	}
+}
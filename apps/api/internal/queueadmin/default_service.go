@@ -0,0 +1,178 @@
+package queueadmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// DefaultService implements Service on top of the asynq inspector.
+type DefaultService struct {
+	inspector Inspector
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a DefaultService. A nil inspector makes every
+// method return ErrUnavailable.
+func NewDefaultService(inspector Inspector) *DefaultService {
+	return &DefaultService{inspector: inspector}
+}
+
+// ListTasks returns a page of tasks in one state. Without a queue, the page
+// applies to each queue and the results are concatenated in queue order.
+func (s *DefaultService) ListTasks(ctx context.Context, filter ListFilter) ([]Task, error) {
+	if s.inspector == nil {
+		return nil, ErrUnavailable
+	}
+	if !slices.Contains(States, filter.State) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidState, filter.State)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = DefaultPageSize
+	}
+	filter.PageSize = min(filter.PageSize, MaxPageSize)
+
+	queues := []string{filter.Queue}
+	if filter.Queue == "" {
+		var err error
+		if queues, err = s.inspector.Queues(); err != nil {
+			return nil, fmt.Errorf("list queues: %w", err)
+		}
+		slices.Sort(queues)
+	}
+
+	tasks := []Task{}
+	for _, queue := range queues {
+		infos, err := s.list(filter.State, queue, asynq.Page(filter.Page), asynq.PageSize(filter.PageSize))
+		if errors.Is(err, asynq.ErrQueueNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list %s tasks in queue %s: %w", filter.State, queue, err)
+		}
+		for _, info := range infos {
+			tasks = append(tasks, toTask(info))
+		}
+	}
+	return tasks, nil
+}
+
+// RequeueTask moves a scheduled, retry or dead task to pending.
+func (s *DefaultService) RequeueTask(ctx context.Context, queue, id string) (*Task, error) {
+	info, err := s.find(queue, id)
+	if err != nil {
+		return nil, err
+	}
+	if info.State == asynq.TaskStatePending || info.State == asynq.TaskStateActive {
+		return nil, fmt.Errorf("%w: task is %s", ErrNotRequeueable, stateOf(info.State))
+	}
+	if err := s.inspector.RunTask(info.Queue, info.ID); err != nil {
+		return nil, fmt.Errorf("requeue task: %w", err)
+	}
+
+	task := toTask(info)
+	task.State = StatePending
+	task.NextProcessAt = nil
+	return &task, nil
+}
+
+// DeleteTask removes a task unless a worker is processing it.
+func (s *DefaultService) DeleteTask(ctx context.Context, queue, id string) error {
+	info, err := s.find(queue, id)
+	if err != nil {
+		return err
+	}
+	if info.State == asynq.TaskStateActive {
+		return ErrTaskActive
+	}
+	if err := s.inspector.DeleteTask(info.Queue, info.ID); err != nil {
+		if errors.Is(err, asynq.ErrTaskNotFound) {
+			return ErrTaskNotFound
+		}
+		return fmt.Errorf("delete task: %w", err)
+	}
+	return nil
+}
+
+// find looks a task up in queue, or in every queue when queue is empty.
+func (s *DefaultService) find(queue, id string) (*asynq.TaskInfo, error) {
+	if s.inspector == nil {
+		return nil, ErrUnavailable
+	}
+	queues := []string{queue}
+	if queue == "" {
+		var err error
+		if queues, err = s.inspector.Queues(); err != nil {
+			return nil, fmt.Errorf("list queues: %w", err)
+		}
+	}
+	for _, q := range queues {
+		info, err := s.inspector.GetTaskInfo(q, id)
+		if errors.Is(err, asynq.ErrQueueNotFound) || errors.Is(err, asynq.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get task: %w", err)
+		}
+		return info, nil
+	}
+	return nil, ErrTaskNotFound
+}
+
+func (s *DefaultService) list(state State, queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+	switch state {
+	case StatePending:
+		return s.inspector.ListPendingTasks(queue, opts...)
+	case StateActive:
+		return s.inspector.ListActiveTasks(queue, opts...)
+	case StateScheduled:
+		return s.inspector.ListScheduledTasks(queue, opts...)
+	case StateRetry:
+		return s.inspector.ListRetryTasks(queue, opts...)
+	default:
+		return s.inspector.ListArchivedTasks(queue, opts...)
+	}
+}
+
+func toTask(info *asynq.TaskInfo) Task {
+	t := Task{
+		ID:        info.ID,
+		Queue:     info.Queue,
+		Type:      info.Type,
+		State:     stateOf(info.State),
+		Retried:   info.Retried,
+		MaxRetry:  info.MaxRetry,
+		LastError: info.LastErr,
+	}
+	if json.Valid(info.Payload) {
+		t.Payload = info.Payload
+	}
+	if !info.LastFailedAt.IsZero() {
+		t.LastFailedAt = timePtr(info.LastFailedAt)
+	}
+	if !info.NextProcessAt.IsZero() {
+		t.NextProcessAt = timePtr(info.NextProcessAt)
+	}
+	return t
+}
+
+func stateOf(s asynq.TaskState) State {
+	if s == asynq.TaskStateArchived {
+		return StateDead
+	}
+	return State(s.String())
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,178 @@
+package queueadmin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQueues returns an inspector over tasks keyed by queue.
+func fakeQueues(tasks map[string][]*asynq.TaskInfo) *InspectorMock {
+	byState := func(state asynq.TaskState) func(string, ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+		return func(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+			out := []*asynq.TaskInfo{}
+			for _, t := range tasks[queue] {
+				if t.State == state {
+					out = append(out, t)
+				}
+			}
+			return out, nil
+		}
+	}
+	return &InspectorMock{
+		QueuesFunc: func() ([]string, error) { return []string{"webhooks", "default"}, nil },
+		GetTaskInfoFunc: func(queue, id string) (*asynq.TaskInfo, error) {
+			for _, t := range tasks[queue] {
+				if t.ID == id {
+					return t, nil
+				}
+			}
+			return nil, fmt.Errorf("asynq: %w", asynq.ErrTaskNotFound)
+		},
+		ListPendingTasksFunc:   byState(asynq.TaskStatePending),
+		ListActiveTasksFunc:    byState(asynq.TaskStateActive),
+		ListScheduledTasksFunc: byState(asynq.TaskStateScheduled),
+		ListRetryTasksFunc:     byState(asynq.TaskStateRetry),
+		ListArchivedTasksFunc:  byState(asynq.TaskStateArchived),
+		RunTaskFunc:            func(queue, id string) error { return nil },
+		DeleteTaskFunc:         func(queue, id string) error { return nil },
+	}
+}
+
+func testTasks() map[string][]*asynq.TaskInfo {
+	failedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return map[string][]*asynq.TaskInfo{
+		"default": {
+			{ID: "t1", Queue: "default", Type: "stage:run", State: asynq.TaskStateRetry,
+				Payload: []byte(`{"image_id":"img-1"}`), Retried: 2, MaxRetry: 5, LastErr: "timeout", LastFailedAt: failedAt},
+			{ID: "t2", Queue: "default", Type: "stage:run", State: asynq.TaskStateArchived, Payload: []byte("not json")},
+			{ID: "t3", Queue: "default", Type: "stage:run", State: asynq.TaskStateActive},
+		},
+		"webhooks": {
+			{ID: "w1", Queue: "webhooks", Type: "delivery:send", State: asynq.TaskStateArchived},
+		},
+	}
+}
+
+func TestDefaultService_ListTasks(t *testing.T) {
+	t.Run("success: lists a state across queues", func(t *testing.T) {
+		inspector := fakeQueues(testTasks())
+
+		got, err := NewDefaultService(inspector).ListTasks(context.Background(), ListFilter{State: StateDead})
+
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "t2", got[0].ID, "queues are listed in name order")
+		assert.Equal(t, StateDead, got[0].State)
+		assert.Nil(t, got[0].Payload, "non-JSON payloads are omitted")
+		assert.Equal(t, "w1", got[1].ID)
+		assert.Equal(t, "default", inspector.ListArchivedTasksCalls()[0].Queue)
+	})
+
+	t.Run("success: maps task details", func(t *testing.T) {
+		got, err := NewDefaultService(fakeQueues(testTasks())).
+			ListTasks(context.Background(), ListFilter{State: StateRetry, Queue: "default"})
+
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.JSONEq(t, `{"image_id":"img-1"}`, string(got[0].Payload))
+		assert.Equal(t, 2, got[0].Retried)
+		assert.Equal(t, "timeout", got[0].LastError)
+		require.NotNil(t, got[0].LastFailedAt)
+		assert.Nil(t, got[0].NextProcessAt)
+	})
+
+	t.Run("success: clamps paging", func(t *testing.T) {
+		inspector := fakeQueues(testTasks())
+
+		_, err := NewDefaultService(inspector).
+			ListTasks(context.Background(), ListFilter{State: StateActive, Queue: "default", PageSize: 1000})
+
+		require.NoError(t, err)
+		assert.Len(t, inspector.ListActiveTasksCalls()[0].Opts, 2)
+		assert.Empty(t, inspector.QueuesCalls())
+	})
+
+	t.Run("fail: unknown state", func(t *testing.T) {
+		_, err := NewDefaultService(fakeQueues(nil)).ListTasks(context.Background(), ListFilter{State: "archived"})
+
+		assert.ErrorIs(t, err, ErrInvalidState)
+	})
+
+	t.Run("fail: not configured", func(t *testing.T) {
+		_, err := NewDefaultService(nil).ListTasks(context.Background(), ListFilter{State: StateRetry})
+
+		assert.ErrorIs(t, err, ErrUnavailable)
+	})
+
+	t.Run("fail: list error", func(t *testing.T) {
+		inspector := fakeQueues(nil)
+		inspector.ListRetryTasksFunc = func(string, ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+			return nil, errors.New("redis down")
+		}
+
+		_, err := NewDefaultService(inspector).ListTasks(context.Background(), ListFilter{State: StateRetry})
+
+		assert.ErrorContains(t, err, "redis down")
+	})
+}
+
+func TestDefaultService_RequeueTask(t *testing.T) {
+	t.Run("success: finds the queue and runs the task", func(t *testing.T) {
+		inspector := fakeQueues(testTasks())
+
+		got, err := NewDefaultService(inspector).RequeueTask(context.Background(), "", "t1")
+
+		require.NoError(t, err)
+		assert.Equal(t, StatePending, got.State)
+		require.Len(t, inspector.RunTaskCalls(), 1)
+		assert.Equal(t, "default", inspector.RunTaskCalls()[0].Queue)
+	})
+
+	t.Run("fail: active task", func(t *testing.T) {
+		inspector := fakeQueues(testTasks())
+
+		_, err := NewDefaultService(inspector).RequeueTask(context.Background(), "default", "t3")
+
+		assert.ErrorIs(t, err, ErrNotRequeueable)
+		assert.Empty(t, inspector.RunTaskCalls())
+	})
+
+	t.Run("fail: unknown task", func(t *testing.T) {
+		_, err := NewDefaultService(fakeQueues(testTasks())).RequeueTask(context.Background(), "", "missing")
+
+		assert.ErrorIs(t, err, ErrTaskNotFound)
+	})
+}
+
+func TestDefaultService_DeleteTask(t *testing.T) {
+	t.Run("success: deletes a dead task", func(t *testing.T) {
+		inspector := fakeQueues(testTasks())
+
+		require.NoError(t, NewDefaultService(inspector).DeleteTask(context.Background(), "", "w1"))
+
+		require.Len(t, inspector.DeleteTaskCalls(), 1)
+		assert.Equal(t, "webhooks", inspector.DeleteTaskCalls()[0].Queue)
+	})
+
+	t.Run("fail: active task", func(t *testing.T) {
+		err := NewDefaultService(fakeQueues(testTasks())).DeleteTask(context.Background(), "", "t3")
+
+		assert.ErrorIs(t, err, ErrTaskActive)
+	})
+
+	t.Run("fail: delete error", func(t *testing.T) {
+		inspector := fakeQueues(testTasks())
+		inspector.DeleteTaskFunc = func(queue, id string) error { return errors.New("redis down") }
+
+		err := NewDefaultService(inspector).DeleteTask(context.Background(), "default", "t1")
+
+		assert.ErrorContains(t, err, "redis down")
+	})
+}
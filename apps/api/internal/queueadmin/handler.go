@@ -0,0 +1,17 @@
+package queueadmin
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin HTTP endpoints for queue inspection.
+type Handler interface {
+	// ListTasks handles GET /admin/queue/tasks - Lists tasks in a state.
+	ListTasks(c echo.Context) error
+
+	// RequeueTask handles POST /admin/queue/tasks/:id/requeue - Runs a failed or scheduled task now.
+	RequeueTask(c echo.Context) error
+
+	// DeleteTask handles DELETE /admin/queue/tasks/:id - Removes a task.
+	DeleteTask(c echo.Context) error
+}
@@ -0,0 +1,20 @@
+package queueadmin
+
+import "github.com/hibiken/asynq"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out inspector_mock.go . Inspector
+
+// Inspector is the subset of *asynq.Inspector the service uses.
+type Inspector interface {
+	Queues() ([]string, error)
+	GetTaskInfo(queue, id string) (*asynq.TaskInfo, error)
+	ListPendingTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListActiveTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListScheduledTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListRetryTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	ListArchivedTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+	RunTask(queue, id string) error
+	DeleteTask(queue, id string) error
+}
+
+var _ Inspector = (*asynq.Inspector)(nil)
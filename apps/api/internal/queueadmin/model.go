@@ -0,0 +1,69 @@
+// Package queueadmin exposes the asynq task queues to admins: listing tasks
+// by state, requeueing failed ones and deleting tasks that should not run.
+package queueadmin
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidState is returned when a list request names an unknown task state.
+	ErrInvalidState = errors.New("invalid task state")
+	// ErrTaskNotFound is returned when no queue holds a task with the given ID.
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrTaskActive is returned when deleting a task a worker is processing.
+	ErrTaskActive = errors.New("task is being processed")
+	// ErrNotRequeueable is returned when requeueing a task that is already
+	// pending or being processed.
+	ErrNotRequeueable = errors.New("task cannot be requeued")
+	// ErrUnavailable is returned when no Redis address is configured.
+	ErrUnavailable = errors.New("queue inspection is not configured")
+)
+
+// State is the lifecycle state of a task, named as in the API.
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateActive    State = "active"
+	StateScheduled State = "scheduled"
+	StateRetry     State = "retry"
+	// StateDead holds tasks that exhausted their retries (asynq's "archived").
+	StateDead State = "dead"
+)
+
+// States lists every state tasks can be listed by.
+var States = []State{StatePending, StateActive, StateScheduled, StateRetry, StateDead}
+
+const (
+	// DefaultPageSize is the number of tasks listed when no page size is given.
+	DefaultPageSize = 30
+	// MaxPageSize caps the page size of a list request.
+	MaxPageSize = 100
+)
+
+// Task is a queued task as shown to admins.
+type Task struct {
+	ID            string          `json:"id"`
+	Queue         string          `json:"queue"`
+	Type          string          `json:"type"`
+	State         State           `json:"state"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	Retried       int             `json:"retried"`
+	MaxRetry      int             `json:"max_retry"`
+	LastError     string          `json:"last_error,omitempty"`
+	LastFailedAt  *time.Time      `json:"last_failed_at,omitempty"`
+	NextProcessAt *time.Time      `json:"next_process_at,omitempty"`
+}
+
+// ListFilter selects the tasks to list.
+type ListFilter struct {
+	State State
+	// Queue limits the listing to one queue; empty lists every queue.
+	Queue string
+	// Page is 1-based.
+	Page     int
+	PageSize int
+}
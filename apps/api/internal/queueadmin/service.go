@@ -0,0 +1,20 @@
+package queueadmin
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service lists and manages queued tasks.
+type Service interface {
+	// ListTasks returns a page of tasks in one state, across queues unless
+	// the filter names one.
+	ListTasks(ctx context.Context, filter ListFilter) ([]Task, error)
+
+	// RequeueTask moves a scheduled, retry or dead task back to pending so a
+	// worker picks it up immediately. An empty queue searches every queue.
+	RequeueTask(ctx context.Context, queue, id string) (*Task, error)
+
+	// DeleteTask removes a task that is not being processed. An empty queue
+	// searches every queue.
+	DeleteTask(ctx context.Context, queue, id string) error
+}
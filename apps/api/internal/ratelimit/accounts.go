@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/billing"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+const (
+	// accountTTL is how long a caller's plan is remembered, so a plan change
+	// takes effect on the limit within a minute.
+	accountTTL = time.Minute
+	// usageTTL is how long a caller's remaining image count is remembered.
+	// Requests that may create images always recount.
+	usageTTL = 10 * time.Second
+	// maxAccounts bounds the cache; expired entries are dropped past it.
+	maxAccounts = 10000
+	// newUserPlan is the plan of callers who have no user yet.
+	newUserPlan = "free"
+)
+
+// account is a caller as the rate limit sees them.
+type account struct {
+	userID   string
+	planCode string
+}
+
+// accountCache remembers callers' user IDs, plans and remaining images
+// briefly, so limiting a request and reporting usage rarely cost a database
+// round trip.
+type accountCache struct {
+	usage    billing.UsageService
+	users    user.Repository
+	ttl      time.Duration
+	usageTTL time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedAccount
+	counts  map[string]cachedCount
+}
+
+type cachedAccount struct {
+	account
+	expires time.Time
+}
+
+type cachedCount struct {
+	remaining int32
+	expires   time.Time
+}
+
+func newAccountCache(usage billing.UsageService, users user.Repository, ttl, usageTTL time.Duration) *accountCache {
+	return &accountCache{
+		usage: usage, users: users, ttl: ttl, usageTTL: usageTTL, now: time.Now,
+		entries: map[string]cachedAccount{}, counts: map[string]cachedCount{},
+	}
+}
+
+// get returns the caller with the given Auth0 subject. Callers without a
+// user yet are on the free plan and not remembered, since their first request
+// creates the user. On error the account has no plan, so the default limit
+// applies.
+func (a *accountCache) get(ctx context.Context, auth0Sub string) (account, error) {
+	now := a.now()
+	a.mu.Lock()
+	cached, ok := a.entries[auth0Sub]
+	a.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.account, nil
+	}
+
+	u, err := a.users.GetByAuth0Sub(ctx, auth0Sub)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return account{planCode: newUserPlan}, nil
+	}
+	if err != nil {
+		return account{}, fmt.Errorf("failed to resolve user: %w", err)
+	}
+	acct := account{userID: u.ID.String()}
+	acct.planCode, err = a.usage.PlanCode(ctx, acct.userID)
+	if err != nil {
+		return account{userID: acct.userID}, fmt.Errorf("failed to resolve plan: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.entries) >= maxAccounts {
+		for sub, e := range a.entries {
+			if !now.Before(e.expires) {
+				delete(a.entries, sub)
+			}
+		}
+	}
+	a.entries[auth0Sub] = cachedAccount{account: acct, expires: now.Add(a.ttl)}
+	return acct, nil
+}
+
+// remaining returns how many images the user can still create. With recount
+// the remembered count is ignored, for requests that may have just used some.
+func (a *accountCache) remaining(ctx context.Context, userID string, recount bool) (int32, error) {
+	now := a.now()
+	if !recount {
+		a.mu.Lock()
+		cached, ok := a.counts[userID]
+		a.mu.Unlock()
+		if ok && now.Before(cached.expires) {
+			return cached.remaining, nil
+		}
+	}
+
+	remaining, err := a.usage.RemainingImages(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.counts) >= maxAccounts {
+		for id, c := range a.counts {
+			if !now.Before(c.expires) {
+				delete(a.counts, id)
+			}
+		}
+	}
+	a.counts[userID] = cachedCount{remaining: remaining, expires: now.Add(a.usageTTL)}
+	return remaining, nil
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out limiter_mock.go . Limiter
+
+// Limiter counts requests against a per-key limit.
+type Limiter interface {
+	// Take counts one request for key, allowed limit requests per minute,
+	// and returns the key's status.
+	Take(ctx context.Context, key string, limit int) (Status, error)
+}
+
+// RedisLimiter is a token bucket Limiter shared by every API instance. Each
+// key's bucket holds a minute's worth of requests, so a client may burst up to
+// its limit, and refills continuously at limit per minute.
+type RedisLimiter struct {
+	rdb *redis.Client
+	now func() time.Time
+}
+
+// Ensure RedisLimiter implements Limiter.
+var _ Limiter = (*RedisLimiter)(nil)
+
+// NewRedisLimiter creates a token bucket limiter.
+func NewRedisLimiter(rdb *redis.Client) *RedisLimiter {
+	return &RedisLimiter{rdb: rdb, now: time.Now}
+}
+
+// takeToken refills the bucket in KEYS[1] for the time since it was last
+// touched and takes a token when one is left. ARGV holds the capacity, the
+// refill rate in tokens per millisecond and the current time in milliseconds.
+// It returns whether a token was taken and the tokens left. The bucket expires
+// once it would be full again.
+var takeToken = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+if now > ts then
+  tokens = math.min(capacity, tokens + (now - ts) * rate)
+  ts = now
+end
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// Take takes a token from the key's bucket.
+func (l *RedisLimiter) Take(ctx context.Context, key string, limit int) (Status, error) {
+	now := l.now()
+	rate := float64(limit) / float64(time.Minute.Milliseconds())
+	res, err := takeToken.Run(ctx, l.rdb, []string{"ratelimit:" + key},
+		limit, strconv.FormatFloat(rate, 'g', -1, 64), now.UnixMilli()).Slice()
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to count request: %w", err)
+	}
+	if len(res) != 2 {
+		return Status{}, fmt.Errorf("failed to count request: unexpected reply %v", res)
+	}
+	allowed, _ := res[0].(int64)
+	tokensLeft, _ := res[1].(string)
+	tokens, err := strconv.ParseFloat(tokensLeft, 64)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to count request: %w", err)
+	}
+
+	untilFull := time.Duration((float64(limit) - tokens) / rate * float64(time.Millisecond))
+	status := Status{
+		Limit:     limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     now.Add(untilFull),
+	}
+	if allowed == 0 {
+		status.Remaining = -1
+		status.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Millisecond))
+	}
+	return status, nil
+}
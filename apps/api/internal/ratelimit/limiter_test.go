@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLimiter_Take(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	now := time.Date(2026, 10, 16, 12, 0, 30, 0, time.UTC)
+	l := NewRedisLimiter(rdb)
+	l.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	first, err := l.Take(ctx, "auth0|a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, first.Limit)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, now.Add(30*time.Second), first.Reset, "one token refills in half a minute")
+
+	_, err = l.Take(ctx, "auth0|a", 2)
+	require.NoError(t, err)
+	third, err := l.Take(ctx, "auth0|a", 2)
+	require.NoError(t, err)
+	assert.False(t, third.Allowed(), "the burst is spent")
+	assert.Equal(t, 30*time.Second, third.RetryAfter)
+
+	other, err := l.Take(ctx, "auth0|b", 2)
+	require.NoError(t, err)
+	assert.True(t, other.Allowed(), "keys are counted separately")
+
+	now = now.Add(30 * time.Second)
+	refilled, err := l.Take(ctx, "auth0|a", 2)
+	require.NoError(t, err)
+	assert.True(t, refilled.Allowed(), "tokens refill over time")
+	assert.Equal(t, 0, refilled.Remaining)
+
+	now = now.Add(time.Hour)
+	full, err := l.Take(ctx, "auth0|a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, full.Remaining, "the bucket never holds more than the limit")
+
+	pro, err := l.Take(ctx, "auth0|a", 300)
+	require.NoError(t, err)
+	assert.Equal(t, 300, pro.Limit)
+	assert.True(t, pro.Allowed(), "a plan upgrade raises the limit at once")
+
+	mr.Close()
+	_, err = l.Take(ctx, "auth0|a", 2)
+	assert.Error(t, err)
+}
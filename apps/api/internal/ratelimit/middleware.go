@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/billing"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// Middleware counts each authenticated request against limiter, at the rate
+// limits set for the caller's plan, and rejects it with 429 once the caller is
+// over the limit. A nil limiter, or one that fails, lets every request through
+// without rate limit headers, and so do limits' exempt paths.
+//
+// X-Usage-Remaining is computed after the handler runs, so a request that
+// creates images reports the quota left afterwards. Other requests may report
+// a count up to usageTTL old, and exempt paths don't report it.
+func Middleware(
+	limiter Limiter, limits config.RateLimit, usage billing.UsageService, users user.Repository, log logging.Logger,
+) echo.MiddlewareFunc {
+	accounts := newAccountCache(usage, users, accountTTL, usageTTL)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			auth0Sub, err := auth.GetUserIDOrDefault(c)
+			if err != nil || auth0Sub == "" {
+				return next(c)
+			}
+			ctx := c.Request().Context()
+			res := c.Response()
+			if limits.Exempt(c.Request().URL.Path) {
+				return next(c)
+			}
+
+			var userID string
+			if limiter != nil && limits.Enabled() {
+				acct, err := accounts.get(ctx, auth0Sub)
+				if err != nil {
+					log.Warn(ctx, "failed to resolve plan for rate limit", "error", err)
+				}
+				userID = acct.userID
+
+				status, err := limiter.Take(ctx, auth0Sub, limits.PerMinuteFor(acct.planCode))
+				if err != nil {
+					log.Warn(ctx, "rate limiter unavailable", "error", err)
+				} else {
+					setRateHeaders(res.Header(), status)
+					if !status.Allowed() {
+						retryAfter := int(math.Ceil(status.RetryAfter.Seconds()))
+						res.Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+						return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
+					}
+				}
+			}
+
+			recount := !isSafeMethod(c.Request().Method)
+			res.Before(func() {
+				id := userID
+				if id == "" {
+					acct, err := accounts.get(ctx, auth0Sub)
+					if acct.userID == "" {
+						if err != nil {
+							log.Warn(ctx, "failed to resolve user for usage header", "error", err)
+						}
+						return
+					}
+					id = acct.userID
+				}
+				remaining, err := accounts.remaining(ctx, id, recount)
+				if err != nil {
+					log.Warn(ctx, "failed to compute usage header", "error", err)
+					return
+				}
+				res.Header().Set(HeaderUsageRemaining, strconv.Itoa(int(max(remaining, 0))))
+			})
+
+			return next(c)
+		}
+	}
+}
+
+// isSafeMethod reports whether requests with method only read, and so can't
+// have created images.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// setRateHeaders reports the limit, the requests left and when the caller's
+// bucket is full again as a Unix timestamp.
+func setRateHeaders(h http.Header, s Status) {
+	h.Set(HeaderLimit, strconv.Itoa(s.Limit))
+	h.Set(HeaderRemaining, strconv.Itoa(max(s.Remaining, 0)))
+	h.Set(HeaderReset, strconv.FormatInt(int64(math.Ceil(float64(s.Reset.UnixMilli())/1000)), 10))
+}
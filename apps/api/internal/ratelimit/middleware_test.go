@@ -0,0 +1,272 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/billing"
+	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestMiddleware(t *testing.T) {
+	userID := uuid.New()
+	reset := time.Now().Add(30 * time.Second).Truncate(time.Second)
+
+	limits := config.RateLimit{
+		RequestsPerMinute: 120, FreePerMinute: 60, ProPerMinute: 300, ExemptPaths: []string{"/api/v1/admin/"},
+	}
+	takeAt := func(want int) *LimiterMock {
+		return &LimiterMock{TakeFunc: func(ctx context.Context, key string, limit int) (Status, error) {
+			assert.Equal(t, "auth0|integrator", key)
+			assert.Equal(t, want, limit)
+			return Status{Limit: limit, Remaining: limit - 1, Reset: reset}, nil
+		}}
+	}
+
+	cases := []struct {
+		name          string
+		limiter       Limiter
+		path          string
+		plan          string
+		planErr       error
+		userErr       error
+		wantStatus    int
+		wantHeaders   map[string]string
+		wantNoHeaders []string
+	}{
+		{
+			name: "success: reports rate limit and usage",
+			limiter: &LimiterMock{TakeFunc: func(ctx context.Context, key string, limit int) (Status, error) {
+				return Status{Limit: 120, Remaining: 119, Reset: reset}, nil
+			}},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				HeaderLimit:          "120",
+				HeaderRemaining:      "119",
+				HeaderUsageRemaining: "42",
+			},
+		},
+		{
+			name: "fail: over the limit",
+			limiter: &LimiterMock{TakeFunc: func(ctx context.Context, key string, limit int) (Status, error) {
+				return Status{Limit: 120, Remaining: -1, Reset: reset, RetryAfter: 2500 * time.Millisecond}, nil
+			}},
+			wantStatus:    http.StatusTooManyRequests,
+			wantHeaders:   map[string]string{HeaderRemaining: "0", "Retry-After": "3"},
+			wantNoHeaders: []string{HeaderUsageRemaining},
+		},
+		{
+			name:        "success: paid plan gets its own limit",
+			limiter:     takeAt(300),
+			plan:        "pro",
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{HeaderLimit: "300", HeaderRemaining: "299"},
+		},
+		{
+			name:        "success: plan lookup failure falls back to the default limit",
+			limiter:     takeAt(120),
+			planErr:     errors.New("db down"),
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{HeaderLimit: "120"},
+		},
+		{
+			name:        "success: new user is on the free plan",
+			limiter:     takeAt(60),
+			userErr:     pgx.ErrNoRows,
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{HeaderLimit: "60"},
+		},
+		{
+			name:          "success: internal routes are not limited",
+			limiter:       &LimiterMock{},
+			path:          "/api/v1/admin/models",
+			wantStatus:    http.StatusOK,
+			wantNoHeaders: []string{HeaderLimit, HeaderUsageRemaining},
+		},
+		{
+			name: "success: limiter failure lets the request through",
+			limiter: &LimiterMock{TakeFunc: func(ctx context.Context, key string, limit int) (Status, error) {
+				return Status{}, errors.New("redis down")
+			}},
+			wantStatus:    http.StatusOK,
+			wantHeaders:   map[string]string{HeaderUsageRemaining: "42"},
+			wantNoHeaders: []string{HeaderLimit},
+		},
+		{
+			name:          "success: no limiter configured",
+			wantStatus:    http.StatusOK,
+			wantHeaders:   map[string]string{HeaderUsageRemaining: "42"},
+			wantNoHeaders: []string{HeaderLimit},
+		},
+		{
+			name:          "success: unknown user has no usage header",
+			userErr:       pgx.ErrNoRows,
+			wantStatus:    http.StatusOK,
+			wantNoHeaders: []string{HeaderUsageRemaining},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			usage := &billing.UsageServiceMock{
+				RemainingImagesFunc: func(ctx context.Context, id string) (int32, error) {
+					assert.Equal(t, userID.String(), id)
+					return 42, nil
+				},
+				PlanCodeFunc: func(ctx context.Context, id string) (string, error) {
+					return tc.plan, tc.planErr
+				},
+			}
+			users := &user.RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+					if tc.userErr != nil {
+						return nil, tc.userErr
+					}
+					return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+				},
+			}
+
+			e := echo.New()
+			e.Use(Middleware(tc.limiter, limits, usage, users, logging.Default()))
+			e.GET("/api/v1/projects", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+			e.GET("/api/v1/admin/models", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+			path := tc.path
+			if path == "" {
+				path = "/api/v1/projects"
+			}
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("X-Test-User", "auth0|integrator")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantStatus, rec.Code)
+			for k, v := range tc.wantHeaders {
+				assert.Equal(t, v, rec.Header().Get(k), k)
+			}
+			for _, k := range tc.wantNoHeaders {
+				assert.Empty(t, rec.Header().Get(k), k)
+			}
+			if tc.wantStatus == http.StatusTooManyRequests {
+				assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestMiddleware_usageCache(t *testing.T) {
+	userID := uuid.New()
+	remaining := int32(42)
+	usage := &billing.UsageServiceMock{
+		RemainingImagesFunc: func(ctx context.Context, id string) (int32, error) { return remaining, nil },
+		PlanCodeFunc:        func(ctx context.Context, id string) (string, error) { return "free", nil },
+	}
+	users := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+
+	e := echo.New()
+	e.Use(Middleware(nil, config.RateLimit{}, usage, users, logging.Default()))
+	e.GET("/api/v1/projects", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.POST("/api/v1/images", func(c echo.Context) error {
+		remaining--
+		return c.NoContent(http.StatusCreated)
+	})
+
+	serve := func(method, path string) string {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Test-User", "auth0|integrator")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Header().Get(HeaderUsageRemaining)
+	}
+
+	assert.Equal(t, "42", serve(http.MethodGet, "/api/v1/projects"))
+	assert.Equal(t, "42", serve(http.MethodGet, "/api/v1/projects"))
+	assert.Len(t, users.GetByAuth0SubCalls(), 1, "the user is remembered")
+	assert.Len(t, usage.RemainingImagesCalls(), 1, "the count is remembered")
+
+	assert.Equal(t, "41", serve(http.MethodPost, "/api/v1/images"), "creating images recounts")
+	assert.Equal(t, "41", serve(http.MethodGet, "/api/v1/projects"))
+	assert.Len(t, usage.RemainingImagesCalls(), 2)
+}
+
+func TestAccountCache(t *testing.T) {
+	userID := uuid.New()
+	planCalls := 0
+	usage := &billing.UsageServiceMock{
+		PlanCodeFunc: func(ctx context.Context, id string) (string, error) {
+			planCalls++
+			return "pro", nil
+		},
+	}
+	users := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	now := time.Now()
+	cache := newAccountCache(usage, users, time.Minute, 10*time.Second)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	acct, err := cache.get(ctx, "auth0|a")
+	require.NoError(t, err)
+	assert.Equal(t, account{userID: userID.String(), planCode: "pro"}, acct)
+
+	_, err = cache.get(ctx, "auth0|a")
+	require.NoError(t, err)
+	assert.Equal(t, 1, planCalls, "the plan is remembered")
+
+	now = now.Add(time.Minute)
+	_, err = cache.get(ctx, "auth0|a")
+	require.NoError(t, err)
+	assert.Equal(t, 2, planCalls, "a plan change is picked up once the entry expires")
+}
+
+func TestAccountCache_remaining(t *testing.T) {
+	counts := 0
+	usage := &billing.UsageServiceMock{
+		RemainingImagesFunc: func(ctx context.Context, id string) (int32, error) {
+			counts++
+			return int32(10 - counts), nil
+		},
+	}
+	now := time.Now()
+	cache := newAccountCache(usage, &user.RepositoryMock{}, time.Minute, 10*time.Second)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	remaining, err := cache.remaining(ctx, "user-1", false)
+	require.NoError(t, err)
+	assert.Equal(t, int32(9), remaining)
+
+	remaining, err = cache.remaining(ctx, "user-1", false)
+	require.NoError(t, err)
+	assert.Equal(t, int32(9), remaining, "the count is remembered")
+
+	remaining, err = cache.remaining(ctx, "user-1", true)
+	require.NoError(t, err)
+	assert.Equal(t, int32(8), remaining, "a recount skips the cache")
+
+	now = now.Add(10 * time.Second)
+	remaining, err = cache.remaining(ctx, "user-1", false)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), remaining, "usage is counted again once the entry expires")
+}
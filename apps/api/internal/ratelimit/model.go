@@ -0,0 +1,31 @@
+// Package ratelimit limits authenticated requests per user, at a rate that
+// depends on their plan, and reports the caller's rate limit and remaining
+// image quota in response headers, so API integrators can throttle themselves
+// without polling the usage endpoint.
+package ratelimit
+
+import "time"
+
+// Response headers set on authenticated requests.
+const (
+	HeaderLimit          = "X-RateLimit-Limit"
+	HeaderRemaining      = "X-RateLimit-Remaining"
+	HeaderReset          = "X-RateLimit-Reset"
+	HeaderUsageRemaining = "X-Usage-Remaining"
+)
+
+// Status is a caller's standing after counting their latest request.
+type Status struct {
+	Limit     int
+	Remaining int // negative once the limit is exceeded
+	// Reset is when the caller's bucket is full again.
+	Reset time.Time
+	// RetryAfter is how long until the next request is allowed, once the
+	// limit is exceeded.
+	RetryAfter time.Duration
+}
+
+// Allowed reports whether the latest request fits within the limit.
+func (s Status) Allowed() bool {
+	return s.Remaining >= 0
+}
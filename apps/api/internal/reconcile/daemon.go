@@ -0,0 +1,195 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DaemonConfig schedules recurring reconciliation.
+type DaemonConfig struct {
+	// Interval is the time between the end of one run and the start of the next.
+	Interval time.Duration
+	// Jitter adds a random delay of up to this much before each run, so
+	// replicas started together don't hit S3 and Postgres in lockstep.
+	Jitter time.Duration
+	// StuckAfter is the queued age at which images are deleted; zero skips
+	// the cleanup, as does Options.DryRun.
+	StuckAfter time.Duration
+	// Processing recovers images stuck in processing; a zero Timeout skips
+	// the recovery, as does Options.DryRun.
+	Processing ProcessingOptions
+	// RunTimeout bounds a single run.
+	RunTimeout time.Duration
+	// ShutdownGrace is how long an in-flight run may keep going after
+	// shutdown is requested before it is cancelled.
+	ShutdownGrace time.Duration
+	// Options scopes each reconciliation pass. Cursor and Limit are ignored:
+	// every run walks all matching images.
+	Options Options
+}
+
+// Daemon runs ReconcileImages, CleanupStuckQueuedImages and
+// RecoverStuckProcessingImages on a schedule.
+type Daemon struct {
+	svc    Service
+	cfg    DaemonConfig
+	jitter func(limit time.Duration) time.Duration
+
+	runs     metric.Int64Counter
+	checked  metric.Int64Counter
+	missing  metric.Int64Counter
+	updated  metric.Int64Counter
+	stuck    metric.Int64Counter
+	recover  metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// NewDaemon validates cfg and creates a Daemon with its metrics.
+func NewDaemon(svc Service, cfg DaemonConfig) (*Daemon, error) {
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("%w: interval must be positive", ErrInvalidOptions)
+	}
+	if cfg.Jitter < 0 || cfg.StuckAfter < 0 || cfg.RunTimeout < 0 || cfg.ShutdownGrace < 0 ||
+		cfg.Processing.Timeout < 0 {
+		return nil, fmt.Errorf("%w: durations must not be negative", ErrInvalidOptions)
+	}
+	cfg.Options.Cursor = ""
+	cfg.Options.Limit = 0
+
+	d := &Daemon{svc: svc, cfg: cfg, jitter: randomJitter}
+	meter := otel.Meter("real-staging-api/reconcile")
+	var err error
+	if d.runs, err = meter.Int64Counter("reconcile.runs",
+		metric.WithDescription("Scheduled reconcile runs, by outcome")); err != nil {
+		return nil, fmt.Errorf("create runs counter: %w", err)
+	}
+	if d.checked, err = meter.Int64Counter("reconcile.images.checked",
+		metric.WithDescription("Images whose files were checked")); err != nil {
+		return nil, fmt.Errorf("create checked counter: %w", err)
+	}
+	if d.missing, err = meter.Int64Counter("reconcile.images.missing",
+		metric.WithDescription("Images found with a missing original or staged file")); err != nil {
+		return nil, fmt.Errorf("create missing counter: %w", err)
+	}
+	if d.updated, err = meter.Int64Counter("reconcile.images.updated",
+		metric.WithDescription("Images marked as error because of missing files")); err != nil {
+		return nil, fmt.Errorf("create updated counter: %w", err)
+	}
+	if d.stuck, err = meter.Int64Counter("reconcile.stuck_queued.deleted",
+		metric.WithDescription("Stuck queued images deleted")); err != nil {
+		return nil, fmt.Errorf("create stuck counter: %w", err)
+	}
+	if d.recover, err = meter.Int64Counter("reconcile.stuck_processing.recovered",
+		metric.WithDescription("Images stuck in processing, by outcome")); err != nil {
+		return nil, fmt.Errorf("create recover counter: %w", err)
+	}
+	if d.duration, err = meter.Float64Histogram("reconcile.run.duration",
+		metric.WithDescription("Duration of a scheduled reconcile run"), metric.WithUnit("s")); err != nil {
+		return nil, fmt.Errorf("create duration histogram: %w", err)
+	}
+	return d, nil
+}
+
+// Run waits a random jitter, then runs every Interval (plus jitter) until ctx
+// is cancelled. A run in progress when ctx is cancelled gets ShutdownGrace to
+// finish; Run returns once it has.
+func (d *Daemon) Run(ctx context.Context) {
+	log := logging.NewDefaultLogger()
+	log.Info(ctx, "reconcile daemon started",
+		"interval", d.cfg.Interval.String(), "jitter", d.cfg.Jitter.String(), "dry_run", d.cfg.Options.DryRun)
+
+	timer := time.NewTimer(d.jitter(d.cfg.Jitter))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info(ctx, "reconcile daemon stopped")
+			return
+		case <-timer.C:
+			if err := d.RunOnce(ctx); err != nil {
+				log.Error(ctx, "reconcile run failed", "error", err)
+			}
+			timer.Reset(d.cfg.Interval + d.jitter(d.cfg.Jitter))
+		}
+	}
+}
+
+// RunOnce runs the stuck-image cleanup and recovery, when enabled, then a
+// reconciliation pass, and records metrics for each. It keeps going for ShutdownGrace after
+// ctx is cancelled so a shutdown doesn't abandon the run halfway.
+func (d *Daemon) RunOnce(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stop := context.AfterFunc(ctx, func() {
+		select {
+		case <-time.After(d.cfg.ShutdownGrace):
+			cancel()
+		case <-runCtx.Done():
+		}
+	})
+	defer stop()
+	if d.cfg.RunTimeout > 0 {
+		var cancelTimeout context.CancelFunc
+		runCtx, cancelTimeout = context.WithTimeout(runCtx, d.cfg.RunTimeout)
+		defer cancelTimeout()
+	}
+
+	start := time.Now()
+	err := d.run(runCtx)
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	d.runs.Add(runCtx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+	d.duration.Record(runCtx, time.Since(start).Seconds())
+	return err
+}
+
+func (d *Daemon) run(ctx context.Context) error {
+	if d.cfg.StuckAfter > 0 && !d.cfg.Options.DryRun {
+		n, err := d.svc.CleanupStuckQueuedImages(ctx, d.cfg.StuckAfter)
+		d.stuck.Add(ctx, int64(n))
+		if err != nil {
+			return fmt.Errorf("cleanup stuck queued images: %w", err)
+		}
+	}
+	if d.cfg.Processing.Timeout > 0 && !d.cfg.Options.DryRun {
+		recovered, err := d.svc.RecoverStuckProcessingImages(ctx, d.cfg.Processing)
+		if err != nil {
+			return fmt.Errorf("recover stuck processing images: %w", err)
+		}
+		for outcome, n := range map[string]int{
+			"requeued": recovered.Requeued, "timed_out": recovered.TimedOut, "failed": recovered.Failed,
+		} {
+			d.recover.Add(ctx, int64(n), metric.WithAttributes(attribute.String("outcome", outcome)))
+		}
+	}
+
+	result, err := d.svc.ReconcileImages(ctx, d.cfg.Options)
+	if err != nil {
+		return fmt.Errorf("reconcile images: %w", err)
+	}
+	dryRun := attribute.Bool("dry_run", result.DryRun)
+	d.checked.Add(ctx, int64(result.Checked), metric.WithAttributes(dryRun))
+	d.missing.Add(ctx, int64(result.MissingOriginal),
+		metric.WithAttributes(attribute.String("file", "original"), dryRun))
+	d.missing.Add(ctx, int64(result.MissingStaged),
+		metric.WithAttributes(attribute.String("file", "staged"), dryRun))
+	d.updated.Add(ctx, int64(result.Updated))
+	return nil
+}
+
+func randomJitter(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit)
+}
@@ -0,0 +1,184 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDaemon(t *testing.T, svc Service, cfg DaemonConfig) *Daemon {
+	t.Helper()
+	d, err := NewDaemon(svc, cfg)
+	require.NoError(t, err)
+	d.jitter = func(time.Duration) time.Duration { return 0 }
+	return d
+}
+
+func TestNewDaemon(t *testing.T) {
+	t.Run("success: ignores cursor and limit", func(t *testing.T) {
+		d, err := NewDaemon(&ServiceMock{}, DaemonConfig{
+			Interval: time.Hour,
+			Options:  Options{Cursor: "abc", Limit: 10, BatchSize: 50},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, Options{BatchSize: 50}, d.cfg.Options)
+	})
+
+	t.Run("fail: zero interval", func(t *testing.T) {
+		_, err := NewDaemon(&ServiceMock{}, DaemonConfig{})
+
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+
+	t.Run("fail: negative jitter", func(t *testing.T) {
+		_, err := NewDaemon(&ServiceMock{}, DaemonConfig{Interval: time.Hour, Jitter: -time.Second})
+
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
+
+func TestDaemon_RunOnce(t *testing.T) {
+	t.Run("success: cleans up stuck images before reconciling", func(t *testing.T) {
+		var mu sync.Mutex
+		var order []string
+		svc := &ServiceMock{
+			CleanupStuckQueuedImagesFunc: func(ctx context.Context, olderThan time.Duration) (int, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, "cleanup")
+				return 3, nil
+			},
+			ReconcileImagesFunc: func(ctx context.Context, opts Options) (*Result, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, "reconcile")
+				return &Result{Checked: 10, MissingOriginal: 1, Updated: 1}, nil
+			},
+		}
+		d := newTestDaemon(t, svc, DaemonConfig{
+			Interval: time.Hour, StuckAfter: 24 * time.Hour, Options: Options{BatchSize: 25},
+		})
+
+		require.NoError(t, d.RunOnce(context.Background()))
+
+		assert.Equal(t, []string{"cleanup", "reconcile"}, order)
+		assert.Equal(t, 24*time.Hour, svc.CleanupStuckQueuedImagesCalls()[0].OlderThan)
+		assert.Equal(t, 25, svc.ReconcileImagesCalls()[0].Opts.BatchSize)
+	})
+
+	t.Run("success: dry run skips the cleanup", func(t *testing.T) {
+		svc := &ServiceMock{
+			ReconcileImagesFunc: func(ctx context.Context, opts Options) (*Result, error) {
+				return &Result{DryRun: true}, nil
+			},
+		}
+		d := newTestDaemon(t, svc, DaemonConfig{
+			Interval: time.Hour, StuckAfter: time.Hour, Options: Options{DryRun: true},
+		})
+
+		require.NoError(t, d.RunOnce(context.Background()))
+
+		assert.Empty(t, svc.CleanupStuckQueuedImagesCalls())
+		assert.Len(t, svc.ReconcileImagesCalls(), 1)
+	})
+
+	t.Run("success: recovers stuck processing images before reconciling", func(t *testing.T) {
+		recovered := false
+		svc := &ServiceMock{
+			RecoverStuckProcessingImagesFunc: func(
+				ctx context.Context, opts ProcessingOptions,
+			) (*ProcessingResult, error) {
+				recovered = true
+				return &ProcessingResult{Stuck: 2, Requeued: 1, TimedOut: 1}, nil
+			},
+			ReconcileImagesFunc: func(ctx context.Context, opts Options) (*Result, error) {
+				assert.True(t, recovered)
+				return &Result{}, nil
+			},
+		}
+		d := newTestDaemon(t, svc, DaemonConfig{
+			Interval: time.Hour, Processing: ProcessingOptions{Timeout: 30 * time.Minute, MaxRetries: 1},
+		})
+
+		require.NoError(t, d.RunOnce(context.Background()))
+
+		assert.Equal(t, 30*time.Minute, svc.RecoverStuckProcessingImagesCalls()[0].Opts.Timeout)
+	})
+
+	t.Run("success: in-flight run survives shutdown within the grace period", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		svc := &ServiceMock{
+			ReconcileImagesFunc: func(runCtx context.Context, opts Options) (*Result, error) {
+				cancel()
+				<-ctx.Done()
+				time.Sleep(10 * time.Millisecond)
+				return &Result{}, runCtx.Err()
+			},
+		}
+		d := newTestDaemon(t, svc, DaemonConfig{Interval: time.Hour, ShutdownGrace: time.Minute})
+
+		assert.NoError(t, d.RunOnce(ctx))
+	})
+
+	t.Run("fail: run is cancelled once the grace period ends", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		svc := &ServiceMock{
+			ReconcileImagesFunc: func(runCtx context.Context, opts Options) (*Result, error) {
+				cancel()
+				<-runCtx.Done()
+				return nil, runCtx.Err()
+			},
+		}
+		d := newTestDaemon(t, svc, DaemonConfig{Interval: time.Hour, ShutdownGrace: time.Millisecond})
+
+		assert.ErrorIs(t, d.RunOnce(ctx), context.Canceled)
+	})
+
+	t.Run("fail: cleanup error stops the run", func(t *testing.T) {
+		svc := &ServiceMock{
+			CleanupStuckQueuedImagesFunc: func(ctx context.Context, olderThan time.Duration) (int, error) {
+				return 0, errors.New("db down")
+			},
+		}
+		d := newTestDaemon(t, svc, DaemonConfig{Interval: time.Hour, StuckAfter: time.Hour})
+
+		assert.ErrorContains(t, d.RunOnce(context.Background()), "db down")
+		assert.Empty(t, svc.ReconcileImagesCalls())
+	})
+}
+
+func TestDaemon_Run(t *testing.T) {
+	t.Run("success: runs until the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		runs := 0
+		svc := &ServiceMock{
+			ReconcileImagesFunc: func(ctx context.Context, opts Options) (*Result, error) {
+				runs++
+				if runs == 2 {
+					cancel()
+				}
+				return &Result{}, nil
+			},
+		}
+		d := newTestDaemon(t, svc, DaemonConfig{Interval: time.Millisecond})
+
+		done := make(chan struct{})
+		go func() {
+			d.Run(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("daemon did not stop")
+		}
+		assert.Equal(t, 2, runs)
+	})
+}
@@ -0,0 +1,280 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/real-staging-ai/api/internal/imageevent"
+	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// ListImages returns live images matching filter, ordered by ID.
+func (r *DefaultRepository) ListImages(ctx context.Context, filter Filter) ([]Image, error) {
+	params := queries.ListImagesForReconcileParams{
+		Column2: filter.Status,
+		Limit:   int32(filter.Limit),
+	}
+	if filter.ProjectID != "" {
+		id, err := uuid.Parse(filter.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project ID: %w", err)
+		}
+		params.Column1 = pgtype.UUID{Bytes: id, Valid: true}
+	}
+	if filter.AfterID != "" {
+		id, err := uuid.Parse(filter.AfterID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		params.Column3 = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	rows, err := queries.New(r.db).ListImagesForReconcile(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	images := make([]Image, len(rows))
+	for i, row := range rows {
+		images[i] = Image{
+			ID:          uuid.UUID(row.ID.Bytes).String(),
+			Status:      string(row.Status),
+			OriginalURL: row.OriginalUrl.String,
+			StagedURL:   row.StagedUrl.String,
+		}
+	}
+	return images, nil
+}
+
+// MarkError moves the image to "error" and records the change in its history
+// in the same statement.
+func (r *DefaultRepository) MarkError(ctx context.Context, imageID, msg string) error {
+	query := `
+		WITH updated AS (
+			UPDATE images
+			SET status = 'error', error = $2, updated_at = now()
+			WHERE id = $1 AND deleted_at IS NULL AND status NOT IN ('error', 'rejected')
+			RETURNING id, status, prompt, model_used, cost_usd, processing_time_ms, error
+		)
+		INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, processing_time_ms, error)
+		SELECT id, status, $3, prompt, model_used, cost_usd, processing_time_ms, error FROM updated`
+
+	if _, err := r.db.Exec(ctx, query, imageID, msg, string(imageevent.SourceReconcile)); err != nil {
+		return fmt.Errorf("failed to mark image as error: %w", err)
+	}
+	return nil
+}
+
+// DeleteStuckQueued hard-deletes the oldest images still queued since before
+// cutoff and returns the originals they referenced.
+func (r *DefaultRepository) DeleteStuckQueued(
+	ctx context.Context, cutoff time.Time, limit int,
+) ([]StuckImage, error) {
+	query := `
+		WITH stuck AS (
+			SELECT i.id
+			FROM images i
+			JOIN projects p ON p.id = i.project_id
+			WHERE i.status = 'queued' AND i.created_at < $1 AND NOT p.locked
+			ORDER BY i.created_at
+			LIMIT $2
+			FOR UPDATE OF i SKIP LOCKED
+		)
+		DELETE FROM images
+		WHERE id IN (SELECT id FROM stuck)
+		RETURNING id, original_image_id`
+
+	rows, err := r.db.Query(ctx, query, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete stuck queued images: %w", err)
+	}
+	defer rows.Close()
+
+	deleted := []StuckImage{}
+	for rows.Next() {
+		var id, originalID pgtype.UUID
+		if err := rows.Scan(&id, &originalID); err != nil {
+			return nil, fmt.Errorf("failed to scan stuck image: %w", err)
+		}
+		img := StuckImage{ID: uuid.UUID(id.Bytes).String()}
+		if originalID.Valid {
+			img.OriginalImageID = uuid.UUID(originalID.Bytes).String()
+		}
+		deleted = append(deleted, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to delete stuck queued images: %w", err)
+	}
+	return deleted, nil
+}
+
+// ListStuckProcessing returns images in processing since before cutoff with
+// the model of their latest processing event, the recoveries since their
+// latest API event and their latest staging job.
+func (r *DefaultRepository) ListStuckProcessing(
+	ctx context.Context, cutoff time.Time, afterID string, limit int,
+) ([]ProcessingImage, error) {
+	var after pgtype.UUID
+	if afterID != "" {
+		id, err := uuid.Parse(afterID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		after = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	query := `
+		SELECT i.id, i.updated_at,
+			COALESCE((
+				SELECT e.model_used FROM image_events e
+				WHERE e.image_id = i.id AND e.status = 'processing'
+				ORDER BY e.created_at DESC LIMIT 1
+			), ''),
+			(
+				SELECT count(*) FROM image_events e
+				WHERE e.image_id = i.id AND e.source = $4 AND e.status = 'queued'
+				  AND e.created_at > COALESCE((
+					SELECT max(a.created_at) FROM image_events a WHERE a.image_id = i.id AND a.source = 'api'
+				  ), '-infinity')
+			),
+			COALESCE(j.type, ''), j.payload_json
+		FROM images i
+		LEFT JOIN LATERAL (
+			SELECT type, payload_json FROM jobs
+			WHERE image_id = i.id AND type IN ('stage:run', 'declutter:run')
+			ORDER BY created_at DESC LIMIT 1
+		) j ON true
+		WHERE i.status = 'processing' AND i.deleted_at IS NULL AND i.updated_at < $1
+		  AND ($2::uuid IS NULL OR i.id > $2)
+		ORDER BY i.id
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, query, cutoff, after, limit, string(imageevent.SourceReconcile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stuck processing images: %w", err)
+	}
+	defer rows.Close()
+
+	images := []ProcessingImage{}
+	for rows.Next() {
+		var id pgtype.UUID
+		var img ProcessingImage
+		if err := rows.Scan(&id, &img.Since, &img.Model, &img.Retries, &img.JobType, &img.Payload); err != nil {
+			return nil, fmt.Errorf("failed to scan stuck processing image: %w", err)
+		}
+		img.ID = uuid.UUID(id.Bytes).String()
+		images = append(images, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stuck processing images: %w", err)
+	}
+	return images, nil
+}
+
+// RequeueProcessing requeues the image, records the event and creates its
+// job in one statement, so a job only exists if the image was requeued.
+func (r *DefaultRepository) RequeueProcessing(ctx context.Context, img ProcessingImage) (string, error) {
+	query := `
+		WITH requeued AS (
+			UPDATE images
+			SET status = 'queued', updated_at = now()
+			WHERE id = $1 AND status = 'processing' AND updated_at = $2 AND deleted_at IS NULL
+			RETURNING id, status, prompt
+		), event AS (
+			INSERT INTO image_events (image_id, status, source, prompt)
+			SELECT id, status, $3, prompt FROM requeued
+		)
+		INSERT INTO jobs (image_id, type, payload_json)
+		SELECT id, $4, $5 FROM requeued
+		RETURNING id`
+
+	var jobID pgtype.UUID
+	err := r.db.QueryRow(ctx, query, img.ID, img.Since, string(imageevent.SourceReconcile), img.JobType, img.Payload).
+		Scan(&jobID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrChanged
+		}
+		return "", fmt.Errorf("failed to requeue image: %w", err)
+	}
+	return uuid.UUID(jobID.Bytes).String(), nil
+}
+
+// FailProcessing marks the image as an error, keeping the model of its
+// latest processing event on the event it records.
+func (r *DefaultRepository) FailProcessing(ctx context.Context, img ProcessingImage, msg string) error {
+	query := `
+		WITH updated AS (
+			UPDATE images
+			SET status = 'error', error = $3, updated_at = now()
+			WHERE id = $1 AND status = 'processing' AND updated_at = $2 AND deleted_at IS NULL
+			RETURNING id, status, prompt, cost_usd, error
+		)
+		INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, error)
+		SELECT id, status, $4, prompt, NULLIF($5::text, ''), cost_usd, error FROM updated`
+
+	tag, err := r.db.Exec(ctx, query, img.ID, img.Since, msg, string(imageevent.SourceReconcile), img.Model)
+	if err != nil {
+		return fmt.Errorf("failed to mark image as error: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrChanged
+	}
+	return nil
+}
+
+// ReferencedObjects collects object references from images (originals, staged
+// results and masks), shared originals, image assets and profile photos.
+func (r *DefaultRepository) ReferencedObjects(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT original_url FROM images WHERE original_url IS NOT NULL
+		UNION ALL
+		SELECT staged_url FROM images WHERE staged_url IS NOT NULL
+		UNION ALL
+		SELECT mask_url FROM images WHERE mask_url IS NOT NULL
+		UNION ALL
+		SELECT s3_key FROM original_images
+		UNION ALL
+		SELECT url FROM image_assets
+		UNION ALL
+		SELECT profile_photo_url FROM users WHERE profile_photo_url IS NOT NULL`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referenced objects: %w", err)
+	}
+	defer rows.Close()
+
+	refs := []string{}
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			return nil, fmt.Errorf("failed to scan referenced object: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list referenced objects: %w", err)
+	}
+	return refs, nil
+}
@@ -0,0 +1,448 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+const (
+	// stuckBatch is the number of stuck images deleted per statement.
+	stuckBatch = 100
+	// processingBatch is the number of processing images listed per query.
+	processingBatch = 100
+)
+
+var validStatuses = map[string]bool{
+	"queued": true, "processing": true, "ready": true, "error": true, "rejected": true, "canceled": true,
+}
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo      Repository
+	files     storage.S3Service
+	bucket    string
+	originals OriginalReleaser
+	enqueuer  queue.Enqueuer
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. bucket is used to resolve
+// object keys from image URLs. Stuck processing images are requeued on
+// enqueuer; when it is nil they are marked as errors instead.
+func NewDefaultService(
+	repo Repository, files storage.S3Service, bucket string, originals OriginalReleaser, enqueuer queue.Enqueuer,
+) *DefaultService {
+	return &DefaultService{repo: repo, files: files, bucket: bucket, originals: originals, enqueuer: enqueuer}
+}
+
+// ReconcileImages walks matching images in ID order, BatchSize at a time, and
+// checks their files with up to Concurrency requests in flight. Files that
+// cannot be checked are counted as failed and the image is left alone.
+func (s *DefaultService) ReconcileImages(ctx context.Context, opts Options) (*Result, error) {
+	ctx, span := otel.Tracer("real-staging-api/reconcile").Start(ctx, "reconcile.images")
+	defer span.End()
+	span.SetAttributes(
+		attribute.Bool("dry_run", opts.DryRun),
+		attribute.Int("limit", opts.Limit),
+		attribute.String("project_id", opts.ProjectID),
+		attribute.String("status", opts.Status),
+	)
+
+	if opts.Status != "" && !validStatuses[opts.Status] {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidOptions, opts.Status)
+	}
+	if opts.Limit < 0 || opts.BatchSize < 0 || opts.Concurrency < 0 {
+		return nil, fmt.Errorf("%w: limit, batch size and concurrency must not be negative", ErrInvalidOptions)
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+
+	result := &Result{DryRun: opts.DryRun}
+	cursor := opts.Cursor
+	seen := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		size := opts.BatchSize
+		if opts.Limit > 0 {
+			if seen >= opts.Limit {
+				result.NextCursor = cursor
+				break
+			}
+			size = min(size, opts.Limit-seen)
+		}
+
+		images, err := s.repo.ListImages(ctx, Filter{
+			ProjectID: opts.ProjectID, Status: opts.Status, AfterID: cursor, Limit: size,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.checkBatch(ctx, images, opts, result)
+		seen += len(images)
+		if len(images) < size {
+			break
+		}
+		cursor = images[len(images)-1].ID
+	}
+
+	logging.NewDefaultLogger().Info(ctx, "reconcile: completed",
+		"checked", result.Checked,
+		"missing_original", result.MissingOriginal,
+		"missing_staged", result.MissingStaged,
+		"updated", result.Updated,
+		"failed", result.Failed,
+		"dry_run", result.DryRun,
+	)
+	return result, nil
+}
+
+// checkBatch checks every image in the batch and folds the outcome into result.
+func (s *DefaultService) checkBatch(ctx context.Context, images []Image, opts Options, result *Result) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, opts.Concurrency)
+
+	for _, img := range images {
+		// Rejected images had their original quarantined, so it is missing on purpose.
+		if img.Status == "error" || img.Status == "rejected" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(img Image) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.checkImage(ctx, img, opts.DryRun, &mu, result)
+		}(img)
+	}
+	wg.Wait()
+}
+
+func (s *DefaultService) checkImage(ctx context.Context, img Image, dryRun bool, mu *sync.Mutex, result *Result) {
+	log := logging.NewDefaultLogger()
+
+	msg, err := s.missingFile(ctx, img)
+
+	mu.Lock()
+	result.Checked++
+	switch {
+	case err != nil:
+		result.Failed++
+	case msg == ErrMsgOriginalMissing:
+		result.MissingOriginal++
+	case msg == ErrMsgStagedMissing:
+		result.MissingStaged++
+	}
+	if msg != "" && len(result.Examples) < maxExamples {
+		result.Examples = append(result.Examples, Example{ImageID: img.ID, Status: img.Status, Error: msg})
+	}
+	mu.Unlock()
+
+	if err != nil {
+		log.Warn(ctx, "reconcile: failed to check image files", "image_id", img.ID, "error", err)
+		return
+	}
+	if msg == "" || dryRun {
+		return
+	}
+
+	err = s.repo.MarkError(ctx, img.ID, msg)
+	mu.Lock()
+	defer mu.Unlock()
+	if err != nil {
+		log.Error(ctx, "reconcile: failed to mark image as error", "image_id", img.ID, "error", err)
+		result.Failed++
+		return
+	}
+	result.Updated++
+}
+
+// missingFile returns the error message for the first of the image's files
+// that is missing from storage, or "" when they are all present.
+func (s *DefaultService) missingFile(ctx context.Context, img Image) (string, error) {
+	if img.OriginalURL != "" {
+		ok, err := s.exists(ctx, img.OriginalURL)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return ErrMsgOriginalMissing, nil
+		}
+	}
+	if img.Status == "ready" && img.StagedURL != "" {
+		ok, err := s.exists(ctx, img.StagedURL)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return ErrMsgStagedMissing, nil
+		}
+	}
+	return "", nil
+}
+
+func (s *DefaultService) exists(ctx context.Context, rawURL string) (bool, error) {
+	key, err := storage.KeyFromURL(rawURL, s.bucket)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.files.HeadFile(ctx, key); err != nil {
+		if storage.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CleanupStuckQueuedImages deletes stuck queued images in batches and releases
+// their originals. A release that fails is logged; the original is left for
+// the orphaned-originals cleanup.
+func (s *DefaultService) CleanupStuckQueuedImages(ctx context.Context, olderThan time.Duration) (int, error) {
+	if olderThan <= 0 {
+		return 0, fmt.Errorf("%w: stuck age must be positive", ErrInvalidOptions)
+	}
+	log := logging.NewDefaultLogger()
+	cutoff := time.Now().Add(-olderThan)
+
+	total := 0
+	for {
+		deleted, err := s.repo.DeleteStuckQueued(ctx, cutoff, stuckBatch)
+		if err != nil {
+			return total, err
+		}
+		total += len(deleted)
+		for _, img := range deleted {
+			if img.OriginalImageID == "" || s.originals == nil {
+				continue
+			}
+			if _, err := s.originals.DecrementReferenceAndCleanup(ctx, img.OriginalImageID); err != nil {
+				log.Warn(ctx, "reconcile: failed to release original of stuck image",
+					"image_id", img.ID, "original_id", img.OriginalImageID, "error", err)
+			}
+		}
+		if len(deleted) < stuckBatch {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Info(ctx, "reconcile: deleted stuck queued images", "count", total, "older_than", olderThan.String())
+	}
+	return total, nil
+}
+
+// RecoverStuckProcessingImages walks the images in processing since before
+// the shortest timeout and recovers those past their own model's timeout.
+// An image whose worker finishes while it is recovered is left alone, and
+// one that can't be recovered is counted as failed and tried again next run.
+func (s *DefaultService) RecoverStuckProcessingImages(
+	ctx context.Context, opts ProcessingOptions,
+) (*ProcessingResult, error) {
+	if opts.Timeout <= 0 {
+		return nil, fmt.Errorf("%w: processing timeout must be positive", ErrInvalidOptions)
+	}
+	for model, timeout := range opts.ModelTimeouts {
+		if timeout <= 0 {
+			return nil, fmt.Errorf("%w: processing timeout for %s must be positive", ErrInvalidOptions, model)
+		}
+	}
+	if opts.MaxRetries < 0 {
+		return nil, fmt.Errorf("%w: max retries must not be negative", ErrInvalidOptions)
+	}
+	log := logging.NewDefaultLogger()
+	now := time.Now()
+
+	result := &ProcessingResult{}
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		images, err := s.repo.ListStuckProcessing(ctx, now.Add(-opts.minTimeout()), after, processingBatch)
+		if err != nil {
+			return nil, err
+		}
+		for _, img := range images {
+			if now.Sub(img.Since) < opts.timeout(img.Model) {
+				continue
+			}
+			result.Stuck++
+			s.recoverProcessing(ctx, img, opts.MaxRetries, result)
+		}
+		if len(images) < processingBatch {
+			break
+		}
+		after = images[len(images)-1].ID
+	}
+
+	if result.Stuck > 0 {
+		log.Info(ctx, "reconcile: recovered stuck processing images",
+			"stuck", result.Stuck, "requeued", result.Requeued, "timed_out", result.TimedOut, "failed", result.Failed)
+	}
+	return result, nil
+}
+
+// recoverProcessing requeues the image from its latest staging job while it
+// has retries left, and marks it as an error otherwise or when it has no job
+// to run again.
+func (s *DefaultService) recoverProcessing(
+	ctx context.Context, img ProcessingImage, maxRetries int, result *ProcessingResult,
+) {
+	log := logging.NewDefaultLogger()
+
+	var payload queue.StageRunPayload
+	retry := s.enqueuer != nil && img.Retries < maxRetries && img.JobType != "" &&
+		json.Unmarshal(img.Payload, &payload) == nil
+	var err error
+	if retry {
+		if err = s.requeue(ctx, img, payload); err == nil {
+			result.Requeued++
+			return
+		}
+	} else if err = s.repo.FailProcessing(ctx, img, ErrMsgProcessingTimedOut); err == nil {
+		result.TimedOut++
+		return
+	}
+	if errors.Is(err, ErrChanged) {
+		return
+	}
+	result.Failed++
+	log.Warn(ctx, "reconcile: failed to recover stuck processing image",
+		"image_id", img.ID, "model", img.Model, "requeue", retry, "error", err)
+}
+
+// requeue requeues the image with a new job and queues the job's task. If
+// the task can't be queued the image is marked as an error, as it would
+// otherwise wait in the queue for a task that never comes.
+func (s *DefaultService) requeue(ctx context.Context, img ProcessingImage, payload queue.StageRunPayload) error {
+	jobID, err := s.repo.RequeueProcessing(ctx, img)
+	if err != nil {
+		return err
+	}
+	enqueue := s.enqueuer.EnqueueStageRun
+	if img.JobType == queue.TaskTypeDeclutterRun {
+		enqueue = s.enqueuer.EnqueueDeclutterRun
+	}
+	if _, err := enqueue(ctx, payload, &queue.EnqueueOpts{TaskID: jobID, Retry: -1}); err != nil {
+		if markErr := s.repo.MarkError(ctx, img.ID, ErrMsgProcessingTimedOut); markErr != nil {
+			return fmt.Errorf("failed to enqueue %s: %w (marking the image as an error failed: %v)",
+				img.JobType, err, markErr)
+		}
+		return fmt.Errorf("failed to enqueue %s: %w", img.JobType, err)
+	}
+	return nil
+}
+
+// ReconcileOrphans loads every referenced key before listing storage, so an
+// object only counts as orphaned if no row pointed at it when the scan began.
+// Objects newer than MinAge are skipped to leave in-flight uploads alone.
+func (s *DefaultService) ReconcileOrphans(ctx context.Context, opts OrphanOptions) (*OrphanResult, error) {
+	ctx, span := otel.Tracer("real-staging-api/reconcile").Start(ctx, "reconcile.orphans")
+	defer span.End()
+	span.SetAttributes(attribute.Bool("delete", opts.Delete), attribute.StringSlice("prefixes", opts.Prefixes))
+
+	if opts.MinAge < 0 {
+		return nil, fmt.Errorf("%w: minimum age must not be negative", ErrInvalidOptions)
+	}
+	if len(opts.Prefixes) == 0 {
+		opts.Prefixes = DefaultOrphanPrefixes
+	}
+	for _, prefix := range opts.Prefixes {
+		if prefix == "" {
+			return nil, fmt.Errorf("%w: empty prefix", ErrInvalidOptions)
+		}
+	}
+
+	log := logging.NewDefaultLogger()
+	refs, err := s.repo.ReferencedObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		key, err := storage.KeyFromURL(ref, s.bucket)
+		if err != nil {
+			log.Warn(ctx, "reconcile: skipping unparseable object reference", "ref", ref, "error", err)
+			continue
+		}
+		referenced[key] = true
+	}
+
+	result := &OrphanResult{DryRun: !opts.Delete}
+	cutoff := time.Now().Add(-opts.MinAge)
+	for _, prefix := range opts.Prefixes {
+		token := ""
+		for {
+			page, err := s.files.ListFiles(ctx, prefix, token)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range page.Files {
+				s.checkObject(ctx, file, referenced, cutoff, opts.Delete, result)
+			}
+			if page.NextToken == "" {
+				break
+			}
+			token = page.NextToken
+		}
+	}
+
+	log.Info(ctx, "reconcile: orphan scan completed",
+		"scanned", result.Scanned,
+		"orphaned", result.Orphaned,
+		"orphaned_bytes", result.OrphanedBytes,
+		"deleted", result.Deleted,
+		"failed", result.Failed,
+		"dry_run", result.DryRun,
+	)
+	return result, nil
+}
+
+func (s *DefaultService) checkObject(
+	ctx context.Context, file storage.FileInfo, referenced map[string]bool, cutoff time.Time, del bool,
+	result *OrphanResult,
+) {
+	result.Scanned++
+	if referenced[file.Key] {
+		return
+	}
+	if file.LastModified.After(cutoff) {
+		result.TooNew++
+		return
+	}
+
+	result.Orphaned++
+	result.OrphanedBytes += file.Size
+	if len(result.Examples) < maxExamples {
+		result.Examples = append(result.Examples, file)
+	}
+	if !del {
+		return
+	}
+	if err := s.files.DeleteFile(ctx, file.Key); err != nil {
+		logging.NewDefaultLogger().Error(ctx, "reconcile: failed to delete orphaned object", "key", file.Key, "error", err)
+		result.Failed++
+		return
+	}
+	result.Deleted++
+}
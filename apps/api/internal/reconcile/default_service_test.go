@@ -0,0 +1,465 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/queue"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// filesWith returns an S3 mock where only the given keys exist and the
+// "flaky" key fails with a request error.
+func filesWith(keys ...string) *storage.S3ServiceMock {
+	present := map[string]bool{}
+	for _, k := range keys {
+		present[k] = true
+	}
+	return &storage.S3ServiceMock{
+		HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
+			if fileKey == "flaky" {
+				return nil, errors.New("connection reset")
+			}
+			if !present[fileKey] {
+				return nil, fmt.Errorf("failed to get file metadata: %w", &types.NotFound{})
+			}
+			return struct{}{}, nil
+		},
+	}
+}
+
+// pagedRepo serves images from a fixed, ID-ordered list.
+func pagedRepo(images []Image) *RepositoryMock {
+	return &RepositoryMock{
+		ListImagesFunc: func(ctx context.Context, filter Filter) ([]Image, error) {
+			out := []Image{}
+			for _, img := range images {
+				if img.ID > filter.AfterID && len(out) < filter.Limit {
+					out = append(out, img)
+				}
+			}
+			return out, nil
+		},
+		MarkErrorFunc: func(ctx context.Context, imageID, msg string) error { return nil },
+	}
+}
+
+func TestDefaultService_ReconcileImages(t *testing.T) {
+	images := []Image{
+		{ID: "1", Status: "ready", OriginalURL: "s3://bucket/o1", StagedURL: "s3://bucket/s1"},
+		{ID: "2", Status: "ready", OriginalURL: "s3://bucket/o2", StagedURL: "s3://bucket/s2"},
+		{ID: "3", Status: "queued", OriginalURL: "s3://bucket/o3"},
+		{ID: "4", Status: "error", OriginalURL: "s3://bucket/gone"},
+		{ID: "5", Status: "ready", OriginalURL: "s3://bucket/flaky", StagedURL: "s3://bucket/s5"},
+		{ID: "6", Status: "rejected", OriginalURL: "s3://bucket/quarantined"},
+	}
+	files := filesWith("o1", "s1", "o2")
+
+	t.Run("success: marks images with missing files", func(t *testing.T) {
+		repo := pagedRepo(images)
+		svc := NewDefaultService(repo, files, "bucket", nil, nil)
+
+		got, err := svc.ReconcileImages(context.Background(), Options{BatchSize: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, got.Checked, "errored and rejected images are skipped")
+		assert.Equal(t, 1, got.MissingOriginal)
+		assert.Equal(t, 1, got.MissingStaged)
+		assert.Equal(t, 2, got.Updated)
+		assert.Equal(t, 1, got.Failed)
+		assert.Empty(t, got.NextCursor)
+		assert.ElementsMatch(t, []Example{
+			{ImageID: "2", Status: "ready", Error: ErrMsgStagedMissing},
+			{ImageID: "3", Status: "queued", Error: ErrMsgOriginalMissing},
+		}, got.Examples)
+
+		marked := map[string]string{}
+		for _, c := range repo.MarkErrorCalls() {
+			marked[c.ImageID] = c.Msg
+		}
+		assert.Equal(t, map[string]string{"2": ErrMsgStagedMissing, "3": ErrMsgOriginalMissing}, marked)
+		assert.Len(t, repo.ListImagesCalls(), 4)
+	})
+
+	t.Run("success: dry run changes nothing", func(t *testing.T) {
+		repo := pagedRepo(images)
+
+		got, err := NewDefaultService(repo, files, "bucket", nil, nil).
+			ReconcileImages(context.Background(), Options{DryRun: true})
+
+		require.NoError(t, err)
+		assert.True(t, got.DryRun)
+		assert.Equal(t, 0, got.Updated)
+		assert.Equal(t, 2, got.MissingOriginal+got.MissingStaged)
+		assert.Empty(t, repo.MarkErrorCalls())
+	})
+
+	t.Run("success: limit stops the pass and returns a cursor", func(t *testing.T) {
+		repo := pagedRepo(images)
+
+		got, err := NewDefaultService(repo, files, "bucket", nil, nil).
+			ReconcileImages(context.Background(), Options{Limit: 2, BatchSize: 5, DryRun: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.Checked)
+		assert.Equal(t, "2", got.NextCursor)
+		assert.Equal(t, 2, repo.ListImagesCalls()[0].Filter.Limit)
+	})
+
+	t.Run("fail: mark error counts as failed", func(t *testing.T) {
+		repo := pagedRepo(images)
+		repo.MarkErrorFunc = func(ctx context.Context, imageID, msg string) error { return errors.New("db down") }
+
+		got, err := NewDefaultService(repo, files, "bucket", nil, nil).ReconcileImages(context.Background(), Options{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, got.Updated)
+		assert.Equal(t, 3, got.Failed)
+	})
+
+	t.Run("fail: unknown status", func(t *testing.T) {
+		_, err := NewDefaultService(pagedRepo(nil), files, "bucket", nil, nil).
+			ReconcileImages(context.Background(), Options{Status: "archived"})
+
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+
+	t.Run("fail: list error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListImagesFunc: func(ctx context.Context, filter Filter) ([]Image, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		_, err := NewDefaultService(repo, files, "bucket", nil, nil).ReconcileImages(context.Background(), Options{})
+
+		assert.Error(t, err)
+	})
+}
+
+type releaser struct {
+	mu       sync.Mutex
+	released []string
+}
+
+func (r *releaser) DecrementReferenceAndCleanup(ctx context.Context, originalImageID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.released = append(r.released, originalImageID)
+	return false, nil
+}
+
+func TestDefaultService_CleanupStuckQueuedImages(t *testing.T) {
+	t.Run("success: deletes in batches and releases originals", func(t *testing.T) {
+		full := make([]StuckImage, stuckBatch)
+		for i := range full {
+			full[i] = StuckImage{ID: fmt.Sprint(i)}
+		}
+		full[0].OriginalImageID = "orig-1"
+		pages := [][]StuckImage{full, {{ID: "last", OriginalImageID: "orig-2"}}}
+
+		var cutoff time.Time
+		repo := &RepositoryMock{
+			DeleteStuckQueuedFunc: func(ctx context.Context, c time.Time, limit int) ([]StuckImage, error) {
+				cutoff = c
+				page := pages[0]
+				pages = pages[1:]
+				return page, nil
+			},
+		}
+		originals := &releaser{}
+
+		n, err := NewDefaultService(repo, nil, "bucket", originals, nil).
+			CleanupStuckQueuedImages(context.Background(), time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, stuckBatch+1, n)
+		assert.Equal(t, []string{"orig-1", "orig-2"}, originals.released)
+		assert.WithinDuration(t, time.Now().Add(-time.Hour), cutoff, time.Minute)
+	})
+
+	t.Run("fail: age must be positive", func(t *testing.T) {
+		_, err := NewDefaultService(&RepositoryMock{}, nil, "bucket", nil, nil).
+			CleanupStuckQueuedImages(context.Background(), 0)
+
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			DeleteStuckQueuedFunc: func(ctx context.Context, c time.Time, limit int) ([]StuckImage, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		_, err := NewDefaultService(repo, nil, "bucket", nil, nil).
+			CleanupStuckQueuedImages(context.Background(), time.Hour)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_RecoverStuckProcessingImages(t *testing.T) {
+	ctx := context.Background()
+	opts := ProcessingOptions{
+		Timeout:       30 * time.Minute,
+		ModelTimeouts: map[string]time.Duration{"slow/model": 2 * time.Hour},
+		MaxRetries:    1,
+	}
+	since := time.Now().Add(-time.Hour)
+	payload := []byte(`{"image_id":"img-1","original_url":"s3://o.jpg","priority":"critical"}`)
+	stuck := func(id string) ProcessingImage {
+		return ProcessingImage{ID: id, Model: "qwen/qwen-image-edit", Since: since, JobType: "stage:run", Payload: payload}
+	}
+
+	t.Run("success: requeues, fails and skips by model timeout and retries", func(t *testing.T) {
+		retried := stuck("img-2")
+		retried.Retries = 1
+		slow := stuck("img-3")
+		slow.Model = "slow/model"
+		declutter := stuck("img-4")
+		declutter.JobType = "declutter:run"
+		repo := &RepositoryMock{
+			ListStuckProcessingFunc: func(
+				ctx context.Context, cutoff time.Time, afterID string, limit int,
+			) ([]ProcessingImage, error) {
+				assert.WithinDuration(t, time.Now().Add(-30*time.Minute), cutoff, time.Minute)
+				return []ProcessingImage{stuck("img-1"), retried, slow, declutter}, nil
+			},
+			RequeueProcessingFunc: func(ctx context.Context, img ProcessingImage) (string, error) {
+				return "job-" + img.ID, nil
+			},
+			FailProcessingFunc: func(ctx context.Context, img ProcessingImage, msg string) error { return nil },
+		}
+		enq := &queue.EnqueuerMock{
+			EnqueueStageRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				assert.Equal(t, queue.PriorityCritical, payload.Priority)
+				return opts.TaskID, nil
+			},
+			EnqueueDeclutterRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return opts.TaskID, nil
+			},
+		}
+
+		got, err := NewDefaultService(repo, nil, "bucket", nil, enq).RecoverStuckProcessingImages(ctx, opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, &ProcessingResult{Stuck: 3, Requeued: 2, TimedOut: 1}, got)
+		require.Len(t, enq.EnqueueStageRunCalls(), 1)
+		assert.Equal(t, "job-img-1", enq.EnqueueStageRunCalls()[0].Opts.TaskID)
+		require.Len(t, enq.EnqueueDeclutterRunCalls(), 1)
+		require.Len(t, repo.FailProcessingCalls(), 1)
+		assert.Equal(t, "img-2", repo.FailProcessingCalls()[0].Img.ID)
+		assert.Equal(t, ErrMsgProcessingTimedOut, repo.FailProcessingCalls()[0].Msg)
+	})
+
+	t.Run("success: without an enqueuer or a job, images are marked as errors", func(t *testing.T) {
+		noJob := stuck("img-2")
+		noJob.JobType, noJob.Payload = "", nil
+		repo := &RepositoryMock{
+			ListStuckProcessingFunc: func(
+				ctx context.Context, cutoff time.Time, afterID string, limit int,
+			) ([]ProcessingImage, error) {
+				return []ProcessingImage{stuck("img-1"), noJob}, nil
+			},
+			FailProcessingFunc: func(ctx context.Context, img ProcessingImage, msg string) error { return nil },
+		}
+
+		got, err := NewDefaultService(repo, nil, "bucket", nil, nil).RecoverStuckProcessingImages(ctx, opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, got.TimedOut)
+	})
+
+	t.Run("success: images that changed meanwhile are left alone", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListStuckProcessingFunc: func(
+				ctx context.Context, cutoff time.Time, afterID string, limit int,
+			) ([]ProcessingImage, error) {
+				return []ProcessingImage{stuck("img-1")}, nil
+			},
+			RequeueProcessingFunc: func(ctx context.Context, img ProcessingImage) (string, error) {
+				return "", ErrChanged
+			},
+		}
+
+		got, err := NewDefaultService(repo, nil, "bucket", nil, &queue.EnqueuerMock{}).
+			RecoverStuckProcessingImages(ctx, opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, &ProcessingResult{Stuck: 1}, got)
+	})
+
+	t.Run("success: an image whose task can't be queued is marked as an error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListStuckProcessingFunc: func(
+				ctx context.Context, cutoff time.Time, afterID string, limit int,
+			) ([]ProcessingImage, error) {
+				return []ProcessingImage{stuck("img-1")}, nil
+			},
+			RequeueProcessingFunc: func(ctx context.Context, img ProcessingImage) (string, error) {
+				return "job-1", nil
+			},
+			MarkErrorFunc: func(ctx context.Context, imageID, msg string) error { return nil },
+		}
+		enq := &queue.EnqueuerMock{
+			EnqueueStageRunFunc: func(
+				ctx context.Context, payload queue.StageRunPayload, opts *queue.EnqueueOpts,
+			) (string, error) {
+				return "", errors.New("redis down")
+			},
+		}
+
+		got, err := NewDefaultService(repo, nil, "bucket", nil, enq).RecoverStuckProcessingImages(ctx, opts)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.Failed)
+		require.Len(t, repo.MarkErrorCalls(), 1)
+		assert.Equal(t, "img-1", repo.MarkErrorCalls()[0].ImageID)
+	})
+
+	t.Run("fail: invalid options", func(t *testing.T) {
+		svc := NewDefaultService(&RepositoryMock{}, nil, "bucket", nil, nil)
+		for _, bad := range []ProcessingOptions{
+			{},
+			{Timeout: time.Hour, MaxRetries: -1},
+			{Timeout: time.Hour, ModelTimeouts: map[string]time.Duration{"m": 0}},
+		} {
+			_, err := svc.RecoverStuckProcessingImages(ctx, bad)
+			assert.ErrorIs(t, err, ErrInvalidOptions)
+		}
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListStuckProcessingFunc: func(
+				ctx context.Context, cutoff time.Time, afterID string, limit int,
+			) ([]ProcessingImage, error) {
+				return nil, errors.New("db down")
+			},
+		}
+
+		_, err := NewDefaultService(repo, nil, "bucket", nil, nil).RecoverStuckProcessingImages(ctx, opts)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultService_ReconcileOrphans(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	listing := map[string][]storage.FileListPage{
+		"uploads/": {
+			{Files: []storage.FileInfo{
+				{Key: "uploads/u1/kept.jpg", Size: 10, LastModified: old},
+				{Key: "uploads/u1/lost.jpg", Size: 20, LastModified: old},
+			}, NextToken: "page-2"},
+			{Files: []storage.FileInfo{{Key: "uploads/u1/fresh.jpg", Size: 30, LastModified: time.Now()}}},
+		},
+		"staged/": {
+			{Files: []storage.FileInfo{
+				{Key: "staged/abc/s.jpg", Size: 40, LastModified: old},
+				{Key: "staged/abc/gone.jpg", Size: 50, LastModified: old},
+			}},
+		},
+	}
+	newFiles := func() *storage.S3ServiceMock {
+		return &storage.S3ServiceMock{
+			ListFilesFunc: func(ctx context.Context, prefix, token string) (*storage.FileListPage, error) {
+				pages := listing[prefix]
+				if token == "page-2" {
+					return &pages[1], nil
+				}
+				if len(pages) == 0 {
+					return &storage.FileListPage{}, nil
+				}
+				return &pages[0], nil
+			},
+			DeleteFileFunc: func(ctx context.Context, fileKey string) error {
+				if fileKey == "staged/abc/gone.jpg" {
+					return errors.New("access denied")
+				}
+				return nil
+			},
+		}
+	}
+	repo := &RepositoryMock{
+		ReferencedObjectsFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"s3://bucket/uploads/u1/kept.jpg", "http://minio:9000/bucket/staged/abc/s.jpg", "::bad"}, nil
+		},
+	}
+	prefixes := []string{"uploads/", "staged/"}
+
+	t.Run("success: reports unreferenced objects without deleting", func(t *testing.T) {
+		files := newFiles()
+
+		got, err := NewDefaultService(repo, files, "bucket", nil, nil).ReconcileOrphans(context.Background(),
+			OrphanOptions{Prefixes: prefixes, MinAge: time.Hour})
+
+		require.NoError(t, err)
+		assert.True(t, got.DryRun)
+		assert.Equal(t, 5, got.Scanned)
+		assert.Equal(t, 1, got.TooNew)
+		assert.Equal(t, 2, got.Orphaned)
+		assert.Equal(t, int64(70), got.OrphanedBytes)
+		assert.Equal(t, []string{"uploads/u1/lost.jpg", "staged/abc/gone.jpg"},
+			[]string{got.Examples[0].Key, got.Examples[1].Key})
+		assert.Empty(t, files.DeleteFileCalls())
+	})
+
+	t.Run("success: deletes orphans and counts failures", func(t *testing.T) {
+		files := newFiles()
+
+		got, err := NewDefaultService(repo, files, "bucket", nil, nil).ReconcileOrphans(context.Background(),
+			OrphanOptions{Prefixes: prefixes, MinAge: time.Hour, Delete: true})
+
+		require.NoError(t, err)
+		assert.False(t, got.DryRun)
+		assert.Equal(t, 1, got.Deleted)
+		assert.Equal(t, 1, got.Failed)
+		assert.Len(t, files.DeleteFileCalls(), 2)
+	})
+
+	t.Run("success: scans the default prefixes", func(t *testing.T) {
+		files := newFiles()
+
+		_, err := NewDefaultService(repo, files, "bucket", nil, nil).ReconcileOrphans(context.Background(), OrphanOptions{})
+
+		require.NoError(t, err)
+		listed := map[string]bool{}
+		for _, c := range files.ListFilesCalls() {
+			listed[c.Prefix] = true
+		}
+		assert.Equal(t, map[string]bool{"uploads/": true, "staged/": true, "users/": true}, listed)
+	})
+
+	t.Run("fail: empty prefix", func(t *testing.T) {
+		_, err := NewDefaultService(repo, newFiles(), "bucket", nil, nil).
+			ReconcileOrphans(context.Background(), OrphanOptions{Prefixes: []string{""}})
+
+		assert.ErrorIs(t, err, ErrInvalidOptions)
+	})
+
+	t.Run("fail: listing error", func(t *testing.T) {
+		files := &storage.S3ServiceMock{
+			ListFilesFunc: func(ctx context.Context, prefix, token string) (*storage.FileListPage, error) {
+				return nil, errors.New("timeout")
+			},
+		}
+
+		_, err := NewDefaultService(repo, files, "bucket", nil, nil).ReconcileOrphans(context.Background(), OrphanOptions{})
+
+		assert.Error(t, err)
+	})
+}
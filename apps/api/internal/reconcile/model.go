@@ -0,0 +1,198 @@
+// Package reconcile keeps image rows consistent with storage. A pass marks
+// images whose files are missing from S3 as errors, a cleanup removes images
+// that never left the queue, and a recovery requeues or fails images stuck in
+// processing. All run one-shot from the reconcile CLI or on a schedule in
+// daemon mode. The orphan scan works in the other
+// direction, finding stored objects that no row references.
+package reconcile
+
+import (
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// Error messages stored on images whose files are missing.
+const (
+	ErrMsgOriginalMissing = "original missing in storage"
+	ErrMsgStagedMissing   = "staged missing in storage"
+	// ErrMsgProcessingTimedOut is stored on images that stayed in processing
+	// past their timeout too many times.
+	ErrMsgProcessingTimedOut = "processing timed out"
+)
+
+const (
+	// DefaultBatchSize is the number of images listed per query.
+	DefaultBatchSize = 100
+	// DefaultConcurrency is the number of concurrent S3 checks.
+	DefaultConcurrency = 5
+	// maxExamples caps how many changed images a Result lists.
+	maxExamples = 20
+	// DefaultOrphanMinAge keeps the orphan scan away from uploads that have
+	// not been attached to an image yet.
+	DefaultOrphanMinAge = 24 * time.Hour
+	// DefaultProcessingTimeout is how long an image may stay in processing
+	// before it is recovered, well past the worker's own prediction timeout
+	// and model fallbacks.
+	DefaultProcessingTimeout = 30 * time.Minute
+	// DefaultProcessingRetries is how many times a stuck image is requeued
+	// before it is marked as an error.
+	DefaultProcessingRetries = 1
+)
+
+// DefaultOrphanPrefixes are scanned when no prefixes are given: the legacy
+// upload and staged roots and the user-scoped namespace.
+var DefaultOrphanPrefixes = []string{"uploads/", "staged/", "users/"}
+
+// ErrInvalidOptions is returned for options a pass cannot run with.
+var ErrInvalidOptions = errors.New("invalid reconcile options")
+
+// ErrChanged is returned when an image changed after a recovery listed it,
+// e.g. because its worker finished after all.
+var ErrChanged = errors.New("image changed since it was listed")
+
+// Options scopes a reconciliation pass.
+type Options struct {
+	// ProjectID limits the pass to one project.
+	ProjectID string
+	// Status limits the pass to images in this status.
+	Status string
+	// Cursor starts the pass after this image ID.
+	Cursor string
+	// Limit stops the pass after this many images; zero checks every match.
+	Limit int
+	// BatchSize is the number of images listed per query.
+	BatchSize int
+	// Concurrency is the number of concurrent S3 checks.
+	Concurrency int
+	// DryRun reports what would change without updating any image.
+	DryRun bool
+}
+
+// Example is an image a pass found files missing for.
+type Example struct {
+	ImageID string `json:"image_id"`
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+}
+
+// Result summarizes a reconciliation pass.
+type Result struct {
+	Checked         int  `json:"checked"`
+	MissingOriginal int  `json:"missing_original"`
+	MissingStaged   int  `json:"missing_staged"`
+	Updated         int  `json:"updated"`
+	Failed          int  `json:"failed"`
+	DryRun          bool `json:"dry_run"`
+	// NextCursor resumes a pass that stopped at its limit.
+	NextCursor string    `json:"next_cursor,omitempty"`
+	Examples   []Example `json:"examples,omitempty"`
+}
+
+// Image is the part of an image row a pass inspects.
+type Image struct {
+	ID          string
+	Status      string
+	OriginalURL string
+	StagedURL   string
+}
+
+// Filter selects a page of images ordered by ID.
+type Filter struct {
+	ProjectID string
+	Status    string
+	AfterID   string
+	Limit     int
+}
+
+// StuckImage is a queued image removed by CleanupStuckQueuedImages.
+type StuckImage struct {
+	ID string
+	// OriginalImageID is the shared original the image held a reference on;
+	// empty for legacy images.
+	OriginalImageID string
+}
+
+// ProcessingOptions configures the recovery of images stuck in processing.
+type ProcessingOptions struct {
+	// Timeout is how long an image may stay in processing with a model that
+	// has no entry in ModelTimeouts.
+	Timeout time.Duration
+	// ModelTimeouts overrides Timeout for images being staged by a model,
+	// keyed by model ID, e.g. "qwen/qwen-image-edit".
+	ModelTimeouts map[string]time.Duration
+	// MaxRetries is how many times an image is requeued before it is marked
+	// as an error instead. It counts the recoveries since the image was last
+	// queued by the API.
+	MaxRetries int
+}
+
+// timeout returns how long an image staged by model may stay in processing.
+func (o ProcessingOptions) timeout(model string) time.Duration {
+	if d, ok := o.ModelTimeouts[model]; ok {
+		return d
+	}
+	return o.Timeout
+}
+
+// minTimeout returns the shortest timeout any image gets.
+func (o ProcessingOptions) minTimeout() time.Duration {
+	shortest := o.Timeout
+	for _, d := range o.ModelTimeouts {
+		shortest = min(shortest, d)
+	}
+	return shortest
+}
+
+// ProcessingImage is an image in processing that a recovery inspects.
+type ProcessingImage struct {
+	ID string
+	// Model is the model of the image's latest processing event; empty if
+	// the worker didn't record one.
+	Model string
+	// Since is when the image last changed, which the worker does each time
+	// it starts staging with a model.
+	Since time.Time
+	// Retries is how many times recovery requeued the image since the API
+	// last queued it.
+	Retries int
+	// JobType and Payload are those of the image's latest staging job; empty
+	// for images without one.
+	JobType string
+	Payload []byte
+}
+
+// ProcessingResult summarizes a recovery of images stuck in processing.
+type ProcessingResult struct {
+	// Stuck is the number of images found past their timeout.
+	Stuck    int `json:"stuck"`
+	Requeued int `json:"requeued"`
+	// TimedOut is the number of images marked as errors.
+	TimedOut int `json:"timed_out"`
+	// Failed is the number of images that could not be recovered this time.
+	Failed int `json:"failed"`
+}
+
+// OrphanOptions scopes an orphan scan.
+type OrphanOptions struct {
+	// Prefixes are the key prefixes to list; DefaultOrphanPrefixes when empty.
+	Prefixes []string
+	// MinAge skips objects modified more recently than this.
+	MinAge time.Duration
+	// Delete removes orphaned objects; otherwise they are only reported.
+	Delete bool
+}
+
+// OrphanResult summarizes an orphan scan.
+type OrphanResult struct {
+	Scanned  int `json:"scanned"`
+	TooNew   int `json:"too_new"`
+	Orphaned int `json:"orphaned"`
+	// OrphanedBytes is the total size of the orphaned objects.
+	OrphanedBytes int64              `json:"orphaned_bytes"`
+	Deleted       int                `json:"deleted"`
+	Failed        int                `json:"failed"`
+	DryRun        bool               `json:"dry_run"`
+	Examples      []storage.FileInfo `json:"examples,omitempty"`
+}
@@ -0,0 +1,40 @@
+package reconcile
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository reads and corrects image rows during reconciliation.
+type Repository interface {
+	// ListImages returns live images matching filter, ordered by ID.
+	ListImages(ctx context.Context, filter Filter) ([]Image, error)
+
+	// MarkError moves the image to "error" with msg and appends a reconcile
+	// event to its history. Images already in error are left untouched.
+	MarkError(ctx context.Context, imageID, msg string) error
+
+	// DeleteStuckQueued hard-deletes up to limit images still queued since
+	// before cutoff. Images in locked projects are kept.
+	DeleteStuckQueued(ctx context.Context, cutoff time.Time, limit int) ([]StuckImage, error)
+
+	// ListStuckProcessing returns up to limit live images in processing that
+	// last changed before cutoff, ordered by ID after afterID.
+	ListStuckProcessing(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]ProcessingImage, error)
+
+	// RequeueProcessing moves the image back to queued, appends a reconcile
+	// event and creates a job with the image's job type and payload,
+	// returning the job's ID. It returns ErrChanged if the image left
+	// processing or changed after img.Since.
+	RequeueProcessing(ctx context.Context, img ProcessingImage) (string, error)
+
+	// FailProcessing moves the image to "error" with msg and appends a
+	// reconcile event. It returns ErrChanged like RequeueProcessing.
+	FailProcessing(ctx context.Context, img ProcessingImage, msg string) error
+
+	// ReferencedObjects returns every object URL or key stored in the
+	// database, including those of trashed images.
+	ReferencedObjects(ctx context.Context) ([]string, error)
+}
@@ -0,0 +1,34 @@
+package reconcile
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service reconciles image rows with storage.
+type Service interface {
+	// ReconcileImages checks that each matching image's original, and staged
+	// image once ready, still exist in S3 and marks images with missing files
+	// as errors unless opts.DryRun is set.
+	ReconcileImages(ctx context.Context, opts Options) (*Result, error)
+
+	// CleanupStuckQueuedImages deletes images that have been queued for longer
+	// than olderThan and returns how many were removed.
+	CleanupStuckQueuedImages(ctx context.Context, olderThan time.Duration) (int, error)
+
+	// RecoverStuckProcessingImages requeues images that have been processing
+	// for longer than their model's timeout, or marks them as errors once
+	// they were requeued opts.MaxRetries times.
+	RecoverStuckProcessingImages(ctx context.Context, opts ProcessingOptions) (*ProcessingResult, error)
+
+	// ReconcileOrphans lists stored objects under opts.Prefixes and reports
+	// those no database row references, deleting them when opts.Delete is set.
+	ReconcileOrphans(ctx context.Context, opts OrphanOptions) (*OrphanResult, error)
+}
+
+// OriginalReleaser drops a deleted image's reference on its shared original.
+type OriginalReleaser interface {
+	DecrementReferenceAndCleanup(ctx context.Context, originalImageID string) (bool, error)
+}
@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// catalog is the staging model catalog as last loaded from the models table,
+// in the order the admin UI lists the models. It is empty until LoadCatalog
+// or SetCatalog runs.
+var catalog atomic.Pointer[[]ModelInfo]
+
+// SetCatalog replaces the model catalog. Servers load it with LoadCatalog;
+// tests set the models they need.
+func SetCatalog(models []ModelInfo) {
+	catalog.Store(&models)
+}
+
+// LoadCatalog replaces the model catalog with the models in the database.
+func LoadCatalog(ctx context.Context, repo Repository) error {
+	models, err := repo.ListModels(ctx)
+	if err != nil {
+		return err
+	}
+	SetCatalog(models)
+	return nil
+}
+
+// RunCatalogRefresh reloads the model catalog every interval until ctx is
+// done, so models added by a migration or enabled on another instance show up
+// without a restart.
+func RunCatalogRefresh(ctx context.Context, repo Repository, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := LoadCatalog(ctx, repo); err != nil {
+				log.Error(ctx, "model catalog refresh failed", "error", err)
+			}
+		}
+	}
+}
+
+// catalogModels returns the catalog's models.
+func catalogModels() []ModelInfo {
+	if models := catalog.Load(); models != nil {
+		return *models
+	}
+	return nil
+}
+
+// IsAvailableModel reports whether modelID is an enabled model in the catalog.
+func IsAvailableModel(modelID string) bool {
+	_, ok := ModelCostPerImage(modelID)
+	return ok
+}
+
+// ModelCostPerImage returns the list price for staging one image with modelID
+// and whether the model is enabled in the catalog.
+func ModelCostPerImage(modelID string) (float64, bool) {
+	for _, model := range catalogModels() {
+		if model.ID == modelID && model.Enabled {
+			return model.CostPerImageUSD, true
+		}
+	}
+	return 0, false
+}
+
+// AvailableModelIDs returns the IDs of the catalog's enabled models.
+func AvailableModelIDs() []string {
+	var ids []string
+	for _, model := range catalogModels() {
+		if model.Enabled {
+			ids = append(ids, model.ID)
+		}
+	}
+	return ids
+}
@@ -56,13 +56,19 @@ func (r *DefaultRepository) GetByKey(ctx context.Context, key string) (*Setting,
 
 // Update updates a setting value.
 func (r *DefaultRepository) Update(ctx context.Context, key, value, userID string) error {
+	// Changes made outside a user session, such as from the CLI, have no updater.
+	var updatedBy any
+	if userID != "" {
+		updatedBy = userID
+	}
+
 	query := `
 		UPDATE settings
 		SET value = $1, updated_at = NOW(), updated_by = $2
 		WHERE key = $3
 	`
 
-	result, err := r.db.Exec(ctx, query, value, userID, key)
+	result, err := r.db.Exec(ctx, query, value, updatedBy, key)
 	if err != nil {
 		return fmt.Errorf("failed to update setting: %w", err)
 	}
@@ -155,6 +161,91 @@ func (r *DefaultRepository) UpdateModelConfig(
 	return nil
 }
 
+// ListModels retrieves the model catalog in display order.
+func (r *DefaultRepository) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	query := `
+		SELECT id, name, description, provider, version, cost_per_image_usd::float8, enabled,
+			latest_version, synced_at
+		FROM models
+		ORDER BY position, id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	defer rows.Close()
+
+	var models []ModelInfo
+	for rows.Next() {
+		var m ModelInfo
+		if err := rows.Scan(
+			&m.ID, &m.Name, &m.Description, &m.Provider, &m.Version, &m.CostPerImageUSD, &m.Enabled,
+			&m.LatestVersion, &m.SyncedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan model: %w", err)
+		}
+		models = append(models, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating models: %w", err)
+	}
+
+	return models, nil
+}
+
+// SetModelEnabled enables or disables a model.
+func (r *DefaultRepository) SetModelEnabled(ctx context.Context, modelID string, enabled bool, userID string) error {
+	var updatedBy any
+	if userID != "" {
+		updatedBy = userID
+	}
+
+	query := `
+		UPDATE models
+		SET enabled = $1, updated_at = NOW(), updated_by = $2
+		WHERE id = $3
+	`
+	result, err := r.db.Exec(ctx, query, enabled, updatedBy, modelID)
+	if err != nil {
+		return fmt.Errorf("failed to update model: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
+	}
+
+	return nil
+}
+
+// UpdateModelSync stores what a sync read about a model from Replicate. An
+// empty description keeps the one the model has.
+func (r *DefaultRepository) UpdateModelSync(ctx context.Context, modelID string, sync ModelSync) error {
+	var schema any
+	if len(sync.InputSchema) > 0 {
+		schema = sync.InputSchema
+	}
+
+	query := `
+		UPDATE models
+		SET description = COALESCE(NULLIF($1, ''), description),
+			latest_version = NULLIF($2, ''),
+			input_schema = $3,
+			synced_at = NOW()
+		WHERE id = $4
+	`
+	result, err := r.db.Exec(ctx, query, sync.Description, sync.LatestVersion, schema, modelID)
+	if err != nil {
+		return fmt.Errorf("failed to update model: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
+	}
+
+	return nil
+}
+
 // getConfigKey converts a model ID to its configuration key.
 func getConfigKey(modelID string) string {
 	// Convert model ID to config key format
@@ -174,6 +265,8 @@ func getConfigKey(modelID string) string {
 		return "model_config_gpt_image_1"
 	case "openai/gpt-image-1.5":
 		return "model_config_gpt_image_1_5"
+	case "stability-ai/stable-image-inpaint":
+		return "model_config_stable_image_inpaint"
 	default:
 		return fmt.Sprintf("model_config_%s", modelID)
 	}
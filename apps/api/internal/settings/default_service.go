@@ -3,20 +3,28 @@ package settings
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+
+	"github.com/real-staging-ai/api/encryption"
+	"github.com/real-staging-ai/api/modelconfig"
 )
 
 // DefaultService implements Service.
 type DefaultService struct {
-	repo Repository
+	repo    Repository
+	keyring *encryption.Keyring
 }
 
 // Ensure DefaultService implements Service.
 var _ Service = (*DefaultService)(nil)
 
-// NewDefaultService creates a new DefaultService.
-func NewDefaultService(repo Repository) *DefaultService {
-	return &DefaultService{repo: repo}
+// NewDefaultService creates a new DefaultService. keyring seals the secret
+// fields of model configs; without one, configs with a secret set can't be
+// saved.
+func NewDefaultService(repo Repository, keyring *encryption.Keyring) *DefaultService {
+	return &DefaultService{repo: repo, keyring: keyring}
 }
 
 // GetActiveModel retrieves the currently active AI model ID.
@@ -30,94 +38,71 @@ func (s *DefaultService) GetActiveModel(ctx context.Context) (string, error) {
 
 // UpdateActiveModel updates the active AI model.
 func (s *DefaultService) UpdateActiveModel(ctx context.Context, modelID, userID string) error {
-	// Validate model ID against available models
-	models, err := s.ListAvailableModels(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to list models: %w", err)
+	model, err := s.findModel(ctx, modelID)
+	if errors.Is(err, ErrModelNotFound) {
+		return fmt.Errorf("invalid model ID: %s", modelID)
 	}
-
-	valid := false
-	for _, model := range models {
-		if model.ID == modelID {
-			valid = true
-			break
-		}
+	if err != nil {
+		return err
 	}
-
-	if !valid {
-		return fmt.Errorf("invalid model ID: %s", modelID)
+	if !model.Enabled {
+		return fmt.Errorf("model is disabled: %s", modelID)
 	}
 
 	return s.repo.Update(ctx, "active_model", modelID, userID)
 }
 
-// ListAvailableModels returns all available AI models.
-// This is hardcoded for now but could be made dynamic in the future.
+// ListAvailableModels returns the model catalog from the database, marking the
+// active model, and refreshes this instance's copy of the catalog with it.
 func (s *DefaultService) ListAvailableModels(ctx context.Context) ([]ModelInfo, error) {
-	// Get the current active model
+	models, err := s.repo.ListModels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	SetCatalog(models)
+
 	activeModelID, _ := s.GetActiveModel(ctx)
+	listed := make([]ModelInfo, len(models))
+	for i, model := range models {
+		model.IsActive = model.ID == activeModelID
+		listed[i] = model
+	}
+	return listed, nil
+}
+
+// SetModelEnabled enables or disables a model and returns it. The active model
+// can't be disabled: jobs without a model would have nothing to run on.
+func (s *DefaultService) SetModelEnabled(
+	ctx context.Context, modelID string, enabled bool, userID string,
+) (*ModelInfo, error) {
+	if !enabled {
+		activeModelID, err := s.GetActiveModel(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if activeModelID == modelID {
+			return nil, ErrActiveModel
+		}
+	}
 
-	models := []ModelInfo{
-		{
-			ID:          "qwen/qwen-image-edit",
-			Name:        "Qwen Image Edit",
-			Description: "Fast image editing model optimized for virtual staging. Requires input image.",
-			Version:     "v1",
-			IsActive:    activeModelID == "qwen/qwen-image-edit",
-		},
-		{
-			ID:   "black-forest-labs/flux-kontext-max",
-			Name: "Flux Kontext Max",
-			Description: "High-quality image generation and editing with advanced context understanding. " +
-				"Supports both text-to-image and image-to-image.",
-			Version:  "v1",
-			IsActive: activeModelID == "black-forest-labs/flux-kontext-max",
-		},
-		{
-			ID:   "black-forest-labs/flux-kontext-pro",
-			Name: "Flux Kontext Pro",
-			Description: "State-of-the-art text-based image editing with high-quality outputs and excellent prompt following. " +
-				"Professional-grade editing capabilities.",
-			Version:  "v1",
-			IsActive: activeModelID == "black-forest-labs/flux-kontext-pro",
-		},
-		{
-			ID:   "bytedance/seedream-3",
-			Name: "Seedream 3",
-			Description: "Unified text-to-image generation and precise editing. " +
-				"Supports both workflows with natural language commands.",
-			Version:  "v1",
-			IsActive: activeModelID == "bytedance/seedream-3",
-		},
-		{
-			ID:   "bytedance/seedream-4",
-			Name: "Seedream 4",
-			Description: "Latest Seedream model with support for up to 4K resolution. " +
-				"High-quality text-to-image and image editing.",
-			Version:  "v1",
-			IsActive: activeModelID == "bytedance/seedream-4",
-		},
-		{
-			ID:   "openai/gpt-image-1",
-			Name: "GPT Image 1",
-			Description: "A multimodal image generation model that creates high-quality images. " +
-				"You need to bring your own verified OpenAI key to use this model. " +
-				"Your OpenAI account will be charged for usage.",
-			Version:  "v1",
-			IsActive: activeModelID == "openai/gpt-image-1",
-		},
-		{
-			ID:   "openai/gpt-image-1.5",
-			Name: "GPT Image 1.5",
-			Description: "A multimodal image generation model that creates high-quality images. " +
-				"You need to bring your own verified OpenAI key to use this model. " +
-				"Your OpenAI account will be charged for usage.",
-			Version:  "v1",
-			IsActive: activeModelID == "openai/gpt-image-1.5",
-		},
+	if err := s.repo.SetModelEnabled(ctx, modelID, enabled, userID); err != nil {
+		return nil, err
 	}
+	return s.findModel(ctx, modelID)
+}
 
-	return models, nil
+// findModel returns modelID from the catalog, marked as active or not.
+func (s *DefaultService) findModel(ctx context.Context, modelID string) (*ModelInfo, error) {
+	models, err := s.ListAvailableModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, model := range models {
+		if model.ID == modelID {
+			return &model, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
 }
 
 // GetSetting retrieves a setting by key.
@@ -125,8 +110,11 @@ func (s *DefaultService) GetSetting(ctx context.Context, key string) (*Setting,
 	return s.repo.GetByKey(ctx, key)
 }
 
-// UpdateSetting updates a setting value.
+// UpdateSetting updates a setting value after checking it is usable.
 func (s *DefaultService) UpdateSetting(ctx context.Context, key, value, userID string) error {
+	if err := validateSetting(key, value); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, key, value, userID)
 }
 
@@ -135,7 +123,8 @@ func (s *DefaultService) ListSettings(ctx context.Context) ([]Setting, error) {
 	return s.repo.List(ctx)
 }
 
-// GetModelConfig retrieves the configuration for a specific model.
+// GetModelConfig retrieves the configuration for a specific model, with its
+// secrets redacted.
 func (s *DefaultService) GetModelConfig(ctx context.Context, modelID string) (*ModelConfig, error) {
 	configJSON, err := s.repo.GetModelConfig(ctx, modelID)
 	if err != nil {
@@ -147,6 +136,9 @@ func (s *DefaultService) GetModelConfig(ctx context.Context, modelID string) (*M
 	if err := json.Unmarshal(configJSON, &configMap); err != nil {
 		return nil, fmt.Errorf("failed to parse model config: %w", err)
 	}
+	if schema, err := modelconfig.Get(modelID); err == nil {
+		schema.RedactSecrets(configMap)
+	}
 
 	return &ModelConfig{
 		ModelID: modelID,
@@ -154,26 +146,34 @@ func (s *DefaultService) GetModelConfig(ctx context.Context, modelID string) (*M
 	}, nil
 }
 
-// UpdateModelConfig updates the configuration for a specific model.
+// UpdateModelConfig validates the configuration for a specific model against
+// its schema and stores it with its secrets sealed. A secret sent back as
+// modelconfig.Redacted keeps its stored value.
 func (s *DefaultService) UpdateModelConfig(
 	ctx context.Context, modelID string, config map[string]interface{}, userID string,
 ) error {
-	// Validate model exists
-	models, err := s.ListAvailableModels(ctx)
+	// Validate model exists; disabled models can still be configured
+	_, err := s.findModel(ctx, modelID)
+	if errors.Is(err, ErrModelNotFound) {
+		return fmt.Errorf("invalid model ID: %s", modelID)
+	}
 	if err != nil {
-		return fmt.Errorf("failed to list models: %w", err)
+		return err
 	}
 
-	valid := false
-	for _, model := range models {
-		if model.ID == modelID {
-			valid = true
-			break
-		}
+	schema, err := modelconfig.Get(modelID)
+	if err != nil {
+		return err
 	}
-
-	if !valid {
-		return fmt.Errorf("invalid model ID: %s", modelID)
+	config = maps.Clone(config)
+	if err := s.keepStoredSecrets(ctx, schema, config); err != nil {
+		return err
+	}
+	if err := schema.Validate(config); err != nil {
+		return err
+	}
+	if err := schema.SealSecrets(config, s.keyring); err != nil {
+		return err
 	}
 
 	// Marshal config to JSON
@@ -186,369 +186,39 @@ func (s *DefaultService) UpdateModelConfig(
 	return s.repo.UpdateModelConfig(ctx, modelID, configJSON, userID)
 }
 
-// GetModelConfigSchema returns the schema for a model's configuration.
-func (s *DefaultService) GetModelConfigSchema(ctx context.Context, modelID string) (*ModelConfigSchema, error) {
-	// Return schema based on model ID
-	switch modelID {
-	case "qwen/qwen-image-edit":
-		return getQwenSchema(), nil
-	case "black-forest-labs/flux-kontext-max", "black-forest-labs/flux-kontext-pro":
-		return getFluxKontextSchema(modelID), nil
-	case "bytedance/seedream-3", "bytedance/seedream-4":
-		return getSeedreamSchema(modelID), nil
-	case "openai/gpt-image-1":
-		return getGPTImageSchema(), nil
-	case "openai/gpt-image-1.5":
-		return getGPTImage15Schema(), nil
-	default:
-		return nil, fmt.Errorf("unknown model ID: %s", modelID)
-	}
-}
-
-func getGPTImageSchema() *ModelConfigSchema {
-	return &ModelConfigSchema{
-		ModelID:     "openai/gpt-image-1",
-		DisplayName: "OpenAI GPT Image 1",
-		Fields: []ModelConfigField{
-			{
-				Name:        "openai_api_key",
-				Type:        "string",
-				Default:     "",
-				Description: "Your OpenAI API key",
-				Required:    true,
-			},
-			{
-				Name:        "prompt",
-				Type:        "string",
-				Default:     "",
-				Description: "A text description of the desired image",
-				Required:    true,
-			},
-			{
-				Name:        "quality",
-				Type:        "string",
-				Default:     "auto",
-				Description: "The quality of the generated image",
-				Options:     []string{"low", "medium", "high", "auto"},
-				Required:    true,
-			},
-			{
-				Name:    "user_id",
-				Type:    "string",
-				Default: "",
-				Description: "An optional unique identifier representing your end-user. " +
-					"This helps OpenAI monitor and detect abuse.",
-			},
-			{
-				Name:        "background",
-				Type:        "string",
-				Default:     "auto",
-				Description: "Set whether the background is transparent or opaque or choose automatically",
-				Options:     []string{"auto", "transparent", "opaque"},
-				Required:    true,
-			},
-			{
-				Name:        "moderation",
-				Type:        "string",
-				Default:     "auto",
-				Description: "Content moderation level",
-				Options:     []string{"auto", "low"},
-				Required:    true,
-			},
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "1:1",
-				Description: "The aspect ratio of the generated image",
-				Options:     []string{"1:1", "3:2", "2:3"},
-				Required:    true,
-			},
-			{
-				Name:        "number_of_images",
-				Type:        "int",
-				Default:     1,
-				Description: "Number of images to generate (1-10)",
-				Min:         ptr(1.0),
-				Max:         ptr(10.0),
-				Required:    true,
-			},
-			{
-				Name:        "output_compression",
-				Type:        "int",
-				Default:     90,
-				Description: "Compression level (0-100%)",
-				Min:         ptr(0.0),
-				Max:         ptr(100.0),
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Default:     "webp",
-				Description: "Output image format",
-				Options:     []string{"png", "jpeg", "webp"},
-				Required:    true,
-			},
-			{
-				Name:        "input_fidelity",
-				Type:        "string",
-				Default:     "low",
-				Description: "Input image fidelity level",
-				Options:     []string{"low", "high"},
-				Required:    true,
-			},
-		},
-	}
-}
-
-func getGPTImage15Schema() *ModelConfigSchema {
-	return &ModelConfigSchema{
-		ModelID:     "openai/gpt-image-1.5",
-		DisplayName: "OpenAI GPT Image 1.5",
-		Fields: []ModelConfigField{
-			{
-				Name:        "openai_api_key",
-				Type:        "string",
-				Default:     "",
-				Description: "Your OpenAI API key",
-				Required:    true,
-			},
-			{
-				Name:        "prompt",
-				Type:        "string",
-				Default:     "",
-				Description: "A text description of the desired image",
-				Required:    true,
-			},
-			{
-				Name:        "quality",
-				Type:        "string",
-				Default:     "auto",
-				Description: "The quality of the generated image",
-				Options:     []string{"low", "medium", "high", "auto"},
-				Required:    true,
-			},
-			{
-				Name:    "user_id",
-				Type:    "string",
-				Default: "",
-				Description: "An optional unique identifier representing your end-user. " +
-					"This helps OpenAI monitor and detect abuse.",
-			},
-			{
-				Name:        "background",
-				Type:        "string",
-				Default:     "auto",
-				Description: "Set whether the background is transparent or opaque or choose automatically",
-				Options:     []string{"auto", "transparent", "opaque"},
-				Required:    true,
-			},
-			{
-				Name:        "moderation",
-				Type:        "string",
-				Default:     "auto",
-				Description: "Content moderation level",
-				Options:     []string{"auto", "low"},
-				Required:    true,
-			},
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "1:1",
-				Description: "The aspect ratio of the generated image",
-				Options:     []string{"1:1", "3:2", "2:3"},
-				Required:    true,
-			},
-			{
-				Name:        "number_of_images",
-				Type:        "int",
-				Default:     1,
-				Description: "Number of images to generate (1-10)",
-				Min:         ptr(1.0),
-				Max:         ptr(10.0),
-				Required:    true,
-			},
-			{
-				Name:        "output_compression",
-				Type:        "int",
-				Default:     90,
-				Description: "Compression level (0-100%)",
-				Min:         ptr(0.0),
-				Max:         ptr(100.0),
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Default:     "webp",
-				Description: "Output image format",
-				Options:     []string{"png", "jpeg", "webp"},
-				Required:    true,
-			},
-			{
-				Name:        "input_fidelity",
-				Type:        "string",
-				Default:     "low",
-				Description: "Input image fidelity level",
-				Options:     []string{"low", "high"},
-				Required:    true,
-			},
-		},
-	}
-}
-
-func getQwenSchema() *ModelConfigSchema {
-	return &ModelConfigSchema{
-		ModelID:     "qwen/qwen-image-edit",
-		DisplayName: "Qwen Image Edit",
-		Fields: []ModelConfigField{
-			{
-				Name:        "go_fast",
-				Type:        "bool",
-				Default:     true,
-				Description: "Enable fast mode for quicker processing",
-				Required:    true,
-			},
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "match_input_image",
-				Description: "Output aspect ratio",
-				Options:     []string{"1:1", "16:9", "4:3", "3:2", "match_input_image"},
-				Required:    true,
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Default:     "webp",
-				Description: "Output image format",
-				Options:     []string{"webp", "png", "jpg"},
-				Required:    true,
-			},
-			{
-				Name:        "output_quality",
-				Type:        "int",
-				Default:     80,
-				Description: "Output image quality (1-100)",
-				Min:         ptr(1.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-		},
-	}
-}
-
-func getFluxKontextSchema(modelID string) *ModelConfigSchema {
-	displayName := "Flux Kontext Max"
-	if modelID == "black-forest-labs/flux-kontext-pro" {
-		displayName = "Flux Kontext Pro"
-	}
-
-	return &ModelConfigSchema{
-		ModelID:     modelID,
-		DisplayName: displayName,
-		Fields: []ModelConfigField{
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "match_input_image",
-				Description: "Output aspect ratio",
-				Options:     []string{"1:1", "16:9", "4:3", "3:2", "match_input_image"},
-				Required:    true,
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Default:     "png",
-				Description: "Output image format",
-				Options:     []string{"webp", "png", "jpg"},
-				Required:    true,
-			},
-			{
-				Name:        "safety_tolerance",
-				Type:        "int",
-				Default:     4,
-				Description: "Safety filter tolerance (1=strict, 6=permissive)",
-				Min:         ptr(1.0),
-				Max:         ptr(6.0),
-				Required:    true,
-			},
-			{
-				Name:        "prompt_upsampling",
-				Type:        "bool",
-				Default:     false,
-				Description: "Enhance prompts automatically",
-				Required:    true,
-			},
-			{
-				Name:        "num_outputs",
-				Type:        "int",
-				Default:     1,
-				Description: "Number of images to generate",
-				Min:         ptr(1.0),
-				Max:         ptr(4.0),
-				Required:    true,
-			},
-			{
-				Name:        "output_quality",
-				Type:        "int",
-				Default:     90,
-				Description: "Output image quality (1-100)",
-				Min:         ptr(1.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-		},
-	}
-}
-
-func getSeedreamSchema(modelID string) *ModelConfigSchema {
-	displayName := "Seedream 3"
-	if modelID == "bytedance/seedream-4" {
-		displayName = "Seedream 4"
-	}
-
-	return &ModelConfigSchema{
-		ModelID:     modelID,
-		DisplayName: displayName,
-		Fields: []ModelConfigField{
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "1:1",
-				Description: "Output aspect ratio",
-				Options:     []string{"1:1", "16:9", "4:3", "3:2"},
-				Required:    true,
-			},
-			{
-				Name:        "num_inference_steps",
-				Type:        "int",
-				Default:     50,
-				Description: "Number of denoising steps (more = higher quality, slower)",
-				Min:         ptr(20.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-			{
-				Name:        "guidance_scale",
-				Type:        "float",
-				Default:     7.5,
-				Description: "How closely to follow the prompt (1.0-20.0)",
-				Min:         ptr(1.0),
-				Max:         ptr(20.0),
-				Required:    true,
-			},
-			{
-				Name:        "output_quality",
-				Type:        "int",
-				Default:     95,
-				Description: "Output image quality (1-100)",
-				Min:         ptr(1.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-		},
+// keepStoredSecrets replaces each secret in config that the client sent back
+// redacted with the stored value. A redacted secret with no stored value is
+// dropped, so validation reports it if it's required.
+func (s *DefaultService) keepStoredSecrets(
+	ctx context.Context, schema *modelconfig.Schema, config map[string]interface{},
+) error {
+	var stored map[string]interface{}
+	for _, f := range schema.Fields {
+		if !f.Secret || config[f.Name] != modelconfig.Redacted {
+			continue
+		}
+		if stored == nil {
+			configJSON, err := s.repo.GetModelConfig(ctx, schema.ModelID)
+			if err != nil {
+				return fmt.Errorf("failed to get model config: %w", err)
+			}
+			if err := json.Unmarshal(configJSON, &stored); err != nil {
+				return fmt.Errorf("failed to parse model config: %w", err)
+			}
+			if stored == nil {
+				stored = map[string]interface{}{}
+			}
+		}
+		if v, ok := stored[f.Name].(string); ok && v != "" {
+			config[f.Name] = v
+		} else {
+			delete(config, f.Name)
+		}
 	}
+	return nil
 }
 
-func ptr(f float64) *float64 {
-	return &f
+// GetModelConfigSchema returns the schema for a model's configuration.
+func (s *DefaultService) GetModelConfigSchema(ctx context.Context, modelID string) (*modelconfig.Schema, error) {
+	return modelconfig.Get(modelID)
 }
@@ -1,9 +1,17 @@
 package settings
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/real-staging-ai/api/encryption"
+	"github.com/real-staging-ai/api/modelconfig"
 )
 
 func TestDefaultService_GetActiveModel(t *testing.T) {
@@ -22,7 +30,7 @@ func TestDefaultService_GetActiveModel(t *testing.T) {
 			},
 		}
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		modelID, err := service.GetActiveModel(ctx)
 
 		if err != nil {
@@ -45,7 +53,7 @@ func TestDefaultService_GetActiveModel(t *testing.T) {
 			},
 		}
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		_, err := service.GetActiveModel(ctx)
 
 		if err == nil {
@@ -54,32 +62,49 @@ func TestDefaultService_GetActiveModel(t *testing.T) {
 	})
 }
 
+// testModels is a catalog with an enabled and a disabled model.
+var testModels = []ModelInfo{
+	{ID: "qwen/qwen-image-edit", Name: "Qwen Image Edit", Provider: ProviderReplicate, Enabled: true},
+	{ID: "black-forest-labs/flux-kontext-max", Name: "Flux Kontext Max", Provider: ProviderReplicate, Enabled: true},
+	{ID: "bytedance/seedream-4", Name: "Seedream 4", Provider: ProviderReplicate, Enabled: true},
+	{ID: "bytedance/seedream-3", Name: "Seedream 3", Provider: ProviderReplicate},
+	{ID: "openai/gpt-image-1", Name: "GPT Image 1", Provider: "openai", Enabled: true},
+}
+
+// catalogRepo returns a repository holding testModels with qwen/qwen-image-edit active.
+func catalogRepo() *RepositoryMock {
+	return &RepositoryMock{
+		GetByKeyFunc: func(ctx context.Context, key string) (*Setting, error) {
+			return &Setting{
+				Key:   "active_model",
+				Value: "qwen/qwen-image-edit",
+			}, nil
+		},
+		ListModelsFunc: func(ctx context.Context) ([]ModelInfo, error) {
+			return append([]ModelInfo(nil), testModels...), nil
+		},
+	}
+}
+
 func TestDefaultService_UpdateActiveModel(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("success: updates active model", func(t *testing.T) {
-		repo := &RepositoryMock{
-			GetByKeyFunc: func(ctx context.Context, key string) (*Setting, error) {
-				return &Setting{
-					Key:   "active_model",
-					Value: "qwen/qwen-image-edit",
-				}, nil
-			},
-			UpdateFunc: func(ctx context.Context, key, value, userID string) error {
-				if key != "active_model" {
-					t.Errorf("expected key 'active_model', got %s", key)
-				}
-				if value != "black-forest-labs/flux-kontext-max" {
-					t.Errorf("expected 'black-forest-labs/flux-kontext-max', got %s", value)
-				}
-				if userID != "user123" {
-					t.Errorf("expected 'user123', got %s", userID)
-				}
-				return nil
-			},
+		repo := catalogRepo()
+		repo.UpdateFunc = func(ctx context.Context, key, value, userID string) error {
+			if key != "active_model" {
+				t.Errorf("expected key 'active_model', got %s", key)
+			}
+			if value != "black-forest-labs/flux-kontext-max" {
+				t.Errorf("expected 'black-forest-labs/flux-kontext-max', got %s", value)
+			}
+			if userID != "user123" {
+				t.Errorf("expected 'user123', got %s", userID)
+			}
+			return nil
 		}
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		err := service.UpdateActiveModel(ctx, "black-forest-labs/flux-kontext-max", "user123")
 
 		if err != nil {
@@ -92,28 +117,15 @@ func TestDefaultService_UpdateActiveModel(t *testing.T) {
 	})
 
 	t.Run("success: updates to Seedream-4 model", func(t *testing.T) {
-		repo := &RepositoryMock{
-			GetByKeyFunc: func(ctx context.Context, key string) (*Setting, error) {
-				return &Setting{
-					Key:   "active_model",
-					Value: "qwen/qwen-image-edit",
-				}, nil
-			},
-			UpdateFunc: func(ctx context.Context, key, value, userID string) error {
-				if key != "active_model" {
-					t.Errorf("expected key 'active_model', got %s", key)
-				}
-				if value != "bytedance/seedream-4" {
-					t.Errorf("expected 'bytedance/seedream-4', got %s", value)
-				}
-				if userID != "user123" {
-					t.Errorf("expected 'user123', got %s", userID)
-				}
-				return nil
-			},
+		repo := catalogRepo()
+		repo.UpdateFunc = func(ctx context.Context, key, value, userID string) error {
+			if value != "bytedance/seedream-4" {
+				t.Errorf("expected 'bytedance/seedream-4', got %s", value)
+			}
+			return nil
 		}
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		err := service.UpdateActiveModel(ctx, "bytedance/seedream-4", "user123")
 
 		if err != nil {
@@ -126,16 +138,9 @@ func TestDefaultService_UpdateActiveModel(t *testing.T) {
 	})
 
 	t.Run("fail: invalid model ID", func(t *testing.T) {
-		repo := &RepositoryMock{
-			GetByKeyFunc: func(ctx context.Context, key string) (*Setting, error) {
-				return &Setting{
-					Key:   "active_model",
-					Value: "qwen/qwen-image-edit",
-				}, nil
-			},
-		}
+		repo := catalogRepo()
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		err := service.UpdateActiveModel(ctx, "invalid/model", "user123")
 
 		if err == nil {
@@ -146,105 +151,277 @@ func TestDefaultService_UpdateActiveModel(t *testing.T) {
 			t.Errorf("expected 0 calls to Update, got %d", len(repo.UpdateCalls()))
 		}
 	})
+
+	t.Run("fail: disabled model", func(t *testing.T) {
+		repo := catalogRepo()
+
+		service := NewDefaultService(repo, nil)
+		err := service.UpdateActiveModel(ctx, "bytedance/seedream-3", "user123")
+
+		if err == nil || !strings.Contains(err.Error(), "disabled") {
+			t.Fatalf("expected disabled model error, got %v", err)
+		}
+
+		if len(repo.UpdateCalls()) != 0 {
+			t.Errorf("expected 0 calls to Update, got %d", len(repo.UpdateCalls()))
+		}
+	})
 }
 
 func TestDefaultService_ListAvailableModels(t *testing.T) {
 	ctx := context.Background()
 
-	t.Run("success: lists all models with active flag", func(t *testing.T) {
-		repo := &RepositoryMock{
-			GetByKeyFunc: func(ctx context.Context, key string) (*Setting, error) {
-				return &Setting{
-					Key:   "active_model",
-					Value: "qwen/qwen-image-edit",
-				}, nil
-			},
-		}
+	t.Run("success: lists the catalog with active flag", func(t *testing.T) {
+		SetCatalog(nil)
+		repo := catalogRepo()
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		models, err := service.ListAvailableModels(ctx)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
-		if len(models) != 7 {
-			t.Fatalf("expected 7 models, got %d", len(models))
+		if len(models) != len(testModels) {
+			t.Fatalf("expected %d models, got %d", len(testModels), len(models))
 		}
 
-		// Check all models
-		qwenFound := false
-		fluxMaxFound := false
-		fluxProFound := false
-		seedream3Found := false
-		seedream4Found := false
-		gptImage1Found := false
-		gptImage1_5Found := false
 		for _, model := range models {
-			if model.ID == "qwen/qwen-image-edit" {
-				qwenFound = true
-				if !model.IsActive {
-					t.Error("expected Qwen model to be active")
-				}
-			}
-			if model.ID == "black-forest-labs/flux-kontext-max" {
-				fluxMaxFound = true
-				if model.IsActive {
-					t.Error("expected Flux Kontext Max model to not be active")
-				}
+			if model.IsActive != (model.ID == "qwen/qwen-image-edit") {
+				t.Errorf("model %s: IsActive = %v", model.ID, model.IsActive)
 			}
-			if model.ID == "black-forest-labs/flux-kontext-pro" {
-				fluxProFound = true
-				if model.IsActive {
-					t.Error("expected Flux Kontext Pro model to not be active")
-				}
-			}
-			if model.ID == "bytedance/seedream-3" {
-				seedream3Found = true
-				if model.IsActive {
-					t.Error("expected Seedream-3 model to not be active")
-				}
-			}
-			if model.ID == "bytedance/seedream-4" {
-				seedream4Found = true
-				if model.IsActive {
-					t.Error("expected Seedream-4 model to not be active")
-				}
-			}
-			if model.ID == "openai/gpt-image-1" {
-				gptImage1Found = true
-				if model.IsActive {
-					t.Error("expected GPT Image 1 model to not be active")
-				}
+		}
+		if models[3].Enabled {
+			t.Error("expected Seedream 3 to stay disabled")
+		}
+
+		// The listing refreshes this instance's catalog
+		if !IsAvailableModel("bytedance/seedream-4") || IsAvailableModel("bytedance/seedream-3") {
+			t.Errorf("catalog not refreshed: %v", AvailableModelIDs())
+		}
+	})
+
+	t.Run("fail: repository error", func(t *testing.T) {
+		repo := catalogRepo()
+		repo.ListModelsFunc = func(ctx context.Context) ([]ModelInfo, error) {
+			return nil, fmt.Errorf("db down")
+		}
+
+		service := NewDefaultService(repo, nil)
+		if _, err := service.ListAvailableModels(ctx); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestDefaultService_SetModelEnabled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: enables a model", func(t *testing.T) {
+		repo := catalogRepo()
+		repo.SetModelEnabledFunc = func(ctx context.Context, modelID string, enabled bool, userID string) error {
+			if modelID != "bytedance/seedream-3" || !enabled || userID != "user123" {
+				t.Errorf("SetModelEnabled(%s, %v, %s)", modelID, enabled, userID)
 			}
-			if model.ID == "openai/gpt-image-1.5" {
-				gptImage1_5Found = true
-				if model.IsActive {
-					t.Error("expected GPT Image 1.5 model to not be active")
-				}
+			return nil
+		}
+
+		model, err := NewDefaultService(repo, nil).SetModelEnabled(ctx, "bytedance/seedream-3", true, "user123")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if model.ID != "bytedance/seedream-3" {
+			t.Errorf("expected Seedream 3, got %s", model.ID)
+		}
+	})
+
+	t.Run("fail: disabling the active model", func(t *testing.T) {
+		repo := catalogRepo()
+
+		_, err := NewDefaultService(repo, nil).SetModelEnabled(ctx, "qwen/qwen-image-edit", false, "user123")
+
+		if !errors.Is(err, ErrActiveModel) {
+			t.Fatalf("expected ErrActiveModel, got %v", err)
+		}
+		if len(repo.SetModelEnabledCalls()) != 0 {
+			t.Errorf("expected 0 calls to SetModelEnabled, got %d", len(repo.SetModelEnabledCalls()))
+		}
+	})
+
+	t.Run("fail: unknown model", func(t *testing.T) {
+		repo := catalogRepo()
+		repo.SetModelEnabledFunc = func(ctx context.Context, modelID string, enabled bool, userID string) error {
+			return fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
+		}
+
+		_, err := NewDefaultService(repo, nil).SetModelEnabled(ctx, "acme/painter", false, "user123")
+
+		if !errors.Is(err, ErrModelNotFound) {
+			t.Fatalf("expected ErrModelNotFound, got %v", err)
+		}
+	})
+}
+
+func TestDefaultService_UpdateModelConfig(t *testing.T) {
+	ctx := context.Background()
+	qwenConfig := func() map[string]interface{} {
+		return map[string]interface{}{
+			"go_fast": true, "aspect_ratio": "16:9", "output_format": "png", "output_quality": 90.0,
+		}
+	}
+
+	t.Run("success: stores a valid config", func(t *testing.T) {
+		repo := catalogRepo()
+		repo.UpdateModelConfigFunc = func(ctx context.Context, modelID string, config []byte, userID string) error {
+			if modelID != "qwen/qwen-image-edit" || !strings.Contains(string(config), `"aspect_ratio":"16:9"`) {
+				t.Errorf("UpdateModelConfig(%s, %s)", modelID, config)
 			}
+			return nil
+		}
+
+		err := NewDefaultService(repo, nil).UpdateModelConfig(ctx, "qwen/qwen-image-edit", qwenConfig(), "user123")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(repo.UpdateModelConfigCalls()) != 1 {
+			t.Errorf("expected 1 call to UpdateModelConfig, got %d", len(repo.UpdateModelConfigCalls()))
+		}
+	})
+
+	t.Run("fail: config doesn't match the schema", func(t *testing.T) {
+		repo := catalogRepo()
+		config := qwenConfig()
+		config["output_quality"] = 101.0
+		config["aspect_ratio"] = "2:1"
+
+		err := NewDefaultService(repo, nil).UpdateModelConfig(ctx, "qwen/qwen-image-edit", config, "user123")
+
+		var invalid *modelconfig.ValidationError
+		if !errors.As(err, &invalid) {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+		if len(invalid.Fields) != 2 || invalid.Fields[0].Field != "aspect_ratio" ||
+			invalid.Fields[1].Field != "output_quality" {
+			t.Errorf("unexpected field errors: %+v", invalid.Fields)
+		}
+		if len(repo.UpdateModelConfigCalls()) != 0 {
+			t.Errorf("expected 0 calls to UpdateModelConfig, got %d", len(repo.UpdateModelConfigCalls()))
+		}
+	})
+
+	t.Run("fail: unknown model", func(t *testing.T) {
+		repo := catalogRepo()
+
+		err := NewDefaultService(repo, nil).UpdateModelConfig(ctx, "acme/painter", qwenConfig(), "user123")
+
+		if err == nil || !strings.Contains(err.Error(), "invalid model ID") {
+			t.Fatalf("expected invalid model ID error, got %v", err)
+		}
+	})
+}
+
+func TestDefaultService_UpdateModelConfig_secrets(t *testing.T) {
+	ctx := context.Background()
+	keyring, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, encryption.KeySize)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gptConfig := func(apiKey string) map[string]interface{} {
+		return map[string]interface{}{
+			"openai_api_key": apiKey, "quality": "auto", "background": "auto", "moderation": "auto",
+			"aspect_ratio": "1:1", "number_of_images": 1.0, "output_format": "webp", "input_fidelity": "low",
+		}
+	}
+	// storedRepo returns a repository that records the stored config and
+	// reads back storedJSON.
+	storedRepo := func(storedJSON string, saved *map[string]interface{}) *RepositoryMock {
+		repo := catalogRepo()
+		repo.GetModelConfigFunc = func(ctx context.Context, modelID string) ([]byte, error) {
+			return []byte(storedJSON), nil
+		}
+		repo.UpdateModelConfigFunc = func(ctx context.Context, modelID string, config []byte, userID string) error {
+			return json.Unmarshal(config, saved)
+		}
+		return repo
+	}
+
+	t.Run("success: seals the API key", func(t *testing.T) {
+		var saved map[string]interface{}
+		repo := storedRepo(`{}`, &saved)
+
+		err := NewDefaultService(repo, keyring).UpdateModelConfig(ctx, "openai/gpt-image-1", gptConfig("sk-live"), "u1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sealed, _ := saved["openai_api_key"].(string)
+		if !modelconfig.IsSealed(sealed) || strings.Contains(sealed, "sk-live") {
+			t.Errorf("openai_api_key stored as %q", sealed)
+		}
+	})
+
+	t.Run("success: redacted key keeps the stored one", func(t *testing.T) {
+		var saved map[string]interface{}
+		repo := storedRepo(`{"openai_api_key":"sealed:c3RvcmVk"}`, &saved)
+
+		err := NewDefaultService(repo, keyring).UpdateModelConfig(
+			ctx, "openai/gpt-image-1", gptConfig(modelconfig.Redacted), "u1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
+		if saved["openai_api_key"] != "sealed:c3RvcmVk" {
+			t.Errorf("openai_api_key stored as %v", saved["openai_api_key"])
+		}
+	})
+
+	t.Run("success: redacted key with nothing stored is left out", func(t *testing.T) {
+		var saved map[string]interface{}
+		repo := storedRepo(`{}`, &saved)
 
-		if !qwenFound {
-			t.Error("expected Qwen model in list")
+		err := NewDefaultService(repo, keyring).UpdateModelConfig(
+			ctx, "openai/gpt-image-1", gptConfig(modelconfig.Redacted), "u1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if !fluxMaxFound {
-			t.Error("expected Flux Kontext Max model in list")
+		if _, ok := saved["openai_api_key"]; ok {
+			t.Errorf("openai_api_key stored as %v", saved["openai_api_key"])
 		}
-		if !fluxProFound {
-			t.Error("expected Flux Kontext Pro model in list")
+	})
+
+	t.Run("fail: no encryption key", func(t *testing.T) {
+		var saved map[string]interface{}
+		repo := storedRepo(`{}`, &saved)
+
+		err := NewDefaultService(repo, nil).UpdateModelConfig(ctx, "openai/gpt-image-1", gptConfig("sk-live"), "u1")
+
+		if !errors.Is(err, modelconfig.ErrNoKeyring) {
+			t.Fatalf("expected ErrNoKeyring, got %v", err)
 		}
-		if !seedream3Found {
-			t.Error("expected Seedream-3 model in list")
+		if len(repo.UpdateModelConfigCalls()) != 0 {
+			t.Errorf("expected 0 calls to UpdateModelConfig, got %d", len(repo.UpdateModelConfigCalls()))
 		}
-		if !seedream4Found {
-			t.Error("expected Seedream-4 model in list")
+	})
+}
+
+func TestDefaultService_GetModelConfig(t *testing.T) {
+	t.Run("success: redacts secrets", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetModelConfigFunc: func(ctx context.Context, modelID string) ([]byte, error) {
+				return []byte(`{"openai_api_key":"sealed:abc","quality":"high"}`), nil
+			},
 		}
-		if !gptImage1Found {
-			t.Error("expected GPT Image 1 model in list")
+
+		got, err := NewDefaultService(repo, nil).GetModelConfig(context.Background(), "openai/gpt-image-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if !gptImage1_5Found {
-			t.Error("expected GPT Image 1.5 model in list")
+		if got.Config["openai_api_key"] != modelconfig.Redacted || got.Config["quality"] != "high" {
+			t.Errorf("GetModelConfig() = %v", got.Config)
 		}
 	})
 }
@@ -264,7 +441,7 @@ func TestDefaultService_GetSetting(t *testing.T) {
 			},
 		}
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		setting, err := service.GetSetting(ctx, "test-key")
 
 		if err != nil {
@@ -287,7 +464,7 @@ func TestDefaultService_UpdateSetting(t *testing.T) {
 			},
 		}
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		err := service.UpdateSetting(ctx, "test-key", "test-value", "user123")
 
 		if err != nil {
@@ -298,6 +475,48 @@ func TestDefaultService_UpdateSetting(t *testing.T) {
 			t.Errorf("expected 1 call to Update, got %d", len(repo.UpdateCalls()))
 		}
 	})
+
+	watermarkCases := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{name: "success: watermark text", key: KeyWatermarkText, value: "Virtually Staged"},
+		{name: "success: watermark position", key: KeyWatermarkPosition, value: "bottom_left"},
+		{name: "success: watermark opacity", key: KeyWatermarkOpacity, value: "0.75"},
+		{name: "fail: blank watermark text", key: KeyWatermarkText, value: "  ", wantErr: true},
+		{name: "fail: watermark text too long", key: KeyWatermarkText, value: strings.Repeat("x", 65), wantErr: true},
+		{name: "fail: unknown watermark position", key: KeyWatermarkPosition, value: "middle", wantErr: true},
+		{name: "fail: watermark opacity not a number", key: KeyWatermarkOpacity, value: "half", wantErr: true},
+		{name: "fail: watermark opacity too faint", key: KeyWatermarkOpacity, value: "0.05", wantErr: true},
+		{name: "success: provider incident", key: KeyProviderIncident, value: `{"active":true,"source":"admin"}`},
+		{name: "fail: provider incident not JSON", key: KeyProviderIncident, value: "on", wantErr: true},
+	}
+	for _, tc := range watermarkCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				UpdateFunc: func(ctx context.Context, key, value, userID string) error {
+					return nil
+				},
+			}
+
+			err := NewDefaultService(repo, nil).UpdateSetting(ctx, tc.key, tc.value, "user123")
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(repo.UpdateCalls()) != 0 {
+					t.Error("expected invalid values not to be stored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
 }
 
 func TestDefaultService_ListSettings(t *testing.T) {
@@ -313,7 +532,7 @@ func TestDefaultService_ListSettings(t *testing.T) {
 			},
 		}
 
-		service := NewDefaultService(repo)
+		service := NewDefaultService(repo, nil)
 		settings, err := service.ListSettings(ctx)
 
 		if err != nil {
@@ -0,0 +1,41 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// KeyProviderIncident holds the provider incident flag as JSON. Workers raise
+// and clear it from their circuit breaker; an incident set by an admin with
+// source "admin" stays until an admin clears it.
+const KeyProviderIncident = "provider_incident"
+
+// IncidentSourceAdmin marks an incident set by hand, which workers leave alone.
+const IncidentSourceAdmin = "admin"
+
+// Incident is the value of KeyProviderIncident.
+type Incident struct {
+	Active bool   `json:"active"`
+	Source string `json:"source,omitempty"`
+	// Models are the affected models; empty means every model.
+	Models []string   `json:"models,omitempty"`
+	Since  *time.Time `json:"since,omitempty"`
+	// RetryAt is when the provider is next tried.
+	RetryAt *time.Time `json:"retry_at,omitempty"`
+	// Message replaces the default status banner.
+	Message string `json:"message,omitempty"`
+}
+
+// ParseIncident decodes a KeyProviderIncident value. An empty value is no
+// incident.
+func ParseIncident(value string) (Incident, error) {
+	var incident Incident
+	if value == "" {
+		return incident, nil
+	}
+	if err := json.Unmarshal([]byte(value), &incident); err != nil {
+		return Incident{}, fmt.Errorf("%s must be a JSON incident: %w", KeyProviderIncident, err)
+	}
+	return incident, nil
+}
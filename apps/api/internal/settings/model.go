@@ -1,6 +1,17 @@
 package settings
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrModelNotFound is returned for a model that isn't in the catalog.
+	ErrModelNotFound = errors.New("model not found")
+	// ErrActiveModel is returned when disabling the active model.
+	ErrActiveModel = errors.New("the active model can't be disabled")
+)
 
 // Setting represents a system configuration setting.
 type Setting struct {
@@ -11,37 +22,50 @@ type Setting struct {
 	UpdatedBy   *string   `json:"updated_by,omitempty"`
 }
 
-// ModelInfo represents information about an available AI model.
+// ModelInfo represents a staging model in the catalog.
+// CostPerImageUSD is the provider's list price for staging one image.
 type ModelInfo struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Version     string `json:"version"`
-	IsActive    bool   `json:"is_active"`
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Provider        string  `json:"provider"`
+	Version         string  `json:"version"`
+	CostPerImageUSD float64 `json:"cost_per_image_usd"`
+	// Enabled models can be chosen for new jobs.
+	Enabled  bool `json:"enabled"`
+	IsActive bool `json:"is_active"`
+	// LatestVersion and SyncedAt are set once a sync has read the model from
+	// Replicate.
+	LatestVersion *string    `json:"latest_version,omitempty"`
+	SyncedAt      *time.Time `json:"synced_at,omitempty"`
 }
 
-// UpdateSettingRequest represents a request to update a setting.
-type UpdateSettingRequest struct {
-	Value string `json:"value"`
+// ProviderReplicate is the provider of models the model sync reads from Replicate.
+const ProviderReplicate = "replicate"
+
+// UpdateModelRequest is the body of PATCH /admin/models/:id.
+type UpdateModelRequest struct {
+	Enabled *bool `json:"enabled"`
 }
 
-// ModelConfigField represents metadata for a configuration field.
-type ModelConfigField struct {
-	Name        string      `json:"name"`
-	Type        string      `json:"type"`
-	Default     interface{} `json:"default"`
-	Description string      `json:"description"`
-	Options     []string    `json:"options,omitempty"`
-	Min         *float64    `json:"min,omitempty"`
-	Max         *float64    `json:"max,omitempty"`
-	Required    bool        `json:"required"`
+// ModelSync is what a sync read about a model from Replicate.
+type ModelSync struct {
+	Description   string
+	LatestVersion string
+	// InputSchema is the OpenAPI schema of the latest version's input.
+	InputSchema json.RawMessage
 }
 
-// ModelConfigSchema describes the configuration structure for a model.
-type ModelConfigSchema struct {
-	ModelID     string             `json:"model_id"`
-	DisplayName string             `json:"display_name"`
-	Fields      []ModelConfigField `json:"fields"`
+// SyncResult reports a model sync. Models not on Replicate are skipped.
+type SyncResult struct {
+	Synced  []string `json:"synced"`
+	Skipped []string `json:"skipped"`
+	Failed  []string `json:"failed"`
+}
+
+// UpdateSettingRequest represents a request to update a setting.
+type UpdateSettingRequest struct {
+	Value string `json:"value"`
 }
 
 // ModelConfig represents the stored configuration for a model.
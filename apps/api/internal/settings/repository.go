@@ -22,4 +22,14 @@ type Repository interface {
 
 	// UpdateModelConfig updates the configuration JSON for a specific model.
 	UpdateModelConfig(ctx context.Context, modelID string, configJSON []byte, userID string) error
+
+	// ListModels retrieves the model catalog in display order.
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+
+	// SetModelEnabled enables or disables a model. Returns ErrModelNotFound if
+	// the model isn't in the catalog.
+	SetModelEnabled(ctx context.Context, modelID string, enabled bool, userID string) error
+
+	// UpdateModelSync stores what a sync read about a model from Replicate.
+	UpdateModelSync(ctx context.Context, modelID string, sync ModelSync) error
 }
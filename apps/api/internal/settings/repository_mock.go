@@ -27,12 +27,21 @@ var _ Repository = &RepositoryMock{}
 //			ListFunc: func(ctx context.Context) ([]Setting, error) {
 //				panic("mock out the List method")
 //			},
+//			ListModelsFunc: func(ctx context.Context) ([]ModelInfo, error) {
+//				panic("mock out the ListModels method")
+//			},
+//			SetModelEnabledFunc: func(ctx context.Context, modelID string, enabled bool, userID string) error {
+//				panic("mock out the SetModelEnabled method")
+//			},
 //			UpdateFunc: func(ctx context.Context, key string, value string, userID string) error {
 //				panic("mock out the Update method")
 //			},
 //			UpdateModelConfigFunc: func(ctx context.Context, modelID string, configJSON []byte, userID string) error {
 //				panic("mock out the UpdateModelConfig method")
 //			},
+//			UpdateModelSyncFunc: func(ctx context.Context, modelID string, sync ModelSync) error {
+//				panic("mock out the UpdateModelSync method")
+//			},
 //		}
 //
 //		// use mockedRepository in code that requires Repository
@@ -49,12 +58,21 @@ type RepositoryMock struct {
 	// ListFunc mocks the List method.
 	ListFunc func(ctx context.Context) ([]Setting, error)
 
+	// ListModelsFunc mocks the ListModels method.
+	ListModelsFunc func(ctx context.Context) ([]ModelInfo, error)
+
+	// SetModelEnabledFunc mocks the SetModelEnabled method.
+	SetModelEnabledFunc func(ctx context.Context, modelID string, enabled bool, userID string) error
+
 	// UpdateFunc mocks the Update method.
 	UpdateFunc func(ctx context.Context, key string, value string, userID string) error
 
 	// UpdateModelConfigFunc mocks the UpdateModelConfig method.
 	UpdateModelConfigFunc func(ctx context.Context, modelID string, configJSON []byte, userID string) error
 
+	// UpdateModelSyncFunc mocks the UpdateModelSync method.
+	UpdateModelSyncFunc func(ctx context.Context, modelID string, sync ModelSync) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// GetByKey holds details about calls to the GetByKey method.
@@ -76,6 +94,22 @@ type RepositoryMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// ListModels holds details about calls to the ListModels method.
+		ListModels []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// SetModelEnabled holds details about calls to the SetModelEnabled method.
+		SetModelEnabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ModelID is the modelID argument value.
+			ModelID string
+			// Enabled is the enabled argument value.
+			Enabled bool
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// Update holds details about calls to the Update method.
 		Update []struct {
 			// Ctx is the ctx argument value.
@@ -98,12 +132,24 @@ type RepositoryMock struct {
 			// UserID is the userID argument value.
 			UserID string
 		}
+		// UpdateModelSync holds details about calls to the UpdateModelSync method.
+		UpdateModelSync []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ModelID is the modelID argument value.
+			ModelID string
+			// Sync is the sync argument value.
+			Sync ModelSync
+		}
 	}
 	lockGetByKey          sync.RWMutex
 	lockGetModelConfig    sync.RWMutex
 	lockList              sync.RWMutex
+	lockListModels        sync.RWMutex
+	lockSetModelEnabled   sync.RWMutex
 	lockUpdate            sync.RWMutex
 	lockUpdateModelConfig sync.RWMutex
+	lockUpdateModelSync   sync.RWMutex
 }
 
 // GetByKey calls GetByKeyFunc.
@@ -210,6 +256,82 @@ func (mock *RepositoryMock) ListCalls() []struct {
 	return calls
 }
 
+// ListModels calls ListModelsFunc.
+func (mock *RepositoryMock) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	if mock.ListModelsFunc == nil {
+		panic("RepositoryMock.ListModelsFunc: method is nil but Repository.ListModels was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListModels.Lock()
+	mock.calls.ListModels = append(mock.calls.ListModels, callInfo)
+	mock.lockListModels.Unlock()
+	return mock.ListModelsFunc(ctx)
+}
+
+// ListModelsCalls gets all the calls that were made to ListModels.
+// Check the length with:
+//
+//	len(mockedRepository.ListModelsCalls())
+func (mock *RepositoryMock) ListModelsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListModels.RLock()
+	calls = mock.calls.ListModels
+	mock.lockListModels.RUnlock()
+	return calls
+}
+
+// SetModelEnabled calls SetModelEnabledFunc.
+func (mock *RepositoryMock) SetModelEnabled(ctx context.Context, modelID string, enabled bool, userID string) error {
+	if mock.SetModelEnabledFunc == nil {
+		panic("RepositoryMock.SetModelEnabledFunc: method is nil but Repository.SetModelEnabled was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ModelID string
+		Enabled bool
+		UserID  string
+	}{
+		Ctx:     ctx,
+		ModelID: modelID,
+		Enabled: enabled,
+		UserID:  userID,
+	}
+	mock.lockSetModelEnabled.Lock()
+	mock.calls.SetModelEnabled = append(mock.calls.SetModelEnabled, callInfo)
+	mock.lockSetModelEnabled.Unlock()
+	return mock.SetModelEnabledFunc(ctx, modelID, enabled, userID)
+}
+
+// SetModelEnabledCalls gets all the calls that were made to SetModelEnabled.
+// Check the length with:
+//
+//	len(mockedRepository.SetModelEnabledCalls())
+func (mock *RepositoryMock) SetModelEnabledCalls() []struct {
+	Ctx     context.Context
+	ModelID string
+	Enabled bool
+	UserID  string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ModelID string
+		Enabled bool
+		UserID  string
+	}
+	mock.lockSetModelEnabled.RLock()
+	calls = mock.calls.SetModelEnabled
+	mock.lockSetModelEnabled.RUnlock()
+	return calls
+}
+
 // Update calls UpdateFunc.
 func (mock *RepositoryMock) Update(ctx context.Context, key string, value string, userID string) error {
 	if mock.UpdateFunc == nil {
@@ -297,3 +419,43 @@ func (mock *RepositoryMock) UpdateModelConfigCalls() []struct {
 	mock.lockUpdateModelConfig.RUnlock()
 	return calls
 }
+
+// UpdateModelSync calls UpdateModelSyncFunc.
+func (mock *RepositoryMock) UpdateModelSync(ctx context.Context, modelID string, sync ModelSync) error {
+	if mock.UpdateModelSyncFunc == nil {
+		panic("RepositoryMock.UpdateModelSyncFunc: method is nil but Repository.UpdateModelSync was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ModelID string
+		Sync    ModelSync
+	}{
+		Ctx:     ctx,
+		ModelID: modelID,
+		Sync:    sync,
+	}
+	mock.lockUpdateModelSync.Lock()
+	mock.calls.UpdateModelSync = append(mock.calls.UpdateModelSync, callInfo)
+	mock.lockUpdateModelSync.Unlock()
+	return mock.UpdateModelSyncFunc(ctx, modelID, sync)
+}
+
+// UpdateModelSyncCalls gets all the calls that were made to UpdateModelSync.
+// Check the length with:
+//
+//	len(mockedRepository.UpdateModelSyncCalls())
+func (mock *RepositoryMock) UpdateModelSyncCalls() []struct {
+	Ctx     context.Context
+	ModelID string
+	Sync    ModelSync
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ModelID string
+		Sync    ModelSync
+	}
+	mock.lockUpdateModelSync.RLock()
+	calls = mock.calls.UpdateModelSync
+	mock.lockUpdateModelSync.RUnlock()
+	return calls
+}
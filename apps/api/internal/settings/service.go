@@ -2,6 +2,8 @@ package settings
 
 import (
 	"context"
+
+	"github.com/real-staging-ai/api/modelconfig"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
@@ -14,9 +16,12 @@ type Service interface {
 	// UpdateActiveModel updates the active AI model.
 	UpdateActiveModel(ctx context.Context, modelID, userID string) error
 
-	// ListAvailableModels returns all available AI models.
+	// ListAvailableModels returns the model catalog, disabled models included.
 	ListAvailableModels(ctx context.Context) ([]ModelInfo, error)
 
+	// SetModelEnabled enables or disables a model and returns it.
+	SetModelEnabled(ctx context.Context, modelID string, enabled bool, userID string) (*ModelInfo, error)
+
 	// GetSetting retrieves a setting by key.
 	GetSetting(ctx context.Context, key string) (*Setting, error)
 
@@ -26,12 +31,16 @@ type Service interface {
 	// ListSettings retrieves all settings.
 	ListSettings(ctx context.Context) ([]Setting, error)
 
-	// GetModelConfig retrieves the configuration for a specific model.
+	// GetModelConfig retrieves the configuration for a specific model. Secret
+	// fields that are set read as modelconfig.Redacted.
 	GetModelConfig(ctx context.Context, modelID string) (*ModelConfig, error)
 
-	// UpdateModelConfig updates the configuration for a specific model.
+	// UpdateModelConfig validates config against the model's schema and stores it
+	// with its secret fields sealed. Returns a *modelconfig.ValidationError if the
+	// config doesn't match, or modelconfig.ErrNoKeyring if a secret is set but no
+	// encryption key is configured.
 	UpdateModelConfig(ctx context.Context, modelID string, config map[string]interface{}, userID string) error
 
 	// GetModelConfigSchema returns the schema for a model's configuration.
-	GetModelConfigSchema(ctx context.Context, modelID string) (*ModelConfigSchema, error)
+	GetModelConfigSchema(ctx context.Context, modelID string) (*modelconfig.Schema, error)
 }
@@ -5,6 +5,7 @@ package settings
 
 import (
 	"context"
+	"github.com/real-staging-ai/api/modelconfig"
 	"sync"
 )
 
@@ -24,7 +25,7 @@ var _ Service = &ServiceMock{}
 //			GetModelConfigFunc: func(ctx context.Context, modelID string) (*ModelConfig, error) {
 //				panic("mock out the GetModelConfig method")
 //			},
-//			GetModelConfigSchemaFunc: func(ctx context.Context, modelID string) (*ModelConfigSchema, error) {
+//			GetModelConfigSchemaFunc: func(ctx context.Context, modelID string) (*modelconfig.Schema, error) {
 //				panic("mock out the GetModelConfigSchema method")
 //			},
 //			GetSettingFunc: func(ctx context.Context, key string) (*Setting, error) {
@@ -36,6 +37,9 @@ var _ Service = &ServiceMock{}
 //			ListSettingsFunc: func(ctx context.Context) ([]Setting, error) {
 //				panic("mock out the ListSettings method")
 //			},
+//			SetModelEnabledFunc: func(ctx context.Context, modelID string, enabled bool, userID string) (*ModelInfo, error) {
+//				panic("mock out the SetModelEnabled method")
+//			},
 //			UpdateActiveModelFunc: func(ctx context.Context, modelID string, userID string) error {
 //				panic("mock out the UpdateActiveModel method")
 //			},
@@ -59,7 +63,7 @@ type ServiceMock struct {
 	GetModelConfigFunc func(ctx context.Context, modelID string) (*ModelConfig, error)
 
 	// GetModelConfigSchemaFunc mocks the GetModelConfigSchema method.
-	GetModelConfigSchemaFunc func(ctx context.Context, modelID string) (*ModelConfigSchema, error)
+	GetModelConfigSchemaFunc func(ctx context.Context, modelID string) (*modelconfig.Schema, error)
 
 	// GetSettingFunc mocks the GetSetting method.
 	GetSettingFunc func(ctx context.Context, key string) (*Setting, error)
@@ -70,6 +74,9 @@ type ServiceMock struct {
 	// ListSettingsFunc mocks the ListSettings method.
 	ListSettingsFunc func(ctx context.Context) ([]Setting, error)
 
+	// SetModelEnabledFunc mocks the SetModelEnabled method.
+	SetModelEnabledFunc func(ctx context.Context, modelID string, enabled bool, userID string) (*ModelInfo, error)
+
 	// UpdateActiveModelFunc mocks the UpdateActiveModel method.
 	UpdateActiveModelFunc func(ctx context.Context, modelID string, userID string) error
 
@@ -117,6 +124,17 @@ type ServiceMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// SetModelEnabled holds details about calls to the SetModelEnabled method.
+		SetModelEnabled []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ModelID is the modelID argument value.
+			ModelID string
+			// Enabled is the enabled argument value.
+			Enabled bool
+			// UserID is the userID argument value.
+			UserID string
+		}
 		// UpdateActiveModel holds details about calls to the UpdateActiveModel method.
 		UpdateActiveModel []struct {
 			// Ctx is the ctx argument value.
@@ -155,6 +173,7 @@ type ServiceMock struct {
 	lockGetSetting           sync.RWMutex
 	lockListAvailableModels  sync.RWMutex
 	lockListSettings         sync.RWMutex
+	lockSetModelEnabled      sync.RWMutex
 	lockUpdateActiveModel    sync.RWMutex
 	lockUpdateModelConfig    sync.RWMutex
 	lockUpdateSetting        sync.RWMutex
@@ -229,7 +248,7 @@ func (mock *ServiceMock) GetModelConfigCalls() []struct {
 }
 
 // GetModelConfigSchema calls GetModelConfigSchemaFunc.
-func (mock *ServiceMock) GetModelConfigSchema(ctx context.Context, modelID string) (*ModelConfigSchema, error) {
+func (mock *ServiceMock) GetModelConfigSchema(ctx context.Context, modelID string) (*modelconfig.Schema, error) {
 	if mock.GetModelConfigSchemaFunc == nil {
 		panic("ServiceMock.GetModelConfigSchemaFunc: method is nil but Service.GetModelConfigSchema was just called")
 	}
@@ -364,6 +383,50 @@ func (mock *ServiceMock) ListSettingsCalls() []struct {
 	return calls
 }
 
+// SetModelEnabled calls SetModelEnabledFunc.
+func (mock *ServiceMock) SetModelEnabled(ctx context.Context, modelID string, enabled bool, userID string) (*ModelInfo, error) {
+	if mock.SetModelEnabledFunc == nil {
+		panic("ServiceMock.SetModelEnabledFunc: method is nil but Service.SetModelEnabled was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ModelID string
+		Enabled bool
+		UserID  string
+	}{
+		Ctx:     ctx,
+		ModelID: modelID,
+		Enabled: enabled,
+		UserID:  userID,
+	}
+	mock.lockSetModelEnabled.Lock()
+	mock.calls.SetModelEnabled = append(mock.calls.SetModelEnabled, callInfo)
+	mock.lockSetModelEnabled.Unlock()
+	return mock.SetModelEnabledFunc(ctx, modelID, enabled, userID)
+}
+
+// SetModelEnabledCalls gets all the calls that were made to SetModelEnabled.
+// Check the length with:
+//
+//	len(mockedService.SetModelEnabledCalls())
+func (mock *ServiceMock) SetModelEnabledCalls() []struct {
+	Ctx     context.Context
+	ModelID string
+	Enabled bool
+	UserID  string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ModelID string
+		Enabled bool
+		UserID  string
+	}
+	mock.lockSetModelEnabled.RLock()
+	calls = mock.calls.SetModelEnabled
+	mock.lockSetModelEnabled.RUnlock()
+	return calls
+}
+
 // UpdateActiveModel calls UpdateActiveModelFunc.
 func (mock *ServiceMock) UpdateActiveModel(ctx context.Context, modelID string, userID string) error {
 	if mock.UpdateActiveModelFunc == nil {
@@ -0,0 +1,85 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// Syncer refreshes the catalog's Replicate models from Replicate.
+type Syncer struct {
+	repo     Repository
+	upstream Upstream
+}
+
+// NewSyncer creates a Syncer.
+func NewSyncer(repo Repository, upstream Upstream) *Syncer {
+	return &Syncer{repo: repo, upstream: upstream}
+}
+
+// Sync stores the description, latest version and input schema Replicate
+// reports for each Replicate model in the catalog, disabled ones included.
+// Models on other providers are skipped. A model that can't be read is
+// recorded as failed and the rest are still synced; only a catalog that
+// can't be listed fails the sync.
+func (s *Syncer) Sync(ctx context.Context) (*SyncResult, error) {
+	models, err := s.repo.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logging.Default()
+	result := &SyncResult{Synced: []string{}, Skipped: []string{}, Failed: []string{}}
+	for _, model := range models {
+		if model.Provider != ProviderReplicate {
+			result.Skipped = append(result.Skipped, model.ID)
+			continue
+		}
+		if err := s.syncModel(ctx, model.ID); err != nil {
+			log.Warn(ctx, "model sync failed", "model_id", model.ID, "error", err)
+			result.Failed = append(result.Failed, model.ID)
+			continue
+		}
+		result.Synced = append(result.Synced, model.ID)
+	}
+	return result, nil
+}
+
+func (s *Syncer) syncModel(ctx context.Context, modelID string) error {
+	sync, err := s.upstream.GetModel(ctx, modelID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateModelSync(ctx, modelID, *sync); err != nil {
+		return fmt.Errorf("failed to store model: %w", err)
+	}
+	return nil
+}
+
+// RunModelSync syncs the catalog now and then every interval until ctx is
+// done, reloading the catalog after each sync.
+func RunModelSync(ctx context.Context, syncer *Syncer, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := syncer.Sync(ctx)
+		if err != nil {
+			log.Error(ctx, "model sync failed", "error", err)
+		} else {
+			log.Info(ctx, "models synced", "synced", len(result.Synced), "failed", len(result.Failed))
+			if err := LoadCatalog(ctx, syncer.repo); err != nil {
+				log.Error(ctx, "model catalog refresh failed", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestSyncer_Sync(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: syncs Replicate models and skips the rest", func(t *testing.T) {
+		repo := catalogRepo()
+		repo.UpdateModelSyncFunc = func(ctx context.Context, modelID string, sync ModelSync) error {
+			if sync.LatestVersion != "v-"+modelID {
+				t.Errorf("%s: LatestVersion = %q", modelID, sync.LatestVersion)
+			}
+			return nil
+		}
+		upstream := &UpstreamMock{
+			GetModelFunc: func(ctx context.Context, modelID string) (*ModelSync, error) {
+				if modelID == "bytedance/seedream-4" {
+					return nil, errors.New("replicate returned 500")
+				}
+				return &ModelSync{LatestVersion: "v-" + modelID}, nil
+			},
+		}
+
+		result, err := NewSyncer(repo, upstream).Sync(ctx)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := &SyncResult{
+			Synced:  []string{"qwen/qwen-image-edit", "black-forest-labs/flux-kontext-max", "bytedance/seedream-3"},
+			Skipped: []string{"openai/gpt-image-1"},
+			Failed:  []string{"bytedance/seedream-4"},
+		}
+		if !reflect.DeepEqual(result, want) {
+			t.Errorf("Sync() = %+v, want %+v", result, want)
+		}
+		if len(repo.UpdateModelSyncCalls()) != 3 {
+			t.Errorf("expected 3 calls to UpdateModelSync, got %d", len(repo.UpdateModelSyncCalls()))
+		}
+	})
+
+	t.Run("fail: catalog can't be listed", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListModelsFunc: func(ctx context.Context) ([]ModelInfo, error) {
+				return nil, fmt.Errorf("db down")
+			},
+		}
+
+		if _, err := NewSyncer(repo, &UpstreamMock{}).Sync(ctx); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestReplicateUpstream_GetModel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer r8_test" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.URL.Path {
+		case "/models/qwen/qwen-image-edit":
+			_, _ = w.Write([]byte(`{"description":"Edits images","latest_version":{"id":"abc123",
+				"openapi_schema":{"components":{"schemas":{"Input":{"type":"object"}}}}}}`))
+		case "/models/black-forest-labs/flux-kontext-pro":
+			_, _ = w.Write([]byte(`{"description":"Official model","latest_version":null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	u := NewReplicateUpstream(config.Replicate{APIToken: "r8_test", BaseURL: srv.URL + "/"})
+	ctx := context.Background()
+
+	t.Run("success: reads the latest version and input schema", func(t *testing.T) {
+		got, err := u.GetModel(ctx, "qwen/qwen-image-edit")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Description != "Edits images" || got.LatestVersion != "abc123" ||
+			string(got.InputSchema) != `{"type":"object"}` {
+			t.Errorf("GetModel() = %+v (schema %s)", got, got.InputSchema)
+		}
+	})
+
+	t.Run("success: unversioned official model", func(t *testing.T) {
+		got, err := u.GetModel(ctx, "black-forest-labs/flux-kontext-pro")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.LatestVersion != "" || got.InputSchema != nil {
+			t.Errorf("GetModel() = %+v", got)
+		}
+	})
+
+	t.Run("fail: unknown model", func(t *testing.T) {
+		if _, err := u.GetModel(ctx, "acme/missing"); !errors.Is(err, ErrModelNotFound) {
+			t.Fatalf("expected ErrModelNotFound, got %v", err)
+		}
+	})
+
+	t.Run("fail: no token", func(t *testing.T) {
+		_, err := NewReplicateUpstream(config.Replicate{BaseURL: srv.URL}).GetModel(ctx, "qwen/qwen-image-edit")
+		if !errors.Is(err, ErrUpstreamNotConfigured) {
+			t.Fatalf("expected ErrUpstreamNotConfigured, got %v", err)
+		}
+	})
+}
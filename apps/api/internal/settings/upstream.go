@@ -0,0 +1,103 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out upstream_mock.go . Upstream
+
+// ErrUpstreamNotConfigured is returned when no Replicate API token is set.
+var ErrUpstreamNotConfigured = errors.New("replicate api token not configured")
+
+// Upstream reads model metadata from Replicate.
+type Upstream interface {
+	// GetModel returns the model's description, latest version and input
+	// schema. Returns ErrModelNotFound if the model doesn't exist.
+	GetModel(ctx context.Context, modelID string) (*ModelSync, error)
+}
+
+// ReplicateUpstream reads models through the Replicate HTTP API.
+type ReplicateUpstream struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// Ensure ReplicateUpstream implements Upstream.
+var _ Upstream = (*ReplicateUpstream)(nil)
+
+// NewReplicateUpstream creates a ReplicateUpstream from the Replicate config.
+func NewReplicateUpstream(cfg config.Replicate) *ReplicateUpstream {
+	return &ReplicateUpstream{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		token:   cfg.APIToken,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// replicateModel is the part of Replicate's model object a sync reads.
+type replicateModel struct {
+	Description   string `json:"description"`
+	LatestVersion *struct {
+		ID            string `json:"id"`
+		OpenAPISchema struct {
+			Components struct {
+				Schemas struct {
+					Input json.RawMessage `json:"Input"`
+				} `json:"schemas"`
+			} `json:"components"`
+		} `json:"openapi_schema"`
+	} `json:"latest_version"`
+}
+
+// GetModel reads the model from Replicate. Official models that aren't
+// versioned have no latest version or schema.
+func (u *ReplicateUpstream) GetModel(ctx context.Context, modelID string) (*ModelSync, error) {
+	if u.token == "" {
+		return nil, ErrUpstreamNotConfigured
+	}
+	owner, name, ok := strings.Cut(modelID, "/")
+	if !ok || owner == "" || name == "" {
+		return nil, fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
+	}
+
+	url := fmt.Sprintf("%s/models/%s/%s", u.baseURL, owner, name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build model request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+u.token)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrModelNotFound, modelID)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to get model: replicate returned %d", resp.StatusCode)
+	}
+
+	var m replicateModel
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode model: %w", err)
+	}
+
+	sync := &ModelSync{Description: m.Description}
+	if m.LatestVersion != nil {
+		sync.LatestVersion = m.LatestVersion.ID
+		sync.InputSchema = m.LatestVersion.OpenAPISchema.Components.Schemas.Input
+	}
+	return sync, nil
+}
@@ -0,0 +1,49 @@
+package settings
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Settings that style the watermark drawn on images staged in projects with
+// watermarking turned on. The worker reads them when it stages each image.
+const (
+	KeyWatermarkText     = "watermark_text"
+	KeyWatermarkPosition = "watermark_position"
+	KeyWatermarkOpacity  = "watermark_opacity"
+)
+
+// WatermarkPositions lists the accepted values of KeyWatermarkPosition.
+var WatermarkPositions = []string{"top_left", "top_right", "bottom_left", "bottom_right", "center"}
+
+// maxWatermarkText bounds the watermark text so it fits across a staged image.
+const maxWatermarkText = 64
+
+// validateSetting rejects values the worker couldn't use. Keys without rules
+// accept any value.
+func validateSetting(key, value string) error {
+	switch key {
+	case KeyWatermarkText:
+		if strings.TrimSpace(value) == "" || utf8.RuneCountInString(value) > maxWatermarkText {
+			return fmt.Errorf("%s must be 1 to %d characters", key, maxWatermarkText)
+		}
+	case KeyWatermarkPosition:
+		if !slices.Contains(WatermarkPositions, value) {
+			return fmt.Errorf("%s must be one of: %s", key, strings.Join(WatermarkPositions, ", "))
+		}
+	case KeyWatermarkOpacity:
+		// A barely visible watermark wouldn't meet disclosure rules.
+		opacity, err := strconv.ParseFloat(value, 64)
+		if err != nil || opacity < 0.1 || opacity > 1 {
+			return fmt.Errorf("%s must be a number between 0.1 and 1", key)
+		}
+	case KeyProviderIncident:
+		if _, err := ParseIncident(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
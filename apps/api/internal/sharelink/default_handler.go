@@ -0,0 +1,139 @@
+package sharelink
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// CodeLinkInactive is the problem code for a revoked or expired link.
+const CodeLinkInactive = "share_link_inactive"
+
+// DefaultHandler serves the share link API.
+type DefaultHandler struct {
+	service     Service
+	userRepo    user.Repository
+	projectRepo project.Repository
+	log         logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(
+	service Service, userRepo user.Repository, projectRepo project.Repository, log logging.Logger,
+) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, projectRepo: projectRepo, log: log}
+}
+
+// CreateLink handles POST /api/v1/projects/:id/share.
+func (h *DefaultHandler) CreateLink(c echo.Context) error {
+	userID, projectID, p := h.accessibleProject(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	var req CreateLinkRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	link, err := h.service.CreateLink(c.Request().Context(), userID, projectID, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to create share link")
+	}
+	return c.JSON(http.StatusCreated, link)
+}
+
+// ListLinks handles GET /api/v1/projects/:id/share.
+func (h *DefaultHandler) ListLinks(c echo.Context) error {
+	_, projectID, p := h.accessibleProject(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	links, err := h.service.ListLinks(c.Request().Context(), projectID)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list share links")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"links": links})
+}
+
+// RevokeLink handles DELETE /api/v1/projects/:id/share/:link_id.
+func (h *DefaultHandler) RevokeLink(c echo.Context) error {
+	linkID := c.Param("link_id")
+	if _, err := uuid.Parse(linkID); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid share link ID format")
+	}
+	_, projectID, p := h.accessibleProject(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	if err := h.service.RevokeLink(c.Request().Context(), projectID, linkID); err != nil {
+		return h.serviceError(c, err, "Failed to revoke share link")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetGallery handles GET /api/v1/shared/:token. It needs no authentication:
+// the token is the credential.
+func (h *DefaultHandler) GetGallery(c echo.Context) error {
+	token := c.Param("token")
+	if token == "" {
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Share link not found")
+	}
+
+	gallery, err := h.service.ViewGallery(c.Request().Context(), token)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to load gallery")
+	}
+	// The image URLs expire, so the gallery must not outlive them in a cache.
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	return c.JSON(http.StatusOK, gallery)
+}
+
+// accessibleProject validates :id and checks the caller can access the project.
+func (h *DefaultHandler) accessibleProject(c echo.Context) (string, string, *problem.Problem) {
+	projectID := c.Param("id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return "", "", problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid project ID format")
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "Invalid or missing JWT token")
+	}
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return "", "", problem.New(http.StatusUnauthorized, problem.CodeUnauthorized, "User not found")
+	}
+	userID := userRow.ID.String()
+
+	if _, err := h.projectRepo.GetProjectByIDAndUserID(c.Request().Context(), projectID, userID); err != nil {
+		return "", "", problem.New(http.StatusForbidden, problem.CodeForbidden, "Project not found or access denied")
+	}
+	return userID, projectID, nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, ErrLinkNotFound):
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Share link not found")
+	case errors.Is(err, ErrLinkInactive):
+		return problem.Write(c, http.StatusGone, CodeLinkInactive, "Share link has expired or been revoked")
+	case errors.Is(err, ErrInvalidLink):
+		return problem.Write(c, http.StatusUnprocessableEntity, problem.CodeValidationFailed, err.Error())
+	}
+	h.log.Error(c.Request().Context(), "share link request failed", "error", err)
+	return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, message)
+}
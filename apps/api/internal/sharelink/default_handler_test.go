@@ -0,0 +1,203 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/echotest"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newTestHandler(svc Service, projectErr error) *DefaultHandler {
+	userID := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	projectRepo := &project.RepositoryMock{
+		GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+			if projectErr != nil {
+				return nil, projectErr
+			}
+			return &project.Project{ID: projectID}, nil
+		},
+	}
+	return NewDefaultHandler(svc, userRepo, projectRepo, logging.Default())
+}
+
+func TestDefaultHandler_CreateLink(t *testing.T) {
+	projectID := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		projectID    string
+		body         string
+		projectErr   error
+		createErr    error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: returns the link",
+			projectID:    projectID,
+			body:         `{"expires_at":"2030-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusCreated,
+			expectBody:   `"url":"/api/v1/shared/tok"`,
+		},
+		{name: "success: no expiry", projectID: projectID, body: `{}`, expectedCode: http.StatusCreated},
+		{name: "fail: invalid project id", projectID: "nope", expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: project not accessible",
+			projectID:    projectID,
+			body:         `{}`,
+			projectErr:   errors.New("not found"),
+			expectedCode: http.StatusForbidden,
+		},
+		{name: "fail: malformed body", projectID: projectID, body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: validation",
+			projectID:    projectID,
+			body:         `{"expires_at":"2020-01-01T00:00:00Z"}`,
+			createErr:    ErrInvalidLink,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:         "fail: service error",
+			projectID:    projectID,
+			body:         `{}`,
+			createErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateLinkFunc: func(ctx context.Context, userID, projectID string, req CreateLinkRequest) (*Link, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Link{ID: "link-1", ProjectID: projectID, Token: "tok", URL: GalleryPath("tok")}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodPost, tc.body, []string{"id"}, []string{tc.projectID})
+
+			require.NoError(t, newTestHandler(svc, tc.projectErr).CreateLink(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_ListLinks(t *testing.T) {
+	svc := &ServiceMock{
+		ListLinksFunc: func(ctx context.Context, projectID string) ([]Link, error) {
+			return []Link{{ID: "link-1", ProjectID: projectID, ViewCount: 3}}, nil
+		},
+	}
+	c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{uuid.NewString()})
+
+	require.NoError(t, newTestHandler(svc, nil).ListLinks(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"view_count":3`)
+}
+
+func TestDefaultHandler_RevokeLink(t *testing.T) {
+	projectID := uuid.NewString()
+	linkID := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		linkID       string
+		projectErr   error
+		revokeErr    error
+		expectedCode int
+	}{
+		{name: "success: revoked", linkID: linkID, expectedCode: http.StatusNoContent},
+		{name: "fail: invalid link id", linkID: "nope", expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: project not accessible",
+			linkID:       linkID,
+			projectErr:   errors.New("not found"),
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "fail: link of another project",
+			linkID:       linkID,
+			revokeErr:    ErrLinkNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				RevokeLinkFunc: func(ctx context.Context, gotProjectID, id string) error {
+					assert.Equal(t, projectID, gotProjectID)
+					return tc.revokeErr
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodDelete, "", []string{"id", "link_id"}, []string{projectID, tc.linkID})
+
+			require.NoError(t, newTestHandler(svc, tc.projectErr).RevokeLink(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_GetGallery(t *testing.T) {
+	cases := []struct {
+		name         string
+		viewErr      error
+		expectedCode int
+		expectBody   string
+	}{
+		{name: "success: gallery", expectedCode: http.StatusOK, expectBody: `"project_name":"Downtown Condo"`},
+		{name: "fail: unknown token", viewErr: ErrLinkNotFound, expectedCode: http.StatusNotFound},
+		{
+			name:         "fail: revoked or expired",
+			viewErr:      ErrLinkInactive,
+			expectedCode: http.StatusGone,
+			expectBody:   CodeLinkInactive,
+		},
+		{name: "fail: service error", viewErr: errors.New("db down"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				ViewGalleryFunc: func(ctx context.Context, token string) (*Gallery, error) {
+					assert.Equal(t, "tok", token)
+					if tc.viewErr != nil {
+						return nil, tc.viewErr
+					}
+					return &Gallery{ProjectName: "Downtown Condo", Images: []GalleryImage{}}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodGet, "", []string{"token"}, []string{"tok"})
+
+			require.NoError(t, newTestHandler(svc, nil).GetGallery(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+			if tc.viewErr == nil {
+				assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
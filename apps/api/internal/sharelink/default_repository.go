@@ -0,0 +1,127 @@
+package sharelink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const linkColumns = `
+	id, project_id, created_by, token, expires_at, revoked_at, view_count, last_viewed_at, created_at`
+
+func scanLink(row pgx.Row) (*Link, error) {
+	var l Link
+	err := row.Scan(
+		&l.ID, &l.ProjectID, &l.CreatedBy, &l.Token, &l.ExpiresAt, &l.RevokedAt, &l.ViewCount, &l.LastViewedAt,
+		&l.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	l.URL = GalleryPath(l.Token)
+	return &l, nil
+}
+
+// Create inserts a new link.
+func (r *DefaultRepository) Create(ctx context.Context, l *Link) (*Link, error) {
+	query := `
+		INSERT INTO share_links (project_id, created_by, token, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING` + linkColumns
+
+	created, err := scanLink(r.db.QueryRow(ctx, query, l.ProjectID, l.CreatedBy, l.Token, l.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+	return created, nil
+}
+
+// ListByProject returns a project's links, newest first.
+func (r *DefaultRepository) ListByProject(ctx context.Context, projectID string) ([]Link, error) {
+	query := `SELECT` + linkColumns + `
+		FROM share_links
+		WHERE project_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []Link{}
+	for rows.Next() {
+		l, err := scanLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share link: %w", err)
+		}
+		links = append(links, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over share link rows: %w", err)
+	}
+	return links, nil
+}
+
+// Revoke marks a link of the project revoked.
+func (r *DefaultRepository) Revoke(ctx context.Context, id, projectID string) error {
+	query := `
+		UPDATE share_links
+		SET revoked_at = coalesce(revoked_at, now())
+		WHERE id = $1 AND project_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, id, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
+
+// GetByToken retrieves a link by its token.
+func (r *DefaultRepository) GetByToken(ctx context.Context, token string) (*Link, error) {
+	query := `SELECT` + linkColumns + `
+		FROM share_links
+		WHERE token = $1`
+
+	l, err := scanLink(r.db.QueryRow(ctx, query, token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLinkNotFound
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	return l, nil
+}
+
+// RecordView counts a view of the link.
+func (r *DefaultRepository) RecordView(ctx context.Context, id string) error {
+	query := `
+		UPDATE share_links
+		SET view_count = view_count + 1, last_viewed_at = now()
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to record share link view: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package sharelink
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// tokenBytes is the entropy of a link token; it is the only thing guarding a gallery.
+const tokenBytes = 24
+
+// DefaultService implements Service on top of the share link table, the
+// project's ready images and presigned S3 URLs.
+type DefaultService struct {
+	repo     Repository
+	projects project.Repository
+	images   image.Service
+	s3       storage.S3Service
+	bucket   string
+	log      logging.Logger
+	now      func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. bucket is the S3 bucket the
+// staged URLs point into.
+func NewDefaultService(
+	repo Repository,
+	projects project.Repository,
+	images image.Service,
+	s3 storage.S3Service,
+	bucket string,
+	log logging.Logger,
+) *DefaultService {
+	return &DefaultService{
+		repo:     repo,
+		projects: projects,
+		images:   images,
+		s3:       s3,
+		bucket:   bucket,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// CreateLink creates a link to the project's gallery on behalf of the user.
+func (s *DefaultService) CreateLink(
+	ctx context.Context, userID, projectID string, req CreateLinkRequest,
+) (*Link, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(s.now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidLink)
+	}
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, &Link{
+		ProjectID: projectID,
+		CreatedBy: userID,
+		Token:     token,
+		ExpiresAt: req.ExpiresAt,
+	})
+}
+
+// ListLinks returns a project's links, newest first.
+func (s *DefaultService) ListLinks(ctx context.Context, projectID string) ([]Link, error) {
+	return s.repo.ListByProject(ctx, projectID)
+}
+
+// RevokeLink stops a link of the project from showing the gallery.
+func (s *DefaultService) RevokeLink(ctx context.Context, projectID, id string) error {
+	return s.repo.Revoke(ctx, id, projectID)
+}
+
+// ViewGallery returns the gallery a token shares and counts the view. A view
+// that fails to be counted is still served.
+func (s *DefaultService) ViewGallery(ctx context.Context, token string) (*Gallery, error) {
+	link, err := s.repo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !link.Active(s.now()) {
+		return nil, ErrLinkInactive
+	}
+
+	proj, err := s.projects.GetProjectByID(ctx, link.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	images, err := s.images.ListReadyImages(ctx, link.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	gallery := &Gallery{ProjectName: proj.Name, ExpiresAt: link.ExpiresAt, Images: []GalleryImage{}}
+	for _, img := range images {
+		key, err := storage.KeyFromURL(*img.StagedURL, s.bucket)
+		if err != nil {
+			return nil, fmt.Errorf("image %s: %w", img.ID, err)
+		}
+		url, err := s.s3.GeneratePresignedGetURL(ctx, key, int64(PresignTTL/time.Second), "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to presign image %s: %w", img.ID, err)
+		}
+		gallery.Images = append(gallery.Images, GalleryImage{
+			ID:        img.ID.String(),
+			RoomType:  img.RoomType,
+			Style:     img.Style,
+			Blurhash:  img.Blurhash,
+			URL:       url,
+			CreatedAt: img.CreatedAt,
+		})
+	}
+
+	if err := s.repo.RecordView(ctx, link.ID); err != nil {
+		s.log.Error(ctx, "failed to count share link view", "link_id", link.ID, "error", err)
+	}
+	return gallery, nil
+}
+
+func newToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate share link token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
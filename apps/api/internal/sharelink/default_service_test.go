@@ -0,0 +1,170 @@
+package sharelink
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+var testNow = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func newTestService(repo Repository, images image.Service, s3 storage.S3Service) *DefaultService {
+	projects := &project.RepositoryMock{
+		GetProjectByIDFunc: func(ctx context.Context, projectID string) (*project.Project, error) {
+			return &project.Project{ID: projectID, Name: "Downtown Condo"}, nil
+		},
+	}
+	svc := NewDefaultService(repo, projects, images, s3, "real-staging", logging.Default())
+	svc.now = func() time.Time { return testNow }
+	return svc
+}
+
+func TestDefaultService_CreateLink(t *testing.T) {
+	ctx := context.Background()
+	echoCreate := func(ctx context.Context, l *Link) (*Link, error) {
+		created := *l
+		created.ID = "link-1"
+		return &created, nil
+	}
+
+	t.Run("success: generates a token", func(t *testing.T) {
+		expires := testNow.Add(24 * time.Hour)
+		repo := &RepositoryMock{CreateFunc: echoCreate}
+
+		link, err := newTestService(repo, nil, nil).CreateLink(ctx, "u-1", "p-1", CreateLinkRequest{ExpiresAt: &expires})
+		require.NoError(t, err)
+		raw, err := base64.RawURLEncoding.DecodeString(link.Token)
+		require.NoError(t, err)
+		assert.Len(t, raw, tokenBytes)
+		assert.Equal(t, "p-1", link.ProjectID)
+		assert.Equal(t, "u-1", link.CreatedBy)
+		assert.Equal(t, &expires, link.ExpiresAt)
+	})
+
+	t.Run("success: tokens differ", func(t *testing.T) {
+		svc := newTestService(&RepositoryMock{CreateFunc: echoCreate}, nil, nil)
+		a, err := svc.CreateLink(ctx, "u-1", "p-1", CreateLinkRequest{})
+		require.NoError(t, err)
+		b, err := svc.CreateLink(ctx, "u-1", "p-1", CreateLinkRequest{})
+		require.NoError(t, err)
+		assert.NotEqual(t, a.Token, b.Token)
+	})
+
+	t.Run("fail: expiry in the past", func(t *testing.T) {
+		past := testNow.Add(-time.Minute)
+		repo := &RepositoryMock{}
+
+		_, err := newTestService(repo, nil, nil).CreateLink(ctx, "u-1", "p-1", CreateLinkRequest{ExpiresAt: &past})
+		assert.ErrorIs(t, err, ErrInvalidLink)
+		assert.Empty(t, repo.CreateCalls())
+	})
+}
+
+func TestDefaultService_ViewGallery(t *testing.T) {
+	ctx := context.Background()
+	staged := "s3://real-staging/users/u1/staged/a.jpg"
+	room := "bedroom"
+	img := &image.Image{ID: uuid.New(), StagedURL: &staged, RoomType: &room, CreatedAt: testNow}
+	images := &image.ServiceMock{
+		ListReadyImagesFunc: func(ctx context.Context, projectID string) ([]*image.Image, error) {
+			assert.Equal(t, "p-1", projectID)
+			return []*image.Image{img}, nil
+		},
+	}
+	s3 := &storage.S3ServiceMock{
+		GeneratePresignedGetURLFunc: func(
+			ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string,
+		) (string, error) {
+			assert.Equal(t, int64(900), expiresInSeconds)
+			return "https://signed.example.com/" + fileKey, nil
+		},
+	}
+	linkWith := func(mod func(*Link)) func(ctx context.Context, token string) (*Link, error) {
+		return func(ctx context.Context, token string) (*Link, error) {
+			l := &Link{ID: "link-1", ProjectID: "p-1", Token: token}
+			mod(l)
+			return l, nil
+		}
+	}
+	earlier := testNow.Add(-time.Hour)
+	later := testNow.Add(time.Hour)
+
+	t.Run("success: presigns images and counts the view", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByTokenFunc: linkWith(func(l *Link) { l.ExpiresAt = &later }),
+			RecordViewFunc: func(ctx context.Context, id string) error { return nil },
+		}
+
+		gallery, err := newTestService(repo, images, s3).ViewGallery(ctx, "tok")
+		require.NoError(t, err)
+		assert.Equal(t, "Downtown Condo", gallery.ProjectName)
+		assert.Equal(t, &later, gallery.ExpiresAt)
+		require.Len(t, gallery.Images, 1)
+		assert.Equal(t, img.ID.String(), gallery.Images[0].ID)
+		assert.Equal(t, &room, gallery.Images[0].RoomType)
+		assert.Equal(t, "https://signed.example.com/users/u1/staged/a.jpg", gallery.Images[0].URL)
+		require.Len(t, repo.RecordViewCalls(), 1)
+		assert.Equal(t, "link-1", repo.RecordViewCalls()[0].ID)
+	})
+
+	t.Run("success: a view that fails to count is still served", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByTokenFunc: linkWith(func(l *Link) {}),
+			RecordViewFunc: func(ctx context.Context, id string) error { return errors.New("db down") },
+		}
+
+		_, err := newTestService(repo, images, s3).ViewGallery(ctx, "tok")
+		assert.NoError(t, err)
+	})
+
+	for _, tc := range []struct {
+		name string
+		mod  func(*Link)
+	}{
+		{name: "fail: revoked", mod: func(l *Link) { l.RevokedAt = &earlier }},
+		{name: "fail: expired", mod: func(l *Link) { l.ExpiresAt = &earlier }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{GetByTokenFunc: linkWith(tc.mod)}
+
+			_, err := newTestService(repo, images, s3).ViewGallery(ctx, "tok")
+			assert.ErrorIs(t, err, ErrLinkInactive)
+			assert.Empty(t, repo.RecordViewCalls())
+		})
+	}
+
+	t.Run("fail: unknown token", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByTokenFunc: func(ctx context.Context, token string) (*Link, error) { return nil, ErrLinkNotFound },
+		}
+
+		_, err := newTestService(repo, images, s3).ViewGallery(ctx, "tok")
+		assert.ErrorIs(t, err, ErrLinkNotFound)
+	})
+
+	t.Run("fail: presign error", func(t *testing.T) {
+		repo := &RepositoryMock{GetByTokenFunc: linkWith(func(l *Link) {})}
+		failing := &storage.S3ServiceMock{
+			GeneratePresignedGetURLFunc: func(
+				ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string,
+			) (string, error) {
+				return "", errors.New("no credentials")
+			},
+		}
+
+		_, err := newTestService(repo, images, failing).ViewGallery(ctx, "tok")
+		assert.ErrorContains(t, err, "failed to presign image")
+		assert.Empty(t, repo.RecordViewCalls())
+	})
+}
@@ -0,0 +1,20 @@
+package sharelink
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for share links and the public gallery.
+type Handler interface {
+	// CreateLink handles POST /projects/:id/share - Creates a gallery link.
+	CreateLink(c echo.Context) error
+
+	// ListLinks handles GET /projects/:id/share - Lists a project's links.
+	ListLinks(c echo.Context) error
+
+	// RevokeLink handles DELETE /projects/:id/share/:link_id - Revokes a link.
+	RevokeLink(c echo.Context) error
+
+	// GetGallery handles GET /shared/:token - Shows a gallery without authentication.
+	GetGallery(c echo.Context) error
+}
@@ -0,0 +1,71 @@
+// Package sharelink lets users share a read-only gallery of a project's staged
+// images through a tokenized public link. Links may expire and can be revoked;
+// each view of the gallery is counted on its link.
+//
+// The gallery never exposes storage directly: every image is served through a
+// short-lived presigned URL generated when the gallery is viewed.
+package sharelink
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrLinkNotFound is returned when a link does not exist or belongs to another project.
+	ErrLinkNotFound = errors.New("share link not found")
+	// ErrLinkInactive is returned when a gallery is requested through a revoked or expired link.
+	ErrLinkInactive = errors.New("share link is no longer active")
+	// ErrInvalidLink is returned when a create request fails validation.
+	ErrInvalidLink = errors.New("invalid share link")
+)
+
+// Link is a public gallery link for one project.
+type Link struct {
+	ID           string     `json:"id"`
+	ProjectID    string     `json:"project_id"`
+	CreatedBy    string     `json:"-"`
+	Token        string     `json:"token"`
+	URL          string     `json:"url"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ViewCount    int64      `json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Active reports whether the link can be viewed at the given time.
+func (l *Link) Active(now time.Time) bool {
+	return l.RevokedAt == nil && (l.ExpiresAt == nil || now.Before(*l.ExpiresAt))
+}
+
+// CreateLinkRequest creates a link. A nil ExpiresAt never expires.
+type CreateLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Gallery is what a share link shows: the project's name and its ready staged images.
+type Gallery struct {
+	ProjectName string         `json:"project_name"`
+	ExpiresAt   *time.Time     `json:"expires_at,omitempty"`
+	Images      []GalleryImage `json:"images"`
+}
+
+// GalleryImage is a staged image in a gallery. URL is presigned and expires
+// after PresignTTL.
+type GalleryImage struct {
+	ID        string    `json:"id"`
+	RoomType  *string   `json:"room_type,omitempty"`
+	Style     *string   `json:"style,omitempty"`
+	Blurhash  *string   `json:"blurhash,omitempty"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PresignTTL is how long the image URLs of a gallery stay valid.
+const PresignTTL = 15 * time.Minute
+
+// GalleryPath is the public API path of the gallery for a token.
+func GalleryPath(token string) string {
+	return "/api/v1/shared/" + token
+}
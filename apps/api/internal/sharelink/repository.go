@@ -0,0 +1,25 @@
+package sharelink
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for share links. Lookups by ID are scoped by
+// project so a link can only be managed through the project it shares.
+type Repository interface {
+	// Create inserts a new link.
+	Create(ctx context.Context, l *Link) (*Link, error)
+
+	// ListByProject returns a project's links, newest first.
+	ListByProject(ctx context.Context, projectID string) ([]Link, error)
+
+	// Revoke marks a link of the project revoked. Revoking a revoked link keeps
+	// its original revocation time.
+	Revoke(ctx context.Context, id, projectID string) error
+
+	// GetByToken retrieves a link by its token, active or not.
+	GetByToken(ctx context.Context, token string) (*Link, error)
+
+	// RecordView counts a view of the link.
+	RecordView(ctx context.Context, id string) error
+}
@@ -0,0 +1,22 @@
+package sharelink
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for share links.
+type Service interface {
+	// CreateLink creates a link to the project's gallery on behalf of the user.
+	CreateLink(ctx context.Context, userID, projectID string, req CreateLinkRequest) (*Link, error)
+
+	// ListLinks returns a project's links, newest first, revoked and expired ones included.
+	ListLinks(ctx context.Context, projectID string) ([]Link, error)
+
+	// RevokeLink stops a link of the project from showing the gallery.
+	RevokeLink(ctx context.Context, projectID, id string) error
+
+	// ViewGallery returns the gallery a token shares and counts the view. It
+	// returns ErrLinkNotFound for unknown tokens and ErrLinkInactive for revoked
+	// or expired links.
+	ViewGallery(ctx context.Context, token string) (*Gallery, error)
+}
@@ -0,0 +1,77 @@
+package slo
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the admin SLO endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// ListStatuses handles GET /admin/slo - Evaluates every objective.
+func (h *DefaultHandler) ListStatuses(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	statuses, err := h.service.Statuses(ctx)
+	if err != nil {
+		h.log.Error(ctx, "failed to evaluate slos", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to evaluate SLOs")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"objectives": statuses,
+	})
+}
+
+// PutObjective handles PUT /admin/slo/:name - Creates or replaces an objective.
+func (h *DefaultHandler) PutObjective(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var o Objective
+	if err := c.Bind(&o); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	o.Name = c.Param("name")
+
+	saved, err := h.service.SaveObjective(ctx, &o)
+	if err != nil {
+		if errors.Is(err, ErrInvalidObjective) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		h.log.Error(ctx, "failed to save slo objective", "error", err, "name", o.Name)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save SLO objective")
+	}
+
+	return c.JSON(http.StatusOK, saved)
+}
+
+// DeleteObjective handles DELETE /admin/slo/:name - Removes an objective.
+func (h *DefaultHandler) DeleteObjective(c echo.Context) error {
+	ctx := c.Request().Context()
+	name := c.Param("name")
+
+	if err := h.service.DeleteObjective(ctx, name); err != nil {
+		if errors.Is(err, ErrObjectiveNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "SLO objective not found")
+		}
+		h.log.Error(ctx, "failed to delete slo objective", "error", err, "name", name)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete SLO objective")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,141 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_ListStatuses(t *testing.T) {
+	t.Run("success: lists statuses", func(t *testing.T) {
+		svc := &ServiceMock{
+			StatusesFunc: func(ctx context.Context) ([]Status, error) {
+				return []Status{{Objective: Objective{Name: "image-create-p99"}, Alert: AlertNone}}, nil
+			},
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		err := h.ListStatuses(e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/admin/slo", nil), rec))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"image-create-p99"`)
+	})
+
+	t.Run("fail: service error", func(t *testing.T) {
+		svc := &ServiceMock{
+			StatusesFunc: func(ctx context.Context) ([]Status, error) { return nil, errors.New("db down") },
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+
+		e := echo.New()
+		err := h.ListStatuses(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder()))
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+	})
+}
+
+func TestDefaultHandler_PutObjective(t *testing.T) {
+	cases := []struct {
+		name       string
+		body       string
+		saveErr    error
+		wantStatus int
+	}{
+		{
+			name:       "success: saves objective named by path",
+			body:       `{"method":"POST","route":"/api/v1/images","kind":"latency","target":0.99,"threshold_ms":800}`,
+			wantStatus: http.StatusOK,
+		},
+		{name: "fail: malformed body", body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name:       "fail: invalid objective",
+			body:       `{"kind":"latency"}`,
+			saveErr:    fmt.Errorf("%w: target must be between 0 and 1 (exclusive)", ErrInvalidObjective),
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "fail: service error", body: `{}`, saveErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				SaveObjectiveFunc: func(ctx context.Context, o *Objective) (*Objective, error) {
+					assert.Equal(t, "image-create-p99", o.Name)
+					return o, tc.saveErr
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/slo/image-create-p99", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("name")
+			c.SetParamValues("image-create-p99")
+
+			err := h.PutObjective(c)
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"threshold_ms":800`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_DeleteObjective(t *testing.T) {
+	cases := []struct {
+		name       string
+		deleteErr  error
+		wantStatus int
+	}{
+		{name: "success: deleted", wantStatus: http.StatusNoContent},
+		{name: "fail: not found", deleteErr: ErrObjectiveNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: service error", deleteErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DeleteObjectiveFunc: func(ctx context.Context, name string) error { return tc.deleteErr },
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/api/v1/admin/slo/x", nil), rec)
+			c.SetParamNames("name")
+			c.SetParamValues("x")
+
+			err := h.DeleteObjective(c)
+			if tc.wantStatus == http.StatusNoContent {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const objectiveColumns = `
+	id, name, method, route, kind, target, threshold_ms, window_days, created_at, updated_at`
+
+func scanObjective(row pgx.Row) (*Objective, error) {
+	var o Objective
+	var kind string
+	var threshold *int32
+	err := row.Scan(
+		&o.ID, &o.Name, &o.Method, &o.Route, &kind, &o.Target, &threshold, &o.WindowDays,
+		&o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	o.Kind = Kind(kind)
+	if threshold != nil {
+		v := int(*threshold)
+		o.ThresholdMs = &v
+	}
+	return &o, nil
+}
+
+// ListObjectives returns all objectives ordered by name.
+func (r *DefaultRepository) ListObjectives(ctx context.Context) ([]Objective, error) {
+	query := `SELECT` + objectiveColumns + `
+		FROM slo_objectives
+		ORDER BY name ASC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list slo objectives: %w", err)
+	}
+	defer rows.Close()
+
+	objectives := []Objective{}
+	for rows.Next() {
+		o, err := scanObjective(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan slo objective: %w", err)
+		}
+		objectives = append(objectives, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over slo objective rows: %w", err)
+	}
+	return objectives, nil
+}
+
+// UpsertObjective creates or replaces the objective with the same name.
+func (r *DefaultRepository) UpsertObjective(ctx context.Context, o *Objective) (*Objective, error) {
+	query := `
+		INSERT INTO slo_objectives (name, method, route, kind, target, threshold_ms, window_days)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (name) DO UPDATE SET
+			method = EXCLUDED.method,
+			route = EXCLUDED.route,
+			kind = EXCLUDED.kind,
+			target = EXCLUDED.target,
+			threshold_ms = EXCLUDED.threshold_ms,
+			window_days = EXCLUDED.window_days,
+			updated_at = now()
+		RETURNING` + objectiveColumns
+
+	saved, err := scanObjective(r.db.QueryRow(ctx, query,
+		o.Name, o.Method, o.Route, string(o.Kind), o.Target, o.ThresholdMs, o.WindowDays,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save slo objective: %w", err)
+	}
+	return saved, nil
+}
+
+// DeleteObjective removes an objective by name.
+func (r *DefaultRepository) DeleteObjective(ctx context.Context, name string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM slo_objectives WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete slo objective: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrObjectiveNotFound
+	}
+	return nil
+}
+
+// AddRollups adds the given counts into the stored per-minute rollups. Rows
+// written by other API instances for the same minute are summed, not replaced.
+func (r *DefaultRepository) AddRollups(ctx context.Context, rollups []Rollup) error {
+	query := `
+		INSERT INTO route_metric_rollups
+			(method, route, bucket_start, requests, errors, latency_sum_ms, latency_buckets)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (method, route, bucket_start) DO UPDATE SET
+			requests = route_metric_rollups.requests + EXCLUDED.requests,
+			errors = route_metric_rollups.errors + EXCLUDED.errors,
+			latency_sum_ms = route_metric_rollups.latency_sum_ms + EXCLUDED.latency_sum_ms,
+			latency_buckets = ARRAY(
+				SELECT COALESCE(a, 0) + COALESCE(b, 0)
+				FROM unnest(route_metric_rollups.latency_buckets, EXCLUDED.latency_buckets) AS t(a, b)
+			)`
+
+	for _, ru := range rollups {
+		if _, err := r.db.Exec(ctx, query,
+			ru.Method, ru.Route, ru.BucketStart, ru.Requests, ru.Errors, ru.LatencySumMs, ru.LatencyBuckets,
+		); err != nil {
+			return fmt.Errorf("failed to add route rollup: %w", err)
+		}
+	}
+	return nil
+}
+
+// Aggregate sums the rollups for a route from since until now.
+func (r *DefaultRepository) Aggregate(
+	ctx context.Context, method, route string, since time.Time,
+) (*Aggregate, error) {
+	totalsQuery := `
+		SELECT COALESCE(SUM(requests), 0)::BIGINT,
+		       COALESCE(SUM(errors), 0)::BIGINT,
+		       COALESCE(SUM(latency_sum_ms), 0)::BIGINT
+		FROM route_metric_rollups
+		WHERE method = $1 AND route = $2 AND bucket_start >= $3`
+
+	agg := &Aggregate{LatencyBuckets: make([]int64, len(LatencyBucketsMs)+1)}
+	err := r.db.QueryRow(ctx, totalsQuery, method, route, since).
+		Scan(&agg.Requests, &agg.Errors, &agg.LatencySumMs)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to aggregate route rollups: %w", err)
+	}
+	if agg.Requests == 0 {
+		return agg, nil
+	}
+
+	bucketsQuery := `
+		SELECT u.idx, SUM(u.n)::BIGINT
+		FROM route_metric_rollups r,
+		     unnest(r.latency_buckets) WITH ORDINALITY AS u(n, idx)
+		WHERE r.method = $1 AND r.route = $2 AND r.bucket_start >= $3
+		GROUP BY u.idx
+		ORDER BY u.idx`
+
+	rows, err := r.db.Query(ctx, bucketsQuery, method, route, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate latency buckets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var idx, n int64
+		if err := rows.Scan(&idx, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan latency bucket: %w", err)
+		}
+		if idx >= 1 && int(idx) <= len(agg.LatencyBuckets) {
+			agg.LatencyBuckets[idx-1] = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over latency bucket rows: %w", err)
+	}
+	return agg, nil
+}
+
+// DeleteRollupsBefore prunes rollups older than the cutoff.
+func (r *DefaultRepository) DeleteRollupsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM route_metric_rollups WHERE bucket_start < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune route rollups: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,253 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// ErrInvalidObjective is returned when an objective fails validation.
+var ErrInvalidObjective = errors.New("invalid slo objective")
+
+// DefaultWindowDays is the compliance window used when an objective does not set one.
+const DefaultWindowDays = 30
+
+var objectiveNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,99}$`)
+
+// DefaultService implements Service on top of the rollup table.
+type DefaultService struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo, now: time.Now}
+}
+
+// Statuses evaluates every objective and reports success rates, budgets and burn-rate alerts.
+func (s *DefaultService) Statuses(ctx context.Context) ([]Status, error) {
+	objectives, err := s.repo.ListObjectives(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(objectives))
+	for _, o := range objectives {
+		st, err := s.evaluate(ctx, o)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate objective %s: %w", o.Name, err)
+		}
+		statuses = append(statuses, *st)
+	}
+	return statuses, nil
+}
+
+func (s *DefaultService) evaluate(ctx context.Context, o Objective) (*Status, error) {
+	now := s.now().UTC()
+	windowDays := o.WindowDays
+	if windowDays <= 0 {
+		windowDays = DefaultWindowDays
+	}
+	st := &Status{
+		Objective:   o,
+		WindowStart: now.AddDate(0, 0, -windowDays),
+		Alert:       AlertNone,
+	}
+
+	full, err := s.repo.Aggregate(ctx, o.Method, o.Route, st.WindowStart)
+	if err != nil {
+		return nil, err
+	}
+	bad := badRequests(o, full)
+	st.Requests = full.Requests
+	st.GoodRequests = full.Requests - bad
+	st.BudgetRemaining = 1
+	if full.Requests > 0 {
+		rate := float64(st.GoodRequests) / float64(full.Requests)
+		st.SuccessRate = &rate
+		st.BudgetRemaining = 1 - burnRate(o, full)
+		st.P99Ms = percentileBound(full.LatencyBuckets, full.Requests, 0.99)
+	}
+
+	burns := []struct {
+		window time.Duration
+		dst    *float64
+	}{
+		{5 * time.Minute, &st.BurnRates.FiveMinutes},
+		{30 * time.Minute, &st.BurnRates.ThirtyMinutes},
+		{time.Hour, &st.BurnRates.OneHour},
+		{6 * time.Hour, &st.BurnRates.SixHours},
+	}
+	for _, b := range burns {
+		agg, err := s.repo.Aggregate(ctx, o.Method, o.Route, now.Add(-b.window))
+		if err != nil {
+			return nil, err
+		}
+		*b.dst = burnRate(o, agg)
+	}
+
+	switch {
+	case st.BurnRates.OneHour > PageBurnRate && st.BurnRates.FiveMinutes > PageBurnRate:
+		st.Alert = AlertPage
+	case st.BurnRates.SixHours > TicketBurnRate && st.BurnRates.ThirtyMinutes > TicketBurnRate:
+		st.Alert = AlertTicket
+	}
+	return st, nil
+}
+
+// badRequests counts requests that miss the objective: 5xx responses for
+// availability, or responses slower than the threshold bucket for latency.
+func badRequests(o Objective, agg *Aggregate) int64 {
+	if o.Kind == KindAvailability {
+		return agg.Errors
+	}
+	if o.ThresholdMs == nil {
+		return 0
+	}
+	var good int64
+	for i, bound := range LatencyBucketsMs {
+		if bound > int64(*o.ThresholdMs) || i >= len(agg.LatencyBuckets) {
+			break
+		}
+		good += agg.LatencyBuckets[i]
+	}
+	return agg.Requests - good
+}
+
+// burnRate is the observed error rate divided by the error budget (1 - target).
+func burnRate(o Objective, agg *Aggregate) float64 {
+	if agg.Requests == 0 || o.Target >= 1 {
+		return 0
+	}
+	errorRate := float64(badRequests(o, agg)) / float64(agg.Requests)
+	return errorRate / (1 - o.Target)
+}
+
+// percentileBound returns the upper bound of the histogram bucket holding the
+// given percentile, or nil when it falls in the overflow bucket.
+func percentileBound(buckets []int64, total int64, p float64) *int64 {
+	rank := int64(float64(total) * p)
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for i, n := range buckets {
+		seen += n
+		if seen >= rank {
+			if i >= len(LatencyBucketsMs) {
+				return nil
+			}
+			bound := LatencyBucketsMs[i]
+			return &bound
+		}
+	}
+	return nil
+}
+
+// SaveObjective validates and stores an objective, replacing any with the same name.
+func (s *DefaultService) SaveObjective(ctx context.Context, o *Objective) (*Objective, error) {
+	if o == nil {
+		return nil, fmt.Errorf("%w: objective is required", ErrInvalidObjective)
+	}
+	o.Method = strings.ToUpper(strings.TrimSpace(o.Method))
+	if o.WindowDays == 0 {
+		o.WindowDays = DefaultWindowDays
+	}
+	if err := validateObjective(o); err != nil {
+		return nil, err
+	}
+	return s.repo.UpsertObjective(ctx, o)
+}
+
+func validateObjective(o *Objective) error {
+	if !objectiveNamePattern.MatchString(o.Name) {
+		return fmt.Errorf("%w: name must be lowercase letters, digits and dashes", ErrInvalidObjective)
+	}
+	switch o.Method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return fmt.Errorf("%w: method must be one of GET, POST, PUT, PATCH, DELETE", ErrInvalidObjective)
+	}
+	if !strings.HasPrefix(o.Route, "/") {
+		return fmt.Errorf("%w: route must be a route template starting with /", ErrInvalidObjective)
+	}
+	if o.Target <= 0 || o.Target >= 1 {
+		return fmt.Errorf("%w: target must be between 0 and 1 (exclusive)", ErrInvalidObjective)
+	}
+	if o.WindowDays < 1 || o.WindowDays > 90 {
+		return fmt.Errorf("%w: window_days must be between 1 and 90", ErrInvalidObjective)
+	}
+	switch o.Kind {
+	case KindLatency:
+		if o.ThresholdMs == nil || !slices.Contains(LatencyBucketsMs, int64(*o.ThresholdMs)) {
+			return fmt.Errorf("%w: threshold_ms must be one of %v", ErrInvalidObjective, LatencyBucketsMs)
+		}
+	case KindAvailability:
+		if o.ThresholdMs != nil {
+			return fmt.Errorf("%w: threshold_ms only applies to latency objectives", ErrInvalidObjective)
+		}
+	default:
+		return fmt.Errorf("%w: kind must be one of: latency, availability", ErrInvalidObjective)
+	}
+	return nil
+}
+
+// DeleteObjective removes an objective by name.
+func (s *DefaultService) DeleteObjective(ctx context.Context, name string) error {
+	return s.repo.DeleteObjective(ctx, name)
+}
+
+// RunEvaluator re-evaluates objectives every interval and logs whenever an
+// objective's burn-rate alert level changes, until ctx is cancelled.
+func RunEvaluator(ctx context.Context, svc Service, interval time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := make(map[string]Alert)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			statuses, err := svc.Statuses(ctx)
+			if err != nil {
+				log.Error(ctx, "slo evaluation failed", "error", err)
+				continue
+			}
+			for _, st := range statuses {
+				prev, ok := last[st.Objective.Name]
+				last[st.Objective.Name] = st.Alert
+				if (ok && prev == st.Alert) || (!ok && st.Alert == AlertNone) {
+					continue
+				}
+				fields := []any{
+					"slo", st.Objective.Name,
+					"alert", string(st.Alert),
+					"burn_rate_1h", st.BurnRates.OneHour,
+					"burn_rate_6h", st.BurnRates.SixHours,
+					"budget_remaining", st.BudgetRemaining,
+				}
+				switch st.Alert {
+				case AlertPage:
+					log.Error(ctx, "slo burn-rate alert", fields...)
+				case AlertTicket:
+					log.Warn(ctx, "slo burn-rate alert", fields...)
+				default:
+					log.Info(ctx, "slo burn-rate alert resolved", fields...)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int { return &v }
+
+// buckets builds a histogram with n requests in the bucket for each bound (ms); bounds
+// not in LatencyBucketsMs land in the overflow bucket.
+func buckets(counts map[int64]int64) []int64 {
+	out := make([]int64, len(LatencyBucketsMs)+1)
+	for bound, n := range counts {
+		idx := len(LatencyBucketsMs)
+		for i, b := range LatencyBucketsMs {
+			if b == bound {
+				idx = i
+			}
+		}
+		out[idx] += n
+	}
+	return out
+}
+
+func TestDefaultService_Statuses(t *testing.T) {
+	now := time.Date(2025, 10, 12, 12, 0, 0, 0, time.UTC)
+	latency := Objective{
+		Name: "image-create-p99", Method: "POST", Route: "/api/v1/images",
+		Kind: KindLatency, Target: 0.99, ThresholdMs: intPtr(800), WindowDays: 30,
+	}
+	availability := Objective{
+		Name: "image-create-success", Method: "POST", Route: "/api/v1/images",
+		Kind: KindAvailability, Target: 0.98, WindowDays: 30,
+	}
+
+	t.Run("success: healthy latency objective", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListObjectivesFunc: func(ctx context.Context) ([]Objective, error) {
+				return []Objective{latency}, nil
+			},
+			AggregateFunc: func(ctx context.Context, method, route string, since time.Time) (*Aggregate, error) {
+				return &Aggregate{Requests: 1000, LatencyBuckets: buckets(map[int64]int64{100: 995, 1000: 5})}, nil
+			},
+		}
+		svc := NewDefaultService(repo)
+		svc.now = func() time.Time { return now }
+
+		statuses, err := svc.Statuses(context.Background())
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+
+		st := statuses[0]
+		assert.Equal(t, now.AddDate(0, 0, -30), st.WindowStart)
+		assert.Equal(t, int64(995), st.GoodRequests)
+		assert.InDelta(t, 0.995, *st.SuccessRate, 1e-9)
+		assert.InDelta(t, 0.5, st.BudgetRemaining, 1e-9)
+		assert.InDelta(t, 0.5, st.BurnRates.OneHour, 1e-9)
+		require.NotNil(t, st.P99Ms)
+		assert.Equal(t, int64(100), *st.P99Ms)
+		assert.Equal(t, AlertNone, st.Alert)
+		assert.Len(t, repo.AggregateCalls(), 5)
+	})
+
+	t.Run("success: fast burn pages", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListObjectivesFunc: func(ctx context.Context) ([]Objective, error) {
+				return []Objective{availability}, nil
+			},
+			AggregateFunc: func(ctx context.Context, method, route string, since time.Time) (*Aggregate, error) {
+				if now.Sub(since) <= time.Hour {
+					// 40% errors against a 2% budget -> burn rate 20
+					return &Aggregate{Requests: 100, Errors: 40}, nil
+				}
+				return &Aggregate{Requests: 10000, Errors: 100}, nil
+			},
+		}
+		svc := NewDefaultService(repo)
+		svc.now = func() time.Time { return now }
+
+		statuses, err := svc.Statuses(context.Background())
+		require.NoError(t, err)
+		st := statuses[0]
+		assert.InDelta(t, 20, st.BurnRates.FiveMinutes, 1e-9)
+		assert.InDelta(t, 0.5, st.BurnRates.SixHours, 1e-9)
+		assert.Equal(t, AlertPage, st.Alert)
+	})
+
+	t.Run("success: slow burn opens ticket", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListObjectivesFunc: func(ctx context.Context) ([]Objective, error) {
+				return []Objective{availability}, nil
+			},
+			AggregateFunc: func(ctx context.Context, method, route string, since time.Time) (*Aggregate, error) {
+				// 15% errors -> burn rate 7.5 everywhere: above ticket, below page
+				return &Aggregate{Requests: 100, Errors: 15}, nil
+			},
+		}
+		svc := NewDefaultService(repo)
+		svc.now = func() time.Time { return now }
+
+		statuses, err := svc.Statuses(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, AlertTicket, statuses[0].Alert)
+	})
+
+	t.Run("success: no traffic", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListObjectivesFunc: func(ctx context.Context) ([]Objective, error) {
+				return []Objective{latency}, nil
+			},
+			AggregateFunc: func(ctx context.Context, method, route string, since time.Time) (*Aggregate, error) {
+				return &Aggregate{LatencyBuckets: buckets(nil)}, nil
+			},
+		}
+		statuses, err := NewDefaultService(repo).Statuses(context.Background())
+		require.NoError(t, err)
+		st := statuses[0]
+		assert.Nil(t, st.SuccessRate)
+		assert.Nil(t, st.P99Ms)
+		assert.Equal(t, 1.0, st.BudgetRemaining)
+		assert.Equal(t, AlertNone, st.Alert)
+	})
+
+	t.Run("fail: aggregate error", func(t *testing.T) {
+		repo := &RepositoryMock{
+			ListObjectivesFunc: func(ctx context.Context) ([]Objective, error) {
+				return []Objective{latency}, nil
+			},
+			AggregateFunc: func(ctx context.Context, method, route string, since time.Time) (*Aggregate, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		_, err := NewDefaultService(repo).Statuses(context.Background())
+		assert.EqualError(t, err, "failed to evaluate objective image-create-p99: db down")
+	})
+}
+
+func TestDefaultService_SaveObjective(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      Objective
+		wantErr string
+	}{
+		{
+			name: "success: latency objective with defaults",
+			in: Objective{
+				Name: "image-get-p99", Method: "get", Route: "/api/v1/images/:id",
+				Kind: KindLatency, Target: 0.99, ThresholdMs: intPtr(300),
+			},
+		},
+		{
+			name:    "fail: bad name",
+			in:      Objective{Name: "Image Create", Method: "POST", Route: "/x", Kind: KindAvailability, Target: 0.9},
+			wantErr: "name must be",
+		},
+		{
+			name:    "fail: bad method",
+			in:      Objective{Name: "x", Method: "TRACE", Route: "/x", Kind: KindAvailability, Target: 0.9},
+			wantErr: "method must be",
+		},
+		{
+			name:    "fail: bad route",
+			in:      Objective{Name: "x", Method: "GET", Route: "x", Kind: KindAvailability, Target: 0.9},
+			wantErr: "route must be",
+		},
+		{
+			name:    "fail: target out of range",
+			in:      Objective{Name: "x", Method: "GET", Route: "/x", Kind: KindAvailability, Target: 1},
+			wantErr: "target must be",
+		},
+		{
+			name: "fail: threshold not a bucket bound",
+			in: Objective{
+				Name: "x", Method: "GET", Route: "/x", Kind: KindLatency, Target: 0.9, ThresholdMs: intPtr(750),
+			},
+			wantErr: "threshold_ms must be one of",
+		},
+		{
+			name: "fail: threshold on availability",
+			in: Objective{
+				Name: "x", Method: "GET", Route: "/x", Kind: KindAvailability, Target: 0.9, ThresholdMs: intPtr(800),
+			},
+			wantErr: "only applies to latency",
+		},
+		{
+			name:    "fail: unknown kind",
+			in:      Objective{Name: "x", Method: "GET", Route: "/x", Kind: "throughput", Target: 0.9},
+			wantErr: "kind must be",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				UpsertObjectiveFunc: func(ctx context.Context, o *Objective) (*Objective, error) {
+					return o, nil
+				},
+			}
+			in := tc.in
+			saved, err := NewDefaultService(repo).SaveObjective(context.Background(), &in)
+			if tc.wantErr != "" {
+				require.ErrorIs(t, err, ErrInvalidObjective)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				assert.Empty(t, repo.UpsertObjectiveCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "GET", saved.Method)
+			assert.Equal(t, DefaultWindowDays, saved.WindowDays)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package slo
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin HTTP endpoints for SLOs.
+type Handler interface {
+	// ListStatuses handles GET /admin/slo - Evaluates every objective.
+	ListStatuses(c echo.Context) error
+
+	// PutObjective handles PUT /admin/slo/:name - Creates or replaces an objective.
+	PutObjective(c echo.Context) error
+
+	// DeleteObjective handles DELETE /admin/slo/:name - Removes an objective.
+	DeleteObjective(c echo.Context) error
+}
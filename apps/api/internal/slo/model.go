@@ -0,0 +1,103 @@
+// Package slo tracks per-route request outcomes and evaluates admin-defined
+// service level objectives and their error-budget burn rates.
+package slo
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrObjectiveNotFound is returned when an objective does not exist.
+var ErrObjectiveNotFound = errors.New("slo objective not found")
+
+// Kind selects what counts as a good request for an objective.
+type Kind string
+
+const (
+	// KindLatency counts a request as good when it completes within ThresholdMs.
+	KindLatency Kind = "latency"
+	// KindAvailability counts a request as good when it does not return a 5xx.
+	KindAvailability Kind = "availability"
+)
+
+// LatencyBucketsMs are the upper bounds of the latency histogram stored with each
+// rollup. A final overflow bucket holds everything slower than the last bound.
+// Latency objective thresholds must be one of these values.
+var LatencyBucketsMs = []int64{25, 50, 100, 200, 300, 500, 800, 1000, 1500, 2500, 5000, 10000}
+
+// RollupRetention is how long rollups are kept; it covers the longest allowed objective window.
+const RollupRetention = 91 * 24 * time.Hour
+
+// Alert is the burn-rate alert level for an objective.
+type Alert string
+
+const (
+	AlertNone Alert = "none"
+	// AlertTicket means the budget is burning fast enough to run out within days.
+	AlertTicket Alert = "ticket"
+	// AlertPage means the budget is burning fast enough to run out within hours.
+	AlertPage Alert = "page"
+)
+
+// Multi-window burn-rate thresholds. A page fires when both the 1h and 5m
+// windows burn faster than PageBurnRate (2% of a 30-day budget in an hour); a
+// ticket fires when both the 6h and 30m windows exceed TicketBurnRate.
+const (
+	PageBurnRate   = 14.4
+	TicketBurnRate = 6.0
+)
+
+// Objective is an admin-defined SLO for a single route.
+type Objective struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Method      string    `json:"method"`
+	Route       string    `json:"route"`
+	Kind        Kind      `json:"kind"`
+	Target      float64   `json:"target"`
+	ThresholdMs *int      `json:"threshold_ms,omitempty"`
+	WindowDays  int       `json:"window_days"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Rollup holds request outcomes for one route during one minute.
+type Rollup struct {
+	Method         string
+	Route          string
+	BucketStart    time.Time
+	Requests       int64
+	Errors         int64
+	LatencySumMs   int64
+	LatencyBuckets []int64
+}
+
+// Aggregate sums rollups for one route over a time range.
+type Aggregate struct {
+	Requests       int64
+	Errors         int64
+	LatencySumMs   int64
+	LatencyBuckets []int64
+}
+
+// BurnRates reports how fast the error budget is being consumed per window;
+// 1.0 spends exactly the budget over the objective's window.
+type BurnRates struct {
+	FiveMinutes   float64 `json:"5m"`
+	ThirtyMinutes float64 `json:"30m"`
+	OneHour       float64 `json:"1h"`
+	SixHours      float64 `json:"6h"`
+}
+
+// Status is the evaluated state of an objective.
+type Status struct {
+	Objective       Objective `json:"objective"`
+	WindowStart     time.Time `json:"window_start"`
+	Requests        int64     `json:"requests"`
+	GoodRequests    int64     `json:"good_requests"`
+	SuccessRate     *float64  `json:"success_rate,omitempty"`
+	P99Ms           *int64    `json:"p99_ms,omitempty"`
+	BudgetRemaining float64   `json:"budget_remaining"`
+	BurnRates       BurnRates `json:"burn_rates"`
+	Alert           Alert     `json:"alert"`
+}
@@ -0,0 +1,175 @@
+package slo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+type rollupKey struct {
+	method string
+	route  string
+	bucket int64
+}
+
+// Recorder buffers per-route request outcomes in memory, bucketed by minute,
+// until they are flushed to the rollup table.
+type Recorder struct {
+	mu      sync.Mutex
+	pending map[rollupKey]*Rollup
+	now     func() time.Time
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{pending: make(map[rollupKey]*Rollup), now: time.Now}
+}
+
+// Observe records one completed request.
+func (r *Recorder) Observe(method, route string, status int, latency time.Duration) {
+	if route == "" {
+		return
+	}
+	start := r.now().UTC().Truncate(time.Minute)
+	key := rollupKey{method: method, route: route, bucket: start.Unix()}
+	ms := latency.Milliseconds()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ru, ok := r.pending[key]
+	if !ok {
+		ru = &Rollup{
+			Method:         method,
+			Route:          route,
+			BucketStart:    start,
+			LatencyBuckets: make([]int64, len(LatencyBucketsMs)+1),
+		}
+		r.pending[key] = ru
+	}
+	ru.Requests++
+	if status >= http.StatusInternalServerError {
+		ru.Errors++
+	}
+	ru.LatencySumMs += ms
+	ru.LatencyBuckets[bucketIndex(ms)]++
+}
+
+// Middleware records the outcome of every request that matched a route. It must
+// be registered outside the request logger so the final status is known.
+func (r *Recorder) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				status = http.StatusInternalServerError
+				if he, ok := err.(*echo.HTTPError); ok {
+					status = he.Code
+				}
+			}
+			r.Observe(c.Request().Method, c.Path(), status, time.Since(start))
+			return err
+		}
+	}
+}
+
+// Drain returns and clears everything buffered so far.
+func (r *Recorder) Drain() []Rollup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rollups := make([]Rollup, 0, len(r.pending))
+	for _, ru := range r.pending {
+		rollups = append(rollups, *ru)
+	}
+	r.pending = make(map[rollupKey]*Rollup)
+	return rollups
+}
+
+// restore merges rollups that failed to flush back into the buffer.
+func (r *Recorder) restore(rollups []Rollup) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ru := range rollups {
+		key := rollupKey{method: ru.Method, route: ru.Route, bucket: ru.BucketStart.Unix()}
+		cur, ok := r.pending[key]
+		if !ok {
+			cp := ru
+			r.pending[key] = &cp
+			continue
+		}
+		cur.Requests += ru.Requests
+		cur.Errors += ru.Errors
+		cur.LatencySumMs += ru.LatencySumMs
+		for i := range cur.LatencyBuckets {
+			if i < len(ru.LatencyBuckets) {
+				cur.LatencyBuckets[i] += ru.LatencyBuckets[i]
+			}
+		}
+	}
+}
+
+// Flush writes buffered rollups to the repository. On failure the rollups are
+// kept for the next attempt.
+func (r *Recorder) Flush(ctx context.Context, repo Repository) error {
+	rollups := r.Drain()
+	if len(rollups) == 0 {
+		return nil
+	}
+	if err := repo.AddRollups(ctx, rollups); err != nil {
+		r.restore(rollups)
+		return err
+	}
+	return nil
+}
+
+// RunFlusher flushes the recorder every interval and prunes rollups older than
+// retention once an hour, until ctx is cancelled. A final flush runs on exit.
+func RunFlusher(ctx context.Context, rec *Recorder, repo Repository, interval, retention time.Duration) {
+	log := logging.NewDefaultLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastPrune := time.Time{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := rec.Flush(context.WithoutCancel(ctx), repo); err != nil {
+				log.Error(ctx, "slo final flush failed", "error", err)
+			}
+			return
+		case <-ticker.C:
+			if err := rec.Flush(ctx, repo); err != nil {
+				log.Error(ctx, "slo rollup flush failed", "error", err)
+			}
+			if time.Since(lastPrune) >= time.Hour {
+				lastPrune = time.Now()
+				n, err := repo.DeleteRollupsBefore(ctx, lastPrune.Add(-retention))
+				if err != nil {
+					log.Error(ctx, "slo rollup prune failed", "error", err)
+				} else if n > 0 {
+					log.Info(ctx, "slo rollups pruned", "count", n)
+				}
+			}
+		}
+	}
+}
+
+// bucketIndex returns the histogram bucket for a latency in milliseconds.
+func bucketIndex(ms int64) int {
+	for i, bound := range LatencyBucketsMs {
+		if ms <= bound {
+			return i
+		}
+	}
+	return len(LatencyBucketsMs)
+}
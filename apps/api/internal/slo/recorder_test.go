@@ -0,0 +1,96 @@
+package slo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_Observe(t *testing.T) {
+	rec := NewRecorder()
+	now := time.Date(2025, 10, 12, 20, 30, 15, 0, time.UTC)
+	rec.now = func() time.Time { return now }
+
+	rec.Observe(http.MethodPost, "/api/v1/images", http.StatusCreated, 40*time.Millisecond)
+	rec.Observe(http.MethodPost, "/api/v1/images", http.StatusInternalServerError, 900*time.Millisecond)
+	rec.Observe(http.MethodPost, "/api/v1/images", http.StatusBadRequest, 20*time.Second)
+	rec.Observe(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+
+	rollups := rec.Drain()
+	require.Len(t, rollups, 1)
+	ru := rollups[0]
+	assert.Equal(t, "/api/v1/images", ru.Route)
+	assert.Equal(t, now.Truncate(time.Minute), ru.BucketStart)
+	assert.Equal(t, int64(3), ru.Requests)
+	assert.Equal(t, int64(1), ru.Errors)
+	assert.Equal(t, int64(1), ru.LatencyBuckets[1])                     // 40ms -> <=50
+	assert.Equal(t, int64(1), ru.LatencyBuckets[7])                     // 900ms -> <=1000
+	assert.Equal(t, int64(1), ru.LatencyBuckets[len(LatencyBucketsMs)]) // overflow
+
+	assert.Empty(t, rec.Drain())
+}
+
+func TestRecorder_Middleware(t *testing.T) {
+	rec := NewRecorder()
+	e := echo.New()
+	e.Use(rec.Middleware())
+	e.GET("/api/v1/images/:id", func(c echo.Context) error {
+		if c.Param("id") == "boom" {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, "down")
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, path := range []string{"/api/v1/images/a", "/api/v1/images/boom"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rollups := rec.Drain()
+	require.Len(t, rollups, 1)
+	assert.Equal(t, "/api/v1/images/:id", rollups[0].Route)
+	assert.Equal(t, int64(2), rollups[0].Requests)
+	assert.Equal(t, int64(1), rollups[0].Errors)
+}
+
+func TestRecorder_Flush(t *testing.T) {
+	t.Run("success: writes and clears buffer", func(t *testing.T) {
+		rec := NewRecorder()
+		rec.Observe(http.MethodGet, "/health", http.StatusOK, time.Millisecond)
+
+		repo := &RepositoryMock{
+			AddRollupsFunc: func(ctx context.Context, rollups []Rollup) error { return nil },
+		}
+		require.NoError(t, rec.Flush(context.Background(), repo))
+		require.Len(t, repo.AddRollupsCalls(), 1)
+		assert.Len(t, repo.AddRollupsCalls()[0].Rollups, 1)
+		assert.Empty(t, rec.Drain())
+	})
+
+	t.Run("fail: keeps rollups for the next attempt", func(t *testing.T) {
+		rec := NewRecorder()
+		rec.Observe(http.MethodGet, "/health", http.StatusOK, time.Millisecond)
+
+		repo := &RepositoryMock{
+			AddRollupsFunc: func(ctx context.Context, rollups []Rollup) error { return errors.New("db down") },
+		}
+		require.Error(t, rec.Flush(context.Background(), repo))
+
+		rec.Observe(http.MethodGet, "/health", http.StatusOK, time.Millisecond)
+		rollups := rec.Drain()
+		require.Len(t, rollups, 1)
+		assert.Equal(t, int64(2), rollups[0].Requests)
+	})
+
+	t.Run("success: nothing buffered", func(t *testing.T) {
+		repo := &RepositoryMock{}
+		require.NoError(t, NewRecorder().Flush(context.Background(), repo))
+		assert.Empty(t, repo.AddRollupsCalls())
+	})
+}
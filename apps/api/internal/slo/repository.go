@@ -0,0 +1,29 @@
+package slo
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for SLO objectives and route rollups.
+type Repository interface {
+	// ListObjectives returns all objectives ordered by name.
+	ListObjectives(ctx context.Context) ([]Objective, error)
+
+	// UpsertObjective creates or replaces the objective with the same name.
+	UpsertObjective(ctx context.Context, o *Objective) (*Objective, error)
+
+	// DeleteObjective removes an objective by name.
+	DeleteObjective(ctx context.Context, name string) error
+
+	// AddRollups adds the given counts into the stored per-minute rollups.
+	AddRollups(ctx context.Context, rollups []Rollup) error
+
+	// Aggregate sums the rollups for a route from since until now.
+	Aggregate(ctx context.Context, method, route string, since time.Time) (*Aggregate, error)
+
+	// DeleteRollupsBefore prunes rollups older than the cutoff.
+	DeleteRollupsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,17 @@
+package slo
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service evaluates objectives against recorded route metrics.
+type Service interface {
+	// Statuses evaluates every objective and reports success rates, budgets and burn-rate alerts.
+	Statuses(ctx context.Context) ([]Status, error)
+
+	// SaveObjective validates and stores an objective, replacing any with the same name.
+	SaveObjective(ctx context.Context, o *Objective) (*Objective, error)
+
+	// DeleteObjective removes an objective by name.
+	DeleteObjective(ctx context.Context, name string) error
+}
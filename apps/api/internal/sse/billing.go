@@ -0,0 +1,163 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// billingChannelPrefix is the prefix of the per-user channels billing changes
+// are published on (billing:user:{user_id}). Webhooks reach any API instance,
+// so changes travel through Redis to whichever instance holds the stream.
+const billingChannelPrefix = "billing:user:"
+
+// billingUpdate is the payload of a "billing_update" event.
+type billingUpdate struct {
+	Type events.Type  `json:"type"`
+	Data events.Event `json:"data"`
+}
+
+// RegisterBillingSubscribers publishes every persisted subscription, invoice
+// and checkout change, and the end of a past-due grace period, to its user's
+// billing channel.
+func RegisterBillingSubscribers(bus events.Bus, rdb *redis.Client) {
+	events.On(bus, "billing_stream", func(ctx context.Context, e events.SubscriptionChanged) error {
+		return publishBilling(ctx, rdb, e.UserID, e)
+	})
+	events.On(bus, "billing_stream", func(ctx context.Context, e events.InvoiceChanged) error {
+		return publishBilling(ctx, rdb, e.UserID, e)
+	})
+	events.On(bus, "billing_stream", func(ctx context.Context, e events.CheckoutCompleted) error {
+		return publishBilling(ctx, rdb, e.UserID, e)
+	})
+	events.On(bus, "billing_stream", func(ctx context.Context, e events.SubscriptionGraceExpired) error {
+		return publishBilling(ctx, rdb, e.UserID, e)
+	})
+}
+
+func publishBilling(ctx context.Context, rdb *redis.Client, userID string, e events.Event) error {
+	payload, err := json.Marshal(billingUpdate{Type: e.EventType(), Data: e})
+	if err != nil {
+		return fmt.Errorf("failed to marshal billing update: %w", err)
+	}
+	if err := rdb.Publish(ctx, billingChannelPrefix+userID, payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish billing update: %w", err)
+	}
+	return nil
+}
+
+// StreamBilling subscribes to the user's billing channel and forwards each change
+// as a "billing_update" event: {"type":"invoice.changed","data":{...}}. It emits
+// "connected" and "heartbeat" events like the image streams.
+func (d *DefaultSSE) StreamBilling(ctx context.Context, w io.Writer, userID string) error {
+	log := logging.NewDefaultLogger()
+	tracer := otel.Tracer("real-staging-api/sse")
+	ctx, span := tracer.Start(ctx, "sse.StreamBilling")
+	span.SetAttributes(attribute.String("user.id", userID))
+	defer span.End()
+
+	var err error
+	switch {
+	case userID == "":
+		err = errors.New("userID required")
+	case d.rdb == nil:
+		err = errors.New("redis client is nil")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	channel := billingChannelPrefix + userID
+	sub := d.rdb.Subscribe(ctx, channel)
+	defer func() { _ = sub.Close() }()
+	if err := d.awaitSubscribe(ctx, sub); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "subscribe failed")
+		log.Error(ctx, "sse subscribe failed", "sse.channels", []string{channel}, "error", err)
+		return fmt.Errorf("subscribe to %s: %w", channel, err)
+	}
+
+	tw := textWriter{w}
+	if err := tw.WriteEvent(EventConnected, map[string]string{"message": "Connected to billing stream"}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "write connected event failed")
+		return err
+	}
+
+	ticker := time.NewTicker(d.heartbeat)
+	defer ticker.Stop()
+	msgCh := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := tw.WriteEvent(EventHeartbeat, map[string]any{"timestamp": time.Now().Unix()}); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "write heartbeat failed")
+				return err
+			}
+		case msg, ok := <-msgCh:
+			if !ok {
+				log.Info(ctx, "sse subscription channel closed", "sse.channels", []string{channel})
+				return nil
+			}
+			if !json.Valid([]byte(msg.Payload)) {
+				log.Warn(ctx, "sse malformed payload", "sse.channel", msg.Channel)
+				continue
+			}
+			if err := tw.WriteEvent(EventBillingUpdate, json.RawMessage(msg.Payload)); err != nil {
+				span.SetStatus(codes.Error, "write billing_update failed")
+				log.Error(ctx, "sse write billing_update failed", "sse.channel", msg.Channel, "error", err)
+				return err
+			}
+		}
+	}
+}
+
+// BillingEvents is an Echo handler for GET /api/v1/billing/events that streams
+// the caller's subscription, invoice and checkout changes as Server-Sent Events,
+// e.g. after a Stripe webhook records a paid invoice:
+//
+//	event: billing_update
+//	data: {"type":"invoice.changed","data":{"stripe_invoice_id":"in_123","status":"paid",...}}
+func (h *DefaultHandler) BillingEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	if h.sse == nil || h.repo == nil {
+		logging.NewDefaultLogger().Error(ctx, "pubsub not configured for billing SSE")
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+	}
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	userID, err := h.repo.UserID(ctx, auth0Sub)
+	if err != nil {
+		logging.NewDefaultLogger().Error(ctx, "failed to resolve user for billing SSE", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to resolve user"})
+	}
+	if userID == "" {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "user not found"})
+	}
+
+	c.Response().Header().Set("Content-Type", "text/event-stream")
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	c.Response().Header().Set("Connection", "keep-alive")
+	return h.sse.StreamBilling(ctx, c.Response().Writer, userID)
+}
@@ -0,0 +1,124 @@
+package sse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/labstack/echo/v4"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/events"
+)
+
+func TestDefaultSSE_StreamBilling_ForwardsBusEvents(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	bus := events.NewDefaultBus(nil)
+	RegisterBillingSubscribers(bus, rdb)
+	streamer := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Minute}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = streamer.StreamBilling(ctx, w, "user-1")
+		close(done)
+	}()
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "Connected to billing stream")
+	})
+
+	occurred := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, bus.Publish(ctx, events.InvoiceChanged{
+		UserID: "user-2", StripeInvoiceID: "in_other", Status: "paid", Reason: "paid", OccurredAt: occurred,
+	}))
+	require.NoError(t, bus.Publish(ctx, events.InvoiceChanged{
+		UserID: "user-1", StripeInvoiceID: "in_1", Status: "paid", AmountPaid: 2900, Reason: "paid", OccurredAt: occurred,
+	}))
+	require.NoError(t, bus.Publish(ctx, events.SubscriptionGraceExpired{
+		UserID: "user-1", StripeSubscriptionID: "sub_1", PastDueSince: occurred, GraceEndedAt: occurred, OccurredAt: occurred,
+	}))
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "subscription.grace_expired")
+	})
+	out := w.String()
+	assert.Contains(t, out, "event: billing_update")
+	assert.Contains(t, out, `"type":"invoice.changed"`)
+	assert.Contains(t, out, `"stripe_invoice_id":"in_1"`)
+	assert.NotContains(t, out, "in_other", "other users' changes are not streamed")
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
+func TestDefaultSSE_StreamBilling_Validation(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	err := NewDefaultSSE(rdb, Config{}, nil).StreamBilling(context.Background(), &bufFlusher{}, "")
+	assert.EqualError(t, err, "userID required")
+}
+
+func TestDefaultHandler_BillingEvents(t *testing.T) {
+	newCtx := func() (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/billing/events", nil)
+		req.Header.Set("X-Test-User", "auth0|billing")
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("success: streams the caller's billing channel", func(t *testing.T) {
+		var streamed string
+		s := &SSEMock{
+			StreamBillingFunc: func(ctx context.Context, w io.Writer, userID string) error {
+				streamed = userID
+				return nil
+			},
+		}
+		repo := &RepositoryMock{
+			UserIDFunc: func(ctx context.Context, auth0Sub string) (string, error) {
+				assert.Equal(t, "auth0|billing", auth0Sub)
+				return "user-1", nil
+			},
+		}
+		c, rec := newCtx()
+
+		require.NoError(t, NewDefaultHandler(s, repo).BillingEvents(c))
+		assert.Equal(t, "user-1", streamed)
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("fail: unknown user", func(t *testing.T) {
+		repo := &RepositoryMock{
+			UserIDFunc: func(ctx context.Context, auth0Sub string) (string, error) { return "", nil },
+		}
+		c, rec := newCtx()
+
+		require.NoError(t, NewDefaultHandler(&SSEMock{}, repo).BillingEvents(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("fail: pubsub not configured", func(t *testing.T) {
+		c, rec := newCtx()
+
+		require.NoError(t, NewDefaultHandler(nil, nil).BillingEvents(c))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
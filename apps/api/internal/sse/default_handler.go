@@ -1,43 +1,67 @@
 package sse
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/real-staging-ai/api/internal/auth"
 	"github.com/real-staging-ai/api/internal/logging"
 )
 
 // DefaultHandler provides Echo HTTP handlers for SSE endpoints.
 type DefaultHandler struct {
-	sse SSE
+	sse      SSE
+	repo     Repository
+	pongWait time.Duration
 }
 
 // NewDefaultHandler constructs a DefaultHandler with the provided SSE implementation.
-func NewDefaultHandler(s SSE) *DefaultHandler {
-	return &DefaultHandler{sse: s}
+// repo checks project access for project_id streams.
+func NewDefaultHandler(s SSE, repo Repository) *DefaultHandler {
+	return &DefaultHandler{sse: s, repo: repo, pongWait: wsPongWait}
 }
 
 // NewDefaultHandlerFromEnv constructs a DefaultHandler using the default Redis-backed SSE implementation.
-// It reads REDIS_ADDR from the environment.
-func NewDefaultHandlerFromEnv(cfg Config) (*DefaultHandler, error) {
-	streamer, err := NewDefaultSSEFromEnv(cfg)
+// It reads REDIS_HOST and REDIS_PORT from the environment.
+func NewDefaultHandlerFromEnv(cfg Config, repo Repository) (*DefaultHandler, error) {
+	streamer, err := NewDefaultSSEFromEnv(cfg, repo)
 	if err != nil {
 		return nil, err
 	}
-	return &DefaultHandler{sse: streamer}, nil
+	return NewDefaultHandler(streamer, repo), nil
 }
 
 // Events is an Echo handler for GET /api/v1/events?image_id={id} that streams
 // Server-Sent Events scoped to a single image (per-image channel).
 //
-// It sets the appropriate SSE headers, validates the image_id query parameter,
+// Passing image_ids={id},{id},... instead streams updates for up to MaxStreamImages
+// images over one connection, which is how clients follow bulk operations such as
+// a project restyle. Batch job_update payloads also carry the image_id.
+//
+// project_id={id} streams updates for every image in a project the caller can
+// access, and narrows image_id/image_ids to that project when combined with them.
+// types={status},{status},... drops updates with other statuses. Filtering
+// happens server-side, so a dashboard watching one project receives nothing else.
+//
+// It sets the appropriate SSE headers, validates the query parameters,
 // and delegates streaming to the configured SSE implementation.
 //
 // Expected minimal payloads are status-only job updates, e.g.:
 //
 //	event: job_update
 //	data: {"status":"processing"}
+//
+// A ready update includes the staged image's placeholder so galleries can paint
+// it before the staged image loads:
+//
+//	event: job_update
+//	data: {"status":"ready","blurhash":"LEHV6nWB2yk8pyo0adR*.7kCMdnj"}
 func (h *DefaultHandler) Events(c echo.Context) error {
 	// Set SSE headers
 	c.Response().Header().Set("Content-Type", "text/event-stream")
@@ -46,17 +70,118 @@ func (h *DefaultHandler) Events(c echo.Context) error {
 	c.Response().Header().Set("Access-Control-Allow-Origin", "*")
 	c.Response().Header().Set("Access-Control-Allow-Headers", "Cache-Control")
 
+	ctx := c.Request().Context()
+	filter, resp, status := parseFilter(c)
+	if resp != nil {
+		return c.JSON(status, resp)
+	}
+	if resp, status := h.checkStream(c, filter); resp != nil {
+		return c.JSON(status, resp)
+	}
+
+	// Stream events until client disconnects (request context is cancelled)
+	w := c.Response().Writer
+	switch {
+	case filter.ProjectID != "" || len(filter.Statuses) > 0:
+		return h.sse.Stream(ctx, w, filter)
+	case c.QueryParam("image_id") == "":
+		return h.sse.StreamImages(ctx, w, filter.ImageIDs)
+	default:
+		return h.sse.StreamImage(ctx, w, filter.ImageIDs[0])
+	}
+}
+
+// parseFilter reads and validates the image_id, image_ids, project_id and
+// types query parameters, returning an error body and status when invalid.
+// image_id takes precedence over image_ids.
+func parseFilter(c echo.Context) (Filter, map[string]string, int) {
 	imageID := c.QueryParam("image_id")
-	if imageID == "" {
+	imageIDs := parseImageIDs(c.QueryParam("image_ids"))
+	projectID := c.QueryParam("project_id")
+	statuses := parseImageIDs(c.QueryParam("types"))
+	if imageID == "" && len(imageIDs) == 0 && projectID == "" {
 		logging.NewDefaultLogger().Warn(c.Request().Context(), "missing image_id for SSE events")
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing image_id"})
+		return Filter{}, map[string]string{"error": "missing image_id, image_ids or project_id"}, http.StatusBadRequest
+	}
+	if len(imageIDs) > MaxStreamImages {
+		return Filter{}, map[string]string{
+			"error": fmt.Sprintf("too many image_ids (max %d)", MaxStreamImages),
+		}, http.StatusBadRequest
+	}
+	if projectID != "" {
+		if _, err := uuid.Parse(projectID); err != nil {
+			return Filter{}, map[string]string{"error": "invalid project_id"}, http.StatusBadRequest
+		}
+	}
+	for _, status := range statuses {
+		if !slices.Contains(JobStatuses, status) {
+			return Filter{}, map[string]string{
+				"error": "types must be a comma-separated list of: " + strings.Join(JobStatuses, ", "),
+			}, http.StatusBadRequest
+		}
 	}
 
+	if imageID != "" {
+		imageIDs = []string{imageID}
+	}
+	return Filter{ImageIDs: imageIDs, ProjectID: projectID, Statuses: statuses}, nil, 0
+}
+
+// checkStream returns an error body and status when filter cannot be streamed:
+// pub/sub is not configured or the caller may not access its project.
+func (h *DefaultHandler) checkStream(c echo.Context, filter Filter) (map[string]string, int) {
 	if h.sse == nil {
-		logging.NewDefaultLogger().Error(c.Request().Context(), "pubsub not configured for SSE", "image_id", imageID)
-		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "pubsub not configured"})
+		logging.NewDefaultLogger().Error(c.Request().Context(), "pubsub not configured for SSE",
+			"image_ids", len(filter.ImageIDs), "project_id", filter.ProjectID)
+		return map[string]string{"error": "pubsub not configured"}, http.StatusServiceUnavailable
 	}
+	if filter.ProjectID != "" {
+		return h.checkProjectAccess(c, filter.ProjectID)
+	}
+	return nil, 0
+}
 
-	// Stream events until client disconnects (request context is cancelled)
-	return h.sse.StreamImage(c.Request().Context(), c.Response().Writer, imageID)
+// checkProjectAccess returns an error body and status when the caller may not
+// stream projectID.
+func (h *DefaultHandler) checkProjectAccess(c echo.Context, projectID string) (map[string]string, int) {
+	ctx := c.Request().Context()
+	if h.repo == nil {
+		logging.NewDefaultLogger().Error(ctx, "project access check not configured for SSE", "project_id", projectID)
+		return map[string]string{"error": "project streams not configured"}, http.StatusServiceUnavailable
+	}
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return map[string]string{"error": "unauthorized"}, http.StatusUnauthorized
+	}
+	ok, err := h.repo.ProjectAccessible(ctx, projectID, auth0Sub)
+	if err != nil {
+		logging.NewDefaultLogger().Error(ctx, "failed to check project access for SSE",
+			"project_id", projectID, "error", err)
+		return map[string]string{"error": "failed to check project access"}, http.StatusInternalServerError
+	}
+	if !ok {
+		return map[string]string{"error": "project not found"}, http.StatusNotFound
+	}
+	return nil, 0
+}
+
+// parseImageIDs splits a comma-separated image_ids (or types) value, dropping blanks and duplicates.
+func parseImageIDs(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	var ids []string
+	for _, part := range strings.Split(raw, ",") {
+		id := strings.TrimSpace(part)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids
 }
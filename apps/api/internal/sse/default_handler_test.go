@@ -2,6 +2,9 @@ package sse
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -35,7 +38,7 @@ func TestDefaultHandler_Events_ConnectedAndUpdate(t *testing.T) {
 	t.Setenv("REDIS_PORT", mr.Port())
 
 	// Build handler from env (uses DefaultSSE)
-	h, err := NewDefaultHandlerFromEnv(Config{HeartbeatInterval: 50 * time.Millisecond})
+	h, err := NewDefaultHandlerFromEnv(Config{HeartbeatInterval: 50 * time.Millisecond}, nil)
 	require.NoError(t, err)
 
 	// Prepare Echo context
@@ -87,7 +90,7 @@ func TestDefaultHandler_Events_ConnectedAndUpdate(t *testing.T) {
 
 func TestDefaultHandler_Events_MissingImageID(t *testing.T) {
 	// Handler with nil SSE is fine; missing image_id is validated before SSE use
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
@@ -103,7 +106,7 @@ func TestDefaultHandler_Events_MissingImageID(t *testing.T) {
 
 func TestDefaultHandler_Events_NoPubSubConfigured(t *testing.T) {
 	// Handler with nil SSE should 503 when image_id is present
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?image_id=img-xyz", nil)
@@ -125,7 +128,7 @@ func TestDefaultHandler_Events_MultiUpdates(t *testing.T) {
 	t.Setenv("REDIS_PORT", mr.Port())
 
 	// Build handler from env (uses DefaultSSE)
-	h, err := NewDefaultHandlerFromEnv(Config{HeartbeatInterval: 50 * time.Millisecond})
+	h, err := NewDefaultHandlerFromEnv(Config{HeartbeatInterval: 50 * time.Millisecond}, nil)
 	require.NoError(t, err)
 
 	// Prepare Echo context
@@ -178,3 +181,132 @@ func TestDefaultHandler_Events_MultiUpdates(t *testing.T) {
 	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
+
+func TestDefaultHandler_Events_ImageIDs(t *testing.T) {
+	t.Run("success: delegates deduplicated ids to StreamImages", func(t *testing.T) {
+		var got []string
+		mock := &SSEMock{
+			StreamImagesFunc: func(ctx context.Context, w io.Writer, imageIDs []string) error {
+				got = imageIDs
+				return nil
+			},
+		}
+		h := NewDefaultHandler(mock, nil)
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/events?image_ids=img-1,%20img-2,,img-1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, h.Events(c))
+		assert.Equal(t, []string{"img-1", "img-2"}, got)
+		assert.Empty(t, mock.StreamImageCalls())
+	})
+
+	t.Run("fail: too many image ids", func(t *testing.T) {
+		h := NewDefaultHandler(&SSEMock{}, nil)
+
+		ids := make([]string, MaxStreamImages+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("img-%d", i)
+		}
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/events?image_ids="+strings.Join(ids, ","), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, h.Events(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "too many image_ids")
+	})
+}
+
+func TestDefaultHandler_Events_Filters(t *testing.T) {
+	const projectID = "7d7c2c1e-3f4a-4a8e-9b1c-2f1e5b6a7c8d"
+	accessible := func(ok bool, err error) *RepositoryMock {
+		return &RepositoryMock{
+			ProjectAccessibleFunc: func(ctx context.Context, projectID, auth0Sub string) (bool, error) {
+				return ok, err
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		repo       *RepositoryMock
+		wantStatus int
+		wantBody   string
+		wantFilter *Filter
+	}{
+		{
+			name:       "success: project stream with types",
+			query:      "project_id=" + projectID + "&types=ready,error",
+			repo:       accessible(true, nil),
+			wantStatus: http.StatusOK,
+			wantFilter: &Filter{ProjectID: projectID, Statuses: []string{"ready", "error"}},
+		},
+		{
+			name:       "success: types narrow a single image",
+			query:      "image_id=img-1&types=ready",
+			wantStatus: http.StatusOK,
+			wantFilter: &Filter{ImageIDs: []string{"img-1"}, Statuses: []string{"ready"}},
+		},
+		{
+			name:       "fail: invalid project id",
+			query:      "project_id=nope",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "invalid project_id",
+		},
+		{
+			name:       "fail: unknown type",
+			query:      "image_id=img-1&types=done",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "types must be",
+		},
+		{
+			name:       "fail: project not accessible",
+			query:      "project_id=" + projectID,
+			repo:       accessible(false, nil),
+			wantStatus: http.StatusNotFound,
+			wantBody:   "project not found",
+		},
+		{
+			name:       "fail: access check error",
+			query:      "project_id=" + projectID,
+			repo:       accessible(false, errors.New("db down")),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &SSEMock{
+				StreamFunc: func(ctx context.Context, w io.Writer, filter Filter) error { return nil },
+			}
+			var repo Repository
+			if tt.repo != nil {
+				repo = tt.repo
+			}
+			h := NewDefaultHandler(mock, repo)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/events?"+tt.query, nil)
+			req.Header.Set("X-Test-User", "auth0|alice")
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.Events(e.NewContext(req, rec)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+			if tt.wantFilter == nil {
+				assert.Empty(t, mock.StreamCalls())
+				return
+			}
+			require.Len(t, mock.StreamCalls(), 1)
+			assert.Equal(t, *tt.wantFilter, mock.StreamCalls()[0].Filter)
+			if tt.repo != nil {
+				assert.Equal(t, "auth0|alice", tt.repo.ProjectAccessibleCalls()[0].Auth0Sub)
+			}
+		})
+	}
+}
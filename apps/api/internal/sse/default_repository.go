@@ -0,0 +1,77 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// ProjectAccessible checks project ownership and organization sharing.
+func (r *DefaultRepository) ProjectAccessible(ctx context.Context, projectID, auth0Sub string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM projects p
+			JOIN users u ON u.auth0_sub = $2
+			WHERE p.id = $1 AND (p.user_id = u.id OR EXISTS (
+				SELECT 1 FROM organization_members m
+				WHERE m.org_id = p.org_id AND m.user_id = u.id
+			))
+		)
+	`
+
+	var ok bool
+	if err := r.db.QueryRow(ctx, query, projectID, auth0Sub).Scan(&ok); err != nil {
+		return false, fmt.Errorf("failed to check project access: %w", err)
+	}
+	return ok, nil
+}
+
+// ImageProjectID looks up the project of an image, including trashed ones.
+func (r *DefaultRepository) ImageProjectID(ctx context.Context, imageID string) (string, error) {
+	if _, err := uuid.Parse(imageID); err != nil {
+		return "", nil
+	}
+	query := `SELECT project_id::text FROM images WHERE id = $1`
+
+	var projectID string
+	if err := r.db.QueryRow(ctx, query, imageID).Scan(&projectID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get image project: %w", err)
+	}
+	return projectID, nil
+}
+
+// UserID looks up a user's internal ID by Auth0 subject.
+func (r *DefaultRepository) UserID(ctx context.Context, auth0Sub string) (string, error) {
+	query := `SELECT id::text FROM users WHERE auth0_sub = $1`
+
+	var userID string
+	if err := r.db.QueryRow(ctx, query, auth0Sub).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return userID, nil
+}
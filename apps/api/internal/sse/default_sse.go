@@ -7,27 +7,34 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	redis "github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/real-staging-ai/api/internal/logging"
 )
 
+// channelPrefix is the prefix of the per-image channels the worker publishes
+// job updates on (jobs:image:{image_id}).
+const channelPrefix = "jobs:image:"
+
 // DefaultSSE is a Redis Pub/Sub–backed implementation of SSE.
 // It streams minimal, status-only job update payloads over Server-Sent Events.
 type DefaultSSE struct {
 	rdb              *redis.Client
+	repo             Repository
 	heartbeat        time.Duration
-	channelFmt       string
 	subscribeTimeout time.Duration
 }
 
 // NewDefaultSSEFromEnv constructs a DefaultSSE using REDIS_HOST and REDIS_PORT from the environment.
-func NewDefaultSSEFromEnv(cfg Config) (*DefaultSSE, error) {
+func NewDefaultSSEFromEnv(cfg Config, repo Repository) (*DefaultSSE, error) {
 	host := os.Getenv("REDIS_HOST")
 	if host == "" {
 		return nil, errors.New("REDIS_HOST not set")
@@ -38,50 +45,144 @@ func NewDefaultSSEFromEnv(cfg Config) (*DefaultSSE, error) {
 	}
 	addr := host + ":" + port
 	rdb := redis.NewClient(&redis.Options{Addr: addr})
-	return NewDefaultSSE(rdb, cfg), nil
+	return NewDefaultSSE(rdb, cfg, repo), nil
 }
 
 // NewDefaultSSE initializes a DefaultSSE with an existing Redis client.
-// If cfg.HeartbeatInterval is zero, a 30s default is used.
-func NewDefaultSSE(rdb *redis.Client, cfg Config) *DefaultSSE {
+// If cfg.HeartbeatInterval is zero, a 30s default is used. repo resolves
+// image projects for project-filtered streams and may be nil otherwise.
+func NewDefaultSSE(rdb *redis.Client, cfg Config, repo Repository) *DefaultSSE {
 	hb := cfg.HeartbeatInterval
 	if hb <= 0 {
 		hb = 30 * time.Second
 	}
 	return &DefaultSSE{
 		rdb:              rdb,
+		repo:             repo,
 		heartbeat:        hb,
-		channelFmt:       "jobs:image:%s",
 		subscribeTimeout: cfg.SubscribeTimeout,
 	}
 }
 
-// StreamImage subscribes to a per-image channel and forwards status-only updates via SSE.
+// StreamImage subscribes to a per-image channel and forwards status updates via SSE.
 // It emits an initial "connected" event, periodic "heartbeat" events, and "job_update" events
-// containing a minimal payload: {"status":"..."}.
+// containing a minimal payload: {"status":"..."}. Ready updates also carry the staged
+// image's "blurhash" when the worker computed one, and error updates carry an
+// "error_code" when the original was rejected.
 func (d *DefaultSSE) StreamImage(ctx context.Context, w io.Writer, imageID string) error {
 	tracer := otel.Tracer("real-staging-api/sse")
 	ctx, span := tracer.Start(ctx, "sse.StreamImage")
 	span.SetAttributes(attribute.String("image.id", imageID))
 	defer span.End()
-	log := logging.NewDefaultLogger()
 
-	if d.rdb == nil {
-		err := errors.New("redis client is nil")
+	if imageID == "" {
+		err := errors.New("imageID required")
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
-	if imageID == "" {
-		err := errors.New("imageID required")
+
+	return d.stream(ctx, span, textWriter{w}, Filter{ImageIDs: []string{imageID}}, false)
+}
+
+// StreamImages subscribes to the per-image channels of every image in imageIDs and
+// forwards their updates over one stream. Each "job_update" payload names its image:
+// {"image_id":"...","status":"..."}.
+func (d *DefaultSSE) StreamImages(ctx context.Context, w io.Writer, imageIDs []string) error {
+	tracer := otel.Tracer("real-staging-api/sse")
+	ctx, span := tracer.Start(ctx, "sse.StreamImages")
+	span.SetAttributes(attribute.Int("images.count", len(imageIDs)))
+	defer span.End()
+
+	if len(imageIDs) == 0 {
+		err := errors.New("imageIDs required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	if err := validateImageIDs(imageIDs); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
 
-	channel := fmt.Sprintf(d.channelFmt, imageID)
-	span.SetAttributes(attribute.String("sse.channel", channel))
-	sub := d.rdb.Subscribe(ctx, channel)
+	return d.stream(ctx, span, textWriter{w}, Filter{ImageIDs: imageIDs}, true)
+}
+
+// Stream forwards the updates selected by filter. A project stream without
+// image IDs subscribes to every image channel and drops updates for images
+// outside the project, looking each image's project up once per stream.
+func (d *DefaultSSE) Stream(ctx context.Context, w io.Writer, filter Filter) error {
+	return d.StreamEvents(ctx, textWriter{w}, filter)
+}
+
+// StreamEvents forwards the updates selected by filter to w, validating the
+// filter as Stream does.
+func (d *DefaultSSE) StreamEvents(ctx context.Context, w EventWriter, filter Filter) error {
+	tracer := otel.Tracer("real-staging-api/sse")
+	ctx, span := tracer.Start(ctx, "sse.Stream")
+	span.SetAttributes(
+		attribute.String("project.id", filter.ProjectID),
+		attribute.Int("images.count", len(filter.ImageIDs)),
+		attribute.StringSlice("sse.statuses", filter.Statuses),
+	)
+	defer span.End()
+
+	var err error
+	switch {
+	case filter.ProjectID == "" && len(filter.ImageIDs) == 0:
+		err = errors.New("imageIDs or projectID required")
+	case len(filter.ImageIDs) > 0:
+		err = validateImageIDs(filter.ImageIDs)
+	}
+	if err == nil && filter.ProjectID != "" && d.repo == nil {
+		err = errors.New("project streams require a repository")
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	tagged := filter.ProjectID != "" || len(filter.ImageIDs) > 1
+	return d.stream(ctx, span, w, filter, tagged)
+}
+
+// validateImageIDs enforces MaxStreamImages and rejects empty IDs.
+func validateImageIDs(imageIDs []string) error {
+	if len(imageIDs) > MaxStreamImages {
+		return fmt.Errorf("too many images: %d (max %d)", len(imageIDs), MaxStreamImages)
+	}
+	if slices.Contains(imageIDs, "") {
+		return errors.New("imageIDs must not contain empty values")
+	}
+	return nil
+}
+
+// stream runs the subscribe/heartbeat/forward loop shared by every stream.
+// When tagged is true, job_update payloads include the image_id of the originating channel.
+func (d *DefaultSSE) stream(ctx context.Context, span trace.Span, w EventWriter, filter Filter, tagged bool) error {
+	log := logging.NewDefaultLogger()
+
+	if d.rdb == nil {
+		err := errors.New("redis client is nil")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	var sub *redis.PubSub
+	channels := make([]string, len(filter.ImageIDs))
+	for i, id := range filter.ImageIDs {
+		channels[i] = channelPrefix + id
+	}
+	if len(channels) == 0 {
+		channels = []string{channelPrefix + "*"}
+		sub = d.rdb.PSubscribe(ctx, channels...)
+	} else {
+		sub = d.rdb.Subscribe(ctx, channels...)
+	}
+	span.SetAttributes(attribute.StringSlice("sse.channels", channels))
 	defer func() { _ = sub.Close() }()
 
 	// Optionally wait for subscription to be established
@@ -89,64 +190,121 @@ func (d *DefaultSSE) StreamImage(ctx context.Context, w io.Writer, imageID strin
 	if err := d.awaitSubscribe(ctx, sub); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "subscribe failed")
-		log.Error(ctx, "sse subscribe failed", "sse.channel", channel, "image_id", imageID, "error", err)
-		return fmt.Errorf("subscribe to %s: %w", channel, err)
+		log.Error(ctx, "sse subscribe failed", "sse.channels", channels, "error", err)
+		return fmt.Errorf("subscribe to %s: %w", strings.Join(channels, ","), err)
 	}
 
 	// Initial "connected" event
-	if err := writeSSE(w, EventConnected, map[string]string{"message": "Connected to image stream"}); err != nil {
+	connected := "Connected to image stream"
+	switch {
+	case filter.ProjectID != "":
+		connected = "Connected to project stream"
+	case tagged:
+		connected = "Connected to batch stream"
+	}
+	if err := w.WriteEvent(EventConnected, map[string]string{"message": connected}); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "write connected event failed")
-		log.Error(ctx, "sse write connected failed", "sse.channel", channel, "image_id", imageID, "error", err)
+		log.Error(ctx, "sse write connected failed", "sse.channels", channels, "error", err)
 		return err
 	}
-	flush(w)
 
 	// Heartbeat ticker
 	ticker := time.NewTicker(d.heartbeat)
 	defer ticker.Stop()
 	msgCh := sub.Channel()
+	projects := newProjectCache(d.repo)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
 		case <-ticker.C:
-			if err := writeSSE(w, EventHeartbeat, map[string]any{"timestamp": time.Now().Unix()}); err != nil {
+			if err := w.WriteEvent(EventHeartbeat, map[string]any{"timestamp": time.Now().Unix()}); err != nil {
 				span.RecordError(err)
 				span.SetStatus(codes.Error, "write heartbeat failed")
-				log.Error(ctx, "sse write heartbeat failed", "sse.channel", channel, "image_id", imageID, "error", err)
+				log.Error(ctx, "sse write heartbeat failed", "sse.channels", channels, "error", err)
 				return err
 			}
-			flush(w)
 		case msg, ok := <-msgCh:
 			if !ok {
 				// Subscription channel closed (unsubscribe or Redis connection closed); exit gracefully.
-				log.Info(ctx, "sse subscription channel closed", "sse.channel", channel, "image_id", imageID)
+				log.Info(ctx, "sse subscription channel closed", "sse.channels", channels)
 				return nil
 			}
-			// Expect minimal status-only JSON payload: {"status":"..."}
+			imageID := strings.TrimPrefix(msg.Channel, channelPrefix)
+			// Expect minimal status JSON payload: {"status":"..."}, plus "blurhash" once ready
+			// and "error_code" for a rejected original
 			var payload struct {
-				Status string `json:"status"`
+				Status    string `json:"status"`
+				Blurhash  string `json:"blurhash"`
+				ErrorCode string `json:"error_code"`
 			}
 			if err := json.Unmarshal([]byte(msg.Payload), &payload); err != nil || payload.Status == "" {
 				// Ignore malformed payloads to keep the stream healthy.
 				if err != nil {
-					log.Warn(ctx, "sse malformed payload", "sse.channel", channel, "image_id", imageID, "error", err)
+					log.Warn(ctx, "sse malformed payload", "sse.channel", msg.Channel, "image_id", imageID, "error", err)
 				}
 				continue
 			}
-			if err := writeSSE(w, EventJobUpdate, map[string]string{"status": payload.Status}); err != nil {
+			if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, payload.Status) {
+				continue
+			}
+			if filter.ProjectID != "" {
+				projectID, err := projects.lookup(ctx, imageID)
+				if err != nil {
+					log.Warn(ctx, "sse image project lookup failed", "image_id", imageID, "error", err)
+					continue
+				}
+				if projectID != filter.ProjectID {
+					continue
+				}
+			}
+			data := map[string]string{"status": payload.Status}
+			if payload.Blurhash != "" {
+				data["blurhash"] = payload.Blurhash
+			}
+			if payload.ErrorCode != "" {
+				data["error_code"] = payload.ErrorCode
+			}
+			if tagged {
+				data["image_id"] = imageID
+			}
+			if err := w.WriteEvent(EventJobUpdate, data); err != nil {
 				span.SetStatus(codes.Error, "write job_update failed")
 				log.Error(ctx, "sse write job_update failed",
-					"sse.channel", channel, "image_id", imageID, "status", payload.Status, "error", err)
+					"sse.channel", msg.Channel, "image_id", imageID, "status", payload.Status, "error", err)
 				return err
 			}
-			flush(w)
 		}
 	}
 }
 
+// projectCache remembers the project of each image seen on a stream; an image
+// never moves between projects, so lookups happen once per image.
+type projectCache struct {
+	repo     Repository
+	projects map[string]string
+}
+
+func newProjectCache(repo Repository) *projectCache {
+	return &projectCache{repo: repo, projects: make(map[string]string)}
+}
+
+// lookup returns the image's project, or "" for unknown images. Errors are
+// not cached so a later update for the same image retries.
+func (p *projectCache) lookup(ctx context.Context, imageID string) (string, error) {
+	if projectID, ok := p.projects[imageID]; ok {
+		return projectID, nil
+	}
+	projectID, err := p.repo.ImageProjectID(ctx, imageID)
+	if err != nil {
+		return "", err
+	}
+	p.projects[imageID] = projectID
+	return projectID, nil
+}
+
 func (d *DefaultSSE) awaitSubscribe(ctx context.Context, sub *redis.PubSub) error {
 	callCtx := ctx
 	if d.subscribeTimeout > 0 {
@@ -159,6 +317,20 @@ func (d *DefaultSSE) awaitSubscribe(ctx context.Context, sub *redis.PubSub) erro
 	return err
 }
 
+// textWriter is the EventWriter for Server-Sent Events: it writes each event
+// in the SSE wire format and flushes it.
+type textWriter struct {
+	w io.Writer
+}
+
+func (t textWriter) WriteEvent(event string, data any) error {
+	if err := writeSSE(t.w, event, data); err != nil {
+		return err
+	}
+	flush(t.w)
+	return nil
+}
+
 // writeSSE writes a single Server-Sent Event to w following the SSE wire format.
 func writeSSE(w io.Writer, event string, data any) error {
 	if event != "" {
@@ -58,7 +58,7 @@ func TestDefaultSSE_StreamImage_StatusUpdate(t *testing.T) {
 	defer func() { _ = rdb.Close() }()
 
 	// Create DefaultSSE with a short heartbeat (we won't rely on it here)
-	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 50 * time.Millisecond})
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 50 * time.Millisecond}, nil)
 
 	// Create a cancellable context and an output buffer that supports flushing
 	ctx, cancel := context.WithCancel(context.Background())
@@ -99,6 +99,86 @@ func TestDefaultSSE_StreamImage_StatusUpdate(t *testing.T) {
 	}
 }
 
+func TestDefaultSSE_StreamImage_ReadyCarriesBlurhash(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamImage(ctx, w, "img-123")
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "event: connected")
+	})
+
+	payload := `{"status":"ready","blurhash":"LEHV6nWB2yk8pyo0adR*.7kCMdnj","internal":"dropped"}`
+	if err := rdb.Publish(ctx, "jobs:image:img-123", payload).Err(); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), `data: {"blurhash":"LEHV6nWB2yk8pyo0adR*.7kCMdnj","status":"ready"}`)
+	})
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
+func TestDefaultSSE_StreamImage_ErrorCarriesErrorCode(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamImage(ctx, w, "img-123")
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "event: connected")
+	})
+
+	payload := `{"status":"error","error_code":"corrupt_image","internal":"dropped"}`
+	if err := rdb.Publish(ctx, "jobs:image:img-123", payload).Err(); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), `data: {"error_code":"corrupt_image","status":"error"}`)
+	})
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
 func TestDefaultSSE_StreamImage_MissingImageID(t *testing.T) {
 	// Start in-memory Redis
 	mr := miniredis.RunT(t)
@@ -107,7 +187,7 @@ func TestDefaultSSE_StreamImage_MissingImageID(t *testing.T) {
 	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
 	defer func() { _ = rdb.Close() }()
 
-	sse := NewDefaultSSE(rdb, Config{})
+	sse := NewDefaultSSE(rdb, Config{}, nil)
 
 	err := sse.StreamImage(context.Background(), &bufFlusher{}, "")
 	if err == nil || !strings.Contains(err.Error(), "imageID required") {
@@ -122,7 +202,7 @@ func TestDefaultSSE_StreamImage_IgnoresMalformedPayload(t *testing.T) {
 	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
 	defer func() { _ = rdb.Close() }()
 
-	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 100 * time.Millisecond})
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 100 * time.Millisecond}, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -168,7 +248,7 @@ func TestDefaultSSE_SubscribeError(t *testing.T) {
 	rdb := redis.NewClient(&redis.Options{Addr: addr})
 	defer func() { _ = rdb.Close() }()
 
-	sse := NewDefaultSSE(rdb, Config{})
+	sse := NewDefaultSSE(rdb, Config{}, nil)
 	err := sse.StreamImage(context.Background(), &bufFlusher{}, "img-sub-fail")
 	if err == nil {
 		t.Fatal("expected error due to subscription failure, got nil")
@@ -184,7 +264,7 @@ func TestDefaultSSE_HeartbeatCadence(t *testing.T) {
 	defer func() { _ = rdb.Close() }()
 
 	// Use a short heartbeat to observe multiple events quickly
-	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 50 * time.Millisecond})
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 50 * time.Millisecond}, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -223,7 +303,7 @@ func TestDefaultSSE_MultipleSubscribers_Isolation(t *testing.T) {
 	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
 	defer func() { _ = rdb.Close() }()
 
-	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 50 * time.Millisecond})
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: 50 * time.Millisecond}, nil)
 
 	// Start two subscribers for different images
 	ctxA, cancelA := context.WithCancel(context.Background())
@@ -300,3 +380,174 @@ func TestDefaultSSE_MultipleSubscribers_Isolation(t *testing.T) {
 		t.Fatal("stream B did not stop after cancel")
 	}
 }
+
+func TestDefaultSSE_StreamImages_TaggedUpdates(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.StreamImages(ctx, w, []string{"img-1", "img-2"})
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "Connected to batch stream")
+	})
+
+	if err := rdb.Publish(ctx, "jobs:image:img-2", `{"status":"ready"}`).Err(); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if err := rdb.Publish(ctx, "jobs:image:img-3", `{"status":"ready"}`).Err(); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if err := rdb.Publish(ctx, "jobs:image:img-1", `{"status":"processing"}`).Err(); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		s := w.String()
+		return strings.Contains(s, `data: {"image_id":"img-2","status":"ready"}`) &&
+			strings.Contains(s, `data: {"image_id":"img-1","status":"processing"}`)
+	})
+	if strings.Contains(w.String(), "img-3") {
+		t.Fatalf("stream received update for unsubscribed image: %s", w.String())
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
+func TestDefaultSSE_StreamImages_Validation(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	sse := NewDefaultSSE(rdb, Config{}, nil)
+
+	tooMany := make([]string, MaxStreamImages+1)
+	for i := range tooMany {
+		tooMany[i] = "img"
+	}
+
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr string
+	}{
+		{name: "fail: no ids", ids: nil, wantErr: "imageIDs required"},
+		{name: "fail: empty id", ids: []string{"img-1", ""}, wantErr: "must not contain empty values"},
+		{name: "fail: too many ids", ids: tooMany, wantErr: "too many images"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sse.StreamImages(context.Background(), &bufFlusher{}, tt.ids)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected %q error, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDefaultSSE_Stream_ProjectFilter(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	projects := map[string]string{"img-1": "proj-a", "img-2": "proj-b", "img-3": "proj-a"}
+	repo := &RepositoryMock{
+		ImageProjectIDFunc: func(ctx context.Context, imageID string) (string, error) {
+			return projects[imageID], nil
+		},
+	}
+	sse := NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Second}, repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := &bufFlusher{}
+	done := make(chan struct{})
+	go func() {
+		_ = sse.Stream(ctx, w, Filter{ProjectID: "proj-a", Statuses: []string{"ready", "error"}})
+		close(done)
+	}()
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		return strings.Contains(w.String(), "Connected to project stream")
+	})
+
+	publish := func(channel, payload string) {
+		t.Helper()
+		if err := rdb.Publish(ctx, channel, payload).Err(); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+	}
+	publish("jobs:image:img-1", `{"status":"processing"}`)
+	publish("jobs:image:img-2", `{"status":"ready"}`)
+	publish("jobs:image:img-1", `{"status":"ready"}`)
+	publish("jobs:image:img-3", `{"status":"error"}`)
+
+	waitFor(t, 500*time.Millisecond, func() bool {
+		s := w.String()
+		return strings.Contains(s, `data: {"image_id":"img-1","status":"ready"}`) &&
+			strings.Contains(s, `data: {"image_id":"img-3","status":"error"}`)
+	})
+	s := w.String()
+	if strings.Contains(s, "img-2") || strings.Contains(s, `"processing"`) {
+		t.Fatalf("stream received filtered updates: %s", s)
+	}
+	if n := len(repo.ImageProjectIDCalls()); n != 3 {
+		t.Fatalf("expected one project lookup per image, got %d", n)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("stream did not stop after cancel")
+	}
+}
+
+func TestDefaultSSE_Stream_Validation(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	tests := []struct {
+		name    string
+		repo    Repository
+		filter  Filter
+		wantErr string
+	}{
+		{name: "fail: nothing to stream", repo: &RepositoryMock{}, wantErr: "imageIDs or projectID required"},
+		{name: "fail: empty id", filter: Filter{ImageIDs: []string{""}}, wantErr: "must not contain empty values"},
+		{name: "fail: project without repository", filter: Filter{ProjectID: "p"}, wantErr: "require a repository"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewDefaultSSE(rdb, Config{}, tt.repo).Stream(context.Background(), &bufFlusher{}, tt.filter)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected %q error, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package sse
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository resolves the project scoping used by filtered streams and the
+// user a billing stream belongs to.
+type Repository interface {
+	// ProjectAccessible reports whether the user with the given Auth0 subject
+	// created the project or belongs to an organization it is shared with.
+	ProjectAccessible(ctx context.Context, projectID, auth0Sub string) (bool, error)
+
+	// ImageProjectID returns the project an image belongs to, or "" when the
+	// image does not exist.
+	ImageProjectID(ctx context.Context, imageID string) (string, error)
+
+	// UserID returns the internal ID of the user with the given Auth0
+	// subject, or "" when there is no such user.
+	UserID(ctx context.Context, auth0Sub string) (string, error)
+}
@@ -32,13 +32,65 @@ type SSE interface {
 	// The writer is typically an http.ResponseWriter. If it implements Flusher,
 	// the implementation should call Flush() after sending events to reduce latency.
 	StreamImage(ctx context.Context, w io.Writer, imageID string) error
+
+	// StreamImages streams events for a set of images over a single connection,
+	// e.g. all variants created by one bulk operation.
+	//
+	// It behaves like StreamImage but subscribes to every per-image channel at once,
+	// and each "job_update" payload carries the image_id it refers to.
+	StreamImages(ctx context.Context, w io.Writer, imageIDs []string) error
+
+	// Stream streams the job updates selected by filter: the listed images,
+	// every image in a project, or the listed images within a project, with
+	// updates whose status is not in filter.Statuses dropped server-side.
+	// Except for a single-image stream, each "job_update" payload carries the
+	// image_id it refers to.
+	Stream(ctx context.Context, w io.Writer, filter Filter) error
+
+	// StreamEvents behaves like Stream but hands each event to w instead of
+	// encoding it in the SSE wire format, so other transports such as
+	// WebSocket deliver the same events.
+	StreamEvents(ctx context.Context, w EventWriter, filter Filter) error
+
+	// StreamBilling streams changes to a user's subscriptions, invoices and
+	// checkouts as "billing_update" events, so billing pages can refresh live.
+	// userID is the internal user ID.
+	StreamBilling(ctx context.Context, w io.Writer, userID string) error
+}
+
+// EventWriter delivers one named event to a client. data is marshalled to JSON.
+type EventWriter interface {
+	WriteEvent(event string, data any) error
+}
+
+// Filter selects the job updates a stream forwards.
+type Filter struct {
+	// ImageIDs limits the stream to these images (at most MaxStreamImages).
+	ImageIDs []string
+	// ProjectID limits the stream to images in this project, including ones
+	// created after the stream opened.
+	ProjectID string
+	// Statuses keeps only job updates with one of these statuses; empty keeps all.
+	Statuses []string
 }
 
 // Handler defines the HTTP-level handler for SSE endpoints, typically using Echo.
 type Handler interface {
-	// Events handles GET /api/v1/events?image_id={id}
+	// Events handles GET /api/v1/events?image_id={id},
+	// GET /api/v1/events?image_ids={id},{id},... for batch progress and
+	// GET /api/v1/events?project_id={id} for a whole project, each optionally
+	// narrowed with types={status},{status},...
 	// It should set SSE headers and delegate to an SSE implementation.
 	Events(c echo.Context) error
+
+	// WebSocket handles GET /api/v1/ws with the same query parameters as
+	// Events, for clients behind proxies that buffer SSE. It upgrades the
+	// connection and sends each event as a JSON text message.
+	WebSocket(c echo.Context) error
+
+	// BillingEvents handles GET /api/v1/billing/events, streaming the
+	// caller's billing changes.
+	BillingEvents(c echo.Context) error
 }
 
 // Flusher is the minimal interface extracted from http.Flusher to avoid
@@ -52,8 +104,16 @@ const (
 	EventConnected = "connected"
 	EventHeartbeat = "heartbeat"
 	EventJobUpdate = "job_update"
+	// EventBillingUpdate carries a subscription, invoice or checkout change.
+	EventBillingUpdate = "billing_update"
 )
 
+// JobStatuses are the job_update statuses a stream can be filtered by.
+var JobStatuses = []string{"queued", "processing", "ready", "error", "rejected"}
+
+// MaxStreamImages caps how many images a single batch stream may subscribe to.
+const MaxStreamImages = 100
+
 // Config carries optional tuning parameters for SSE implementations.
 // Implementations may choose to ignore fields if not relevant.
 type Config struct {
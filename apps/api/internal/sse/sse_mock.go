@@ -20,9 +20,21 @@ var _ SSE = &SSEMock{}
 //
 //		// make and configure a mocked SSE
 //		mockedSSE := &SSEMock{
+//			StreamFunc: func(ctx context.Context, w io.Writer, filter Filter) error {
+//				panic("mock out the Stream method")
+//			},
+//			StreamBillingFunc: func(ctx context.Context, w io.Writer, userID string) error {
+//				panic("mock out the StreamBilling method")
+//			},
+//			StreamEventsFunc: func(ctx context.Context, w EventWriter, filter Filter) error {
+//				panic("mock out the StreamEvents method")
+//			},
 //			StreamImageFunc: func(ctx context.Context, w io.Writer, imageID string) error {
 //				panic("mock out the StreamImage method")
 //			},
+//			StreamImagesFunc: func(ctx context.Context, w io.Writer, imageIDs []string) error {
+//				panic("mock out the StreamImages method")
+//			},
 //		}
 //
 //		// use mockedSSE in code that requires SSE
@@ -30,11 +42,50 @@ var _ SSE = &SSEMock{}
 //
 //	}
 type SSEMock struct {
+	// StreamFunc mocks the Stream method.
+	StreamFunc func(ctx context.Context, w io.Writer, filter Filter) error
+
+	// StreamBillingFunc mocks the StreamBilling method.
+	StreamBillingFunc func(ctx context.Context, w io.Writer, userID string) error
+
+	// StreamEventsFunc mocks the StreamEvents method.
+	StreamEventsFunc func(ctx context.Context, w EventWriter, filter Filter) error
+
 	// StreamImageFunc mocks the StreamImage method.
 	StreamImageFunc func(ctx context.Context, w io.Writer, imageID string) error
 
+	// StreamImagesFunc mocks the StreamImages method.
+	StreamImagesFunc func(ctx context.Context, w io.Writer, imageIDs []string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// Stream holds details about calls to the Stream method.
+		Stream []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W io.Writer
+			// Filter is the filter argument value.
+			Filter Filter
+		}
+		// StreamBilling holds details about calls to the StreamBilling method.
+		StreamBilling []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W io.Writer
+			// UserID is the userID argument value.
+			UserID string
+		}
+		// StreamEvents holds details about calls to the StreamEvents method.
+		StreamEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W EventWriter
+			// Filter is the filter argument value.
+			Filter Filter
+		}
 		// StreamImage holds details about calls to the StreamImage method.
 		StreamImage []struct {
 			// Ctx is the ctx argument value.
@@ -44,8 +95,141 @@ type SSEMock struct {
 			// ImageID is the imageID argument value.
 			ImageID string
 		}
+		// StreamImages holds details about calls to the StreamImages method.
+		StreamImages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// W is the w argument value.
+			W io.Writer
+			// ImageIDs is the imageIDs argument value.
+			ImageIDs []string
+		}
+	}
+	lockStream        sync.RWMutex
+	lockStreamBilling sync.RWMutex
+	lockStreamEvents  sync.RWMutex
+	lockStreamImage   sync.RWMutex
+	lockStreamImages  sync.RWMutex
+}
+
+// Stream calls StreamFunc.
+func (mock *SSEMock) Stream(ctx context.Context, w io.Writer, filter Filter) error {
+	if mock.StreamFunc == nil {
+		panic("SSEMock.StreamFunc: method is nil but SSE.Stream was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		W      io.Writer
+		Filter Filter
+	}{
+		Ctx:    ctx,
+		W:      w,
+		Filter: filter,
 	}
-	lockStreamImage sync.RWMutex
+	mock.lockStream.Lock()
+	mock.calls.Stream = append(mock.calls.Stream, callInfo)
+	mock.lockStream.Unlock()
+	return mock.StreamFunc(ctx, w, filter)
+}
+
+// StreamCalls gets all the calls that were made to Stream.
+// Check the length with:
+//
+//	len(mockedSSE.StreamCalls())
+func (mock *SSEMock) StreamCalls() []struct {
+	Ctx    context.Context
+	W      io.Writer
+	Filter Filter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		W      io.Writer
+		Filter Filter
+	}
+	mock.lockStream.RLock()
+	calls = mock.calls.Stream
+	mock.lockStream.RUnlock()
+	return calls
+}
+
+// StreamBilling calls StreamBillingFunc.
+func (mock *SSEMock) StreamBilling(ctx context.Context, w io.Writer, userID string) error {
+	if mock.StreamBillingFunc == nil {
+		panic("SSEMock.StreamBillingFunc: method is nil but SSE.StreamBilling was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		W      io.Writer
+		UserID string
+	}{
+		Ctx:    ctx,
+		W:      w,
+		UserID: userID,
+	}
+	mock.lockStreamBilling.Lock()
+	mock.calls.StreamBilling = append(mock.calls.StreamBilling, callInfo)
+	mock.lockStreamBilling.Unlock()
+	return mock.StreamBillingFunc(ctx, w, userID)
+}
+
+// StreamBillingCalls gets all the calls that were made to StreamBilling.
+// Check the length with:
+//
+//	len(mockedSSE.StreamBillingCalls())
+func (mock *SSEMock) StreamBillingCalls() []struct {
+	Ctx    context.Context
+	W      io.Writer
+	UserID string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		W      io.Writer
+		UserID string
+	}
+	mock.lockStreamBilling.RLock()
+	calls = mock.calls.StreamBilling
+	mock.lockStreamBilling.RUnlock()
+	return calls
+}
+
+// StreamEvents calls StreamEventsFunc.
+func (mock *SSEMock) StreamEvents(ctx context.Context, w EventWriter, filter Filter) error {
+	if mock.StreamEventsFunc == nil {
+		panic("SSEMock.StreamEventsFunc: method is nil but SSE.StreamEvents was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		W      EventWriter
+		Filter Filter
+	}{
+		Ctx:    ctx,
+		W:      w,
+		Filter: filter,
+	}
+	mock.lockStreamEvents.Lock()
+	mock.calls.StreamEvents = append(mock.calls.StreamEvents, callInfo)
+	mock.lockStreamEvents.Unlock()
+	return mock.StreamEventsFunc(ctx, w, filter)
+}
+
+// StreamEventsCalls gets all the calls that were made to StreamEvents.
+// Check the length with:
+//
+//	len(mockedSSE.StreamEventsCalls())
+func (mock *SSEMock) StreamEventsCalls() []struct {
+	Ctx    context.Context
+	W      EventWriter
+	Filter Filter
+} {
+	var calls []struct {
+		Ctx    context.Context
+		W      EventWriter
+		Filter Filter
+	}
+	mock.lockStreamEvents.RLock()
+	calls = mock.calls.StreamEvents
+	mock.lockStreamEvents.RUnlock()
+	return calls
 }
 
 // StreamImage calls StreamImageFunc.
@@ -88,6 +272,46 @@ func (mock *SSEMock) StreamImageCalls() []struct {
 	return calls
 }
 
+// StreamImages calls StreamImagesFunc.
+func (mock *SSEMock) StreamImages(ctx context.Context, w io.Writer, imageIDs []string) error {
+	if mock.StreamImagesFunc == nil {
+		panic("SSEMock.StreamImagesFunc: method is nil but SSE.StreamImages was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		W        io.Writer
+		ImageIDs []string
+	}{
+		Ctx:      ctx,
+		W:        w,
+		ImageIDs: imageIDs,
+	}
+	mock.lockStreamImages.Lock()
+	mock.calls.StreamImages = append(mock.calls.StreamImages, callInfo)
+	mock.lockStreamImages.Unlock()
+	return mock.StreamImagesFunc(ctx, w, imageIDs)
+}
+
+// StreamImagesCalls gets all the calls that were made to StreamImages.
+// Check the length with:
+//
+//	len(mockedSSE.StreamImagesCalls())
+func (mock *SSEMock) StreamImagesCalls() []struct {
+	Ctx      context.Context
+	W        io.Writer
+	ImageIDs []string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		W        io.Writer
+		ImageIDs []string
+	}
+	mock.lockStreamImages.RLock()
+	calls = mock.calls.StreamImages
+	mock.lockStreamImages.RUnlock()
+	return calls
+}
+
 // Ensure, that HandlerMock does implement Handler.
 // If this is not the case, regenerate this file with moq.
 var _ Handler = &HandlerMock{}
@@ -98,9 +322,15 @@ var _ Handler = &HandlerMock{}
 //
 //		// make and configure a mocked Handler
 //		mockedHandler := &HandlerMock{
+//			BillingEventsFunc: func(c echo.Context) error {
+//				panic("mock out the BillingEvents method")
+//			},
 //			EventsFunc: func(c echo.Context) error {
 //				panic("mock out the Events method")
 //			},
+//			WebSocketFunc: func(c echo.Context) error {
+//				panic("mock out the WebSocket method")
+//			},
 //		}
 //
 //		// use mockedHandler in code that requires Handler
@@ -108,18 +338,68 @@ var _ Handler = &HandlerMock{}
 //
 //	}
 type HandlerMock struct {
+	// BillingEventsFunc mocks the BillingEvents method.
+	BillingEventsFunc func(c echo.Context) error
+
 	// EventsFunc mocks the Events method.
 	EventsFunc func(c echo.Context) error
 
+	// WebSocketFunc mocks the WebSocket method.
+	WebSocketFunc func(c echo.Context) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// BillingEvents holds details about calls to the BillingEvents method.
+		BillingEvents []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
 		// Events holds details about calls to the Events method.
 		Events []struct {
 			// C is the c argument value.
 			C echo.Context
 		}
+		// WebSocket holds details about calls to the WebSocket method.
+		WebSocket []struct {
+			// C is the c argument value.
+			C echo.Context
+		}
+	}
+	lockBillingEvents sync.RWMutex
+	lockEvents        sync.RWMutex
+	lockWebSocket     sync.RWMutex
+}
+
+// BillingEvents calls BillingEventsFunc.
+func (mock *HandlerMock) BillingEvents(c echo.Context) error {
+	if mock.BillingEventsFunc == nil {
+		panic("HandlerMock.BillingEventsFunc: method is nil but Handler.BillingEvents was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockBillingEvents.Lock()
+	mock.calls.BillingEvents = append(mock.calls.BillingEvents, callInfo)
+	mock.lockBillingEvents.Unlock()
+	return mock.BillingEventsFunc(c)
+}
+
+// BillingEventsCalls gets all the calls that were made to BillingEvents.
+// Check the length with:
+//
+//	len(mockedHandler.BillingEventsCalls())
+func (mock *HandlerMock) BillingEventsCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
 	}
-	lockEvents sync.RWMutex
+	mock.lockBillingEvents.RLock()
+	calls = mock.calls.BillingEvents
+	mock.lockBillingEvents.RUnlock()
+	return calls
 }
 
 // Events calls EventsFunc.
@@ -153,3 +433,35 @@ func (mock *HandlerMock) EventsCalls() []struct {
 	mock.lockEvents.RUnlock()
 	return calls
 }
+
+// WebSocket calls WebSocketFunc.
+func (mock *HandlerMock) WebSocket(c echo.Context) error {
+	if mock.WebSocketFunc == nil {
+		panic("HandlerMock.WebSocketFunc: method is nil but Handler.WebSocket was just called")
+	}
+	callInfo := struct {
+		C echo.Context
+	}{
+		C: c,
+	}
+	mock.lockWebSocket.Lock()
+	mock.calls.WebSocket = append(mock.calls.WebSocket, callInfo)
+	mock.lockWebSocket.Unlock()
+	return mock.WebSocketFunc(c)
+}
+
+// WebSocketCalls gets all the calls that were made to WebSocket.
+// Check the length with:
+//
+//	len(mockedHandler.WebSocketCalls())
+func (mock *HandlerMock) WebSocketCalls() []struct {
+	C echo.Context
+} {
+	var calls []struct {
+		C echo.Context
+	}
+	mock.lockWebSocket.RLock()
+	calls = mock.calls.WebSocket
+	mock.lockWebSocket.RUnlock()
+	return calls
+}
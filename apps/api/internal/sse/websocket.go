@@ -0,0 +1,125 @@
+package sse
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+const (
+	// wsWriteWait bounds how long a single message or control frame may take to send.
+	wsWriteWait = 10 * time.Second
+	// wsPongWait is how long the client may go without answering a ping. The
+	// server pings at 90% of it so a healthy client always answers in time.
+	wsPongWait = 60 * time.Second
+	// wsMaxMessageSize caps client messages; clients only send control frames.
+	wsMaxMessageSize = 512
+)
+
+// upgrader accepts connections from any origin, like the SSE endpoint's
+// Access-Control-Allow-Origin: *. Requests authenticate with a bearer token
+// rather than cookies, so a cross-site page cannot open a stream as the user.
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// wsMessage is the JSON text message carrying one event.
+type wsMessage struct {
+	Event string `json:"event"`
+	Data  any    `json:"data"`
+}
+
+// wsWriter is the EventWriter for a WebSocket connection.
+type wsWriter struct {
+	conn *websocket.Conn
+}
+
+func (w wsWriter) WriteEvent(event string, data any) error {
+	if err := w.conn.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+		return err
+	}
+	return w.conn.WriteJSON(wsMessage{Event: event, Data: data})
+}
+
+// WebSocket is an Echo handler for GET /api/v1/ws. It accepts the query
+// parameters of Events, validates them and checks project access before
+// upgrading, then sends each event as a text message such as:
+//
+//	{"event":"job_update","data":{"status":"processing"}}
+//
+// The server pings the client and drops it when a pong does not arrive
+// within wsPongWait.
+func (h *DefaultHandler) WebSocket(c echo.Context) error {
+	filter, resp, status := parseFilter(c)
+	if resp != nil {
+		return c.JSON(status, resp)
+	}
+	if resp, status := h.checkStream(c, filter); resp != nil {
+		return c.JSON(status, resp)
+	}
+
+	conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// Upgrade has already replied with an HTTP error.
+		logging.NewDefaultLogger().Warn(c.Request().Context(), "websocket upgrade failed", "error", err)
+		return nil
+	}
+	defer func() { _ = conn.Close() }()
+
+	// A hijacked connection's request context is not cancelled when the
+	// client goes away, so the read loop below cancels ctx instead.
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+	go readUntilClosed(conn, h.pongWait, cancel)
+	go pingUntilDone(ctx, conn, h.pongWait*9/10, cancel)
+
+	err = h.sse.StreamEvents(ctx, wsWriter{conn: conn}, filter)
+	code, reason := websocket.CloseNormalClosure, ""
+	if err != nil && ctx.Err() == nil {
+		logging.NewDefaultLogger().Error(ctx, "websocket stream failed", "project_id", filter.ProjectID, "error", err)
+		code, reason = websocket.CloseInternalServerErr, "stream failed"
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(wsWriteWait))
+	return nil
+}
+
+// readUntilClosed reads (and discards) client messages so pong and close
+// frames are processed, calling cancel once the connection fails or closes.
+func readUntilClosed(conn *websocket.Conn, pongWait time.Duration, cancel context.CancelFunc) {
+	defer cancel()
+	conn.SetReadLimit(wsMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// pingUntilDone sends a ping every interval until ctx is done, calling cancel
+// when a ping cannot be sent. WriteControl may run concurrently with the
+// event writes.
+func pingUntilDone(ctx context.Context, conn *websocket.Conn, interval time.Duration, cancel context.CancelFunc) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				cancel()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package sse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveWebSocket serves h.WebSocket on GET /ws and returns its ws:// URL.
+func serveWebSocket(t *testing.T, h *DefaultHandler) string {
+	t.Helper()
+	e := echo.New()
+	e.GET("/ws", h.WebSocket)
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+}
+
+func TestDefaultHandler_WebSocket_StreamsJobUpdates(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	h := NewDefaultHandler(NewDefaultSSE(rdb, Config{HeartbeatInterval: time.Minute}, nil), nil)
+	conn, _, err := websocket.DefaultDialer.Dial(serveWebSocket(t, h)+"?image_ids=img-1,img-2&types=ready", nil)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	var msg wsMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, EventConnected, msg.Event)
+	assert.Equal(t, map[string]any{"message": "Connected to batch stream"}, msg.Data)
+
+	ctx := context.Background()
+	require.NoError(t, rdb.Publish(ctx, "jobs:image:img-1", `{"status":"processing"}`).Err())
+	require.NoError(t, rdb.Publish(ctx, "jobs:image:img-2", `{"status":"ready"}`).Err())
+
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, EventJobUpdate, msg.Event)
+	assert.Equal(t, map[string]any{"image_id": "img-2", "status": "ready"}, msg.Data, "processing is filtered out")
+}
+
+func TestDefaultHandler_WebSocket_PingPong(t *testing.T) {
+	stream := make(chan struct{})
+	s := &SSEMock{
+		StreamEventsFunc: func(ctx context.Context, _ EventWriter, _ Filter) error {
+			close(stream)
+			<-ctx.Done()
+			return nil
+		},
+	}
+	h := NewDefaultHandler(s, nil)
+	h.pongWait = 100 * time.Millisecond
+	url := serveWebSocket(t, h) + "?image_id=img-1"
+
+	t.Run("success: a client answering pings stays connected", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		<-stream
+
+		pings := 0
+		conn.SetPingHandler(func(data string) error {
+			pings++
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		// Reading processes control frames; nothing else arrives before the deadline.
+		_ = conn.SetReadDeadline(time.Now().Add(350 * time.Millisecond))
+		_, _, err = conn.ReadMessage()
+		var netErr interface{ Timeout() bool }
+		require.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout(), "connection should still be open, got %v", err)
+		assert.GreaterOrEqual(t, pings, 2)
+	})
+
+	t.Run("fail: a client that stops answering is dropped", func(t *testing.T) {
+		stream = make(chan struct{})
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		<-stream
+
+		conn.SetPingHandler(func(string) error { return nil })
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "got %v", err)
+	})
+}
+
+func TestDefaultHandler_WebSocket_RejectsBeforeUpgrade(t *testing.T) {
+	testCases := []struct {
+		name         string
+		handler      *DefaultHandler
+		query        string
+		expectStatus int
+	}{
+		{
+			name:         "fail: missing image_id",
+			handler:      NewDefaultHandler(&SSEMock{}, nil),
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: unknown type",
+			handler:      NewDefaultHandler(&SSEMock{}, nil),
+			query:        "?image_id=img-1&types=done",
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "fail: pubsub not configured",
+			handler:      NewDefaultHandler(nil, nil),
+			query:        "?image_id=img-1",
+			expectStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "fail: project not accessible",
+			handler: NewDefaultHandler(&SSEMock{}, &RepositoryMock{
+				ProjectAccessibleFunc: func(context.Context, string, string) (bool, error) { return false, nil },
+			}),
+			query:        "?project_id=7f9c2b1e-8a3d-4e5f-9b6a-1c2d3e4f5a6b",
+			expectStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, resp, err := websocket.DefaultDialer.Dial(serveWebSocket(t, tc.handler)+tc.query, nil)
+			require.ErrorIs(t, err, websocket.ErrBadHandshake)
+			defer func() { _ = resp.Body.Close() }()
+			assert.Equal(t, tc.expectStatus, resp.StatusCode)
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/real-staging-ai/api/internal/config"
+	"github.com/real-staging-ai/api/internal/logging"
 )
 
 // DefaultDatabase wraps the pgx connection pool with tracing
@@ -23,7 +24,9 @@ type DefaultDatabase struct {
 	tracer trace.Tracer
 }
 
-// NewDefaultDatabase creates a new database connection with OpenTelemetry instrumentation.
+// NewDefaultDatabase creates a new database connection with OpenTelemetry
+// instrumentation. Every query on the pool goes through a QueryTracer, which
+// records per-query metrics and logs queries slower than cfg.SlowQueryThreshold.
 func NewDefaultDatabase(cfg *config.DB) (*DefaultDatabase, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("database config is required")
@@ -41,8 +44,18 @@ func NewDefaultDatabase(cfg *config.DB) (*DefaultDatabase, error) {
 			cfg.User, cfg.Password, hostPort, cfg.Database, cfg.SSLMode)
 	}
 
+	poolCfg, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	tracer, err := NewQueryTracer(cfg.SlowQueryThreshold(), logging.Default())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create query tracer: %w", err)
+	}
+	poolCfg.ConnConfig.Tracer = tracer
+
 	// Create connection pool
-	pool, err := pgxpool.New(ctx, dbURL)
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -129,6 +142,23 @@ func (db *DefaultDatabase) Exec(ctx context.Context, sql string, arguments ...an
 	return tag, err
 }
 
+// Begin starts a transaction on the pool with tracing
+func (db *DefaultDatabase) Begin(ctx context.Context) (pgx.Tx, error) {
+	tr := db.tracer
+	if tr == nil {
+		tr = otel.Tracer("real-staging-api/database")
+	}
+	ctx, span := tr.Start(ctx, "db.begin")
+	defer span.End()
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	return tx, err
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -15,7 +17,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
-	"github.com/google/uuid"
 
 	configLib "github.com/real-staging-ai/api/internal/config"
 )
@@ -164,14 +165,24 @@ func NewDefaultS3Service(ctx context.Context, s3Cfg *configLib.S3) (*DefaultS3Se
 
 // GeneratePresignedUploadURL generates a presigned URL for uploading a file to S3.
 func (s *DefaultS3Service) GeneratePresignedUploadURL(
-	ctx context.Context, userID, filename, contentType string, fileSize int64,
+	ctx context.Context, userID, projectID, filename, contentType string, fileSize int64,
 ) (*PresignedUploadResult, error) {
-	// Generate a unique file key
-	fileExt := filepath.Ext(filename)
-	baseName := strings.TrimSuffix(filename, fileExt)
-	uniqueID := uuid.New().String()
-	fileKey := fmt.Sprintf("uploads/%s/%s-%s%s", userID, baseName, uniqueID, fileExt)
+	// Generate a unique, user-scoped file key
+	return s.presignUpload(ctx, UploadKey(userID, projectID, filename), contentType)
+}
 
+// GeneratePresignedMaskUploadURL generates a presigned URL for uploading a
+// PNG staging mask to the project.
+func (s *DefaultS3Service) GeneratePresignedMaskUploadURL(
+	ctx context.Context, userID, projectID, filename string,
+) (*PresignedUploadResult, error) {
+	return s.presignUpload(ctx, MaskKey(userID, projectID, filename), "image/png")
+}
+
+// presignUpload presigns a PUT of fileKey with contentType.
+func (s *DefaultS3Service) presignUpload(
+	ctx context.Context, fileKey, contentType string,
+) (*PresignedUploadResult, error) {
 	// Choose a client for presigning. If public endpoint is set, use a client
 	// with that base endpoint so the URL host is browser-accessible. Provide
 	// static credentials to avoid IMDS.
@@ -240,6 +251,61 @@ func (s *DefaultS3Service) DeleteFile(ctx context.Context, fileKey string) error
 	return nil
 }
 
+// CopyFile copies an object to a new key within the same bucket.
+func (s *DefaultS3Service) CopyFile(ctx context.Context, srcKey, dstKey string) error {
+	_, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(s.Cfg.BucketName),
+		CopySource: aws.String(url.PathEscape(s.Cfg.BucketName + "/" + srcKey)),
+		Key:        aws.String(dstKey),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy file: %w", err)
+	}
+
+	return nil
+}
+
+// DownloadFile opens an object for reading. The caller must close the returned body.
+func (s *DefaultS3Service) DownloadFile(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+	result, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.Cfg.BucketName),
+		Key:    aws.String(fileKey),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+
+	return result.Body, nil
+}
+
+// ListFiles lists up to 1000 objects under prefix per call.
+func (s *DefaultS3Service) ListFiles(ctx context.Context, prefix, continuationToken string) (*FileListPage, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.Cfg.BucketName),
+		Prefix: aws.String(prefix),
+	}
+	if continuationToken != "" {
+		input.ContinuationToken = aws.String(continuationToken)
+	}
+	result, err := s.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	page := &FileListPage{Files: make([]FileInfo, 0, len(result.Contents))}
+	for _, obj := range result.Contents {
+		page.Files = append(page.Files, FileInfo{
+			Key:          aws.ToString(obj.Key),
+			Size:         aws.ToInt64(obj.Size),
+			LastModified: aws.ToTime(obj.LastModified),
+		})
+	}
+	if aws.ToBool(result.IsTruncated) {
+		page.NextToken = aws.ToString(result.NextContinuationToken)
+	}
+	return page, nil
+}
+
 // HeadFile checks if a file exists in S3 and returns its metadata.
 func (s *DefaultS3Service) HeadFile(ctx context.Context, fileKey string) (interface{}, error) {
 	result, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
@@ -253,6 +319,14 @@ func (s *DefaultS3Service) HeadFile(ctx context.Context, fileKey string) (interf
 	return result, nil
 }
 
+// IsNotFound reports whether err from HeadFile or DownloadFile means the
+// object does not exist, as opposed to a failed request.
+func IsNotFound(err error) bool {
+	var notFound *types.NotFound
+	var noSuchKey *types.NoSuchKey
+	return errors.As(err, &notFound) || errors.As(err, &noSuchKey)
+}
+
 // ValidateContentType checks if the content type is allowed for uploads.
 func ValidateContentType(contentType string) bool {
 	allowedTypes := []string{
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -40,6 +42,25 @@ func TestValidateContentType(t *testing.T) {
 	}
 }
 
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "head not found", err: fmt.Errorf("failed to get file metadata: %w", &types.NotFound{}), expected: true},
+		{name: "get no such key", err: &types.NoSuchKey{}, expected: true},
+		{name: "other error", err: errors.New("connection reset"), expected: false},
+		{name: "nil", err: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsNotFound(tt.err))
+		})
+	}
+}
+
 func TestValidateFileSize(t *testing.T) {
 	const max = 10 * 1024 * 1024 // 10MB
 	tests := []struct {
@@ -133,6 +154,7 @@ func TestDefaultS3Service_GeneratePresignedUploadURL(t *testing.T) {
 
 	type input struct {
 		userID      string
+		projectID   string
 		filename    string
 		contentType string
 		fileSize    int64
@@ -164,9 +186,16 @@ func TestDefaultS3Service_GeneratePresignedUploadURL(t *testing.T) {
 			bucket: "media",
 			in:     input{userID: "u", filename: "render.webp", contentType: "image/webp", fileSize: 999},
 		},
+		{
+			name:   "project scoped",
+			bucket: "media",
+			in: input{
+				userID: "u", projectID: "p-1", filename: "room.jpg", contentType: "image/jpeg", fileSize: 999,
+			},
+		},
 	}
 
-	defaultAssertFn := func(t *testing.T, res *PresignedUploadResult, bucket, userID, filename string) {
+	defaultAssertFn := func(t *testing.T, res *PresignedUploadResult, bucket, userID, projectID, filename string) {
 		t.Helper()
 		require.NotNil(t, res)
 		assert.NotEmpty(t, res.UploadURL)
@@ -175,8 +204,13 @@ func TestDefaultS3Service_GeneratePresignedUploadURL(t *testing.T) {
 		ext := filepath.Ext(filename)
 		base := strings.TrimSuffix(filename, ext)
 
-		// FileKey structure: uploads/{userID}/{base}-{uuid}{ext}
-		assert.True(t, strings.HasPrefix(res.FileKey, "uploads/"+userID+"/"), "file key prefix mismatch: %s", res.FileKey)
+		// FileKey structure: users/{userID}/projects/{projectID}/originals/{base}-{uuid}{ext}
+		// or users/{userID}/uploads/{base}-{uuid}{ext} when no project is given.
+		prefix := "users/" + userID + "/uploads/"
+		if projectID != "" {
+			prefix = "users/" + userID + "/projects/" + projectID + "/originals/"
+		}
+		assert.True(t, strings.HasPrefix(res.FileKey, prefix), "file key prefix mismatch: %s", res.FileKey)
 		assert.True(t, strings.HasSuffix(res.FileKey, ext), "file key suffix mismatch: %s", res.FileKey)
 		assert.Contains(t, res.FileKey, base+"-", "file key should contain base name and hyphen before uuid: %s", res.FileKey)
 
@@ -199,13 +233,15 @@ func TestDefaultS3Service_GeneratePresignedUploadURL(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, svc)
 
-			res, err := svc.GeneratePresignedUploadURL(ctx, tt.in.userID, tt.in.filename, tt.in.contentType, tt.in.fileSize)
+			res, err := svc.GeneratePresignedUploadURL(
+				ctx, tt.in.userID, tt.in.projectID, tt.in.filename, tt.in.contentType, tt.in.fileSize,
+			)
 			require.NoError(t, err)
 
 			if tt.assertFn != nil {
 				tt.assertFn(t, res)
 			} else {
-				defaultAssertFn(t, res, tt.bucket, tt.in.userID, tt.in.filename)
+				defaultAssertFn(t, res, tt.bucket, tt.in.userID, tt.in.projectID, tt.in.filename)
 			}
 
 			if tt.assertURL != nil {
@@ -372,7 +408,7 @@ func TestDefaultS3Service_GeneratePresignedUploadURL_ContextCanceled(t *testing.
 	canceled, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	res, err := svc.GeneratePresignedUploadURL(canceled, "user", "file.jpg", "image/jpeg", 123)
+	res, err := svc.GeneratePresignedUploadURL(canceled, "user", "", "file.jpg", "image/jpeg", 123)
 	assert.Error(t, err)
 	assert.Nil(t, res)
 }
@@ -462,6 +498,38 @@ func TestDefaultS3Service_HeadFile(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDefaultS3Service_ListFiles(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	ctx := context.Background()
+
+	svc, err := NewDefaultS3Service(ctx, &configLib.S3{BucketName: "unit-bucket"})
+	require.NoError(t, err)
+	require.NotNil(t, svc)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	page, err := svc.ListFiles(canceled, "uploads/", "")
+	assert.Error(t, err)
+	assert.Nil(t, page)
+}
+
+func TestDefaultS3Service_DownloadFile(t *testing.T) {
+	t.Setenv("APP_ENV", "test")
+	ctx := context.Background()
+
+	svc, err := NewDefaultS3Service(ctx, &configLib.S3{BucketName: "unit-bucket"})
+	require.NoError(t, err)
+	require.NotNil(t, svc)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	body, err := svc.DownloadFile(canceled, "uploads/user/missing.jpg")
+	assert.Error(t, err)
+	assert.Nil(t, body)
+}
+
 func TestDefaultS3Service_CreateBucket(t *testing.T) {
 	t.Setenv("APP_ENV", "test")
 	ctx := context.Background()
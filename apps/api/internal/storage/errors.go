@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PostgreSQL error codes the repositories turn into domain errors.
+const (
+	// UniqueViolation is raised when a row conflicts with a unique index.
+	UniqueViolation = "23505"
+	// CheckViolation is raised when a row fails a CHECK constraint.
+	CheckViolation = "23514"
+)
+
+// IsUniqueViolation reports whether err is a unique index conflict.
+func IsUniqueViolation(err error) bool {
+	return hasCode(err, UniqueViolation)
+}
+
+// IsCheckViolation reports whether err is a failed CHECK constraint.
+func IsCheckViolation(err error) bool {
+	return hasCode(err, CheckViolation)
+}
+
+func hasCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodes(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		unique bool
+		check  bool
+	}{
+		{name: "success: unique violation", err: &pgconn.PgError{Code: UniqueViolation}, unique: true},
+		{
+			name:   "success: wrapped unique violation",
+			err:    fmt.Errorf("failed to insert: %w", &pgconn.PgError{Code: UniqueViolation}),
+			unique: true,
+		},
+		{name: "success: check violation", err: &pgconn.PgError{Code: CheckViolation}, check: true},
+		{name: "success: other postgres error", err: &pgconn.PgError{Code: "23503"}},
+		{name: "success: not a postgres error", err: errors.New("boom")},
+		{name: "success: nil", err: nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.unique, IsUniqueViolation(tc.err))
+			assert.Equal(t, tc.check, IsCheckViolation(tc.err))
+		})
+	}
+}
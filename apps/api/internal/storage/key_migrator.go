@@ -0,0 +1,255 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// KeyMigrationOptions controls a legacy-to-user-scoped key migration run.
+type KeyMigrationOptions struct {
+	// DryRun reports what would change without copying objects or updating rows.
+	DryRun bool
+	// DeleteSource removes the legacy objects once every row referencing them
+	// has been rewritten.
+	DeleteSource bool
+	// BatchSize is the number of image rows scanned per query.
+	BatchSize int
+}
+
+// KeyMigrationResult summarizes a key migration run.
+type KeyMigrationResult struct {
+	Scanned       int      `json:"scanned"`
+	Migrated      int      `json:"migrated"`
+	ObjectsCopied int      `json:"objects_copied"`
+	SourceDeleted int      `json:"source_deleted"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+// KeyMigrator copies objects stored under the legacy uploads/ and staged/
+// prefixes into the users/<user_id>/projects/<project_id>/ namespace and
+// rewrites image URLs, and the keys of the originals they share, to point at
+// the new keys.
+type KeyMigrator struct {
+	db     Database
+	s3     S3Service
+	bucket string
+}
+
+// NewKeyMigrator creates a new KeyMigrator.
+func NewKeyMigrator(db Database, s3 S3Service, bucket string) *KeyMigrator {
+	return &KeyMigrator{db: db, s3: s3, bucket: bucket}
+}
+
+const listImagesForKeyMigration = `
+SELECT i.id, i.original_url, i.staged_url, p.id, p.user_id, o.id, o.s3_key
+FROM images i
+JOIN projects p ON p.id = i.project_id
+LEFT JOIN original_images o ON o.id = i.original_image_id
+WHERE ($1::uuid IS NULL OR i.id > $1::uuid)
+ORDER BY i.id ASC
+LIMIT $2`
+
+const updateImageURLsForKeyMigration = `
+UPDATE images
+SET original_url = $2, staged_url = $3, updated_at = now()
+WHERE id = $1`
+
+const updateOriginalKeyForKeyMigration = `
+UPDATE original_images
+SET s3_key = $2
+WHERE id = $1 AND s3_key = $3`
+
+type keyMigrationRow struct {
+	imageID     pgtype.UUID
+	originalURL pgtype.Text
+	stagedURL   pgtype.Text
+	projectID   pgtype.UUID
+	userID      pgtype.UUID
+	sharedID    pgtype.UUID
+	sharedKey   pgtype.Text
+}
+
+// Run walks every image row and migrates any legacy object keys it references.
+// Objects shared by several images (e.g. multi-style batches) are copied once,
+// and a shared original moves into the project of the first image that uses it.
+func (m *KeyMigrator) Run(ctx context.Context, opts KeyMigrationOptions) (*KeyMigrationResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+
+	result := &KeyMigrationResult{}
+	copied := make(map[string]string) // legacy key -> new key
+	var cursor pgtype.UUID
+
+	for {
+		rows, err := m.listBatch(ctx, cursor, opts.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			result.Scanned++
+			if err := m.migrateRow(ctx, row, opts, copied, result); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("image %s: %v", row.imageID.String(), err))
+			}
+		}
+		cursor = rows[len(rows)-1].imageID
+	}
+
+	if opts.DeleteSource && !opts.DryRun && len(result.Errors) == 0 {
+		for oldKey := range copied {
+			if err := m.s3.DeleteFile(ctx, oldKey); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("delete %s: %v", oldKey, err))
+				continue
+			}
+			result.SourceDeleted++
+		}
+	}
+
+	return result, nil
+}
+
+func (m *KeyMigrator) listBatch(ctx context.Context, cursor pgtype.UUID, limit int) ([]keyMigrationRow, error) {
+	rows, err := m.db.Query(ctx, listImagesForKeyMigration, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	defer rows.Close()
+
+	var out []keyMigrationRow
+	for rows.Next() {
+		var r keyMigrationRow
+		if err := rows.Scan(
+			&r.imageID, &r.originalURL, &r.stagedURL, &r.projectID, &r.userID, &r.sharedID, &r.sharedKey,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan image: %w", err)
+		}
+		out = append(out, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate images: %w", err)
+	}
+	return out, nil
+}
+
+func (m *KeyMigrator) migrateRow(
+	ctx context.Context,
+	row keyMigrationRow,
+	opts KeyMigrationOptions,
+	copied map[string]string,
+	result *KeyMigrationResult,
+) error {
+	userID, projectID := row.userID.String(), row.projectID.String()
+
+	newOriginal, changedOriginal, err := m.migrateURL(ctx, row.originalURL, userID, projectID, opts, copied, result)
+	if err != nil {
+		return err
+	}
+	newStaged, changedStaged, err := m.migrateURL(ctx, row.stagedURL, userID, projectID, opts, copied, result)
+	if err != nil {
+		return err
+	}
+	changedShared, err := m.migrateSharedKey(ctx, row, opts, copied, result)
+	if err != nil {
+		return err
+	}
+	if !changedOriginal && !changedStaged && !changedShared {
+		return nil
+	}
+
+	if !opts.DryRun && (changedOriginal || changedStaged) {
+		if _, err := m.db.Exec(ctx, updateImageURLsForKeyMigration, row.imageID, newOriginal, newStaged); err != nil {
+			return fmt.Errorf("failed to update image URLs: %w", err)
+		}
+	}
+	result.Migrated++
+	return nil
+}
+
+// migrateSharedKey moves the deduplicated original the image references, so
+// deleting the legacy sources doesn't leave its s3_key pointing at nothing.
+// Only a row still holding the legacy key is updated, which makes repeating
+// it for the other images sharing the original harmless.
+func (m *KeyMigrator) migrateSharedKey(
+	ctx context.Context,
+	row keyMigrationRow,
+	opts KeyMigrationOptions,
+	copied map[string]string,
+	result *KeyMigrationResult,
+) (bool, error) {
+	if !row.sharedID.Valid || !row.sharedKey.Valid || IsUserScopedKey(row.sharedKey.String) {
+		return false, nil
+	}
+
+	oldKey := row.sharedKey.String
+	newKey, err := m.migrateObject(ctx, oldKey, row.userID.String(), row.projectID.String(), opts, copied, result)
+	if err != nil {
+		return false, err
+	}
+	if !opts.DryRun {
+		if _, err := m.db.Exec(ctx, updateOriginalKeyForKeyMigration, row.sharedID, newKey, oldKey); err != nil {
+			return false, fmt.Errorf("failed to update original image key: %w", err)
+		}
+	}
+	return true, nil
+}
+
+func (m *KeyMigrator) migrateURL(
+	ctx context.Context,
+	raw pgtype.Text,
+	userID, projectID string,
+	opts KeyMigrationOptions,
+	copied map[string]string,
+	result *KeyMigrationResult,
+) (pgtype.Text, bool, error) {
+	if !raw.Valid || raw.String == "" {
+		return raw, false, nil
+	}
+
+	oldKey, err := KeyFromURL(raw.String, m.bucket)
+	if err != nil {
+		return raw, false, err
+	}
+	if IsUserScopedKey(oldKey) {
+		return raw, false, nil
+	}
+
+	newKey, err := m.migrateObject(ctx, oldKey, userID, projectID, opts, copied, result)
+	if err != nil {
+		return raw, false, err
+	}
+
+	return pgtype.Text{String: ReplaceKeyInURL(raw.String, oldKey, newKey), Valid: true}, true, nil
+}
+
+// migrateObject copies a legacy object to its user-scoped key, unless an
+// earlier row already did, and returns the new key.
+func (m *KeyMigrator) migrateObject(
+	ctx context.Context,
+	oldKey, userID, projectID string,
+	opts KeyMigrationOptions,
+	copied map[string]string,
+	result *KeyMigrationResult,
+) (string, error) {
+	if newKey, ok := copied[oldKey]; ok {
+		return newKey, nil
+	}
+
+	newKey, err := MigrateKey(userID, projectID, oldKey)
+	if err != nil {
+		return "", err
+	}
+	if !opts.DryRun {
+		if err := m.s3.CopyFile(ctx, oldKey, newKey); err != nil {
+			return "", err
+		}
+	}
+	copied[oldKey] = newKey
+	result.ObjectsCopied++
+	return newKey, nil
+}
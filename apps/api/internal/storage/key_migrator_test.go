@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyMigrator_Run(t *testing.T) {
+	imageID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	siblingID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	projectID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	userID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	prefix := "users/" + userID.String() + "/projects/" + projectID.String()
+
+	originalURL := "https://bucket.s3.amazonaws.com/uploads/" + userID.String() + "/room.jpg"
+	stagedURL := "s3://bucket/staged/00000000/00000000-0000-0000-0000-000000000001-staged.jpg"
+
+	cols := []string{"id", "original_url", "staged_url", "id", "user_id", "id", "s3_key"}
+	uid := func(u uuid.UUID) pgtype.UUID { return pgtype.UUID{Bytes: u, Valid: true} }
+	txt := func(s string) pgtype.Text { return pgtype.Text{String: s, Valid: s != ""} }
+
+	tests := []struct {
+		name       string
+		opts       KeyMigrationOptions
+		copyErr    error
+		expectRows func(mock pgxmock.PgxPoolIface)
+		assertFn   func(t *testing.T, res *KeyMigrationResult, s3Mock *S3ServiceMock, execCalls int)
+	}{
+		{
+			name: "success: copies shared originals once and rewrites urls",
+			opts: KeyMigrationOptions{DeleteSource: true},
+			expectRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(cols).
+						AddRow(uid(imageID), txt(originalURL), txt(stagedURL), uid(projectID), uid(userID), pgtype.UUID{}, pgtype.Text{}).
+						AddRow(uid(siblingID), txt(originalURL), txt(""), uid(projectID), uid(userID), pgtype.UUID{}, pgtype.Text{}))
+				mock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(cols))
+			},
+			assertFn: func(t *testing.T, res *KeyMigrationResult, s3Mock *S3ServiceMock, execCalls int) {
+				assert.Equal(t, 2, res.Scanned)
+				assert.Equal(t, 2, res.Migrated)
+				assert.Equal(t, 2, res.ObjectsCopied)
+				assert.Equal(t, 2, res.SourceDeleted)
+				assert.Empty(t, res.Errors)
+				require.Len(t, s3Mock.CopyFileCalls(), 2)
+				assert.Equal(t, prefix+"/originals/room.jpg", s3Mock.CopyFileCalls()[0].DstKey)
+				assert.Equal(t, 2, execCalls)
+			},
+		},
+		{
+			name: "success: dry run does not touch storage",
+			opts: KeyMigrationOptions{DryRun: true, DeleteSource: true},
+			expectRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(cols).
+						AddRow(uid(imageID), txt(originalURL), txt(stagedURL), uid(projectID), uid(userID), pgtype.UUID{}, pgtype.Text{}))
+				mock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(cols))
+			},
+			assertFn: func(t *testing.T, res *KeyMigrationResult, s3Mock *S3ServiceMock, execCalls int) {
+				assert.Equal(t, 1, res.Migrated)
+				assert.Equal(t, 2, res.ObjectsCopied)
+				assert.Empty(t, s3Mock.CopyFileCalls())
+				assert.Empty(t, s3Mock.DeleteFileCalls())
+				assert.Equal(t, 0, execCalls)
+			},
+		},
+		{
+			name:    "fail: copy error is recorded and sources are kept",
+			opts:    KeyMigrationOptions{DeleteSource: true},
+			copyErr: errors.New("access denied"),
+			expectRows: func(mock pgxmock.PgxPoolIface) {
+				mock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(cols).
+						AddRow(uid(imageID), txt(originalURL), txt(""), uid(projectID), uid(userID), pgtype.UUID{}, pgtype.Text{}))
+				mock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+					WillReturnRows(pgxmock.NewRows(cols))
+			},
+			assertFn: func(t *testing.T, res *KeyMigrationResult, s3Mock *S3ServiceMock, execCalls int) {
+				assert.Len(t, res.Errors, 1)
+				assert.Equal(t, 0, res.Migrated)
+				assert.Empty(t, s3Mock.DeleteFileCalls())
+				assert.Equal(t, 0, execCalls)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poolMock, err := pgxmock.NewPool()
+			require.NoError(t, err)
+			defer poolMock.Close()
+			tt.expectRows(poolMock)
+
+			execCalls := 0
+			dbMock := &DatabaseMock{
+				QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+					return poolMock.Query(ctx, sql, args...)
+				},
+				ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+					execCalls++
+					return pgconn.NewCommandTag("UPDATE 1"), nil
+				},
+			}
+			s3Mock := &S3ServiceMock{
+				CopyFileFunc:   func(ctx context.Context, srcKey, dstKey string) error { return tt.copyErr },
+				DeleteFileFunc: func(ctx context.Context, fileKey string) error { return nil },
+			}
+
+			res, err := NewKeyMigrator(dbMock, s3Mock, "bucket").Run(context.Background(), tt.opts)
+			require.NoError(t, err)
+			tt.assertFn(t, res, s3Mock, execCalls)
+			assert.NoError(t, poolMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKeyMigrator_Run_sharedOriginals(t *testing.T) {
+	imageID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	siblingID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	originalID := uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	projectID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	userID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	legacyKey := "uploads/" + userID.String() + "/room.jpg"
+	newKey := "users/" + userID.String() + "/projects/" + projectID.String() + "/originals/room.jpg"
+	originalURL := "https://bucket.s3.amazonaws.com/" + legacyKey
+
+	cols := []string{"id", "original_url", "staged_url", "id", "user_id", "id", "s3_key"}
+	uid := func(u uuid.UUID) pgtype.UUID { return pgtype.UUID{Bytes: u, Valid: true} }
+	txt := func(s string) pgtype.Text { return pgtype.Text{String: s, Valid: s != ""} }
+
+	poolMock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer poolMock.Close()
+	poolMock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows(cols).
+			AddRow(uid(imageID), txt(originalURL), txt(""), uid(projectID), uid(userID), uid(originalID), txt(legacyKey)).
+			AddRow(uid(siblingID), txt(""), txt(""), uid(projectID), uid(userID), uid(originalID), txt(legacyKey)))
+	poolMock.ExpectQuery(`SELECT i.id, i.original_url`).WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows(cols))
+
+	var originalUpdates [][]interface{}
+	dbMock := &DatabaseMock{
+		QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+			return poolMock.Query(ctx, sql, args...)
+		},
+		ExecFunc: func(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+			if sql == updateOriginalKeyForKeyMigration {
+				originalUpdates = append(originalUpdates, args)
+			}
+			return pgconn.NewCommandTag("UPDATE 1"), nil
+		},
+	}
+	s3Mock := &S3ServiceMock{
+		CopyFileFunc:   func(ctx context.Context, srcKey, dstKey string) error { return nil },
+		DeleteFileFunc: func(ctx context.Context, fileKey string) error { return nil },
+	}
+
+	res, err := NewKeyMigrator(dbMock, s3Mock, "bucket").Run(context.Background(), KeyMigrationOptions{DeleteSource: true})
+	require.NoError(t, err)
+
+	assert.Empty(t, res.Errors)
+	assert.Equal(t, 2, res.Migrated)
+	assert.Equal(t, 1, res.ObjectsCopied, "the original and the image URL share one object")
+	require.Len(t, s3Mock.CopyFileCalls(), 1)
+	assert.Equal(t, newKey, s3Mock.CopyFileCalls()[0].DstKey)
+	require.Len(t, originalUpdates, 2)
+	assert.Equal(t, []interface{}{uid(originalID), newKey, legacyKey}, originalUpdates[0])
+	require.Len(t, s3Mock.DeleteFileCalls(), 1)
+	assert.Equal(t, legacyKey, s3Mock.DeleteFileCalls()[0].FileKey)
+	assert.NoError(t, poolMock.ExpectationsWereMet())
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Object keys are namespaced per tenant so lifecycle policies, quota accounting
+// and GDPR deletion can operate on a single prefix:
+//
+//	users/<user_id>/projects/<project_id>/originals/<name>-<uuid><ext>
+//	users/<user_id>/projects/<project_id>/staged/<image_id>-staged<ext>
+//	users/<user_id>/projects/<project_id>/cutouts/<image_id>/<run>-<n>.png
+//	users/<user_id>/projects/<project_id>/masks/<name>-<uuid>.png
+//	users/<user_id>/uploads/<name>-<uuid><ext>   (uploads not yet bound to a project)
+//
+// Keys written before the namespace existed use the legacy layout
+// uploads/<user_id>/... and staged/<image_id[:8]>/...; see MigrateKey.
+const (
+	userKeyRoot      = "users"
+	legacyUploadRoot = "uploads"
+	legacyStagedRoot = "staged"
+)
+
+// UserPrefix returns the key prefix that owns every object belonging to a user.
+func UserPrefix(userID string) string {
+	return fmt.Sprintf("%s/%s/", userKeyRoot, userID)
+}
+
+// ProjectPrefix returns the key prefix that owns every object belonging to a project.
+func ProjectPrefix(userID, projectID string) string {
+	return fmt.Sprintf("%sprojects/%s/", UserPrefix(userID), projectID)
+}
+
+// UploadKey builds a unique key for a new original upload. When projectID is
+// empty the upload is placed under the user's unassigned uploads prefix.
+func UploadKey(userID, projectID, filename string) string {
+	fileExt := filepath.Ext(filename)
+	baseName := strings.TrimSuffix(filename, fileExt)
+	name := fmt.Sprintf("%s-%s%s", baseName, uuid.New().String(), fileExt)
+	if projectID == "" {
+		return UserPrefix(userID) + "uploads/" + name
+	}
+	return ProjectPrefix(userID, projectID) + "originals/" + name
+}
+
+// MaskKey builds a unique key for a new staging mask in a project.
+func MaskKey(userID, projectID, filename string) string {
+	fileExt := filepath.Ext(filename)
+	baseName := strings.TrimSuffix(filename, fileExt)
+	return fmt.Sprintf("%smasks/%s-%s%s", ProjectPrefix(userID, projectID), baseName, uuid.New().String(), fileExt)
+}
+
+// IsUserScopedKey reports whether a key already lives under the users/ namespace.
+func IsUserScopedKey(key string) bool {
+	return strings.HasPrefix(key, userKeyRoot+"/")
+}
+
+// MigrateKey maps a legacy key onto the user-scoped layout. Keys that are
+// already user-scoped are returned unchanged.
+func MigrateKey(userID, projectID, key string) (string, error) {
+	if userID == "" || projectID == "" {
+		return "", fmt.Errorf("user ID and project ID are required")
+	}
+	if IsUserScopedKey(key) {
+		return key, nil
+	}
+
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return "", fmt.Errorf("unrecognized object key layout: %s", key)
+	}
+
+	switch parts[0] {
+	case legacyUploadRoot:
+		return ProjectPrefix(userID, projectID) + "originals/" + parts[2], nil
+	case legacyStagedRoot:
+		return ProjectPrefix(userID, projectID) + "staged/" + parts[2], nil
+	default:
+		return "", fmt.Errorf("unrecognized object key layout: %s", key)
+	}
+}
+
+// KeyFromURL extracts the object key from an s3:// URL, a virtual-hosted URL
+// or a path-style URL that includes the bucket name.
+func KeyFromURL(rawURL, bucket string) (string, error) {
+	if strings.HasPrefix(rawURL, "s3://") {
+		parts := strings.SplitN(strings.TrimPrefix(rawURL, "s3://"), "/", 2)
+		if len(parts) < 2 || parts[1] == "" {
+			return "", fmt.Errorf("invalid s3:// URL format")
+		}
+		return parts[1], nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL: %w", err)
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if bucket != "" {
+		key = strings.TrimPrefix(key, bucket+"/")
+	}
+	if key == "" {
+		return "", fmt.Errorf("URL has no object key: %s", rawURL)
+	}
+	return key, nil
+}
+
+// ReplaceKeyInURL rewrites the object key portion of a URL produced by
+// KeyFromURL, preserving scheme, host and any bucket path segment.
+func ReplaceKeyInURL(rawURL, oldKey, newKey string) string {
+	if strings.HasPrefix(rawURL, "s3://") {
+		bucket := strings.SplitN(strings.TrimPrefix(rawURL, "s3://"), "/", 2)[0]
+		return fmt.Sprintf("s3://%s/%s", bucket, newKey)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.HasSuffix(u.Path, oldKey) {
+		return rawURL
+	}
+	u.Path = path.Join(strings.TrimSuffix(u.Path, oldKey), newKey)
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path
+	}
+	u.RawPath = ""
+	return u.String()
+}
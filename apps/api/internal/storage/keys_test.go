@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		projectID string
+		filename  string
+		prefix    string
+	}{
+		{
+			name:      "success: project scoped",
+			userID:    "u1",
+			projectID: "p1",
+			filename:  "room.jpg",
+			prefix:    "users/u1/projects/p1/originals/room-",
+		},
+		{
+			name:     "success: unassigned upload",
+			userID:   "u1",
+			filename: "room.png",
+			prefix:   "users/u1/uploads/room-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := UploadKey(tt.userID, tt.projectID, tt.filename)
+			assert.True(t, strings.HasPrefix(key, tt.prefix), "unexpected key: %s", key)
+			assert.True(t, IsUserScopedKey(key))
+			assert.True(t, strings.HasPrefix(key, UserPrefix(tt.userID)))
+		})
+	}
+}
+
+func TestMaskKey(t *testing.T) {
+	key := MaskKey("u1", "p1", "sofa-area.png")
+
+	assert.True(t, strings.HasPrefix(key, "users/u1/projects/p1/masks/sofa-area-"), "unexpected key: %s", key)
+	assert.True(t, strings.HasSuffix(key, ".png"))
+	assert.True(t, IsUserScopedKey(key))
+}
+
+func TestMigrateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "success: legacy upload",
+			key:  "uploads/u1/room-abc.jpg",
+			want: "users/u1/projects/p1/originals/room-abc.jpg",
+		},
+		{
+			name: "success: legacy staged",
+			key:  "staged/12345678/12345678-aaaa-staged.jpg",
+			want: "users/u1/projects/p1/staged/12345678-aaaa-staged.jpg",
+		},
+		{
+			name: "success: already scoped",
+			key:  "users/u1/projects/p1/originals/room.jpg",
+			want: "users/u1/projects/p1/originals/room.jpg",
+		},
+		{name: "fail: unknown root", key: "misc/u1/room.jpg", wantErr: true},
+		{name: "fail: too short", key: "uploads/room.jpg", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MigrateKey("u1", "p1", tt.key)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestKeyFromURL_ReplaceKeyInURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		bucket  string
+		key     string
+		newKey  string
+		wantURL string
+	}{
+		{
+			name:    "success: s3 scheme",
+			url:     "s3://bucket/staged/1234/1234-staged.jpg",
+			bucket:  "bucket",
+			key:     "staged/1234/1234-staged.jpg",
+			newKey:  "users/u/projects/p/staged/1234-staged.jpg",
+			wantURL: "s3://bucket/users/u/projects/p/staged/1234-staged.jpg",
+		},
+		{
+			name:    "success: virtual hosted",
+			url:     "https://bucket.s3.amazonaws.com/uploads/u/a.jpg",
+			bucket:  "bucket",
+			key:     "uploads/u/a.jpg",
+			newKey:  "users/u/projects/p/originals/a.jpg",
+			wantURL: "https://bucket.s3.amazonaws.com/users/u/projects/p/originals/a.jpg",
+		},
+		{
+			name:    "success: path style",
+			url:     "http://localhost:4566/bucket/uploads/u/a.jpg",
+			bucket:  "bucket",
+			key:     "uploads/u/a.jpg",
+			newKey:  "users/u/projects/p/originals/a.jpg",
+			wantURL: "http://localhost:4566/bucket/users/u/projects/p/originals/a.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := KeyFromURL(tt.url, tt.bucket)
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, key)
+			assert.Equal(t, tt.wantURL, ReplaceKeyInURL(tt.url, key, tt.newKey))
+		})
+	}
+}
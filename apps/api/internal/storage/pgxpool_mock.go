@@ -20,6 +20,9 @@ var _ PgxPool = &PgxPoolMock{}
 //
 //		// make and configure a mocked PgxPool
 //		mockedPgxPool := &PgxPoolMock{
+//			BeginFunc: func(ctx context.Context) (pgx.Tx, error) {
+//				panic("mock out the Begin method")
+//			},
 //			CloseFunc: func()  {
 //				panic("mock out the Close method")
 //			},
@@ -42,6 +45,9 @@ var _ PgxPool = &PgxPoolMock{}
 //
 //	}
 type PgxPoolMock struct {
+	// BeginFunc mocks the Begin method.
+	BeginFunc func(ctx context.Context) (pgx.Tx, error)
+
 	// CloseFunc mocks the Close method.
 	CloseFunc func()
 
@@ -59,6 +65,11 @@ type PgxPoolMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Begin holds details about calls to the Begin method.
+		Begin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// Close holds details about calls to the Close method.
 		Close []struct {
 		}
@@ -95,6 +106,7 @@ type PgxPoolMock struct {
 			Args []interface{}
 		}
 	}
+	lockBegin    sync.RWMutex
 	lockClose    sync.RWMutex
 	lockExec     sync.RWMutex
 	lockPing     sync.RWMutex
@@ -102,6 +114,38 @@ type PgxPoolMock struct {
 	lockQueryRow sync.RWMutex
 }
 
+// Begin calls BeginFunc.
+func (mock *PgxPoolMock) Begin(ctx context.Context) (pgx.Tx, error) {
+	if mock.BeginFunc == nil {
+		panic("PgxPoolMock.BeginFunc: method is nil but PgxPool.Begin was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockBegin.Lock()
+	mock.calls.Begin = append(mock.calls.Begin, callInfo)
+	mock.lockBegin.Unlock()
+	return mock.BeginFunc(ctx)
+}
+
+// BeginCalls gets all the calls that were made to Begin.
+// Check the length with:
+//
+//	len(mockedPgxPool.BeginCalls())
+func (mock *PgxPoolMock) BeginCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockBegin.RLock()
+	calls = mock.calls.Begin
+	mock.lockBegin.RUnlock()
+	return calls
+}
+
 // Close calls CloseFunc.
 func (mock *PgxPoolMock) Close() {
 	if mock.CloseFunc == nil {
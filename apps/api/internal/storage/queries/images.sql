@@ -1,16 +1,42 @@
 -- name: CreateImage :one
-INSERT INTO images (project_id, original_url, room_type, style, seed, prompt)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at;
+-- The sandbox flag is copied from the project owner's account mode so that every
+-- downstream consumer (worker, usage counts) can isolate sandbox images without a join.
+INSERT INTO images (project_id, original_url, room_type, style, seed, prompt, mode, sandbox)
+VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE((
+  SELECT u.account_mode = 'sandbox'
+  FROM projects p
+  JOIN users u ON u.id = p.user_id
+  WHERE p.id = $1
+), false))
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, sandbox, mode;
+
+-- name: CreateImageVariant :one
+-- Creates a new variant of a live image that shares its original, mask and mode. The original's
+-- reference is taken in the same statement, so cleanup can never delete it in between.
+WITH source AS (
+  SELECT project_id, original_url, original_image_id, sandbox, mask_url, mode
+  FROM images
+  WHERE id = $1
+    AND deleted_at IS NULL
+), reference AS (
+  UPDATE original_images
+  SET reference_count = reference_count + 1,
+      updated_at = now()
+  WHERE id = (SELECT original_image_id FROM source)
+)
+INSERT INTO images (project_id, original_url, original_image_id, room_type, style, seed, prompt, sandbox, mask_url, mode)
+SELECT project_id, original_url, original_image_id, $2, $3, $4, $5, sandbox, mask_url, mode
+FROM source
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, sandbox, mask_url, mode;
 
 -- name: GetImageByID :one
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, blurhash, error_code, model_used, primary_image_id, mask_url, mode, detected_room_type, room_type_confidence, staged_content_type
 FROM images
 WHERE id = $1
   AND deleted_at IS NULL;
 
 -- name: GetImagesByProjectID :many
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, blurhash, error_code, staged_content_type
 FROM images
 WHERE project_id = $1
   AND deleted_at IS NULL
@@ -47,9 +73,11 @@ DELETE FROM images
 WHERE id = $1;
 
 -- name: DeleteImagesByProjectID :exec
--- Hard delete all images in a project - used when cascading project deletion
+-- Hard delete all images in a project - used when cascading project deletion.
+-- Images in a locked project are never deleted.
 DELETE FROM images
-WHERE project_id = $1;
+WHERE project_id = $1
+  AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.id = images.project_id AND p.locked);
 
 -- name: DeleteStuckQueuedImages :many
 -- Hard delete stuck queued images - cleanup operation for failed uploads
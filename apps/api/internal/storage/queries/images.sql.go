@@ -12,9 +12,14 @@ import (
 )
 
 const CreateImage = `-- name: CreateImage :one
-INSERT INTO images (project_id, original_url, room_type, style, seed, prompt)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at
+INSERT INTO images (project_id, original_url, room_type, style, seed, prompt, mode, sandbox)
+VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE((
+  SELECT u.account_mode = 'sandbox'
+  FROM projects p
+  JOIN users u ON u.id = p.user_id
+  WHERE p.id = $1
+), false))
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, sandbox, mode
 `
 
 type CreateImageParams struct {
@@ -24,6 +29,7 @@ type CreateImageParams struct {
 	Style       pgtype.Text `json:"style"`
 	Seed        pgtype.Int8 `json:"seed"`
 	Prompt      pgtype.Text `json:"prompt"`
+	Mode        string      `json:"mode"`
 }
 
 type CreateImageRow struct {
@@ -40,8 +46,12 @@ type CreateImageRow struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	Sandbox     bool               `json:"sandbox"`
+	Mode        string             `json:"mode"`
 }
 
+// The sandbox flag is copied from the project owner's account mode so that every
+// downstream consumer (worker, usage counts) can isolate sandbox images without a join.
 func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error) {
 	row := q.db.QueryRow(ctx, CreateImage,
 		arg.ProjectID,
@@ -50,6 +60,7 @@ func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (*Crea
 		arg.Style,
 		arg.Seed,
 		arg.Prompt,
+		arg.Mode,
 	)
 	var i CreateImageRow
 	err := row.Scan(
@@ -66,6 +77,85 @@ func (q *Queries) CreateImage(ctx context.Context, arg CreateImageParams) (*Crea
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Sandbox,
+		&i.Mode,
+	)
+	return &i, err
+}
+
+const CreateImageVariant = `-- name: CreateImageVariant :one
+WITH source AS (
+  SELECT project_id, original_url, original_image_id, sandbox, mask_url, mode
+  FROM images
+  WHERE id = $1
+    AND deleted_at IS NULL
+), reference AS (
+  UPDATE original_images
+  SET reference_count = reference_count + 1,
+      updated_at = now()
+  WHERE id = (SELECT original_image_id FROM source)
+)
+INSERT INTO images (project_id, original_url, original_image_id, room_type, style, seed, prompt, sandbox, mask_url, mode)
+SELECT project_id, original_url, original_image_id, $2, $3, $4, $5, sandbox, mask_url, mode
+FROM source
+RETURNING id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, sandbox, mask_url, mode
+`
+
+type CreateImageVariantParams struct {
+	ID       pgtype.UUID `json:"id"`
+	RoomType pgtype.Text `json:"room_type"`
+	Style    pgtype.Text `json:"style"`
+	Seed     pgtype.Int8 `json:"seed"`
+	Prompt   pgtype.Text `json:"prompt"`
+}
+
+type CreateImageVariantRow struct {
+	ID          pgtype.UUID        `json:"id"`
+	ProjectID   pgtype.UUID        `json:"project_id"`
+	OriginalUrl pgtype.Text        `json:"original_url"`
+	StagedUrl   pgtype.Text        `json:"staged_url"`
+	RoomType    pgtype.Text        `json:"room_type"`
+	Style       pgtype.Text        `json:"style"`
+	Seed        pgtype.Int8        `json:"seed"`
+	Prompt      pgtype.Text        `json:"prompt"`
+	Status      ImageStatus        `json:"status"`
+	Error       pgtype.Text        `json:"error"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	Sandbox     bool               `json:"sandbox"`
+	MaskUrl     pgtype.Text        `json:"mask_url"`
+	Mode        string             `json:"mode"`
+}
+
+// Creates a new variant of a live image that shares its original, mask and mode. The original's
+// reference is taken in the same statement, so cleanup can never delete it in between.
+func (q *Queries) CreateImageVariant(ctx context.Context, arg CreateImageVariantParams) (*CreateImageVariantRow, error) {
+	row := q.db.QueryRow(ctx, CreateImageVariant,
+		arg.ID,
+		arg.RoomType,
+		arg.Style,
+		arg.Seed,
+		arg.Prompt,
+	)
+	var i CreateImageVariantRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.OriginalUrl,
+		&i.StagedUrl,
+		&i.RoomType,
+		&i.Style,
+		&i.Seed,
+		&i.Prompt,
+		&i.Status,
+		&i.Error,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Sandbox,
+		&i.MaskUrl,
+		&i.Mode,
 	)
 	return &i, err
 }
@@ -84,9 +174,11 @@ func (q *Queries) DeleteImage(ctx context.Context, id pgtype.UUID) error {
 const DeleteImagesByProjectID = `-- name: DeleteImagesByProjectID :exec
 DELETE FROM images
 WHERE project_id = $1
+  AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.id = images.project_id AND p.locked)
 `
 
-// Hard delete all images in a project - used when cascading project deletion
+// Hard delete all images in a project - used when cascading project deletion.
+// Images in a locked project are never deleted.
 func (q *Queries) DeleteImagesByProjectID(ctx context.Context, projectID pgtype.UUID) error {
 	_, err := q.db.Exec(ctx, DeleteImagesByProjectID, projectID)
 	return err
@@ -127,26 +219,35 @@ func (q *Queries) DeleteStuckQueuedImages(ctx context.Context, dollar_1 pgtype.I
 }
 
 const GetImageByID = `-- name: GetImageByID :one
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, blurhash, error_code, model_used, primary_image_id, mask_url, mode, detected_room_type, room_type_confidence, staged_content_type
 FROM images
 WHERE id = $1
   AND deleted_at IS NULL
 `
 
 type GetImageByIDRow struct {
-	ID          pgtype.UUID        `json:"id"`
-	ProjectID   pgtype.UUID        `json:"project_id"`
-	OriginalUrl pgtype.Text        `json:"original_url"`
-	StagedUrl   pgtype.Text        `json:"staged_url"`
-	RoomType    pgtype.Text        `json:"room_type"`
-	Style       pgtype.Text        `json:"style"`
-	Seed        pgtype.Int8        `json:"seed"`
-	Prompt      pgtype.Text        `json:"prompt"`
-	Status      ImageStatus        `json:"status"`
-	Error       pgtype.Text        `json:"error"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	ID                 pgtype.UUID        `json:"id"`
+	ProjectID          pgtype.UUID        `json:"project_id"`
+	OriginalUrl        pgtype.Text        `json:"original_url"`
+	StagedUrl          pgtype.Text        `json:"staged_url"`
+	RoomType           pgtype.Text        `json:"room_type"`
+	Style              pgtype.Text        `json:"style"`
+	Seed               pgtype.Int8        `json:"seed"`
+	Prompt             pgtype.Text        `json:"prompt"`
+	Status             ImageStatus        `json:"status"`
+	Error              pgtype.Text        `json:"error"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt          pgtype.Timestamptz `json:"deleted_at"`
+	Blurhash           pgtype.Text        `json:"blurhash"`
+	ErrorCode          pgtype.Text        `json:"error_code"`
+	ModelUsed          pgtype.Text        `json:"model_used"`
+	PrimaryImageID     pgtype.UUID        `json:"primary_image_id"`
+	MaskUrl            pgtype.Text        `json:"mask_url"`
+	Mode               string             `json:"mode"`
+	DetectedRoomType   pgtype.Text        `json:"detected_room_type"`
+	RoomTypeConfidence pgtype.Float8      `json:"room_type_confidence"`
+	StagedContentType  pgtype.Text        `json:"staged_content_type"`
 }
 
 func (q *Queries) GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error) {
@@ -166,12 +267,21 @@ func (q *Queries) GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageBy
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Blurhash,
+		&i.ErrorCode,
+		&i.ModelUsed,
+		&i.PrimaryImageID,
+		&i.MaskUrl,
+		&i.Mode,
+		&i.DetectedRoomType,
+		&i.RoomTypeConfidence,
+		&i.StagedContentType,
 	)
 	return &i, err
 }
 
 const GetImagesByProjectID = `-- name: GetImagesByProjectID :many
-SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at
+SELECT id, project_id, original_url, staged_url, room_type, style, seed, prompt, status, error, created_at, updated_at, deleted_at, blurhash, error_code, staged_content_type
 FROM images
 WHERE project_id = $1
   AND deleted_at IS NULL
@@ -179,19 +289,22 @@ ORDER BY created_at DESC
 `
 
 type GetImagesByProjectIDRow struct {
-	ID          pgtype.UUID        `json:"id"`
-	ProjectID   pgtype.UUID        `json:"project_id"`
-	OriginalUrl pgtype.Text        `json:"original_url"`
-	StagedUrl   pgtype.Text        `json:"staged_url"`
-	RoomType    pgtype.Text        `json:"room_type"`
-	Style       pgtype.Text        `json:"style"`
-	Seed        pgtype.Int8        `json:"seed"`
-	Prompt      pgtype.Text        `json:"prompt"`
-	Status      ImageStatus        `json:"status"`
-	Error       pgtype.Text        `json:"error"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	ID                pgtype.UUID        `json:"id"`
+	ProjectID         pgtype.UUID        `json:"project_id"`
+	OriginalUrl       pgtype.Text        `json:"original_url"`
+	StagedUrl         pgtype.Text        `json:"staged_url"`
+	RoomType          pgtype.Text        `json:"room_type"`
+	Style             pgtype.Text        `json:"style"`
+	Seed              pgtype.Int8        `json:"seed"`
+	Prompt            pgtype.Text        `json:"prompt"`
+	Status            ImageStatus        `json:"status"`
+	Error             pgtype.Text        `json:"error"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt         pgtype.Timestamptz `json:"deleted_at"`
+	Blurhash          pgtype.Text        `json:"blurhash"`
+	ErrorCode         pgtype.Text        `json:"error_code"`
+	StagedContentType pgtype.Text        `json:"staged_content_type"`
 }
 
 func (q *Queries) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error) {
@@ -217,6 +330,9 @@ func (q *Queries) GetImagesByProjectID(ctx context.Context, projectID pgtype.UUI
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Blurhash,
+			&i.ErrorCode,
+			&i.StagedContentType,
 		); err != nil {
 			return nil, err
 		}
@@ -50,6 +50,23 @@ SELECT
 FROM invoices
 WHERE stripe_invoice_id = $1;
 
+-- Returns the invoice only when it belongs to the user.
+-- name: GetInvoiceByIDForUser :one
+SELECT
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at
+FROM invoices
+WHERE id = $1 AND user_id = $2;
+
 -- name: ListInvoicesByUserID :many
 SELECT
   id,
@@ -64,6 +81,8 @@ SELECT
   created_at,
   updated_at
 FROM invoices
-WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+    OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -11,6 +11,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const GetInvoiceByIDForUser = `-- name: GetInvoiceByIDForUser :one
+SELECT
+  id,
+  user_id,
+  stripe_invoice_id,
+  stripe_subscription_id,
+  status,
+  amount_due,
+  amount_paid,
+  currency,
+  invoice_number,
+  created_at,
+  updated_at
+FROM invoices
+WHERE id = $1 AND user_id = $2
+`
+
+type GetInvoiceByIDForUserParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+// Returns the invoice only when it belongs to the user.
+func (q *Queries) GetInvoiceByIDForUser(ctx context.Context, arg GetInvoiceByIDForUserParams) (*Invoice, error) {
+	row := q.db.QueryRow(ctx, GetInvoiceByIDForUser, arg.ID, arg.UserID)
+	var i Invoice
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StripeInvoiceID,
+		&i.StripeSubscriptionID,
+		&i.Status,
+		&i.AmountDue,
+		&i.AmountPaid,
+		&i.Currency,
+		&i.InvoiceNumber,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
 const GetInvoiceByStripeID = `-- name: GetInvoiceByStripeID :one
 SELECT
   id,
@@ -62,18 +104,28 @@ SELECT
   updated_at
 FROM invoices
 WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+  AND ($2::timestamptz IS NULL
+    OR (created_at, id) < ($2::timestamptz, $3::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $4 OFFSET $5
 `
 
 type ListInvoicesByUserIDParams struct {
-	UserID pgtype.UUID `json:"user_id"`
-	Limit  int32       `json:"limit"`
-	Offset int32       `json:"offset"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	Limit          int32              `json:"limit"`
+	Offset         int32              `json:"offset"`
 }
 
 func (q *Queries) ListInvoicesByUserID(ctx context.Context, arg ListInvoicesByUserIDParams) ([]*Invoice, error) {
-	rows, err := q.db.Query(ctx, ListInvoicesByUserID, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, ListInvoicesByUserID,
+		arg.UserID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -18,6 +18,8 @@ const (
 	ImageStatusProcessing ImageStatus = "processing"
 	ImageStatusReady      ImageStatus = "ready"
 	ImageStatusError      ImageStatus = "error"
+	ImageStatusRejected   ImageStatus = "rejected"
+	ImageStatusCanceled   ImageStatus = "canceled"
 )
 
 func (e *ImageStatus) Scan(src interface{}) error {
@@ -79,6 +81,57 @@ type Image struct {
 	// Custom prompt for AI staging. If null, uses default prompt from library based on room_type and style
 	Prompt          pgtype.Text `json:"prompt"`
 	OriginalImageID pgtype.UUID `json:"original_image_id"`
+	// EXIF orientation of the original before normalization; NULL until inspected
+	OriginalOrientation pgtype.Int2 `json:"original_orientation"`
+	CutoutStatus        pgtype.Text `json:"cutout_status"`
+	CutoutError         pgtype.Text `json:"cutout_error"`
+	// True when the image was created by a sandbox account and is staged by the fake provider
+	Sandbox bool `json:"sandbox"`
+	// BlurHash of the staged image; NULL until staged or when it could not be computed
+	Blurhash pgtype.Text `json:"blurhash"`
+	// Validation code when the original was rejected (e.g. corrupt_image); NULL otherwise
+	ErrorCode pgtype.Text `json:"error_code"`
+	// EXIF metadata stripped from the original (camera, taken_at, gps); NULL when not preserved or absent
+	OriginalMetadata []byte `json:"original_metadata"`
+	// When the owner was told this errored image will be moved to the trash; NULL until then
+	CleanupNotifiedAt pgtype.Timestamptz `json:"cleanup_notified_at"`
+	// Prompt experiment the image was staged in; NULL when it was not
+	PromptExperimentID pgtype.UUID `json:"prompt_experiment_id"`
+	// Experiment variant whose prompt staged the image; NULL when not in an experiment
+	PromptVariantID pgtype.UUID `json:"prompt_variant_id"`
+	// Owner verdict on the staged result: true approved, false rejected, NULL not given
+	Approved pgtype.Bool `json:"approved"`
+	// Image whose staging job also made this one; NULL for images staged by their own job
+	PrimaryImageID pgtype.UUID `json:"primary_image_id"`
+	// PNG mask of the region to stage, white where the model may repaint; NULL stages the whole photo
+	MaskUrl pgtype.Text `json:"mask_url"`
+	// stage adds furniture, declutter empties the room, both replaces its furniture
+	Mode string `json:"mode"`
+	// Room type the worker detected for an image uploaded without one
+	DetectedRoomType pgtype.Text `json:"detected_room_type"`
+	// Confidence (0-1) the detection model gave detected_room_type
+	RoomTypeConfidence pgtype.Float8 `json:"room_type_confidence"`
+	// Content type of the staged file, e.g. image/webp; NULL for images staged before it was recorded
+	StagedContentType pgtype.Text `json:"staged_content_type"`
+}
+
+type ImageAsset struct {
+	ID          pgtype.UUID `json:"id"`
+	ImageID     pgtype.UUID `json:"image_id"`
+	Kind        string      `json:"kind"`
+	Url         string      `json:"url"`
+	ContentType string      `json:"content_type"`
+	Width       int32       `json:"width"`
+	Height      int32       `json:"height"`
+	// Left edge of the asset within the parent staged image, in pixels
+	SourceX int32 `json:"source_x"`
+	// Top edge of the asset within the parent staged image, in pixels
+	SourceY   int32              `json:"source_y"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	// For thumbnails, the image file it was made from: original or staged
+	Source pgtype.Text `json:"source"`
+	// For thumbnails, the size bucket: small, medium or large
+	Size pgtype.Text `json:"size"`
 }
 
 type Invoice struct {
@@ -107,6 +160,34 @@ type Job struct {
 	FinishedAt  pgtype.Timestamptz `json:"finished_at"`
 }
 
+type Organization struct {
+	ID            pgtype.UUID        `json:"id"`
+	Name          string             `json:"name"`
+	BillingUserID pgtype.UUID        `json:"billing_user_id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
+type OrganizationInvitation struct {
+	ID         pgtype.UUID        `json:"id"`
+	OrgID      pgtype.UUID        `json:"org_id"`
+	Email      string             `json:"email"`
+	Role       string             `json:"role"`
+	TokenHash  string             `json:"token_hash"`
+	InvitedBy  pgtype.UUID        `json:"invited_by"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	AcceptedAt pgtype.Timestamptz `json:"accepted_at"`
+	AcceptedBy pgtype.UUID        `json:"accepted_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrganizationMember struct {
+	OrgID     pgtype.UUID        `json:"org_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Role      string             `json:"role"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type OriginalImage struct {
 	ID             pgtype.UUID        `json:"id"`
 	ContentHash    string             `json:"content_hash"`
@@ -120,6 +201,26 @@ type OriginalImage struct {
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 }
 
+type OutboundDelivery struct {
+	ID                pgtype.UUID        `json:"id"`
+	Channel           string             `json:"channel"`
+	Destination       string             `json:"destination"`
+	EventType         string             `json:"event_type"`
+	Subject           pgtype.Text        `json:"subject"`
+	Payload           []byte             `json:"payload"`
+	Status            string             `json:"status"`
+	Attempts          int32              `json:"attempts"`
+	MaxAttempts       int32              `json:"max_attempts"`
+	LastError         pgtype.Text        `json:"last_error"`
+	LastStatusCode    pgtype.Int4        `json:"last_status_code"`
+	EnqueuedAt        pgtype.Timestamptz `json:"enqueued_at"`
+	LastAttemptAt     pgtype.Timestamptz `json:"last_attempt_at"`
+	DeliveredAt       pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	WebhookEndpointID pgtype.UUID        `json:"webhook_endpoint_id"`
+}
+
 type Plan struct {
 	ID           pgtype.UUID `json:"id"`
 	Code         string      `json:"code"`
@@ -140,6 +241,43 @@ type Project struct {
 	UserID    pgtype.UUID        `json:"user_id"`
 	Name      string             `json:"name"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	OrgID     pgtype.UUID        `json:"org_id"`
+	Locked    bool               `json:"locked"`
+	// Overlay the configured watermark on images staged in this project
+	Watermark bool `json:"watermark"`
+	// Staging model for images in this project; NULL falls back to the user preference, then active_model
+	ModelID pgtype.Text `json:"model_id"`
+	// Language-region tag such as en-GB used to localize furniture sizes in stage prompts; NULL keeps US phrasing
+	Locale    pgtype.Text        `json:"locale"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PromptReview struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	ProjectID  pgtype.UUID        `json:"project_id"`
+	ImageID    pgtype.UUID        `json:"image_id"`
+	Prompt     string             `json:"prompt"`
+	Decision   string             `json:"decision"`
+	Violations []byte             `json:"violations"`
+	Status     string             `json:"status"`
+	ReviewedBy pgtype.Text        `json:"reviewed_by"`
+	ReviewNote pgtype.Text        `json:"review_note"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ReviewedAt pgtype.Timestamptz `json:"reviewed_at"`
+}
+
+type RouteMetricRollup struct {
+	Method string `json:"method"`
+	// Echo route template, e.g. /api/v1/images/:id
+	Route       string             `json:"route"`
+	BucketStart pgtype.Timestamptz `json:"bucket_start"`
+	Requests    int64              `json:"requests"`
+	// Responses with a 5xx status
+	Errors       int64 `json:"errors"`
+	LatencySumMs int64 `json:"latency_sum_ms"`
+	// Request counts per latency histogram bucket (see slo.LatencyBucketsMs)
+	LatencyBuckets []int64 `json:"latency_buckets"`
 }
 
 // System-wide configuration settings
@@ -157,6 +295,21 @@ type Setting struct {
 	ModelSettings []byte      `json:"model_settings"`
 }
 
+type SloObjective struct {
+	ID     pgtype.UUID `json:"id"`
+	Name   string      `json:"name"`
+	Method string      `json:"method"`
+	Route  string      `json:"route"`
+	Kind   string      `json:"kind"`
+	// Fraction of requests that must be good, e.g. 0.99
+	Target float64 `json:"target"`
+	// Latency objectives only: a request is good when it completes within this many ms
+	ThresholdMs pgtype.Int4        `json:"threshold_ms"`
+	WindowDays  int32              `json:"window_days"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
 type Subscription struct {
 	ID                   pgtype.UUID        `json:"id"`
 	UserID               pgtype.UUID        `json:"user_id"`
@@ -186,4 +339,30 @@ type User struct {
 	ProfilePhotoUrl  pgtype.Text        `json:"profile_photo_url"`
 	Preferences      []byte             `json:"preferences"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	// Tenant mode: live uses real billing and models, sandbox uses Stripe test mode and the fake provider
+	AccountMode string `json:"account_mode"`
+	// Stripe test clock bound to the sandbox customer
+	StripeTestClockID pgtype.Text `json:"stripe_test_clock_id"`
+}
+
+type UserPreference struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	Namespace string      `json:"namespace"`
+	// AES-256-GCM sealed JSON object, bound to user_id and namespace
+	Ciphertext []byte             `json:"ciphertext"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WebhookEndpoint struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	ProjectID   pgtype.UUID        `json:"project_id"`
+	Url         string             `json:"url"`
+	Secret      string             `json:"secret"`
+	EventTypes  []string           `json:"event_types"`
+	Description pgtype.Text        `json:"description"`
+	Active      bool               `json:"active"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
@@ -13,17 +13,21 @@ WHERE id = $1;
 SELECT * FROM original_images
 WHERE content_hash = $1;
 
--- name: IncrementReferenceCount :exec
+-- name: IncrementReferenceCount :execrows
+-- Affects no rows when the original has already been deleted.
 UPDATE original_images
 SET reference_count = reference_count + 1,
     updated_at = now()
 WHERE id = $1;
 
--- name: DecrementReferenceCount :exec
+-- name: DecrementReferenceCount :one
+-- The row lock taken by the UPDATE serializes concurrent decrements, so the
+-- returned count is exact.
 UPDATE original_images
 SET reference_count = GREATEST(reference_count - 1, 0),
     updated_at = now()
-WHERE id = $1;
+WHERE id = $1
+RETURNING reference_count;
 
 -- name: ListOrphanedOriginalImages :many
 SELECT * FROM original_images
@@ -36,6 +40,15 @@ LIMIT $2;
 DELETE FROM original_images
 WHERE id = $1;
 
+-- name: DeleteUnreferencedOriginalImage :one
+-- Deletes the original only if nothing references it. The condition is
+-- rechecked under the row lock, so an original re-referenced by a concurrent
+-- upload is kept (no row is returned).
+DELETE FROM original_images
+WHERE id = $1
+  AND reference_count = 0
+RETURNING s3_key;
+
 -- name: GetOriginalImageStats :one
 SELECT 
   COUNT(*) as total_count,
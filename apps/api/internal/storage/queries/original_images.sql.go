@@ -53,16 +53,21 @@ func (q *Queries) CreateOriginalImage(ctx context.Context, arg CreateOriginalIma
 	return &i, err
 }
 
-const DecrementReferenceCount = `-- name: DecrementReferenceCount :exec
+const DecrementReferenceCount = `-- name: DecrementReferenceCount :one
 UPDATE original_images
 SET reference_count = GREATEST(reference_count - 1, 0),
     updated_at = now()
 WHERE id = $1
+RETURNING reference_count
 `
 
-func (q *Queries) DecrementReferenceCount(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, DecrementReferenceCount, id)
-	return err
+// The row lock taken by the UPDATE serializes concurrent decrements, so the
+// returned count is exact.
+func (q *Queries) DecrementReferenceCount(ctx context.Context, id pgtype.UUID) (int32, error) {
+	row := q.db.QueryRow(ctx, DecrementReferenceCount, id)
+	var reference_count int32
+	err := row.Scan(&reference_count)
+	return reference_count, err
 }
 
 const DeleteOriginalImage = `-- name: DeleteOriginalImage :exec
@@ -75,6 +80,23 @@ func (q *Queries) DeleteOriginalImage(ctx context.Context, id pgtype.UUID) error
 	return err
 }
 
+const DeleteUnreferencedOriginalImage = `-- name: DeleteUnreferencedOriginalImage :one
+DELETE FROM original_images
+WHERE id = $1
+  AND reference_count = 0
+RETURNING s3_key
+`
+
+// Deletes the original only if nothing references it. The condition is
+// rechecked under the row lock, so an original re-referenced by a concurrent
+// upload is kept (no row is returned).
+func (q *Queries) DeleteUnreferencedOriginalImage(ctx context.Context, id pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, DeleteUnreferencedOriginalImage, id)
+	var s3_key string
+	err := row.Scan(&s3_key)
+	return s3_key, err
+}
+
 const GetOriginalImageByHash = `-- name: GetOriginalImageByHash :one
 SELECT id, content_hash, s3_key, file_size, mime_type, width, height, reference_count, created_at, updated_at FROM original_images
 WHERE content_hash = $1
@@ -152,16 +174,20 @@ func (q *Queries) GetOriginalImageStats(ctx context.Context) (*GetOriginalImageS
 	return &i, err
 }
 
-const IncrementReferenceCount = `-- name: IncrementReferenceCount :exec
+const IncrementReferenceCount = `-- name: IncrementReferenceCount :execrows
 UPDATE original_images
 SET reference_count = reference_count + 1,
     updated_at = now()
 WHERE id = $1
 `
 
-func (q *Queries) IncrementReferenceCount(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, IncrementReferenceCount, id)
-	return err
+// Affects no rows when the original has already been deleted.
+func (q *Queries) IncrementReferenceCount(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, IncrementReferenceCount, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const ListOrphanedOriginalImages = `-- name: ListOrphanedOriginalImages :many
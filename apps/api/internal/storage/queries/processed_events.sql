@@ -35,6 +35,13 @@ RETURNING
   payload,
   received_at;
 
+-- Records the event unless it already is. Affects no rows for an event that
+-- was processed before, or is being processed by a transaction that commits first.
+-- name: ClaimProcessedEvent :execrows
+INSERT INTO processed_events (stripe_event_id, type, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (stripe_event_id) DO NOTHING;
+
 -- Optional maintenance: delete older processed events by timestamp (retention)
 -- name: DeleteOldProcessedEvents :exec
 DELETE FROM processed_events
@@ -113,9 +120,11 @@ SELECT
   created_at,
   updated_at
 FROM subscriptions
-WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('after_created_at')::timestamptz IS NULL
+    OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: DeleteSubscriptionByStripeID :exec
 DELETE FROM subscriptions
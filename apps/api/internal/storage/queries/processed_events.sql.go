@@ -11,6 +11,28 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const ClaimProcessedEvent = `-- name: ClaimProcessedEvent :execrows
+INSERT INTO processed_events (stripe_event_id, type, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (stripe_event_id) DO NOTHING
+`
+
+type ClaimProcessedEventParams struct {
+	StripeEventID string      `json:"stripe_event_id"`
+	Type          pgtype.Text `json:"type"`
+	Payload       []byte      `json:"payload"`
+}
+
+// Records the event unless it already is. Affects no rows for an event that
+// was processed before, or is being processed by a transaction that commits first.
+func (q *Queries) ClaimProcessedEvent(ctx context.Context, arg ClaimProcessedEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, ClaimProcessedEvent, arg.StripeEventID, arg.Type, arg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const CreateProcessedEvent = `-- name: CreateProcessedEvent :one
 INSERT INTO processed_events (stripe_event_id, type, payload)
 VALUES ($1, $2, $3)
@@ -143,18 +165,28 @@ SELECT
   updated_at
 FROM subscriptions
 WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+  AND ($2::timestamptz IS NULL
+    OR (created_at, id) < ($2::timestamptz, $3::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $4 OFFSET $5
 `
 
 type ListSubscriptionsByUserIDParams struct {
-	UserID pgtype.UUID `json:"user_id"`
-	Limit  int32       `json:"limit"`
-	Offset int32       `json:"offset"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	Limit          int32              `json:"limit"`
+	Offset         int32              `json:"offset"`
 }
 
 func (q *Queries) ListSubscriptionsByUserID(ctx context.Context, arg ListSubscriptionsByUserIDParams) ([]*Subscription, error) {
-	rows, err := q.db.Query(ctx, ListSubscriptionsByUserID, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, ListSubscriptionsByUserID,
+		arg.UserID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -4,16 +4,84 @@ VALUES ($1, $2)
 RETURNING id, name, user_id, created_at;
 
 -- name: GetProjectByID :one
-SELECT id, name, user_id, created_at
+SELECT id, name, user_id, created_at, org_id, locked, watermark, model_id, locale
 FROM projects
 WHERE id = $1;
 
+-- name: GetProjectByIDForMember :one
+-- The creator or any member of the project's organization can access it
+SELECT id, name, user_id, created_at, org_id, locked, watermark, model_id, locale
+FROM projects
+WHERE id = $1 AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2
+));
+
+-- name: GetProjectsByOrgID :many
+SELECT id, name, user_id, created_at, org_id
+FROM projects
+WHERE org_id = $1
+ORDER BY created_at DESC;
+
+-- name: SetProjectOrg :one
+UPDATE projects
+SET org_id = $2
+WHERE id = $1
+RETURNING id, name, user_id, created_at, org_id;
+
+-- name: GetProjectBillingUserID :one
+-- Images in an org project are billed to the org's billing user
+SELECT COALESCE(o.billing_user_id, p.user_id)::uuid AS billing_user_id
+FROM projects p
+LEFT JOIN organizations o ON o.id = p.org_id
+WHERE p.id = $1;
+
 -- name: GetProjectsByUserID :many
 SELECT id, name, user_id, created_at
 FROM projects
 WHERE user_id = $1
 ORDER BY created_at DESC;
 
+-- name: SearchProjectsByUserID :many
+-- Filters by name and by the status of the project's images, and sorts by
+-- created_at or updated_at, newest first unless ascending. Image counts skip
+-- images in the trash.
+SELECT p.id, p.name, p.user_id, p.created_at, p.updated_at, p.org_id, p.locked, p.watermark, p.model_id, p.locale,
+  c.queued_count, c.processing_count, c.ready_count, c.error_count, c.rejected_count,
+  c.canceled_count
+FROM projects p
+CROSS JOIN LATERAL (
+  SELECT
+    COUNT(*) FILTER (WHERE i.status = 'queued') AS queued_count,
+    COUNT(*) FILTER (WHERE i.status = 'processing') AS processing_count,
+    COUNT(*) FILTER (WHERE i.status = 'ready') AS ready_count,
+    COUNT(*) FILTER (WHERE i.status = 'error') AS error_count,
+    COUNT(*) FILTER (WHERE i.status = 'rejected') AS rejected_count,
+    COUNT(*) FILTER (WHERE i.status = 'canceled') AS canceled_count
+  FROM images i
+  WHERE i.project_id = p.id AND i.deleted_at IS NULL
+) c
+WHERE p.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('name_pattern')::text IS NULL OR p.name ILIKE sqlc.narg('name_pattern')::text)
+  AND (sqlc.narg('statuses')::text[] IS NULL OR EXISTS (
+    SELECT 1 FROM images s
+    WHERE s.project_id = p.id AND s.deleted_at IS NULL AND s.status::text = ANY(sqlc.narg('statuses')::text[])
+  ))
+  AND (sqlc.narg('after_at')::timestamptz IS NULL
+    OR (sqlc.arg('ascending')::bool
+      AND (CASE WHEN sqlc.arg('sort_by')::text = 'updated_at' THEN p.updated_at ELSE p.created_at END, p.id)
+        > (sqlc.narg('after_at')::timestamptz, sqlc.narg('after_id')::uuid))
+    OR (NOT sqlc.arg('ascending')::bool
+      AND (CASE WHEN sqlc.arg('sort_by')::text = 'updated_at' THEN p.updated_at ELSE p.created_at END, p.id)
+        < (sqlc.narg('after_at')::timestamptz, sqlc.narg('after_id')::uuid)))
+ORDER BY
+  CASE WHEN sqlc.arg('ascending')::bool
+    THEN CASE WHEN sqlc.arg('sort_by')::text = 'updated_at' THEN p.updated_at ELSE p.created_at END END ASC,
+  CASE WHEN sqlc.arg('ascending')::bool THEN p.id END ASC,
+  CASE WHEN sqlc.arg('sort_by')::text = 'updated_at' THEN p.updated_at ELSE p.created_at END DESC,
+  p.id DESC
+LIMIT sqlc.arg('limit');
+
 -- name: GetAllProjects :many
 SELECT id, name, user_id, created_at
 FROM projects
@@ -26,18 +94,42 @@ WHERE id = $1
 RETURNING id, name, user_id, created_at;
 
 -- name: UpdateProjectByUserID :one
+-- Org owners and admins can rename shared projects; a null watermark, model_id
+-- or locale keeps it and an empty model_id or locale clears it
 UPDATE projects
-SET name = $3
-WHERE id = $1 AND user_id = $2
-RETURNING id, name, user_id, created_at;
+SET name = $3, watermark = COALESCE(sqlc.narg('watermark'), watermark),
+  model_id = CASE WHEN sqlc.narg('model_id')::text IS NULL THEN model_id
+    ELSE NULLIF(sqlc.narg('model_id')::text, '') END,
+  locale = CASE WHEN sqlc.narg('locale')::text IS NULL THEN locale
+    ELSE NULLIF(sqlc.narg('locale')::text, '') END
+WHERE id = $1 AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+))
+RETURNING id, name, user_id, created_at, watermark, model_id, locale;
+
+-- name: SetProjectLockedByUserID :one
+-- Org owners and admins can lock and unlock shared projects
+UPDATE projects
+SET locked = $3
+WHERE id = $1 AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+))
+RETURNING id, name, user_id, created_at, org_id, locked, watermark, model_id, locale;
 
 -- name: DeleteProject :exec
+-- Locked projects are never deleted
 DELETE FROM projects
-WHERE id = $1;
+WHERE id = $1 AND NOT locked;
 
 -- name: DeleteProjectByUserID :exec
+-- Org owners and admins can delete shared projects; locked projects are never deleted
 DELETE FROM projects
-WHERE id = $1 AND user_id = $2;
+WHERE id = $1 AND NOT locked AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+));
 
 -- name: CountProjectsByUserID :one
 SELECT COUNT(*)
@@ -56,9 +56,10 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (*
 
 const DeleteProject = `-- name: DeleteProject :exec
 DELETE FROM projects
-WHERE id = $1
+WHERE id = $1 AND NOT locked
 `
 
+// Locked projects are never deleted
 func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) error {
 	_, err := q.db.Exec(ctx, DeleteProject, id)
 	return err
@@ -66,7 +67,10 @@ func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) error {
 
 const DeleteProjectByUserID = `-- name: DeleteProjectByUserID :exec
 DELETE FROM projects
-WHERE id = $1 AND user_id = $2
+WHERE id = $1 AND NOT locked AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+))
 `
 
 type DeleteProjectByUserIDParams struct {
@@ -74,6 +78,7 @@ type DeleteProjectByUserIDParams struct {
 	UserID pgtype.UUID `json:"user_id"`
 }
 
+// Org owners and admins can delete shared projects; locked projects are never deleted
 func (q *Queries) DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) error {
 	_, err := q.db.Exec(ctx, DeleteProjectByUserID, arg.ID, arg.UserID)
 	return err
@@ -117,8 +122,23 @@ func (q *Queries) GetAllProjects(ctx context.Context) ([]*GetAllProjectsRow, err
 	return items, nil
 }
 
+const GetProjectBillingUserID = `-- name: GetProjectBillingUserID :one
+SELECT COALESCE(o.billing_user_id, p.user_id)::uuid AS billing_user_id
+FROM projects p
+LEFT JOIN organizations o ON o.id = p.org_id
+WHERE p.id = $1
+`
+
+// Images in an org project are billed to the org's billing user
+func (q *Queries) GetProjectBillingUserID(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, GetProjectBillingUserID, id)
+	var billing_user_id pgtype.UUID
+	err := row.Scan(&billing_user_id)
+	return billing_user_id, err
+}
+
 const GetProjectByID = `-- name: GetProjectByID :one
-SELECT id, name, user_id, created_at
+SELECT id, name, user_id, created_at, org_id, locked, watermark, model_id, locale
 FROM projects
 WHERE id = $1
 `
@@ -128,6 +148,11 @@ type GetProjectByIDRow struct {
 	Name      string             `json:"name"`
 	UserID    pgtype.UUID        `json:"user_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	OrgID     pgtype.UUID        `json:"org_id"`
+	Locked    bool               `json:"locked"`
+	Watermark bool               `json:"watermark"`
+	ModelID   pgtype.Text        `json:"model_id"`
+	Locale    pgtype.Text        `json:"locale"`
 }
 
 func (q *Queries) GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error) {
@@ -138,10 +163,100 @@ func (q *Queries) GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProje
 		&i.Name,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.OrgID,
+		&i.Locked,
+		&i.Watermark,
+		&i.ModelID,
+		&i.Locale,
+	)
+	return &i, err
+}
+
+const GetProjectByIDForMember = `-- name: GetProjectByIDForMember :one
+SELECT id, name, user_id, created_at, org_id, locked, watermark, model_id, locale
+FROM projects
+WHERE id = $1 AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2
+))
+`
+
+type GetProjectByIDForMemberParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetProjectByIDForMemberRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	OrgID     pgtype.UUID        `json:"org_id"`
+	Locked    bool               `json:"locked"`
+	Watermark bool               `json:"watermark"`
+	ModelID   pgtype.Text        `json:"model_id"`
+	Locale    pgtype.Text        `json:"locale"`
+}
+
+// The creator or any member of the project's organization can access it
+func (q *Queries) GetProjectByIDForMember(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error) {
+	row := q.db.QueryRow(ctx, GetProjectByIDForMember, arg.ID, arg.UserID)
+	var i GetProjectByIDForMemberRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.OrgID,
+		&i.Locked,
+		&i.Watermark,
+		&i.ModelID,
+		&i.Locale,
 	)
 	return &i, err
 }
 
+const GetProjectsByOrgID = `-- name: GetProjectsByOrgID :many
+SELECT id, name, user_id, created_at, org_id
+FROM projects
+WHERE org_id = $1
+ORDER BY created_at DESC
+`
+
+type GetProjectsByOrgIDRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	OrgID     pgtype.UUID        `json:"org_id"`
+}
+
+func (q *Queries) GetProjectsByOrgID(ctx context.Context, orgID pgtype.UUID) ([]*GetProjectsByOrgIDRow, error) {
+	rows, err := q.db.Query(ctx, GetProjectsByOrgID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetProjectsByOrgIDRow{}
+	for rows.Next() {
+		var i GetProjectsByOrgIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.OrgID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetProjectsByUserID = `-- name: GetProjectsByUserID :many
 SELECT id, name, user_id, created_at
 FROM projects
@@ -181,6 +296,202 @@ func (q *Queries) GetProjectsByUserID(ctx context.Context, userID pgtype.UUID) (
 	return items, nil
 }
 
+const SearchProjectsByUserID = `-- name: SearchProjectsByUserID :many
+SELECT p.id, p.name, p.user_id, p.created_at, p.updated_at, p.org_id, p.locked, p.watermark, p.model_id, p.locale,
+  c.queued_count, c.processing_count, c.ready_count, c.error_count, c.rejected_count,
+  c.canceled_count
+FROM projects p
+CROSS JOIN LATERAL (
+  SELECT
+    COUNT(*) FILTER (WHERE i.status = 'queued') AS queued_count,
+    COUNT(*) FILTER (WHERE i.status = 'processing') AS processing_count,
+    COUNT(*) FILTER (WHERE i.status = 'ready') AS ready_count,
+    COUNT(*) FILTER (WHERE i.status = 'error') AS error_count,
+    COUNT(*) FILTER (WHERE i.status = 'rejected') AS rejected_count,
+    COUNT(*) FILTER (WHERE i.status = 'canceled') AS canceled_count
+  FROM images i
+  WHERE i.project_id = p.id AND i.deleted_at IS NULL
+) c
+WHERE p.user_id = $1
+  AND ($2::text IS NULL OR p.name ILIKE $2::text)
+  AND ($3::text[] IS NULL OR EXISTS (
+    SELECT 1 FROM images s
+    WHERE s.project_id = p.id AND s.deleted_at IS NULL AND s.status::text = ANY($3::text[])
+  ))
+  AND ($4::timestamptz IS NULL
+    OR ($5::bool
+      AND (CASE WHEN $6::text = 'updated_at' THEN p.updated_at ELSE p.created_at END, p.id)
+        > ($4::timestamptz, $7::uuid))
+    OR (NOT $5::bool
+      AND (CASE WHEN $6::text = 'updated_at' THEN p.updated_at ELSE p.created_at END, p.id)
+        < ($4::timestamptz, $7::uuid)))
+ORDER BY
+  CASE WHEN $5::bool
+    THEN CASE WHEN $6::text = 'updated_at' THEN p.updated_at ELSE p.created_at END END ASC,
+  CASE WHEN $5::bool THEN p.id END ASC,
+  CASE WHEN $6::text = 'updated_at' THEN p.updated_at ELSE p.created_at END DESC,
+  p.id DESC
+LIMIT $8
+`
+
+type SearchProjectsByUserIDParams struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	NamePattern pgtype.Text        `json:"name_pattern"`
+	Statuses    []string           `json:"statuses"`
+	AfterAt     pgtype.Timestamptz `json:"after_at"`
+	Ascending   bool               `json:"ascending"`
+	SortBy      string             `json:"sort_by"`
+	AfterID     pgtype.UUID        `json:"after_id"`
+	Limit       int32              `json:"limit"`
+}
+
+type SearchProjectsByUserIDRow struct {
+	ID              pgtype.UUID        `json:"id"`
+	Name            string             `json:"name"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	OrgID           pgtype.UUID        `json:"org_id"`
+	Locked          bool               `json:"locked"`
+	Watermark       bool               `json:"watermark"`
+	ModelID         pgtype.Text        `json:"model_id"`
+	Locale          pgtype.Text        `json:"locale"`
+	QueuedCount     int64              `json:"queued_count"`
+	ProcessingCount int64              `json:"processing_count"`
+	ReadyCount      int64              `json:"ready_count"`
+	ErrorCount      int64              `json:"error_count"`
+	RejectedCount   int64              `json:"rejected_count"`
+	CanceledCount   int64              `json:"canceled_count"`
+}
+
+// Filters by name and by the status of the project's images, and sorts by
+// created_at or updated_at, newest first unless ascending. Image counts skip
+// images in the trash.
+func (q *Queries) SearchProjectsByUserID(ctx context.Context, arg SearchProjectsByUserIDParams) ([]*SearchProjectsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, SearchProjectsByUserID,
+		arg.UserID,
+		arg.NamePattern,
+		arg.Statuses,
+		arg.AfterAt,
+		arg.Ascending,
+		arg.SortBy,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*SearchProjectsByUserIDRow{}
+	for rows.Next() {
+		var i SearchProjectsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.OrgID,
+			&i.Locked,
+			&i.Watermark,
+			&i.ModelID,
+			&i.Locale,
+			&i.QueuedCount,
+			&i.ProcessingCount,
+			&i.ReadyCount,
+			&i.ErrorCount,
+			&i.RejectedCount,
+			&i.CanceledCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SetProjectLockedByUserID = `-- name: SetProjectLockedByUserID :one
+UPDATE projects
+SET locked = $3
+WHERE id = $1 AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+))
+RETURNING id, name, user_id, created_at, org_id, locked, watermark, model_id, locale
+`
+
+type SetProjectLockedByUserIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+	Locked bool        `json:"locked"`
+}
+
+type SetProjectLockedByUserIDRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	OrgID     pgtype.UUID        `json:"org_id"`
+	Locked    bool               `json:"locked"`
+	Watermark bool               `json:"watermark"`
+	ModelID   pgtype.Text        `json:"model_id"`
+	Locale    pgtype.Text        `json:"locale"`
+}
+
+// Org owners and admins can lock and unlock shared projects
+func (q *Queries) SetProjectLockedByUserID(ctx context.Context, arg SetProjectLockedByUserIDParams) (*SetProjectLockedByUserIDRow, error) {
+	row := q.db.QueryRow(ctx, SetProjectLockedByUserID, arg.ID, arg.UserID, arg.Locked)
+	var i SetProjectLockedByUserIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.OrgID,
+		&i.Locked,
+		&i.Watermark,
+		&i.ModelID,
+		&i.Locale,
+	)
+	return &i, err
+}
+
+const SetProjectOrg = `-- name: SetProjectOrg :one
+UPDATE projects
+SET org_id = $2
+WHERE id = $1
+RETURNING id, name, user_id, created_at, org_id
+`
+
+type SetProjectOrgParams struct {
+	ID    pgtype.UUID `json:"id"`
+	OrgID pgtype.UUID `json:"org_id"`
+}
+
+type SetProjectOrgRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	Name      string             `json:"name"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	OrgID     pgtype.UUID        `json:"org_id"`
+}
+
+func (q *Queries) SetProjectOrg(ctx context.Context, arg SetProjectOrgParams) (*SetProjectOrgRow, error) {
+	row := q.db.QueryRow(ctx, SetProjectOrg, arg.ID, arg.OrgID)
+	var i SetProjectOrgRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.OrgID,
+	)
+	return &i, err
+}
+
 const UpdateProject = `-- name: UpdateProject :one
 UPDATE projects
 SET name = $2
@@ -214,15 +525,25 @@ func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (*
 
 const UpdateProjectByUserID = `-- name: UpdateProjectByUserID :one
 UPDATE projects
-SET name = $3
-WHERE id = $1 AND user_id = $2
-RETURNING id, name, user_id, created_at
+SET name = $3, watermark = COALESCE($4, watermark),
+  model_id = CASE WHEN $5::text IS NULL THEN model_id
+    ELSE NULLIF($5::text, '') END,
+  locale = CASE WHEN $6::text IS NULL THEN locale
+    ELSE NULLIF($6::text, '') END
+WHERE id = $1 AND (user_id = $2 OR EXISTS (
+  SELECT 1 FROM organization_members m
+  WHERE m.org_id = projects.org_id AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+))
+RETURNING id, name, user_id, created_at, watermark, model_id, locale
 `
 
 type UpdateProjectByUserIDParams struct {
-	ID     pgtype.UUID `json:"id"`
-	UserID pgtype.UUID `json:"user_id"`
-	Name   string      `json:"name"`
+	ID        pgtype.UUID `json:"id"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Name      string      `json:"name"`
+	Watermark pgtype.Bool `json:"watermark"`
+	ModelID   pgtype.Text `json:"model_id"`
+	Locale    pgtype.Text `json:"locale"`
 }
 
 type UpdateProjectByUserIDRow struct {
@@ -230,16 +551,31 @@ type UpdateProjectByUserIDRow struct {
 	Name      string             `json:"name"`
 	UserID    pgtype.UUID        `json:"user_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Watermark bool               `json:"watermark"`
+	ModelID   pgtype.Text        `json:"model_id"`
+	Locale    pgtype.Text        `json:"locale"`
 }
 
+// Org owners and admins can rename shared projects; a null watermark, model_id
+// or locale keeps it and an empty model_id or locale clears it
 func (q *Queries) UpdateProjectByUserID(ctx context.Context, arg UpdateProjectByUserIDParams) (*UpdateProjectByUserIDRow, error) {
-	row := q.db.QueryRow(ctx, UpdateProjectByUserID, arg.ID, arg.UserID, arg.Name)
+	row := q.db.QueryRow(ctx, UpdateProjectByUserID,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Watermark,
+		arg.ModelID,
+		arg.Locale,
+	)
 	var i UpdateProjectByUserIDRow
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
 		&i.UserID,
 		&i.CreatedAt,
+		&i.Watermark,
+		&i.ModelID,
+		&i.Locale,
 	)
 	return &i, err
 }
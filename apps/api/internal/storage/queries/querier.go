@@ -11,6 +11,9 @@ import (
 )
 
 type Querier interface {
+	// Records the event unless it already is. Affects no rows for an event that
+	// was processed before, or is being processed by a transaction that commits first.
+	ClaimProcessedEvent(ctx context.Context, arg ClaimProcessedEventParams) (int64, error)
 	CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error)
 	// Count how many images a user created within a specific date range
 	// IMPORTANT: This counts ALL images (including soft-deleted) to prevent gaming the system
@@ -19,6 +22,9 @@ type Querier interface {
 	CountProjectsByUserID(ctx context.Context, userID pgtype.UUID) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
 	CreateImage(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error)
+	// Creates a new variant of a live image that shares its original. The original's
+	// reference is taken in the same statement, so cleanup can never delete it in between.
+	CreateImageVariant(ctx context.Context, arg CreateImageVariantParams) (*CreateImageVariantRow, error)
 	CreateJob(ctx context.Context, arg CreateJobParams) (*Job, error)
 	CreateOriginalImage(ctx context.Context, arg CreateOriginalImageParams) (*OriginalImage, error)
 	// Create a new plan
@@ -26,26 +32,37 @@ type Querier interface {
 	CreateProcessedEvent(ctx context.Context, arg CreateProcessedEventParams) (*ProcessedEvent, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (*CreateProjectRow, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error)
-	DecrementReferenceCount(ctx context.Context, id pgtype.UUID) error
+	// The row lock taken by the UPDATE serializes concurrent decrements, so the
+	// returned count is exact.
+	DecrementReferenceCount(ctx context.Context, id pgtype.UUID) (int32, error)
 	// Hard delete an image - only use for cleanup operations
 	DeleteImage(ctx context.Context, id pgtype.UUID) error
-	// Hard delete all images in a project - used when cascading project deletion
+	// Hard delete all images in a project - used when cascading project deletion.
+	// Images in a locked project are never deleted.
 	DeleteImagesByProjectID(ctx context.Context, projectID pgtype.UUID) error
 	DeleteJob(ctx context.Context, id pgtype.UUID) error
 	DeleteJobsByImageID(ctx context.Context, imageID pgtype.UUID) error
 	// Optional maintenance: delete older processed events by timestamp (retention)
 	DeleteOldProcessedEvents(ctx context.Context, receivedAt pgtype.Timestamptz) error
 	DeleteOriginalImage(ctx context.Context, id pgtype.UUID) error
+	// Locked projects are never deleted
 	DeleteProject(ctx context.Context, id pgtype.UUID) error
+	// Org owners and admins can delete shared projects; locked projects are never deleted
 	DeleteProjectByUserID(ctx context.Context, arg DeleteProjectByUserIDParams) error
 	// Hard delete stuck queued images - cleanup operation for failed uploads
 	DeleteStuckQueuedImages(ctx context.Context, dollar_1 pgtype.Interval) ([]*DeleteStuckQueuedImagesRow, error)
 	DeleteSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) error
+	// Deletes the original only if nothing references it. The condition is
+	// rechecked under the row lock, so an original re-referenced by a concurrent
+	// upload is kept (no row is returned).
+	DeleteUnreferencedOriginalImage(ctx context.Context, id pgtype.UUID) (string, error)
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
 	FailJob(ctx context.Context, arg FailJobParams) (*Job, error)
 	GetAllProjects(ctx context.Context) ([]*GetAllProjectsRow, error)
 	GetImageByID(ctx context.Context, id pgtype.UUID) (*GetImageByIDRow, error)
 	GetImagesByProjectID(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error)
+	// Returns the invoice only when it belongs to the user.
+	GetInvoiceByIDForUser(ctx context.Context, arg GetInvoiceByIDForUserParams) (*Invoice, error)
 	GetInvoiceByStripeID(ctx context.Context, stripeInvoiceID string) (*Invoice, error)
 	GetJobByID(ctx context.Context, id pgtype.UUID) (*Job, error)
 	GetJobsByImageID(ctx context.Context, imageID pgtype.UUID) ([]*Job, error)
@@ -64,7 +81,12 @@ type Querier interface {
 	// Processed Events (Stripe Idempotency)
 	// sqlc queries for processed_events and subscriptions
 	GetProcessedEventByStripeID(ctx context.Context, stripeEventID string) (*ProcessedEvent, error)
+	// Images in an org project are billed to the org's billing user
+	GetProjectBillingUserID(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
 	GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error)
+	// The creator or any member of the project's organization can access it
+	GetProjectByIDForMember(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error)
+	GetProjectsByOrgID(ctx context.Context, orgID pgtype.UUID) ([]*GetProjectsByOrgIDRow, error)
 	GetProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error)
 	GetSubscriptionByStripeID(ctx context.Context, stripeSubscriptionID string) (*Subscription, error)
 	// Get the user's current active plan based on their subscription
@@ -75,7 +97,8 @@ type Querier interface {
 	GetUserByStripeCustomerID(ctx context.Context, stripeCustomerID pgtype.Text) (*GetUserByStripeCustomerIDRow, error)
 	GetUserProfileByAuth0Sub(ctx context.Context, auth0Sub string) (*GetUserProfileByAuth0SubRow, error)
 	GetUserProfileByID(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error)
-	IncrementReferenceCount(ctx context.Context, id pgtype.UUID) error
+	// Affects no rows when the original has already been deleted.
+	IncrementReferenceCount(ctx context.Context, id pgtype.UUID) (int64, error)
 	// List all active subscriptions (for validation)
 	ListAllActiveSubscriptions(ctx context.Context) ([]*Subscription, error)
 	// List all available plans
@@ -87,6 +110,13 @@ type Querier interface {
 	ListSubscriptionsByUserID(ctx context.Context, arg ListSubscriptionsByUserIDParams) ([]*Subscription, error)
 	ListSubscriptionsByUserIDAndStatuses(ctx context.Context, arg ListSubscriptionsByUserIDAndStatusesParams) ([]*Subscription, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error)
+	// Filters by name and by the status of the project's images, and sorts by
+	// created_at or updated_at, newest first unless ascending. Image counts skip
+	// images in the trash.
+	SearchProjectsByUserID(ctx context.Context, arg SearchProjectsByUserIDParams) ([]*SearchProjectsByUserIDRow, error)
+	// Org owners and admins can lock and unlock shared projects
+	SetProjectLockedByUserID(ctx context.Context, arg SetProjectLockedByUserIDParams) (*SetProjectLockedByUserIDRow, error)
+	SetProjectOrg(ctx context.Context, arg SetProjectOrgParams) (*SetProjectOrgRow, error)
 	// Soft delete an image - marks it as deleted but keeps it in DB for usage tracking
 	SoftDeleteImage(ctx context.Context, id pgtype.UUID) error
 	StartJob(ctx context.Context, id pgtype.UUID) (*Job, error)
@@ -97,6 +127,7 @@ type Querier interface {
 	// Update an existing plan
 	UpdatePlan(ctx context.Context, arg UpdatePlanParams) (*Plan, error)
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (*UpdateProjectRow, error)
+	// Org owners and admins can rename shared projects
 	UpdateProjectByUserID(ctx context.Context, arg UpdateProjectByUserIDParams) (*UpdateProjectByUserIDRow, error)
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (*UpdateUserProfileRow, error)
 	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (*UpdateUserRoleRow, error)
@@ -19,6 +19,9 @@ var _ Querier = &QuerierMock{}
 //
 //		// make and configure a mocked Querier
 //		mockedQuerier := &QuerierMock{
+//			ClaimProcessedEventFunc: func(ctx context.Context, arg ClaimProcessedEventParams) (int64, error) {
+//				panic("mock out the ClaimProcessedEvent method")
+//			},
 //			CompleteJobFunc: func(ctx context.Context, id pgtype.UUID) (*Job, error) {
 //				panic("mock out the CompleteJob method")
 //			},
@@ -34,6 +37,9 @@ var _ Querier = &QuerierMock{}
 //			CreateImageFunc: func(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error) {
 //				panic("mock out the CreateImage method")
 //			},
+//			CreateImageVariantFunc: func(ctx context.Context, arg CreateImageVariantParams) (*CreateImageVariantRow, error) {
+//				panic("mock out the CreateImageVariant method")
+//			},
 //			CreateJobFunc: func(ctx context.Context, arg CreateJobParams) (*Job, error) {
 //				panic("mock out the CreateJob method")
 //			},
@@ -52,7 +58,7 @@ var _ Querier = &QuerierMock{}
 //			CreateUserFunc: func(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error) {
 //				panic("mock out the CreateUser method")
 //			},
-//			DecrementReferenceCountFunc: func(ctx context.Context, id pgtype.UUID) error {
+//			DecrementReferenceCountFunc: func(ctx context.Context, id pgtype.UUID) (int32, error) {
 //				panic("mock out the DecrementReferenceCount method")
 //			},
 //			DeleteImageFunc: func(ctx context.Context, id pgtype.UUID) error {
@@ -85,6 +91,9 @@ var _ Querier = &QuerierMock{}
 //			DeleteSubscriptionByStripeIDFunc: func(ctx context.Context, stripeSubscriptionID string) error {
 //				panic("mock out the DeleteSubscriptionByStripeID method")
 //			},
+//			DeleteUnreferencedOriginalImageFunc: func(ctx context.Context, id pgtype.UUID) (string, error) {
+//				panic("mock out the DeleteUnreferencedOriginalImage method")
+//			},
 //			DeleteUserFunc: func(ctx context.Context, id pgtype.UUID) error {
 //				panic("mock out the DeleteUser method")
 //			},
@@ -100,6 +109,9 @@ var _ Querier = &QuerierMock{}
 //			GetImagesByProjectIDFunc: func(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error) {
 //				panic("mock out the GetImagesByProjectID method")
 //			},
+//			GetInvoiceByIDForUserFunc: func(ctx context.Context, arg GetInvoiceByIDForUserParams) (*Invoice, error) {
+//				panic("mock out the GetInvoiceByIDForUser method")
+//			},
 //			GetInvoiceByStripeIDFunc: func(ctx context.Context, stripeInvoiceID string) (*Invoice, error) {
 //				panic("mock out the GetInvoiceByStripeID method")
 //			},
@@ -136,9 +148,18 @@ var _ Querier = &QuerierMock{}
 //			GetProcessedEventByStripeIDFunc: func(ctx context.Context, stripeEventID string) (*ProcessedEvent, error) {
 //				panic("mock out the GetProcessedEventByStripeID method")
 //			},
+//			GetProjectBillingUserIDFunc: func(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+//				panic("mock out the GetProjectBillingUserID method")
+//			},
 //			GetProjectByIDFunc: func(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error) {
 //				panic("mock out the GetProjectByID method")
 //			},
+//			GetProjectByIDForMemberFunc: func(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error) {
+//				panic("mock out the GetProjectByIDForMember method")
+//			},
+//			GetProjectsByOrgIDFunc: func(ctx context.Context, orgID pgtype.UUID) ([]*GetProjectsByOrgIDRow, error) {
+//				panic("mock out the GetProjectsByOrgID method")
+//			},
 //			GetProjectsByUserIDFunc: func(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error) {
 //				panic("mock out the GetProjectsByUserID method")
 //			},
@@ -163,7 +184,7 @@ var _ Querier = &QuerierMock{}
 //			GetUserProfileByIDFunc: func(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error) {
 //				panic("mock out the GetUserProfileByID method")
 //			},
-//			IncrementReferenceCountFunc: func(ctx context.Context, id pgtype.UUID) error {
+//			IncrementReferenceCountFunc: func(ctx context.Context, id pgtype.UUID) (int64, error) {
 //				panic("mock out the IncrementReferenceCount method")
 //			},
 //			ListAllActiveSubscriptionsFunc: func(ctx context.Context) ([]*Subscription, error) {
@@ -190,6 +211,15 @@ var _ Querier = &QuerierMock{}
 //			ListUsersFunc: func(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error) {
 //				panic("mock out the ListUsers method")
 //			},
+//			SearchProjectsByUserIDFunc: func(ctx context.Context, arg SearchProjectsByUserIDParams) ([]*SearchProjectsByUserIDRow, error) {
+//				panic("mock out the SearchProjectsByUserID method")
+//			},
+//			SetProjectLockedByUserIDFunc: func(ctx context.Context, arg SetProjectLockedByUserIDParams) (*SetProjectLockedByUserIDRow, error) {
+//				panic("mock out the SetProjectLockedByUserID method")
+//			},
+//			SetProjectOrgFunc: func(ctx context.Context, arg SetProjectOrgParams) (*SetProjectOrgRow, error) {
+//				panic("mock out the SetProjectOrg method")
+//			},
 //			SoftDeleteImageFunc: func(ctx context.Context, id pgtype.UUID) error {
 //				panic("mock out the SoftDeleteImage method")
 //			},
@@ -242,6 +272,9 @@ var _ Querier = &QuerierMock{}
 //
 //	}
 type QuerierMock struct {
+	// ClaimProcessedEventFunc mocks the ClaimProcessedEvent method.
+	ClaimProcessedEventFunc func(ctx context.Context, arg ClaimProcessedEventParams) (int64, error)
+
 	// CompleteJobFunc mocks the CompleteJob method.
 	CompleteJobFunc func(ctx context.Context, id pgtype.UUID) (*Job, error)
 
@@ -257,6 +290,9 @@ type QuerierMock struct {
 	// CreateImageFunc mocks the CreateImage method.
 	CreateImageFunc func(ctx context.Context, arg CreateImageParams) (*CreateImageRow, error)
 
+	// CreateImageVariantFunc mocks the CreateImageVariant method.
+	CreateImageVariantFunc func(ctx context.Context, arg CreateImageVariantParams) (*CreateImageVariantRow, error)
+
 	// CreateJobFunc mocks the CreateJob method.
 	CreateJobFunc func(ctx context.Context, arg CreateJobParams) (*Job, error)
 
@@ -276,7 +312,7 @@ type QuerierMock struct {
 	CreateUserFunc func(ctx context.Context, arg CreateUserParams) (*CreateUserRow, error)
 
 	// DecrementReferenceCountFunc mocks the DecrementReferenceCount method.
-	DecrementReferenceCountFunc func(ctx context.Context, id pgtype.UUID) error
+	DecrementReferenceCountFunc func(ctx context.Context, id pgtype.UUID) (int32, error)
 
 	// DeleteImageFunc mocks the DeleteImage method.
 	DeleteImageFunc func(ctx context.Context, id pgtype.UUID) error
@@ -308,6 +344,9 @@ type QuerierMock struct {
 	// DeleteSubscriptionByStripeIDFunc mocks the DeleteSubscriptionByStripeID method.
 	DeleteSubscriptionByStripeIDFunc func(ctx context.Context, stripeSubscriptionID string) error
 
+	// DeleteUnreferencedOriginalImageFunc mocks the DeleteUnreferencedOriginalImage method.
+	DeleteUnreferencedOriginalImageFunc func(ctx context.Context, id pgtype.UUID) (string, error)
+
 	// DeleteUserFunc mocks the DeleteUser method.
 	DeleteUserFunc func(ctx context.Context, id pgtype.UUID) error
 
@@ -323,6 +362,9 @@ type QuerierMock struct {
 	// GetImagesByProjectIDFunc mocks the GetImagesByProjectID method.
 	GetImagesByProjectIDFunc func(ctx context.Context, projectID pgtype.UUID) ([]*GetImagesByProjectIDRow, error)
 
+	// GetInvoiceByIDForUserFunc mocks the GetInvoiceByIDForUser method.
+	GetInvoiceByIDForUserFunc func(ctx context.Context, arg GetInvoiceByIDForUserParams) (*Invoice, error)
+
 	// GetInvoiceByStripeIDFunc mocks the GetInvoiceByStripeID method.
 	GetInvoiceByStripeIDFunc func(ctx context.Context, stripeInvoiceID string) (*Invoice, error)
 
@@ -359,9 +401,18 @@ type QuerierMock struct {
 	// GetProcessedEventByStripeIDFunc mocks the GetProcessedEventByStripeID method.
 	GetProcessedEventByStripeIDFunc func(ctx context.Context, stripeEventID string) (*ProcessedEvent, error)
 
+	// GetProjectBillingUserIDFunc mocks the GetProjectBillingUserID method.
+	GetProjectBillingUserIDFunc func(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
+
 	// GetProjectByIDFunc mocks the GetProjectByID method.
 	GetProjectByIDFunc func(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error)
 
+	// GetProjectByIDForMemberFunc mocks the GetProjectByIDForMember method.
+	GetProjectByIDForMemberFunc func(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error)
+
+	// GetProjectsByOrgIDFunc mocks the GetProjectsByOrgID method.
+	GetProjectsByOrgIDFunc func(ctx context.Context, orgID pgtype.UUID) ([]*GetProjectsByOrgIDRow, error)
+
 	// GetProjectsByUserIDFunc mocks the GetProjectsByUserID method.
 	GetProjectsByUserIDFunc func(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error)
 
@@ -387,7 +438,7 @@ type QuerierMock struct {
 	GetUserProfileByIDFunc func(ctx context.Context, id pgtype.UUID) (*GetUserProfileByIDRow, error)
 
 	// IncrementReferenceCountFunc mocks the IncrementReferenceCount method.
-	IncrementReferenceCountFunc func(ctx context.Context, id pgtype.UUID) error
+	IncrementReferenceCountFunc func(ctx context.Context, id pgtype.UUID) (int64, error)
 
 	// ListAllActiveSubscriptionsFunc mocks the ListAllActiveSubscriptions method.
 	ListAllActiveSubscriptionsFunc func(ctx context.Context) ([]*Subscription, error)
@@ -413,6 +464,15 @@ type QuerierMock struct {
 	// ListUsersFunc mocks the ListUsers method.
 	ListUsersFunc func(ctx context.Context, arg ListUsersParams) ([]*ListUsersRow, error)
 
+	// SearchProjectsByUserIDFunc mocks the SearchProjectsByUserID method.
+	SearchProjectsByUserIDFunc func(ctx context.Context, arg SearchProjectsByUserIDParams) ([]*SearchProjectsByUserIDRow, error)
+
+	// SetProjectLockedByUserIDFunc mocks the SetProjectLockedByUserID method.
+	SetProjectLockedByUserIDFunc func(ctx context.Context, arg SetProjectLockedByUserIDParams) (*SetProjectLockedByUserIDRow, error)
+
+	// SetProjectOrgFunc mocks the SetProjectOrg method.
+	SetProjectOrgFunc func(ctx context.Context, arg SetProjectOrgParams) (*SetProjectOrgRow, error)
+
 	// SoftDeleteImageFunc mocks the SoftDeleteImage method.
 	SoftDeleteImageFunc func(ctx context.Context, id pgtype.UUID) error
 
@@ -460,6 +520,13 @@ type QuerierMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ClaimProcessedEvent holds details about calls to the ClaimProcessedEvent method.
+		ClaimProcessedEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg ClaimProcessedEventParams
+		}
 		// CompleteJob holds details about calls to the CompleteJob method.
 		CompleteJob []struct {
 			// Ctx is the ctx argument value.
@@ -493,6 +560,13 @@ type QuerierMock struct {
 			// Arg is the arg argument value.
 			Arg CreateImageParams
 		}
+		// CreateImageVariant holds details about calls to the CreateImageVariant method.
+		CreateImageVariant []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg CreateImageVariantParams
+		}
 		// CreateJob holds details about calls to the CreateJob method.
 		CreateJob []struct {
 			// Ctx is the ctx argument value.
@@ -612,6 +686,13 @@ type QuerierMock struct {
 			// StripeSubscriptionID is the stripeSubscriptionID argument value.
 			StripeSubscriptionID string
 		}
+		// DeleteUnreferencedOriginalImage holds details about calls to the DeleteUnreferencedOriginalImage method.
+		DeleteUnreferencedOriginalImage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
 		// DeleteUser holds details about calls to the DeleteUser method.
 		DeleteUser []struct {
 			// Ctx is the ctx argument value.
@@ -645,6 +726,13 @@ type QuerierMock struct {
 			// ProjectID is the projectID argument value.
 			ProjectID pgtype.UUID
 		}
+		// GetInvoiceByIDForUser holds details about calls to the GetInvoiceByIDForUser method.
+		GetInvoiceByIDForUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetInvoiceByIDForUserParams
+		}
 		// GetInvoiceByStripeID holds details about calls to the GetInvoiceByStripeID method.
 		GetInvoiceByStripeID []struct {
 			// Ctx is the ctx argument value.
@@ -727,6 +815,13 @@ type QuerierMock struct {
 			// StripeEventID is the stripeEventID argument value.
 			StripeEventID string
 		}
+		// GetProjectBillingUserID holds details about calls to the GetProjectBillingUserID method.
+		GetProjectBillingUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID pgtype.UUID
+		}
 		// GetProjectByID holds details about calls to the GetProjectByID method.
 		GetProjectByID []struct {
 			// Ctx is the ctx argument value.
@@ -734,6 +829,20 @@ type QuerierMock struct {
 			// ID is the id argument value.
 			ID pgtype.UUID
 		}
+		// GetProjectByIDForMember holds details about calls to the GetProjectByIDForMember method.
+		GetProjectByIDForMember []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg GetProjectByIDForMemberParams
+		}
+		// GetProjectsByOrgID holds details about calls to the GetProjectsByOrgID method.
+		GetProjectsByOrgID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// OrgID is the orgID argument value.
+			OrgID pgtype.UUID
+		}
 		// GetProjectsByUserID holds details about calls to the GetProjectsByUserID method.
 		GetProjectsByUserID []struct {
 			// Ctx is the ctx argument value.
@@ -849,6 +958,27 @@ type QuerierMock struct {
 			// Arg is the arg argument value.
 			Arg ListUsersParams
 		}
+		// SearchProjectsByUserID holds details about calls to the SearchProjectsByUserID method.
+		SearchProjectsByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg SearchProjectsByUserIDParams
+		}
+		// SetProjectLockedByUserID holds details about calls to the SetProjectLockedByUserID method.
+		SetProjectLockedByUserID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg SetProjectLockedByUserIDParams
+		}
+		// SetProjectOrg holds details about calls to the SetProjectOrg method.
+		SetProjectOrg []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Arg is the arg argument value.
+			Arg SetProjectOrgParams
+		}
 		// SoftDeleteImage holds details about calls to the SoftDeleteImage method.
 		SoftDeleteImage []struct {
 			// Ctx is the ctx argument value.
@@ -955,11 +1085,13 @@ type QuerierMock struct {
 			Arg UpsertSubscriptionByStripeIDParams
 		}
 	}
+	lockClaimProcessedEvent                  sync.RWMutex
 	lockCompleteJob                          sync.RWMutex
 	lockCountImagesCreatedInPeriod           sync.RWMutex
 	lockCountProjectsByUserID                sync.RWMutex
 	lockCountUsers                           sync.RWMutex
 	lockCreateImage                          sync.RWMutex
+	lockCreateImageVariant                   sync.RWMutex
 	lockCreateJob                            sync.RWMutex
 	lockCreateOriginalImage                  sync.RWMutex
 	lockCreatePlan                           sync.RWMutex
@@ -977,11 +1109,13 @@ type QuerierMock struct {
 	lockDeleteProjectByUserID                sync.RWMutex
 	lockDeleteStuckQueuedImages              sync.RWMutex
 	lockDeleteSubscriptionByStripeID         sync.RWMutex
+	lockDeleteUnreferencedOriginalImage      sync.RWMutex
 	lockDeleteUser                           sync.RWMutex
 	lockFailJob                              sync.RWMutex
 	lockGetAllProjects                       sync.RWMutex
 	lockGetImageByID                         sync.RWMutex
 	lockGetImagesByProjectID                 sync.RWMutex
+	lockGetInvoiceByIDForUser                sync.RWMutex
 	lockGetInvoiceByStripeID                 sync.RWMutex
 	lockGetJobByID                           sync.RWMutex
 	lockGetJobsByImageID                     sync.RWMutex
@@ -994,7 +1128,10 @@ type QuerierMock struct {
 	lockGetPlanByCode                        sync.RWMutex
 	lockGetPlanByPriceID                     sync.RWMutex
 	lockGetProcessedEventByStripeID          sync.RWMutex
+	lockGetProjectBillingUserID              sync.RWMutex
 	lockGetProjectByID                       sync.RWMutex
+	lockGetProjectByIDForMember              sync.RWMutex
+	lockGetProjectsByOrgID                   sync.RWMutex
 	lockGetProjectsByUserID                  sync.RWMutex
 	lockGetSubscriptionByStripeID            sync.RWMutex
 	lockGetUserActivePlan                    sync.RWMutex
@@ -1012,6 +1149,9 @@ type QuerierMock struct {
 	lockListSubscriptionsByUserID            sync.RWMutex
 	lockListSubscriptionsByUserIDAndStatuses sync.RWMutex
 	lockListUsers                            sync.RWMutex
+	lockSearchProjectsByUserID               sync.RWMutex
+	lockSetProjectLockedByUserID             sync.RWMutex
+	lockSetProjectOrg                        sync.RWMutex
 	lockSoftDeleteImage                      sync.RWMutex
 	lockStartJob                             sync.RWMutex
 	lockUpdateImageStatus                    sync.RWMutex
@@ -1029,6 +1169,42 @@ type QuerierMock struct {
 	lockUpsertSubscriptionByStripeID         sync.RWMutex
 }
 
+// ClaimProcessedEvent calls ClaimProcessedEventFunc.
+func (mock *QuerierMock) ClaimProcessedEvent(ctx context.Context, arg ClaimProcessedEventParams) (int64, error) {
+	if mock.ClaimProcessedEventFunc == nil {
+		panic("QuerierMock.ClaimProcessedEventFunc: method is nil but Querier.ClaimProcessedEvent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg ClaimProcessedEventParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockClaimProcessedEvent.Lock()
+	mock.calls.ClaimProcessedEvent = append(mock.calls.ClaimProcessedEvent, callInfo)
+	mock.lockClaimProcessedEvent.Unlock()
+	return mock.ClaimProcessedEventFunc(ctx, arg)
+}
+
+// ClaimProcessedEventCalls gets all the calls that were made to ClaimProcessedEvent.
+// Check the length with:
+//
+//	len(mockedQuerier.ClaimProcessedEventCalls())
+func (mock *QuerierMock) ClaimProcessedEventCalls() []struct {
+	Ctx context.Context
+	Arg ClaimProcessedEventParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg ClaimProcessedEventParams
+	}
+	mock.lockClaimProcessedEvent.RLock()
+	calls = mock.calls.ClaimProcessedEvent
+	mock.lockClaimProcessedEvent.RUnlock()
+	return calls
+}
+
 // CompleteJob calls CompleteJobFunc.
 func (mock *QuerierMock) CompleteJob(ctx context.Context, id pgtype.UUID) (*Job, error) {
 	if mock.CompleteJobFunc == nil {
@@ -1205,6 +1381,42 @@ func (mock *QuerierMock) CreateImageCalls() []struct {
 	return calls
 }
 
+// CreateImageVariant calls CreateImageVariantFunc.
+func (mock *QuerierMock) CreateImageVariant(ctx context.Context, arg CreateImageVariantParams) (*CreateImageVariantRow, error) {
+	if mock.CreateImageVariantFunc == nil {
+		panic("QuerierMock.CreateImageVariantFunc: method is nil but Querier.CreateImageVariant was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg CreateImageVariantParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockCreateImageVariant.Lock()
+	mock.calls.CreateImageVariant = append(mock.calls.CreateImageVariant, callInfo)
+	mock.lockCreateImageVariant.Unlock()
+	return mock.CreateImageVariantFunc(ctx, arg)
+}
+
+// CreateImageVariantCalls gets all the calls that were made to CreateImageVariant.
+// Check the length with:
+//
+//	len(mockedQuerier.CreateImageVariantCalls())
+func (mock *QuerierMock) CreateImageVariantCalls() []struct {
+	Ctx context.Context
+	Arg CreateImageVariantParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg CreateImageVariantParams
+	}
+	mock.lockCreateImageVariant.RLock()
+	calls = mock.calls.CreateImageVariant
+	mock.lockCreateImageVariant.RUnlock()
+	return calls
+}
+
 // CreateJob calls CreateJobFunc.
 func (mock *QuerierMock) CreateJob(ctx context.Context, arg CreateJobParams) (*Job, error) {
 	if mock.CreateJobFunc == nil {
@@ -1422,7 +1634,7 @@ func (mock *QuerierMock) CreateUserCalls() []struct {
 }
 
 // DecrementReferenceCount calls DecrementReferenceCountFunc.
-func (mock *QuerierMock) DecrementReferenceCount(ctx context.Context, id pgtype.UUID) error {
+func (mock *QuerierMock) DecrementReferenceCount(ctx context.Context, id pgtype.UUID) (int32, error) {
 	if mock.DecrementReferenceCountFunc == nil {
 		panic("QuerierMock.DecrementReferenceCountFunc: method is nil but Querier.DecrementReferenceCount was just called")
 	}
@@ -1817,6 +2029,42 @@ func (mock *QuerierMock) DeleteSubscriptionByStripeIDCalls() []struct {
 	return calls
 }
 
+// DeleteUnreferencedOriginalImage calls DeleteUnreferencedOriginalImageFunc.
+func (mock *QuerierMock) DeleteUnreferencedOriginalImage(ctx context.Context, id pgtype.UUID) (string, error) {
+	if mock.DeleteUnreferencedOriginalImageFunc == nil {
+		panic("QuerierMock.DeleteUnreferencedOriginalImageFunc: method is nil but Querier.DeleteUnreferencedOriginalImage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDeleteUnreferencedOriginalImage.Lock()
+	mock.calls.DeleteUnreferencedOriginalImage = append(mock.calls.DeleteUnreferencedOriginalImage, callInfo)
+	mock.lockDeleteUnreferencedOriginalImage.Unlock()
+	return mock.DeleteUnreferencedOriginalImageFunc(ctx, id)
+}
+
+// DeleteUnreferencedOriginalImageCalls gets all the calls that were made to DeleteUnreferencedOriginalImage.
+// Check the length with:
+//
+//	len(mockedQuerier.DeleteUnreferencedOriginalImageCalls())
+func (mock *QuerierMock) DeleteUnreferencedOriginalImageCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockDeleteUnreferencedOriginalImage.RLock()
+	calls = mock.calls.DeleteUnreferencedOriginalImage
+	mock.lockDeleteUnreferencedOriginalImage.RUnlock()
+	return calls
+}
+
 // DeleteUser calls DeleteUserFunc.
 func (mock *QuerierMock) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 	if mock.DeleteUserFunc == nil {
@@ -1993,6 +2241,42 @@ func (mock *QuerierMock) GetImagesByProjectIDCalls() []struct {
 	return calls
 }
 
+// GetInvoiceByIDForUser calls GetInvoiceByIDForUserFunc.
+func (mock *QuerierMock) GetInvoiceByIDForUser(ctx context.Context, arg GetInvoiceByIDForUserParams) (*Invoice, error) {
+	if mock.GetInvoiceByIDForUserFunc == nil {
+		panic("QuerierMock.GetInvoiceByIDForUserFunc: method is nil but Querier.GetInvoiceByIDForUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetInvoiceByIDForUserParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetInvoiceByIDForUser.Lock()
+	mock.calls.GetInvoiceByIDForUser = append(mock.calls.GetInvoiceByIDForUser, callInfo)
+	mock.lockGetInvoiceByIDForUser.Unlock()
+	return mock.GetInvoiceByIDForUserFunc(ctx, arg)
+}
+
+// GetInvoiceByIDForUserCalls gets all the calls that were made to GetInvoiceByIDForUser.
+// Check the length with:
+//
+//	len(mockedQuerier.GetInvoiceByIDForUserCalls())
+func (mock *QuerierMock) GetInvoiceByIDForUserCalls() []struct {
+	Ctx context.Context
+	Arg GetInvoiceByIDForUserParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetInvoiceByIDForUserParams
+	}
+	mock.lockGetInvoiceByIDForUser.RLock()
+	calls = mock.calls.GetInvoiceByIDForUser
+	mock.lockGetInvoiceByIDForUser.RUnlock()
+	return calls
+}
+
 // GetInvoiceByStripeID calls GetInvoiceByStripeIDFunc.
 func (mock *QuerierMock) GetInvoiceByStripeID(ctx context.Context, stripeInvoiceID string) (*Invoice, error) {
 	if mock.GetInvoiceByStripeIDFunc == nil {
@@ -2421,6 +2705,42 @@ func (mock *QuerierMock) GetProcessedEventByStripeIDCalls() []struct {
 	return calls
 }
 
+// GetProjectBillingUserID calls GetProjectBillingUserIDFunc.
+func (mock *QuerierMock) GetProjectBillingUserID(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	if mock.GetProjectBillingUserIDFunc == nil {
+		panic("QuerierMock.GetProjectBillingUserIDFunc: method is nil but Querier.GetProjectBillingUserID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetProjectBillingUserID.Lock()
+	mock.calls.GetProjectBillingUserID = append(mock.calls.GetProjectBillingUserID, callInfo)
+	mock.lockGetProjectBillingUserID.Unlock()
+	return mock.GetProjectBillingUserIDFunc(ctx, id)
+}
+
+// GetProjectBillingUserIDCalls gets all the calls that were made to GetProjectBillingUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectBillingUserIDCalls())
+func (mock *QuerierMock) GetProjectBillingUserIDCalls() []struct {
+	Ctx context.Context
+	ID  pgtype.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  pgtype.UUID
+	}
+	mock.lockGetProjectBillingUserID.RLock()
+	calls = mock.calls.GetProjectBillingUserID
+	mock.lockGetProjectBillingUserID.RUnlock()
+	return calls
+}
+
 // GetProjectByID calls GetProjectByIDFunc.
 func (mock *QuerierMock) GetProjectByID(ctx context.Context, id pgtype.UUID) (*GetProjectByIDRow, error) {
 	if mock.GetProjectByIDFunc == nil {
@@ -2457,6 +2777,78 @@ func (mock *QuerierMock) GetProjectByIDCalls() []struct {
 	return calls
 }
 
+// GetProjectByIDForMember calls GetProjectByIDForMemberFunc.
+func (mock *QuerierMock) GetProjectByIDForMember(ctx context.Context, arg GetProjectByIDForMemberParams) (*GetProjectByIDForMemberRow, error) {
+	if mock.GetProjectByIDForMemberFunc == nil {
+		panic("QuerierMock.GetProjectByIDForMemberFunc: method is nil but Querier.GetProjectByIDForMember was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg GetProjectByIDForMemberParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockGetProjectByIDForMember.Lock()
+	mock.calls.GetProjectByIDForMember = append(mock.calls.GetProjectByIDForMember, callInfo)
+	mock.lockGetProjectByIDForMember.Unlock()
+	return mock.GetProjectByIDForMemberFunc(ctx, arg)
+}
+
+// GetProjectByIDForMemberCalls gets all the calls that were made to GetProjectByIDForMember.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectByIDForMemberCalls())
+func (mock *QuerierMock) GetProjectByIDForMemberCalls() []struct {
+	Ctx context.Context
+	Arg GetProjectByIDForMemberParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg GetProjectByIDForMemberParams
+	}
+	mock.lockGetProjectByIDForMember.RLock()
+	calls = mock.calls.GetProjectByIDForMember
+	mock.lockGetProjectByIDForMember.RUnlock()
+	return calls
+}
+
+// GetProjectsByOrgID calls GetProjectsByOrgIDFunc.
+func (mock *QuerierMock) GetProjectsByOrgID(ctx context.Context, orgID pgtype.UUID) ([]*GetProjectsByOrgIDRow, error) {
+	if mock.GetProjectsByOrgIDFunc == nil {
+		panic("QuerierMock.GetProjectsByOrgIDFunc: method is nil but Querier.GetProjectsByOrgID was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		OrgID pgtype.UUID
+	}{
+		Ctx:   ctx,
+		OrgID: orgID,
+	}
+	mock.lockGetProjectsByOrgID.Lock()
+	mock.calls.GetProjectsByOrgID = append(mock.calls.GetProjectsByOrgID, callInfo)
+	mock.lockGetProjectsByOrgID.Unlock()
+	return mock.GetProjectsByOrgIDFunc(ctx, orgID)
+}
+
+// GetProjectsByOrgIDCalls gets all the calls that were made to GetProjectsByOrgID.
+// Check the length with:
+//
+//	len(mockedQuerier.GetProjectsByOrgIDCalls())
+func (mock *QuerierMock) GetProjectsByOrgIDCalls() []struct {
+	Ctx   context.Context
+	OrgID pgtype.UUID
+} {
+	var calls []struct {
+		Ctx   context.Context
+		OrgID pgtype.UUID
+	}
+	mock.lockGetProjectsByOrgID.RLock()
+	calls = mock.calls.GetProjectsByOrgID
+	mock.lockGetProjectsByOrgID.RUnlock()
+	return calls
+}
+
 // GetProjectsByUserID calls GetProjectsByUserIDFunc.
 func (mock *QuerierMock) GetProjectsByUserID(ctx context.Context, userID pgtype.UUID) ([]*GetProjectsByUserIDRow, error) {
 	if mock.GetProjectsByUserIDFunc == nil {
@@ -2746,7 +3138,7 @@ func (mock *QuerierMock) GetUserProfileByIDCalls() []struct {
 }
 
 // IncrementReferenceCount calls IncrementReferenceCountFunc.
-func (mock *QuerierMock) IncrementReferenceCount(ctx context.Context, id pgtype.UUID) error {
+func (mock *QuerierMock) IncrementReferenceCount(ctx context.Context, id pgtype.UUID) (int64, error) {
 	if mock.IncrementReferenceCountFunc == nil {
 		panic("QuerierMock.IncrementReferenceCountFunc: method is nil but Querier.IncrementReferenceCount was just called")
 	}
@@ -3061,6 +3453,114 @@ func (mock *QuerierMock) ListUsersCalls() []struct {
 	return calls
 }
 
+// SearchProjectsByUserID calls SearchProjectsByUserIDFunc.
+func (mock *QuerierMock) SearchProjectsByUserID(ctx context.Context, arg SearchProjectsByUserIDParams) ([]*SearchProjectsByUserIDRow, error) {
+	if mock.SearchProjectsByUserIDFunc == nil {
+		panic("QuerierMock.SearchProjectsByUserIDFunc: method is nil but Querier.SearchProjectsByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg SearchProjectsByUserIDParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockSearchProjectsByUserID.Lock()
+	mock.calls.SearchProjectsByUserID = append(mock.calls.SearchProjectsByUserID, callInfo)
+	mock.lockSearchProjectsByUserID.Unlock()
+	return mock.SearchProjectsByUserIDFunc(ctx, arg)
+}
+
+// SearchProjectsByUserIDCalls gets all the calls that were made to SearchProjectsByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.SearchProjectsByUserIDCalls())
+func (mock *QuerierMock) SearchProjectsByUserIDCalls() []struct {
+	Ctx context.Context
+	Arg SearchProjectsByUserIDParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg SearchProjectsByUserIDParams
+	}
+	mock.lockSearchProjectsByUserID.RLock()
+	calls = mock.calls.SearchProjectsByUserID
+	mock.lockSearchProjectsByUserID.RUnlock()
+	return calls
+}
+
+// SetProjectLockedByUserID calls SetProjectLockedByUserIDFunc.
+func (mock *QuerierMock) SetProjectLockedByUserID(ctx context.Context, arg SetProjectLockedByUserIDParams) (*SetProjectLockedByUserIDRow, error) {
+	if mock.SetProjectLockedByUserIDFunc == nil {
+		panic("QuerierMock.SetProjectLockedByUserIDFunc: method is nil but Querier.SetProjectLockedByUserID was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg SetProjectLockedByUserIDParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockSetProjectLockedByUserID.Lock()
+	mock.calls.SetProjectLockedByUserID = append(mock.calls.SetProjectLockedByUserID, callInfo)
+	mock.lockSetProjectLockedByUserID.Unlock()
+	return mock.SetProjectLockedByUserIDFunc(ctx, arg)
+}
+
+// SetProjectLockedByUserIDCalls gets all the calls that were made to SetProjectLockedByUserID.
+// Check the length with:
+//
+//	len(mockedQuerier.SetProjectLockedByUserIDCalls())
+func (mock *QuerierMock) SetProjectLockedByUserIDCalls() []struct {
+	Ctx context.Context
+	Arg SetProjectLockedByUserIDParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg SetProjectLockedByUserIDParams
+	}
+	mock.lockSetProjectLockedByUserID.RLock()
+	calls = mock.calls.SetProjectLockedByUserID
+	mock.lockSetProjectLockedByUserID.RUnlock()
+	return calls
+}
+
+// SetProjectOrg calls SetProjectOrgFunc.
+func (mock *QuerierMock) SetProjectOrg(ctx context.Context, arg SetProjectOrgParams) (*SetProjectOrgRow, error) {
+	if mock.SetProjectOrgFunc == nil {
+		panic("QuerierMock.SetProjectOrgFunc: method is nil but Querier.SetProjectOrg was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Arg SetProjectOrgParams
+	}{
+		Ctx: ctx,
+		Arg: arg,
+	}
+	mock.lockSetProjectOrg.Lock()
+	mock.calls.SetProjectOrg = append(mock.calls.SetProjectOrg, callInfo)
+	mock.lockSetProjectOrg.Unlock()
+	return mock.SetProjectOrgFunc(ctx, arg)
+}
+
+// SetProjectOrgCalls gets all the calls that were made to SetProjectOrg.
+// Check the length with:
+//
+//	len(mockedQuerier.SetProjectOrgCalls())
+func (mock *QuerierMock) SetProjectOrgCalls() []struct {
+	Ctx context.Context
+	Arg SetProjectOrgParams
+} {
+	var calls []struct {
+		Ctx context.Context
+		Arg SetProjectOrgParams
+	}
+	mock.lockSetProjectOrg.RLock()
+	calls = mock.calls.SetProjectOrg
+	mock.lockSetProjectOrg.RUnlock()
+	return calls
+}
+
 // SoftDeleteImage calls SoftDeleteImageFunc.
 func (mock *QuerierMock) SoftDeleteImage(ctx context.Context, id pgtype.UUID) error {
 	if mock.SoftDeleteImageFunc == nil {
@@ -2,12 +2,18 @@
 -- Count how many images a user created within a specific date range
 -- IMPORTANT: This counts ALL images (including soft-deleted) to prevent gaming the system
 -- Users cannot reduce their usage count by deleting images
+-- Sandbox and live images are counted separately so trials never consume live quota
+-- Images in an org project count against the org's billing user, not the project creator
+-- Extra outputs of a multi-image prediction ride on the job's own image and are not counted
 SELECT COUNT(*)::int
 FROM images i
 JOIN projects p ON i.project_id = p.id
-WHERE p.user_id = $1
+LEFT JOIN organizations o ON o.id = p.org_id
+WHERE COALESCE(o.billing_user_id, p.user_id) = $1
   AND i.created_at >= $2
-  AND i.created_at < $3;
+  AND i.created_at < $3
+  AND i.sandbox = $4
+  AND i.primary_image_id IS NULL;
 
 -- name: GetPlanByCode :one
 -- Get a plan by its code (free, pro, business, etc.)
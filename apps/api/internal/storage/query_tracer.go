@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// callerSkipPrefixes are the packages between a repository and the wire;
+// the first frame outside them is the query's caller.
+var callerSkipPrefixes = []string{
+	"github.com/jackc/pgx/",
+	"github.com/real-staging-ai/api/internal/storage.",
+	"github.com/real-staging-ai/api/internal/storage/queries.",
+}
+
+// QueryTracer is a pgx.QueryTracer that records how long each query takes and
+// how many rows it returned or changed, and logs the ones slower than a
+// threshold. Queries are named after their sqlc "-- name:" annotation, or
+// after the calling function for hand-written SQL, so metrics stay grouped
+// per call site rather than per statement text.
+type QueryTracer struct {
+	slow     time.Duration
+	log      logging.Logger
+	duration metric.Float64Histogram
+	rows     metric.Int64Histogram
+}
+
+// Ensure QueryTracer implements pgx.QueryTracer.
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+// queryTrace is what TraceQueryStart hands to TraceQueryEnd through the context.
+type queryTrace struct {
+	start  time.Time
+	name   string
+	caller string
+	sql    string
+	args   []any
+}
+
+type queryTraceKey struct{}
+
+// NewQueryTracer creates a QueryTracer with its metrics. Queries taking at
+// least slow are logged; zero disables the log.
+func NewQueryTracer(slow time.Duration, log logging.Logger) (*QueryTracer, error) {
+	t := &QueryTracer{slow: slow, log: log}
+	meter := otel.Meter("real-staging-api/database")
+	var err error
+	if t.duration, err = meter.Float64Histogram("db.query.duration",
+		metric.WithDescription("Duration of a database query, by query name"), metric.WithUnit("s")); err != nil {
+		return nil, fmt.Errorf("create duration histogram: %w", err)
+	}
+	if t.rows, err = meter.Int64Histogram("db.query.rows",
+		metric.WithDescription("Rows returned or affected by a database query, by query name")); err != nil {
+		return nil, fmt.Errorf("create rows histogram: %w", err)
+	}
+	return t, nil
+}
+
+// TraceQueryStart notes the start time, name and caller of the query.
+func (t *QueryTracer) TraceQueryStart(
+	ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData,
+) context.Context {
+	fn, caller := queryCaller()
+	name := sqlcQueryName(data.SQL)
+	if name == "" {
+		name = fn
+	}
+	return context.WithValue(ctx, queryTraceKey{}, &queryTrace{
+		start: time.Now(), name: name, caller: caller, sql: data.SQL, args: data.Args,
+	})
+}
+
+// TraceQueryEnd records the query's metrics and logs it when it was slow. For
+// Query it runs once the rows are closed, so the row count is complete.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	qt, ok := ctx.Value(queryTraceKey{}).(*queryTrace)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(qt.start)
+	rows := data.CommandTag.RowsAffected()
+	outcome := "ok"
+	if data.Err != nil {
+		outcome = "error"
+	}
+
+	attrs := metric.WithAttributes(attribute.String("db.query.name", qt.name), attribute.String("outcome", outcome))
+	t.duration.Record(ctx, elapsed.Seconds(), attrs)
+	t.rows.Record(ctx, rows, attrs)
+
+	if t.slow <= 0 || elapsed < t.slow || t.log == nil {
+		return
+	}
+	kv := []any{
+		"query", qt.name,
+		"caller", qt.caller,
+		"duration_ms", elapsed.Milliseconds(),
+		"rows", rows,
+		"sql", compactSQL(qt.sql),
+		"args", redactArgs(qt.args),
+	}
+	if data.Err != nil {
+		kv = append(kv, "error", data.Err)
+	}
+	t.log.Warn(ctx, "slow query", kv...)
+}
+
+// sqlcQueryName returns X from a leading "-- name: X :one" annotation, or ""
+// when the statement has none.
+func sqlcQueryName(sql string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(sql), "-- name: ")
+	if !ok {
+		return ""
+	}
+	if i := strings.IndexAny(rest, " \n"); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+// queryCaller returns the short function name and file:line of the first
+// frame outside pgx and the storage packages.
+func queryCaller() (fn, caller string) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !skipCallerFrame(f.Function) {
+			// "github.com/x/api/internal/image.(*DefaultRepository).Get" -> "image.(*DefaultRepository).Get"
+			return path.Base(f.Function), fmt.Sprintf("%s:%d", path.Base(f.File), f.Line)
+		}
+		if !more {
+			return "unknown", "unknown"
+		}
+	}
+}
+
+func skipCallerFrame(function string) bool {
+	if strings.HasPrefix(function, "runtime.") {
+		return true
+	}
+	for _, p := range callerSkipPrefixes {
+		if strings.HasPrefix(function, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// compactSQL collapses whitespace so a statement fits on one log line.
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// redactArgs replaces bound parameters with their Go types, keeping emails,
+// tokens and other user data out of the logs while still showing the shape
+// of the call.
+func redactArgs(args []any) []string {
+	redacted := make([]string, len(args))
+	for i, a := range args {
+		if a == nil {
+			redacted[i] = "nil"
+			continue
+		}
+		redacted[i] = fmt.Sprintf("%T", a)
+	}
+	return redacted
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestQueryTracer_SlowQueryLog(t *testing.T) {
+	const sql = "-- name: GetUserByAuth0Sub :one\nSELECT id\n  FROM users WHERE auth0_sub = $1"
+
+	tests := []struct {
+		name    string
+		slow    time.Duration
+		elapsed time.Duration
+		err     error
+		wantLog bool
+	}{
+		{name: "success: fast query is not logged", slow: time.Second, elapsed: time.Millisecond},
+		{name: "success: slow query is logged", slow: 10 * time.Millisecond, elapsed: 50 * time.Millisecond, wantLog: true},
+		{
+			name: "success: slow failed query is logged with its error", slow: 10 * time.Millisecond,
+			elapsed: 50 * time.Millisecond, err: errors.New("canceled"), wantLog: true,
+		},
+		{name: "success: zero threshold disables the log", elapsed: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logged []any
+			log := &logging.LoggerMock{
+				WarnFunc: func(ctx context.Context, msg string, keysAndValues ...any) {
+					assert.Equal(t, "slow query", msg)
+					logged = keysAndValues
+				},
+			}
+			tracer, err := NewQueryTracer(tt.slow, log)
+			require.NoError(t, err)
+
+			ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+				SQL: sql, Args: []any{"auth0|secret-user", 42, nil},
+			})
+			ctx.Value(queryTraceKey{}).(*queryTrace).start = time.Now().Add(-tt.elapsed)
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+				CommandTag: pgconn.NewCommandTag("SELECT 1"), Err: tt.err,
+			})
+
+			if !tt.wantLog {
+				assert.Empty(t, log.WarnCalls())
+				return
+			}
+			require.Len(t, log.WarnCalls(), 1)
+			fields := map[string]any{}
+			for i := 0; i+1 < len(logged); i += 2 {
+				fields[logged[i].(string)] = logged[i+1]
+			}
+			assert.Equal(t, "GetUserByAuth0Sub", fields["query"])
+			assert.Equal(t, int64(1), fields["rows"])
+			assert.Equal(t, "-- name: GetUserByAuth0Sub :one SELECT id FROM users WHERE auth0_sub = $1", fields["sql"])
+			assert.Equal(t, []string{"string", "int", "nil"}, fields["args"], "bound values must not be logged")
+			assert.NotContains(t, fmt.Sprint(logged...), "secret-user")
+			if tt.err != nil {
+				assert.Equal(t, tt.err, fields["error"])
+			} else {
+				assert.NotContains(t, fields, "error")
+			}
+		})
+	}
+}
+
+func TestQueryTracer_EndWithoutStart(t *testing.T) {
+	log := &logging.LoggerMock{}
+	tracer, err := NewQueryTracer(time.Nanosecond, log)
+	require.NoError(t, err)
+
+	assert.NotPanics(t, func() {
+		tracer.TraceQueryEnd(context.Background(), nil, pgx.TraceQueryEndData{})
+	})
+}
+
+func TestSQLCQueryName(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{name: "success: sqlc annotation", sql: "-- name: CreateImage :one\nINSERT INTO images", want: "CreateImage"},
+		{name: "success: leading whitespace", sql: "\n\t-- name: ListImages :many\nSELECT 1", want: "ListImages"},
+		{name: "success: hand-written SQL has no name", sql: "SELECT id FROM images WHERE id = $1", want: ""},
+		{name: "success: other comments are not names", sql: "-- lock the row\nSELECT 1 FOR UPDATE", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sqlcQueryName(tt.sql))
+		})
+	}
+}
+
+func TestQueryCaller_SkipsStoragePackages(t *testing.T) {
+	var fn, caller string
+	func() { fn, caller = queryCallerFromHere() }()
+
+	// The test itself lives in package storage, so every frame up to the test
+	// runner is skipped.
+	assert.Equal(t, "testing.tRunner", fn)
+	assert.Contains(t, caller, "testing.go:")
+}
+
+// queryCallerFromHere stands in for TraceQueryStart, whose frame queryCaller skips.
+func queryCallerFromHere() (string, string) {
+	return queryCaller()
+}
@@ -1,6 +1,23 @@
 package storage
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// FileInfo describes a stored object.
+type FileInfo struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// FileListPage is one page of a ListFiles listing.
+type FileListPage struct {
+	Files     []FileInfo
+	NextToken string
+}
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out s3_service_mock.go . S3Service
 
@@ -10,11 +27,24 @@ type S3Service interface {
 	HeadFile(ctx context.Context, fileKey string) (interface{}, error)
 	// DeleteFile deletes a file from S3.
 	DeleteFile(ctx context.Context, fileKey string) error
+	// CopyFile copies an object to a new key within the same bucket.
+	CopyFile(ctx context.Context, srcKey, dstKey string) error
+	// DownloadFile opens the object body for reading. Callers must close it.
+	DownloadFile(ctx context.Context, fileKey string) (io.ReadCloser, error)
+	// ListFiles returns one page of objects under prefix in key order.
+	// Pass the previous page's NextToken to continue; it is empty on the last page.
+	ListFiles(ctx context.Context, prefix, continuationToken string) (*FileListPage, error)
 	// GetFileURL returns the public URL for a file in S3.
 	GetFileURL(fileKey string) string
 	// GeneratePresignedUploadURL generates a presigned URL for uploading a file to S3.
+	// The key is scoped to the user and, when projectID is set, to the project.
 	GeneratePresignedUploadURL(
-		ctx context.Context, userID, filename, contentType string, fileSize int64,
+		ctx context.Context, userID, projectID, filename, contentType string, fileSize int64,
+	) (*PresignedUploadResult, error)
+	// GeneratePresignedMaskUploadURL generates a presigned URL for uploading a
+	// PNG staging mask to the project.
+	GeneratePresignedMaskUploadURL(
+		ctx context.Context, userID, projectID, filename string,
 	) (*PresignedUploadResult, error)
 	// CreateBucket creates the S3 bucket if it doesn't exist.
 	CreateBucket(ctx context.Context) error
@@ -5,6 +5,7 @@ package storage
 
 import (
 	"context"
+	"io"
 	"sync"
 )
 
@@ -18,16 +19,25 @@ var _ S3Service = &S3ServiceMock{}
 //
 //		// make and configure a mocked S3Service
 //		mockedS3Service := &S3ServiceMock{
+//			CopyFileFunc: func(ctx context.Context, srcKey string, dstKey string) error {
+//				panic("mock out the CopyFile method")
+//			},
 //			CreateBucketFunc: func(ctx context.Context) error {
 //				panic("mock out the CreateBucket method")
 //			},
 //			DeleteFileFunc: func(ctx context.Context, fileKey string) error {
 //				panic("mock out the DeleteFile method")
 //			},
+//			DownloadFileFunc: func(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+//				panic("mock out the DownloadFile method")
+//			},
 //			GeneratePresignedGetURLFunc: func(ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string) (string, error) {
 //				panic("mock out the GeneratePresignedGetURL method")
 //			},
-//			GeneratePresignedUploadURLFunc: func(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error) {
+//			GeneratePresignedMaskUploadURLFunc: func(ctx context.Context, userID string, projectID string, filename string) (*PresignedUploadResult, error) {
+//				panic("mock out the GeneratePresignedMaskUploadURL method")
+//			},
+//			GeneratePresignedUploadURLFunc: func(ctx context.Context, userID string, projectID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error) {
 //				panic("mock out the GeneratePresignedUploadURL method")
 //			},
 //			GetFileURLFunc: func(fileKey string) string {
@@ -36,6 +46,9 @@ var _ S3Service = &S3ServiceMock{}
 //			HeadFileFunc: func(ctx context.Context, fileKey string) (interface{}, error) {
 //				panic("mock out the HeadFile method")
 //			},
+//			ListFilesFunc: func(ctx context.Context, prefix string, continuationToken string) (*FileListPage, error) {
+//				panic("mock out the ListFiles method")
+//			},
 //		}
 //
 //		// use mockedS3Service in code that requires S3Service
@@ -43,17 +56,26 @@ var _ S3Service = &S3ServiceMock{}
 //
 //	}
 type S3ServiceMock struct {
+	// CopyFileFunc mocks the CopyFile method.
+	CopyFileFunc func(ctx context.Context, srcKey string, dstKey string) error
+
 	// CreateBucketFunc mocks the CreateBucket method.
 	CreateBucketFunc func(ctx context.Context) error
 
 	// DeleteFileFunc mocks the DeleteFile method.
 	DeleteFileFunc func(ctx context.Context, fileKey string) error
 
+	// DownloadFileFunc mocks the DownloadFile method.
+	DownloadFileFunc func(ctx context.Context, fileKey string) (io.ReadCloser, error)
+
 	// GeneratePresignedGetURLFunc mocks the GeneratePresignedGetURL method.
 	GeneratePresignedGetURLFunc func(ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string) (string, error)
 
+	// GeneratePresignedMaskUploadURLFunc mocks the GeneratePresignedMaskUploadURL method.
+	GeneratePresignedMaskUploadURLFunc func(ctx context.Context, userID string, projectID string, filename string) (*PresignedUploadResult, error)
+
 	// GeneratePresignedUploadURLFunc mocks the GeneratePresignedUploadURL method.
-	GeneratePresignedUploadURLFunc func(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error)
+	GeneratePresignedUploadURLFunc func(ctx context.Context, userID string, projectID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error)
 
 	// GetFileURLFunc mocks the GetFileURL method.
 	GetFileURLFunc func(fileKey string) string
@@ -61,8 +83,20 @@ type S3ServiceMock struct {
 	// HeadFileFunc mocks the HeadFile method.
 	HeadFileFunc func(ctx context.Context, fileKey string) (interface{}, error)
 
+	// ListFilesFunc mocks the ListFiles method.
+	ListFilesFunc func(ctx context.Context, prefix string, continuationToken string) (*FileListPage, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CopyFile holds details about calls to the CopyFile method.
+		CopyFile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SrcKey is the srcKey argument value.
+			SrcKey string
+			// DstKey is the dstKey argument value.
+			DstKey string
+		}
 		// CreateBucket holds details about calls to the CreateBucket method.
 		CreateBucket []struct {
 			// Ctx is the ctx argument value.
@@ -75,6 +109,13 @@ type S3ServiceMock struct {
 			// FileKey is the fileKey argument value.
 			FileKey string
 		}
+		// DownloadFile holds details about calls to the DownloadFile method.
+		DownloadFile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FileKey is the fileKey argument value.
+			FileKey string
+		}
 		// GeneratePresignedGetURL holds details about calls to the GeneratePresignedGetURL method.
 		GeneratePresignedGetURL []struct {
 			// Ctx is the ctx argument value.
@@ -86,12 +127,25 @@ type S3ServiceMock struct {
 			// ContentDisposition is the contentDisposition argument value.
 			ContentDisposition string
 		}
+		// GeneratePresignedMaskUploadURL holds details about calls to the GeneratePresignedMaskUploadURL method.
+		GeneratePresignedMaskUploadURL []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID string
+			// ProjectID is the projectID argument value.
+			ProjectID string
+			// Filename is the filename argument value.
+			Filename string
+		}
 		// GeneratePresignedUploadURL holds details about calls to the GeneratePresignedUploadURL method.
 		GeneratePresignedUploadURL []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// UserID is the userID argument value.
 			UserID string
+			// ProjectID is the projectID argument value.
+			ProjectID string
 			// Filename is the filename argument value.
 			Filename string
 			// ContentType is the contentType argument value.
@@ -111,13 +165,66 @@ type S3ServiceMock struct {
 			// FileKey is the fileKey argument value.
 			FileKey string
 		}
+		// ListFiles holds details about calls to the ListFiles method.
+		ListFiles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Prefix is the prefix argument value.
+			Prefix string
+			// ContinuationToken is the continuationToken argument value.
+			ContinuationToken string
+		}
+	}
+	lockCopyFile                       sync.RWMutex
+	lockCreateBucket                   sync.RWMutex
+	lockDeleteFile                     sync.RWMutex
+	lockDownloadFile                   sync.RWMutex
+	lockGeneratePresignedGetURL        sync.RWMutex
+	lockGeneratePresignedMaskUploadURL sync.RWMutex
+	lockGeneratePresignedUploadURL     sync.RWMutex
+	lockGetFileURL                     sync.RWMutex
+	lockHeadFile                       sync.RWMutex
+	lockListFiles                      sync.RWMutex
+}
+
+// CopyFile calls CopyFileFunc.
+func (mock *S3ServiceMock) CopyFile(ctx context.Context, srcKey string, dstKey string) error {
+	if mock.CopyFileFunc == nil {
+		panic("S3ServiceMock.CopyFileFunc: method is nil but S3Service.CopyFile was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		SrcKey string
+		DstKey string
+	}{
+		Ctx:    ctx,
+		SrcKey: srcKey,
+		DstKey: dstKey,
+	}
+	mock.lockCopyFile.Lock()
+	mock.calls.CopyFile = append(mock.calls.CopyFile, callInfo)
+	mock.lockCopyFile.Unlock()
+	return mock.CopyFileFunc(ctx, srcKey, dstKey)
+}
+
+// CopyFileCalls gets all the calls that were made to CopyFile.
+// Check the length with:
+//
+//	len(mockedS3Service.CopyFileCalls())
+func (mock *S3ServiceMock) CopyFileCalls() []struct {
+	Ctx    context.Context
+	SrcKey string
+	DstKey string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		SrcKey string
+		DstKey string
 	}
-	lockCreateBucket               sync.RWMutex
-	lockDeleteFile                 sync.RWMutex
-	lockGeneratePresignedGetURL    sync.RWMutex
-	lockGeneratePresignedUploadURL sync.RWMutex
-	lockGetFileURL                 sync.RWMutex
-	lockHeadFile                   sync.RWMutex
+	mock.lockCopyFile.RLock()
+	calls = mock.calls.CopyFile
+	mock.lockCopyFile.RUnlock()
+	return calls
 }
 
 // CreateBucket calls CreateBucketFunc.
@@ -188,6 +295,42 @@ func (mock *S3ServiceMock) DeleteFileCalls() []struct {
 	return calls
 }
 
+// DownloadFile calls DownloadFileFunc.
+func (mock *S3ServiceMock) DownloadFile(ctx context.Context, fileKey string) (io.ReadCloser, error) {
+	if mock.DownloadFileFunc == nil {
+		panic("S3ServiceMock.DownloadFileFunc: method is nil but S3Service.DownloadFile was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		FileKey string
+	}{
+		Ctx:     ctx,
+		FileKey: fileKey,
+	}
+	mock.lockDownloadFile.Lock()
+	mock.calls.DownloadFile = append(mock.calls.DownloadFile, callInfo)
+	mock.lockDownloadFile.Unlock()
+	return mock.DownloadFileFunc(ctx, fileKey)
+}
+
+// DownloadFileCalls gets all the calls that were made to DownloadFile.
+// Check the length with:
+//
+//	len(mockedS3Service.DownloadFileCalls())
+func (mock *S3ServiceMock) DownloadFileCalls() []struct {
+	Ctx     context.Context
+	FileKey string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		FileKey string
+	}
+	mock.lockDownloadFile.RLock()
+	calls = mock.calls.DownloadFile
+	mock.lockDownloadFile.RUnlock()
+	return calls
+}
+
 // GeneratePresignedGetURL calls GeneratePresignedGetURLFunc.
 func (mock *S3ServiceMock) GeneratePresignedGetURL(ctx context.Context, fileKey string, expiresInSeconds int64, contentDisposition string) (string, error) {
 	if mock.GeneratePresignedGetURLFunc == nil {
@@ -232,20 +375,66 @@ func (mock *S3ServiceMock) GeneratePresignedGetURLCalls() []struct {
 	return calls
 }
 
+// GeneratePresignedMaskUploadURL calls GeneratePresignedMaskUploadURLFunc.
+func (mock *S3ServiceMock) GeneratePresignedMaskUploadURL(ctx context.Context, userID string, projectID string, filename string) (*PresignedUploadResult, error) {
+	if mock.GeneratePresignedMaskUploadURLFunc == nil {
+		panic("S3ServiceMock.GeneratePresignedMaskUploadURLFunc: method is nil but S3Service.GeneratePresignedMaskUploadURL was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    string
+		ProjectID string
+		Filename  string
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		ProjectID: projectID,
+		Filename:  filename,
+	}
+	mock.lockGeneratePresignedMaskUploadURL.Lock()
+	mock.calls.GeneratePresignedMaskUploadURL = append(mock.calls.GeneratePresignedMaskUploadURL, callInfo)
+	mock.lockGeneratePresignedMaskUploadURL.Unlock()
+	return mock.GeneratePresignedMaskUploadURLFunc(ctx, userID, projectID, filename)
+}
+
+// GeneratePresignedMaskUploadURLCalls gets all the calls that were made to GeneratePresignedMaskUploadURL.
+// Check the length with:
+//
+//	len(mockedS3Service.GeneratePresignedMaskUploadURLCalls())
+func (mock *S3ServiceMock) GeneratePresignedMaskUploadURLCalls() []struct {
+	Ctx       context.Context
+	UserID    string
+	ProjectID string
+	Filename  string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    string
+		ProjectID string
+		Filename  string
+	}
+	mock.lockGeneratePresignedMaskUploadURL.RLock()
+	calls = mock.calls.GeneratePresignedMaskUploadURL
+	mock.lockGeneratePresignedMaskUploadURL.RUnlock()
+	return calls
+}
+
 // GeneratePresignedUploadURL calls GeneratePresignedUploadURLFunc.
-func (mock *S3ServiceMock) GeneratePresignedUploadURL(ctx context.Context, userID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error) {
+func (mock *S3ServiceMock) GeneratePresignedUploadURL(ctx context.Context, userID string, projectID string, filename string, contentType string, fileSize int64) (*PresignedUploadResult, error) {
 	if mock.GeneratePresignedUploadURLFunc == nil {
 		panic("S3ServiceMock.GeneratePresignedUploadURLFunc: method is nil but S3Service.GeneratePresignedUploadURL was just called")
 	}
 	callInfo := struct {
 		Ctx         context.Context
 		UserID      string
+		ProjectID   string
 		Filename    string
 		ContentType string
 		FileSize    int64
 	}{
 		Ctx:         ctx,
 		UserID:      userID,
+		ProjectID:   projectID,
 		Filename:    filename,
 		ContentType: contentType,
 		FileSize:    fileSize,
@@ -253,7 +442,7 @@ func (mock *S3ServiceMock) GeneratePresignedUploadURL(ctx context.Context, userI
 	mock.lockGeneratePresignedUploadURL.Lock()
 	mock.calls.GeneratePresignedUploadURL = append(mock.calls.GeneratePresignedUploadURL, callInfo)
 	mock.lockGeneratePresignedUploadURL.Unlock()
-	return mock.GeneratePresignedUploadURLFunc(ctx, userID, filename, contentType, fileSize)
+	return mock.GeneratePresignedUploadURLFunc(ctx, userID, projectID, filename, contentType, fileSize)
 }
 
 // GeneratePresignedUploadURLCalls gets all the calls that were made to GeneratePresignedUploadURL.
@@ -263,6 +452,7 @@ func (mock *S3ServiceMock) GeneratePresignedUploadURL(ctx context.Context, userI
 func (mock *S3ServiceMock) GeneratePresignedUploadURLCalls() []struct {
 	Ctx         context.Context
 	UserID      string
+	ProjectID   string
 	Filename    string
 	ContentType string
 	FileSize    int64
@@ -270,6 +460,7 @@ func (mock *S3ServiceMock) GeneratePresignedUploadURLCalls() []struct {
 	var calls []struct {
 		Ctx         context.Context
 		UserID      string
+		ProjectID   string
 		Filename    string
 		ContentType string
 		FileSize    int64
@@ -347,3 +538,43 @@ func (mock *S3ServiceMock) HeadFileCalls() []struct {
 	mock.lockHeadFile.RUnlock()
 	return calls
 }
+
+// ListFiles calls ListFilesFunc.
+func (mock *S3ServiceMock) ListFiles(ctx context.Context, prefix string, continuationToken string) (*FileListPage, error) {
+	if mock.ListFilesFunc == nil {
+		panic("S3ServiceMock.ListFilesFunc: method is nil but S3Service.ListFiles was just called")
+	}
+	callInfo := struct {
+		Ctx               context.Context
+		Prefix            string
+		ContinuationToken string
+	}{
+		Ctx:               ctx,
+		Prefix:            prefix,
+		ContinuationToken: continuationToken,
+	}
+	mock.lockListFiles.Lock()
+	mock.calls.ListFiles = append(mock.calls.ListFiles, callInfo)
+	mock.lockListFiles.Unlock()
+	return mock.ListFilesFunc(ctx, prefix, continuationToken)
+}
+
+// ListFilesCalls gets all the calls that were made to ListFiles.
+// Check the length with:
+//
+//	len(mockedS3Service.ListFilesCalls())
+func (mock *S3ServiceMock) ListFilesCalls() []struct {
+	Ctx               context.Context
+	Prefix            string
+	ContinuationToken string
+} {
+	var calls []struct {
+		Ctx               context.Context
+		Prefix            string
+		ContinuationToken string
+	}
+	mock.lockListFiles.RLock()
+	calls = mock.calls.ListFiles
+	mock.lockListFiles.RUnlock()
+	return calls
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// TxBeginner is implemented by a Database that can start transactions.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// InTx runs fn with a Database whose statements all belong to one transaction,
+// committing it when fn returns nil and rolling it back otherwise. A db that
+// cannot start transactions, such as a test fake, runs fn on db itself.
+func InTx(ctx context.Context, db Database, fn func(tx Database) error) error {
+	beginner, ok := db.(TxBeginner)
+	if !ok {
+		return fn(db)
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back a committed transaction is a no-op.
+	defer func() { _ = tx.Rollback(context.WithoutCancel(ctx)) }()
+
+	if err := fn(&txDatabase{tx: tx, pool: db.Pool()}); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// txDatabase is the Database handed to an InTx callback.
+type txDatabase struct {
+	tx   pgx.Tx
+	pool PgxPool
+}
+
+// Close is a no-op; the transaction ends when InTx returns.
+func (d *txDatabase) Close() {}
+
+// Pool returns the pool the transaction was started on. Statements run on it
+// directly are outside the transaction.
+func (d *txDatabase) Pool() PgxPool { return d.pool }
+
+func (d *txDatabase) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return d.tx.QueryRow(ctx, sql, args...)
+}
+
+func (d *txDatabase) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return d.tx.Query(ctx, sql, args...)
+}
+
+func (d *txDatabase) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return d.tx.Exec(ctx, sql, args...)
+}
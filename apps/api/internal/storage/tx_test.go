@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInTx(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: commits when fn succeeds", func(t *testing.T) {
+		pool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer pool.Close()
+		db := &DefaultDatabase{pool: pool}
+
+		pool.ExpectBegin()
+		pool.ExpectExec("UPDATE a").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		pool.ExpectExec("UPDATE b").WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		pool.ExpectCommit()
+
+		err = InTx(ctx, db, func(tx Database) error {
+			assert.Equal(t, db.Pool(), tx.Pool())
+			if _, err := tx.Exec(ctx, "UPDATE a"); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, "UPDATE b")
+			return err
+		})
+
+		require.NoError(t, err)
+		assert.NoError(t, pool.ExpectationsWereMet())
+	})
+
+	t.Run("fail: rolls back when fn fails", func(t *testing.T) {
+		pool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer pool.Close()
+
+		pool.ExpectBegin()
+		pool.ExpectExec("UPDATE a").WillReturnError(errors.New("boom"))
+		pool.ExpectRollback()
+
+		err = InTx(ctx, &DefaultDatabase{pool: pool}, func(tx Database) error {
+			_, err := tx.Exec(ctx, "UPDATE a")
+			return err
+		})
+
+		assert.EqualError(t, err, "boom")
+		assert.NoError(t, pool.ExpectationsWereMet())
+	})
+
+	t.Run("fail: begin error", func(t *testing.T) {
+		pool, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer pool.Close()
+
+		pool.ExpectBegin().WillReturnError(errors.New("no connection"))
+
+		called := false
+		err = InTx(ctx, &DefaultDatabase{pool: pool}, func(Database) error {
+			called = true
+			return nil
+		})
+
+		assert.ErrorContains(t, err, "failed to begin transaction")
+		assert.False(t, called)
+	})
+
+	t.Run("success: runs fn directly without transaction support", func(t *testing.T) {
+		db := &DatabaseMock{}
+
+		var got Database
+		err := InTx(ctx, db, func(tx Database) error {
+			got = tx
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Same(t, db, got)
+	})
+}
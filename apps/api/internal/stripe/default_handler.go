@@ -2,10 +2,8 @@ package stripe
 
 import (
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,19 +14,36 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/dunning"
+	"github.com/real-staging-ai/api/internal/events"
 	"github.com/real-staging-ai/api/internal/logging"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/user"
+	"github.com/real-staging-ai/api/webhookauth"
 )
 
 // DefaultHandler handles Stripe webhooks and related event processing.
 type DefaultHandler struct {
-	db storage.Database
+	db     storage.Database
+	bus    events.Bus
+	replay webhookauth.ReplayCache
+	// outbox, when set, collects events until the transaction that produced
+	// them commits instead of publishing them on bus straight away.
+	outbox *[]events.Event
 }
 
 // NewDefaultHandler constructs a Stripe DefaultHandler.
-func NewDefaultHandler(db storage.Database) *DefaultHandler {
-	return &DefaultHandler{db: db}
+func NewDefaultHandler(db storage.Database, bus events.Bus) *DefaultHandler {
+	return &DefaultHandler{db: db, bus: bus}
+}
+
+// NewDefaultHandlerWithReplayCache constructs a Stripe DefaultHandler that
+// rejects a signed webhook request it has already accepted.
+func NewDefaultHandlerWithReplayCache(
+	db storage.Database, bus events.Bus, replay webhookauth.ReplayCache,
+) *DefaultHandler {
+	return &DefaultHandler{db: db, bus: bus, replay: replay}
 }
 
 // errorResponse is a simple JSON error envelope for handler responses.
@@ -90,9 +105,27 @@ func (h *DefaultHandler) Webhook(c echo.Context) error {
 	}
 	// Verify signature when a secret is configured
 	if webhookSecret != "" {
-		stripeSignature := c.Request().Header.Get("Stripe-Signature")
-		if err := verifyStripeSignature(body, stripeSignature, webhookSecret, 5*time.Minute, time.Now); err != nil {
+		verifier := webhookauth.NewVerifier(webhookauth.Stripe, []string{
+			webhookSecret,
+			// Accepted until every delivery signed before a secret rotation has arrived
+			os.Getenv("STRIPE_WEBHOOK_SECRET_PREVIOUS"),
+			// Sandbox accounts bill through Stripe test mode, whose webhook endpoint signs with its own secret
+			os.Getenv("STRIPE_TEST_WEBHOOK_SECRET"),
+		}, webhookauth.Options{Tolerance: webhookTolerance(), Replay: h.replay})
+		if err := verifier.Verify(ctx, c.Request().Header, body); err != nil {
 			log.Error(ctx, fmt.Sprintf("Stripe signature verification failed: %v", err))
+			switch {
+			case errors.Is(err, webhookauth.ErrReplayed):
+				return c.JSON(http.StatusConflict, errorResponse{
+					Error:   "conflict",
+					Message: "Webhook request already received",
+				})
+			case errors.Is(err, webhookauth.ErrReplayCacheUnavailable):
+				return c.JSON(http.StatusServiceUnavailable, errorResponse{
+					Error:   "service_unavailable",
+					Message: "Unable to verify webhook",
+				})
+			}
 			return c.JSON(http.StatusUnauthorized, errorResponse{
 				Error:   "unauthorized",
 				Message: "Invalid webhook signature",
@@ -113,6 +146,14 @@ func (h *DefaultHandler) Webhook(c echo.Context) error {
 		})
 	}
 
+	if event.ID == "" {
+		log.Error(ctx, "Stripe webhook event has no ID")
+		return c.JSON(http.StatusBadRequest, errorResponse{
+			Error:   "bad_request",
+			Message: "Missing event ID",
+		})
+	}
+
 	log.Error(ctx, fmt.Sprintf("Received Stripe webhook event: %s (ID: %s)", event.Type, event.ID))
 
 	// Idempotency check: ensure the event hasn't already been processed
@@ -131,106 +172,91 @@ func (h *DefaultHandler) Webhook(c echo.Context) error {
 		})
 	}
 
-	// Handle different event types
-	switch event.Type {
-	case "checkout.session.completed":
-		if err := h.handleCheckoutSessionCompleted(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling checkout.session.completed: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
+	// Apply the event's changes in one transaction and announce them once it commits,
+	// so a failure leaves nothing half-written for Stripe's retry to trip over. The
+	// event is recorded as processed in the same transaction: a delivery racing this
+	// one waits for it and then finds the event taken, while a rolled-back attempt
+	// leaves it free for the retry.
+	var outbox []events.Event
+	var duplicate bool
+	err = storage.InTx(c.Request().Context(), h.db, func(tx storage.Database) error {
+		txh := &DefaultHandler{db: tx, bus: h.bus, outbox: &outbox}
+		claimed, err := txh.claimStripeEvent(c.Request().Context(), event.ID, event.Type, body)
+		if err != nil {
+			return err
 		}
-
-	case "customer.subscription.created":
-		if err := h.handleSubscriptionCreated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.subscription.created: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
+		if !claimed {
+			duplicate = true
+			return nil
 		}
+		return txh.dispatch(c.Request().Context(), &event)
+	})
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf("Error handling %s: %v", event.Type, err))
+		return c.JSON(http.StatusInternalServerError, errorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to process webhook",
+		})
+	}
+	if duplicate {
+		return c.JSON(http.StatusOK, map[string]string{
+			"status": "duplicate",
+		})
+	}
+	for _, e := range outbox {
+		h.publish(c.Request().Context(), e)
+	}
 
-	case "customer.subscription.updated":
-		if err := h.handleSubscriptionUpdated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.subscription.updated: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+	// Return 200 to acknowledge receipt of the webhook
+	return c.JSON(http.StatusOK, map[string]string{
+		"status": "received",
+	})
+}
 
-	case "customer.subscription.deleted":
-		if err := h.handleSubscriptionDeleted(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.subscription.deleted: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
+// ---------------------------- Event Handlers ----------------------------
 
+// dispatch routes event to the handler for its type. Unhandled types are logged and ignored.
+func (h *DefaultHandler) dispatch(ctx context.Context, event *StripeEvent) error {
+	switch event.Type {
+	case "checkout.session.completed":
+		return h.handleCheckoutSessionCompleted(ctx, event)
+	case "customer.subscription.created":
+		return h.handleSubscriptionCreated(ctx, event)
+	case "customer.subscription.updated":
+		return h.handleSubscriptionUpdated(ctx, event)
+	case "customer.subscription.deleted":
+		return h.handleSubscriptionDeleted(ctx, event)
 	case "customer.created":
-		if err := h.handleCustomerCreated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.created: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
-
+		return h.handleCustomerCreated(ctx, event)
 	case "customer.updated":
-		if err := h.handleCustomerUpdated(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.updated: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
-
+		return h.handleCustomerUpdated(ctx, event)
 	case "customer.deleted":
-		if err := h.handleCustomerDeleted(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling customer.deleted: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
-
+		return h.handleCustomerDeleted(ctx, event)
+	case "invoice.paid":
+		return h.handleInvoicePaid(ctx, event)
 	case "invoice.payment_succeeded":
-		if err := h.handleInvoicePaymentSucceeded(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling invoice.payment_succeeded: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
-
+		return h.handleInvoicePaymentSucceeded(ctx, event)
 	case "invoice.payment_failed":
-		if err := h.handleInvoicePaymentFailed(c.Request().Context(), &event); err != nil {
-			log.Error(ctx, fmt.Sprintf("Error handling invoice.payment_failed: %v", err))
-			return c.JSON(http.StatusInternalServerError, errorResponse{
-				Error:   "internal_server_error",
-				Message: "Failed to process webhook",
-			})
-		}
-
+		return h.handleInvoicePaymentFailed(ctx, event)
 	default:
-		log.Error(ctx, fmt.Sprintf("Unhandled webhook event type: %s", event.Type))
+		logging.Default().Error(ctx, fmt.Sprintf("Unhandled webhook event type: %s", event.Type))
+		return nil
 	}
+}
 
-	// Mark event as processed (idempotency scaffold). If this fails, log and still acknowledge.
-	if err := h.markStripeEventProcessed(c.Request().Context(), event.ID, event.Type, body); err != nil {
-		log.Error(ctx, fmt.Sprintf("Failed to mark Stripe event processed: %v", err))
+// publish announces a persisted change, holding it back in the outbox while
+// h runs inside a transaction.
+func (h *DefaultHandler) publish(ctx context.Context, event events.Event) {
+	if h.outbox != nil {
+		*h.outbox = append(*h.outbox, event)
+		return
 	}
-
-	// Return 200 to acknowledge receipt of the webhook
-	return c.JSON(http.StatusOK, map[string]string{
-		"status": "received",
-	})
+	if h.bus == nil {
+		return
+	}
+	_ = h.bus.Publish(ctx, event)
 }
 
-// ---------------------------- Event Handlers ----------------------------
-
 // handleCheckoutSessionCompleted processes successful checkout sessions.
 func (h *DefaultHandler) handleCheckoutSessionCompleted(ctx context.Context, event *StripeEvent) error {
 	log := logging.Default()
@@ -245,22 +271,26 @@ func (h *DefaultHandler) handleCheckoutSessionCompleted(ctx context.Context, eve
 		return nil
 	}
 
+	sessionID, _ := sessionData["id"].(string)
 	customerID, _ := sessionData["customer"].(string)
 	paymentStatus, _ := sessionData["payment_status"].(string)
 	clientReferenceID, _ := sessionData["client_reference_id"].(string)
+	mode, _ := sessionData["mode"].(string)
 
 	log.Error(ctx, fmt.Sprintf("Checkout completed - Customer: %s, Payment Status: %s, Reference: %s",
 		customerID, paymentStatus, clientReferenceID))
 
 	// Link Stripe customer to a user by client_reference_id (Auth0 sub or internal user ref)
+	var userID string
 	if clientReferenceID != "" && customerID != "" {
 		userRepo := user.NewDefaultRepository(h.db)
 		u, err := userRepo.GetByAuth0Sub(ctx, clientReferenceID)
 		if err == nil {
+			userID = u.ID.String()
 			// Only set stripe_customer_id if it's not already set
 			if !u.StripeCustomerID.Valid || u.StripeCustomerID.String == "" {
-				if _, err := userRepo.UpdateStripeCustomerID(ctx, u.ID.String(), customerID); err != nil {
-					log.Error(ctx, fmt.Sprintf("Failed to update user's Stripe customer ID: %v", err))
+				if _, err := userRepo.UpdateStripeCustomerID(ctx, userID, customerID); err != nil {
+					return fmt.Errorf("failed to update user's Stripe customer ID: %w", err)
 				}
 			}
 		} else {
@@ -271,12 +301,63 @@ func (h *DefaultHandler) handleCheckoutSessionCompleted(ctx context.Context, eve
 		}
 	}
 
+	// One-time payments are credit pack purchases; their credits are added once paid.
+	if mode == "payment" && paymentStatus == "paid" {
+		buyerID, err := h.addPurchasedCredits(ctx, sessionData)
+		if err != nil {
+			return err
+		}
+		if buyerID != "" {
+			userID = buyerID
+		}
+	}
+
+	if userID != "" && sessionID != "" {
+		h.publish(ctx, events.CheckoutCompleted{
+			UserID:                  userID,
+			StripeCheckoutSessionID: sessionID,
+			Mode:                    mode,
+			OccurredAt:              time.Now().UTC(),
+		})
+	}
 	return nil
 }
 
+// addPurchasedCredits adds the credits of a paid credit pack Checkout Session
+// and returns the buyer's user ID, or "" when the session is not a credit pack.
+// Stripe may deliver the event more than once; the session is only credited once.
+func (h *DefaultHandler) addPurchasedCredits(ctx context.Context, sessionData map[string]interface{}) (string, error) {
+	log := logging.Default()
+
+	sessionID, _ := sessionData["id"].(string)
+	metadata, _ := sessionData["metadata"].(map[string]interface{})
+	userID, _ := metadata[credit.MetadataUserID].(string)
+	pack, _ := metadata[credit.MetadataPack].(string)
+	creditsRaw, _ := metadata[credit.MetadataCredits].(string)
+	if sessionID == "" || userID == "" || pack == "" {
+		return "", nil
+	}
+	credits, err := strconv.ParseInt(creditsRaw, 10, 32)
+	if err != nil || credits <= 0 {
+		log.Error(ctx, fmt.Sprintf("Invalid credits %q on checkout session %s", creditsRaw, sessionID))
+		return "", nil
+	}
+
+	added, err := credit.NewDefaultRepository(h.db).AddPurchase(ctx, userID, int32(credits), sessionID)
+	if err != nil {
+		return "", fmt.Errorf("failed to add purchased credits: %w", err)
+	}
+	if added {
+		log.Info(ctx, fmt.Sprintf("Added %d credits (%s) for user %s from checkout session %s",
+			credits, pack, userID, sessionID))
+	}
+	return userID, nil
+}
+
 // persistSubscription is a helper to persist subscription data to the database.
+// A non-empty status overrides the one in subscriptionData.
 func (h *DefaultHandler) persistSubscription(
-	ctx context.Context, subscriptionData map[string]interface{}, eventType string,
+	ctx context.Context, subscriptionData map[string]interface{}, eventType, status string,
 ) error {
 	log := logging.Default()
 
@@ -287,7 +368,9 @@ func (h *DefaultHandler) persistSubscription(
 
 	customerID, _ := subscriptionData["customer"].(string)
 	subscriptionID, _ := subscriptionData["id"].(string)
-	status, _ := subscriptionData["status"].(string)
+	if status == "" {
+		status, _ = subscriptionData["status"].(string)
+	}
 
 	log.Error(ctx, fmt.Sprintf("Subscription %s - Customer: %s, Subscription: %s, Status: %s",
 		eventType, customerID, subscriptionID, status))
@@ -346,13 +429,26 @@ func (h *DefaultHandler) persistSubscription(
 		cancelAtPeriodEnd = v
 	}
 
-	if _, err := subRepo.UpsertByStripeID(
+	sub, err := subRepo.UpsertByStripeID(
 		ctx, u.ID.String(), subscriptionID, status, priceIDPtr, cpsPtr, cpePtr,
 		cancelAtPtr, canceledAtPtr, cancelAtPeriodEnd,
-	); err != nil {
-		log.Error(ctx, fmt.Sprintf("Failed to upsert subscription (%s): %v", eventType, err))
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert subscription (%s): %w", eventType, err)
+	}
+	// A failed renewal starts the grace period; any other status ends it.
+	if err := dunning.NewDefaultRepository(h.db).Track(ctx, sub.ID.String(), u.ID.String(), status); err != nil {
+		return fmt.Errorf("failed to track grace period (%s): %w", eventType, err)
 	}
 
+	h.publish(ctx, events.SubscriptionChanged{
+		UserID:               u.ID.String(),
+		StripeSubscriptionID: subscriptionID,
+		Status:               status,
+		PriceID:              priceIDPtr,
+		Reason:               eventType,
+		OccurredAt:           time.Now().UTC(),
+	})
 	return nil
 }
 
@@ -362,7 +458,7 @@ func (h *DefaultHandler) handleSubscriptionCreated(ctx context.Context, event *S
 	if !ok {
 		return fmt.Errorf("invalid subscription data")
 	}
-	return h.persistSubscription(ctx, subscriptionData, "created")
+	return h.persistSubscription(ctx, subscriptionData, "created", "")
 }
 
 // handleSubscriptionUpdated processes subscription update events.
@@ -371,160 +467,53 @@ func (h *DefaultHandler) handleSubscriptionUpdated(ctx context.Context, event *S
 	if !ok {
 		return fmt.Errorf("invalid subscription data")
 	}
-	return h.persistSubscription(ctx, subscriptionData, "updated")
+	return h.persistSubscription(ctx, subscriptionData, "updated", "")
 }
 
 // handleSubscriptionDeleted processes subscription cancellation events.
+// Stripe sends the final state (typically "canceled"); it is persisted as canceled.
 func (h *DefaultHandler) handleSubscriptionDeleted(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
-
 	subscriptionData, ok := event.Data["object"].(map[string]interface{})
 	if !ok {
 		return fmt.Errorf("invalid subscription data")
 	}
-	if h.db == nil {
-		// No database configured (e.g., in tests). Skip persistence.
-		return nil
-	}
-
-	customerID, _ := subscriptionData["customer"].(string)
-	subscriptionID, _ := subscriptionData["id"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Subscription deleted - Customer: %s, Subscription: %s",
-		customerID, subscriptionID))
-
-	// Mark subscription as canceled/deactivated
-	subRepo := NewSubscriptionsRepository(h.db)
-	userRepo := user.NewDefaultRepository(h.db)
-	if customerID != "" && subscriptionID != "" {
-		// Stripe sends a final status (typically "canceled"); persist it
-		if u, err := userRepo.GetByStripeCustomerID(ctx, customerID); err == nil {
-			// Extract optional subscription details even on deletion (Stripe often includes final state)
-			var priceIDPtr *string
-			if itemsRaw, ok := subscriptionData["items"].(map[string]interface{}); ok {
-				if dataArr, ok := itemsRaw["data"].([]interface{}); ok && len(dataArr) > 0 {
-					if firstItem, ok := dataArr[0].(map[string]interface{}); ok {
-						if priceRaw, ok := firstItem["price"].(map[string]interface{}); ok {
-							if pid, ok := priceRaw["id"].(string); ok && pid != "" {
-								priceIDPtr = &pid
-							}
-						}
-					}
-				}
-			}
-
-			var (
-				cpsPtr, cpePtr, cancelAtPtr, canceledAtPtr *time.Time
-				cancelAtPeriodEnd                          bool
-			)
-
-			if v, ok := subscriptionData["current_period_start"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				cpsPtr = &t
-			}
-			if v, ok := subscriptionData["current_period_end"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				cpePtr = &t
-			}
-			if v, ok := subscriptionData["cancel_at"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				cancelAtPtr = &t
-			}
-			if v, ok := subscriptionData["canceled_at"].(float64); ok && v > 0 {
-				t := time.Unix(int64(v), 0)
-				canceledAtPtr = &t
-			}
-			if v, ok := subscriptionData["cancel_at_period_end"].(bool); ok {
-				cancelAtPeriodEnd = v
-			}
+	return h.persistSubscription(ctx, subscriptionData, "deleted", "canceled")
+}
 
-			if _, err := subRepo.UpsertByStripeID(
-				ctx, u.ID.String(), subscriptionID, "canceled", priceIDPtr, cpsPtr, cpePtr,
-				cancelAtPtr, canceledAtPtr, cancelAtPeriodEnd,
-			); err != nil {
-				log.Error(ctx, fmt.Sprintf("Failed to upsert subscription (deleted): %v", err))
-			}
-		} else {
-			log.Error(ctx, fmt.Sprintf(
-				"No user found for Stripe customer on subscription.deleted: %s (err=%v)", customerID, err))
-		}
+// handleInvoicePaid processes invoices settled by payment or otherwise (e.g. paid out of band).
+func (h *DefaultHandler) handleInvoicePaid(ctx context.Context, event *StripeEvent) error {
+	invoiceData, ok := event.Data["object"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid invoice data")
 	}
-	return nil
+	return h.persistInvoice(ctx, invoiceData, "paid", "paid")
 }
 
 // handleInvoicePaymentSucceeded processes successful payment events.
 func (h *DefaultHandler) handleInvoicePaymentSucceeded(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
-
 	invoiceData, ok := event.Data["object"].(map[string]interface{})
 	if !ok {
 		return fmt.Errorf("invalid invoice data")
 	}
-	if h.db == nil {
-		// No database configured (e.g., in tests). Skip persistence.
-		return nil
-	}
-
-	invoiceID, _ := invoiceData["id"].(string)
-	customerID, _ := invoiceData["customer"].(string)
-	subscriptionID, _ := invoiceData["subscription"].(string)
-	status, _ := invoiceData["status"].(string)
-	if status == "" {
-		status = "paid"
-	}
-
-	var amountDueI, amountPaidI int32
-	if v, ok := invoiceData["amount_due"].(float64); ok {
-		amountDueI = int32(v)
-	}
-	if v, ok := invoiceData["amount_paid"].(float64); ok {
-		amountPaidI = int32(v)
-	}
-	currency, _ := invoiceData["currency"].(string)
-	invoiceNumber, _ := invoiceData["number"].(string)
-
-	log.Error(ctx, fmt.Sprintf("Invoice payment succeeded - Invoice: %s, Customer: %s, Subscription: %s, AmountPaid: %.2f",
-		invoiceID, customerID, subscriptionID, float64(amountPaidI)/100))
-
-	// Persist invoice
-	if customerID != "" && invoiceID != "" {
-		userRepo := user.NewDefaultRepository(h.db)
-		if u, err := userRepo.GetByStripeCustomerID(ctx, customerID); err == nil {
-			invRepo := NewInvoicesRepository(h.db)
-
-			var subIDPtr, currencyPtr, invNumPtr *string
-			if subscriptionID != "" {
-				subIDPtr = &subscriptionID
-			}
-			if currency != "" {
-				currencyPtr = &currency
-			}
-			if invoiceNumber != "" {
-				invNumPtr = &invoiceNumber
-			}
-
-			if _, err := invRepo.Upsert(
-				ctx, u.ID.String(), invoiceID, subIDPtr, status, amountDueI, amountPaidI, currencyPtr, invNumPtr,
-			); err != nil {
-				log.Error(ctx, fmt.Sprintf("Failed to upsert invoice (payment_succeeded): %v", err))
-			}
-		} else {
-			log.Error(ctx, fmt.Sprintf(
-				"No user found for Stripe customer on invoice.payment_succeeded: %s (err=%v)", customerID, err))
-		}
-	}
-
-	return nil
+	return h.persistInvoice(ctx, invoiceData, "payment_succeeded", "paid")
 }
 
 // handleInvoicePaymentFailed processes failed payment events.
 func (h *DefaultHandler) handleInvoicePaymentFailed(ctx context.Context, event *StripeEvent) error {
-	log := logging.Default()
-
 	invoiceData, ok := event.Data["object"].(map[string]interface{})
 	if !ok {
 		return fmt.Errorf("invalid invoice data")
 	}
+	return h.persistInvoice(ctx, invoiceData, "payment_failed", "failed")
+}
+
+// persistInvoice is a helper to persist invoice data to the database.
+// defaultStatus is stored when the invoice carries no status.
+func (h *DefaultHandler) persistInvoice(
+	ctx context.Context, invoiceData map[string]interface{}, eventType, defaultStatus string,
+) error {
+	log := logging.Default()
+
 	if h.db == nil {
 		// No database configured (e.g., in tests). Skip persistence.
 		return nil
@@ -535,7 +524,7 @@ func (h *DefaultHandler) handleInvoicePaymentFailed(ctx context.Context, event *
 	subscriptionID, _ := invoiceData["subscription"].(string)
 	status, _ := invoiceData["status"].(string)
 	if status == "" {
-		status = "failed"
+		status = defaultStatus
 	}
 
 	var amountDueI, amountPaidI int32
@@ -548,37 +537,48 @@ func (h *DefaultHandler) handleInvoicePaymentFailed(ctx context.Context, event *
 	currency, _ := invoiceData["currency"].(string)
 	invoiceNumber, _ := invoiceData["number"].(string)
 
-	log.Error(ctx, fmt.Sprintf("Invoice payment failed - Invoice: %s, Customer: %s, Subscription: %s",
-		invoiceID, customerID, subscriptionID))
+	log.Error(ctx, fmt.Sprintf("Invoice %s - Invoice: %s, Customer: %s, Subscription: %s, AmountPaid: %.2f",
+		eventType, invoiceID, customerID, subscriptionID, float64(amountPaidI)/100))
 
-	// Persist invoice with failed status
-	if customerID != "" && invoiceID != "" {
-		userRepo := user.NewDefaultRepository(h.db)
-		if u, err := userRepo.GetByStripeCustomerID(ctx, customerID); err == nil {
-			invRepo := NewInvoicesRepository(h.db)
+	if customerID == "" || invoiceID == "" {
+		return nil
+	}
 
-			var subIDPtr, currencyPtr, invNumPtr *string
-			if subscriptionID != "" {
-				subIDPtr = &subscriptionID
-			}
-			if currency != "" {
-				currencyPtr = &currency
-			}
-			if invoiceNumber != "" {
-				invNumPtr = &invoiceNumber
-			}
+	u, err := user.NewDefaultRepository(h.db).GetByStripeCustomerID(ctx, customerID)
+	if err != nil {
+		log.Error(ctx, fmt.Sprintf(
+			"No user found for Stripe customer on invoice.%s: %s (err=%v)", eventType, customerID, err))
+		return nil
+	}
 
-			if _, err := invRepo.Upsert(
-				ctx, u.ID.String(), invoiceID, subIDPtr, status, amountDueI, amountPaidI, currencyPtr, invNumPtr,
-			); err != nil {
-				log.Error(ctx, fmt.Sprintf("Failed to upsert invoice (payment_failed): %v", err))
-			}
-		} else {
-			log.Error(ctx, fmt.Sprintf(
-				"No user found for Stripe customer on invoice.payment_failed: %s (err=%v)", customerID, err))
-		}
+	var subIDPtr, currencyPtr, invNumPtr *string
+	if subscriptionID != "" {
+		subIDPtr = &subscriptionID
+	}
+	if currency != "" {
+		currencyPtr = &currency
+	}
+	if invoiceNumber != "" {
+		invNumPtr = &invoiceNumber
 	}
 
+	if _, err := NewInvoicesRepository(h.db).Upsert(
+		ctx, u.ID.String(), invoiceID, subIDPtr, status, amountDueI, amountPaidI, currencyPtr, invNumPtr,
+	); err != nil {
+		return fmt.Errorf("failed to upsert invoice (%s): %w", eventType, err)
+	}
+
+	h.publish(ctx, events.InvoiceChanged{
+		UserID:               u.ID.String(),
+		StripeInvoiceID:      invoiceID,
+		StripeSubscriptionID: subIDPtr,
+		Status:               status,
+		AmountDue:            amountDueI,
+		AmountPaid:           amountPaidI,
+		Currency:             currencyPtr,
+		Reason:               eventType,
+		OccurredAt:           time.Now().UTC(),
+	})
 	return nil
 }
 
@@ -635,103 +635,35 @@ func (h *DefaultHandler) handleCustomerDeleted(ctx context.Context, event *Strip
 	return nil
 }
 
-// ---------------------------- Signature Helpers ----------------------------
-
-// verifyStripeSignature verifies the Stripe webhook signature.
-// See: https://docs.stripe.com/webhooks/signatures
-func verifyStripeSignature(body []byte, sigHeader, secret string, tolerance time.Duration, now func() time.Time) error {
-	if sigHeader == "" {
-		return fmt.Errorf("missing Stripe-Signature header")
-	}
-
-	ts, v1s, err := parseStripeSignatureHeader(sigHeader)
-	if err != nil {
-		return fmt.Errorf("invalid signature header: %w", err)
-	}
-	if len(v1s) == 0 {
-		return fmt.Errorf("no v1 signatures found")
-	}
-
-	// Enforce timestamp tolerance window
-	t := time.Unix(ts, 0)
-	diff := now().Sub(t)
-	if diff < 0 {
-		diff = -diff
-	}
-	if diff > tolerance {
-		return fmt.Errorf("timestamp outside tolerance window")
-	}
-
-	expected := computeStripeSignature(body, ts, secret)
-
-	// Compare expected signature with any provided v1 (constant-time)
-	for _, sig := range v1s {
-		if hmac.Equal(sig, expected) {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("no matching signature")
-}
-
-// parseStripeSignatureHeader parses "t=timestamp,v1=hex,v1=hex2,..."
-func parseStripeSignatureHeader(header string) (int64, [][]byte, error) {
-	var ts int64
-	var haveTS bool
-	var v1s [][]byte
-
-	for _, part := range strings.Split(header, ",") {
-		part = strings.TrimSpace(part)
-		if strings.HasPrefix(part, "t=") {
-			tStr := strings.TrimPrefix(part, "t=")
-			val, err := strconv.ParseInt(tStr, 10, 64)
-			if err != nil {
-				return 0, nil, fmt.Errorf("invalid timestamp: %w", err)
-			}
-			ts = val
-			haveTS = true
-		} else if strings.HasPrefix(part, "v1=") {
-			v := strings.TrimPrefix(part, "v1=")
-			b, err := hex.DecodeString(v)
-			if err != nil {
-				return 0, nil, fmt.Errorf("invalid v1 signature: %w", err)
-			}
-			v1s = append(v1s, b)
-		}
-	}
-
-	if !haveTS {
-		return 0, nil, fmt.Errorf("missing timestamp")
-	}
-
-	return ts, v1s, nil
-}
-
-// computeStripeSignature computes HMAC-SHA256 over "timestamp.payload"
-func computeStripeSignature(body []byte, ts int64, secret string) []byte {
-	mac := hmac.New(sha256.New, []byte(secret))
-	_, _ = fmt.Fprintf(mac, "%d.", ts)
-	_, _ = mac.Write(body)
-	return mac.Sum(nil)
-}
-
 // isDevLikeEnv reports whether the current process appears to be running in a dev/test environment.
 func isDevLikeEnv() bool {
-	// Check common env markers
+	// The first marker that is set decides; any value other than a dev one,
+	// including an unrecognized one, requires signed webhooks.
 	for _, key := range []string{"APP_ENV", "GO_ENV", "ENV", "NODE_ENV"} {
-		val := strings.ToLower(strings.TrimSpace(os.Getenv(key)))
-		switch val {
-		case "", "dev", "development", "local", "test":
+		switch strings.ToLower(strings.TrimSpace(os.Getenv(key))) {
+		case "":
+			continue
+		case "dev", "development", "local", "test":
 			return true
-		case "prod", "production", "staging":
-			// explicit non-dev markers; continue checking others but prefer non-dev
+		default:
+			return false
 		}
 	}
-	// Heuristic: go test binaries typically end with ".test"
-	if strings.HasSuffix(os.Args[0], ".test") {
-		return true
-	}
-	return false
+	// Unset, APP_ENV defaults to dev
+	return true
+}
+
+// webhookTolerance is how far a delivery's signed timestamp may be from now,
+// from STRIPE_WEBHOOK_TOLERANCE_SECONDS. Zero, the default when unset or
+// invalid, means webhookauth.DefaultTolerance, which matches Stripe's own
+// libraries. Older deliveries are rejected even when correctly signed, which
+// bounds how long a captured request can be replayed.
+func webhookTolerance() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("STRIPE_WEBHOOK_TOLERANCE_SECONDS"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
 }
 
 // ---------------------------- Idempotency Helpers ----------------------------
@@ -747,16 +679,16 @@ func (h *DefaultHandler) alreadyProcessedStripeEvent(ctx context.Context, eventI
 	return repo.IsProcessed(ctx, eventID)
 }
 
-// markStripeEventProcessed records the processed event (idempotency scaffold).
-// Stores the type and raw payload when available.
-func (h *DefaultHandler) markStripeEventProcessed(
+// claimStripeEvent records the event as processed with its type and raw payload,
+// reporting false when another delivery already did. When db is nil (tests),
+// every event is claimed.
+func (h *DefaultHandler) claimStripeEvent(
 	ctx context.Context, eventID, eventType string, payload []byte,
-) error {
+) (bool, error) {
 	if h.db == nil {
 		// No database configured (e.g., in tests). Skip persistence.
-		return nil
+		return true, nil
 	}
 	repo := NewProcessedEventsRepository(h.db)
-	_, err := repo.Upsert(ctx, eventID, &eventType, payload)
-	return err
+	return repo.Claim(ctx, eventID, eventType, payload)
 }
@@ -0,0 +1,249 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v2"
+
+	"github.com/real-staging-ai/api/internal/events"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// txDB is a Database over a pgxmock pool, so webhook handling runs in a transaction.
+type txDB struct {
+	pgxmock.PgxPoolIface
+}
+
+func (d txDB) Pool() storage.PgxPool { return d.PgxPoolIface }
+
+func newLifecycleHandler(t *testing.T) (*DefaultHandler, pgxmock.PgxPoolIface, *[]events.Event) {
+	t.Helper()
+	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
+	pool, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("failed to create pool mock: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	var published []events.Event
+	bus := &events.BusMock{
+		PublishFunc: func(ctx context.Context, event events.Event) error {
+			published = append(published, event)
+			return nil
+		},
+	}
+	return &DefaultHandler{db: txDB{pool}, bus: bus}, pool, &published
+}
+
+func creditPackEvent() []byte {
+	return makeEvent("checkout.session.completed", map[string]any{
+		"id":             "cs_pack",
+		"mode":           "payment",
+		"payment_status": "paid",
+		"metadata": map[string]any{
+			"user_id":     testUserID.String(),
+			"credit_pack": "pack_20",
+			"credits":     "20",
+		},
+	})
+}
+
+// expectClaim expects the webhook transaction to record evt_test as processed,
+// affecting rows rows.
+func expectClaim(pool pgxmock.PgxPoolIface, rows int64) {
+	eventType := pgtype.Text{String: "checkout.session.completed", Valid: true}
+	pool.ExpectExec("INSERT INTO processed_events").WithArgs("evt_test", eventType, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", rows))
+}
+
+func TestWebhook_Lifecycle_CommitsThenPublishes(t *testing.T) {
+	h, pool, published := newLifecycleHandler(t)
+
+	pool.ExpectQuery("processed_events").WithArgs("evt_test").WillReturnError(pgx.ErrNoRows)
+	pool.ExpectBegin()
+	expectClaim(pool, 1)
+	pool.ExpectQuery("credit_ledger").WithArgs(testUserID.String(), int32(20), "cs_pack").
+		WillReturnRows(pgxmock.NewRows([]string{"balance"}).AddRow(int32(20)))
+	pool.ExpectCommit()
+
+	c, rec := newEchoCtx(http.MethodPost, creditPackEvent(), nil)
+	if err := h.Webhook(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	if len(*published) != 1 {
+		t.Fatalf("expected one event, got %v", *published)
+	}
+	got, ok := (*published)[0].(events.CheckoutCompleted)
+	if !ok || got.UserID != testUserID.String() ||
+		got.StripeCheckoutSessionID != "cs_pack" || got.Mode != "payment" {
+		t.Fatalf("unexpected event: %+v", (*published)[0])
+	}
+}
+
+func TestWebhook_Lifecycle_RollsBackOnFailure(t *testing.T) {
+	h, pool, published := newLifecycleHandler(t)
+
+	pool.ExpectQuery("processed_events").WithArgs("evt_test").WillReturnError(pgx.ErrNoRows)
+	pool.ExpectBegin()
+	expectClaim(pool, 1)
+	pool.ExpectQuery("credit_ledger").WithArgs(testUserID.String(), int32(20), "cs_pack").
+		WillReturnError(errors.New("boom"))
+	// The claim is rolled back with the changes, leaving the event to Stripe's retry.
+	pool.ExpectRollback()
+
+	c, rec := newEchoCtx(http.MethodPost, creditPackEvent(), nil)
+	if err := h.Webhook(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 so Stripe retries, got %d", rec.Code)
+	}
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	if len(*published) != 0 {
+		t.Fatalf("expected no events for a rolled back change, got %v", *published)
+	}
+}
+
+// A delivery that passed the processed check while another was applying the same
+// event finds it claimed once that transaction commits, and changes nothing.
+func TestWebhook_Lifecycle_ConcurrentDuplicateSkipped(t *testing.T) {
+	h, pool, published := newLifecycleHandler(t)
+
+	pool.ExpectQuery("processed_events").WithArgs("evt_test").WillReturnError(pgx.ErrNoRows)
+	pool.ExpectBegin()
+	expectClaim(pool, 0)
+	pool.ExpectCommit()
+
+	c, rec := newEchoCtx(http.MethodPost, creditPackEvent(), nil)
+	if err := h.Webhook(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "duplicate") {
+		t.Fatalf("expected 200 duplicate, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if err := pool.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	if len(*published) != 0 {
+		t.Fatalf("expected no events for a duplicate, got %v", *published)
+	}
+}
+
+func Test_handleInvoicePaid_PublishesInvoiceChanged(t *testing.T) {
+	var published []events.Event
+	h := NewDefaultHandler(&simpleDB{}, nil)
+	h.bus = &events.BusMock{
+		PublishFunc: func(ctx context.Context, event events.Event) error {
+			published = append(published, event)
+			return nil
+		},
+	}
+
+	evt := StripeEvent{
+		Type: "invoice.paid",
+		Data: map[string]interface{}{
+			"object": map[string]interface{}{
+				"id":           "in_paid",
+				"customer":     "cus_paid",
+				"subscription": "sub_paid",
+				"amount_due":   float64(2900),
+				"amount_paid":  float64(2900),
+				"currency":     "usd",
+			},
+		},
+	}
+	if err := h.dispatch(context.Background(), &evt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(published) != 1 {
+		t.Fatalf("expected one event, got %v", published)
+	}
+	got, ok := published[0].(events.InvoiceChanged)
+	if !ok || got.UserID != testUserID.String() || got.StripeInvoiceID != "in_paid" ||
+		got.Status != "paid" || got.AmountPaid != 2900 || got.Reason != "paid" {
+		t.Fatalf("unexpected event: %+v", published[0])
+	}
+}
+
+func Test_handleSubscriptionDeleted_PublishesCanceled(t *testing.T) {
+	var published []events.Event
+	h := NewDefaultHandler(&simpleDB{}, nil)
+	h.bus = &events.BusMock{
+		PublishFunc: func(ctx context.Context, event events.Event) error {
+			published = append(published, event)
+			return nil
+		},
+	}
+
+	evt := StripeEvent{
+		Data: map[string]interface{}{
+			"object": map[string]interface{}{"customer": "cus_del", "id": "sub_del", "status": "active"},
+		},
+	}
+	if err := h.handleSubscriptionDeleted(context.Background(), &evt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(published) != 1 {
+		t.Fatalf("expected one event, got %v", published)
+	}
+	got, ok := published[0].(events.SubscriptionChanged)
+	if !ok || got.Status != "canceled" || got.Reason != "deleted" {
+		t.Fatalf("unexpected event: %+v", published[0])
+	}
+}
+
+// execRecorderDB records the statements executed against a simpleDB.
+type execRecorderDB struct {
+	simpleDB
+	execs [][]any
+}
+
+func (d *execRecorderDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	d.execs = append(d.execs, append([]any{sql}, args...))
+	return pgconn.CommandTag{}, nil
+}
+
+func Test_handleSubscriptionUpdated_TracksGracePeriod(t *testing.T) {
+	for _, status := range []string{"past_due", "active"} {
+		t.Run("success: "+status, func(t *testing.T) {
+			db := &execRecorderDB{}
+			h := NewDefaultHandler(db, nil)
+			h.bus = &events.BusMock{PublishFunc: func(ctx context.Context, event events.Event) error { return nil }}
+
+			evt := StripeEvent{
+				Data: map[string]interface{}{
+					"object": map[string]interface{}{"customer": "cus_2", "id": "sub_2", "status": status},
+				},
+			}
+			if err := h.handleSubscriptionUpdated(context.Background(), &evt); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var tracked []any
+			for _, exec := range db.execs {
+				if strings.Contains(exec[0].(string), "subscription_dunning") {
+					tracked = exec[1:]
+				}
+			}
+			want := []any{testUserID.String(), testUserID.String(), status}
+			if len(tracked) != 3 || tracked[0] != want[0] || tracked[1] != want[1] || tracked[2] != want[2] {
+				t.Fatalf("expected the grace period to be tracked with %v, got %v", want, tracked)
+			}
+		})
+	}
+}
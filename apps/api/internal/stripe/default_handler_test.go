@@ -3,21 +3,26 @@ package stripe
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"context"
 	"encoding/json"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/labstack/echo/v4"
 
+	"github.com/real-staging-ai/api/internal/events"
 	"github.com/real-staging-ai/api/internal/storage"
+	"github.com/real-staging-ai/api/webhookauth"
 )
 
 // helper to build Stripe-Signature header string with given timestamp and one or more v1 signatures.
@@ -29,162 +34,14 @@ func makeSigHeader(ts int64, sigs ...[]byte) string {
 	return header
 }
 
-func TestParseStripeSignatureHeader_Success(t *testing.T) {
-	ts := int64(1700000000)
-	// two valid hex signatures: 0x00 and 0xaa
-	header := fmt.Sprintf("t=%d,v1=00,v1=aa", ts)
-
-	gotTS, v1s, err := parseStripeSignatureHeader(header)
+// signStripe computes the v1 signature Stripe would send for body.
+func signStripe(t *testing.T, body []byte, ts int64, secret string) []byte {
+	t.Helper()
+	sig, err := webhookauth.Stripe.Sign(secret, webhookauth.Signature{Timestamp: ts}, body)
 	if err != nil {
-		t.Fatalf("expected no error, got %v", err)
-	}
-	if gotTS != ts {
-		t.Fatalf("expected ts=%d, got %d", ts, gotTS)
-	}
-	if len(v1s) != 2 {
-		t.Fatalf("expected 2 signatures, got %d", len(v1s))
-	}
-	if !bytes.Equal(v1s[0], []byte{0x00}) {
-		t.Fatalf("expected first sig = 00, got %x", v1s[0])
-	}
-	if !bytes.Equal(v1s[1], []byte{0xaa}) {
-		t.Fatalf("expected second sig = aa, got %x", v1s[1])
-	}
-}
-
-func TestParseStripeSignatureHeader_MissingTimestamp(t *testing.T) {
-	header := "v1=00"
-
-	_, _, err := parseStripeSignatureHeader(header)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
-	}
-	if want := "missing timestamp"; err == nil || !contains(err.Error(), want) {
-		t.Fatalf("expected error containing %q, got %v", want, err)
-	}
-}
-
-func TestParseStripeSignatureHeader_InvalidTimestamp(t *testing.T) {
-	header := "t=abc,v1=00"
-
-	_, _, err := parseStripeSignatureHeader(header)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
-	}
-	if want := "invalid timestamp"; !contains(err.Error(), want) {
-		t.Fatalf("expected error containing %q, got %v", want, err)
-	}
-}
-
-func TestParseStripeSignatureHeader_InvalidV1Hex(t *testing.T) {
-	header := "t=1700000000,v1=zz"
-
-	_, _, err := parseStripeSignatureHeader(header)
-	if err == nil {
-		t.Fatalf("expected error, got nil")
-	}
-	if want := "invalid v1 signature"; !contains(err.Error(), want) {
-		t.Fatalf("expected error containing %q, got %v", want, err)
-	}
-}
-
-func TestVerifyStripeSignature_Valid(t *testing.T) {
-	body := []byte(`{"id":"evt_123"}`)
-	secret := "whsec_test"
-	ts := int64(1700000000)
-
-	expected := computeStripeSignature(body, ts, secret)
-	header := makeSigHeader(ts, expected)
-
-	now := func() time.Time { return time.Unix(ts+60, 0) } // within 5 minutes
-	if err := verifyStripeSignature(body, header, secret, 5*time.Minute, now); err != nil {
-		t.Fatalf("expected valid signature, got error: %v", err)
-	}
-}
-
-func TestVerifyStripeSignature_NoHeader(t *testing.T) {
-	body := []byte(`{}`)
-	secret := "whsec_test"
-	now := func() time.Time { return time.Unix(1700000000, 0) }
-
-	err := verifyStripeSignature(body, "", secret, 5*time.Minute, now)
-	if err == nil {
-		t.Fatalf("expected error for missing header, got nil")
-	}
-	if want := "missing Stripe-Signature header"; !contains(err.Error(), want) {
-		t.Fatalf("expected error containing %q, got %v", want, err)
-	}
-}
-
-func TestVerifyStripeSignature_NoV1Provided(t *testing.T) {
-	body := []byte(`{}`)
-	secret := "whsec_test"
-	ts := int64(1700000000)
-	header := fmt.Sprintf("t=%d", ts)
-	now := func() time.Time { return time.Unix(ts, 0) }
-
-	err := verifyStripeSignature(body, header, secret, 5*time.Minute, now)
-	if err == nil {
-		t.Fatalf("expected error when no v1 signatures, got nil")
-	}
-	if want := "no v1 signatures found"; !contains(err.Error(), want) {
-		t.Fatalf("expected error containing %q, got %v", want, err)
-	}
-}
-
-func TestVerifyStripeSignature_MismatchedSignature(t *testing.T) {
-	body := []byte(`{"id":"evt_123"}`)
-	secret := "whsec_test"
-	badSecret := "whsec_other"
-	ts := int64(1700000000)
-
-	// signature computed with wrong secret
-	wrong := computeStripeSignature(body, ts, badSecret)
-	header := makeSigHeader(ts, wrong)
-
-	now := func() time.Time { return time.Unix(ts, 0) }
-	err := verifyStripeSignature(body, header, secret, 5*time.Minute, now)
-	if err == nil {
-		t.Fatalf("expected signature mismatch error, got nil")
-	}
-	if want := "no matching signature"; !contains(err.Error(), want) {
-		t.Fatalf("expected error containing %q, got %v", want, err)
-	}
-}
-
-func TestVerifyStripeSignature_MultipleV1_OneMatches(t *testing.T) {
-	body := []byte(`{"ok":true}`)
-	secret := "whsec_test"
-	ts := int64(1700000000)
-
-	// first sig is bogus but valid hex, second is correct
-	bogus := []byte("bogus-signature-bytes") // arbitrary
-	good := computeStripeSignature(body, ts, secret)
-	header := makeSigHeader(ts, bogus, good)
-
-	now := func() time.Time { return time.Unix(ts, 0) }
-	if err := verifyStripeSignature(body, header, secret, 5*time.Minute, now); err != nil {
-		t.Fatalf("expected success when one of multiple signatures matches, got %v", err)
-	}
-}
-
-func TestVerifyStripeSignature_TimestampOutsideTolerance(t *testing.T) {
-	body := []byte(`{"id":"evt_999"}`)
-	secret := "whsec_test"
-	ts := int64(1700000000)
-
-	sig := computeStripeSignature(body, ts, secret)
-	header := makeSigHeader(ts, sig)
-
-	// now is 10 minutes ahead, tolerance 5 minutes -> should fail
-	now := func() time.Time { return time.Unix(ts, 0).Add(10 * time.Minute) }
-	err := verifyStripeSignature(body, header, secret, 5*time.Minute, now)
-	if err == nil {
-		t.Fatalf("expected timestamp tolerance error, got nil")
-	}
-	if want := "timestamp outside tolerance window"; !contains(err.Error(), want) {
-		t.Fatalf("expected error containing %q, got %v", want, err)
+		t.Fatalf("failed to sign: %v", err)
 	}
+	return sig
 }
 
 // contains is a tiny helper to avoid pulling extra deps in tests.
@@ -202,7 +59,7 @@ func contains(s, substr string) bool {
 
 // ---------------------------- Direct DB-branch handler tests ----------------------------
 
-// simpleDB returns okRow for all QueryRow calls to exercise DB-backed branches.
+// simpleDB returns idRow for all QueryRow calls to exercise DB-backed branches.
 type simpleDB struct{}
 
 func (s *simpleDB) Close() {}
@@ -210,7 +67,22 @@ func (s *simpleDB) Close() {}
 func (s *simpleDB) Pool() storage.PgxPool { return nil }
 
 func (s *simpleDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	return okRow{}
+	return idRow{}
+}
+
+// idRow implements pgx.Row and fills every UUID column with testUserID, so
+// rows scanned from it can be written back.
+type idRow struct{}
+
+var testUserID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+
+func (idRow) Scan(dest ...any) error {
+	for _, d := range dest {
+		if id, ok := d.(*pgtype.UUID); ok {
+			*id = pgtype.UUID{Bytes: testUserID, Valid: true}
+		}
+	}
+	return nil
 }
 
 func (s *simpleDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
@@ -222,7 +94,7 @@ func (s *simpleDB) Exec(ctx context.Context, sql string, args ...interface{}) (p
 }
 
 func Test_handleSubscriptionCreated_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, nil)
 
 	evt := StripeEvent{
 		Data: map[string]interface{}{
@@ -240,7 +112,7 @@ func Test_handleSubscriptionCreated_DB_Branch(t *testing.T) {
 }
 
 func Test_handleSubscriptionUpdated_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, nil)
 
 	evt := StripeEvent{
 		Data: map[string]interface{}{
@@ -258,7 +130,7 @@ func Test_handleSubscriptionUpdated_DB_Branch(t *testing.T) {
 }
 
 func Test_handleSubscriptionDeleted_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, nil)
 
 	evt := StripeEvent{
 		Data: map[string]interface{}{
@@ -275,7 +147,7 @@ func Test_handleSubscriptionDeleted_DB_Branch(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentSucceeded_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, nil)
 
 	evt := StripeEvent{
 		Data: map[string]interface{}{
@@ -298,7 +170,7 @@ func Test_handleInvoicePaymentSucceeded_DB_Branch(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentFailed_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, nil)
 
 	evt := StripeEvent{
 		Data: map[string]interface{}{
@@ -338,7 +210,7 @@ func (u *userNotFoundDB) Exec(ctx context.Context, sql string, args ...interface
 }
 
 func Test_handleCheckoutSessionCompleted_UserNotFound_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": map[string]interface{}{
@@ -355,7 +227,7 @@ func Test_handleCheckoutSessionCompleted_UserNotFound_OK(t *testing.T) {
 }
 
 func Test_handleSubscriptionCreated_UserNotFound_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": map[string]interface{}{
@@ -371,7 +243,7 @@ func Test_handleSubscriptionCreated_UserNotFound_OK(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentSucceeded_UserNotFound_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": map[string]interface{}{
@@ -440,38 +312,43 @@ func (f *fakeDBAlreadyProcessed) Exec(ctx context.Context, sql string, args ...i
 	return pgconn.CommandTag{}, nil
 }
 
-// fakeDBIdemFirstMissingThenUpsertOK simulates IsProcessed=false then Upsert success.
-type fakeDBIdemFirstMissingThenUpsertOK struct {
-	calls int
+// fakeDBIdemClaim reports every event as not yet processed and answers the
+// claim in the webhook transaction with claimRows affected rows or claimErr.
+type fakeDBIdemClaim struct {
+	claimRows int
+	claimErr  error
+	claims    int
 }
 
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Close()                {}
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Pool() storage.PgxPool { return nil }
-func (f *fakeDBIdemFirstMissingThenUpsertOK) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	f.calls++
-	if f.calls == 1 {
-		// First call for GetProcessedEventByStripeID -> not found
-		return errRow{}
-	}
-	// Second call for UpsertProcessedEventByStripeID -> ok
-	return okRow{}
+func (f *fakeDBIdemClaim) Close()                {}
+func (f *fakeDBIdemClaim) Pool() storage.PgxPool { return nil }
+func (f *fakeDBIdemClaim) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	// GetProcessedEventByStripeID -> not found
+	return errRow{}
 }
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Query(
+func (f *fakeDBIdemClaim) Query(
 	ctx context.Context, sql string, args ...interface{},
 ) (pgx.Rows, error) {
 	return nil, nil
 }
-func (f *fakeDBIdemFirstMissingThenUpsertOK) Exec(
+func (f *fakeDBIdemClaim) Exec(
 	ctx context.Context, sql string, args ...interface{},
 ) (pgconn.CommandTag, error) {
-	return pgconn.CommandTag{}, nil
+	if !strings.Contains(sql, "processed_events") {
+		return pgconn.CommandTag{}, nil
+	}
+	f.claims++
+	if f.claimErr != nil {
+		return pgconn.CommandTag{}, f.claimErr
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("INSERT 0 %d", f.claimRows)), nil
 }
 
 // ---------------------------- Webhook tests ----------------------------
 
 func TestWebhook_EmptyBody_BadRequest(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	c, rec := newEchoCtx(http.MethodPost, []byte{}, nil)
 
 	if err := h.Webhook(c); err != nil {
@@ -484,7 +361,7 @@ func TestWebhook_EmptyBody_BadRequest(t *testing.T) {
 
 func TestWebhook_InvalidJSON_BadRequest(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	body := []byte("{invalid json")
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
 
@@ -498,7 +375,7 @@ func TestWebhook_InvalidJSON_BadRequest(t *testing.T) {
 
 func TestWebhook_UnhandledType_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	body := makeEvent("unhandled.event", map[string]any{"x": 1})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -517,7 +394,7 @@ func TestWebhook_UnhandledType_OK(t *testing.T) {
 func TestWebhook_Signature_MissingHeader_Unauthorized(t *testing.T) {
 	secret := "whsec_test"
 	t.Setenv("STRIPE_WEBHOOK_SECRET", secret)
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	body := makeEvent("customer.created", map[string]any{"id": "cus_123"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -534,13 +411,12 @@ func TestWebhook_Signature_MissingHeader_Unauthorized(t *testing.T) {
 func TestWebhook_Signature_Valid_OK(t *testing.T) {
 	secret := "whsec_test"
 	t.Setenv("STRIPE_WEBHOOK_SECRET", secret)
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	body := makeEvent("customer.created", map[string]any{"id": "cus_123", "email": "a@b"})
 	ts := time.Now().Unix()
-	sig := computeStripeSignature(body, ts, secret)
 	headers := map[string]string{
-		"Stripe-Signature": makeSigHeader(ts, sig),
+		"Stripe-Signature": makeSigHeader(ts, signStripe(t, body, ts, secret)),
 	}
 	c, rec := newEchoCtx(http.MethodPost, body, headers)
 
@@ -555,6 +431,76 @@ func TestWebhook_Signature_Valid_OK(t *testing.T) {
 	}
 }
 
+func TestWebhook_Signature_Stale_Unauthorized(t *testing.T) {
+	secret := "whsec_test"
+	t.Setenv("STRIPE_WEBHOOK_SECRET", secret)
+	t.Setenv("STRIPE_WEBHOOK_TOLERANCE_SECONDS", "60")
+	h := NewDefaultHandler(nil, nil)
+
+	body := makeEvent("customer.created", map[string]any{"id": "cus_123"})
+	ts := time.Now().Add(-2 * time.Minute).Unix()
+	headers := map[string]string{
+		"Stripe-Signature": makeSigHeader(ts, signStripe(t, body, ts, secret)),
+	}
+	c, rec := newEchoCtx(http.MethodPost, body, headers)
+
+	_ = h.Webhook(c)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a delivery older than the tolerance, got %d", rec.Code)
+	}
+}
+
+func TestWebhook_Signature_PreviousSecret_OK(t *testing.T) {
+	t.Setenv("STRIPE_WEBHOOK_SECRET", "whsec_new")
+	t.Setenv("STRIPE_WEBHOOK_SECRET_PREVIOUS", "whsec_old")
+	h := NewDefaultHandler(nil, nil)
+
+	body := makeEvent("customer.created", map[string]any{"id": "cus_123"})
+	ts := time.Now().Unix()
+	headers := map[string]string{
+		"Stripe-Signature": makeSigHeader(ts, signStripe(t, body, ts, "whsec_old")),
+	}
+	c, rec := newEchoCtx(http.MethodPost, body, headers)
+
+	if err := h.Webhook(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a delivery signed before rotation, got %d", rec.Code)
+	}
+}
+
+func TestWebhook_Signature_Replayed_Conflict(t *testing.T) {
+	secret := "whsec_test"
+	t.Setenv("STRIPE_WEBHOOK_SECRET", secret)
+	seen := map[string]bool{}
+	h := NewDefaultHandlerWithReplayCache(nil, nil, &webhookauth.ReplayCacheMock{
+		RememberFunc: func(_ context.Context, key string, _ time.Duration) (bool, error) {
+			first := !seen[key]
+			seen[key] = true
+			return first, nil
+		},
+	})
+
+	body := makeEvent("customer.created", map[string]any{"id": "cus_123"})
+	ts := time.Now().Unix()
+	headers := map[string]string{
+		"Stripe-Signature": makeSigHeader(ts, signStripe(t, body, ts, secret)),
+	}
+
+	c, rec := newEchoCtx(http.MethodPost, body, headers)
+	_ = h.Webhook(c)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for first delivery, got %d", rec.Code)
+	}
+
+	c, rec = newEchoCtx(http.MethodPost, body, headers)
+	_ = h.Webhook(c)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for replayed delivery, got %d", rec.Code)
+	}
+}
+
 // ---------------------------- Additional edge-case tests ----------------------------
 
 type badReader struct{}
@@ -563,7 +509,7 @@ func (badReader) Read(p []byte) (int, error) { return 0, fmt.Errorf("read error"
 
 func TestWebhook_ReadBodyError_BadRequest(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/stripe/webhook", badReader{})
@@ -603,7 +549,7 @@ func (f *fakeDBIdemError) Exec(ctx context.Context, sql string, args ...interfac
 
 func TestWebhook_IdempotencyError_500(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBIdemError{})
+	h := NewDefaultHandler(&fakeDBIdemError{}, nil)
 
 	body := makeEvent("unhandled.event", map[string]any{"x": "y"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -617,7 +563,7 @@ func TestWebhook_IdempotencyError_500(t *testing.T) {
 }
 
 func Test_handleCheckoutSessionCompleted_DB_Branch(t *testing.T) {
-	h := NewDefaultHandler(&simpleDB{})
+	h := NewDefaultHandler(&simpleDB{}, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": map[string]interface{}{
@@ -633,8 +579,53 @@ func Test_handleCheckoutSessionCompleted_DB_Branch(t *testing.T) {
 	}
 }
 
+func Test_handleCheckoutSessionCompleted_CreditPack(t *testing.T) {
+	var args []interface{}
+	db := &storage.DatabaseMock{
+		QueryRowFunc: func(ctx context.Context, sql string, a ...interface{}) pgx.Row {
+			if contains(sql, "credit_ledger") {
+				args = a
+			}
+			return okRow{}
+		},
+	}
+	h := NewDefaultHandler(db, nil)
+	evt := StripeEvent{
+		Data: map[string]interface{}{
+			"object": map[string]interface{}{
+				"id":             "cs_credits",
+				"customer":       "cus_credits",
+				"mode":           "payment",
+				"payment_status": "paid",
+				"metadata": map[string]interface{}{
+					"user_id":     "11111111-1111-1111-1111-111111111111",
+					"credit_pack": "pack_20",
+					"credits":     "20",
+				},
+			},
+		},
+	}
+	if err := h.handleCheckoutSessionCompleted(context.Background(), &evt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(args) != 3 ||
+		args[0] != "11111111-1111-1111-1111-111111111111" || args[1] != int32(20) || args[2] != "cs_credits" {
+		t.Fatalf("expected a purchase of 20 credits for cs_credits, got %v", args)
+	}
+
+	// Unpaid sessions add nothing.
+	args = nil
+	evt.Data["object"].(map[string]interface{})["payment_status"] = "unpaid"
+	if err := h.handleCheckoutSessionCompleted(context.Background(), &evt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args != nil {
+		t.Fatalf("expected no purchase for an unpaid session, got %v", args)
+	}
+}
+
 func Test_handleCheckoutSessionCompleted_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -646,7 +637,7 @@ func Test_handleCheckoutSessionCompleted_InvalidData(t *testing.T) {
 }
 
 func Test_handleSubscriptionCreated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -658,7 +649,7 @@ func Test_handleSubscriptionCreated_InvalidData(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentSucceeded_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -670,7 +661,7 @@ func Test_handleInvoicePaymentSucceeded_InvalidData(t *testing.T) {
 }
 
 func Test_handleCustomerCreated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -682,7 +673,7 @@ func Test_handleCustomerCreated_InvalidData(t *testing.T) {
 }
 
 func Test_handleCustomerUpdated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -694,7 +685,7 @@ func Test_handleCustomerUpdated_InvalidData(t *testing.T) {
 }
 
 func Test_handleCustomerDeleted_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -706,7 +697,7 @@ func Test_handleCustomerDeleted_InvalidData(t *testing.T) {
 }
 
 func Test_handleSubscriptionUpdated_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -718,7 +709,7 @@ func Test_handleSubscriptionUpdated_InvalidData(t *testing.T) {
 }
 
 func Test_handleSubscriptionDeleted_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -730,7 +721,7 @@ func Test_handleSubscriptionDeleted_InvalidData(t *testing.T) {
 }
 
 func Test_handleInvoicePaymentFailed_InvalidData(t *testing.T) {
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	evt := StripeEvent{
 		Data: map[string]interface{}{
 			"object": "not_a_map",
@@ -743,7 +734,7 @@ func Test_handleInvoicePaymentFailed_InvalidData(t *testing.T) {
 
 func TestWebhook_CheckoutSessionCompleted_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{"id": "cs_123", "customer": "cus_1", "payment_status": "paid", "client_reference_id": "auth0|u1"}
 	body := makeEvent("checkout.session.completed", obj)
@@ -759,7 +750,7 @@ func TestWebhook_CheckoutSessionCompleted_OK(t *testing.T) {
 
 func TestWebhook_SubscriptionCreated_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{"id": "sub_1", "customer": "cus_1", "status": "active"}
 	body := makeEvent("customer.subscription.created", obj)
@@ -775,7 +766,7 @@ func TestWebhook_SubscriptionCreated_OK(t *testing.T) {
 
 func TestWebhook_SubscriptionUpdated_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{"id": "sub_2", "customer": "cus_2", "status": "past_due"}
 	body := makeEvent("customer.subscription.updated", obj)
@@ -791,7 +782,7 @@ func TestWebhook_SubscriptionUpdated_OK(t *testing.T) {
 
 func TestWebhook_SubscriptionDeleted_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{"id": "sub_3", "customer": "cus_3"}
 	body := makeEvent("customer.subscription.deleted", obj)
@@ -807,7 +798,7 @@ func TestWebhook_SubscriptionDeleted_OK(t *testing.T) {
 
 func TestWebhook_InvoicePaymentSucceeded_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{
 		"id": "in_1", "customer": "cus_1", "subscription": "sub_1", "status": "paid",
@@ -826,7 +817,7 @@ func TestWebhook_InvoicePaymentSucceeded_OK(t *testing.T) {
 
 func TestWebhook_InvoicePaymentFailed_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{
 		"id": "in_2", "customer": "cus_2", "subscription": "sub_2", "status": "failed",
@@ -845,7 +836,7 @@ func TestWebhook_InvoicePaymentFailed_OK(t *testing.T) {
 
 func TestWebhook_CustomerCreated_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{"id": "cus_x", "email": "x@y"}
 	body := makeEvent("customer.created", obj)
@@ -861,7 +852,7 @@ func TestWebhook_CustomerCreated_OK(t *testing.T) {
 
 func TestWebhook_CustomerUpdated_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{"id": "cus_y", "email": "y@z"}
 	body := makeEvent("customer.updated", obj)
@@ -877,7 +868,7 @@ func TestWebhook_CustomerUpdated_OK(t *testing.T) {
 
 func TestWebhook_CustomerDeleted_OK(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 
 	obj := map[string]any{"id": "cus_z"}
 	body := makeEvent("customer.deleted", obj)
@@ -893,7 +884,7 @@ func TestWebhook_CustomerDeleted_OK(t *testing.T) {
 
 func TestWebhook_Idempotent_Duplicate(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBAlreadyProcessed{})
+	h := NewDefaultHandler(&fakeDBAlreadyProcessed{}, nil)
 
 	body := makeEvent("customer.created", map[string]any{"id": "cus_dup"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -909,9 +900,10 @@ func TestWebhook_Idempotent_Duplicate(t *testing.T) {
 	}
 }
 
-func TestWebhook_MarkProcessed_DB_Success(t *testing.T) {
+func TestWebhook_ClaimEvent_DB_Success(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBIdemFirstMissingThenUpsertOK{})
+	f := &fakeDBIdemClaim{claimRows: 1}
+	h := NewDefaultHandler(f, nil)
 
 	body := makeEvent("unhandled.event", map[string]any{"x": "y"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -925,52 +917,46 @@ func TestWebhook_MarkProcessed_DB_Success(t *testing.T) {
 	if !contains(rec.Body.String(), "received") {
 		t.Fatalf("expected response to contain 'received', got %s", rec.Body.String())
 	}
+	if f.claims != 1 {
+		t.Fatalf("expected the event to be claimed once, got %d", f.claims)
+	}
 }
 
-// Ensure we actually attempt Upsert (IsProcessed + Upsert -> 2 QueryRow calls)
-func TestWebhook_MarkProcessed_Upsert_CalledTwice(t *testing.T) {
+// A delivery that lost the race for the event, or arrived after it was
+// processed, applies nothing and acknowledges it as a duplicate.
+func TestWebhook_ClaimEvent_AlreadyClaimed_Duplicate(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	f := &fakeDBIdemFirstMissingThenUpsertOK{}
-	h := NewDefaultHandler(f)
+	h := NewDefaultHandler(&fakeDBIdemClaim{claimRows: 0}, nil)
+	var published []events.Event
+	h.bus = &events.BusMock{
+		PublishFunc: func(ctx context.Context, event events.Event) error {
+			published = append(published, event)
+			return nil
+		},
+	}
 
-	body := makeEvent("unhandled.event", map[string]any{"ok": true})
-	c, _ := newEchoCtx(http.MethodPost, body, nil)
+	body := makeEvent("checkout.session.completed", map[string]any{
+		"id": "cs_dup", "mode": "payment", "payment_status": "paid",
+		"metadata": map[string]any{"user_id": testUserID.String(), "credit_pack": "pack_20", "credits": "20"},
+	})
+	c, rec := newEchoCtx(http.MethodPost, body, nil)
 
 	if err := h.Webhook(c); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if f.calls != 2 {
-		t.Fatalf("expected 2 QueryRow calls (IsProcessed + Upsert), got %d", f.calls)
+	if rec.Code != http.StatusOK || !contains(rec.Body.String(), "duplicate") {
+		t.Fatalf("expected 200 duplicate, got %d: %s", rec.Code, rec.Body.String())
 	}
-}
-
-// fakeDBIdemUpsertError simulates IsProcessed=false then Upsert failing (mark processed error)
-// Webhook should still acknowledge with 200.
-type fakeDBIdemUpsertError struct {
-	calls int
-}
-
-func (f *fakeDBIdemUpsertError) Close()                {}
-func (f *fakeDBIdemUpsertError) Pool() storage.PgxPool { return nil }
-func (f *fakeDBIdemUpsertError) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
-	f.calls++
-	if f.calls == 1 {
-		// IsProcessed -> not found
-		return errRow{}
+	if len(published) != 0 {
+		t.Fatalf("expected no events for a duplicate, got %v", published)
 	}
-	// Upsert -> error on scan
-	return failingRow{}
-}
-func (f *fakeDBIdemUpsertError) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
-	return nil, nil
-}
-func (f *fakeDBIdemUpsertError) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
-	return pgconn.CommandTag{}, nil
 }
 
-func TestWebhook_MarkProcessed_DB_UpsertError_OK(t *testing.T) {
+// Failing to record the event fails the delivery, so Stripe retries it rather
+// than it being applied without a record that would stop a later replay.
+func TestWebhook_ClaimEvent_Error_500(t *testing.T) {
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
-	h := NewDefaultHandler(&fakeDBIdemUpsertError{})
+	h := NewDefaultHandler(&fakeDBIdemClaim{claimErr: errors.New("boom")}, nil)
 
 	body := makeEvent("unhandled.event", map[string]any{"x": "y"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
@@ -978,28 +964,35 @@ func TestWebhook_MarkProcessed_DB_UpsertError_OK(t *testing.T) {
 	if err := h.Webhook(c); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if rec.Code != http.StatusOK {
-		t.Fatalf("expected 200 even if mark-processed fails, got %d", rec.Code)
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
 	}
-	if !contains(rec.Body.String(), "received") {
-		t.Fatalf("expected response to contain 'received', got %s", rec.Body.String())
+}
+
+func TestWebhook_MissingEventID_BadRequest(t *testing.T) {
+	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
+	h := NewDefaultHandler(&fakeDBIdemClaim{claimRows: 1}, nil)
+
+	c, rec := newEchoCtx(http.MethodPost, []byte(`{"type":"invoice.paid","data":{"object":{}}}`), nil)
+
+	if err := h.Webhook(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an event that cannot be deduplicated, got %d", rec.Code)
 	}
 }
 
-// Enforce STRIPE_WEBHOOK_SECRET in non-dev envs (simulate non-dev by setting envs and tweaking os.Args[0])
+// Enforce STRIPE_WEBHOOK_SECRET in non-dev envs. APP_ENV alone marks production;
+// the other markers are left unset as they are on a real deployment.
 func TestWebhook_MissingSecret_NonDev_ServiceUnavailable(t *testing.T) {
-	// Force non-dev
 	t.Setenv("APP_ENV", "production")
-	t.Setenv("GO_ENV", "production")
-	t.Setenv("ENV", "production")
-	t.Setenv("NODE_ENV", "production")
+	t.Setenv("GO_ENV", "")
+	t.Setenv("ENV", "")
+	t.Setenv("NODE_ENV", "")
 	t.Setenv("STRIPE_WEBHOOK_SECRET", "")
 
-	orig := os.Args[0]
-	os.Args[0] = "api-server" // avoid ".test" heuristic
-	defer func() { os.Args[0] = orig }()
-
-	h := NewDefaultHandler(nil)
+	h := NewDefaultHandler(nil, nil)
 	body := makeEvent("customer.created", map[string]any{"id": "cus_nondev"})
 	c, rec := newEchoCtx(http.MethodPost, body, nil)
 
@@ -1012,10 +1005,55 @@ func TestWebhook_MissingSecret_NonDev_ServiceUnavailable(t *testing.T) {
 	}
 }
 
+func Test_isDevLikeEnv(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"success: unset defaults to dev", map[string]string{}, true},
+		{"success: APP_ENV=test", map[string]string{"APP_ENV": "test"}, true},
+		{"success: production", map[string]string{"APP_ENV": "production"}, false},
+		{"success: unknown name is not dev", map[string]string{"APP_ENV": "staging"}, false},
+		{"success: APP_ENV wins over NODE_ENV", map[string]string{"APP_ENV": "prod", "NODE_ENV": "development"}, false},
+		{"success: falls back to GO_ENV", map[string]string{"GO_ENV": "Development"}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"APP_ENV", "GO_ENV", "ENV", "NODE_ENV"} {
+				t.Setenv(key, tc.env[key])
+			}
+			if got := isDevLikeEnv(); got != tc.want {
+				t.Fatalf("isDevLikeEnv() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_webhookTolerance(t *testing.T) {
+	cases := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"600", 10 * time.Minute},
+		{"-5", 0},
+		{"soon", 0},
+	}
+	for _, tc := range cases {
+		t.Run("success: "+tc.value, func(t *testing.T) {
+			t.Setenv("STRIPE_WEBHOOK_TOLERANCE_SECONDS", tc.value)
+			if got := webhookTolerance(); got != tc.want {
+				t.Fatalf("webhookTolerance() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 // Mapping edge-case: ensure subscription handler tolerates nested price/timestamps presence
 // even when user lookup fails (no-rows), exercising mapping paths.
 func Test_handleSubscriptionCreated_Mapping_PriceAndTimes_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, nil)
 	now := float64(time.Now().Unix())
 
 	evt := StripeEvent{
@@ -1047,7 +1085,7 @@ func Test_handleSubscriptionCreated_Mapping_PriceAndTimes_OK(t *testing.T) {
 
 // Mapping edge-case: invoice with partial data (no currency/number) should still process OK
 func Test_handleInvoicePaymentSucceeded_PartialData_OK(t *testing.T) {
-	h := NewDefaultHandler(&userNotFoundDB{})
+	h := NewDefaultHandler(&userNotFoundDB{}, nil)
 
 	evt := StripeEvent{
 		Data: map[string]interface{}{
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
@@ -21,6 +22,10 @@ type ProcessedEventsRepository interface {
 	// Upsert marks the given event as processed; if it already exists, it is a no-op.
 	// eventType may be nil. payload should be a JSON-encoded body (may be nil/empty).
 	Upsert(ctx context.Context, stripeEventID string, eventType *string, payload []byte) (*queries.ProcessedEvent, error)
+	// Claim marks the given event as processed and reports whether this call did so.
+	// Run in the transaction that applies the event, it returns false for an event
+	// another delivery has applied, including one committed while Claim waited.
+	Claim(ctx context.Context, stripeEventID string, eventType string, payload []byte) (bool, error)
 	// Get returns the processed event record by Stripe event ID.
 	Get(ctx context.Context, stripeEventID string) (*queries.ProcessedEvent, error)
 	// DeleteOlderThan removes processed events older than the given timestamp (for retention housekeeping).
@@ -50,6 +55,13 @@ type SubscriptionsRepository interface {
 	// ListByUserID lists subscriptions for a user with pagination.
 	ListByUserID(ctx context.Context, userID string, limit, offset int32) ([]*queries.Subscription, error)
 
+	// ListPageByUserID lists a page of a user's subscriptions, newest first,
+	// skipping offset rows after the cursor, and returns the cursor of the
+	// next page, nil on the last one.
+	ListPageByUserID(
+		ctx context.Context, userID string, page pagination.Page, offset int32,
+	) ([]*queries.Subscription, *string, error)
+
 	// DeleteByStripeID deletes a subscription row by Stripe subscription ID.
 	DeleteByStripeID(ctx context.Context, stripeSubscriptionID string) error
 }
@@ -73,8 +85,19 @@ type InvoicesRepository interface {
 	// GetByStripeID returns an invoice by Stripe invoice ID.
 	GetByStripeID(ctx context.Context, stripeInvoiceID string) (*queries.Invoice, error)
 
+	// GetByIDForUser returns the user's invoice with the given ID, or
+	// pgx.ErrNoRows when there is none.
+	GetByIDForUser(ctx context.Context, id, userID string) (*queries.Invoice, error)
+
 	// ListByUserID lists invoices for a user with pagination.
 	ListByUserID(ctx context.Context, userID string, limit, offset int32) ([]*queries.Invoice, error)
+
+	// ListPageByUserID lists a page of a user's invoices, newest first,
+	// skipping offset rows after the cursor, and returns the cursor of the
+	// next page, nil on the last one.
+	ListPageByUserID(
+		ctx context.Context, userID string, page pagination.Page, offset int32,
+	) ([]*queries.Invoice, *string, error)
 }
 
 /* ---------------------------- Implementations ---------------------------- */
@@ -149,6 +172,24 @@ func (r *processedEventsRepo) Upsert(
 	return pe, nil
 }
 
+func (r *processedEventsRepo) Claim(
+	ctx context.Context, stripeEventID string, eventType string, payload []byte,
+) (bool, error) {
+	var data []byte
+	if len(payload) > 0 {
+		data = payload
+	}
+	n, err := r.q.ClaimProcessedEvent(ctx, queries.ClaimProcessedEventParams{
+		StripeEventID: stripeEventID,
+		Type:          pgtype.Text{String: eventType, Valid: eventType != ""},
+		Payload:       data,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim processed event: %w", err)
+	}
+	return n == 1, nil
+}
+
 func (r *processedEventsRepo) Get(ctx context.Context, stripeEventID string) (*queries.ProcessedEvent, error) {
 	pe, err := r.q.GetProcessedEventByStripeID(ctx, stripeEventID)
 	if err != nil {
@@ -259,6 +300,31 @@ func (r *subscriptionsRepo) ListByUserID(
 	return results, nil
 }
 
+func (r *subscriptionsRepo) ListPageByUserID(
+	ctx context.Context, userID string, page pagination.Page, offset int32,
+) ([]*queries.Subscription, *string, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	afterCreatedAt, afterID := page.AfterParams()
+	results, err := r.q.ListSubscriptionsByUserID(ctx, queries.ListSubscriptionsByUserIDParams{
+		UserID:         pgtype.UUID{Bytes: uid, Valid: true},
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+		Limit:          page.Fetch(),
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list subscriptions by user: %w", err)
+	}
+	results, next := pagination.Trim(results, page, func(s *queries.Subscription) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: s.CreatedAt.Time, ID: uuid.UUID(s.ID.Bytes).String()}
+	})
+	return results, next, nil
+}
+
 func (r *subscriptionsRepo) DeleteByStripeID(ctx context.Context, stripeSubscriptionID string) error {
 	if err := r.q.DeleteSubscriptionByStripeID(ctx, stripeSubscriptionID); err != nil {
 		return fmt.Errorf("failed to delete subscription by stripe id: %w", err)
@@ -323,6 +389,29 @@ func (r *invoicesRepo) GetByStripeID(ctx context.Context, stripeInvoiceID string
 	return inv, nil
 }
 
+func (r *invoicesRepo) GetByIDForUser(ctx context.Context, id, userID string) (*queries.Invoice, error) {
+	iid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invoice ID format: %w", err)
+	}
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	inv, err := r.q.GetInvoiceByIDForUser(ctx, queries.GetInvoiceByIDForUserParams{
+		ID:     pgtype.UUID{Bytes: iid, Valid: true},
+		UserID: pgtype.UUID{Bytes: uid, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pgx.ErrNoRows
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+	return inv, nil
+}
+
 func (r *invoicesRepo) ListByUserID(
 	ctx context.Context, userID string, limit, offset int32,
 ) ([]*queries.Invoice, error) {
@@ -342,3 +431,28 @@ func (r *invoicesRepo) ListByUserID(
 	}
 	return results, nil
 }
+
+func (r *invoicesRepo) ListPageByUserID(
+	ctx context.Context, userID string, page pagination.Page, offset int32,
+) ([]*queries.Invoice, *string, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	afterCreatedAt, afterID := page.AfterParams()
+	results, err := r.q.ListInvoicesByUserID(ctx, queries.ListInvoicesByUserIDParams{
+		UserID:         pgtype.UUID{Bytes: uid, Valid: true},
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+		Limit:          page.Fetch(),
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list invoices by user: %w", err)
+	}
+	results, next := pagination.Trim(results, page, func(inv *queries.Invoice) pagination.Cursor {
+		return pagination.Cursor{CreatedAt: inv.CreatedAt.Time, ID: uuid.UUID(inv.ID.Bytes).String()}
+	})
+	return results, next, nil
+}
@@ -172,3 +172,44 @@ func TestInvoicesRepository_GetByStripeID_NotFound(t *testing.T) {
 		t.Fatalf("expected pgx.ErrNoRows, got %v", err)
 	}
 }
+
+func TestInvoicesRepository_GetByIDForUser(t *testing.T) {
+	ctx := context.Background()
+	const (
+		invoiceID = "cccccccc-cccc-cccc-cccc-cccccccccccc"
+		userID    = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	)
+
+	t.Run("success: returns the user's invoice", func(t *testing.T) {
+		db := &fakeDB{row: &rowStub{inv: queries.Invoice{
+			ID:              toUUID(uuid.MustParse(invoiceID)),
+			UserID:          toUUID(uuid.MustParse(userID)),
+			StripeInvoiceID: "in_test_3",
+			Status:          "paid",
+		}}}
+
+		got, err := NewInvoicesRepository(db).GetByIDForUser(ctx, invoiceID, userID)
+		if err != nil {
+			t.Fatalf("GetByIDForUser returned error: %v", err)
+		}
+		if got.StripeInvoiceID != "in_test_3" {
+			t.Fatalf("StripeInvoiceID mismatch: got %q want %q", got.StripeInvoiceID, "in_test_3")
+		}
+	})
+
+	t.Run("fail: another user's invoice is not found", func(t *testing.T) {
+		db := &fakeDB{row: &rowStub{err: pgx.ErrNoRows}}
+
+		_, err := NewInvoicesRepository(db).GetByIDForUser(ctx, invoiceID, userID)
+		if !errors.Is(err, pgx.ErrNoRows) {
+			t.Fatalf("expected pgx.ErrNoRows, got %v", err)
+		}
+	})
+
+	t.Run("fail: invalid invoice ID", func(t *testing.T) {
+		_, err := NewInvoicesRepository(&fakeDB{}).GetByIDForUser(ctx, "not-a-uuid", userID)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
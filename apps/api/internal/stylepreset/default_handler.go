@@ -0,0 +1,128 @@
+package stylepreset
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/problem"
+	"github.com/real-staging-ai/api/modelconfig"
+)
+
+// CodeDuplicateName is the problem code for a preset name conflict.
+const CodeDuplicateName = "style_preset_exists"
+
+// DefaultHandler serves the style preset API.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// CreatePreset handles POST /api/v1/admin/style-presets.
+func (h *DefaultHandler) CreatePreset(c echo.Context) error {
+	var req CreatePresetRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	p, err := h.service.CreatePreset(c.Request().Context(), req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to create style preset")
+	}
+	h.log.Info(c.Request().Context(), "style preset created", "preset_id", p.ID, "model_id", p.ModelID)
+	return c.JSON(http.StatusCreated, p)
+}
+
+// ListPresets handles GET /api/v1/style-presets.
+func (h *DefaultHandler) ListPresets(c echo.Context) error {
+	presets, err := h.service.ListPresets(c.Request().Context())
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list style presets")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"presets": presets})
+}
+
+// GetPreset handles GET /api/v1/style-presets/:id.
+func (h *DefaultHandler) GetPreset(c echo.Context) error {
+	id, p := presetID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	preset, err := h.service.GetPreset(c.Request().Context(), id)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to get style preset")
+	}
+	return c.JSON(http.StatusOK, preset)
+}
+
+// UpdatePreset handles PATCH /api/v1/admin/style-presets/:id.
+func (h *DefaultHandler) UpdatePreset(c echo.Context) error {
+	id, p := presetID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	var req UpdatePresetRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Write(c, http.StatusBadRequest, problem.CodeBadRequest, "Invalid request format")
+	}
+
+	preset, err := h.service.UpdatePreset(c.Request().Context(), id, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to update style preset")
+	}
+	h.log.Info(c.Request().Context(), "style preset updated", "preset_id", preset.ID, "model_id", preset.ModelID)
+	return c.JSON(http.StatusOK, preset)
+}
+
+// DeletePreset handles DELETE /api/v1/admin/style-presets/:id.
+func (h *DefaultHandler) DeletePreset(c echo.Context) error {
+	id, p := presetID(c)
+	if p != nil {
+		return problem.Send(c, p)
+	}
+
+	if err := h.service.DeletePreset(c.Request().Context(), id); err != nil {
+		return h.serviceError(c, err, "Failed to delete style preset")
+	}
+	h.log.Info(c.Request().Context(), "style preset deleted", "preset_id", id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// presetID validates :id.
+func presetID(c echo.Context) (string, *problem.Problem) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", problem.New(http.StatusBadRequest, problem.CodeBadRequest, "Invalid style preset ID format")
+	}
+	return id, nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	var invalid *modelconfig.ValidationError
+	switch {
+	case errors.As(err, &invalid):
+		return problem.Send(c, problem.New(http.StatusUnprocessableEntity, problem.CodeValidationFailed,
+			"The config overrides are invalid for the preset's model").With("validation_errors", invalid.Fields))
+	case errors.Is(err, ErrPresetNotFound):
+		return problem.Write(c, http.StatusNotFound, problem.CodeNotFound, "Style preset not found")
+	case errors.Is(err, ErrInvalidPreset):
+		return problem.Write(c, http.StatusUnprocessableEntity, problem.CodeValidationFailed, err.Error())
+	case errors.Is(err, ErrDuplicateName):
+		return problem.Write(c, http.StatusConflict, CodeDuplicateName, err.Error())
+	}
+	h.log.Error(c.Request().Context(), "style preset request failed", "error", err)
+	return problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, message)
+}
@@ -0,0 +1,171 @@
+package stylepreset
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/echotest"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/modelconfig"
+)
+
+func TestDefaultHandler_CreatePreset(t *testing.T) {
+	body := `{"name":"Luxury","model_id":"black-forest-labs/flux-kontext-max",` +
+		`"config_overrides":{"output_format":"png"}}`
+
+	cases := []struct {
+		name         string
+		body         string
+		createErr    error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: returns the preset",
+			body:         body,
+			expectedCode: http.StatusCreated,
+			expectBody:   `"config_overrides":{"output_format":"png"}`,
+		},
+		{name: "fail: malformed body", body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: validation",
+			body:         body,
+			createErr:    ErrInvalidPreset,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name: "fail: invalid overrides",
+			body: body,
+			createErr: &modelconfig.ValidationError{Fields: []modelconfig.FieldError{
+				{Field: "output_format", Message: "must be one of: webp, png, jpg"},
+			}},
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   `"validation_errors":[{"field":"output_format"`,
+		},
+		{
+			name:         "fail: duplicate name",
+			body:         body,
+			createErr:    ErrDuplicateName,
+			expectedCode: http.StatusConflict,
+			expectBody:   CodeDuplicateName,
+		},
+		{
+			name:         "fail: service error",
+			body:         body,
+			createErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreatePresetFunc: func(ctx context.Context, req CreatePresetRequest) (*Preset, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Preset{
+						ID: "preset-1", Name: req.Name, ModelID: req.ModelID, ConfigOverrides: req.ConfigOverrides,
+					}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodPost, tc.body, nil, nil)
+
+			require.NoError(t, NewDefaultHandler(svc, logging.Default()).CreatePreset(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_ListPresets(t *testing.T) {
+	svc := &ServiceMock{
+		ListPresetsFunc: func(ctx context.Context) ([]Preset, error) {
+			return []Preset{{ID: "preset-1", Name: "Luxury"}}, nil
+		},
+	}
+	c, rec := echotest.NewContext(http.MethodGet, "", nil, nil)
+
+	require.NoError(t, NewDefaultHandler(svc, logging.Default()).ListPresets(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"presets":[{"id":"preset-1"`)
+}
+
+func TestDefaultHandler_GetPreset(t *testing.T) {
+	id := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		id           string
+		getErr       error
+		expectedCode int
+	}{
+		{name: "success: returns the preset", id: id, expectedCode: http.StatusOK},
+		{name: "fail: invalid id", id: "nope", expectedCode: http.StatusBadRequest},
+		{name: "fail: not found", id: id, getErr: ErrPresetNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetPresetFunc: func(ctx context.Context, gotID string) (*Preset, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Preset{ID: gotID, Name: "Luxury"}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{tc.id})
+
+			require.NoError(t, NewDefaultHandler(svc, logging.Default()).GetPreset(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_UpdatePreset(t *testing.T) {
+	svc := &ServiceMock{
+		UpdatePresetFunc: func(ctx context.Context, id string, req UpdatePresetRequest) (*Preset, error) {
+			require.NotNil(t, req.PromptTemplate)
+			assert.Nil(t, req.Name)
+			return &Preset{ID: id, Name: "Luxury"}, nil
+		},
+	}
+	c, rec := echotest.NewContext(http.MethodPatch, `{"prompt_template":""}`, []string{"id"}, []string{uuid.NewString()})
+
+	require.NoError(t, NewDefaultHandler(svc, logging.Default()).UpdatePreset(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "prompt_template")
+}
+
+func TestDefaultHandler_DeletePreset(t *testing.T) {
+	cases := []struct {
+		name         string
+		deleteErr    error
+		expectedCode int
+	}{
+		{name: "success: deleted", expectedCode: http.StatusNoContent},
+		{name: "fail: not found", deleteErr: ErrPresetNotFound, expectedCode: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				DeletePresetFunc: func(ctx context.Context, id string) error { return tc.deleteErr },
+			}
+			c, rec := echotest.NewContext(http.MethodDelete, "", []string{"id"}, []string{uuid.NewString()})
+
+			require.NoError(t, NewDefaultHandler(svc, logging.Default()).DeletePreset(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
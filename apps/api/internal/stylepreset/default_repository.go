@@ -0,0 +1,143 @@
+package stylepreset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// presetColumns are the style_presets columns scanPreset reads.
+const presetColumns = ` id, name, description, model_id, prompt_template, config_overrides, created_at, updated_at`
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+func scanPreset(row pgx.Row) (*Preset, error) {
+	var p Preset
+	var overrides []byte
+	if err := row.Scan(&p.ID, &p.Name, &p.Description, &p.ModelID, &p.PromptTemplate, &overrides,
+		&p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(overrides, &p.ConfigOverrides); err != nil {
+		return nil, fmt.Errorf("failed to decode config overrides: %w", err)
+	}
+	if p.ConfigOverrides == nil {
+		p.ConfigOverrides = map[string]interface{}{}
+	}
+	return &p, nil
+}
+
+// Create inserts a new preset.
+func (r *DefaultRepository) Create(ctx context.Context, p *Preset) (*Preset, error) {
+	overrides, err := json.Marshal(p.ConfigOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config overrides: %w", err)
+	}
+	query := `
+		INSERT INTO style_presets (name, description, model_id, prompt_template, config_overrides)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING` + presetColumns
+
+	created, err := scanPreset(r.db.QueryRow(ctx, query, p.Name, p.Description, p.ModelID, p.PromptTemplate,
+		overrides))
+	if err != nil {
+		if storage.IsUniqueViolation(err) {
+			return nil, ErrDuplicateName
+		}
+		return nil, fmt.Errorf("failed to create style preset: %w", err)
+	}
+	return created, nil
+}
+
+// GetByID retrieves a preset.
+func (r *DefaultRepository) GetByID(ctx context.Context, id string) (*Preset, error) {
+	query := `SELECT` + presetColumns + ` FROM style_presets WHERE id = $1`
+
+	p, err := scanPreset(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPresetNotFound
+		}
+		return nil, fmt.Errorf("failed to get style preset: %w", err)
+	}
+	return p, nil
+}
+
+// List returns every preset ordered by name.
+func (r *DefaultRepository) List(ctx context.Context) ([]Preset, error) {
+	query := `SELECT` + presetColumns + ` FROM style_presets ORDER BY name ASC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list style presets: %w", err)
+	}
+	defer rows.Close()
+
+	presets := []Preset{}
+	for rows.Next() {
+		p, err := scanPreset(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan style preset: %w", err)
+		}
+		presets = append(presets, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over style preset rows: %w", err)
+	}
+	return presets, nil
+}
+
+// Update persists every field of a preset.
+func (r *DefaultRepository) Update(ctx context.Context, p *Preset) (*Preset, error) {
+	overrides, err := json.Marshal(p.ConfigOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config overrides: %w", err)
+	}
+	query := `
+		UPDATE style_presets
+		SET name = $2, description = $3, model_id = $4, prompt_template = $5, config_overrides = $6,
+			updated_at = now()
+		WHERE id = $1
+		RETURNING` + presetColumns
+
+	updated, err := scanPreset(r.db.QueryRow(ctx, query, p.ID, p.Name, p.Description, p.ModelID, p.PromptTemplate,
+		overrides))
+	if err != nil {
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, ErrPresetNotFound
+		case storage.IsUniqueViolation(err):
+			return nil, ErrDuplicateName
+		}
+		return nil, fmt.Errorf("failed to update style preset: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete removes a preset.
+func (r *DefaultRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM style_presets WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete style preset: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPresetNotFound
+	}
+	return nil
+}
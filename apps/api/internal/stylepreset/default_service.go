@@ -0,0 +1,78 @@
+package stylepreset
+
+import "context"
+
+// DefaultService implements Service on top of the preset table.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// CreatePreset adds a preset.
+func (s *DefaultService) CreatePreset(ctx context.Context, req CreatePresetRequest) (*Preset, error) {
+	p := &Preset{
+		Name:            req.Name,
+		Description:     req.Description,
+		ModelID:         req.ModelID,
+		PromptTemplate:  req.PromptTemplate,
+		ConfigOverrides: req.ConfigOverrides,
+	}
+	if err := p.normalize(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, p)
+}
+
+// ListPresets returns every preset ordered by name.
+func (s *DefaultService) ListPresets(ctx context.Context) ([]Preset, error) {
+	return s.repo.List(ctx)
+}
+
+// GetPreset retrieves a preset.
+func (s *DefaultService) GetPreset(ctx context.Context, id string) (*Preset, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// UpdatePreset changes a preset.
+func (s *DefaultService) UpdatePreset(ctx context.Context, id string, req UpdatePresetRequest) (*Preset, error) {
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	if req.ModelID != nil {
+		p.ModelID = *req.ModelID
+	}
+	if req.PromptTemplate != nil {
+		p.PromptTemplate = req.PromptTemplate
+		if *req.PromptTemplate == "" {
+			p.PromptTemplate = nil
+		}
+	}
+	if req.ConfigOverrides != nil {
+		p.ConfigOverrides = req.ConfigOverrides
+	}
+	if err := p.normalize(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Update(ctx, p)
+}
+
+// DeletePreset removes a preset.
+func (s *DefaultService) DeletePreset(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
@@ -0,0 +1,180 @@
+package stylepreset
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/modelconfig"
+)
+
+const (
+	fluxModel  = "black-forest-labs/flux-kontext-max"
+	qwenModel  = "qwen/qwen-image-edit"
+	otherModel = "acme/no-schema"
+)
+
+func init() {
+	settings.SetCatalog([]settings.ModelInfo{
+		{ID: fluxModel, Enabled: true}, {ID: qwenModel, Enabled: true}, {ID: otherModel, Enabled: true},
+	})
+}
+
+func strPtr(s string) *string { return &s }
+
+func echoCreate(ctx context.Context, p *Preset) (*Preset, error) {
+	created := *p
+	created.ID = "preset-1"
+	return &created, nil
+}
+
+func TestDefaultService_CreatePreset(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		req       CreatePresetRequest
+		expectErr error
+	}{
+		{
+			name: "success: template and overrides",
+			req: CreatePresetRequest{
+				Name: "  Luxury Modern - High Fidelity ", ModelID: fluxModel,
+				PromptTemplate:  strPtr("A luxurious {style} {room} with marble accents"),
+				ConfigOverrides: map[string]interface{}{"output_format": "png", "safety_tolerance": 2.0},
+			},
+		},
+		{
+			name: "success: model only",
+			req:  CreatePresetRequest{Name: "Luxury Modern - High Fidelity", ModelID: otherModel},
+		},
+		{
+			name:      "fail: blank name",
+			req:       CreatePresetRequest{Name: " ", ModelID: fluxModel},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name: "fail: description too long",
+			req: CreatePresetRequest{
+				Name: "Long", ModelID: fluxModel, Description: strings.Repeat("a", MaxDescriptionLength+1),
+			},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: model not in the catalog",
+			req:       CreatePresetRequest{Name: "Retired", ModelID: "acme/retired"},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name:      "fail: unknown placeholder",
+			req:       CreatePresetRequest{Name: "Typo", ModelID: fluxModel, PromptTemplate: strPtr("A cozy {syle} {room}")},
+			expectErr: ErrInvalidPreset,
+		},
+		{
+			name: "fail: overrides for a model without a schema",
+			req: CreatePresetRequest{
+				Name: "Other", ModelID: otherModel, ConfigOverrides: map[string]interface{}{"steps": 40.0},
+			},
+			expectErr: ErrInvalidPreset,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{CreateFunc: echoCreate}
+
+			created, err := NewDefaultService(repo).CreatePreset(ctx, tc.req)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				assert.Empty(t, repo.CreateCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Luxury Modern - High Fidelity", created.Name)
+			assert.NotNil(t, created.ConfigOverrides)
+		})
+	}
+
+	t.Run("fail: invalid overrides list every field", func(t *testing.T) {
+		repo := &RepositoryMock{CreateFunc: echoCreate}
+
+		_, err := NewDefaultService(repo).CreatePreset(ctx, CreatePresetRequest{
+			Name: "Bad", ModelID: fluxModel,
+			ConfigOverrides: map[string]interface{}{"output_format": "gif", "steps": 40.0},
+		})
+
+		var invalid *modelconfig.ValidationError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, []modelconfig.FieldError{
+			{Field: "output_format", Message: "must be one of: webp, png, jpg"},
+			{Field: "steps", Message: "unknown field"},
+		}, invalid.Fields)
+		assert.Empty(t, repo.CreateCalls())
+	})
+}
+
+func TestDefaultService_UpdatePreset(t *testing.T) {
+	ctx := context.Background()
+	existing := func() *Preset {
+		return &Preset{
+			ID: "preset-1", Name: "Luxury", ModelID: fluxModel,
+			PromptTemplate:  strPtr("A luxurious {style} {room}"),
+			ConfigOverrides: map[string]interface{}{"safety_tolerance": 2.0},
+		}
+	}
+	newRepo := func() *RepositoryMock {
+		return &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Preset, error) { return existing(), nil },
+			UpdateFunc:  func(ctx context.Context, p *Preset) (*Preset, error) { return p, nil },
+		}
+	}
+
+	t.Run("success: an empty template removes it", func(t *testing.T) {
+		updated, err := NewDefaultService(newRepo()).UpdatePreset(ctx, "preset-1",
+			UpdatePresetRequest{Name: strPtr(" Luxury 2 "), PromptTemplate: strPtr("")})
+		require.NoError(t, err)
+		assert.Equal(t, "Luxury 2", updated.Name)
+		assert.Nil(t, updated.PromptTemplate)
+		assert.Equal(t, map[string]interface{}{"safety_tolerance": 2.0}, updated.ConfigOverrides)
+	})
+
+	t.Run("success: overrides are replaced", func(t *testing.T) {
+		updated, err := NewDefaultService(newRepo()).UpdatePreset(ctx, "preset-1",
+			UpdatePresetRequest{ConfigOverrides: map[string]interface{}{"output_quality": 95.0}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"output_quality": 95.0}, updated.ConfigOverrides)
+		assert.Equal(t, "A luxurious {style} {room}", *updated.PromptTemplate)
+	})
+
+	t.Run("fail: overrides checked against the new model", func(t *testing.T) {
+		repo := newRepo()
+		_, err := NewDefaultService(repo).UpdatePreset(ctx, "preset-1", UpdatePresetRequest{ModelID: strPtr(qwenModel)})
+
+		var invalid *modelconfig.ValidationError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "safety_tolerance", invalid.Fields[0].Field)
+		assert.Empty(t, repo.UpdateCalls())
+	})
+
+	t.Run("fail: not found", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id string) (*Preset, error) { return nil, ErrPresetNotFound },
+		}
+		_, err := NewDefaultService(repo).UpdatePreset(ctx, "preset-1", UpdatePresetRequest{Name: strPtr("x")})
+		assert.ErrorIs(t, err, ErrPresetNotFound)
+	})
+}
+
+func TestPreset_Render(t *testing.T) {
+	room, style := "living_room", "scandinavian"
+
+	p := &Preset{PromptTemplate: strPtr("A bright {style} {room} with oak floors")}
+	assert.Equal(t, "A bright scandinavian living room with oak floors", *p.Render(&room, &style))
+	assert.Equal(t, "A bright modern room with oak floors", *p.Render(nil, nil))
+
+	assert.Nil(t, (&Preset{}).Render(&room, &style))
+}
@@ -0,0 +1,24 @@
+package stylepreset
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for style presets. Any user may list
+// and read presets; only admins may change them.
+type Handler interface {
+	// CreatePreset handles POST /admin/style-presets - Adds a preset.
+	CreatePreset(c echo.Context) error
+
+	// ListPresets handles GET /style-presets - Lists every preset.
+	ListPresets(c echo.Context) error
+
+	// GetPreset handles GET /style-presets/:id - Gets a preset.
+	GetPreset(c echo.Context) error
+
+	// UpdatePreset handles PATCH /admin/style-presets/:id - Changes a preset.
+	UpdatePreset(c echo.Context) error
+
+	// DeletePreset handles DELETE /admin/style-presets/:id - Deletes a preset.
+	DeletePreset(c echo.Context) error
+}
@@ -0,0 +1,117 @@
+// Package stylepreset lets admins define named style presets.
+//
+// A preset bundles a staging model, an optional prompt template and overrides
+// of the model's config, such as "Luxury Modern - High Fidelity". Image
+// requests reference a preset by ID instead of setting the model, prompt and
+// config themselves. The template's {room} and {style} placeholders are filled
+// in from the request like a prompt template's, and the overrides are laid
+// over the admin's config for the model when the worker stages the image.
+package stylepreset
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/api/internal/prompttemplate"
+	"github.com/real-staging-ai/api/internal/settings"
+	"github.com/real-staging-ai/api/modelconfig"
+)
+
+// Limits on presets.
+const (
+	MaxNameLength        = 100
+	MaxDescriptionLength = 500
+)
+
+var (
+	// ErrPresetNotFound is returned when a preset does not exist.
+	ErrPresetNotFound = errors.New("style preset not found")
+	// ErrInvalidPreset is returned when a preset's definition is invalid.
+	// Invalid config overrides are reported as a *modelconfig.ValidationError.
+	ErrInvalidPreset = errors.New("invalid style preset")
+	// ErrDuplicateName is returned when another preset has the name.
+	ErrDuplicateName = errors.New("a style preset with this name already exists")
+)
+
+// Preset is a named combination of model, prompt template and model config.
+type Preset struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ModelID     string `json:"model_id"`
+	// PromptTemplate may contain {room} and {style} placeholders. Nil stages
+	// with the prompt library's prompt for the room type and style.
+	PromptTemplate *string `json:"prompt_template,omitempty"`
+	// ConfigOverrides are model config fields laid over the admin's config
+	// for ModelID.
+	ConfigOverrides map[string]interface{} `json:"config_overrides"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// CreatePresetRequest creates a preset.
+type CreatePresetRequest struct {
+	Name            string                 `json:"name"`
+	Description     string                 `json:"description"`
+	ModelID         string                 `json:"model_id"`
+	PromptTemplate  *string                `json:"prompt_template,omitempty"`
+	ConfigOverrides map[string]interface{} `json:"config_overrides,omitempty"`
+}
+
+// UpdatePresetRequest changes a preset. Nil fields are left unchanged; an
+// empty PromptTemplate removes the template and ConfigOverrides replaces the
+// overrides as a whole.
+type UpdatePresetRequest struct {
+	Name            *string                `json:"name,omitempty"`
+	Description     *string                `json:"description,omitempty"`
+	ModelID         *string                `json:"model_id,omitempty"`
+	PromptTemplate  *string                `json:"prompt_template,omitempty"`
+	ConfigOverrides map[string]interface{} `json:"config_overrides,omitempty"`
+}
+
+// Render returns the prompt the preset's template produces for a room type
+// and style, or nil when the preset has no template.
+func (p *Preset) Render(roomType, style *string) *string {
+	if p.PromptTemplate == nil {
+		return nil
+	}
+	prompt := (&prompttemplate.Template{Body: *p.PromptTemplate}).Render(roomType, style)
+	return &prompt
+}
+
+// normalize trims the preset's name and description and checks every field.
+// Overrides are checked against the config schema of the preset's model.
+func (p *Preset) normalize() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || utf8.RuneCountInString(p.Name) > MaxNameLength {
+		return fmt.Errorf("%w: name must be between 1 and %d characters", ErrInvalidPreset, MaxNameLength)
+	}
+	p.Description = strings.TrimSpace(p.Description)
+	if utf8.RuneCountInString(p.Description) > MaxDescriptionLength {
+		return fmt.Errorf("%w: description exceeds %d characters", ErrInvalidPreset, MaxDescriptionLength)
+	}
+	if !settings.IsAvailableModel(p.ModelID) {
+		return fmt.Errorf("%w: model_id must be one of: %s", ErrInvalidPreset,
+			strings.Join(settings.AvailableModelIDs(), ", "))
+	}
+	if p.PromptTemplate != nil {
+		if err := prompttemplate.ValidateBody(*p.PromptTemplate); err != nil {
+			return fmt.Errorf("%w: prompt_template: %w", ErrInvalidPreset, err)
+		}
+	}
+
+	if p.ConfigOverrides == nil {
+		p.ConfigOverrides = map[string]interface{}{}
+	}
+	if len(p.ConfigOverrides) == 0 {
+		return nil
+	}
+	schema, err := modelconfig.Get(p.ModelID)
+	if err != nil {
+		return fmt.Errorf("%w: %s has no configurable fields to override", ErrInvalidPreset, p.ModelID)
+	}
+	return schema.ValidateOverrides(p.ConfigOverrides)
+}
@@ -0,0 +1,23 @@
+package stylepreset
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for style presets.
+type Repository interface {
+	// Create inserts a new preset. Returns ErrDuplicateName if another preset has the name.
+	Create(ctx context.Context, p *Preset) (*Preset, error)
+
+	// GetByID retrieves a preset.
+	GetByID(ctx context.Context, id string) (*Preset, error)
+
+	// List returns every preset ordered by name.
+	List(ctx context.Context) ([]Preset, error)
+
+	// Update persists every field of a preset.
+	Update(ctx context.Context, p *Preset) (*Preset, error)
+
+	// Delete removes a preset.
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,26 @@
+package stylepreset
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for style presets.
+type Service interface {
+	// CreatePreset adds a preset. Returns a *modelconfig.ValidationError if
+	// its config overrides are invalid for its model.
+	CreatePreset(ctx context.Context, req CreatePresetRequest) (*Preset, error)
+
+	// ListPresets returns every preset ordered by name.
+	ListPresets(ctx context.Context) ([]Preset, error)
+
+	// GetPreset retrieves a preset.
+	GetPreset(ctx context.Context, id string) (*Preset, error)
+
+	// UpdatePreset changes a preset. The overrides are checked again when
+	// the model changes.
+	UpdatePreset(ctx context.Context, id string, req UpdatePresetRequest) (*Preset, error)
+
+	// DeletePreset removes a preset. Images already created from it keep
+	// their model, prompt and config.
+	DeletePreset(ctx context.Context, id string) error
+}
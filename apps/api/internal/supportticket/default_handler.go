@@ -0,0 +1,73 @@
+package supportticket
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the support ticket endpoint.
+type DefaultHandler struct {
+	service  Service
+	userRepo user.Repository
+	log      logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, userRepo user.Repository, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Create handles POST /api/v1/images/:id/support-ticket.
+func (h *DefaultHandler) Create(c echo.Context) error {
+	imageID := c.Param("id")
+	if _, err := uuid.Parse(imageID); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid image ID format"})
+	}
+
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "Invalid or missing JWT token"})
+	}
+	ctx := c.Request().Context()
+	userRow, err := h.userRepo.GetByAuth0Sub(ctx, auth0Sub)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "User not found"})
+	}
+
+	var req CreateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	ticket, err := h.service.Create(ctx, userRow.ID.String(), imageID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrImageNotFound):
+			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Image not found"})
+		case errors.Is(err, ErrMessageRequired), errors.Is(err, ErrMessageTooLong):
+			return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+		}
+		h.log.Error(ctx, "failed to create support ticket", "image_id", imageID, "error", err)
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_server_error",
+			Message: "Failed to create support ticket",
+		})
+	}
+	return c.JSON(http.StatusCreated, ticket)
+}
@@ -0,0 +1,97 @@
+package supportticket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func TestDefaultHandler_Create(t *testing.T) {
+	owner := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: owner, Valid: true}}, nil
+		},
+	}
+
+	cases := []struct {
+		name         string
+		imageID      string
+		body         string
+		createErr    error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: ticket created",
+			imageID:      imageID,
+			body:         `{"message":"The sofa came out blurry"}`,
+			expectedCode: http.StatusCreated,
+			expectBody:   `"id":"ticket-1"`,
+		},
+		{name: "fail: invalid image id", imageID: "nope", expectedCode: http.StatusBadRequest},
+		{name: "fail: malformed body", imageID: imageID, body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: image not found",
+			imageID:      imageID,
+			body:         `{"message":"help"}`,
+			createErr:    ErrImageNotFound,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "fail: validation",
+			imageID:      imageID,
+			body:         `{"message":""}`,
+			createErr:    ErrMessageRequired,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "message is required",
+		},
+		{
+			name:         "fail: service error",
+			imageID:      imageID,
+			body:         `{"message":"help"}`,
+			createErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, userID, imageID string, req CreateRequest) (*Ticket, error) {
+					assert.Equal(t, owner.String(), userID)
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Ticket{ID: "ticket-1", ImageID: imageID, Message: req.Message}, nil
+				},
+			}
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.imageID)
+
+			require.NoError(t, NewDefaultHandler(svc, userRepo, logging.Default()).Create(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
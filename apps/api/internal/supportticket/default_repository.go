@@ -0,0 +1,116 @@
+package supportticket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/imageevent"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+// GetSnapshot reads the image through its project so other users' images are not found.
+func (r *DefaultRepository) GetSnapshot(ctx context.Context, userID, imageID string) (*Snapshot, error) {
+	query := `
+		SELECT i.project_id::text, i.status::text, i.error, i.error_code, i.room_type, i.style, i.prompt,
+			i.model_used, i.replicate_prediction_id, i.sandbox, i.created_at, i.updated_at, i.deleted_at
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		WHERE i.id = $1 AND p.user_id = $2`
+
+	var s Snapshot
+	err := r.db.QueryRow(ctx, query, imageID, userID).Scan(
+		&s.ProjectID, &s.Status, &s.Error, &s.ErrorCode, &s.RoomType, &s.Style, &s.Prompt,
+		&s.ModelUsed, &s.ReplicatePredictionID, &s.Sandbox, &s.CreatedAt, &s.UpdatedAt, &s.DeletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrImageNotFound
+		}
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, image_id, status::text, source, prompt, model_used,
+			cost_usd::float8, processing_time_ms, error, created_at
+		FROM image_events
+		WHERE image_id = $1
+		ORDER BY created_at, id`, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list image events: %w", err)
+	}
+	defer rows.Close()
+
+	s.Events = []imageevent.Event{}
+	for rows.Next() {
+		var e imageevent.Event
+		var source string
+		if err := rows.Scan(
+			&e.ID, &e.ImageID, &e.Status, &source, &e.Prompt, &e.ModelUsed,
+			&e.CostUSD, &e.ProcessingTimeMs, &e.Error, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan image event: %w", err)
+		}
+		e.Source = imageevent.Source(source)
+		s.Events = append(s.Events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over image event rows: %w", err)
+	}
+	return &s, nil
+}
+
+// GetUserEmail returns the user's email.
+func (r *DefaultRepository) GetUserEmail(ctx context.Context, userID string) (string, error) {
+	var email string
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(email, '') FROM users WHERE id = $1`, userID).Scan(&email)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user email: %w", err)
+	}
+	return email, nil
+}
+
+// Create inserts the ticket with its snapshot stored as JSON.
+func (r *DefaultRepository) Create(ctx context.Context, ticket *Ticket) error {
+	snapshot, err := json.Marshal(ticket.Run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal ticket snapshot: %w", err)
+	}
+
+	query := `
+		INSERT INTO support_tickets (user_id, image_id, message, snapshot, prediction_logs)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id::text, created_at`
+	err = r.db.QueryRow(ctx, query,
+		ticket.UserID, ticket.ImageID, ticket.Message, snapshot, ticket.PredictionLogs,
+	).Scan(&ticket.ID, &ticket.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create support ticket: %w", err)
+	}
+	return nil
+}
+
+// SetDelivery links the ticket to the delivery forwarding it.
+func (r *DefaultRepository) SetDelivery(ctx context.Context, ticketID, deliveryID string) error {
+	_, err := r.db.Exec(ctx, `UPDATE support_tickets SET delivery_id = $2 WHERE id = $1`, ticketID, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to set support ticket delivery: %w", err)
+	}
+	return nil
+}
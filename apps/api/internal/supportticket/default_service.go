@@ -0,0 +1,106 @@
+package supportticket
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo       Repository
+	logs       PredictionLogs
+	deliveries delivery.Service
+	webhookURL string
+	log        logging.Logger
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. Tickets are forwarded to
+// webhookURL through the delivery outbox; an empty URL only stores them.
+func NewDefaultService(
+	repo Repository, logs PredictionLogs, deliveries delivery.Service, webhookURL string, log logging.Logger,
+) *DefaultService {
+	return &DefaultService{repo: repo, logs: logs, deliveries: deliveries, webhookURL: webhookURL, log: log}
+}
+
+// Create snapshots the image, attaches its prediction logs and stores the
+// ticket, then forwards it to the helpdesk. Missing logs and a failed forward
+// are logged rather than returned: the ticket is already saved and support can
+// still find it.
+func (s *DefaultService) Create(ctx context.Context, userID, imageID string, req CreateRequest) (*Ticket, error) {
+	message := strings.TrimSpace(req.Message)
+	switch {
+	case message == "":
+		return nil, ErrMessageRequired
+	case utf8.RuneCountInString(message) > MaxMessageLength:
+		return nil, ErrMessageTooLong
+	}
+
+	snapshot, err := s.repo.GetSnapshot(ctx, userID, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := &Ticket{
+		UserID:         userID,
+		ImageID:        imageID,
+		Message:        message,
+		Run:            *snapshot,
+		PredictionLogs: s.predictionLogs(ctx, snapshot),
+	}
+	if err := s.repo.Create(ctx, ticket); err != nil {
+		return nil, err
+	}
+
+	if s.webhookURL != "" {
+		ticket.Forwarded = s.forward(ctx, ticket)
+	}
+	return ticket, nil
+}
+
+// predictionLogs returns the tail of the logs of the image's last prediction.
+// Sandbox images are staged by the fake provider, which has no logs.
+func (s *DefaultService) predictionLogs(ctx context.Context, snapshot *Snapshot) *string {
+	if snapshot.Sandbox || snapshot.ReplicatePredictionID == nil || *snapshot.ReplicatePredictionID == "" {
+		return nil
+	}
+	logs, err := s.logs.Logs(ctx, *snapshot.ReplicatePredictionID)
+	if err != nil {
+		s.log.Warn(ctx, "failed to fetch prediction logs for support ticket",
+			"prediction_id", *snapshot.ReplicatePredictionID, "error", err)
+		return nil
+	}
+	if logs = tail(logs); logs == "" {
+		return nil
+	}
+	return &logs
+}
+
+// forward queues the ticket for the helpdesk webhook and reports whether it was queued.
+func (s *DefaultService) forward(ctx context.Context, ticket *Ticket) bool {
+	reporter := Reporter{UserID: ticket.UserID}
+	email, err := s.repo.GetUserEmail(ctx, ticket.UserID)
+	if err != nil {
+		s.log.Warn(ctx, "failed to get support ticket reporter email", "ticket_id", ticket.ID, "error", err)
+	}
+	reporter.Email = email
+
+	forwarded := *ticket
+	forwarded.Forwarded = true
+	payload := ForwardPayload{Type: EventTypeTicketCreated, Ticket: &forwarded, Reporter: reporter}
+	d, err := s.deliveries.EnqueueWebhook(ctx, s.webhookURL, EventTypeTicketCreated, payload)
+	if err != nil {
+		s.log.Error(ctx, "failed to forward support ticket", "ticket_id", ticket.ID, "error", err)
+		return false
+	}
+	if err := s.repo.SetDelivery(ctx, ticket.ID, d.ID); err != nil {
+		s.log.Error(ctx, "failed to record support ticket delivery", "ticket_id", ticket.ID, "error", err)
+	}
+	return true
+}
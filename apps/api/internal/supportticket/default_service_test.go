@@ -0,0 +1,175 @@
+package supportticket
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+const (
+	userID  = "11111111-1111-1111-1111-111111111111"
+	imageID = "22222222-2222-2222-2222-222222222222"
+)
+
+func strPtr(s string) *string { return &s }
+
+func newRepo(snapshot *Snapshot) *RepositoryMock {
+	return &RepositoryMock{
+		GetSnapshotFunc: func(ctx context.Context, userID, imageID string) (*Snapshot, error) {
+			if snapshot == nil {
+				return nil, ErrImageNotFound
+			}
+			return snapshot, nil
+		},
+		GetUserEmailFunc: func(ctx context.Context, userID string) (string, error) {
+			return "agent@example.com", nil
+		},
+		CreateFunc: func(ctx context.Context, ticket *Ticket) error {
+			ticket.ID = "ticket-1"
+			return nil
+		},
+		SetDeliveryFunc: func(ctx context.Context, ticketID, deliveryID string) error { return nil },
+	}
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	failed := &Snapshot{
+		Status:                "error",
+		Error:                 strPtr("prediction failed"),
+		ReplicatePredictionID: strPtr("pred-1"),
+	}
+	logs := &PredictionLogsMock{
+		LogsFunc: func(ctx context.Context, predictionID string) (string, error) {
+			return "loading model\nCUDA out of memory\n", nil
+		},
+	}
+
+	t.Run("success: stores the snapshot and log tail and forwards", func(t *testing.T) {
+		repo := newRepo(failed)
+		var forwarded ForwardPayload
+		deliveries := &delivery.ServiceMock{
+			EnqueueWebhookFunc: func(
+				ctx context.Context, endpoint, eventType string, payload interface{},
+			) (*delivery.Delivery, error) {
+				assert.Equal(t, "https://helpdesk.example.com/hook", endpoint)
+				assert.Equal(t, EventTypeTicketCreated, eventType)
+				forwarded = payload.(ForwardPayload)
+				return &delivery.Delivery{ID: "delivery-1"}, nil
+			},
+		}
+		svc := NewDefaultService(repo, logs, deliveries, "https://helpdesk.example.com/hook", logging.Default())
+
+		ticket, err := svc.Create(context.Background(), userID, imageID, CreateRequest{Message: "  It failed twice  "})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ticket-1", ticket.ID)
+		assert.Equal(t, "It failed twice", ticket.Message)
+		assert.Equal(t, "error", ticket.Run.Status)
+		require.NotNil(t, ticket.PredictionLogs)
+		assert.Equal(t, "loading model\nCUDA out of memory", *ticket.PredictionLogs)
+		assert.True(t, ticket.Forwarded)
+
+		require.Len(t, repo.CreateCalls(), 1)
+		assert.Equal(t, userID, repo.CreateCalls()[0].Ticket.UserID)
+		require.Len(t, repo.SetDeliveryCalls(), 1)
+		assert.Equal(t, "delivery-1", repo.SetDeliveryCalls()[0].DeliveryID)
+		assert.Equal(t, Reporter{UserID: userID, Email: "agent@example.com"}, forwarded.Reporter)
+		assert.True(t, forwarded.Ticket.Forwarded)
+	})
+
+	t.Run("success: stored only without a helpdesk webhook", func(t *testing.T) {
+		repo := newRepo(failed)
+		svc := NewDefaultService(repo, logs, &delivery.ServiceMock{}, "", logging.Default())
+
+		ticket, err := svc.Create(context.Background(), userID, imageID, CreateRequest{Message: "help"})
+
+		require.NoError(t, err)
+		assert.False(t, ticket.Forwarded)
+		assert.Empty(t, repo.SetDeliveryCalls())
+	})
+
+	t.Run("success: failed forward keeps the ticket", func(t *testing.T) {
+		deliveries := &delivery.ServiceMock{
+			EnqueueWebhookFunc: func(
+				ctx context.Context, endpoint, eventType string, payload interface{},
+			) (*delivery.Delivery, error) {
+				return nil, errors.New("outbox down")
+			},
+		}
+		svc := NewDefaultService(newRepo(failed), logs, deliveries, "https://helpdesk.example.com/hook", logging.Default())
+
+		ticket, err := svc.Create(context.Background(), userID, imageID, CreateRequest{Message: "help"})
+
+		require.NoError(t, err)
+		assert.False(t, ticket.Forwarded)
+	})
+
+	t.Run("success: logs that can't be fetched are left out", func(t *testing.T) {
+		broken := &PredictionLogsMock{
+			LogsFunc: func(ctx context.Context, predictionID string) (string, error) {
+				return "", ErrLogsNotConfigured
+			},
+		}
+		svc := NewDefaultService(newRepo(failed), broken, &delivery.ServiceMock{}, "", logging.Default())
+
+		ticket, err := svc.Create(context.Background(), userID, imageID, CreateRequest{Message: "help"})
+
+		require.NoError(t, err)
+		assert.Nil(t, ticket.PredictionLogs)
+	})
+
+	t.Run("success: sandbox images have no logs to fetch", func(t *testing.T) {
+		sandbox := &Snapshot{Status: "error", Sandbox: true, ReplicatePredictionID: strPtr("fake-1")}
+		svc := NewDefaultService(newRepo(sandbox), &PredictionLogsMock{}, &delivery.ServiceMock{}, "", logging.Default())
+
+		ticket, err := svc.Create(context.Background(), userID, imageID, CreateRequest{Message: "help"})
+
+		require.NoError(t, err)
+		assert.Nil(t, ticket.PredictionLogs)
+	})
+
+	t.Run("fail: validation", func(t *testing.T) {
+		svc := NewDefaultService(&RepositoryMock{}, logs, &delivery.ServiceMock{}, "", logging.Default())
+
+		_, err := svc.Create(context.Background(), userID, imageID, CreateRequest{Message: " \n "})
+		assert.ErrorIs(t, err, ErrMessageRequired)
+
+		_, err = svc.Create(context.Background(), userID, imageID,
+			CreateRequest{Message: strings.Repeat("é", MaxMessageLength+1)})
+		assert.ErrorIs(t, err, ErrMessageTooLong)
+	})
+
+	t.Run("fail: image not found", func(t *testing.T) {
+		svc := NewDefaultService(newRepo(nil), logs, &delivery.ServiceMock{}, "", logging.Default())
+
+		_, err := svc.Create(context.Background(), userID, imageID, CreateRequest{Message: "help"})
+		assert.ErrorIs(t, err, ErrImageNotFound)
+	})
+}
+
+func TestTail(t *testing.T) {
+	var lines []string
+	for i := 0; i < 80; i++ {
+		lines = append(lines, "step "+strings.Repeat("x", i))
+	}
+	got := tail(strings.Join(lines, "\n") + "\n")
+	assert.Len(t, strings.Split(got, "\n"), logTailLines)
+	assert.True(t, strings.HasPrefix(got, "step "+strings.Repeat("x", 80-logTailLines)+"\n"))
+
+	long := strings.Repeat("é", logTailBytes) + "\nlast line"
+	got = tail(long)
+	assert.Equal(t, "last line", got)
+
+	oneLine := strings.Repeat("é", logTailBytes)
+	got = tail(oneLine)
+	assert.LessOrEqual(t, len(got), logTailBytes)
+	assert.True(t, utf8.ValidString(got))
+}
@@ -0,0 +1,96 @@
+package supportticket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out logs_mock.go . PredictionLogs
+
+// ErrLogsNotConfigured is returned when no Replicate API token is configured.
+var ErrLogsNotConfigured = errors.New("replicate api token not configured")
+
+// PredictionLogs fetches the logs a model wrote while running a prediction.
+type PredictionLogs interface {
+	// Logs returns the prediction's full logs.
+	Logs(ctx context.Context, predictionID string) (string, error)
+}
+
+// ReplicateLogs reads prediction logs through the Replicate HTTP API.
+type ReplicateLogs struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// Ensure ReplicateLogs implements PredictionLogs.
+var _ PredictionLogs = (*ReplicateLogs)(nil)
+
+// NewReplicateLogs creates a ReplicateLogs from the Replicate config.
+func NewReplicateLogs(cfg config.Replicate) *ReplicateLogs {
+	return &ReplicateLogs{
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		token:   cfg.APIToken,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Logs returns the logs of the prediction, which Replicate keeps with it.
+func (l *ReplicateLogs) Logs(ctx context.Context, predictionID string) (string, error) {
+	if l.token == "" {
+		return "", ErrLogsNotConfigured
+	}
+
+	endpoint := fmt.Sprintf("%s/predictions/%s", l.baseURL, url.PathEscape(predictionID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build prediction request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+l.token)
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get prediction: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get prediction: replicate returned %d", resp.StatusCode)
+	}
+
+	var prediction struct {
+		Logs string `json:"logs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&prediction); err != nil {
+		return "", fmt.Errorf("failed to decode prediction: %w", err)
+	}
+	return prediction.Logs, nil
+}
+
+// tail returns the last logTailLines lines of logs, cut to at most
+// logTailBytes at a line boundary where possible.
+func tail(logs string) string {
+	logs = strings.TrimRight(logs, "\n")
+	lines := strings.Split(logs, "\n")
+	if len(lines) > logTailLines {
+		lines = lines[len(lines)-logTailLines:]
+	}
+	out := strings.Join(lines, "\n")
+	if len(out) <= logTailBytes {
+		return out
+	}
+	// The cut may land inside a character as well as a line.
+	out = strings.ToValidUTF8(out[len(out)-logTailBytes:], "")
+	if i := strings.IndexByte(out, '\n'); i >= 0 && i < len(out)-1 {
+		return out[i+1:]
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package supportticket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/config"
+)
+
+func TestReplicateLogs_Logs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer r8_test", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/predictions/pred-1":
+			_, _ = w.Write([]byte(`{"id":"pred-1","status":"failed","logs":"loading model\nCUDA out of memory\n"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	l := NewReplicateLogs(config.Replicate{APIToken: "r8_test", BaseURL: srv.URL + "/"})
+
+	t.Run("success: returns the prediction's logs", func(t *testing.T) {
+		logs, err := l.Logs(context.Background(), "pred-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "loading model\nCUDA out of memory\n", logs)
+	})
+
+	t.Run("fail: unknown prediction", func(t *testing.T) {
+		_, err := l.Logs(context.Background(), "pred-missing")
+		assert.ErrorContains(t, err, "replicate returned 404")
+	})
+
+	t.Run("fail: no token", func(t *testing.T) {
+		_, err := NewReplicateLogs(config.Replicate{BaseURL: srv.URL}).Logs(context.Background(), "pred-1")
+		assert.ErrorIs(t, err, ErrLogsNotConfigured)
+	})
+}
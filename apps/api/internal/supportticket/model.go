@@ -0,0 +1,87 @@
+// Package supportticket lets users report a failed image to support from the
+// image itself.
+//
+// A ticket bundles the user's message with a snapshot of the image and its
+// staging history and the tail of the model's prediction logs, taken when the
+// ticket is opened. When a helpdesk webhook is configured the ticket is also
+// forwarded there through the delivery outbox.
+package supportticket
+
+import (
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/imageevent"
+)
+
+var (
+	// ErrImageNotFound is returned when the image does not exist or belongs to another user.
+	ErrImageNotFound = errors.New("image not found")
+	// ErrMessageRequired is returned when the ticket has no message.
+	ErrMessageRequired = errors.New("message is required")
+	// ErrMessageTooLong is returned when the message exceeds MaxMessageLength.
+	ErrMessageTooLong = errors.New("message must be at most 4000 characters")
+)
+
+const (
+	// MaxMessageLength is the longest message a ticket accepts, in characters.
+	MaxMessageLength = 4000
+	// EventTypeTicketCreated is the event type of tickets forwarded to the helpdesk.
+	EventTypeTicketCreated = "support.ticket_created"
+
+	// logTailLines is how many of the last prediction log lines a ticket keeps.
+	logTailLines = 50
+	// logTailBytes caps the kept log tail for models that log long lines.
+	logTailBytes = 8 << 10
+)
+
+// CreateRequest is the body of a new ticket.
+type CreateRequest struct {
+	Message string `json:"message"`
+}
+
+// Ticket is a support request about one image.
+type Ticket struct {
+	ID      string   `json:"id"`
+	UserID  string   `json:"-"`
+	ImageID string   `json:"image_id"`
+	Message string   `json:"message"`
+	Run     Snapshot `json:"run"`
+	// PredictionLogs is the tail of the model's logs for the image's last
+	// prediction; nil when there was none or the logs couldn't be fetched.
+	PredictionLogs *string `json:"prediction_logs,omitempty"`
+	// Forwarded reports whether the ticket was queued for the helpdesk webhook.
+	Forwarded bool      `json:"forwarded"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Snapshot is the image's staging state when a ticket was opened.
+type Snapshot struct {
+	ProjectID             string             `json:"project_id"`
+	Status                string             `json:"status"`
+	Error                 *string            `json:"error,omitempty"`
+	ErrorCode             *string            `json:"error_code,omitempty"`
+	RoomType              *string            `json:"room_type,omitempty"`
+	Style                 *string            `json:"style,omitempty"`
+	Prompt                *string            `json:"prompt,omitempty"`
+	ModelUsed             *string            `json:"model_used,omitempty"`
+	ReplicatePredictionID *string            `json:"replicate_prediction_id,omitempty"`
+	Sandbox               bool               `json:"sandbox"`
+	CreatedAt             time.Time          `json:"created_at"`
+	UpdatedAt             time.Time          `json:"updated_at"`
+	DeletedAt             *time.Time         `json:"deleted_at,omitempty"`
+	Events                []imageevent.Event `json:"events"`
+}
+
+// ForwardPayload is the body POSTed to the helpdesk webhook.
+type ForwardPayload struct {
+	Type     string   `json:"type"`
+	Ticket   *Ticket  `json:"ticket"`
+	Reporter Reporter `json:"reporter"`
+}
+
+// Reporter identifies who opened a ticket, so the helpdesk can reply.
+type Reporter struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email,omitempty"`
+}
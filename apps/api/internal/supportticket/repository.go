@@ -0,0 +1,22 @@
+package supportticket
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for support tickets.
+type Repository interface {
+	// GetSnapshot returns the image's current state and staging history. Trashed
+	// images are included. Returns ErrImageNotFound if the image is missing or
+	// not in one of the user's projects.
+	GetSnapshot(ctx context.Context, userID, imageID string) (*Snapshot, error)
+
+	// GetUserEmail returns the user's email, or "" when none is on file.
+	GetUserEmail(ctx context.Context, userID string) (string, error)
+
+	// Create stores the ticket and sets its ID and CreatedAt.
+	Create(ctx context.Context, ticket *Ticket) error
+
+	// SetDelivery records the outbox delivery forwarding the ticket.
+	SetDelivery(ctx context.Context, ticketID, deliveryID string) error
+}
@@ -0,0 +1,13 @@
+package supportticket
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for support tickets.
+type Service interface {
+	// Create opens a ticket about one of the user's images. Returns
+	// ErrImageNotFound if the image is missing or belongs to another user, and
+	// ErrMessageRequired or ErrMessageTooLong for an unusable message.
+	Create(ctx context.Context, userID, imageID string, req CreateRequest) (*Ticket, error)
+}
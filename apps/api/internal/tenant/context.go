@@ -0,0 +1,23 @@
+package tenant
+
+import "context"
+
+type modeKey struct{}
+
+// WithMode returns a copy of ctx carrying the account mode.
+func WithMode(ctx context.Context, mode Mode) context.Context {
+	return context.WithValue(ctx, modeKey{}, mode)
+}
+
+// FromContext returns the account mode stored in ctx, defaulting to ModeLive.
+func FromContext(ctx context.Context) Mode {
+	if mode, ok := ctx.Value(modeKey{}).(Mode); ok {
+		return mode
+	}
+	return ModeLive
+}
+
+// IsSandbox reports whether ctx belongs to a sandbox account.
+func IsSandbox(ctx context.Context) bool {
+	return FromContext(ctx) == ModeSandbox
+}
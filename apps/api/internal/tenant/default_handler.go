@@ -0,0 +1,81 @@
+package tenant
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// DefaultHandler serves the admin account-mode endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// SetAccountModeRequest is the body of PUT /admin/users/:id/account-mode.
+type SetAccountModeRequest struct {
+	Mode Mode `json:"mode"`
+}
+
+// GetAccountMode handles GET /admin/users/:id/account-mode - Returns the account mode.
+func (h *DefaultHandler) GetAccountMode(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Param("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	account, err := h.service.GetAccount(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "User not found")
+		}
+		h.log.Error(ctx, "failed to get account", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get account")
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+// SetAccountMode handles PUT /admin/users/:id/account-mode - Switches live/sandbox.
+func (h *DefaultHandler) SetAccountMode(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID := c.Param("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req SetAccountModeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	account, err := h.service.SetMode(ctx, userID, req.Mode)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidMode):
+			return echo.NewHTTPError(http.StatusBadRequest, "mode must be live or sandbox")
+		case errors.Is(err, ErrAccountNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "User not found")
+		case errors.Is(err, ErrOpenSubscriptions):
+			return echo.NewHTTPError(http.StatusConflict, "Cancel open subscriptions before switching account mode")
+		}
+		h.log.Error(ctx, "failed to set account mode", "error", err, "user_id", userID)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to set account mode")
+	}
+
+	h.log.Info(ctx, "account mode updated", "user_id", userID, "mode", account.Mode)
+	return c.JSON(http.StatusOK, account)
+}
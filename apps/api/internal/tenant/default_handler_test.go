@@ -0,0 +1,132 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestDefaultHandler_GetAccountMode(t *testing.T) {
+	userID := uuid.NewString()
+
+	cases := []struct {
+		name       string
+		userID     string
+		getErr     error
+		wantStatus int
+	}{
+		{name: "success: returns account", userID: userID, wantStatus: http.StatusOK},
+		{name: "fail: invalid user id", userID: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: not found", userID: userID, getErr: ErrAccountNotFound, wantStatus: http.StatusNotFound},
+		{
+			name: "fail: service error", userID: userID,
+			getErr: errors.New("db down"), wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				GetAccountFunc: func(ctx context.Context, id string) (*Account, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Account{UserID: id, Mode: ModeSandbox}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.userID)
+
+			err := h.GetAccountMode(c)
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"mode":"sandbox"`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_SetAccountMode(t *testing.T) {
+	userID := uuid.NewString()
+
+	cases := []struct {
+		name       string
+		userID     string
+		body       string
+		setErr     error
+		wantStatus int
+	}{
+		{name: "success: switches to sandbox", userID: userID, body: `{"mode":"sandbox"}`, wantStatus: http.StatusOK},
+		{name: "fail: invalid user id", userID: "nope", body: `{"mode":"sandbox"}`, wantStatus: http.StatusBadRequest},
+		{name: "fail: malformed body", userID: userID, body: `{`, wantStatus: http.StatusBadRequest},
+		{
+			name: "fail: invalid mode", userID: userID, body: `{"mode":"demo"}`,
+			setErr: ErrInvalidMode, wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "fail: not found", userID: userID, body: `{"mode":"sandbox"}`,
+			setErr: ErrAccountNotFound, wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "fail: open subscriptions", userID: userID, body: `{"mode":"sandbox"}`,
+			setErr: ErrOpenSubscriptions, wantStatus: http.StatusConflict,
+		},
+		{
+			name: "fail: service error", userID: userID, body: `{"mode":"sandbox"}`,
+			setErr: errors.New("db down"), wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				SetModeFunc: func(ctx context.Context, id string, mode Mode) (*Account, error) {
+					if tc.setErr != nil {
+						return nil, tc.setErr
+					}
+					return &Account{UserID: id, Mode: mode}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.userID)
+
+			err := h.SetAccountMode(c)
+			if tc.wantStatus == http.StatusOK {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"mode":"sandbox"`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const accountColumns = `
+	id::text, auth0_sub, account_mode, stripe_customer_id, stripe_test_clock_id, updated_at`
+
+func scanAccount(row pgx.Row) (*Account, error) {
+	var a Account
+	var mode string
+	err := row.Scan(&a.UserID, &a.Auth0Sub, &mode, &a.StripeCustomerID, &a.StripeTestClockID, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	a.Mode = Mode(mode)
+	return &a, nil
+}
+
+// GetByAuth0Sub returns the account for an Auth0 subject.
+func (r *DefaultRepository) GetByAuth0Sub(ctx context.Context, auth0Sub string) (*Account, error) {
+	query := `SELECT` + accountColumns + `
+		FROM users
+		WHERE auth0_sub = $1`
+
+	a, err := scanAccount(r.db.QueryRow(ctx, query, auth0Sub))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	return a, nil
+}
+
+// GetByUserID returns the account for a user ID.
+func (r *DefaultRepository) GetByUserID(ctx context.Context, userID string) (*Account, error) {
+	query := `SELECT` + accountColumns + `
+		FROM users
+		WHERE id = $1`
+
+	a, err := scanAccount(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	return a, nil
+}
+
+// HasOpenSubscriptions reports whether the user has a subscription that is still billing.
+func (r *DefaultRepository) HasOpenSubscriptions(ctx context.Context, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM subscriptions
+			WHERE user_id = $1 AND status IN ('active', 'trialing', 'past_due', 'unpaid')
+		)`
+
+	var open bool
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&open); err != nil {
+		return false, fmt.Errorf("failed to check open subscriptions: %w", err)
+	}
+	return open, nil
+}
+
+// SetMode changes the account mode and clears the Stripe customer and test clock.
+func (r *DefaultRepository) SetMode(ctx context.Context, userID string, mode Mode) (*Account, error) {
+	query := `
+		UPDATE users
+		SET account_mode = $2,
+			stripe_customer_id = NULL,
+			stripe_test_clock_id = NULL,
+			updated_at = now()
+		WHERE id = $1
+		RETURNING` + accountColumns
+
+	a, err := scanAccount(r.db.QueryRow(ctx, query, userID, string(mode)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to set account mode: %w", err)
+	}
+	return a, nil
+}
+
+// SetTestClock records the Stripe test clock bound to a sandbox customer.
+func (r *DefaultRepository) SetTestClock(ctx context.Context, userID, testClockID string) error {
+	query := `
+		UPDATE users
+		SET stripe_test_clock_id = $2, updated_at = now()
+		WHERE id = $1 AND account_mode = 'sandbox'`
+
+	tag, err := r.db.Exec(ctx, query, userID, testClockID)
+	if err != nil {
+		return fmt.Errorf("failed to set stripe test clock: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAccountNotFound
+	}
+	return nil
+}
+
+// SetTestCustomer replaces a sandbox user's Stripe customer and test clock together.
+func (r *DefaultRepository) SetTestCustomer(ctx context.Context, userID, customerID, testClockID string) error {
+	query := `
+		UPDATE users
+		SET stripe_customer_id = NULLIF($2, ''),
+			stripe_test_clock_id = NULLIF($3, ''),
+			updated_at = now()
+		WHERE id = $1 AND account_mode = 'sandbox'`
+
+	tag, err := r.db.Exec(ctx, query, userID, customerID, testClockID)
+	if err != nil {
+		return fmt.Errorf("failed to set stripe test customer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAccountNotFound
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultService implements Service.
+type DefaultService struct {
+	repo Repository
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository) *DefaultService {
+	return &DefaultService{repo: repo}
+}
+
+// GetAccount returns the account for a user ID.
+func (s *DefaultService) GetAccount(ctx context.Context, userID string) (*Account, error) {
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+// SetMode switches an account between live and sandbox. Setting the current mode
+// is a no-op. Switching is refused while a subscription is still billing, because
+// the Stripe customer behind it belongs to the mode being left and is cleared.
+func (s *DefaultService) SetMode(ctx context.Context, userID string, mode Mode) (*Account, error) {
+	if !mode.Valid() {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidMode, mode)
+	}
+
+	current, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Mode == mode {
+		return current, nil
+	}
+
+	open, err := s.repo.HasOpenSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, ErrOpenSubscriptions
+	}
+
+	return s.repo.SetMode(ctx, userID, mode)
+}
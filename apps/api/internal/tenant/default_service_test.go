@@ -0,0 +1,59 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultService_SetMode(t *testing.T) {
+	cases := []struct {
+		name        string
+		mode        Mode
+		current     Mode
+		getErr      error
+		open        bool
+		wantErr     error
+		wantSetMode bool
+	}{
+		{name: "success: switches live to sandbox", mode: ModeSandbox, current: ModeLive, wantSetMode: true},
+		{name: "success: same mode is a no-op", mode: ModeSandbox, current: ModeSandbox},
+		{name: "fail: invalid mode", mode: "demo", wantErr: ErrInvalidMode},
+		{name: "fail: account not found", mode: ModeSandbox, getErr: ErrAccountNotFound, wantErr: ErrAccountNotFound},
+		{name: "fail: open subscriptions", mode: ModeSandbox, current: ModeLive, open: true, wantErr: ErrOpenSubscriptions},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				GetByUserIDFunc: func(ctx context.Context, userID string) (*Account, error) {
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &Account{UserID: userID, Mode: tc.current}, nil
+				},
+				HasOpenSubscriptionsFunc: func(ctx context.Context, userID string) (bool, error) {
+					return tc.open, nil
+				},
+				SetModeFunc: func(ctx context.Context, userID string, mode Mode) (*Account, error) {
+					return &Account{UserID: userID, Mode: mode}, nil
+				},
+			}
+			svc := NewDefaultService(repo)
+
+			account, err := svc.SetMode(context.Background(), "u1", tc.mode)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tc.wantErr))
+				assert.Empty(t, repo.SetModeCalls())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.mode, account.Mode)
+			assert.Equal(t, tc.wantSetMode, len(repo.SetModeCalls()) == 1)
+		})
+	}
+}
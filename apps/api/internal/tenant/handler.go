@@ -0,0 +1,14 @@
+package tenant
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the admin HTTP endpoints for account modes.
+type Handler interface {
+	// GetAccountMode handles GET /admin/users/:id/account-mode - Returns the account mode.
+	GetAccountMode(c echo.Context) error
+
+	// SetAccountMode handles PUT /admin/users/:id/account-mode - Switches live/sandbox.
+	SetAccountMode(c echo.Context) error
+}
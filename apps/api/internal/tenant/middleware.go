@@ -0,0 +1,46 @@
+package tenant
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+// HeaderAccountMode is set on responses to sandbox requests so integrators can
+// confirm they are not touching live data.
+const HeaderAccountMode = "X-Account-Mode"
+
+// Middleware resolves the caller's account mode and stores it in the request
+// context. Callers without a users row yet are live. Lookup failures reject the
+// request rather than risk treating a sandbox tenant as live.
+func Middleware(repo Repository, log logging.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			auth0Sub, err := auth.GetUserIDOrDefault(c)
+			if err != nil || auth0Sub == "" {
+				return next(c)
+			}
+
+			req := c.Request()
+			mode := ModeLive
+			account, err := repo.GetByAuth0Sub(req.Context(), auth0Sub)
+			switch {
+			case err == nil:
+				mode = account.Mode
+			case !errors.Is(err, ErrAccountNotFound):
+				log.Error(req.Context(), "failed to resolve account mode", "error", err)
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to resolve account")
+			}
+
+			if mode == ModeSandbox {
+				c.Response().Header().Set(HeaderAccountMode, string(mode))
+			}
+			c.SetRequest(req.WithContext(WithMode(req.Context(), mode)))
+			return next(c)
+		}
+	}
+}
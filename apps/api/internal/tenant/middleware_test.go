@@ -0,0 +1,73 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+)
+
+func TestMiddleware(t *testing.T) {
+	cases := []struct {
+		name       string
+		account    *Account
+		lookupErr  error
+		wantMode   Mode
+		wantHeader string
+		wantErr    bool
+	}{
+		{name: "success: live account", account: &Account{Mode: ModeLive}, wantMode: ModeLive},
+		{
+			name: "success: sandbox account", account: &Account{Mode: ModeSandbox},
+			wantMode: ModeSandbox, wantHeader: "sandbox",
+		},
+		{name: "success: unknown user is live", lookupErr: ErrAccountNotFound, wantMode: ModeLive},
+		{name: "fail: lookup error", lookupErr: errors.New("db down"), wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{
+				GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*Account, error) {
+					assert.Equal(t, "auth0|sandbox", auth0Sub)
+					return tc.account, tc.lookupErr
+				},
+			}
+
+			var gotMode Mode
+			next := func(c echo.Context) error {
+				gotMode = FromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Test-User", "auth0|sandbox")
+			rec := httptest.NewRecorder()
+
+			err := Middleware(repo, logging.Default())(next)(e.NewContext(req, rec))
+			if tc.wantErr {
+				var he *echo.HTTPError
+				require.ErrorAs(t, err, &he)
+				assert.Equal(t, http.StatusInternalServerError, he.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMode, gotMode)
+			assert.Equal(t, tc.wantHeader, rec.Header().Get(HeaderAccountMode))
+		})
+	}
+}
+
+func TestFromContext_defaultsToLive(t *testing.T) {
+	assert.Equal(t, ModeLive, FromContext(context.Background()))
+	assert.False(t, IsSandbox(context.Background()))
+	assert.True(t, IsSandbox(WithMode(context.Background(), ModeSandbox)))
+}
@@ -0,0 +1,43 @@
+// Package tenant resolves the account mode of the calling user and carries it
+// through request contexts so billing, usage and staging can keep sandbox
+// tenants isolated from live data and live spend.
+package tenant
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrAccountNotFound is returned when no user matches the lookup.
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrInvalidMode is returned when a mode other than live or sandbox is requested.
+	ErrInvalidMode = errors.New("invalid account mode")
+	// ErrOpenSubscriptions is returned when switching modes would orphan an active subscription.
+	ErrOpenSubscriptions = errors.New("account has open subscriptions")
+)
+
+// Mode is the tenant-wide account mode.
+type Mode string
+
+const (
+	// ModeLive uses live Stripe billing and the configured staging model.
+	ModeLive Mode = "live"
+	// ModeSandbox uses Stripe test mode with test clocks and the fake staging provider.
+	ModeSandbox Mode = "sandbox"
+)
+
+// Valid reports whether m is a known mode.
+func (m Mode) Valid() bool {
+	return m == ModeLive || m == ModeSandbox
+}
+
+// Account is the tenant view of a user row.
+type Account struct {
+	UserID            string    `json:"user_id"`
+	Auth0Sub          string    `json:"auth0_sub"`
+	Mode              Mode      `json:"mode"`
+	StripeCustomerID  *string   `json:"stripe_customer_id,omitempty"`
+	StripeTestClockID *string   `json:"stripe_test_clock_id,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package tenant
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for account modes.
+type Repository interface {
+	// GetByAuth0Sub returns the account for an Auth0 subject.
+	GetByAuth0Sub(ctx context.Context, auth0Sub string) (*Account, error)
+
+	// GetByUserID returns the account for a user ID.
+	GetByUserID(ctx context.Context, userID string) (*Account, error)
+
+	// HasOpenSubscriptions reports whether the user has a subscription that is still billing.
+	HasOpenSubscriptions(ctx context.Context, userID string) (bool, error)
+
+	// SetMode changes the account mode and clears the Stripe customer and test clock,
+	// which belong to the previous mode's Stripe account.
+	SetMode(ctx context.Context, userID string, mode Mode) (*Account, error)
+
+	// SetTestClock records the Stripe test clock bound to a sandbox customer.
+	SetTestClock(ctx context.Context, userID, testClockID string) error
+
+	// SetTestCustomer replaces a sandbox user's Stripe customer and test clock together.
+	// Empty IDs clear the columns.
+	SetTestCustomer(ctx context.Context, userID, customerID, testClockID string) error
+}
@@ -0,0 +1,14 @@
+package tenant
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages account modes.
+type Service interface {
+	// GetAccount returns the account for a user ID.
+	GetAccount(ctx context.Context, userID string) (*Account, error)
+
+	// SetMode switches an account between live and sandbox.
+	SetMode(ctx context.Context, userID string, mode Mode) (*Account, error)
+}
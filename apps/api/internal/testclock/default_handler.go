@@ -0,0 +1,120 @@
+package testclock
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/tenant"
+)
+
+// DefaultHandler serves the test-clock admin endpoints.
+type DefaultHandler struct {
+	service Service
+	log     logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(service Service, log logging.Logger) *DefaultHandler {
+	return &DefaultHandler{service: service, log: log}
+}
+
+// GetClock handles GET /admin/users/:id/test-clock - Returns the clock and its status.
+func (h *DefaultHandler) GetClock(c echo.Context) error {
+	userID, err := userIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	clock, err := h.service.Get(c.Request().Context(), userID)
+	if err != nil {
+		return h.toHTTPError(c, err, "Failed to get test clock")
+	}
+	return c.JSON(http.StatusOK, clock)
+}
+
+// CreateClock handles POST /admin/users/:id/test-clock - Binds a new clock and customer.
+func (h *DefaultHandler) CreateClock(c echo.Context) error {
+	userID, err := userIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	var req CreateRequest
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(&req); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	clock, err := h.service.Create(c.Request().Context(), userID, &req)
+	if err != nil {
+		return h.toHTTPError(c, err, "Failed to create test clock")
+	}
+
+	h.log.Info(c.Request().Context(), "test clock created", "user_id", userID, "test_clock_id", clock.ID)
+	return c.JSON(http.StatusCreated, clock)
+}
+
+// AdvanceClock handles POST /admin/users/:id/test-clock/advance - Moves the clock forward.
+func (h *DefaultHandler) AdvanceClock(c echo.Context) error {
+	userID, err := userIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	var req AdvanceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	clock, err := h.service.Advance(c.Request().Context(), userID, &req)
+	if err != nil {
+		return h.toHTTPError(c, err, "Failed to advance test clock")
+	}
+	return c.JSON(http.StatusAccepted, clock)
+}
+
+// DeleteClock handles DELETE /admin/users/:id/test-clock - Deletes the clock and customer.
+func (h *DefaultHandler) DeleteClock(c echo.Context) error {
+	userID, err := userIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Delete(c.Request().Context(), userID); err != nil {
+		return h.toHTTPError(c, err, "Failed to delete test clock")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func userIDParam(c echo.Context) (string, error) {
+	userID := c.Param("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		return "", echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+	return userID, nil
+}
+
+func (h *DefaultHandler) toHTTPError(c echo.Context, err error, fallback string) error {
+	switch {
+	case errors.Is(err, ErrNotConfigured):
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Stripe test mode not configured")
+	case errors.Is(err, tenant.ErrAccountNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	case errors.Is(err, ErrNoClock):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrNotSandbox), errors.Is(err, ErrClockExists):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidAdvance):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	h.log.Error(c.Request().Context(), "test clock request failed", "error", err, "user_id", c.Param("id"))
+	return echo.NewHTTPError(http.StatusInternalServerError, fallback)
+}
@@ -0,0 +1,153 @@
+package testclock
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/tenant"
+)
+
+func newClockContext(method, userID, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(userID)
+	return c, rec
+}
+
+func TestDefaultHandler_CreateClock(t *testing.T) {
+	userID := uuid.NewString()
+
+	cases := []struct {
+		name       string
+		userID     string
+		body       string
+		createErr  error
+		wantStatus int
+	}{
+		{name: "success: empty body", userID: userID, wantStatus: http.StatusCreated},
+		{name: "success: explicit frozen time", userID: userID, body: `{"frozen_time":"2025-01-01T00:00:00Z"}`,
+			wantStatus: http.StatusCreated},
+		{name: "fail: invalid user id", userID: "nope", wantStatus: http.StatusBadRequest},
+		{name: "fail: malformed body", userID: userID, body: `{`, wantStatus: http.StatusBadRequest},
+		{name: "fail: not configured", userID: userID, createErr: ErrNotConfigured,
+			wantStatus: http.StatusServiceUnavailable},
+		{name: "fail: user not found", userID: userID, createErr: tenant.ErrAccountNotFound, wantStatus: http.StatusNotFound},
+		{name: "fail: live account", userID: userID, createErr: ErrNotSandbox, wantStatus: http.StatusConflict},
+		{name: "fail: already bound", userID: userID, createErr: ErrClockExists, wantStatus: http.StatusConflict},
+		{name: "fail: stripe error", userID: userID, createErr: errors.New("stripe down"),
+			wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateFunc: func(ctx context.Context, id string, req *CreateRequest) (*Clock, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Clock{ID: "clock_1", UserID: id, Status: "ready"}, nil
+				},
+			}
+			h := NewDefaultHandler(svc, logging.Default())
+			c, rec := newClockContext(http.MethodPost, tc.userID, tc.body)
+
+			err := h.CreateClock(c)
+			if tc.wantStatus == http.StatusCreated {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusCreated, rec.Code)
+				assert.Contains(t, rec.Body.String(), `"id":"clock_1"`)
+				return
+			}
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tc.wantStatus, he.Code)
+		})
+	}
+}
+
+func TestDefaultHandler_AdvanceClock(t *testing.T) {
+	userID := uuid.NewString()
+
+	t.Run("success: accepted while advancing", func(t *testing.T) {
+		svc := &ServiceMock{
+			AdvanceFunc: func(ctx context.Context, id string, req *AdvanceRequest) (*Clock, error) {
+				assert.Equal(t, 30, req.Days)
+				return &Clock{ID: "clock_1", Status: "advancing"}, nil
+			},
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+		c, rec := newClockContext(http.MethodPost, userID, `{"days":30}`)
+
+		require.NoError(t, h.AdvanceClock(c))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":"advancing"`)
+	})
+
+	t.Run("fail: invalid advance", func(t *testing.T) {
+		svc := &ServiceMock{
+			AdvanceFunc: func(ctx context.Context, id string, req *AdvanceRequest) (*Clock, error) {
+				return nil, ErrInvalidAdvance
+			},
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+		c, _ := newClockContext(http.MethodPost, userID, `{}`)
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.AdvanceClock(c), &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestDefaultHandler_GetAndDeleteClock(t *testing.T) {
+	userID := uuid.NewString()
+
+	t.Run("success: get", func(t *testing.T) {
+		svc := &ServiceMock{
+			GetFunc: func(ctx context.Context, id string) (*Clock, error) {
+				return &Clock{ID: "clock_1", Status: "ready"}, nil
+			},
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+		c, rec := newClockContext(http.MethodGet, userID, "")
+
+		require.NoError(t, h.GetClock(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("fail: get without clock", func(t *testing.T) {
+		svc := &ServiceMock{
+			GetFunc: func(ctx context.Context, id string) (*Clock, error) { return nil, ErrNoClock },
+		}
+		h := NewDefaultHandler(svc, logging.Default())
+		c, _ := newClockContext(http.MethodGet, userID, "")
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.GetClock(c), &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+	})
+
+	t.Run("success: delete", func(t *testing.T) {
+		svc := &ServiceMock{DeleteFunc: func(ctx context.Context, id string) error { return nil }}
+		h := NewDefaultHandler(svc, logging.Default())
+		c, rec := newClockContext(http.MethodDelete, userID, "")
+
+		require.NoError(t, h.DeleteClock(c))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+}
@@ -0,0 +1,185 @@
+package testclock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+
+	"github.com/real-staging-ai/api/internal/tenant"
+)
+
+// DefaultService implements Service on top of Stripe test mode.
+type DefaultService struct {
+	accounts tenant.Repository
+	stripe   StripeClient
+	now      func() time.Time
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService. A nil client means Stripe test
+// mode is not configured and every call returns ErrNotConfigured.
+func NewDefaultService(accounts tenant.Repository, client StripeClient) *DefaultService {
+	return &DefaultService{accounts: accounts, stripe: client, now: time.Now}
+}
+
+// Get returns the user's test clock with its current Stripe status.
+func (s *DefaultService) Get(ctx context.Context, userID string) (*Clock, error) {
+	account, err := s.boundAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	clock, err := s.stripe.GetClock(*account.StripeTestClockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test clock: %w", err)
+	}
+	return toClock(account, clock), nil
+}
+
+// Create starts a test clock and creates a fresh sandbox customer frozen on it.
+// Stripe cannot move an existing customer onto a clock, so the user's previous
+// sandbox customer, if any, is replaced.
+func (s *DefaultService) Create(ctx context.Context, userID string, req *CreateRequest) (*Clock, error) {
+	account, err := s.sandboxAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StripeTestClockID != nil {
+		return nil, ErrClockExists
+	}
+
+	frozenTime := s.now()
+	if req != nil && req.FrozenTime != nil {
+		frozenTime = *req.FrozenTime
+	}
+
+	clock, err := s.stripe.NewClock("sandbox "+userID, frozenTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create test clock: %w", err)
+	}
+
+	cust, err := s.stripe.NewCustomer(&stripe.CustomerParams{
+		TestClock: stripe.String(clock.ID),
+		Metadata: map[string]string{
+			"user_id":      userID,
+			"auth0_sub":    account.Auth0Sub,
+			"account_mode": string(tenant.ModeSandbox),
+		},
+	})
+	if err != nil {
+		// Don't leave an empty clock behind; Stripe caps clocks per account.
+		_ = s.stripe.DeleteClock(clock.ID)
+		return nil, fmt.Errorf("failed to create sandbox customer: %w", err)
+	}
+
+	if err := s.accounts.SetTestCustomer(ctx, userID, cust.ID, clock.ID); err != nil {
+		return nil, err
+	}
+	account.StripeCustomerID = &cust.ID
+	account.StripeTestClockID = &clock.ID
+	return toClock(account, clock), nil
+}
+
+// Advance moves the user's test clock forward, either to an absolute time or by
+// a number of days. Stripe advances asynchronously; the returned clock is usually
+// still advancing.
+func (s *DefaultService) Advance(ctx context.Context, userID string, req *AdvanceRequest) (*Clock, error) {
+	if req == nil || (req.FrozenTime == nil) == (req.Days == 0) {
+		return nil, fmt.Errorf("%w: set exactly one of frozen_time or days", ErrInvalidAdvance)
+	}
+	if req.Days < 0 || req.Days > MaxAdvanceDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidAdvance, MaxAdvanceDays)
+	}
+
+	account, err := s.boundAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.stripe.GetClock(*account.StripeTestClockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test clock: %w", err)
+	}
+	currentTime := time.Unix(current.FrozenTime, 0)
+
+	target := currentTime.AddDate(0, 0, req.Days)
+	if req.FrozenTime != nil {
+		target = *req.FrozenTime
+	}
+	if !target.After(currentTime) {
+		return nil, fmt.Errorf("%w: frozen_time must be after %s", ErrInvalidAdvance, currentTime.UTC().Format(time.RFC3339))
+	}
+
+	clock, err := s.stripe.AdvanceClock(current.ID, target)
+	if err != nil {
+		var se *stripe.Error
+		if errors.As(err, &se) && se.HTTPStatusCode == http.StatusBadRequest {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidAdvance, se.Msg)
+		}
+		return nil, fmt.Errorf("failed to advance test clock: %w", err)
+	}
+	return toClock(account, clock), nil
+}
+
+// Delete removes the test clock, which deletes its customer and subscriptions in
+// Stripe, and unbinds both from the user.
+func (s *DefaultService) Delete(ctx context.Context, userID string) error {
+	account, err := s.boundAccount(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.stripe.DeleteClock(*account.StripeTestClockID); err != nil {
+		var se *stripe.Error
+		if !errors.As(err, &se) || se.HTTPStatusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to delete test clock: %w", err)
+		}
+	}
+	return s.accounts.SetTestCustomer(ctx, userID, "", "")
+}
+
+// sandboxAccount loads the account and checks Stripe test mode is usable for it.
+func (s *DefaultService) sandboxAccount(ctx context.Context, userID string) (*tenant.Account, error) {
+	if s.stripe == nil {
+		return nil, ErrNotConfigured
+	}
+	account, err := s.accounts.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Mode != tenant.ModeSandbox {
+		return nil, ErrNotSandbox
+	}
+	return account, nil
+}
+
+// boundAccount is sandboxAccount for a user that must already have a test clock.
+func (s *DefaultService) boundAccount(ctx context.Context, userID string) (*tenant.Account, error) {
+	account, err := s.sandboxAccount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account.StripeTestClockID == nil {
+		return nil, ErrNoClock
+	}
+	return account, nil
+}
+
+func toClock(account *tenant.Account, clock *stripe.TestHelpersTestClock) *Clock {
+	c := &Clock{
+		ID:         clock.ID,
+		UserID:     account.UserID,
+		FrozenTime: time.Unix(clock.FrozenTime, 0).UTC(),
+		Status:     string(clock.Status),
+	}
+	if account.StripeCustomerID != nil {
+		c.CustomerID = *account.StripeCustomerID
+	}
+	return c
+}
@@ -0,0 +1,188 @@
+package testclock
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81"
+
+	"github.com/real-staging-ai/api/internal/tenant"
+)
+
+func strPtr(s string) *string { return &s }
+
+func sandboxAccount(clockID *string) *tenant.Account {
+	return &tenant.Account{
+		UserID:            "u1",
+		Auth0Sub:          "auth0|sandbox",
+		Mode:              tenant.ModeSandbox,
+		StripeCustomerID:  strPtr("cus_1"),
+		StripeTestClockID: clockID,
+	}
+}
+
+func accountsReturning(account *tenant.Account) *tenant.RepositoryMock {
+	return &tenant.RepositoryMock{
+		GetByUserIDFunc: func(ctx context.Context, userID string) (*tenant.Account, error) {
+			return account, nil
+		},
+		SetTestCustomerFunc: func(ctx context.Context, userID, customerID, testClockID string) error {
+			return nil
+		},
+	}
+}
+
+func TestDefaultService_Create(t *testing.T) {
+	frozen := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success: creates clock and customer and binds both", func(t *testing.T) {
+		accounts := accountsReturning(sandboxAccount(nil))
+		client := &StripeClientMock{
+			NewClockFunc: func(name string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error) {
+				assert.True(t, frozenTime.Equal(frozen))
+				return &stripe.TestHelpersTestClock{ID: "clock_1", FrozenTime: frozenTime.Unix(), Status: "ready"}, nil
+			},
+			NewCustomerFunc: func(params *stripe.CustomerParams) (*stripe.Customer, error) {
+				assert.Equal(t, "clock_1", *params.TestClock)
+				assert.Equal(t, "sandbox", params.Metadata["account_mode"])
+				return &stripe.Customer{ID: "cus_2"}, nil
+			},
+		}
+		svc := NewDefaultService(accounts, client)
+
+		clock, err := svc.Create(context.Background(), "u1", &CreateRequest{FrozenTime: &frozen})
+		require.NoError(t, err)
+		assert.Equal(t, "clock_1", clock.ID)
+		assert.Equal(t, "cus_2", clock.CustomerID)
+		assert.True(t, clock.FrozenTime.Equal(frozen))
+		require.Len(t, accounts.SetTestCustomerCalls(), 1)
+		assert.Equal(t, "cus_2", accounts.SetTestCustomerCalls()[0].CustomerID)
+		assert.Equal(t, "clock_1", accounts.SetTestCustomerCalls()[0].TestClockID)
+	})
+
+	t.Run("fail: customer creation deletes the new clock", func(t *testing.T) {
+		client := &StripeClientMock{
+			NewClockFunc: func(name string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error) {
+				return &stripe.TestHelpersTestClock{ID: "clock_1"}, nil
+			},
+			NewCustomerFunc: func(params *stripe.CustomerParams) (*stripe.Customer, error) {
+				return nil, errors.New("stripe down")
+			},
+			DeleteClockFunc: func(id string) error { return nil },
+		}
+		svc := NewDefaultService(accountsReturning(sandboxAccount(nil)), client)
+
+		_, err := svc.Create(context.Background(), "u1", nil)
+		require.Error(t, err)
+		require.Len(t, client.DeleteClockCalls(), 1)
+		assert.Equal(t, "clock_1", client.DeleteClockCalls()[0].ID)
+	})
+
+	t.Run("fail: already bound", func(t *testing.T) {
+		svc := NewDefaultService(accountsReturning(sandboxAccount(strPtr("clock_1"))), &StripeClientMock{})
+		_, err := svc.Create(context.Background(), "u1", nil)
+		assert.ErrorIs(t, err, ErrClockExists)
+	})
+
+	t.Run("fail: live account", func(t *testing.T) {
+		account := sandboxAccount(nil)
+		account.Mode = tenant.ModeLive
+		svc := NewDefaultService(accountsReturning(account), &StripeClientMock{})
+		_, err := svc.Create(context.Background(), "u1", nil)
+		assert.ErrorIs(t, err, ErrNotSandbox)
+	})
+
+	t.Run("fail: not configured", func(t *testing.T) {
+		svc := NewDefaultService(accountsReturning(sandboxAccount(nil)), nil)
+		_, err := svc.Create(context.Background(), "u1", nil)
+		assert.ErrorIs(t, err, ErrNotConfigured)
+	})
+}
+
+func TestDefaultService_Advance(t *testing.T) {
+	current := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := current.Add(-time.Hour)
+
+	cases := []struct {
+		name       string
+		req        *AdvanceRequest
+		advanceErr error
+		wantTarget time.Time
+		wantErr    error
+	}{
+		{name: "success: advance by days", req: &AdvanceRequest{Days: 31}, wantTarget: current.AddDate(0, 0, 31)},
+		{
+			name: "success: advance to absolute time", req: &AdvanceRequest{FrozenTime: &[]time.Time{current.Add(time.Hour)}[0]},
+			wantTarget: current.Add(time.Hour),
+		},
+		{name: "fail: neither set", req: &AdvanceRequest{}, wantErr: ErrInvalidAdvance},
+		{name: "fail: both set", req: &AdvanceRequest{Days: 1, FrozenTime: &current}, wantErr: ErrInvalidAdvance},
+		{name: "fail: too many days", req: &AdvanceRequest{Days: MaxAdvanceDays + 1}, wantErr: ErrInvalidAdvance},
+		{name: "fail: not forward", req: &AdvanceRequest{FrozenTime: &past}, wantErr: ErrInvalidAdvance},
+		{
+			name: "fail: stripe rejects advance", req: &AdvanceRequest{Days: 400},
+			advanceErr: &stripe.Error{HTTPStatusCode: http.StatusBadRequest, Msg: "too far"},
+			wantErr:    ErrInvalidAdvance,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &StripeClientMock{
+				GetClockFunc: func(id string) (*stripe.TestHelpersTestClock, error) {
+					return &stripe.TestHelpersTestClock{ID: id, FrozenTime: current.Unix(), Status: "ready"}, nil
+				},
+				AdvanceClockFunc: func(id string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error) {
+					if tc.advanceErr != nil {
+						return nil, tc.advanceErr
+					}
+					return &stripe.TestHelpersTestClock{ID: id, FrozenTime: frozenTime.Unix(), Status: "advancing"}, nil
+				},
+			}
+			svc := NewDefaultService(accountsReturning(sandboxAccount(strPtr("clock_1"))), client)
+
+			clock, err := svc.Advance(context.Background(), "u1", tc.req)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "advancing", clock.Status)
+			assert.True(t, clock.FrozenTime.Equal(tc.wantTarget), "got %s", clock.FrozenTime)
+		})
+	}
+}
+
+func TestDefaultService_Delete(t *testing.T) {
+	t.Run("success: deletes clock and unbinds customer", func(t *testing.T) {
+		accounts := accountsReturning(sandboxAccount(strPtr("clock_1")))
+		client := &StripeClientMock{DeleteClockFunc: func(id string) error { return nil }}
+		svc := NewDefaultService(accounts, client)
+
+		require.NoError(t, svc.Delete(context.Background(), "u1"))
+		require.Len(t, accounts.SetTestCustomerCalls(), 1)
+		assert.Empty(t, accounts.SetTestCustomerCalls()[0].CustomerID)
+		assert.Empty(t, accounts.SetTestCustomerCalls()[0].TestClockID)
+	})
+
+	t.Run("success: clock already gone in stripe", func(t *testing.T) {
+		accounts := accountsReturning(sandboxAccount(strPtr("clock_1")))
+		client := &StripeClientMock{DeleteClockFunc: func(id string) error {
+			return &stripe.Error{HTTPStatusCode: http.StatusNotFound}
+		}}
+		svc := NewDefaultService(accounts, client)
+
+		require.NoError(t, svc.Delete(context.Background(), "u1"))
+		assert.Len(t, accounts.SetTestCustomerCalls(), 1)
+	})
+
+	t.Run("fail: no clock", func(t *testing.T) {
+		svc := NewDefaultService(accountsReturning(sandboxAccount(nil)), &StripeClientMock{})
+		assert.ErrorIs(t, svc.Delete(context.Background(), "u1"), ErrNoClock)
+	})
+}
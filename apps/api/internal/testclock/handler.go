@@ -0,0 +1,20 @@
+package testclock
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the test-only admin endpoints for Stripe test clocks.
+type Handler interface {
+	// GetClock handles GET /admin/users/:id/test-clock - Returns the clock and its status.
+	GetClock(c echo.Context) error
+
+	// CreateClock handles POST /admin/users/:id/test-clock - Binds a new clock and customer.
+	CreateClock(c echo.Context) error
+
+	// AdvanceClock handles POST /admin/users/:id/test-clock/advance - Moves the clock forward.
+	AdvanceClock(c echo.Context) error
+
+	// DeleteClock handles DELETE /admin/users/:id/test-clock - Deletes the clock and customer.
+	DeleteClock(c echo.Context) error
+}
@@ -0,0 +1,50 @@
+// Package testclock drives Stripe test clocks bound to sandbox customers so
+// integration tests can exercise renewals, proration and dunning by moving
+// billing time forward instead of waiting for it.
+package testclock
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotConfigured is returned when no Stripe test-mode key is configured.
+	ErrNotConfigured = errors.New("stripe test mode not configured")
+	// ErrNotSandbox is returned when the user is not a sandbox account.
+	ErrNotSandbox = errors.New("test clocks are only available to sandbox accounts")
+	// ErrClockExists is returned when the user is already bound to a test clock.
+	ErrClockExists = errors.New("user already has a test clock")
+	// ErrNoClock is returned when the user has no test clock.
+	ErrNoClock = errors.New("user has no test clock")
+	// ErrInvalidAdvance is returned when an advance does not move the clock forward.
+	ErrInvalidAdvance = errors.New("invalid advance")
+)
+
+// MaxAdvanceDays bounds a single relative advance. Stripe enforces its own,
+// tighter limit of two intervals of the shortest subscription on the clock.
+const MaxAdvanceDays = 730
+
+// Clock is a Stripe test clock and the sandbox customer frozen on it.
+type Clock struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	CustomerID string    `json:"customer_id,omitempty"`
+	FrozenTime time.Time `json:"frozen_time"`
+	// Status is ready, advancing or internal_failure. Advancing is asynchronous;
+	// poll until the clock is ready again before asserting on billing state.
+	Status string `json:"status"`
+}
+
+// CreateRequest is the body of POST /admin/users/:id/test-clock.
+type CreateRequest struct {
+	// FrozenTime is the clock's starting time; defaults to now.
+	FrozenTime *time.Time `json:"frozen_time,omitempty"`
+}
+
+// AdvanceRequest is the body of POST /admin/users/:id/test-clock/advance.
+// Exactly one of FrozenTime or Days must be set.
+type AdvanceRequest struct {
+	FrozenTime *time.Time `json:"frozen_time,omitempty"`
+	Days       int        `json:"days,omitempty"`
+}
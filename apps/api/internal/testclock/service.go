@@ -0,0 +1,20 @@
+package testclock
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service manages the Stripe test clock bound to a sandbox user.
+type Service interface {
+	// Get returns the user's test clock with its current Stripe status.
+	Get(ctx context.Context, userID string) (*Clock, error)
+
+	// Create starts a test clock and creates a fresh sandbox customer frozen on it.
+	Create(ctx context.Context, userID string, req *CreateRequest) (*Clock, error)
+
+	// Advance moves the user's test clock forward.
+	Advance(ctx context.Context, userID string, req *AdvanceRequest) (*Clock, error)
+
+	// Delete removes the test clock, which deletes its customer and subscriptions in Stripe.
+	Delete(ctx context.Context, userID string) error
+}
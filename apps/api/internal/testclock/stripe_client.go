@@ -0,0 +1,70 @@
+package testclock
+
+import (
+	"time"
+
+	"github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/customer"
+	"github.com/stripe/stripe-go/v81/testhelpers/testclock"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out stripe_client_mock.go . StripeClient
+
+// StripeClient is the subset of the Stripe API used to manage test clocks.
+type StripeClient interface {
+	NewClock(name string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error)
+	GetClock(id string) (*stripe.TestHelpersTestClock, error)
+	AdvanceClock(id string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error)
+	DeleteClock(id string) error
+	NewCustomer(params *stripe.CustomerParams) (*stripe.Customer, error)
+}
+
+// DefaultStripeClient calls Stripe with a fixed test-mode key. It never reads or
+// sets the package-level stripe.Key, so it cannot leak into live requests.
+type DefaultStripeClient struct {
+	clocks    testclock.Client
+	customers customer.Client
+}
+
+// Ensure DefaultStripeClient implements StripeClient.
+var _ StripeClient = (*DefaultStripeClient)(nil)
+
+// NewDefaultStripeClient creates a client for the given Stripe test-mode key.
+func NewDefaultStripeClient(testSecretKey string) *DefaultStripeClient {
+	backend := stripe.GetBackend(stripe.APIBackend)
+	return &DefaultStripeClient{
+		clocks:    testclock.Client{B: backend, Key: testSecretKey},
+		customers: customer.Client{B: backend, Key: testSecretKey},
+	}
+}
+
+// NewClock creates a test clock frozen at frozenTime.
+func (c *DefaultStripeClient) NewClock(name string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error) {
+	return c.clocks.New(&stripe.TestHelpersTestClockParams{
+		FrozenTime: stripe.Int64(frozenTime.Unix()),
+		Name:       stripe.String(name),
+	})
+}
+
+// GetClock retrieves a test clock.
+func (c *DefaultStripeClient) GetClock(id string) (*stripe.TestHelpersTestClock, error) {
+	return c.clocks.Get(id, nil)
+}
+
+// AdvanceClock starts moving a test clock forward to frozenTime.
+func (c *DefaultStripeClient) AdvanceClock(id string, frozenTime time.Time) (*stripe.TestHelpersTestClock, error) {
+	return c.clocks.Advance(id, &stripe.TestHelpersTestClockAdvanceParams{
+		FrozenTime: stripe.Int64(frozenTime.Unix()),
+	})
+}
+
+// DeleteClock deletes a test clock along with its customers and subscriptions.
+func (c *DefaultStripeClient) DeleteClock(id string) error {
+	_, err := c.clocks.Del(id, nil)
+	return err
+}
+
+// NewCustomer creates a customer.
+func (c *DefaultStripeClient) NewCustomer(params *stripe.CustomerParams) (*stripe.Customer, error) {
+	return c.customers.New(params)
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/real-staging-ai/api/internal/i18n"
 	"github.com/real-staging-ai/api/internal/storage/queries"
 )
 
@@ -116,6 +118,17 @@ func validateProfileUpdate(req *ProfileUpdateRequest) error {
 		return fmt.Errorf("company name must be less than 100 characters")
 	}
 
+	// Preferred language must have a catalog
+	if len(req.Preferences) > 0 {
+		var prefs Preferences
+		if err := json.Unmarshal(req.Preferences, &prefs); err != nil {
+			return fmt.Errorf("preferences must be a JSON object: %w", err)
+		}
+		if prefs.Language != "" && !i18n.IsSupported(prefs.Language) {
+			return fmt.Errorf("%w: %s", i18n.ErrUnsupportedLanguage, prefs.Language)
+		}
+	}
+
 	return nil
 }
 
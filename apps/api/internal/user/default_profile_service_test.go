@@ -408,6 +408,16 @@ func TestDefaultProfileService_UpdateProfile(t *testing.T) {
 			expectErr:   true,
 			errContains: "company name must be less than 100 characters",
 		},
+		{
+			name:   "fail: unsupported language",
+			userID: userID.String(),
+			req: &ProfileUpdateRequest{
+				Preferences: []byte(`{"language":"tlh"}`),
+			},
+			setupMock:   func(repo *RepositoryMock) {},
+			expectErr:   true,
+			errContains: "unsupported language: tlh",
+		},
 		{
 			name:   "fail: user not found",
 			userID: userID.String(),
@@ -62,4 +62,7 @@ type Preferences struct {
 	MarketingEmails    bool   `json:"marketing_emails"`
 	DefaultRoomType    string `json:"default_room_type,omitempty"`
 	DefaultStyle       string `json:"default_style,omitempty"`
+	// Language localizes API error messages for this user, overriding
+	// Accept-Language. Empty uses the request header.
+	Language string `json:"language,omitempty"`
 }
@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/real-staging-ai/api/internal/auth"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+// DefaultHandler serves the webhook endpoint API.
+type DefaultHandler struct {
+	service     Service
+	userRepo    user.Repository
+	projectRepo project.Repository
+	log         logging.Logger
+}
+
+// Ensure DefaultHandler implements Handler.
+var _ Handler = (*DefaultHandler)(nil)
+
+// NewDefaultHandler creates a new DefaultHandler.
+func NewDefaultHandler(
+	service Service, userRepo user.Repository, projectRepo project.Repository, log logging.Logger,
+) *DefaultHandler {
+	return &DefaultHandler{service: service, userRepo: userRepo, projectRepo: projectRepo, log: log}
+}
+
+// ErrorResponse represents an error response.
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// CreateEndpoint handles POST /api/v1/projects/:project_id/webhooks.
+// The response is the only time the signing secret is returned.
+func (h *DefaultHandler) CreateEndpoint(c echo.Context) error {
+	userID, projectID, errResp := h.ownedProject(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	var req CreateEndpointRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	ctx := c.Request().Context()
+	endpoint, err := h.service.CreateEndpoint(ctx, userID, projectID, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to create webhook endpoint")
+	}
+	return c.JSON(http.StatusCreated, endpoint)
+}
+
+// ListEndpoints handles GET /api/v1/projects/:project_id/webhooks.
+func (h *DefaultHandler) ListEndpoints(c echo.Context) error {
+	userID, projectID, errResp := h.ownedProject(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	endpoints, err := h.service.ListEndpoints(c.Request().Context(), userID, projectID)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list webhook endpoints")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"endpoints": endpoints})
+}
+
+// GetEndpoint handles GET /api/v1/webhooks/:id.
+func (h *DefaultHandler) GetEndpoint(c echo.Context) error {
+	userID, id, errResp := h.ownedEndpointRequest(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	endpoint, err := h.service.GetEndpoint(c.Request().Context(), userID, id)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to get webhook endpoint")
+	}
+	return c.JSON(http.StatusOK, endpoint)
+}
+
+// UpdateEndpoint handles PATCH /api/v1/webhooks/:id.
+func (h *DefaultHandler) UpdateEndpoint(c echo.Context) error {
+	userID, id, errResp := h.ownedEndpointRequest(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	var req UpdateEndpointRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid request format"})
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(c.Request().Context(), userID, id, req)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to update webhook endpoint")
+	}
+	return c.JSON(http.StatusOK, endpoint)
+}
+
+// DeleteEndpoint handles DELETE /api/v1/webhooks/:id.
+func (h *DefaultHandler) DeleteEndpoint(c echo.Context) error {
+	userID, id, errResp := h.ownedEndpointRequest(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	if err := h.service.DeleteEndpoint(c.Request().Context(), userID, id); err != nil {
+		return h.serviceError(c, err, "Failed to delete webhook endpoint")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /api/v1/webhooks/:id/deliveries.
+// Query params:
+// - limit: page size (default: 50, max: 200)
+// - offset: rows to skip (default: 0)
+func (h *DefaultHandler) ListDeliveries(c echo.Context) error {
+	userID, id, errResp := h.ownedEndpointRequest(c)
+	if errResp != nil {
+		return errResp()
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	deliveries, err := h.service.ListDeliveries(c.Request().Context(), userID, id, limit, offset)
+	if err != nil {
+		return h.serviceError(c, err, "Failed to list webhook deliveries")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"deliveries": deliveries})
+}
+
+// ownedProject validates :project_id and checks the caller owns the project.
+func (h *DefaultHandler) ownedProject(c echo.Context) (string, string, func() error) {
+	projectID := c.Param("project_id")
+	if _, err := uuid.Parse(projectID); err != nil {
+		return "", "", func() error {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid project ID format"})
+		}
+	}
+
+	userID, errResp := h.currentUserID(c)
+	if errResp != nil {
+		return "", "", errResp
+	}
+
+	if _, err := h.projectRepo.GetProjectByIDAndUserID(c.Request().Context(), projectID, userID); err != nil {
+		return "", "", func() error {
+			return c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "forbidden",
+				Message: "Project not found or access denied",
+			})
+		}
+	}
+	return userID, projectID, nil
+}
+
+// ownedEndpointRequest validates :id and resolves the caller; ownership is
+// enforced by the service, which only finds endpoints belonging to the user.
+func (h *DefaultHandler) ownedEndpointRequest(c echo.Context) (string, string, func() error) {
+	id := c.Param("id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", "", func() error {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "bad_request", Message: "Invalid webhook ID format"})
+		}
+	}
+
+	userID, errResp := h.currentUserID(c)
+	if errResp != nil {
+		return "", "", errResp
+	}
+	return userID, id, nil
+}
+
+func (h *DefaultHandler) currentUserID(c echo.Context) (string, func() error) {
+	auth0Sub, err := auth.GetUserIDOrDefault(c)
+	if err != nil {
+		return "", func() error {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "unauthorized",
+				Message: "Invalid or missing JWT token",
+			})
+		}
+	}
+
+	userRow, err := h.userRepo.GetByAuth0Sub(c.Request().Context(), auth0Sub)
+	if err != nil {
+		return "", func() error {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "unauthorized", Message: "User not found"})
+		}
+	}
+	return userRow.ID.String(), nil
+}
+
+func (h *DefaultHandler) serviceError(c echo.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, ErrEndpointNotFound):
+		return c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: "Webhook endpoint not found"})
+	case errors.Is(err, ErrInvalidEndpoint):
+		return c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: "validation_failed", Message: err.Error()})
+	}
+	h.log.Error(c.Request().Context(), "webhook endpoint request failed", "error", err)
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal_server_error", Message: message})
+}
@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+	"github.com/real-staging-ai/api/internal/echotest"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/project"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+	"github.com/real-staging-ai/api/internal/user"
+)
+
+func newTestHandler(svc Service, projectErr error) *DefaultHandler {
+	userID := uuid.New()
+	userRepo := &user.RepositoryMock{
+		GetByAuth0SubFunc: func(ctx context.Context, auth0Sub string) (*queries.GetUserByAuth0SubRow, error) {
+			return &queries.GetUserByAuth0SubRow{ID: pgtype.UUID{Bytes: userID, Valid: true}}, nil
+		},
+	}
+	projectRepo := &project.RepositoryMock{
+		GetProjectByIDAndUserIDFunc: func(ctx context.Context, projectID, userID string) (*project.Project, error) {
+			if projectErr != nil {
+				return nil, projectErr
+			}
+			return &project.Project{ID: projectID}, nil
+		},
+	}
+	return NewDefaultHandler(svc, userRepo, projectRepo, logging.Default())
+}
+
+func TestDefaultHandler_CreateEndpoint(t *testing.T) {
+	projectID := uuid.NewString()
+
+	cases := []struct {
+		name         string
+		projectID    string
+		body         string
+		projectErr   error
+		createErr    error
+		expectedCode int
+		expectBody   string
+	}{
+		{
+			name:         "success: returns secret once",
+			projectID:    projectID,
+			body:         `{"url":"https://example.com/hook"}`,
+			expectedCode: http.StatusCreated,
+			expectBody:   `"secret":"whsec_new"`,
+		},
+		{name: "fail: invalid project id", projectID: "nope", expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: project not owned",
+			projectID:    projectID,
+			body:         `{"url":"https://example.com/hook"}`,
+			projectErr:   errors.New("not found"),
+			expectedCode: http.StatusForbidden,
+		},
+		{name: "fail: malformed body", projectID: projectID, body: `{`, expectedCode: http.StatusBadRequest},
+		{
+			name:         "fail: validation",
+			projectID:    projectID,
+			body:         `{"url":"ftp://example.com"}`,
+			createErr:    ErrInvalidEndpoint,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectBody:   "validation_failed",
+		},
+		{
+			name:         "fail: service error",
+			projectID:    projectID,
+			body:         `{"url":"https://example.com/hook"}`,
+			createErr:    errors.New("db down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &ServiceMock{
+				CreateEndpointFunc: func(
+					ctx context.Context, userID, projectID string, req CreateEndpointRequest,
+				) (*Endpoint, error) {
+					if tc.createErr != nil {
+						return nil, tc.createErr
+					}
+					return &Endpoint{ID: "ep-1", ProjectID: projectID, URL: req.URL, Secret: "whsec_new"}, nil
+				},
+			}
+			c, rec := echotest.NewContext(http.MethodPost, tc.body, []string{"project_id"}, []string{tc.projectID})
+
+			require.NoError(t, newTestHandler(svc, tc.projectErr).CreateEndpoint(c))
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			if tc.expectBody != "" {
+				assert.Contains(t, rec.Body.String(), tc.expectBody)
+			}
+		})
+	}
+}
+
+func TestDefaultHandler_endpointRoutes(t *testing.T) {
+	id := uuid.NewString()
+
+	t.Run("success: get", func(t *testing.T) {
+		svc := &ServiceMock{
+			GetEndpointFunc: func(ctx context.Context, userID, id string) (*Endpoint, error) {
+				return &Endpoint{ID: id}, nil
+			},
+		}
+		c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{id})
+
+		require.NoError(t, newTestHandler(svc, nil).GetEndpoint(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "secret")
+	})
+
+	t.Run("fail: get not owned", func(t *testing.T) {
+		svc := &ServiceMock{
+			GetEndpointFunc: func(ctx context.Context, userID, id string) (*Endpoint, error) {
+				return nil, ErrEndpointNotFound
+			},
+		}
+		c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{id})
+
+		require.NoError(t, newTestHandler(svc, nil).GetEndpoint(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("fail: invalid id", func(t *testing.T) {
+		c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{"nope"})
+
+		require.NoError(t, newTestHandler(&ServiceMock{}, nil).GetEndpoint(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("success: update", func(t *testing.T) {
+		svc := &ServiceMock{
+			UpdateEndpointFunc: func(
+				ctx context.Context, userID, id string, req UpdateEndpointRequest,
+			) (*Endpoint, error) {
+				require.NotNil(t, req.Active)
+				return &Endpoint{ID: id, Active: *req.Active}, nil
+			},
+		}
+		c, rec := echotest.NewContext(http.MethodPatch, `{"active":false}`, []string{"id"}, []string{id})
+
+		require.NoError(t, newTestHandler(svc, nil).UpdateEndpoint(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"active":false`)
+	})
+
+	t.Run("success: delete", func(t *testing.T) {
+		svc := &ServiceMock{DeleteEndpointFunc: func(ctx context.Context, userID, id string) error { return nil }}
+		c, rec := echotest.NewContext(http.MethodDelete, "", []string{"id"}, []string{id})
+
+		require.NoError(t, newTestHandler(svc, nil).DeleteEndpoint(c))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("success: deliveries", func(t *testing.T) {
+		svc := &ServiceMock{
+			ListDeliveriesFunc: func(
+				ctx context.Context, userID, id string, limit, offset int,
+			) ([]delivery.Delivery, error) {
+				assert.Equal(t, 20, limit)
+				return []delivery.Delivery{{ID: "d-1", Status: delivery.StatusRetrying}}, nil
+			},
+		}
+		c, rec := echotest.NewContext(http.MethodGet, "", []string{"id"}, []string{id})
+		c.QueryParams().Set("limit", "20")
+
+		require.NoError(t, newTestHandler(svc, nil).ListDeliveries(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":"retrying"`)
+	})
+}
@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+// DefaultRepository implements Repository using PostgreSQL.
+type DefaultRepository struct {
+	db storage.Database
+}
+
+// Ensure DefaultRepository implements Repository.
+var _ Repository = (*DefaultRepository)(nil)
+
+// NewDefaultRepository creates a new DefaultRepository.
+func NewDefaultRepository(db storage.Database) *DefaultRepository {
+	return &DefaultRepository{db: db}
+}
+
+const endpointColumns = `
+	w.id, w.user_id, w.project_id, w.url, w.secret, w.event_types, w.description, w.active,
+	w.created_at, w.updated_at`
+
+func scanEndpoint(row pgx.Row) (*Endpoint, error) {
+	var e Endpoint
+	err := row.Scan(
+		&e.ID, &e.UserID, &e.ProjectID, &e.URL, &e.Secret, &e.EventTypes, &e.Description, &e.Active,
+		&e.CreatedAt, &e.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *DefaultRepository) queryEndpoints(ctx context.Context, query string, args ...interface{}) ([]Endpoint, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []Endpoint{}
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		endpoints = append(endpoints, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over webhook endpoint rows: %w", err)
+	}
+	return endpoints, nil
+}
+
+// Create inserts a new endpoint.
+func (r *DefaultRepository) Create(ctx context.Context, e *Endpoint) (*Endpoint, error) {
+	query := `
+		INSERT INTO webhook_endpoints AS w (user_id, project_id, url, secret, event_types, description, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING` + endpointColumns
+
+	created, err := scanEndpoint(r.db.QueryRow(ctx, query,
+		e.UserID, e.ProjectID, e.URL, e.Secret, e.EventTypes, e.Description, e.Active,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+	return created, nil
+}
+
+// GetByID retrieves an endpoint owned by the user.
+func (r *DefaultRepository) GetByID(ctx context.Context, id, userID string) (*Endpoint, error) {
+	query := `SELECT` + endpointColumns + `
+		FROM webhook_endpoints w
+		WHERE w.id = $1 AND w.user_id = $2`
+
+	e, err := scanEndpoint(r.db.QueryRow(ctx, query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEndpointNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	return e, nil
+}
+
+// ListByProject returns the user's endpoints for a project, oldest first.
+func (r *DefaultRepository) ListByProject(ctx context.Context, projectID, userID string) ([]Endpoint, error) {
+	query := `SELECT` + endpointColumns + `
+		FROM webhook_endpoints w
+		WHERE w.project_id = $1 AND w.user_id = $2
+		ORDER BY w.created_at ASC`
+
+	return r.queryEndpoints(ctx, query, projectID, userID)
+}
+
+// Update persists URL, event types, description and active flag.
+func (r *DefaultRepository) Update(ctx context.Context, e *Endpoint) (*Endpoint, error) {
+	query := `
+		UPDATE webhook_endpoints AS w
+		SET url = $3, event_types = $4, description = $5, active = $6, updated_at = now()
+		WHERE w.id = $1 AND w.user_id = $2
+		RETURNING` + endpointColumns
+
+	updated, err := scanEndpoint(r.db.QueryRow(ctx, query,
+		e.ID, e.UserID, e.URL, e.EventTypes, e.Description, e.Active,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEndpointNotFound
+		}
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+	return updated, nil
+}
+
+// Delete removes an endpoint owned by the user together with its delivery history.
+func (r *DefaultRepository) Delete(ctx context.Context, id, userID string) error {
+	query := `DELETE FROM webhook_endpoints WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEndpointNotFound
+	}
+	return nil
+}
+
+// ListSubscribed returns the active endpoints of the image's project that subscribe to eventType.
+func (r *DefaultRepository) ListSubscribed(ctx context.Context, imageID, eventType string) ([]Endpoint, error) {
+	query := `SELECT` + endpointColumns + `
+		FROM webhook_endpoints w
+		JOIN images i ON i.project_id = w.project_id
+		WHERE i.id = $1 AND w.active AND $2 = ANY(w.event_types)
+		ORDER BY w.created_at ASC`
+
+	return r.queryEndpoints(ctx, query, imageID, eventType)
+}
@@ -0,0 +1,202 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+)
+
+// secretPrefix marks endpoint signing secrets so they are recognisable in logs and config.
+const secretPrefix = "whsec_"
+
+// DefaultService implements Service on top of the endpoint table and the delivery outbox.
+type DefaultService struct {
+	repo       Repository
+	deliveries delivery.Service
+}
+
+// Ensure DefaultService implements Service.
+var _ Service = (*DefaultService)(nil)
+
+// NewDefaultService creates a new DefaultService.
+func NewDefaultService(repo Repository, deliveries delivery.Service) *DefaultService {
+	return &DefaultService{repo: repo, deliveries: deliveries}
+}
+
+// CreateEndpoint registers a callback URL for a project and returns it with its signing secret.
+func (s *DefaultService) CreateEndpoint(
+	ctx context.Context, userID, projectID string, req CreateEndpointRequest,
+) (*Endpoint, error) {
+	if err := validateURL(req.URL); err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, &Endpoint{
+		UserID:      userID,
+		ProjectID:   projectID,
+		URL:         req.URL,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Description: req.Description,
+		Active:      true,
+	})
+}
+
+// ListEndpoints returns the user's endpoints for a project.
+func (s *DefaultService) ListEndpoints(ctx context.Context, userID, projectID string) ([]Endpoint, error) {
+	endpoints, err := s.repo.ListByProject(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range endpoints {
+		endpoints[i].Secret = ""
+	}
+	return endpoints, nil
+}
+
+// GetEndpoint retrieves one of the user's endpoints.
+func (s *DefaultService) GetEndpoint(ctx context.Context, userID, id string) (*Endpoint, error) {
+	e, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	e.Secret = ""
+	return e, nil
+}
+
+// UpdateEndpoint changes an endpoint's URL, event types, description or active flag.
+func (s *DefaultService) UpdateEndpoint(
+	ctx context.Context, userID, id string, req UpdateEndpointRequest,
+) (*Endpoint, error) {
+	e, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateURL(*req.URL); err != nil {
+			return nil, err
+		}
+		e.URL = *req.URL
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := normalizeEventTypes(*req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		e.EventTypes = eventTypes
+	}
+	if req.Description != nil {
+		e.Description = req.Description
+	}
+	if req.Active != nil {
+		e.Active = *req.Active
+	}
+
+	updated, err := s.repo.Update(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+	updated.Secret = ""
+	return updated, nil
+}
+
+// DeleteEndpoint removes an endpoint and its delivery history.
+func (s *DefaultService) DeleteEndpoint(ctx context.Context, userID, id string) error {
+	return s.repo.Delete(ctx, id, userID)
+}
+
+// ListDeliveries returns an endpoint's delivery history, newest first.
+func (s *DefaultService) ListDeliveries(
+	ctx context.Context, userID, id string, limit, offset int,
+) ([]delivery.Delivery, error) {
+	if _, err := s.repo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return s.deliveries.ListDeliveries(ctx, delivery.ListFilter{
+		EndpointID: id,
+		Channel:    delivery.ChannelWebhook,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+// Dispatch queues a signed delivery of the event to every subscribed endpoint.
+// Every endpoint is attempted even if an earlier one fails; the failures are joined.
+func (s *DefaultService) Dispatch(ctx context.Context, event ImageEvent) (int, error) {
+	endpoints, err := s.repo.ListSubscribed(ctx, event.ImageID, string(event.Type))
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	var errs []error
+	for _, e := range endpoints {
+		payload := Payload{
+			ID:        uuid.NewString(),
+			Type:      string(event.Type),
+			CreatedAt: event.OccurredAt,
+			Data: PayloadData{
+				ImageID:   event.ImageID,
+				ProjectID: e.ProjectID,
+				Status:    event.Status,
+				Error:     event.Error,
+			},
+		}
+		if _, err := s.deliveries.EnqueueEndpointWebhook(ctx, e.ID, e.URL, payload.Type, payload); err != nil {
+			errs = append(errs, fmt.Errorf("endpoint %s: %w", e.ID, err))
+			continue
+		}
+		queued++
+	}
+	return queued, errors.Join(errs...)
+}
+
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidEndpoint)
+	}
+	return nil
+}
+
+// normalizeEventTypes defaults an empty list to every supported event and rejects unknown or duplicate types.
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return slices.Clone(SupportedEventTypes), nil
+	}
+	out := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		if !slices.Contains(SupportedEventTypes, t) {
+			return nil, fmt.Errorf("%w: unsupported event type %q", ErrInvalidEndpoint, t)
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,254 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+	"github.com/real-staging-ai/api/internal/events"
+)
+
+func storedEndpoint() *Endpoint {
+	return &Endpoint{
+		ID:         "ep-1",
+		UserID:     "u-1",
+		ProjectID:  "p-1",
+		URL:        "https://example.com/hook",
+		Secret:     "whsec_stored",
+		EventTypes: append([]string(nil), SupportedEventTypes...),
+		Active:     true,
+	}
+}
+
+func TestDefaultService_CreateEndpoint(t *testing.T) {
+	ctx := context.Background()
+	echoCreate := func(ctx context.Context, e *Endpoint) (*Endpoint, error) {
+		created := *e
+		created.ID = "ep-1"
+		return &created, nil
+	}
+
+	t.Run("success: defaults to every event and returns a secret", func(t *testing.T) {
+		repo := &RepositoryMock{CreateFunc: echoCreate}
+		svc := NewDefaultService(repo, &delivery.ServiceMock{})
+
+		e, err := svc.CreateEndpoint(ctx, "u-1", "p-1", CreateEndpointRequest{URL: "https://example.com/hook"})
+		require.NoError(t, err)
+		assert.Equal(t, SupportedEventTypes, e.EventTypes)
+		assert.True(t, strings.HasPrefix(e.Secret, secretPrefix))
+		assert.Len(t, e.Secret, len(secretPrefix)+48)
+		assert.True(t, e.Active)
+		assert.Equal(t, "u-1", repo.CreateCalls()[0].E.UserID)
+	})
+
+	t.Run("success: de-duplicates event types", func(t *testing.T) {
+		svc := NewDefaultService(&RepositoryMock{CreateFunc: echoCreate}, &delivery.ServiceMock{})
+
+		e, err := svc.CreateEndpoint(ctx, "u-1", "p-1", CreateEndpointRequest{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"image.ready", "image.ready"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"image.ready"}, e.EventTypes)
+	})
+
+	for _, tc := range []struct {
+		name string
+		req  CreateEndpointRequest
+	}{
+		{name: "fail: relative url", req: CreateEndpointRequest{URL: "/hook"}},
+		{name: "fail: unsupported scheme", req: CreateEndpointRequest{URL: "ftp://example.com/hook"}},
+		{
+			name: "fail: unknown event type",
+			req:  CreateEndpointRequest{URL: "https://example.com/hook", EventTypes: []string{"image.deleted"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &RepositoryMock{}
+			svc := NewDefaultService(repo, &delivery.ServiceMock{})
+
+			_, err := svc.CreateEndpoint(ctx, "u-1", "p-1", tc.req)
+			assert.ErrorIs(t, err, ErrInvalidEndpoint)
+			assert.Empty(t, repo.CreateCalls())
+		})
+	}
+}
+
+func TestDefaultService_secretsHidden(t *testing.T) {
+	ctx := context.Background()
+	repo := &RepositoryMock{
+		GetByIDFunc: func(ctx context.Context, id, userID string) (*Endpoint, error) { return storedEndpoint(), nil },
+		ListByProjectFunc: func(ctx context.Context, projectID, userID string) ([]Endpoint, error) {
+			return []Endpoint{*storedEndpoint()}, nil
+		},
+		UpdateFunc: func(ctx context.Context, e *Endpoint) (*Endpoint, error) { return e, nil },
+	}
+	svc := NewDefaultService(repo, &delivery.ServiceMock{})
+
+	got, err := svc.GetEndpoint(ctx, "u-1", "ep-1")
+	require.NoError(t, err)
+	assert.Empty(t, got.Secret)
+
+	list, err := svc.ListEndpoints(ctx, "u-1", "p-1")
+	require.NoError(t, err)
+	assert.Empty(t, list[0].Secret)
+
+	inactive := false
+	updated, err := svc.UpdateEndpoint(ctx, "u-1", "ep-1", UpdateEndpointRequest{Active: &inactive})
+	require.NoError(t, err)
+	assert.Empty(t, updated.Secret)
+	assert.False(t, updated.Active)
+}
+
+func TestDefaultService_UpdateEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: changes only provided fields", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id, userID string) (*Endpoint, error) { return storedEndpoint(), nil },
+			UpdateFunc:  func(ctx context.Context, e *Endpoint) (*Endpoint, error) { return e, nil },
+		}
+		svc := NewDefaultService(repo, &delivery.ServiceMock{})
+
+		eventTypes := []string{"image.failed"}
+		e, err := svc.UpdateEndpoint(ctx, "u-1", "ep-1", UpdateEndpointRequest{EventTypes: &eventTypes})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"image.failed"}, e.EventTypes)
+		assert.Equal(t, "https://example.com/hook", e.URL)
+		assert.True(t, e.Active)
+	})
+
+	t.Run("fail: invalid url", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id, userID string) (*Endpoint, error) { return storedEndpoint(), nil },
+		}
+		svc := NewDefaultService(repo, &delivery.ServiceMock{})
+
+		bad := "not a url"
+		_, err := svc.UpdateEndpoint(ctx, "u-1", "ep-1", UpdateEndpointRequest{URL: &bad})
+		assert.ErrorIs(t, err, ErrInvalidEndpoint)
+		assert.Empty(t, repo.UpdateCalls())
+	})
+
+	t.Run("fail: not owned", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id, userID string) (*Endpoint, error) {
+				return nil, ErrEndpointNotFound
+			},
+		}
+		svc := NewDefaultService(repo, &delivery.ServiceMock{})
+
+		_, err := svc.UpdateEndpoint(ctx, "u-2", "ep-1", UpdateEndpointRequest{})
+		assert.ErrorIs(t, err, ErrEndpointNotFound)
+	})
+}
+
+func TestDefaultService_ListDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: filters the outbox by endpoint", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id, userID string) (*Endpoint, error) { return storedEndpoint(), nil },
+		}
+		deliveries := &delivery.ServiceMock{
+			ListDeliveriesFunc: func(ctx context.Context, filter delivery.ListFilter) ([]delivery.Delivery, error) {
+				return []delivery.Delivery{{ID: "d-1"}}, nil
+			},
+		}
+		svc := NewDefaultService(repo, deliveries)
+
+		got, err := svc.ListDeliveries(ctx, "u-1", "ep-1", 10, 5)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+
+		filter := deliveries.ListDeliveriesCalls()[0].Filter
+		assert.Equal(t, "ep-1", filter.EndpointID)
+		assert.Equal(t, delivery.ChannelWebhook, filter.Channel)
+		assert.Equal(t, 10, filter.Limit)
+		assert.Equal(t, 5, filter.Offset)
+	})
+
+	t.Run("fail: not owned", func(t *testing.T) {
+		repo := &RepositoryMock{
+			GetByIDFunc: func(ctx context.Context, id, userID string) (*Endpoint, error) {
+				return nil, ErrEndpointNotFound
+			},
+		}
+		deliveries := &delivery.ServiceMock{}
+		svc := NewDefaultService(repo, deliveries)
+
+		_, err := svc.ListDeliveries(ctx, "u-2", "ep-1", 0, 0)
+		assert.ErrorIs(t, err, ErrEndpointNotFound)
+		assert.Empty(t, deliveries.ListDeliveriesCalls())
+	})
+}
+
+func TestDefaultService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	second := storedEndpoint()
+	second.ID = "ep-2"
+	second.URL = "https://other.example.com/hook"
+	repo := &RepositoryMock{
+		ListSubscribedFunc: func(ctx context.Context, imageID, eventType string) ([]Endpoint, error) {
+			return []Endpoint{*storedEndpoint(), *second}, nil
+		},
+	}
+	deliveries := &delivery.ServiceMock{
+		EnqueueEndpointWebhookFunc: func(
+			ctx context.Context, endpointID, endpoint, eventType string, payload interface{},
+		) (*delivery.Delivery, error) {
+			if endpointID == "ep-1" {
+				return nil, errors.New("db down")
+			}
+			return &delivery.Delivery{ID: "d-1"}, nil
+		},
+	}
+	svc := NewDefaultService(repo, deliveries)
+
+	n, err := svc.Dispatch(ctx, ImageEvent{
+		Type: events.TypeImageFailed, ImageID: "img-1", Status: "error", Error: "model failed", OccurredAt: at,
+	})
+	assert.Equal(t, 1, n)
+	assert.ErrorContains(t, err, "endpoint ep-1")
+
+	assert.Equal(t, "image.failed", repo.ListSubscribedCalls()[0].EventType)
+	require.Len(t, deliveries.EnqueueEndpointWebhookCalls(), 2)
+	call := deliveries.EnqueueEndpointWebhookCalls()[1]
+	assert.Equal(t, "ep-2", call.EndpointID)
+	assert.Equal(t, "https://other.example.com/hook", call.Endpoint)
+	payload, ok := call.Payload.(Payload)
+	require.True(t, ok)
+	assert.Equal(t, "image.failed", payload.Type)
+	assert.Equal(t, at, payload.CreatedAt)
+	assert.Equal(t, PayloadData{ImageID: "img-1", ProjectID: "p-1", Status: "error", Error: "model failed"}, payload.Data)
+	assert.NotEmpty(t, payload.ID)
+}
+
+func TestRegisterSubscribers(t *testing.T) {
+	svc := &ServiceMock{
+		DispatchFunc: func(ctx context.Context, event ImageEvent) (int, error) { return 1, nil },
+	}
+	bus := events.NewDefaultBus(nil)
+	RegisterSubscribers(bus, svc)
+
+	ctx := context.Background()
+	require.NoError(t, bus.Publish(ctx, events.ImageProcessing{ImageID: "img-1"}))
+	require.NoError(t, bus.Publish(ctx, events.ImageReady{ImageID: "img-1"}))
+	require.NoError(t, bus.Publish(ctx, events.ImageFailed{ImageID: "img-1", Error: "boom"}))
+	require.NoError(t, bus.Publish(ctx, events.ImageCreated{ImageID: "img-1"}))
+
+	calls := svc.DispatchCalls()
+	require.Len(t, calls, 3)
+	assert.Equal(t, ImageEvent{Type: events.TypeImageProcessing, ImageID: "img-1", Status: "processing"}, calls[0].Event)
+	assert.Equal(t, "ready", calls[1].Event.Status)
+	assert.Equal(t,
+		ImageEvent{Type: events.TypeImageFailed, ImageID: "img-1", Status: "error", Error: "boom"}, calls[2].Event)
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import "github.com/labstack/echo/v4"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out handler_mock.go . Handler
+
+// Handler defines the HTTP endpoints for webhook registration and history.
+type Handler interface {
+	// CreateEndpoint handles POST /projects/:project_id/webhooks - Registers a callback URL.
+	CreateEndpoint(c echo.Context) error
+
+	// ListEndpoints handles GET /projects/:project_id/webhooks - Lists a project's endpoints.
+	ListEndpoints(c echo.Context) error
+
+	// GetEndpoint handles GET /webhooks/:id - Gets an endpoint.
+	GetEndpoint(c echo.Context) error
+
+	// UpdateEndpoint handles PATCH /webhooks/:id - Updates an endpoint.
+	UpdateEndpoint(c echo.Context) error
+
+	// DeleteEndpoint handles DELETE /webhooks/:id - Deletes an endpoint and its history.
+	DeleteEndpoint(c echo.Context) error
+
+	// ListDeliveries handles GET /webhooks/:id/deliveries - Lists an endpoint's delivery history.
+	ListDeliveries(c echo.Context) error
+}
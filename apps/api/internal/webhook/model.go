@@ -0,0 +1,85 @@
+// Package webhook lets users register per-project callback URLs that receive
+// signed POSTs when their images move through the staging lifecycle.
+//
+// Deliveries reuse the outbound_deliveries outbox, so retries and the
+// per-attempt log come from the delivery worker; this package owns endpoint
+// registration, event fan-out and the per-endpoint history view.
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/api/internal/events"
+)
+
+var (
+	// ErrEndpointNotFound is returned when an endpoint does not exist or belongs to another user.
+	ErrEndpointNotFound = errors.New("webhook endpoint not found")
+	// ErrInvalidEndpoint is returned when a URL or event type fails validation.
+	ErrInvalidEndpoint = errors.New("invalid webhook endpoint")
+)
+
+// SupportedEventTypes are the image lifecycle events an endpoint can subscribe to.
+var SupportedEventTypes = []string{
+	string(events.TypeImageProcessing),
+	string(events.TypeImageReady),
+	string(events.TypeImageFailed),
+}
+
+// Endpoint is a registered callback URL for one project.
+//
+// Secret is only populated in the response that creates the endpoint; it is
+// the HMAC-SHA256 key the receiver uses to verify X-RealStaging-Signature.
+type Endpoint struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"-"`
+	ProjectID   string    `json:"project_id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	EventTypes  []string  `json:"event_types"`
+	Description *string   `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateEndpointRequest registers a callback URL. An empty EventTypes subscribes to every supported event.
+type CreateEndpointRequest struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	Description *string  `json:"description,omitempty"`
+}
+
+// UpdateEndpointRequest changes an endpoint. Nil fields are left unchanged.
+type UpdateEndpointRequest struct {
+	URL         *string   `json:"url,omitempty"`
+	EventTypes  *[]string `json:"event_types,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Active      *bool     `json:"active,omitempty"`
+}
+
+// ImageEvent is an image lifecycle transition to fan out to subscribed endpoints.
+type ImageEvent struct {
+	Type       events.Type
+	ImageID    string
+	Status     string
+	Error      string
+	OccurredAt time.Time
+}
+
+// Payload is the JSON body POSTed to an endpoint.
+type Payload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      PayloadData `json:"data"`
+}
+
+// PayloadData describes the image that changed state.
+type PayloadData struct {
+	ImageID   string `json:"image_id"`
+	ProjectID string `json:"project_id"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
@@ -0,0 +1,27 @@
+package webhook
+
+import "context"
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
+
+// Repository defines data access for webhook endpoints. Every user-facing
+// lookup is scoped by user ID so one user can never see another's endpoints.
+type Repository interface {
+	// Create inserts a new endpoint.
+	Create(ctx context.Context, e *Endpoint) (*Endpoint, error)
+
+	// GetByID retrieves an endpoint owned by the user.
+	GetByID(ctx context.Context, id, userID string) (*Endpoint, error)
+
+	// ListByProject returns the user's endpoints for a project, oldest first.
+	ListByProject(ctx context.Context, projectID, userID string) ([]Endpoint, error)
+
+	// Update persists URL, event types, description and active flag.
+	Update(ctx context.Context, e *Endpoint) (*Endpoint, error)
+
+	// Delete removes an endpoint owned by the user together with its delivery history.
+	Delete(ctx context.Context, id, userID string) error
+
+	// ListSubscribed returns the active endpoints of the image's project that subscribe to eventType.
+	ListSubscribed(ctx context.Context, imageID, eventType string) ([]Endpoint, error)
+}
@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/delivery"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
+
+// Service defines the business logic for webhook endpoints.
+type Service interface {
+	// CreateEndpoint registers a callback URL for a project and returns it with its signing secret.
+	CreateEndpoint(ctx context.Context, userID, projectID string, req CreateEndpointRequest) (*Endpoint, error)
+
+	// ListEndpoints returns the user's endpoints for a project.
+	ListEndpoints(ctx context.Context, userID, projectID string) ([]Endpoint, error)
+
+	// GetEndpoint retrieves one of the user's endpoints.
+	GetEndpoint(ctx context.Context, userID, id string) (*Endpoint, error)
+
+	// UpdateEndpoint changes an endpoint's URL, event types, description or active flag.
+	UpdateEndpoint(ctx context.Context, userID, id string, req UpdateEndpointRequest) (*Endpoint, error)
+
+	// DeleteEndpoint removes an endpoint and its delivery history.
+	DeleteEndpoint(ctx context.Context, userID, id string) error
+
+	// ListDeliveries returns an endpoint's delivery history, newest first.
+	ListDeliveries(ctx context.Context, userID, id string, limit, offset int) ([]delivery.Delivery, error)
+
+	// Dispatch queues a signed delivery of the event to every subscribed endpoint
+	// and returns the number of deliveries queued.
+	Dispatch(ctx context.Context, event ImageEvent) (int, error)
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/real-staging-ai/api/internal/events"
+)
+
+// RegisterSubscribers fans image lifecycle events out to registered endpoints.
+func RegisterSubscribers(bus events.Bus, svc Service) {
+	events.On(bus, "webhook", func(ctx context.Context, e events.ImageProcessing) error {
+		_, err := svc.Dispatch(ctx, ImageEvent{
+			Type: e.EventType(), ImageID: e.ImageID, Status: "processing", OccurredAt: e.OccurredAt,
+		})
+		return err
+	})
+	events.On(bus, "webhook", func(ctx context.Context, e events.ImageReady) error {
+		_, err := svc.Dispatch(ctx, ImageEvent{
+			Type: e.EventType(), ImageID: e.ImageID, Status: "ready", OccurredAt: e.OccurredAt,
+		})
+		return err
+	})
+	events.On(bus, "webhook", func(ctx context.Context, e events.ImageFailed) error {
+		_, err := svc.Dispatch(ctx, ImageEvent{
+			Type: e.EventType(), ImageID: e.ImageID, Status: "error", Error: e.Error, OccurredAt: e.OccurredAt,
+		})
+		return err
+	})
+}
@@ -0,0 +1,70 @@
+package modelconfig
+
+// JSONSchemaDialect is the JSON Schema draft emitted for model configurations.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is the subset of JSON Schema (draft 2020-12) needed to describe a
+// model configuration object and its fields.
+type JSONSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	ID          string                 `json:"$id,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Enum        []string               `json:"enum,omitempty"`
+	Default     interface{}            `json:"default,omitempty"`
+	Minimum     *float64               `json:"minimum,omitempty"`
+	Maximum     *float64               `json:"maximum,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	WriteOnly   bool                   `json:"writeOnly,omitempty"`
+}
+
+// ToJSONSchema converts the bespoke field list into a standard JSON Schema
+// document so external tools and form generators can consume it directly.
+func (s *Schema) ToJSONSchema() *JSONSchema {
+	out := &JSONSchema{
+		Schema:     JSONSchemaDialect,
+		ID:         "urn:realstaging:model-config:" + s.ModelID,
+		Title:      s.DisplayName,
+		Type:       "object",
+		Properties: make(map[string]*JSONSchema, len(s.Fields)),
+		Required:   []string{},
+	}
+
+	for _, f := range s.Fields {
+		prop := &JSONSchema{
+			Type:        jsonSchemaType(f.Type),
+			Description: f.Description,
+			Enum:        f.Options,
+			Default:     f.Default,
+			Minimum:     f.Min,
+			Maximum:     f.Max,
+		}
+		if f.Secret {
+			prop.Format = "password"
+			prop.WriteOnly = true
+		}
+		out.Properties[f.Name] = prop
+		if f.Required {
+			out.Required = append(out.Required, f.Name)
+		}
+	}
+
+	return out
+}
+
+// jsonSchemaType maps a Field type onto its JSON Schema type.
+func jsonSchemaType(fieldType string) string {
+	switch fieldType {
+	case "int":
+		return "integer"
+	case "float":
+		return "number"
+	case "bool":
+		return "boolean"
+	default:
+		return fieldType
+	}
+}
@@ -0,0 +1,85 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_ToJSONSchema(t *testing.T) {
+	schema := &Schema{
+		ModelID:     "test/model",
+		DisplayName: "Test Model",
+		Fields: []Field{
+			{Name: "go_fast", Type: "bool", Default: false, Description: "Fast mode", Required: true},
+			{Name: "steps", Type: "int", Default: 28, Min: ptr(1.0), Max: ptr(50.0)},
+			{Name: "guidance", Type: "float", Default: 2.5},
+			{Name: "format", Type: "string", Default: "png", Options: []string{"png", "jpg"}, Required: true},
+			{Name: "api_key", Type: "string", Default: "", Secret: true},
+		},
+	}
+
+	out := schema.ToJSONSchema()
+
+	assert.Equal(t, JSONSchemaDialect, out.Schema)
+	assert.Equal(t, "urn:realstaging:model-config:test/model", out.ID)
+	assert.Equal(t, "Test Model", out.Title)
+	assert.Equal(t, "object", out.Type)
+	assert.Equal(t, []string{"go_fast", "format"}, out.Required)
+
+	assert.Equal(t, "boolean", out.Properties["go_fast"].Type)
+	assert.Equal(t, "integer", out.Properties["steps"].Type)
+	assert.Equal(t, 1.0, *out.Properties["steps"].Minimum)
+	assert.Equal(t, 50.0, *out.Properties["steps"].Maximum)
+	assert.Equal(t, "number", out.Properties["guidance"].Type)
+	assert.Equal(t, []string{"png", "jpg"}, out.Properties["format"].Enum)
+	assert.Equal(t, "password", out.Properties["api_key"].Format)
+	assert.True(t, out.Properties["api_key"].WriteOnly)
+	assert.False(t, out.Properties["format"].WriteOnly)
+
+	// Zero-valued defaults must survive serialization.
+	raw, err := json.Marshal(out)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	props := decoded["properties"].(map[string]interface{})
+	assert.Equal(t, false, props["go_fast"].(map[string]interface{})["default"])
+}
+
+func TestSchema_ToJSONSchema_knownModels(t *testing.T) {
+	for _, id := range []string{
+		"qwen/qwen-image-edit",
+		"black-forest-labs/flux-kontext-max",
+		"bytedance/seedream-4",
+		"openai/gpt-image-1",
+		"openai/gpt-image-1.5",
+		"stability-ai/stable-image-inpaint",
+	} {
+		t.Run(id, func(t *testing.T) {
+			schema, err := Get(id)
+			require.NoError(t, err)
+
+			out := schema.ToJSONSchema()
+			require.Len(t, out.Properties, len(schema.Fields))
+			for name, prop := range out.Properties {
+				assert.Contains(t, []string{"string", "integer", "number", "boolean"}, prop.Type, name)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Run("success: shared schema carries the model ID", func(t *testing.T) {
+		schema, err := Get("black-forest-labs/flux-kontext-pro")
+		require.NoError(t, err)
+		assert.Equal(t, "black-forest-labs/flux-kontext-pro", schema.ModelID)
+		assert.Equal(t, "Flux Kontext Pro", schema.DisplayName)
+	})
+
+	t.Run("fail: unknown model", func(t *testing.T) {
+		_, err := Get("acme/unknown")
+		assert.ErrorIs(t, err, ErrUnknownModel)
+	})
+}
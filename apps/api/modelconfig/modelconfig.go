@@ -0,0 +1,351 @@
+// Package modelconfig describes the configuration each staging model accepts.
+// The API serves these schemas to the admin UI, which generates its forms from
+// them, and the worker tests its config validation against them, so a form can
+// only offer values the worker accepts. Like the encryption package it seals
+// secrets with, it imports only the standard library, so the worker can use
+// it too.
+package modelconfig
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownModel is returned for a model that has no configuration schema.
+var ErrUnknownModel = errors.New("unknown model ID")
+
+// Field describes one configuration field.
+type Field struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"` // "string", "int", "float" or "bool"
+	Default     interface{} `json:"default"`
+	Description string      `json:"description"`
+	Options     []string    `json:"options,omitempty"` // For dropdown fields
+	Min         *float64    `json:"min,omitempty"`     // For numeric fields
+	Max         *float64    `json:"max,omitempty"`     // For numeric fields
+	Required    bool        `json:"required"`
+	// Secret marks a credential such as an API key. Forms should mask it.
+	Secret bool `json:"secret,omitempty"`
+}
+
+// Schema describes the configuration of one model.
+type Schema struct {
+	ModelID     string  `json:"model_id"`
+	DisplayName string  `json:"display_name"`
+	Fields      []Field `json:"fields"`
+	// Inpaints marks a model that can stage only the region a mask marks.
+	Inpaints bool `json:"inpaints,omitempty"`
+}
+
+// Get returns the configuration schema for modelID.
+func Get(modelID string) (*Schema, error) {
+	switch modelID {
+	case "qwen/qwen-image-edit":
+		return qwenSchema(), nil
+	case "black-forest-labs/flux-kontext-max", "black-forest-labs/flux-kontext-pro":
+		return fluxKontextSchema(modelID), nil
+	case "bytedance/seedream-3", "bytedance/seedream-4":
+		return seedreamSchema(modelID), nil
+	case "openai/gpt-image-1":
+		return gptImageSchema(modelID, "OpenAI GPT Image 1"), nil
+	case "openai/gpt-image-1.5":
+		return gptImageSchema(modelID, "OpenAI GPT Image 1.5"), nil
+	case "stability-ai/stable-image-inpaint":
+		return stabilityInpaintSchema(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownModel, modelID)
+	}
+}
+
+func qwenSchema() *Schema {
+	return &Schema{
+		ModelID:     "qwen/qwen-image-edit",
+		DisplayName: "Qwen Image Edit",
+		Fields: []Field{
+			{
+				Name:        "go_fast",
+				Type:        "bool",
+				Default:     true,
+				Description: "Enable fast mode for quicker processing",
+				Required:    true,
+			},
+			{
+				Name:        "aspect_ratio",
+				Type:        "string",
+				Default:     "match_input_image",
+				Description: "Output aspect ratio",
+				Options:     []string{"1:1", "16:9", "4:3", "3:2", "match_input_image"},
+				Required:    true,
+			},
+			{
+				Name:        "output_format",
+				Type:        "string",
+				Default:     "webp",
+				Description: "Output image format",
+				Options:     []string{"webp", "png", "jpg"},
+				Required:    true,
+			},
+			{
+				Name:        "output_quality",
+				Type:        "int",
+				Default:     80,
+				Description: "Output image quality (1-100)",
+				Min:         ptr(1.0),
+				Max:         ptr(100.0),
+				Required:    true,
+			},
+		},
+	}
+}
+
+func fluxKontextSchema(modelID string) *Schema {
+	displayName := "Flux Kontext Max"
+	if modelID == "black-forest-labs/flux-kontext-pro" {
+		displayName = "Flux Kontext Pro"
+	}
+
+	return &Schema{
+		ModelID:     modelID,
+		DisplayName: displayName,
+		Fields: []Field{
+			{
+				Name:        "aspect_ratio",
+				Type:        "string",
+				Default:     "match_input_image",
+				Description: "Output aspect ratio",
+				Options:     []string{"1:1", "16:9", "4:3", "3:2", "match_input_image"},
+				Required:    true,
+			},
+			{
+				Name:        "output_format",
+				Type:        "string",
+				Default:     "png",
+				Description: "Output image format",
+				Options:     []string{"webp", "png", "jpg"},
+				Required:    true,
+			},
+			{
+				Name:        "safety_tolerance",
+				Type:        "int",
+				Default:     4,
+				Description: "Safety filter tolerance (1=strict, 6=permissive)",
+				Min:         ptr(1.0),
+				Max:         ptr(6.0),
+				Required:    true,
+			},
+			{
+				Name:        "prompt_upsampling",
+				Type:        "bool",
+				Default:     false,
+				Description: "Enhance prompts automatically",
+				Required:    true,
+			},
+			{
+				Name:        "num_outputs",
+				Type:        "int",
+				Default:     1,
+				Description: "Number of images to generate",
+				Min:         ptr(1.0),
+				Max:         ptr(4.0),
+				Required:    true,
+			},
+			{
+				Name:        "output_quality",
+				Type:        "int",
+				Default:     90,
+				Description: "Output image quality (1-100)",
+				Min:         ptr(1.0),
+				Max:         ptr(100.0),
+				Required:    true,
+			},
+		},
+	}
+}
+
+func seedreamSchema(modelID string) *Schema {
+	displayName := "Seedream 3"
+	if modelID == "bytedance/seedream-4" {
+		displayName = "Seedream 4"
+	}
+
+	return &Schema{
+		ModelID:     modelID,
+		DisplayName: displayName,
+		Fields: []Field{
+			{
+				Name:        "aspect_ratio",
+				Type:        "string",
+				Default:     "1:1",
+				Description: "Output aspect ratio",
+				Options:     []string{"1:1", "16:9", "4:3", "3:2"},
+				Required:    true,
+			},
+			{
+				Name:        "num_inference_steps",
+				Type:        "int",
+				Default:     50,
+				Description: "Number of denoising steps (more = higher quality, slower)",
+				Min:         ptr(20.0),
+				Max:         ptr(100.0),
+				Required:    true,
+			},
+			{
+				Name:        "guidance_scale",
+				Type:        "float",
+				Default:     7.5,
+				Description: "How closely to follow the prompt (1.0-20.0)",
+				Min:         ptr(1.0),
+				Max:         ptr(20.0),
+				Required:    true,
+			},
+			{
+				Name:        "output_quality",
+				Type:        "int",
+				Default:     95,
+				Description: "Output image quality (1-100)",
+				Min:         ptr(1.0),
+				Max:         ptr(100.0),
+				Required:    true,
+			},
+		},
+	}
+}
+
+// gptImageSchema describes the GPT Image models, which share their parameters.
+func gptImageSchema(modelID, displayName string) *Schema {
+	return &Schema{
+		ModelID:     modelID,
+		DisplayName: displayName,
+		Fields: []Field{
+			{
+				Name:    "openai_api_key",
+				Type:    "string",
+				Default: "",
+				Description: "OpenAI API key for users who haven't added their own. " +
+					"Without it, only users with their own key can use this model.",
+				Secret: true,
+			},
+			{
+				Name:        "prompt",
+				Type:        "string",
+				Default:     "",
+				Description: "Prompt to use when the staging job doesn't provide one",
+			},
+			{
+				Name:        "quality",
+				Type:        "string",
+				Default:     "auto",
+				Description: "The quality of the generated image",
+				Options:     []string{"low", "medium", "high", "auto"},
+				Required:    true,
+			},
+			{
+				Name:    "user_id",
+				Type:    "string",
+				Default: "",
+				Description: "An optional unique identifier representing your end-user. " +
+					"This helps OpenAI monitor and detect abuse.",
+			},
+			{
+				Name:        "background",
+				Type:        "string",
+				Default:     "auto",
+				Description: "Set whether the background is transparent or opaque or choose automatically",
+				Options:     []string{"auto", "transparent", "opaque"},
+				Required:    true,
+			},
+			{
+				Name:        "moderation",
+				Type:        "string",
+				Default:     "auto",
+				Description: "Content moderation level",
+				Options:     []string{"auto", "low"},
+				Required:    true,
+			},
+			{
+				Name:        "aspect_ratio",
+				Type:        "string",
+				Default:     "1:1",
+				Description: "The aspect ratio of the generated image",
+				Options:     []string{"1:1", "3:2", "2:3"},
+				Required:    true,
+			},
+			{
+				Name:        "number_of_images",
+				Type:        "int",
+				Default:     1,
+				Description: "Number of images to generate (1-10)",
+				Min:         ptr(1.0),
+				Max:         ptr(10.0),
+				Required:    true,
+			},
+			{
+				Name:        "output_compression",
+				Type:        "int",
+				Default:     90,
+				Description: "Compression level (0-100%)",
+				Min:         ptr(0.0),
+				Max:         ptr(100.0),
+			},
+			{
+				Name:        "output_format",
+				Type:        "string",
+				Default:     "webp",
+				Description: "Output image format",
+				Options:     []string{"png", "jpeg", "webp"},
+				Required:    true,
+			},
+			{
+				Name:        "input_fidelity",
+				Type:        "string",
+				Default:     "low",
+				Description: "How closely the output should match the style and features of the input image",
+				Options:     []string{"low", "high"},
+				Required:    true,
+			},
+		},
+	}
+}
+
+func stabilityInpaintSchema() *Schema {
+	return &Schema{
+		ModelID:     "stability-ai/stable-image-inpaint",
+		DisplayName: "Stable Image Inpaint",
+		Inpaints:    true,
+		Fields: []Field{
+			{
+				Name:        "negative_prompt",
+				Type:        "string",
+				Default:     "",
+				Description: "What the staged image should not contain",
+			},
+			{
+				Name:        "strength",
+				Type:        "float",
+				Default:     0.7,
+				Description: "How much of the photo the model may repaint (0-1)",
+				Min:         ptr(0.01),
+				Max:         ptr(1.0),
+				Required:    true,
+			},
+			{
+				Name:        "output_format",
+				Type:        "string",
+				Default:     "png",
+				Description: "Output image format",
+				Options:     []string{"png", "jpeg", "webp"},
+				Required:    true,
+			},
+		},
+	}
+}
+
+// Inpaints reports whether modelID can stage only the region a mask marks.
+func Inpaints(modelID string) bool {
+	schema, err := Get(modelID)
+	return err == nil && schema.Inpaints
+}
+
+func ptr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,86 @@
+package modelconfig
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/real-staging-ai/api/encryption"
+)
+
+// Redacted replaces a secret's value in configs sent to clients. A client
+// that sends it back unchanged keeps the stored secret.
+const Redacted = "********"
+
+// sealedPrefix marks a secret value sealed with the encryption keyring.
+// Values without it were stored before secrets were sealed and are used
+// as they are.
+const sealedPrefix = "sealed:"
+
+// ErrNoKeyring is returned when a config has a secret to seal or open but no
+// encryption key is configured.
+var ErrNoKeyring = errors.New("no encryption key configured for secret fields")
+
+// SealSecrets replaces the value of each secret field in config with its
+// ciphertext. Values that are empty or already sealed are left alone. The
+// ciphertext is bound to the model and field, so it can't be moved to another.
+func (s *Schema) SealSecrets(config map[string]interface{}, keyring *encryption.Keyring) error {
+	for _, f := range s.Fields {
+		v, ok := config[f.Name].(string)
+		if !f.Secret || !ok || v == "" || IsSealed(v) {
+			continue
+		}
+		if keyring == nil {
+			return fmt.Errorf("%w: %s", ErrNoKeyring, f.Name)
+		}
+		sealed, err := keyring.Seal([]byte(v), s.secretAAD(f.Name))
+		if err != nil {
+			return fmt.Errorf("failed to seal %s: %w", f.Name, err)
+		}
+		config[f.Name] = sealedPrefix + base64.StdEncoding.EncodeToString(sealed)
+	}
+	return nil
+}
+
+// OpenSecrets replaces each sealed secret in config with its plaintext.
+func (s *Schema) OpenSecrets(config map[string]interface{}, keyring *encryption.Keyring) error {
+	for _, f := range s.Fields {
+		v, ok := config[f.Name].(string)
+		if !f.Secret || !ok || !IsSealed(v) {
+			continue
+		}
+		if keyring == nil {
+			return fmt.Errorf("%w: %s", ErrNoKeyring, f.Name)
+		}
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(v, sealedPrefix))
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", f.Name, encryption.ErrMalformed)
+		}
+		plaintext, err := keyring.Open(sealed, s.secretAAD(f.Name))
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+		config[f.Name] = string(plaintext)
+	}
+	return nil
+}
+
+// RedactSecrets replaces the value of each secret field that is set with
+// Redacted.
+func (s *Schema) RedactSecrets(config map[string]interface{}) {
+	for _, f := range s.Fields {
+		if v, ok := config[f.Name].(string); f.Secret && ok && v != "" {
+			config[f.Name] = Redacted
+		}
+	}
+}
+
+// IsSealed reports whether v is a sealed secret.
+func IsSealed(v string) bool {
+	return strings.HasPrefix(v, sealedPrefix)
+}
+
+func (s *Schema) secretAAD(field string) []byte {
+	return []byte("model-config:" + s.ModelID + ":" + field)
+}
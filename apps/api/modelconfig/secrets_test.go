@@ -0,0 +1,104 @@
+package modelconfig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/encryption"
+)
+
+func testKeyring(t *testing.T) *encryption.Keyring {
+	t.Helper()
+	kr, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, encryption.KeySize)))
+	require.NoError(t, err)
+	return kr
+}
+
+func TestSchema_SealSecrets(t *testing.T) {
+	schema, err := Get("openai/gpt-image-1")
+	require.NoError(t, err)
+	kr := testKeyring(t)
+
+	t.Run("success: seals secrets and round-trips", func(t *testing.T) {
+		cfg := map[string]interface{}{"openai_api_key": "sk-live", "quality": "high"}
+
+		require.NoError(t, schema.SealSecrets(cfg, kr))
+
+		sealed := cfg["openai_api_key"].(string)
+		assert.True(t, IsSealed(sealed))
+		assert.NotContains(t, sealed, "sk-live")
+		assert.Equal(t, "high", cfg["quality"])
+
+		// Sealing again leaves the ciphertext alone.
+		require.NoError(t, schema.SealSecrets(cfg, kr))
+		assert.Equal(t, sealed, cfg["openai_api_key"])
+
+		require.NoError(t, schema.OpenSecrets(cfg, kr))
+		assert.Equal(t, "sk-live", cfg["openai_api_key"])
+	})
+
+	t.Run("success: empty secret needs no key", func(t *testing.T) {
+		cfg := map[string]interface{}{"openai_api_key": ""}
+		assert.NoError(t, schema.SealSecrets(cfg, nil))
+	})
+
+	t.Run("fail: no keyring", func(t *testing.T) {
+		cfg := map[string]interface{}{"openai_api_key": "sk-live"}
+		assert.ErrorIs(t, schema.SealSecrets(cfg, nil), ErrNoKeyring)
+		assert.Equal(t, "sk-live", cfg["openai_api_key"])
+	})
+}
+
+func TestSchema_OpenSecrets(t *testing.T) {
+	schema, err := Get("openai/gpt-image-1")
+	require.NoError(t, err)
+	kr := testKeyring(t)
+	sealedFor := func(s *Schema) string {
+		cfg := map[string]interface{}{"openai_api_key": "sk-live"}
+		require.NoError(t, s.SealSecrets(cfg, kr))
+		return cfg["openai_api_key"].(string)
+	}
+
+	t.Run("success: plaintext stored before sealing is used as is", func(t *testing.T) {
+		cfg := map[string]interface{}{"openai_api_key": "sk-legacy"}
+		require.NoError(t, schema.OpenSecrets(cfg, nil))
+		assert.Equal(t, "sk-legacy", cfg["openai_api_key"])
+	})
+
+	t.Run("fail: no keyring", func(t *testing.T) {
+		cfg := map[string]interface{}{"openai_api_key": sealedFor(schema)}
+		assert.ErrorIs(t, schema.OpenSecrets(cfg, nil), ErrNoKeyring)
+	})
+
+	t.Run("fail: sealed for another model", func(t *testing.T) {
+		other, err := Get("openai/gpt-image-1.5")
+		require.NoError(t, err)
+		cfg := map[string]interface{}{"openai_api_key": sealedFor(other)}
+
+		assert.Error(t, schema.OpenSecrets(cfg, kr))
+	})
+
+	t.Run("fail: malformed ciphertext", func(t *testing.T) {
+		cfg := map[string]interface{}{"openai_api_key": sealedPrefix + "not base64!"}
+		assert.ErrorIs(t, schema.OpenSecrets(cfg, kr), encryption.ErrMalformed)
+	})
+}
+
+func TestSchema_RedactSecrets(t *testing.T) {
+	schema, err := Get("openai/gpt-image-1")
+	require.NoError(t, err)
+
+	cfg := map[string]interface{}{"openai_api_key": "sealed:abc", "user_id": "u1"}
+	schema.RedactSecrets(cfg)
+
+	assert.Equal(t, Redacted, cfg["openai_api_key"])
+	assert.Equal(t, "u1", cfg["user_id"])
+
+	empty := map[string]interface{}{"openai_api_key": ""}
+	schema.RedactSecrets(empty)
+	assert.Empty(t, empty["openai_api_key"])
+}
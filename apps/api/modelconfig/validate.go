@@ -0,0 +1,176 @@
+package modelconfig
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ValidationError lists what is wrong with a submitted model configuration.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// FieldError is one invalid field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+	return "invalid model config: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks config against the schema: every required field is set,
+// each value has the field's type and lies within its options and range, and
+// no field is unknown. Optional fields may be left out or null. It returns a
+// *ValidationError listing every problem, in schema order with unknown fields
+// last, or nil.
+func (s *Schema) Validate(config map[string]interface{}) error {
+	var errs []FieldError
+	known := make(map[string]bool, len(s.Fields))
+	for _, f := range s.Fields {
+		known[f.Name] = true
+		if msg := f.check(config[f.Name]); msg != "" {
+			errs = append(errs, FieldError{Field: f.Name, Message: msg})
+		}
+	}
+
+	var unknown []string
+	for k := range config {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		errs = append(errs, FieldError{Field: k, Message: "unknown field"})
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Fields: errs}
+	}
+	return nil
+}
+
+// ValidateOverrides checks a partial config that is laid over the admin's
+// config for the model: each value must be valid for its field, no field may
+// be unknown or secret, and a required field may not be set to null. It
+// returns a *ValidationError like Validate, or nil.
+func (s *Schema) ValidateOverrides(overrides map[string]interface{}) error {
+	fields := make(map[string]Field, len(s.Fields))
+	for _, f := range s.Fields {
+		fields[f.Name] = f
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []FieldError
+	for _, k := range keys {
+		f, ok := fields[k]
+		switch {
+		case !ok:
+			errs = append(errs, FieldError{Field: k, Message: "unknown field"})
+		case f.Secret:
+			errs = append(errs, FieldError{Field: k, Message: "cannot be overridden"})
+		default:
+			if msg := f.check(overrides[k]); msg != "" {
+				errs = append(errs, FieldError{Field: k, Message: msg})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Fields: errs}
+	}
+	return nil
+}
+
+// check returns why v is not a valid value for f, or "". A nil v is a field
+// that was left out or set to null.
+func (f Field) check(v interface{}) string {
+	if v == nil {
+		if f.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	switch f.Type {
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if f.Required && len(f.Options) == 0 && strings.TrimSpace(s) == "" {
+			return "is required"
+		}
+		if len(f.Options) > 0 && !slices.Contains(f.Options, s) {
+			return "must be one of: " + strings.Join(f.Options, ", ")
+		}
+	case "bool":
+		if _, ok := v.(bool); !ok {
+			return "must be a boolean"
+		}
+	case "int":
+		n, ok := number(v)
+		if !ok || n != math.Trunc(n) {
+			return "must be an integer"
+		}
+		return f.checkRange(n)
+	case "float":
+		n, ok := number(v)
+		if !ok {
+			return "must be a number"
+		}
+		return f.checkRange(n)
+	}
+	return ""
+}
+
+// checkRange returns why n is outside the field's range, or "".
+func (f Field) checkRange(n float64) string {
+	switch {
+	case f.Min != nil && f.Max != nil && (n < *f.Min || n > *f.Max):
+		return fmt.Sprintf("must be between %s and %s", formatNumber(*f.Min), formatNumber(*f.Max))
+	case f.Min != nil && n < *f.Min:
+		return "must be at least " + formatNumber(*f.Min)
+	case f.Max != nil && n > *f.Max:
+		return "must be at most " + formatNumber(*f.Max)
+	}
+	return ""
+}
+
+// number returns v as a float64. Decoded JSON numbers are float64; Go
+// callers may pass any integer or float type.
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,176 @@
+package modelconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	schema := &Schema{
+		ModelID: "test/model",
+		Fields: []Field{
+			{Name: "go_fast", Type: "bool", Required: true},
+			{Name: "steps", Type: "int", Min: ptr(1.0), Max: ptr(50.0), Required: true},
+			{Name: "guidance", Type: "float", Min: ptr(1.0)},
+			{Name: "format", Type: "string", Options: []string{"png", "jpg"}, Required: true},
+			{Name: "api_key", Type: "string", Required: true, Secret: true},
+			{Name: "user_id", Type: "string"},
+		},
+	}
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"go_fast": true, "steps": 28.0, "guidance": 2.5, "format": "png", "api_key": "sk-test", "user_id": "u1",
+		}
+	}
+
+	t.Run("success: valid config", func(t *testing.T) {
+		assert.NoError(t, schema.Validate(valid()))
+	})
+
+	t.Run("success: optional fields left out or null", func(t *testing.T) {
+		cfg := valid()
+		delete(cfg, "guidance")
+		cfg["user_id"] = nil
+
+		assert.NoError(t, schema.Validate(cfg))
+	})
+
+	t.Run("success: decoded JSON and Go integers", func(t *testing.T) {
+		var cfg map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"go_fast":false,"steps":50,"format":"jpg","api_key":"k"}`), &cfg))
+		assert.NoError(t, schema.Validate(cfg))
+
+		cfg["steps"] = 1
+		assert.NoError(t, schema.Validate(cfg))
+	})
+
+	tests := []struct {
+		name   string
+		modify func(cfg map[string]interface{})
+		want   []FieldError
+	}{
+		{
+			name:   "fail: required field missing",
+			modify: func(cfg map[string]interface{}) { delete(cfg, "go_fast") },
+			want:   []FieldError{{Field: "go_fast", Message: "is required"}},
+		},
+		{
+			name:   "fail: required string empty",
+			modify: func(cfg map[string]interface{}) { cfg["api_key"] = "  " },
+			want:   []FieldError{{Field: "api_key", Message: "is required"}},
+		},
+		{
+			name:   "fail: wrong type",
+			modify: func(cfg map[string]interface{}) { cfg["go_fast"] = "yes" },
+			want:   []FieldError{{Field: "go_fast", Message: "must be a boolean"}},
+		},
+		{
+			name:   "fail: fractional integer",
+			modify: func(cfg map[string]interface{}) { cfg["steps"] = 2.5 },
+			want:   []FieldError{{Field: "steps", Message: "must be an integer"}},
+		},
+		{
+			name:   "fail: out of range",
+			modify: func(cfg map[string]interface{}) { cfg["steps"] = 51.0 },
+			want:   []FieldError{{Field: "steps", Message: "must be between 1 and 50"}},
+		},
+		{
+			name:   "fail: below minimum",
+			modify: func(cfg map[string]interface{}) { cfg["guidance"] = 0.5 },
+			want:   []FieldError{{Field: "guidance", Message: "must be at least 1"}},
+		},
+		{
+			name:   "fail: not an option",
+			modify: func(cfg map[string]interface{}) { cfg["format"] = "gif" },
+			want:   []FieldError{{Field: "format", Message: "must be one of: png, jpg"}},
+		},
+		{
+			name: "fail: every problem listed, unknown fields last",
+			modify: func(cfg map[string]interface{}) {
+				cfg["zeta"] = 1
+				cfg["alpha"] = 1
+				cfg["steps"] = "many"
+				cfg["format"] = nil
+			},
+			want: []FieldError{
+				{Field: "steps", Message: "must be an integer"},
+				{Field: "format", Message: "is required"},
+				{Field: "alpha", Message: "unknown field"},
+				{Field: "zeta", Message: "unknown field"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+
+			err := schema.Validate(cfg)
+
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tt.want, verr.Fields)
+		})
+	}
+}
+
+func TestSchema_ValidateOverrides(t *testing.T) {
+	schema := &Schema{
+		ModelID: "test/model",
+		Fields: []Field{
+			{Name: "steps", Type: "int", Min: ptr(1.0), Max: ptr(50.0), Required: true},
+			{Name: "format", Type: "string", Options: []string{"png", "jpg"}, Required: true},
+			{Name: "api_key", Type: "string", Required: true, Secret: true},
+			{Name: "user_id", Type: "string"},
+		},
+	}
+
+	t.Run("success: required fields may be left out", func(t *testing.T) {
+		assert.NoError(t, schema.ValidateOverrides(map[string]interface{}{"steps": 40.0}))
+		assert.NoError(t, schema.ValidateOverrides(map[string]interface{}{}))
+		assert.NoError(t, schema.ValidateOverrides(map[string]interface{}{"user_id": nil}))
+	})
+
+	t.Run("fail: every problem listed by field name", func(t *testing.T) {
+		err := schema.ValidateOverrides(map[string]interface{}{
+			"zeta": 1, "steps": 60.0, "format": nil, "api_key": "sk-test",
+		})
+
+		var verr *ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, []FieldError{
+			{Field: "api_key", Message: "cannot be overridden"},
+			{Field: "format", Message: "is required"},
+			{Field: "steps", Message: "must be between 1 and 50"},
+			{Field: "zeta", Message: "unknown field"},
+		}, verr.Fields)
+	})
+}
+
+func TestSchema_Validate_defaults(t *testing.T) {
+	for _, id := range []string{
+		"qwen/qwen-image-edit",
+		"black-forest-labs/flux-kontext-max",
+		"bytedance/seedream-3",
+		"openai/gpt-image-1",
+		"stability-ai/stable-image-inpaint",
+	} {
+		t.Run(id, func(t *testing.T) {
+			schema, err := Get(id)
+			require.NoError(t, err)
+
+			cfg := map[string]interface{}{}
+			for _, f := range schema.Fields {
+				cfg[f.Name] = f.Default
+				if f.Secret {
+					cfg[f.Name] = "sk-test"
+				}
+			}
+
+			assert.NoError(t, schema.Validate(cfg))
+		})
+	}
+}
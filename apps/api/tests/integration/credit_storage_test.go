@@ -0,0 +1,126 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/credit"
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/storage/queries"
+)
+
+func TestCreditLedger(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const userID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	imgRepo := image.NewDefaultRepository(db)
+	newImage := func() string {
+		img, err := imgRepo.CreateImage(ctx, "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			"http://example.com/credit.jpg", nil, nil, nil, nil, "stage")
+		require.NoError(t, err)
+		return img.ID.String()
+	}
+	repo := credit.NewDefaultRepository(db)
+
+	balance, err := repo.Balance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), balance)
+
+	debited, err := repo.Debit(ctx, userID, newImage())
+	require.NoError(t, err)
+	assert.False(t, debited, "no credits to spend")
+
+	added, err := repo.AddPurchase(ctx, userID, 1, "cs_test_1")
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = repo.AddPurchase(ctx, userID, 1, "cs_test_1")
+	require.NoError(t, err)
+	assert.False(t, added, "a session is credited once")
+
+	first := newImage()
+	debited, err = repo.Debit(ctx, userID, first)
+	require.NoError(t, err)
+	assert.True(t, debited)
+	debited, err = repo.Debit(ctx, userID, newImage())
+	require.NoError(t, err)
+	assert.False(t, debited, "the only credit is spent")
+
+	refunded, err := repo.Refund(ctx, first)
+	require.NoError(t, err)
+	assert.True(t, refunded)
+	refunded, err = repo.Refund(ctx, first)
+	require.NoError(t, err)
+	assert.False(t, refunded, "an image is refunded once")
+
+	balance, err = repo.Balance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), balance)
+
+	entries, err := repo.ListEntries(ctx, userID, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, credit.ReasonRefund, entries[0].Reason)
+	assert.Equal(t, credit.ReasonDebit, entries[1].Reason)
+	assert.Equal(t, int32(-1), entries[1].Delta)
+	assert.Equal(t, credit.ReasonPurchase, entries[2].Reason)
+	require.NotNil(t, entries[2].CheckoutSessionID)
+	assert.Equal(t, "cs_test_1", *entries[2].CheckoutSessionID)
+}
+
+func TestImageRepository_WithCredit(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const (
+		userID    = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+		projectID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+		url       = "http://example.com/race.jpg"
+		creates   = 8
+	)
+	credits := credit.NewDefaultRepository(db)
+	_, err := credits.AddPurchase(ctx, userID, 1, "cs_test_race")
+	require.NoError(t, err)
+
+	imgRepo := image.NewDefaultRepository(db)
+	var wg sync.WaitGroup
+	errs := make([]error, creates)
+	for i := range creates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = imgRepo.WithCredit(ctx, userID, func(repo image.Repository) (*queries.Image, error) {
+				return repo.CreateImage(ctx, projectID, url, nil, nil, nil, nil, "stage")
+			})
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, image.ErrNoCredits)
+	}
+	assert.Equal(t, 1, succeeded, "one credit pays for one image")
+
+	balance, err := credits.Balance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), balance)
+
+	var count int
+	require.NoError(t, db.Pool().QueryRow(ctx, `SELECT count(*) FROM images WHERE original_url = $1`, url).Scan(&count))
+	assert.Equal(t, 1, count, "images without a credit are rolled back")
+}
@@ -0,0 +1,66 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/dunning"
+)
+
+func TestDunning_GracePeriod(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const userID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	var subscriptionID string
+	require.NoError(t, db.Pool().QueryRow(ctx, `
+		INSERT INTO subscriptions (user_id, stripe_subscription_id, status, price_id)
+		VALUES ($1, 'sub_dunning_1', 'past_due', 'price_pro')
+		RETURNING id::text`, userID).Scan(&subscriptionID))
+	repo := dunning.NewDefaultRepository(db)
+
+	grace, err := repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, grace, "no grace period before the status is tracked")
+
+	require.NoError(t, repo.Track(ctx, subscriptionID, userID, "past_due"))
+	first, err := repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	assert.Equal(t, "sub_dunning_1", first.StripeSubscriptionID)
+	require.NotNil(t, first.PriceID)
+	assert.Equal(t, "price_pro", *first.PriceID)
+
+	require.NoError(t, repo.Track(ctx, subscriptionID, userID, "past_due"))
+	again, err := repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, again)
+	assert.True(t, first.PastDueSince.Equal(again.PastDueSince), "a repeated past_due keeps the original start")
+
+	expired, err := repo.MarkExpired(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired, "a grace period that just started is not expired")
+
+	expired, err = repo.MarkExpired(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, userID, expired[0].UserID)
+	assert.NotNil(t, expired[0].ExpiredAt)
+
+	expired, err = repo.MarkExpired(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, expired, "a grace period expires once")
+
+	require.NoError(t, repo.Track(ctx, subscriptionID, userID, "active"))
+	grace, err = repo.GetByUserID(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, grace, "a paid subscription ends its grace period")
+}
@@ -95,7 +95,7 @@ func newAPITestServer(t *testing.T, db *storage.DefaultDatabase) (*httptest.Serv
 
 	imgRepo := image.NewDefaultRepository(db)
 	jobRepo := job.NewDefaultRepository(db)
-	imgSvc := image.NewDefaultService(cfg, imgRepo, jobRepo, nil)
+	imgSvc := image.NewDefaultService(cfg, imgRepo, jobRepo, nil, nil, nil)
 
 	srv := httpLib.NewTestServer(&config.Config{S3: config.S3{SecretKey: "sk_test_fake"}}, logging.Default(), db, s3, imgSvc)
 	return httptest.NewServer(srv), s3
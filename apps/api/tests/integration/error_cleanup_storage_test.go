@@ -0,0 +1,112 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/errorcleanup"
+	"github.com/real-staging-ai/api/internal/image"
+)
+
+func TestErrorCleanup_NotifyAndTrash(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const (
+		projectID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+		ownerID   = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	)
+	_, err := db.Pool().Exec(ctx, `UPDATE users SET email = 'owner@example.com' WHERE id = $1`, ownerID)
+	require.NoError(t, err)
+
+	images := image.NewDefaultRepository(db)
+	newErrored := func(url string, age time.Duration) string {
+		img, err := images.CreateImage(ctx, projectID, url, nil, nil, nil, nil, "stage")
+		require.NoError(t, err)
+		_, err = db.Pool().Exec(ctx,
+			`UPDATE images SET status = 'error', error = 'model timeout', updated_at = $2 WHERE id = $1`,
+			img.ID, time.Now().Add(-age))
+		require.NoError(t, err)
+		return img.ID.String()
+	}
+	old := newErrored("http://example.com/old.jpg", 40*24*time.Hour)
+	newErrored("http://example.com/recent.jpg", 2*24*time.Hour)
+	reviewed := newErrored("http://example.com/reviewed.jpg", 40*24*time.Hour)
+	_, err = db.Pool().Exec(ctx,
+		`INSERT INTO prompt_reviews (project_id, image_id, prompt, decision) VALUES ($1, $2, 'p', 'flagged')`,
+		projectID, reviewed)
+	require.NoError(t, err)
+	ticketed := newErrored("http://example.com/ticketed.jpg", 40*24*time.Hour)
+	_, err = db.Pool().Exec(ctx,
+		`INSERT INTO support_tickets (user_id, image_id, message, snapshot) VALUES ($1, $2, 'still failing', '{}')`,
+		ownerID, ticketed)
+	require.NoError(t, err)
+	rated := newErrored("http://example.com/rated.jpg", 40*24*time.Hour)
+	_, err = db.Pool().Exec(ctx,
+		`INSERT INTO image_feedback (image_id, user_id, rating) VALUES ($1, $2, 1)`, rated, ownerID)
+	require.NoError(t, err)
+
+	repo := errorcleanup.NewDefaultRepository(db)
+	now := time.Now()
+
+	marked, err := repo.MarkNotified(ctx, now.Add(-23*24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, marked, 1, "recent, reviewed, ticketed and rated images are left alone")
+	assert.Equal(t, old, marked[0].ID)
+	assert.Equal(t, "Test Project 1", marked[0].ProjectName)
+	assert.Equal(t, "owner@example.com", marked[0].OwnerEmail)
+	assert.Equal(t, "model timeout", marked[0].Error)
+
+	marked, err = repo.MarkNotified(ctx, now.Add(-23*24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, marked, "owners are told once")
+
+	trashed, err := repo.TrashNotified(ctx, now.Add(-7*24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Zero(t, trashed, "the notice period hasn't passed")
+
+	_, err = db.Pool().Exec(ctx,
+		`UPDATE images SET cleanup_notified_at = now() - interval '8 days' WHERE id = $1`, old)
+	require.NoError(t, err)
+	trashed, err = repo.TrashNotified(ctx, now.Add(-7*24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, trashed)
+
+	// A ticket opened after the notice keeps the image too.
+	_, err = db.Pool().Exec(ctx, `DELETE FROM support_tickets WHERE image_id = $1`, ticketed)
+	require.NoError(t, err)
+	marked, err = repo.MarkNotified(ctx, now.Add(-23*24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, marked, 1)
+	assert.Equal(t, ticketed, marked[0].ID)
+	_, err = db.Pool().Exec(ctx,
+		`INSERT INTO support_tickets (user_id, image_id, message, snapshot) VALUES ($1, $2, 'please keep', '{}')`,
+		ownerID, ticketed)
+	require.NoError(t, err)
+	_, err = db.Pool().Exec(ctx,
+		`UPDATE images SET cleanup_notified_at = now() - interval '8 days' WHERE id = $1`, ticketed)
+	require.NoError(t, err)
+	trashed, err = repo.TrashNotified(ctx, now.Add(-7*24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Zero(t, trashed)
+
+	trash, err := images.ListDeletedImagesByProjectID(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, old, trash[0].ID.String())
+
+	// Restoring starts the clock over.
+	_, err = images.RestoreImage(ctx, old)
+	require.NoError(t, err)
+	marked, err = repo.MarkNotified(ctx, now.Add(-23*24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, marked)
+}
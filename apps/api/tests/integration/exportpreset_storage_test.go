@@ -0,0 +1,78 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/exportpreset"
+	"github.com/real-staging-ai/api/internal/org"
+)
+
+func TestExportPreset_Storage(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const userID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	orgs := org.NewDefaultRepository(db)
+	brokerage, err := orgs.Create(ctx, "Acme Realty", userID)
+	require.NoError(t, err)
+	other, err := orgs.Create(ctx, "Other Realty", userID)
+	require.NoError(t, err)
+
+	repo := exportpreset.NewDefaultRepository(db)
+	width := 1024
+	text := "Acme Realty"
+	mls, err := repo.Create(ctx, &exportpreset.Preset{
+		OrgID: brokerage.ID, Name: "mls", Width: &width, Format: exportpreset.FormatJPEG, Quality: 85,
+		WatermarkText: &text, WatermarkPosition: "bottom_right", MLSBanner: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &width, mls.Width)
+	assert.Nil(t, mls.Height)
+	_, err = repo.Create(ctx, &exportpreset.Preset{
+		OrgID: brokerage.ID, Name: "archive", Format: exportpreset.FormatPNG, Quality: 90,
+		WatermarkPosition: "bottom_right",
+	})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, &exportpreset.Preset{
+		OrgID: brokerage.ID, Name: "mls", Format: exportpreset.FormatJPEG, Quality: 90,
+		WatermarkPosition: "bottom_right",
+	})
+	assert.ErrorIs(t, err, exportpreset.ErrDuplicateName)
+	_, err = repo.Create(ctx, &exportpreset.Preset{
+		OrgID: other.ID, Name: "mls", Format: exportpreset.FormatJPEG, Quality: 90,
+		WatermarkPosition: "bottom_right",
+	})
+	require.NoError(t, err, "names are unique per organization")
+
+	list, err := repo.List(ctx, brokerage.ID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "archive", list[0].Name, "presets are ordered by name")
+
+	byName, err := repo.GetByName(ctx, brokerage.ID, "mls")
+	require.NoError(t, err)
+	assert.Equal(t, mls.ID, byName.ID)
+	_, err = repo.GetByID(ctx, other.ID, mls.ID)
+	assert.ErrorIs(t, err, exportpreset.ErrPresetNotFound, "presets belong to their organization")
+
+	mls.Width = nil
+	mls.WatermarkText = nil
+	updated, err := repo.Update(ctx, mls)
+	require.NoError(t, err)
+	assert.Nil(t, updated.Width)
+	assert.Nil(t, updated.WatermarkText)
+
+	assert.ErrorIs(t, repo.Delete(ctx, other.ID, mls.ID), exportpreset.ErrPresetNotFound)
+	require.NoError(t, repo.Delete(ctx, brokerage.ID, mls.ID))
+	_, err = repo.GetByID(ctx, brokerage.ID, mls.ID)
+	assert.ErrorIs(t, err, exportpreset.ErrPresetNotFound)
+}
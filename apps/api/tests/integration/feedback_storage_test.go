@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/feedback"
+	"github.com/real-staging-ai/api/internal/image"
+)
+
+func TestFeedback_UpsertAndQuality(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const (
+		userID    = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+		projectID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+	)
+	images := image.NewDefaultRepository(db)
+	staged := func(model string, prompt *string) string {
+		img, err := images.CreateImage(ctx, projectID, "http://example.com/bedroom.jpg", nil, nil, nil, nil, "stage")
+		require.NoError(t, err)
+		_, err = db.Pool().Exec(ctx,
+			`UPDATE images SET status = 'ready', model_used = $2, room_type = 'bedroom', prompt = $3 WHERE id = $1`,
+			img.ID.String(), model, prompt)
+		require.NoError(t, err)
+		return img.ID.String()
+	}
+	custom := "A cozy bedroom with a canopy bed."
+	first := staged("qwen/qwen-image-edit", nil)
+	second := staged("black-forest-labs/flux-kontext-max", nil)
+	third := staged("qwen/qwen-image-edit", &custom)
+
+	repo := feedback.NewDefaultRepository(db)
+	status, err := repo.GetImageStatus(ctx, userID, first)
+	require.NoError(t, err)
+	assert.Equal(t, "ready", status)
+	_, err = repo.GetImageStatus(ctx, "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a99", first)
+	assert.ErrorIs(t, err, feedback.ErrImageNotFound)
+
+	comment := "Too dark"
+	created, err := repo.Upsert(ctx, userID, first, feedback.SubmitRequest{Rating: 2, Comment: &comment})
+	require.NoError(t, err)
+	require.NotNil(t, created.ModelUsed)
+	assert.Equal(t, "qwen/qwen-image-edit", *created.ModelUsed)
+
+	// Rating again replaces the earlier rating.
+	updated, err := repo.Upsert(ctx, userID, first, feedback.SubmitRequest{Rating: 4})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, updated.ID)
+	assert.Equal(t, 4, updated.Rating)
+	assert.Nil(t, updated.Comment)
+
+	_, err = repo.Upsert(ctx, userID, second, feedback.SubmitRequest{Rating: 5})
+	require.NoError(t, err)
+	_, err = repo.Upsert(ctx, userID, third, feedback.SubmitRequest{Rating: 1})
+	require.NoError(t, err)
+
+	q := feedback.Query{
+		Interval: feedback.IntervalMonth,
+		From:     time.Now().Add(-time.Hour),
+		To:       time.Now().Add(time.Hour),
+	}
+	models, err := repo.ModelQuality(ctx, q)
+	require.NoError(t, err)
+	require.Len(t, models, 2)
+	assert.Equal(t, "black-forest-labs/flux-kontext-max", models[0].Model)
+	assert.Equal(t, "qwen/qwen-image-edit", models[1].Model)
+	assert.Equal(t, 2, models[1].Ratings)
+	assert.InDelta(t, 2.5, models[1].AverageRating, 0.001)
+	assert.Equal(t, [5]int{1, 0, 0, 1, 0}, models[1].Distribution)
+
+	// The custom prompt's rating is left out of the prompt comparison.
+	prompts, err := repo.PromptQuality(ctx, q)
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+	assert.Equal(t, "bedroom", prompts[0].RoomType)
+	assert.Equal(t, "modern", prompts[0].Style)
+	assert.Nil(t, prompts[0].VariantID)
+	assert.Equal(t, 2, prompts[0].Ratings)
+}
@@ -0,0 +1,65 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/idempotency"
+)
+
+func TestIdempotency_Keys(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const owner = "auth0|testuser"
+	repo := idempotency.NewDefaultRepository(db)
+
+	rec, err := repo.Claim(ctx, owner, "key-1", "hash-1")
+	require.NoError(t, err)
+	assert.Nil(t, rec, "the first request claims the key")
+
+	rec, err = repo.Claim(ctx, owner, "key-1", "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, "hash-1", rec.RequestHash)
+	assert.Nil(t, rec.Response, "the first request is still running")
+
+	other, err := repo.Claim(ctx, "auth0|other", "key-1", "hash-1")
+	require.NoError(t, err)
+	assert.Nil(t, other, "keys are scoped to their owner")
+
+	resp := idempotency.Response{Status: 201, ContentType: "application/json", Body: []byte(`{"id":"img-1"}`)}
+	require.NoError(t, repo.Complete(ctx, owner, "key-1", resp))
+	rec, err = repo.Claim(ctx, owner, "key-1", "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	require.NotNil(t, rec.Response)
+	assert.Equal(t, resp, *rec.Response)
+
+	require.NoError(t, repo.Release(ctx, owner, "key-1"))
+	rec, err = repo.Claim(ctx, owner, "key-1", "hash-2")
+	require.NoError(t, err)
+	assert.Nil(t, rec, "a released key is claimed again")
+
+	_, err = db.Pool().Exec(ctx, `UPDATE idempotency_keys SET locked_at = now() - interval '2 minutes'
+		WHERE owner = $1 AND key = 'key-1'`, owner)
+	require.NoError(t, err)
+	rec, err = repo.Claim(ctx, owner, "key-1", "hash-2")
+	require.NoError(t, err)
+	assert.Nil(t, rec, "a key held by a request that never finished is claimed again")
+
+	n, err := repo.DeleteExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	n, err = repo.DeleteExpired(ctx, time.Now().Add(idempotency.TTL+time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
@@ -0,0 +1,75 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/pagination"
+)
+
+func TestImageSearch_SearchImages(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const (
+		userID    = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+		projectID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+	)
+	repo := image.NewDefaultRepository(db)
+	create := func(prompt, roomType, style, model string, seed int64) string {
+		img, err := repo.CreateImage(ctx, projectID, "http://example.com/"+roomType+".jpg", nil, nil, nil, nil, "stage")
+		require.NoError(t, err)
+		_, err = db.Pool().Exec(ctx,
+			`UPDATE images SET prompt = $2, room_type = $3, style = $4, model_used = $5, seed = $6 WHERE id = $1`,
+			img.ID.String(), prompt, roomType, style, model, seed)
+		require.NoError(t, err)
+		return img.ID.String()
+	}
+	bedroom := create("cozy staging with wool throws", "bedroom", "scandinavian", "black-forest-labs/flux-kontext-max", 42)
+	living := create("bright and airy", "living_room", "scandinavian", "qwen/qwen-image-edit", 7)
+	trashed := create("scandinavian bedroom", "bedroom", "scandinavian", "qwen/qwen-image-edit", 42)
+	require.NoError(t, repo.DeleteImage(ctx, trashed))
+
+	ids := func(query string) []string {
+		images, _, err := repo.SearchImages(ctx, userID, image.ParseSearchQuery(query), pagination.First())
+		require.NoError(t, err)
+		out := []string{}
+		for _, img := range images {
+			out = append(out, img.ID.String())
+		}
+		return out
+	}
+
+	assert.ElementsMatch(t, []string{bedroom, living}, ids("scandinavian"))
+	assert.Equal(t, []string{bedroom}, ids("scandinavian bedroom with seed 42"))
+	assert.Equal(t, []string{living}, ids("living room"), "underscores in room types match words")
+	assert.Equal(t, []string{bedroom}, ids("flux"), "model names match by their parts")
+	assert.Equal(t, []string{living}, ids("seed:7"))
+	assert.Empty(t, ids("kitchen"))
+
+	images, _, err := repo.SearchImages(ctx, "c0eebc99-9c0b-4ef8-bb6d-6bb9bd380a13",
+		image.ParseSearchQuery("scandinavian"), pagination.First())
+	require.NoError(t, err)
+	assert.Empty(t, images, "other users' images are not searched")
+
+	page := pagination.Page{Limit: 1}
+	first, next, err := repo.SearchImages(ctx, userID, image.ParseSearchQuery("scandinavian"), page)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	require.NotNil(t, next)
+	page.After, err = pagination.Decode(*next)
+	require.NoError(t, err)
+	second, next, err := repo.SearchImages(ctx, userID, image.ParseSearchQuery("scandinavian"), page)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Nil(t, next)
+	assert.NotEqual(t, first[0].ID, second[0].ID)
+}
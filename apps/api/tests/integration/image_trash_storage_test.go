@@ -0,0 +1,61 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+)
+
+func TestImageTrash_RestoreAndPurge(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const projectID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+	repo := image.NewDefaultRepository(db)
+	img, err := repo.CreateImage(ctx, projectID, "http://example.com/trash.jpg", nil, nil, nil, nil, "stage")
+	require.NoError(t, err)
+	imageID := img.ID.String()
+
+	require.NoError(t, repo.DeleteImage(ctx, imageID))
+	trash, err := repo.ListDeletedImagesByProjectID(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.True(t, trash[0].DeletedAt.Valid)
+
+	restored, err := repo.RestoreImage(ctx, imageID)
+	require.NoError(t, err)
+	assert.False(t, restored.DeletedAt.Valid)
+	_, err = repo.RestoreImage(ctx, imageID)
+	assert.Error(t, err, "a live image can't be restored")
+
+	// Trash it again and age it past both cut-offs.
+	require.NoError(t, repo.DeleteImage(ctx, imageID))
+	_, err = db.Pool().Exec(ctx,
+		`UPDATE images SET deleted_at = now() - interval '40 days', created_at = now() - interval '60 days'
+		 WHERE id = $1`, imageID)
+	require.NoError(t, err)
+
+	now := time.Now()
+	purged, err := repo.PurgeDeletedImages(ctx, now.Add(-50*24*time.Hour), now.Add(-31*24*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, purged, "deleted too recently for the retention period")
+
+	purged, err = repo.PurgeDeletedImages(ctx, now.Add(-30*24*time.Hour), now.Add(-31*24*time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	assert.Equal(t, imageID, purged[0].ID.String())
+	assert.Equal(t, []string{"http://example.com/trash.jpg"}, purged[0].Files)
+
+	trash, err = repo.ListDeletedImagesByProjectID(ctx, projectID)
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+}
@@ -0,0 +1,51 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/imageevent"
+)
+
+func TestImageEvents_History(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	imgRepo := image.NewDefaultRepository(db)
+	prompt := "bright coastal living room"
+	img, err := imgRepo.CreateImage(ctx, "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+		"http://example.com/history.jpg", nil, nil, nil, &prompt, "stage")
+	require.NoError(t, err)
+	imageID := img.ID.String()
+
+	repo := imageevent.NewDefaultRepository(db)
+	require.NoError(t, repo.AppendSnapshot(ctx, imageID, imageevent.SourceAPI))
+
+	_, err = imgRepo.UpdateImageWithError(ctx, imageID, "model timed out")
+	require.NoError(t, err)
+	require.NoError(t, repo.AppendSnapshot(ctx, imageID, imageevent.SourceReconcile))
+
+	events, err := repo.ListByImage(ctx, imageID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "queued", events[0].Status)
+	assert.Equal(t, imageevent.SourceAPI, events[0].Source)
+	require.NotNil(t, events[0].Prompt)
+	assert.Equal(t, prompt, *events[0].Prompt)
+	assert.Equal(t, "error", events[1].Status)
+	require.NotNil(t, events[1].Error)
+	assert.Equal(t, "model timed out", *events[1].Error)
+
+	err = repo.AppendSnapshot(ctx, "00000000-0000-0000-0000-000000000000", imageevent.SourceAPI)
+	assert.ErrorIs(t, err, imageevent.ErrImageNotFound)
+	_, err = repo.ListByImage(ctx, "00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, imageevent.ErrImageNotFound)
+}
@@ -0,0 +1,69 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/modelversion"
+)
+
+func TestModelVersionPins_UpgradeFlow(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const modelID = "qwen/qwen-image-edit"
+	v1 := strings.Repeat("a", 64)
+	v2 := strings.Repeat("b", 64)
+	repo := modelversion.NewDefaultRepository(db)
+
+	_, err := repo.Get(ctx, modelID)
+	assert.ErrorIs(t, err, modelversion.ErrPinNotFound)
+	_, err = repo.Rollback(ctx, modelID, "auth0|admin")
+	assert.ErrorIs(t, err, modelversion.ErrNothingToRollBack)
+
+	pin, err := repo.Pin(ctx, modelID, v1, "auth0|admin")
+	require.NoError(t, err)
+	assert.Equal(t, v1, pin.Version)
+	assert.Nil(t, pin.PreviousVersion)
+
+	pin, err = repo.SetCanary(ctx, modelID, v2, 10, "auth0|admin")
+	require.NoError(t, err)
+	require.NotNil(t, pin.CanaryStartedAt)
+	started := *pin.CanaryStartedAt
+
+	pin, err = repo.SetCanary(ctx, modelID, v2, 50, "auth0|admin")
+	require.NoError(t, err)
+	assert.Equal(t, 50, pin.CanaryPercent)
+	assert.Equal(t, started, *pin.CanaryStartedAt)
+
+	pin, err = repo.Promote(ctx, modelID, "auth0|admin")
+	require.NoError(t, err)
+	assert.Equal(t, v2, pin.Version)
+	require.NotNil(t, pin.PreviousVersion)
+	assert.Equal(t, v1, *pin.PreviousVersion)
+	assert.Nil(t, pin.CanaryVersion)
+
+	_, err = repo.Promote(ctx, modelID, "auth0|admin")
+	assert.ErrorIs(t, err, modelversion.ErrNoCanary)
+
+	pin, err = repo.Rollback(ctx, modelID, "auth0|admin")
+	require.NoError(t, err)
+	assert.Equal(t, v1, pin.Version)
+	assert.Nil(t, pin.PreviousVersion)
+
+	pins, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pins, 1)
+
+	outcomes, err := repo.Outcomes(ctx, modelID, []string{v1, v2}, started)
+	require.NoError(t, err)
+	assert.Empty(t, outcomes)
+}
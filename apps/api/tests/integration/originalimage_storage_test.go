@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/originalimage"
+	"github.com/real-staging-ai/api/internal/storage"
+)
+
+func TestOriginalImage_ConcurrentReferences(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	repo := originalimage.NewDefaultRepository(db)
+	var s3Deletes atomic.Int32
+	s3 := &storage.S3ServiceMock{
+		DeleteFileFunc: func(ctx context.Context, fileKey string) error {
+			s3Deletes.Add(1)
+			return nil
+		},
+	}
+	svc := originalimage.NewDefaultService(repo, s3)
+
+	newOriginal := func(t *testing.T) string {
+		t.Helper()
+		hash := fmt.Sprintf("refcount-%d", time.Now().UnixNano())
+		original, err := repo.CreateOriginalImage(ctx, hash, "originals/"+hash+".jpg", 1024, "image/jpeg", nil, nil)
+		require.NoError(t, err)
+		id := original.ID.String()
+		t.Cleanup(func() { _ = repo.DeleteOriginalImage(ctx, id) })
+		return id
+	}
+
+	t.Run("success: concurrent creates and deletes keep an exact count", func(t *testing.T) {
+		s3Deletes.Store(0)
+		id := newOriginal(t)
+		for i := 0; i < 9; i++ {
+			require.NoError(t, repo.IncrementReferenceCount(ctx, id))
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, repo.IncrementReferenceCount(ctx, id))
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := svc.DecrementReferenceAndCleanup(ctx, id)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		original, err := repo.GetOriginalImageByID(ctx, id)
+		require.NoError(t, err)
+		assert.EqualValues(t, 10, original.ReferenceCount)
+		assert.Zero(t, s3Deletes.Load())
+	})
+
+	t.Run("success: the last delete racing a create never loses a referenced original", func(t *testing.T) {
+		for run := 0; run < 20; run++ {
+			s3Deletes.Store(0)
+			id := newOriginal(t)
+
+			var wg sync.WaitGroup
+			var createErr error
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_, err := svc.DecrementReferenceAndCleanup(ctx, id)
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				createErr = repo.IncrementReferenceCount(ctx, id)
+			}()
+			wg.Wait()
+
+			original, err := repo.GetOriginalImageByID(ctx, id)
+			if createErr == nil {
+				require.NoError(t, err, "run %d: referenced original was deleted", run)
+				assert.EqualValues(t, 1, original.ReferenceCount)
+				assert.Zero(t, s3Deletes.Load())
+			} else {
+				assert.ErrorIs(t, createErr, originalimage.ErrOriginalImageNotFound)
+				assert.Error(t, err)
+				assert.EqualValues(t, 1, s3Deletes.Load())
+			}
+		}
+	})
+}
@@ -0,0 +1,51 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/overage"
+)
+
+func TestOverageUsage(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const userID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	img, err := image.NewDefaultRepository(db).CreateImage(ctx, "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+		"http://example.com/overage.jpg", nil, nil, nil, nil, "stage")
+	require.NoError(t, err)
+	imageID := img.ID.String()
+	repo := overage.NewDefaultRepository(db)
+
+	usage, err := repo.Cancel(ctx, imageID)
+	require.NoError(t, err)
+	assert.Nil(t, usage, "nothing to cancel before the image is recorded")
+
+	recorded, err := repo.Record(ctx, userID, imageID, "cus_test_1")
+	require.NoError(t, err)
+	assert.True(t, recorded)
+	recorded, err = repo.Record(ctx, userID, imageID, "cus_test_1")
+	require.NoError(t, err)
+	assert.False(t, recorded, "an image is recorded once")
+
+	usage, err = repo.Cancel(ctx, imageID)
+	require.NoError(t, err)
+	require.NotNil(t, usage)
+	assert.Equal(t, imageID, usage.ImageID)
+	assert.Equal(t, userID, usage.UserID)
+	assert.Equal(t, "cus_test_1", usage.StripeCustomerID)
+	assert.NotNil(t, usage.CancelledAt)
+
+	usage, err = repo.Cancel(ctx, imageID)
+	require.NoError(t, err)
+	assert.Nil(t, usage, "an image is cancelled once")
+}
@@ -0,0 +1,75 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/real-staging-ai/api/internal/config"
+	httpLib "github.com/real-staging-ai/api/internal/http"
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/logging"
+	"github.com/real-staging-ai/api/internal/preference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPreferences_Integration(t *testing.T) {
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	TruncateAllTables(ctx, db.Pool())
+	SeedDatabase(ctx, db.Pool())
+
+	s3ServiceMock := SetupTestS3Service(t, ctx)
+	cfg := &config.Config{
+		S3:         config.S3{SecretKey: "sk_test_fake"},
+		Encryption: config.Encryption{Key: "rPVL6YdZl8Rtvpiypu8VxN9EbCaJh0o312Hth8LL5Ag="},
+	}
+	server := httpLib.NewTestServer(cfg, logging.Default(), db, s3ServiceMock, &image.ServiceMock{})
+
+	do := func(method, body string) (int, preference.Response) {
+		req := httptest.NewRequest(method, "/api/v1/user/preferences", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, req)
+
+		var resp preference.Response
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec.Code, resp
+	}
+
+	code, resp := do(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, resp.Preferences)
+	assert.Nil(t, resp.UpdatedAt)
+
+	code, _ = do(http.MethodPut, `{"preferences":{
+		"gallery":{"layout":"list","page_size":50},
+		"notifications":{"email_on_ready":true}
+	}}`)
+	require.Equal(t, http.StatusOK, code)
+
+	code, resp = do(http.MethodPut, `{"preferences":{"gallery":{"layout":"grid"},"notifications":null}}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"layout":"grid"}`, string(resp.Preferences["gallery"]))
+	assert.NotContains(t, resp.Preferences, "notifications")
+	assert.NotNil(t, resp.UpdatedAt)
+
+	var plaintext int
+	err := db.Pool().QueryRow(ctx,
+		`SELECT count(*) FROM user_preferences WHERE position('grid'::bytea IN ciphertext) > 0`).Scan(&plaintext)
+	require.NoError(t, err)
+	assert.Zero(t, plaintext, "values are stored encrypted")
+
+	code, _ = do(http.MethodPut, `{"preferences":{"gallery":{"layout":"carousel"}}}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
@@ -552,6 +552,27 @@ func TestDeleteProject_Handlers(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "bad_request",
 		},
+		{
+			name:      "fail: project locked",
+			projectID: "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			setupData: func(t *testing.T, db storage.Database) {
+				TruncateAllTables(ctx, db.Pool())
+				SeedDatabase(ctx, db.Pool())
+				_, err := db.Pool().Exec(ctx, "UPDATE projects SET locked = true WHERE id = $1",
+					"b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12")
+				require.NoError(t, err)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "project_locked",
+			validate: func(t *testing.T, db storage.Database) {
+				var count int
+				err := db.Pool().QueryRow(context.Background(),
+					"SELECT COUNT(*) FROM projects WHERE id = $1",
+					"b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12").Scan(&count)
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -7,6 +7,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/pagination"
 	"github.com/real-staging-ai/api/internal/project"
 	"github.com/real-staging-ai/api/internal/storage"
 	"github.com/stretchr/testify/assert"
@@ -261,14 +263,22 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 
 	storageInstance := project.NewDefaultStorageSQLc(db)
 	userID := "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	watermarkOn := true
+	model, noModel := "bytedance/seedream-4", ""
+	locale := "en-GB"
 
 	testCases := []struct {
-		name         string
-		projectID    string
-		userID       string
-		newName      string
-		expectError  bool
-		expectedName string
+		name            string
+		projectID       string
+		userID          string
+		newName         string
+		watermark       *bool
+		modelID         *string
+		locale          *string
+		expectError     bool
+		expectedName    string
+		expectedModelID *string
+		expectedLocale  *string
 	}{
 		{
 			name:         "success: update project by correct user",
@@ -278,6 +288,48 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 			expectError:  false,
 			expectedName: "Updated by User",
 		},
+		{
+			name:            "success: pin a model",
+			projectID:       "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			userID:          userID,
+			newName:         "Pinned",
+			modelID:         &model,
+			expectedName:    "Pinned",
+			expectedModelID: &model,
+		},
+		{
+			name:         "success: empty model clears it",
+			projectID:    "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			userID:       userID,
+			newName:      "Unpinned",
+			modelID:      &noModel,
+			expectedName: "Unpinned",
+		},
+		{
+			name:           "success: set the locale",
+			projectID:      "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			userID:         userID,
+			newName:        "Localized",
+			locale:         &locale,
+			expectedName:   "Localized",
+			expectedLocale: &locale,
+		},
+		{
+			name:         "success: empty locale clears it",
+			projectID:    "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			userID:       userID,
+			newName:      "US phrasing",
+			locale:       &noModel,
+			expectedName: "US phrasing",
+		},
+		{
+			name:         "success: turn the watermark on",
+			projectID:    "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
+			userID:       userID,
+			newName:      "Watermarked",
+			watermark:    &watermarkOn,
+			expectedName: "Watermarked",
+		},
 		{
 			name:        "fail: update project by wrong user",
 			projectID:   "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12",
@@ -303,7 +355,9 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			updatedProject, err := storageInstance.UpdateProjectByUserID(ctx, tc.projectID, tc.userID, tc.newName)
+			updatedProject, err := storageInstance.UpdateProjectByUserID(
+				ctx, tc.projectID, tc.userID, tc.newName, tc.watermark, tc.modelID, tc.locale,
+			)
 
 			if tc.expectError {
 				assert.Error(t, err)
@@ -314,6 +368,9 @@ func TestProjectStorageSQLc_UpdateProjectByUserID(t *testing.T) {
 			assert.Equal(t, tc.projectID, updatedProject.ID)
 			assert.Equal(t, tc.expectedName, updatedProject.Name)
 			assert.Equal(t, tc.userID, updatedProject.UserID)
+			assert.Equal(t, tc.watermark != nil, updatedProject.Watermark)
+			assert.Equal(t, tc.expectedModelID, updatedProject.ModelID)
+			assert.Equal(t, tc.expectedLocale, updatedProject.Locale)
 		})
 	}
 }
@@ -492,6 +549,123 @@ func TestProjectStorageSQLc_CountProjectsByUserID(t *testing.T) {
 	}
 }
 
+func TestProjectStorageSQLc_ListProjectsByUserID(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+
+	truncateTables(t, db.Pool())
+	seedTables(t, db.Pool())
+
+	storageInstance := project.NewDefaultStorageSQLc(db)
+	const userID = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+	const seeded = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+
+	kitchen, err := storageInstance.CreateProject(ctx, &project.Project{Name: "Kitchen 100%"}, userID)
+	require.NoError(t, err)
+	beach, err := storageInstance.CreateProject(ctx, &project.Project{Name: "Beach House"}, userID)
+	require.NoError(t, err)
+	age := func(projectID, interval string) {
+		_, err := db.Pool().Exec(ctx, `UPDATE projects SET created_at = now() - $2::interval WHERE id = $1`,
+			projectID, interval)
+		require.NoError(t, err)
+	}
+	age(seeded, "3 days")
+	age(kitchen.ID, "2 days")
+	age(beach.ID, "1 day")
+	// Renaming the seeded project last makes it the most recently updated.
+	_, err = storageInstance.UpdateProject(ctx, seeded, "Test Project 1")
+	require.NoError(t, err)
+
+	imgRepo := image.NewDefaultRepository(db)
+	for _, status := range []string{"ready", "ready", "error"} {
+		img, err := imgRepo.CreateImage(ctx, kitchen.ID, "http://example.com/k.jpg", nil, nil, nil, nil, "stage")
+		require.NoError(t, err)
+		_, err = db.Pool().Exec(ctx, `UPDATE images SET status = $2 WHERE id = $1`, img.ID.String(), status)
+		require.NoError(t, err)
+	}
+	trashed, err := imgRepo.CreateImage(ctx, beach.ID, "http://example.com/b.jpg", nil, nil, nil, nil, "stage")
+	require.NoError(t, err)
+	require.NoError(t, imgRepo.DeleteImage(ctx, trashed.ID.String()))
+
+	ids := func(projects []project.Project) []string {
+		out := make([]string, 0, len(projects))
+		for _, p := range projects {
+			out = append(out, p.ID)
+		}
+		return out
+	}
+
+	testCases := []struct {
+		name    string
+		filter  project.ListFilter
+		wantIDs []string
+	}{
+		{
+			name:    "success: newest first by default",
+			wantIDs: []string{beach.ID, kitchen.ID, seeded},
+		},
+		{
+			name:    "success: oldest first",
+			filter:  project.ListFilter{Ascending: true},
+			wantIDs: []string{seeded, kitchen.ID, beach.ID},
+		},
+		{
+			name:    "success: most recently updated first",
+			filter:  project.ListFilter{Sort: project.SortUpdatedAt},
+			wantIDs: []string{seeded, beach.ID, kitchen.ID},
+		},
+		{
+			name:    "success: name search ignores case and escapes wildcards",
+			filter:  project.ListFilter{Name: "kitchen 100%"},
+			wantIDs: []string{kitchen.ID},
+		},
+		{
+			name:    "success: a wildcard matches only itself",
+			filter:  project.ListFilter{Name: "%"},
+			wantIDs: []string{kitchen.ID},
+		},
+		{
+			name:    "success: status filter skips trashed images",
+			filter:  project.ListFilter{Statuses: []string{"error", "queued"}},
+			wantIDs: []string{kitchen.ID},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			projects, next, err := storageInstance.ListProjectsByUserID(ctx, userID, tc.filter, pagination.First())
+			require.NoError(t, err)
+			assert.Nil(t, next)
+			assert.Equal(t, tc.wantIDs, ids(projects))
+		})
+	}
+
+	t.Run("success: image counts", func(t *testing.T) {
+		projects, _, err := storageInstance.ListProjectsByUserID(ctx, userID, project.ListFilter{}, pagination.First())
+		require.NoError(t, err)
+		require.Len(t, projects, 3)
+		assert.Equal(t, project.ImageCounts{}, *projects[0].ImageCounts, "trashed images are not counted")
+		assert.Equal(t, project.ImageCounts{Ready: 2, Error: 1}, *projects[1].ImageCounts)
+	})
+
+	t.Run("success: pages follow the sort", func(t *testing.T) {
+		filter := project.ListFilter{Sort: project.SortUpdatedAt, Ascending: true}
+		page := pagination.Page{Limit: 2}
+		first, next, err := storageInstance.ListProjectsByUserID(ctx, userID, filter, page)
+		require.NoError(t, err)
+		assert.Equal(t, []string{kitchen.ID, beach.ID}, ids(first))
+		require.NotNil(t, next)
+
+		page.After, err = pagination.Decode(*next)
+		require.NoError(t, err)
+		rest, next, err := storageInstance.ListProjectsByUserID(ctx, userID, filter, page)
+		require.NoError(t, err)
+		assert.Equal(t, []string{seeded}, ids(rest))
+		assert.Nil(t, next)
+	})
+}
+
 func TestProjectStorageSQLc_Integration(t *testing.T) {
 	ctx := context.Background()
 	db := SetupTestDatabase(t)
@@ -523,7 +697,9 @@ func TestProjectStorageSQLc_Integration(t *testing.T) {
 	assert.Equal(t, int64(2), count)
 
 	// 4. Update the project
-	updatedProject, err := storageInstance.UpdateProjectByUserID(ctx, createdProject.ID, userID, "Updated Integration Project")
+	updatedProject, err := storageInstance.UpdateProjectByUserID(
+		ctx, createdProject.ID, userID, "Updated Integration Project", nil, nil, nil,
+	)
 	require.NoError(t, err)
 	assert.Equal(t, "Updated Integration Project", updatedProject.Name)
 
@@ -0,0 +1,84 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/image"
+	"github.com/real-staging-ai/api/internal/promptexperiment"
+)
+
+func TestPromptExperiment_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const projectID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+	repo := promptexperiment.NewDefaultRepository(db)
+	weight := 3
+	req := promptexperiment.CreateExperimentRequest{
+		Name: "Warmer bedrooms", RoomType: "bedroom", Style: "modern",
+		Variants: []promptexperiment.CreateVariantRequest{
+			{Name: "control", Prompt: "Stage a bedroom."},
+			{Name: "warm", Prompt: "Stage a warm bedroom.", Weight: &weight},
+		},
+	}
+
+	created, err := repo.Create(ctx, req, "auth0|admin")
+	require.NoError(t, err)
+	assert.Equal(t, promptexperiment.StatusRunning, created.Status)
+	require.Len(t, created.Variants, 2)
+	assert.Equal(t, "control", created.Variants[0].Name)
+	assert.Equal(t, 1, created.Variants[0].Weight)
+	assert.Equal(t, 3, created.Variants[1].Weight)
+
+	// One running experiment per room type and style.
+	_, err = repo.Create(ctx, req, "auth0|admin")
+	assert.ErrorIs(t, err, promptexperiment.ErrAlreadyRunning)
+
+	// Tag images the way the worker does and record owners' verdicts.
+	images := image.NewDefaultRepository(db)
+	tag := func(variantID, status string, approved *bool) {
+		img, err := images.CreateImage(ctx, projectID, "http://example.com/bedroom.jpg", nil, nil, nil, nil, "stage")
+		require.NoError(t, err)
+		_, err = db.Pool().Exec(ctx,
+			`UPDATE images SET prompt_experiment_id = $2, prompt_variant_id = $3, status = $4 WHERE id = $1`,
+			img.ID.String(), created.ID, variantID, status)
+		require.NoError(t, err)
+		if approved != nil {
+			require.NoError(t, images.SetImageApproval(ctx, img.ID.String(), approved))
+		}
+	}
+	yes, no := true, false
+	tag(created.Variants[0].ID, "ready", &yes)
+	tag(created.Variants[0].ID, "error", nil)
+	tag(created.Variants[1].ID, "ready", &no)
+	tag(created.Variants[1].ID, "processing", nil)
+
+	outcomes, err := repo.Outcomes(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, promptexperiment.Outcome{Assigned: 2, Ready: 1, Failed: 1, Approved: 1},
+		outcomes[created.Variants[0].ID])
+	assert.Equal(t, promptexperiment.Outcome{Assigned: 2, Ready: 1, Rejected: 1},
+		outcomes[created.Variants[1].ID])
+
+	stopped, err := repo.Stop(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, promptexperiment.StatusStopped, stopped.Status)
+	assert.NotNil(t, stopped.StoppedAt)
+	_, err = repo.Stop(ctx, created.ID)
+	assert.ErrorIs(t, err, promptexperiment.ErrNotRunning)
+
+	// Once stopped, a new experiment can run for the same room type and style.
+	_, err = repo.Create(ctx, req, "auth0|admin")
+	require.NoError(t, err)
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
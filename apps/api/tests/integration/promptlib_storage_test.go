@@ -0,0 +1,102 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/promptlib"
+)
+
+func TestPromptLibrary_ReplaceOverrides(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	_, err := db.Pool().Exec(ctx,
+		`INSERT INTO prompt_builtins (room_type, style, prompt) VALUES ('bedroom', 'modern', 'Stage a bedroom.')`)
+	require.NoError(t, err)
+
+	repo := promptlib.NewDefaultRepository(db)
+	builtins, err := repo.ListBuiltins(ctx)
+	require.NoError(t, err)
+	require.Len(t, builtins, 1)
+
+	require.NoError(t, repo.ReplaceOverrides(ctx, []promptlib.Override{
+		{RoomType: "bedroom", Style: "modern", Prompt: "Tuned bedroom."},
+		{RoomType: "kitchen", Style: "modern", Prompt: "Tuned kitchen."},
+	}, "auth0|admin"))
+
+	// Changed prompts bump their version and overrides missing from the set are deleted.
+	require.NoError(t, repo.ReplaceOverrides(ctx, []promptlib.Override{
+		{RoomType: "bedroom", Style: "modern", Prompt: "Tuned bedroom, take two."},
+		{RoomType: "office", Style: "modern", Prompt: "Tuned office."},
+	}, "auth0|admin"))
+
+	overrides, err := repo.ListOverrides(ctx)
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+	assert.Equal(t, "bedroom", overrides[0].RoomType)
+	assert.Equal(t, 2, overrides[0].Version)
+	assert.Equal(t, "office", overrides[1].RoomType)
+	assert.Equal(t, 1, overrides[1].Version)
+	require.NotNil(t, overrides[1].UpdatedBy)
+	assert.Equal(t, "auth0|admin", *overrides[1].UpdatedBy)
+
+	require.NoError(t, repo.ReplaceOverrides(ctx, nil, "auth0|admin"))
+	overrides, err = repo.ListOverrides(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+}
+
+func TestPromptLibrary_SetOverrideVersions(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	repo := promptlib.NewDefaultRepository(db)
+	zero, one := 0, 1
+
+	created, err := repo.SetOverride(ctx, "bedroom", "modern", "First.", "auth0|admin", &zero)
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Version)
+
+	// A stale version, or a create when the override exists, is rejected.
+	_, err = repo.SetOverride(ctx, "bedroom", "modern", "Second.", "auth0|admin", &zero)
+	assert.ErrorIs(t, err, promptlib.ErrVersionConflict)
+
+	updated, err := repo.SetOverride(ctx, "bedroom", "modern", "Second.", "auth0|other", &one)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+
+	// Imports are recorded too.
+	require.NoError(t, repo.ReplaceOverrides(ctx, []promptlib.Override{
+		{RoomType: "bedroom", Style: "modern", Prompt: "Imported."},
+	}, "auth0|admin"))
+
+	// Deleting keeps the history, and a new override carries on from it.
+	require.NoError(t, repo.DeleteOverride(ctx, "bedroom", "modern"))
+	assert.ErrorIs(t, repo.DeleteOverride(ctx, "bedroom", "modern"), promptlib.ErrPromptNotFound)
+	_, err = repo.GetOverride(ctx, "bedroom", "modern")
+	assert.ErrorIs(t, err, promptlib.ErrPromptNotFound)
+
+	recreated, err := repo.SetOverride(ctx, "bedroom", "modern", "First.", "auth0|admin", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, recreated.Version)
+
+	versions, err := repo.ListOverrideVersions(ctx, "bedroom", "modern")
+	require.NoError(t, err)
+	require.Len(t, versions, 4)
+	assert.Equal(t, 4, versions[0].Version)
+	assert.Equal(t, "Imported.", versions[1].Prompt)
+	assert.Equal(t, "Second.", versions[2].Prompt)
+	require.NotNil(t, versions[2].CreatedBy)
+	assert.Equal(t, "auth0|other", *versions[2].CreatedBy)
+	assert.Equal(t, 1, versions[3].Version)
+}
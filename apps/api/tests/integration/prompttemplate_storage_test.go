@@ -0,0 +1,60 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/prompttemplate"
+)
+
+func TestPromptTemplate_Storage(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const (
+		userID    = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+		otherUser = "c0eebc99-9c0b-4ef8-bb6d-6bb9bd380a13"
+	)
+	repo := prompttemplate.NewDefaultRepository(db)
+
+	cozy, err := repo.Create(ctx, &prompttemplate.Template{
+		UserID: userID, Name: "Cozy", Body: "A cozy {style} {room} with warm light",
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &prompttemplate.Template{UserID: userID, Name: "Airy", Body: "An airy, bright {room}"})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, &prompttemplate.Template{UserID: userID, Name: "Cozy", Body: "Another cozy room"})
+	assert.ErrorIs(t, err, prompttemplate.ErrDuplicateName)
+
+	list, err := repo.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "Airy", list[0].Name, "templates are ordered by name")
+	count, err := repo.Count(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, err = repo.GetByID(ctx, cozy.ID, otherUser)
+	assert.ErrorIs(t, err, prompttemplate.ErrTemplateNotFound, "templates are private to their user")
+
+	cozy.Name = "Airy"
+	_, err = repo.Update(ctx, cozy)
+	assert.ErrorIs(t, err, prompttemplate.ErrDuplicateName)
+	cozy.Name = "Cozy evening"
+	updated, err := repo.Update(ctx, cozy)
+	require.NoError(t, err)
+	assert.Equal(t, "Cozy evening", updated.Name)
+
+	assert.ErrorIs(t, repo.Delete(ctx, cozy.ID, otherUser), prompttemplate.ErrTemplateNotFound)
+	require.NoError(t, repo.Delete(ctx, cozy.ID, userID))
+	_, err = repo.GetByID(ctx, cozy.ID, userID)
+	assert.ErrorIs(t, err, prompttemplate.ErrTemplateNotFound)
+}
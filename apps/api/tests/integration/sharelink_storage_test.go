@@ -0,0 +1,66 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/internal/sharelink"
+)
+
+func TestShareLink_Storage(t *testing.T) {
+	ctx := context.Background()
+	db := SetupTestDatabase(t)
+	defer db.Close()
+	require.NoError(t, ResetDatabase(ctx, db.Pool()))
+
+	const (
+		userID    = "a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11"
+		projectID = "b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12"
+	)
+	repo := sharelink.NewDefaultRepository(db)
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+	link, err := repo.Create(ctx, &sharelink.Link{
+		ProjectID: projectID, CreatedBy: userID, Token: "token-1", ExpiresAt: &expires,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/shared/token-1", link.URL)
+	require.NotNil(t, link.ExpiresAt)
+	assert.True(t, expires.Equal(*link.ExpiresAt))
+	assert.Zero(t, link.ViewCount)
+
+	got, err := repo.GetByToken(ctx, "token-1")
+	require.NoError(t, err)
+	assert.Equal(t, link.ID, got.ID)
+	_, err = repo.GetByToken(ctx, "token-2")
+	assert.ErrorIs(t, err, sharelink.ErrLinkNotFound)
+
+	require.NoError(t, repo.RecordView(ctx, link.ID))
+	require.NoError(t, repo.RecordView(ctx, link.ID))
+	got, err = repo.GetByToken(ctx, "token-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got.ViewCount)
+	assert.NotNil(t, got.LastViewedAt)
+
+	assert.ErrorIs(t, repo.Revoke(ctx, link.ID, "c0eebc99-9c0b-4ef8-bb6d-6bb9bd380a13"), sharelink.ErrLinkNotFound,
+		"a link is revoked through its own project")
+	require.NoError(t, repo.Revoke(ctx, link.ID, projectID))
+	revoked, err := repo.GetByToken(ctx, "token-1")
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	require.NoError(t, repo.Revoke(ctx, link.ID, projectID))
+	again, err := repo.GetByToken(ctx, "token-1")
+	require.NoError(t, err)
+	assert.True(t, revoked.RevokedAt.Equal(*again.RevokedAt), "revoking twice keeps the first time")
+
+	links, err := repo.ListByProject(ctx, projectID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.False(t, links[0].Active(time.Now()))
+}
@@ -21,7 +21,10 @@ func SeedDatabase(ctx context.Context, pool storage.PgxPool) error {
 // TruncateAllTables truncates all tables and resets sequences
 func TruncateAllTables(ctx context.Context, pool storage.PgxPool) error {
 	query := `
-		TRUNCATE TABLE processed_events, invoices, subscriptions, images, jobs, projects, users, plans RESTART IDENTITY CASCADE
+		TRUNCATE TABLE processed_events, invoices, subscriptions, images, jobs, projects, users, plans,
+			model_version_pins, prompt_builtins, prompt_overrides, prompt_override_versions, idempotency_keys,
+			prompt_experiments, prompt_experiment_variants, image_feedback
+			RESTART IDENTITY CASCADE
 	`
 	_, err := pool.Exec(ctx, query)
 	return err
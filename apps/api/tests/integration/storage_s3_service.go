@@ -51,7 +51,7 @@ func TestS3Integration_UploadHeadDelete(t *testing.T) {
 	require.NoError(t, err)
 
 	// Generate a presigned URL for upload
-	presigned, err := svc.GeneratePresignedUploadURL(ctx, userID, filename, contentType, int64(len(fileBytes)))
+	presigned, err := svc.GeneratePresignedUploadURL(ctx, userID, "", filename, contentType, int64(len(fileBytes)))
 	require.NoError(t, err)
 	require.NotNil(t, presigned)
 	require.NotEmpty(t, presigned.UploadURL)
@@ -0,0 +1,209 @@
+// Package watermark draws the "Virtually Staged" disclosure that MLS rules in
+// many markets require on digitally staged listing photos.
+package watermark
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // staged images may come back as PNG
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	_ "golang.org/x/image/webp" // or WebP, depending on the model's output format
+)
+
+// Position places the watermark on the image.
+type Position string
+
+// Positions anchor the watermark to a corner, inset by a small margin, or to
+// the middle of the image.
+const (
+	TopLeft     Position = "top_left"
+	TopRight    Position = "top_right"
+	BottomLeft  Position = "bottom_left"
+	BottomRight Position = "bottom_right"
+	Center      Position = "center"
+)
+
+// Valid reports whether p is a known position.
+func (p Position) Valid() bool {
+	switch p {
+	case TopLeft, TopRight, BottomLeft, BottomRight, Center:
+		return true
+	}
+	return false
+}
+
+// Options style a watermark.
+type Options struct {
+	Text     string
+	Position Position
+	// Opacity of the text, from 0.1 to 1. The backing box behind it is drawn
+	// at half this so the photo shows through.
+	Opacity float64
+}
+
+// DefaultText is the watermark text used when no setting overrides it. Unlike
+// text an admin sets, it is translated into the language the image was
+// requested in.
+const DefaultText = "Virtually Staged"
+
+// DefaultOptions returns the style used when no settings override it.
+func DefaultOptions() Options {
+	return Options{Text: DefaultText, Position: BottomRight, Opacity: 0.6}
+}
+
+// Quality is the JPEG quality watermarked images are encoded at. It matches
+// what staged images are usually delivered at, so the overlay doesn't
+// visibly soften the photo.
+const Quality = 92
+
+// boldFont is parsed once; it is bundled, so a parse failure is a build problem.
+var boldFont = mustParse(gobold.TTF)
+
+func mustParse(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(fmt.Sprintf("watermark: parse bundled font: %v", err))
+	}
+	return f
+}
+
+// Apply decodes data, draws the watermark described by opts and returns the
+// result as a JPEG. The text is sized relative to the image so it reads the
+// same on a phone snapshot and a full-resolution photo.
+func Apply(data []byte, opts Options) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+
+	if err := Draw(dst, opts); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: Quality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Draw draws the watermark described by opts onto dst in place.
+func Draw(dst draw.Image, opts Options) error {
+	text := strings.TrimSpace(opts.Text)
+	if text == "" {
+		return fmt.Errorf("watermark text is empty")
+	}
+	if !opts.Position.Valid() {
+		return fmt.Errorf("unknown watermark position %q", opts.Position)
+	}
+	opacity := min(max(opts.Opacity, 0.1), 1)
+	bounds := dst.Bounds()
+
+	// Start at 1/24 of the shorter side and shrink until the text fits across.
+	size := max(float64(min(bounds.Dx(), bounds.Dy()))/24, 8)
+	face, width, err := fitFace(text, size, bounds.Dx())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = face.Close() }()
+
+	metrics := face.Metrics()
+	pad := metrics.Height.Ceil() / 2
+	box := image.Rect(0, 0, width+2*pad, metrics.Height.Ceil()+2*pad)
+	box = box.Add(place(bounds, box.Size(), opts.Position, pad))
+
+	draw.Draw(dst, box, image.NewUniform(color.NRGBA{A: alpha(opacity / 2)}), image.Point{}, draw.Over)
+	drawer := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(color.NRGBA{R: 255, G: 255, B: 255, A: alpha(opacity)}),
+		Face: face,
+		Dot:  fixed.P(box.Min.X+pad, box.Min.Y+pad+metrics.Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+	return nil
+}
+
+// Banner draws text centered on a dark band across the full width of the
+// bottom of dst, the form of disclosure some MLS rules ask for instead of a
+// corner mark.
+func Banner(dst draw.Image, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("banner text is empty")
+	}
+	bounds := dst.Bounds()
+
+	size := max(float64(min(bounds.Dx(), bounds.Dy()))/20, 8)
+	face, width, err := fitFace(text, size, bounds.Dx())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = face.Close() }()
+
+	metrics := face.Metrics()
+	pad := metrics.Height.Ceil() / 2
+	band := image.Rect(bounds.Min.X, bounds.Max.Y-metrics.Height.Ceil()-2*pad, bounds.Max.X, bounds.Max.Y)
+
+	draw.Draw(dst, band, image.NewUniform(color.NRGBA{A: alpha(0.75)}), image.Point{}, draw.Over)
+	drawer := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(color.White),
+		Face: face,
+		Dot:  fixed.P(band.Min.X+(band.Dx()-width)/2, band.Min.Y+pad+metrics.Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+	return nil
+}
+
+// fitFace returns a face at size, scaled down when the text would be wider
+// than most of the image, and the text's width in that face.
+func fitFace(text string, size float64, imageWidth int) (font.Face, int, error) {
+	limit := imageWidth * 9 / 10
+	for {
+		face, err := opentype.NewFace(boldFont, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, 0, fmt.Errorf("create font face: %w", err)
+		}
+		width := font.MeasureString(face, text).Ceil()
+		if width <= limit || size <= 6 {
+			return face, width, nil
+		}
+		_ = face.Close()
+		size = max(size*float64(limit)/float64(width), 6)
+	}
+}
+
+// place returns the top-left corner of a box of the given size, inset by
+// margin from the edges it's anchored to.
+func place(bounds image.Rectangle, size image.Point, pos Position, margin int) image.Point {
+	left, top := bounds.Min.X+margin, bounds.Min.Y+margin
+	right, bottom := bounds.Max.X-margin-size.X, bounds.Max.Y-margin-size.Y
+	switch pos {
+	case TopLeft:
+		return image.Pt(left, top)
+	case TopRight:
+		return image.Pt(right, top)
+	case BottomLeft:
+		return image.Pt(left, bottom)
+	case Center:
+		return image.Pt(bounds.Min.X+(bounds.Dx()-size.X)/2, bounds.Min.Y+(bounds.Dy()-size.Y)/2)
+	default:
+		return image.Pt(right, bottom)
+	}
+}
+
+func alpha(opacity float64) uint8 {
+	return uint8(opacity*255 + 0.5)
+}
@@ -0,0 +1,130 @@
+package watermark
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func grayJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 128, G: 128, B: 128, A: 255}), image.Point{}, draw.Src)
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	return buf.Bytes()
+}
+
+// changed returns the bounding box of pixels that differ noticeably from the
+// plain gray source.
+func changed(img image.Image) image.Rectangle {
+	var r image.Rectangle
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			if c.Y < 110 || c.Y > 146 {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return r
+}
+
+func TestPosition_Valid(t *testing.T) {
+	assert.True(t, BottomRight.Valid())
+	assert.True(t, Center.Valid())
+	assert.False(t, Position("middle").Valid())
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		position Position
+		inside   func(b image.Rectangle, w, h int) bool
+	}{
+		{name: "success: bottom right", position: BottomRight, inside: func(b image.Rectangle, w, h int) bool {
+			return b.Min.X > w/2 && b.Min.Y > h/2
+		}},
+		{name: "success: top left", position: TopLeft, inside: func(b image.Rectangle, w, h int) bool {
+			return b.Max.X < w/2 && b.Max.Y < h/2
+		}},
+		{name: "success: center", position: Center, inside: func(b image.Rectangle, w, h int) bool {
+			return b.Min.X < w/2 && b.Max.X > w/2 && b.Min.Y < h/2 && b.Max.Y > h/2
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := Apply(grayJPEG(t, 1200, 800), Options{Text: "Virtually Staged", Position: tc.position, Opacity: 1})
+			require.NoError(t, err)
+
+			img, format, err := image.Decode(bytes.NewReader(out))
+			require.NoError(t, err)
+			assert.Equal(t, "jpeg", format)
+			assert.Equal(t, image.Rect(0, 0, 1200, 800), img.Bounds())
+
+			marked := changed(img)
+			require.False(t, marked.Empty(), "watermark is drawn")
+			assert.True(t, tc.inside(marked, 1200, 800), "watermark at %s: %v", tc.position, marked)
+		})
+	}
+
+	t.Run("success: long text is scaled to fit", func(t *testing.T) {
+		out, err := Apply(grayJPEG(t, 300, 300), Options{
+			Text: "Virtually Staged - furniture is not included", Position: BottomLeft, Opacity: 1,
+		})
+		require.NoError(t, err)
+		img, _, err := image.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+
+		marked := changed(img)
+		assert.GreaterOrEqual(t, marked.Min.X, 0)
+		assert.LessOrEqual(t, marked.Max.X, 300)
+	})
+
+	t.Run("fail: empty text", func(t *testing.T) {
+		_, err := Apply(grayJPEG(t, 10, 10), Options{Text: " ", Position: BottomRight, Opacity: 1})
+		assert.ErrorContains(t, err, "watermark text is empty")
+	})
+
+	t.Run("fail: unknown position", func(t *testing.T) {
+		_, err := Apply(grayJPEG(t, 10, 10), Options{Text: "x", Position: "middle", Opacity: 1})
+		assert.ErrorContains(t, err, "unknown watermark position")
+	})
+
+	t.Run("fail: not an image", func(t *testing.T) {
+		_, err := Apply([]byte("nope"), DefaultOptions())
+		assert.ErrorContains(t, err, "decode image")
+	})
+}
+
+func TestBanner(t *testing.T) {
+	gray := func(w, h int) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{R: 128, G: 128, B: 128, A: 255}), image.Point{}, draw.Src)
+		return img
+	}
+
+	t.Run("success: band spans the bottom edge", func(t *testing.T) {
+		img := gray(1200, 800)
+		require.NoError(t, Banner(img, "Virtually Staged"))
+
+		marked := changed(img)
+		require.False(t, marked.Empty(), "banner is drawn")
+		assert.Equal(t, 0, marked.Min.X)
+		assert.Equal(t, 1200, marked.Max.X)
+		assert.Equal(t, 800, marked.Max.Y)
+		assert.Greater(t, marked.Min.Y, 800/2)
+	})
+
+	t.Run("fail: empty text", func(t *testing.T) {
+		assert.ErrorContains(t, Banner(gray(10, 10), " "), "banner text is empty")
+	})
+}
@@ -0,0 +1,44 @@
+// Package webhookauth verifies signed webhook deliveries from third parties:
+// it checks the HMAC signature against one or more secrets (so secrets can be
+// rotated without dropping deliveries), rejects stale timestamps and, with a
+// ReplayCache, refuses a signed request that has already been accepted.
+package webhookauth
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrMissingSignature is returned when the request carries no signature.
+	ErrMissingSignature = errors.New("missing webhook signature")
+	// ErrMalformedSignature is returned when the signature headers cannot be parsed.
+	ErrMalformedSignature = errors.New("malformed webhook signature")
+	// ErrTimestampOutOfTolerance is returned when the signed timestamp is too
+	// far from the current time.
+	ErrTimestampOutOfTolerance = errors.New("webhook timestamp outside tolerance window")
+	// ErrSignatureMismatch is returned when no signature matches any secret.
+	ErrSignatureMismatch = errors.New("no matching webhook signature")
+	// ErrReplayed is returned when the same signed request was already accepted.
+	ErrReplayed = errors.New("webhook request already received")
+	// ErrReplayCacheUnavailable is returned when the replay cache cannot be
+	// consulted. Verification fails closed.
+	ErrReplayCacheUnavailable = errors.New("webhook replay cache unavailable")
+	// ErrNoSecret is returned when a verifier has no secret to check against.
+	ErrNoSecret = errors.New("no webhook secret configured")
+)
+
+// DefaultTolerance is how far a signed timestamp may drift from the current
+// time, in either direction, before a delivery is rejected.
+const DefaultTolerance = 5 * time.Minute
+
+// Signature is what a Scheme reads from the request headers.
+type Signature struct {
+	// ID is the delivery ID covered by the signature; empty when the scheme
+	// does not sign one.
+	ID string
+	// Timestamp is the signed Unix timestamp.
+	Timestamp int64
+	// Values are the candidate signatures; one must match.
+	Values [][]byte
+}
@@ -0,0 +1,34 @@
+package webhookauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out replay_mock.go . ReplayCache
+
+// ReplayCache remembers signed requests that were already accepted.
+type ReplayCache interface {
+	// Remember records key for ttl and reports whether it was not already recorded.
+	Remember(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// RedisReplayCache is a ReplayCache shared by every instance receiving webhooks.
+type RedisReplayCache struct {
+	rdb *redis.Client
+}
+
+// Ensure RedisReplayCache implements ReplayCache.
+var _ ReplayCache = (*RedisReplayCache)(nil)
+
+// NewRedisReplayCache creates a replay cache backed by rdb.
+func NewRedisReplayCache(rdb *redis.Client) *RedisReplayCache {
+	return &RedisReplayCache{rdb: rdb}
+}
+
+// Remember sets the key only if it does not exist yet.
+func (r *RedisReplayCache) Remember(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return r.rdb.SetNX(ctx, "webhookauth:replay:"+key, 1, ttl).Result()
+}
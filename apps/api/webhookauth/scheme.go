@@ -0,0 +1,128 @@
+package webhookauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Scheme is a provider's signing format.
+type Scheme interface {
+	// Name identifies the scheme in replay cache keys.
+	Name() string
+	// Parse reads the signature from the request headers.
+	Parse(header http.Header) (Signature, error)
+	// Sign computes the signature of body the provider would send for secret.
+	Sign(secret string, sig Signature, body []byte) ([]byte, error)
+}
+
+// Stripe signs "timestamp.body" with HMAC-SHA256 and sends
+// "Stripe-Signature: t=<timestamp>,v1=<hex>[,v1=<hex>...]".
+// See https://docs.stripe.com/webhooks/signatures.
+var Stripe Scheme = stripeScheme{}
+
+// Replicate follows the Standard Webhooks format: HMAC-SHA256 of
+// "id.timestamp.body" keyed with the base64 part of a "whsec_" secret, sent
+// as "webhook-signature: v1,<base64> [v1,<base64>...]" alongside webhook-id
+// and webhook-timestamp. See https://replicate.com/docs/topics/webhooks/verify-webhook.
+var Replicate Scheme = standardScheme{}
+
+type stripeScheme struct{}
+
+func (stripeScheme) Name() string { return "stripe" }
+
+func (stripeScheme) Parse(header http.Header) (Signature, error) {
+	raw := header.Get("Stripe-Signature")
+	if raw == "" {
+		return Signature{}, fmt.Errorf("%w: no Stripe-Signature header", ErrMissingSignature)
+	}
+
+	var sig Signature
+	var haveTS bool
+	for _, part := range strings.Split(raw, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return Signature{}, fmt.Errorf("%w: invalid timestamp %q", ErrMalformedSignature, value)
+			}
+			sig.Timestamp = ts
+			haveTS = true
+		case "v1":
+			b, err := hex.DecodeString(value)
+			if err != nil {
+				return Signature{}, fmt.Errorf("%w: invalid v1 signature", ErrMalformedSignature)
+			}
+			sig.Values = append(sig.Values, b)
+		}
+	}
+
+	if !haveTS {
+		return Signature{}, fmt.Errorf("%w: missing timestamp", ErrMalformedSignature)
+	}
+	if len(sig.Values) == 0 {
+		return Signature{}, fmt.Errorf("%w: no v1 signatures", ErrMissingSignature)
+	}
+	return sig, nil
+}
+
+func (stripeScheme) Sign(secret string, sig Signature, body []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d.", sig.Timestamp)
+	_, _ = mac.Write(body)
+	return mac.Sum(nil), nil
+}
+
+type standardScheme struct{}
+
+func (standardScheme) Name() string { return "replicate" }
+
+func (standardScheme) Parse(header http.Header) (Signature, error) {
+	id, ts, raw := header.Get("webhook-id"), header.Get("webhook-timestamp"), header.Get("webhook-signature")
+	if raw == "" {
+		return Signature{}, fmt.Errorf("%w: no webhook-signature header", ErrMissingSignature)
+	}
+	if id == "" {
+		return Signature{}, fmt.Errorf("%w: missing webhook-id", ErrMalformedSignature)
+	}
+
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return Signature{}, fmt.Errorf("%w: invalid timestamp %q", ErrMalformedSignature, ts)
+	}
+
+	sig := Signature{ID: id, Timestamp: timestamp}
+	for _, entry := range strings.Fields(raw) {
+		version, value, ok := strings.Cut(entry, ",")
+		if !ok || version != "v1" {
+			// Other versions may be added by the provider; skip them.
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return Signature{}, fmt.Errorf("%w: invalid v1 signature", ErrMalformedSignature)
+		}
+		sig.Values = append(sig.Values, b)
+	}
+	if len(sig.Values) == 0 {
+		return Signature{}, fmt.Errorf("%w: no v1 signatures", ErrMissingSignature)
+	}
+	return sig, nil
+}
+
+func (standardScheme) Sign(secret string, sig Signature, body []byte) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook secret: %w", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	_, _ = fmt.Fprintf(mac, "%s.%d.", sig.ID, sig.Timestamp)
+	_, _ = mac.Write(body)
+	return mac.Sum(nil), nil
+}
@@ -0,0 +1,216 @@
+package webhookauth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stripeSecret = "whsec_test"
+
+func stripeHeader(t *testing.T, body []byte, ts int64, secrets ...string) http.Header {
+	t.Helper()
+	value := fmt.Sprintf("t=%d", ts)
+	for _, secret := range secrets {
+		sig, err := Stripe.Sign(secret, Signature{Timestamp: ts}, body)
+		require.NoError(t, err)
+		value += ",v1=" + hex.EncodeToString(sig)
+	}
+	h := http.Header{}
+	h.Set("Stripe-Signature", value)
+	return h
+}
+
+func TestStripe_Parse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expectErr error
+		expectSig Signature
+	}{
+		{
+			name:      "success: timestamp and two signatures",
+			value:     "t=1700000000, v1=00,v1=aa,v0=ff",
+			expectSig: Signature{Timestamp: 1700000000, Values: [][]byte{{0x00}, {0xaa}}},
+		},
+		{name: "fail: no header", expectErr: ErrMissingSignature},
+		{name: "fail: missing timestamp", value: "v1=00", expectErr: ErrMalformedSignature},
+		{name: "fail: invalid timestamp", value: "t=abc,v1=00", expectErr: ErrMalformedSignature},
+		{name: "fail: invalid v1 hex", value: "t=1700000000,v1=zz", expectErr: ErrMalformedSignature},
+		{name: "fail: no v1 signatures", value: "t=1700000000", expectErr: ErrMissingSignature},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := http.Header{}
+			if tc.value != "" {
+				h.Set("Stripe-Signature", tc.value)
+			}
+			sig, err := Stripe.Parse(h)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectSig, sig)
+		})
+	}
+}
+
+func TestReplicate_Verify(t *testing.T) {
+	// Example delivery from the Standard Webhooks reference implementation.
+	secret := "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+	body := []byte(`{"test": 2432232314}`)
+	h := http.Header{}
+	h.Set("webhook-id", "msg_p5jXN8AQM9LWM0D4loKWxJek")
+	h.Set("webhook-timestamp", "1614265330")
+	h.Set("webhook-signature", "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=")
+
+	v := NewVerifier(Replicate, []string{secret}, Options{})
+	v.now = func() time.Time { return time.Unix(1614265330, 0) }
+	require.NoError(t, v.Verify(context.Background(), h, body))
+
+	h.Set("webhook-id", "msg_other")
+	assert.ErrorIs(t, v.Verify(context.Background(), h, body), ErrSignatureMismatch, "the ID is signed")
+
+	h.Set("webhook-signature", "v1,not-base64!")
+	assert.ErrorIs(t, v.Verify(context.Background(), h, body), ErrMalformedSignature)
+
+	bad := NewVerifier(Replicate, []string{"whsec_%%%"}, Options{})
+	bad.now = v.now
+	h.Set("webhook-id", "msg_p5jXN8AQM9LWM0D4loKWxJek")
+	h.Set("webhook-signature", "v1,"+base64.StdEncoding.EncodeToString([]byte("x")))
+	assert.Error(t, bad.Verify(context.Background(), h, body))
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	body := []byte(`{"id":"evt_123"}`)
+	ts := int64(1700000000)
+	at := func(offset time.Duration) func() time.Time {
+		return func() time.Time { return time.Unix(ts, 0).Add(offset) }
+	}
+
+	testCases := []struct {
+		name      string
+		secrets   []string
+		header    http.Header
+		now       func() time.Time
+		expectErr error
+	}{
+		{
+			name:    "success: within tolerance",
+			secrets: []string{stripeSecret},
+			header:  stripeHeader(t, body, ts, stripeSecret),
+			now:     at(time.Minute),
+		},
+		{
+			name:    "success: one of several signatures matches",
+			secrets: []string{stripeSecret},
+			header:  stripeHeader(t, body, ts, "whsec_other", stripeSecret),
+			now:     at(0),
+		},
+		{
+			name:    "success: signed with the previous secret during rotation",
+			secrets: []string{"whsec_new", "", stripeSecret},
+			header:  stripeHeader(t, body, ts, stripeSecret),
+			now:     at(0),
+		},
+		{
+			name:      "fail: wrong secret",
+			secrets:   []string{stripeSecret},
+			header:    stripeHeader(t, body, ts, "whsec_other"),
+			now:       at(0),
+			expectErr: ErrSignatureMismatch,
+		},
+		{
+			name:      "fail: timestamp too old",
+			secrets:   []string{stripeSecret},
+			header:    stripeHeader(t, body, ts, stripeSecret),
+			now:       at(10 * time.Minute),
+			expectErr: ErrTimestampOutOfTolerance,
+		},
+		{
+			name:      "fail: timestamp in the future",
+			secrets:   []string{stripeSecret},
+			header:    stripeHeader(t, body, ts, stripeSecret),
+			now:       at(-10 * time.Minute),
+			expectErr: ErrTimestampOutOfTolerance,
+		},
+		{
+			name:      "fail: missing header",
+			secrets:   []string{stripeSecret},
+			header:    http.Header{},
+			now:       at(0),
+			expectErr: ErrMissingSignature,
+		},
+		{
+			name:      "fail: no secrets",
+			secrets:   []string{""},
+			header:    stripeHeader(t, body, ts, stripeSecret),
+			now:       at(0),
+			expectErr: ErrNoSecret,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewVerifier(Stripe, tc.secrets, Options{})
+			v.now = tc.now
+			err := v.Verify(context.Background(), tc.header, body)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestVerifier_Replay(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+
+	ctx := context.Background()
+	body := []byte(`{"id":"evt_123"}`)
+	now := time.Now()
+	v := NewVerifier(Stripe, []string{stripeSecret}, Options{Replay: NewRedisReplayCache(rdb)})
+
+	header := stripeHeader(t, body, now.Unix(), stripeSecret)
+	require.NoError(t, v.Verify(ctx, header, body))
+	assert.ErrorIs(t, v.Verify(ctx, header, body), ErrReplayed)
+
+	retry := stripeHeader(t, body, now.Unix()+1, stripeSecret)
+	assert.NoError(t, v.Verify(ctx, retry, body), "a re-signed retry is not a replay")
+
+	keys := mr.Keys()
+	require.Len(t, keys, 2)
+	assert.Equal(t, 2*DefaultTolerance, mr.TTL(keys[0]))
+
+	failing := NewVerifier(Stripe, []string{stripeSecret}, Options{Replay: &ReplayCacheMock{
+		RememberFunc: func(context.Context, string, time.Duration) (bool, error) {
+			return false, errors.New("connection refused")
+		},
+	}})
+	assert.ErrorIs(t, failing.Verify(ctx, header, body), ErrReplayCacheUnavailable)
+}
+
+func TestVerifier_Tolerance(t *testing.T) {
+	body := []byte(`{}`)
+	ts := int64(1700000000)
+	v := NewVerifier(Stripe, []string{stripeSecret}, Options{Tolerance: time.Minute})
+	v.now = func() time.Time { return time.Unix(ts, 0).Add(2 * time.Minute) }
+
+	err := v.Verify(context.Background(), stripeHeader(t, body, ts, stripeSecret), body)
+	assert.ErrorIs(t, err, ErrTimestampOutOfTolerance)
+}
@@ -0,0 +1,78 @@
+// Command realstaging runs every Real Staging AI process and operator task from
+// one binary:
+//
+//	realstaging serve api
+//	realstaging serve worker
+//	realstaging reconcile images -dry-run
+//	realstaging admin set-model -model qwen/qwen-image-edit
+//	realstaging migrate up
+//
+// Global flags come before the command and select the configuration every
+// subcommand loads, e.g. "realstaging -env prod -config-dir /config migrate up".
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/real-staging-ai/api/apicmd"
+	"github.com/real-staging-ai/api/command"
+	"github.com/real-staging-ai/worker/workercmd"
+)
+
+// root is the realstaging command tree.
+var root = &command.Command{
+	Name: "realstaging",
+	Commands: []*command.Command{
+		{
+			Name:     "serve",
+			Summary:  "run a long-lived service until SIGINT or SIGTERM",
+			Commands: []*command.Command{apicmd.ServeAPI, workercmd.ServeWorker},
+		},
+		apicmd.Reconcile,
+		{
+			Name:    "admin",
+			Summary: "one-off operator tasks",
+			Commands: []*command.Command{
+				apicmd.SetModel, apicmd.SyncModels, apicmd.MigrateS3Keys, workercmd.BackfillOrientation,
+			},
+		},
+		apicmd.Migrate,
+	},
+}
+
+func main() {
+	global := flag.NewFlagSet("realstaging", flag.ContinueOnError)
+	env := global.String("env", "", "configuration environment, loading config/<env>.yml (overrides APP_ENV)")
+	configDir := global.String("config-dir", "", "directory holding shared.yml and <env>.yml (overrides CONFIG_DIR)")
+	global.Usage = func() {
+		command.Execute(context.Background(), root, []string{"-h"}, global.Output())
+		_, _ = fmt.Fprintln(global.Output(), "\nglobal flags, given before the command:")
+		global.PrintDefaults()
+	}
+	if err := global.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+
+	// Both the API and worker configuration load from the environment, so
+	// every subcommand sees the same settings.
+	if *env != "" {
+		_ = os.Setenv("APP_ENV", *env)
+	}
+	if *configDir != "" {
+		_ = os.Setenv("CONFIG_DIR", *configDir)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := command.Execute(ctx, root, global.Args(), os.Stderr)
+	stop()
+	os.Exit(code)
+}
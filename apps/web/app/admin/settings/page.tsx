@@ -15,6 +15,7 @@ interface ModelInfo {
   name: string;
   description: string;
   version: string;
+  enabled: boolean;
   is_active: boolean;
 }
 
@@ -73,6 +74,27 @@ export default function AdminSettingsPage() {
     }
   };
 
+  const setModelEnabled = async (modelID: string, enabled: boolean) => {
+    try {
+      setUpdating(modelID);
+      setError(null);
+      setSuccessMessage(null);
+
+      await apiFetch(`/v1/admin/models/${encodeURIComponent(modelID)}`, {
+        method: "PATCH",
+        body: JSON.stringify({ enabled }),
+      });
+
+      setSuccessMessage(enabled ? "Model enabled." : "Model disabled.");
+      await fetchModels();
+      setTimeout(() => setSuccessMessage(null), 3000);
+    } catch (err) {
+      setError(err instanceof Error ? err.message : "An error occurred");
+    } finally {
+      setUpdating(null);
+    }
+  };
+
   if (loading) {
     return (
       <div className="container mx-auto px-4 py-8">
@@ -144,6 +166,7 @@ export default function AdminSettingsPage() {
                         {model.is_active && (
                           <Badge className="bg-blue-600 dark:bg-blue-500">Active</Badge>
                         )}
+                        {!model.enabled && <Badge variant="secondary">Disabled</Badge>}
                         <Badge variant="outline">{model.version}</Badge>
                       </div>
                       <p className="text-sm text-gray-600 dark:text-gray-400 mb-3">
@@ -166,6 +189,16 @@ export default function AdminSettingsPage() {
                         Configure
                       </Button>
                       {!model.is_active && (
+                        <Button
+                          onClick={() => setModelEnabled(model.id, !model.enabled)}
+                          disabled={updating === model.id}
+                          variant="outline"
+                          size="sm"
+                        >
+                          {model.enabled ? "Disable" : "Enable"}
+                        </Button>
+                      )}
+                      {!model.is_active && model.enabled && (
                         <Button
                           onClick={() => updateActiveModel(model.id)}
                           disabled={updating === model.id}
@@ -13,6 +13,10 @@ interface UsageStats {
   period_end: string;
   has_subscription: boolean;
   remaining_images: number;
+  overage_cap?: number;
+  overage_images?: number;
+  payment_past_due?: boolean;
+  grace_period_ends_at?: string;
 }
 
 interface Subscription {
@@ -428,6 +432,20 @@ export default function BillingPage() {
                   style={{ width: `${Math.min(getUsagePercentage(), 100)}%` }}
                 />
               </div>
+              {(usage.overage_cap ?? 0) > 0 && (
+                <p className="mt-2 text-xs sm:text-sm text-gray-600 dark:text-gray-400">
+                  Overage: {(usage.overage_images ?? 0).toLocaleString()} of{' '}
+                  {(usage.overage_cap ?? 0).toLocaleString()} extra images used this period, billed per image on
+                  your next invoice.
+                </p>
+              )}
+              {usage.payment_past_due && usage.grace_period_ends_at && (
+                <p className="mt-2 flex items-center gap-2 text-xs sm:text-sm text-amber-700 dark:text-amber-400">
+                  <AlertCircle className="h-4 w-4 flex-shrink-0" />
+                  Your last payment failed. Update your payment method before{' '}
+                  {new Date(usage.grace_period_ends_at).toLocaleDateString()} to keep your plan.
+                </p>
+              )}
             </div>
           </div>
         </div>
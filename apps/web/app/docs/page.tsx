@@ -84,7 +84,7 @@ export default function DocsPage() {
                   Choose Your Style
                 </h3>
                 <p className="text-gray-600 dark:text-gray-400 mb-3">
-                  Select from Modern, Contemporary, Traditional, Industrial, or Scandinavian styles. Pick the room type (bedroom, living room, kitchen, etc.) for best results.
+                  Select from Modern, Contemporary, Traditional, Industrial, Scandinavian, Bohemian, Farmhouse, Mid-Century Modern, Coastal, or Japandi styles. Pick the room type (bedroom, living room, kitchen, etc.) for best results.
                 </p>
               </div>
             </div>
@@ -25,7 +25,7 @@ import {
   Keyboard
 } from "lucide-react";
 
-import { apiFetch } from "@/lib/api";
+import { apiFetch, apiFetchAll } from "@/lib/api";
 import { cn, formatRelativeTime } from "@/lib/utils";
 import { getCachedUrl, setCachedUrl, clearExpiredCache } from "@/lib/imageCache";
 
@@ -34,10 +34,6 @@ type Project = {
   name: string;
 };
 
-type ProjectListResponse = {
-  projects: Project[];
-};
-
 type ImageRecord = {
   id: string;
   project_id: string;
@@ -52,10 +48,6 @@ type ImageRecord = {
   updated_at: string;
 };
 
-type ImageListResponse = {
-  images: ImageRecord[];
-};
-
 type ImageVariant = {
   id: string;
   style?: string | null;
@@ -78,9 +70,23 @@ type GroupedImage = {
   variants: ImageVariant[];
 };
 
-type GroupedImageListResponse = {
-  images: GroupedImage[];
-};
+// A group can span pages of the grouped listing; join its variants back up.
+function mergeGroups(groups: GroupedImage[]): GroupedImage[] {
+  const merged: GroupedImage[] = [];
+  const byOriginal = new Map<string, GroupedImage>();
+  for (const group of groups) {
+    const existing = group.original_image_id ? byOriginal.get(group.original_image_id) : undefined;
+    if (existing) {
+      existing.variants = [...existing.variants, ...group.variants];
+      continue;
+    }
+    if (group.original_image_id) {
+      byOriginal.set(group.original_image_id, group);
+    }
+    merged.push(group);
+  }
+  return merged;
+}
 
 export default function ImagesPage() {
   const [projects, setProjects] = useState<Project[]>([]);
@@ -501,8 +507,7 @@ export default function ImagesPage() {
     setLoadingProjects(true);
     setStatusMessage("Loading projects...");
     try {
-      const res = await apiFetch<ProjectListResponse>("/v1/projects");
-      const list = res.projects ?? [];
+      const list = await apiFetchAll<Project>("/v1/projects?limit=100", "projects");
       setProjects(list);
       if (list.length === 0) {
         setSelectedProjectId("");
@@ -549,8 +554,8 @@ export default function ImagesPage() {
     try {
       if (useGroupedView) {
         // Fetch grouped images
-        const res = await apiFetch<GroupedImageListResponse>(`/v1/projects/${projectId}/images/grouped`);
-        const groupedList = res.images ?? [];
+        const pages = await apiFetchAll<GroupedImage>(`/v1/projects/${projectId}/images/grouped?limit=100`, "images");
+        const groupedList = mergeGroups(pages);
         setGroupedImages(groupedList);
         
         // Flatten for compatibility with existing code
@@ -589,8 +594,7 @@ export default function ImagesPage() {
         }
       } else {
         // Fetch flat list
-        const res = await apiFetch<ImageListResponse>(`/v1/projects/${projectId}/images`);
-        const list = res.images ?? [];
+        const list = await apiFetchAll<ImageRecord>(`/v1/projects/${projectId}/images?limit=100`, "images");
         
         // Sort by staging date (updated_at), newest first
         list.sort((a, b) => {
@@ -22,6 +22,12 @@ vi.mock('next/navigation', () => ({
 const apiFetchMock = vi.fn()
 vi.mock('@/lib/api', () => ({
   apiFetch: (...args: unknown[]) => apiFetchMock(...args),
+  openEventSource: vi.fn(async () => null),
+}))
+
+// The API keys card loads its own data; it has its own tests
+vi.mock('@/components/ProviderKeys', () => ({
+  default: () => null,
 }))
 
 import ProfilePage from './page'
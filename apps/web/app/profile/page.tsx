@@ -18,10 +18,11 @@ import {
   Palette,
   Home,
 } from 'lucide-react';
-import { apiFetch } from '@/lib/api';
+import { apiFetch, openEventSource } from '@/lib/api';
 import { toFormData, buildUpdatePayload } from '@/lib/profile';
 import type { BackendProfile } from '@/lib/profile';
 import { PaymentElementForm } from '@/components/stripe/PaymentElementForm';
+import ProviderKeys from '@/components/ProviderKeys';
 
 interface SubscriptionAPI {
   id: string;
@@ -225,6 +226,33 @@ function ProfilePageContent() {
     }
   }, [user, searchParams, fetchProfileAndSubscription, pollSubscriptionWithRetry]);
 
+  // Refresh billing details as soon as a Stripe webhook changes them
+  useEffect(() => {
+    if (!user) return;
+
+    let source: EventSource | null = null;
+    let closed = false;
+    openEventSource('/v1/billing/events')
+      .then((es) => {
+        if (closed) {
+          es?.close();
+          return;
+        }
+        source = es;
+        source?.addEventListener('billing_update', () => {
+          fetchProfileAndSubscription();
+        });
+      })
+      .catch((error) => {
+        console.error('Failed to open billing stream:', error);
+      });
+
+    return () => {
+      closed = true;
+      source?.close();
+    };
+  }, [user, fetchProfileAndSubscription]);
+
   const handleSave = useCallback(async () => {
     setSaving(true);
     setMessage(null);
@@ -974,6 +1002,14 @@ function ProfilePageContent() {
                   <option value="bathroom">Bathroom</option>
                   <option value="dining_room">Dining Room</option>
                   <option value="office">Office</option>
+                  <option value="entryway">Entryway</option>
+                  <option value="outdoor">Outdoor/Patio</option>
+                  <option value="basement">Basement</option>
+                  <option value="garage">Garage</option>
+                  <option value="nursery">Nursery</option>
+                  <option value="laundry_room">Laundry Room</option>
+                  <option value="home_gym">Home Gym</option>
+                  <option value="sunroom">Sunroom</option>
                 </select>
               </div>
 
@@ -993,6 +1029,10 @@ function ProfilePageContent() {
                   <option value="scandinavian">Scandinavian</option>
                   <option value="industrial">Industrial</option>
                   <option value="bohemian">Bohemian</option>
+                  <option value="farmhouse">Farmhouse</option>
+                  <option value="mid_century">Mid-Century Modern</option>
+                  <option value="coastal">Coastal</option>
+                  <option value="japandi">Japandi</option>
                 </select>
               </div>
             </div>
@@ -1000,6 +1040,8 @@ function ProfilePageContent() {
         </div>
       </div>
 
+      <ProviderKeys />
+
       {/* Spacer for sticky button bar */}
       <div className="h-20 sm:h-24" />
 
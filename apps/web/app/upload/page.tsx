@@ -3,7 +3,7 @@
 import { useEffect, useState, useCallback, useRef } from "react";
 import { useRouter } from "next/navigation";
 import { Upload as UploadIcon, FolderOpen, Plus, RefreshCw, CheckCircle2, Loader2, FileImage, X, AlertCircle, CreditCard, Lock } from "lucide-react";
-import { apiFetch } from "@/lib/api";
+import { apiFetch, apiFetchAll } from "@/lib/api";
 import { cn } from "@/lib/utils";
 
 type Project = {
@@ -11,10 +11,6 @@ type Project = {
   name: string
 }
 
-type ProjectListResponse = {
-  projects: Project[]
-}
-
 type FileWithOverrides = {
   file: File
   id: string
@@ -103,20 +99,20 @@ export default function UploadPage() {
   async function loadProjects() {
     try {
       setStatus("Loading projects...")
-      const res = await apiFetch<ProjectListResponse>("/v1/projects")
-      setProjects(res.projects || [])
+      const list = await apiFetchAll<Project>("/v1/projects?limit=100", "projects")
+      setProjects(list)
       
       // If no project selected, try to restore from localStorage or use first project
-      if (!projectId && res.projects && res.projects.length > 0) {
+      if (!projectId && list.length > 0) {
         const savedProjectId = typeof window !== 'undefined' 
           ? localStorage.getItem('selectedProjectId') 
           : null
         
         // Check if saved project still exists
-        if (savedProjectId && res.projects.some(p => p.id === savedProjectId)) {
+        if (savedProjectId && list.some(p => p.id === savedProjectId)) {
           setProjectId(savedProjectId)
         } else {
-          setProjectId(res.projects[0].id)
+          setProjectId(list[0].id)
         }
       }
       setStatus("")
@@ -252,6 +248,7 @@ export default function UploadPage() {
             filename: fileData.file.name,
             content_type: fileData.file.type || "application/octet-stream",
             file_size: fileData.file.size,
+            project_id: projectId,
           }),
         }
       ).catch((err) => {
@@ -633,6 +630,12 @@ export default function UploadPage() {
                     <option value="office">Office</option>
                     <option value="entryway">Entryway</option>
                     <option value="outdoor">Outdoor/Patio</option>
+                    <option value="basement">Basement</option>
+                    <option value="garage">Garage</option>
+                    <option value="nursery">Nursery</option>
+                    <option value="laundry_room">Laundry Room</option>
+                    <option value="home_gym">Home Gym</option>
+                    <option value="sunroom">Sunroom</option>
                   </select>
                 </div>
                 <div>
@@ -655,6 +658,11 @@ export default function UploadPage() {
                       <option value="traditional">Traditional</option>
                       <option value="industrial">Industrial</option>
                       <option value="scandinavian">Scandinavian</option>
+                      <option value="bohemian">Bohemian</option>
+                      <option value="farmhouse">Farmhouse</option>
+                      <option value="mid_century">Mid-Century Modern</option>
+                      <option value="coastal">Coastal</option>
+                      <option value="japandi">Japandi</option>
                     </select>
                     <p className="text-xs text-gray-500 dark:text-gray-400 mt-1">
                       Hold Cmd/Ctrl to select multiple styles
@@ -815,6 +823,12 @@ export default function UploadPage() {
                                   <option value="office">Office</option>
                                   <option value="entryway">Entryway</option>
                                   <option value="outdoor">Outdoor/Patio</option>
+                                  <option value="basement">Basement</option>
+                                  <option value="garage">Garage</option>
+                                  <option value="nursery">Nursery</option>
+                                  <option value="laundry_room">Laundry Room</option>
+                                  <option value="home_gym">Home Gym</option>
+                                  <option value="sunroom">Sunroom</option>
                                 </select>
                               </div>
                               <div>
@@ -835,6 +849,11 @@ export default function UploadPage() {
                                   <option value="traditional">Traditional</option>
                                   <option value="industrial">Industrial</option>
                                   <option value="scandinavian">Scandinavian</option>
+                                  <option value="bohemian">Bohemian</option>
+                                  <option value="farmhouse">Farmhouse</option>
+                                  <option value="mid_century">Mid-Century Modern</option>
+                                  <option value="coastal">Coastal</option>
+                                  <option value="japandi">Japandi</option>
                                 </select>
                                 {fileData.styles && fileData.styles.length > 0 ? (
                                   <div className="mt-1 flex flex-wrap gap-1">
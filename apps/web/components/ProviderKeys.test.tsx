@@ -0,0 +1,126 @@
+import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest'
+import React from 'react'
+import { createRoot } from 'react-dom/client'
+import { act } from 'react-dom/test-utils'
+
+// Mock api client
+const apiFetchMock = vi.fn()
+vi.mock('@/lib/api', () => ({
+  apiFetch: (...args: unknown[]) => apiFetchMock(...args),
+}))
+
+import ProviderKeys from './ProviderKeys'
+
+function render(ui: React.ReactElement) {
+  const container = document.createElement('div')
+  document.body.appendChild(container)
+  const root = createRoot(container)
+  act(() => {
+    root.render(ui)
+  })
+  return { container, root }
+}
+
+async function flush() {
+  await act(async () => {
+    await Promise.resolve()
+  })
+}
+
+function status(container: HTMLElement) {
+  return container.querySelector('[data-testid="openai-key-status"]')?.textContent
+}
+
+function button(container: HTMLElement, label: string) {
+  return Array.from(container.querySelectorAll('button')).find((b) => b.textContent?.includes(label))
+}
+
+const stored = { provider: 'openai', hint: 'x7Qa', created_at: '', updated_at: '' }
+
+describe('ProviderKeys', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+  afterEach(() => {
+    document.body.innerHTML = ''
+  })
+
+  it('success: shows the stored key hint', async () => {
+    apiFetchMock.mockResolvedValueOnce({ credentials: [stored] })
+
+    const { container } = render(<ProviderKeys />)
+    await flush()
+
+    expect(apiFetchMock).toHaveBeenCalledWith('/v1/user/credentials')
+    expect(status(container)).toBe('Key ending in x7Qa')
+    expect(button(container, 'Remove')).toBeTruthy()
+  })
+
+  it('success: saves a key and clears the input', async () => {
+    apiFetchMock
+      .mockResolvedValueOnce({ credentials: [] })
+      .mockResolvedValueOnce(stored)
+
+    const { container } = render(<ProviderKeys />)
+    await flush()
+    expect(status(container)).toBe('No key stored')
+
+    const input = container.querySelector('input[type="password"]') as HTMLInputElement
+    await act(async () => {
+      const setValue = Object.getOwnPropertyDescriptor(HTMLInputElement.prototype, 'value')?.set
+      setValue?.call(input, 'sk-proj-abcdefghijklmnopx7Qa')
+      input.dispatchEvent(new Event('input', { bubbles: true }))
+    })
+    await act(async () => {
+      button(container, 'Save Key')?.click()
+    })
+    await flush()
+
+    expect(apiFetchMock).toHaveBeenLastCalledWith('/v1/user/credentials', {
+      method: 'POST',
+      body: JSON.stringify({ provider: 'openai', api_key: 'sk-proj-abcdefghijklmnopx7Qa' }),
+    })
+    expect(status(container)).toBe('Key ending in x7Qa')
+    expect(input.value).toBe('')
+  })
+
+  it('success: removes the stored key', async () => {
+    apiFetchMock
+      .mockResolvedValueOnce({ credentials: [stored] })
+      .mockResolvedValueOnce('')
+
+    const { container } = render(<ProviderKeys />)
+    await flush()
+    await act(async () => {
+      button(container, 'Remove')?.click()
+    })
+    await flush()
+
+    expect(apiFetchMock).toHaveBeenLastCalledWith('/v1/user/credentials/openai', { method: 'DELETE' })
+    expect(status(container)).toBe('No key stored')
+  })
+
+  it('fail: shows the problem detail when a key is rejected', async () => {
+    apiFetchMock
+      .mockResolvedValueOnce({ credentials: [] })
+      .mockRejectedValueOnce(
+        new Error('Request failed 422: {"code":"validation_failed","detail":"invalid credential: OpenAI API keys start with sk-"}'),
+      )
+
+    const { container } = render(<ProviderKeys />)
+    await flush()
+
+    const input = container.querySelector('input[type="password"]') as HTMLInputElement
+    await act(async () => {
+      const setValue = Object.getOwnPropertyDescriptor(HTMLInputElement.prototype, 'value')?.set
+      setValue?.call(input, 'r8_abcdefghijklmnopqrst')
+      input.dispatchEvent(new Event('input', { bubbles: true }))
+    })
+    await act(async () => {
+      button(container, 'Save Key')?.click()
+    })
+    await flush()
+
+    expect(container.textContent).toContain('OpenAI API keys start with sk-')
+  })
+})
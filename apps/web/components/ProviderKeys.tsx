@@ -0,0 +1,143 @@
+'use client';
+
+import { useCallback, useEffect, useState } from 'react';
+import { KeyRound, Loader2, Trash2 } from 'lucide-react';
+import { apiFetch } from '@/lib/api';
+
+interface Credential {
+  provider: string;
+  hint: string;
+  created_at: string;
+  updated_at: string;
+}
+
+/**
+ * Lets the user store their own OpenAI API key, so GPT Image jobs run on
+ * their OpenAI account. A stored key is never shown again, only its last
+ * four characters.
+ */
+export default function ProviderKeys() {
+  const [openAI, setOpenAI] = useState<Credential | null>(null);
+  const [apiKey, setApiKey] = useState('');
+  const [loading, setLoading] = useState(true);
+  const [busy, setBusy] = useState(false);
+  const [error, setError] = useState<string | null>(null);
+
+  const load = useCallback(async () => {
+    try {
+      const data = await apiFetch<{ credentials: Credential[] }>('/v1/user/credentials');
+      setOpenAI(data.credentials.find((c) => c.provider === 'openai') ?? null);
+    } catch {
+      setError('Failed to load your API keys');
+    } finally {
+      setLoading(false);
+    }
+  }, []);
+
+  useEffect(() => {
+    load();
+  }, [load]);
+
+  const save = async () => {
+    setBusy(true);
+    setError(null);
+    try {
+      const saved = await apiFetch<Credential>('/v1/user/credentials', {
+        method: 'POST',
+        body: JSON.stringify({ provider: 'openai', api_key: apiKey }),
+      });
+      setOpenAI(saved);
+      setApiKey('');
+    } catch (err) {
+      setError(problemDetail(err) ?? 'Failed to save your API key');
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const remove = async () => {
+    setBusy(true);
+    setError(null);
+    try {
+      await apiFetch('/v1/user/credentials/openai', { method: 'DELETE' });
+      setOpenAI(null);
+    } catch (err) {
+      setError(problemDetail(err) ?? 'Failed to remove your API key');
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  return (
+    <div className="card">
+      <div className="card-header">
+        <div className="flex items-center gap-2">
+          <KeyRound className="h-5 w-5 text-blue-600" />
+          <h2 className="text-xl font-semibold">API Keys</h2>
+        </div>
+        <p className="text-sm text-gray-600 dark:text-gray-400 mt-1">
+          Use your own OpenAI account for GPT Image staging. Your key is encrypted and never shown again.
+        </p>
+      </div>
+      <div className="card-body space-y-4">
+        {loading ? (
+          <Loader2 className="h-5 w-5 animate-spin text-blue-600" />
+        ) : (
+          <>
+            <div className="flex items-center justify-between p-3 border border-gray-200 dark:border-gray-800 rounded-lg">
+              <div>
+                <p className="font-medium">OpenAI</p>
+                <p className="text-sm text-gray-500" data-testid="openai-key-status">
+                  {openAI ? `Key ending in ${openAI.hint}` : 'No key stored'}
+                </p>
+              </div>
+              {openAI && (
+                <button
+                  onClick={remove}
+                  disabled={busy}
+                  className="btn btn-secondary flex items-center gap-2"
+                >
+                  <Trash2 className="h-4 w-4" />
+                  Remove
+                </button>
+              )}
+            </div>
+
+            <div className="flex flex-col sm:flex-row gap-2">
+              <input
+                type="password"
+                autoComplete="off"
+                value={apiKey}
+                onChange={(e) => setApiKey(e.target.value)}
+                placeholder={openAI ? 'Replace with a new key' : 'sk-...'}
+                className="flex-1 px-4 py-3 sm:py-2 border border-gray-300 dark:border-gray-700 rounded-lg focus:ring-2 focus:ring-blue-500 focus:border-transparent bg-white dark:bg-slate-900 text-base"
+              />
+              <button
+                onClick={save}
+                disabled={busy || apiKey.trim() === ''}
+                className="btn btn-primary flex items-center justify-center gap-2"
+              >
+                {busy && <Loader2 className="h-4 w-4 animate-spin" />}
+                Save Key
+              </button>
+            </div>
+          </>
+        )}
+
+        {error && <p className="text-sm text-red-600 dark:text-red-400">{error}</p>}
+      </div>
+    </div>
+  );
+}
+
+/** problemDetail returns the detail of the problem an apiFetch error carries. */
+function problemDetail(err: unknown): string | null {
+  if (!(err instanceof Error)) return null;
+  const body = err.message.replace(/^Request failed \d+: /, '');
+  try {
+    const parsed = JSON.parse(body) as { detail?: string };
+    return parsed.detail || null;
+  } catch {
+    return null;
+  }
+}
@@ -33,6 +33,7 @@ interface ModelConfigField {
   min?: number;
   max?: number;
   required: boolean;
+  secret?: boolean;
 }
 
 interface ModelConfigSchema {
@@ -64,11 +65,13 @@ export function ModelConfigDialog({
   const [loading, setLoading] = useState(true);
   const [saving, setSaving] = useState(false);
   const [error, setError] = useState<string | null>(null);
+  const [fieldErrors, setFieldErrors] = useState<Record<string, string>>({});
 
   const fetchSchemaAndConfig = async () => {
     try {
       setLoading(true);
       setError(null);
+      setFieldErrors({});
 
       // Fetch schema and current config in parallel
       const [schemaData, configData] = await Promise.all([
@@ -108,6 +111,7 @@ export function ModelConfigDialog({
     try {
       setSaving(true);
       setError(null);
+      setFieldErrors({});
 
       await apiFetch(`/v1/admin/models/${encodeURIComponent(modelId)}/config`, {
         method: "PUT",
@@ -117,7 +121,13 @@ export function ModelConfigDialog({
       onSuccess();
       onOpenChange(false);
     } catch (err) {
-      setError(err instanceof Error ? err.message : "Failed to save configuration");
+      const invalid = parseValidationErrors(err);
+      if (invalid) {
+        // Keep the form open and point at the fields the API rejected.
+        setFieldErrors(invalid);
+      } else {
+        setError(err instanceof Error ? err.message : "Failed to save configuration");
+      }
     } finally {
       setSaving(false);
     }
@@ -182,7 +192,8 @@ export function ModelConfigDialog({
             <Label htmlFor={field.name}>{formatFieldName(field.name)}</Label>
             <Input
               id={field.name}
-              type="text"
+              type={field.secret ? "password" : "text"}
+              autoComplete={field.secret ? "off" : undefined}
               value={stringValue}
               onChange={(e: React.ChangeEvent<HTMLInputElement>) => updateConfigValue(field.name, e.target.value)}
             />
@@ -271,7 +282,16 @@ export function ModelConfigDialog({
           </Alert>
         ) : schema ? (
           <div className="space-y-6 py-4">
-            {schema.fields.map((field) => renderField(field))}
+            {schema.fields.map((field) => (
+              <div key={field.name}>
+                {renderField(field)}
+                {fieldErrors[field.name] && (
+                  <p className="mt-1 text-sm text-red-600 dark:text-red-400">
+                    {fieldErrors[field.name]}
+                  </p>
+                )}
+              </div>
+            ))}
           </div>
         ) : null}
 
@@ -302,6 +322,24 @@ export function ModelConfigDialog({
   );
 }
 
+/**
+ * Returns the per-field messages of a validation_failed response, or null if
+ * err isn't one. apiFetch puts the response body in the error message.
+ */
+function parseValidationErrors(err: unknown): Record<string, string> | null {
+  if (!(err instanceof Error)) return null;
+  const body = err.message.replace(/^Request failed \d+: /, "");
+  try {
+    const parsed = JSON.parse(body) as {
+      validation_errors?: { field: string; message: string }[];
+    };
+    if (!parsed.validation_errors?.length) return null;
+    return Object.fromEntries(parsed.validation_errors.map((e) => [e.field, e.message]));
+  } catch {
+    return null;
+  }
+}
+
 function formatFieldName(name: string): string {
   return name
     .split("_")
@@ -1,5 +1,5 @@
 import { describe, it, expect, vi, beforeEach, afterEach } from 'vitest';
-import { apiFetch } from './api';
+import { apiFetch, apiFetchAll, openEventSource } from './api';
 
 // Mock global fetch
 const mockFetch = vi.fn();
@@ -392,3 +392,72 @@ describe('apiFetch', () => {
     });
   });
 });
+
+describe('apiFetchAll', () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  const page = (body: unknown) => ({
+    ok: true,
+    json: async () => body,
+    headers: new Headers({ 'content-type': 'application/json' }),
+  } as Response);
+  const noToken = { ok: false } as Response;
+
+  it('success: follows next_cursor until the last page', async () => {
+    mockFetch
+      .mockResolvedValueOnce(noToken)
+      .mockResolvedValueOnce(page({ projects: [{ id: 'p1' }], next_cursor: 'c 1' }))
+      .mockResolvedValueOnce(noToken)
+      .mockResolvedValueOnce(page({ projects: [{ id: 'p2' }], next_cursor: null }));
+
+    const result = await apiFetchAll<{ id: string }>('/v1/projects?limit=100', 'projects');
+
+    expect(result).toEqual([{ id: 'p1' }, { id: 'p2' }]);
+    expect(mockFetch.mock.calls[1][0]).toBe('/api/v1/projects?limit=100');
+    expect(mockFetch.mock.calls[3][0]).toBe('/api/v1/projects?limit=100&cursor=c%201');
+  });
+
+  it('success: treats a missing key as an empty page', async () => {
+    mockFetch.mockResolvedValueOnce(noToken).mockResolvedValueOnce(page({}));
+
+    expect(await apiFetchAll('/v1/projects', 'projects')).toEqual([]);
+  });
+});
+
+describe('openEventSource', () => {
+  class FakeEventSource {
+    constructor(public url: string) {}
+  }
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    vi.stubGlobal('EventSource', FakeEventSource);
+  });
+
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it('success: passes the access token as a query parameter', async () => {
+    mockFetch.mockResolvedValueOnce({
+      ok: true,
+      json: async () => ({ token: 'stream-token' }),
+    } as Response);
+
+    const source = (await openEventSource('/v1/billing/events')) as unknown as FakeEventSource;
+
+    const url = new URL(source.url);
+    expect(url.pathname).toBe('/api/v1/billing/events');
+    expect(url.searchParams.get('access_token')).toBe('stream-token');
+  });
+
+  it('success: opens the stream without a token', async () => {
+    mockFetch.mockResolvedValueOnce({ ok: false } as Response);
+
+    const source = (await openEventSource('/v1/billing/events')) as unknown as FakeEventSource;
+
+    expect(new URL(source.url).searchParams.has('access_token')).toBe(false);
+  });
+});
@@ -57,3 +57,36 @@ export async function apiFetch<T = Json>(path: string, options: RequestInit = {}
   }
   return (await res.text()) as T
 }
+
+/**
+ * Fetch every page of a cursor-paged list endpoint and concatenate the
+ * arrays found under key. Pages are followed through next_cursor.
+ */
+export async function apiFetchAll<T>(path: string, key: string): Promise<T[]> {
+  const items: T[] = []
+  let cursor: string | null | undefined
+  do {
+    const sep = path.includes('?') ? '&' : '?'
+    const pagePath = cursor ? `${path}${sep}cursor=${encodeURIComponent(cursor)}` : path
+    const page = await apiFetch<Record<string, unknown>>(pagePath)
+    items.push(...((page[key] as T[] | undefined) ?? []))
+    cursor = page.next_cursor as string | null | undefined
+  } while (cursor)
+  return items
+}
+
+/**
+ * Open a Server-Sent Events stream on the backend API.
+ * EventSource cannot set headers, so the access token travels as the
+ * access_token query parameter. Returns null outside the browser.
+ */
+export async function openEventSource(path: string): Promise<EventSource | null> {
+  if (typeof window === 'undefined' || typeof EventSource === 'undefined') return null
+
+  const url = new URL(`${API_BASE}${path}`, window.location.origin)
+  const token = await getAccessToken()
+  if (token) {
+    url.searchParams.set('access_token', token)
+  }
+  return new EventSource(url.toString())
+}
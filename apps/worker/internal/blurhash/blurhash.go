@@ -0,0 +1,162 @@
+// Package blurhash encodes images as BlurHash strings: a few dozen characters
+// describing a blurred version of the image that clients can decode into a
+// placeholder while the full image loads. See https://blurha.sh.
+package blurhash
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+)
+
+// Default component counts. Four by three suits the landscape room photos
+// staging produces and keeps the hash at 28 characters.
+const (
+	DefaultXComponents = 4
+	DefaultYComponents = 3
+)
+
+// sampleSize bounds the longer side of the grid the hash is computed from.
+// The hash only keeps a handful of low frequencies, so sampling more pixels
+// costs time without changing the result in any visible way.
+const sampleSize = 64
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// Encode returns the BlurHash of img using xComponents by yComponents cosine
+// components, each between 1 and 9.
+func Encode(img image.Image, xComponents, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", fmt.Errorf("blurhash: components must be between 1 and 9, got %dx%d", xComponents, yComponents)
+	}
+	b := img.Bounds()
+	if b.Empty() {
+		return "", fmt.Errorf("blurhash: empty image")
+	}
+
+	w, h, pixels := sample(img)
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			factors = append(factors, basisFactor(pixels, w, h, i, j))
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(encode83((xComponents-1)+(yComponents-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maxValue := 1.0
+	if len(ac) > 0 {
+		var actualMax float64
+		for _, f := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maxValue = float64(quantisedMax+1) / 166
+		sb.WriteString(encode83(quantisedMax, 1))
+	} else {
+		sb.WriteString(encode83(0, 1))
+	}
+
+	sb.WriteString(encode83(encodeDC(dc), 4))
+	for _, f := range ac {
+		sb.WriteString(encode83(encodeAC(f, maxValue), 2))
+	}
+	return sb.String(), nil
+}
+
+// sample averages img down to a grid no larger than sampleSize on its longer
+// side and returns the grid's linear RGB values, row by row.
+func sample(img image.Image) (int, int, [][3]float64) {
+	b := img.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	w, h := sw, sh
+	if longest := max(sw, sh); longest > sampleSize {
+		w = max(1, sw*sampleSize/longest)
+		h = max(1, sh*sampleSize/longest)
+	}
+
+	pixels := make([][3]float64, w*h)
+	for y := 0; y < h; y++ {
+		y0, y1 := b.Min.Y+y*sh/h, b.Min.Y+(y+1)*sh/h
+		for x := 0; x < w; x++ {
+			x0, x1 := b.Min.X+x*sw/w, b.Min.X+(x+1)*sw/w
+			var r, g, bl, n float64
+			for py := y0; py < y1; py++ {
+				for px := x0; px < x1; px++ {
+					cr, cg, cb, _ := img.At(px, py).RGBA()
+					r += sRGBToLinear(cr >> 8)
+					g += sRGBToLinear(cg >> 8)
+					bl += sRGBToLinear(cb >> 8)
+					n++
+				}
+			}
+			pixels[y*w+x] = [3]float64{r / n, g / n, bl / n}
+		}
+	}
+	return w, h, pixels
+}
+
+// basisFactor projects the pixels onto the (i, j) cosine basis function.
+func basisFactor(pixels [][3]float64, w, h, i, j int) [3]float64 {
+	normalisation := 2.0
+	if i == 0 && j == 0 {
+		normalisation = 1
+	}
+	var r, g, b float64
+	for y := 0; y < h; y++ {
+		cy := math.Cos(math.Pi * float64(j) * float64(y) / float64(h))
+		for x := 0; x < w; x++ {
+			basis := normalisation * math.Cos(math.Pi*float64(i)*float64(x)/float64(w)) * cy
+			p := pixels[y*w+x]
+			r += basis * p[0]
+			g += basis * p[1]
+			b += basis * p[2]
+		}
+	}
+	scale := 1 / float64(w*h)
+	return [3]float64{r * scale, g * scale, b * scale}
+}
+
+func encodeDC(c [3]float64) int {
+	return linearToSRGB(c[0])<<16 + linearToSRGB(c[1])<<8 + linearToSRGB(c[2])
+}
+
+func encodeAC(c [3]float64, maxValue float64) int {
+	quant := func(v float64) int {
+		return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maxValue, 0.5)*9+9.5))))
+	}
+	return quant(c[0])*19*19 + quant(c[1])*19 + quant(c[2])
+}
+
+func encode83(value, length int) string {
+	out := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		out[i] = base83Chars[value%83]
+		value /= 83
+	}
+	return string(out)
+}
+
+func sRGBToLinear(v uint32) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
@@ -0,0 +1,82 @@
+package blurhash
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode83(s string) int {
+	v := 0
+	for _, c := range s {
+		v = v*83 + strings.IndexRune(base83Chars, c)
+	}
+	return v
+}
+
+func solid(w, h int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func mustEncode(t *testing.T, img image.Image) string {
+	t.Helper()
+	hash, err := Encode(img, 4, 3)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("success: solid image keeps its colour in the DC component", func(t *testing.T) {
+		hash, err := Encode(solid(300, 200, color.RGBA{R: 200, G: 100, B: 50, A: 255}), 4, 3)
+		require.NoError(t, err)
+
+		require.Len(t, hash, 28)
+		assert.Equal(t, "L", hash[:1], "size flag for 4x3")
+		dc := decode83(hash[2:6])
+		assert.InDelta(t, 200, dc>>16, 1)
+		assert.InDelta(t, 100, (dc>>8)&0xFF, 1)
+		assert.InDelta(t, 50, dc&0xFF, 1)
+	})
+
+	t.Run("success: horizontal split shows up in the first AC component", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 400, 300))
+		draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+		draw.Draw(img, image.Rect(200, 0, 400, 300), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+		hash, err := Encode(img, 4, 3)
+		require.NoError(t, err)
+		require.Len(t, hash, 28)
+
+		// Brighter on the right means a strongly negative first horizontal cosine term.
+		first := decode83(hash[6:8])
+		assert.Less(t, first/(19*19), 3)
+		assert.Equal(t, hash, mustEncode(t, img), "encoding is deterministic")
+	})
+
+	t.Run("success: offset bounds and tiny images", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(10, 10, 11, 11))
+		img.Set(10, 10, color.White)
+		hash, err := Encode(img, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "00"+encode83(0xFFFFFF, 4), hash)
+	})
+
+	t.Run("fail: components out of range", func(t *testing.T) {
+		_, err := Encode(solid(10, 10, color.White), 0, 3)
+		assert.Error(t, err)
+		_, err = Encode(solid(10, 10, color.White), 4, 10)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: empty image", func(t *testing.T) {
+		_, err := Encode(image.NewRGBA(image.Rectangle{}), 4, 3)
+		assert.Error(t, err)
+	})
+}
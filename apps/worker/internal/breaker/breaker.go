@@ -0,0 +1,158 @@
+// Package breaker keeps a circuit breaker per staging model so an outage at
+// the model's provider pauses its stage jobs instead of failing every one.
+package breaker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// Outage is a model whose circuit is open.
+type Outage struct {
+	Model model.ID
+	// Since is when the circuit opened.
+	Since time.Time
+	// RetryAt is when the next probe run is let through.
+	RetryAt time.Time
+}
+
+// Options configures a Breaker.
+type Options struct {
+	// FailureThreshold is how many runs in a row must fail to open a circuit.
+	FailureThreshold int
+	// Cooldown is how long an open circuit waits before a probe run.
+	Cooldown time.Duration
+	// MaxCooldown caps the wait, which doubles after each failed probe.
+	MaxCooldown time.Duration
+	// OnChange, when set, is called with every outage after a circuit opens,
+	// closes or moves its next probe. Calls are serialized and each one sees
+	// the state after its change.
+	OnChange func(outages []Outage)
+}
+
+type circuit struct {
+	failures int
+	since    time.Time
+	retryAt  time.Time // zero while closed
+	cooldown time.Duration
+	probing  bool
+}
+
+// Breaker tracks consecutive staging failures per model. It is safe for
+// concurrent use.
+type Breaker struct {
+	opts Options
+	now  func() time.Time
+
+	mu       sync.Mutex
+	circuits map[model.ID]*circuit
+
+	notifyMu sync.Mutex
+}
+
+// New creates a Breaker with every circuit closed.
+func New(opts Options) *Breaker {
+	if opts.MaxCooldown < opts.Cooldown {
+		opts.MaxCooldown = opts.Cooldown
+	}
+	return &Breaker{opts: opts, now: time.Now, circuits: map[model.ID]*circuit{}}
+}
+
+// Allow reports whether a run of m may start. While m's circuit is open it
+// returns false and when to try again. Once the cooldown is over a single probe
+// is let through; other runs wait another cooldown for its result.
+func (b *Breaker) Allow(m model.ID) (time.Time, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[m]
+	if c == nil || c.retryAt.IsZero() {
+		return time.Time{}, true
+	}
+	now := b.now()
+	if now.Before(c.retryAt) {
+		return c.retryAt, false
+	}
+	c.retryAt = now.Add(c.cooldown)
+	c.probing = true
+	return time.Time{}, true
+}
+
+// Success records a run of m that worked, closing its circuit.
+func (b *Breaker) Success(m model.ID) {
+	b.mu.Lock()
+	c := b.circuits[m]
+	if c == nil {
+		b.mu.Unlock()
+		return
+	}
+	delete(b.circuits, m)
+	wasOpen := !c.retryAt.IsZero()
+	b.mu.Unlock()
+
+	if wasOpen {
+		b.notify()
+	}
+}
+
+// Failure records a run of m that failed. It opens the circuit once
+// FailureThreshold runs have failed in a row, and doubles the cooldown when a
+// probe fails. Runs that were already under way when the circuit opened don't
+// count against it.
+func (b *Breaker) Failure(m model.ID) {
+	b.mu.Lock()
+	c := b.circuits[m]
+	if c == nil {
+		c = &circuit{}
+		b.circuits[m] = c
+	}
+	now := b.now()
+	changed := false
+	switch {
+	case c.retryAt.IsZero():
+		c.failures++
+		if c.failures >= b.opts.FailureThreshold {
+			c.since, c.cooldown = now, b.opts.Cooldown
+			c.retryAt = now.Add(c.cooldown)
+			changed = true
+		}
+	case c.probing:
+		c.probing = false
+		c.cooldown = min(2*c.cooldown, b.opts.MaxCooldown)
+		c.retryAt = now.Add(c.cooldown)
+		changed = true
+	}
+	b.mu.Unlock()
+
+	if changed {
+		b.notify()
+	}
+}
+
+// Outages returns the models whose circuit is open, by model ID.
+func (b *Breaker) Outages() []Outage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var outages []Outage
+	for m, c := range b.circuits {
+		if !c.retryAt.IsZero() {
+			outages = append(outages, Outage{Model: m, Since: c.since, RetryAt: c.retryAt})
+		}
+	}
+	sort.Slice(outages, func(i, j int) bool { return outages[i].Model < outages[j].Model })
+	return outages
+}
+
+// notify reports the current outages to OnChange.
+func (b *Breaker) notify() {
+	if b.opts.OnChange == nil {
+		return
+	}
+	b.notifyMu.Lock()
+	defer b.notifyMu.Unlock()
+	b.opts.OnChange(b.Outages())
+}
@@ -0,0 +1,104 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+const testModel = model.ID("test/model")
+
+func newTestBreaker(t *testing.T) (*Breaker, *time.Time, *[][]Outage) {
+	t.Helper()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var changes [][]Outage
+	b := New(Options{
+		FailureThreshold: 3,
+		Cooldown:         time.Minute,
+		MaxCooldown:      3 * time.Minute,
+		OnChange:         func(o []Outage) { changes = append(changes, o) },
+	})
+	b.now = func() time.Time { return now }
+	return b, &now, &changes
+}
+
+func TestBreaker(t *testing.T) {
+	t.Run("success: opens after consecutive failures", func(t *testing.T) {
+		b, now, changes := newTestBreaker(t)
+
+		b.Failure(testModel)
+		b.Failure(testModel)
+		_, ok := b.Allow(testModel)
+		assert.True(t, ok)
+		assert.Empty(t, *changes)
+
+		b.Failure(testModel)
+		retryAt, ok := b.Allow(testModel)
+		assert.False(t, ok)
+		assert.Equal(t, now.Add(time.Minute), retryAt)
+		require.Len(t, *changes, 1)
+		assert.Equal(t, []Outage{{Model: testModel, Since: *now, RetryAt: retryAt}}, (*changes)[0])
+
+		_, ok = b.Allow("other/model")
+		assert.True(t, ok)
+	})
+
+	t.Run("success: a success resets the count", func(t *testing.T) {
+		b, _, changes := newTestBreaker(t)
+
+		b.Failure(testModel)
+		b.Failure(testModel)
+		b.Success(testModel)
+		b.Failure(testModel)
+
+		_, ok := b.Allow(testModel)
+		assert.True(t, ok)
+		assert.Empty(t, *changes)
+	})
+
+	t.Run("success: a probe closes the circuit", func(t *testing.T) {
+		b, now, changes := newTestBreaker(t)
+		for range 3 {
+			b.Failure(testModel)
+		}
+
+		*now = now.Add(time.Minute)
+		_, ok := b.Allow(testModel)
+		assert.True(t, ok, "probe")
+		_, ok = b.Allow(testModel)
+		assert.False(t, ok, "only one probe at a time")
+
+		b.Success(testModel)
+		_, ok = b.Allow(testModel)
+		assert.True(t, ok)
+		require.Len(t, *changes, 2)
+		assert.Empty(t, (*changes)[1])
+		assert.Empty(t, b.Outages())
+	})
+
+	t.Run("success: failed probes back off up to the max", func(t *testing.T) {
+		b, now, changes := newTestBreaker(t)
+		for range 3 {
+			b.Failure(testModel)
+		}
+		// In-flight runs that fail after the circuit opened don't extend it.
+		b.Failure(testModel)
+		require.Len(t, *changes, 1)
+
+		for _, wait := range []time.Duration{2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+			*now = b.Outages()[0].RetryAt
+			_, ok := b.Allow(testModel)
+			require.True(t, ok)
+			b.Failure(testModel)
+
+			retryAt, ok := b.Allow(testModel)
+			assert.False(t, ok)
+			assert.Equal(t, now.Add(wait), retryAt)
+		}
+		assert.Len(t, *changes, 4)
+	})
+}
@@ -6,26 +6,48 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+
+	"github.com/real-staging-ai/api/encryption"
 )
 
 // Config represents the application configuration.
 type Config struct {
-	App       App       `yaml:"app"`
-	DB        DB        `yaml:"db"`
-	Job       Job       `yaml:"job"`
-	Logging   Logging   `yaml:"logging"`
-	OTEL      OTEL      `yaml:"otel"`
-	Redis     Redis     `yaml:"redis"`
-	Replicate Replicate `yaml:"replicate"`
-	S3        S3        `yaml:"s3"`
+	App        App        `yaml:"app"`
+	Breaker    Breaker    `yaml:"breaker"`
+	Cutout     Cutout     `yaml:"cutout"`
+	DB         DB         `yaml:"db"`
+	Email      Email      `yaml:"email"`
+	Encryption Encryption `yaml:"encryption"`
+	Fallback   Fallback   `yaml:"fallback"`
+	Job        Job        `yaml:"job"`
+	Logging    Logging    `yaml:"logging"`
+	Malware    Malware    `yaml:"malware"`
+	OTEL       OTEL       `yaml:"otel"`
+	OpenAI     OpenAI     `yaml:"openai"`
+	Original   Original   `yaml:"original"`
+	Prompt     Prompt     `yaml:"prompt"`
+	Quality    Quality    `yaml:"quality"`
+	Redis      Redis      `yaml:"redis"`
+	Replicate  Replicate  `yaml:"replicate"`
+	Room       Room       `yaml:"room"`
+	S3         S3         `yaml:"s3"`
+	Stability  Stability  `yaml:"stability"`
+	Webhook    Webhook    `yaml:"webhook"`
 }
 
 type App struct {
 	Env string `yaml:"env" env:"APP_ENV" env-default:"dev"`
 }
 
+// Cutout configures the cutout pipeline that turns staged furniture into
+// transparent-background PNGs.
+type Cutout struct {
+	ModelID string `yaml:"model_id" env:"CUTOUT_MODEL_ID" env-default:"meta/sam-2"`
+}
+
 type DB struct {
 	PGDatabase string `yaml:"pgdatabase" env:"PGDATABASE" env-default:"realstaging"`
 	PGHost     string `yaml:"pghost" env:"PGHOST" env-default:"localhost"`
@@ -35,19 +57,134 @@ type DB struct {
 	PGSSLMode  string `yaml:"pgsslmode" env:"PGSSLMODE" env-default:"disable"`
 }
 
+// Email configures SMTP for outbound email deliveries. When SMTPHost is empty,
+// email deliveries fail and stay visible in the delivery log.
+type Email struct {
+	From         string `yaml:"from" env:"EMAIL_FROM" env-default:"no-reply@realstaging.ai"`
+	SMTPHost     string `yaml:"smtp_host" env:"SMTP_HOST"`
+	SMTPPassword string `yaml:"smtp_password" env:"SMTP_PASSWORD"`
+	SMTPPort     int    `yaml:"smtp_port" env:"SMTP_PORT" env-default:"587"`
+	SMTPUsername string `yaml:"smtp_username" env:"SMTP_USERNAME"`
+}
+
+// Encryption holds the keys the API seals secret model config fields with,
+// such as BYO API keys. The worker opens them at job time; without the key,
+// jobs for models with a sealed secret fail.
+type Encryption struct {
+	Key          string   `yaml:"key" env:"ENCRYPTION_KEY"`
+	PreviousKeys []string `yaml:"previous_keys" env:"ENCRYPTION_KEY_PREVIOUS" env-separator:","`
+}
+
+// Keyring builds the keyring, or returns nil when no key is configured.
+func (e Encryption) Keyring() (*encryption.Keyring, error) {
+	if e.Key == "" {
+		return nil, nil
+	}
+	return encryption.NewKeyring(e.Key, e.PreviousKeys...)
+}
+
+// Breaker configures the per-model circuit breaker. After FailureThreshold
+// staging runs of a model fail in a row its circuit opens: stage jobs for the
+// model stay queued and new images are accepted as delayed. One run is let
+// through every CooldownSeconds to probe for recovery, waiting twice as long
+// after each failed probe up to MaxCooldownSeconds. A threshold of 0 disables it.
+type Breaker struct {
+	FailureThreshold   int `yaml:"failure_threshold" env:"PROVIDER_BREAKER_THRESHOLD" env-default:"5"`
+	CooldownSeconds    int `yaml:"cooldown_seconds" env:"PROVIDER_BREAKER_COOLDOWN_SECONDS" env-default:"60"`
+	MaxCooldownSeconds int `yaml:"max_cooldown_seconds" env:"PROVIDER_BREAKER_MAX_COOLDOWN_SECONDS" env-default:"900"`
+}
+
+// Fallback configures retrying a failed staging run with other models. When
+// the job's model fails or times out, the models in Chain are tried in order,
+// skipping the one that just failed, until one succeeds or MaxAttempts models
+// (the first included) have run. An empty Chain disables fallback.
+type Fallback struct {
+	Chain       []string `yaml:"chain" env:"MODEL_FALLBACK_CHAIN" env-separator:","`
+	MaxAttempts int      `yaml:"max_attempts" env:"MODEL_FALLBACK_MAX_ATTEMPTS" env-default:"2"`
+}
+
+// Job configures the job queue. Stage jobs send a heartbeat every
+// HeartbeatSeconds; a job without one for StallAfterSeconds is
+// treated as stalled and requeued by the check that runs every StallCheckSeconds.
+//
+// On SIGTERM the worker stops taking jobs and gives running ones up to
+// ShutdownTimeoutSeconds to finish; stage jobs still running then are requeued
+// to resume their prediction on another worker. Keep it a few seconds under
+// the orchestrator's grace period so the handoff isn't killed.
+//
+// Staging jobs wait in the critical, default (QueueName) or low queue by the
+// payer's plan. The weights set how often each queue is polled relative to
+// the others, deliveries included, so higher priorities are served first
+// without starving the rest.
 type Job struct {
-	QueueName         string `yaml:"queue_name" env:"JOB_QUEUE_NAME" env-default:"default"`
-	WorkerConcurrency int    `yaml:"worker_concurrency" env:"WORKER_CONCURRENCY" env-default:"5"`
+	QueueName              string `yaml:"queue_name" env:"JOB_QUEUE_NAME" env-default:"default"`
+	WorkerConcurrency      int    `yaml:"worker_concurrency" env:"WORKER_CONCURRENCY" env-default:"5"`
+	HeartbeatSeconds       int    `yaml:"heartbeat_seconds" env:"JOB_HEARTBEAT_SECONDS" env-default:"10"`
+	StallAfterSeconds      int    `yaml:"stall_after_seconds" env:"JOB_STALL_AFTER_SECONDS" env-default:"45"`
+	StallCheckSeconds      int    `yaml:"stall_check_seconds" env:"JOB_STALL_CHECK_SECONDS" env-default:"30"`
+	CriticalWeight         int    `yaml:"critical_weight" env:"JOB_QUEUE_CRITICAL_WEIGHT" env-default:"12"`
+	DefaultWeight          int    `yaml:"default_weight" env:"JOB_QUEUE_DEFAULT_WEIGHT" env-default:"6"`
+	LowWeight              int    `yaml:"low_weight" env:"JOB_QUEUE_LOW_WEIGHT" env-default:"3"`
+	ShutdownTimeoutSeconds int    `yaml:"shutdown_timeout_seconds" env:"WORKER_SHUTDOWN_TIMEOUT_SECONDS" env-default:"60"`
 }
 
 type Logging struct {
 	Level string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
 }
 
+// Malware configures scanning of uploaded originals before they are staged.
+// With Scanner "clamav" each original is streamed to the clamd at ClamAVAddr;
+// infected files are moved under QuarantinePrefix and their image is rejected.
+// An empty Scanner disables scanning.
+type Malware struct {
+	Scanner          string `yaml:"scanner" env:"MALWARE_SCANNER"`
+	ClamAVAddr       string `yaml:"clamav_addr" env:"CLAMAV_ADDR" env-default:"localhost:3310"`
+	QuarantinePrefix string `yaml:"quarantine_prefix" env:"MALWARE_QUARANTINE_PREFIX" env-default:"quarantine/"`
+	TimeoutSeconds   int    `yaml:"timeout_seconds" env:"MALWARE_SCAN_TIMEOUT_SECONDS" env-default:"60"`
+}
+
 type OTEL struct {
 	ExporterOTLPEndpoint string `yaml:"exporter_otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
 }
 
+// OpenAI configures the OpenAI Images API, which runs the GPT Image models.
+// Each request uses the OpenAI key in the model's config.
+type OpenAI struct {
+	BaseURL string `yaml:"base_url" env:"OPENAI_BASE_URL" env-default:"https://api.openai.com/v1"`
+}
+
+// Original limits the uploaded originals the worker will stage. Files over
+// either limit, in another format or that fail to decode are rejected before
+// staging. Zero disables a limit. Metadata is always stripped from originals;
+// PreserveMetadata keeps a copy of the EXIF (camera, capture time, GPS) on
+// the image row.
+type Original struct {
+	MaxBytes         int64 `yaml:"max_bytes" env:"ORIGINAL_MAX_BYTES" env-default:"10485760"`
+	MaxDimension     int   `yaml:"max_dimension" env:"ORIGINAL_MAX_DIMENSION" env-default:"8192"`
+	PreserveMetadata bool  `yaml:"preserve_metadata" env:"ORIGINAL_PRESERVE_METADATA"`
+}
+
+// Prompt configures how the worker reads admin prompt overrides and
+// experiments. Each is cached for CacheTTLSeconds, so an edit reaches every
+// worker within that time without a deploy. 0 reads them for every job.
+type Prompt struct {
+	CacheTTLSeconds int `yaml:"cache_ttl_seconds" env:"PROMPT_CACHE_TTL_SECONDS" env-default:"60"`
+}
+
+// Quality configures the checks run on each staged image before it is stored.
+// An image that fails one is staged once more with a new seed, then marked as
+// an error. MaxAspectDrift is the largest accepted relative difference between
+// the staged and original aspect ratios. Images whose luminance (0-255) varies
+// by at most BlankMaxStdDev, or averages at most BlackMaxLuma, count as blank.
+// RejectNSFW fails images the model's safety checker flagged. Zero disables a
+// check.
+type Quality struct {
+	MaxAspectDrift float64 `yaml:"max_aspect_drift" env:"QUALITY_MAX_ASPECT_DRIFT" env-default:"0.1"`
+	BlankMaxStdDev float64 `yaml:"blank_max_stddev" env:"QUALITY_BLANK_MAX_STDDEV" env-default:"2"`
+	BlackMaxLuma   float64 `yaml:"black_max_luma" env:"QUALITY_BLACK_MAX_LUMA" env-default:"8"`
+	RejectNSFW     bool    `yaml:"reject_nsfw" env:"QUALITY_REJECT_NSFW" env-default:"true"`
+}
+
 type Redis struct {
 	Host string `yaml:"host" env:"REDIS_HOST"`
 	Port string `yaml:"port" env:"REDIS_PORT" env-default:"6379"`
@@ -61,8 +198,36 @@ func (r Redis) Addr() string {
 	return r.Host + ":" + r.Port
 }
 
+// Replicate configures the Replicate client. When WebhookURL is set, predictions
+// report completion to the worker's callback server on WebhookAddr instead of being polled.
+// ModelTimeouts and PollIntervals override how long a model's predictions
+// are waited for and how often they are polled, keyed by model ID, e.g.
+// "bytedance/seedream-4:20m"; models not listed keep their own.
+//
+// ImageInput sets how photos reach Replicate models: "data_url" inlines them
+// in the request, "file" uploads them to Replicate's files API and
+// "presigned" passes a presigned S3 URL, which needs a bucket Replicate can
+// reach (S3_PUBLIC_ENDPOINT, if the worker's own endpoint is private).
 type Replicate struct {
-	APIToken string `yaml:"api_token" env:"REPLICATE_API_TOKEN"`
+	APIToken      string `yaml:"api_token" env:"REPLICATE_API_TOKEN"`
+	WebhookAddr   string `yaml:"webhook_addr" env:"REPLICATE_WEBHOOK_ADDR" env-default:":8080"`
+	WebhookSecret string `yaml:"webhook_secret" env:"REPLICATE_WEBHOOK_SECRET"`
+	// WebhookSecretPrevious is still accepted after WebhookSecret is rotated.
+	WebhookSecretPrevious string                   `yaml:"webhook_secret_previous" env:"REPLICATE_WEBHOOK_SECRET_PREVIOUS"`
+	WebhookURL            string                   `yaml:"webhook_url" env:"REPLICATE_WEBHOOK_URL"`
+	ModelTimeouts         map[string]time.Duration `yaml:"model_timeouts" env:"REPLICATE_MODEL_TIMEOUTS" env-separator:","`
+	PollIntervals         map[string]time.Duration `yaml:"poll_intervals" env:"REPLICATE_POLL_INTERVALS" env-separator:","`
+	ImageInput            string                   `yaml:"image_input" env:"REPLICATE_IMAGE_INPUT" env-default:"data_url"`
+}
+
+// Room configures room type detection for images uploaded without a room
+// type. The vision model DetectionModelID classifies the original before it
+// is staged; the detected type and the model's confidence are stored on the
+// image, and the prompt for that room is used when the confidence is at least
+// MinConfidence. An empty DetectionModelID disables detection.
+type Room struct {
+	DetectionModelID string  `yaml:"detection_model_id" env:"ROOM_DETECTION_MODEL_ID"`
+	MinConfidence    float64 `yaml:"min_confidence" env:"ROOM_DETECTION_MIN_CONFIDENCE" env-default:"0.6"`
 }
 
 type S3 struct {
@@ -75,6 +240,18 @@ type S3 struct {
 	UsePathStyle   bool   `yaml:"use_path_style" env:"S3_USE_PATH_STYLE"`
 }
 
+// Stability configures Stability AI, which runs the models registered with the
+// Stability provider. Without an API key, jobs for those models fail.
+type Stability struct {
+	APIKey  string `yaml:"api_key" env:"STABILITY_API_KEY"`
+	BaseURL string `yaml:"base_url" env:"STABILITY_BASE_URL" env-default:"https://api.stability.ai"`
+}
+
+// Webhook configures outbound webhook deliveries.
+type Webhook struct {
+	TimeoutSeconds int `yaml:"timeout_seconds" env:"WEBHOOK_TIMEOUT_SECONDS" env-default:"10"`
+}
+
 // Load loads configuration from YAML files based on APP_ENV.
 // It loads config/shared.yml first, then overlays config/{env}.yml,
 // then apps/worker/secrets.yml (if present).
@@ -124,6 +301,10 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read environment variables: %w", err)
 	}
 
+	if _, err := cfg.Encryption.Keyring(); err != nil {
+		return nil, fmt.Errorf("invalid encryption configuration: %w", err)
+	}
+
 	return cfg, nil
 }
 
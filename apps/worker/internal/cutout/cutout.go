@@ -0,0 +1,128 @@
+// Package cutout turns segmentation masks into transparent-background PNGs of
+// individual objects, cropped to each object's bounds.
+package cutout
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"sort"
+)
+
+// Options controls which masks become cut-outs.
+type Options struct {
+	// MinAreaFraction drops masks covering less of the image than this
+	// (specks, outlet covers, picture-frame corners).
+	MinAreaFraction float64
+	// MaxAreaFraction drops masks covering more of the image than this
+	// (walls, floors, ceilings).
+	MaxAreaFraction float64
+	// MaxPieces caps the number of cut-outs, keeping the largest.
+	MaxPieces int
+}
+
+// DefaultOptions suits furniture in a staged room photo.
+var DefaultOptions = Options{MinAreaFraction: 0.005, MaxAreaFraction: 0.4, MaxPieces: 12}
+
+// Piece is one object cut out of the source image.
+type Piece struct {
+	// Image holds the object's pixels with everything outside the mask transparent.
+	Image *image.NRGBA
+	// Bounds is where the piece sits in the source image.
+	Bounds image.Rectangle
+	// Area is the number of source pixels covered by the mask.
+	Area int
+}
+
+// Extract cuts the masked region out of src. The mask's luminance is used as
+// alpha, so soft mask edges stay soft; masks of a different size are scaled
+// to src with nearest-neighbour sampling. ok is false when the mask is empty.
+func Extract(src, mask image.Image) (piece Piece, ok bool) {
+	sb := src.Bounds()
+	mb := mask.Bounds()
+	if sb.Empty() || mb.Empty() {
+		return Piece{}, false
+	}
+
+	alpha := image.NewAlpha(image.Rect(0, 0, sb.Dx(), sb.Dy()))
+	box := image.Rectangle{}
+	area := 0
+	for y := 0; y < sb.Dy(); y++ {
+		my := mb.Min.Y + y*mb.Dy()/sb.Dy()
+		for x := 0; x < sb.Dx(); x++ {
+			mx := mb.Min.X + x*mb.Dx()/sb.Dx()
+			a := color.GrayModel.Convert(mask.At(mx, my)).(color.Gray).Y
+			if a == 0 {
+				continue
+			}
+			alpha.SetAlpha(x, y, color.Alpha{A: a})
+			box = box.Union(image.Rect(x, y, x+1, y+1))
+			area++
+		}
+	}
+	if area == 0 {
+		return Piece{}, false
+	}
+
+	out := image.NewNRGBA(image.Rect(0, 0, box.Dx(), box.Dy()))
+	for y := box.Min.Y; y < box.Max.Y; y++ {
+		for x := box.Min.X; x < box.Max.X; x++ {
+			a := alpha.AlphaAt(x, y).A
+			if a == 0 {
+				continue
+			}
+			c := color.NRGBAModel.Convert(src.At(sb.Min.X+x, sb.Min.Y+y)).(color.NRGBA)
+			c.A = uint8(uint16(c.A) * uint16(a) / 0xff)
+			out.SetNRGBA(x-box.Min.X, y-box.Min.Y, c)
+		}
+	}
+	return Piece{Image: out, Bounds: box, Area: area}, true
+}
+
+// Select extracts every mask and keeps the pieces within the size limits,
+// largest first.
+func Select(src image.Image, masks []image.Image, opts Options) []Piece {
+	total := src.Bounds().Dx() * src.Bounds().Dy()
+	if total == 0 {
+		return nil
+	}
+
+	var pieces []Piece
+	for _, m := range masks {
+		p, ok := Extract(src, m)
+		if !ok {
+			continue
+		}
+		frac := float64(p.Area) / float64(total)
+		if frac < opts.MinAreaFraction || (opts.MaxAreaFraction > 0 && frac > opts.MaxAreaFraction) {
+			continue
+		}
+		pieces = append(pieces, p)
+	}
+
+	sort.SliceStable(pieces, func(i, j int) bool { return pieces[i].Area > pieces[j].Area })
+	if opts.MaxPieces > 0 && len(pieces) > opts.MaxPieces {
+		pieces = pieces[:opts.MaxPieces]
+	}
+	return pieces
+}
+
+// EncodePNG encodes a piece as a PNG, preserving transparency.
+func EncodePNG(p Piece) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, p); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WritePNG writes a piece to w as a PNG, preserving transparency.
+func WritePNG(w io.Writer, p Piece) error {
+	if err := png.Encode(w, p.Image); err != nil {
+		return fmt.Errorf("encode png: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cutout
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solid(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// rectMask returns a black mask of size w x h with r painted white.
+func rectMask(w, h int, r image.Rectangle) *image.Gray {
+	m := image.NewGray(image.Rect(0, 0, w, h))
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			m.SetGray(x, y, color.Gray{Y: 0xff})
+		}
+	}
+	return m
+}
+
+func TestExtract(t *testing.T) {
+	red := color.RGBA{R: 200, A: 255}
+
+	t.Run("success: crops to mask bounds with transparent background", func(t *testing.T) {
+		src := solid(100, 80, red)
+		mask := rectMask(100, 80, image.Rect(10, 20, 40, 60))
+
+		p, ok := Extract(src, mask)
+		require.True(t, ok)
+		assert.Equal(t, image.Rect(10, 20, 40, 60), p.Bounds)
+		assert.Equal(t, 30*40, p.Area)
+		assert.Equal(t, image.Rect(0, 0, 30, 40), p.Image.Bounds())
+		assert.Equal(t, color.NRGBA{R: 200, A: 255}, p.Image.NRGBAAt(0, 0))
+	})
+
+	t.Run("success: soft mask edges become partial alpha", func(t *testing.T) {
+		src := solid(4, 4, red)
+		mask := image.NewGray(image.Rect(0, 0, 4, 4))
+		mask.SetGray(1, 1, color.Gray{Y: 0x80})
+
+		p, ok := Extract(src, mask)
+		require.True(t, ok)
+		assert.Equal(t, uint8(0x80), p.Image.NRGBAAt(0, 0).A)
+	})
+
+	t.Run("success: smaller mask is scaled to the source", func(t *testing.T) {
+		src := solid(200, 100, red)
+		mask := rectMask(100, 50, image.Rect(50, 0, 100, 50))
+
+		p, ok := Extract(src, mask)
+		require.True(t, ok)
+		assert.Equal(t, image.Rect(100, 0, 200, 100), p.Bounds)
+	})
+
+	t.Run("fail: empty mask", func(t *testing.T) {
+		_, ok := Extract(solid(10, 10, red), image.NewGray(image.Rect(0, 0, 10, 10)))
+		assert.False(t, ok)
+	})
+}
+
+func TestSelect(t *testing.T) {
+	src := solid(100, 100, color.RGBA{G: 255, A: 255})
+	masks := []image.Image{
+		rectMask(100, 100, image.Rect(0, 0, 100, 60)),  // wall: 60%
+		rectMask(100, 100, image.Rect(0, 0, 2, 2)),     // speck: 0.04%
+		rectMask(100, 100, image.Rect(10, 10, 20, 20)), // 1%
+		rectMask(100, 100, image.Rect(50, 50, 80, 80)), // 9%
+		rectMask(100, 100, image.Rect(60, 10, 75, 25)), // 2.25%
+		image.NewGray(image.Rect(0, 0, 100, 100)),      // empty
+	}
+
+	t.Run("success: filters by area and sorts largest first", func(t *testing.T) {
+		pieces := Select(src, masks, DefaultOptions)
+		require.Len(t, pieces, 3)
+		assert.Equal(t, image.Rect(50, 50, 80, 80), pieces[0].Bounds)
+		assert.Equal(t, image.Rect(60, 10, 75, 25), pieces[1].Bounds)
+		assert.Equal(t, image.Rect(10, 10, 20, 20), pieces[2].Bounds)
+	})
+
+	t.Run("success: caps piece count", func(t *testing.T) {
+		opts := DefaultOptions
+		opts.MaxPieces = 1
+		pieces := Select(src, masks, opts)
+		require.Len(t, pieces, 1)
+		assert.Equal(t, image.Rect(50, 50, 80, 80), pieces[0].Bounds)
+	})
+}
+
+func TestEncodePNG(t *testing.T) {
+	p, ok := Extract(solid(10, 10, color.RGBA{B: 255, A: 255}), rectMask(10, 10, image.Rect(2, 2, 6, 6)))
+	require.True(t, ok)
+
+	data, err := EncodePNG(p)
+	require.NoError(t, err)
+	decoded, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 4), decoded.Bounds())
+}
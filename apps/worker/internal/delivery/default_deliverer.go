@@ -0,0 +1,90 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/repository"
+)
+
+// DefaultDeliverer routes outbox rows to the sender for their channel.
+type DefaultDeliverer struct {
+	repo    repository.DeliveryRepository
+	senders map[string]Sender
+}
+
+// Ensure DefaultDeliverer implements Deliverer.
+var _ Deliverer = (*DefaultDeliverer)(nil)
+
+// NewDefaultDeliverer creates a new DefaultDeliverer.
+func NewDefaultDeliverer(repo repository.DeliveryRepository, webhook, email Sender) *DefaultDeliverer {
+	return &DefaultDeliverer{
+		repo: repo,
+		senders: map[string]Sender{
+			ChannelWebhook: webhook,
+			ChannelEmail:   email,
+		},
+	}
+}
+
+// Deliver sends the outbox row identified by deliveryID and records the attempt.
+// Rows that are already delivered or failed are skipped so a redelivered task
+// never sends twice. A failed attempt returns an error so the queue retries it.
+func (d *DefaultDeliverer) Deliver(ctx context.Context, deliveryID string) error {
+	log := logging.Default()
+
+	row, err := d.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to load delivery: %w", err)
+	}
+	if row.Status == "delivered" || row.Status == "failed" {
+		log.Info(ctx, "delivery already finalized, skipping", "delivery_id", deliveryID, "status", row.Status)
+		return nil
+	}
+
+	sender, ok := d.senders[row.Channel]
+	if !ok || sender == nil {
+		return d.recordFailure(ctx, row, 0, fmt.Errorf("no sender for channel %q", row.Channel))
+	}
+
+	statusCode, sendErr := sender.Send(ctx, Message{
+		DeliveryID:  row.ID,
+		EventType:   row.EventType,
+		Destination: row.Destination,
+		Subject:     row.Subject,
+		Payload:     row.Payload,
+		Attempt:     row.Attempts + 1,
+		Secret:      row.Secret,
+	})
+	if sendErr != nil {
+		var se *SendError
+		if errors.As(sendErr, &se) {
+			statusCode = se.StatusCode
+		}
+		return d.recordFailure(ctx, row, statusCode, sendErr)
+	}
+
+	if err := d.repo.MarkDelivered(ctx, row.ID, statusCode); err != nil {
+		// The send succeeded; don't return an error or the queue would resend it.
+		log.Error(ctx, "failed to record delivery success", "delivery_id", row.ID, "error", err)
+		return nil
+	}
+	log.Info(ctx, "delivery sent", "delivery_id", row.ID, "channel", row.Channel, "status_code", statusCode)
+	return nil
+}
+
+func (d *DefaultDeliverer) recordFailure(
+	ctx context.Context, row *repository.Delivery, statusCode int, sendErr error,
+) error {
+	log := logging.Default()
+
+	status, err := d.repo.MarkAttemptFailed(ctx, row.ID, statusCode, sendErr.Error())
+	if err != nil {
+		log.Error(ctx, "failed to record delivery failure", "delivery_id", row.ID, "error", err)
+	}
+	log.Warn(ctx, "delivery attempt failed",
+		"delivery_id", row.ID, "channel", row.Channel, "attempt", row.Attempts+1, "status", status, "error", sendErr)
+	return fmt.Errorf("delivery %s attempt %d failed: %w", row.ID, row.Attempts+1, sendErr)
+}
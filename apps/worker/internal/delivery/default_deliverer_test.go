@@ -0,0 +1,105 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/repository"
+)
+
+type fakeDeliveryRepo struct {
+	row          *repository.Delivery
+	getErr       error
+	delivered    []int
+	failed       []string
+	failedStatus string
+}
+
+func (f *fakeDeliveryRepo) GetDelivery(ctx context.Context, id string) (*repository.Delivery, error) {
+	return f.row, f.getErr
+}
+
+func (f *fakeDeliveryRepo) MarkDelivered(ctx context.Context, id string, statusCode int) error {
+	f.delivered = append(f.delivered, statusCode)
+	return nil
+}
+
+func (f *fakeDeliveryRepo) MarkAttemptFailed(
+	ctx context.Context, id string, statusCode int, errorMsg string,
+) (string, error) {
+	f.failed = append(f.failed, errorMsg)
+	return f.failedStatus, nil
+}
+
+type fakeSender struct {
+	status int
+	err    error
+	sent   []Message
+}
+
+func (f *fakeSender) Send(ctx context.Context, msg Message) (int, error) {
+	f.sent = append(f.sent, msg)
+	return f.status, f.err
+}
+
+func TestDefaultDeliverer_Deliver(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: webhook delivered", func(t *testing.T) {
+		repo := &fakeDeliveryRepo{row: &repository.Delivery{
+			ID: "d-1", Channel: ChannelWebhook, Destination: "https://example.com", EventType: "image.ready",
+			Status: "pending", Attempts: 0, MaxAttempts: 8,
+		}}
+		webhook := &fakeSender{status: 204}
+		d := NewDefaultDeliverer(repo, webhook, &fakeSender{})
+
+		require.NoError(t, d.Deliver(ctx, "d-1"))
+		require.Len(t, webhook.sent, 1)
+		assert.Equal(t, 1, webhook.sent[0].Attempt)
+		assert.Equal(t, []int{204}, repo.delivered)
+	})
+
+	t.Run("success: finalized delivery is skipped", func(t *testing.T) {
+		repo := &fakeDeliveryRepo{row: &repository.Delivery{ID: "d-1", Channel: ChannelEmail, Status: "delivered"}}
+		email := &fakeSender{}
+		d := NewDefaultDeliverer(repo, &fakeSender{}, email)
+
+		require.NoError(t, d.Deliver(ctx, "d-1"))
+		assert.Empty(t, email.sent)
+	})
+
+	t.Run("fail: send error records attempt and returns error for retry", func(t *testing.T) {
+		repo := &fakeDeliveryRepo{
+			row:          &repository.Delivery{ID: "d-1", Channel: ChannelWebhook, Status: "retrying", Attempts: 2},
+			failedStatus: "retrying",
+		}
+		webhook := &fakeSender{status: 503, err: &SendError{StatusCode: 503, Err: errors.New("unavailable")}}
+		d := NewDefaultDeliverer(repo, webhook, &fakeSender{})
+
+		err := d.Deliver(ctx, "d-1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "attempt 3")
+		assert.Equal(t, []string{"status 503: unavailable"}, repo.failed)
+		assert.Empty(t, repo.delivered)
+	})
+
+	t.Run("fail: unknown channel", func(t *testing.T) {
+		repo := &fakeDeliveryRepo{row: &repository.Delivery{ID: "d-1", Channel: "sms", Status: "pending"}}
+		d := NewDefaultDeliverer(repo, &fakeSender{}, &fakeSender{})
+
+		require.Error(t, d.Deliver(ctx, "d-1"))
+		require.Len(t, repo.failed, 1)
+	})
+
+	t.Run("fail: load error", func(t *testing.T) {
+		repo := &fakeDeliveryRepo{getErr: repository.ErrDeliveryNotFound}
+		d := NewDefaultDeliverer(repo, &fakeSender{}, &fakeSender{})
+
+		err := d.Deliver(ctx, "d-1")
+		assert.ErrorIs(t, err, repository.ErrDeliveryNotFound)
+	})
+}
@@ -0,0 +1,58 @@
+// Package delivery sends outbound webhooks and emails recorded in the API's
+// outbox (outbound_deliveries) and records the outcome of every attempt.
+package delivery
+
+import (
+	"context"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out delivery_mock.go . Deliverer Sender
+
+// Channels match the outbound_deliveries.channel values written by the API.
+const (
+	ChannelWebhook = "webhook"
+	ChannelEmail   = "email"
+)
+
+// Message is a single outbound webhook or email to send.
+type Message struct {
+	DeliveryID  string
+	EventType   string
+	Destination string
+	Subject     string
+	Payload     []byte
+	Attempt     int
+	// Secret, when set, is used to sign webhook bodies (see SignatureHeader).
+	Secret string
+}
+
+// SendError describes a failed send. StatusCode is the HTTP status for
+// webhooks, or zero when no response was received.
+type SendError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *SendError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("status %d: %v", e.StatusCode, e.Err)
+	}
+	return e.Err.Error()
+}
+
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
+
+// Sender delivers a message over a single channel.
+type Sender interface {
+	// Send delivers the message and returns the HTTP status code, if any.
+	Send(ctx context.Context, msg Message) (int, error)
+}
+
+// Deliverer processes a delivery:send task.
+type Deliverer interface {
+	// Deliver sends the outbox row identified by deliveryID and records the attempt.
+	Deliver(ctx context.Context, deliveryID string) error
+}
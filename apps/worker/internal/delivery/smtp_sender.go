@@ -0,0 +1,86 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+// SMTPSender sends email deliveries through an SMTP relay.
+type SMTPSender struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Ensure SMTPSender implements Sender.
+var _ Sender = (*SMTPSender)(nil)
+
+// NewSMTPSender creates an SMTPSender from the email configuration.
+func NewSMTPSender(cfg config.Email) *SMTPSender {
+	return &SMTPSender{
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		host:     cfg.SMTPHost,
+		from:     cfg.From,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// emailPayload mirrors the body stored by the API for email deliveries.
+type emailPayload struct {
+	Body string `json:"body"`
+}
+
+// Send delivers the email. SMTP has no status code, so zero is returned.
+func (s *SMTPSender) Send(ctx context.Context, msg Message) (int, error) {
+	if s.host == "" {
+		return 0, &SendError{Err: errors.New("smtp is not configured (SMTP_HOST is empty)")}
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, &SendError{Err: err}
+	}
+
+	var p emailPayload
+	if err := json.Unmarshal(msg.Payload, &p); err != nil {
+		return 0, &SendError{Err: fmt.Errorf("decode email payload: %w", err)}
+	}
+
+	var auth smtp.Auth
+	if s.username != "" {
+		auth = smtp.PlainAuth("", s.username, s.password, s.host)
+	}
+	if err := s.sendMail(s.addr, auth, s.from, []string{msg.Destination}, s.compose(msg, p.Body)); err != nil {
+		return 0, &SendError{Err: err}
+	}
+	return 0, nil
+}
+
+func (s *SMTPSender) compose(msg Message, body string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.Destination)
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	fmt.Fprintf(&b, "X-RealStaging-Delivery: %s\r\n", msg.DeliveryID)
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return []byte(b.String())
+}
+
+// sanitizeHeader strips CR/LF so user-supplied subjects can't inject headers.
+func sanitizeHeader(v string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(v)
+}
@@ -0,0 +1,55 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+func TestSMTPSender_Send(t *testing.T) {
+	msg := Message{
+		DeliveryID:  "d-1",
+		Destination: "user@example.com",
+		Subject:     "Hello\r\nBcc: evil@example.com",
+		Payload:     []byte(`{"body":"Your images are ready."}`),
+	}
+
+	t.Run("success: sends composed message", func(t *testing.T) {
+		s := NewSMTPSender(config.Email{SMTPHost: "smtp.example.com", SMTPPort: 587, From: "no-reply@example.com"})
+		var gotAddr string
+		var gotTo []string
+		var gotMsg []byte
+		s.sendMail = func(addr string, a smtp.Auth, from string, to []string, body []byte) error {
+			gotAddr, gotTo, gotMsg = addr, to, body
+			return nil
+		}
+
+		status, err := s.Send(context.Background(), msg)
+		require.NoError(t, err)
+		assert.Equal(t, 0, status)
+		assert.Equal(t, "smtp.example.com:587", gotAddr)
+		assert.Equal(t, []string{"user@example.com"}, gotTo)
+		assert.Contains(t, string(gotMsg), "Subject: Hello  Bcc: evil@example.com\r\n")
+		assert.Contains(t, string(gotMsg), "\r\n\r\nYour images are ready.")
+	})
+
+	t.Run("fail: smtp not configured", func(t *testing.T) {
+		_, err := NewSMTPSender(config.Email{}).Send(context.Background(), msg)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail: relay error", func(t *testing.T) {
+		s := NewSMTPSender(config.Email{SMTPHost: "smtp.example.com", SMTPPort: 25})
+		s.sendMail = func(addr string, a smtp.Auth, from string, to []string, body []byte) error {
+			return errors.New("550 mailbox unavailable")
+		}
+		_, err := s.Send(context.Background(), msg)
+		assert.ErrorContains(t, err, "550")
+	})
+}
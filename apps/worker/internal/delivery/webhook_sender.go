@@ -0,0 +1,75 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader carries the HMAC signature of webhooks sent to registered endpoints.
+// Its value is "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed by the endpoint secret>".
+const SignatureHeader = "X-RealStaging-Signature"
+
+// WebhookSender POSTs the stored JSON payload to the destination URL.
+type WebhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// Ensure WebhookSender implements Sender.
+var _ Sender = (*WebhookSender)(nil)
+
+// NewWebhookSender creates a WebhookSender with the given request timeout.
+func NewWebhookSender(timeout time.Duration) *WebhookSender {
+	return &WebhookSender{client: &http.Client{Timeout: timeout}, now: time.Now}
+}
+
+// Send delivers the webhook. Any non-2xx response is treated as a failure.
+func (s *WebhookSender) Send(ctx context.Context, msg Message) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.Destination, bytes.NewReader(msg.Payload))
+	if err != nil {
+		return 0, &SendError{Err: fmt.Errorf("build request: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "RealStaging-Webhooks/1.0")
+	req.Header.Set("X-RealStaging-Event", msg.EventType)
+	req.Header.Set("X-RealStaging-Delivery", msg.DeliveryID)
+	req.Header.Set("X-RealStaging-Attempt", strconv.Itoa(msg.Attempt))
+	if msg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(msg.Secret, s.now(), msg.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, &SendError{Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	// Drain a bounded amount so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, &SendError{
+			StatusCode: resp.StatusCode,
+			Err:        fmt.Errorf("unexpected response %s", resp.Status),
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the SignatureHeader value for body. The timestamp is part of the
+// signed content so receivers can reject stale or replayed deliveries.
+func Sign(secret string, at time.Time, body []byte) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
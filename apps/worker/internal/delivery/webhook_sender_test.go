@@ -0,0 +1,108 @@
+package delivery
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSender_Send(t *testing.T) {
+	cases := []struct {
+		name       string
+		respStatus int
+		wantErr    bool
+	}{
+		{name: "success: 2xx response", respStatus: http.StatusOK},
+		{name: "fail: 5xx response", respStatus: http.StatusBadGateway, wantErr: true},
+		{name: "fail: 4xx response", respStatus: http.StatusGone, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotBody []byte
+			var gotHeader http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header
+				gotBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tc.respStatus)
+			}))
+			defer srv.Close()
+
+			s := NewWebhookSender(time.Second)
+			status, err := s.Send(context.Background(), Message{
+				DeliveryID:  "d-1",
+				EventType:   "image.ready",
+				Destination: srv.URL,
+				Payload:     []byte(`{"image_id":"img"}`),
+				Attempt:     2,
+			})
+
+			assert.Equal(t, tc.respStatus, status)
+			assert.JSONEq(t, `{"image_id":"img"}`, string(gotBody))
+			assert.Equal(t, "application/json", gotHeader.Get("Content-Type"))
+			assert.Equal(t, "image.ready", gotHeader.Get("X-RealStaging-Event"))
+			assert.Equal(t, "d-1", gotHeader.Get("X-RealStaging-Delivery"))
+			assert.Equal(t, "2", gotHeader.Get("X-RealStaging-Attempt"))
+			if !tc.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var se *SendError
+			require.True(t, errors.As(err, &se))
+			assert.Equal(t, tc.respStatus, se.StatusCode)
+		})
+	}
+
+	t.Run("fail: connection error has no status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := srv.URL
+		srv.Close()
+
+		status, err := NewWebhookSender(time.Second).Send(context.Background(), Message{Destination: url})
+		require.Error(t, err)
+		assert.Equal(t, 0, status)
+	})
+}
+
+func TestWebhookSender_Send_signature(t *testing.T) {
+	var gotSig string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSig = r.Header.Get(SignatureHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	at := time.Unix(1735689600, 0)
+	s := NewWebhookSender(time.Second)
+	s.now = func() time.Time { return at }
+
+	t.Run("success: signed when endpoint has a secret", func(t *testing.T) {
+		_, err := s.Send(context.Background(), Message{
+			Destination: srv.URL,
+			Payload:     []byte(`{"image_id":"img"}`),
+			Secret:      "whsec_test",
+		})
+		require.NoError(t, err)
+
+		mac := hmac.New(sha256.New, []byte("whsec_test"))
+		mac.Write([]byte("1735689600." + string(gotBody)))
+		assert.Equal(t, "t=1735689600,v1="+hex.EncodeToString(mac.Sum(nil)), gotSig)
+	})
+
+	t.Run("success: unsigned without a secret", func(t *testing.T) {
+		_, err := s.Send(context.Background(), Message{Destination: srv.URL, Payload: []byte(`{}`)})
+		require.NoError(t, err)
+		assert.Empty(t, gotSig)
+	})
+}
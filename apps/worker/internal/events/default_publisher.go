@@ -84,8 +84,16 @@ func (p *defaultRedisPublisher) PublishJobUpdate(ctx context.Context, ev JobUpda
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
-	// Minimal payload: status only (SSE contract)
-	payload, err := json.Marshal(map[string]string{"status": ev.Status})
+	// Minimal payload: status only (SSE contract), plus the placeholder once staged
+	// and the validation code of a rejected original
+	msg := map[string]string{"status": ev.Status}
+	if ev.Blurhash != "" {
+		msg["blurhash"] = ev.Blurhash
+	}
+	if ev.ErrorCode != "" {
+		msg["error_code"] = ev.ErrorCode
+	}
+	payload, err := json.Marshal(msg)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "marshal payload")
@@ -54,6 +54,62 @@ func TestDefaultPublisher_Success_NoRetry(t *testing.T) {
 	}
 }
 
+func TestDefaultPublisher_ReadyIncludesBlurhash(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	pub := NewDefaultPublisherWithClient(rdb, Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	imageID := "img-ready"
+	sub := rdb.Subscribe(ctx, "jobs:image:"+imageID)
+	require.NoError(t, sub.Ping(ctx))
+	defer func() { _ = sub.Close() }()
+	msgCh := sub.Channel()
+	time.Sleep(10 * time.Millisecond)
+
+	ev := JobUpdateEvent{ImageID: imageID, Status: "ready", Blurhash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj"}
+	require.NoError(t, pub.PublishJobUpdate(ctx, ev))
+
+	select {
+	case msg := <-msgCh:
+		require.NotNil(t, msg)
+		assert.JSONEq(t, `{"status":"ready","blurhash":"LEHV6nWB2yk8pyo0adR*.7kCMdnj"}`, msg.Payload)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+func TestDefaultPublisher_ErrorIncludesErrorCode(t *testing.T) {
+	mr := miniredis.RunT(t)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	pub := NewDefaultPublisherWithClient(rdb, Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	imageID := "img-invalid"
+	sub := rdb.Subscribe(ctx, "jobs:image:"+imageID)
+	require.NoError(t, sub.Ping(ctx))
+	defer func() { _ = sub.Close() }()
+	msgCh := sub.Channel()
+	time.Sleep(10 * time.Millisecond)
+
+	ev := JobUpdateEvent{ImageID: imageID, Status: "error", Error: "damaged", ErrorCode: "corrupt_image"}
+	require.NoError(t, pub.PublishJobUpdate(ctx, ev))
+
+	select {
+	case msg := <-msgCh:
+		require.NotNil(t, msg)
+		assert.JSONEq(t, `{"status":"error","error_code":"corrupt_image"}`, msg.Payload)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for message")
+	}
+}
+
 func TestDefaultPublisher_RetryAndFail_Logs(t *testing.T) {
 	prev := logging.Default()
 	memLogger := &memoryLogger{}
@@ -13,6 +13,10 @@ type JobUpdateEvent struct {
 	Status   string `json:"status"`
 	Error    string `json:"error,omitempty"`
 	Progress int    `json:"progress,omitempty"`
+	// Blurhash is the staged image's placeholder, sent with "ready" updates.
+	Blurhash string `json:"blurhash,omitempty"`
+	// ErrorCode is sent with "error" updates when the original failed validation.
+	ErrorCode string `json:"error_code,omitempty"`
 }
 
 // Publisher publishes job update events to a pub/sub backend (Redis),
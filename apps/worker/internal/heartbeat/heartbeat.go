@@ -0,0 +1,91 @@
+// Package heartbeat detects stage jobs whose worker died mid-flight.
+//
+// While a worker runs a stage job it keeps a short-lived heartbeat for the
+// image in Redis and records the job's payload and Replicate prediction ID.
+// If the worker is killed (for example by the OOM killer) the heartbeat
+// expires; the Monitor then counts the job as stalled and requeues it with
+// the recorded prediction ID so the next attempt picks up the prediction that
+// is already running instead of paying for a new one.
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// ErrInFlight is returned by Claim when another worker's heartbeat for the
+// image is still live.
+var ErrInFlight = errors.New("stage job is already in flight")
+
+// Record is what a worker stores about an in-flight stage job.
+type Record struct {
+	ImageID      string
+	WorkerID     string
+	Payload      []byte
+	PredictionID string
+	StartedAt    time.Time
+}
+
+// Store tracks in-flight stage jobs and their heartbeats.
+type Store interface {
+	// Claim starts the heartbeat for an image's stage job. The returned record
+	// carries the prediction ID left behind by an earlier attempt, if any.
+	Claim(ctx context.Context, imageID string, payload []byte) (*Record, error)
+
+	// Beat refreshes the heartbeat for an image.
+	Beat(ctx context.Context, imageID string) error
+
+	// SetPrediction records the Replicate prediction started for an image.
+	SetPrediction(ctx context.Context, imageID, predictionID string) error
+
+	// Release forgets an image once its job has finished.
+	Release(ctx context.Context, imageID string) error
+
+	// Stalled returns the in-flight jobs whose heartbeats have stopped. Each
+	// stalled job is handed to exactly one caller, even across workers.
+	Stalled(ctx context.Context) ([]Record, error)
+
+	// RecordStall counts a stalled job and returns the total across all workers.
+	RecordStall(ctx context.Context) (int64, error)
+}
+
+// Keep refreshes the image's heartbeat every interval until the returned stop
+// function is called. Stop releases the image, except when ctx was canceled
+// (the worker is shutting down): the record is then kept so the job's next
+// attempt can resume its prediction.
+func Keep(ctx context.Context, store Store, imageID string, interval time.Duration) (stop func()) {
+	log := logging.Default()
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := store.Beat(ctx, imageID); err != nil {
+					log.Warn(ctx, "heartbeat failed", "image_id", imageID, "error", err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+		if ctx.Err() != nil {
+			return
+		}
+		if err := store.Release(ctx, imageID); err != nil {
+			log.Warn(ctx, "failed to release heartbeat", "image_id", imageID, "error", err)
+		}
+	}
+}
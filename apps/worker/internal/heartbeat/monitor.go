@@ -0,0 +1,106 @@
+package heartbeat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// Requeuer puts a stage job back on the queue.
+type Requeuer interface {
+	RequeueStage(ctx context.Context, payload []byte) error
+}
+
+// Monitor periodically requeues stage jobs whose heartbeats have stopped.
+type Monitor struct {
+	store    Store
+	requeuer Requeuer
+	interval time.Duration
+	stalled  metric.Int64Counter
+}
+
+// NewMonitor creates a monitor that checks for stalled jobs every interval.
+func NewMonitor(store Store, requeuer Requeuer, interval time.Duration) (*Monitor, error) {
+	stalled, err := otel.Meter("real-staging-worker/heartbeat").Int64Counter(
+		"worker.stage_jobs.stalled",
+		metric.WithDescription("Stage jobs whose worker stopped sending heartbeats"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create stalled jobs counter: %w", err)
+	}
+	return &Monitor{store: store, requeuer: requeuer, interval: interval, stalled: stalled}, nil
+}
+
+// Run checks for stalled jobs until ctx is canceled.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Check(ctx); err != nil {
+				logging.Default().Error(ctx, "stalled job check failed", "error", err)
+			}
+		}
+	}
+}
+
+// Check requeues every stalled job once, carrying its recorded prediction ID
+// so the next attempt resumes that prediction. A job that fails to requeue is
+// logged; asynq still retries the original task once its lease expires.
+func (m *Monitor) Check(ctx context.Context) error {
+	log := logging.Default()
+
+	records, err := m.store.Stalled(ctx)
+	for _, rec := range records {
+		m.stalled.Add(ctx, 1, metric.WithAttributes(attribute.Bool("prediction.resumable", rec.PredictionID != "")))
+		total, countErr := m.store.RecordStall(ctx)
+		if countErr != nil {
+			log.Warn(ctx, "failed to count stalled job", "image_id", rec.ImageID, "error", countErr)
+		}
+		log.Warn(ctx, "stage job stalled, requeueing",
+			"image_id", rec.ImageID,
+			"worker_id", rec.WorkerID,
+			"prediction_id", rec.PredictionID,
+			"started_at", rec.StartedAt,
+			"stalled_total", total,
+		)
+
+		payload, payloadErr := WithPredictionID(rec.Payload, rec.PredictionID)
+		if payloadErr != nil {
+			log.Error(ctx, "failed to rebuild stalled job payload", "image_id", rec.ImageID, "error", payloadErr)
+			continue
+		}
+		if reqErr := m.requeuer.RequeueStage(ctx, payload); reqErr != nil {
+			log.Error(ctx, "failed to requeue stalled job", "image_id", rec.ImageID, "error", reqErr)
+		}
+	}
+	return err
+}
+
+// WithPredictionID sets prediction_id on a stage job payload, leaving the
+// other fields untouched.
+func WithPredictionID(payload []byte, predictionID string) ([]byte, error) {
+	if predictionID == "" {
+		return payload, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal payload: %w", err)
+	}
+	id, err := json.Marshal(predictionID)
+	if err != nil {
+		return nil, fmt.Errorf("marshal prediction id: %w", err)
+	}
+	fields["prediction_id"] = id
+	return json.Marshal(fields)
+}
@@ -0,0 +1,106 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRequeuer struct {
+	payloads [][]byte
+	err      error
+}
+
+func (f *fakeRequeuer) RequeueStage(_ context.Context, payload []byte) error {
+	f.payloads = append(f.payloads, payload)
+	return f.err
+}
+
+func TestMonitor_Check(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: requeues stalled jobs with their prediction", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{"image_id":"img-1","original_url":"s3://o.jpg"}`))
+		require.NoError(t, err)
+		require.NoError(t, store.SetPrediction(ctx, "img-1", "pred-1"))
+		_, err = store.Claim(ctx, "img-2", []byte(`{"image_id":"img-2"}`))
+		require.NoError(t, err)
+		mr.FastForward(31 * time.Second)
+
+		requeuer := &fakeRequeuer{}
+		m, err := NewMonitor(store, requeuer, time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, m.Check(ctx))
+
+		require.Len(t, requeuer.payloads, 2)
+		got := map[string]string{}
+		for _, p := range requeuer.payloads {
+			got[string(p)] = string(p)
+		}
+		assert.Contains(t, got, `{"image_id":"img-1","original_url":"s3://o.jpg","prediction_id":"pred-1"}`)
+		assert.Contains(t, got, `{"image_id":"img-2"}`)
+		total, err := mr.Get(stalledTotalKey)
+		require.NoError(t, err)
+		assert.Equal(t, "2", total)
+	})
+
+	t.Run("success: nothing to do while heartbeats are live", func(t *testing.T) {
+		store, _ := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{"image_id":"img-1"}`))
+		require.NoError(t, err)
+
+		requeuer := &fakeRequeuer{}
+		m, err := NewMonitor(store, requeuer, time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, m.Check(ctx))
+		assert.Empty(t, requeuer.payloads)
+	})
+
+	t.Run("fail: requeue errors are logged, not returned", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{"image_id":"img-1"}`))
+		require.NoError(t, err)
+		mr.FastForward(31 * time.Second)
+
+		requeuer := &fakeRequeuer{err: errors.New("redis down")}
+		m, err := NewMonitor(store, requeuer, time.Minute)
+		require.NoError(t, err)
+		assert.NoError(t, m.Check(ctx))
+		assert.Len(t, requeuer.payloads, 1)
+	})
+}
+
+func TestKeep(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: stop releases the job", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+
+		stop := Keep(ctx, store, "img-1", time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+		stop()
+
+		assert.False(t, mr.Exists(heartbeatKey("img-1")))
+		assert.False(t, mr.Exists(recordKey("img-1")))
+	})
+
+	t.Run("success: keeps the record when the worker shuts down", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+
+		cctx, cancel := context.WithCancel(ctx)
+		stop := Keep(cctx, store, "img-1", time.Hour)
+		cancel()
+		stop()
+
+		assert.True(t, mr.Exists(recordKey("img-1")))
+	})
+}
@@ -0,0 +1,170 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	redis "github.com/redis/go-redis/v9"
+)
+
+// Redis keys. A job's heartbeat is a short-lived key per image; its record
+// outlives the heartbeat so a stalled job can be requeued from it.
+const (
+	inflightKey     = "worker:stage:inflight"
+	stalledTotalKey = "worker:stage:stalled_total"
+	heartbeatPrefix = "worker:stage:heartbeat:"
+	recordPrefix    = "worker:stage:job:"
+)
+
+// recordTTL bounds how long a record lingers if nothing ever releases it.
+const recordTTL = 24 * time.Hour
+
+// claimStalled removes an image from the in-flight set only if its heartbeat
+// has expired, so two monitors never both claim the same job.
+var claimStalled = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+return redis.call('SREM', KEYS[2], ARGV[1])
+`)
+
+// RedisStore is a Redis-backed Store.
+type RedisStore struct {
+	rdb      *redis.Client
+	workerID string
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// Ensure RedisStore implements Store.
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore creates a store whose heartbeats expire after ttl unless refreshed.
+func NewRedisStore(rdb *redis.Client, workerID string, ttl time.Duration) *RedisStore {
+	return &RedisStore{rdb: rdb, workerID: workerID, ttl: ttl, now: time.Now}
+}
+
+func heartbeatKey(imageID string) string { return heartbeatPrefix + imageID }
+func recordKey(imageID string) string    { return recordPrefix + imageID }
+
+// Claim takes the image's heartbeat, failing with ErrInFlight if another
+// worker holds it, and records the job. A prediction ID recorded by an
+// earlier attempt is kept and returned.
+func (s *RedisStore) Claim(ctx context.Context, imageID string, payload []byte) (*Record, error) {
+	ok, err := s.rdb.SetNX(ctx, heartbeatKey(imageID), s.workerID, s.ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("claim heartbeat: %w", err)
+	}
+	if !ok {
+		return nil, ErrInFlight
+	}
+
+	predictionID, err := s.rdb.HGet(ctx, recordKey(imageID), "prediction_id").Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("read job record: %w", err)
+	}
+
+	rec := &Record{
+		ImageID:      imageID,
+		WorkerID:     s.workerID,
+		Payload:      payload,
+		PredictionID: predictionID,
+		StartedAt:    s.now().UTC(),
+	}
+	_, err = s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, recordKey(imageID),
+			"worker_id", rec.WorkerID,
+			"payload", rec.Payload,
+			"started_at", rec.StartedAt.Format(time.RFC3339Nano),
+		)
+		pipe.Expire(ctx, recordKey(imageID), recordTTL)
+		pipe.SAdd(ctx, inflightKey, imageID)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record job: %w", err)
+	}
+	return rec, nil
+}
+
+// Beat extends the image's heartbeat.
+func (s *RedisStore) Beat(ctx context.Context, imageID string) error {
+	ok, err := s.rdb.Expire(ctx, heartbeatKey(imageID), s.ttl).Result()
+	if err != nil {
+		return fmt.Errorf("refresh heartbeat: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("heartbeat for image %s expired", imageID)
+	}
+	return nil
+}
+
+// SetPrediction records the prediction started for the image's job.
+func (s *RedisStore) SetPrediction(ctx context.Context, imageID, predictionID string) error {
+	if err := s.rdb.HSet(ctx, recordKey(imageID), "prediction_id", predictionID).Err(); err != nil {
+		return fmt.Errorf("record prediction: %w", err)
+	}
+	return nil
+}
+
+// Release deletes the image's heartbeat and record.
+func (s *RedisStore) Release(ctx context.Context, imageID string) error {
+	_, err := s.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SRem(ctx, inflightKey, imageID)
+		pipe.Del(ctx, heartbeatKey(imageID), recordKey(imageID))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("release heartbeat: %w", err)
+	}
+	return nil
+}
+
+// Stalled claims every in-flight image whose heartbeat has expired and
+// returns its record. Records are left in place for the requeued attempt.
+func (s *RedisStore) Stalled(ctx context.Context) ([]Record, error) {
+	imageIDs, err := s.rdb.SMembers(ctx, inflightKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list in-flight jobs: %w", err)
+	}
+
+	var stalled []Record
+	for _, imageID := range imageIDs {
+		claimed, err := claimStalled.Run(ctx, s.rdb, []string{heartbeatKey(imageID), inflightKey}, imageID).Int()
+		if err != nil {
+			return stalled, fmt.Errorf("claim stalled job: %w", err)
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		fields, err := s.rdb.HGetAll(ctx, recordKey(imageID)).Result()
+		if err != nil {
+			return stalled, fmt.Errorf("read job record: %w", err)
+		}
+		if fields["payload"] == "" {
+			// The record expired; there is nothing left to requeue.
+			continue
+		}
+		rec := Record{
+			ImageID:      imageID,
+			WorkerID:     fields["worker_id"],
+			Payload:      []byte(fields["payload"]),
+			PredictionID: fields["prediction_id"],
+		}
+		rec.StartedAt, _ = time.Parse(time.RFC3339Nano, fields["started_at"])
+		stalled = append(stalled, rec)
+	}
+	return stalled, nil
+}
+
+// RecordStall increments the shared stalled-job counter.
+func (s *RedisStore) RecordStall(ctx context.Context) (int64, error) {
+	total, err := s.rdb.Incr(ctx, stalledTotalKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("count stalled job: %w", err)
+	}
+	return total, nil
+}
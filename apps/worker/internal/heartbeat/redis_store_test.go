@@ -0,0 +1,136 @@
+package heartbeat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	redis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, workerID string) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return NewRedisStore(rdb, workerID, 30*time.Second), mr
+}
+
+func TestRedisStore_Claim(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: claims a new job", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+
+		rec, err := store.Claim(ctx, "img-1", []byte(`{"image_id":"img-1"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "worker-a", rec.WorkerID)
+		assert.Empty(t, rec.PredictionID)
+		assert.True(t, mr.Exists(heartbeatKey("img-1")))
+		ok, err := mr.SIsMember(inflightKey, "img-1")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("fail: another worker's heartbeat is live", func(t *testing.T) {
+		store, _ := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+
+		_, err = store.Claim(ctx, "img-1", []byte(`{}`))
+		assert.ErrorIs(t, err, ErrInFlight)
+	})
+
+	t.Run("success: keeps the prediction of a stalled attempt", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+		require.NoError(t, store.SetPrediction(ctx, "img-1", "pred-1"))
+
+		mr.FastForward(31 * time.Second)
+		rec, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, "pred-1", rec.PredictionID)
+	})
+}
+
+func TestRedisStore_Beat(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: extends the heartbeat", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+
+		mr.FastForward(20 * time.Second)
+		require.NoError(t, store.Beat(ctx, "img-1"))
+		mr.FastForward(20 * time.Second)
+		assert.True(t, mr.Exists(heartbeatKey("img-1")))
+	})
+
+	t.Run("fail: heartbeat already expired", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+
+		mr.FastForward(31 * time.Second)
+		assert.Error(t, store.Beat(ctx, "img-1"))
+	})
+}
+
+func TestRedisStore_Stalled(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: returns jobs whose heartbeat expired once", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-live", []byte(`{"image_id":"img-live"}`))
+		require.NoError(t, err)
+		_, err = store.Claim(ctx, "img-dead", []byte(`{"image_id":"img-dead"}`))
+		require.NoError(t, err)
+		require.NoError(t, store.SetPrediction(ctx, "img-dead", "pred-1"))
+
+		mr.FastForward(20 * time.Second)
+		require.NoError(t, store.Beat(ctx, "img-live"))
+		mr.FastForward(20 * time.Second)
+
+		stalled, err := store.Stalled(ctx)
+		require.NoError(t, err)
+		require.Len(t, stalled, 1)
+		assert.Equal(t, "img-dead", stalled[0].ImageID)
+		assert.Equal(t, "worker-a", stalled[0].WorkerID)
+		assert.Equal(t, "pred-1", stalled[0].PredictionID)
+		assert.JSONEq(t, `{"image_id":"img-dead"}`, string(stalled[0].Payload))
+
+		again, err := store.Stalled(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, again)
+	})
+
+	t.Run("success: released jobs are never stalled", func(t *testing.T) {
+		store, mr := newTestStore(t, "worker-a")
+		_, err := store.Claim(ctx, "img-1", []byte(`{}`))
+		require.NoError(t, err)
+		require.NoError(t, store.Release(ctx, "img-1"))
+
+		mr.FastForward(31 * time.Second)
+		stalled, err := store.Stalled(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, stalled)
+		assert.False(t, mr.Exists(recordKey("img-1")))
+	})
+}
+
+func TestRedisStore_RecordStall(t *testing.T) {
+	store, _ := newTestStore(t, "worker-a")
+
+	total, err := store.RecordStall(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	total, err = store.RecordStall(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+}
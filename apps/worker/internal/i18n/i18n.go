@@ -0,0 +1,58 @@
+// Package i18n translates the text the worker writes for end users into the
+// language a staging job was requested in: the error messages stored on
+// images and published with job updates, and the default watermark. As in the
+// API, messages are looked up by their English text, so a string missing from
+// a catalog is simply served in English. Logs are never translated.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DefaultLanguage is used for jobs that name no language or one without a
+// catalog.
+const DefaultLanguage = "en"
+
+// locales holds one JSON object per language, mapping English messages to
+// their translations.
+//
+//go:embed locales/*.json
+var locales embed.FS
+
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	entries, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	out := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := locales.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic("i18n: invalid catalog " + entry.Name() + ": " + err.Error())
+		}
+		out[strings.TrimSuffix(entry.Name(), ".json")] = catalog
+	}
+	return out
+}
+
+// T translates msg into lang. With args, the translation is used as a fmt
+// format, so catalog entries must keep the verbs of msg.
+func T(lang, msg string, args ...any) string {
+	if translated, ok := catalogs[lang][msg]; ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestT(t *testing.T) {
+	t.Run("success: translates into the language", func(t *testing.T) {
+		assert.Equal(t, "Amueblado virtualmente", T("es", "Virtually Staged"))
+	})
+
+	t.Run("success: formats translated messages", func(t *testing.T) {
+		assert.Equal(t, "Le fichier envoyé dépasse la limite de 10 MB.",
+			T("fr", "The uploaded file is larger than the %s limit.", "10 MB"))
+	})
+
+	t.Run("success: unknown messages stay english", func(t *testing.T) {
+		assert.Equal(t, "Something new", T("es", "Something new"))
+	})
+
+	t.Run("success: english and unknown languages are not translated", func(t *testing.T) {
+		assert.Equal(t, "Virtually Staged", T("", "Virtually Staged"))
+		assert.Equal(t, "Virtually Staged", T(DefaultLanguage, "Virtually Staged"))
+		assert.Equal(t, "Virtually Staged", T("de", "Virtually Staged"))
+	})
+
+	t.Run("success: messages without args are not formatted", func(t *testing.T) {
+		assert.Equal(t, "100% done", T("es", "100% done"))
+	})
+}
+
+var verbs = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// Every catalog must translate the same messages and keep their fmt verbs in
+// order, or T would garble formatted messages.
+func TestCatalogs(t *testing.T) {
+	reference := catalogs["es"]
+	assert.NotEmpty(t, reference)
+
+	for lang, catalog := range catalogs {
+		t.Run(lang, func(t *testing.T) {
+			for msg, translated := range catalog {
+				assert.Equal(t, verbs.FindAllString(msg, -1), verbs.FindAllString(translated, -1), msg)
+				assert.NotEmpty(t, translated, msg)
+			}
+			for msg := range reference {
+				assert.Contains(t, catalog, msg)
+			}
+			assert.Len(t, catalog, len(reference))
+		})
+	}
+}
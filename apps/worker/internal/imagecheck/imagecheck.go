@@ -0,0 +1,168 @@
+// Package imagecheck verifies that an uploaded original is an image the
+// worker can stage: a JPEG, PNG or WebP within the configured size and
+// dimension limits that decodes without errors.
+//
+// Uploads go straight to S3 through a presigned URL, so the API only sees the
+// content type and size the client declares. This is the first point where
+// the bytes themselves are inspected.
+package imagecheck
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/webp"
+
+	"github.com/real-staging-ai/worker/internal/i18n"
+)
+
+// Code identifies why an original failed validation. It is stored on the image
+// as error_code so clients can tell a bad upload from a staging failure.
+type Code string
+
+const (
+	// CodeUnsupportedFormat means the file is not a JPEG, PNG or WebP.
+	CodeUnsupportedFormat Code = "unsupported_format"
+	// CodeFileTooLarge means the file exceeds Limits.MaxBytes.
+	CodeFileTooLarge Code = "file_too_large"
+	// CodeDimensionsTooLarge means the width or height exceeds Limits.MaxDimension.
+	CodeDimensionsTooLarge Code = "dimensions_too_large"
+	// CodeCorrupt means the file is empty, truncated or otherwise fails to decode.
+	CodeCorrupt Code = "corrupt_image"
+)
+
+// Error is a validation failure. Its message is written for end users.
+type Error struct {
+	Code    Code
+	Message string
+	// format and args are what Message was formatted from, so Localize can
+	// translate it; an Error built without newError has neither.
+	format string
+	args   []any
+}
+
+// newError returns an Error whose English message is format filled with args.
+func newError(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: i18n.T(i18n.DefaultLanguage, format, args...), format: format, args: args}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Localize returns the message translated into lang, or in English when it
+// has no translation.
+func (e *Error) Localize(lang string) string {
+	if e.format == "" {
+		return i18n.T(lang, e.Message)
+	}
+	return i18n.T(lang, e.format, e.args...)
+}
+
+// Limits bounds the originals that are accepted. A zero field disables that limit.
+type Limits struct {
+	// MaxBytes is the largest accepted file size.
+	MaxBytes int64
+	// MaxDimension is the largest accepted width or height, in pixels.
+	MaxDimension int
+}
+
+// Info describes a valid original.
+type Info struct {
+	// Format is "jpeg", "png" or "webp".
+	Format string
+	Width  int
+	Height int
+}
+
+// decoder decodes one of the accepted formats.
+type decoder struct {
+	name         string
+	label        string
+	magic        func([]byte) bool
+	decodeConfig func(io.Reader) (image.Config, error)
+	decode       func(io.Reader) (image.Image, error)
+}
+
+var decoders = []decoder{
+	{
+		name:         "jpeg",
+		label:        "JPEG",
+		magic:        func(b []byte) bool { return bytes.HasPrefix(b, []byte{0xFF, 0xD8, 0xFF}) },
+		decodeConfig: jpeg.DecodeConfig,
+		decode:       jpeg.Decode,
+	},
+	{
+		name:         "png",
+		label:        "PNG",
+		magic:        func(b []byte) bool { return bytes.HasPrefix(b, []byte("\x89PNG\r\n\x1a\n")) },
+		decodeConfig: png.DecodeConfig,
+		decode:       png.Decode,
+	},
+	{
+		name:  "webp",
+		label: "WebP",
+		magic: func(b []byte) bool {
+			return len(b) >= 12 && string(b[:4]) == "RIFF" && string(b[8:12]) == "WEBP"
+		},
+		decodeConfig: webp.DecodeConfig,
+		decode:       webp.Decode,
+	},
+}
+
+// Check validates data against limits. It returns an *Error when the original
+// should be rejected. The format is taken from the file's signature, not its
+// name or declared content type, and the dimensions are checked before the
+// full decode so an oversized image is never decompressed.
+func Check(data []byte, limits Limits) (*Info, error) {
+	if len(data) == 0 {
+		return nil, newError(CodeCorrupt, "The uploaded file is empty.")
+	}
+	if limits.MaxBytes > 0 && int64(len(data)) > limits.MaxBytes {
+		return nil, newError(CodeFileTooLarge, "The uploaded file is larger than the %s limit.",
+			formatBytes(limits.MaxBytes))
+	}
+
+	var dec *decoder
+	for i := range decoders {
+		if decoders[i].magic(data) {
+			dec = &decoders[i]
+			break
+		}
+	}
+	if dec == nil {
+		return nil, newError(CodeUnsupportedFormat,
+			"The uploaded file is %s; only JPEG, PNG and WebP images are accepted.", http.DetectContentType(data))
+	}
+
+	cfg, err := dec.decodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, corrupt(dec.label)
+	}
+	if limits.MaxDimension > 0 && (cfg.Width > limits.MaxDimension || cfg.Height > limits.MaxDimension) {
+		return nil, newError(CodeDimensionsTooLarge, "The image is %d×%d pixels; the largest accepted side is %d pixels.",
+			cfg.Width, cfg.Height, limits.MaxDimension)
+	}
+	if _, err := dec.decode(bytes.NewReader(data)); err != nil {
+		return nil, corrupt(dec.label)
+	}
+	return &Info{Format: dec.name, Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+func corrupt(format string) *Error {
+	return newError(CodeCorrupt, "The uploaded %s image is damaged or incomplete.", format)
+}
+
+// formatBytes renders n in whole MB when it is a multiple of one, for messages.
+func formatBytes(n int64) string {
+	const mb = 1 << 20
+	if n%mb == 0 {
+		return fmt.Sprintf("%d MB", n/mb)
+	}
+	return fmt.Sprintf("%d bytes", n)
+}
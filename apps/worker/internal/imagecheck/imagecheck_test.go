@@ -0,0 +1,116 @@
+package imagecheck
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tinyWebP is a 1×1 lossless WebP.
+const tinyWebP = "UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA=="
+
+func encoded(t *testing.T, format string, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i)
+	}
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, nil)
+	case "png":
+		err = png.Encode(&buf, img)
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestCheck(t *testing.T) {
+	webpData, err := base64.StdEncoding.DecodeString(tinyWebP)
+	require.NoError(t, err)
+	jpegData := encoded(t, "jpeg", 64, 48)
+	pngData := encoded(t, "png", 32, 32)
+	limits := Limits{MaxBytes: 1 << 20, MaxDimension: 100}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		limits   Limits
+		want     *Info
+		wantCode Code
+	}{
+		{name: "success: jpeg", data: jpegData, limits: limits, want: &Info{Format: "jpeg", Width: 64, Height: 48}},
+		{name: "success: png", data: pngData, limits: limits, want: &Info{Format: "png", Width: 32, Height: 32}},
+		{name: "success: webp", data: webpData, limits: limits, want: &Info{Format: "webp", Width: 1, Height: 1}},
+		{
+			name: "success: zero limits accept anything decodable", data: encoded(t, "png", 300, 10),
+			want: &Info{Format: "png", Width: 300, Height: 10},
+		},
+		{name: "fail: empty file", data: nil, limits: limits, wantCode: CodeCorrupt},
+		{name: "fail: gif", data: encoded(t, "gif", 8, 8), limits: limits, wantCode: CodeUnsupportedFormat},
+		{name: "fail: pdf", data: []byte("%PDF-1.7\n..."), limits: limits, wantCode: CodeUnsupportedFormat},
+		{name: "fail: over byte limit", data: jpegData, limits: Limits{MaxBytes: 100}, wantCode: CodeFileTooLarge},
+		{
+			name: "fail: over dimension limit", data: encoded(t, "png", 101, 10), limits: limits,
+			wantCode: CodeDimensionsTooLarge,
+		},
+		{name: "fail: truncated jpeg", data: jpegData[:len(jpegData)/2], limits: limits, wantCode: CodeCorrupt},
+		{name: "fail: truncated png", data: pngData[:40], limits: limits, wantCode: CodeCorrupt},
+		{
+			name: "fail: jpeg signature only", data: []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 0}, limits: limits,
+			wantCode: CodeCorrupt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := Check(tt.data, tt.limits)
+			if tt.wantCode == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, info)
+				return
+			}
+			var vErr *Error
+			require.ErrorAs(t, err, &vErr)
+			assert.Equal(t, tt.wantCode, vErr.Code)
+			assert.NotEmpty(t, vErr.Message)
+			assert.Nil(t, info)
+		})
+	}
+}
+
+func TestCheck_Messages(t *testing.T) {
+	_, err := Check(encoded(t, "png", 120, 80), Limits{MaxDimension: 100})
+	assert.EqualError(t, err, "The image is 120×80 pixels; the largest accepted side is 100 pixels.")
+
+	_, err = Check(encoded(t, "png", 10, 10), Limits{MaxBytes: 10})
+	assert.EqualError(t, err, "The uploaded file is larger than the 10 bytes limit.")
+
+	_, err = Check(make([]byte, 2<<20), Limits{MaxBytes: 1 << 20})
+	assert.EqualError(t, err, "The uploaded file is larger than the 1 MB limit.")
+
+	_, err = Check(encoded(t, "gif", 4, 4), Limits{})
+	assert.EqualError(t, err, "The uploaded file is image/gif; only JPEG, PNG and WebP images are accepted.")
+}
+
+func TestError_Localize(t *testing.T) {
+	_, err := Check(encoded(t, "png", 120, 80), Limits{MaxDimension: 100})
+	var vErr *Error
+	require.ErrorAs(t, err, &vErr)
+
+	assert.Equal(t, "La imagen mide 120×80 píxeles; el lado más grande aceptado es de 100 píxeles.",
+		vErr.Localize("es"))
+	assert.Equal(t, vErr.Message, vErr.Localize("en"))
+	assert.Equal(t, vErr.Message, vErr.Localize(""))
+}
@@ -0,0 +1,101 @@
+package malware
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamavChunkSize is how much of the file each INSTREAM chunk carries.
+const clamavChunkSize = 64 << 10
+
+// ClamAV scans files with a clamd daemon over its INSTREAM command.
+type ClamAV struct {
+	addr    string
+	timeout time.Duration
+}
+
+// Ensure ClamAV implements Scanner.
+var _ Scanner = (*ClamAV)(nil)
+
+// NewClamAV creates a scanner for the clamd listening on addr (host:port).
+// Each scan must finish within timeout; zero means no limit beyond ctx.
+func NewClamAV(addr string, timeout time.Duration) *ClamAV {
+	return &ClamAV{addr: addr, timeout: timeout}
+}
+
+// Scan streams r to clamd in length-prefixed chunks and parses its reply,
+// which is "stream: OK" for a clean file and "stream: <signature> FOUND" for
+// an infected one.
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (*Verdict, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect to clamd: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if err := stream(conn, r); err != nil {
+		return nil, err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("read clamd reply: %w", err)
+	}
+	return parseReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// stream sends the INSTREAM command followed by r and the zero-length chunk
+// that ends it.
+func stream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return fmt.Errorf("send clamd command: %w", err)
+	}
+	buf := make([]byte, 4+clamavChunkSize)
+	for {
+		n, readErr := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := w.Write(buf[:4+n]); err != nil {
+				return fmt.Errorf("send file to clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("read file: %w", readErr)
+		}
+	}
+	if _, err := w.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("send file to clamd: %w", err)
+	}
+	return nil
+}
+
+// parseReply turns a clamd INSTREAM reply into a verdict.
+func parseReply(reply string) (*Verdict, error) {
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return &Verdict{Scanner: "clamav"}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &Verdict{Infected: true, Signature: strings.TrimSuffix(result, " FOUND"), Scanner: "clamav"}, nil
+	default:
+		return nil, fmt.Errorf("clamd: %s", reply)
+	}
+}
@@ -0,0 +1,101 @@
+package malware
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd accepts one INSTREAM session, records the streamed bytes and
+// answers with reply.
+func fakeClamd(t *testing.T, reply string) (addr string, received <-chan []byte) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	got := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		cmd := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, cmd); err != nil || string(cmd) != "zINSTREAM\x00" {
+			got <- nil
+			return
+		}
+		var data bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+				got <- nil
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&data, conn, int64(size)); err != nil {
+				got <- nil
+				return
+			}
+		}
+		got <- data.Bytes()
+		_, _ = io.WriteString(conn, reply+"\x00")
+	}()
+	return ln.Addr().String(), got
+}
+
+func TestClamAV_Scan(t *testing.T) {
+	testCases := []struct {
+		name      string
+		reply     string
+		expected  *Verdict
+		expectErr string
+	}{
+		{name: "success: clean", reply: "stream: OK", expected: &Verdict{Scanner: "clamav"}},
+		{
+			name:     "success: infected",
+			reply:    "stream: Eicar-Test-Signature FOUND",
+			expected: &Verdict{Infected: true, Signature: "Eicar-Test-Signature", Scanner: "clamav"},
+		},
+		{name: "fail: clamd error", reply: "INSTREAM size limit exceeded. ERROR", expectErr: "size limit exceeded"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			addr, received := fakeClamd(t, tc.reply)
+			file := strings.Repeat("x", 3*clamavChunkSize/2)
+
+			verdict, err := NewClamAV(addr, 5*time.Second).Scan(context.Background(), strings.NewReader(file))
+
+			assert.Equal(t, []byte(file), <-received, "the whole file is streamed")
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, verdict)
+		})
+	}
+
+	t.Run("fail: clamd unreachable", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		require.NoError(t, ln.Close())
+
+		_, err = NewClamAV(addr, time.Second).Scan(context.Background(), strings.NewReader("x"))
+		assert.ErrorContains(t, err, "connect to clamd")
+	})
+}
@@ -0,0 +1,28 @@
+// Package malware scans uploaded files for viruses and other malware before
+// the worker stages them.
+package malware
+
+import (
+	"context"
+	"io"
+)
+
+// ErrorCode is stored on images whose original was found infected.
+const ErrorCode = "malware_detected"
+
+// Verdict is the outcome of a scan.
+type Verdict struct {
+	// Infected reports whether the scanner flagged the file.
+	Infected bool
+	// Signature names what was found, e.g. "Eicar-Test-Signature".
+	Signature string
+	// Scanner names the scanner that gave the verdict, e.g. "clamav".
+	Scanner string
+}
+
+// Scanner checks a file for malware. An error means the file could not be
+// scanned, not that it is infected.
+type Scanner interface {
+	// Scan reads r to the end and reports what it found.
+	Scan(ctx context.Context, r io.Reader) (*Verdict, error)
+}
@@ -0,0 +1,93 @@
+package orientation
+
+import (
+	"context"
+	"fmt"
+)
+
+// OriginalStore lists originals that still need inspecting and records the result.
+type OriginalStore interface {
+	ListOriginalsMissingOrientation(ctx context.Context, afterURL string, limit int) ([]string, error)
+	SetOriginalOrientation(ctx context.Context, originalURL string, orientation int) error
+}
+
+// Normalizer rewrites an original upright in storage and returns the orientation it found.
+type Normalizer interface {
+	NormalizeOrientation(ctx context.Context, originalURL string) (int, error)
+}
+
+// BackfillOptions controls a backfill run.
+type BackfillOptions struct {
+	// DryRun counts the originals that would be inspected without touching them.
+	DryRun bool
+	// BatchSize is the number of originals listed per query.
+	BatchSize int
+	// Limit stops the run after this many originals; zero means no limit.
+	Limit int
+}
+
+// BackfillResult summarizes a backfill run.
+type BackfillResult struct {
+	Scanned   int      `json:"scanned"`
+	Corrected int      `json:"corrected"`
+	Upright   int      `json:"upright"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// Backfiller corrects originals uploaded before orientation was normalized at
+// ingestion time.
+type Backfiller struct {
+	store      OriginalStore
+	normalizer Normalizer
+}
+
+// NewBackfiller creates a new Backfiller.
+func NewBackfiller(store OriginalStore, normalizer Normalizer) *Backfiller {
+	return &Backfiller{store: store, normalizer: normalizer}
+}
+
+// Run walks every uninspected original once. Failed originals are reported and
+// left uninspected so a later run retries them.
+func (b *Backfiller) Run(ctx context.Context, opts BackfillOptions) (*BackfillResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 200
+	}
+
+	result := &BackfillResult{}
+	cursor := ""
+	for {
+		urls, err := b.store.ListOriginalsMissingOrientation(ctx, cursor, opts.BatchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(urls) == 0 {
+			return result, nil
+		}
+
+		for _, u := range urls {
+			if opts.Limit > 0 && result.Scanned >= opts.Limit {
+				return result, nil
+			}
+			result.Scanned++
+			if opts.DryRun {
+				continue
+			}
+
+			o, err := b.normalizer.NormalizeOrientation(ctx, u)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", u, err))
+				continue
+			}
+			if err := b.store.SetOriginalOrientation(ctx, u, o); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", u, err))
+				continue
+			}
+			if o == Normal {
+				result.Upright++
+			} else {
+				result.Corrected++
+			}
+		}
+		cursor = urls[len(urls)-1]
+	}
+}
@@ -0,0 +1,75 @@
+package orientation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	urls     []string
+	recorded map[string]int
+}
+
+func (f *fakeStore) ListOriginalsMissingOrientation(ctx context.Context, afterURL string, limit int) ([]string, error) {
+	var out []string
+	for _, u := range f.urls {
+		if u > afterURL && len(out) < limit {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) SetOriginalOrientation(ctx context.Context, originalURL string, orientation int) error {
+	f.recorded[originalURL] = orientation
+	return nil
+}
+
+type fakeNormalizer map[string]int
+
+func (f fakeNormalizer) NormalizeOrientation(ctx context.Context, originalURL string) (int, error) {
+	o, ok := f[originalURL]
+	if !ok {
+		return 0, errors.New("not found")
+	}
+	return o, nil
+}
+
+func TestBackfiller_Run(t *testing.T) {
+	urls := []string{"s3://b/a.jpg", "s3://b/b.jpg", "s3://b/c.jpg"}
+	normalizer := fakeNormalizer{"s3://b/a.jpg": Rotate90CW, "s3://b/b.jpg": Normal}
+
+	t.Run("success: corrects across batches and reports failures", func(t *testing.T) {
+		store := &fakeStore{urls: urls, recorded: map[string]int{}}
+		result, err := NewBackfiller(store, normalizer).Run(context.Background(), BackfillOptions{BatchSize: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, result.Scanned)
+		assert.Equal(t, 1, result.Corrected)
+		assert.Equal(t, 1, result.Upright)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, map[string]int{"s3://b/a.jpg": Rotate90CW, "s3://b/b.jpg": Normal}, store.recorded)
+	})
+
+	t.Run("success: dry run touches nothing", func(t *testing.T) {
+		store := &fakeStore{urls: urls, recorded: map[string]int{}}
+		result, err := NewBackfiller(store, normalizer).Run(context.Background(), BackfillOptions{DryRun: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, result.Scanned)
+		assert.Empty(t, store.recorded)
+	})
+
+	t.Run("success: limit stops early", func(t *testing.T) {
+		store := &fakeStore{urls: urls, recorded: map[string]int{}}
+		result, err := NewBackfiller(store, normalizer).Run(context.Background(), BackfillOptions{Limit: 1})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Scanned)
+		assert.Len(t, store.recorded, 1)
+	})
+}
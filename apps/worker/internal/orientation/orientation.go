@@ -0,0 +1,165 @@
+// Package orientation reads the EXIF orientation tag from JPEG originals and
+// bakes it into the pixels, so downstream consumers (AI models, browsers that
+// ignore EXIF, thumbnailers) all see the photo the right way up.
+package orientation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+)
+
+// EXIF orientation values (TIFF tag 0x0112).
+const (
+	Normal         = 1
+	FlipHorizontal = 2
+	Rotate180      = 3
+	FlipVertical   = 4
+	Transpose      = 5
+	Rotate90CW     = 6
+	Transverse     = 7
+	Rotate90CCW    = 8
+)
+
+// jpegQuality is used when re-encoding a corrected original.
+const jpegQuality = 92
+
+const exifOrientationTag = 0x0112
+
+// FromEXIF returns the EXIF orientation of a JPEG, or Normal when the data is
+// not a JPEG, has no EXIF block, or the tag is missing or out of range.
+func FromEXIF(data []byte) int {
+	tiff := exifPayload(data)
+	if tiff == nil || len(tiff) < 8 {
+		return Normal
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return Normal
+	}
+	if order.Uint16(tiff[2:4]) != 0x002A {
+		return Normal
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return Normal
+	}
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < entries; i++ {
+		off := ifd + 2 + i*12
+		if off+12 > len(tiff) {
+			return Normal
+		}
+		if order.Uint16(tiff[off:off+2]) != exifOrientationTag {
+			continue
+		}
+		v := int(order.Uint16(tiff[off+8 : off+10]))
+		if v < Normal || v > Rotate90CCW {
+			return Normal
+		}
+		return v
+	}
+	return Normal
+}
+
+// exifPayload returns the TIFF structure inside a JPEG's APP1 Exif segment.
+func exifPayload(data []byte) []byte {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil
+	}
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		// Start of scan or end of image: metadata segments are over.
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if size < 2 || pos+2+size > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : pos+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		pos += 2 + size
+	}
+	return nil
+}
+
+// Normalize returns the image re-encoded with its EXIF orientation applied,
+// along with the orientation that was found. When the orientation is already
+// Normal (or the data isn't a JPEG) the input is returned unchanged.
+func Normalize(data []byte) ([]byte, int, error) {
+	o := FromEXIF(data)
+	if o == Normal {
+		return data, o, nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, o, fmt.Errorf("decode jpeg: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, Apply(img, o), &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, o, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return buf.Bytes(), o, nil
+}
+
+// Apply returns img transformed so that an image tagged with orientation o
+// displays upright without the tag.
+func Apply(img image.Image, o int) image.Image {
+	if o <= Normal || o > Rotate90CCW {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if o >= Transpose {
+		dw, dh = h, w
+	}
+
+	// src maps a destination pixel back to its source pixel.
+	var src func(x, y int) (int, int)
+	switch o {
+	case FlipHorizontal:
+		src = func(x, y int) (int, int) { return w - 1 - x, y }
+	case Rotate180:
+		src = func(x, y int) (int, int) { return w - 1 - x, h - 1 - y }
+	case FlipVertical:
+		src = func(x, y int) (int, int) { return x, h - 1 - y }
+	case Transpose:
+		src = func(x, y int) (int, int) { return y, x }
+	case Rotate90CW:
+		src = func(x, y int) (int, int) { return y, h - 1 - x }
+	case Transverse:
+		src = func(x, y int) (int, int) { return w - 1 - y, h - 1 - x }
+	case Rotate90CCW:
+		src = func(x, y int) (int, int) { return w - 1 - y, x }
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			sx, sy := src(x, y)
+			dst.Set(x, y, color.NRGBAModel.Convert(img.At(b.Min.X+sx, b.Min.Y+sy)))
+		}
+	}
+	return dst
+}
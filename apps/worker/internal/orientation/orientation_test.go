@@ -0,0 +1,161 @@
+package orientation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exifJPEG encodes img as a JPEG and inserts an APP1 segment carrying the orientation tag.
+func exifJPEG(t *testing.T, img image.Image, o int, order binary.ByteOrder) []byte {
+	t.Helper()
+	var enc bytes.Buffer
+	require.NoError(t, jpeg.Encode(&enc, img, &jpeg.Options{Quality: 100}))
+
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 0x002A)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3) // SHORT
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], uint16(o))
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	seg := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	seg = append(seg, payload...)
+
+	raw := enc.Bytes()
+	out := append([]byte{}, raw[:2]...)
+	out = append(out, seg...)
+	return append(out, raw[2:]...)
+}
+
+func quadrantImage(w, h int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.NRGBA{A: 255}
+			if x < w/2 {
+				c.R = 255
+			}
+			if y < h/2 {
+				c.G = 255
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestFromEXIF(t *testing.T) {
+	img := quadrantImage(8, 4)
+	cases := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "success: little endian", data: exifJPEG(t, img, Rotate90CW, binary.LittleEndian), want: Rotate90CW},
+		{name: "success: big endian", data: exifJPEG(t, img, Rotate90CCW, binary.BigEndian), want: Rotate90CCW},
+		{name: "success: out of range is normal", data: exifJPEG(t, img, 42, binary.BigEndian), want: Normal},
+		{name: "success: no exif is normal", data: func() []byte {
+			var b bytes.Buffer
+			require.NoError(t, jpeg.Encode(&b, img, nil))
+			return b.Bytes()
+		}(), want: Normal},
+		{name: "success: not a jpeg is normal", data: []byte("\x89PNG\r\n\x1a\n"), want: Normal},
+		{name: "success: truncated segment is normal", data: []byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF, 0xFF}, want: Normal},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, FromEXIF(tc.data))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	// 2x1 source: red on the left, blue on the right.
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	cases := []struct {
+		name        string
+		orientation int
+		wantW       int
+		wantH       int
+		want        map[image.Point]color.NRGBA
+	}{
+		{name: "success: normal", orientation: Normal, wantW: 2, wantH: 1,
+			want: map[image.Point]color.NRGBA{{0, 0}: red, {1, 0}: blue}},
+		{name: "success: flip horizontal", orientation: FlipHorizontal, wantW: 2, wantH: 1,
+			want: map[image.Point]color.NRGBA{{0, 0}: blue, {1, 0}: red}},
+		{name: "success: rotate 180", orientation: Rotate180, wantW: 2, wantH: 1,
+			want: map[image.Point]color.NRGBA{{0, 0}: blue, {1, 0}: red}},
+		{name: "success: rotate 90 cw", orientation: Rotate90CW, wantW: 1, wantH: 2,
+			want: map[image.Point]color.NRGBA{{0, 0}: red, {0, 1}: blue}},
+		{name: "success: rotate 90 ccw", orientation: Rotate90CCW, wantW: 1, wantH: 2,
+			want: map[image.Point]color.NRGBA{{0, 0}: blue, {0, 1}: red}},
+		{name: "success: transpose", orientation: Transpose, wantW: 1, wantH: 2,
+			want: map[image.Point]color.NRGBA{{0, 0}: red, {0, 1}: blue}},
+		{name: "success: transverse", orientation: Transverse, wantW: 1, wantH: 2,
+			want: map[image.Point]color.NRGBA{{0, 0}: blue, {0, 1}: red}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := Apply(src, tc.orientation)
+			assert.Equal(t, tc.wantW, out.Bounds().Dx())
+			assert.Equal(t, tc.wantH, out.Bounds().Dy())
+			for p, c := range tc.want {
+				assert.Equal(t, c, color.NRGBAModel.Convert(out.At(p.X, p.Y)), "pixel %v", p)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("success: rotates sideways original and drops the tag", func(t *testing.T) {
+		data := exifJPEG(t, quadrantImage(64, 32), Rotate90CW, binary.LittleEndian)
+
+		out, o, err := Normalize(data)
+		require.NoError(t, err)
+		assert.Equal(t, Rotate90CW, o)
+		assert.Equal(t, Normal, FromEXIF(out))
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, 32, cfg.Width)
+		assert.Equal(t, 64, cfg.Height)
+	})
+
+	t.Run("success: upright image returned unchanged", func(t *testing.T) {
+		data := exifJPEG(t, quadrantImage(8, 8), Normal, binary.BigEndian)
+
+		out, o, err := Normalize(data)
+		require.NoError(t, err)
+		assert.Equal(t, Normal, o)
+		assert.Equal(t, data, out)
+	})
+
+	t.Run("fail: corrupt jpeg body", func(t *testing.T) {
+		data := exifJPEG(t, quadrantImage(8, 8), Rotate180, binary.BigEndian)
+		_, _, err := Normalize(data[:len(data)/3])
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,535 @@
+// Package photometa reads the metadata embedded in uploaded photos and strips
+// it before the files are served or sent to a model.
+//
+// Phone photos carry EXIF with the GPS position they were taken at, along
+// with camera details, XMP and comments. Originals are public to anyone the
+// listing is shared with, so the worker removes all of it and, when
+// configured, keeps the useful fields in the database instead.
+package photometa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Metadata is what an original's EXIF said about it. Fields the photo didn't
+// carry are left zero.
+type Metadata struct {
+	CameraMake  string `json:"camera_make,omitempty"`
+	CameraModel string `json:"camera_model,omitempty"`
+	LensModel   string `json:"lens_model,omitempty"`
+	Software    string `json:"software,omitempty"`
+	// TakenAt is the capture time as the camera recorded it, in its local
+	// time: EXIF rarely says which zone that was.
+	TakenAt     string `json:"taken_at,omitempty"`
+	Orientation int    `json:"orientation,omitempty"`
+	GPS         *GPS   `json:"gps,omitempty"`
+}
+
+// GPS is the position a photo was taken at, in decimal degrees.
+type GPS struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// TIFF tags read from IFD0, the Exif IFD and the GPS IFD.
+const (
+	tagMake             = 0x010F
+	tagModel            = 0x0110
+	tagOrientation      = 0x0112
+	tagSoftware         = 0x0131
+	tagDateTime         = 0x0132
+	tagExifIFD          = 0x8769
+	tagGPSIFD           = 0x8825
+	tagDateTimeOriginal = 0x9003
+	tagLensModel        = 0xA434
+
+	tagGPSLatitudeRef  = 0x0001
+	tagGPSLatitude     = 0x0002
+	tagGPSLongitudeRef = 0x0003
+	tagGPSLongitude    = 0x0004
+)
+
+// TIFF field types.
+const (
+	typeASCII    = 2
+	typeShort    = 3
+	typeLong     = 4
+	typeRational = 5
+)
+
+// exifDateLayout is how EXIF records date-times.
+const exifDateLayout = "2006:01:02 15:04:05"
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// Read returns the EXIF metadata of a JPEG, PNG or WebP, or nil when it has
+// none that can be parsed.
+func Read(data []byte) *Metadata {
+	t := tiffPayload(data)
+	if t == nil {
+		return nil
+	}
+	r, ok := newTIFFReader(t)
+	if !ok {
+		return nil
+	}
+
+	ifd0 := r.ifd(r.order.Uint32(t[4:8]))
+	if ifd0 == nil {
+		return nil
+	}
+	m := &Metadata{
+		CameraMake:  r.ascii(ifd0[tagMake]),
+		CameraModel: r.ascii(ifd0[tagModel]),
+		Software:    r.ascii(ifd0[tagSoftware]),
+		TakenAt:     exifTime(r.ascii(ifd0[tagDateTime])),
+	}
+	if o, ok := r.short(ifd0[tagOrientation]); ok && o >= 1 && o <= 8 {
+		m.Orientation = o
+	}
+	if off, ok := r.long(ifd0[tagExifIFD]); ok {
+		if exif := r.ifd(off); exif != nil {
+			m.LensModel = r.ascii(exif[tagLensModel])
+			if taken := exifTime(r.ascii(exif[tagDateTimeOriginal])); taken != "" {
+				m.TakenAt = taken
+			}
+		}
+	}
+	if off, ok := r.long(ifd0[tagGPSIFD]); ok {
+		if gps := r.ifd(off); gps != nil {
+			m.GPS = r.gps(gps)
+		}
+	}
+
+	if *m == (Metadata{}) {
+		return nil
+	}
+	return m
+}
+
+// exifTime reformats an EXIF date-time as RFC 3339 without a zone, or returns
+// "" when it doesn't parse. Cameras with an unset clock write zeros.
+func exifTime(s string) string {
+	t, err := time.Parse(exifDateLayout, s)
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
+
+// tiffPayload finds the TIFF structure holding a file's EXIF.
+func tiffPayload(data []byte) []byte {
+	switch {
+	case isJPEG(data):
+		var payload []byte
+		_ = walkJPEG(data, func(marker byte, segment []byte) bool {
+			if marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+				payload = segment[len(exifHeader):]
+				return false
+			}
+			return true
+		})
+		return payload
+	case isPNG(data):
+		var payload []byte
+		_ = walkPNG(data, func(typ string, chunk, _ []byte) bool {
+			if typ == "eXIf" {
+				payload = chunk
+				return false
+			}
+			return true
+		})
+		return payload
+	case isWebP(data):
+		var payload []byte
+		_ = walkWebP(data, func(fourCC string, chunk []byte) bool {
+			if fourCC == "EXIF" {
+				payload = bytes.TrimPrefix(chunk, exifHeader)
+				return false
+			}
+			return true
+		})
+		return payload
+	}
+	return nil
+}
+
+// field is one TIFF directory entry.
+type field struct {
+	typ   uint16
+	count uint32
+	value []byte // count values of typ, already resolved from the offset
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func newTIFFReader(data []byte) (*tiffReader, bool) {
+	if len(data) < 8 {
+		return nil, false
+	}
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, false
+	}
+	if order.Uint16(data[2:4]) != 0x002A {
+		return nil, false
+	}
+	return &tiffReader{data: data, order: order}, true
+}
+
+// ifd returns the entries of the directory at off, keyed by tag, or nil when
+// it lies outside the data.
+func (r *tiffReader) ifd(off uint32) map[uint16]field {
+	start := int(off)
+	if start < 8 || start+2 > len(r.data) {
+		return nil
+	}
+	n := int(r.order.Uint16(r.data[start:]))
+	fields := make(map[uint16]field, n)
+	for i := 0; i < n; i++ {
+		e := start + 2 + i*12
+		if e+12 > len(r.data) {
+			break
+		}
+		f := field{typ: r.order.Uint16(r.data[e+2:]), count: r.order.Uint32(r.data[e+4:])}
+		size := typeSize(f.typ) * uint64(f.count)
+		if size == 0 {
+			continue
+		}
+		if size <= 4 {
+			f.value = r.data[e+8 : e+8+int(size)]
+		} else {
+			at := uint64(r.order.Uint32(r.data[e+8:]))
+			if at+size > uint64(len(r.data)) {
+				continue
+			}
+			f.value = r.data[at : at+size]
+		}
+		fields[r.order.Uint16(r.data[e:])] = f
+	}
+	return fields
+}
+
+func typeSize(typ uint16) uint64 {
+	switch typ {
+	case typeASCII:
+		return 1
+	case typeShort:
+		return 2
+	case typeLong:
+		return 4
+	case typeRational:
+		return 8
+	}
+	return 0
+}
+
+func (r *tiffReader) ascii(f field) string {
+	if f.typ != typeASCII {
+		return ""
+	}
+	s, _, _ := strings.Cut(string(f.value), "\x00")
+	return strings.TrimSpace(s)
+}
+
+func (r *tiffReader) short(f field) (int, bool) {
+	if f.typ != typeShort || len(f.value) < 2 {
+		return 0, false
+	}
+	return int(r.order.Uint16(f.value)), true
+}
+
+func (r *tiffReader) long(f field) (uint32, bool) {
+	if f.typ != typeLong || len(f.value) < 4 {
+		return 0, false
+	}
+	return r.order.Uint32(f.value), true
+}
+
+// degrees converts a degrees, minutes, seconds triple of rationals.
+func (r *tiffReader) degrees(f field) (float64, bool) {
+	if f.typ != typeRational || f.count != 3 {
+		return 0, false
+	}
+	var parts [3]float64
+	for i := range parts {
+		num := r.order.Uint32(f.value[i*8:])
+		den := r.order.Uint32(f.value[i*8+4:])
+		if den == 0 {
+			return 0, false
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+	return parts[0] + parts[1]/60 + parts[2]/3600, true
+}
+
+func (r *tiffReader) gps(ifd map[uint16]field) *GPS {
+	lat, okLat := r.degrees(ifd[tagGPSLatitude])
+	lon, okLon := r.degrees(ifd[tagGPSLongitude])
+	if !okLat || !okLon || lat > 90 || lon > 180 {
+		return nil
+	}
+	if r.ascii(ifd[tagGPSLatitudeRef]) == "S" {
+		lat = -lat
+	}
+	if r.ascii(ifd[tagGPSLongitudeRef]) == "W" {
+		lon = -lon
+	}
+	round := func(v float64) float64 { return math.Round(v*1e6) / 1e6 }
+	return &GPS{Latitude: round(lat), Longitude: round(lon)}
+}
+
+// Strip returns the image without its metadata, and whether there was any to
+// remove. It drops JPEG EXIF, XMP, Photoshop/IPTC and comment segments and
+// anything after the end of the image (multi-picture extras, motion photo
+// video); PNG eXIf, text and time chunks; and WebP EXIF and XMP chunks. ICC
+// colour profiles are kept so colours don't shift. Other formats are returned
+// unchanged.
+func Strip(data []byte) ([]byte, bool, error) {
+	switch {
+	case isJPEG(data):
+		return stripJPEG(data)
+	case isPNG(data):
+		return stripPNG(data)
+	case isWebP(data):
+		return stripWebP(data)
+	}
+	return data, false, nil
+}
+
+var errMalformed = errors.New("malformed image")
+
+func isJPEG(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0xFF && data[1] == 0xD8
+}
+
+// walkJPEG calls fn with the marker and payload of each metadata segment
+// before the first scan, until fn returns false.
+func walkJPEG(data []byte, fn func(marker byte, segment []byte) bool) error {
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return errMalformed
+		}
+		marker := data[pos+1]
+		if marker == 0xFF { // fill byte
+			pos++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if size < 2 || pos+2+size > len(data) {
+			return errMalformed
+		}
+		if !fn(marker, data[pos+4:pos+2+size]) {
+			return nil
+		}
+		pos += 2 + size
+	}
+	return errMalformed
+}
+
+// keepJPEGSegment reports whether an APPn or COM segment is needed to decode
+// or colour-manage the image. Everything else in those ranges is metadata.
+func keepJPEGSegment(marker byte, segment []byte) bool {
+	switch {
+	case marker == 0xFE: // COM
+		return false
+	case marker == 0xE0: // APP0: JFIF
+		return true
+	case marker == 0xE2: // APP2: ICC profile, or multi-picture index
+		return bytes.HasPrefix(segment, []byte("ICC_PROFILE\x00"))
+	case marker == 0xEE: // APP14: Adobe colour transform
+		return true
+	case marker >= 0xE1 && marker <= 0xEF:
+		return false
+	}
+	return true
+}
+
+func stripJPEG(data []byte) ([]byte, bool, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	changed := false
+	pos := 2
+	for {
+		if pos+2 > len(data) || data[pos] != 0xFF {
+			return nil, false, fmt.Errorf("jpeg: %w", errMalformed)
+		}
+		marker := data[pos+1]
+		switch {
+		case marker == 0xFF:
+			pos++
+			continue
+		case marker == 0xD9: // EOI
+			out = append(out, 0xFF, 0xD9)
+			if pos+2 < len(data) {
+				changed = true
+			}
+			if !changed {
+				return data, false, nil
+			}
+			return out, true, nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // TEM, RSTn: no length
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return nil, false, fmt.Errorf("jpeg: %w", errMalformed)
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + size
+		if size < 2 || end > len(data) {
+			return nil, false, fmt.Errorf("jpeg: %w", errMalformed)
+		}
+		if !keepJPEGSegment(marker, data[pos+4:end]) {
+			changed = true
+			pos = end
+			continue
+		}
+		out = append(out, data[pos:end]...)
+		pos = end
+
+		if marker == 0xDA { // SOS: copy entropy-coded data up to the next marker
+			scan := pos
+			for scan+1 < len(data) && (data[scan] != 0xFF || data[scan+1] == 0x00 ||
+				(data[scan+1] >= 0xD0 && data[scan+1] <= 0xD7)) {
+				scan++
+			}
+			if scan+1 >= len(data) {
+				return nil, false, fmt.Errorf("jpeg: %w", errMalformed)
+			}
+			out = append(out, data[pos:scan]...)
+			pos = scan
+		}
+	}
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+func isPNG(data []byte) bool {
+	return bytes.HasPrefix(data, pngSignature)
+}
+
+// pngMetadataChunks are the ancillary chunks that hold metadata.
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// walkPNG calls fn with the type and data of each chunk, and the whole chunk
+// including its length and CRC, until fn returns false.
+func walkPNG(data []byte, fn func(typ string, chunk, raw []byte) bool) error {
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+12 > len(data) {
+			return errMalformed
+		}
+		n := int(binary.BigEndian.Uint32(data[pos:]))
+		if n < 0 || pos+12+n > len(data) {
+			return errMalformed
+		}
+		if !fn(string(data[pos+4:pos+8]), data[pos+8:pos+8+n], data[pos:pos+12+n]) {
+			return nil
+		}
+		pos += 12 + n
+	}
+	return nil
+}
+
+func stripPNG(data []byte) ([]byte, bool, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	changed := false
+	err := walkPNG(data, func(typ string, _, raw []byte) bool {
+		if pngMetadataChunks[typ] {
+			changed = true
+		} else {
+			out = append(out, raw...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("png: %w", err)
+	}
+	if !changed {
+		return data, false, nil
+	}
+	return out, true, nil
+}
+
+func isWebP(data []byte) bool {
+	return len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
+
+// VP8X flags announcing EXIF and XMP chunks.
+const (
+	vp8xFlagEXIF = 0x08
+	vp8xFlagXMP  = 0x04
+)
+
+// walkWebP calls fn with the FourCC and payload of each RIFF chunk until fn
+// returns false.
+func walkWebP(data []byte, fn func(fourCC string, chunk []byte) bool) error {
+	pos := 12
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return errMalformed
+		}
+		n := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		if n < 0 || pos+8+n > len(data) {
+			return errMalformed
+		}
+		if !fn(string(data[pos:pos+4]), data[pos+8:pos+8+n]) {
+			return nil
+		}
+		pos += 8 + n + n%2
+	}
+	return nil
+}
+
+func stripWebP(data []byte) ([]byte, bool, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:12]...)
+	changed := false
+	err := walkWebP(data, func(fourCC string, chunk []byte) bool {
+		if fourCC == "EXIF" || fourCC == "XMP " {
+			changed = true
+			return true
+		}
+		start := len(out)
+		out = append(out, fourCC...)
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(chunk)))
+		out = append(out, chunk...)
+		if len(chunk)%2 == 1 {
+			out = append(out, 0)
+		}
+		if fourCC == "VP8X" && len(chunk) > 0 {
+			out[start+8] &^= vp8xFlagEXIF | vp8xFlagXMP
+		}
+		return true
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("webp: %w", err)
+	}
+	if !changed {
+		return data, false, nil
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, true, nil
+}
@@ -0,0 +1,320 @@
+package photometa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// byteOrder is binary.LittleEndian or binary.BigEndian.
+type byteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+type entry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+func asciiEntry(tag uint16, s string) entry {
+	return entry{tag: tag, typ: typeASCII, count: uint32(len(s) + 1), value: append([]byte(s), 0)}
+}
+
+func shortEntry(order byteOrder, tag uint16, v uint16) entry {
+	return entry{tag: tag, typ: typeShort, count: 1, value: order.AppendUint16(nil, v)}
+}
+
+// dmsEntry encodes degrees, minutes and hundredths of seconds as three rationals.
+func dmsEntry(order byteOrder, tag uint16, d, m, cs uint32) entry {
+	var b []byte
+	for _, r := range [][2]uint32{{d, 1}, {m, 1}, {cs, 100}} {
+		b = order.AppendUint32(b, r[0])
+		b = order.AppendUint32(b, r[1])
+	}
+	return entry{tag: tag, typ: typeRational, count: 3, value: b}
+}
+
+// buildTIFF lays out IFD0 with pointers to the Exif and GPS IFDs when given,
+// followed by the values that don't fit in their entries.
+func buildTIFF(order byteOrder, ifd0, exif, gps []entry) []byte {
+	size := func(es []entry) int {
+		if es == nil {
+			return 0
+		}
+		return 2 + 12*len(es) + 4
+	}
+	if exif != nil {
+		ifd0 = append(ifd0, entry{tag: tagExifIFD, typ: typeLong, count: 1})
+	}
+	if gps != nil {
+		ifd0 = append(ifd0, entry{tag: tagGPSIFD, typ: typeLong, count: 1})
+	}
+	offExif := 8 + size(ifd0)
+	offGPS := offExif + size(exif)
+	for i := range ifd0 {
+		switch ifd0[i].tag {
+		case tagExifIFD:
+			ifd0[i].value = order.AppendUint32(nil, uint32(offExif))
+		case tagGPSIFD:
+			ifd0[i].value = order.AppendUint32(nil, uint32(offGPS))
+		}
+	}
+
+	out := []byte("II")
+	if order == binary.BigEndian {
+		out = []byte("MM")
+	}
+	out = order.AppendUint16(out, 0x002A)
+	out = order.AppendUint32(out, 8)
+	var extra []byte
+	extraAt := offGPS + size(gps)
+	for _, ifd := range [][]entry{ifd0, exif, gps} {
+		if ifd == nil {
+			continue
+		}
+		out = order.AppendUint16(out, uint16(len(ifd)))
+		for _, e := range ifd {
+			out = order.AppendUint16(out, e.tag)
+			out = order.AppendUint16(out, e.typ)
+			out = order.AppendUint32(out, e.count)
+			if len(e.value) <= 4 {
+				out = append(out, e.value...)
+				out = append(out, make([]byte, 4-len(e.value))...)
+				continue
+			}
+			out = order.AppendUint32(out, uint32(extraAt+len(extra)))
+			extra = append(extra, e.value...)
+		}
+		out = order.AppendUint32(out, 0)
+	}
+	return append(out, extra...)
+}
+
+func phoneTIFF(order byteOrder) []byte {
+	return buildTIFF(order,
+		[]entry{
+			asciiEntry(tagMake, "Apple"),
+			asciiEntry(tagModel, "iPhone 15 Pro"),
+			shortEntry(order, tagOrientation, 6),
+			asciiEntry(tagDateTime, "2025:05:02 10:00:00"),
+		},
+		[]entry{
+			asciiEntry(tagDateTimeOriginal, "2025:05:01 14:03:22"),
+			asciiEntry(tagLensModel, "iPhone 15 Pro back camera"),
+		},
+		[]entry{
+			asciiEntry(tagGPSLatitudeRef, "N"),
+			dmsEntry(order, tagGPSLatitude, 37, 46, 3000), // 37°46'30"
+			asciiEntry(tagGPSLongitudeRef, "W"),
+			dmsEntry(order, tagGPSLongitude, 122, 25, 900), // 122°25'9"
+		},
+	)
+}
+
+func testImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 16), G: uint8(y * 32), A: 255})
+		}
+	}
+	return img
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker}
+	seg = binary.BigEndian.AppendUint16(seg, uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+// phoneJPEG is a JPEG carrying EXIF, XMP, an ICC profile, a comment and a
+// trailing extra image, as phone cameras write them.
+func phoneJPEG(t *testing.T, order byteOrder) []byte {
+	t.Helper()
+	var enc bytes.Buffer
+	require.NoError(t, jpeg.Encode(&enc, testImage(), &jpeg.Options{Quality: 90}))
+	raw := enc.Bytes()
+
+	out := append([]byte{}, raw[:2]...)
+	out = append(out, jpegSegment(0xE1, append([]byte("Exif\x00\x00"), phoneTIFF(order)...))...)
+	out = append(out, jpegSegment(0xE1, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>"))...)
+	out = append(out, jpegSegment(0xE2, []byte("ICC_PROFILE\x00\x01\x01profile"))...)
+	out = append(out, jpegSegment(0xE2, []byte("MPF\x00index"))...)
+	out = append(out, jpegSegment(0xFE, []byte("taken at 12 Elm St"))...)
+	out = append(out, raw[2:]...)
+	return append(out, []byte("\xFF\xD8depth map")...)
+}
+
+func pngChunk(typ string, data []byte) []byte {
+	c := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	c = append(c, typ...)
+	c = append(c, data...)
+	return binary.BigEndian.AppendUint32(c, crc32.ChecksumIEEE(c[4:]))
+}
+
+func phonePNG(t *testing.T) []byte {
+	t.Helper()
+	var enc bytes.Buffer
+	require.NoError(t, png.Encode(&enc, testImage()))
+	raw := enc.Bytes()
+
+	// IHDR is 8+4+4+13+4 bytes into the file; metadata chunks follow it.
+	ihdrEnd := 8 + 25
+	out := append([]byte{}, raw[:ihdrEnd]...)
+	out = append(out, pngChunk("eXIf", phoneTIFF(binary.BigEndian))...)
+	out = append(out, pngChunk("tEXt", []byte("Comment\x00taken at 12 Elm St"))...)
+	out = append(out, pngChunk("iCCP", []byte("profile\x00\x00data"))...)
+	return append(out, raw[ihdrEnd:]...)
+}
+
+func webpChunk(fourCC string, data []byte) []byte {
+	c := append([]byte(fourCC), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+	c = append(c, data...)
+	if len(data)%2 == 1 {
+		c = append(c, 0)
+	}
+	return c
+}
+
+func phoneWebP() []byte {
+	body := []byte("WEBP")
+	body = append(body, webpChunk("VP8X", []byte{0x2C, 0, 0, 0, 15, 0, 0, 7, 0, 0})...) // ICC, EXIF, XMP
+	body = append(body, webpChunk("ICCP", []byte("profile"))...)
+	body = append(body, webpChunk("VP8 ", []byte("bitstream"))...)
+	body = append(body, webpChunk("EXIF", append([]byte("Exif\x00\x00"), phoneTIFF(binary.LittleEndian)...))...)
+	body = append(body, webpChunk("XMP ", []byte("<x:xmpmeta/>"))...)
+	out := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	return append(out, body...)
+}
+
+var phoneMetadata = &Metadata{
+	CameraMake:  "Apple",
+	CameraModel: "iPhone 15 Pro",
+	LensModel:   "iPhone 15 Pro back camera",
+	TakenAt:     "2025-05-01T14:03:22",
+	Orientation: 6,
+	GPS:         &GPS{Latitude: 37.775, Longitude: -122.419167},
+}
+
+func TestRead(t *testing.T) {
+	testCases := []struct {
+		name string
+		data []byte
+		want *Metadata
+	}{
+		{name: "success: little-endian jpeg", data: phoneJPEG(t, binary.LittleEndian), want: phoneMetadata},
+		{name: "success: big-endian jpeg", data: phoneJPEG(t, binary.BigEndian), want: phoneMetadata},
+		{name: "success: png eXIf chunk", data: phonePNG(t), want: phoneMetadata},
+		{name: "success: webp EXIF chunk", data: phoneWebP(), want: phoneMetadata},
+		{
+			name: "success: camera clock never set",
+			data: append([]byte("\xFF\xD8"), jpegSegment(0xE1, append([]byte("Exif\x00\x00"),
+				buildTIFF(binary.BigEndian, []entry{asciiEntry(tagDateTime, "0000:00:00 00:00:00"),
+					asciiEntry(tagModel, "X100")}, nil, nil)...))...),
+			want: &Metadata{CameraModel: "X100"},
+		},
+		{name: "success: no exif", data: []byte("\xFF\xD8\xFF\xD9")},
+		{name: "success: not an image", data: []byte("hello")},
+		{
+			name: "success: truncated exif",
+			data: append([]byte("\xFF\xD8"), jpegSegment(0xE1, []byte("Exif\x00\x00II*\x00\xFF\x00\x00\x00"))...),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Read(tc.data))
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	t.Run("success: jpeg keeps pixels and colour profile only", func(t *testing.T) {
+		in := phoneJPEG(t, binary.LittleEndian)
+		out, changed, err := Strip(in)
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		assert.Nil(t, Read(out))
+		assert.NotContains(t, string(out), "Exif")
+		assert.NotContains(t, string(out), "xmpmeta")
+		assert.NotContains(t, string(out), "MPF")
+		assert.NotContains(t, string(out), "Elm St")
+		assert.NotContains(t, string(out), "depth map")
+		assert.Contains(t, string(out), "ICC_PROFILE")
+		assert.Equal(t, []byte{0xFF, 0xD9}, out[len(out)-2:])
+
+		want, err := jpeg.Decode(bytes.NewReader(in))
+		require.NoError(t, err)
+		got, err := jpeg.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, want, got, "entropy-coded data is copied unchanged")
+	})
+
+	t.Run("success: png drops metadata chunks", func(t *testing.T) {
+		out, changed, err := Strip(phonePNG(t))
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.NotContains(t, string(out), "eXIf")
+		assert.NotContains(t, string(out), "Elm St")
+		assert.Contains(t, string(out), "iCCP")
+		_, err = png.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+	})
+
+	t.Run("success: webp drops chunks and clears their flags", func(t *testing.T) {
+		out, changed, err := Strip(phoneWebP())
+		require.NoError(t, err)
+		assert.True(t, changed)
+
+		var chunks []string
+		require.NoError(t, walkWebP(out, func(fourCC string, chunk []byte) bool {
+			chunks = append(chunks, fourCC)
+			if fourCC == "VP8X" {
+				assert.Equal(t, byte(0x20), chunk[0], "only the ICC flag is left")
+			}
+			return true
+		}))
+		assert.Equal(t, []string{"VP8X", "ICCP", "VP8 "}, chunks)
+		assert.Equal(t, uint32(len(out)-8), binary.LittleEndian.Uint32(out[4:]))
+	})
+
+	t.Run("success: nothing to strip", func(t *testing.T) {
+		var enc bytes.Buffer
+		require.NoError(t, jpeg.Encode(&enc, testImage(), nil))
+		in := enc.Bytes()
+		out, changed, err := Strip(in)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, in, out)
+	})
+
+	t.Run("success: other formats pass through", func(t *testing.T) {
+		out, changed, err := Strip([]byte("GIF89a"))
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, []byte("GIF89a"), out)
+	})
+
+	t.Run("fail: truncated jpeg", func(t *testing.T) {
+		in := phoneJPEG(t, binary.LittleEndian)
+		_, _, err := Strip(in[:len(in)/2])
+		assert.ErrorIs(t, err, errMalformed)
+	})
+
+	t.Run("fail: truncated png", func(t *testing.T) {
+		in := phonePNG(t)
+		_, _, err := Strip(in[:40])
+		assert.ErrorIs(t, err, errMalformed)
+	})
+}
@@ -0,0 +1,29 @@
+package processor
+
+import (
+	"slices"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// FallbackPolicy lists the models a stage job falls back to, in order, when
+// its model fails. MaxAttempts caps how many models one job runs, the first
+// included; below 2 there is no fallback.
+type FallbackPolicy struct {
+	Models      []model.ID
+	MaxAttempts int
+}
+
+// next returns the models to try after primary fails, without repeats.
+func (f FallbackPolicy) next(primary model.ID) []model.ID {
+	tried := []model.ID{primary}
+	for _, m := range f.Models {
+		if len(tried) >= f.MaxAttempts {
+			break
+		}
+		if !slices.Contains(tried, m) {
+			tried = append(tried, m)
+		}
+	}
+	return tried[1:]
+}
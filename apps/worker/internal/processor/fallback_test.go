@@ -0,0 +1,57 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestFallbackPolicy_Next(t *testing.T) {
+	chain := []model.ID{model.ModelFluxKontextPro, model.ModelQwenImageEdit, model.ModelSeedream4}
+
+	testCases := []struct {
+		name     string
+		policy   FallbackPolicy
+		primary  model.ID
+		expected []model.ID
+	}{
+		{
+			name:     "success: next model in the chain",
+			policy:   FallbackPolicy{Models: chain, MaxAttempts: 2},
+			primary:  model.ModelSeedream4,
+			expected: []model.ID{model.ModelFluxKontextPro},
+		},
+		{
+			name:     "success: skips the model that failed",
+			policy:   FallbackPolicy{Models: chain, MaxAttempts: 3},
+			primary:  model.ModelFluxKontextPro,
+			expected: []model.ID{model.ModelQwenImageEdit, model.ModelSeedream4},
+		},
+		{
+			name:     "success: chain shorter than the attempts",
+			policy:   FallbackPolicy{Models: chain[:1], MaxAttempts: 5},
+			primary:  model.ModelQwenImageEdit,
+			expected: []model.ID{model.ModelFluxKontextPro},
+		},
+		{
+			name:     "success: one attempt disables fallback",
+			policy:   FallbackPolicy{Models: chain, MaxAttempts: 1},
+			primary:  model.ModelQwenImageEdit,
+			expected: []model.ID{},
+		},
+		{
+			name:     "success: empty chain",
+			policy:   FallbackPolicy{MaxAttempts: 3},
+			primary:  model.ModelQwenImageEdit,
+			expected: []model.ID{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.next(tc.primary))
+		})
+	}
+}
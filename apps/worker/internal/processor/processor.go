@@ -3,23 +3,59 @@ package processor
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
+	"github.com/real-staging-ai/api/modelconfig"
+	"github.com/real-staging-ai/api/watermark"
+
+	"github.com/real-staging-ai/worker/internal/breaker"
+	"github.com/real-staging-ai/worker/internal/delivery"
 	"github.com/real-staging-ai/worker/internal/events"
+	"github.com/real-staging-ai/worker/internal/heartbeat"
+	"github.com/real-staging-ai/worker/internal/i18n"
+	"github.com/real-staging-ai/worker/internal/imagecheck"
 	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/malware"
+	"github.com/real-staging-ai/worker/internal/quality"
 	"github.com/real-staging-ai/worker/internal/queue"
 	"github.com/real-staging-ai/worker/internal/repository"
+	"github.com/real-staging-ai/worker/internal/settings"
 	"github.com/real-staging-ai/worker/internal/staging"
 	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/staging/prompt"
+	"github.com/real-staging-ai/worker/internal/thumbnail"
 )
 
 // SettingsRepository defines interface for getting settings.
 type SettingsRepository interface {
 	GetActiveModel(ctx context.Context) (model.ID, error)
+	GetModelVersion(ctx context.Context, modelID model.ID) (string, error)
+	IsModelEnabled(ctx context.Context, modelID model.ID) (bool, error)
+	GetPromptOverride(ctx context.Context, roomType, style string) (string, error)
+	GetPromptExperiment(ctx context.Context, roomType, style string) (*settings.PromptExperiment, error)
+	GetWatermark(ctx context.Context, imageID string) (*watermark.Options, error)
+	GetPromptLocale(ctx context.Context, imageID string) (string, error)
+}
+
+// TaskEnqueuer queues follow-up tasks from a running job.
+type TaskEnqueuer interface {
+	Enqueue(ctx context.Context, taskType string, payload []byte) error
+}
+
+// StageRequeuer puts a stage job back on the staging queue.
+type StageRequeuer interface {
+	RequeueStage(ctx context.Context, payload []byte) error
 }
 
 // ImageProcessor handles image processing jobs.
@@ -28,20 +64,48 @@ type ImageProcessor struct {
 	stagingService staging.Service
 	publisher      events.Publisher
 	settingsRepo   SettingsRepository
+	deliverer      delivery.Deliverer
+	assetRepo      repository.AssetRepository
+	tasks          TaskEnqueuer    // nil disables thumbnails
+	heartbeats     heartbeat.Store // nil disables stage job heartbeats
+	beatInterval   time.Duration
+	fallback       FallbackPolicy
+	breaker        *breaker.Breaker // nil disables the circuit breaker
+	requeuer       StageRequeuer    // nil fails low-quality images without a retry
 }
 
-// NewImageProcessor creates a new image processor.
+// NewImageProcessor creates a new image processor. Stage jobs queue thumbnail
+// tasks on tasks, send a heartbeat to heartbeats every beatInterval while they
+// run and retry a failed staging run with the models in fallback. Stage jobs
+// for a model whose circuit is open in breaker are deferred until it probes.
+// A job whose staged image fails a quality check is requeued on requeuer once.
 func NewImageProcessor(
 	imageRepo repository.ImageRepository,
 	stagingService staging.Service,
 	publisher events.Publisher,
 	settingsRepo SettingsRepository,
+	deliverer delivery.Deliverer,
+	assetRepo repository.AssetRepository,
+	tasks TaskEnqueuer,
+	heartbeats heartbeat.Store,
+	beatInterval time.Duration,
+	fallback FallbackPolicy,
+	breaker *breaker.Breaker,
+	requeuer StageRequeuer,
 ) *ImageProcessor {
 	return &ImageProcessor{
 		imageRepo:      imageRepo,
 		stagingService: stagingService,
 		publisher:      publisher,
 		settingsRepo:   settingsRepo,
+		deliverer:      deliverer,
+		assetRepo:      assetRepo,
+		tasks:          tasks,
+		heartbeats:     heartbeats,
+		beatInterval:   beatInterval,
+		fallback:       fallback,
+		breaker:        breaker,
+		requeuer:       requeuer,
 	}
 }
 
@@ -53,6 +117,52 @@ type JobPayload struct {
 	Style       *string `json:"style,omitempty"`
 	Seed        *int64  `json:"seed,omitempty"`
 	Prompt      *string `json:"prompt,omitempty"`
+	// ModelID is the model the API resolved for the job (request, project or
+	// user preference); empty uses the active model.
+	ModelID *string `json:"model_id,omitempty"`
+	// Sandbox images are staged by the fake provider instead of the active model.
+	Sandbox bool `json:"sandbox,omitempty"`
+	// MaskURL limits staging to the white areas of a PNG mask; nil stages the
+	// whole photo. Only inpainting models take one.
+	MaskURL *string `json:"mask_url,omitempty"`
+	// Mode is "declutter" to empty the room or "both" to empty and then stage
+	// it; empty stages the photo as it is.
+	Mode string `json:"mode,omitempty"`
+	// ModelConfig overrides fields of the admin's config for ModelID, as set
+	// by the style preset the image was created from. Fallback models run
+	// with their own config.
+	ModelConfig map[string]interface{} `json:"model_config,omitempty"`
+	// PredictionID is set when a stalled job is requeued so the new attempt
+	// resumes the prediction the stalled one started.
+	PredictionID string `json:"prediction_id,omitempty"`
+	// InputFiles are the Replicate files an interrupted attempt uploaded for
+	// its prediction, which the attempt resuming it deletes once done.
+	InputFiles []string `json:"input_files,omitempty"`
+	// QualityRetry is set on the job requeued after a staged image failed a
+	// quality check; if this attempt fails one too, the image is an error.
+	QualityRetry bool `json:"quality_retry,omitempty"`
+	// Language is the language the image was requested in; empty is English.
+	// Error messages and the default watermark are translated into it, and
+	// the prompt is adapted to it.
+	Language string `json:"language,omitempty"`
+}
+
+// CutoutJobPayload represents the payload for a cutout pipeline job.
+type CutoutJobPayload struct {
+	ImageID   string `json:"image_id"`
+	StagedURL string `json:"staged_url"`
+}
+
+// ThumbnailJobPayload represents the payload for a thumbnail job.
+type ThumbnailJobPayload struct {
+	ImageID   string           `json:"image_id"`
+	Source    thumbnail.Source `json:"source"`
+	SourceURL string           `json:"source_url"`
+}
+
+// DeliveryJobPayload represents the payload for an outbound delivery job.
+type DeliveryJobPayload struct {
+	DeliveryID string `json:"delivery_id"`
 }
 
 // ProcessJob processes a job based on its type.
@@ -68,8 +178,14 @@ func (p *ImageProcessor) ProcessJob(ctx context.Context, job *queue.Job) error {
 	defer span.End()
 
 	switch job.Type {
-	case "stage:run":
+	case "stage:run", "declutter:run":
 		return p.processStageJob(ctx, job)
+	case "delivery:send":
+		return p.processDeliveryJob(ctx, job)
+	case "cutout:run":
+		return p.processCutoutJob(ctx, job)
+	case "thumbnail:run":
+		return p.processThumbnailJob(ctx, job)
 	default:
 		err := fmt.Errorf("unknown job type: %s", job.Type)
 		span.RecordError(err)
@@ -78,6 +194,185 @@ func (p *ImageProcessor) ProcessJob(ctx context.Context, job *queue.Job) error {
 	}
 }
 
+// processDeliveryJob sends an outbound webhook or email from the outbox.
+func (p *ImageProcessor) processDeliveryJob(ctx context.Context, job *queue.Job) error {
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processDeliveryJob")
+	defer span.End()
+
+	var payload DeliveryJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal job payload")
+		return fmt.Errorf("failed to unmarshal job payload: %w", err)
+	}
+	if payload.DeliveryID == "" {
+		err := fmt.Errorf("missing required field: delivery_id")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	span.SetAttributes(attribute.String("delivery.id", payload.DeliveryID))
+
+	if p.deliverer == nil {
+		err := fmt.Errorf("outbound deliveries are not configured")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	if err := p.deliverer.Deliver(ctx, payload.DeliveryID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "delivery failed")
+		return err
+	}
+	return nil
+}
+
+// processCutoutJob cuts individual furniture pieces out of a staged image and
+// records them as child assets. The image itself is left untouched.
+func (p *ImageProcessor) processCutoutJob(ctx context.Context, job *queue.Job) error {
+	log := logging.Default()
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processCutoutJob")
+	defer span.End()
+
+	var payload CutoutJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal job payload")
+		return fmt.Errorf("failed to unmarshal job payload: %w", err)
+	}
+	if payload.ImageID == "" || payload.StagedURL == "" {
+		err := fmt.Errorf("missing required field: image_id and staged_url are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	span.SetAttributes(attribute.String("image.id", payload.ImageID))
+
+	if p.assetRepo == nil {
+		err := fmt.Errorf("cutout pipeline is not configured")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	if err := p.assetRepo.SetCutoutsProcessing(ctx, payload.ImageID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "set processing failed")
+		return fmt.Errorf("failed to mark cutouts as processing: %w", err)
+	}
+
+	cutouts, err := p.stagingService.CreateCutouts(ctx, &staging.CutoutRequest{
+		ImageID:   payload.ImageID,
+		StagedURL: payload.StagedURL,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "cutout failed")
+		log.Error(ctx, "Failed to create cutouts", "image_id", payload.ImageID, "error", err)
+		if setErr := p.assetRepo.SetCutoutsError(ctx, payload.ImageID, err.Error()); setErr != nil {
+			log.Error(ctx, "Failed to mark cutouts as error", "image_id", payload.ImageID, "error", setErr)
+		}
+		return fmt.Errorf("failed to create cutouts: %w", err)
+	}
+
+	assets := make([]repository.CutoutAsset, 0, len(cutouts))
+	for _, c := range cutouts {
+		assets = append(assets, repository.CutoutAsset{
+			URL: c.URL, Width: c.Width, Height: c.Height, SourceX: c.X, SourceY: c.Y,
+		})
+	}
+	if err := p.assetRepo.ReplaceCutouts(ctx, payload.ImageID, assets); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "record cutouts failed")
+		return fmt.Errorf("failed to record cutouts: %w", err)
+	}
+
+	log.Info(ctx, "Cutouts created", "image_id", payload.ImageID, "count", len(cutouts))
+	span.SetStatus(codes.Ok, "cutouts created")
+	return nil
+}
+
+// processThumbnailJob makes the thumbnails of one of an image's files and
+// records them as child assets, replacing any earlier set for that file.
+func (p *ImageProcessor) processThumbnailJob(ctx context.Context, job *queue.Job) error {
+	log := logging.Default()
+	tracer := otel.Tracer("real-staging-worker/processor")
+	ctx, span := tracer.Start(ctx, "processor.processThumbnailJob")
+	defer span.End()
+
+	var payload ThumbnailJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unmarshal job payload")
+		return fmt.Errorf("failed to unmarshal job payload: %w", err)
+	}
+	if payload.ImageID == "" || payload.SourceURL == "" || !payload.Source.Valid() {
+		err := fmt.Errorf("missing required field: image_id, source_url and a source of original or staged are required")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	span.SetAttributes(
+		attribute.String("image.id", payload.ImageID),
+		attribute.String("thumbnail.source", string(payload.Source)),
+	)
+
+	if p.assetRepo == nil {
+		err := fmt.Errorf("thumbnail pipeline is not configured")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	thumbs, err := p.stagingService.CreateThumbnails(ctx, &staging.ThumbnailRequest{
+		ImageID:   payload.ImageID,
+		Source:    payload.Source,
+		SourceURL: payload.SourceURL,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "thumbnail failed")
+		log.Error(ctx, "Failed to create thumbnails", "image_id", payload.ImageID,
+			"source", string(payload.Source), "error", err)
+		return fmt.Errorf("failed to create thumbnails: %w", err)
+	}
+
+	assets := make([]repository.ThumbnailAsset, 0, len(thumbs))
+	for _, t := range thumbs {
+		assets = append(assets, repository.ThumbnailAsset{
+			Size: string(t.Size), URL: t.URL, Width: t.Width, Height: t.Height,
+		})
+	}
+	if err := p.assetRepo.ReplaceThumbnails(ctx, payload.ImageID, string(payload.Source), assets); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "record thumbnails failed")
+		return fmt.Errorf("failed to record thumbnails: %w", err)
+	}
+
+	log.Info(ctx, "Thumbnails created", "image_id", payload.ImageID, "source", string(payload.Source))
+	span.SetStatus(codes.Ok, "thumbnails created")
+	return nil
+}
+
+// enqueueThumbnails queues a thumbnail:run task for one of the image's files.
+// Thumbnails are an optimisation, so failures are logged and the stage job
+// carries on; clients fall back to the full-size file.
+func (p *ImageProcessor) enqueueThumbnails(ctx context.Context, imageID string, source thumbnail.Source, url string) {
+	if p.tasks == nil {
+		return
+	}
+	log := logging.Default()
+	payload, err := json.Marshal(ThumbnailJobPayload{ImageID: imageID, Source: source, SourceURL: url})
+	if err == nil {
+		err = p.tasks.Enqueue(ctx, "thumbnail:run", payload)
+	}
+	if err != nil {
+		log.Warn(ctx, "Failed to queue thumbnails", "image_id", imageID, "source", string(source), "error", err)
+	}
+}
+
 // processStageJob processes an image staging job.
 func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) error {
 	log := logging.Default()
@@ -106,25 +401,134 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 		span.SetStatus(codes.Error, err.Error())
 		return err
 	}
+	if job.Type == "declutter:run" && !prompt.Declutters(payload.Mode) {
+		payload.Mode = prompt.ModeDeclutter
+	}
 
 	span.SetAttributes(
 		attribute.String("image.id", payload.ImageID),
+		attribute.Bool("image.sandbox", payload.Sandbox),
+		attribute.String("image.mode", payload.Mode),
 	)
 
 	log.Info(ctx, fmt.Sprintf("Processing stage job for image %s", payload.ImageID))
 
-	// Get active model from database
-	activeModel, err := p.settingsRepo.GetActiveModel(ctx)
+	predictionID, onPrediction, release, err := p.startHeartbeat(ctx, payload, job.Payload)
+	if errors.Is(err, heartbeat.ErrInFlight) {
+		// asynq's lease recovery and the stalled-job monitor can both requeue a job;
+		// whichever attempt runs first owns it.
+		log.Warn(ctx, "Stage job already in flight on another worker, skipping", "image_id", payload.ImageID)
+		span.SetStatus(codes.Ok, "duplicate skipped")
+		return nil
+	}
+	release = sync.OnceFunc(release)
+	defer release()
+
+	// Track the latest prediction, which a job interrupted by shutdown hands over.
+	var lastPrediction atomic.Value
+	lastPrediction.Store(predictionID)
+	recordPrediction := onPrediction
+	onPrediction = func(id string) {
+		lastPrediction.Store(id)
+		recordPrediction(id)
+	}
+	// Replicate files an interrupted prediction may still read go with it.
+	var inputFiles []string
+
+	// Jobs canceled while queued may still be picked up when the API couldn't
+	// take them off the queue.
+	if p.canceled(ctx, payload.ImageID) {
+		log.Info(ctx, "Stage job was canceled, skipping", "image_id", payload.ImageID)
+		span.SetStatus(codes.Ok, "canceled")
+		return nil
+	}
+
+	// Sandbox images always use the fake provider; everything else uses the job's model, or
+	// the active model from the database when the job has none
+	activeModel := staging.FakeModelID
+	if !payload.Sandbox && payload.ModelID != nil && *payload.ModelID != "" {
+		activeModel = model.ID(*payload.ModelID)
+	} else if !payload.Sandbox {
+		var err error
+		activeModel, err = p.settingsRepo.GetActiveModel(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to get active model")
+			log.Error(ctx, "Failed to get active model", "image_id", payload.ImageID, "error", err)
+			return fmt.Errorf("failed to get active model: %w", err)
+		}
+	}
+
+	// Pinned models stage with an exact version; history records it as "<model>:<version>"
+	// so a canary can be compared with the pinned version.
+	modelVersion := ""
+	modelUsed := string(activeModel)
+	if !payload.Sandbox {
+		var err error
+		modelVersion, modelUsed, err = p.pinnedModel(ctx, activeModel)
+		if errors.Is(err, errModelDisabled) {
+			p.failUnusableModel(ctx, payload.ImageID, err)
+			span.SetStatus(codes.Ok, "model disabled")
+			return nil
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to get model version")
+			log.Error(ctx, "Failed to get model version", "image_id", payload.ImageID, "error", err)
+			return err
+		}
+	}
+	log.Info(ctx, "Using model for staging", "model_id", string(activeModel), "version", modelVersion,
+		"image_id", payload.ImageID)
+
+	// Only inpainting models can keep the unmasked part of the photo; staging
+	// the whole room with another would undo what the mask was for.
+	if !payload.Sandbox && payload.MaskURL != nil && !modelconfig.Inpaints(string(activeModel)) {
+		p.failUnusableModel(ctx, payload.ImageID, fmt.Errorf("%w: %s", errMaskUnsupported, activeModel))
+		span.SetStatus(codes.Ok, "mask unsupported")
+		return nil
+	}
+
+	// While the model's provider is down the job waits in the queue, still
+	// queued, rather than failing the image.
+	if !payload.Sandbox && p.breaker != nil {
+		if retryAt, ok := p.breaker.Allow(activeModel); !ok {
+			log.Warn(ctx, "Model circuit is open, deferring stage job", "image_id", payload.ImageID,
+				"model_id", string(activeModel), "retry_at", retryAt)
+			span.SetStatus(codes.Ok, "deferred")
+			return queue.Defer(retryAt)
+		}
+	}
+
+	// Watermarked projects must never publish an unmarked image, so a failed
+	// lookup fails the job and asynq retries it.
+	mark, err := p.settingsRepo.GetWatermark(ctx, payload.ImageID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get watermark")
+		log.Error(ctx, "Failed to get watermark", "image_id", payload.ImageID, "error", err)
+		return fmt.Errorf("failed to get watermark: %w", err)
+	}
+	if mark != nil && mark.Text == watermark.DefaultText {
+		mark.Text = i18n.T(payload.Language, mark.Text)
+	}
+
+	// Scan the upload before anything else reads it. A scan that can't run
+	// fails the job so asynq retries it; an unscanned file is never staged.
+	quarantined, err := p.scanOriginal(ctx, payload)
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "failed to get active model")
-		log.Error(ctx, "Failed to get active model", "image_id", payload.ImageID, "error", err)
-		return fmt.Errorf("failed to get active model: %w", err)
+		span.SetStatus(codes.Error, "malware scan failed")
+		return err
+	}
+	if quarantined {
+		span.SetStatus(codes.Ok, "original quarantined")
+		return nil
 	}
-	log.Info(ctx, "Using model for staging", "model_id", string(activeModel), "image_id", payload.ImageID)
 
 	// Mark image as processing
-	if err := p.imageRepo.SetProcessing(ctx, payload.ImageID); err != nil {
+	startedAt := time.Now()
+	if err := p.imageRepo.SetProcessing(ctx, payload.ImageID, modelUsed); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "set processing failed")
 		log.Error(ctx, "Failed to mark image as processing", "image_id", payload.ImageID, "error", err)
@@ -140,16 +544,78 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 		// Don't fail the job if SSE publish fails
 	}
 
+	// Reject files that aren't usable images before spending a model run on them.
+	if rejected := p.validateOriginal(ctx, payload); rejected {
+		span.SetStatus(codes.Ok, "original rejected")
+		return nil
+	}
+
+	// Fix sideways originals and strip their GPS and camera metadata before
+	// staging, so the model and the UI both see them upright and nothing
+	// private is shared with the model provider or listing viewers.
+	p.preprocessOriginal(ctx, payload.ImageID, payload.OriginalURL)
+	p.enqueueThumbnails(ctx, payload.ImageID, thumbnail.SourceOriginal, payload.OriginalURL)
+
+	// Photos uploaded without a room type get the prompt for the room the
+	// detection model sees, when it is sure enough.
+	if !payload.Sandbox && (payload.RoomType == nil || *payload.RoomType == "") {
+		if roomType := p.detectRoomType(ctx, payload.ImageID, payload.OriginalURL); roomType != "" {
+			payload.RoomType = &roomType
+		}
+	}
+
 	// Stage the image with AI
-	stagedURL, err := p.stagingService.StageImage(ctx, &staging.StagingRequest{
-		ImageID:     payload.ImageID,
-		OriginalURL: payload.OriginalURL,
-		ModelID:     string(activeModel), // Use model from database
-		RoomType:    payload.RoomType,
-		Style:       payload.Style,
-		Seed:        payload.Seed,
-		Prompt:      payload.Prompt,
-	})
+	req := &staging.StagingRequest{
+		ImageID:        payload.ImageID,
+		OriginalURL:    payload.OriginalURL,
+		ModelID:        string(activeModel), // Use model from database
+		ModelVersion:   modelVersion,
+		RoomType:       payload.RoomType,
+		Style:          payload.Style,
+		Seed:           payload.Seed,
+		Prompt:         payload.Prompt,
+		PromptOverride: p.promptOverride(ctx, payload),
+		Locale:         p.promptLocale(ctx, payload.ImageID),
+		Language:       payload.Language,
+		Mode:           payload.Mode,
+		ModelConfig:    payload.ModelConfig,
+		PredictionID:   predictionID,
+		OnPrediction:   onPrediction,
+		InputFiles:     payload.InputFiles,
+		OnInputFiles:   func(ids []string) { inputFiles = append(inputFiles, ids...) },
+		Watermark:      mark,
+	}
+	if payload.MaskURL != nil {
+		req.MaskURL = *payload.MaskURL
+	}
+	var staged *staging.StagingResult
+	if payload.Sandbox {
+		staged, err = p.stagingService.StageImage(ctx, req)
+	} else {
+		staged, err = p.stage(ctx, req)
+		if err != nil && !lowQuality(err) {
+			staged, modelUsed, err = p.stageWithFallback(ctx, req, err)
+		}
+	}
+	// The worker is shutting down and the job ran out of time to finish: it
+	// goes to another worker rather than failing the image.
+	if ctx.Err() != nil {
+		span.SetStatus(codes.Ok, "handed off")
+		return p.handOff(ctx, payload.ImageID, job.Payload, lastPrediction.Load().(string), inputFiles, release)
+	}
+	// The owner may have canceled the job while it ran; whatever it made is
+	// dropped and the image stays canceled.
+	if p.canceled(ctx, payload.ImageID) {
+		log.Info(ctx, "Stage job was canceled while staging, discarding result", "image_id", payload.ImageID)
+		span.SetStatus(codes.Ok, "canceled")
+		return nil
+	}
+	var failed *quality.Error
+	if errors.As(err, &failed) {
+		p.retryLowQuality(ctx, payload, job.Payload, failed, release)
+		span.SetStatus(codes.Ok, "low quality output")
+		return nil
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "staging failed")
@@ -172,27 +638,580 @@ func (p *ImageProcessor) processStageJob(ctx context.Context, job *queue.Job) er
 		return fmt.Errorf("failed to stage image: %w", err)
 	}
 
-	log.Info(ctx, fmt.Sprintf("Successfully staged image: %s", stagedURL))
+	log.Info(ctx, fmt.Sprintf("Successfully staged image: %s", staged.URL))
 
 	// Mark image as ready with staged URL
-	if err := p.imageRepo.SetReady(ctx, payload.ImageID, stagedURL); err != nil {
+	if err := p.imageRepo.SetReady(ctx, payload.ImageID, staged.URL, repository.StageStats{
+		ModelID:     modelUsed,
+		Duration:    time.Since(startedAt),
+		Blurhash:    staged.Blurhash,
+		ContentType: staged.ContentType,
+		CostUSD:     staged.CostUSD,
+		Seed:        staged.Seed,
+	}); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "set ready failed")
 		log.Error(ctx, "Failed to mark image as ready", "image_id", payload.ImageID, "error", err)
 		return fmt.Errorf("failed to mark image as ready: %w", err)
 	}
 
-	// Publish ready status
+	// Publish ready status with the placeholder so galleries can paint it right away
 	if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
-		ImageID: payload.ImageID,
-		Status:  "ready",
+		ImageID:  payload.ImageID,
+		Status:   "ready",
+		Blurhash: staged.Blurhash,
 	}); err != nil {
 		log.Error(ctx, "Failed to publish ready status", "image_id", payload.ImageID, "error", err)
 		// Don't fail the job if SSE publish fails
 	}
+	p.enqueueThumbnails(ctx, payload.ImageID, thumbnail.SourceStaged, staged.URL)
+	p.storeExtraOutputs(ctx, payload, staged.Extras, modelUsed, time.Since(startedAt))
 
 	log.Info(ctx, fmt.Sprintf("Image %s processing complete", payload.ImageID))
 	span.SetStatus(codes.Ok, "processing complete")
 
 	return nil
 }
+
+// storeExtraOutputs adds the other images the model made for the job as ready
+// variants of the image, each announced and thumbnailed like the image itself.
+// The prediction's cost stays on the image the job was for. A variant that
+// can't be stored is logged and skipped; the job has already succeeded.
+func (p *ImageProcessor) storeExtraOutputs(
+	ctx context.Context, payload JobPayload, extras []staging.StagedOutput, modelUsed string, took time.Duration,
+) {
+	log := logging.Default()
+	for _, out := range extras {
+		id, err := p.imageRepo.AddOutput(ctx, payload.ImageID, out.URL, repository.StageStats{
+			ModelID:     modelUsed,
+			Duration:    took,
+			Blurhash:    out.Blurhash,
+			ContentType: out.ContentType,
+		})
+		if err != nil {
+			log.Error(ctx, "Failed to store extra output", "image_id", payload.ImageID, "url", out.URL, "error", err)
+			continue
+		}
+		if id == "" {
+			continue
+		}
+		log.Info(ctx, "Stored extra output as a variant", "image_id", payload.ImageID, "variant_id", id)
+
+		if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
+			ImageID:  id,
+			Status:   "ready",
+			Blurhash: out.Blurhash,
+		}); err != nil {
+			log.Error(ctx, "Failed to publish ready status", "image_id", id, "error", err)
+		}
+		p.enqueueThumbnails(ctx, id, thumbnail.SourceOriginal, payload.OriginalURL)
+		p.enqueueThumbnails(ctx, id, thumbnail.SourceStaged, out.URL)
+	}
+}
+
+// errModelDisabled is returned by pinnedModel for a model an admin disabled.
+var errModelDisabled = errors.New("model is disabled")
+
+// errMaskUnsupported fails a job with a mask on a model that can't use one.
+var errMaskUnsupported = errors.New("model can't stage a masked region")
+
+// pinnedModel returns the Replicate version pinned for m, empty when it runs
+// the latest, and the "<model>:<version>" history records for it. Returns
+// errModelDisabled if an admin has disabled m.
+func (p *ImageProcessor) pinnedModel(ctx context.Context, m model.ID) (version, used string, err error) {
+	enabled, err := p.settingsRepo.IsModelEnabled(ctx, m)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to check model: %w", err)
+	}
+	if !enabled {
+		return "", "", fmt.Errorf("%w: %s", errModelDisabled, m)
+	}
+
+	version, err = p.settingsRepo.GetModelVersion(ctx, m)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get model version: %w", err)
+	}
+	if version == "" {
+		logging.Default().Warn(ctx, "Model version not pinned, using latest upstream", "model_id", string(m))
+		return "", string(m), nil
+	}
+	return version, string(m) + ":" + version, nil
+}
+
+// failUnusableModel marks the image as an error because its job's model can't
+// stage it: the model is disabled, or can't use the job's mask. Retrying
+// wouldn't help, so the job finishes.
+func (p *ImageProcessor) failUnusableModel(ctx context.Context, imageID string, err error) {
+	log := logging.Default()
+
+	log.Warn(ctx, "Stage job's model can't stage the image", "image_id", imageID, "error", err)
+	if setErr := p.imageRepo.SetError(ctx, imageID, err.Error()); setErr != nil {
+		log.Error(ctx, "Failed to mark image as error", "image_id", imageID, "error", setErr)
+	}
+	if pubErr := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
+		ImageID: imageID,
+		Status:  "error",
+		Error:   err.Error(),
+	}); pubErr != nil {
+		log.Error(ctx, "Failed to publish error status", "image_id", imageID, "error", pubErr)
+	}
+}
+
+// stage runs req and records the outcome against its model's circuit. Runs
+// cut short by ctx or canceled by the image's owner say nothing about the
+// provider and aren't recorded; a low-quality image still counts as the
+// provider answering.
+func (p *ImageProcessor) stage(ctx context.Context, req *staging.StagingRequest) (*staging.StagingResult, error) {
+	staged, err := p.stagingService.StageImage(ctx, req)
+	if p.breaker == nil || ctx.Err() != nil || errors.Is(err, staging.ErrPredictionCanceled) {
+		return staged, err
+	}
+	if err != nil && !lowQuality(err) {
+		p.breaker.Failure(model.ID(req.ModelID))
+	} else {
+		p.breaker.Success(model.ID(req.ModelID))
+	}
+	return staged, err
+}
+
+// lowQuality reports whether err is a failed quality check on the staged image.
+func lowQuality(err error) bool {
+	var failed *quality.Error
+	return errors.As(err, &failed)
+}
+
+// handOffTimeout bounds the Redis calls that hand an interrupted job over.
+const handOffTimeout = 5 * time.Second
+
+// handOff requeues a stage job interrupted by the worker shutting down, with
+// the prediction it was waiting on so the next attempt resumes it, and the
+// Replicate files uploaded for it so that attempt deletes them. The job's
+// heartbeat is released first so that attempt isn't skipped as in flight.
+// Without a requeuer it returns an error and asynq pushes the task back.
+func (p *ImageProcessor) handOff(
+	ctx context.Context, imageID string, raw []byte, predictionID string, inputFiles []string, release func(),
+) error {
+	log := logging.Default()
+	if p.requeuer == nil {
+		return fmt.Errorf("stage job interrupted: %w", ctx.Err())
+	}
+	payload, err := heartbeat.WithPredictionID(raw, predictionID)
+	if err == nil {
+		payload, err = withInputFiles(payload, inputFiles)
+	}
+	if err != nil {
+		return fmt.Errorf("stage job interrupted: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), handOffTimeout)
+	defer cancel()
+	release()
+	if p.heartbeats != nil {
+		if err := p.heartbeats.Release(ctx, imageID); err != nil {
+			log.Warn(ctx, "Failed to release interrupted job's heartbeat", "image_id", imageID, "error", err)
+		}
+	}
+	if err := p.requeuer.RequeueStage(ctx, payload); err != nil {
+		return fmt.Errorf("failed to requeue interrupted stage job: %w", err)
+	}
+	log.Info(ctx, "Stage job interrupted by shutdown, requeued", "image_id", imageID, "prediction_id", predictionID)
+	return nil
+}
+
+// retryLowQuality handles a staged image that failed a quality check. The
+// first time, it releases the job's heartbeat, so the retry isn't skipped as
+// in flight, and requeues the job with a new seed. A retry that fails again,
+// or a job that can't be requeued, marks the image as an error with the
+// check's code.
+func (p *ImageProcessor) retryLowQuality(
+	ctx context.Context, payload JobPayload, raw []byte, failed *quality.Error, release func(),
+) {
+	log := logging.Default()
+
+	if !payload.QualityRetry && p.requeuer != nil {
+		seed := rand.Int64N(math.MaxInt32) + 1
+		retry, err := withQualityRetry(raw, seed)
+		if err == nil {
+			release()
+			err = p.requeuer.RequeueStage(ctx, retry)
+		}
+		if err == nil {
+			log.Warn(ctx, "Staged image failed a quality check, staging again with a new seed",
+				"image_id", payload.ImageID, "code", string(failed.Code), "seed", seed)
+			return
+		}
+		log.Error(ctx, "Failed to requeue stage job after a quality check", "image_id", payload.ImageID, "error", err)
+	}
+
+	log.Warn(ctx, "Staged image failed a quality check", "image_id", payload.ImageID,
+		"code", string(failed.Code), "error", failed.Message)
+	message := failed.Localize(payload.Language)
+	if err := p.imageRepo.SetValidationError(ctx, payload.ImageID, string(failed.Code), message); err != nil {
+		log.Error(ctx, "Failed to mark image as error", "image_id", payload.ImageID, "error", err)
+	}
+	if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
+		ImageID:   payload.ImageID,
+		Status:    "error",
+		Error:     message,
+		ErrorCode: string(failed.Code),
+	}); err != nil {
+		log.Error(ctx, "Failed to publish error status", "image_id", payload.ImageID, "error", err)
+	}
+}
+
+// withQualityRetry marks a stage job payload as its quality retry with the
+// given seed, dropping any prediction to resume and leaving the other fields
+// untouched.
+func withQualityRetry(payload []byte, seed int64) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal payload: %w", err)
+	}
+	fields["seed"] = json.RawMessage(strconv.FormatInt(seed, 10))
+	fields["quality_retry"] = json.RawMessage("true")
+	delete(fields, "prediction_id")
+	delete(fields, "input_files")
+	return json.Marshal(fields)
+}
+
+// withInputFiles sets input_files on a stage job payload to fileIDs, leaving
+// the other fields untouched. An empty list removes the field.
+func withInputFiles(payload []byte, fileIDs []string) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal payload: %w", err)
+	}
+	if len(fileIDs) == 0 {
+		delete(fields, "input_files")
+		return json.Marshal(fields)
+	}
+	ids, err := json.Marshal(fileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("marshal input files: %w", err)
+	}
+	fields["input_files"] = ids
+	return json.Marshal(fields)
+}
+
+// stageWithFallback retries req, which failed with stageErr, on each model the
+// fallback policy lists until one succeeds, skipping models whose circuit is
+// open or that an admin disabled. Every attempt is recorded as a new processing event with its model,
+// and the model that produced the image is returned for model_used. A fallback
+// never resumes the failed prediction or takes the job's config overrides. It
+// gives up early when ctx is done or the job was canceled, returning the last
+// error.
+func (p *ImageProcessor) stageWithFallback(
+	ctx context.Context, req *staging.StagingRequest, stageErr error,
+) (*staging.StagingResult, string, error) {
+	log := logging.Default()
+
+	for _, next := range p.fallback.next(model.ID(req.ModelID)) {
+		if ctx.Err() != nil || errors.Is(stageErr, staging.ErrPredictionCanceled) || p.canceled(ctx, req.ImageID) {
+			break
+		}
+		if p.breaker != nil {
+			if _, ok := p.breaker.Allow(next); !ok {
+				continue
+			}
+		}
+		if req.MaskURL != "" && !modelconfig.Inpaints(string(next)) {
+			continue
+		}
+		log.Warn(ctx, "Staging failed, falling back to another model", "image_id", req.ImageID,
+			"model_id", req.ModelID, "fallback_model_id", string(next), "error", stageErr)
+
+		version, used, err := p.pinnedModel(ctx, next)
+		if err != nil {
+			log.Error(ctx, "Skipping fallback model", "image_id", req.ImageID,
+				"model_id", string(next), "error", err)
+			continue
+		}
+		if err := p.imageRepo.SetProcessing(ctx, req.ImageID, used); err != nil {
+			log.Warn(ctx, "Failed to record fallback attempt", "image_id", req.ImageID, "error", err)
+		}
+
+		req.ModelID, req.ModelVersion, req.PredictionID, req.ModelConfig = string(next), version, "", nil
+		// The failed attempt deleted the files it was handed.
+		req.InputFiles = nil
+		staged, err := p.stage(ctx, req)
+		if err == nil {
+			log.Info(ctx, "Staged with fallback model", "image_id", req.ImageID, "model_id", used)
+			return staged, used, nil
+		}
+		stageErr = err
+	}
+	return nil, "", stageErr
+}
+
+// promptOverride looks up the admin override for the job's room type and style.
+// When an experiment is running for them, the image is tagged with one of its
+// variants and that variant's prompt is used instead. Sandbox images, images
+// with their own prompt and decluttered images stay out of experiments. A
+// failed lookup is logged and staging falls back to the override, or to the
+// built-in prompt. Declutter-only jobs don't stage, so they have no override.
+func (p *ImageProcessor) promptOverride(ctx context.Context, payload JobPayload) string {
+	if payload.Mode == prompt.ModeDeclutter {
+		return ""
+	}
+	var roomType, style string
+	if payload.RoomType != nil {
+		roomType = *payload.RoomType
+	}
+	if payload.Style != nil {
+		style = *payload.Style
+	}
+	roomType, style = prompt.Key(roomType, style)
+
+	log := logging.Default()
+	if !payload.Sandbox && !prompt.Declutters(payload.Mode) && (payload.Prompt == nil || *payload.Prompt == "") {
+		if variant := p.promptVariant(ctx, payload.ImageID, roomType, style); variant != "" {
+			return variant
+		}
+	}
+
+	override, err := p.settingsRepo.GetPromptOverride(ctx, roomType, style)
+	if err != nil {
+		log.Warn(ctx, "Failed to get prompt override, using built-in prompt",
+			"image_id", payload.ImageID, "room_type", roomType, "style", style, "error", err)
+		return ""
+	}
+	return override
+}
+
+// promptVariant assigns the image a variant of the experiment running for the
+// room type and style and returns its prompt, or "" when there is none. The
+// assignment is recorded before staging so the experiment report counts it.
+func (p *ImageProcessor) promptVariant(ctx context.Context, imageID, roomType, style string) string {
+	log := logging.Default()
+	experiment, err := p.settingsRepo.GetPromptExperiment(ctx, roomType, style)
+	if err != nil {
+		log.Warn(ctx, "Failed to get prompt experiment, using prompt override",
+			"image_id", imageID, "room_type", roomType, "style", style, "error", err)
+		return ""
+	}
+	if experiment == nil {
+		return ""
+	}
+
+	variant := experiment.Pick(imageID)
+	if err := p.imageRepo.SetPromptVariant(ctx, imageID, experiment.ID, variant.ID); err != nil {
+		log.Warn(ctx, "Failed to record prompt variant, using prompt override",
+			"image_id", imageID, "experiment_id", experiment.ID, "error", err)
+		return ""
+	}
+	return variant.Prompt
+}
+
+// promptLocale looks up the locale of the image's project. A failed lookup is
+// logged and the prompt keeps its US phrasing.
+func (p *ImageProcessor) promptLocale(ctx context.Context, imageID string) string {
+	locale, err := p.settingsRepo.GetPromptLocale(ctx, imageID)
+	if err != nil {
+		logging.Default().Warn(ctx, "Failed to get prompt locale, using US phrasing",
+			"image_id", imageID, "error", err)
+		return ""
+	}
+	return locale
+}
+
+// validateOriginal checks the uploaded original and, when it is rejected, sets
+// the image to error with the validation code and reports true. The job then
+// finishes without retrying since the same file would fail again. When the
+// check itself fails (S3 unavailable, say) it is logged and staging continues;
+// staging reads the same file and fails in its own way if it can't. The error
+// shown on the image is in the job's language.
+func (p *ImageProcessor) validateOriginal(ctx context.Context, payload JobPayload) bool {
+	log := logging.Default()
+	imageID := payload.ImageID
+
+	info, err := p.stagingService.ValidateOriginal(ctx, payload.OriginalURL)
+	var invalid *imagecheck.Error
+	if errors.As(err, &invalid) {
+		log.Warn(ctx, "Original failed validation", "image_id", imageID, "code", string(invalid.Code),
+			"error", invalid.Message)
+		message := invalid.Localize(payload.Language)
+		if setErr := p.imageRepo.SetValidationError(ctx, imageID, string(invalid.Code), message); setErr != nil {
+			log.Error(ctx, "Failed to mark image as error", "image_id", imageID, "error", setErr)
+		}
+		if pubErr := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
+			ImageID:   imageID,
+			Status:    "error",
+			Error:     message,
+			ErrorCode: string(invalid.Code),
+		}); pubErr != nil {
+			log.Error(ctx, "Failed to publish error status", "image_id", imageID, "error", pubErr)
+		}
+		return true
+	}
+	if err != nil {
+		log.Warn(ctx, "Failed to validate original, staging anyway", "image_id", imageID, "error", err)
+		return false
+	}
+	log.Info(ctx, "Original validated", "image_id", imageID, "format", info.Format,
+		"width", info.Width, "height", info.Height)
+	return false
+}
+
+// malwareMessage is the error shown on images whose original was quarantined,
+// translated into the job's language.
+const malwareMessage = "The uploaded file was flagged by the malware scanner and has been quarantined."
+
+// scanOriginal checks the original for malware. An infected original is moved
+// to quarantine, its image is set to rejected and the admins are alerted; it
+// then reports true and the job finishes without retrying. A scan or move that
+// fails is returned as an error. Once the file is quarantined, a failure to
+// record it is logged instead, since a retry would find nothing to scan.
+func (p *ImageProcessor) scanOriginal(ctx context.Context, payload JobPayload) (bool, error) {
+	log := logging.Default()
+	imageID, originalURL := payload.ImageID, payload.OriginalURL
+
+	verdict, err := p.stagingService.ScanOriginal(ctx, originalURL)
+	if err != nil {
+		log.Error(ctx, "Failed to scan original", "image_id", imageID, "error", err)
+		return false, fmt.Errorf("failed to scan original: %w", err)
+	}
+	if verdict == nil {
+		return false, nil
+	}
+	if !verdict.Infected {
+		log.Info(ctx, "Original scanned clean", "image_id", imageID, "scanner", verdict.Scanner)
+		return false, nil
+	}
+
+	quarantineURL, err := p.stagingService.QuarantineOriginal(ctx, originalURL)
+	if err != nil {
+		log.Error(ctx, "Failed to quarantine infected original", "image_id", imageID,
+			"signature", verdict.Signature, "error", err)
+		return false, fmt.Errorf("failed to quarantine original: %w", err)
+	}
+	log.Error(ctx, "Malware detected, original quarantined", "image_id", imageID, "scanner", verdict.Scanner,
+		"signature", verdict.Signature, "quarantine_url", quarantineURL)
+
+	message := i18n.T(payload.Language, malwareMessage)
+	if err := p.imageRepo.SetRejected(ctx, imageID, message, repository.MalwareDetection{
+		OriginalURL:   originalURL,
+		QuarantineURL: quarantineURL,
+		Scanner:       verdict.Scanner,
+		Signature:     verdict.Signature,
+	}); err != nil {
+		log.Error(ctx, "Failed to mark image as rejected", "image_id", imageID, "error", err)
+	}
+	if err := p.publisher.PublishJobUpdate(ctx, events.JobUpdateEvent{
+		ImageID:   imageID,
+		Status:    "rejected",
+		Error:     message,
+		ErrorCode: malware.ErrorCode,
+	}); err != nil {
+		log.Error(ctx, "Failed to publish rejected status", "image_id", imageID, "error", err)
+	}
+	return true, nil
+}
+
+// preprocessOriginal applies the original's EXIF orientation and strips its
+// metadata in storage, then records the orientation found and, when it was
+// preserved, the metadata. Failures are logged and staging continues: the
+// staging service also corrects orientation in memory.
+func (p *ImageProcessor) preprocessOriginal(ctx context.Context, imageID, originalURL string) {
+	log := logging.Default()
+
+	res, err := p.stagingService.PreprocessOriginal(ctx, originalURL)
+	if err != nil {
+		log.Warn(ctx, "Failed to preprocess original", "image_id", imageID, "error", err)
+		return
+	}
+	if res.Orientation != 1 {
+		log.Info(ctx, "Corrected original orientation", "image_id", imageID, "exif_orientation", res.Orientation)
+	}
+	if res.Stripped {
+		log.Info(ctx, "Stripped original metadata", "image_id", imageID)
+	}
+	if err := p.imageRepo.SetOriginalOrientation(ctx, originalURL, res.Orientation); err != nil {
+		log.Warn(ctx, "Failed to record original orientation", "image_id", imageID, "error", err)
+	}
+	if res.Metadata != nil {
+		if err := p.imageRepo.SetOriginalMetadata(ctx, originalURL, res.Metadata); err != nil {
+			log.Warn(ctx, "Failed to record original metadata", "image_id", imageID, "error", err)
+		}
+	}
+}
+
+// detectRoomType classifies the room in the original, records the detection
+// on the image and returns the detected room type when the model was
+// confident, or "" to stage with the generic prompt. Detection is optional,
+// so a failure is logged and staging carries on without it.
+func (p *ImageProcessor) detectRoomType(ctx context.Context, imageID, originalURL string) string {
+	log := logging.Default()
+
+	detection, err := p.stagingService.DetectRoomType(ctx, originalURL)
+	if err != nil {
+		log.Warn(ctx, "Failed to detect room type", "image_id", imageID, "error", err)
+		return ""
+	}
+	if detection == nil {
+		return ""
+	}
+	log.Info(ctx, "Detected room type", "image_id", imageID, "room_type", detection.RoomType,
+		"confidence", detection.Confidence, "confident", detection.Confident)
+	if err := p.imageRepo.SetDetectedRoomType(ctx, imageID, detection.RoomType, detection.Confidence); err != nil {
+		log.Warn(ctx, "Failed to record detected room type", "image_id", imageID, "error", err)
+	}
+	if !detection.Confident {
+		return ""
+	}
+	return detection.RoomType
+}
+
+// canceled reports whether the image's owner canceled its staging job. A
+// failed lookup is logged and counts as not canceled: the worker's status
+// updates never overwrite a canceled image anyway.
+func (p *ImageProcessor) canceled(ctx context.Context, imageID string) bool {
+	canceled, err := p.imageRepo.IsCanceled(ctx, imageID)
+	if err != nil {
+		logging.Default().Warn(ctx, "Failed to check whether the job was canceled", "image_id", imageID, "error", err)
+		return false
+	}
+	return canceled
+}
+
+// startHeartbeat claims the stage job's heartbeat and keeps it alive until
+// release is called. It returns the prediction to resume, taken from the
+// payload or from the record a stalled earlier attempt left behind, and a
+// callback that records new predictions on the image, where the API finds them
+// to cancel, and in the heartbeat. A heartbeat store that can't be reached is
+// logged and staging carries on unmonitored; only ErrInFlight is returned.
+func (p *ImageProcessor) startHeartbeat(
+	ctx context.Context, payload JobPayload, raw []byte,
+) (predictionID string, onPrediction func(string), release func(), err error) {
+	log := logging.Default()
+	predictionID = payload.PredictionID
+	release = func() {}
+	recordOnImage := func(id string) {
+		if err := p.imageRepo.SetPrediction(ctx, payload.ImageID, id); err != nil {
+			log.Warn(ctx, "Failed to record prediction on image", "image_id", payload.ImageID,
+				"prediction_id", id, "error", err)
+		}
+	}
+	if p.heartbeats == nil {
+		return predictionID, recordOnImage, release, nil
+	}
+
+	rec, err := p.heartbeats.Claim(ctx, payload.ImageID, raw)
+	if errors.Is(err, heartbeat.ErrInFlight) {
+		return "", nil, release, err
+	}
+	if err != nil {
+		log.Warn(ctx, "Failed to start stage job heartbeat", "image_id", payload.ImageID, "error", err)
+		return predictionID, recordOnImage, release, nil
+	}
+	if predictionID == "" {
+		predictionID = rec.PredictionID
+	}
+
+	onPrediction = func(id string) {
+		recordOnImage(id)
+		if err := p.heartbeats.SetPrediction(ctx, payload.ImageID, id); err != nil {
+			log.Warn(ctx, "Failed to record prediction", "image_id", payload.ImageID, "prediction_id", id, "error", err)
+		}
+	}
+	return predictionID, onPrediction, heartbeat.Keep(ctx, p.heartbeats, payload.ImageID, p.beatInterval), nil
+}
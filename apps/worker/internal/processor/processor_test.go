@@ -0,0 +1,94 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithQualityRetry(t *testing.T) {
+	t.Run("success: sets the seed and retry flag and drops the prediction", func(t *testing.T) {
+		raw := []byte(`{"image_id":"img-1","seed":42,"prediction_id":"pred-1","input_files":["file-1"],` +
+			`"room_type":"bedroom"}`)
+
+		got, err := withQualityRetry(raw, 7)
+		require.NoError(t, err)
+
+		var payload JobPayload
+		require.NoError(t, json.Unmarshal(got, &payload))
+		assert.Equal(t, "img-1", payload.ImageID)
+		require.NotNil(t, payload.Seed)
+		assert.Equal(t, int64(7), *payload.Seed)
+		assert.True(t, payload.QualityRetry)
+		assert.Empty(t, payload.PredictionID)
+		assert.Empty(t, payload.InputFiles)
+		require.NotNil(t, payload.RoomType)
+		assert.Equal(t, "bedroom", *payload.RoomType)
+	})
+
+	t.Run("success: keeps fields the payload doesn't know", func(t *testing.T) {
+		got, err := withQualityRetry([]byte(`{"image_id":"img-1","trace":"abc"}`), 7)
+		require.NoError(t, err)
+		assert.Contains(t, string(got), `"trace":"abc"`)
+	})
+
+	t.Run("success: keeps the request language", func(t *testing.T) {
+		got, err := withQualityRetry([]byte(`{"image_id":"img-1","language":"es"}`), 7)
+		require.NoError(t, err)
+
+		var payload JobPayload
+		require.NoError(t, json.Unmarshal(got, &payload))
+		assert.Equal(t, "es", payload.Language)
+	})
+
+	t.Run("fail: payload is not an object", func(t *testing.T) {
+		_, err := withQualityRetry([]byte(`[]`), 7)
+		assert.Error(t, err)
+	})
+}
+
+type requeuerFunc func(ctx context.Context, payload []byte) error
+
+func (f requeuerFunc) RequeueStage(ctx context.Context, payload []byte) error { return f(ctx, payload) }
+
+func TestHandOff(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("success: requeues the job with its prediction after releasing it", func(t *testing.T) {
+		released := false
+		var requeued []byte
+		p := &ImageProcessor{requeuer: requeuerFunc(func(ctx context.Context, payload []byte) error {
+			assert.True(t, released)
+			assert.NoError(t, ctx.Err())
+			requeued = payload
+			return nil
+		})}
+
+		err := p.handOff(canceled, "img-1", []byte(`{"image_id":"img-1"}`), "pred-1", nil, func() { released = true })
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"image_id":"img-1","prediction_id":"pred-1"}`, string(requeued))
+	})
+
+	t.Run("success: hands over the Replicate files the prediction reads", func(t *testing.T) {
+		var requeued []byte
+		p := &ImageProcessor{requeuer: requeuerFunc(func(ctx context.Context, payload []byte) error {
+			requeued = payload
+			return nil
+		})}
+
+		raw := []byte(`{"image_id":"img-1","prediction_id":"pred-0","input_files":["file-0"]}`)
+		err := p.handOff(canceled, "img-1", raw, "pred-1", []string{"file-0", "file-1"}, func() {})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"image_id":"img-1","prediction_id":"pred-1","input_files":["file-0","file-1"]}`,
+			string(requeued))
+	})
+
+	t.Run("fail: no requeuer", func(t *testing.T) {
+		err := (&ImageProcessor{}).handOff(canceled, "img-1", []byte(`{}`), "pred-1", nil, func() {})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,155 @@
+// Package quality checks a staged image for signs that the model failed
+// without reporting an error: an output whose shape doesn't match the
+// original, or one that is blank or black.
+//
+// The worker checks each staged image before storing it. One that fails is
+// staged once more with a different seed, then marked as an error with the
+// failed check's code.
+package quality
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register JPEG for image.Decode
+	_ "image/png"  // register PNG for image.Decode
+	"io"
+	"math"
+
+	_ "golang.org/x/image/webp" // register WebP for image.Decode
+
+	"github.com/real-staging-ai/worker/internal/i18n"
+)
+
+// Code identifies the check a staged image failed. It is stored on the image
+// as error_code once the retry has failed too.
+type Code string
+
+const (
+	// CodeResolutionMismatch means the staged image's aspect ratio differs
+	// from the original's by more than Options.MaxAspectDrift.
+	CodeResolutionMismatch Code = "resolution_mismatch"
+	// CodeBlankOutput means the staged image is a single flat colour or almost
+	// entirely black.
+	CodeBlankOutput Code = "blank_output"
+	// CodeNSFW means the model flagged its output as NSFW.
+	CodeNSFW Code = "nsfw_output"
+	// CodeUndecodable means the staged image could not be decoded.
+	CodeUndecodable Code = "undecodable_output"
+)
+
+// Error is a failed check. Its message is written for end users.
+type Error struct {
+	Code    Code
+	Message string
+	// format and args are what Message was formatted from, so Localize can
+	// translate it; an Error built without newError has neither.
+	format string
+	args   []any
+}
+
+// newError returns an Error whose English message is format filled with args.
+func newError(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: i18n.T(i18n.DefaultLanguage, format, args...), format: format, args: args}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Localize returns the message translated into lang, or in English when it
+// has no translation.
+func (e *Error) Localize(lang string) string {
+	if e.format == "" {
+		return i18n.T(lang, e.Message)
+	}
+	return i18n.T(lang, e.format, e.args...)
+}
+
+// Options selects the checks to run. A zero field disables its check.
+type Options struct {
+	// MaxAspectDrift is the largest accepted relative difference between the
+	// staged and original aspect ratios; 0.1 allows 10%.
+	MaxAspectDrift float64
+	// BlankMaxStdDev flags images whose luminance, on a 0 to 255 scale, varies
+	// by no more than this standard deviation.
+	BlankMaxStdDev float64
+	// BlackMaxLuma flags images whose mean luminance, on a 0 to 255 scale, is
+	// no more than this.
+	BlackMaxLuma float64
+	// RejectNSFW fails images the model flagged as NSFW.
+	RejectNSFW bool
+}
+
+// Enabled reports whether any check is enabled.
+func (o Options) Enabled() bool {
+	return o.MaxAspectDrift > 0 || o.BlankMaxStdDev > 0 || o.BlackMaxLuma > 0 || o.RejectNSFW
+}
+
+// sampleGrid is how many pixels per side are sampled to measure luminance.
+const sampleGrid = 64
+
+// Check runs the enabled pixel checks on staged against original. It returns
+// an *Error when staged fails one, and any other error when original can't
+// be decoded to compare against.
+func Check(original io.Reader, staged []byte, opts Options) error {
+	if opts.MaxAspectDrift <= 0 && opts.BlankMaxStdDev <= 0 && opts.BlackMaxLuma <= 0 {
+		return nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(staged))
+	if err != nil {
+		return newError(CodeUndecodable, "The staged image could not be read.")
+	}
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return newError(CodeUndecodable, "The staged image is empty.")
+	}
+
+	if opts.MaxAspectDrift > 0 {
+		cfg, _, err := image.DecodeConfig(original)
+		if err != nil {
+			return fmt.Errorf("decode original: %w", err)
+		}
+		if cfg.Width > 0 && cfg.Height > 0 {
+			want := float64(cfg.Width) / float64(cfg.Height)
+			got := float64(bounds.Dx()) / float64(bounds.Dy())
+			if math.Abs(got-want)/want > opts.MaxAspectDrift {
+				return newError(CodeResolutionMismatch,
+					"The staged image is %dx%d, which doesn't match the %dx%d original.",
+					bounds.Dx(), bounds.Dy(), cfg.Width, cfg.Height)
+			}
+		}
+	}
+
+	mean, stddev := luminance(img)
+	if opts.BlackMaxLuma > 0 && mean <= opts.BlackMaxLuma {
+		return newError(CodeBlankOutput, "The staged image came back black.")
+	}
+	if opts.BlankMaxStdDev > 0 && stddev <= opts.BlankMaxStdDev {
+		return newError(CodeBlankOutput, "The staged image came back blank.")
+	}
+	return nil
+}
+
+// luminance returns the mean and standard deviation of img's luminance on a
+// 0 to 255 scale, measured on a grid of up to sampleGrid by sampleGrid pixels.
+func luminance(img image.Image) (mean, stddev float64) {
+	b := img.Bounds()
+	stepX := max(1, b.Dx()/sampleGrid)
+	stepY := max(1, b.Dy()/sampleGrid)
+
+	var sum, sumSq float64
+	var n int
+	for y := b.Min.Y; y < b.Max.Y; y += stepY {
+		for x := b.Min.X; x < b.Max.X; x += stepX {
+			r, g, bl, _ := img.At(x, y).RGBA()
+			l := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)) / 257
+			sum += l
+			sumSq += l * l
+			n++
+		}
+	}
+	mean = sum / float64(n)
+	return mean, math.Sqrt(math.Max(0, sumSq/float64(n)-mean*mean))
+}
@@ -0,0 +1,102 @@
+package quality
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encoded returns a w×h PNG filled by fill.
+func encoded(t *testing.T, w, h int, fill func(x, y int) color.Color) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, fill(x, y))
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func gradient(x, y int) color.Color { return color.Gray{Y: uint8((x + y) * 2)} }
+
+func flat(c uint8) func(x, y int) color.Color {
+	return func(x, y int) color.Color { return color.Gray{Y: c} }
+}
+
+func TestCheck(t *testing.T) {
+	original := encoded(t, 80, 60, gradient)
+	opts := Options{MaxAspectDrift: 0.1, BlankMaxStdDev: 2, BlackMaxLuma: 8}
+
+	tests := []struct {
+		name     string
+		staged   []byte
+		opts     Options
+		wantCode Code
+	}{
+		{name: "success: same shape and detail", staged: encoded(t, 80, 60, gradient), opts: opts},
+		{name: "success: scaled output", staged: encoded(t, 40, 31, gradient), opts: opts},
+		{name: "success: no checks enabled", staged: []byte("not an image")},
+		{
+			name: "success: disabled aspect check", staged: encoded(t, 60, 80, gradient),
+			opts: Options{BlankMaxStdDev: 2},
+		},
+		{
+			name: "fail: aspect ratio mismatch", staged: encoded(t, 60, 80, gradient), opts: opts,
+			wantCode: CodeResolutionMismatch,
+		},
+		{name: "fail: flat grey", staged: encoded(t, 80, 60, flat(128)), opts: opts, wantCode: CodeBlankOutput},
+		{
+			name: "fail: noisy black", opts: opts, wantCode: CodeBlankOutput,
+			staged: encoded(t, 80, 60, func(x, y int) color.Color { return color.Gray{Y: uint8((x * y) % 12)} }),
+		},
+		{name: "fail: undecodable", staged: []byte("not an image"), opts: opts, wantCode: CodeUndecodable},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Check(bytes.NewReader(original), tc.staged, tc.opts)
+			if tc.wantCode == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var qerr *Error
+			require.ErrorAs(t, err, &qerr)
+			assert.Equal(t, tc.wantCode, qerr.Code)
+			assert.NotEmpty(t, qerr.Message)
+		})
+	}
+
+	t.Run("fail: undecodable original", func(t *testing.T) {
+		err := Check(strings.NewReader("nope"), encoded(t, 10, 10, gradient), opts)
+		require.Error(t, err)
+		var qerr *Error
+		assert.NotErrorAs(t, err, &qerr)
+	})
+}
+
+func TestOptions_Enabled(t *testing.T) {
+	assert.False(t, Options{}.Enabled())
+	assert.True(t, Options{RejectNSFW: true}.Enabled())
+	assert.True(t, Options{MaxAspectDrift: 0.1}.Enabled())
+}
+
+func TestError_Localize(t *testing.T) {
+	err := Check(bytes.NewReader(encoded(t, 80, 60, gradient)), encoded(t, 60, 80, gradient), Options{MaxAspectDrift: 0.1})
+	var qerr *Error
+	require.ErrorAs(t, err, &qerr)
+	assert.Equal(t, "The staged image is 60x80, which doesn't match the 80x60 original.", qerr.Message)
+	assert.Equal(t, "L'image meublée mesure 60x80, ce qui ne correspond pas à l'original de 80x60.", qerr.Localize("fr"))
+	assert.Equal(t, qerr.Message, qerr.Localize(""))
+
+	nsfw := &Error{Code: CodeNSFW, Message: "The model flagged the staged image as unsafe."}
+	assert.Equal(t, "El modelo marcó la imagen amueblada como inapropiada.", nsfw.Localize("es"))
+}
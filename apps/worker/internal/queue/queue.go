@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"strconv"
 	"sync"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/real-staging-ai/worker/internal/config"
 	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/repository"
 )
 
 // Job represents a processing job.
@@ -29,6 +31,26 @@ type QueueClient interface {
 	GetNextJob(ctx context.Context) (*Job, error)
 	MarkJobCompleted(ctx context.Context, jobID string) error
 	MarkJobFailed(ctx context.Context, jobID string, errorMsg string) error
+	DeferJob(ctx context.Context, jobID string, until time.Time) error
+	// Shutdown stops taking jobs and waits for the running ones to finish.
+	// Call it once the consumer has stopped calling GetNextJob; a job it
+	// still gets must be marked as usual.
+	Shutdown()
+}
+
+// DeferredError reports that a job could not run yet and should be retried at
+// Until, without counting as a failed attempt.
+type DeferredError struct {
+	Until time.Time
+}
+
+func (e *DeferredError) Error() string {
+	return fmt.Sprintf("job deferred until %s", e.Until.Format(time.RFC3339))
+}
+
+// Defer returns a DeferredError for until.
+func Defer(until time.Time) error {
+	return &DeferredError{Until: until}
 }
 
 // MockQueueClient is a mock implementation for development/testing.
@@ -61,6 +83,14 @@ func (m *MockQueueClient) MarkJobFailed(ctx context.Context, jobID string, error
 	return nil
 }
 
+// DeferJob puts a job back to run again at until.
+func (m *MockQueueClient) DeferJob(ctx context.Context, jobID string, until time.Time) error {
+	return nil
+}
+
+// Shutdown does nothing; the mock queue never holds jobs.
+func (m *MockQueueClient) Shutdown() {}
+
 // AsynqQueueClient is a production-ready queue client backed by Redis + asynq.
 // It adapts asynq's push-based handler model into our pull-based QueueClient API
 // by bridging tasks through an internal channel and result signaling.
@@ -74,7 +104,8 @@ type AsynqQueueClient struct {
 // NewAsynqQueueClient initializes an Asynq-backed queue client.
 // Required env: REDIS_HOST
 // Optional env: REDIS_PORT (default: "6379"), JOB_QUEUE_NAME (default: "default"), WORKER_CONCURRENCY (default: 5)
-func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
+// Staging tasks that fail for good are recorded in deadLetters when it is not nil.
+func NewAsynqQueueClient(cfg *config.Config, deadLetters repository.DeadLetterRepository) (*AsynqQueueClient, error) {
 	// Get address from config
 	addr := cfg.Redis.Addr()
 	if addr == "" {
@@ -82,10 +113,7 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 			"redis address not set. Set REDIS_HOST and REDIS_PORT in config or environment")
 	}
 
-	queueName := os.Getenv("JOB_QUEUE_NAME")
-	if queueName == "" {
-		queueName = cfg.Job.QueueName
-	}
+	queueName := jobQueueName(cfg)
 
 	concurrency := cfg.Job.WorkerConcurrency
 	if v := os.Getenv("WORKER_CONCURRENCY"); v != "" {
@@ -99,10 +127,25 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 	srv := asynq.NewServer(
 		asynq.RedisClientOpt{Addr: addr},
 		asynq.Config{
-			Concurrency: concurrency,
-			Queues:      map[string]int{queueName: 1},
+			Concurrency:    concurrency,
+			Queues:         queuePriorities(queueName, cfg.Job),
+			RetryDelayFunc: retryDelay,
+			// Tasks still running when the timeout ends are pushed back to their queue.
+			ShutdownTimeout: shutdownTimeout(cfg.Job),
+			// Deferred jobs are retried without using up an attempt.
+			IsFailure: func(err error) bool {
+				var deferred *DeferredError
+				return !errors.As(err, &deferred)
+			},
 			ErrorHandler: asynq.ErrorHandlerFunc(func(ctx context.Context, t *asynq.Task, err error) {
+				var deferred *DeferredError
+				if errors.As(err, &deferred) {
+					return
+				}
 				logger.Error(ctx, "asynq handler error", "type", t.Type(), "error", err)
+				if deadLetters != nil {
+					recordDeadLetter(ctx, deadLetters, t, err)
+				}
 			}),
 		},
 	)
@@ -114,58 +157,17 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 	}
 
 	mux := asynq.NewServeMux()
-	// Register exact task type used by the API enqueuer.
-	// Wildcards are not supported by asynq mux.
-	logger.Info(context.Background(), "Registering asynq handler", "task_type", "stage:run")
-
-	mux.HandleFunc("stage:run", func(ctx context.Context, t *asynq.Task) error {
-		logger.Info(ctx, "=== ASYNQ HANDLER CALLED ===", "task_type", t.Type())
-
-		// Create a local job id to correlate completion/failure.
-		jobID := fmt.Sprintf("%d", time.Now().UnixNano())
-		jb := &Job{
-			ID:      jobID,
-			Type:    t.Type(),
-			Payload: t.Payload(),
-			Status:  "queued",
-		}
-		resCh := make(chan error, 1)
-		c.mu.Lock()
-		c.results[jobID] = resCh
-		c.mu.Unlock()
-
-		// Deliver job to consumer
-		logger.Info(ctx, "asynq task received, delivering to job channel",
-			"task_type", t.Type(), "job_id", jobID, "channel_len", len(c.jobs))
-		select {
-		case c.jobs <- jb:
-		case <-ctx.Done():
-			c.mu.Lock()
-			delete(c.results, jobID)
-			c.mu.Unlock()
-			return ctx.Err()
-		}
-
-		// Wait for processing result from the worker.
-		select {
-		case err := <-resCh:
-			if err != nil {
-				logger.Warn(ctx, "worker marked task failed", "task_type", t.Type(), "job_id", jobID, "error", err)
-			} else {
-				logger.Info(ctx, "worker marked task completed", "task_type", t.Type(), "job_id", jobID)
-			}
-			return err
-		case <-ctx.Done():
-			c.mu.Lock()
-			delete(c.results, jobID)
-			c.mu.Unlock()
-			return ctx.Err()
-		}
-	})
+	// Register exact task types used by the API enqueuer and the worker's own
+	// follow-up tasks. Wildcards are not supported by asynq mux.
+	for _, taskType := range []string{"stage:run", "declutter:run", "delivery:send", "cutout:run", "thumbnail:run"} {
+		logger.Info(context.Background(), "Registering asynq handler", "task_type", taskType)
+		mux.HandleFunc(taskType, c.bridge)
+	}
 
 	// Start the asynq server in the background.
 	logger.Info(context.Background(), "starting asynq server",
-		"redis_addr", addr, "queue", queueName, "concurrency", concurrency)
+		"redis_addr", addr, "queue", queueName, "concurrency", concurrency,
+		"weights", queuePriorities(queueName, cfg.Job))
 	go func() {
 		if err := srv.Run(mux); err != nil {
 			logger.Error(context.Background(), "asynq server exited", "error", err)
@@ -175,6 +177,169 @@ func NewAsynqQueueClient(cfg *config.Config) (*AsynqQueueClient, error) {
 	return c, nil
 }
 
+// handOffGrace is how much longer asynq waits for running tasks at shutdown
+// than the worker does, so stage jobs interrupted at the worker's timeout can
+// requeue themselves before asynq pushes their tasks back as well.
+const handOffGrace = 10 * time.Second
+
+// shutdownTimeout returns how long asynq waits for running tasks to finish
+// when the worker shuts down.
+func shutdownTimeout(job config.Job) time.Duration {
+	return time.Duration(max(job.ShutdownTimeoutSeconds, 0))*time.Second + handOffGrace
+}
+
+// jobQueueName returns the staging queue, preferring JOB_QUEUE_NAME over config.
+func jobQueueName(cfg *config.Config) string {
+	if q := os.Getenv("JOB_QUEUE_NAME"); q != "" {
+		return q
+	}
+	return cfg.Job.QueueName
+}
+
+// Outbound deliveries use their own queues (see the API's queue.QueueWebhooks
+// and queue.QueueEmails) so a slow receiver can't starve staging jobs.
+const (
+	webhooksQueue = "webhooks"
+	emailsQueue   = "emails"
+)
+
+// Staging jobs above and below the default priority wait in their own queues
+// (see the API's queue.QueueCritical and queue.QueueLow); default ones use the
+// job queue.
+const (
+	criticalQueue = "critical"
+	lowQueue      = "low"
+)
+
+// queuePriorities returns the asynq queue weights: staging jobs get the bulk of
+// the worker's attention, weighted by priority as configured, while
+// deliveries still make steady progress. Weights below 1 count as 1, since
+// asynq ignores queues without a positive weight.
+func queuePriorities(jobQueue string, job config.Job) map[string]int {
+	return map[string]int{
+		criticalQueue: max(job.CriticalWeight, 1),
+		jobQueue:      max(job.DefaultWeight, 1),
+		lowQueue:      max(job.LowWeight, 1),
+		webhooksQueue: 2,
+		emailsQueue:   1,
+	}
+}
+
+// Outbound deliveries back off exponentially from deliveryRetryBase, doubling
+// per attempt up to deliveryRetryMax, so a receiver that is down for a while
+// isn't hammered while the default 8 attempts still span about an hour.
+const (
+	deliveryRetryBase = 30 * time.Second
+	deliveryRetryMax  = time.Hour
+)
+
+// retryDelay retries deferred jobs when they asked to be, and otherwise uses
+// exponential backoff with jitter for delivery tasks and asynq's default
+// policy for everything else.
+func retryDelay(n int, err error, t *asynq.Task) time.Duration {
+	var deferred *DeferredError
+	if errors.As(err, &deferred) {
+		return max(time.Until(deferred.Until), time.Second)
+	}
+	if t.Type() != "delivery:send" {
+		return asynq.DefaultRetryDelayFunc(n, err, t)
+	}
+	return deliveryBackoff(n, rand.Float64())
+}
+
+// deliveryBackoff returns the delay before retry n (0-based), spread by up to
+// 20% using jitter in [0, 1) so failed deliveries don't retry in lockstep.
+func deliveryBackoff(n int, jitter float64) time.Duration {
+	d := deliveryRetryMax
+	if n < 16 {
+		d = min(deliveryRetryBase<<n, deliveryRetryMax)
+	}
+	return d + time.Duration(float64(d)*0.2*jitter)
+}
+
+// givenUp reports whether a failed task of taskType is a dead letter: a
+// staging task that used up its retries or asked not to be retried.
+func givenUp(taskType string, retried, maxRetry int, err error) bool {
+	if taskType != "stage:run" && taskType != "declutter:run" {
+		return false
+	}
+	return retried >= maxRetry || errors.Is(err, asynq.SkipRetry)
+}
+
+// recordDeadLetter stores the failed task t in deadLetters when asynq is about
+// to archive it. ctx is the task's context, which may already be done.
+func recordDeadLetter(ctx context.Context, deadLetters repository.DeadLetterRepository, t *asynq.Task, err error) {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if !givenUp(t.Type(), retried, maxRetry, err) {
+		return
+	}
+	taskID, _ := asynq.GetTaskID(ctx)
+	queueName, _ := asynq.GetQueueName(ctx)
+
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	letter := repository.DeadLetter{
+		TaskID:   taskID,
+		TaskType: t.Type(),
+		Queue:    queueName,
+		Payload:  t.Payload(),
+		Error:    err.Error(),
+		Attempts: retried,
+	}
+	if recErr := deadLetters.RecordDeadLetter(writeCtx, letter); recErr != nil {
+		logging.Default().Error(ctx, "failed to record dead letter", "task_id", taskID, "error", recErr)
+	}
+}
+
+// bridge hands an asynq task to the pull-based consumer and waits for it to
+// report completion or failure through MarkJobCompleted / MarkJobFailed.
+func (c *AsynqQueueClient) bridge(ctx context.Context, t *asynq.Task) error {
+	logger := logging.Default()
+	logger.Info(ctx, "=== ASYNQ HANDLER CALLED ===", "task_type", t.Type())
+
+	// Create a local job id to correlate completion/failure.
+	jobID := fmt.Sprintf("%d", time.Now().UnixNano())
+	jb := &Job{
+		ID:      jobID,
+		Type:    t.Type(),
+		Payload: t.Payload(),
+		Status:  "queued",
+	}
+	resCh := make(chan error, 1)
+	c.mu.Lock()
+	c.results[jobID] = resCh
+	c.mu.Unlock()
+
+	// Deliver job to consumer
+	logger.Info(ctx, "asynq task received, delivering to job channel",
+		"task_type", t.Type(), "job_id", jobID, "channel_len", len(c.jobs))
+	select {
+	case c.jobs <- jb:
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.results, jobID)
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+
+	// Wait for processing result from the worker.
+	select {
+	case err := <-resCh:
+		if err != nil {
+			logger.Warn(ctx, "worker marked task failed", "task_type", t.Type(), "job_id", jobID, "error", err)
+		} else {
+			logger.Info(ctx, "worker marked task completed", "task_type", t.Type(), "job_id", jobID)
+		}
+		return err
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.results, jobID)
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
 // GetNextJob returns the next available job if present (non-blocking).
 func (c *AsynqQueueClient) GetNextJob(ctx context.Context) (*Job, error) {
 	select {
@@ -206,6 +371,39 @@ func (c *AsynqQueueClient) MarkJobCompleted(ctx context.Context, jobID string) e
 
 // MarkJobFailed reports failure back to the asynq handler (will retry per queue policy).
 func (c *AsynqQueueClient) MarkJobFailed(ctx context.Context, jobID string, errorMsg string) error {
+	return c.report(ctx, jobID, errors.New(errorMsg))
+}
+
+// DeferJob hands a job back to asynq to run again at until. The attempt does
+// not count towards the task's retries.
+func (c *AsynqQueueClient) DeferJob(ctx context.Context, jobID string, until time.Time) error {
+	return c.report(ctx, jobID, Defer(until))
+}
+
+// Shutdown stops taking tasks, hands the ones still waiting for the consumer
+// back to asynq to run again without using up an attempt, and waits for the
+// running ones to finish. asynq pushes tasks still running after its shutdown
+// timeout back to their queue.
+func (c *AsynqQueueClient) Shutdown() {
+	c.srv.Stop()
+	c.handBackWaiting()
+	c.srv.Shutdown()
+}
+
+// handBackWaiting defers every job waiting in the channel to run again now.
+func (c *AsynqQueueClient) handBackWaiting() {
+	for {
+		select {
+		case jb := <-c.jobs:
+			_ = c.report(context.Background(), jb.ID, Defer(time.Now()))
+		default:
+			return
+		}
+	}
+}
+
+// report sends a job's result to its waiting asynq handler.
+func (c *AsynqQueueClient) report(ctx context.Context, jobID string, err error) error {
 	c.mu.Lock()
 	ch, ok := c.results[jobID]
 	if ok {
@@ -215,7 +413,6 @@ func (c *AsynqQueueClient) MarkJobFailed(ctx context.Context, jobID string, erro
 	if !ok {
 		return nil
 	}
-	err := errors.New(errorMsg)
 	select {
 	case ch <- err:
 		return nil
@@ -0,0 +1,124 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+func TestDeliveryBackoff(t *testing.T) {
+	cases := []struct {
+		n      int
+		jitter float64
+		want   time.Duration
+	}{
+		{n: 0, want: 30 * time.Second},
+		{n: 1, want: time.Minute},
+		{n: 3, want: 4 * time.Minute},
+		{n: 6, want: 32 * time.Minute},
+		{n: 7, want: time.Hour},
+		{n: 40, want: time.Hour},
+		{n: 0, jitter: 0.5, want: 33 * time.Second},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, deliveryBackoff(tc.n, tc.jitter), "n=%d jitter=%v", tc.n, tc.jitter)
+	}
+}
+
+func TestRetryDelay_Deferred(t *testing.T) {
+	task := asynq.NewTask("stage:run", nil)
+
+	d := retryDelay(0, Defer(time.Now().Add(10*time.Minute)), task)
+	assert.InDelta(t, float64(10*time.Minute), float64(d), float64(time.Second))
+
+	assert.Equal(t, time.Second, retryDelay(0, Defer(time.Now().Add(-time.Minute)), task))
+}
+
+func TestStageTaskType(t *testing.T) {
+	cases := []struct {
+		payload string
+		want    string
+	}{
+		{payload: `{"image_id":"img-1"}`, want: "stage:run"},
+		{payload: `{"image_id":"img-1","mode":"stage"}`, want: "stage:run"},
+		{payload: `{"image_id":"img-1","mode":"declutter"}`, want: "declutter:run"},
+		{payload: `{"image_id":"img-1","mode":"both"}`, want: "declutter:run"},
+		{payload: `not json`, want: "stage:run"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, stageTaskType([]byte(tc.payload)), tc.payload)
+	}
+}
+
+func TestStageQueue(t *testing.T) {
+	cases := []struct {
+		payload string
+		want    string
+	}{
+		{payload: `{"image_id":"img-1"}`, want: "jobs"},
+		{payload: `{"image_id":"img-1","priority":"default"}`, want: "jobs"},
+		{payload: `{"image_id":"img-1","priority":"critical"}`, want: "critical"},
+		{payload: `{"image_id":"img-1","priority":"low"}`, want: "low"},
+		{payload: `not json`, want: "jobs"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, stageQueue([]byte(tc.payload), "jobs"), tc.payload)
+	}
+}
+
+func TestQueuePriorities(t *testing.T) {
+	got := queuePriorities("jobs", config.Job{CriticalWeight: 10, DefaultWeight: 5, LowWeight: 0})
+	assert.Equal(t, map[string]int{"critical": 10, "jobs": 5, "low": 1, "webhooks": 2, "emails": 1}, got)
+}
+
+func TestGivenUp(t *testing.T) {
+	failed := errors.New("provider down")
+	cases := []struct {
+		name     string
+		taskType string
+		retried  int
+		maxRetry int
+		err      error
+		want     bool
+	}{
+		{name: "retries left", taskType: "stage:run", retried: 3, maxRetry: 25, err: failed, want: false},
+		{name: "retries used up", taskType: "stage:run", retried: 25, maxRetry: 25, err: failed, want: true},
+		{name: "declutter retries used up", taskType: "declutter:run", retried: 25, maxRetry: 25, err: failed, want: true},
+		{
+			name: "skip retry", taskType: "stage:run", retried: 0, maxRetry: 25,
+			err: fmt.Errorf("bad payload: %w", asynq.SkipRetry), want: true,
+		},
+		{name: "not a staging task", taskType: "delivery:send", retried: 8, maxRetry: 8, err: failed, want: false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, givenUp(tc.taskType, tc.retried, tc.maxRetry, tc.err), tc.name)
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	assert.Equal(t, 70*time.Second, shutdownTimeout(config.Job{ShutdownTimeoutSeconds: 60}))
+	assert.Equal(t, handOffGrace, shutdownTimeout(config.Job{ShutdownTimeoutSeconds: -5}))
+}
+
+func TestHandBackWaiting(t *testing.T) {
+	res := make(chan error, 1)
+	c := &AsynqQueueClient{jobs: make(chan *Job, 1), results: map[string]chan error{"job-1": res}}
+	c.jobs <- &Job{ID: "job-1"}
+
+	c.handBackWaiting()
+
+	var deferred *DeferredError
+	assert.ErrorAs(t, <-res, &deferred)
+	assert.Empty(t, c.jobs)
+	assert.Empty(t, c.results)
+}
@@ -0,0 +1,117 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/real-staging-ai/worker/internal/config"
+)
+
+// StageRequeuer puts stage:run and declutter:run tasks back on their staging queue.
+type StageRequeuer struct {
+	client *asynq.Client
+	queue  string
+}
+
+// NewStageRequeuer creates a requeuer on the configured Redis and staging queue.
+func NewStageRequeuer(cfg *config.Config) (*StageRequeuer, error) {
+	addr := cfg.Redis.Addr()
+	if addr == "" {
+		return nil, errors.New(
+			"redis address not set. Set REDIS_HOST and REDIS_PORT in config or environment")
+	}
+	return &StageRequeuer{
+		client: asynq.NewClient(asynq.RedisClientOpt{Addr: addr}),
+		queue:  jobQueueName(cfg),
+	}, nil
+}
+
+// RequeueStage enqueues a stage:run task with the given payload, or a
+// declutter:run task when the payload's mode removes furniture, on the queue
+// of the payload's priority.
+func (r *StageRequeuer) RequeueStage(ctx context.Context, payload []byte) error {
+	taskType := stageTaskType(payload)
+	task := asynq.NewTask(taskType, payload)
+	if _, err := r.client.EnqueueContext(ctx, task, asynq.Queue(stageQueue(payload, r.queue))); err != nil {
+		return fmt.Errorf("enqueue %s: %w", taskType, err)
+	}
+	return nil
+}
+
+// stageTaskType returns the task type a staging job's payload runs as. The
+// API only sets a mode on declutter:run jobs.
+func stageTaskType(payload []byte) string {
+	var job struct {
+		Mode string `json:"mode"`
+	}
+	if json.Unmarshal(payload, &job) == nil && job.Mode != "" && job.Mode != "stage" {
+		return "declutter:run"
+	}
+	return "stage:run"
+}
+
+// stageQueue returns the queue a staging job's payload is put on: the queue
+// of its priority, or jobQueue for the default priority.
+func stageQueue(payload []byte, jobQueue string) string {
+	var job struct {
+		Priority string `json:"priority"`
+	}
+	if json.Unmarshal(payload, &job) != nil {
+		return jobQueue
+	}
+	switch job.Priority {
+	case "critical":
+		return criticalQueue
+	case "low":
+		return lowQueue
+	default:
+		return jobQueue
+	}
+}
+
+// Close releases the requeuer's Redis connection.
+func (r *StageRequeuer) Close() error {
+	return r.client.Close()
+}
+
+// taskMaxRetry bounds retries of follow-up tasks; they are cheap to redo by
+// hand and failing ones would otherwise occupy the queue for days.
+const taskMaxRetry = 3
+
+// TaskEnqueuer puts follow-up tasks, such as thumbnail:run, on the job queue.
+type TaskEnqueuer struct {
+	client *asynq.Client
+	queue  string
+}
+
+// NewTaskEnqueuer creates an enqueuer on the configured Redis and job queue.
+func NewTaskEnqueuer(cfg *config.Config) (*TaskEnqueuer, error) {
+	addr := cfg.Redis.Addr()
+	if addr == "" {
+		return nil, errors.New(
+			"redis address not set. Set REDIS_HOST and REDIS_PORT in config or environment")
+	}
+	return &TaskEnqueuer{
+		client: asynq.NewClient(asynq.RedisClientOpt{Addr: addr}),
+		queue:  jobQueueName(cfg),
+	}, nil
+}
+
+// Enqueue enqueues a task of the given type and payload.
+func (e *TaskEnqueuer) Enqueue(ctx context.Context, taskType string, payload []byte) error {
+	_, err := e.client.EnqueueContext(ctx, asynq.NewTask(taskType, payload),
+		asynq.Queue(e.queue), asynq.MaxRetry(taskMaxRetry))
+	if err != nil {
+		return fmt.Errorf("enqueue %s: %w", taskType, err)
+	}
+	return nil
+}
+
+// Close releases the enqueuer's Redis connection.
+func (e *TaskEnqueuer) Close() error {
+	return e.client.Close()
+}
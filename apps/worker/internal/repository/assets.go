@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out asset_repository_mock.go . AssetRepository
+
+// CutoutAsset is a cut-out to be recorded in image_assets.
+type CutoutAsset struct {
+	URL     string
+	Width   int
+	Height  int
+	SourceX int
+	SourceY int
+}
+
+// ThumbnailAsset is a thumbnail to be recorded in image_assets.
+type ThumbnailAsset struct {
+	Size   string
+	URL    string
+	Width  int
+	Height int
+}
+
+// AssetRepository records cutout progress and the child assets it produces.
+type AssetRepository interface {
+	// SetCutoutsProcessing marks the image's cutout run as "processing".
+	SetCutoutsProcessing(ctx context.Context, imageID string) error
+	// ReplaceCutouts swaps the image's cut-outs for the given set and marks the run "ready".
+	ReplaceCutouts(ctx context.Context, imageID string, cutouts []CutoutAsset) error
+	// SetCutoutsError marks the image's cutout run as "error" with a message.
+	SetCutoutsError(ctx context.Context, imageID string, errorMsg string) error
+	// ReplaceThumbnails swaps the image's thumbnails of one source ("original"
+	// or "staged") for the given set.
+	ReplaceThumbnails(ctx context.Context, imageID, source string, thumbs []ThumbnailAsset) error
+}
+
+// DefaultAssetRepository is a sql.DB-backed implementation using plain SQL.
+type DefaultAssetRepository struct {
+	db *sql.DB
+}
+
+// NewAssetRepository constructs a new DefaultAssetRepository.
+func NewAssetRepository(db *sql.DB) *DefaultAssetRepository {
+	return &DefaultAssetRepository{db: db}
+}
+
+// SetCutoutsProcessing marks the image's cutout run as "processing".
+func (r *DefaultAssetRepository) SetCutoutsProcessing(ctx context.Context, imageID string) error {
+	const q = `
+		UPDATE images
+		SET cutout_status = 'processing', cutout_error = NULL, updated_at = now()
+		WHERE id = $1::uuid;
+	`
+	if _, err := r.db.ExecContext(ctx, q, imageID); err != nil {
+		return fmt.Errorf("update cutout status to processing: %w", err)
+	}
+	return nil
+}
+
+// ReplaceCutouts swaps the image's cut-outs for the given set and marks the
+// run "ready" in a single transaction, so readers never see a partial set.
+func (r *DefaultAssetRepository) ReplaceCutouts(ctx context.Context, imageID string, cutouts []CutoutAsset) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin cutout transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const del = `DELETE FROM image_assets WHERE image_id = $1::uuid AND kind = 'cutout';`
+	if _, err := tx.ExecContext(ctx, del, imageID); err != nil {
+		return fmt.Errorf("delete previous cutouts: %w", err)
+	}
+
+	const ins = `
+		INSERT INTO image_assets (image_id, kind, url, content_type, width, height, source_x, source_y)
+		VALUES ($1::uuid, 'cutout', $2, 'image/png', $3, $4, $5, $6);
+	`
+	for _, c := range cutouts {
+		if _, err := tx.ExecContext(ctx, ins, imageID, c.URL, c.Width, c.Height, c.SourceX, c.SourceY); err != nil {
+			return fmt.Errorf("insert cutout: %w", err)
+		}
+	}
+
+	const done = `
+		UPDATE images
+		SET cutout_status = 'ready', cutout_error = NULL, updated_at = now()
+		WHERE id = $1::uuid;
+	`
+	if _, err := tx.ExecContext(ctx, done, imageID); err != nil {
+		return fmt.Errorf("update cutout status to ready: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit cutouts: %w", err)
+	}
+	return nil
+}
+
+// SetCutoutsError marks the image's cutout run as "error" with a message.
+func (r *DefaultAssetRepository) SetCutoutsError(ctx context.Context, imageID string, errorMsg string) error {
+	if errorMsg == "" {
+		return fmt.Errorf("error message cannot be empty")
+	}
+	const q = `
+		UPDATE images
+		SET cutout_status = 'error', cutout_error = $2, updated_at = now()
+		WHERE id = $1::uuid;
+	`
+	if _, err := r.db.ExecContext(ctx, q, imageID, errorMsg); err != nil {
+		return fmt.Errorf("update cutout status to error: %w", err)
+	}
+	return nil
+}
+
+// ReplaceThumbnails swaps the image's thumbnails of one source for the given
+// set in a single transaction, so readers see either the old or the new set.
+func (r *DefaultAssetRepository) ReplaceThumbnails(
+	ctx context.Context, imageID, source string, thumbs []ThumbnailAsset,
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin thumbnail transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const del = `DELETE FROM image_assets WHERE image_id = $1::uuid AND kind = 'thumbnail' AND source = $2;`
+	if _, err := tx.ExecContext(ctx, del, imageID, source); err != nil {
+		return fmt.Errorf("delete previous thumbnails: %w", err)
+	}
+
+	const ins = `
+		INSERT INTO image_assets (image_id, kind, url, content_type, width, height, source, size)
+		VALUES ($1::uuid, 'thumbnail', $2, 'image/jpeg', $3, $4, $5, $6);
+	`
+	for _, t := range thumbs {
+		if _, err := tx.ExecContext(ctx, ins, imageID, t.URL, t.Width, t.Height, source, t.Size); err != nil {
+			return fmt.Errorf("insert thumbnail: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit thumbnails: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockAssetRepo(t *testing.T) (*DefaultAssetRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return NewAssetRepository(db), mock, func() { _ = db.Close() }
+}
+
+func TestDefaultAssetRepository_SetCutoutsProcessing(t *testing.T) {
+	repo, mock, cleanup := newMockAssetRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec("SET cutout_status = 'processing'").WithArgs("img-1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.SetCutoutsProcessing(context.Background(), "img-1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultAssetRepository_ReplaceCutouts(t *testing.T) {
+	cutouts := []CutoutAsset{
+		{URL: "s3://bucket/cutouts/img-1/run-1.png", Width: 300, Height: 200, SourceX: 10, SourceY: 20},
+		{URL: "s3://bucket/cutouts/img-1/run-2.png", Width: 80, Height: 120, SourceX: 400, SourceY: 50},
+	}
+
+	t.Run("success: replaces cut-outs and marks ready", func(t *testing.T) {
+		repo, mock, cleanup := newMockAssetRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM image_assets").WithArgs("img-1").WillReturnResult(sqlmock.NewResult(0, 3))
+		for _, c := range cutouts {
+			mock.ExpectExec("INSERT INTO image_assets").
+				WithArgs("img-1", c.URL, c.Width, c.Height, c.SourceX, c.SourceY).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectExec("SET cutout_status = 'ready'").WithArgs("img-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.ReplaceCutouts(context.Background(), "img-1", cutouts))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: insert error rolls back", func(t *testing.T) {
+		repo, mock, cleanup := newMockAssetRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM image_assets").WithArgs("img-1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO image_assets").WillReturnError(errors.New("boom"))
+		mock.ExpectRollback()
+
+		err := repo.ReplaceCutouts(context.Background(), "img-1", cutouts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insert cutout")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDefaultAssetRepository_SetCutoutsError(t *testing.T) {
+	t.Run("success: stores message", func(t *testing.T) {
+		repo, mock, cleanup := newMockAssetRepo(t)
+		defer cleanup()
+
+		mock.ExpectExec("SET cutout_status = 'error'").WithArgs("img-1", "boom").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.SetCutoutsError(context.Background(), "img-1", "boom"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: empty message", func(t *testing.T) {
+		repo, _, cleanup := newMockAssetRepo(t)
+		defer cleanup()
+
+		assert.Error(t, repo.SetCutoutsError(context.Background(), "img-1", ""))
+	})
+}
+
+func TestDefaultAssetRepository_ReplaceThumbnails(t *testing.T) {
+	thumbs := []ThumbnailAsset{
+		{Size: "small", URL: "s3://bucket/thumbnails/img-1/staged-small-run.jpg", Width: 256, Height: 192},
+		{Size: "medium", URL: "s3://bucket/thumbnails/img-1/staged-medium-run.jpg", Width: 640, Height: 480},
+	}
+
+	t.Run("success: replaces the source's thumbnails", func(t *testing.T) {
+		repo, mock, cleanup := newMockAssetRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM image_assets").WithArgs("img-1", "staged").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		for _, th := range thumbs {
+			mock.ExpectExec("INSERT INTO image_assets").
+				WithArgs("img-1", th.URL, th.Width, th.Height, "staged", th.Size).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.ReplaceThumbnails(context.Background(), "img-1", "staged", thumbs))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: insert error rolls back", func(t *testing.T) {
+		repo, mock, cleanup := newMockAssetRepo(t)
+		defer cleanup()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM image_assets").WithArgs("img-1", "original").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO image_assets").WillReturnError(errors.New("boom"))
+		mock.ExpectRollback()
+
+		err := repo.ReplaceThumbnails(context.Background(), "img-1", "original", thumbs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insert thumbnail")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
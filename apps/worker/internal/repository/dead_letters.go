@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out dead_letter_repository_mock.go . DeadLetterRepository
+
+// DeadLetter is a staging task that failed for good.
+type DeadLetter struct {
+	TaskID   string
+	TaskType string
+	Queue    string
+	Payload  []byte
+	Error    string
+	// Attempts is how many times the task was retried before it was given up.
+	Attempts int
+}
+
+// DeadLetterRepository stores staging tasks that failed for good, so admins
+// can retry or discard them.
+type DeadLetterRepository interface {
+	// RecordDeadLetter stores the task with its payload and last error.
+	RecordDeadLetter(ctx context.Context, letter DeadLetter) error
+}
+
+// DefaultDeadLetterRepository is a sql.DB-backed implementation using plain SQL.
+type DefaultDeadLetterRepository struct {
+	db *sql.DB
+}
+
+// NewDeadLetterRepository constructs a new DefaultDeadLetterRepository.
+func NewDeadLetterRepository(db *sql.DB) *DefaultDeadLetterRepository {
+	return &DefaultDeadLetterRepository{db: db}
+}
+
+// RecordDeadLetter stores the task. The payload's image is linked when it
+// still exists; payloads that aren't JSON are stored as a JSON string.
+func (r *DefaultDeadLetterRepository) RecordDeadLetter(ctx context.Context, letter DeadLetter) error {
+	const q = `
+		INSERT INTO dead_letters (task_id, task_type, queue, image_id, payload, error, attempts)
+		VALUES ($1, $2, $3, (SELECT id FROM images WHERE id::text = $4), $5::jsonb, $6, $7);
+	`
+	payload := letter.Payload
+	if !json.Valid(payload) {
+		payload, _ = json.Marshal(string(letter.Payload))
+	}
+	var job struct {
+		ImageID string `json:"image_id"`
+	}
+	_ = json.Unmarshal(payload, &job)
+
+	_, err := r.db.ExecContext(ctx, q,
+		letter.TaskID, letter.TaskType, letter.Queue, job.ImageID, string(payload), letter.Error, letter.Attempts,
+	)
+	if err != nil {
+		return fmt.Errorf("record dead letter: %w", err)
+	}
+	return nil
+}
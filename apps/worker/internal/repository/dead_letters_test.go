@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultDeadLetterRepository_RecordDeadLetter(t *testing.T) {
+	t.Run("success: stores the task with its image", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("INSERT INTO dead_letters").
+			WithArgs("task-1", "stage:run", "default", "img-1", `{"image_id":"img-1"}`, "provider down", 25).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = NewDeadLetterRepository(db).RecordDeadLetter(context.Background(), DeadLetter{
+			TaskID:   "task-1",
+			TaskType: "stage:run",
+			Queue:    "default",
+			Payload:  []byte(`{"image_id":"img-1"}`),
+			Error:    "provider down",
+			Attempts: 25,
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: stores a payload that isn't JSON as a string", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("INSERT INTO dead_letters").
+			WithArgs("task-1", "stage:run", "default", "", `"not json"`, "bad payload", 0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = NewDeadLetterRepository(db).RecordDeadLetter(context.Background(), DeadLetter{
+			TaskID:   "task-1",
+			TaskType: "stage:run",
+			Queue:    "default",
+			Payload:  []byte("not json"),
+			Error:    "bad payload",
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("INSERT INTO dead_letters").WillReturnError(assert.AnError)
+
+		err = NewDeadLetterRepository(db).RecordDeadLetter(context.Background(), DeadLetter{Payload: []byte(`{}`)})
+		assert.ErrorContains(t, err, "record dead letter")
+	})
+}
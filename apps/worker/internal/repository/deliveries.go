@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+//go:generate go run github.com/matryer/moq@v0.5.3 -out delivery_repository_mock.go . DeliveryRepository
+
+// ErrDeliveryNotFound is returned when an outbox row does not exist.
+var ErrDeliveryNotFound = errors.New("delivery not found")
+
+// Delivery is the worker's view of an outbound_deliveries row.
+type Delivery struct {
+	ID          string
+	Channel     string
+	Destination string
+	EventType   string
+	Subject     string
+	Payload     []byte
+	Status      string
+	Attempts    int
+	MaxAttempts int
+	// Secret is the signing key of the user's webhook endpoint, empty for
+	// deliveries that were not sent to a registered endpoint.
+	Secret string
+}
+
+// DeliveryRepository records the outcome of outbound webhook and email attempts.
+type DeliveryRepository interface {
+	// GetDelivery loads an outbox row by ID.
+	GetDelivery(ctx context.Context, id string) (*Delivery, error)
+	// MarkDelivered records a successful attempt.
+	MarkDelivered(ctx context.Context, id string, statusCode int) error
+	// MarkAttemptFailed records a failed attempt and returns the resulting status:
+	// "retrying" while attempts remain, "failed" once they are exhausted.
+	MarkAttemptFailed(ctx context.Context, id string, statusCode int, errorMsg string) (string, error)
+}
+
+// DefaultDeliveryRepository is a sql.DB-backed implementation using plain SQL.
+type DefaultDeliveryRepository struct {
+	db *sql.DB
+}
+
+// NewDeliveryRepository constructs a new DefaultDeliveryRepository.
+func NewDeliveryRepository(db *sql.DB) *DefaultDeliveryRepository {
+	return &DefaultDeliveryRepository{db: db}
+}
+
+// GetDelivery loads an outbox row by ID.
+func (r *DefaultDeliveryRepository) GetDelivery(ctx context.Context, id string) (*Delivery, error) {
+	const q = `
+		SELECT d.id, d.channel, d.destination, d.event_type, COALESCE(d.subject, ''), d.payload, d.status,
+		       d.attempts, d.max_attempts, COALESCE(w.secret, '')
+		FROM outbound_deliveries d
+		LEFT JOIN webhook_endpoints w ON w.id = d.webhook_endpoint_id
+		WHERE d.id = $1::uuid;
+	`
+	var d Delivery
+	err := r.db.QueryRowContext(ctx, q, id).Scan(
+		&d.ID, &d.Channel, &d.Destination, &d.EventType, &d.Subject, &d.Payload, &d.Status, &d.Attempts, &d.MaxAttempts,
+		&d.Secret,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("get delivery: %w", err)
+	}
+	return &d, nil
+}
+
+// MarkDelivered records a successful attempt. A zero statusCode is stored as NULL
+// (e.g. for SMTP, which has no HTTP status).
+func (r *DefaultDeliveryRepository) MarkDelivered(ctx context.Context, id string, statusCode int) error {
+	const q = `
+		UPDATE outbound_deliveries
+		SET status = 'delivered', attempts = attempts + 1, last_status_code = NULLIF($2, 0),
+		    last_error = NULL, last_attempt_at = now(), delivered_at = now(), updated_at = now()
+		WHERE id = $1::uuid;
+	`
+	if _, err := r.db.ExecContext(ctx, q, id, statusCode); err != nil {
+		return fmt.Errorf("mark delivery delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkAttemptFailed records a failed attempt and returns the resulting status.
+func (r *DefaultDeliveryRepository) MarkAttemptFailed(
+	ctx context.Context, id string, statusCode int, errorMsg string,
+) (string, error) {
+	const q = `
+		UPDATE outbound_deliveries
+		SET attempts = attempts + 1,
+		    status = CASE WHEN attempts + 1 >= max_attempts THEN 'failed' ELSE 'retrying' END,
+		    last_status_code = NULLIF($2, 0), last_error = $3, last_attempt_at = now(), updated_at = now()
+		WHERE id = $1::uuid
+		RETURNING status;
+	`
+	var status string
+	if err := r.db.QueryRowContext(ctx, q, id, statusCode, errorMsg).Scan(&status); err != nil {
+		return "", fmt.Errorf("mark delivery attempt failed: %w", err)
+	}
+	return status, nil
+}
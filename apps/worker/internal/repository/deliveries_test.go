@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockDeliveryRepo(t *testing.T) (*DefaultDeliveryRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	return NewDeliveryRepository(db), mock, func() { _ = db.Close() }
+}
+
+func TestDefaultDeliveryRepository_GetDelivery(t *testing.T) {
+	t.Run("success: loads row", func(t *testing.T) {
+		repo, mock, cleanup := newMockDeliveryRepo(t)
+		defer cleanup()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "channel", "destination", "event_type", "subject", "payload", "status", "attempts", "max_attempts",
+			"secret",
+		}).AddRow("d-1", "webhook", "https://example.com", "image.ready", "", []byte(`{}`), "pending", 0, 8, "whsec_1")
+		mock.ExpectQuery("FROM outbound_deliveries").WithArgs("d-1").WillReturnRows(rows)
+
+		d, err := repo.GetDelivery(context.Background(), "d-1")
+		require.NoError(t, err)
+		assert.Equal(t, "webhook", d.Channel)
+		assert.Equal(t, 8, d.MaxAttempts)
+		assert.Equal(t, "whsec_1", d.Secret)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: not found", func(t *testing.T) {
+		repo, mock, cleanup := newMockDeliveryRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery("FROM outbound_deliveries").WithArgs("d-1").WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetDelivery(context.Background(), "d-1")
+		assert.ErrorIs(t, err, ErrDeliveryNotFound)
+	})
+}
+
+func TestDefaultDeliveryRepository_MarkDelivered(t *testing.T) {
+	repo, mock, cleanup := newMockDeliveryRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec("SET status = 'delivered'").WithArgs("d-1", 200).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.MarkDelivered(context.Background(), "d-1", 200))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultDeliveryRepository_MarkAttemptFailed(t *testing.T) {
+	t.Run("success: returns resulting status", func(t *testing.T) {
+		repo, mock, cleanup := newMockDeliveryRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery("RETURNING status").
+			WithArgs("d-1", 503, "status 503: unavailable").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("failed"))
+
+		status, err := repo.MarkAttemptFailed(context.Background(), "d-1", 503, "status 503: unavailable")
+		require.NoError(t, err)
+		assert.Equal(t, "failed", status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		repo, mock, cleanup := newMockDeliveryRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery("RETURNING status").WillReturnError(assert.AnError)
+
+		_, err := repo.MarkAttemptFailed(context.Background(), "d-1", 0, "boom")
+		assert.ErrorContains(t, err, "mark delivery attempt failed")
+	})
+}
@@ -3,24 +3,92 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	_ "github.com/lib/pq"
+
+	"github.com/real-staging-ai/worker/internal/malware"
+	"github.com/real-staging-ai/worker/internal/photometa"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out image_repository_mock.go . ImageRepository
 
 // ImageRepository exposes write operations necessary for the worker to
-// update image processing status and final staged URL.
+// update image processing status and final staged URL. Each status change
+// also appends an image_events row so the image's history stays complete.
 type ImageRepository interface {
-	// SetProcessing marks the image as "processing".
-	SetProcessing(ctx context.Context, imageID string) error
-	// SetReady marks the image as "ready" and sets the staged URL.
-	SetReady(ctx context.Context, imageID string, stagedURL string) error
+	// SetProcessing marks the image as "processing" with the model about to stage it.
+	SetProcessing(ctx context.Context, imageID string, modelID string) error
+	// SetReady marks the image as "ready", sets the staged URL and stores the run's stats.
+	SetReady(ctx context.Context, imageID string, stagedURL string, stats StageStats) error
+	// AddOutput stores another image the job for primaryImageID made as a
+	// ready variant of it and returns the new image's ID.
+	AddOutput(ctx context.Context, primaryImageID string, stagedURL string, stats StageStats) (string, error)
 	// SetError marks the image as "error" and sets the error message.
 	SetError(ctx context.Context, imageID string, errorMsg string) error
+	// SetValidationError marks the image as "error" because its original was
+	// rejected, storing the validation code alongside the message.
+	SetValidationError(ctx context.Context, imageID string, code string, errorMsg string) error
+	// SetRejected marks the image as "rejected" because its original carried
+	// malware, records the detection and queues an alert email to every admin.
+	SetRejected(ctx context.Context, imageID string, errorMsg string, detection MalwareDetection) error
+	// SetPromptVariant tags the image with the prompt experiment and variant
+	// it is staged with.
+	SetPromptVariant(ctx context.Context, imageID, experimentID, variantID string) error
+	// SetDetectedRoomType records the room type detected for an image uploaded
+	// without one and the detection model's confidence in it.
+	SetDetectedRoomType(ctx context.Context, imageID, roomType string, confidence float64) error
+	// SetOriginalOrientation records the EXIF orientation found on the original
+	// before it was normalized. It applies to every image sharing the original.
+	SetOriginalOrientation(ctx context.Context, originalURL string, orientation int) error
+	// SetOriginalMetadata stores the EXIF metadata stripped from the original.
+	// It applies to every image sharing the original.
+	SetOriginalMetadata(ctx context.Context, originalURL string, metadata *photometa.Metadata) error
+	// ListOriginalsMissingOrientation returns distinct original URLs, ordered and
+	// greater than afterURL, whose orientation has not been inspected yet.
+	ListOriginalsMissingOrientation(ctx context.Context, afterURL string, limit int) ([]string, error)
+	// SetPrediction records the Replicate prediction staging the image, so
+	// the API can cancel it.
+	SetPrediction(ctx context.Context, imageID, predictionID string) error
+	// IsCanceled reports whether the image's owner canceled its staging job.
+	IsCanceled(ctx context.Context, imageID string) (bool, error)
+}
+
+// StageStats describes a finished staging run for the image's history.
+type StageStats struct {
+	// ModelID is the model that produced the staged image.
+	ModelID string
+	// Duration is how long staging took, from pickup to upload.
+	Duration time.Duration
+	// Blurhash is the staged image's placeholder; empty leaves it unset.
+	Blurhash string
+	// ContentType is the staged image's format, such as "image/webp"; empty
+	// leaves it unset.
+	ContentType string
+	// CostUSD is what the run's prediction cost.
+	CostUSD float64
+	// Seed is the seed the model ran with; nil keeps the requested one.
+	Seed *int64
 }
 
+// MalwareDetection describes an infected original that was quarantined.
+type MalwareDetection struct {
+	// OriginalURL is where the upload was stored.
+	OriginalURL string
+	// QuarantineURL is where it was moved.
+	QuarantineURL string
+	// Scanner names the scanner that flagged it.
+	Scanner string
+	// Signature is what the scanner found.
+	Signature string
+}
+
+// MalwareAlertEventType tags the admin alert emails in the delivery log.
+const MalwareAlertEventType = "security.malware_detected"
+
 // DefaultImageRepository is a sql.DB-backed implementation using plain SQL.
 type DefaultImageRepository struct {
 	db *sql.DB
@@ -31,49 +99,344 @@ func NewImageRepository(db *sql.DB) *DefaultImageRepository {
 	return &DefaultImageRepository{db: db}
 }
 
-// SetProcessing marks the image as "processing".
-func (r *DefaultImageRepository) SetProcessing(ctx context.Context, imageID string) error {
+// SetProcessing marks the image as "processing" and records which model is
+// staging it. Retries append another event, so the history shows each attempt.
+func (r *DefaultImageRepository) SetProcessing(ctx context.Context, imageID string, modelID string) error {
 	const q = `
-		UPDATE images
-		SET status = 'processing', updated_at = now()
-		WHERE id = $1::uuid AND status IN ('queued','processing');
+		WITH updated AS (
+			UPDATE images
+			SET status = 'processing', updated_at = now()
+			WHERE id = $1::uuid AND status IN ('queued','processing')
+			RETURNING id, status, prompt, cost_usd
+		)
+		INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd)
+		SELECT id, status, 'worker', prompt, NULLIF($2::text, ''), cost_usd FROM updated;
 	`
-	if _, err := r.db.ExecContext(ctx, q, imageID); err != nil {
+	if _, err := r.db.ExecContext(ctx, q, imageID, modelID); err != nil {
 		return fmt.Errorf("update image status to processing: %w", err)
 	}
 	return nil
 }
 
-// SetReady marks the image as "ready" and sets the staged URL.
-// This operation is idempotent in the sense that reapplying the same values
-// does not cause an error or adverse effects.
-func (r *DefaultImageRepository) SetReady(ctx context.Context, imageID string, stagedURL string) error {
+// SetReady marks the image as "ready", sets the staged URL, content type and blurhash and
+// stores the processing time, cost and the seed the model reported. The ready event records the cost too,
+// so each run's cost is kept when the image is staged again. This operation is
+// idempotent in the sense that reapplying the same values does not cause an
+// error or adverse effects.
+func (r *DefaultImageRepository) SetReady(
+	ctx context.Context, imageID string, stagedURL string, stats StageStats,
+) error {
 	if stagedURL == "" {
 		return fmt.Errorf("stagedURL cannot be empty")
 	}
 	const q = `
-		UPDATE images
-		SET staged_url = $2, status = 'ready', updated_at = now()
-		WHERE id = $1::uuid AND status IN ('queued','processing');
+		WITH updated AS (
+			UPDATE images
+			SET staged_url = $2, status = 'ready', processing_time_ms = $4, blurhash = NULLIF($5::text, ''),
+				cost_usd = $6, seed = COALESCE($7::bigint, seed), staged_content_type = NULLIF($8::text, ''),
+				updated_at = now()
+			WHERE id = $1::uuid AND status IN ('queued','processing')
+			RETURNING id, status, prompt, cost_usd, processing_time_ms
+		)
+		INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, processing_time_ms)
+		SELECT id, status, 'worker', prompt, NULLIF($3::text, ''), cost_usd, processing_time_ms FROM updated;
 	`
-	if _, err := r.db.ExecContext(ctx, q, imageID, stagedURL); err != nil {
+	_, err := r.db.ExecContext(ctx, q,
+		imageID, stagedURL, stats.ModelID, stats.Duration.Milliseconds(), stats.Blurhash, stats.CostUSD, stats.Seed,
+		stats.ContentType)
+	if err != nil {
 		return fmt.Errorf("update image with staged url: %w", err)
 	}
 	return nil
 }
 
-// SetError marks the image as "error" and stores an error message.
+// AddOutput stores another image the model made in the job that staged
+// primaryImageID, for configs that ask for several. The output becomes a new
+// ready image sharing the primary image's original, room type, style, seed,
+// prompt, mask and mode, with primary_image_id pointing back at it; the original's reference
+// is taken in the same statement, and a ready event starts its history. It
+// returns "" without storing anything when the primary image was deleted or
+// the output is already stored, so a retried job doesn't add it twice.
+func (r *DefaultImageRepository) AddOutput(
+	ctx context.Context, primaryImageID string, stagedURL string, stats StageStats,
+) (string, error) {
+	if stagedURL == "" {
+		return "", fmt.Errorf("stagedURL cannot be empty")
+	}
+	const q = `
+		WITH source AS (
+			SELECT project_id, original_url, original_image_id, room_type, style, seed, prompt, sandbox, mask_url, mode,
+				detected_room_type, room_type_confidence
+			FROM images
+			WHERE id = $1::uuid AND deleted_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM images WHERE primary_image_id = $1::uuid AND staged_url = $2)
+		), reference AS (
+			UPDATE original_images
+			SET reference_count = reference_count + 1, updated_at = now()
+			WHERE id = (SELECT original_image_id FROM source)
+		), created AS (
+			INSERT INTO images (project_id, original_url, original_image_id, room_type, style, seed, prompt, sandbox,
+				mask_url, mode, detected_room_type, room_type_confidence, primary_image_id, staged_url, status,
+				processing_time_ms, blurhash, cost_usd, model_used, staged_content_type)
+			SELECT project_id, original_url, original_image_id, room_type, style, seed, prompt, sandbox, mask_url, mode,
+				detected_room_type, room_type_confidence, $1::uuid, $2, 'ready', $4, NULLIF($5::text, ''), $6, NULLIF($3::text, ''),
+				NULLIF($7::text, '')
+			FROM source
+			RETURNING id, status, prompt, cost_usd, processing_time_ms
+		), event AS (
+			INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, processing_time_ms)
+			SELECT id, status, 'worker', prompt, NULLIF($3::text, ''), cost_usd, processing_time_ms FROM created
+		)
+		SELECT id FROM created;
+	`
+	var id string
+	err := r.db.QueryRowContext(ctx, q,
+		primaryImageID, stagedURL, stats.ModelID, stats.Duration.Milliseconds(), stats.Blurhash, stats.CostUSD,
+		stats.ContentType,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("insert extra output: %w", err)
+	}
+	return id, nil
+}
+
+// SetError marks the image as "error" and stores an error message. The
+// history event carries the model recorded when processing started.
 func (r *DefaultImageRepository) SetError(ctx context.Context, imageID string, errorMsg string) error {
 	if errorMsg == "" {
 		return fmt.Errorf("error message cannot be empty")
 	}
 	const q = `
-		UPDATE images
-		SET status = 'error', error = $2, updated_at = now()
-		WHERE id = $1::uuid AND status IN ('queued','processing');
+		WITH updated AS (
+			UPDATE images
+			SET status = 'error', error = $2, updated_at = now()
+			WHERE id = $1::uuid AND status IN ('queued','processing')
+			RETURNING id, status, prompt, cost_usd, error
+		)
+		INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, error)
+		SELECT id, status, 'worker', prompt, (
+			SELECT model_used FROM image_events
+			WHERE image_id = updated.id AND status = 'processing'
+			ORDER BY created_at DESC LIMIT 1
+		), cost_usd, error FROM updated;
 	`
 	if _, err := r.db.ExecContext(ctx, q, imageID, errorMsg); err != nil {
 		return fmt.Errorf("update image with error: %w", err)
 	}
 	return nil
 }
+
+// SetValidationError marks the image as "error" with a machine-readable code,
+// such as "corrupt_image", so clients can tell a rejected upload from a failed
+// staging run. Like SetError it appends an image_events row.
+func (r *DefaultImageRepository) SetValidationError(
+	ctx context.Context, imageID string, code string, errorMsg string,
+) error {
+	if code == "" || errorMsg == "" {
+		return fmt.Errorf("error code and message cannot be empty")
+	}
+	const q = `
+		WITH updated AS (
+			UPDATE images
+			SET status = 'error', error = $3, error_code = $2, updated_at = now()
+			WHERE id = $1::uuid AND status IN ('queued','processing')
+			RETURNING id, status, prompt, cost_usd, error
+		)
+		INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, error)
+		SELECT id, status, 'worker', prompt, (
+			SELECT model_used FROM image_events
+			WHERE image_id = updated.id AND status = 'processing'
+			ORDER BY created_at DESC LIMIT 1
+		), cost_usd, error FROM updated;
+	`
+	if _, err := r.db.ExecContext(ctx, q, imageID, code, errorMsg); err != nil {
+		return fmt.Errorf("update image with validation error: %w", err)
+	}
+	return nil
+}
+
+// SetRejected marks the image as "rejected" with the malware_detected code and
+// appends an image_events row like SetValidationError. In the same statement
+// it records the detection and writes an alert email for every admin to the
+// outbox, where the API's relay picks it up. The detection and alerts are
+// written even if the image has moved on, since the file was quarantined.
+func (r *DefaultImageRepository) SetRejected(
+	ctx context.Context, imageID string, errorMsg string, detection MalwareDetection,
+) error {
+	if errorMsg == "" || detection.Signature == "" {
+		return fmt.Errorf("error message and signature cannot be empty")
+	}
+	const q = `
+		WITH updated AS (
+			UPDATE images
+			SET status = 'rejected', error = $3, error_code = $2, updated_at = now()
+			WHERE id = $1::uuid AND status IN ('queued','processing')
+			RETURNING id, status, prompt, cost_usd, error
+		), history AS (
+			INSERT INTO image_events (image_id, status, source, prompt, cost_usd, error)
+			SELECT id, status, 'worker', prompt, cost_usd, error FROM updated
+		), detection AS (
+			INSERT INTO malware_detections (image_id, original_url, quarantine_url, scanner, signature)
+			VALUES ($1::uuid, $4, $5, $6, $7)
+		)
+		INSERT INTO outbound_deliveries (channel, destination, event_type, subject, payload)
+		SELECT 'email', u.email, $8, $9, jsonb_build_object('body', $10::text)
+		FROM users u
+		WHERE u.role = 'admin' AND COALESCE(u.email, '') <> '';
+	`
+	subject, body := malwareAlertEmail(imageID, detection)
+	_, err := r.db.ExecContext(ctx, q, imageID, malware.ErrorCode, errorMsg, detection.OriginalURL,
+		detection.QuarantineURL, detection.Scanner, detection.Signature, MalwareAlertEventType, subject, body)
+	if err != nil {
+		return fmt.Errorf("update image as rejected: %w", err)
+	}
+	return nil
+}
+
+// malwareAlertEmail tells admins what was found and where the file went.
+func malwareAlertEmail(imageID string, d MalwareDetection) (subject, body string) {
+	subject = "Malware detected in an uploaded image"
+	body = fmt.Sprintf("The %s scanner found %s in the original of image %s.\n\n"+
+		"Uploaded to: %s\nQuarantined at: %s\n\n"+
+		"The image was rejected and won't be staged. The file stays in quarantine until it is removed by hand.\n",
+		d.Scanner, d.Signature, imageID, d.OriginalURL, d.QuarantineURL)
+	return subject, body
+}
+
+// SetPromptVariant records which experiment variant's prompt stages the image.
+func (r *DefaultImageRepository) SetPromptVariant(
+	ctx context.Context, imageID, experimentID, variantID string,
+) error {
+	const q = `
+		UPDATE images
+		SET prompt_experiment_id = $2::uuid, prompt_variant_id = $3::uuid
+		WHERE id = $1::uuid;
+	`
+	if _, err := r.db.ExecContext(ctx, q, imageID, experimentID, variantID); err != nil {
+		return fmt.Errorf("update image prompt variant: %w", err)
+	}
+	return nil
+}
+
+// SetPrediction records the prediction on the image while it is queued or
+// processing; a canceled or finished image keeps the prediction it has.
+func (r *DefaultImageRepository) SetPrediction(ctx context.Context, imageID, predictionID string) error {
+	const q = `
+		UPDATE images
+		SET replicate_prediction_id = $2, updated_at = now()
+		WHERE id = $1::uuid AND status IN ('queued','processing');
+	`
+	if _, err := r.db.ExecContext(ctx, q, imageID, predictionID); err != nil {
+		return fmt.Errorf("update image prediction: %w", err)
+	}
+	return nil
+}
+
+// IsCanceled reads the image's status. An image that no longer exists isn't
+// canceled; its status updates simply find nothing to change.
+func (r *DefaultImageRepository) IsCanceled(ctx context.Context, imageID string) (bool, error) {
+	const q = `SELECT status = 'canceled' FROM images WHERE id = $1::uuid;`
+	var canceled bool
+	err := r.db.QueryRowContext(ctx, q, imageID).Scan(&canceled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get image status: %w", err)
+	}
+	return canceled, nil
+}
+
+// SetDetectedRoomType records the room type detected for the image and the
+// detection model's confidence in it.
+func (r *DefaultImageRepository) SetDetectedRoomType(
+	ctx context.Context, imageID, roomType string, confidence float64,
+) error {
+	if confidence < 0 || confidence > 1 {
+		return fmt.Errorf("invalid room type confidence: %v", confidence)
+	}
+	const q = `
+		UPDATE images
+		SET detected_room_type = $2, room_type_confidence = $3, updated_at = now()
+		WHERE id = $1::uuid;
+	`
+	if _, err := r.db.ExecContext(ctx, q, imageID, roomType, confidence); err != nil {
+		return fmt.Errorf("update image detected room type: %w", err)
+	}
+	return nil
+}
+
+// SetOriginalOrientation records the EXIF orientation found on the original
+// before it was normalized. It applies to every image sharing the original.
+func (r *DefaultImageRepository) SetOriginalOrientation(
+	ctx context.Context, originalURL string, orientation int,
+) error {
+	if orientation < 1 || orientation > 8 {
+		return fmt.Errorf("invalid orientation: %d", orientation)
+	}
+	const q = `
+		UPDATE images
+		SET original_orientation = $2, updated_at = now()
+		WHERE original_url = $1;
+	`
+	if _, err := r.db.ExecContext(ctx, q, originalURL, orientation); err != nil {
+		return fmt.Errorf("update image original orientation: %w", err)
+	}
+	return nil
+}
+
+// SetOriginalMetadata stores the EXIF metadata stripped from the original.
+// It applies to every image sharing the original.
+func (r *DefaultImageRepository) SetOriginalMetadata(
+	ctx context.Context, originalURL string, metadata *photometa.Metadata,
+) error {
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("marshal original metadata: %w", err)
+	}
+	const q = `
+		UPDATE images
+		SET original_metadata = $2, updated_at = now()
+		WHERE original_url = $1;
+	`
+	if _, err := r.db.ExecContext(ctx, q, originalURL, b); err != nil {
+		return fmt.Errorf("update image original metadata: %w", err)
+	}
+	return nil
+}
+
+// ListOriginalsMissingOrientation returns distinct original URLs, ordered and
+// greater than afterURL, whose orientation has not been inspected yet.
+func (r *DefaultImageRepository) ListOriginalsMissingOrientation(
+	ctx context.Context, afterURL string, limit int,
+) ([]string, error) {
+	const q = `
+		SELECT DISTINCT original_url
+		FROM images
+		WHERE original_orientation IS NULL AND deleted_at IS NULL
+		  AND original_url IS NOT NULL AND original_url > $1
+		ORDER BY original_url
+		LIMIT $2;
+	`
+	rows, err := r.db.QueryContext(ctx, q, afterURL, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list originals missing orientation: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var urls []string
+	for rows.Next() {
+		var u string
+		if err := rows.Scan(&u); err != nil {
+			return nil, fmt.Errorf("scan original url: %w", err)
+		}
+		urls = append(urls, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate original urls: %w", err)
+	}
+	return urls, nil
+}
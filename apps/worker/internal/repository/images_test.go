@@ -2,12 +2,17 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/photometa"
 )
 
 func newMockRepo(t *testing.T) (*DefaultImageRepository, sqlmock.Sqlmock, func()) {
@@ -31,12 +36,15 @@ func TestDefaultImageRepository_SetProcessing_Success(t *testing.T) {
 	// Match multi-line SQL and allow whitespace/newlines
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'processing', updated_at = now() " +
-			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+			"WHERE id = $1::uuid AND status IN ('queued','processing') " +
+			"RETURNING id, status, prompt, cost_usd ) " +
+			"INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd) " +
+			"SELECT id, status, 'worker', prompt, NULLIF($2::text, ''), cost_usd FROM updated;")
 	mock.ExpectExec(query).
-		WithArgs(imageID).
+		WithArgs(imageID, "qwen/qwen-image-edit").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err := repo.SetProcessing(ctx, imageID)
+	err := repo.SetProcessing(ctx, imageID, "qwen/qwen-image-edit")
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -50,12 +58,15 @@ func TestDefaultImageRepository_SetProcessing_DBError(t *testing.T) {
 
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'processing', updated_at = now() " +
-			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+			"WHERE id = $1::uuid AND status IN ('queued','processing') " +
+			"RETURNING id, status, prompt, cost_usd ) " +
+			"INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd) " +
+			"SELECT id, status, 'worker', prompt, NULLIF($2::text, ''), cost_usd FROM updated;")
 	mock.ExpectExec(query).
-		WithArgs(imageID).
+		WithArgs(imageID, "").
 		WillReturnError(assert.AnError)
 
-	err := repo.SetProcessing(ctx, imageID)
+	err := repo.SetProcessing(ctx, imageID, "")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "update image status to processing")
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -68,19 +79,87 @@ func TestDefaultImageRepository_SetReady_Success(t *testing.T) {
 	ctx := context.Background()
 	imageID := "b5a4b7a1-3584-4b09-9b6a-6a6e1f2d9e90"
 	stagedURL := "https://example.com/image-staged.jpg"
+	seed := int64(1234)
 
 	query := regexp.QuoteMeta(
-		"UPDATE images SET staged_url = $2, status = 'ready', updated_at = now() " +
-			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+		"UPDATE images SET staged_url = $2, status = 'ready', processing_time_ms = $4, " +
+			"blurhash = NULLIF($5::text, ''), cost_usd = $6, seed = COALESCE($7::bigint, seed), " +
+			"staged_content_type = NULLIF($8::text, ''), updated_at = now() " +
+			"WHERE id = $1::uuid AND status IN ('queued','processing') " +
+			"RETURNING id, status, prompt, cost_usd, processing_time_ms ) " +
+			"INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, processing_time_ms) " +
+			"SELECT id, status, 'worker', prompt, NULLIF($3::text, ''), cost_usd, processing_time_ms FROM updated;")
 	mock.ExpectExec(query).
-		WithArgs(imageID, stagedURL).
+		WithArgs(imageID, stagedURL, "qwen/qwen-image-edit", int64(1500), "LEHV6nWB2yk8pyo0adR*.7kCMdnj", 0.03, int64(1234),
+			"image/webp").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err := repo.SetReady(ctx, imageID, stagedURL)
+	err := repo.SetReady(ctx, imageID, stagedURL, StageStats{
+		ModelID: "qwen/qwen-image-edit", Duration: 1500 * time.Millisecond, Blurhash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj",
+		ContentType: "image/webp", CostUSD: 0.03, Seed: &seed,
+	})
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestDefaultImageRepository_AddOutput(t *testing.T) {
+	ctx := context.Background()
+	primaryID := "b5a4b7a1-3584-4b09-9b6a-6a6e1f2d9e90"
+	stagedURL := "s3://bucket/staged/b5a4b7a1/b5a4b7a1-3584-4b09-9b6a-6a6e1f2d9e90-staged-2.jpg"
+	stats := StageStats{
+		ModelID: "black-forest-labs/flux-kontext-max", Duration: 2 * time.Second, Blurhash: "LEHV6nWB",
+		ContentType: "image/png",
+	}
+	query := `WITH source AS \(.+NOT EXISTS \(SELECT 1 FROM images WHERE primary_image_id = \$1::uuid ` +
+		`AND staged_url = \$2\).+INSERT INTO images \(.+primary_image_id.+\).+SELECT id FROM created`
+
+	t.Run("success: stores the output as a new image", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).
+			WithArgs(primaryID, stagedURL, stats.ModelID, int64(2000), stats.Blurhash, float64(0), "image/png").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("6a4c2f5e-1b7d-4e0a-9c3f-8d2b1e5a7c90"))
+
+		id, err := repo.AddOutput(ctx, primaryID, stagedURL, stats)
+		require.NoError(t, err)
+		assert.Equal(t, "6a4c2f5e-1b7d-4e0a-9c3f-8d2b1e5a7c90", id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: already stored or primary deleted", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		id, err := repo.AddOutput(ctx, primaryID, stagedURL, stats)
+		require.NoError(t, err)
+		assert.Empty(t, id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: empty URL", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		_, err := repo.AddOutput(ctx, primaryID, "", stats)
+		assert.ErrorContains(t, err, "stagedURL cannot be empty")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(query).WillReturnError(assert.AnError)
+
+		_, err := repo.AddOutput(ctx, primaryID, stagedURL, stats)
+		assert.ErrorContains(t, err, "insert extra output")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestDefaultImageRepository_SetReady_EmptyURL(t *testing.T) {
 	repo, mock, cleanup := newMockRepo(t)
 	defer cleanup()
@@ -88,7 +167,7 @@ func TestDefaultImageRepository_SetReady_EmptyURL(t *testing.T) {
 	ctx := context.Background()
 	imageID := "b5a4b7a1-3584-4b09-9b6a-6a6e1f2d9e90"
 
-	err := repo.SetReady(ctx, imageID, "")
+	err := repo.SetReady(ctx, imageID, "", StageStats{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "stagedURL cannot be empty")
 	// No SQL should have been executed
@@ -104,13 +183,18 @@ func TestDefaultImageRepository_SetReady_DBError(t *testing.T) {
 	stagedURL := "https://example.com/image-staged.jpg"
 
 	query := regexp.QuoteMeta(
-		"UPDATE images SET staged_url = $2, status = 'ready', updated_at = now() " +
-			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+		"UPDATE images SET staged_url = $2, status = 'ready', processing_time_ms = $4, " +
+			"blurhash = NULLIF($5::text, ''), cost_usd = $6, seed = COALESCE($7::bigint, seed), " +
+			"staged_content_type = NULLIF($8::text, ''), updated_at = now() " +
+			"WHERE id = $1::uuid AND status IN ('queued','processing') " +
+			"RETURNING id, status, prompt, cost_usd, processing_time_ms ) " +
+			"INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, processing_time_ms) " +
+			"SELECT id, status, 'worker', prompt, NULLIF($3::text, ''), cost_usd, processing_time_ms FROM updated;")
 	mock.ExpectExec(query).
-		WithArgs(imageID, stagedURL).
+		WithArgs(imageID, stagedURL, "", int64(0), "", float64(0), nil, "").
 		WillReturnError(assert.AnError)
 
-	err := repo.SetReady(ctx, imageID, stagedURL)
+	err := repo.SetReady(ctx, imageID, stagedURL, StageStats{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "update image with staged url")
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -126,7 +210,12 @@ func TestDefaultImageRepository_SetError_Success(t *testing.T) {
 
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'error', error = $2, updated_at = now() " +
-			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+			"WHERE id = $1::uuid AND status IN ('queued','processing') " +
+			"RETURNING id, status, prompt, cost_usd, error ) " +
+			"INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, error) " +
+			"SELECT id, status, 'worker', prompt, ( SELECT model_used FROM image_events " +
+			"WHERE image_id = updated.id AND status = 'processing' " +
+			"ORDER BY created_at DESC LIMIT 1 ), cost_usd, error FROM updated;")
 	mock.ExpectExec(query).
 		WithArgs(imageID, errMsg).
 		WillReturnResult(sqlmock.NewResult(0, 1))
@@ -160,7 +249,12 @@ func TestDefaultImageRepository_SetError_DBError(t *testing.T) {
 
 	query := regexp.QuoteMeta(
 		"UPDATE images SET status = 'error', error = $2, updated_at = now() " +
-			"WHERE id = $1::uuid AND status IN ('queued','processing');")
+			"WHERE id = $1::uuid AND status IN ('queued','processing') " +
+			"RETURNING id, status, prompt, cost_usd, error ) " +
+			"INSERT INTO image_events (image_id, status, source, prompt, model_used, cost_usd, error) " +
+			"SELECT id, status, 'worker', prompt, ( SELECT model_used FROM image_events " +
+			"WHERE image_id = updated.id AND status = 'processing' " +
+			"ORDER BY created_at DESC LIMIT 1 ), cost_usd, error FROM updated;")
 	mock.ExpectExec(query).
 		WithArgs(imageID, errMsg).
 		WillReturnError(assert.AnError)
@@ -170,3 +264,239 @@ func TestDefaultImageRepository_SetError_DBError(t *testing.T) {
 	assert.Contains(t, err.Error(), "update image with error")
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestDefaultImageRepository_SetValidationError(t *testing.T) {
+	imageID := "0e5b2e97-4324-4f47-bc8b-05d33d62d9b4"
+	query := regexp.QuoteMeta("SET status = 'error', error = $3, error_code = $2, updated_at = now()")
+
+	t.Run("success: stores the code and message", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs(imageID, "corrupt_image", "The uploaded JPEG image is damaged or incomplete.").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetValidationError(context.Background(), imageID, "corrupt_image",
+			"The uploaded JPEG image is damaged or incomplete.")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: empty code", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		err := repo.SetValidationError(context.Background(), imageID, "", "bad")
+		assert.ErrorContains(t, err, "cannot be empty")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).WillReturnError(assert.AnError)
+
+		err := repo.SetValidationError(context.Background(), imageID, "file_too_large", "too big")
+		assert.ErrorContains(t, err, "update image with validation error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDefaultImageRepository_SetRejected(t *testing.T) {
+	imageID := "0e5b2e97-4324-4f47-bc8b-05d33d62d9b4"
+	query := regexp.QuoteMeta("SET status = 'rejected', error = $3, error_code = $2, updated_at = now()")
+	detection := MalwareDetection{
+		OriginalURL:   "s3://bucket/uploads/a.jpg",
+		QuarantineURL: "s3://bucket/quarantine/uploads/a.jpg",
+		Scanner:       "clamav",
+		Signature:     "Eicar-Test-Signature",
+	}
+
+	t.Run("success: records the detection and alerts admins", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).
+			WithArgs(imageID, "malware_detected", "flagged", detection.OriginalURL, detection.QuarantineURL,
+				"clamav", "Eicar-Test-Signature", MalwareAlertEventType, "Malware detected in an uploaded image",
+				sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		assert.NoError(t, repo.SetRejected(context.Background(), imageID, "flagged", detection))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: empty signature", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		err := repo.SetRejected(context.Background(), imageID, "flagged", MalwareDetection{})
+		assert.ErrorContains(t, err, "cannot be empty")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: db error", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectExec(query).WillReturnError(assert.AnError)
+
+		err := repo.SetRejected(context.Background(), imageID, "flagged", detection)
+		assert.ErrorContains(t, err, "update image as rejected")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMalwareAlertEmail(t *testing.T) {
+	subject, body := malwareAlertEmail("img-1", MalwareDetection{
+		OriginalURL:   "s3://bucket/uploads/a.jpg",
+		QuarantineURL: "s3://bucket/quarantine/uploads/a.jpg",
+		Scanner:       "clamav",
+		Signature:     "Eicar-Test-Signature",
+	})
+	assert.Equal(t, "Malware detected in an uploaded image", subject)
+	assert.Contains(t, body, "The clamav scanner found Eicar-Test-Signature in the original of image img-1.")
+	assert.Contains(t, body, "Quarantined at: s3://bucket/quarantine/uploads/a.jpg")
+}
+
+func TestDefaultImageRepository_SetOriginalOrientation(t *testing.T) {
+	t.Run("success: updates every image sharing the original", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectExec(regexp.QuoteMeta("SET original_orientation = $2")).
+			WithArgs("s3://bucket/a.jpg", 6).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		assert.NoError(t, repo.SetOriginalOrientation(context.Background(), "s3://bucket/a.jpg", 6))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: out of range", func(t *testing.T) {
+		repo, _, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		assert.Error(t, repo.SetOriginalOrientation(context.Background(), "s3://bucket/a.jpg", 9))
+	})
+}
+
+func TestDefaultImageRepository_SetPromptVariant(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("SET prompt_experiment_id = $2::uuid, prompt_variant_id = $3::uuid")).
+		WithArgs("img-1", "exp-1", "var-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.SetPromptVariant(context.Background(), "img-1", "exp-1", "var-1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_SetPrediction(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("SET replicate_prediction_id = $2")).
+		WithArgs("img-1", "pred-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.SetPrediction(context.Background(), "img-1", "pred-1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_IsCanceled(t *testing.T) {
+	t.Run("success: canceled image", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT status = 'canceled' FROM images")).
+			WithArgs("img-1").
+			WillReturnRows(sqlmock.NewRows([]string{"canceled"}).AddRow(true))
+
+		canceled, err := repo.IsCanceled(context.Background(), "img-1")
+		assert.NoError(t, err)
+		assert.True(t, canceled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: missing image isn't canceled", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT status = 'canceled' FROM images")).
+			WithArgs("img-1").
+			WillReturnError(sql.ErrNoRows)
+
+		canceled, err := repo.IsCanceled(context.Background(), "img-1")
+		assert.NoError(t, err)
+		assert.False(t, canceled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: database error", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT status = 'canceled' FROM images")).
+			WithArgs("img-1").
+			WillReturnError(errors.New("connection refused"))
+
+		_, err := repo.IsCanceled(context.Background(), "img-1")
+		assert.ErrorContains(t, err, "get image status")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDefaultImageRepository_SetDetectedRoomType(t *testing.T) {
+	t.Run("success: stores the detection", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		mock.ExpectExec(regexp.QuoteMeta("SET detected_room_type = $2, room_type_confidence = $3")).
+			WithArgs("img-1", "kitchen", 0.92).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.SetDetectedRoomType(context.Background(), "img-1", "kitchen", 0.92))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: confidence out of range", func(t *testing.T) {
+		repo, mock, cleanup := newMockRepo(t)
+		defer cleanup()
+
+		assert.Error(t, repo.SetDetectedRoomType(context.Background(), "img-1", "kitchen", 1.5))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDefaultImageRepository_SetOriginalMetadata(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("SET original_metadata = $2")).
+		WithArgs("s3://bucket/a.jpg", []byte(`{"camera_make":"Apple","gps":{"latitude":37.775,"longitude":-122.4}}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.SetOriginalMetadata(context.Background(), "s3://bucket/a.jpg", &photometa.Metadata{
+		CameraMake: "Apple",
+		GPS:        &photometa.GPS{Latitude: 37.775, Longitude: -122.4},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultImageRepository_ListOriginalsMissingOrientation(t *testing.T) {
+	repo, mock, cleanup := newMockRepo(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE original_orientation IS NULL")).
+		WithArgs("", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"original_url"}).AddRow("s3://bucket/a.jpg").AddRow("s3://bucket/b.jpg"))
+
+	urls, err := repo.ListOriginalsMissingOrientation(context.Background(), "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3://bucket/a.jpg", "s3://bucket/b.jpg"}, urls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
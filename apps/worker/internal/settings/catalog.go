@@ -0,0 +1,66 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// CatalogModel is a model as the models table lists it. The table is the
+// model catalog the API reads; admins and the API's Replicate sync own it.
+type CatalogModel struct {
+	ID       model.ID
+	Provider model.Provider
+	Enabled  bool
+}
+
+// ListModels returns the model catalog.
+func (r *DefaultRepository) ListModels(ctx context.Context) ([]CatalogModel, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, provider, enabled FROM models ORDER BY position, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var models []CatalogModel
+	for rows.Next() {
+		var m CatalogModel
+		var id, provider string
+		if err := rows.Scan(&id, &provider, &m.Enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan model: %w", err)
+		}
+		m.ID, m.Provider = model.ID(id), model.Provider(provider)
+		models = append(models, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating models: %w", err)
+	}
+	return models, nil
+}
+
+// CheckCatalog compares the catalog with the models registry can run. It
+// returns the enabled catalog models the worker can't run, or would run on
+// another provider than the catalog says, and the registered models the
+// catalog doesn't list, which the worker treats as disabled.
+func CheckCatalog(catalog []CatalogModel, registry *model.ModelRegistry) (unsupported, unlisted []model.ID) {
+	listed := make(map[model.ID]bool, len(catalog))
+	for _, m := range catalog {
+		listed[m.ID] = true
+		if !m.Enabled {
+			continue
+		}
+		meta, err := registry.Get(m.ID)
+		if err != nil || meta.RunsOn() != m.Provider {
+			unsupported = append(unsupported, m.ID)
+		}
+	}
+	for _, meta := range registry.List() {
+		if !listed[meta.ID] {
+			unlisted = append(unlisted, meta.ID)
+		}
+	}
+	slices.Sort(unlisted)
+	return unsupported, unlisted
+}
@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestDefaultRepository_ListModels(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT id, provider, enabled FROM models ORDER BY position, id")
+
+	t.Run("success: lists the catalog", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "enabled"}).
+			AddRow(string(model.ModelQwenImageEdit), "replicate", true).
+			AddRow(string(model.ModelGPTImage1), "openai", false))
+
+		got, err := NewDefaultRepository(db, nil).ListModels(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []CatalogModel{
+			{ID: model.ModelQwenImageEdit, Provider: model.ProviderReplicate, Enabled: true},
+			{ID: model.ModelGPTImage1, Provider: model.ProviderOpenAI},
+		}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(assert.AnError)
+
+		_, err = NewDefaultRepository(db, nil).ListModels(context.Background())
+
+		assert.ErrorContains(t, err, "failed to list models")
+	})
+}
+
+func TestCheckCatalog(t *testing.T) {
+	registry := model.NewModelRegistry()
+	var catalog []CatalogModel
+	for _, meta := range registry.List() {
+		if meta.ID == model.ModelSeedream3 {
+			continue
+		}
+		catalog = append(catalog, CatalogModel{ID: meta.ID, Provider: meta.RunsOn(), Enabled: true})
+	}
+	catalog = append(catalog,
+		CatalogModel{ID: "acme/new-model", Provider: model.ProviderReplicate, Enabled: true},
+		CatalogModel{ID: "acme/old-model", Provider: model.ProviderReplicate},
+	)
+	for i := range catalog {
+		if catalog[i].ID == model.ModelGPTImage1 {
+			catalog[i].Provider = model.ProviderReplicate
+		}
+	}
+
+	unsupported, unlisted := CheckCatalog(catalog, registry)
+
+	assert.ElementsMatch(t, []model.ID{"acme/new-model", model.ModelGPTImage1}, unsupported,
+		"enabled models the worker can't run, or runs elsewhere, are reported")
+	assert.Equal(t, []model.ID{model.ModelSeedream3}, unlisted)
+}
@@ -6,8 +6,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/real-staging-ai/api/encryption"
+	"github.com/real-staging-ai/api/modelconfig"
+	"github.com/real-staging-ai/api/watermark"
 
 	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/staging/prompt"
 )
 
 const (
@@ -18,16 +25,21 @@ const (
 	configKeySeedream4      = "seedream_4"
 	configKeyGPTImage1      = "gpt_image_1"
 	configKeyGPTImage1_5    = "gpt_image_1_5"
+
+	configKeyStableImageInpaint = "stable_image_inpaint"
 )
 
 // DefaultRepository provides access to settings stored in the database.
 type DefaultRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	keyring *encryption.Keyring
 }
 
-// NewDefaultRepository creates a new settings repository.
-func NewDefaultRepository(db *sql.DB) *DefaultRepository {
-	return &DefaultRepository{db: db}
+// NewDefaultRepository creates a new settings repository. keyring opens the
+// secret fields of model configs; without one, configs with a sealed secret
+// can't be read.
+func NewDefaultRepository(db *sql.DB, keyring *encryption.Keyring) *DefaultRepository {
+	return &DefaultRepository{db: db, keyring: keyring}
 }
 
 // GetActiveModel retrieves the active model ID from settings.
@@ -50,6 +62,153 @@ func (r *DefaultRepository) GetActiveModel(ctx context.Context) (model.ID, error
 	return modelID, nil
 }
 
+// GetModelVersion returns the pinned version for modelID. While a canary runs,
+// canary_percent of calls get the canary version instead.
+func (r *DefaultRepository) GetModelVersion(ctx context.Context, modelID model.ID) (string, error) {
+	query := `SELECT version, canary_version, canary_percent FROM model_version_pins WHERE model_id = $1`
+
+	var (
+		version string
+		canary  sql.NullString
+		percent int
+	)
+	err := r.db.QueryRowContext(ctx, query, string(modelID)).Scan(&version, &canary, &percent)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to query model version: %w", err)
+	}
+
+	return pickVersion(version, canary, percent, rand.IntN(100)), nil
+}
+
+// pickVersion returns the canary when roll (0-99) falls inside its percentage.
+func pickVersion(version string, canary sql.NullString, percent, roll int) string {
+	if canary.Valid && roll < percent {
+		return canary.String
+	}
+	return version
+}
+
+// GetPromptOverride returns the admin override for the room type and style,
+// or "" when there is none.
+func (r *DefaultRepository) GetPromptOverride(ctx context.Context, roomType, style string) (string, error) {
+	query := `SELECT prompt FROM prompt_overrides WHERE room_type = $1 AND style = $2`
+
+	var override string
+	err := r.db.QueryRowContext(ctx, query, roomType, style).Scan(&override)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to query prompt override: %w", err)
+	}
+	return override, nil
+}
+
+// GetPromptLocale returns the locale the image's project localizes prompts for.
+func (r *DefaultRepository) GetPromptLocale(ctx context.Context, imageID string) (string, error) {
+	query := `SELECT COALESCE(p.locale, '') FROM images i JOIN projects p ON p.id = i.project_id WHERE i.id = $1`
+
+	var locale string
+	if err := r.db.QueryRowContext(ctx, query, imageID).Scan(&locale); err != nil {
+		return "", fmt.Errorf("failed to query project locale: %w", err)
+	}
+	return locale, nil
+}
+
+// GetWatermark returns the watermark style for the image when its project has
+// watermarking on. Missing or unusable settings keep their defaults, so a
+// project that asks for a watermark always gets one.
+func (r *DefaultRepository) GetWatermark(ctx context.Context, imageID string) (*watermark.Options, error) {
+	query := `SELECT p.watermark FROM images i JOIN projects p ON p.id = i.project_id WHERE i.id = $1`
+
+	var enabled bool
+	if err := r.db.QueryRowContext(ctx, query, imageID).Scan(&enabled); err != nil {
+		return nil, fmt.Errorf("failed to query project watermark: %w", err)
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT key, value FROM settings WHERE key IN ('watermark_text', 'watermark_position', 'watermark_opacity')`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query watermark settings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	opts := watermark.DefaultOptions()
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan watermark setting: %w", err)
+		}
+		switch key {
+		case "watermark_text":
+			if value != "" {
+				opts.Text = value
+			}
+		case "watermark_position":
+			if pos := watermark.Position(value); pos.Valid() {
+				opts.Position = pos
+			}
+		case "watermark_opacity":
+			if opacity, err := strconv.ParseFloat(value, 64); err == nil && opacity >= 0.1 && opacity <= 1 {
+				opts.Opacity = opacity
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read watermark settings: %w", err)
+	}
+	return &opts, nil
+}
+
+// SyncBuiltinPrompts replaces the published built-in prompts with entries in
+// one statement, so the API never exports a half-synced library.
+func (r *DefaultRepository) SyncBuiltinPrompts(ctx context.Context, entries []prompt.Entry) error {
+	payload, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal built-in prompts: %w", err)
+	}
+
+	query := `
+		WITH incoming AS (
+			SELECT * FROM jsonb_to_recordset($1::jsonb) AS x(room_type TEXT, style TEXT, prompt TEXT)
+		), removed AS (
+			DELETE FROM prompt_builtins b
+			WHERE NOT EXISTS (
+				SELECT 1 FROM incoming i WHERE i.room_type = b.room_type AND i.style = b.style
+			)
+		)
+		INSERT INTO prompt_builtins (room_type, style, prompt)
+		SELECT room_type, style, prompt FROM incoming
+		ON CONFLICT (room_type, style) DO UPDATE
+		SET prompt = EXCLUDED.prompt, updated_at = now()
+		WHERE prompt_builtins.prompt IS DISTINCT FROM EXCLUDED.prompt
+	`
+	if _, err := r.db.ExecContext(ctx, query, string(payload)); err != nil {
+		return fmt.Errorf("failed to sync built-in prompts: %w", err)
+	}
+	return nil
+}
+
+// IsModelEnabled reports whether modelID is enabled in the models table.
+// Models the table doesn't list are disabled.
+func (r *DefaultRepository) IsModelEnabled(ctx context.Context, modelID model.ID) (bool, error) {
+	var enabled bool
+	err := r.db.QueryRowContext(ctx, `SELECT enabled FROM models WHERE id = $1`, string(modelID)).Scan(&enabled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to query model: %w", err)
+	}
+	return enabled, nil
+}
+
 // GetModelConfig retrieves the configuration for a specific model.
 func (r *DefaultRepository) GetModelConfig(
 	ctx context.Context, modelID model.ID,
@@ -66,6 +225,11 @@ func (r *DefaultRepository) GetModelConfig(
 		return nil, fmt.Errorf("failed to query config: %w", err)
 	}
 
+	configJSON, err = r.openSecrets(modelID, configJSON)
+	if err != nil {
+		return nil, err
+	}
+
 	config, err := model.ParseModelConfig(modelID, configJSON)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
@@ -74,42 +238,54 @@ func (r *DefaultRepository) GetModelConfig(
 	return config, nil
 }
 
-// UpdateModelConfig updates the configuration for a specific model.
-func (r *DefaultRepository) UpdateModelConfig(
-	ctx context.Context, modelID model.ID, config model.Config, userID string,
-) error {
-	// Validate config before storing
-	if err := config.Validate(); err != nil {
-		return fmt.Errorf("invalid config: %w", err)
+// openSecrets decrypts the secret fields the API sealed in configJSON. Models
+// without a config schema have no secrets.
+func (r *DefaultRepository) openSecrets(modelID model.ID, configJSON []byte) ([]byte, error) {
+	schema, err := modelconfig.Get(string(modelID))
+	if err != nil {
+		return configJSON, nil
 	}
 
-	key := fmt.Sprintf("model_config_%s", getConfigKey(modelID))
-
-	configJSON, err := json.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+	var config map[string]interface{}
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := schema.OpenSecrets(config, r.keyring); err != nil {
+		return nil, fmt.Errorf("failed to decrypt config: %w", err)
 	}
+	return json.Marshal(config)
+}
 
+// GetUserAPIKey opens the key with the same additional data the API's
+// credential package sealed it with, binding it to its owner and provider.
+func (r *DefaultRepository) GetUserAPIKey(
+	ctx context.Context, imageID string, provider model.Provider,
+) (string, error) {
 	query := `
-		UPDATE settings 
-		SET model_settings = $1, updated_at = NOW(), updated_by = $2
-		WHERE key = $3
-	`
-	result, err := r.db.ExecContext(ctx, query, configJSON, userID, key)
-	if err != nil {
-		return fmt.Errorf("failed to update config: %w", err)
-	}
+		SELECT c.user_id, c.ciphertext
+		FROM images i
+		JOIN projects p ON p.id = i.project_id
+		JOIN user_credentials c ON c.user_id = p.user_id AND c.provider = $2
+		WHERE i.id = $1`
 
-	rows, err := result.RowsAffected()
+	var userID string
+	var ciphertext []byte
+	err := r.db.QueryRowContext(ctx, query, imageID, string(provider)).Scan(&userID, &ciphertext)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to query user credential: %w", err)
 	}
-
-	if rows == 0 {
-		return fmt.Errorf("config not found for model: %s", modelID)
+	if r.keyring == nil {
+		return "", fmt.Errorf("failed to decrypt %s key: %w", provider, modelconfig.ErrNoKeyring)
 	}
 
-	return nil
+	key, err := r.keyring.Open(ciphertext, []byte("user_credentials:"+userID+":"+string(provider)))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s key: %w", provider, err)
+	}
+	return string(key), nil
 }
 
 // getConfigKey converts a ModelID to its configuration key suffix.
@@ -129,6 +305,8 @@ func getConfigKey(modelID model.ID) string {
 		return configKeyGPTImage1
 	case model.ModelGPTImage1_5:
 		return configKeyGPTImage1_5
+	case model.ModelStableImageInpaint:
+		return configKeyStableImageInpaint
 	default:
 		return string(modelID)
 	}
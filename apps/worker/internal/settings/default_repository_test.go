@@ -0,0 +1,422 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/api/encryption"
+	"github.com/real-staging-ai/api/modelconfig"
+	"github.com/real-staging-ai/api/watermark"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/staging/prompt"
+)
+
+func TestDefaultRepository_GetModelVersion(t *testing.T) {
+	pinned := strings.Repeat("a", 64)
+	query := regexp.QuoteMeta(
+		"SELECT version, canary_version, canary_percent FROM model_version_pins WHERE model_id = $1")
+
+	t.Run("success: returns pinned version", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs(string(model.ModelQwenImageEdit)).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "canary_version", "canary_percent"}).
+				AddRow(pinned, nil, 0))
+
+		got, err := NewDefaultRepository(db, nil).GetModelVersion(context.Background(), model.ModelQwenImageEdit)
+
+		require.NoError(t, err)
+		assert.Equal(t, pinned, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: unpinned model has no version", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+		got, err := NewDefaultRepository(db, nil).GetModelVersion(context.Background(), model.ModelQwenImageEdit)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(assert.AnError)
+
+		_, err = NewDefaultRepository(db, nil).GetModelVersion(context.Background(), model.ModelQwenImageEdit)
+
+		assert.ErrorContains(t, err, "failed to query model version")
+	})
+}
+
+func TestPickVersion(t *testing.T) {
+	canary := sql.NullString{String: "canary", Valid: true}
+
+	assert.Equal(t, "canary", pickVersion("pinned", canary, 10, 9))
+	assert.Equal(t, "pinned", pickVersion("pinned", canary, 10, 10))
+	assert.Equal(t, "canary", pickVersion("pinned", canary, 100, 99))
+	assert.Equal(t, "pinned", pickVersion("pinned", sql.NullString{}, 0, 0))
+}
+
+func TestDefaultRepository_GetPromptOverride(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT prompt FROM prompt_overrides WHERE room_type = $1 AND style = $2")
+
+	t.Run("success: returns override", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs("bedroom", "modern").
+			WillReturnRows(sqlmock.NewRows([]string{"prompt"}).AddRow("Tuned bedroom prompt."))
+
+		got, err := NewDefaultRepository(db, nil).GetPromptOverride(context.Background(), "bedroom", "modern")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Tuned bedroom prompt.", got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: no override", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+		got, err := NewDefaultRepository(db, nil).GetPromptOverride(context.Background(), "bedroom", "modern")
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(assert.AnError)
+
+		_, err = NewDefaultRepository(db, nil).GetPromptOverride(context.Background(), "bedroom", "modern")
+
+		assert.ErrorContains(t, err, "failed to query prompt override")
+	})
+}
+
+func TestDefaultRepository_SyncBuiltinPrompts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO prompt_builtins (room_type, style, prompt)")).
+		WithArgs(`[{"room_type":"bedroom","style":"modern","prompt":"Stage it."}]`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewDefaultRepository(db, nil).SyncBuiltinPrompts(context.Background(), []prompt.Entry{
+		{RoomType: "bedroom", Style: "modern", Prompt: "Stage it."},
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDefaultRepository_IsModelEnabled(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT enabled FROM models WHERE id = $1")
+
+	t.Run("success: disabled model", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs(string(model.ModelSeedream3)).
+			WillReturnRows(sqlmock.NewRows([]string{"enabled"}).AddRow(false))
+
+		got, err := NewDefaultRepository(db, nil).IsModelEnabled(context.Background(), model.ModelSeedream3)
+
+		require.NoError(t, err)
+		assert.False(t, got)
+	})
+
+	t.Run("success: model missing from the table is disabled", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+		got, err := NewDefaultRepository(db, nil).IsModelEnabled(context.Background(), model.ModelSeedream3)
+
+		require.NoError(t, err)
+		assert.False(t, got)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(assert.AnError)
+
+		_, err = NewDefaultRepository(db, nil).IsModelEnabled(context.Background(), model.ModelSeedream3)
+
+		assert.ErrorContains(t, err, "failed to query model")
+	})
+}
+
+func TestDefaultRepository_GetModelConfig(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT model_settings FROM settings WHERE key = $1")
+	keyring, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, encryption.KeySize)))
+	require.NoError(t, err)
+	schema, err := modelconfig.Get(string(model.ModelGPTImage1))
+	require.NoError(t, err)
+
+	cfg := map[string]interface{}{}
+	for _, f := range schema.Fields {
+		cfg[f.Name] = f.Default
+	}
+	cfg["openai_api_key"] = "sk-live"
+	require.NoError(t, schema.SealSecrets(cfg, keyring))
+	sealed, err := json.Marshal(cfg)
+	require.NoError(t, err)
+
+	t.Run("success: opens sealed secrets", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs("model_config_gpt_image_1").
+			WillReturnRows(sqlmock.NewRows([]string{"model_settings"}).AddRow(sealed))
+
+		got, err := NewDefaultRepository(db, keyring).GetModelConfig(context.Background(), model.ModelGPTImage1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "sk-live", got.(*model.GPTImageConfig).OpenAIAPIKey)
+	})
+
+	t.Run("fail: sealed secret without a keyring", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"model_settings"}).AddRow(sealed))
+
+		_, err = NewDefaultRepository(db, nil).GetModelConfig(context.Background(), model.ModelGPTImage1)
+
+		assert.ErrorIs(t, err, modelconfig.ErrNoKeyring)
+	})
+}
+
+func TestDefaultRepository_GetUserAPIKey(t *testing.T) {
+	query := regexp.QuoteMeta("SELECT c.user_id, c.ciphertext")
+	userID := "6f1c2a9e-5b7d-4a3c-9e8f-1a2b3c4d5e6f"
+	keyring, err := encryption.NewKeyring(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, encryption.KeySize)))
+	require.NoError(t, err)
+	// Sealed as the API's credential package seals it.
+	sealed, err := keyring.Seal([]byte("sk-user"), []byte("user_credentials:"+userID+":openai"))
+	require.NoError(t, err)
+
+	t.Run("success: opens the owner's key", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs("img-1", "openai").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "ciphertext"}).AddRow(userID, sealed))
+
+		got, err := NewDefaultRepository(db, keyring).GetUserAPIKey(context.Background(), "img-1", model.ProviderOpenAI)
+
+		require.NoError(t, err)
+		assert.Equal(t, "sk-user", got)
+	})
+
+	t.Run("success: owner has no key", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+		got, err := NewDefaultRepository(db, nil).GetUserAPIKey(context.Background(), "img-1", model.ProviderOpenAI)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("fail: key sealed for another user", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "ciphertext"}).AddRow("another-user", sealed))
+
+		_, err = NewDefaultRepository(db, keyring).GetUserAPIKey(context.Background(), "img-1", model.ProviderOpenAI)
+
+		assert.ErrorContains(t, err, "failed to decrypt openai key")
+	})
+
+	t.Run("fail: no keyring", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "ciphertext"}).AddRow(userID, sealed))
+
+		_, err = NewDefaultRepository(db, nil).GetUserAPIKey(context.Background(), "img-1", model.ProviderOpenAI)
+
+		assert.ErrorIs(t, err, modelconfig.ErrNoKeyring)
+	})
+}
+
+func TestDefaultRepository_GetPromptLocale(t *testing.T) {
+	query := regexp.QuoteMeta(
+		"SELECT COALESCE(p.locale, '') FROM images i JOIN projects p ON p.id = i.project_id WHERE i.id = $1")
+
+	t.Run("success: project locale", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs("img-1").WillReturnRows(sqlmock.NewRows([]string{"locale"}).AddRow("en-GB"))
+
+		got, err := NewDefaultRepository(db, nil).GetPromptLocale(context.Background(), "img-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "en-GB", got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fail: image not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(sql.ErrNoRows)
+
+		_, err = NewDefaultRepository(db, nil).GetPromptLocale(context.Background(), "img-1")
+
+		assert.ErrorContains(t, err, "failed to query project locale")
+	})
+}
+
+func TestDefaultRepository_GetWatermark(t *testing.T) {
+	projectQuery := regexp.QuoteMeta(
+		"SELECT p.watermark FROM images i JOIN projects p ON p.id = i.project_id WHERE i.id = $1")
+	settingsQuery := regexp.QuoteMeta("SELECT key, value FROM settings WHERE key IN")
+
+	t.Run("success: project without a watermark", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(projectQuery).WithArgs("img-1").
+			WillReturnRows(sqlmock.NewRows([]string{"watermark"}).AddRow(false))
+
+		got, err := NewDefaultRepository(db, nil).GetWatermark(context.Background(), "img-1")
+
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: settings override the defaults", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(projectQuery).WithArgs("img-1").
+			WillReturnRows(sqlmock.NewRows([]string{"watermark"}).AddRow(true))
+		mock.ExpectQuery(settingsQuery).WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).
+			AddRow("watermark_text", "Digitally Staged").
+			AddRow("watermark_position", "top_left").
+			AddRow("watermark_opacity", "0.8"))
+
+		got, err := NewDefaultRepository(db, nil).GetWatermark(context.Background(), "img-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, &watermark.Options{Text: "Digitally Staged", Position: watermark.TopLeft, Opacity: 0.8}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: unusable settings keep the defaults", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(projectQuery).WillReturnRows(sqlmock.NewRows([]string{"watermark"}).AddRow(true))
+		mock.ExpectQuery(settingsQuery).WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).
+			AddRow("watermark_position", "middle").
+			AddRow("watermark_opacity", "0"))
+
+		got, err := NewDefaultRepository(db, nil).GetWatermark(context.Background(), "img-1")
+
+		require.NoError(t, err)
+		want := watermark.DefaultOptions()
+		assert.Equal(t, &want, got)
+	})
+
+	t.Run("fail: image not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(projectQuery).WillReturnError(sql.ErrNoRows)
+
+		_, err = NewDefaultRepository(db, nil).GetWatermark(context.Background(), "img-1")
+
+		assert.ErrorContains(t, err, "failed to query project watermark")
+	})
+
+	t.Run("fail: settings query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(projectQuery).WillReturnRows(sqlmock.NewRows([]string{"watermark"}).AddRow(true))
+		mock.ExpectQuery(settingsQuery).WillReturnError(assert.AnError)
+
+		_, err = NewDefaultRepository(db, nil).GetWatermark(context.Background(), "img-1")
+
+		assert.ErrorContains(t, err, "failed to query watermark settings")
+	})
+}
+
+func TestDefaultRepository_SetIncident(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	since := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE settings SET value = $2")).
+		WithArgs("provider_incident",
+			`{"active":true,"source":"worker","models":["flux"],"since":"2026-01-01T12:00:00Z"}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewDefaultRepository(db, nil).SetIncident(context.Background(), Incident{
+		Active: true, Source: IncidentSourceWorker, Models: []string{"flux"}, Since: &since,
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
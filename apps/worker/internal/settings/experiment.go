@@ -0,0 +1,76 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+)
+
+// PromptExperiment is a running A/B test of prompts for a room type and style.
+type PromptExperiment struct {
+	ID       string
+	Variants []PromptVariant
+}
+
+// PromptVariant is one prompt an experiment tries, with its relative weight.
+type PromptVariant struct {
+	ID     string
+	Prompt string
+	Weight int
+}
+
+// Pick returns the variant an image is assigned. The choice hashes the image
+// ID, so it is spread across variants by weight yet stays the same when the
+// image's job is retried.
+func (e *PromptExperiment) Pick(imageID string) PromptVariant {
+	total := 0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(e.ID + "/" + imageID))
+	roll := int(h.Sum32() % uint32(total))
+	for _, v := range e.Variants {
+		if roll < v.Weight {
+			return v
+		}
+		roll -= v.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
+
+// GetPromptExperiment returns the experiment running for the room type and
+// style with its variants, or nil when there is none.
+func (r *DefaultRepository) GetPromptExperiment(
+	ctx context.Context, roomType, style string,
+) (*PromptExperiment, error) {
+	query := `
+		SELECT e.id, v.id, v.prompt, v.weight
+		FROM prompt_experiments e
+		JOIN prompt_experiment_variants v ON v.experiment_id = e.id
+		WHERE e.room_type = $1 AND e.style = $2 AND e.status = 'running'
+		ORDER BY v.position`
+
+	rows, err := r.db.QueryContext(ctx, query, roomType, style)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt experiment: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var experiment *PromptExperiment
+	for rows.Next() {
+		var experimentID string
+		var v PromptVariant
+		if err := rows.Scan(&experimentID, &v.ID, &v.Prompt, &v.Weight); err != nil {
+			return nil, fmt.Errorf("failed to scan prompt experiment variant: %w", err)
+		}
+		if experiment == nil {
+			experiment = &PromptExperiment{ID: experimentID}
+		}
+		experiment.Variants = append(experiment.Variants, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read prompt experiment: %w", err)
+	}
+	return experiment, nil
+}
@@ -0,0 +1,84 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptExperiment_Pick(t *testing.T) {
+	experiment := &PromptExperiment{ID: "exp-1", Variants: []PromptVariant{
+		{ID: "a", Weight: 1},
+		{ID: "b", Weight: 3},
+	}}
+
+	t.Run("success: the same image always gets the same variant", func(t *testing.T) {
+		first := experiment.Pick("img-1")
+		for range 5 {
+			assert.Equal(t, first, experiment.Pick("img-1"))
+		}
+	})
+
+	t.Run("success: images are spread by weight", func(t *testing.T) {
+		counts := map[string]int{}
+		for i := range 4000 {
+			counts[experiment.Pick(fmt.Sprintf("img-%d", i)).ID]++
+		}
+		assert.InDelta(t, 1000, counts["a"], 150)
+		assert.InDelta(t, 3000, counts["b"], 150)
+	})
+}
+
+func TestDefaultRepository_GetPromptExperiment(t *testing.T) {
+	query := regexp.QuoteMeta("WHERE e.room_type = $1 AND e.style = $2 AND e.status = 'running'")
+
+	t.Run("success: returns the running experiment's variants in order", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WithArgs("bedroom", "modern").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "id", "prompt", "weight"}).
+				AddRow("exp-1", "a", "Control.", 1).
+				AddRow("exp-1", "b", "Warm.", 2))
+
+		got, err := NewDefaultRepository(db, nil).GetPromptExperiment(context.Background(), "bedroom", "modern")
+
+		require.NoError(t, err)
+		assert.Equal(t, &PromptExperiment{ID: "exp-1", Variants: []PromptVariant{
+			{ID: "a", Prompt: "Control.", Weight: 1},
+			{ID: "b", Prompt: "Warm.", Weight: 2},
+		}}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success: no running experiment", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id", "id", "prompt", "weight"}))
+
+		got, err := NewDefaultRepository(db, nil).GetPromptExperiment(context.Background(), "bedroom", "modern")
+
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("fail: query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectQuery(query).WillReturnError(assert.AnError)
+
+		_, err = NewDefaultRepository(db, nil).GetPromptExperiment(context.Background(), "bedroom", "modern")
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
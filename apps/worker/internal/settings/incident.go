@@ -0,0 +1,45 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// KeyProviderIncident is the setting holding the provider incident flag the
+// API turns into a status banner and delayed image responses.
+const KeyProviderIncident = "provider_incident"
+
+// IncidentSourceWorker marks an incident raised by a worker's circuit breaker.
+// An incident an admin set by hand has another source and is left alone.
+const IncidentSourceWorker = "worker"
+
+// Incident is the value of the provider_incident setting.
+type Incident struct {
+	Active bool   `json:"active"`
+	Source string `json:"source,omitempty"`
+	// Models are the affected models; empty means every model.
+	Models  []string   `json:"models,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+	RetryAt *time.Time `json:"retry_at,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// SetIncident stores incident as the provider incident flag, unless an admin
+// has set the flag by hand.
+func (r *DefaultRepository) SetIncident(ctx context.Context, incident Incident) error {
+	value, err := json.Marshal(incident)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident: %w", err)
+	}
+
+	query := `
+		UPDATE settings SET value = $2, updated_at = NOW(), updated_by = NULL
+		WHERE key = $1 AND COALESCE(NULLIF(value, '')::jsonb->>'source', '') <> 'admin'
+	`
+	if _, err := r.db.ExecContext(ctx, query, KeyProviderIncident, string(value)); err != nil {
+		return fmt.Errorf("failed to set provider incident: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package settings
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// PromptCache is a Repository that keeps prompt overrides and experiments in
+// memory for a TTL, so a job doesn't query the database for its prompt. An
+// admin's edit, or an experiment starting or stopping, reaches each worker
+// within one TTL. Missing overrides and experiments are cached too; lookup
+// errors are not.
+type PromptCache struct {
+	Repository
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu          sync.Mutex
+	entries     map[promptKey]cachedPrompt
+	experiments map[promptKey]cachedExperiment
+}
+
+// promptKey identifies an override by room type and style.
+type promptKey struct {
+	roomType string
+	style    string
+}
+
+// cachedPrompt is an override, or "" for none, and when it stops being served.
+type cachedPrompt struct {
+	prompt  string
+	expires time.Time
+}
+
+// cachedExperiment is an experiment, or nil for none, and when it stops being served.
+type cachedExperiment struct {
+	experiment *PromptExperiment
+	expires    time.Time
+}
+
+// NewPromptCache wraps repo, caching its prompt overrides and experiments for ttl.
+func NewPromptCache(repo Repository, ttl time.Duration) *PromptCache {
+	return &PromptCache{
+		Repository:  repo,
+		ttl:         ttl,
+		now:         time.Now,
+		entries:     make(map[promptKey]cachedPrompt),
+		experiments: make(map[promptKey]cachedExperiment),
+	}
+}
+
+// GetPromptOverride returns the cached override while it is fresh, and
+// otherwise reads it from the repository.
+func (c *PromptCache) GetPromptOverride(ctx context.Context, roomType, style string) (string, error) {
+	k := promptKey{roomType, style}
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[k]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.prompt, nil
+	}
+
+	prompt, err := c.Repository.GetPromptOverride(ctx, roomType, style)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.entries[k] = cachedPrompt{prompt: prompt, expires: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return prompt, nil
+}
+
+// GetPromptExperiment returns the cached experiment while it is fresh, and
+// otherwise reads it from the repository.
+func (c *PromptCache) GetPromptExperiment(
+	ctx context.Context, roomType, style string,
+) (*PromptExperiment, error) {
+	k := promptKey{roomType, style}
+	now := c.now()
+
+	c.mu.Lock()
+	entry, ok := c.experiments[k]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.experiment, nil
+	}
+
+	experiment, err := c.Repository.GetPromptExperiment(ctx, roomType, style)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.experiments[k] = cachedExperiment{experiment: experiment, expires: now.Add(c.ttl)}
+	c.mu.Unlock()
+	return experiment, nil
+}
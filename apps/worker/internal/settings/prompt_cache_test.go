@@ -0,0 +1,121 @@
+package settings
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// overrideRepo serves GetPromptOverride and counts its calls.
+type overrideRepo struct {
+	Repository
+	prompt string
+	err    error
+	calls  int
+}
+
+func (r *overrideRepo) GetPromptOverride(ctx context.Context, roomType, style string) (string, error) {
+	r.calls++
+	return r.prompt, r.err
+}
+
+func (r *overrideRepo) GetPromptExperiment(ctx context.Context, roomType, style string) (*PromptExperiment, error) {
+	r.calls++
+	if r.err != nil || r.prompt == "" {
+		return nil, r.err
+	}
+	return &PromptExperiment{ID: r.prompt}, nil
+}
+
+func TestPromptCache_GetPromptOverride(t *testing.T) {
+	t.Run("success: serves the override until the TTL passes", func(t *testing.T) {
+		repo := &overrideRepo{prompt: "Tuned."}
+		cache := NewPromptCache(repo, time.Minute)
+		now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+		cache.now = func() time.Time { return now }
+
+		for range 3 {
+			got, err := cache.GetPromptOverride(context.Background(), "bedroom", "modern")
+			require.NoError(t, err)
+			assert.Equal(t, "Tuned.", got)
+		}
+		assert.Equal(t, 1, repo.calls)
+
+		repo.prompt = "Tuned again."
+		now = now.Add(time.Minute)
+		got, err := cache.GetPromptOverride(context.Background(), "bedroom", "modern")
+		require.NoError(t, err)
+		assert.Equal(t, "Tuned again.", got)
+		assert.Equal(t, 2, repo.calls)
+	})
+
+	t.Run("success: caches a missing override per room type and style", func(t *testing.T) {
+		repo := &overrideRepo{}
+		cache := NewPromptCache(repo, time.Minute)
+
+		for range 2 {
+			got, err := cache.GetPromptOverride(context.Background(), "bedroom", "modern")
+			require.NoError(t, err)
+			assert.Empty(t, got)
+		}
+		_, err := cache.GetPromptOverride(context.Background(), "kitchen", "modern")
+		require.NoError(t, err)
+		assert.Equal(t, 2, repo.calls)
+	})
+
+	t.Run("fail: errors are not cached", func(t *testing.T) {
+		repo := &overrideRepo{err: assert.AnError}
+		cache := NewPromptCache(repo, time.Minute)
+
+		_, err := cache.GetPromptOverride(context.Background(), "bedroom", "modern")
+		assert.ErrorIs(t, err, assert.AnError)
+
+		repo.err, repo.prompt = nil, "Tuned."
+		got, err := cache.GetPromptOverride(context.Background(), "bedroom", "modern")
+		require.NoError(t, err)
+		assert.Equal(t, "Tuned.", got)
+		assert.Equal(t, 2, repo.calls)
+	})
+}
+
+func TestPromptCache_GetPromptExperiment(t *testing.T) {
+	t.Run("success: serves the experiment until the TTL passes", func(t *testing.T) {
+		repo := &overrideRepo{prompt: "exp-1"}
+		cache := NewPromptCache(repo, time.Minute)
+		now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+		cache.now = func() time.Time { return now }
+
+		for range 2 {
+			got, err := cache.GetPromptExperiment(context.Background(), "bedroom", "modern")
+			require.NoError(t, err)
+			assert.Equal(t, "exp-1", got.ID)
+		}
+		assert.Equal(t, 1, repo.calls)
+
+		// Stopped: the next lookup after the TTL finds none, and that is cached too.
+		repo.prompt = ""
+		now = now.Add(time.Minute)
+		for range 2 {
+			got, err := cache.GetPromptExperiment(context.Background(), "bedroom", "modern")
+			require.NoError(t, err)
+			assert.Nil(t, got)
+		}
+		assert.Equal(t, 2, repo.calls)
+	})
+
+	t.Run("fail: errors are not cached", func(t *testing.T) {
+		repo := &overrideRepo{err: assert.AnError}
+		cache := NewPromptCache(repo, time.Minute)
+
+		_, err := cache.GetPromptExperiment(context.Background(), "bedroom", "modern")
+		assert.ErrorIs(t, err, assert.AnError)
+
+		repo.err, repo.prompt = nil, "exp-1"
+		got, err := cache.GetPromptExperiment(context.Background(), "bedroom", "modern")
+		require.NoError(t, err)
+		assert.Equal(t, "exp-1", got.ID)
+	})
+}
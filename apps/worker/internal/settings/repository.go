@@ -3,7 +3,10 @@ package settings
 import (
 	"context"
 
+	"github.com/real-staging-ai/api/watermark"
+
 	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/staging/prompt"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out repository_mock.go . Repository
@@ -13,9 +16,49 @@ type Repository interface {
 	// Returns the default model if not found.
 	GetActiveModel(ctx context.Context) (model.ID, error)
 
-	// GetModelConfig retrieves the configuration for a specific model
+	// GetModelVersion returns the pinned Replicate version to stage a model with,
+	// choosing the canary version for its share of calls. Returns "" if the
+	// model isn't pinned.
+	GetModelVersion(ctx context.Context, modelID model.ID) (string, error)
+
+	// GetPromptOverride returns the admin override for the room type and style,
+	// or "" when the built-in prompt applies.
+	GetPromptOverride(ctx context.Context, roomType, style string) (string, error)
+
+	// GetPromptExperiment returns the prompt experiment running for the room
+	// type and style, or nil when there is none.
+	GetPromptExperiment(ctx context.Context, roomType, style string) (*PromptExperiment, error)
+
+	// GetWatermark returns the watermark to draw on the image's staged result,
+	// or nil when its project doesn't ask for one.
+	GetWatermark(ctx context.Context, imageID string) (*watermark.Options, error)
+
+	// GetPromptLocale returns the locale of the image's project, or "" when
+	// the project keeps US phrasing.
+	GetPromptLocale(ctx context.Context, imageID string) (string, error)
+
+	// SyncBuiltinPrompts publishes the worker's built-in prompts so the API can
+	// export them alongside the overrides.
+	SyncBuiltinPrompts(ctx context.Context, entries []prompt.Entry) error
+
+	// ListModels returns the model catalog from the models table the API
+	// reads, in the order the admin UI lists it.
+	ListModels(ctx context.Context) ([]CatalogModel, error)
+
+	// IsModelEnabled reports whether an admin has left the model enabled.
+	// Models missing from the models table are disabled.
+	IsModelEnabled(ctx context.Context, modelID model.ID) (bool, error)
+
+	// SetIncident stores the provider incident flag the API shows as a status
+	// banner.
+	SetIncident(ctx context.Context, incident Incident) error
+
+	// GetModelConfig retrieves the configuration for a specific model, with
+	// its secret fields decrypted. Model configs are written by the API, which
+	// seals the secrets.
 	GetModelConfig(ctx context.Context, modelID model.ID) (model.Config, error)
 
-	// UpdateModelConfig updates the configuration for a specific model
-	UpdateModelConfig(ctx context.Context, modelID model.ID, config model.Config, userID string) error
+	// GetUserAPIKey returns the key the owner of the image stored for the
+	// provider, decrypted, or "" when they haven't stored one.
+	GetUserAPIKey(ctx context.Context, imageID string, provider model.Provider) (string, error)
 }
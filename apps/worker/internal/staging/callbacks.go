@@ -0,0 +1,144 @@
+package staging
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/api/webhookauth"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+)
+
+// maxCallbackBody caps the size of a Replicate callback body we are willing to read.
+const maxCallbackBody = 1 << 20
+
+// unclaimedCallbackTTL is how long a callback that arrived before its job started
+// waiting (or for a prediction this replica never created) is kept around.
+const unclaimedCallbackTTL = 10 * time.Minute
+
+// PredictionCallbacks correlates Replicate webhook callbacks with the jobs waiting
+// on those predictions. It is an http.Handler for the callback endpoint.
+type PredictionCallbacks struct {
+	mu        sync.Mutex
+	waiters   map[string]chan *replicate.Prediction
+	unclaimed map[string]unclaimedPrediction
+	verifier  *webhookauth.Verifier
+	now       func() time.Time
+}
+
+type unclaimedPrediction struct {
+	pred       *replicate.Prediction
+	receivedAt time.Time
+}
+
+// NewPredictionCallbacks creates a callback registry. When verifier is non-nil,
+// callbacks must carry a valid Replicate webhook signature.
+func NewPredictionCallbacks(verifier *webhookauth.Verifier) *PredictionCallbacks {
+	return &PredictionCallbacks{
+		waiters:   make(map[string]chan *replicate.Prediction),
+		unclaimed: make(map[string]unclaimedPrediction),
+		verifier:  verifier,
+		now:       time.Now,
+	}
+}
+
+// wait registers interest in a prediction. The returned channel receives the
+// prediction once it reaches a terminal status; release must be called when the
+// caller stops waiting.
+func (c *PredictionCallbacks) wait(predictionID string) (<-chan *replicate.Prediction, func()) {
+	ch := make(chan *replicate.Prediction, 1)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The callback can beat CreatePrediction's response for fast models.
+	if u, ok := c.unclaimed[predictionID]; ok {
+		delete(c.unclaimed, predictionID)
+		ch <- u.pred
+	} else {
+		c.waiters[predictionID] = ch
+	}
+
+	release := func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.waiters[predictionID] == ch {
+			delete(c.waiters, predictionID)
+		}
+	}
+	return ch, release
+}
+
+// Resolve hands a terminal prediction to the job waiting on it. Predictions nobody
+// is waiting for yet are held for unclaimedCallbackTTL. Non-terminal updates are ignored.
+func (c *PredictionCallbacks) Resolve(pred *replicate.Prediction) {
+	if pred == nil || pred.ID == "" || !pred.Status.Terminated() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ch, ok := c.waiters[pred.ID]; ok {
+		delete(c.waiters, pred.ID)
+		ch <- pred
+		return
+	}
+
+	now := c.now()
+	for id, u := range c.unclaimed {
+		if now.Sub(u.receivedAt) > unclaimedCallbackTTL {
+			delete(c.unclaimed, id)
+		}
+	}
+	c.unclaimed[pred.ID] = unclaimedPrediction{pred: pred, receivedAt: now}
+}
+
+// ServeHTTP accepts a Replicate prediction callback. Unknown predictions are
+// acknowledged with 200 so Replicate does not keep retrying them.
+func (c *PredictionCallbacks) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log := logging.Default()
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if c.verifier != nil {
+		if err := c.verifier.Verify(r.Context(), r.Header, body); err != nil {
+			log.Warn(r.Context(), "replicate callback rejected", "error", err)
+			switch {
+			case errors.Is(err, webhookauth.ErrReplayed):
+				http.Error(w, "callback already received", http.StatusConflict)
+			case errors.Is(err, webhookauth.ErrReplayCacheUnavailable):
+				http.Error(w, "unable to verify callback", http.StatusServiceUnavailable)
+			default:
+				http.Error(w, "invalid signature", http.StatusUnauthorized)
+			}
+			return
+		}
+	}
+
+	var pred replicate.Prediction
+	if err := json.Unmarshal(body, &pred); err != nil || pred.ID == "" {
+		http.Error(w, "invalid prediction payload", http.StatusBadRequest)
+		return
+	}
+
+	log.Debug(r.Context(), "replicate callback received", "prediction_id", pred.ID, "status", pred.Status)
+
+	c.Resolve(&pred)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,228 @@
+package staging
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/api/webhookauth"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestPredictionCallbacks_ServeHTTP(t *testing.T) {
+	t.Run("success: resolves a waiting prediction", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		ch, release := c.wait("pred-1")
+		defer release()
+
+		body := `{"id":"pred-1","status":"succeeded","output":["https://example.com/out.png"]}`
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/replicate", strings.NewReader(body)))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+		select {
+		case pred := <-ch:
+			if pred.Status != replicate.Succeeded {
+				t.Errorf("expected succeeded, got %s", pred.Status)
+			}
+		default:
+			t.Fatal("expected prediction to be delivered")
+		}
+	})
+
+	t.Run("success: acknowledges unknown predictions", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		rec := httptest.NewRecorder()
+		body := `{"id":"pred-unknown","status":"failed"}`
+		c.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/replicate", strings.NewReader(body)))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("fail: rejects non-POST requests", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks/replicate", nil))
+
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected status 405, got %d", rec.Code)
+		}
+	})
+
+	t.Run("fail: rejects payloads without an id", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/replicate", strings.NewReader(`{}`)))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", rec.Code)
+		}
+	})
+
+	t.Run("success: accepts a signed callback once", func(t *testing.T) {
+		secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("callback-secret"))
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer func() { _ = rdb.Close() }()
+		verifier := webhookauth.NewVerifier(webhookauth.Replicate, []string{secret},
+			webhookauth.Options{Replay: webhookauth.NewRedisReplayCache(rdb)})
+		c := NewPredictionCallbacks(verifier)
+
+		body := `{"id":"pred-1","status":"succeeded"}`
+		sig := webhookauth.Signature{ID: "msg_1", Timestamp: time.Now().Unix()}
+		mac, err := webhookauth.Replicate.Sign(secret, sig, []byte(body))
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		send := func(signature string) int {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/replicate", strings.NewReader(body))
+			req.Header.Set("webhook-id", sig.ID)
+			req.Header.Set("webhook-timestamp", strconv.FormatInt(sig.Timestamp, 10))
+			req.Header.Set("webhook-signature", signature)
+			rec := httptest.NewRecorder()
+			c.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		if code := send("v1," + base64.StdEncoding.EncodeToString([]byte("forged"))); code != http.StatusUnauthorized {
+			t.Fatalf("expected status 401 for a forged signature, got %d", code)
+		}
+		if code := send("v1," + base64.StdEncoding.EncodeToString(mac)); code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", code)
+		}
+		if code := send("v1," + base64.StdEncoding.EncodeToString(mac)); code != http.StatusConflict {
+			t.Fatalf("expected status 409 for a replay, got %d", code)
+		}
+	})
+}
+
+func TestPredictionCallbacks_Resolve(t *testing.T) {
+	t.Run("success: callback before wait is claimed", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		c.Resolve(&replicate.Prediction{ID: "pred-1", Status: replicate.Succeeded, Output: "x"})
+
+		ch, release := c.wait("pred-1")
+		defer release()
+		select {
+		case pred := <-ch:
+			if pred.ID != "pred-1" {
+				t.Errorf("expected pred-1, got %s", pred.ID)
+			}
+		default:
+			t.Fatal("expected early callback to be delivered")
+		}
+	})
+
+	t.Run("success: ignores non-terminal updates", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		ch, release := c.wait("pred-1")
+		defer release()
+
+		c.Resolve(&replicate.Prediction{ID: "pred-1", Status: replicate.Processing})
+		select {
+		case <-ch:
+			t.Fatal("did not expect a non-terminal prediction to be delivered")
+		default:
+		}
+	})
+
+	t.Run("success: expires unclaimed callbacks", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		now := time.Now()
+		c.now = func() time.Time { return now }
+		c.Resolve(&replicate.Prediction{ID: "stale", Status: replicate.Succeeded})
+
+		now = now.Add(unclaimedCallbackTTL + time.Second)
+		c.Resolve(&replicate.Prediction{ID: "fresh", Status: replicate.Succeeded})
+
+		if _, ok := c.unclaimed["stale"]; ok {
+			t.Error("expected stale callback to be pruned")
+		}
+		if _, ok := c.unclaimed["fresh"]; !ok {
+			t.Error("expected fresh callback to be kept")
+		}
+	})
+}
+
+func TestDefaultService_awaitPrediction_Callback(t *testing.T) {
+	t.Run("success: returns output from callback", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		s := &DefaultService{webhookURL: "https://worker.example.com/webhooks/replicate", callbacks: c}
+
+		go func() {
+			for {
+				c.mu.Lock()
+				_, waiting := c.waiters["pred-1"]
+				c.mu.Unlock()
+				if waiting {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			c.Resolve(&replicate.Prediction{ID: "pred-1", Status: replicate.Succeeded, Output: "https://example.com/o.png"})
+		}()
+
+		pred, err := s.awaitPrediction(context.Background(), "pred-1", model.Timing{}.OrDefault())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pred.Output != "https://example.com/o.png" {
+			t.Errorf("unexpected output: %v", pred.Output)
+		}
+	})
+
+	t.Run("fail: surfaces a failed prediction", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		s := &DefaultService{webhookURL: "https://worker.example.com/webhooks/replicate", callbacks: c}
+		c.Resolve(&replicate.Prediction{ID: "pred-1", Status: replicate.Failed, Error: "NSFW"})
+
+		_, err := s.awaitPrediction(context.Background(), "pred-1", model.Timing{}.OrDefault())
+		if err == nil || !strings.Contains(err.Error(), "prediction failed") {
+			t.Fatalf("expected prediction failed error, got %v", err)
+		}
+	})
+
+	t.Run("fail: stops when the context is canceled", func(t *testing.T) {
+		c := NewPredictionCallbacks(nil)
+		s := &DefaultService{webhookURL: "https://worker.example.com/webhooks/replicate", callbacks: c}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := s.awaitPrediction(ctx, "pred-1", model.Timing{}.OrDefault()); err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestDefaultService_predictionWebhook(t *testing.T) {
+	t.Run("success: empty webhook without a URL", func(t *testing.T) {
+		w := (&DefaultService{}).predictionWebhook()
+		if w.URL != "" || len(w.Events) != 0 {
+			t.Errorf("expected empty webhook, got %+v", w)
+		}
+	})
+
+	t.Run("success: completed events with a URL", func(t *testing.T) {
+		w := (&DefaultService{webhookURL: "https://worker.example.com/webhooks/replicate"}).predictionWebhook()
+		if w.URL != "https://worker.example.com/webhooks/replicate" {
+			t.Errorf("unexpected URL: %s", w.URL)
+		}
+		if len(w.Events) != 1 || w.Events[0] != replicate.WebhookEventCompleted {
+			t.Errorf("expected completed event only, got %v", w.Events)
+		}
+	})
+}
@@ -6,8 +6,13 @@ import (
 	"github.com/real-staging-ai/worker/internal/staging/model"
 )
 
-// ConfigRepository handles model configuration persistence.
+// ConfigRepository handles model configuration persistence and the provider
+// keys users bring.
 type ConfigRepository interface {
 	// GetModelConfig retrieves the configuration for a specific model
 	GetModelConfig(ctx context.Context, modelID model.ID) (model.Config, error)
+
+	// GetUserAPIKey returns the key the owner of the image stored for the
+	// provider, or "" when they haven't stored one.
+	GetUserAPIKey(ctx context.Context, imageID string, provider model.Provider) (string, error)
 }
@@ -0,0 +1,166 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register JPEG decoder for staged images
+	_ "image/png"  // register PNG decoder for staged images and masks
+	"io"
+	"strings"
+	"time"
+
+	"github.com/replicate/replicate-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/cutout"
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// DefaultCutoutModel is the Replicate segmentation model used for cut-outs.
+// It runs automatic mask generation and returns one mask per detected object.
+const DefaultCutoutModel = "meta/sam-2"
+
+// CreateCutouts segments a staged image and uploads a transparent-background
+// PNG for each detected furniture piece, largest first.
+func (s *DefaultService) CreateCutouts(ctx context.Context, req *CutoutRequest) ([]Cutout, error) {
+	log := logging.Default()
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.CreateCutouts")
+	span.SetAttributes(
+		attribute.String("image.id", req.ImageID),
+		attribute.String("model.id", s.cutoutModelID),
+	)
+	defer span.End()
+
+	fileKey, err := extractS3KeyFromURL(req.StagedURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid S3 URL")
+		return nil, fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+
+	file, err := s.fetchObject(ctx, fileKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "S3 download failed")
+		return nil, fmt.Errorf("failed to download staged image: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	src, _, err := image.Decode(file.Reader())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "decode image failed")
+		return nil, fmt.Errorf("failed to decode staged image: %w", err)
+	}
+
+	inputURL, inputFile, err := s.imageInput(ctx, model.ID(s.cutoutModelID), fileKey, file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "image input failed")
+		return nil, err
+	}
+	defer s.deleteInputFiles(ctx, inputFile)
+
+	input := replicate.PredictionInput{"image": inputURL}
+	prediction, err := s.replicateClient.CreatePrediction(ctx, s.cutoutModelID, input, s.predictionWebhook(), false)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "CreatePrediction failed")
+		return nil, fmt.Errorf("failed to create segmentation prediction: %w", err)
+	}
+	pred, err := s.awaitPrediction(ctx, prediction.ID, s.timing(model.ID(s.cutoutModelID)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "segmentation failed")
+		return nil, fmt.Errorf("segmentation failed: %w", err)
+	}
+
+	urls := maskURLs(pred.Output)
+	masks := make([]image.Image, 0, len(urls))
+	for _, u := range urls {
+		raw, err := s.downloadFromURL(ctx, u)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "download mask failed")
+			return nil, fmt.Errorf("failed to download mask: %w", err)
+		}
+		m, _, err := image.Decode(bytes.NewReader(raw))
+		if err != nil {
+			log.Warn(ctx, "skipping undecodable mask", "image_id", req.ImageID, "error", err)
+			continue
+		}
+		masks = append(masks, m)
+	}
+
+	pieces := cutout.Select(src, masks, cutout.DefaultOptions)
+	span.SetAttributes(
+		attribute.Int("cutout.masks", len(masks)),
+		attribute.Int("cutout.pieces", len(pieces)),
+	)
+
+	// Each run writes fresh keys: uploads are cached as immutable, so
+	// overwriting a previous run's objects would serve stale pixels.
+	run := time.Now().UTC().Format("20060102T150405")
+	cutouts := make([]Cutout, 0, len(pieces))
+	for i, p := range pieces {
+		key := cutoutObjectKey(fileKey, req.ImageID, run, i+1)
+		u, err := s.uploadEncoded(ctx, req.ImageID, key, "image/png", func(w io.Writer) error {
+			return cutout.WritePNG(w, p)
+		})
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "S3 upload failed")
+			return nil, fmt.Errorf("failed to upload cutout: %w", err)
+		}
+		cutouts = append(cutouts, Cutout{
+			URL:    u,
+			Width:  p.Bounds.Dx(),
+			Height: p.Bounds.Dy(),
+			X:      p.Bounds.Min.X,
+			Y:      p.Bounds.Min.Y,
+		})
+	}
+
+	span.SetStatus(codes.Ok, "cutouts created")
+	return cutouts, nil
+}
+
+// maskURLs extracts per-object mask URLs from a segmentation prediction.
+// SAM-style models return {"individual_masks": [...], "combined_mask": "..."};
+// plain lists of URLs are accepted too.
+func maskURLs(output interface{}) []string {
+	var list []interface{}
+	switch v := output.(type) {
+	case map[string]interface{}:
+		list, _ = v["individual_masks"].([]interface{})
+	case []interface{}:
+		list = v
+	}
+
+	urls := make([]string, 0, len(list))
+	for _, item := range list {
+		if u, ok := item.(string); ok && u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// cutoutObjectKey returns the key for one cut-out, alongside the staged image
+// it was taken from (see stagedObjectKey).
+func cutoutObjectKey(stagedKey, imageID, run string, n int) string {
+	name := fmt.Sprintf("%s/%s-%d.png", imageID, run, n)
+	parts := strings.SplitN(stagedKey, "/", 5)
+	if len(parts) == 5 && parts[0] == "users" && parts[2] == "projects" {
+		return fmt.Sprintf("users/%s/projects/%s/cutouts/%s", parts[1], parts[3], name)
+	}
+	if len(parts) >= 3 && parts[0] == "users" {
+		return fmt.Sprintf("users/%s/cutouts/%s", parts[1], name)
+	}
+	return "cutouts/" + name
+}
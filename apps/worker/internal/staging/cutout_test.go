@@ -0,0 +1,74 @@
+package staging
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCutoutObjectKey(t *testing.T) {
+	const imageID = "12345678-aaaa-bbbb-cccc-1234567890ab"
+	tests := []struct {
+		name      string
+		stagedKey string
+		want      string
+	}{
+		{
+			name:      "success: project scoped staged image",
+			stagedKey: "users/u1/projects/p1/staged/" + imageID + "-staged.jpg",
+			want:      "users/u1/projects/p1/cutouts/" + imageID + "/run-2.png",
+		},
+		{
+			name:      "success: unassigned user staged image",
+			stagedKey: "users/u1/staged/" + imageID + "-staged.jpg",
+			want:      "users/u1/cutouts/" + imageID + "/run-2.png",
+		},
+		{
+			name:      "success: legacy staged image",
+			stagedKey: "staged/12345678/" + imageID + "-staged.jpg",
+			want:      "cutouts/" + imageID + "/run-2.png",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cutoutObjectKey(tt.stagedKey, imageID, "run", 2); got != tt.want {
+				t.Errorf("cutoutObjectKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskURLs(t *testing.T) {
+	tests := []struct {
+		name   string
+		output interface{}
+		want   []string
+	}{
+		{
+			name: "success: sam individual masks",
+			output: map[string]interface{}{
+				"combined_mask":    "https://cdn/combined.png",
+				"individual_masks": []interface{}{"https://cdn/0.png", "https://cdn/1.png"},
+			},
+			want: []string{"https://cdn/0.png", "https://cdn/1.png"},
+		},
+		{
+			name:   "success: plain list skips non-strings",
+			output: []interface{}{"https://cdn/0.png", 42, ""},
+			want:   []string{"https://cdn/0.png"},
+		},
+		{
+			name:   "success: unexpected output yields nothing",
+			output: "https://cdn/single.png",
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskURLs(tt.output); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("maskURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,59 +3,114 @@ package staging
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
+	"image"
 	"io"
 	"net/http"
 	"net/url"
+	"path"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/replicate/replicate-go"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/real-staging-ai/api/watermark"
+
+	"github.com/real-staging-ai/worker/internal/blurhash"
+	"github.com/real-staging-ai/worker/internal/imagecheck"
 	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/malware"
+	"github.com/real-staging-ai/worker/internal/orientation"
+	"github.com/real-staging-ai/worker/internal/photometa"
+	"github.com/real-staging-ai/worker/internal/quality"
 	"github.com/real-staging-ai/worker/internal/staging/model"
 	"github.com/real-staging-ai/worker/internal/staging/prompt"
 )
 
 // DefaultService implements the Service interface using Replicate AI and S3.
+// Models registered with another provider run on that provider instead.
 type DefaultService struct {
-	s3Client        *s3.Client
-	bucketName      string
-	replicateClient *replicate.Client
-	modelID         model.ID
-	registry        *model.ModelRegistry
-	promptLib       *prompt.Library
-	configRepo      ConfigRepository // For loading model configurations
+	s3Client         *s3.Client
+	uploader         *manager.Uploader
+	bucketName       string
+	replicateClient  *replicate.Client
+	stability        Provider // Runs Stability AI models; nil without an API key
+	openai           Provider // Runs OpenAI models with the user's key or the one in their config
+	modelID          model.ID
+	registry         *model.ModelRegistry
+	promptLib        *prompt.Library
+	configRepo       ConfigRepository // For loading model configurations
+	cutoutModelID    string
+	roomDetection    roomDetectionConfig       // Detects room types; an empty model disables it
+	webhookURL       string                    // Replicate callback URL; empty means poll
+	callbacks        *PredictionCallbacks      // Receives callbacks when webhookURL is set
+	originalLimits   imagecheck.Limits         // Bounds the originals ValidateOriginal accepts
+	preserveMetadata bool                      // Return the EXIF PreprocessOriginal strips
+	scanner          malware.Scanner           // Scans originals; nil disables scanning
+	quarantinePrefix string                    // Where QuarantineOriginal moves infected originals
+	quality          quality.Options           // Checks run on staged images before they are stored
+	timings          map[model.ID]model.Timing // Override the registry's prediction timings
+	imageInputMode   ImageInput                // How images are passed to Replicate models
+	presign          *s3.PresignClient         // Presigns the URLs ImageInputPresigned passes
 }
 
 // Ensure DefaultService implements Service interface.
 var _ Service = (*DefaultService)(nil)
 
+// callbackSafetyInterval is how often a job waiting on a callback checks in with Replicate.
+const callbackSafetyInterval = 30 * time.Second
+
 // awsConfigLoader allows overriding AWS config loading in tests.
 var awsConfigLoader = config.LoadDefaultConfig
 
 // ServiceConfig holds configuration for the staging service.
 type ServiceConfig struct {
-	BucketName     string
-	ReplicateToken string
-	ModelID        model.ID
-	S3Endpoint     string
-	S3Region       string
-	S3AccessKey    string
-	S3SecretKey    string
-	S3UsePathStyle bool
-	AppEnv         string
-	ConfigRepo     ConfigRepository // Optional: for loading model configs from database
+	BucketName               string
+	ReplicateToken           string
+	ModelID                  model.ID
+	S3Endpoint               string
+	S3Region                 string
+	S3AccessKey              string
+	S3SecretKey              string
+	S3UsePathStyle           bool
+	AppEnv                   string
+	ConfigRepo               ConfigRepository     // Optional: for loading model configs from database
+	CutoutModelID            string               // Optional: segmentation model for cut-outs (default DefaultCutoutModel)
+	RoomDetectionModelID     string               // Optional: vision model that detects room types (empty: off)
+	RoomDetectionConfidence  float64              // Optional: confidence a detection needs to be staged with
+	WebhookURL               string               // Optional: public URL Replicate calls when a prediction completes
+	Callbacks                *PredictionCallbacks // Required with WebhookURL: serves that URL
+	OriginalLimits           imagecheck.Limits    // Optional: size and dimension limits for originals (zero: none)
+	PreserveOriginalMetadata bool                 // Optional: return the EXIF stripped from originals so it can be stored
+	Scanner                  malware.Scanner      // Optional: scans originals for malware (nil: no scanning)
+	QuarantinePrefix         string               // Optional: key prefix for infected originals (default "quarantine/")
+	Quality                  quality.Options      // Optional: checks run on staged images (zero: none)
+	StabilityAPIKey          string               // Optional: runs the Stability AI models (empty: they fail)
+	StabilityBaseURL         string               // Optional: Stability API URL (default DefaultStabilityBaseURL)
+	OpenAIBaseURL            string               // Optional: OpenAI API URL (default DefaultOpenAIBaseURL)
+	// Optional: per-model overrides of the registry's prediction timeout and
+	// poll interval; zero fields keep the model's own
+	ModelTimings map[model.ID]model.Timing
+	// Optional: how images are passed to Replicate models (default ImageInputDataURL)
+	ImageInput ImageInput
+	// Optional: S3 endpoint Replicate can reach, for ImageInputPresigned (default S3Endpoint)
+	S3PublicEndpoint string
 }
 
+// DefaultQuarantinePrefix is where QuarantineOriginal moves infected originals.
+const DefaultQuarantinePrefix = "quarantine/"
+
 // NewDefaultService creates a new DefaultService instance using provided configuration.
 func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService, error) {
 	// Validate required configuration
@@ -80,6 +135,33 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 		return nil, fmt.Errorf("unsupported model: %s", modelID)
 	}
 
+	if cfg.WebhookURL != "" && cfg.Callbacks == nil {
+		return nil, fmt.Errorf("prediction callbacks are required when a webhook URL is set")
+	}
+
+	cutoutModelID := cfg.CutoutModelID
+	if cutoutModelID == "" {
+		cutoutModelID = DefaultCutoutModel
+	}
+
+	roomDetection := roomDetectionConfig{
+		modelID:       model.ID(cfg.RoomDetectionModelID),
+		minConfidence: cfg.RoomDetectionConfidence,
+	}
+
+	quarantinePrefix := cfg.QuarantinePrefix
+	if quarantinePrefix == "" {
+		quarantinePrefix = DefaultQuarantinePrefix
+	}
+
+	imageInput := cfg.ImageInput
+	if imageInput == "" {
+		imageInput = ImageInputDataURL
+	}
+	if !imageInput.Valid() {
+		return nil, fmt.Errorf("unsupported image input: %s", imageInput)
+	}
+
 	bucketName := cfg.BucketName
 	replicateToken := cfg.ReplicateToken
 
@@ -89,6 +171,11 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 		return nil, fmt.Errorf("failed to create Replicate client: %w", err)
 	}
 
+	var stability Provider
+	if cfg.StabilityAPIKey != "" {
+		stability = newStabilityProvider(cfg.StabilityAPIKey, cfg.StabilityBaseURL)
+	}
+
 	// Initialize S3 client
 	var awsCfg aws.Config
 
@@ -107,13 +194,28 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 		})
 
 		return &DefaultService{
-			s3Client:        s3Client,
-			bucketName:      bucketName,
-			replicateClient: replicateClient,
-			modelID:         modelID,
-			registry:        registry,
-			promptLib:       prompt.New(),
-			configRepo:      cfg.ConfigRepo,
+			s3Client:         s3Client,
+			uploader:         newUploader(s3Client),
+			bucketName:       bucketName,
+			replicateClient:  replicateClient,
+			stability:        stability,
+			openai:           newOpenAIProvider(cfg.OpenAIBaseURL),
+			modelID:          modelID,
+			registry:         registry,
+			promptLib:        prompt.New(),
+			configRepo:       cfg.ConfigRepo,
+			cutoutModelID:    cutoutModelID,
+			roomDetection:    roomDetection,
+			webhookURL:       cfg.WebhookURL,
+			callbacks:        cfg.Callbacks,
+			originalLimits:   cfg.OriginalLimits,
+			preserveMetadata: cfg.PreserveOriginalMetadata,
+			scanner:          cfg.Scanner,
+			quarantinePrefix: quarantinePrefix,
+			quality:          cfg.Quality,
+			timings:          cfg.ModelTimings,
+			imageInputMode:   imageInput,
+			presign:          newPresignClient(s3Client, cfg.S3PublicEndpoint),
 		}, nil
 	}
 
@@ -139,13 +241,28 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 		})
 
 		return &DefaultService{
-			s3Client:        s3Client,
-			bucketName:      bucketName,
-			replicateClient: replicateClient,
-			modelID:         modelID,
-			registry:        registry,
-			promptLib:       prompt.New(),
-			configRepo:      cfg.ConfigRepo,
+			s3Client:         s3Client,
+			uploader:         newUploader(s3Client),
+			bucketName:       bucketName,
+			replicateClient:  replicateClient,
+			stability:        stability,
+			openai:           newOpenAIProvider(cfg.OpenAIBaseURL),
+			modelID:          modelID,
+			registry:         registry,
+			promptLib:        prompt.New(),
+			configRepo:       cfg.ConfigRepo,
+			cutoutModelID:    cutoutModelID,
+			roomDetection:    roomDetection,
+			webhookURL:       cfg.WebhookURL,
+			callbacks:        cfg.Callbacks,
+			originalLimits:   cfg.OriginalLimits,
+			preserveMetadata: cfg.PreserveOriginalMetadata,
+			scanner:          cfg.Scanner,
+			quarantinePrefix: quarantinePrefix,
+			quality:          cfg.Quality,
+			timings:          cfg.ModelTimings,
+			imageInputMode:   imageInput,
+			presign:          newPresignClient(s3Client, cfg.S3PublicEndpoint),
 		}, nil
 	}
 
@@ -158,18 +275,33 @@ func NewDefaultService(ctx context.Context, cfg *ServiceConfig) (*DefaultService
 	s3Client := s3.NewFromConfig(awsCfg)
 
 	return &DefaultService{
-		s3Client:        s3Client,
-		bucketName:      bucketName,
-		replicateClient: replicateClient,
-		modelID:         modelID,
-		registry:        registry,
-		promptLib:       prompt.New(),
-		configRepo:      cfg.ConfigRepo,
+		s3Client:         s3Client,
+		uploader:         newUploader(s3Client),
+		bucketName:       bucketName,
+		replicateClient:  replicateClient,
+		stability:        stability,
+		openai:           newOpenAIProvider(cfg.OpenAIBaseURL),
+		modelID:          modelID,
+		registry:         registry,
+		promptLib:        prompt.New(),
+		configRepo:       cfg.ConfigRepo,
+		cutoutModelID:    cutoutModelID,
+		roomDetection:    roomDetection,
+		webhookURL:       cfg.WebhookURL,
+		callbacks:        cfg.Callbacks,
+		originalLimits:   cfg.OriginalLimits,
+		preserveMetadata: cfg.PreserveOriginalMetadata,
+		scanner:          cfg.Scanner,
+		quarantinePrefix: quarantinePrefix,
+		quality:          cfg.Quality,
+		timings:          cfg.ModelTimings,
+		imageInputMode:   imageInput,
+		presign:          newPresignClient(s3Client, cfg.S3PublicEndpoint),
 	}, nil
 }
 
-// StageImage processes an image with AI staging and returns the staged image URL in S3.
-func (s *DefaultService) StageImage(ctx context.Context, req *StagingRequest) (string, error) {
+// StageImage processes an image with AI staging and returns the staged image in S3.
+func (s *DefaultService) StageImage(ctx context.Context, req *StagingRequest) (*StagingResult, error) {
 	log := logging.Default()
 	tracer := otel.Tracer("real-staging-worker/staging")
 	ctx, span := tracer.Start(ctx, "staging.StageImage")
@@ -186,11 +318,11 @@ func (s *DefaultService) StageImage(ctx context.Context, req *StagingRequest) (s
 		// Fall back to service default if not specified
 		modelID = s.modelID
 	}
-	if !s.registry.Exists(modelID) {
+	if modelID != FakeModelID && !s.registry.Exists(modelID) {
 		err := fmt.Errorf("unsupported model: %s", modelID)
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid model")
-		return "", err
+		return nil, err
 	}
 
 	// Extract the S3 file key from the original URL
@@ -198,64 +330,275 @@ func (s *DefaultService) StageImage(ctx context.Context, req *StagingRequest) (s
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid S3 URL")
-		return "", fmt.Errorf("failed to extract S3 key from URL: %w", err)
+		return nil, fmt.Errorf("failed to extract S3 key from URL: %w", err)
 	}
 
 	// Download the original image from S3
-	originalImage, err := s.DownloadFromS3(ctx, fileKey)
+	original, err := s.fetchObject(ctx, fileKey)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "S3 download failed")
-		return "", fmt.Errorf("failed to download original image: %w", err)
+		return nil, fmt.Errorf("failed to download original image: %w", err)
 	}
-	defer func() {
-		if err := originalImage.Close(); err != nil {
+	defer func() { _ = original.Close() }()
+
+	// Models ignore EXIF, so a sideways phone photo would come back rotated.
+	// The processor normally fixes the stored original first; this covers
+	// originals that couldn't be rewritten. The rotated copy is only on the
+	// worker, so the model can't be pointed at the stored file.
+	inputKey := fileKey
+	if upright, err := uprightCopy(original); err != nil {
+		log.Warn(ctx, "failed to apply EXIF orientation", "image_id", req.ImageID, "error", err)
+	} else if upright != nil {
+		_ = original.Close()
+		original = upright
+		inputKey = ""
+	}
+
+	var stagedImageBytes []byte
+	var costUSD float64
+	var seed *int64
+	var pred *stagedPrediction
+	if modelID == FakeModelID {
+		// Sandbox accounts are staged locally and never spend Replicate credits.
+		stagedImageBytes, err = fakeStage(original.Reader())
+		if err != nil {
 			span.RecordError(err)
-			log.Error(ctx, "failed to close original image", "error", err)
+			span.SetStatus(codes.Error, "fake provider failed")
+			return nil, err
 		}
-	}()
+	} else {
+		// Replicate files uploaded for the predictions, including those of
+		// earlier attempts, are deleted once this attempt is done. A job handed
+		// off on shutdown resumes the prediction, which may still read them,
+		// so they go with it.
+		inputFiles := slices.Clone(req.InputFiles)
+		defer func() {
+			if ctx.Err() == nil {
+				s.deleteInputFiles(ctx, inputFiles...)
+			} else if req.OnInputFiles != nil {
+				req.OnInputFiles(inputFiles)
+			}
+		}()
 
-	// Read the image content
-	imageBytes, err := io.ReadAll(originalImage)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "read image failed")
-		return "", fmt.Errorf("failed to read image content: %w", err)
-	}
+		if req.PredictionID != "" {
+			pred, err = s.resumePrediction(ctx, modelID, req.PredictionID)
+			if err != nil {
+				log.Warn(ctx, "could not resume prediction, starting a new one",
+					"image_id", req.ImageID, "prediction_id", req.PredictionID, "error", err)
+			}
+		}
 
-	// Convert to base64 data URL for Replicate
-	mimeType := http.DetectContentType(imageBytes)
-	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(imageBytes))
+		if pred == nil {
+			var maskURL string
+			if req.MaskURL != "" {
+				var maskFile string
+				maskURL, maskFile, err = s.maskInput(ctx, span, modelID, req.MaskURL)
+				if err != nil {
+					return nil, err
+				}
+				if maskFile != "" {
+					inputFiles = append(inputFiles, maskFile)
+				}
+			}
 
-	// Build the prompt using library or custom prompt
-	promptText := s.buildPrompt(req.RoomType, req.Style, req.Prompt)
+			// Build the prompt using library or custom prompt
+			promptText := s.buildPrompt(req.RoomType, req.Style, req.Prompt, req.PromptOverride, req.Locale, req.Language,
+				req.Mode)
 
-	// Call Replicate AI to stage the image
-	stagedImageURL, err := s.callReplicateAPI(ctx, modelID, dataURL, promptText, req.Seed)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "Replicate API failed")
-		return "", fmt.Errorf("failed to stage image with Replicate: %w", err)
+			// Pass the original as a data URL, a Replicate file or a presigned URL
+			inputURL, inputFile, err := s.imageInput(ctx, modelID, inputKey, original)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "image input failed")
+				return nil, err
+			}
+			if inputFile != "" {
+				inputFiles = append(inputFiles, inputFile)
+			}
+
+			// Run the model on its provider to stage the image
+			pred, err = s.runPrediction(ctx, req.ImageID, modelID, req.ModelVersion, inputURL, maskURL, promptText,
+				req.Seed, req.ModelConfig, req.OnPrediction)
+			if s.quality.RejectNSFW && errors.Is(err, errNSFW) {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "output flagged as NSFW")
+				return nil, &quality.Error{Code: quality.CodeNSFW, Message: "The model flagged the staged image as unsafe."}
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "provider failed")
+				return nil, fmt.Errorf("failed to stage image: %w", err)
+			}
+		}
+
+		// Fetch the staged image from the provider
+		costUSD = s.predictionCost(modelID, pred.result)
+		seed = pred.result.Seed
+
+		stagedImageBytes, err = pred.provider.Fetch(ctx, pred.result)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "download staged image failed")
+			return nil, fmt.Errorf("failed to download staged image: %w", err)
+		}
 	}
 
-	// Download the staged image from Replicate's CDN
-	stagedImageBytes, err := s.downloadFromURL(ctx, stagedImageURL)
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "download staged image failed")
-		return "", fmt.Errorf("failed to download staged image: %w", err)
+	// Models sometimes return a black, blank or reshaped image without
+	// reporting an error; catch it before it is stored. An original that
+	// can't be compared against skips the checks.
+	if err := quality.Check(original.Reader(), stagedImageBytes, s.quality); err != nil {
+		var qerr *quality.Error
+		if errors.As(err, &qerr) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "quality check failed")
+			return nil, err
+		}
+		log.Warn(ctx, "failed to check staged image quality", "image_id", req.ImageID, "error", err)
 	}
 
-	// Upload the staged image to S3
-	stagedURL, err := s.UploadToS3(ctx, req.ImageID, bytes.NewReader(stagedImageBytes), "image/jpeg")
+	// Disclose the staging on the image itself before anyone can download it;
+	// thumbnails and cut-outs are made from this file and carry it too.
+	if req.Watermark != nil {
+		stagedImageBytes, err = watermark.Apply(stagedImageBytes, *req.Watermark)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "watermark failed")
+			return nil, fmt.Errorf("failed to watermark staged image: %w", err)
+		}
+	}
+
+	// Upload the staged image to S3 in the format the model (or the
+	// watermark, which re-encodes as JPEG) produced
+	var declared string
+	if pred != nil {
+		declared = pred.outputFormat
+	}
+	contentType, ext := stagedFormat(stagedImageBytes, declared)
+	stagedKey := stagedObjectKey(fileKey, req.ImageID, ext)
+	stagedURL, err := s.uploadObject(ctx, req.ImageID, stagedKey, bytes.NewReader(stagedImageBytes), contentType)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "S3 upload failed")
-		return "", fmt.Errorf("failed to upload staged image: %w", err)
+		return nil, fmt.Errorf("failed to upload staged image: %w", err)
+	}
+
+	result := &StagingResult{URL: stagedURL, ContentType: contentType, CostUSD: costUSD, Seed: seed}
+	if hash, err := stagedBlurhash(stagedImageBytes); err != nil {
+		log.Warn(ctx, "failed to compute staged image blurhash", "image_id", req.ImageID, "error", err)
+	} else {
+		result.Blurhash = hash
+	}
+	if pred != nil && len(pred.result.ExtraOutputURLs) > 0 {
+		result.Extras = s.stageExtraOutputs(ctx, req, pred, stagedKey, original)
 	}
 
 	span.SetStatus(codes.Ok, "staging completed")
-	return stagedURL, nil
+	return result, nil
+}
+
+// stageExtraOutputs stores the other images a prediction made, checked and
+// watermarked like the first, next to it in S3 as "-staged-2.jpg" and so on,
+// each with the extension of its own format.
+// The image the job was for is already staged, so an output that fails is
+// logged and left out rather than failing the job.
+func (s *DefaultService) stageExtraOutputs(
+	ctx context.Context, req *StagingRequest, pred *stagedPrediction, stagedKey string, original *spooledFile,
+) []StagedOutput {
+	log := logging.Default()
+	base := strings.TrimSuffix(stagedKey, path.Ext(stagedKey))
+
+	var outputs []StagedOutput
+	for i, url := range pred.result.ExtraOutputURLs {
+		n := i + 2
+		staged, err := pred.provider.Fetch(ctx, &ProviderResult{OutputURL: url})
+		if err != nil {
+			log.Warn(ctx, "failed to download extra output", "image_id", req.ImageID, "output", n, "error", err)
+			continue
+		}
+		if err := quality.Check(original.Reader(), staged, s.quality); err != nil {
+			var qerr *quality.Error
+			if errors.As(err, &qerr) {
+				log.Warn(ctx, "extra output failed a quality check", "image_id", req.ImageID, "output", n,
+					"code", string(qerr.Code))
+				continue
+			}
+			log.Warn(ctx, "failed to check extra output quality", "image_id", req.ImageID, "output", n, "error", err)
+		}
+		if req.Watermark != nil {
+			if staged, err = watermark.Apply(staged, *req.Watermark); err != nil {
+				log.Warn(ctx, "failed to watermark extra output", "image_id", req.ImageID, "output", n, "error", err)
+				continue
+			}
+		}
+
+		contentType, ext := stagedFormat(staged, pred.outputFormat)
+		key := fmt.Sprintf("%s-%d%s", base, n, ext)
+		stagedURL, err := s.uploadObject(ctx, req.ImageID, key, bytes.NewReader(staged), contentType)
+		if err != nil {
+			log.Warn(ctx, "failed to upload extra output", "image_id", req.ImageID, "output", n, "error", err)
+			continue
+		}
+		out := StagedOutput{URL: stagedURL, ContentType: contentType}
+		if hash, err := stagedBlurhash(staged); err != nil {
+			log.Warn(ctx, "failed to compute extra output blurhash", "image_id", req.ImageID, "output", n, "error", err)
+		} else {
+			out.Blurhash = hash
+		}
+		outputs = append(outputs, out)
+	}
+	return outputs
+}
+
+// stagedExtensions maps the formats models emit to the extension their staged
+// files are stored with.
+var stagedExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// outputFormats maps the output_format values model configs take to the
+// content type of the files they produce.
+var outputFormats = map[string]string{
+	"jpeg": "image/jpeg",
+	"jpg":  "image/jpeg",
+	"png":  "image/png",
+	"webp": "image/webp",
+}
+
+// stagedFormat sniffs the content type of a staged image and returns it with
+// the extension to store it under. A format the sniffer doesn't recognize is
+// stored as declared, the output_format the model was asked for, or as PNG
+// when the model takes none.
+func stagedFormat(data []byte, declared string) (contentType, ext string) {
+	contentType = http.DetectContentType(data)
+	if ext, ok := stagedExtensions[contentType]; ok {
+		return contentType, ext
+	}
+	if contentType, ok := outputFormats[declared]; ok {
+		return contentType, stagedExtensions[contentType]
+	}
+	return "image/png", ".png"
+}
+
+// declaredOutputFormat returns the output_format set in cfg, or "" when the
+// model takes none.
+func declaredOutputFormat(cfg model.Config) string {
+	if cfg == nil {
+		return ""
+	}
+	format, _ := cfg.ToMap()["output_format"].(string)
+	return format
+}
+
+// stagedBlurhash decodes the staged image and returns its blurhash.
+func stagedBlurhash(data []byte) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("decode staged image: %w", err)
+	}
+	return blurhash.Encode(img, blurhash.DefaultXComponents, blurhash.DefaultYComponents)
 }
 
 // DownloadFromS3 downloads a file from S3 and returns its content.
@@ -282,18 +625,25 @@ func (s *DefaultService) DownloadFromS3(ctx context.Context, fileKey string) (io
 // UploadToS3 uploads a file to S3 and returns the public URL.
 func (s *DefaultService) UploadToS3(
 	ctx context.Context, imageID string, content io.Reader, contentType string,
+) (string, error) {
+	return s.uploadObject(ctx, imageID, stagedObjectKey("", imageID, stagedExtensions[contentType]), content, contentType)
+}
+
+// uploadObject writes content to the given key and returns its s3:// URL.
+func (s *DefaultService) uploadObject(
+	ctx context.Context, imageID, fileKey string, content io.Reader, contentType string,
 ) (string, error) {
 	tracer := otel.Tracer("real-staging-worker/staging")
 	_, span := tracer.Start(ctx, "staging.UploadToS3")
-	span.SetAttributes(attribute.String("image.id", imageID))
+	span.SetAttributes(
+		attribute.String("image.id", imageID),
+		attribute.String("s3.key", fileKey),
+	)
 	defer span.End()
 
-	// Generate the S3 key for the staged image
-	fileKey := fmt.Sprintf("staged/%s/%s-staged.jpg", imageID[:8], imageID)
-
 	// Upload to S3
 	// Set Cache-Control for Render Edge Caching: staged images are immutable, cache for 1 year
-	_, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+	_, err := s.uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket:       aws.String(s.bucketName),
 		Key:          aws.String(fileKey),
 		Body:         content,
@@ -302,7 +652,7 @@ func (s *DefaultService) UploadToS3(
 	})
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "PutObject failed")
+		span.SetStatus(codes.Error, "upload failed")
 		return "", fmt.Errorf("failed to upload to S3: %w", err)
 	}
 
@@ -315,14 +665,358 @@ func (s *DefaultService) UploadToS3(
 	return publicURL, nil
 }
 
-// callReplicateAPI calls the Replicate API to stage an image.
-func (s *DefaultService) callReplicateAPI(
-	ctx context.Context, modelID model.ID, imageDataURL, prompt string, seed *int64,
-) (string, error) {
+// NormalizeOrientation rewrites the original in place with its EXIF orientation
+// applied and returns the orientation that was found (1 means already upright).
+func (s *DefaultService) NormalizeOrientation(ctx context.Context, originalURL string) (int, error) {
 	tracer := otel.Tracer("real-staging-worker/staging")
-	ctx, span := tracer.Start(ctx, "staging.callReplicateAPI")
+	ctx, span := tracer.Start(ctx, "staging.NormalizeOrientation")
+	defer span.End()
+
+	fileKey, data, err := s.readOriginal(ctx, span, originalURL)
+	if err != nil {
+		return 0, err
+	}
+
+	upright, o, err := orientation.Normalize(data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "normalize failed")
+		return o, fmt.Errorf("failed to normalize orientation: %w", err)
+	}
+	span.SetAttributes(attribute.Int("image.exif_orientation", o))
+	if o == orientation.Normal {
+		return o, nil
+	}
+	if err := s.rewriteOriginal(ctx, span, fileKey, upright, "image/jpeg"); err != nil {
+		return o, err
+	}
+	return o, nil
+}
+
+// PreprocessOriginal rewrites the original in place upright and without its
+// metadata. Sideways JPEGs are re-encoded with the orientation applied, which
+// drops every metadata segment; other originals have their metadata removed
+// without touching the image data. The file is only written when something
+// changed.
+func (s *DefaultService) PreprocessOriginal(ctx context.Context, originalURL string) (*PreprocessResult, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.PreprocessOriginal")
+	defer span.End()
+
+	fileKey, data, err := s.readOriginal(ctx, span, originalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &PreprocessResult{}
+	if s.preserveMetadata {
+		res.Metadata = photometa.Read(data)
+	}
+
+	out, o, err := orientation.Normalize(data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "normalize failed")
+		return nil, fmt.Errorf("failed to normalize orientation: %w", err)
+	}
+	res.Orientation = o
+	if o == orientation.Normal {
+		out, res.Stripped, err = photometa.Strip(data)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "strip metadata failed")
+			return nil, fmt.Errorf("failed to strip metadata: %w", err)
+		}
+	} else {
+		res.Stripped = true
+	}
+	span.SetAttributes(
+		attribute.Int("image.exif_orientation", o),
+		attribute.Bool("image.metadata_stripped", res.Stripped),
+	)
+	if !res.Stripped {
+		return res, nil
+	}
+
+	if err := s.rewriteOriginal(ctx, span, fileKey, out, http.DetectContentType(out)); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// fetchOriginal downloads the original behind originalURL to a temporary file
+// and returns its key and the file, which the caller closes.
+func (s *DefaultService) fetchOriginal(
+	ctx context.Context, span trace.Span, originalURL string,
+) (string, *spooledFile, error) {
+	fileKey, err := extractS3KeyFromURL(originalURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid S3 URL")
+		return "", nil, fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+	span.SetAttributes(attribute.String("s3.key", fileKey))
+
+	file, err := s.fetchObject(ctx, fileKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "S3 download failed")
+		return "", nil, fmt.Errorf("failed to download original image: %w", err)
+	}
+	return fileKey, file, nil
+}
+
+// readOriginal downloads the original behind originalURL and returns its key
+// and content, for the steps that rewrite it.
+func (s *DefaultService) readOriginal(
+	ctx context.Context, span trace.Span, originalURL string,
+) (string, []byte, error) {
+	fileKey, file, err := s.fetchOriginal(ctx, span, originalURL)
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := file.Bytes()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "read original failed")
+		return "", nil, fmt.Errorf("failed to read original image: %w", err)
+	}
+	return fileKey, data, nil
+}
+
+// uprightCopy returns a copy of original with its EXIF orientation applied,
+// or nil when it is already upright. Only a sideways photo is read into
+// memory, to be re-encoded.
+func uprightCopy(original *spooledFile) (*spooledFile, error) {
+	head, err := original.Head(metadataHeadBytes)
+	if err != nil {
+		return nil, err
+	}
+	if orientation.FromEXIF(head) == orientation.Normal {
+		return nil, nil
+	}
+	data, err := original.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	upright, _, err := orientation.Normalize(data)
+	if err != nil {
+		return nil, err
+	}
+	return spool(bytes.NewReader(upright))
+}
+
+// maskInput downloads the mask behind maskURL and returns the URL modelID
+// reads it from, passed like the original (see imageInput).
+func (s *DefaultService) maskInput(
+	ctx context.Context, span trace.Span, modelID model.ID, maskURL string,
+) (url, fileID string, err error) {
+	fileKey, err := extractS3KeyFromURL(maskURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid mask URL")
+		return "", "", fmt.Errorf("failed to extract S3 key from mask URL: %w", err)
+	}
+
+	mask, err := s.fetchObject(ctx, fileKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "mask download failed")
+		return "", "", fmt.Errorf("failed to download mask: %w", err)
+	}
+	defer func() { _ = mask.Close() }()
+
+	url, fileID, err = s.imageInput(ctx, modelID, fileKey, mask)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "mask input failed")
+		return "", "", err
+	}
+	return url, fileID, nil
+}
+
+// rewriteOriginal overwrites the original at fileKey.
+func (s *DefaultService) rewriteOriginal(
+	ctx context.Context, span trace.Span, fileKey string, data []byte, contentType string,
+) error {
+	// Originals are overwritten, so don't mark them immutable like staged outputs.
+	_, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(fileKey),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "PutObject failed")
+		return fmt.Errorf("failed to write normalized original: %w", err)
+	}
+	return nil
+}
+
+// ValidateOriginal downloads the original and checks that it is a JPEG, PNG or
+// WebP within the configured limits that decodes cleanly. Only the first
+// MaxBytes+1 bytes are read, so an oversized upload is rejected without
+// buffering all of it.
+func (s *DefaultService) ValidateOriginal(ctx context.Context, originalURL string) (*imagecheck.Info, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.ValidateOriginal")
+	defer span.End()
+
+	fileKey, err := extractS3KeyFromURL(originalURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid S3 URL")
+		return nil, fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+	span.SetAttributes(attribute.String("s3.key", fileKey))
+
+	body, err := s.DownloadFromS3(ctx, fileKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "S3 download failed")
+		return nil, fmt.Errorf("failed to download original image: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	var r io.Reader = body
+	if s.originalLimits.MaxBytes > 0 {
+		r = io.LimitReader(body, s.originalLimits.MaxBytes+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "read image failed")
+		return nil, fmt.Errorf("failed to read image content: %w", err)
+	}
+
+	info, err := imagecheck.Check(data, s.originalLimits)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid original")
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.String("image.format", info.Format),
+		attribute.Int("image.width", info.Width),
+		attribute.Int("image.height", info.Height),
+	)
+	return info, nil
+}
+
+// ScanOriginal streams the original through the configured scanner. It
+// returns a nil verdict when scanning is disabled.
+func (s *DefaultService) ScanOriginal(ctx context.Context, originalURL string) (*malware.Verdict, error) {
+	if s.scanner == nil {
+		return nil, nil
+	}
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.ScanOriginal")
+	defer span.End()
+
+	fileKey, err := extractS3KeyFromURL(originalURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid S3 URL")
+		return nil, fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+	span.SetAttributes(attribute.String("s3.key", fileKey))
+
+	body, err := s.DownloadFromS3(ctx, fileKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "S3 download failed")
+		return nil, fmt.Errorf("failed to download original image: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	verdict, err := s.scanner.Scan(ctx, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "scan failed")
+		return nil, fmt.Errorf("failed to scan original: %w", err)
+	}
+	span.SetAttributes(
+		attribute.String("malware.scanner", verdict.Scanner),
+		attribute.Bool("malware.infected", verdict.Infected),
+	)
+	return verdict, nil
+}
+
+// QuarantineOriginal copies the original under the quarantine prefix, keeping
+// its key so it can be traced back to the user and project, then deletes it.
+func (s *DefaultService) QuarantineOriginal(ctx context.Context, originalURL string) (string, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.QuarantineOriginal")
+	defer span.End()
+
+	fileKey, err := extractS3KeyFromURL(originalURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid S3 URL")
+		return "", fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+	quarantineKey := s.quarantinePrefix + fileKey
+	span.SetAttributes(
+		attribute.String("s3.key", fileKey),
+		attribute.String("s3.quarantine_key", quarantineKey),
+	)
+
+	copySource := (&url.URL{Path: s.bucketName + "/" + fileKey}).EscapedPath()
+	if _, err := s.s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(s.bucketName),
+		Key:        aws.String(quarantineKey),
+		CopySource: aws.String(copySource),
+	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "CopyObject failed")
+		return "", fmt.Errorf("failed to copy original to quarantine: %w", err)
+	}
+	if _, err := s.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(fileKey),
+	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "DeleteObject failed")
+		return "", fmt.Errorf("failed to delete quarantined original: %w", err)
+	}
+	return fmt.Sprintf("s3://%s/%s", s.bucketName, quarantineKey), nil
+}
+
+// stagedObjectKey returns the key for a staged output. Originals stored under
+// users/<user_id>/projects/<project_id>/ get their staged sibling in the same
+// project prefix so per-tenant lifecycle rules and deletion cover both; other
+// user-scoped originals stay under the user's prefix, and legacy originals keep
+// the flat staged/<id[:8]>/ layout. ext is the file's extension, such as ".jpg".
+func stagedObjectKey(originalKey, imageID, ext string) string {
+	parts := strings.SplitN(originalKey, "/", 5)
+	if len(parts) == 5 && parts[0] == "users" && parts[2] == "projects" {
+		return fmt.Sprintf("users/%s/projects/%s/staged/%s-staged%s", parts[1], parts[3], imageID, ext)
+	}
+	if len(parts) >= 3 && parts[0] == "users" {
+		return fmt.Sprintf("users/%s/staged/%s-staged%s", parts[1], imageID, ext)
+	}
+	return fmt.Sprintf("staged/%s/%s-staged%s", imageID[:8], imageID, ext)
+}
+
+// runPrediction runs a model on its provider to stage an image. A non-empty
+// version runs that exact model version instead of the latest, and a non-empty
+// maskDataURL limits staging to the region it marks. configOverrides are laid
+// over the model's config. Models that run on OpenAI use the image owner's own
+// key when they have stored one.
+// onCreated, if set, receives the prediction ID once the provider accepts it,
+// unless the provider's jobs can't be resumed.
+func (s *DefaultService) runPrediction(
+	ctx context.Context, imageID string, modelID model.ID, version, imageDataURL, maskDataURL, prompt string,
+	seed *int64, configOverrides map[string]interface{}, onCreated func(string),
+) (*stagedPrediction, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.runPrediction")
 	span.SetAttributes(
 		attribute.String("model", string(modelID)),
+		attribute.String("model_version", version),
 		attribute.String("prompt", prompt),
 	)
 	defer span.End()
@@ -332,7 +1026,15 @@ func (s *DefaultService) callReplicateAPI(
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "model not found")
-		return "", fmt.Errorf("failed to get model metadata: %w", err)
+		return nil, fmt.Errorf("failed to get model metadata: %w", err)
+	}
+	span.SetAttributes(attribute.String("provider", string(modelMeta.RunsOn())))
+
+	provider, err := s.provider(modelMeta)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "provider not configured")
+		return nil, err
 	}
 
 	// Load model configuration from database (optional)
@@ -346,120 +1048,321 @@ func (s *DefaultService) callReplicateAPI(
 			modelConfig = nil
 		}
 	}
+	if len(configOverrides) > 0 {
+		overridden, err := model.ApplyOverrides(modelID, modelConfig, configOverrides)
+		if err != nil {
+			log := logging.Default()
+			log.Warn(ctx, "failed to apply model config overrides, ignoring them", "error", err, "model", modelID)
+		} else {
+			modelConfig = overridden
+		}
+	}
+
+	// A failed lookup fails the run rather than billing the job to the key in
+	// the model config.
+	var apiKey string
+	if modelMeta.RunsOn() == model.ProviderOpenAI && s.configRepo != nil {
+		apiKey, err = s.configRepo.GetUserAPIKey(ctx, imageID, model.ProviderOpenAI)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "user API key lookup failed")
+			return nil, fmt.Errorf("failed to get user API key: %w", err)
+		}
+	}
 
 	// Build the input parameters using the model's input builder
 	inputReq := &model.ModelInputRequest{
 		ImageDataURL: imageDataURL,
+		MaskDataURL:  maskDataURL,
 		Prompt:       prompt,
 		Seed:         seed,
 		Config:       modelConfig, // Will use defaults if nil
+		APIKey:       apiKey,
 	}
 
 	input, err := modelMeta.InputBuilder.BuildInput(ctx, inputReq)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "input build failed")
-		return "", fmt.Errorf("failed to build model input: %w", err)
+		return nil, fmt.Errorf("failed to build model input: %w", err)
 	}
 
 	// Create and run the prediction
-	webhook := replicate.Webhook{
-		URL:    "", // No webhook for now
-		Events: []replicate.WebhookEventType{},
+	result, err := provider.CreateJob(ctx, &ProviderJob{ModelID: modelID, Version: version, Input: input})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "CreateJob failed")
+		return nil, err
+	}
+	if onCreated != nil && result.ID != "" {
+		onCreated(result.ID)
 	}
 
-	prediction, err := s.replicateClient.CreatePrediction(ctx, string(modelID), input, &webhook, false)
+	if result.Done {
+		result, err = succeeded(result)
+	} else {
+		result, err = awaitJob(ctx, provider, result.ID, s.timing(modelID))
+	}
 	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "CreatePrediction failed")
-		return "", fmt.Errorf("failed to create prediction: %w", err)
+		span.SetStatus(codes.Error, "prediction failed")
+		return nil, err
+	}
+
+	outputFormat, _ := input["output_format"].(string)
+	span.SetStatus(codes.Ok, "prediction succeeded")
+	return &stagedPrediction{provider: provider, result: result, outputFormat: outputFormat}, nil
+}
+
+// provider returns the provider that runs meta's model.
+func (s *DefaultService) provider(meta *model.ModelMetadata) (Provider, error) {
+	switch meta.RunsOn() {
+	case model.ProviderReplicate:
+		return replicateProvider{s: s}, nil
+	case model.ProviderStability:
+		if s.stability == nil {
+			return nil, fmt.Errorf("model %s runs on Stability AI, which has no API key configured", meta.ID)
+		}
+		return s.stability, nil
+	case model.ProviderOpenAI:
+		return s.openai, nil
+	default:
+		return nil, fmt.Errorf("model %s runs on unknown provider %q", meta.ID, meta.Provider)
+	}
+}
+
+// resumePrediction waits for a prediction started by an earlier attempt. A
+// prediction that already finished is returned without waiting, since its
+// callback will not be delivered again.
+func (s *DefaultService) resumePrediction(
+	ctx context.Context, modelID model.ID, predictionID string,
+) (*stagedPrediction, error) {
+	meta, err := s.registry.Get(modelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model metadata: %w", err)
+	}
+	provider, err := s.provider(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := provider.Poll(ctx, predictionID)
+	if err != nil {
+		return nil, err
+	}
+	if result.Done {
+		result, err = succeeded(result)
+	} else {
+		result, err = awaitJob(ctx, provider, predictionID, s.timing(modelID))
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The job's own config isn't known here; the model's default declares
+	// what it most likely produced.
+	return &stagedPrediction{
+		provider: provider, result: result, outputFormat: declaredOutputFormat(meta.DefaultConfig),
+	}, nil
+}
+
+// timing returns how long to wait for the model's predictions and how often
+// to poll them: the model's own timing with any configured override applied.
+func (s *DefaultService) timing(id model.ID) model.Timing {
+	return s.registry.Timing(id).Override(s.timings[id])
+}
+
+// stagedPrediction is a staging prediction that succeeded.
+type stagedPrediction struct {
+	provider     Provider // Ran the prediction and fetches its output
+	result       *ProviderResult
+	outputFormat string // output_format the model was asked for, if it takes one
+}
+
+// predictionCost prices a prediction with the model's pricing.
+func (s *DefaultService) predictionCost(modelID model.ID, result *ProviderResult) float64 {
+	meta, err := s.registry.Get(modelID)
+	if err != nil {
+		return 0
+	}
+	return meta.Pricing.Cost(result.PredictSeconds)
+}
+
+// predictionOutputURLs extracts the image URLs from a prediction's output,
+// which can be a string URL or an array of URLs when the model was asked for
+// several images. Entries that aren't URLs are skipped.
+func predictionOutputURLs(output interface{}) ([]string, error) {
+	var urls []string
+	switch v := output.(type) {
+	case string:
+		if v != "" {
+			urls = append(urls, v)
+		}
+	case []interface{}:
+		for _, item := range v {
+			if url, ok := item.(string); ok && url != "" {
+				urls = append(urls, url)
+			}
+		}
+	}
+
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("could not extract output URL from prediction")
+	}
+	return urls, nil
+}
+
+// predictionWebhook returns the webhook to attach to new predictions. Without a
+// configured callback URL it is empty and awaitPrediction polls instead.
+func (s *DefaultService) predictionWebhook() *replicate.Webhook {
+	if s.webhookURL == "" {
+		return &replicate.Webhook{URL: "", Events: []replicate.WebhookEventType{}}
 	}
+	return &replicate.Webhook{
+		URL:    s.webhookURL,
+		Events: []replicate.WebhookEventType{replicate.WebhookEventCompleted},
+	}
+}
+
+// awaitPrediction waits for a prediction to finish and returns it once it has
+// succeeded, using Replicate callbacks when configured and polling otherwise.
+// It gives up after timing's timeout.
+func (s *DefaultService) awaitPrediction(
+	ctx context.Context, predictionID string, timing model.Timing,
+) (*replicate.Prediction, error) {
+	if s.webhookURL != "" && s.callbacks != nil {
+		return s.awaitCallback(ctx, predictionID, timing.Timeout)
+	}
+	return s.pollPrediction(ctx, predictionID, timing)
+}
 
+// pollPrediction polls a prediction every timing.PollInterval until it finishes.
+func (s *DefaultService) pollPrediction(
+	ctx context.Context, predictionID string, timing model.Timing,
+) (*replicate.Prediction, error) {
 	// Wait for the prediction to complete (with timeout)
-	ticker := time.NewTicker(2 * time.Second)
+	ticker := time.NewTicker(timing.PollInterval)
 	defer ticker.Stop()
 
-	timeout := time.After(5 * time.Minute)
+	timeout := time.After(timing.Timeout)
 
 	for {
 		select {
 		case <-timeout:
-			err := fmt.Errorf("prediction timed out after 5 minutes")
-			span.RecordError(err)
-			span.SetStatus(codes.Error, "prediction timeout")
-			return "", err
+			return nil, fmt.Errorf("prediction timed out after %s", timing.Timeout)
 
 		case <-ticker.C:
-			pred, err := s.replicateClient.GetPrediction(ctx, prediction.ID)
+			pred, err := s.replicateClient.GetPrediction(ctx, predictionID)
 			if err != nil {
-				span.RecordError(err)
-				span.SetStatus(codes.Error, "GetPrediction failed")
-				return "", fmt.Errorf("failed to get prediction status: %w", err)
+				return nil, fmt.Errorf("failed to get prediction status: %w", err)
 			}
 
-			switch pred.Status {
-			case replicate.Succeeded:
-				// Extract the output URL from the prediction
-				if pred.Output == nil {
-					err := fmt.Errorf("prediction succeeded but output is nil")
-					span.RecordError(err)
-					span.SetStatus(codes.Error, "nil output")
-					return "", err
-				}
+			if _, done, err := predictionOutcome(pred); done {
+				return finished(pred, err)
+			}
+		}
+	}
+}
 
-				// The output can be a string URL or an array of URLs
-				var outputURL string
-				switch v := pred.Output.(type) {
-				case string:
-					outputURL = v
-				case []interface{}:
-					if len(v) > 0 {
-						if url, ok := v[0].(string); ok {
-							outputURL = url
-						}
-					}
-				}
+// awaitCallback waits for Replicate to call back with the finished prediction.
+// A slow safety poll covers callbacks that were lost or routed to another replica.
+func (s *DefaultService) awaitCallback(
+	ctx context.Context, predictionID string, timeout time.Duration,
+) (*replicate.Prediction, error) {
+	ch, release := s.callbacks.wait(predictionID)
+	defer release()
 
-				if outputURL == "" {
-					err := fmt.Errorf("could not extract output URL from prediction")
-					span.RecordError(err)
-					span.SetStatus(codes.Error, "invalid output format")
-					return "", err
-				}
+	ticker := time.NewTicker(callbackSafetyInterval)
+	defer ticker.Stop()
 
-				span.SetStatus(codes.Ok, "prediction succeeded")
-				return outputURL, nil
+	deadline := time.After(timeout)
 
-			case replicate.Failed:
-				err := fmt.Errorf("prediction failed: %v", pred.Error)
-				span.RecordError(err)
-				span.SetStatus(codes.Error, "prediction failed")
-				return "", err
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
 
-			case replicate.Canceled:
-				err := fmt.Errorf("prediction was canceled")
-				span.RecordError(err)
-				span.SetStatus(codes.Error, "prediction canceled")
-				return "", err
+		case <-deadline:
+			return nil, fmt.Errorf("prediction timed out after %s", timeout)
+
+		case pred := <-ch:
+			_, _, err := predictionOutcome(pred)
+			return finished(pred, err)
 
-			case replicate.Processing, replicate.Starting:
-				// Continue polling
+		case <-ticker.C:
+			pred, err := s.replicateClient.GetPrediction(ctx, predictionID)
+			if err != nil {
+				// The callback may still arrive; only polling failures are fatal.
+				logging.Default().Warn(ctx, "safety poll failed", "prediction_id", predictionID, "error", err)
 				continue
+			}
 
-			default:
-				err := fmt.Errorf("unknown prediction status: %s", pred.Status)
-				span.RecordError(err)
-				span.SetStatus(codes.Error, "unknown status")
-				return "", err
+			if _, done, err := predictionOutcome(pred); done {
+				return finished(pred, err)
 			}
 		}
 	}
 }
 
+// finished returns pred unless it failed.
+func finished(pred *replicate.Prediction, err error) (*replicate.Prediction, error) {
+	if err != nil {
+		return nil, err
+	}
+	return pred, nil
+}
+
+// predictionOutcome interprets a prediction's status. done is false while the
+// prediction is still running.
+func predictionOutcome(pred *replicate.Prediction) (output interface{}, done bool, err error) {
+	switch pred.Status {
+	case replicate.Succeeded:
+		if pred.Output == nil {
+			return nil, true, fmt.Errorf("prediction succeeded but output is nil")
+		}
+		return pred.Output, true, nil
+
+	case replicate.Failed:
+		if flaggedNSFW(pred.Error) {
+			return nil, true, fmt.Errorf("prediction failed: %v: %w", pred.Error, errNSFW)
+		}
+		return nil, true, fmt.Errorf("prediction failed: %v", pred.Error)
+
+	case replicate.Canceled:
+		return nil, true, ErrPredictionCanceled
+
+	case replicate.Processing, replicate.Starting:
+		return nil, false, nil
+
+	default:
+		return nil, true, fmt.Errorf("unknown prediction status: %s", pred.Status)
+	}
+}
+
+// ErrPredictionCanceled is returned when the prediction was canceled, which
+// the API does when the image's owner cancels its staging job.
+var ErrPredictionCanceled = errors.New("prediction was canceled")
+
+// errNSFW marks a prediction that failed because the model's safety checker
+// flagged its output.
+var errNSFW = errors.New("output flagged as NSFW")
+
+// flaggedNSFW reports whether a failed prediction's error comes from the
+// model's safety checker. Replicate models word it differently: "NSFW content
+// detected", or "flagged as sensitive" (E005).
+func flaggedNSFW(predErr interface{}) bool {
+	msg := strings.ToLower(fmt.Sprint(predErr))
+	return strings.Contains(msg, "nsfw") || strings.Contains(msg, "flagged as sensitive")
+}
+
 // buildPrompt constructs the AI prompt using the library or custom prompt.
-// If customPrompt is provided, it takes precedence.
-// Otherwise, retrieves the appropriate prompt from the library based on room type and style.
-func (s *DefaultService) buildPrompt(roomType, style, customPrompt *string) string {
+// If customPrompt is provided, it is sanitized and takes precedence.
+// Otherwise uses the admin override when set, then the library prompt for the
+// room type and style. Furniture sizes are adapted to locale, and the prompt
+// to language (see prompt.Library.BuildLocalized). In a declutter mode the
+// prompt first empties the room, and for prompt.ModeBoth then stages it.
+func (s *DefaultService) buildPrompt(
+	roomType, style, customPrompt *string, override, locale, language, mode string,
+) string {
 	// Extract values from pointers, using empty strings as defaults
 	roomTypeStr := ""
 	if roomType != nil {
@@ -476,8 +1379,13 @@ func (s *DefaultService) buildPrompt(roomType, style, customPrompt *string) stri
 		customPromptStr = *customPrompt
 	}
 
+	if prompt.Declutters(mode) {
+		return s.promptLib.BuildDeclutter(roomTypeStr, styleStr, customPromptStr, override, locale, language,
+			mode == prompt.ModeBoth)
+	}
+
 	// Use the prompt library to build the final prompt
-	return s.promptLib.Build(roomTypeStr, styleStr, customPromptStr)
+	return s.promptLib.BuildLocalized(roomTypeStr, styleStr, customPromptStr, override, locale, language)
 }
 
 // downloadFromURL downloads content from an HTTP(S) URL.
@@ -503,7 +1411,11 @@ func (s *DefaultService) downloadFromURL(ctx context.Context, url string) ([]byt
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	return data, nil
 }
 
 // extractS3KeyFromURL extracts the S3 key from a URL.
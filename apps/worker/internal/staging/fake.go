@@ -0,0 +1,61 @@
+package staging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// FakeModelID selects the fake staging provider used by sandbox accounts. It is
+// not in the model registry and never reaches Replicate.
+const FakeModelID model.ID = "sandbox/fake"
+
+// fakeTint is blended over the original so sandbox output is visibly different
+// from the input without resembling real staging.
+var fakeTint = color.RGBA{R: 255, G: 196, B: 120, A: 255}
+
+// fakeStage stands in for a staging model. It tints the original, frames it
+// with a solid border and re-encodes it as JPEG, so integrators exercise the
+// full upload, status and delivery flow with deterministic output.
+func fakeStage(original io.Reader) ([]byte, error) {
+	src, _, err := image.Decode(original)
+	if err != nil {
+		return nil, fmt.Errorf("fake provider: failed to decode original: %w", err)
+	}
+
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+
+	const tintWeight = 64 // out of 256
+	for i := 0; i+3 < len(dst.Pix); i += 4 {
+		dst.Pix[i] = blend(dst.Pix[i], fakeTint.R, tintWeight)
+		dst.Pix[i+1] = blend(dst.Pix[i+1], fakeTint.G, tintWeight)
+		dst.Pix[i+2] = blend(dst.Pix[i+2], fakeTint.B, tintWeight)
+	}
+
+	border := max(1, min(b.Dx(), b.Dy())/50)
+	frame := image.NewUniform(fakeTint)
+	w, h := b.Dx(), b.Dy()
+	draw.Draw(dst, image.Rect(0, 0, w, border), frame, image.Point{}, draw.Src)
+	draw.Draw(dst, image.Rect(0, h-border, w, h), frame, image.Point{}, draw.Src)
+	draw.Draw(dst, image.Rect(0, 0, border, h), frame, image.Point{}, draw.Src)
+	draw.Draw(dst, image.Rect(w-border, 0, w, h), frame, image.Point{}, draw.Src)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, dst, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, fmt.Errorf("fake provider: failed to encode output: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// blend mixes b into a with the given weight out of 256.
+func blend(a, b uint8, weight int) uint8 {
+	return uint8((int(a)*(256-weight) + int(b)*weight) >> 8)
+}
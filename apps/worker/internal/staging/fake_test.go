@@ -0,0 +1,54 @@
+package staging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestFakeStage(t *testing.T) {
+	t.Run("success: tints and frames the original as JPEG", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(0, 0, 200, 100))
+		draw.Draw(src, src.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+		var in bytes.Buffer
+		if err := png.Encode(&in, src); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := fakeStage(bytes.NewReader(in.Bytes()))
+		if err != nil {
+			t.Fatalf("fakeStage() error = %v", err)
+		}
+
+		img, format, err := image.Decode(bytes.NewReader(out))
+		if err != nil {
+			t.Fatalf("decode output: %v", err)
+		}
+		if format != "jpeg" {
+			t.Errorf("format = %q, want jpeg", format)
+		}
+		if img.Bounds().Dx() != 200 || img.Bounds().Dy() != 100 {
+			t.Errorf("bounds = %v, want 200x100", img.Bounds())
+		}
+
+		// The frame is the tint colour; the interior is the original pulled toward it.
+		edge := color.RGBAModel.Convert(img.At(0, 0)).(color.RGBA)
+		if edge.R < 200 {
+			t.Errorf("edge pixel = %+v, want frame colour", edge)
+		}
+		center := color.RGBAModel.Convert(img.At(100, 50)).(color.RGBA)
+		if center.R == 0 || center.R >= edge.R {
+			t.Errorf("center pixel = %+v, want a partial tint", center)
+		}
+	})
+
+	t.Run("fail: not an image", func(t *testing.T) {
+		if _, err := fakeStage(strings.NewReader("not an image")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package staging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// ImageInput selects how images are passed to Replicate models. Models on
+// other providers always get a data URL, which their clients decode.
+type ImageInput string
+
+const (
+	// ImageInputDataURL inlines the image in the prediction request as a
+	// base64 data URL, a third larger than the file.
+	ImageInputDataURL ImageInput = "data_url"
+	// ImageInputFile uploads the image to Replicate's files API and passes
+	// the file's URL. The file is deleted once the prediction is done.
+	ImageInputFile ImageInput = "file"
+	// ImageInputPresigned passes a presigned S3 URL Replicate downloads the
+	// image from. The bucket must be reachable from Replicate, through
+	// S3PublicEndpoint when the worker's own endpoint isn't.
+	ImageInputPresigned ImageInput = "presigned"
+)
+
+// Valid reports whether i is a known image input.
+func (i ImageInput) Valid() bool {
+	switch i {
+	case ImageInputDataURL, ImageInputFile, ImageInputPresigned:
+		return true
+	}
+	return false
+}
+
+// newPresignClient returns a client that presigns requests to client's
+// bucket, against publicEndpoint when it is set.
+func newPresignClient(client *s3.Client, publicEndpoint string) *s3.PresignClient {
+	if publicEndpoint == "" {
+		return s3.NewPresignClient(client)
+	}
+	return s3.NewPresignClient(client, s3.WithPresignClientFromClientOptions(func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(publicEndpoint)
+	}))
+}
+
+// imageInput returns the URL modelID reads file from. fileKey is where file
+// is stored in S3, or empty when file differs from the stored one. fileID is
+// the Replicate file uploaded for the URL, if any; pass it to
+// deleteInputFiles once the prediction is done.
+func (s *DefaultService) imageInput(
+	ctx context.Context, modelID model.ID, fileKey string, file *spooledFile,
+) (url, fileID string, err error) {
+	mimeType, err := file.ContentType()
+	if err != nil {
+		return "", "", err
+	}
+
+	input := s.imageInputMode
+	if meta, err := s.registry.Get(modelID); err == nil && meta.RunsOn() != model.ProviderReplicate {
+		input = ImageInputDataURL
+	}
+	if input == ImageInputPresigned && fileKey == "" {
+		input = ImageInputDataURL
+	}
+
+	switch input {
+	case ImageInputFile:
+		uploaded, err := s.replicateClient.CreateFileFromPath(ctx, file.Name(), &replicate.CreateFileOptions{
+			ContentType: mimeType,
+		})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to upload image to Replicate: %w", err)
+		}
+		if uploaded.URLs["get"] == "" {
+			s.deleteInputFiles(ctx, uploaded.ID)
+			return "", "", fmt.Errorf("replicate file %s has no URL", uploaded.ID)
+		}
+		return uploaded.URLs["get"], uploaded.ID, nil
+	case ImageInputPresigned:
+		// The URL only has to outlive the wait for the prediction.
+		req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(s.bucketName),
+			Key:    aws.String(fileKey),
+		}, s3.WithPresignExpires(s.timing(modelID).Timeout))
+		if err != nil {
+			return "", "", fmt.Errorf("failed to presign image URL: %w", err)
+		}
+		return req.URL, "", nil
+	default:
+		u, err := dataURL(mimeType, file.Reader(), file.Size())
+		return u, "", err
+	}
+}
+
+// deleteInputFiles deletes Replicate files imageInput uploaded. Failures are
+// only logged; an empty ID is skipped.
+func (s *DefaultService) deleteInputFiles(ctx context.Context, fileIDs ...string) {
+	for _, id := range fileIDs {
+		if id == "" {
+			continue
+		}
+		if err := s.replicateClient.DeleteFile(context.WithoutCancel(ctx), id); err != nil {
+			logging.Default().Warn(ctx, "failed to delete Replicate file", "file_id", id, "error", err)
+		}
+	}
+}
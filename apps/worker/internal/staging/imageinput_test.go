@@ -0,0 +1,125 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/replicate/replicate-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestImageInput_Valid(t *testing.T) {
+	assert.True(t, ImageInputDataURL.Valid())
+	assert.True(t, ImageInputFile.Valid())
+	assert.True(t, ImageInputPresigned.Valid())
+	assert.False(t, ImageInput("").Valid())
+	assert.False(t, ImageInput("url").Valid())
+}
+
+// newReplicateFiles returns a client for a fake Replicate files API that
+// records the files deleted from it.
+func newReplicateFiles(t *testing.T, status int) (*replicate.Client, *[]string) {
+	t.Helper()
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/files":
+			w.WriteHeader(status)
+			if status == http.StatusCreated {
+				_, _ = w.Write([]byte(`{"id":"file-1","urls":{"get":"https://api.replicate.com/v1/files/file-1"}}`))
+			}
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/files/"))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := replicate.NewClient(replicate.WithToken("test"), replicate.WithBaseURL(srv.URL),
+		replicate.WithRetryPolicy(0, nil))
+	require.NoError(t, err)
+	return client, &deleted
+}
+
+func TestDefaultService_imageInput(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("\x89PNG\r\n\x1a\n fake png")
+	file, err := spool(bytes.NewReader(data))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	newService := func(t *testing.T, input ImageInput) *DefaultService {
+		s, _ := newTestTransferService(t)
+		s.registry = model.NewModelRegistry()
+		s.imageInputMode = input
+		s.presign = newPresignClient(s.s3Client, "https://files.example.com")
+		return s
+	}
+
+	t.Run("success: data URL", func(t *testing.T) {
+		s := newService(t, ImageInputDataURL)
+
+		u, fileID, err := s.imageInput(ctx, model.ModelQwenImageEdit, "originals/a.png", file)
+		require.NoError(t, err)
+		assert.Empty(t, fileID)
+		assert.Equal(t, "data:image/png;base64,"+base64.StdEncoding.EncodeToString(data), u)
+	})
+
+	t.Run("success: presigned URL expires with the model's timeout", func(t *testing.T) {
+		s := newService(t, ImageInputPresigned)
+
+		u, _, err := s.imageInput(ctx, model.ModelSeedream4, "originals/a.png", file)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(u, "https://files.example.com/bucket/originals/a.png?"), u)
+		assert.Contains(t, u, "X-Amz-Expires=900")
+	})
+
+	t.Run("success: presigned falls back to a data URL for files not in S3", func(t *testing.T) {
+		s := newService(t, ImageInputPresigned)
+
+		u, _, err := s.imageInput(ctx, model.ModelQwenImageEdit, "", file)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(u, "data:image/png;base64,"))
+	})
+
+	t.Run("success: other providers always get a data URL", func(t *testing.T) {
+		s := newService(t, ImageInputFile)
+
+		u, _, err := s.imageInput(ctx, model.ModelStableImageInpaint, "originals/a.png", file)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(u, "data:image/png;base64,"))
+	})
+
+	t.Run("success: Replicate file is deleted once done", func(t *testing.T) {
+		s := newService(t, ImageInputFile)
+		client, deleted := newReplicateFiles(t, http.StatusCreated)
+		s.replicateClient = client
+
+		u, fileID, err := s.imageInput(ctx, model.ModelQwenImageEdit, "originals/a.png", file)
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.replicate.com/v1/files/file-1", u)
+		assert.Equal(t, "file-1", fileID)
+		assert.Empty(t, *deleted)
+
+		s.deleteInputFiles(ctx, "", fileID)
+		assert.Equal(t, []string{"file-1"}, *deleted)
+	})
+
+	t.Run("fail: Replicate file upload error", func(t *testing.T) {
+		s := newService(t, ImageInputFile)
+		client, _ := newReplicateFiles(t, http.StatusInternalServerError)
+		s.replicateClient = client
+
+		_, fileID, err := s.imageInput(ctx, model.ModelQwenImageEdit, "originals/a.png", file)
+		assert.ErrorContains(t, err, "failed to upload image to Replicate")
+		assert.Empty(t, fileID)
+	})
+}
@@ -3,10 +3,12 @@ package model
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 )
 
-// Config defines the interface for model-specific configuration.
+// Config defines the interface for model-specific configuration. The fields,
+// options and ranges each config accepts are described for the admin UI by
+// the shared schemas in github.com/real-staging-ai/api/modelconfig; keep
+// Validate in step with them.
 type Config interface {
 	// ToMap converts the config to a map for Replicate API
 	ToMap() map[string]interface{}
@@ -18,33 +20,6 @@ type Config interface {
 	GetDefaults() Config
 }
 
-// ConfigField defines metadata for a configuration field (used for UI generation).
-type ConfigField struct {
-	Name        string      `json:"name"`
-	Type        string      `json:"type"` // "string", "int", "float", "bool", "array"
-	Title       string      `json:"title,omitempty"`
-	Default     interface{} `json:"default"`
-	Description string      `json:"description"`
-	Options     []string    `json:"options,omitempty"` // For dropdown fields
-	Min         *float64    `json:"min,omitempty"`     // For numeric fields
-	Max         *float64    `json:"max,omitempty"`     // For numeric fields
-	Required    bool        `json:"required"`
-	XOrder      *int        `json:"x_order,omitempty"`
-	Nullable    bool        `json:"nullable,omitempty"`
-	Format      string      `json:"format,omitempty"`
-	WriteOnly   bool        `json:"write_only,omitempty"`
-	Secret      bool        `json:"x_cog_secret,omitempty"`
-	ItemsType   string      `json:"items_type,omitempty"`
-	ItemsFormat string      `json:"items_format,omitempty"`
-}
-
-// ConfigSchema describes the configuration structure for UI generation.
-type ConfigSchema struct {
-	ModelID     ID            `json:"model_id"`
-	DisplayName string        `json:"display_name"`
-	Fields      []ConfigField `json:"fields"`
-}
-
 // QwenConfig contains all Qwen Image Edit model parameters.
 type QwenConfig struct {
 	GoFast        bool   `json:"go_fast"`
@@ -97,252 +72,6 @@ func (c *QwenConfig) GetDefaults() Config {
 	}
 }
 
-func getGPTImageSchema() *ConfigSchema {
-	return &ConfigSchema{
-		ModelID:     ModelGPTImage1,
-		DisplayName: "GPT Image 1",
-		Fields: []ConfigField{
-			{
-				Name:        "openai_api_key",
-				Type:        "string",
-				Title:       "Openai Api Key",
-				Default:     "",
-				Description: "Your OpenAI API key",
-				Required:    true,
-				Format:      "password",
-				WriteOnly:   true,
-				Secret:      true,
-				XOrder:      intPtr(0),
-			},
-			{
-				Name:        "prompt",
-				Type:        "string",
-				Title:       "Prompt",
-				Default:     "",
-				Description: "A text description of the desired image",
-				Required:    true,
-				XOrder:      intPtr(1),
-			},
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Title:       "aspect_ratio",
-				Default:     "1:1",
-				Description: "The aspect ratio of the generated image",
-				Options:     []string{"1:1", "3:2", "2:3"},
-				XOrder:      intPtr(2),
-			},
-			{
-				Name:        "input_fidelity",
-				Type:        "string",
-				Title:       "input_fidelity",
-				Default:     "low",
-				Description: "Control how much effort the model will exert to match the style and features of input images",
-				Options:     []string{"low", "high"},
-				XOrder:      intPtr(3),
-			},
-			{
-				Name:        "input_images",
-				Type:        "array",
-				Title:       "Input Images",
-				Description: "A list of images to use as input for the generation",
-				ItemsType:   "string",
-				ItemsFormat: "uri",
-				Nullable:    true,
-				XOrder:      intPtr(4),
-			},
-			{
-				Name:        "number_of_images",
-				Type:        "int",
-				Title:       "Number Of Images",
-				Default:     1,
-				Description: "Number of images to generate (1-10)",
-				Min:         ptr(1.0),
-				Max:         ptr(10.0),
-				XOrder:      intPtr(5),
-			},
-			{
-				Name:        "quality",
-				Type:        "string",
-				Title:       "quality",
-				Default:     "auto",
-				Description: "The quality of the generated image",
-				Options:     []string{"low", "medium", "high", "auto"},
-				XOrder:      intPtr(6),
-			},
-			{
-				Name:        "background",
-				Type:        "string",
-				Title:       "background",
-				Default:     "auto",
-				Description: "Set whether the background is transparent or opaque or choose automatically",
-				Options:     []string{"auto", "transparent", "opaque"},
-				XOrder:      intPtr(7),
-			},
-			{
-				Name:        "output_compression",
-				Type:        "int",
-				Title:       "Output Compression",
-				Default:     90,
-				Description: "Compression level (0-100%)",
-				Min:         ptr(0.0),
-				Max:         ptr(100.0),
-				XOrder:      intPtr(8),
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Title:       "output_format",
-				Default:     "webp",
-				Description: "Output format",
-				Options:     []string{"png", "jpeg", "webp"},
-				XOrder:      intPtr(9),
-			},
-			{
-				Name:        "moderation",
-				Type:        "string",
-				Title:       "moderation",
-				Default:     "auto",
-				Description: "Content moderation level",
-				Options:     []string{"auto", "low"},
-				XOrder:      intPtr(10),
-			},
-			{
-				Name:  "user_id",
-				Type:  "string",
-				Title: "User Id",
-				Description: "An optional unique identifier representing your end-user. " +
-					"This helps OpenAI monitor and detect abuse.",
-				Nullable: true,
-				XOrder:   intPtr(11),
-			},
-		},
-	}
-}
-
-func getGPTImage15Schema() *ConfigSchema {
-	return &ConfigSchema{
-		ModelID:     ModelGPTImage1_5,
-		DisplayName: "GPT Image 1.5",
-		Fields: []ConfigField{
-			{
-				Name:        "openai_api_key",
-				Type:        "string",
-				Title:       "Openai Api Key",
-				Default:     "",
-				Description: "Your OpenAI API key",
-				Required:    true,
-				Format:      "password",
-				WriteOnly:   true,
-				Secret:      true,
-				XOrder:      intPtr(0),
-			},
-			{
-				Name:        "prompt",
-				Type:        "string",
-				Title:       "Prompt",
-				Default:     "",
-				Description: "A text description of the desired image",
-				Required:    true,
-				XOrder:      intPtr(1),
-			},
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Title:       "aspect_ratio",
-				Default:     "1:1",
-				Description: "The aspect ratio of the generated image",
-				Options:     []string{"1:1", "3:2", "2:3"},
-				XOrder:      intPtr(2),
-			},
-			{
-				Name:        "input_fidelity",
-				Type:        "string",
-				Title:       "input_fidelity",
-				Default:     "low",
-				Description: "Control how much effort the model will exert to match the style and features of input images",
-				Options:     []string{"low", "high"},
-				XOrder:      intPtr(3),
-			},
-			{
-				Name:        "input_images",
-				Type:        "array",
-				Title:       "Input Images",
-				Description: "A list of images to use as input for the generation",
-				ItemsType:   "string",
-				ItemsFormat: "uri",
-				Nullable:    true,
-				XOrder:      intPtr(4),
-			},
-			{
-				Name:        "number_of_images",
-				Type:        "int",
-				Title:       "Number Of Images",
-				Default:     1,
-				Description: "Number of images to generate (1-10)",
-				Min:         ptr(1.0),
-				Max:         ptr(10.0),
-				XOrder:      intPtr(5),
-			},
-			{
-				Name:        "quality",
-				Type:        "string",
-				Title:       "quality",
-				Default:     "auto",
-				Description: "The quality of the generated image",
-				Options:     []string{"low", "medium", "high", "auto"},
-				XOrder:      intPtr(6),
-			},
-			{
-				Name:        "background",
-				Type:        "string",
-				Title:       "background",
-				Default:     "auto",
-				Description: "Set whether the background is transparent or opaque or choose automatically",
-				Options:     []string{"auto", "transparent", "opaque"},
-				XOrder:      intPtr(7),
-			},
-			{
-				Name:        "output_compression",
-				Type:        "int",
-				Title:       "Output Compression",
-				Default:     90,
-				Description: "Compression level (0-100%)",
-				Min:         ptr(0.0),
-				Max:         ptr(100.0),
-				XOrder:      intPtr(8),
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Title:       "output_format",
-				Default:     "webp",
-				Description: "Output format",
-				Options:     []string{"png", "jpeg", "webp"},
-				XOrder:      intPtr(9),
-			},
-			{
-				Name:        "moderation",
-				Type:        "string",
-				Title:       "moderation",
-				Default:     "auto",
-				Description: "Content moderation level",
-				Options:     []string{"auto", "low"},
-				XOrder:      intPtr(10),
-			},
-			{
-				Name:  "user_id",
-				Type:  "string",
-				Title: "User Id",
-				Description: "An optional unique identifier representing your end-user. " +
-					"This helps OpenAI monitor and detect abuse.",
-				Nullable: true,
-				XOrder:   intPtr(11),
-			},
-		},
-	}
-}
-
 // FluxKontextConfig contains all Flux Kontext model parameters.
 type FluxKontextConfig struct {
 	AspectRatio      string `json:"aspect_ratio"`
@@ -466,6 +195,7 @@ func (c *SeedreamConfig) GetDefaults() Config {
 
 // GPTImageConfig contains all GPT Image 1 model parameters.
 type GPTImageConfig struct {
+	// OpenAIAPIKey is used for users who haven't stored their own key.
 	OpenAIAPIKey      string  `json:"openai_api_key"`
 	Prompt            string  `json:"prompt"`
 	AspectRatio       string  `json:"aspect_ratio"`
@@ -503,10 +233,6 @@ func (c *GPTImageConfig) ToMap() map[string]interface{} {
 
 // Validate checks if GPTImageConfig is valid.
 func (c *GPTImageConfig) Validate() error {
-	if strings.TrimSpace(c.OpenAIAPIKey) == "" {
-		return fmt.Errorf("openai_api_key is required")
-	}
-
 	validQuality := []string{"low", "medium", "high", "auto"}
 	if !contains(validQuality, c.Quality) {
 		return fmt.Errorf("invalid quality: %s", c.Quality)
@@ -565,23 +291,60 @@ func (c *GPTImageConfig) GetDefaults() Config {
 	}
 }
 
+// StabilityInpaintConfig contains the Stability inpainting model parameters.
+type StabilityInpaintConfig struct {
+	NegativePrompt string `json:"negative_prompt"`
+	// Strength is how much of the photo the model may repaint, from 0 (none)
+	// to 1 (all of it). It is sent as a uniform inpainting mask.
+	Strength     float64 `json:"strength"`
+	OutputFormat string  `json:"output_format"`
+	Seed         *int64  `json:"seed,omitempty"`
+}
+
+// ToMap converts StabilityInpaintConfig to a map of Stability form fields.
+func (c *StabilityInpaintConfig) ToMap() map[string]interface{} {
+	m := map[string]interface{}{
+		"negative_prompt": c.NegativePrompt,
+		"strength":        c.Strength,
+		"output_format":   c.OutputFormat,
+	}
+	if c.Seed != nil {
+		m["seed"] = *c.Seed
+	}
+	return m
+}
+
+// Validate checks if StabilityInpaintConfig is valid.
+func (c *StabilityInpaintConfig) Validate() error {
+	if c.Strength <= 0 || c.Strength > 1 {
+		return fmt.Errorf("strength must be greater than 0 and at most 1, got %f", c.Strength)
+	}
+
+	validFormats := []string{"png", "jpeg", "webp"}
+	if !contains(validFormats, c.OutputFormat) {
+		return fmt.Errorf("invalid output_format: %s", c.OutputFormat)
+	}
+
+	if c.Seed != nil && (*c.Seed < 0 || *c.Seed > 4294967294) {
+		return fmt.Errorf("seed must be between 0 and 4294967294, got %d", *c.Seed)
+	}
+
+	return nil
+}
+
+// GetDefaults returns StabilityInpaintConfig with default values.
+func (c *StabilityInpaintConfig) GetDefaults() Config {
+	return &StabilityInpaintConfig{
+		Strength:     0.7,
+		OutputFormat: "png",
+	}
+}
+
 // ParseModelConfig parses JSON into the appropriate ModelConfig type.
 func ParseModelConfig(modelID ID, data []byte) (Config, error) {
-	var config Config
-
-	switch modelID {
-	case ModelQwenImageEdit:
-		config = &QwenConfig{}
-	case ModelFluxKontextMax, ModelFluxKontextPro:
-		config = &FluxKontextConfig{}
-	case ModelSeedream3, ModelSeedream4:
-		config = &SeedreamConfig{}
-	case ModelGPTImage1:
-		config = &GPTImageConfig{}
-	case ModelGPTImage1_5:
-		config = &GPTImageConfig{}
-	default:
-		return nil, fmt.Errorf("unknown model ID: %s", modelID)
+	config, err := newConfig(modelID)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := json.Unmarshal(data, config); err != nil {
@@ -595,175 +358,54 @@ func ParseModelConfig(modelID ID, data []byte) (Config, error) {
 	return config, nil
 }
 
-// GetConfigSchema returns the schema for a given model configuration.
-func GetConfigSchema(modelID ID) (*ConfigSchema, error) {
-	switch modelID {
-	case ModelQwenImageEdit:
-		return getQwenSchema(), nil
-	case ModelFluxKontextMax, ModelFluxKontextPro:
-		return getFluxKontextSchema(modelID), nil
-	case ModelSeedream3, ModelSeedream4:
-		return getSeedreamSchema(modelID), nil
-	case ModelGPTImage1:
-		return getGPTImageSchema(), nil
-	case ModelGPTImage1_5:
-		return getGPTImage15Schema(), nil
-	default:
-		return nil, fmt.Errorf("unknown model ID: %s", modelID)
+// ApplyOverrides returns base with the fields in overrides, such as a style
+// preset's, replaced. A nil base starts from the model's defaults. The result
+// is validated like a parsed config, and base is left unchanged.
+func ApplyOverrides(modelID ID, base Config, overrides map[string]interface{}) (Config, error) {
+	config, err := newConfig(modelID)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		base = config.GetDefaults()
 	}
-}
 
-func getQwenSchema() *ConfigSchema {
-	return &ConfigSchema{
-		ModelID:     ModelQwenImageEdit,
-		DisplayName: "Qwen Image Edit",
-		Fields: []ConfigField{
-			{
-				Name:        "go_fast",
-				Type:        "bool",
-				Default:     true,
-				Description: "Enable fast mode for quicker processing",
-				Required:    true,
-			},
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "match_input_image",
-				Description: "Output aspect ratio",
-				Options:     []string{"1:1", "16:9", "4:3", "3:2", "match_input_image"},
-				Required:    true,
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Default:     "webp",
-				Description: "Output image format",
-				Options:     []string{"webp", "png", "jpg"},
-				Required:    true,
-			},
-			{
-				Name:        "output_quality",
-				Type:        "int",
-				Default:     80,
-				Description: "Output image quality (1-100)",
-				Min:         ptr(1.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-		},
+	data, err := json.Marshal(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to copy config: %w", err)
+	}
+	data, err = json.Marshal(overrides)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal overrides: %w", err)
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to apply overrides: %w", err)
 	}
-}
 
-func getFluxKontextSchema(modelID ID) *ConfigSchema {
-	displayName := "Flux Kontext Max"
-	if modelID == ModelFluxKontextPro {
-		displayName = "Flux Kontext Pro"
-	}
-
-	return &ConfigSchema{
-		ModelID:     modelID,
-		DisplayName: displayName,
-		Fields: []ConfigField{
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "match_input_image",
-				Description: "Output aspect ratio",
-				Options:     []string{"1:1", "16:9", "4:3", "3:2", "match_input_image"},
-				Required:    true,
-			},
-			{
-				Name:        "output_format",
-				Type:        "string",
-				Default:     "png",
-				Description: "Output image format",
-				Options:     []string{"webp", "png", "jpg"},
-				Required:    true,
-			},
-			{
-				Name:        "safety_tolerance",
-				Type:        "int",
-				Default:     4,
-				Description: "Safety filter tolerance (1=strict, 6=permissive)",
-				Min:         ptr(1.0),
-				Max:         ptr(6.0),
-				Required:    true,
-			},
-			{
-				Name:        "prompt_upsampling",
-				Type:        "bool",
-				Default:     false,
-				Description: "Enhance prompts automatically",
-				Required:    true,
-			},
-			{
-				Name:        "num_outputs",
-				Type:        "int",
-				Default:     1,
-				Description: "Number of images to generate",
-				Min:         ptr(1.0),
-				Max:         ptr(4.0),
-				Required:    true,
-			},
-			{
-				Name:        "output_quality",
-				Type:        "int",
-				Default:     90,
-				Description: "Output image quality (1-100)",
-				Min:         ptr(1.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-		},
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	return config, nil
 }
 
-func getSeedreamSchema(modelID ID) *ConfigSchema {
-	displayName := "Seedream 3"
-	if modelID == ModelSeedream4 {
-		displayName = "Seedream 4"
-	}
-
-	return &ConfigSchema{
-		ModelID:     modelID,
-		DisplayName: displayName,
-		Fields: []ConfigField{
-			{
-				Name:        "aspect_ratio",
-				Type:        "string",
-				Default:     "1:1",
-				Description: "Output aspect ratio",
-				Options:     []string{"1:1", "16:9", "4:3", "3:2"},
-				Required:    true,
-			},
-			{
-				Name:        "num_inference_steps",
-				Type:        "int",
-				Default:     50,
-				Description: "Number of denoising steps (more = higher quality, slower)",
-				Min:         ptr(20.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-			{
-				Name:        "guidance_scale",
-				Type:        "float",
-				Default:     7.5,
-				Description: "How closely to follow the prompt (1.0-20.0)",
-				Min:         ptr(1.0),
-				Max:         ptr(20.0),
-				Required:    true,
-			},
-			{
-				Name:        "output_quality",
-				Type:        "int",
-				Default:     95,
-				Description: "Output image quality (1-100)",
-				Min:         ptr(1.0),
-				Max:         ptr(100.0),
-				Required:    true,
-			},
-		},
+// newConfig returns an empty config of the type modelID takes.
+func newConfig(modelID ID) (Config, error) {
+	switch modelID {
+	case ModelQwenImageEdit:
+		return &QwenConfig{}, nil
+	case ModelFluxKontextMax, ModelFluxKontextPro:
+		return &FluxKontextConfig{}, nil
+	case ModelSeedream3, ModelSeedream4:
+		return &SeedreamConfig{}, nil
+	case ModelGPTImage1, ModelGPTImage1_5:
+		return &GPTImageConfig{}, nil
+	case ModelStableImageInpaint:
+		return &StabilityInpaintConfig{}, nil
+	default:
+		return nil, fmt.Errorf("unknown model ID: %s", modelID)
 	}
 }
 
@@ -777,11 +419,3 @@ func contains(slice []string, item string) bool {
 	}
 	return false
 }
-
-func ptr(f float64) *float64 {
-	return &f
-}
-
-func intPtr(i int) *int {
-	return &i
-}
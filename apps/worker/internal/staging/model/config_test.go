@@ -0,0 +1,194 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/real-staging-ai/api/modelconfig"
+)
+
+// TestConfig_matchesSchema checks every registered model's config validation
+// against the schema the admin UI builds its form from and the API validates
+// submitted configs with, so neither can let through a value the worker
+// rejects or turn away one it accepts.
+func TestConfig_matchesSchema(t *testing.T) {
+	for _, m := range NewModelRegistry().List() {
+		t.Run(string(m.ID), func(t *testing.T) {
+			schema, err := modelconfig.Get(string(m.ID))
+			if err != nil {
+				t.Fatalf("no schema: %v", err)
+			}
+
+			defaults := schemaDefaults(schema)
+			if err := parseConfigMap(m.ID, defaults); err != nil {
+				t.Fatalf("schema defaults rejected: %v", err)
+			}
+
+			cfg, _ := ParseModelConfig(m.ID, mustMarshal(t, defaults))
+			var keys map[string]json.RawMessage
+			if err := json.Unmarshal(mustMarshal(t, cfg), &keys); err != nil {
+				t.Fatalf("unmarshal config: %v", err)
+			}
+			fields := map[string]bool{}
+			for _, f := range schema.Fields {
+				fields[f.Name] = true
+				if _, ok := keys[f.Name]; !ok {
+					t.Errorf("schema field %s isn't in the worker config", f.Name)
+				}
+			}
+			for k := range keys {
+				// The seed is set per job, not configured.
+				if !fields[k] && k != "seed" {
+					t.Errorf("worker config field %s isn't in the schema", k)
+				}
+			}
+
+			for _, f := range schema.Fields {
+				checkField(t, m.ID, schema, defaults, f)
+			}
+		})
+	}
+}
+
+// checkField checks the field's options, range and required flag against
+// ParseModelConfig and the schema's own validation, changing only that field
+// from the defaults.
+func checkField(
+	t *testing.T, id ID, schema *modelconfig.Schema, defaults map[string]interface{}, f modelconfig.Field,
+) {
+	t.Helper()
+	with := func(v interface{}) map[string]interface{} {
+		cfg := make(map[string]interface{}, len(defaults))
+		for k, d := range defaults {
+			cfg[k] = d
+		}
+		if v == nil {
+			delete(cfg, f.Name)
+		} else {
+			cfg[f.Name] = v
+		}
+		return cfg
+	}
+	accept := func(v interface{}) {
+		if err := parseConfigMap(id, with(v)); err != nil {
+			t.Errorf("%s=%v rejected: %v", f.Name, v, err)
+		}
+		if err := schema.Validate(with(v)); err != nil {
+			t.Errorf("%s=%v rejected by the schema: %v", f.Name, v, err)
+		}
+	}
+	reject := func(v interface{}) {
+		if err := parseConfigMap(id, with(v)); err == nil {
+			t.Errorf("%s=%v accepted", f.Name, v)
+		}
+		if err := schema.Validate(with(v)); err == nil {
+			t.Errorf("%s=%v accepted by the schema", f.Name, v)
+		}
+	}
+
+	for _, opt := range f.Options {
+		accept(opt)
+	}
+	if len(f.Options) > 0 {
+		reject("not-an-option")
+	}
+
+	step := 1.0
+	if f.Type == "float" {
+		step = 0.01
+	}
+	if f.Min != nil {
+		accept(*f.Min)
+		reject(*f.Min - step)
+	}
+	if f.Max != nil {
+		accept(*f.Max)
+		reject(*f.Max + step)
+	}
+
+	switch {
+	case !f.Required:
+		accept(nil)
+	case f.Type == "string" && len(f.Options) == 0:
+		reject("")
+	}
+}
+
+// schemaDefaults returns a config with every field at its schema default.
+// Secrets have no usable default, so they get a placeholder.
+func schemaDefaults(schema *modelconfig.Schema) map[string]interface{} {
+	cfg := make(map[string]interface{}, len(schema.Fields))
+	for _, f := range schema.Fields {
+		cfg[f.Name] = f.Default
+		if f.Secret {
+			cfg[f.Name] = "sk-test"
+		}
+	}
+	return cfg
+}
+
+func parseConfigMap(id ID, cfg map[string]interface{}) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	_, err = ParseModelConfig(id, data)
+	return err
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return data
+}
+
+func TestApplyOverrides(t *testing.T) {
+	t.Run("success: overrides the admin config", func(t *testing.T) {
+		base := &FluxKontextConfig{
+			AspectRatio: "16:9", OutputFormat: "webp", SafetyTolerance: 2, NumOutputs: 1, OutputQuality: 80,
+		}
+
+		got, err := ApplyOverrides(ModelFluxKontextMax, base, map[string]interface{}{
+			"output_format": "png", "output_quality": 100.0,
+		})
+		if err != nil {
+			t.Fatalf("ApplyOverrides() error = %v", err)
+		}
+		want := &FluxKontextConfig{
+			AspectRatio: "16:9", OutputFormat: "png", SafetyTolerance: 2, NumOutputs: 1, OutputQuality: 100,
+		}
+		if *got.(*FluxKontextConfig) != *want {
+			t.Errorf("ApplyOverrides() = %+v, want %+v", got, want)
+		}
+		if base.OutputFormat != "webp" {
+			t.Errorf("base changed: %+v", base)
+		}
+	})
+
+	t.Run("success: no admin config starts from the defaults", func(t *testing.T) {
+		got, err := ApplyOverrides(ModelQwenImageEdit, nil, map[string]interface{}{"output_quality": 95.0})
+		if err != nil {
+			t.Fatalf("ApplyOverrides() error = %v", err)
+		}
+		cfg := got.(*QwenConfig)
+		if cfg.OutputQuality != 95 || cfg.OutputFormat != "webp" || !cfg.GoFast {
+			t.Errorf("ApplyOverrides() = %+v", cfg)
+		}
+	})
+
+	t.Run("fail: invalid override", func(t *testing.T) {
+		_, err := ApplyOverrides(ModelQwenImageEdit, nil, map[string]interface{}{"output_format": "gif"})
+		if err == nil || err.Error() != "invalid config: invalid output_format: gif" {
+			t.Errorf("ApplyOverrides() error = %v", err)
+		}
+	})
+
+	t.Run("fail: unknown model", func(t *testing.T) {
+		if _, err := ApplyOverrides("acme/unknown", nil, map[string]interface{}{"steps": 4.0}); err == nil {
+			t.Error("expected an error for an unknown model")
+		}
+	})
+}
@@ -37,8 +37,10 @@ func (b *GPTImageInputBuilder) BuildInput(
 		return nil, fmt.Errorf("invalid config type for GPT Image model")
 	}
 
-	if strings.TrimSpace(gptConfig.OpenAIAPIKey) == "" {
-		return nil, fmt.Errorf("openai_api_key is required")
+	// The provider rejects a job without a key, so none is required here.
+	apiKey := gptConfig.OpenAIAPIKey
+	if req.APIKey != "" {
+		apiKey = req.APIKey
 	}
 
 	prompt := strings.TrimSpace(req.Prompt)
@@ -50,7 +52,7 @@ func (b *GPTImageInputBuilder) BuildInput(
 	}
 
 	input := replicate.PredictionInput{
-		"openai_api_key":     gptConfig.OpenAIAPIKey,
+		"openai_api_key":     apiKey,
 		"prompt":             prompt,
 		"quality":            gptConfig.Quality,
 		"aspect_ratio":       gptConfig.AspectRatio,
@@ -0,0 +1,15 @@
+package model
+
+// Pricing is what a provider charges for one prediction. On Replicate,
+// official models are billed per output image and community models by the
+// second of compute; a model may have both.
+type Pricing struct {
+	PerImageUSD  float64
+	PerSecondUSD float64
+}
+
+// Cost returns the charge for one staged image whose prediction ran for
+// predictSeconds.
+func (p Pricing) Cost(predictSeconds float64) float64 {
+	return p.PerImageUSD + p.PerSecondUSD*predictSeconds
+}
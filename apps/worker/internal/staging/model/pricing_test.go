@@ -0,0 +1,25 @@
+package model
+
+import "testing"
+
+func TestPricing_Cost(t *testing.T) {
+	tests := []struct {
+		name    string
+		pricing Pricing
+		seconds float64
+		want    float64
+	}{
+		{name: "success: per image", pricing: Pricing{PerImageUSD: 0.04}, seconds: 9, want: 0.04},
+		{name: "success: per second", pricing: Pricing{PerSecondUSD: 0.002}, seconds: 4, want: 0.008},
+		{name: "success: both", pricing: Pricing{PerImageUSD: 0.01, PerSecondUSD: 0.001}, seconds: 5, want: 0.015},
+		{name: "success: free", seconds: 9, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pricing.Cost(tt.seconds); got != tt.want {
+				t.Errorf("Cost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/replicate/replicate-go"
 )
@@ -19,14 +20,36 @@ const (
 	ModelSeedream4      ID = "bytedance/seedream-4"
 	ModelGPTImage1      ID = "openai/gpt-image-1"
 	ModelGPTImage1_5    ID = "openai/gpt-image-1.5"
+
+	ModelStableImageInpaint ID = "stability-ai/stable-image-inpaint"
+)
+
+// Provider names the API that runs a model's predictions.
+type Provider string
+
+const (
+	// ProviderReplicate runs predictions on Replicate.
+	ProviderReplicate Provider = "replicate"
+	// ProviderStability runs predictions on Stability AI's REST API.
+	ProviderStability Provider = "stability"
+	// ProviderOpenAI runs predictions on the OpenAI Images API.
+	ProviderOpenAI Provider = "openai"
 )
 
 // ModelInputRequest contains the parameters needed to build model input.
 type ModelInputRequest struct {
+	// ImageDataURL is the photo to stage as a data URL. Replicate models may
+	// get an HTTPS URL instead (see staging.ImageInput).
 	ImageDataURL string
-	Prompt       string
-	Seed         *int64
-	Config       Config // Optional: model-specific configuration (uses defaults if nil)
+	// MaskDataURL is a PNG mask, white where the model may repaint. Only
+	// inpainting models use it; empty stages the whole photo.
+	MaskDataURL string
+	Prompt      string
+	Seed        *int64
+	Config      Config // Optional: model-specific configuration (uses defaults if nil)
+	// APIKey is the user's own key for the model's provider. When set it
+	// replaces the key in Config.
+	APIKey string
 }
 
 // ModelInputBuilder defines the interface for building model-specific input parameters.
@@ -42,12 +65,22 @@ type ModelInputBuilder interface {
 
 // ModelMetadata contains information about a registered model.
 type ModelMetadata struct {
-	ID            ID
-	Name          string
-	Description   string
+	ID          ID
+	Name        string
+	Description string
+	// Version is descriptive only. Jobs run the version pinned in
+	// model_version_pins, or the latest upstream version if the model isn't pinned.
 	Version       string
 	InputBuilder  ModelInputBuilder
 	DefaultConfig Config // Default configuration for this model
+	// Provider runs the model's predictions. Empty means ProviderReplicate.
+	Provider Provider
+	// Pricing is what the provider charges per prediction. PerImageUSD should
+	// match the model's list price in the models table.
+	Pricing Pricing
+	// Timing is how long the model's predictions are waited for and how
+	// often they are polled. Zero fields use the defaults.
+	Timing Timing
 }
 
 // ModelRegistry manages the available AI models and their configurations.
@@ -69,6 +102,7 @@ func NewModelRegistry() *ModelRegistry {
 		Version:       "latest",
 		InputBuilder:  NewQwenInputBuilder(),
 		DefaultConfig: (&QwenConfig{}).GetDefaults(),
+		Pricing:       Pricing{PerImageUSD: 0.03},
 	})
 
 	// Register Flux Kontext Max model
@@ -79,6 +113,8 @@ func NewModelRegistry() *ModelRegistry {
 		Version:       "latest",
 		InputBuilder:  NewFluxKontextInputBuilder(),
 		DefaultConfig: (&FluxKontextConfig{}).GetDefaults(),
+		Pricing:       Pricing{PerImageUSD: 0.08},
+		Timing:        Timing{Timeout: 10 * time.Minute, PollInterval: 3 * time.Second},
 	})
 
 	// Register Flux Kontext Pro model
@@ -89,6 +125,7 @@ func NewModelRegistry() *ModelRegistry {
 		Version:       "latest",
 		InputBuilder:  NewFluxKontextInputBuilder(),
 		DefaultConfig: (&FluxKontextConfig{}).GetDefaults(),
+		Pricing:       Pricing{PerImageUSD: 0.04},
 	})
 
 	// Register Seedream models
@@ -99,6 +136,7 @@ func NewModelRegistry() *ModelRegistry {
 		Version:       "latest",
 		InputBuilder:  NewSeedreamInputBuilder(),
 		DefaultConfig: (&SeedreamConfig{}).GetDefaults(),
+		Pricing:       Pricing{PerImageUSD: 0.03},
 	})
 
 	registry.Register(&ModelMetadata{
@@ -108,16 +146,23 @@ func NewModelRegistry() *ModelRegistry {
 		Version:       "latest",
 		InputBuilder:  NewSeedreamInputBuilder(),
 		DefaultConfig: (&SeedreamConfig{}).GetDefaults(),
+		Pricing:       Pricing{PerImageUSD: 0.03},
+		// 4K outputs can take well over the default five minutes.
+		Timing: Timing{Timeout: 15 * time.Minute, PollInterval: 5 * time.Second},
 	})
 
+	// The GPT Image models run on the OpenAI Images API with the user's own
+	// OpenAI key, so they cost nothing here.
+
 	// Register GPT Image 1 model
 	registry.Register(&ModelMetadata{
 		ID:            ModelGPTImage1,
 		Name:          "GPT Image 1",
 		Description:   "OpenAI's GPT Image 1 model providing multimodal image generation",
-		Version:       "5ac56c15446a60fa63b3823de926ada90f5971c2cf9b1dd07659126cfda434e6",
+		Version:       "gpt-image-1",
 		InputBuilder:  NewGPTImageInputBuilder(),
 		DefaultConfig: (&GPTImageConfig{}).GetDefaults(),
+		Provider:      ProviderOpenAI,
 	})
 
 	// Register GPT Image 1.5 model
@@ -128,11 +173,42 @@ func NewModelRegistry() *ModelRegistry {
 		Version:       "gpt-image-1.5",
 		InputBuilder:  NewGPTImageInputBuilder(),
 		DefaultConfig: (&GPTImageConfig{}).GetDefaults(),
+		Provider:      ProviderOpenAI,
+	})
+
+	// Register Stable Image Inpaint, which runs on Stability AI rather than
+	// Replicate
+	registry.Register(&ModelMetadata{
+		ID:            ModelStableImageInpaint,
+		Name:          "Stable Image Inpaint",
+		Description:   "Stability AI's inpainting model, run on Stability's own API",
+		Version:       "v2beta",
+		InputBuilder:  NewStabilityInpaintInputBuilder(),
+		DefaultConfig: (&StabilityInpaintConfig{}).GetDefaults(),
+		Provider:      ProviderStability,
+		Pricing:       Pricing{PerImageUSD: 0.03},
 	})
 
 	return registry
 }
 
+// RunsOn returns the provider that runs the model.
+func (m *ModelMetadata) RunsOn() Provider {
+	if m.Provider == "" {
+		return ProviderReplicate
+	}
+	return m.Provider
+}
+
+// Timing returns the timing of the model's predictions, with defaults for
+// unset fields and for models that aren't registered.
+func (r *ModelRegistry) Timing(id ID) Timing {
+	if model, exists := r.models[id]; exists {
+		return model.Timing.OrDefault()
+	}
+	return Timing{}.OrDefault()
+}
+
 // Register adds a model to the registry.
 func (r *ModelRegistry) Register(metadata *ModelMetadata) {
 	r.models[metadata.ID] = metadata
@@ -1,7 +1,9 @@
 package model
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewModelRegistry(t *testing.T) {
@@ -32,14 +34,29 @@ func TestNewModelRegistry(t *testing.T) {
 		if !registry.Exists(ModelSeedream4) {
 			t.Error("expected Seedream-4 model to be registered")
 		}
+
+		// Verify the Stability and OpenAI models run on their own APIs and the
+		// rest on Replicate
+		for _, m := range registry.List() {
+			want := ProviderReplicate
+			switch {
+			case m.ID == ModelStableImageInpaint:
+				want = ProviderStability
+			case strings.HasPrefix(string(m.ID), "openai/"):
+				want = ProviderOpenAI
+			}
+			if m.RunsOn() != want {
+				t.Errorf("expected %s to run on %s, got %s", m.ID, want, m.RunsOn())
+			}
+		}
 	})
 
 	t.Run("success: registry has correct model count", func(t *testing.T) {
 		registry := NewModelRegistry()
 
 		models := registry.List()
-		if len(models) != 7 {
-			t.Errorf("expected 7 models to be registered, got %d", len(models))
+		if len(models) != 8 {
+			t.Errorf("expected 8 models to be registered, got %d", len(models))
 		}
 	})
 }
@@ -194,8 +211,8 @@ func TestModelRegistry_List(t *testing.T) {
 
 		models := registry.List()
 
-		if len(models) != 7 {
-			t.Errorf("expected 7 models to be registered, got %d", len(models))
+		if len(models) != 8 {
+			t.Errorf("expected 8 models to be registered, got %d", len(models))
 		}
 
 		// Verify all models are in the list
@@ -315,3 +332,37 @@ func TestModelRegistry_Exists(t *testing.T) {
 		}
 	})
 }
+
+func TestModelRegistry_Timing(t *testing.T) {
+	registry := NewModelRegistry()
+
+	tests := []struct {
+		name string
+		id   ID
+		want Timing
+	}{
+		{
+			name: "success: slow model waits longer",
+			id:   ModelSeedream4,
+			want: Timing{Timeout: 15 * time.Minute, PollInterval: 5 * time.Second},
+		},
+		{
+			name: "success: model without timing uses the defaults",
+			id:   ModelSeedream3,
+			want: Timing{Timeout: DefaultTimeout, PollInterval: DefaultPollInterval},
+		},
+		{
+			name: "success: unknown model uses the defaults",
+			id:   ID("nonexistent/model"),
+			want: Timing{Timeout: DefaultTimeout, PollInterval: DefaultPollInterval},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := registry.Timing(tt.id); got != tt.want {
+				t.Errorf("Timing() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/replicate/replicate-go"
+)
+
+// StabilityInpaintInputBuilder builds input parameters for Stability AI's
+// inpainting model. The Stability provider sends them as form fields.
+type StabilityInpaintInputBuilder struct{}
+
+// Ensure StabilityInpaintInputBuilder implements ModelInputBuilder.
+var _ ModelInputBuilder = (*StabilityInpaintInputBuilder)(nil)
+
+// NewStabilityInpaintInputBuilder creates a new StabilityInpaintInputBuilder.
+func NewStabilityInpaintInputBuilder() *StabilityInpaintInputBuilder {
+	return &StabilityInpaintInputBuilder{}
+}
+
+// BuildInput creates the input parameters for the Stability inpainting model.
+func (b *StabilityInpaintInputBuilder) BuildInput(
+	ctx context.Context, req *ModelInputRequest,
+) (replicate.PredictionInput, error) {
+	if err := b.Validate(req); err != nil {
+		return nil, err
+	}
+
+	// Use provided config or fall back to defaults
+	config := req.Config
+	if config == nil {
+		config = (&StabilityInpaintConfig{}).GetDefaults()
+	}
+
+	stabilityConfig, ok := config.(*StabilityInpaintConfig)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type for Stability inpaint model")
+	}
+
+	input := replicate.PredictionInput{
+		"image":         req.ImageDataURL,
+		"prompt":        req.Prompt,
+		"strength":      stabilityConfig.Strength,
+		"output_format": stabilityConfig.OutputFormat,
+	}
+	if stabilityConfig.NegativePrompt != "" {
+		input["negative_prompt"] = stabilityConfig.NegativePrompt
+	}
+	if req.MaskDataURL != "" {
+		input["mask"] = req.MaskDataURL
+	}
+
+	// Seed from config takes precedence over request seed
+	if stabilityConfig.Seed != nil {
+		input["seed"] = *stabilityConfig.Seed
+	} else if req.Seed != nil {
+		input["seed"] = *req.Seed
+	}
+
+	return input, nil
+}
+
+// Validate checks if the request is valid for the Stability inpainting model.
+func (b *StabilityInpaintInputBuilder) Validate(req *ModelInputRequest) error {
+	if req == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+	if req.ImageDataURL == "" {
+		return fmt.Errorf("image data URL is required")
+	}
+	if req.Prompt == "" {
+		return fmt.Errorf("prompt is required")
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStabilityInpaintInputBuilder_BuildInput(t *testing.T) {
+	t.Run("success: builds input with defaults", func(t *testing.T) {
+		builder := NewStabilityInpaintInputBuilder()
+		req := &ModelInputRequest{
+			ImageDataURL: "data:image/png;base64,test",
+			Prompt:       "Modern living room with minimalist furniture",
+		}
+
+		input, err := builder.BuildInput(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if input["image"] != req.ImageDataURL {
+			t.Errorf("expected image %q, got %q", req.ImageDataURL, input["image"])
+		}
+		if input["prompt"] != req.Prompt {
+			t.Errorf("expected prompt %q, got %q", req.Prompt, input["prompt"])
+		}
+		if input["strength"] != 0.7 {
+			t.Errorf("expected strength to be 0.7, got %v", input["strength"])
+		}
+		if input["output_format"] != "png" {
+			t.Errorf("expected output_format to be 'png', got %v", input["output_format"])
+		}
+		if _, ok := input["negative_prompt"]; ok {
+			t.Error("expected negative_prompt to be omitted")
+		}
+		if _, ok := input["seed"]; ok {
+			t.Error("expected seed to be omitted")
+		}
+		if _, ok := input["mask"]; ok {
+			t.Error("expected mask to be omitted")
+		}
+	})
+
+	t.Run("success: config seed takes precedence over request seed", func(t *testing.T) {
+		builder := NewStabilityInpaintInputBuilder()
+		configSeed, requestSeed := int64(7), int64(42)
+		req := &ModelInputRequest{
+			ImageDataURL: "data:image/png;base64,test",
+			Prompt:       "Add modern furniture",
+			Seed:         &requestSeed,
+			Config: &StabilityInpaintConfig{
+				NegativePrompt: "clutter",
+				Strength:       0.5,
+				OutputFormat:   "webp",
+				Seed:           &configSeed,
+			},
+		}
+
+		input, err := builder.BuildInput(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if input["seed"] != int64(7) {
+			t.Errorf("expected seed 7, got %v", input["seed"])
+		}
+		if input["negative_prompt"] != "clutter" || input["strength"] != 0.5 || input["output_format"] != "webp" {
+			t.Errorf("unexpected input: %v", input)
+		}
+	})
+
+	t.Run("success: passes the job's mask", func(t *testing.T) {
+		builder := NewStabilityInpaintInputBuilder()
+		req := &ModelInputRequest{
+			ImageDataURL: "data:image/png;base64,test",
+			MaskDataURL:  "data:image/png;base64,mask",
+			Prompt:       "Add a sofa",
+		}
+
+		input, err := builder.BuildInput(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if input["mask"] != req.MaskDataURL {
+			t.Errorf("expected mask %q, got %v", req.MaskDataURL, input["mask"])
+		}
+	})
+
+	t.Run("fail: wrong config type", func(t *testing.T) {
+		builder := NewStabilityInpaintInputBuilder()
+		req := &ModelInputRequest{
+			ImageDataURL: "data:image/png;base64,test",
+			Prompt:       "Add modern furniture",
+			Config:       &QwenConfig{},
+		}
+
+		if _, err := builder.BuildInput(context.Background(), req); err == nil {
+			t.Fatal("expected error for wrong config type")
+		}
+	})
+
+	t.Run("fail: empty image", func(t *testing.T) {
+		builder := NewStabilityInpaintInputBuilder()
+
+		_, err := builder.BuildInput(context.Background(), &ModelInputRequest{Prompt: "Add modern furniture"})
+		if err == nil || err.Error() != "image data URL is required" {
+			t.Fatalf("expected image data URL error, got %v", err)
+		}
+	})
+}
+
+func TestStabilityInpaintConfig_Validate(t *testing.T) {
+	seed := int64(-1)
+	tests := []struct {
+		name    string
+		config  *StabilityInpaintConfig
+		wantErr bool
+	}{
+		{name: "success: defaults", config: (&StabilityInpaintConfig{}).GetDefaults().(*StabilityInpaintConfig)},
+		{name: "fail: zero strength", config: &StabilityInpaintConfig{Strength: 0, OutputFormat: "png"}, wantErr: true},
+		{name: "fail: strength above 1", config: &StabilityInpaintConfig{Strength: 1.5, OutputFormat: "png"}, wantErr: true},
+		{name: "fail: unknown format", config: &StabilityInpaintConfig{Strength: 0.5, OutputFormat: "jpg"}, wantErr: true},
+		{
+			name:    "fail: negative seed",
+			config:  &StabilityInpaintConfig{Strength: 0.5, OutputFormat: "png", Seed: &seed},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// Defaults for models without their own Timing.
+const (
+	DefaultTimeout      = 5 * time.Minute
+	DefaultPollInterval = 2 * time.Second
+)
+
+// Timing is how long the worker waits for a model's prediction before giving
+// up on it, and how often it polls the prediction meanwhile. Models that take
+// longer, like 4K Seedream, get a longer timeout and are polled less often.
+type Timing struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+}
+
+// Override returns t with the non-zero fields of o.
+func (t Timing) Override(o Timing) Timing {
+	if o.Timeout > 0 {
+		t.Timeout = o.Timeout
+	}
+	if o.PollInterval > 0 {
+		t.PollInterval = o.PollInterval
+	}
+	return t
+}
+
+// OrDefault returns t with zero fields set to DefaultTimeout and
+// DefaultPollInterval.
+func (t Timing) OrDefault() Timing {
+	return Timing{Timeout: DefaultTimeout, PollInterval: DefaultPollInterval}.Override(t)
+}
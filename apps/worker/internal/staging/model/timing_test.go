@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTiming_OrDefault(t *testing.T) {
+	tests := []struct {
+		name   string
+		timing Timing
+		want   Timing
+	}{
+		{name: "success: defaults", want: Timing{Timeout: DefaultTimeout, PollInterval: DefaultPollInterval}},
+		{
+			name:   "success: keeps set fields",
+			timing: Timing{Timeout: 15 * time.Minute},
+			want:   Timing{Timeout: 15 * time.Minute, PollInterval: DefaultPollInterval},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.timing.OrDefault(); got != tt.want {
+				t.Errorf("OrDefault() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTiming_Override(t *testing.T) {
+	base := Timing{Timeout: 15 * time.Minute, PollInterval: 5 * time.Second}
+	got := base.Override(Timing{PollInterval: time.Second})
+	want := Timing{Timeout: 15 * time.Minute, PollInterval: time.Second}
+	if got != want {
+		t.Errorf("Override() = %+v, want %+v", got, want)
+	}
+}
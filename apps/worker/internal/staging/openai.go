@@ -0,0 +1,200 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// DefaultOpenAIBaseURL is OpenAI's REST API.
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// openAIEditsPath is the Images API edit endpoint. It answers with the
+// finished image, so its jobs are done as soon as they are created.
+const openAIEditsPath = "/images/edits"
+
+// maxOpenAIResponse bounds the JSON (with a base64 image) read from OpenAI.
+const maxOpenAIResponse = 64 << 20
+
+// openAISizes maps the GPT Image aspect ratios to Images API sizes.
+var openAISizes = map[string]string{
+	"1:1": "1024x1024",
+	"3:2": "1536x1024",
+	"2:3": "1024x1536",
+}
+
+// openAIProvider calls the OpenAI Images API directly, with the API key the
+// model's config brings, instead of running GPT Image models on Replicate.
+type openAIProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// Ensure openAIProvider implements Provider.
+var _ Provider = (*openAIProvider)(nil)
+
+// newOpenAIProvider creates an OpenAI provider. An empty baseURL uses
+// DefaultOpenAIBaseURL.
+func newOpenAIProvider(baseURL string) *openAIProvider {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+	return &openAIProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: model.DefaultTimeout},
+	}
+}
+
+// openAIResponse is the Images API's answer to an edit request.
+type openAIResponse struct {
+	Data []struct {
+		B64JSON string `json:"b64_json"`
+	} `json:"data"`
+}
+
+// openAIError is the body of an OpenAI error response.
+type openAIError struct {
+	Error struct {
+		Message string `json:"message"`
+		Code    string `json:"code"`
+	} `json:"error"`
+}
+
+// CreateJob edits the input image and returns the finished job. The worker
+// keeps one image, so one is requested whatever number_of_images says.
+func (p *openAIProvider) CreateJob(ctx context.Context, job *ProviderJob) (*ProviderResult, error) {
+	apiKey, _ := job.Input["openai_api_key"].(string)
+	if strings.TrimSpace(apiKey) == "" {
+		return nil, errors.New("no OpenAI API key: add your own in account settings, " +
+			"or ask an admin to set openai_api_key in the model config")
+	}
+	body, contentType, err := openAIForm(job.ModelID, job.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+openAIEditsPath, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call OpenAI: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxOpenAIResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAI response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr openAIError
+		_ = json.Unmarshal(raw, &apiErr)
+		msg := apiErr.Error.Message
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		if apiErr.Error.Code == "moderation_blocked" || apiErr.Error.Code == "content_policy_violation" {
+			return &ProviderResult{Done: true, Err: fmt.Errorf("prediction failed: %s: %w", msg, errNSFW)}, nil
+		}
+		return nil, fmt.Errorf("openai returned %d: %s", resp.StatusCode, msg)
+	}
+
+	var out openAIResponse
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAI response: %w", err)
+	}
+	if len(out.Data) == 0 {
+		return &ProviderResult{Done: true, Err: fmt.Errorf("prediction succeeded but output is nil")}, nil
+	}
+	output, err := base64.StdEncoding.DecodeString(out.Data[0].B64JSON)
+	if err != nil || len(output) == 0 {
+		return &ProviderResult{Done: true, Err: fmt.Errorf("prediction succeeded but output is invalid")}, nil
+	}
+	return &ProviderResult{Done: true, Output: output}, nil
+}
+
+// Poll always fails: OpenAI jobs are finished when CreateJob returns, and
+// have no ID to look them up by.
+func (p *openAIProvider) Poll(ctx context.Context, jobID string) (*ProviderResult, error) {
+	return nil, errors.New("openai jobs can't be polled")
+}
+
+// Fetch returns the image CreateJob received.
+func (p *openAIProvider) Fetch(ctx context.Context, result *ProviderResult) ([]byte, error) {
+	if len(result.Output) == 0 {
+		return nil, errors.New("openai job has no output")
+	}
+	return result.Output, nil
+}
+
+// openAIForm encodes input, as built by the GPT Image input builder, as the
+// multipart form the edit endpoint takes.
+func openAIForm(modelID model.ID, input map[string]interface{}) (io.Reader, string, error) {
+	images, _ := input["input_images"].([]string)
+	if len(images) == 0 {
+		return nil, "", errors.New("input image is required")
+	}
+	original, err := decodeDataURL(images[0])
+	if err != nil {
+		return nil, "", err
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	// The Images API checks each image's content type, which
+	// CreateFormFile always sets to application/octet-stream.
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="image"; filename="image"`)
+	header.Set("Content-Type", http.DetectContentType(original))
+	part, err := w.CreatePart(header)
+	if err == nil {
+		_, err = part.Write(original)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	fields := map[string]string{
+		"model": strings.TrimPrefix(string(modelID), "openai/"),
+		"n":     "1",
+	}
+	if size, ok := openAISizes[fmt.Sprint(input["aspect_ratio"])]; ok {
+		fields["size"] = size
+	}
+	for _, name := range []string{"prompt", "quality", "input_fidelity", "background", "output_format"} {
+		if v, ok := input[name]; ok {
+			fields[name] = fmt.Sprint(v)
+		}
+	}
+	if v, ok := input["output_compression"]; ok && fields["output_format"] != "png" {
+		fields["output_compression"] = fmt.Sprint(v)
+	}
+	if v, ok := input["user_id"]; ok {
+		fields["user"] = fmt.Sprint(v)
+	}
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, "", fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to encode form: %w", err)
+	}
+	return &body, w.FormDataContentType(), nil
+}
@@ -0,0 +1,235 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestOpenAIProvider_CreateJob(t *testing.T) {
+	staged := []byte("staged image")
+	input := func(t *testing.T) map[string]interface{} {
+		return map[string]interface{}{
+			"openai_api_key": "sk-user", "prompt": "stage it", "aspect_ratio": "3:2", "quality": "high",
+			"input_fidelity": "high", "number_of_images": 4, "output_format": "webp", "output_compression": 80,
+			"input_images": []string{pngDataURL(t, 2, 2)},
+		}
+	}
+
+	t.Run("success: sends the edit and returns the image", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != openAIEditsPath {
+				t.Errorf("path = %s", r.URL.Path)
+			}
+			if got := r.Header.Get("Authorization"); got != "Bearer sk-user" {
+				t.Errorf("Authorization = %q", got)
+			}
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parse form: %v", err)
+			}
+			want := map[string]string{
+				"model": "gpt-image-1.5", "n": "1", "size": "1536x1024", "prompt": "stage it", "quality": "high",
+				"input_fidelity": "high", "output_format": "webp", "output_compression": "80",
+			}
+			for k, v := range want {
+				if got := r.FormValue(k); got != v {
+					t.Errorf("%s = %q, want %q", k, got, v)
+				}
+			}
+			_, header, err := r.FormFile("image")
+			if err != nil {
+				t.Fatalf("image: %v", err)
+			}
+			if ct := header.Header.Get("Content-Type"); ct != "image/png" {
+				t.Errorf("image Content-Type = %q, want image/png", ct)
+			}
+			_, _ = io.WriteString(w, `{"data":[{"b64_json":"`+base64.StdEncoding.EncodeToString(staged)+`"}]}`)
+		}))
+		defer srv.Close()
+
+		p := newOpenAIProvider(srv.URL)
+		got, err := p.CreateJob(context.Background(), &ProviderJob{ModelID: model.ModelGPTImage1_5, Input: input(t)})
+		if err != nil {
+			t.Fatalf("CreateJob() error = %v", err)
+		}
+		if !got.Done || got.Err != nil || got.ID != "" {
+			t.Fatalf("CreateJob() = %+v", got)
+		}
+		out, err := p.Fetch(context.Background(), got)
+		if err != nil || !bytes.Equal(out, staged) {
+			t.Errorf("Fetch() = %q, %v", out, err)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantNSFW bool
+		wantErr  bool // CreateJob itself fails rather than the job
+	}{
+		{
+			name: "fail: moderation blocked", status: 400, wantNSFW: true,
+			body: `{"error":{"message":"Your request was rejected","code":"moderation_blocked"}}`,
+		},
+		{name: "fail: no images returned", status: 200, body: `{"data":[]}`},
+		{
+			name: "fail: invalid key", status: 401, wantErr: true,
+			body: `{"error":{"message":"Incorrect API key provided","code":"invalid_api_key"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer srv.Close()
+
+			got, err := newOpenAIProvider(srv.URL).CreateJob(context.Background(), &ProviderJob{
+				ModelID: model.ModelGPTImage1, Input: input(t),
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CreateJob() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateJob() error = %v", err)
+			}
+			if !got.Done || got.Err == nil {
+				t.Fatalf("CreateJob() = %+v, want a failed job", got)
+			}
+			if errors.Is(got.Err, errNSFW) != tt.wantNSFW {
+				t.Errorf("errors.Is(err, errNSFW) = %v, want %v", !tt.wantNSFW, tt.wantNSFW)
+			}
+		})
+	}
+
+	t.Run("fail: no API key", func(t *testing.T) {
+		in := input(t)
+		in["openai_api_key"] = " "
+		_, err := newOpenAIProvider("http://unused").CreateJob(context.Background(), &ProviderJob{
+			ModelID: model.ModelGPTImage1, Input: in,
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("fail: no input image", func(t *testing.T) {
+		in := input(t)
+		delete(in, "input_images")
+		_, err := newOpenAIProvider("http://unused").CreateJob(context.Background(), &ProviderJob{
+			ModelID: model.ModelGPTImage1, Input: in,
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// keyRepo is a ConfigRepository holding one model config and one user key.
+type keyRepo struct {
+	config  model.Config
+	userKey string
+	err     error
+}
+
+func (r *keyRepo) GetModelConfig(ctx context.Context, modelID model.ID) (model.Config, error) {
+	return r.config, nil
+}
+
+func (r *keyRepo) GetUserAPIKey(ctx context.Context, imageID string, provider model.Provider) (string, error) {
+	if imageID != "img-1" || provider != model.ProviderOpenAI {
+		return "", fmt.Errorf("unexpected lookup for %s, %s", imageID, provider)
+	}
+	return r.userKey, r.err
+}
+
+func TestDefaultService_RunPrediction_userAPIKey(t *testing.T) {
+	ctx := context.Background()
+	originalLoader := awsConfigLoader
+	defer func() { awsConfigLoader = originalLoader }()
+	awsConfigLoader = func(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+		return aws.Config{Region: "us-west-1"}, nil
+	}
+
+	configured := (&model.GPTImageConfig{}).GetDefaults().(*model.GPTImageConfig)
+	configured.OpenAIAPIKey = "sk-deployment"
+
+	tests := []struct {
+		name     string
+		repo     *keyRepo
+		wantAuth string
+		wantErr  string
+	}{
+		{name: "success: user's key", repo: &keyRepo{config: configured, userKey: "sk-user"}, wantAuth: "Bearer sk-user"},
+		{name: "success: model config key without one", repo: &keyRepo{config: configured}, wantAuth: "Bearer sk-deployment"},
+		{
+			name:    "fail: no key at all",
+			repo:    &keyRepo{config: (&model.GPTImageConfig{}).GetDefaults()},
+			wantErr: "no OpenAI API key",
+		},
+		{
+			name:    "fail: lookup error",
+			repo:    &keyRepo{config: configured, err: errors.New("db down")},
+			wantErr: "failed to get user API key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotAuth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuth = r.Header.Get("Authorization")
+				_, _ = io.WriteString(w, `{"data":[{"b64_json":"`+base64.StdEncoding.EncodeToString([]byte("staged"))+`"}]}`)
+			}))
+			defer srv.Close()
+
+			service, err := NewDefaultService(ctx, &ServiceConfig{
+				BucketName:     "test-bucket",
+				ReplicateToken: "test-token",
+				ModelID:        model.ModelGPTImage1,
+				S3Region:       "us-west-1",
+				AppEnv:         "dev",
+				ConfigRepo:     tt.repo,
+				OpenAIBaseURL:  srv.URL,
+			})
+			if err != nil {
+				t.Fatalf("NewDefaultService() error = %v", err)
+			}
+
+			_, err = service.runPrediction(ctx, "img-1", model.ModelGPTImage1, "", pngDataURL(t, 2, 2), "", "stage it", nil,
+				nil, nil)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runPrediction() error = %v, want %q", err, tt.wantErr)
+				}
+				if gotAuth != "" {
+					t.Errorf("OpenAI was called with %q", gotAuth)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runPrediction() error = %v", err)
+			}
+			if gotAuth != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", gotAuth, tt.wantAuth)
+			}
+		})
+	}
+}
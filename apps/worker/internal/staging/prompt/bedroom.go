@@ -15,19 +15,12 @@ func buildBedroomPrompt(style string, specifics ...string) string {
 	b.WriteString(fmt.Sprintf("This is a BEDROOM requiring %s-style BEDROOM FURNITURE ONLY. ", style))
 
 	// CRITICAL: Structural preservation FIRST, before any furniture instructions
-	b.WriteString("STRUCTURAL PRESERVATION - ABSOLUTE PRIORITY: ")
-	b.WriteString("Do NOT modify, move, or alter ANY walls, paint colors, windows, doors, or architectural features. ")
-	b.WriteString("Keep ALL existing walls in their EXACT positions. ")
-	b.WriteString("Do NOT remove, add, resize, or relocate windows. ")
-	b.WriteString("Do NOT change wall colors, paint finishes, or add wall treatments. ")
-	b.WriteString("Do NOT modify ceiling height, flooring material, or room dimensions. ")
-	b.WriteString("Do NOT alter light fixtures, ceiling fans, or built-in features. ")
-	b.WriteString("ONLY add furniture, bedding, rugs, artwork, and decorative items. ")
-	b.WriteString("The room's structure must remain COMPLETELY UNCHANGED. ")
+	writePreservationRules(&b, "furniture, bedding, rugs, artwork, and decorative items")
 
 	// Emphasize bedroom furniture requirements
 	b.WriteString("BEDROOM FURNITURE REQUIREMENTS: This is a sleeping space. ")
-	b.WriteString("Essential bedroom furniture includes: bed with headboard (platform, upholstered, or wood frame), ")
+	b.WriteString("Essential bedroom furniture includes: a queen-size bed (a full-size bed in small rooms) ")
+	b.WriteString("with headboard (platform, upholstered, or wood frame), ")
 	b.WriteString("nightstands (1-2 flanking the bed), table lamps or pendant lights, dresser or chest of drawers. ")
 	b.WriteString("NEVER add living room furniture like sofas, couches, sectionals, coffee tables, or TV stands. ")
 	b.WriteString("NEVER add entertainment centers, media consoles, or television units. ")
@@ -48,7 +41,8 @@ func buildBedroomPrompt(style string, specifics ...string) string {
 	b.WriteString("Do NOT block doorways, hallways, or thresholds with furniture. ")
 	b.WriteString("Do NOT add seating unless space explicitly allows (reading chair in corner only). ")
 	b.WriteString("Bedding should be neatly made with pillows arranged. ")
-	b.WriteString("Area rugs should be at the foot of the bed or under the bed, not blocking pathways.")
+	b.WriteString("Area rugs should be at the foot of the bed or under the bed, not blocking pathways; ")
+	b.WriteString("a rug under the bed is about 8x10 ft and extends past both sides.")
 
 	return b.String()
 }
@@ -140,3 +134,71 @@ func buildBedroomScandinavian() string {
 		"Include one or two houseplants in simple ceramic pots for organic warmth.",
 	)
 }
+
+func buildBedroomBohemian() string {
+	return buildBedroomPrompt("bohemian",
+		"Add a low wooden or rattan bed with a woven or carved headboard, centered on the main wall.",
+		"Include mismatched nightstands in carved wood or rattan on each side of the bed.",
+		"Add rattan pendants or ceramic table lamps with warm bulbs above or on the nightstands.",
+		"Include a vintage-style wooden dresser with brass hardware along a secondary wall.",
+		"Add layered bedding: linen duvet in terracotta or rust, patterned quilt, fringed throw, "+
+			"and mixed-print pillows.",
+		"Use a kilim or Moroccan-style rug under the foot of the bed.",
+		"Add a macramé wall hanging or eclectic framed prints above the headboard.",
+		"Include trailing plants and potted palms in woven baskets.",
+	)
+}
+
+func buildBedroomFarmhouse() string {
+	return buildBedroomPrompt("modern farmhouse",
+		"Add a wood bed with a shiplap-style or paneled headboard in natural or white-washed wood, "+
+			"centered on the main wall.",
+		"Include two matching wooden nightstands with black iron pulls on each side of the bed.",
+		"Add table lamps with ceramic or wood bases and linen shades.",
+		"Include a distressed wood dresser along a secondary wall with a round mirror above.",
+		"Add white or cream bedding with a waffle-knit throw and plaid or ticking-stripe pillows.",
+		"Use a neutral jute or flat-woven rug under the bed.",
+		"Add simple wall decor above the headboard: a framed botanical print or wooden wreath.",
+		"Only add a wooden bench at the foot of the bed if the room is large.",
+	)
+}
+
+func buildBedroomMidCentury() string {
+	return buildBedroomPrompt("mid-century modern",
+		"Add a walnut platform bed with a low slatted or upholstered headboard, centered on the main wall.",
+		"Include two walnut nightstands with tapered legs and a single drawer on each side of the bed.",
+		"Add ceramic or brass table lamps with drum shades on the nightstands.",
+		"Include a long low walnut dresser with sculpted pulls along a secondary wall.",
+		"Add bedding in white with accents in mustard, teal, or burnt orange and geometric pillows.",
+		"Use a geometric wool rug under the bed.",
+		"Add abstract mid-century art or a sunburst mirror above the headboard.",
+		"Only add a molded shell accent chair in a corner if the room is large.",
+	)
+}
+
+func buildBedroomCoastal() string {
+	return buildBedroomPrompt("coastal",
+		"Add a whitewashed wood or rattan bed with a woven or slatted headboard, centered on the main wall.",
+		"Include light wood or white nightstands on each side of the bed.",
+		"Add ceramic table lamps in soft blue or sand with white linen shades.",
+		"Include a whitewashed or light oak dresser along a secondary wall.",
+		"Add crisp white bedding with soft blue, seafoam, and striped linen pillows and a light throw.",
+		"Use a natural jute rug or soft blue-and-white striped rug under the bed.",
+		"Add framed ocean photography or a seascape above the headboard.",
+		"Include small coastal accents: a glass vase, a bowl of shells, a small potted palm.",
+	)
+}
+
+func buildBedroomJapandi() string {
+	return buildBedroomPrompt("Japandi",
+		"Add a low wooden platform bed in light oak or dark-stained ash with a simple, low headboard, "+
+			"centered on the main wall.",
+		"Include two low, minimalist wooden nightstands on each side of the bed.",
+		"Add paper lantern or simple ceramic table lamps with warm light.",
+		"Include a low, handleless wooden dresser along a secondary wall.",
+		"Add linen bedding in oatmeal, warm gray, or muted clay with few pillows.",
+		"Use a flat-woven wool or jute rug under the bed.",
+		"Add a single restrained art piece above the headboard, such as an ink wash print.",
+		"Include one stoneware vase with a branch; keep surfaces mostly clear.",
+	)
+}
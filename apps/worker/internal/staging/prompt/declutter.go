@@ -0,0 +1,151 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Modes say what a job does with the furniture already in the photo.
+const (
+	// ModeStage adds staged furniture to the photo as it is.
+	ModeStage = "stage"
+	// ModeDeclutter removes the furniture and personal items in the photo and
+	// leaves the room empty.
+	ModeDeclutter = "declutter"
+	// ModeBoth removes the furniture and personal items in the photo and
+	// stages the emptied room.
+	ModeBoth = "both"
+)
+
+// Declutters reports whether jobs in mode remove what is in the photo.
+func Declutters(mode string) bool {
+	return mode == ModeDeclutter || mode == ModeBoth
+}
+
+// declutterRoom describes what decluttering removes from a room type, and
+// what it must keep beyond the structure every room keeps.
+type declutterRoom struct {
+	name  string
+	items string
+	keep  string
+}
+
+// declutterRooms is the declutter prompt set, keyed like the staging prompts.
+var declutterRooms = map[string]declutterRoom{
+	"living_room": {
+		name: "living room",
+		items: "sofas, armchairs, coffee and side tables, rugs, floor and table lamps, TVs and media stands, " +
+			"freestanding shelves and their contents, throw pillows and blankets, plants, toys, and framed photos",
+		keep: "fireplaces, mantels, and built-in shelving",
+	},
+	"bedroom": {
+		name: "bedroom",
+		items: "beds, nightstands, dressers, desks, chairs, rugs, lamps, clothing, laundry baskets, bedding, " +
+			"and personal photos",
+		keep: "closet doors and built-in wardrobes",
+	},
+	"kitchen": {
+		name: "kitchen",
+		items: "kitchen tables and chairs, bar stools, small countertop appliances, dish racks, dishes, food, " +
+			"papers and magnets on the refrigerator, towels, and trash cans",
+		keep: "cabinets, countertops, backsplashes, sinks, faucets, and installed appliances",
+	},
+	"bathroom": {
+		name:  "bathroom",
+		items: "toiletries, towels, bath mats, shower caddies, laundry hampers, scales, and trash cans",
+		keep:  "vanities, sinks, faucets, mirrors, toilets, tubs, showers, and glass enclosures",
+	},
+	"dining_room": {
+		name:  "dining room",
+		items: "dining tables and chairs, sideboards, rugs, table settings, centerpieces, and high chairs",
+		keep:  "chandeliers and built-in cabinets",
+	},
+	"office": {
+		name:  "office",
+		items: "desks, office chairs, bookshelves and their contents, computers, cables, papers, and boxes",
+		keep:  "built-in desks and shelving",
+	},
+	"entryway": {
+		name:  "entryway",
+		items: "coats, shoes, bags, umbrellas, benches, console tables, rugs, and mail",
+		keep:  "stairs, railings, and built-in closets",
+	},
+	"outdoor": {
+		name:  "outdoor space",
+		items: "patio furniture, grills, planters, toys, garden hoses, bins, and tools",
+		keep:  "decks, patios, railings, fences, pergolas, lawns, and landscaping",
+	},
+	"basement": {
+		name:  "basement",
+		items: "sofas, chairs, tables, TVs, exercise equipment, storage boxes and shelving, toys, and rugs",
+		keep:  "furnaces, water heaters, sump pumps, utility panels, and support posts",
+	},
+	"garage": {
+		name:  "garage",
+		items: "vehicles, bikes, tools, storage boxes, freestanding shelving, sports gear, bins, and clutter",
+		keep:  "garage doors and their openers, water heaters, and utility panels",
+	},
+	"nursery": {
+		name:  "nursery",
+		items: "cribs, changing tables, dressers, gliders, toys, baby gear, rugs, and personal photos",
+		keep:  "closet doors and built-in shelving",
+	},
+	"laundry_room": {
+		name:  "laundry room",
+		items: "laundry baskets, clothing, detergent bottles, drying racks, ironing boards, and clutter",
+		keep:  "washers, dryers, utility sinks, and cabinets",
+	},
+	"home_gym": {
+		name:  "home gym",
+		items: "treadmills, bikes, weight benches, racks, weights, mats, and fitness accessories",
+		keep:  "wall-mounted mirrors and built-in storage",
+	},
+	"sunroom": {
+		name:  "sunroom",
+		items: "sofas, chairs, tables, rugs, plants and planters, and personal items",
+		keep:  "window frames and built-in benches",
+	},
+	"default": {
+		name:  "room",
+		items: "furniture, rugs, lamps, boxes, clothing, toys, papers, and personal photos",
+	},
+}
+
+// BuildDeclutter builds the prompt for a job in a decluttering mode. Without
+// restage it asks for the room emptied of its furniture and belongings. With
+// restage the emptied room is then staged with the prompt BuildLocalized
+// returns for roomType, style, customPrompt, override, locale and language.
+func (l *Library) BuildDeclutter(
+	roomType, style, customPrompt, override, locale, language string, restage bool,
+) string {
+	room, ok := declutterRooms[roomType]
+	if !ok {
+		room = declutterRooms["default"]
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Professional real estate decluttering for a %s. ", room.name))
+	b.WriteString("You are a professional real estate photo editor preparing an occupied home for its listing. ")
+	b.WriteString("REMOVE ALL movable furniture and personal belongings, including ")
+	b.WriteString(room.items)
+	b.WriteString(". Also remove clutter, cables, wall hangings, and anything left on the floor. ")
+
+	b.WriteString("KEEP EXACTLY AS THEY ARE: walls, paint colors, windows, doors, flooring, ceilings, ")
+	b.WriteString("light fixtures, and window frames")
+	if room.keep != "" {
+		b.WriteString(", as well as ")
+		b.WriteString(room.keep)
+	}
+	b.WriteString(". Do NOT change the camera angle, room dimensions, or architecture. ")
+	b.WriteString("Fill in the floor, walls, and surfaces revealed behind removed items so they match the ")
+	b.WriteString("surrounding materials, lighting, and perspective. ")
+	b.WriteString("Leave no shadows, outlines, or marks where items stood. ")
+
+	if !restage {
+		b.WriteString("The result must show the same room completely empty and clean, ready to be staged.")
+		return b.String()
+	}
+	b.WriteString("Then stage the emptied room as follows. ")
+	b.WriteString(l.BuildLocalized(roomType, style, customPrompt, override, locale, language))
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLibrary_BuildDeclutter(t *testing.T) {
+	lib := New()
+
+	t.Run("success: empties the room", func(t *testing.T) {
+		got := lib.BuildDeclutter("kitchen", "modern", "", "", "", "", false)
+		assert.Contains(t, got, "decluttering for a kitchen")
+		assert.Contains(t, got, "dish racks")
+		assert.Contains(t, got, "installed appliances")
+		assert.Contains(t, got, "completely empty")
+		assert.NotContains(t, got, lib.Build("kitchen", "modern", ""))
+	})
+
+	t.Run("success: restage stages the emptied room", func(t *testing.T) {
+		got := lib.BuildDeclutter("bedroom", "scandinavian", "", "", "", "", true)
+		assert.Contains(t, got, "clothing, laundry baskets")
+		assert.Contains(t, got, "Then stage the emptied room")
+		assert.Contains(t, got, lib.Build("bedroom", "scandinavian", ""))
+		assert.NotContains(t, got, "completely empty")
+	})
+
+	t.Run("success: restage uses the override and custom prompt", func(t *testing.T) {
+		got := lib.BuildDeclutter("bedroom", "modern", "", "Tuned bedroom prompt.", "", "", true)
+		assert.Contains(t, got, "Tuned bedroom prompt.")
+
+		got = lib.BuildDeclutter("bedroom", "modern", "Add a navy sofa.", "Tuned bedroom prompt.", "", "", true)
+		assert.Contains(t, got, "Add a navy sofa.")
+		assert.NotContains(t, got, "Tuned bedroom prompt.")
+	})
+
+	t.Run("success: garage keeps the garage door", func(t *testing.T) {
+		got := lib.BuildDeclutter("garage", "", "", "", "", "", false)
+		assert.Contains(t, got, "decluttering for a garage")
+		assert.Contains(t, got, "garage doors and their openers")
+	})
+
+	t.Run("success: unknown room type uses the default set", func(t *testing.T) {
+		got := lib.BuildDeclutter("", "", "", "", "", "", false)
+		assert.Contains(t, got, "decluttering for a room")
+		assert.Contains(t, got, "boxes, clothing")
+	})
+}
+
+func TestDeclutters(t *testing.T) {
+	assert.False(t, Declutters(""))
+	assert.False(t, Declutters(ModeStage))
+	assert.True(t, Declutters(ModeDeclutter))
+	assert.True(t, Declutters(ModeBoth))
+}
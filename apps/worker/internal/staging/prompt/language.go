@@ -0,0 +1,27 @@
+package prompt
+
+// Prompts stay in English whatever language a job was requested in, since
+// the staging models follow English instructions best. The request language
+// still shapes them: a custom prompt is marked as written in it, so the model
+// reads it as instructions rather than text to paint into the photo, and a
+// project without a locale gets the furniture sizes of the language's main
+// market.
+
+// languages are the request languages besides English that prompts adapt to.
+var languages = map[string]struct {
+	// name is what the model is told a custom prompt is written in.
+	name string
+	// locale is the locale sizes follow when the project sets none.
+	locale string
+}{
+	"es": {name: "Spanish", locale: "es-ES"},
+	"fr": {name: "French", locale: "fr-FR"},
+}
+
+// localeFor returns locale, or the locale of language when locale is empty.
+func localeFor(locale, language string) string {
+	if locale != "" {
+		return locale
+	}
+	return languages[language].locale
+}
@@ -2,6 +2,7 @@ package prompt
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -19,15 +20,57 @@ func New() *Library {
 	return lib
 }
 
-// Get retrieves a prompt for the given room type and style.
-// Returns the prompt and a boolean indicating if it was found.
-func (l *Library) Get(roomType, style string) (string, bool) {
+// Entry is one built-in prompt.
+type Entry struct {
+	RoomType string `json:"room_type"`
+	Style    string `json:"style"`
+	Prompt   string `json:"prompt"`
+}
+
+// Entries lists every built-in prompt ordered by room type and style.
+func (l *Library) Entries() []Entry {
+	entries := make([]Entry, 0, len(l.prompts)*len(l.prompts["default"]))
+	for roomType, styles := range l.prompts {
+		for style, prompt := range styles {
+			entries = append(entries, Entry{RoomType: roomType, Style: style, Prompt: prompt})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].RoomType != entries[j].RoomType {
+			return entries[i].RoomType < entries[j].RoomType
+		}
+		return entries[i].Style < entries[j].Style
+	})
+	return entries
+}
+
+// RoomTypes lists the room types with prompts of their own, in name order.
+func (l *Library) RoomTypes() []string {
+	roomTypes := make([]string, 0, len(l.prompts))
+	for roomType := range l.prompts {
+		if roomType != "default" {
+			roomTypes = append(roomTypes, roomType)
+		}
+	}
+	sort.Strings(roomTypes)
+	return roomTypes
+}
+
+// Key fills in the room type and style a lookup uses when either is empty.
+func Key(roomType, style string) (string, string) {
 	if roomType == "" {
 		roomType = "default"
 	}
 	if style == "" {
 		style = "modern"
 	}
+	return roomType, style
+}
+
+// Get retrieves a prompt for the given room type and style.
+// Returns the prompt and a boolean indicating if it was found.
+func (l *Library) Get(roomType, style string) (string, bool) {
+	roomType, style = Key(roomType, style)
 
 	// Try exact match first
 	if styles, ok := l.prompts[roomType]; ok {
@@ -51,11 +94,12 @@ func (l *Library) Get(roomType, style string) (string, bool) {
 }
 
 // Build constructs a prompt for the given room type and style.
-// If customPrompt is provided, it takes precedence.
-// Otherwise, looks up the prompt from the library.
+// If customPrompt is provided, it takes precedence: it is sanitized and wrapped
+// so the structural-preservation rules always follow the user's text.
+// Otherwise, or if nothing survives sanitization, looks up the prompt from the library.
 func (l *Library) Build(roomType, style, customPrompt string) string {
-	if customPrompt != "" {
-		return customPrompt
+	if custom := SanitizeCustomPrompt(customPrompt); custom != "" {
+		return wrapCustomPrompt(custom, "", "")
 	}
 
 	if prompt, ok := l.Get(roomType, style); ok {
@@ -66,6 +110,31 @@ func (l *Library) Build(roomType, style, customPrompt string) string {
 	return l.buildGenericPrompt(roomType, style)
 }
 
+// BuildWithOverride is Build with an admin override standing in for the
+// library prompt. A custom prompt still takes precedence over the override.
+func (l *Library) BuildWithOverride(roomType, style, customPrompt, override string) string {
+	if custom := SanitizeCustomPrompt(customPrompt); custom != "" {
+		return wrapCustomPrompt(custom, "", "")
+	}
+	if override != "" {
+		return override
+	}
+	return l.Build(roomType, style, "")
+}
+
+// BuildLocalized is BuildWithOverride with the furniture sizes adapted to
+// locale (see Localize), or without one to the main market of language, the
+// language the job was requested in. A custom prompt is left as the user wrote
+// it, marked as written in language; only the rules wrapped around it are
+// localized.
+func (l *Library) BuildLocalized(roomType, style, customPrompt, override, locale, language string) string {
+	locale = localeFor(locale, language)
+	if custom := SanitizeCustomPrompt(customPrompt); custom != "" {
+		return wrapCustomPrompt(custom, locale, language)
+	}
+	return Localize(l.BuildWithOverride(roomType, style, "", override), locale)
+}
+
 // buildGenericPrompt creates a basic prompt when no specific one is found.
 func (l *Library) buildGenericPrompt(roomType, style string) string {
 	var b strings.Builder
@@ -98,6 +167,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildLivingRoomTraditional(),
 		"industrial":   buildLivingRoomIndustrial(),
 		"scandinavian": buildLivingRoomScandinavian(),
+		"bohemian":     buildLivingRoomBohemian(),
+		"farmhouse":    buildLivingRoomFarmhouse(),
+		"mid_century":  buildLivingRoomMidCentury(),
+		"coastal":      buildLivingRoomCoastal(),
+		"japandi":      buildLivingRoomJapandi(),
 		"default":      buildLivingRoomModern(),
 	}
 
@@ -108,6 +182,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildBedroomTraditional(),
 		"industrial":   buildBedroomIndustrial(),
 		"scandinavian": buildBedroomScandinavian(),
+		"bohemian":     buildBedroomBohemian(),
+		"farmhouse":    buildBedroomFarmhouse(),
+		"mid_century":  buildBedroomMidCentury(),
+		"coastal":      buildBedroomCoastal(),
+		"japandi":      buildBedroomJapandi(),
 		"default":      buildBedroomModern(),
 	}
 
@@ -118,6 +197,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildKitchenTraditional(),
 		"industrial":   buildKitchenIndustrial(),
 		"scandinavian": buildKitchenScandinavian(),
+		"bohemian":     buildKitchenBohemian(),
+		"farmhouse":    buildKitchenFarmhouse(),
+		"mid_century":  buildKitchenMidCentury(),
+		"coastal":      buildKitchenCoastal(),
+		"japandi":      buildKitchenJapandi(),
 		"default":      buildKitchenModern(),
 	}
 
@@ -128,6 +212,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildBathroomTraditional(),
 		"industrial":   buildBathroomIndustrial(),
 		"scandinavian": buildBathroomScandinavian(),
+		"bohemian":     buildBathroomBohemian(),
+		"farmhouse":    buildBathroomFarmhouse(),
+		"mid_century":  buildBathroomMidCentury(),
+		"coastal":      buildBathroomCoastal(),
+		"japandi":      buildBathroomJapandi(),
 		"default":      buildBathroomModern(),
 	}
 
@@ -138,6 +227,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildDiningRoomTraditional(),
 		"industrial":   buildDiningRoomIndustrial(),
 		"scandinavian": buildDiningRoomScandinavian(),
+		"bohemian":     buildDiningRoomBohemian(),
+		"farmhouse":    buildDiningRoomFarmhouse(),
+		"mid_century":  buildDiningRoomMidCentury(),
+		"coastal":      buildDiningRoomCoastal(),
+		"japandi":      buildDiningRoomJapandi(),
 		"default":      buildDiningRoomModern(),
 	}
 
@@ -148,6 +242,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildOfficeTraditional(),
 		"industrial":   buildOfficeIndustrial(),
 		"scandinavian": buildOfficeScandinavian(),
+		"bohemian":     buildOfficeBohemian(),
+		"farmhouse":    buildOfficeFarmhouse(),
+		"mid_century":  buildOfficeMidCentury(),
+		"coastal":      buildOfficeCoastal(),
+		"japandi":      buildOfficeJapandi(),
 		"default":      buildOfficeModern(),
 	}
 
@@ -158,6 +257,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildEntrywayTraditional(),
 		"industrial":   buildEntrywayIndustrial(),
 		"scandinavian": buildEntrywayScandinavian(),
+		"bohemian":     buildEntrywayBohemian(),
+		"farmhouse":    buildEntrywayFarmhouse(),
+		"mid_century":  buildEntrywayMidCentury(),
+		"coastal":      buildEntrywayCoastal(),
+		"japandi":      buildEntrywayJapandi(),
 		"default":      buildEntrywayModern(),
 	}
 
@@ -168,9 +272,104 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildOutdoorTraditional(),
 		"industrial":   buildOutdoorIndustrial(),
 		"scandinavian": buildOutdoorScandinavian(),
+		"bohemian":     buildOutdoorBohemian(),
+		"farmhouse":    buildOutdoorFarmhouse(),
+		"mid_century":  buildOutdoorMidCentury(),
+		"coastal":      buildOutdoorCoastal(),
+		"japandi":      buildOutdoorJapandi(),
 		"default":      buildOutdoorModern(),
 	}
 
+	// Basement prompts
+	l.prompts["basement"] = map[string]string{
+		"modern":       buildBasementModern(),
+		"contemporary": buildBasementContemporary(),
+		"traditional":  buildBasementTraditional(),
+		"industrial":   buildBasementIndustrial(),
+		"scandinavian": buildBasementScandinavian(),
+		"bohemian":     buildBasementBohemian(),
+		"farmhouse":    buildBasementFarmhouse(),
+		"mid_century":  buildBasementMidCentury(),
+		"coastal":      buildBasementCoastal(),
+		"japandi":      buildBasementJapandi(),
+		"default":      buildBasementModern(),
+	}
+
+	// Garage prompts
+	l.prompts["garage"] = map[string]string{
+		"modern":       buildGarageModern(),
+		"contemporary": buildGarageContemporary(),
+		"traditional":  buildGarageTraditional(),
+		"industrial":   buildGarageIndustrial(),
+		"scandinavian": buildGarageScandinavian(),
+		"bohemian":     buildGarageBohemian(),
+		"farmhouse":    buildGarageFarmhouse(),
+		"mid_century":  buildGarageMidCentury(),
+		"coastal":      buildGarageCoastal(),
+		"japandi":      buildGarageJapandi(),
+		"default":      buildGarageModern(),
+	}
+
+	// Nursery prompts
+	l.prompts["nursery"] = map[string]string{
+		"modern":       buildNurseryModern(),
+		"contemporary": buildNurseryContemporary(),
+		"traditional":  buildNurseryTraditional(),
+		"industrial":   buildNurseryIndustrial(),
+		"scandinavian": buildNurseryScandinavian(),
+		"bohemian":     buildNurseryBohemian(),
+		"farmhouse":    buildNurseryFarmhouse(),
+		"mid_century":  buildNurseryMidCentury(),
+		"coastal":      buildNurseryCoastal(),
+		"japandi":      buildNurseryJapandi(),
+		"default":      buildNurseryModern(),
+	}
+
+	// Laundry room prompts
+	l.prompts["laundry_room"] = map[string]string{
+		"modern":       buildLaundryRoomModern(),
+		"contemporary": buildLaundryRoomContemporary(),
+		"traditional":  buildLaundryRoomTraditional(),
+		"industrial":   buildLaundryRoomIndustrial(),
+		"scandinavian": buildLaundryRoomScandinavian(),
+		"bohemian":     buildLaundryRoomBohemian(),
+		"farmhouse":    buildLaundryRoomFarmhouse(),
+		"mid_century":  buildLaundryRoomMidCentury(),
+		"coastal":      buildLaundryRoomCoastal(),
+		"japandi":      buildLaundryRoomJapandi(),
+		"default":      buildLaundryRoomModern(),
+	}
+
+	// Home gym prompts
+	l.prompts["home_gym"] = map[string]string{
+		"modern":       buildHomeGymModern(),
+		"contemporary": buildHomeGymContemporary(),
+		"traditional":  buildHomeGymTraditional(),
+		"industrial":   buildHomeGymIndustrial(),
+		"scandinavian": buildHomeGymScandinavian(),
+		"bohemian":     buildHomeGymBohemian(),
+		"farmhouse":    buildHomeGymFarmhouse(),
+		"mid_century":  buildHomeGymMidCentury(),
+		"coastal":      buildHomeGymCoastal(),
+		"japandi":      buildHomeGymJapandi(),
+		"default":      buildHomeGymModern(),
+	}
+
+	// Sunroom prompts
+	l.prompts["sunroom"] = map[string]string{
+		"modern":       buildSunroomModern(),
+		"contemporary": buildSunroomContemporary(),
+		"traditional":  buildSunroomTraditional(),
+		"industrial":   buildSunroomIndustrial(),
+		"scandinavian": buildSunroomScandinavian(),
+		"bohemian":     buildSunroomBohemian(),
+		"farmhouse":    buildSunroomFarmhouse(),
+		"mid_century":  buildSunroomMidCentury(),
+		"coastal":      buildSunroomCoastal(),
+		"japandi":      buildSunroomJapandi(),
+		"default":      buildSunroomModern(),
+	}
+
 	// Default prompts (used as fallback)
 	l.prompts["default"] = map[string]string{
 		"modern":       buildDefaultModern(),
@@ -178,6 +377,11 @@ func (l *Library) loadPrompts() {
 		"traditional":  buildDefaultTraditional(),
 		"industrial":   buildDefaultIndustrial(),
 		"scandinavian": buildDefaultScandinavian(),
+		"bohemian":     buildDefaultBohemian(),
+		"farmhouse":    buildDefaultFarmhouse(),
+		"mid_century":  buildDefaultMidCentury(),
+		"coastal":      buildDefaultCoastal(),
+		"japandi":      buildDefaultJapandi(),
 		"default":      buildDefaultModern(),
 	}
 }
@@ -190,6 +394,23 @@ func buildPrompt(roomType, style string, specifics ...string) string {
 	b.WriteString("You are a professional real estate photographer creating staged photos. ")
 
 	// CRITICAL: Structural preservation FIRST, before any furniture instructions
+	writePreservationRules(&b, "furniture, rugs, artwork, and decorative items")
+
+	// Add room-specific instructions
+	for _, s := range specifics {
+		b.WriteString(s)
+		b.WriteString(" ")
+	}
+
+	// Common placement rules for all prompts
+	writePlacementRules(&b)
+
+	return b.String()
+}
+
+// writePreservationRules writes the structural-preservation block used by
+// indoor and custom prompts. allowed lists what the model may add to the room.
+func writePreservationRules(b *strings.Builder, allowed string) {
 	b.WriteString("STRUCTURAL PRESERVATION - ABSOLUTE PRIORITY: ")
 	b.WriteString("Do NOT modify, move, or alter ANY walls, paint colors, windows, doors, or architectural features. ")
 	b.WriteString("Keep ALL existing walls in their EXACT positions. ")
@@ -197,20 +418,17 @@ func buildPrompt(roomType, style string, specifics ...string) string {
 	b.WriteString("Do NOT change wall colors, paint finishes, or add wall treatments. ")
 	b.WriteString("Do NOT modify ceiling height, flooring material, or room dimensions. ")
 	b.WriteString("Do NOT alter light fixtures, ceiling fans, or built-in features. ")
-	b.WriteString("ONLY add furniture, rugs, artwork, and decorative items. ")
+	b.WriteString("ONLY add ")
+	b.WriteString(allowed)
+	b.WriteString(". ")
 	b.WriteString("The room's structure must remain COMPLETELY UNCHANGED. ")
+}
 
-	// Add room-specific instructions
-	for _, s := range specifics {
-		b.WriteString(s)
-		b.WriteString(" ")
-	}
-
-	// Common placement rules for all prompts
+// writePlacementRules writes the placement rules that close generic and custom prompts.
+func writePlacementRules(b *strings.Builder) {
 	b.WriteString("CRITICAL PLACEMENT RULES: ")
 	b.WriteString("Do NOT block doorways, hallways, or thresholds with furniture. ")
 	b.WriteString("Furniture must be appropriately sized and placed for the room. ")
+	b.WriteString("Leave walkways at least 3 feet wide. ")
 	b.WriteString("Maintain realistic lighting and shadows.")
-
-	return b.String()
 }
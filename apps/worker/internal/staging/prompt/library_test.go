@@ -0,0 +1,113 @@
+package prompt
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibrary_Entries(t *testing.T) {
+	lib := New()
+	entries := lib.Entries()
+
+	require.NotEmpty(t, entries)
+	for i := 1; i < len(entries); i++ {
+		prev, cur := entries[i-1], entries[i]
+		assert.True(t, prev.RoomType < cur.RoomType || (prev.RoomType == cur.RoomType && prev.Style < cur.Style),
+			"entries out of order at %d", i)
+	}
+	for _, e := range entries {
+		got, ok := lib.Get(e.RoomType, e.Style)
+		require.True(t, ok)
+		assert.Equal(t, got, e.Prompt)
+	}
+}
+
+func TestLibrary_Get_Styles(t *testing.T) {
+	lib := New()
+	rooms := []string{
+		"living_room", "bedroom", "kitchen", "bathroom", "dining_room", "office", "entryway", "outdoor", "default",
+		"basement", "garage", "nursery", "laundry_room", "home_gym", "sunroom",
+	}
+	styles := []string{"bohemian", "farmhouse", "mid_century", "coastal", "japandi"}
+
+	for _, room := range rooms {
+		modern, ok := lib.Get(room, "modern")
+		require.True(t, ok)
+		for _, style := range styles {
+			got, ok := lib.Get(room, style)
+			require.True(t, ok, "%s/%s", room, style)
+			assert.NotEqual(t, modern, got, "%s/%s falls back to modern", room, style)
+		}
+	}
+}
+
+func TestLibrary_RoomTypes(t *testing.T) {
+	lib := New()
+	roomTypes := lib.RoomTypes()
+
+	assert.NotContains(t, roomTypes, "default")
+	assert.True(t, sort.StringsAreSorted(roomTypes))
+	fallback, _ := lib.Get("default", "modern")
+	for _, room := range []string{"basement", "garage", "nursery", "laundry_room", "home_gym", "sunroom"} {
+		assert.Contains(t, roomTypes, room)
+		got, ok := lib.Get(room, "modern")
+		require.True(t, ok)
+		assert.NotEqual(t, fallback, got, "%s falls back to the default room", room)
+	}
+}
+
+func TestLibrary_BuildWithOverride(t *testing.T) {
+	lib := New()
+
+	t.Run("success: override replaces the library prompt", func(t *testing.T) {
+		assert.Equal(t, "Tuned bedroom prompt.", lib.BuildWithOverride("bedroom", "modern", "", "Tuned bedroom prompt."))
+	})
+
+	t.Run("success: custom prompt wins over the override", func(t *testing.T) {
+		got := lib.BuildWithOverride("bedroom", "modern", "Add a navy sofa.", "Tuned bedroom prompt.")
+		assert.Contains(t, got, "Add a navy sofa.")
+		assert.NotContains(t, got, "Tuned bedroom prompt.")
+	})
+
+	t.Run("success: no override falls back to the library", func(t *testing.T) {
+		assert.Equal(t, lib.Build("bedroom", "modern", ""), lib.BuildWithOverride("bedroom", "modern", "", ""))
+	})
+}
+
+func TestLibrary_BuildLocalized(t *testing.T) {
+	lib := New()
+
+	t.Run("success: english leaves the prompt as it is", func(t *testing.T) {
+		assert.Equal(t, lib.BuildWithOverride("bedroom", "modern", "", ""),
+			lib.BuildLocalized("bedroom", "modern", "", "", "", ""))
+	})
+
+	t.Run("success: language without a locale picks its market's sizes", func(t *testing.T) {
+		assert.Equal(t, lib.BuildLocalized("bedroom", "modern", "", "", "fr-FR", ""),
+			lib.BuildLocalized("bedroom", "modern", "", "", "", "fr"))
+		assert.Equal(t, lib.BuildLocalized("bedroom", "modern", "", "", "en-GB", ""),
+			lib.BuildLocalized("bedroom", "modern", "", "", "en-GB", "fr"))
+	})
+
+	t.Run("success: custom prompt is marked with its language", func(t *testing.T) {
+		got := lib.BuildLocalized("bedroom", "modern", "Ajoute un canapé bleu marine.", "", "", "fr")
+		assert.Contains(t, got, "Ajoute un canapé bleu marine.")
+		assert.Contains(t, got, "These preferences are written in French")
+
+		got = lib.BuildLocalized("bedroom", "modern", "Add a navy sofa.", "", "", "de")
+		assert.NotContains(t, got, "These preferences are written in")
+	})
+}
+
+func TestKey(t *testing.T) {
+	roomType, style := Key("", "")
+	assert.Equal(t, "default", roomType)
+	assert.Equal(t, "modern", style)
+
+	roomType, style = Key("kitchen", "industrial")
+	assert.Equal(t, "kitchen", roomType)
+	assert.Equal(t, "industrial", style)
+}
@@ -69,3 +69,72 @@ func buildLivingRoomScandinavian() string {
 		"Include houseplants for natural warmth.",
 	)
 }
+
+func buildLivingRoomBohemian() string {
+	return buildPrompt("living room", "bohemian",
+		"Add a low, relaxed sofa in a warm earth tone (terracotta, ochre, olive) or a rattan-framed sofa.",
+		"Include a carved wood or rattan coffee table with a few stacked books and a brass tray.",
+		"Add a rattan or peacock-style accent chair and one or two floor poufs if space allows.",
+		"Include a low wooden media console along ONE wall (not in front of windows).",
+		"Add layered textiles: mixed-pattern throw pillows, a fringed or woven throw blanket.",
+		"Use a vintage-style kilim or Moroccan area rug to define the seating area.",
+		"Include plenty of plants: a fiddle-leaf fig, trailing pothos, and potted palms in woven baskets.",
+		"Add a gallery of eclectic framed prints, a macramé or woven wall hanging.",
+		"Include warm lighting: a rattan pendant, paper lantern, or ceramic table lamps.",
+	)
+}
+
+func buildLivingRoomFarmhouse() string {
+	return buildPrompt("living room", "modern farmhouse",
+		"Add a slipcovered sofa in white, oatmeal, or light gray linen.",
+		"Include a reclaimed or distressed wood coffee table with a simple trestle or X base.",
+		"Add 1-2 leather or upholstered armchairs with wooden legs.",
+		"Include a wooden media console with black iron hardware along ONE wall.",
+		"Add plaid, buffalo-check, or chunky knit throw pillows and a woven throw blanket.",
+		"Use a neutral jute or flat-woven wool area rug.",
+		"Include a galvanized or ceramic vase with eucalyptus or dried cotton stems.",
+		"Add a large round mirror or framed vintage landscape on the wall.",
+		"Include black metal or wood-and-linen table and floor lamps.",
+	)
+}
+
+func buildLivingRoomMidCentury() string {
+	return buildPrompt("living room", "mid-century modern",
+		"Add a tufted sofa with tapered wooden legs in mustard, teal, rust, or gray upholstery.",
+		"Include an oval or surfboard-shaped walnut coffee table with splayed legs.",
+		"Add an iconic-style lounge chair (molded plywood with leather) or a pair of shell chairs.",
+		"Include a low walnut credenza with sliding doors along ONE wall (not in front of windows).",
+		"Add a sputnik or arc floor lamp and a ceramic table lamp with drum shade.",
+		"Include abstract geometric art and a sunburst mirror on the walls.",
+		"Add sculptural ceramics, a few hardback books, and a snake plant in a tapered planter.",
+		"Use a geometric or solid wool area rug in warm tones.",
+	)
+}
+
+func buildLivingRoomCoastal() string {
+	return buildPrompt("living room", "coastal",
+		"Add a slipcovered or linen sofa in white or sand with relaxed, deep seating.",
+		"Include a whitewashed wood or woven-top coffee table.",
+		"Add 1-2 rattan or wicker accent chairs with light cushions.",
+		"Include a light wood or whitewashed media console along ONE wall.",
+		"Add throw pillows in soft blues, seafoam, and white, with striped or textured linen.",
+		"Use a natural jute or sisal area rug, or a soft striped rug in blue and white.",
+		"Include airy decor: glass vases, driftwood accents, coral-shaped objects, a bowl of shells.",
+		"Add framed ocean photography or soft watercolor seascapes.",
+		"Include woven pendant or ceramic table lamps with white linen shades.",
+	)
+}
+
+func buildLivingRoomJapandi() string {
+	return buildPrompt("living room", "Japandi",
+		"Add a low-profile sofa with clean lines in oatmeal, warm gray, or muted clay linen.",
+		"Include a low coffee table in light oak or dark-stained ash with simple joinery.",
+		"Add one wooden lounge chair with a woven paper-cord or cane seat.",
+		"Include a low, minimalist wooden media console along ONE wall (not in front of windows).",
+		"Add a paper lantern floor lamp or a simple ceramic table lamp.",
+		"Include one or two pieces of restrained art: ink wash or muted abstract prints.",
+		"Add a few handmade ceramics, a branch in a stoneware vase, and one sculptural plant.",
+		"Use a flat-woven wool or jute area rug in a muted neutral.",
+		"Keep the room uncluttered, with generous empty space around each piece.",
+	)
+}
@@ -0,0 +1,122 @@
+package prompt
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Prompts are written with US furniture sizes: imperial units and US bed
+// names. Localize rewrites them for a project's locale so a model asked for a
+// "king" bed in a London flat draws what a UK buyer would call one.
+
+var (
+	// bedSize matches a US bed name with an optional "-size" and the bed it describes.
+	bedSize = regexp.MustCompile(`(?i)\b(california king|king|queen|full|twin)((?:[- ]size[d]?)?)(\s+beds?)\b`)
+	// areaSize matches a width by length in feet, as rug sizes are given.
+	areaSize = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*(?:x|×|by)\s*(\d+(?:\.\d+)?)[ -]?(?:ft|feet|foot)\b`)
+	// feet and inches match a single length.
+	feet   = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)[ -]?(?:ft|feet|foot)\b`)
+	inches = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)[ -]?(?:inch|inches)\b`)
+)
+
+// ukBeds maps US bed names to the UK and Irish names for the nearest size.
+var ukBeds = map[string]string{
+	"california king": "super king",
+	"king":            "super king",
+	"queen":           "king",
+	"full":            "double",
+	"twin":            "single",
+}
+
+// metricBeds gives the mattress size sold in most metric countries for each US bed.
+var metricBeds = map[string]string{
+	"california king": "180 x 210 cm",
+	"king":            "180 x 200 cm",
+	"queen":           "160 x 200 cm",
+	"full":            "140 x 200 cm",
+	"twin":            "90 x 200 cm",
+}
+
+// imperialRegions still size furniture and rugs in feet and inches.
+var imperialRegions = map[string]bool{"US": true, "CA": true, "LR": true, "MM": true}
+
+// ValidLocale reports whether locale is a language-REGION tag such as "en-GB".
+func ValidLocale(locale string) bool {
+	lang, region, ok := strings.Cut(locale, "-")
+	return ok && len(lang) == 2 && len(region) == 2 &&
+		strings.ToLower(lang) == lang && strings.ToUpper(region) == region &&
+		isLetters(lang) && isLetters(region)
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// Localize rewrites the furniture sizes in text for locale. The US and other
+// countries that use imperial sizes get the text unchanged, as does an empty
+// or malformed locale. The UK and Ireland get their own bed names; Australia
+// and New Zealand keep US bed names, which match their sizes; elsewhere beds
+// are given as metric mattress sizes. Lengths become centimeters or meters
+// outside the imperial countries.
+func Localize(text, locale string) string {
+	if !ValidLocale(locale) {
+		return text
+	}
+	region := locale[3:]
+	if imperialRegions[region] {
+		return text
+	}
+
+	switch region {
+	case "GB", "IE":
+		text = replaceBeds(text, func(name, size, bed string) string {
+			uk := ukBeds[name]
+			if uk == "double" || uk == "single" {
+				// "double-size bed" is not said in the UK.
+				size = ""
+			}
+			return uk + size + bed
+		})
+	case "AU", "NZ":
+	default:
+		text = replaceBeds(text, func(name, _, bed string) string { return metricBeds[name] + bed })
+	}
+
+	text = areaSize.ReplaceAllStringFunc(text, func(m string) string {
+		sub := areaSize.FindStringSubmatch(m)
+		return fmt.Sprintf("%d x %d cm", roundCM(sub[1], 30.48), roundCM(sub[2], 30.48))
+	})
+	text = feet.ReplaceAllStringFunc(text, func(m string) string {
+		meters := math.Round(parseFloat(feet.FindStringSubmatch(m)[1])*3.048) / 10
+		return strconv.FormatFloat(meters, 'f', -1, 64) + " m"
+	})
+	return inches.ReplaceAllStringFunc(text, func(m string) string {
+		return fmt.Sprintf("%d cm", int(math.Round(parseFloat(inches.FindStringSubmatch(m)[1])*2.54)))
+	})
+}
+
+// replaceBeds rewrites each bed size with name lowercased.
+func replaceBeds(text string, repl func(name, size, bed string) string) string {
+	return bedSize.ReplaceAllStringFunc(text, func(m string) string {
+		sub := bedSize.FindStringSubmatch(m)
+		return repl(strings.ToLower(sub[1]), sub[2], sub[3])
+	})
+}
+
+// roundCM converts a length to centimeters, rounded to 10 cm like rug sizes are.
+func roundCM(value string, cmPerUnit float64) int {
+	return int(math.Round(parseFloat(value)*cmPerUnit/10) * 10)
+}
+
+func parseFloat(s string) float64 {
+	n, _ := strconv.ParseFloat(s, 64)
+	return n
+}
@@ -0,0 +1,71 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalize(t *testing.T) {
+	const text = "Add a queen-size bed and two twin beds. Place an 8x10 ft rug, " +
+		"keep walkways 3 feet wide and hang art 60 inches up."
+
+	testCases := []struct {
+		name     string
+		locale   string
+		expected string
+	}{
+		{name: "success: empty locale keeps US phrasing", locale: "", expected: text},
+		{name: "success: US", locale: "en-US", expected: text},
+		{name: "success: Canada keeps imperial sizes", locale: "fr-CA", expected: text},
+		{name: "success: malformed locale is ignored", locale: "english", expected: text},
+		{
+			name:   "success: UK bed names and metric lengths",
+			locale: "en-GB",
+			expected: "Add a king-size bed and two single beds. Place an 240 x 300 cm rug, " +
+				"keep walkways 0.9 m wide and hang art 152 cm up.",
+		},
+		{
+			name:   "success: Australia keeps US bed names",
+			locale: "en-AU",
+			expected: "Add a queen-size bed and two twin beds. Place an 240 x 300 cm rug, " +
+				"keep walkways 0.9 m wide and hang art 152 cm up.",
+		},
+		{
+			name:   "success: metric mattress sizes elsewhere",
+			locale: "de-DE",
+			expected: "Add a 160 x 200 cm bed and two 90 x 200 cm beds. Place an 240 x 300 cm rug, " +
+				"keep walkways 0.9 m wide and hang art 152 cm up.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Localize(text, tc.locale))
+		})
+	}
+}
+
+func TestLocalize_BuiltInPrompts(t *testing.T) {
+	prompt := New().Build("bedroom", "modern", "")
+
+	assert.Contains(t, Localize(prompt, "en-GB"), "king-size bed (a double bed in small rooms)")
+	assert.Contains(t, Localize(prompt, "de-DE"), "160 x 200 cm bed (a 140 x 200 cm bed in small rooms)")
+	assert.NotContains(t, Localize(prompt, "de-DE"), "feet")
+}
+
+func TestValidLocale(t *testing.T) {
+	for locale, expected := range map[string]bool{
+		"en-GB":  true,
+		"de-DE":  true,
+		"":       false,
+		"en":     false,
+		"en-gb":  false,
+		"EN-GB":  false,
+		"en_GB":  false,
+		"e1-GB":  false,
+		"eng-GB": false,
+	} {
+		assert.Equal(t, expected, ValidLocale(locale), locale)
+	}
+}
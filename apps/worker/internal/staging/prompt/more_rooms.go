@@ -0,0 +1,573 @@
+package prompt
+
+// Basement prompts - finished basements staged as family or media rooms
+func buildBasementModern() string {
+	return buildPrompt("finished basement", "modern",
+		"Stage the space as a family or media room.",
+		"Add a sleek sectional in gray or charcoal facing ONE wall with a low media console.",
+		"Include a modern coffee table and a pair of accent chairs if space allows.",
+		"Add bright floor lamps to offset the limited natural light.",
+		"Use a large modern area rug to define the seating area.",
+		"Do NOT cover egress windows, sump pumps, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementContemporary() string {
+	return buildPrompt("finished basement", "contemporary",
+		"Stage the space as a family room with a comfortable sectional with rounded edges.",
+		"Include a coffee table with mixed materials and a media console.",
+		"Add layered lighting: floor lamps and table lamps with warm bulbs.",
+		"Include a textured area rug and soft throw pillows.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementTraditional() string {
+	return buildPrompt("finished basement", "traditional",
+		"Stage the space as a den with a classic sofa and coordinating armchairs.",
+		"Include a wooden coffee table and side tables.",
+		"Add table lamps with fabric shades for warm light.",
+		"Include a bookcase or game table if space allows.",
+		"Use a traditional patterned area rug.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementIndustrial() string {
+	return buildPrompt("finished basement", "industrial",
+		"Stage the space as a media or game room with a leather sofa.",
+		"Include a metal-and-wood coffee table and metal stools.",
+		"Add Edison bulb floor lamps.",
+		"Include open metal shelving against ONE wall.",
+		"Use a jute or dark flat-woven rug.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementScandinavian() string {
+	return buildPrompt("finished basement", "Scandinavian",
+		"Stage the space as a bright family room with a light-colored sofa.",
+		"Include a simple light wood coffee table and media console.",
+		"Add several white or light wood lamps to brighten the space.",
+		"Use a light-colored area rug and a few houseplants.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementBohemian() string {
+	return buildPrompt("finished basement", "bohemian",
+		"Stage the space as a relaxed lounge with a low sofa in earth tones and floor poufs.",
+		"Include a carved wood or rattan coffee table.",
+		"Add warm lighting: rattan pendants or ceramic table lamps.",
+		"Use a layered kilim rug and plants in woven baskets.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementFarmhouse() string {
+	return buildPrompt("finished basement", "modern farmhouse",
+		"Stage the space as a family room with a slipcovered sofa in white or oatmeal.",
+		"Include a reclaimed wood coffee table and a wooden media console.",
+		"Add black metal floor and table lamps.",
+		"Use a neutral jute rug and plaid throw pillows.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementMidCentury() string {
+	return buildPrompt("finished basement", "mid-century modern",
+		"Stage the space as a media room with a tufted sofa on tapered legs.",
+		"Include a walnut coffee table and a low walnut credenza along ONE wall.",
+		"Add an arc floor lamp and a ceramic table lamp.",
+		"Use a geometric wool rug in warm tones.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementCoastal() string {
+	return buildPrompt("finished basement", "coastal",
+		"Stage the space as a bright family room with a white linen sofa.",
+		"Include a whitewashed coffee table and rattan accent chairs.",
+		"Add ceramic lamps with linen shades to brighten the space.",
+		"Use a natural jute rug and soft blue pillows.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+func buildBasementJapandi() string {
+	return buildPrompt("finished basement", "Japandi",
+		"Stage the space as a calm lounge with a low sofa in oatmeal linen.",
+		"Include a low wooden coffee table.",
+		"Add paper lantern floor lamps for soft light.",
+		"Use a flat-woven wool rug and one sculptural plant.",
+		"Do NOT cover egress windows, utility panels, or mechanical equipment.",
+	)
+}
+
+// Garage prompts - garages are staged as clean, organized storage
+func buildGarageModern() string {
+	return buildPrompt("garage", "modern",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add wall-mounted cabinets or slatwall storage in white or gray along ONE wall.",
+		"Include a simple workbench with a few neatly arranged tools.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageContemporary() string {
+	return buildPrompt("garage", "contemporary",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add closed storage cabinets and a wall rack for bikes.",
+		"Include a workbench with a pegboard above it.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageTraditional() string {
+	return buildPrompt("garage", "traditional",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add wooden shelving with labeled storage bins.",
+		"Include a wooden workbench with hand tools on a pegboard.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageIndustrial() string {
+	return buildPrompt("garage", "industrial",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add steel shelving units and a rolling metal tool chest.",
+		"Include a heavy-duty workbench with a metal top.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageScandinavian() string {
+	return buildPrompt("garage", "Scandinavian",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add white wall-mounted storage and a light wood bench.",
+		"Include simple hooks for bikes and garden tools.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageBohemian() string {
+	return buildPrompt("garage", "bohemian",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add wooden shelving with woven storage baskets.",
+		"Include a potting bench with a few terra cotta pots.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageFarmhouse() string {
+	return buildPrompt("garage", "modern farmhouse",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add wooden shelving and galvanized storage bins.",
+		"Include a wooden workbench and a wall rack for garden tools.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageMidCentury() string {
+	return buildPrompt("garage", "mid-century modern",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add closed cabinets in a muted accent color along ONE wall.",
+		"Include a simple workbench with a pegboard.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageCoastal() string {
+	return buildPrompt("garage", "coastal",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add white shelving with bins and a wall rack for bikes and surfboards or paddleboards.",
+		"Include a simple bench by the house door.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+func buildGarageJapandi() string {
+	return buildPrompt("garage", "Japandi",
+		"Stage the space as a clean, organized garage, NOT a living space.",
+		"Add a few closed wooden cabinets and a low bench.",
+		"Include minimal wall hooks; keep every surface clear.",
+		"Keep the parking area clear. Do NOT add furniture, beds, or sofas.",
+		"Do NOT alter the garage door, concrete floor, or water heater.",
+	)
+}
+
+// Nursery prompts
+func buildNurseryModern() string {
+	return buildPrompt("nursery", "modern",
+		"Add a crib with clean lines in white or natural wood against a wall away from the window.",
+		"Include a modern dresser that doubles as a changing table.",
+		"Add a glider or rocking chair with a small side table in a corner.",
+		"Use a soft area rug and simple geometric wall art.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryContemporary() string {
+	return buildPrompt("nursery", "contemporary",
+		"Add a crib with soft rounded edges against a wall away from the window.",
+		"Include a dresser with a changing pad on top.",
+		"Add an upholstered glider in a corner.",
+		"Use a textured area rug and soft, muted wall art.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryTraditional() string {
+	return buildPrompt("nursery", "traditional",
+		"Add a classic spindle or sleigh crib against a wall away from the window.",
+		"Include a wooden dresser with a changing pad on top.",
+		"Add a traditional rocking chair in a corner.",
+		"Use a soft patterned rug and framed nursery prints.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryIndustrial() string {
+	return buildPrompt("nursery", "industrial",
+		"Add a crib with a metal or dark wood frame against a wall away from the window.",
+		"Include a wood-and-metal dresser with a changing pad.",
+		"Add a leather or canvas glider in a corner.",
+		"Use a neutral jute rug and simple black-framed prints.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryScandinavian() string {
+	return buildPrompt("nursery", "Scandinavian",
+		"Add a light wood crib against a wall away from the window.",
+		"Include a white dresser with a changing pad.",
+		"Add a light upholstered glider in a corner.",
+		"Use a white or cream rug and a few soft toys on a simple shelf.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryBohemian() string {
+	return buildPrompt("nursery", "bohemian",
+		"Add a rattan or cane crib against a wall away from the window.",
+		"Include a wooden dresser with a changing pad.",
+		"Add a rattan rocking chair in a corner.",
+		"Use a soft patterned rug, a woven wall hanging, and plants out of reach.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryFarmhouse() string {
+	return buildPrompt("nursery", "modern farmhouse",
+		"Add a white or natural wood crib against a wall away from the window.",
+		"Include a wooden dresser with a changing pad and a woven basket.",
+		"Add a rocking chair with a knit throw in a corner.",
+		"Use a neutral rug and simple botanical prints.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryMidCentury() string {
+	return buildPrompt("nursery", "mid-century modern",
+		"Add a walnut crib with tapered legs against a wall away from the window.",
+		"Include a low walnut dresser with a changing pad.",
+		"Add an upholstered glider in a muted accent color.",
+		"Use a geometric rug and playful abstract prints.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryCoastal() string {
+	return buildPrompt("nursery", "coastal",
+		"Add a white crib against a wall away from the window.",
+		"Include a whitewashed dresser with a changing pad.",
+		"Add a light upholstered glider in a corner.",
+		"Use a soft blue-and-white rug and gentle seascape prints.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+func buildNurseryJapandi() string {
+	return buildPrompt("nursery", "Japandi",
+		"Add a low light wood crib against a wall away from the window.",
+		"Include a simple wooden dresser with a changing pad.",
+		"Add a low upholstered chair in oatmeal linen.",
+		"Use a flat-woven rug; keep decor to a single calm print.",
+		"Do NOT add adult beds, desks, or TVs.",
+	)
+}
+
+// Laundry room prompts - laundry rooms need minimal staging
+func buildLaundryRoomModern() string {
+	return buildPrompt("laundry room", "modern",
+		"Add a few matching storage bins and a folded stack of towels.",
+		"Include a modern laundry basket and a small plant.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+		"Keep counters mostly clear.",
+	)
+}
+
+func buildLaundryRoomContemporary() string {
+	return buildPrompt("laundry room", "contemporary",
+		"Add fabric storage bins and neatly folded towels.",
+		"Include a woven laundry basket and a glass detergent dispenser.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+	)
+}
+
+func buildLaundryRoomTraditional() string {
+	return buildPrompt("laundry room", "traditional",
+		"Add wicker baskets and neatly folded towels.",
+		"Include a classic framed print and a small runner.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+	)
+}
+
+func buildLaundryRoomIndustrial() string {
+	return buildPrompt("laundry room", "industrial",
+		"Add wire baskets and a metal laundry cart.",
+		"Include a few glass jars for detergent.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+	)
+}
+
+func buildLaundryRoomScandinavian() string {
+	return buildPrompt("laundry room", "Scandinavian",
+		"Add white and light wood storage bins and folded white towels.",
+		"Include a small plant.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+		"Keep very minimal.",
+	)
+}
+
+func buildLaundryRoomBohemian() string {
+	return buildPrompt("laundry room", "bohemian",
+		"Add woven baskets and towels in warm earth tones.",
+		"Include a small patterned runner and a trailing plant.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+	)
+}
+
+func buildLaundryRoomFarmhouse() string {
+	return buildPrompt("laundry room", "modern farmhouse",
+		"Add galvanized and wicker baskets and folded white towels.",
+		"Include a vintage-style sign or framed print.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+	)
+}
+
+func buildLaundryRoomMidCentury() string {
+	return buildPrompt("laundry room", "mid-century modern",
+		"Add storage bins in a muted accent color and folded towels.",
+		"Include a small snake plant in a tapered pot.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+	)
+}
+
+func buildLaundryRoomCoastal() string {
+	return buildPrompt("laundry room", "coastal",
+		"Add white and soft blue baskets and folded towels.",
+		"Include a glass jar of shells and a light striped runner.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+	)
+}
+
+func buildLaundryRoomJapandi() string {
+	return buildPrompt("laundry room", "Japandi",
+		"Add a few wooden or linen storage bins.",
+		"Include one stoneware jar for detergent.",
+		"Do NOT add or replace washers, dryers, sinks, or cabinets.",
+		"Keep very minimal.",
+	)
+}
+
+// Home gym prompts
+func buildHomeGymModern() string {
+	return buildPrompt("home gym", "modern",
+		"Add rubber gym flooring mats over part of the floor, a treadmill or rowing machine, "+
+			"and a sleek dumbbell rack.",
+		"Include a large frameless mirror leaning against or mounted on ONE wall.",
+		"Add a bench and a rolled yoga mat.",
+		"Do NOT overcrowd the space; leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymContemporary() string {
+	return buildPrompt("home gym", "contemporary",
+		"Add an exercise bike or rowing machine and a dumbbell rack.",
+		"Include a large mirror on ONE wall and a yoga mat with blocks.",
+		"Add a plant and a small towel shelf.",
+		"Leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymTraditional() string {
+	return buildPrompt("home gym", "traditional",
+		"Add a treadmill and a wooden rack with dumbbells.",
+		"Include a framed mirror on ONE wall.",
+		"Add a wooden bench and a basket of towels.",
+		"Leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymIndustrial() string {
+	return buildPrompt("home gym", "industrial",
+		"Add a squat rack or power cage, a barbell with plates, and rubber flooring mats.",
+		"Include a kettlebell set and a black metal dumbbell rack.",
+		"Add a large metal-framed mirror on ONE wall.",
+		"Leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymScandinavian() string {
+	return buildPrompt("home gym", "Scandinavian",
+		"Add a light wood wall bar or rowing machine and a yoga mat.",
+		"Include light-colored dumbbells on a simple rack.",
+		"Add a large mirror and a houseplant.",
+		"Keep very minimal with open floor.",
+	)
+}
+
+func buildHomeGymBohemian() string {
+	return buildPrompt("home gym", "bohemian",
+		"Stage the space as a yoga and fitness room with patterned yoga mats and floor cushions.",
+		"Include a woven basket of blocks and straps and a set of light dumbbells.",
+		"Add plants and a macramé wall hanging.",
+		"Leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymFarmhouse() string {
+	return buildPrompt("home gym", "modern farmhouse",
+		"Add an exercise bike or treadmill and a wooden dumbbell rack.",
+		"Include a large arched mirror on ONE wall.",
+		"Add a wooden bench and a wire basket of towels.",
+		"Leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymMidCentury() string {
+	return buildPrompt("home gym", "mid-century modern",
+		"Add a rowing machine and a walnut dumbbell rack.",
+		"Include a round mirror on ONE wall and a yoga mat.",
+		"Add a snake plant in a tapered planter.",
+		"Leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymCoastal() string {
+	return buildPrompt("home gym", "coastal",
+		"Add an exercise bike or rowing machine and light dumbbells on a white rack.",
+		"Include a large mirror and a yoga mat in soft blue.",
+		"Add a woven basket of towels.",
+		"Leave open floor for exercise.",
+	)
+}
+
+func buildHomeGymJapandi() string {
+	return buildPrompt("home gym", "Japandi",
+		"Stage the space as a calm fitness room with a natural yoga mat and a wooden rowing machine.",
+		"Include a low wooden bench and a single set of dumbbells.",
+		"Keep very minimal with open floor.",
+	)
+}
+
+// Sunroom prompts
+func buildSunroomModern() string {
+	return buildPrompt("sunroom", "modern",
+		"Add a pair of sleek lounge chairs or a low sofa in light, fade-resistant fabric.",
+		"Include a simple coffee table and a side table.",
+		"Add several large potted plants near the windows.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomContemporary() string {
+	return buildPrompt("sunroom", "contemporary",
+		"Add a comfortable sofa with soft cushions and a pair of accent chairs.",
+		"Include a coffee table with mixed materials.",
+		"Add lush potted plants and a textured rug.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomTraditional() string {
+	return buildPrompt("sunroom", "traditional",
+		"Add a wicker or upholstered settee with matching armchairs.",
+		"Include a wooden coffee table and a tea set.",
+		"Add potted ferns and flowering plants and a classic rug.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomIndustrial() string {
+	return buildPrompt("sunroom", "industrial",
+		"Add metal-framed lounge chairs with canvas cushions.",
+		"Include a metal-and-wood coffee table.",
+		"Add plants in galvanized or concrete planters.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomScandinavian() string {
+	return buildPrompt("sunroom", "Scandinavian",
+		"Add a light-colored sofa or lounge chairs with light wood frames.",
+		"Include a simple light wood coffee table.",
+		"Add white ceramic planters with green plants and a sheepskin throw.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomBohemian() string {
+	return buildPrompt("sunroom", "bohemian",
+		"Add a rattan daybed or peacock chair with mixed-print cushions.",
+		"Include floor poufs and a low carved wood table.",
+		"Add an abundance of plants: palms, hanging planters, and trailing vines.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomFarmhouse() string {
+	return buildPrompt("sunroom", "modern farmhouse",
+		"Add a slipcovered loveseat and a pair of wooden rocking chairs.",
+		"Include a rustic wood coffee table.",
+		"Add potted herbs and a galvanized planter.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomMidCentury() string {
+	return buildPrompt("sunroom", "mid-century modern",
+		"Add a pair of molded lounge chairs and a low sofa with tapered legs.",
+		"Include a round walnut coffee table.",
+		"Add architectural plants in tapered planters.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomCoastal() string {
+	return buildPrompt("sunroom", "coastal",
+		"Add white wicker seating with soft blue and white cushions.",
+		"Include a whitewashed coffee table.",
+		"Add palms and hydrangeas and a jute rug.",
+		"Do NOT cover or block the windows.",
+	)
+}
+
+func buildSunroomJapandi() string {
+	return buildPrompt("sunroom", "Japandi",
+		"Add a low wooden lounge chair and a floor cushion in oatmeal linen.",
+		"Include a low wooden table with a stoneware tea set.",
+		"Add a bonsai or a small Japanese maple.",
+		"Do NOT cover or block the windows.",
+	)
+}
@@ -98,6 +98,52 @@ func buildKitchenScandinavian() string {
 	)
 }
 
+func buildKitchenBohemian() string {
+	return buildPrompt("kitchen", "bohemian",
+		"Add rattan or woven-seat bar stools.",
+		"Include a ceramic fruit bowl, a few potted herbs, and a woven basket on the counter.",
+		"Add a rattan or woven pendant light if no fixtures exist.",
+		"Keep counters mostly clear.",
+	)
+}
+
+func buildKitchenFarmhouse() string {
+	return buildPrompt("kitchen", "modern farmhouse",
+		"Add wooden bar stools with black metal frames or cross-back seats.",
+		"Include a wooden cutting board, a stoneware crock of utensils, and a bowl of lemons.",
+		"Add black metal or enamel pendant lights if no fixtures exist.",
+		"Do NOT add appliances to existing kitchens.",
+		"Keep counters mostly clear.",
+	)
+}
+
+func buildKitchenMidCentury() string {
+	return buildPrompt("kitchen", "mid-century modern",
+		"Add molded or walnut bar stools with tapered legs.",
+		"Include a colorful ceramic bowl and a teak serving board.",
+		"Add globe or cone pendant lights if no fixtures exist.",
+		"Keep counters mostly clear.",
+	)
+}
+
+func buildKitchenCoastal() string {
+	return buildPrompt("kitchen", "coastal",
+		"Add woven rattan or whitewashed wood bar stools.",
+		"Include a glass bowl of citrus and a small potted herb.",
+		"Add woven or clear glass pendant lights if no fixtures exist.",
+		"Keep counters light, airy, and mostly clear.",
+	)
+}
+
+func buildKitchenJapandi() string {
+	return buildPrompt("kitchen", "Japandi",
+		"Add simple low-back wooden bar stools in light oak or dark ash.",
+		"Include one stoneware bowl and a wooden board; nothing else on the counter.",
+		"Add paper or matte ceramic pendant lights if no fixtures exist.",
+		"Keep very minimal and calm.",
+	)
+}
+
 // Bathroom prompts - bathrooms need minimal staging
 func buildBathroomModern() string {
 	return buildPrompt("bathroom", "modern",
@@ -140,6 +186,46 @@ func buildBathroomScandinavian() string {
 	)
 }
 
+func buildBathroomBohemian() string {
+	return buildPrompt("bathroom", "bohemian",
+		"Add towels in warm earth tones with fringe or woven texture.",
+		"Include a woven basket and a trailing plant.",
+		"Add a small patterned bath mat.",
+	)
+}
+
+func buildBathroomFarmhouse() string {
+	return buildPrompt("bathroom", "modern farmhouse",
+		"Add white waffle or striped towels.",
+		"Include a wooden tray with a glass soap dispenser and a small vase of greenery.",
+		"Add a woven basket with rolled towels.",
+	)
+}
+
+func buildBathroomMidCentury() string {
+	return buildPrompt("bathroom", "mid-century modern",
+		"Add solid towels in a muted accent color (mustard, teal, or olive).",
+		"Include a few sculptural ceramic accessories.",
+		"Add a small snake plant in a tapered pot.",
+	)
+}
+
+func buildBathroomCoastal() string {
+	return buildPrompt("bathroom", "coastal",
+		"Add white and soft blue towels.",
+		"Include a glass jar of shells and a small woven basket.",
+		"Add a light striped bath mat.",
+	)
+}
+
+func buildBathroomJapandi() string {
+	return buildPrompt("bathroom", "Japandi",
+		"Add linen towels in oatmeal or warm gray.",
+		"Include a wooden bath stool or tray and one stoneware accessory.",
+		"Keep very minimal.",
+	)
+}
+
 // Dining Room prompts
 func buildDiningRoomModern() string {
 	return buildPrompt("dining room", "modern",
@@ -191,6 +277,56 @@ func buildDiningRoomScandinavian() string {
 	)
 }
 
+func buildDiningRoomBohemian() string {
+	return buildPrompt("dining room", "bohemian",
+		"Add a carved or reclaimed wood dining table.",
+		"Include mismatched rattan, cane, or upholstered chairs.",
+		"Add a rattan or woven pendant above the table.",
+		"Include a centerpiece of dried grasses or pampas in a ceramic vase.",
+		"Add a vintage-style rug under the table if space allows.",
+	)
+}
+
+func buildDiningRoomFarmhouse() string {
+	return buildPrompt("dining room", "modern farmhouse",
+		"Add a long wooden trestle or farmhouse dining table.",
+		"Include cross-back or Windsor chairs, optionally a bench on one side.",
+		"Add a black iron or wood-beaded chandelier above the table.",
+		"Include a wooden dough bowl or galvanized vase with greenery as a centerpiece.",
+		"Add a wooden hutch or sideboard if space allows.",
+	)
+}
+
+func buildDiningRoomMidCentury() string {
+	return buildPrompt("dining room", "mid-century modern",
+		"Add an oval or round walnut dining table with tapered legs.",
+		"Include molded shell or upholstered chairs with wooden legs.",
+		"Add a sputnik or globe pendant above the table.",
+		"Include a sculptural ceramic vase as a centerpiece.",
+		"Add a low walnut sideboard if space allows.",
+	)
+}
+
+func buildDiningRoomCoastal() string {
+	return buildPrompt("dining room", "coastal",
+		"Add a whitewashed or light oak dining table.",
+		"Include woven rattan or white slipcovered chairs.",
+		"Add a woven or glass pendant light above the table.",
+		"Include a glass vase with white flowers or a bowl of shells as a centerpiece.",
+		"Add a light wood sideboard if space allows.",
+	)
+}
+
+func buildDiningRoomJapandi() string {
+	return buildPrompt("dining room", "Japandi",
+		"Add a low-slung wooden dining table in light oak or dark ash.",
+		"Include simple wooden chairs with woven paper-cord seats.",
+		"Add a paper or linen pendant light above the table.",
+		"Include a single stoneware vase with a branch.",
+		"Keep very minimal.",
+	)
+}
+
 // Office prompts
 func buildOfficeModern() string {
 	return buildPrompt("office", "modern",
@@ -242,6 +378,56 @@ func buildOfficeScandinavian() string {
 	)
 }
 
+func buildOfficeBohemian() string {
+	return buildPrompt("office", "bohemian",
+		"Add a vintage wooden desk.",
+		"Include a rattan or upholstered chair.",
+		"Add a ceramic or rattan desk lamp.",
+		"Include open shelving with books, baskets, and trailing plants.",
+		"Add a small patterned rug.",
+	)
+}
+
+func buildOfficeFarmhouse() string {
+	return buildPrompt("office", "modern farmhouse",
+		"Add a wooden desk with a simple trestle or turned legs.",
+		"Include an upholstered or wooden desk chair.",
+		"Add a black metal desk lamp.",
+		"Include a wooden bookcase with baskets.",
+		"Add a small potted plant.",
+	)
+}
+
+func buildOfficeMidCentury() string {
+	return buildPrompt("office", "mid-century modern",
+		"Add a walnut desk with tapered legs.",
+		"Include a molded or leather office chair.",
+		"Add a brass or enamel desk lamp.",
+		"Include a low walnut bookcase or credenza.",
+		"Add a few books and a ceramic planter.",
+	)
+}
+
+func buildOfficeCoastal() string {
+	return buildPrompt("office", "coastal",
+		"Add a whitewashed or light wood desk.",
+		"Include a woven or white upholstered chair.",
+		"Add a ceramic desk lamp with a linen shade.",
+		"Include white shelving with baskets and a few books.",
+		"Add a framed seascape print.",
+	)
+}
+
+func buildOfficeJapandi() string {
+	return buildPrompt("office", "Japandi",
+		"Add a simple wooden desk in light oak or dark ash.",
+		"Include a minimal wooden or upholstered chair.",
+		"Add a paper or matte ceramic desk lamp.",
+		"Include a low wooden shelf with few objects.",
+		"Keep the desk clear.",
+	)
+}
+
 // Entryway prompts
 func buildEntrywayModern() string {
 	return buildPrompt("entryway", "modern",
@@ -292,6 +478,55 @@ func buildEntrywayScandinavian() string {
 	)
 }
 
+func buildEntrywayBohemian() string {
+	return buildPrompt("entryway", "bohemian",
+		"Add a carved wood or rattan console or bench.",
+		"Include a round rattan or carved mirror.",
+		"Add a woven basket and a potted plant.",
+		"Include a small vintage-style runner.",
+		"Do NOT block entry path.",
+	)
+}
+
+func buildEntrywayFarmhouse() string {
+	return buildPrompt("entryway", "modern farmhouse",
+		"Add a wooden bench or console with black iron accents.",
+		"Include a large round or arched mirror.",
+		"Add a woven basket for shoes and a vase of greenery.",
+		"Include coat hooks if wall space exists.",
+		"Keep pathway clear.",
+	)
+}
+
+func buildEntrywayMidCentury() string {
+	return buildPrompt("entryway", "mid-century modern",
+		"Add a slim walnut console with tapered legs.",
+		"Include a sunburst or round mirror.",
+		"Add a ceramic table lamp.",
+		"Minimal accessories.",
+		"Do NOT block entry path.",
+	)
+}
+
+func buildEntrywayCoastal() string {
+	return buildPrompt("entryway", "coastal",
+		"Add a whitewashed console or woven bench.",
+		"Include a round mirror with a rope or light wood frame.",
+		"Add a woven basket and a glass vase.",
+		"Keep entry clear and airy.",
+	)
+}
+
+func buildEntrywayJapandi() string {
+	return buildPrompt("entryway", "Japandi",
+		"Add a low wooden bench.",
+		"Include a simple round or rectangular mirror.",
+		"Add a single stoneware vase.",
+		"Include minimal coat hooks if wall space exists.",
+		"Keep very minimal.",
+	)
+}
+
 // Outdoor/Patio prompts
 func buildOutdoorModern() string {
 	return buildOutdoorPrompt("modern",
@@ -351,6 +586,59 @@ func buildOutdoorScandinavian() string {
 	)
 }
 
+func buildOutdoorBohemian() string {
+	return buildOutdoorPrompt("bohemian",
+		"Add all-weather wicker or rattan lounge chairs and a low outdoor sofa with cushions "+
+			"in terracotta, ochre, and olive performance fabric.",
+		"Include a low teak or mosaic-top outdoor coffee table.",
+		"Add outdoor floor cushions and poufs in weather-proof patterned fabrics.",
+		"Include a patterned polypropylene outdoor rug.",
+		"Add clustered terra cotta planters with palms, grasses, and trailing plants, "+
+			"and lantern or string lighting.",
+	)
+}
+
+func buildOutdoorFarmhouse() string {
+	return buildOutdoorPrompt("modern farmhouse",
+		"Add wooden Adirondack chairs or a teak bench in white, natural, or black finish.",
+		"Include a rustic teak or acacia outdoor dining table with cross-back chairs.",
+		"Add galvanized metal planters and wooden crates with lavender, herbs, and boxwoods.",
+		"Include outdoor cushions in white, cream, or buffalo-check performance fabric.",
+		"Add black metal lanterns or Edison-bulb string lights.",
+	)
+}
+
+func buildOutdoorMidCentury() string {
+	return buildOutdoorPrompt("mid-century modern",
+		"Add powder-coated steel or teak lounge chairs with low, angled profiles and tapered legs.",
+		"Include a round teak or concrete side table and a low outdoor coffee table.",
+		"Add cylindrical or tapered concrete planters with architectural plants: snake plants, agave, "+
+			"or ornamental grasses.",
+		"Include outdoor cushions in mustard, teal, or burnt orange performance fabric.",
+		"Add globe outdoor lights or simple cone wall fixtures.",
+	)
+}
+
+func buildOutdoorCoastal() string {
+	return buildOutdoorPrompt("coastal",
+		"Add white or light gray all-weather wicker seating or whitewashed teak chairs.",
+		"Include a light teak outdoor coffee or dining table.",
+		"Add planters with ornamental grasses, hydrangeas, and small palms.",
+		"Include outdoor cushions and pillows in white, navy, and soft blue stripes of performance fabric.",
+		"Add rope-accented lanterns or simple outdoor string lights.",
+	)
+}
+
+func buildOutdoorJapandi() string {
+	return buildOutdoorPrompt("Japandi",
+		"Add low teak or dark-stained wood outdoor seating with simple, clean lines.",
+		"Include a low wooden outdoor table.",
+		"Add a few stone or matte ceramic planters with Japanese maple, bamboo, or moss.",
+		"Include outdoor cushions in oatmeal or warm gray performance fabric.",
+		"Add a stone lantern or low path lights; keep the space uncluttered.",
+	)
+}
+
 // Default/fallback prompts
 func buildDefaultModern() string {
 	return buildPrompt("room", "modern",
@@ -394,3 +682,44 @@ func buildDefaultScandinavian() string {
 		"Keep very minimal and bright.",
 	)
 }
+
+func buildDefaultBohemian() string {
+	return buildPrompt("room", "bohemian",
+		"Add appropriate bohemian furniture in rattan and carved wood.",
+		"Include layered patterned textiles in warm earth tones.",
+		"Add plenty of plants in woven baskets.",
+		"Do NOT overcrowd the space.",
+	)
+}
+
+func buildDefaultFarmhouse() string {
+	return buildPrompt("room", "modern farmhouse",
+		"Add appropriate farmhouse furniture in natural and distressed wood.",
+		"Include black metal accents and neutral linen textiles.",
+		"Create a warm, welcoming space.",
+	)
+}
+
+func buildDefaultMidCentury() string {
+	return buildPrompt("room", "mid-century modern",
+		"Add appropriate mid-century furniture in walnut with tapered legs.",
+		"Include sculptural lighting and accents in mustard, teal, or rust.",
+		"Keep design clean and uncluttered.",
+	)
+}
+
+func buildDefaultCoastal() string {
+	return buildPrompt("room", "coastal",
+		"Add appropriate coastal furniture in whitewashed wood and rattan.",
+		"Include white, sand, and soft blue textiles.",
+		"Keep the space light and airy.",
+	)
+}
+
+func buildDefaultJapandi() string {
+	return buildPrompt("room", "Japandi",
+		"Add appropriate low, simple furniture in light oak or dark ash.",
+		"Include muted natural textiles and handmade ceramics.",
+		"Keep very minimal and calm.",
+	)
+}
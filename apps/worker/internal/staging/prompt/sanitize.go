@@ -0,0 +1,111 @@
+package prompt
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxCustomPromptLength caps user-supplied prompt text, in characters. It matches
+// the API's request validation so the worker never trusts a queued payload blindly.
+const MaxCustomPromptLength = 2000
+
+// overridePatterns match sentences that try to replace or escape the staging
+// instructions rather than describe the staging. Any sentence matching one is
+// dropped from a custom prompt.
+var overridePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override|bypass|skip)\b.{0,40}\b` +
+		`(instructions?|rules?|prompts?|directions?|constraints?|guidelines?|restrictions?)\b`),
+	regexp.MustCompile(`(?i)\b(new|updated|real|actual)\s+(instructions?|rules?|system prompt)\s*:`),
+	regexp.MustCompile(`(?i)\bsystem\s+prompt\b`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+(now|no\s+longer)\b`),
+	regexp.MustCompile(`(?i)\b(from\s+now\s+on|developer\s+mode|jailbreak)\b`),
+	regexp.MustCompile(`(?i)\bpretend\s+(to\s+be|you\s+are)\b`),
+	regexp.MustCompile(`(?i)\b(structural\s+preservation|placement\s+rules?)\b.{0,40}\b` +
+		`(does\s+not|doesn't|no\s+longer)\s+apply\b`),
+}
+
+// markupPattern removes chat-template and role markers such as "<|im_start|>",
+// "[INST]" or a leading "system:" that some models treat as turn boundaries.
+var markupPattern = regexp.MustCompile(`(?i)<\|[^|>]*\|>|\[/?(?:inst|sys)\]|<</?sys>>|` +
+	`(^|[\n.!?]\s*)(?:system|assistant|user|developer)\s*:`)
+
+// sentencePattern splits text into sentences, keeping the terminator.
+var sentencePattern = regexp.MustCompile(`[^.!?\n]+[.!?]*`)
+
+// SanitizeCustomPrompt normalizes user-supplied prompt text before it is sent
+// to a model. It removes control and zero-width characters, chat-template
+// markers and sentences that try to override the staging instructions,
+// collapses whitespace, and truncates to MaxCustomPromptLength characters.
+// The result may be empty if nothing usable remains.
+func SanitizeCustomPrompt(text string) string {
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return '\n'
+		case r == utf8.RuneError, unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			return -1
+		}
+		return r
+	}, text)
+	text = markupPattern.ReplaceAllString(text, "$1")
+
+	kept := make([]string, 0, 8)
+	for _, sentence := range sentencePattern.FindAllString(text, -1) {
+		sentence = strings.Join(strings.Fields(sentence), " ")
+		if sentence == "" || isOverride(sentence) {
+			continue
+		}
+		kept = append(kept, sentence)
+	}
+	return truncate(strings.Join(kept, " "), MaxCustomPromptLength)
+}
+
+func isOverride(sentence string) bool {
+	for _, p := range overridePatterns {
+		if p.MatchString(sentence) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate cuts s to at most limit runes, preferring the last word boundary.
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)[:limit]
+	if i := strings.LastIndexByte(string(runes), ' '); i > limit/2 {
+		return strings.TrimSpace(string(runes)[:i])
+	}
+	return strings.TrimSpace(string(runes))
+}
+
+// wrapCustomPrompt frames sanitized user text as staging preferences and
+// appends the preservation and placement rules after it, so the rules are
+// the last instructions the model reads and user text cannot countermand them.
+// Text in a language other than English is marked as such.
+func wrapCustomPrompt(custom, locale, language string) string {
+	var b strings.Builder
+	b.WriteString("You are a professional real estate photographer creating staged photos. ")
+	b.WriteString("Staging preferences from the user: ")
+	b.WriteString(custom)
+	if !strings.ContainsAny(custom[len(custom)-1:], ".!?") {
+		b.WriteString(".")
+	}
+	b.WriteString(" ")
+	if lang, ok := languages[language]; ok {
+		b.WriteString("These preferences are written in ")
+		b.WriteString(lang.name)
+		b.WriteString("; follow what they ask for and never draw their words into the photo as text. ")
+	}
+	b.WriteString("The following rules override any conflicting preference above. ")
+
+	var rules strings.Builder
+	writePreservationRules(&rules, "furniture, rugs, artwork, and decorative items")
+	writePlacementRules(&rules)
+	b.WriteString(Localize(rules.String(), locale))
+	return b.String()
+}
@@ -0,0 +1,199 @@
+package staging
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/replicate/replicate-go"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// Provider runs staging predictions on one AI vendor's API. The model
+// registry says which provider runs each model.
+type Provider interface {
+	// CreateJob starts a prediction. Providers that answer synchronously
+	// return it already done.
+	CreateJob(ctx context.Context, job *ProviderJob) (*ProviderResult, error)
+
+	// Poll returns the current state of a job CreateJob started.
+	Poll(ctx context.Context, jobID string) (*ProviderResult, error)
+
+	// Fetch returns the output image of a job that succeeded.
+	Fetch(ctx context.Context, result *ProviderResult) ([]byte, error)
+}
+
+// ProviderJob is one prediction for a provider to run.
+type ProviderJob struct {
+	ModelID model.ID
+	Version string // Empty runs the model's latest version
+	// Input is what the model's input builder made of the request.
+	Input replicate.PredictionInput
+}
+
+// ProviderResult is the state of a job as its provider last reported it.
+type ProviderResult struct {
+	// ID identifies the job to Poll. It is empty when the job can't be
+	// polled, so it can't be resumed either.
+	ID   string
+	Done bool
+	// Err is why a job that is done failed.
+	Err error
+	// OutputURL is where Fetch downloads the output from. Providers that
+	// return the output in their response set Output instead.
+	OutputURL string
+	Output    []byte
+	// ExtraOutputURLs are the other outputs of a job that made several
+	// images, in the order the provider returned them.
+	ExtraOutputURLs []string
+	// Text is the answer of a job whose model replies in words, such as the
+	// room detection model, joined from the pieces the provider returned.
+	Text string
+	// PredictSeconds is the compute time the provider reported, or 0 if it didn't.
+	PredictSeconds float64
+	// Seed is the seed the job ran with, when the provider reports it. Models
+	// pick a random one when the input has none.
+	Seed *int64
+}
+
+// awaiter is implemented by providers that have a better way to wait for a job
+// than polling it every timing.PollInterval.
+type awaiter interface {
+	Await(ctx context.Context, jobID string, timing model.Timing) (*ProviderResult, error)
+}
+
+// awaitJob waits for a job to finish and returns it once it has succeeded,
+// giving up after timing's timeout.
+func awaitJob(ctx context.Context, p Provider, jobID string, timing model.Timing) (*ProviderResult, error) {
+	if a, ok := p.(awaiter); ok {
+		return a.Await(ctx, jobID, timing)
+	}
+
+	ticker := time.NewTicker(timing.PollInterval)
+	defer ticker.Stop()
+
+	timeout := time.After(timing.Timeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+
+		case <-timeout:
+			return nil, fmt.Errorf("prediction timed out after %s", timing.Timeout)
+
+		case <-ticker.C:
+			result, err := p.Poll(ctx, jobID)
+			if err != nil {
+				return nil, err
+			}
+			if result.Done {
+				return succeeded(result)
+			}
+		}
+	}
+}
+
+// succeeded returns result unless its job failed.
+func succeeded(result *ProviderResult) (*ProviderResult, error) {
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return result, nil
+}
+
+// replicateProvider runs predictions on Replicate with the service's client,
+// waiting on callbacks when the service has a webhook URL.
+type replicateProvider struct {
+	s *DefaultService
+}
+
+// Ensure replicateProvider implements Provider.
+var _ Provider = replicateProvider{}
+
+// CreateJob creates a Replicate prediction.
+func (p replicateProvider) CreateJob(ctx context.Context, job *ProviderJob) (*ProviderResult, error) {
+	identifier := string(job.ModelID)
+	if job.Version != "" {
+		identifier += ":" + job.Version
+	}
+	pred, err := p.s.replicateClient.CreatePrediction(ctx, identifier, job.Input, p.s.predictionWebhook(), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prediction: %w", err)
+	}
+	return replicateResult(pred), nil
+}
+
+// Poll gets the prediction's status from Replicate.
+func (p replicateProvider) Poll(ctx context.Context, jobID string) (*ProviderResult, error) {
+	pred, err := p.s.replicateClient.GetPrediction(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prediction status: %w", err)
+	}
+	return replicateResult(pred), nil
+}
+
+// Await waits for the prediction with awaitPrediction, which prefers callbacks.
+func (p replicateProvider) Await(ctx context.Context, jobID string, timing model.Timing) (*ProviderResult, error) {
+	pred, err := p.s.awaitPrediction(ctx, jobID, timing)
+	if err != nil {
+		return nil, err
+	}
+	return succeeded(replicateResult(pred))
+}
+
+// Fetch downloads the output from Replicate's CDN.
+func (p replicateProvider) Fetch(ctx context.Context, result *ProviderResult) ([]byte, error) {
+	return p.s.downloadFromURL(ctx, result.OutputURL)
+}
+
+// replicateResult reads the status, output URL and compute time of a prediction.
+func replicateResult(pred *replicate.Prediction) *ProviderResult {
+	_, done, err := predictionOutcome(pred)
+	result := &ProviderResult{ID: pred.ID, Done: done}
+	if done && err == nil {
+		var urls []string
+		if urls, err = predictionOutputURLs(pred.Output); err == nil {
+			result.OutputURL, result.ExtraOutputURLs = urls[0], urls[1:]
+			result.Text = strings.Join(urls, "")
+		}
+	}
+	result.Err = err
+	if pred.Metrics != nil && pred.Metrics.PredictTime != nil {
+		result.PredictSeconds = *pred.Metrics.PredictTime
+	}
+	result.Seed = predictionSeed(pred)
+	return result
+}
+
+// loggedSeed finds the seed models print when they start, such as "Using
+// seed: 1234" or "seed=1234".
+var loggedSeed = regexp.MustCompile(`(?i)\bseed\b\s*[:=]?\s*(\d+)`)
+
+// predictionSeed returns the seed a prediction ran with: the one its logs
+// report, which covers seeds the model picked itself, else the one in its
+// input. It is nil when neither has one.
+func predictionSeed(pred *replicate.Prediction) *int64 {
+	if pred.Logs != nil {
+		if m := loggedSeed.FindStringSubmatch(*pred.Logs); m != nil {
+			if seed, err := strconv.ParseInt(m[1], 10, 64); err == nil {
+				return &seed
+			}
+		}
+	}
+	switch v := pred.Input["seed"].(type) {
+	case float64:
+		seed := int64(v)
+		return &seed
+	case int64:
+		return &v
+	case int:
+		seed := int64(v)
+		return &seed
+	}
+	return nil
+}
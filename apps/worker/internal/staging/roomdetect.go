@@ -0,0 +1,129 @@
+package staging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/replicate/replicate-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/staging/prompt"
+)
+
+// DefaultRoomDetectionModel is the Replicate vision model that detects room
+// types. It is small and answers a question about the photo in text, and runs
+// on Replicate like the cut-out model.
+const DefaultRoomDetectionModel = "lucataco/moondream2"
+
+// detectableRoomTypes are the room types detection chooses from: those the
+// prompt library has prompts for.
+var detectableRoomTypes = prompt.New().RoomTypes()
+
+// roomDetectionQuestion asks the detection model for the room type as JSON.
+var roomDetectionQuestion = "Which room of a home does this real estate photo show? " +
+	"Answer with only a JSON object such as {\"room_type\": \"kitchen\", \"confidence\": 0.9}, " +
+	"where room_type is one of " + strings.Join(detectableRoomTypes, ", ") +
+	" and confidence is how sure you are, from 0 to 1."
+
+// roomDetectionConfig configures DetectRoomType.
+type roomDetectionConfig struct {
+	modelID       model.ID // Empty disables detection
+	minConfidence float64  // Detections below it are stored but not staged with
+}
+
+// DetectRoomType asks the room detection model which room the original shows.
+func (s *DefaultService) DetectRoomType(ctx context.Context, originalURL string) (*RoomDetection, error) {
+	if s.roomDetection.modelID == "" {
+		return nil, nil
+	}
+
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.DetectRoomType")
+	span.SetAttributes(attribute.String("model.id", string(s.roomDetection.modelID)))
+	defer span.End()
+
+	fileKey, original, err := s.fetchOriginal(ctx, span, originalURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = original.Close() }()
+	inputURL, inputFile, err := s.imageInput(ctx, s.roomDetection.modelID, fileKey, original)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "image input failed")
+		return nil, err
+	}
+	defer s.deleteInputFiles(ctx, inputFile)
+
+	detection, err := detectRoom(
+		ctx, replicateProvider{s: s}, s.roomDetection.modelID, s.timing(s.roomDetection.modelID), inputURL,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "room detection failed")
+		return nil, err
+	}
+	detection.Confident = detection.Confidence >= s.roomDetection.minConfidence
+
+	span.SetAttributes(
+		attribute.String("room.detected_type", detection.RoomType),
+		attribute.Float64("room.confidence", detection.Confidence),
+	)
+	span.SetStatus(codes.Ok, "room detected")
+	return detection, nil
+}
+
+// detectRoom runs the detection model on the photo in imageDataURL and reads
+// its answer.
+func detectRoom(
+	ctx context.Context, p Provider, modelID model.ID, timing model.Timing, imageDataURL string,
+) (*RoomDetection, error) {
+	result, err := p.CreateJob(ctx, &ProviderJob{
+		ModelID: modelID,
+		Input:   replicate.PredictionInput{"image": imageDataURL, "prompt": roomDetectionQuestion},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start room detection: %w", err)
+	}
+	if result.Done {
+		result, err = succeeded(result)
+	} else {
+		result, err = awaitJob(ctx, p, result.ID, timing)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("room detection failed: %w", err)
+	}
+	return parseRoomDetection(result.Text)
+}
+
+// parseRoomDetection reads the JSON object in the detection model's answer.
+// Models often wrap it in prose or a code fence, so only the outermost braces
+// are parsed.
+func parseRoomDetection(text string) (*RoomDetection, error) {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("room detection answer has no JSON object: %q", text)
+	}
+	var answer struct {
+		RoomType   string  `json:"room_type"`
+		Confidence float64 `json:"confidence"`
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &answer); err != nil {
+		return nil, fmt.Errorf("failed to parse room detection answer: %w", err)
+	}
+
+	roomType := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(answer.RoomType)), " ", "_")
+	if !slices.Contains(detectableRoomTypes, roomType) {
+		return nil, fmt.Errorf("room detection answered unknown room type %q", answer.RoomType)
+	}
+	if answer.Confidence < 0 || answer.Confidence > 1 {
+		return nil, fmt.Errorf("room detection confidence out of range: %v", answer.Confidence)
+	}
+	return &RoomDetection{RoomType: roomType, Confidence: answer.Confidence}, nil
+}
@@ -0,0 +1,123 @@
+package staging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// answeringProvider is a Provider whose jobs finish at once with a text answer.
+type answeringProvider struct {
+	text string
+	err  error
+	jobs []*ProviderJob
+}
+
+func (p *answeringProvider) CreateJob(ctx context.Context, job *ProviderJob) (*ProviderResult, error) {
+	p.jobs = append(p.jobs, job)
+	return &ProviderResult{ID: "job-1", Done: true, Err: p.err, Text: p.text}, nil
+}
+
+func (p *answeringProvider) Poll(ctx context.Context, jobID string) (*ProviderResult, error) {
+	return nil, errors.New("not polled")
+}
+
+func (p *answeringProvider) Fetch(ctx context.Context, result *ProviderResult) ([]byte, error) {
+	return nil, errors.New("no image output")
+}
+
+func TestDetectRoom(t *testing.T) {
+	t.Run("success: asks the model about the photo", func(t *testing.T) {
+		p := &answeringProvider{text: `{"room_type": "bedroom", "confidence": 0.8}`}
+		got, err := detectRoom(context.Background(), p, "lucataco/moondream2", model.Timing{}, "data:image/jpeg;base64,AAAA")
+		if err != nil {
+			t.Fatalf("detectRoom() error = %v", err)
+		}
+		if got.RoomType != "bedroom" || got.Confidence != 0.8 {
+			t.Errorf("detectRoom() = %+v", got)
+		}
+		if len(p.jobs) != 1 || p.jobs[0].Input["image"] != "data:image/jpeg;base64,AAAA" ||
+			!strings.Contains(p.jobs[0].Input["prompt"].(string), "dining_room") {
+			t.Errorf("jobs = %+v", p.jobs)
+		}
+	})
+
+	t.Run("fail: prediction failed", func(t *testing.T) {
+		p := &answeringProvider{err: errors.New("model crashed")}
+		if _, err := detectRoom(context.Background(), p, "lucataco/moondream2", model.Timing{}, "data:"); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+// pendingProvider is a Provider whose jobs never finish.
+type pendingProvider struct {
+	answeringProvider
+	polls int
+}
+
+func (p *pendingProvider) Poll(ctx context.Context, jobID string) (*ProviderResult, error) {
+	p.polls++
+	return &ProviderResult{ID: jobID}, nil
+}
+
+func TestAwaitJob(t *testing.T) {
+	t.Run("fail: gives up after the model's timeout", func(t *testing.T) {
+		p := &pendingProvider{}
+		timing := model.Timing{Timeout: 50 * time.Millisecond, PollInterval: 5 * time.Millisecond}
+
+		_, err := awaitJob(context.Background(), p, "job-1", timing)
+		if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+			t.Fatalf("awaitJob() error = %v, want timeout", err)
+		}
+		if p.polls == 0 {
+			t.Error("expected the job to be polled")
+		}
+	})
+}
+
+func TestParseRoomDetection(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantType string
+		wantConf float64
+		wantErr  bool
+	}{
+		{name: "success: bare JSON", text: `{"room_type": "kitchen", "confidence": 0.93}`,
+			wantType: "kitchen", wantConf: 0.93},
+		{name: "success: wrapped in prose and a code fence",
+			text:     "Sure!\n```json\n{\"room_type\": \"Living Room\", \"confidence\": 0.7}\n```",
+			wantType: "living_room", wantConf: 0.7},
+		{name: "success: home gym", text: `{"room_type": "Home Gym", "confidence": 0.8}`,
+			wantType: "home_gym", wantConf: 0.8},
+		{name: "fail: no JSON", text: "It looks like a kitchen.", wantErr: true},
+		{name: "fail: unknown room type", text: `{"room_type": "attic", "confidence": 0.9}`, wantErr: true},
+		{name: "fail: confidence out of range", text: `{"room_type": "kitchen", "confidence": 93}`, wantErr: true},
+		{name: "fail: malformed JSON", text: `{"room_type": kitchen}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRoomDetection(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRoomDetection() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.RoomType != tt.wantType || got.Confidence != tt.wantConf) {
+				t.Errorf("parseRoomDetection() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestDefaultService_DetectRoomType(t *testing.T) {
+	t.Run("success: off without a model", func(t *testing.T) {
+		got, err := (&DefaultService{}).DetectRoomType(context.Background(), "s3://bucket/uploads/a.jpg")
+		if err != nil || got != nil {
+			t.Errorf("DetectRoomType() = %+v, %v; want nil, nil", got, err)
+		}
+	})
+}
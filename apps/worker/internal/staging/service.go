@@ -3,6 +3,13 @@ package staging
 import (
 	"context"
 	"io"
+
+	"github.com/real-staging-ai/api/watermark"
+
+	"github.com/real-staging-ai/worker/internal/imagecheck"
+	"github.com/real-staging-ai/worker/internal/malware"
+	"github.com/real-staging-ai/worker/internal/photometa"
+	"github.com/real-staging-ai/worker/internal/thumbnail"
 )
 
 //go:generate go run github.com/matryer/moq@v0.5.3 -out service_mock.go . Service
@@ -12,22 +19,170 @@ type StagingRequest struct {
 	ImageID     string
 	OriginalURL string
 	ModelID     string // AI model to use for this staging request
-	RoomType    *string
-	Style       *string
-	Seed        *int64
-	Prompt      *string
+	// ModelVersion pins the Replicate version to run; empty runs the model's latest version.
+	ModelVersion string
+	RoomType     *string
+	Style        *string
+	Seed         *int64
+	Prompt       *string
+	// PromptOverride replaces the library prompt for the room type and style
+	// when set; a custom Prompt still takes precedence.
+	PromptOverride string
+	// Locale, a tag such as "en-GB", adapts the furniture sizes in the prompt;
+	// empty keeps the US phrasing.
+	Locale string
+	// Language is the language the job was requested in; empty is English.
+	// A custom Prompt is marked as written in it, and without a Locale the
+	// sizes follow its main market.
+	Language string
+	// MaskURL is the S3 URL of a PNG mask, white where the model may repaint;
+	// empty stages the whole photo.
+	MaskURL string
+	// Mode is prompt.ModeDeclutter to empty the room or prompt.ModeBoth to
+	// empty and then stage it; empty stages the photo as it is.
+	Mode string
+	// ModelConfig overrides fields of the admin's config for ModelID, as a
+	// style preset does; nil runs the model with the admin's config.
+	ModelConfig map[string]interface{}
+	// PredictionID resumes a Replicate prediction started by an earlier attempt
+	// at this job instead of paying for a new one.
+	PredictionID string
+	// OnPrediction, if set, is called with the ID of each new prediction as soon
+	// as Replicate accepts it.
+	OnPrediction func(predictionID string)
+	// InputFiles are the Replicate files earlier attempts at this job uploaded
+	// for their predictions. They are deleted once this attempt is done.
+	InputFiles []string
+	// OnInputFiles, if set, is called when ctx ends before the attempt is done,
+	// with the Replicate files it didn't delete because the prediction may
+	// still read them. The attempt that resumes the prediction takes them as
+	// InputFiles.
+	OnInputFiles func(fileIDs []string)
+	// Watermark, if set, is drawn on the staged image before it is stored.
+	Watermark *watermark.Options
+}
+
+// StagingResult describes a staged image stored in S3.
+type StagingResult struct {
+	URL string
+	// ContentType is the staged image's format, as stored in S3: JPEG when it
+	// was watermarked, otherwise what the model emitted.
+	ContentType string
+	// Blurhash is a compact placeholder for the staged image. It is empty when
+	// the staged image could not be decoded.
+	Blurhash string
+	// CostUSD is what the prediction cost, priced from the model's pricing and
+	// the compute time Replicate reported. Sandbox runs cost nothing.
+	CostUSD float64
+	// Seed is the seed the model ran with, including one it picked at random.
+	// It is nil when the provider doesn't report one.
+	Seed *int64
+	// Extras are the other images the model made when its config asks for
+	// several, stored next to URL. Outputs that couldn't be fetched, failed a
+	// quality check or couldn't be stored are left out.
+	Extras []StagedOutput
+}
+
+// RoomDetection is the room type the room detection model saw in a photo.
+type RoomDetection struct {
+	RoomType string
+	// Confidence is how sure the model was, from 0 to 1.
+	Confidence float64
+	// Confident reports whether Confidence reached the configured minimum,
+	// so staging may use RoomType.
+	Confident bool
+}
+
+// StagedOutput is one more image a model made for a staging request.
+type StagedOutput struct {
+	URL         string
+	ContentType string
+	Blurhash    string
+}
+
+// CutoutRequest contains the parameters for cutting furniture out of a staged image.
+type CutoutRequest struct {
+	ImageID   string
+	StagedURL string
+}
+
+// Cutout is a transparent-background PNG of one piece of furniture, stored in S3.
+type Cutout struct {
+	URL    string
+	Width  int
+	Height int
+	// X and Y locate the cut-out's top-left corner within the staged image.
+	X int
+	Y int
+}
+
+// ThumbnailRequest contains the parameters for thumbnailing one of an image's files.
+type ThumbnailRequest struct {
+	ImageID   string
+	Source    thumbnail.Source
+	SourceURL string
+}
+
+// Thumbnail is a downscaled JPEG copy of an image file, stored in S3.
+type Thumbnail struct {
+	Size   thumbnail.Size
+	URL    string
+	Width  int
+	Height int
+}
+
+// PreprocessResult describes what PreprocessOriginal did to an original.
+type PreprocessResult struct {
+	// Orientation is the EXIF orientation that was applied; 1 means the
+	// original was already upright.
+	Orientation int
+	// Stripped reports whether the original carried metadata that was removed.
+	Stripped bool
+	// Metadata is the EXIF the original carried. It is only set when the
+	// service is configured to preserve it and there was any.
+	Metadata *photometa.Metadata
 }
 
 // Service defines the interface for AI-powered virtual staging operations.
 type Service interface {
-	// StageImage processes an image with AI staging and returns the staged image URL in S3.
+	// StageImage processes an image with AI staging and returns the staged image in S3.
 	// It downloads the original from S3, sends it to Replicate for processing,
-	// and uploads the result back to S3.
-	StageImage(ctx context.Context, req *StagingRequest) (string, error)
+	// uploads the result back to S3 and computes its blurhash.
+	StageImage(ctx context.Context, req *StagingRequest) (*StagingResult, error)
 
 	// DownloadFromS3 downloads a file from S3 and returns its content.
 	DownloadFromS3(ctx context.Context, fileKey string) (io.ReadCloser, error)
 
 	// UploadToS3 uploads a file to S3 and returns the public URL.
 	UploadToS3(ctx context.Context, imageID string, content io.Reader, contentType string) (string, error)
+
+	// PreprocessOriginal rewrites the original in place with its EXIF orientation
+	// applied and its metadata (GPS position, camera details, comments) removed.
+	PreprocessOriginal(ctx context.Context, originalURL string) (*PreprocessResult, error)
+
+	// ValidateOriginal checks that the original is a supported image within the
+	// configured limits. It returns an *imagecheck.Error when the file must be
+	// rejected and any other error when it could not be checked.
+	ValidateOriginal(ctx context.Context, originalURL string) (*imagecheck.Info, error)
+
+	// ScanOriginal checks the original for malware. It returns a nil verdict
+	// when no scanner is configured and an error when the file couldn't be scanned.
+	ScanOriginal(ctx context.Context, originalURL string) (*malware.Verdict, error)
+
+	// DetectRoomType classifies the room in the original. It returns a nil
+	// detection when room detection is off and an error when the model's
+	// answer isn't one of the room types the prompt library stages.
+	DetectRoomType(ctx context.Context, originalURL string) (*RoomDetection, error)
+
+	// QuarantineOriginal moves the original under the quarantine prefix, out
+	// of reach of the presigned URLs that serve it, and returns its new URL.
+	QuarantineOriginal(ctx context.Context, originalURL string) (string, error)
+
+	// CreateCutouts segments a staged image and uploads a transparent-background
+	// PNG for each detected furniture piece, largest first.
+	CreateCutouts(ctx context.Context, req *CutoutRequest) ([]Cutout, error)
+
+	// CreateThumbnails uploads a JPEG thumbnail of the source image in each
+	// thumbnail size, smallest first.
+	CreateThumbnails(ctx context.Context, req *ThumbnailRequest) ([]Thumbnail, error)
 }
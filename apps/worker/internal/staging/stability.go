@@ -0,0 +1,265 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+// DefaultStabilityBaseURL is Stability AI's REST API.
+const DefaultStabilityBaseURL = "https://api.stability.ai"
+
+// stabilityInpaintPath is Stability's inpainting endpoint. It answers with the
+// finished image, so its jobs are done as soon as they are created.
+const stabilityInpaintPath = "/v2beta/stable-image/edit/inpaint"
+
+// maxStabilityResponse bounds the JSON (with a base64 image) read from Stability.
+const maxStabilityResponse = 64 << 20
+
+// stabilityProvider runs predictions on Stability AI's REST API.
+type stabilityProvider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// Ensure stabilityProvider implements Provider.
+var _ Provider = (*stabilityProvider)(nil)
+
+// newStabilityProvider creates a Stability provider. An empty baseURL uses
+// DefaultStabilityBaseURL.
+func newStabilityProvider(apiKey, baseURL string) *stabilityProvider {
+	if baseURL == "" {
+		baseURL = DefaultStabilityBaseURL
+	}
+	return &stabilityProvider{
+		apiKey:  apiKey,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: model.DefaultTimeout},
+	}
+}
+
+// stabilityResponse is Stability's JSON answer to an inpainting request.
+type stabilityResponse struct {
+	Image        string `json:"image"`
+	FinishReason string `json:"finish_reason"`
+	// Seed is the seed the image was made with, random unless one was sent.
+	Seed *int64 `json:"seed"`
+}
+
+// stabilityError is the body of a Stability error response.
+type stabilityError struct {
+	Name   string   `json:"name"`
+	Errors []string `json:"errors"`
+}
+
+// CreateJob inpaints the input image and returns the finished job. The
+// model's strength is sent as a gray mask: the job's own mask scaled by it, or
+// a uniform one that repaints the whole photo that much.
+func (p *stabilityProvider) CreateJob(ctx context.Context, job *ProviderJob) (*ProviderResult, error) {
+	body, contentType, err := stabilityForm(job.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+stabilityInpaintPath, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Stability: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxStabilityResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Stability response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr stabilityError
+		_ = json.Unmarshal(raw, &apiErr)
+		msg := strings.Join(apiErr.Errors, "; ")
+		if msg == "" {
+			msg = http.StatusText(resp.StatusCode)
+		}
+		if apiErr.Name == "content_moderation" {
+			return &ProviderResult{Done: true, Err: fmt.Errorf("prediction failed: %s: %w", msg, errNSFW)}, nil
+		}
+		return nil, fmt.Errorf("stability returned %d: %s", resp.StatusCode, msg)
+	}
+
+	var out stabilityResponse
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode Stability response: %w", err)
+	}
+	switch out.FinishReason {
+	case "SUCCESS":
+	case "CONTENT_FILTERED":
+		return &ProviderResult{Done: true, Err: fmt.Errorf("prediction failed: output filtered: %w", errNSFW)}, nil
+	default:
+		return &ProviderResult{Done: true, Err: fmt.Errorf("prediction failed: %s", out.FinishReason)}, nil
+	}
+
+	output, err := base64.StdEncoding.DecodeString(out.Image)
+	if err != nil || len(output) == 0 {
+		return &ProviderResult{Done: true, Err: fmt.Errorf("prediction succeeded but output is invalid")}, nil
+	}
+	return &ProviderResult{Done: true, Output: output, Seed: out.Seed}, nil
+}
+
+// Poll always fails: Stability jobs are finished when CreateJob returns, and
+// have no ID to look them up by.
+func (p *stabilityProvider) Poll(ctx context.Context, jobID string) (*ProviderResult, error) {
+	return nil, errors.New("stability jobs can't be polled")
+}
+
+// Fetch returns the image CreateJob received.
+func (p *stabilityProvider) Fetch(ctx context.Context, result *ProviderResult) ([]byte, error) {
+	if len(result.Output) == 0 {
+		return nil, errors.New("stability job has no output")
+	}
+	return result.Output, nil
+}
+
+// stabilityForm encodes input, as built by the Stability input builder, as the
+// multipart form the inpainting endpoint takes.
+func stabilityForm(input map[string]interface{}) (io.Reader, string, error) {
+	dataURL, _ := input["image"].(string)
+	original, err := decodeDataURL(dataURL)
+	if err != nil {
+		return nil, "", err
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode input image: %w", err)
+	}
+	strength, ok := input["strength"].(float64)
+	if !ok {
+		strength = 1
+	}
+	var mask []byte
+	if maskURL, _ := input["mask"].(string); maskURL != "" {
+		mask, err = regionMask(maskURL, cfg.Width, cfg.Height, strength)
+	} else {
+		mask, err = uniformMask(cfg.Width, cfg.Height, strength)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	files := []struct {
+		field, name string
+		data        []byte
+	}{
+		{"image", "image", original},
+		{"mask", "mask.png", mask},
+	}
+	for _, f := range files {
+		part, err := w.CreateFormFile(f.field, f.name)
+		if err == nil {
+			_, err = part.Write(f.data)
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode %s: %w", f.field, err)
+		}
+	}
+	for _, field := range []string{"prompt", "negative_prompt", "output_format", "seed"} {
+		v, ok := input[field]
+		if !ok {
+			continue
+		}
+		if err := w.WriteField(field, formValue(v)); err != nil {
+			return nil, "", fmt.Errorf("failed to encode %s: %w", field, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to encode form: %w", err)
+	}
+	return &body, w.FormDataContentType(), nil
+}
+
+// formValue formats an input value as a form field.
+func formValue(v interface{}) string {
+	if n, ok := v.(int64); ok {
+		return strconv.FormatInt(n, 10)
+	}
+	return fmt.Sprint(v)
+}
+
+// uniformMask returns a width x height PNG mask that lets the model repaint
+// every pixel by strength (0-1).
+func uniformMask(width, height int, strength float64) ([]byte, error) {
+	level := uint8(min(max(strength, 0), 1) * 255)
+	mask := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range mask.Pix {
+		mask.Pix[i] = level
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, mask); err != nil {
+		return nil, fmt.Errorf("failed to encode mask: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// regionMask scales the PNG mask in maskDataURL to width x height and dims it
+// by strength (0-1), so the model repaints the white region that much and
+// leaves the black region alone.
+func regionMask(maskDataURL string, width, height int, strength float64) ([]byte, error) {
+	data, err := decodeDataURL(maskDataURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mask: %w", err)
+	}
+	src, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode mask: %w", err)
+	}
+
+	mask := image.NewGray(image.Rect(0, 0, width, height))
+	draw.BiLinear.Scale(mask, mask.Bounds(), src, src.Bounds(), draw.Src, nil)
+	scale := min(max(strength, 0), 1)
+	for i, v := range mask.Pix {
+		mask.Pix[i] = uint8(float64(v) * scale)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, mask); err != nil {
+		return nil, fmt.Errorf("failed to encode mask: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeDataURL returns the bytes of a base64 data URL.
+func decodeDataURL(dataURL string) ([]byte, error) {
+	header, data, ok := strings.Cut(dataURL, ",")
+	if !ok || !strings.HasPrefix(header, "data:") || !strings.HasSuffix(header, ";base64") {
+		return nil, errors.New("input image is not a base64 data URL")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode input image: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,240 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func pngDataURL(t *testing.T, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestStabilityProvider_CreateJob(t *testing.T) {
+	staged := []byte("staged image")
+
+	t.Run("success: sends the form and returns the image", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != stabilityInpaintPath {
+				t.Errorf("path = %s", r.URL.Path)
+			}
+			if got := r.Header.Get("Authorization"); got != "Bearer sk-test" {
+				t.Errorf("Authorization = %q", got)
+			}
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parse form: %v", err)
+			}
+			if r.FormValue("prompt") != "stage it" || r.FormValue("seed") != "42" || r.FormValue("output_format") != "png" {
+				t.Errorf("form = %v", r.MultipartForm.Value)
+			}
+			f, _, err := r.FormFile("mask")
+			if err != nil {
+				t.Fatalf("mask: %v", err)
+			}
+			defer func() { _ = f.Close() }()
+			mask, err := png.Decode(f)
+			if err != nil {
+				t.Fatalf("decode mask: %v", err)
+			}
+			if b := mask.Bounds(); b.Dx() != 4 || b.Dy() != 3 {
+				t.Errorf("mask size = %v, want 4x3", b)
+			}
+			if y := mask.(*image.Gray).GrayAt(0, 0).Y; y != 127 {
+				t.Errorf("mask level = %d, want 127", y)
+			}
+			_, _ = io.WriteString(w,
+				`{"image":"`+base64.StdEncoding.EncodeToString(staged)+`","finish_reason":"SUCCESS","seed":42}`)
+		}))
+		defer srv.Close()
+
+		p := newStabilityProvider("sk-test", srv.URL)
+		got, err := p.CreateJob(context.Background(), &ProviderJob{
+			ModelID: model.ModelStableImageInpaint,
+			Input: map[string]interface{}{
+				"image": pngDataURL(t, 4, 3), "prompt": "stage it", "strength": 0.5,
+				"output_format": "png", "seed": int64(42),
+			},
+		})
+		if err != nil {
+			t.Fatalf("CreateJob() error = %v", err)
+		}
+		if !got.Done || got.Err != nil || got.ID != "" {
+			t.Fatalf("CreateJob() = %+v", got)
+		}
+		if got.Seed == nil || *got.Seed != 42 {
+			t.Errorf("Seed = %v, want 42", got.Seed)
+		}
+		out, err := p.Fetch(context.Background(), got)
+		if err != nil || !bytes.Equal(out, staged) {
+			t.Errorf("Fetch() = %q, %v", out, err)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantNSFW bool
+		wantErr  bool // CreateJob itself fails rather than the job
+	}{
+		{name: "fail: output filtered", status: 200, body: `{"finish_reason":"CONTENT_FILTERED"}`, wantNSFW: true},
+		{
+			name: "fail: prompt moderated", status: 403, wantNSFW: true,
+			body: `{"name":"content_moderation","errors":["flagged"]}`,
+		},
+		{name: "fail: bad request", status: 400, body: `{"name":"bad_request","errors":["bad"]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer srv.Close()
+
+			got, err := newStabilityProvider("sk-test", srv.URL).CreateJob(context.Background(), &ProviderJob{
+				Input: map[string]interface{}{"image": pngDataURL(t, 2, 2), "prompt": "stage it"},
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CreateJob() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateJob() error = %v", err)
+			}
+			if !got.Done || got.Err == nil {
+				t.Fatalf("CreateJob() = %+v, want a failed job", got)
+			}
+			if errors.Is(got.Err, errNSFW) != tt.wantNSFW {
+				t.Errorf("errors.Is(err, errNSFW) = %v, want %v", !tt.wantNSFW, tt.wantNSFW)
+			}
+		})
+	}
+
+	t.Run("fail: image is not a data URL", func(t *testing.T) {
+		_, err := newStabilityProvider("sk-test", "http://unused").CreateJob(context.Background(), &ProviderJob{
+			Input: map[string]interface{}{"image": "https://example.com/a.png", "prompt": "stage it"},
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestRegionMask(t *testing.T) {
+	// The left half of a 4x3 photo is white, so only it may be repainted.
+	half := image.NewGray(image.Rect(0, 0, 4, 3))
+	for y := 0; y < 3; y++ {
+		half.SetGray(0, y, color.Gray{Y: 255})
+		half.SetGray(1, y, color.Gray{Y: 255})
+	}
+	white := image.NewGray(image.Rect(0, 0, 1, 1))
+	white.Pix[0] = 255
+
+	tests := []struct {
+		name      string
+		mask      image.Image
+		wantLeft  uint8
+		wantRight uint8
+	}{
+		{name: "success: dims the white region by strength", mask: half, wantLeft: 127, wantRight: 0},
+		{name: "success: scales the mask to the photo", mask: white, wantLeft: 127, wantRight: 127},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, tt.mask); err != nil {
+				t.Fatalf("encode mask: %v", err)
+			}
+			dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+
+			out, err := regionMask(dataURL, 4, 3, 0.5)
+			if err != nil {
+				t.Fatalf("regionMask() error = %v", err)
+			}
+			got, err := png.Decode(bytes.NewReader(out))
+			if err != nil {
+				t.Fatalf("decode mask: %v", err)
+			}
+			gray := got.(*image.Gray)
+			if b := gray.Bounds(); b.Dx() != 4 || b.Dy() != 3 {
+				t.Errorf("mask size = %v, want 4x3", b)
+			}
+			if y := gray.GrayAt(0, 1).Y; y != tt.wantLeft {
+				t.Errorf("left level = %d, want %d", y, tt.wantLeft)
+			}
+			if y := gray.GrayAt(3, 1).Y; y != tt.wantRight {
+				t.Errorf("right level = %d, want %d", y, tt.wantRight)
+			}
+		})
+	}
+
+	t.Run("fail: mask is not a PNG", func(t *testing.T) {
+		dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("not a png"))
+		if _, err := regionMask(dataURL, 4, 3, 0.5); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestDefaultService_provider(t *testing.T) {
+	registry := model.NewModelRegistry()
+	stabilityMeta, _ := registry.Get(model.ModelStableImageInpaint)
+	qwenMeta, _ := registry.Get(model.ModelQwenImageEdit)
+
+	t.Run("success: replicate by default", func(t *testing.T) {
+		p, err := (&DefaultService{}).provider(qwenMeta)
+		if err != nil {
+			t.Fatalf("provider() error = %v", err)
+		}
+		if _, ok := p.(replicateProvider); !ok {
+			t.Errorf("provider() = %T, want replicateProvider", p)
+		}
+	})
+
+	t.Run("success: stability with an API key", func(t *testing.T) {
+		s := &DefaultService{stability: newStabilityProvider("sk-test", "")}
+		p, err := s.provider(stabilityMeta)
+		if err != nil {
+			t.Fatalf("provider() error = %v", err)
+		}
+		if _, ok := p.(*stabilityProvider); !ok {
+			t.Errorf("provider() = %T, want *stabilityProvider", p)
+		}
+	})
+
+	t.Run("success: openai for GPT Image models", func(t *testing.T) {
+		gptMeta, _ := registry.Get(model.ModelGPTImage1)
+		s := &DefaultService{openai: newOpenAIProvider("")}
+		p, err := s.provider(gptMeta)
+		if err != nil {
+			t.Fatalf("provider() error = %v", err)
+		}
+		if _, ok := p.(*openAIProvider); !ok {
+			t.Errorf("provider() = %T, want *openAIProvider", p)
+		}
+	})
+
+	t.Run("fail: stability without an API key", func(t *testing.T) {
+		if _, err := (&DefaultService{}).provider(stabilityMeta); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package staging
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/real-staging-ai/worker/internal/thumbnail"
+)
+
+// CreateThumbnails downloads the source image and uploads a JPEG thumbnail of
+// it in each size, smallest first.
+func (s *DefaultService) CreateThumbnails(ctx context.Context, req *ThumbnailRequest) ([]Thumbnail, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.CreateThumbnails")
+	span.SetAttributes(
+		attribute.String("image.id", req.ImageID),
+		attribute.String("thumbnail.source", string(req.Source)),
+	)
+	defer span.End()
+
+	fileKey, err := extractS3KeyFromURL(req.SourceURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid S3 URL")
+		return nil, fmt.Errorf("failed to extract S3 key from URL: %w", err)
+	}
+
+	file, err := s.fetchObject(ctx, fileKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "S3 download failed")
+		return nil, fmt.Errorf("failed to download %s image: %w", req.Source, err)
+	}
+	src, _, err := image.Decode(file.Reader())
+	_ = file.Close()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "decode image failed")
+		return nil, fmt.Errorf("failed to decode %s image: %w", req.Source, err)
+	}
+
+	// Like cut-outs, each run writes fresh keys so a restaged image's
+	// thumbnails never collide with cached copies of the previous ones.
+	run := time.Now().UTC().Format("20060102T150405")
+	thumbs := make([]Thumbnail, 0, len(thumbnail.Sizes))
+	for _, size := range thumbnail.Sizes {
+		resized := thumbnail.Resize(src, size.MaxEdge())
+		key := thumbnailObjectKey(fileKey, req.ImageID, req.Source, size, run)
+		u, err := s.uploadEncoded(ctx, req.ImageID, key, "image/jpeg", func(w io.Writer) error {
+			return thumbnail.WriteJPEG(w, resized)
+		})
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "S3 upload failed")
+			return nil, fmt.Errorf("failed to upload %s thumbnail: %w", size, err)
+		}
+		thumbs = append(thumbs, Thumbnail{
+			Size:   size,
+			URL:    u,
+			Width:  resized.Bounds().Dx(),
+			Height: resized.Bounds().Dy(),
+		})
+	}
+
+	span.SetStatus(codes.Ok, "thumbnails created")
+	return thumbs, nil
+}
+
+// thumbnailObjectKey returns the key for one thumbnail, under a thumbnails/
+// prefix alongside the file it was made from (see stagedObjectKey).
+func thumbnailObjectKey(sourceKey, imageID string, source thumbnail.Source, size thumbnail.Size, run string) string {
+	name := fmt.Sprintf("%s/%s-%s-%s.jpg", imageID, source, size, run)
+	parts := strings.SplitN(sourceKey, "/", 5)
+	if len(parts) == 5 && parts[0] == "users" && parts[2] == "projects" {
+		return fmt.Sprintf("users/%s/projects/%s/thumbnails/%s", parts[1], parts[3], name)
+	}
+	if len(parts) >= 3 && parts[0] == "users" {
+		return fmt.Sprintf("users/%s/thumbnails/%s", parts[1], name)
+	}
+	return "thumbnails/" + name
+}
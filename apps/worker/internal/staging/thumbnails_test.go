@@ -0,0 +1,50 @@
+package staging
+
+import (
+	"testing"
+
+	"github.com/real-staging-ai/worker/internal/thumbnail"
+)
+
+func TestThumbnailObjectKey(t *testing.T) {
+	const imageID = "12345678-aaaa-bbbb-cccc-1234567890ab"
+	tests := []struct {
+		name      string
+		sourceKey string
+		source    thumbnail.Source
+		want      string
+	}{
+		{
+			name:      "success: project scoped original",
+			sourceKey: "users/u1/projects/p1/originals/room-uuid.jpg",
+			source:    thumbnail.SourceOriginal,
+			want:      "users/u1/projects/p1/thumbnails/" + imageID + "/original-small-run.jpg",
+		},
+		{
+			name:      "success: project scoped staged image",
+			sourceKey: "users/u1/projects/p1/staged/" + imageID + "-staged.jpg",
+			source:    thumbnail.SourceStaged,
+			want:      "users/u1/projects/p1/thumbnails/" + imageID + "/staged-small-run.jpg",
+		},
+		{
+			name:      "success: unassigned user original",
+			sourceKey: "users/u1/uploads/room-uuid.jpg",
+			source:    thumbnail.SourceOriginal,
+			want:      "users/u1/thumbnails/" + imageID + "/original-small-run.jpg",
+		},
+		{
+			name:      "success: legacy staged image",
+			sourceKey: "staged/12345678/" + imageID + "-staged.jpg",
+			source:    thumbnail.SourceStaged,
+			want:      "thumbnails/" + imageID + "/staged-small-run.jpg",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := thumbnailObjectKey(tt.sourceKey, imageID, tt.source, thumbnail.Small, "run"); got != tt.want {
+				t.Errorf("thumbnailObjectKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
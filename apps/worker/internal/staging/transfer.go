@@ -0,0 +1,171 @@
+package staging
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Uploads are sent in parts of uploadPartSize, one at a time, so a streamed
+// upload holds at most one part in memory.
+const (
+	uploadPartSize    = manager.MinUploadPartSize
+	uploadConcurrency = 1
+)
+
+// newUploader returns an uploader that writes to S3 through client in parts.
+// Bodies under one part are sent with a single PutObject.
+func newUploader(client *s3.Client) *manager.Uploader {
+	return manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = uploadPartSize
+		u.Concurrency = uploadConcurrency
+	})
+}
+
+// metadataHeadBytes is how much of a file is read to find its JPEG metadata
+// segments, which are at most 64 KiB each and come before the image data.
+const metadataHeadBytes = 256 << 10
+
+// spooledFile is a file a job downloaded to a temporary file, so it can be
+// streamed into decoders and uploads instead of being held in memory. Close
+// removes it.
+type spooledFile struct {
+	file *os.File
+	size int64
+}
+
+// spool copies r into a new temporary file.
+func spool(r io.Reader) (*spooledFile, error) {
+	file, err := os.CreateTemp("", "staging-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	size, err := io.Copy(file, r)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to read content: %w", err)
+	}
+	return &spooledFile{file: file, size: size}, nil
+}
+
+// Name returns the path of the temporary file.
+func (f *spooledFile) Name() string { return f.file.Name() }
+
+// Size returns the file's length in bytes.
+func (f *spooledFile) Size() int64 { return f.size }
+
+// Reader returns a reader from the start of the file. Each reader keeps its
+// own offset, so several can be used one after another.
+func (f *spooledFile) Reader() io.Reader { return io.NewSectionReader(f.file, 0, f.size) }
+
+// Head returns up to the first n bytes of the file.
+func (f *spooledFile) Head(n int64) ([]byte, error) {
+	return f.readAll(io.NewSectionReader(f.file, 0, min(n, f.size)))
+}
+
+// Bytes reads the whole file into memory, for the steps that rewrite it.
+func (f *spooledFile) Bytes() ([]byte, error) {
+	return f.readAll(io.NewSectionReader(f.file, 0, f.size))
+}
+
+func (f *spooledFile) readAll(r *io.SectionReader) ([]byte, error) {
+	buf := make([]byte, r.Size())
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("failed to read temp file: %w", err)
+	}
+	return buf, nil
+}
+
+// ContentType sniffs the file's MIME type from its first bytes.
+func (f *spooledFile) ContentType() (string, error) {
+	head, err := f.Head(512)
+	if err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head), nil
+}
+
+// Close closes and removes the temporary file.
+func (f *spooledFile) Close() error {
+	err := f.file.Close()
+	if rmErr := os.Remove(f.file.Name()); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// fetchObject downloads fileKey to a temporary file. The caller closes it.
+func (s *DefaultService) fetchObject(ctx context.Context, fileKey string) (*spooledFile, error) {
+	tracer := otel.Tracer("real-staging-worker/staging")
+	ctx, span := tracer.Start(ctx, "staging.FetchObject")
+	span.SetAttributes(attribute.String("s3.key", fileKey))
+	defer span.End()
+
+	result, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucketName),
+		Key:    aws.String(fileKey),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "GetObject failed")
+		return nil, fmt.Errorf("failed to get object from S3: %w", err)
+	}
+	defer func() { _ = result.Body.Close() }()
+
+	file, err := spool(result.Body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "read object failed")
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int64("s3.bytes", file.Size()))
+	span.SetStatus(codes.Ok, "download completed")
+	return file, nil
+}
+
+// uploadEncoded uploads what encode writes to fileKey and returns its s3://
+// URL. The encoder writes into an io.Pipe the uploader reads from, so the
+// encoded file is never held whole.
+func (s *DefaultService) uploadEncoded(
+	ctx context.Context, imageID, fileKey, contentType string, encode func(io.Writer) error,
+) (string, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		// A failed encode fails the upload reading from the pipe; a failed
+		// upload stops the encoder at its next write.
+		pw.CloseWithError(encode(pw))
+	}()
+	u, err := s.uploadObject(ctx, imageID, fileKey, pr, contentType)
+	_ = pr.CloseWithError(errors.New("upload finished"))
+	return u, err
+}
+
+// dataURL returns the size bytes of r as a base64 data URL. The URL is
+// written into a buffer of its final size, where encoding to a string first
+// would copy it twice.
+func dataURL(mimeType string, r io.Reader, size int64) (string, error) {
+	var b strings.Builder
+	b.Grow(len("data:;base64,") + len(mimeType) + base64.StdEncoding.EncodedLen(int(size)))
+	b.WriteString("data:")
+	b.WriteString(mimeType)
+	b.WriteString(";base64,")
+	enc := base64.NewEncoder(base64.StdEncoding, &b)
+	if _, err := io.Copy(enc, r); err != nil {
+		return "", fmt.Errorf("failed to encode data URL: %w", err)
+	}
+	_ = enc.Close()
+	return b.String(), nil
+}
@@ -0,0 +1,232 @@
+package staging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 stores the objects PUT to it, whole or in parts, in memory and
+// serves them back.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	parts   map[string][][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	query := r.URL.Query()
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if query.Has("partNumber") {
+			f.parts[r.URL.Path] = append(f.parts[r.URL.Path], body)
+			w.Header().Set("ETag", fmt.Sprintf(`"%d"`, len(f.parts[r.URL.Path])))
+			return
+		}
+		f.objects[r.URL.Path] = body
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodPost:
+		if query.Has("uploads") {
+			f.types[r.URL.Path] = r.Header.Get("Content-Type")
+			_, _ = io.WriteString(w, `<InitiateMultipartUploadResult><UploadId>1</UploadId></InitiateMultipartUploadResult>`)
+			return
+		}
+		f.objects[r.URL.Path] = bytes.Join(f.parts[r.URL.Path], nil)
+		_, _ = io.WriteString(w, `<CompleteMultipartUploadResult></CompleteMultipartUploadResult>`)
+	case http.MethodDelete:
+		delete(f.parts, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(body)
+	}
+}
+
+func newTestTransferService(t *testing.T) (*DefaultService, *fakeS3) {
+	t.Helper()
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}, parts: map[string][][]byte{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+	return &DefaultService{
+		s3Client:   client,
+		uploader:   newUploader(client),
+		bucketName: "bucket",
+	}, fake
+}
+
+func TestSpool(t *testing.T) {
+	data := []byte("\x89PNG\r\n\x1a\n fake png")
+	file, err := spool(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(len(data)), file.Size())
+	got, err := io.ReadAll(file.Reader())
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+	// Each reader starts from the beginning.
+	got, err = file.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	head, err := file.Head(4)
+	require.NoError(t, err)
+	assert.Equal(t, data[:4], head)
+	head, err = file.Head(1 << 20)
+	require.NoError(t, err)
+	assert.Equal(t, data, head)
+
+	contentType, err := file.ContentType()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	require.NoError(t, file.Close())
+	_, err = os.Stat(file.Name())
+	assert.True(t, os.IsNotExist(err), "temp file is removed on close")
+}
+
+func TestUprightCopy(t *testing.T) {
+	var enc bytes.Buffer
+	require.NoError(t, jpeg.Encode(&enc, image.NewRGBA(image.Rect(0, 0, 40, 20)), nil))
+	raw := enc.Bytes()
+
+	t.Run("success: upright original is not copied", func(t *testing.T) {
+		original, err := spool(bytes.NewReader(raw))
+		require.NoError(t, err)
+		defer func() { _ = original.Close() }()
+
+		upright, err := uprightCopy(original)
+		require.NoError(t, err)
+		assert.Nil(t, upright)
+	})
+
+	t.Run("success: sideways original is turned upright", func(t *testing.T) {
+		// A big-endian APP1 Exif segment with orientation 6 (rotate 90° CW).
+		tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01\x01\x12\x00\x03\x00\x00\x00\x01\x00\x06\x00\x00\x00\x00\x00\x00")
+		payload := append([]byte("Exif\x00\x00"), tiff...)
+		sideways := append([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0, byte(len(payload) + 2)}, payload...)
+		sideways = append(sideways, raw[2:]...)
+		original, err := spool(bytes.NewReader(sideways))
+		require.NoError(t, err)
+		defer func() { _ = original.Close() }()
+
+		upright, err := uprightCopy(original)
+		require.NoError(t, err)
+		require.NotNil(t, upright)
+		defer func() { _ = upright.Close() }()
+		cfg, _, err := image.DecodeConfig(upright.Reader())
+		require.NoError(t, err)
+		assert.Equal(t, 20, cfg.Width)
+		assert.Equal(t, 40, cfg.Height)
+	})
+}
+
+func TestDataURL(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte("a"), []byte("ab"), []byte("abc"), bytes.Repeat([]byte{0xff, 0, 7}, 1000)} {
+		want := "data:image/png;base64," + base64.StdEncoding.EncodeToString(data)
+		got, err := dataURL("image/png", bytes.NewReader(data), int64(len(data)))
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestUploadEncoded(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success: streams the encoder's output to S3", func(t *testing.T) {
+		s, fake := newTestTransferService(t)
+		// Over one part, so the upload is sent in parts.
+		want := bytes.Repeat([]byte("0123456789"), int(uploadPartSize)/10+1)
+
+		u, err := s.uploadEncoded(ctx, "img-1", "thumbs/a.jpg", "image/jpeg", func(w io.Writer) error {
+			_, err := w.Write(want)
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "s3://bucket/thumbs/a.jpg", u)
+
+		assert.Len(t, fake.parts["/bucket/thumbs/a.jpg"], 2)
+		file, err := s.fetchObject(ctx, "thumbs/a.jpg")
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+		got, err := file.Bytes()
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(want, got), "uploaded object differs")
+	})
+
+	t.Run("success: small files are uploaded whole", func(t *testing.T) {
+		s, fake := newTestTransferService(t)
+
+		_, err := s.uploadEncoded(ctx, "img-1", "thumbs/b.jpg", "image/jpeg", func(w io.Writer) error {
+			_, err := io.WriteString(w, "jpeg bytes")
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg bytes", string(fake.objects["/bucket/thumbs/b.jpg"]))
+		assert.Equal(t, "image/jpeg", fake.types["/bucket/thumbs/b.jpg"])
+	})
+
+	t.Run("fail: encoder error fails the upload", func(t *testing.T) {
+		s, fake := newTestTransferService(t)
+
+		_, err := s.uploadEncoded(ctx, "img-1", "thumbs/c.jpg", "image/jpeg", func(w io.Writer) error {
+			_, _ = io.WriteString(w, "partial")
+			return errors.New("encode jpeg: boom")
+		})
+		assert.ErrorContains(t, err, "boom")
+		assert.NotContains(t, fake.objects, "/bucket/thumbs/c.jpg")
+	})
+}
+
+func TestFetchObject(t *testing.T) {
+	ctx := context.Background()
+	s, fake := newTestTransferService(t)
+	// Larger than the 64 MiB the worker used to cap downloads at.
+	want := bytes.Repeat([]byte("x"), 65<<20)
+	fake.objects["/bucket/large.jpg"] = want
+
+	file, err := s.fetchObject(ctx, "large.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(want)), file.Size())
+	got, err := io.ReadAll(file.Reader())
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(want, got), "downloaded object differs")
+	require.NoError(t, file.Close())
+
+	_, err = s.fetchObject(ctx, "missing.jpg")
+	assert.ErrorContains(t, err, "failed to get object from S3")
+}
@@ -0,0 +1,92 @@
+// Package thumbnail makes the downscaled JPEG copies of original and staged
+// images that galleries show instead of the full-size files.
+package thumbnail
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+
+	"golang.org/x/image/draw"
+)
+
+// Source names the image file thumbnails are made from.
+type Source string
+
+const (
+	// SourceOriginal is the uploaded original.
+	SourceOriginal Source = "original"
+	// SourceStaged is the staged result.
+	SourceStaged Source = "staged"
+)
+
+// Valid reports whether s is a known source.
+func (s Source) Valid() bool {
+	return s == SourceOriginal || s == SourceStaged
+}
+
+// Size names a thumbnail bucket.
+type Size string
+
+const (
+	// Small suits grid tiles.
+	Small Size = "small"
+	// Medium suits list rows and previews.
+	Medium Size = "medium"
+	// Large suits a lightbox on a laptop screen.
+	Large Size = "large"
+)
+
+// Sizes lists every bucket, smallest first.
+var Sizes = []Size{Small, Medium, Large}
+
+// MaxEdge is the longest side, in pixels, of a thumbnail of this size.
+func (s Size) MaxEdge() int {
+	switch s {
+	case Small:
+		return 256
+	case Medium:
+		return 640
+	case Large:
+		return 1280
+	default:
+		return 0
+	}
+}
+
+// Quality is the JPEG quality thumbnails are encoded at.
+const Quality = 82
+
+// Resize scales src so its longest side is at most maxEdge, keeping its aspect
+// ratio. Images already within maxEdge are copied at their own size rather
+// than scaled up.
+func Resize(src image.Image, maxEdge int) *image.RGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if longest := max(w, h); longest > maxEdge {
+		w = max(1, w*maxEdge/longest)
+		h = max(1, h*maxEdge/longest)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// EncodeJPEG encodes img as a JPEG at Quality.
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteJPEG(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteJPEG writes img to w as a JPEG at Quality.
+func WriteJPEG(w io.Writer, img image.Image) error {
+	if err := jpeg.Encode(w, img, &jpeg.Options{Quality: Quality}); err != nil {
+		return fmt.Errorf("encode jpeg: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solid(w, h int, c color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(img, img.Bounds(), image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func TestSource_Valid(t *testing.T) {
+	assert.True(t, SourceOriginal.Valid())
+	assert.True(t, SourceStaged.Valid())
+	assert.False(t, Source("cutout").Valid())
+}
+
+func TestSize_MaxEdge(t *testing.T) {
+	prev := 0
+	for _, s := range Sizes {
+		assert.Greater(t, s.MaxEdge(), prev, "sizes are listed smallest first")
+		prev = s.MaxEdge()
+	}
+	assert.Zero(t, Size("huge").MaxEdge())
+}
+
+func TestResize(t *testing.T) {
+	testCases := []struct {
+		name    string
+		w, h    int
+		maxEdge int
+		wantW   int
+		wantH   int
+	}{
+		{name: "success: landscape is bounded by width", w: 4000, h: 3000, maxEdge: 256, wantW: 256, wantH: 192},
+		{name: "success: portrait is bounded by height", w: 3000, h: 4000, maxEdge: 640, wantW: 480, wantH: 640},
+		{name: "success: small image is not scaled up", w: 200, h: 100, maxEdge: 1280, wantW: 200, wantH: 100},
+		{name: "success: thin strip keeps one pixel", w: 5000, h: 2, maxEdge: 256, wantW: 256, wantH: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := solid(tc.w, tc.h, color.RGBA{R: 10, G: 120, B: 200, A: 255})
+			got := Resize(src, tc.maxEdge)
+			assert.Equal(t, image.Rect(0, 0, tc.wantW, tc.wantH), got.Bounds())
+			assert.Equal(t, color.RGBA{R: 10, G: 120, B: 200, A: 255}, got.RGBAAt(tc.wantW/2, tc.wantH/2))
+		})
+	}
+}
+
+func TestEncodeJPEG(t *testing.T) {
+	data, err := EncodeJPEG(solid(64, 48, color.White))
+	require.NoError(t, err)
+
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 64, cfg.Width)
+	assert.Equal(t, 48, cfg.Height)
+}
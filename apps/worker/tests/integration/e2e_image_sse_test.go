@@ -57,8 +57,8 @@ func TestEndToEnd_ImageLifecycle_SSE_Error(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, s3Svc.CreateBucket(ctx))
 
-	imgRepo := image.NewDefaultRepository(db)
-	jobRepo := job.NewDefaultRepository(db)
+	imgRepo := image.NewDefaultRepository(db, nil)
+	jobRepo := job.NewDefaultRepository(db, nil)
 	imgSvc := image.NewDefaultService(imgRepo, jobRepo)
 
 	apiServer := httpLib.NewTestServer(db, s3Svc, imgSvc)
@@ -90,7 +90,7 @@ func TestEndToEnd_ImageLifecycle_SSE_Error(t *testing.T) {
 		wctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		qc, err := workerQueue.NewAsynqQueueClient(&cfg)
+		qc, err := workerQueue.NewAsynqQueueClient(&cfg, nil)
 		if err != nil {
 			return
 		}
@@ -111,7 +111,7 @@ func TestEndToEnd_ImageLifecycle_SSE_Error(t *testing.T) {
 				OriginalURL string `json:"original_url"`
 			}
 			_ = json.Unmarshal(job.Payload, &payload)
-			_ = imgWrite.SetProcessing(wctx, payload.ImageID)
+			_ = imgWrite.SetProcessing(wctx, payload.ImageID, "test-model")
 			if pub != nil {
 				_ = pub.PublishJobUpdate(wctx, workerEvents.JobUpdateEvent{JobID: job.ID, ImageID: payload.ImageID, Status: "processing"})
 			}
@@ -235,8 +235,8 @@ func TestEndToEnd_ImageLifecycle_SSE(t *testing.T) {
 	require.NoError(t, s3Svc.CreateBucket(ctx))
 
 	// API services & server
-	imgRepo := image.NewDefaultRepository(db)
-	jobRepo := job.NewDefaultRepository(db)
+	imgRepo := image.NewDefaultRepository(db, nil)
+	jobRepo := job.NewDefaultRepository(db, nil)
 	imgSvc := image.NewDefaultService(imgRepo, jobRepo)
 
 	apiServer := httpLib.NewTestServer(db, s3Svc, imgSvc)
@@ -273,7 +273,7 @@ func TestEndToEnd_ImageLifecycle_SSE(t *testing.T) {
 		defer cancel()
 
 		// Queue client (asynq-backed)
-		qc, err := workerQueue.NewAsynqQueueClient(&cfg)
+		qc, err := workerQueue.NewAsynqQueueClient(&cfg, nil)
 		if err != nil {
 			return
 		}
@@ -298,14 +298,14 @@ func TestEndToEnd_ImageLifecycle_SSE(t *testing.T) {
 			}
 			_ = json.Unmarshal(job.Payload, &payload)
 			// Update DB: processing
-			_ = imgWrite.SetProcessing(wctx, payload.ImageID)
+			_ = imgWrite.SetProcessing(wctx, payload.ImageID, "test-model")
 			if pub != nil {
 				_ = pub.PublishJobUpdate(wctx, workerEvents.JobUpdateEvent{JobID: job.ID, ImageID: payload.ImageID, Status: "processing"})
 			}
 			// Simulate work then ready
 			time.Sleep(200 * time.Millisecond)
 			staged := payload.OriginalURL + "-staged.jpg"
-			_ = imgWrite.SetReady(wctx, payload.ImageID, staged)
+			_ = imgWrite.SetReady(wctx, payload.ImageID, staged, workerRepo.StageStats{ModelID: "test-model"})
 			if pub != nil {
 				_ = pub.PublishJobUpdate(wctx, workerEvents.JobUpdateEvent{JobID: job.ID, ImageID: payload.ImageID, Status: "ready"})
 			}
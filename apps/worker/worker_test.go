@@ -1,9 +0,0 @@
-package main
-
-import "testing"
-
-func TestWorkerPlaceholder(t *testing.T) {
-	// Placeholder test to ensure the worker module has at least one test
-	// This will be replaced with actual worker tests when the worker is implemented
-	t.Log("Worker module placeholder test")
-}
@@ -0,0 +1,81 @@
+package workercmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "github.com/lib/pq"
+
+	"github.com/real-staging-ai/api/command"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/orientation"
+	"github.com/real-staging-ai/worker/internal/repository"
+	"github.com/real-staging-ai/worker/internal/staging"
+)
+
+// BackfillOrientation is "realstaging admin backfill-orientation", which
+// rewrites originals uploaded before EXIF orientation was applied at ingestion,
+// so previously sideways photos stage upright, and records the orientation that
+// was found on each image row.
+var BackfillOrientation = &command.Command{
+	Name:    "backfill-orientation",
+	Summary: "rewrite sideways originals upright",
+	Run:     runBackfillOrientation,
+}
+
+func runBackfillOrientation(ctx context.Context, args []string) error {
+	var opts orientation.BackfillOptions
+	fs := command.NewFlagSet("admin backfill-orientation")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "count originals that would be inspected without downloading them")
+	fs.IntVar(&opts.BatchSize, "batch-size", 200, "number of originals to list per query")
+	fs.IntVar(&opts.Limit, "limit", 0, "stop after this many originals (0 = no limit)")
+	if err := command.Parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL())
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	stagingService, err := staging.NewDefaultService(ctx, &staging.ServiceConfig{
+		BucketName:     cfg.S3Bucket(),
+		ReplicateToken: cfg.Replicate.APIToken,
+		S3Endpoint:     cfg.S3.Endpoint,
+		S3Region:       cfg.S3.Region,
+		S3AccessKey:    cfg.S3.AccessKey,
+		S3SecretKey:    cfg.S3.SecretKey,
+		S3UsePathStyle: cfg.S3.UsePathStyle,
+		AppEnv:         cfg.App.Env,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize staging service: %w", err)
+	}
+
+	backfiller := orientation.NewBackfiller(repository.NewImageRepository(db), stagingService)
+	result, err := backfiller.Run(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := command.PrintJSON(result); err != nil {
+		return err
+	}
+
+	if len(result.Errors) > 0 {
+		return errors.New("some originals could not be normalized; see errors above")
+	}
+	return nil
+}
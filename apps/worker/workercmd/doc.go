@@ -0,0 +1,2 @@
+// Package workercmd implements the realstaging subcommands that run worker code.
+package workercmd
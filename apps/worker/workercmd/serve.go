@@ -0,0 +1,533 @@
+package workercmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+	redis "github.com/redis/go-redis/v9"
+
+	"github.com/real-staging-ai/api/command"
+	"github.com/real-staging-ai/api/webhookauth"
+
+	"github.com/real-staging-ai/worker/internal/breaker"
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/delivery"
+	"github.com/real-staging-ai/worker/internal/events"
+	"github.com/real-staging-ai/worker/internal/heartbeat"
+	"github.com/real-staging-ai/worker/internal/imagecheck"
+	"github.com/real-staging-ai/worker/internal/logging"
+	"github.com/real-staging-ai/worker/internal/malware"
+	"github.com/real-staging-ai/worker/internal/processor"
+	"github.com/real-staging-ai/worker/internal/quality"
+	"github.com/real-staging-ai/worker/internal/queue"
+	"github.com/real-staging-ai/worker/internal/repository"
+	"github.com/real-staging-ai/worker/internal/settings"
+	"github.com/real-staging-ai/worker/internal/staging"
+	"github.com/real-staging-ai/worker/internal/staging/model"
+	"github.com/real-staging-ai/worker/internal/staging/prompt"
+	"github.com/real-staging-ai/worker/internal/telemetry"
+)
+
+// ServeWorker is "realstaging serve worker", which processes staging, thumbnail
+// and delivery jobs until ctx is done.
+var ServeWorker = &command.Command{
+	Name:    "worker",
+	Summary: "run the job worker",
+	Run:     runServeWorker,
+}
+
+func runServeWorker(ctx context.Context, args []string) error {
+	if err := command.Parse(command.NewFlagSet("serve worker"), args, 0); err != nil {
+		return err
+	}
+
+	log := logging.Default()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	log.Info(ctx, fmt.Sprintf("Loaded configuration for environment: %s", cfg.App.Env))
+
+	// Initialize OpenTelemetry
+	shutdown, err := telemetry.InitTracing(ctx, "real-staging-worker")
+	if err != nil {
+		log.Error(ctx, "Failed to initialize tracing:", err)
+	}
+	defer func() {
+		if err := shutdown(ctx); err != nil {
+			log.Error(ctx, fmt.Sprintf("Failed to shutdown tracing: %v", err))
+		}
+	}()
+
+	// Initialize database connection using config
+	dsn := cfg.DatabaseURL()
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Error(ctx, fmt.Sprintf("Failed to close database: %v", err))
+		}
+	}()
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	imgRepo := repository.NewImageRepository(db)
+
+	// Config.Load has already checked the key, so this only fails if it's unset.
+	keyring, err := cfg.Encryption.Keyring()
+	if err != nil {
+		return fmt.Errorf("invalid encryption configuration: %w", err)
+	}
+	if keyring == nil {
+		log.Warn(ctx, "ENCRYPTION_KEY is not set; jobs for models with a sealed API key will fail")
+	}
+
+	// Get active model from database settings
+	settingsRepo := settings.NewDefaultRepository(db, keyring)
+	activeModel, err := settingsRepo.GetActiveModel(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active model from settings: %w", err)
+	}
+	log.Info(ctx, fmt.Sprintf("Using model: %s", activeModel))
+
+	// Publish the built-in prompts so admins can export them with their overrides.
+	if err := settingsRepo.SyncBuiltinPrompts(ctx, prompt.New().Entries()); err != nil {
+		log.Warn(ctx, fmt.Sprintf("Failed to publish built-in prompts: %v", err))
+	}
+
+	// The models table the API reads is the model catalog; jobs naming a
+	// model it doesn't list, or lists as disabled, fail.
+	catalog, err := settingsRepo.ListModels(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load model catalog: %w", err)
+	}
+	unsupported, unlisted := settings.CheckCatalog(catalog, model.NewModelRegistry())
+	for _, m := range unsupported {
+		log.Warn(ctx, fmt.Sprintf("Catalog model %s can't be run by this worker", m))
+	}
+	if len(unlisted) > 0 {
+		log.Info(ctx, fmt.Sprintf("Models missing from the catalog are disabled: %v", unlisted))
+	}
+	if !slices.ContainsFunc(catalog, func(m settings.CatalogModel) bool { return m.ID == activeModel && m.Enabled }) {
+		log.Warn(ctx, fmt.Sprintf("Active model %s is not enabled in the catalog; its jobs will fail", activeModel))
+	}
+
+	// Replicate reports finished predictions to the callback server when a public URL is configured
+	var callbacks *staging.PredictionCallbacks
+	if cfg.Replicate.WebhookURL != "" {
+		callbacks = staging.NewPredictionCallbacks(newCallbackVerifier(cfg))
+	}
+
+	scanner, err := newMalwareScanner(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize malware scanner: %w", err)
+	}
+
+	modelTimings, err := newModelTimings(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid model timings: %w", err)
+	}
+
+	// Initialize the staging service with config
+	stagingCfg := &staging.ServiceConfig{
+		BucketName:     cfg.S3Bucket(),
+		ReplicateToken: cfg.Replicate.APIToken,
+		ModelID:        activeModel, // Use model from database settings
+		S3Endpoint:     cfg.S3.Endpoint,
+		S3Region:       cfg.S3.Region,
+		S3AccessKey:    cfg.S3.AccessKey,
+		S3SecretKey:    cfg.S3.SecretKey,
+		S3UsePathStyle: cfg.S3.UsePathStyle,
+		AppEnv:         cfg.App.Env,
+		ConfigRepo:     settingsRepo, // Add settings repository for model config loading
+		CutoutModelID:  cfg.Cutout.ModelID,
+		// Detection is off unless a model is configured
+		RoomDetectionModelID:    cfg.Room.DetectionModelID,
+		RoomDetectionConfidence: cfg.Room.MinConfidence,
+		WebhookURL:              cfg.Replicate.WebhookURL,
+		Callbacks:               callbacks,
+		OriginalLimits: imagecheck.Limits{
+			MaxBytes:     cfg.Original.MaxBytes,
+			MaxDimension: cfg.Original.MaxDimension,
+		},
+		PreserveOriginalMetadata: cfg.Original.PreserveMetadata,
+		Scanner:                  scanner,
+		QuarantinePrefix:         cfg.Malware.QuarantinePrefix,
+		Quality: quality.Options{
+			MaxAspectDrift: cfg.Quality.MaxAspectDrift,
+			BlankMaxStdDev: cfg.Quality.BlankMaxStdDev,
+			BlackMaxLuma:   cfg.Quality.BlackMaxLuma,
+			RejectNSFW:     cfg.Quality.RejectNSFW,
+		},
+		StabilityAPIKey:  cfg.Stability.APIKey,
+		StabilityBaseURL: cfg.Stability.BaseURL,
+		OpenAIBaseURL:    cfg.OpenAI.BaseURL,
+		ModelTimings:     modelTimings,
+		ImageInput:       staging.ImageInput(cfg.Replicate.ImageInput),
+		S3PublicEndpoint: cfg.S3.PublicEndpoint,
+	}
+	stagingService, err := staging.NewDefaultService(ctx, stagingCfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize staging service: %w", err)
+	}
+
+	log.Info(ctx, "Starting Real Staging AI Worker...")
+	if callbacks != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/webhooks/replicate", callbacks)
+		srv := &http.Server{Addr: cfg.Replicate.WebhookAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			log.Info(ctx, "Replicate callback server listening", "addr", srv.Addr)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error(ctx, fmt.Sprintf("Replicate callback server failed: %v", err))
+			}
+		}()
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to shutdown Replicate callback server: %v", err))
+			}
+		}()
+	} else {
+		log.Info(ctx, "Replicate callbacks disabled (no REPLICATE_WEBHOOK_URL); polling predictions")
+	}
+
+	// Initialize events publisher (Redis) if configured
+	var pub events.Publisher
+	if p, err := events.NewDefaultPublisher(cfg); err == nil {
+		pub = p
+		log.Info(ctx, "Events publisher enabled")
+	} else {
+		log.Info(ctx, "Events publisher disabled (no REDIS_HOST)")
+		pub = &events.NoopPublisher{}
+	}
+
+	// Outbound webhooks and emails are sent from the same worker on their own queues
+	deliverer := delivery.NewDefaultDeliverer(
+		repository.NewDeliveryRepository(db),
+		delivery.NewWebhookSender(time.Duration(cfg.Webhook.TimeoutSeconds)*time.Second),
+		delivery.NewSMTPSender(cfg.Email),
+	)
+
+	// Stage jobs heartbeat to Redis so jobs lost with their worker are detected and requeued
+	var heartbeats heartbeat.Store
+	var stageRequeuer processor.StageRequeuer
+	beatInterval := time.Duration(cfg.Job.HeartbeatSeconds) * time.Second
+	if addr := cfg.Redis.Addr(); addr != "" {
+		rdb := redis.NewClient(&redis.Options{Addr: addr})
+		defer func() {
+			if err := rdb.Close(); err != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to close heartbeat Redis client: %v", err))
+			}
+		}()
+		store := heartbeat.NewRedisStore(rdb, workerID(), time.Duration(cfg.Job.StallAfterSeconds)*time.Second)
+		heartbeats = store
+
+		requeuer, err := queue.NewStageRequeuer(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to initialize stage requeuer: %w", err)
+		}
+		defer func() {
+			if err := requeuer.Close(); err != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to close stage requeuer: %v", err))
+			}
+		}()
+		stageRequeuer = requeuer
+		monitor, err := heartbeat.NewMonitor(store, requeuer, time.Duration(cfg.Job.StallCheckSeconds)*time.Second)
+		if err != nil {
+			return fmt.Errorf("failed to initialize stalled job monitor: %w", err)
+		}
+		go monitor.Run(ctx)
+		log.Info(ctx, "Stage job heartbeats enabled",
+			"interval_seconds", cfg.Job.HeartbeatSeconds, "stall_after_seconds", cfg.Job.StallAfterSeconds)
+	} else {
+		log.Info(ctx, "Stage job heartbeats disabled (no REDIS_HOST)")
+	}
+
+	// Stage jobs queue thumbnail tasks for the original and the staged result
+	var tasks processor.TaskEnqueuer
+	if enqueuer, err := queue.NewTaskEnqueuer(cfg); err == nil {
+		defer func() {
+			if err := enqueuer.Close(); err != nil {
+				log.Error(ctx, fmt.Sprintf("Failed to close task enqueuer: %v", err))
+			}
+		}()
+		tasks = enqueuer
+	} else {
+		log.Info(ctx, "Thumbnails disabled (no REDIS_HOST)")
+	}
+
+	fallback, err := newFallbackPolicy(cfg)
+	if err != nil {
+		return fmt.Errorf("invalid model fallback chain: %w", err)
+	}
+	if len(fallback.Models) > 0 {
+		log.Info(ctx, "Model fallback enabled", "chain", cfg.Fallback.Chain, "max_attempts", fallback.MaxAttempts)
+	}
+
+	circuits := newBreaker(ctx, cfg, settingsRepo)
+	if circuits != nil {
+		log.Info(ctx, "Model circuit breaker enabled", "failure_threshold", cfg.Breaker.FailureThreshold,
+			"cooldown_seconds", cfg.Breaker.CooldownSeconds)
+	}
+
+	// Prompt overrides are read per job; cache them so admin edits apply within the TTL
+	var jobSettings settings.Repository = settingsRepo
+	if ttl := time.Duration(cfg.Prompt.CacheTTLSeconds) * time.Second; ttl > 0 {
+		jobSettings = settings.NewPromptCache(settingsRepo, ttl)
+	}
+
+	// Initialize the job processor with settings repo for dynamic model selection
+	proc := processor.NewImageProcessor(
+		imgRepo, stagingService, pub, jobSettings, deliverer, repository.NewAssetRepository(db),
+		tasks, heartbeats, beatInterval, fallback, circuits, stageRequeuer,
+	)
+
+	// Initialize the queue client (Redis/asynq in production)
+	var queueClient queue.QueueClient
+	// Log queue-related configuration for clarity
+	redisAddr := cfg.Redis.Addr()
+	queueName := cfg.Job.QueueName
+	concurrency := cfg.Job.WorkerConcurrency
+	log.Info(ctx, "Queue configuration", "redis_addr", redisAddr, "queue", queueName, "concurrency", concurrency)
+	if qc, err := queue.NewAsynqQueueClient(cfg, repository.NewDeadLetterRepository(db)); err == nil {
+		queueClient = qc
+		log.Info(ctx, "Using Asynq queue backend")
+	} else {
+		queueClient = queue.NewMockQueueClient()
+		log.Info(ctx, "Using mock queue backend (no Redis Address configured)")
+	}
+
+	// Jobs run on workCtx, which outlives ctx by up to the shutdown timeout so
+	// a job running at SIGTERM can finish. Its result is reported on markCtx,
+	// which is never canceled, so asynq learns of it even past the timeout.
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
+	markCtx := context.WithoutCancel(ctx)
+
+	// Start processing jobs
+	loopDone := make(chan struct{})
+	go func() {
+		defer close(loopDone)
+		log.Info(ctx, "Job polling loop started")
+		pollCount := 0
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info(ctx, "Job polling loop stopped, no longer taking jobs")
+				return
+			default:
+				// Poll for jobs
+				job, err := queueClient.GetNextJob(ctx)
+				if err != nil {
+					log.Error(ctx, fmt.Sprintf("Error getting next job: %v", err))
+					time.Sleep(5 * time.Second)
+					continue
+				}
+
+				if job == nil {
+					// No jobs available, wait a bit
+					pollCount++
+					if pollCount%30 == 0 {
+						log.Info(ctx, "Still polling for jobs...", "poll_count", pollCount)
+					}
+					time.Sleep(2 * time.Second)
+					continue
+				}
+
+				// Reset counter when job is found
+				pollCount = 0
+
+				// Process the job
+				log.Info(ctx, fmt.Sprintf("Processing job %s of type %s", job.ID, job.Type))
+
+				// The processor handles all DB updates and SSE events internally
+				var deferred *queue.DeferredError
+				if err := proc.ProcessJob(workCtx, job); errors.As(err, &deferred) {
+					if markErr := queueClient.DeferJob(markCtx, job.ID, deferred.Until); markErr != nil {
+						log.Error(ctx, fmt.Sprintf("Failed to defer job %s: %v", job.ID, markErr))
+					}
+				} else if err != nil {
+					log.Error(ctx, fmt.Sprintf("Error processing job %s: %v", job.ID, err))
+					if markErr := queueClient.MarkJobFailed(markCtx, job.ID, err.Error()); markErr != nil {
+						log.Error(ctx, fmt.Sprintf("Failed to mark job %s as failed: %v", job.ID, markErr))
+					}
+				} else {
+					log.Info(ctx, fmt.Sprintf("Successfully processed job %s", job.ID))
+					if markErr := queueClient.MarkJobCompleted(markCtx, job.ID); markErr != nil {
+						log.Error(ctx, fmt.Sprintf("Failed to mark job %s as completed: %v", job.ID, markErr))
+					}
+				}
+			}
+		}
+	}()
+
+	log.Info(ctx, "Worker started. Press Ctrl+C to stop.")
+	<-ctx.Done()
+	drain(markCtx, queueClient, loopDone, cancelWork, time.Duration(cfg.Job.ShutdownTimeoutSeconds)*time.Second)
+	log.Info(ctx, "Worker stopped.")
+	return nil
+}
+
+// drain shuts the worker down gracefully once the polling loop has been told
+// to stop: the queue stops handing out jobs and returns the ones still
+// waiting, and the job running, if any, gets until timeout to finish. After
+// that cancelWork interrupts it; stage jobs then requeue themselves to resume
+// their prediction elsewhere, and asynq pushes anything else back.
+func drain(
+	ctx context.Context, queueClient queue.QueueClient, loopDone <-chan struct{}, cancelWork func(),
+	timeout time.Duration,
+) {
+	log := logging.Default()
+	log.Info(ctx, "Shutting down worker, draining in-flight jobs", "timeout", timeout.String())
+
+	queueDone := make(chan struct{})
+	go func() {
+		defer close(queueDone)
+		queueClient.Shutdown()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-loopDone:
+	case <-timer.C:
+		log.Warn(ctx, "Shutdown timeout reached, interrupting in-flight job")
+		cancelWork()
+		<-loopDone
+	}
+	<-queueDone
+}
+
+// workerID identifies this worker process in heartbeat records.
+func workerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// newFallbackPolicy turns MODEL_FALLBACK_CHAIN into a processor policy,
+// rejecting models the worker can't run.
+func newFallbackPolicy(cfg *config.Config) (processor.FallbackPolicy, error) {
+	policy := processor.FallbackPolicy{MaxAttempts: cfg.Fallback.MaxAttempts}
+	registry := model.NewModelRegistry()
+	for _, id := range cfg.Fallback.Chain {
+		m := model.ID(strings.TrimSpace(id))
+		if !registry.Exists(m) {
+			return policy, fmt.Errorf("unsupported model %q", m)
+		}
+		policy.Models = append(policy.Models, m)
+	}
+	return policy, nil
+}
+
+// newModelTimings turns REPLICATE_MODEL_TIMEOUTS and REPLICATE_POLL_INTERVALS
+// into per-model overrides of the registry's prediction timings.
+func newModelTimings(cfg *config.Config) (map[model.ID]model.Timing, error) {
+	timings := map[model.ID]model.Timing{}
+	for id, timeout := range cfg.Replicate.ModelTimeouts {
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout for model %q must be positive", id)
+		}
+		m := model.ID(strings.TrimSpace(id))
+		t := timings[m]
+		t.Timeout = timeout
+		timings[m] = t
+	}
+	for id, interval := range cfg.Replicate.PollIntervals {
+		if interval <= 0 {
+			return nil, fmt.Errorf("poll interval for model %q must be positive", id)
+		}
+		m := model.ID(strings.TrimSpace(id))
+		t := timings[m]
+		t.PollInterval = interval
+		timings[m] = t
+	}
+	return timings, nil
+}
+
+// newBreaker returns the model circuit breaker, or nil when PROVIDER_BREAKER_THRESHOLD
+// is 0. Open circuits are published as the provider incident flag, which is
+// cleared on startup since this worker's circuits start closed.
+func newBreaker(ctx context.Context, cfg *config.Config, settingsRepo *settings.DefaultRepository) *breaker.Breaker {
+	if cfg.Breaker.FailureThreshold <= 0 {
+		return nil
+	}
+	log := logging.Default()
+	setIncident := func(outages []breaker.Outage) {
+		incident := settings.Incident{Active: len(outages) > 0, Source: settings.IncidentSourceWorker}
+		for i, o := range outages {
+			incident.Models = append(incident.Models, string(o.Model))
+			if i == 0 || o.Since.Before(*incident.Since) {
+				incident.Since = &o.Since
+			}
+			if i == 0 || o.RetryAt.Before(*incident.RetryAt) {
+				incident.RetryAt = &o.RetryAt
+			}
+		}
+		writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := settingsRepo.SetIncident(writeCtx, incident); err != nil {
+			log.Error(ctx, "Failed to update provider incident", "error", err)
+			return
+		}
+		if incident.Active {
+			log.Warn(ctx, "Provider incident raised", "models", incident.Models, "retry_at", *incident.RetryAt)
+		} else {
+			log.Info(ctx, "Provider incident cleared")
+		}
+	}
+	setIncident(nil)
+
+	return breaker.New(breaker.Options{
+		FailureThreshold: cfg.Breaker.FailureThreshold,
+		Cooldown:         time.Duration(cfg.Breaker.CooldownSeconds) * time.Second,
+		MaxCooldown:      time.Duration(cfg.Breaker.MaxCooldownSeconds) * time.Second,
+		OnChange:         setIncident,
+	})
+}
+
+// newMalwareScanner returns the scanner named by MALWARE_SCANNER, or nil when
+// scanning is off.
+func newMalwareScanner(cfg *config.Config) (malware.Scanner, error) {
+	switch cfg.Malware.Scanner {
+	case "":
+		return nil, nil
+	case "clamav":
+		timeout := time.Duration(cfg.Malware.TimeoutSeconds) * time.Second
+		return malware.NewClamAV(cfg.Malware.ClamAVAddr, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown malware scanner %q", cfg.Malware.Scanner)
+	}
+}
+
+// newCallbackVerifier checks Replicate callback signatures against the current
+// and previous webhook secrets, rejecting replays through Redis when it is
+// configured. It returns nil when no secret is set.
+func newCallbackVerifier(cfg *config.Config) *webhookauth.Verifier {
+	if cfg.Replicate.WebhookSecret == "" {
+		return nil
+	}
+	var opts webhookauth.Options
+	if addr := cfg.Redis.Addr(); addr != "" {
+		opts.Replay = webhookauth.NewRedisReplayCache(redis.NewClient(&redis.Options{Addr: addr}))
+	}
+	secrets := []string{cfg.Replicate.WebhookSecret, cfg.Replicate.WebhookSecretPrevious}
+	return webhookauth.NewVerifier(webhookauth.Replicate, secrets, opts)
+}
@@ -0,0 +1,71 @@
+package workercmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/real-staging-ai/worker/internal/config"
+	"github.com/real-staging-ai/worker/internal/staging/model"
+)
+
+func TestNewFallbackPolicy(t *testing.T) {
+	t.Run("success: trims and keeps the configured chain", func(t *testing.T) {
+		cfg := &config.Config{Fallback: config.Fallback{
+			Chain:       []string{" qwen/qwen-image-edit", "black-forest-labs/flux-kontext-max "},
+			MaxAttempts: 2,
+		}}
+
+		policy, err := newFallbackPolicy(cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []model.ID{model.ModelQwenImageEdit, model.ModelFluxKontextMax}
+		if len(policy.Models) != len(want) || policy.Models[0] != want[0] || policy.Models[1] != want[1] {
+			t.Fatalf("models = %v, want %v", policy.Models, want)
+		}
+		if policy.MaxAttempts != 2 {
+			t.Fatalf("max attempts = %d, want 2", policy.MaxAttempts)
+		}
+	})
+
+	t.Run("fail: unsupported model", func(t *testing.T) {
+		cfg := &config.Config{Fallback: config.Fallback{Chain: []string{"acme/unknown"}}}
+
+		if _, err := newFallbackPolicy(cfg); err == nil {
+			t.Fatal("expected an error for an unsupported model")
+		}
+	})
+}
+
+func TestNewModelTimings(t *testing.T) {
+	t.Run("success: merges timeouts and poll intervals per model", func(t *testing.T) {
+		cfg := &config.Config{Replicate: config.Replicate{
+			ModelTimeouts: map[string]time.Duration{"bytedance/seedream-4": 20 * time.Minute},
+			PollIntervals: map[string]time.Duration{
+				"bytedance/seedream-4": 10 * time.Second, "qwen/qwen-image-edit": 3 * time.Second,
+			},
+		}}
+
+		timings, err := newModelTimings(cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := model.Timing{Timeout: 20 * time.Minute, PollInterval: 10 * time.Second}
+		if got := timings[model.ModelSeedream4]; got != want {
+			t.Errorf("seedream-4 timing = %+v, want %+v", got, want)
+		}
+		if got := timings[model.ModelQwenImageEdit]; got != (model.Timing{PollInterval: 3 * time.Second}) {
+			t.Errorf("qwen timing = %+v", got)
+		}
+	})
+
+	t.Run("fail: timeout must be positive", func(t *testing.T) {
+		cfg := &config.Config{Replicate: config.Replicate{
+			ModelTimeouts: map[string]time.Duration{"bytedance/seedream-4": 0},
+		}}
+
+		if _, err := newModelTimings(cfg); err == nil {
+			t.Fatal("expected an error for a zero timeout")
+		}
+	})
+}
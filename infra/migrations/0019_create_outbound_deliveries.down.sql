@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_outbound_deliveries_pending;
+DROP INDEX IF EXISTS idx_outbound_deliveries_destination;
+DROP TABLE IF EXISTS outbound_deliveries;
@@ -0,0 +1,30 @@
+-- Outbox for outbound webhooks and emails.
+-- Rows are written by the API and processed by the worker on the
+-- "webhooks" / "emails" asynq queues; each attempt updates the row so the
+-- table doubles as the per-destination delivery log.
+CREATE TABLE outbound_deliveries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  channel VARCHAR(16) NOT NULL CHECK (channel IN ('webhook', 'email')),
+  destination TEXT NOT NULL,
+  event_type VARCHAR(100) NOT NULL,
+  subject TEXT,
+  payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+  status VARCHAR(16) NOT NULL DEFAULT 'pending'
+    CHECK (status IN ('pending', 'retrying', 'delivered', 'failed')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  max_attempts INTEGER NOT NULL DEFAULT 8,
+  last_error TEXT,
+  last_status_code INTEGER,
+  enqueued_at TIMESTAMPTZ,
+  last_attempt_at TIMESTAMPTZ,
+  delivered_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Delivery log lookups per destination (newest first)
+CREATE INDEX idx_outbound_deliveries_destination ON outbound_deliveries(destination, created_at DESC);
+
+-- Outbox relay: rows that were never handed to the queue
+CREATE INDEX idx_outbound_deliveries_pending ON outbound_deliveries(created_at)
+  WHERE status = 'pending' AND enqueued_at IS NULL;
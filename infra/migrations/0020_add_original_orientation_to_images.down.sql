@@ -0,0 +1,3 @@
+-- Remove original_orientation column from images table
+DROP INDEX IF EXISTS idx_images_original_orientation_pending;
+ALTER TABLE images DROP COLUMN original_orientation;
@@ -0,0 +1,11 @@
+-- EXIF orientation found on the uploaded original (1-8) before the worker
+-- rewrote it upright. NULL means the original has not been inspected yet;
+-- any value other than 1 means the stored object was corrected.
+ALTER TABLE images ADD COLUMN original_orientation SMALLINT
+  CHECK (original_orientation BETWEEN 1 AND 8);
+
+COMMENT ON COLUMN images.original_orientation IS 'EXIF orientation of the original before normalization; NULL until inspected';
+
+-- Backfill scans for originals that have not been inspected yet
+CREATE INDEX idx_images_original_orientation_pending ON images(id)
+  WHERE original_orientation IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Remove image assets and cutout tracking columns
+ALTER TABLE images DROP COLUMN IF EXISTS cutout_error;
+ALTER TABLE images DROP COLUMN IF EXISTS cutout_status;
+DROP INDEX IF EXISTS idx_image_assets_image_id;
+DROP TABLE IF EXISTS image_assets;
@@ -0,0 +1,26 @@
+-- Child assets derived from an image, such as furniture cut-outs produced by
+-- the cutout pipeline. Each asset is its own object in S3 and can be presigned
+-- independently of the parent image.
+CREATE TABLE image_assets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  image_id UUID NOT NULL REFERENCES images(id) ON DELETE CASCADE,
+  kind VARCHAR(32) NOT NULL CHECK (kind IN ('cutout')),
+  url TEXT NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  source_x INTEGER NOT NULL DEFAULT 0,
+  source_y INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN image_assets.source_x IS 'Left edge of the asset within the parent staged image, in pixels';
+COMMENT ON COLUMN image_assets.source_y IS 'Top edge of the asset within the parent staged image, in pixels';
+
+CREATE INDEX idx_image_assets_image_id ON image_assets(image_id, kind, created_at);
+
+-- Progress of the most recent cutout run for an image. NULL means cut-outs
+-- were never requested.
+ALTER TABLE images ADD COLUMN cutout_status VARCHAR(16)
+  CHECK (cutout_status IN ('queued', 'processing', 'ready', 'error'));
+ALTER TABLE images ADD COLUMN cutout_error TEXT;
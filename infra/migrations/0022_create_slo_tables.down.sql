@@ -0,0 +1,4 @@
+-- Remove SLO objectives and per-route rollups
+DROP TABLE IF EXISTS slo_objectives;
+DROP INDEX IF EXISTS idx_route_metric_rollups_bucket_start;
+DROP TABLE IF EXISTS route_metric_rollups;
@@ -0,0 +1,42 @@
+-- Per-route request rollups used for SLO tracking.
+-- Each API instance buffers request outcomes in memory and flushes them here once a
+-- minute; concurrent instances add into the same row via ON CONFLICT.
+CREATE TABLE route_metric_rollups (
+  method VARCHAR(10) NOT NULL,
+  route TEXT NOT NULL,
+  bucket_start TIMESTAMPTZ NOT NULL,
+  requests BIGINT NOT NULL DEFAULT 0,
+  errors BIGINT NOT NULL DEFAULT 0,
+  latency_sum_ms BIGINT NOT NULL DEFAULT 0,
+  latency_buckets BIGINT[] NOT NULL,
+  PRIMARY KEY (method, route, bucket_start)
+);
+
+COMMENT ON COLUMN route_metric_rollups.route IS 'Echo route template, e.g. /api/v1/images/:id';
+COMMENT ON COLUMN route_metric_rollups.errors IS 'Responses with a 5xx status';
+COMMENT ON COLUMN route_metric_rollups.latency_buckets IS 'Request counts per latency histogram bucket (see slo.LatencyBucketsMs)';
+
+-- Retention pruning
+CREATE INDEX idx_route_metric_rollups_bucket_start ON route_metric_rollups(bucket_start);
+
+-- Admin-defined service level objectives evaluated against route_metric_rollups.
+CREATE TABLE slo_objectives (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name VARCHAR(100) NOT NULL UNIQUE,
+  method VARCHAR(10) NOT NULL,
+  route TEXT NOT NULL,
+  kind VARCHAR(16) NOT NULL CHECK (kind IN ('latency', 'availability')),
+  target DOUBLE PRECISION NOT NULL CHECK (target > 0 AND target < 1),
+  threshold_ms INTEGER CHECK (threshold_ms > 0),
+  window_days INTEGER NOT NULL DEFAULT 30 CHECK (window_days BETWEEN 1 AND 90),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (kind <> 'latency' OR threshold_ms IS NOT NULL)
+);
+
+COMMENT ON COLUMN slo_objectives.target IS 'Fraction of requests that must be good, e.g. 0.99';
+COMMENT ON COLUMN slo_objectives.threshold_ms IS 'Latency objectives only: a request is good when it completes within this many ms';
+
+INSERT INTO slo_objectives (name, method, route, kind, target, threshold_ms) VALUES
+  ('image-create-p99', 'POST', '/api/v1/images', 'latency', 0.99, 800),
+  ('image-create-success', 'POST', '/api/v1/images', 'availability', 0.98, NULL);
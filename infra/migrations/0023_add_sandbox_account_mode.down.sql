@@ -0,0 +1,5 @@
+-- Remove sandbox account mode
+DROP INDEX IF EXISTS idx_users_account_mode;
+ALTER TABLE images DROP COLUMN IF EXISTS sandbox;
+ALTER TABLE users DROP COLUMN IF EXISTS stripe_test_clock_id;
+ALTER TABLE users DROP COLUMN IF EXISTS account_mode;
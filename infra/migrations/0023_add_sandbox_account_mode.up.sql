@@ -0,0 +1,16 @@
+-- Sandbox accounts trial the API without touching live billing or the paid
+-- staging provider. The mode is a tenant-wide flag on the user and is copied
+-- onto every image at creation so workers and usage counts never need to join
+-- back to the owner.
+ALTER TABLE users ADD COLUMN account_mode VARCHAR(16) NOT NULL DEFAULT 'live'
+  CHECK (account_mode IN ('live', 'sandbox'));
+ALTER TABLE users ADD COLUMN stripe_test_clock_id TEXT;
+
+COMMENT ON COLUMN users.account_mode IS 'Tenant mode: live uses real billing and models, sandbox uses Stripe test mode and the fake provider';
+COMMENT ON COLUMN users.stripe_test_clock_id IS 'Stripe test clock bound to the sandbox customer';
+
+ALTER TABLE images ADD COLUMN sandbox BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN images.sandbox IS 'True when the image was created by a sandbox account and is staged by the fake provider';
+
+CREATE INDEX idx_users_account_mode ON users(account_mode) WHERE account_mode <> 'live';
@@ -0,0 +1,5 @@
+-- Remove webhook endpoints and the delivery link
+DROP INDEX IF EXISTS idx_outbound_deliveries_webhook_endpoint;
+ALTER TABLE outbound_deliveries DROP COLUMN IF EXISTS webhook_endpoint_id;
+DROP INDEX IF EXISTS idx_webhook_endpoints_project;
+DROP TABLE IF EXISTS webhook_endpoints;
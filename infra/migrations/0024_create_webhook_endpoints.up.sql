@@ -0,0 +1,25 @@
+-- Per-project callback URLs for image lifecycle webhooks.
+-- Deliveries go through the outbound_deliveries outbox; the worker signs each
+-- POST with the endpoint's secret (HMAC-SHA256).
+CREATE TABLE webhook_endpoints (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  event_types TEXT[] NOT NULL DEFAULT ARRAY['image.processing', 'image.ready', 'image.failed'],
+  description TEXT,
+  active BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Fan-out lookup when an image in a project changes state
+CREATE INDEX idx_webhook_endpoints_project ON webhook_endpoints(project_id) WHERE active;
+
+-- Delivery history per endpoint; removing an endpoint removes its history
+ALTER TABLE outbound_deliveries
+  ADD COLUMN webhook_endpoint_id UUID REFERENCES webhook_endpoints(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_outbound_deliveries_webhook_endpoint ON outbound_deliveries(webhook_endpoint_id, created_at DESC)
+  WHERE webhook_endpoint_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Remove the fair-housing prompt review queue
+DROP INDEX IF EXISTS idx_prompt_reviews_status_created;
+DROP TABLE IF EXISTS prompt_reviews;
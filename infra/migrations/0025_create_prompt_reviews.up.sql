@@ -0,0 +1,20 @@
+-- Fair-housing review queue for custom prompts.
+-- Rejected prompts never reach the worker; flagged prompts are staged and
+-- queued here so an admin can clear or uphold them.
+CREATE TABLE prompt_reviews (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+  image_id UUID REFERENCES images(id) ON DELETE SET NULL,
+  prompt TEXT NOT NULL,
+  decision TEXT NOT NULL CHECK (decision IN ('rejected', 'flagged')),
+  violations JSONB NOT NULL DEFAULT '[]'::jsonb,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'cleared', 'upheld')),
+  reviewed_by TEXT,
+  review_note TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  reviewed_at TIMESTAMPTZ
+);
+
+-- Admin queue: oldest pending first
+CREATE INDEX idx_prompt_reviews_status_created ON prompt_reviews(status, created_at);
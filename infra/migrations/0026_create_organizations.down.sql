@@ -0,0 +1,8 @@
+-- Remove organizations, memberships, invitations and project sharing
+DROP INDEX IF EXISTS idx_projects_org;
+ALTER TABLE projects DROP COLUMN IF EXISTS org_id;
+DROP INDEX IF EXISTS idx_organization_invitations_pending;
+DROP TABLE IF EXISTS organization_invitations;
+DROP INDEX IF EXISTS idx_organization_members_user;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
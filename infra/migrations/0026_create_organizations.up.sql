@@ -0,0 +1,45 @@
+-- Organizations let brokerages share projects and a single billing owner.
+-- Images in an org project count against the org's billing user instead of
+-- the project creator.
+CREATE TABLE organizations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  billing_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE organization_members (
+  org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role TEXT NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (org_id, user_id)
+);
+
+-- "Which orgs am I in" and the project access checks
+CREATE INDEX idx_organization_members_user ON organization_members(user_id);
+
+-- Only a SHA-256 of the token is stored; the token itself is returned once to the inviter
+CREATE TABLE organization_invitations (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  email TEXT NOT NULL,
+  role TEXT NOT NULL CHECK (role IN ('admin', 'member')),
+  token_hash TEXT NOT NULL UNIQUE,
+  invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  accepted_at TIMESTAMPTZ,
+  accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One open invitation per address per org
+CREATE UNIQUE INDEX idx_organization_invitations_pending
+  ON organization_invitations(org_id, lower(email)) WHERE accepted_at IS NULL;
+
+-- Projects stay owned by their creator; org_id shares them with the org's members
+ALTER TABLE projects
+  ADD COLUMN org_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_projects_org ON projects(org_id) WHERE org_id IS NOT NULL;
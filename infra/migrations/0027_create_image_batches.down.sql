@@ -0,0 +1,4 @@
+-- Remove async image batches
+DROP INDEX IF EXISTS idx_image_batches_user;
+DROP TABLE IF EXISTS image_batch_items;
+DROP TABLE IF EXISTS image_batches;
@@ -0,0 +1,23 @@
+-- Async batch staging: POST /images/batch with "async": true records a batch
+-- and creates its images in the background; clients poll GET /batches/:id.
+CREATE TABLE image_batches (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  total INTEGER NOT NULL CHECK (total > 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One row per requested image, in request order. Once created, an item's
+-- progress is read from the image it points at.
+CREATE TABLE image_batch_items (
+  batch_id UUID NOT NULL REFERENCES image_batches(id) ON DELETE CASCADE,
+  item_index INTEGER NOT NULL,
+  project_id UUID NOT NULL,
+  state TEXT NOT NULL DEFAULT 'pending' CHECK (state IN ('pending', 'created', 'failed')),
+  image_id UUID REFERENCES images(id) ON DELETE SET NULL,
+  error TEXT,
+  PRIMARY KEY (batch_id, item_index)
+);
+
+CREATE INDEX idx_image_batches_user ON image_batches(user_id, created_at DESC);
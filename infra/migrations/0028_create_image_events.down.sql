@@ -0,0 +1,3 @@
+-- Remove per-image staging history
+DROP INDEX IF EXISTS idx_image_events_image;
+DROP TABLE IF EXISTS image_events;
@@ -0,0 +1,17 @@
+-- Per-image staging history: one row per status transition, with the prompt,
+-- model, cost and processing time in effect at that point. Rows are appended
+-- by the API on create, by the worker as it stages and by reconciliation.
+CREATE TABLE image_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  image_id UUID NOT NULL REFERENCES images(id) ON DELETE CASCADE,
+  status image_status NOT NULL,
+  source TEXT NOT NULL CHECK (source IN ('api', 'worker', 'reconcile')),
+  prompt TEXT,
+  model_used TEXT,
+  cost_usd DECIMAL(10, 4),
+  processing_time_ms INTEGER,
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX idx_image_events_image ON image_events(image_id, created_at);
@@ -0,0 +1,2 @@
+-- Remove pinned model versions
+DROP TABLE IF EXISTS model_version_pins;
@@ -0,0 +1,16 @@
+-- Pinned Replicate model versions. The worker stages with the pinned version
+-- instead of whatever "latest" resolves to; a canary version, when set, serves
+-- canary_percent of jobs until it is promoted or rolled back.
+CREATE TABLE model_version_pins (
+  model_id TEXT PRIMARY KEY,
+  version TEXT NOT NULL CHECK (version ~ '^[a-f0-9]{64}$'),
+  canary_version TEXT CHECK (canary_version ~ '^[a-f0-9]{64}$'),
+  canary_percent INTEGER NOT NULL DEFAULT 0 CHECK (canary_percent BETWEEN 0 AND 100),
+  canary_started_at TIMESTAMPTZ,
+  -- The version replaced by the last promotion, kept so it can be rolled back.
+  previous_version TEXT,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  -- Auth subject of the admin who made the last change.
+  updated_by TEXT,
+  CHECK (canary_version IS NOT NULL OR canary_percent = 0)
+);
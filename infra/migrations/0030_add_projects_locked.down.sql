@@ -0,0 +1,2 @@
+-- Remove the project delete-protection lock
+ALTER TABLE projects DROP COLUMN IF EXISTS locked;
@@ -0,0 +1,5 @@
+-- A locked project holds published listing assets. While locked, its images
+-- cannot be deleted or restyled and the project itself cannot be deleted.
+ALTER TABLE projects ADD COLUMN locked BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN projects.locked IS 'Blocks image deletion, project deletion and restyles until an owner or org admin unlocks it';
@@ -0,0 +1,3 @@
+-- Remove the prompt library tables
+DROP TABLE IF EXISTS prompt_overrides;
+DROP TABLE IF EXISTS prompt_builtins;
@@ -0,0 +1,23 @@
+-- The staging prompt library as versioned assets. The worker publishes its
+-- built-in prompts here on startup; admins replace individual prompts with
+-- overrides, which the worker prefers for the exact room type and style.
+-- Export/import bundles move overrides between environments.
+CREATE TABLE prompt_builtins (
+  room_type VARCHAR(50) NOT NULL,
+  style VARCHAR(50) NOT NULL,
+  prompt TEXT NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (room_type, style)
+);
+
+CREATE TABLE prompt_overrides (
+  room_type VARCHAR(50) NOT NULL,
+  style VARCHAR(50) NOT NULL,
+  prompt TEXT NOT NULL CHECK (prompt <> ''),
+  -- Incremented each time the prompt text changes in this environment.
+  version INTEGER NOT NULL DEFAULT 1,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  -- Auth subject of the admin who made the last change.
+  updated_by TEXT,
+  PRIMARY KEY (room_type, style)
+);
@@ -0,0 +1,2 @@
+-- Remove the staged image placeholder hash
+ALTER TABLE images DROP COLUMN IF EXISTS blurhash;
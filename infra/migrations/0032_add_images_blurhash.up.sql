@@ -0,0 +1,5 @@
+-- Compact placeholder the worker computes from each staged image, so galleries
+-- can paint something before the full image loads.
+ALTER TABLE images ADD COLUMN blurhash TEXT;
+
+COMMENT ON COLUMN images.blurhash IS 'BlurHash of the staged image; NULL until staged or when it could not be computed';
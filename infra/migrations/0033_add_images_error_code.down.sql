@@ -0,0 +1,2 @@
+-- Remove the image error code
+ALTER TABLE images DROP COLUMN IF EXISTS error_code;
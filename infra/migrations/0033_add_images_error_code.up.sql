@@ -0,0 +1,5 @@
+-- Machine-readable reason an image failed, set when the worker rejects the
+-- uploaded original, so clients can tell a bad upload from a staging failure.
+ALTER TABLE images ADD COLUMN error_code TEXT;
+
+COMMENT ON COLUMN images.error_code IS 'Validation code when the original was rejected (e.g. corrupt_image); NULL otherwise';
@@ -0,0 +1,8 @@
+-- Remove thumbnail assets
+DROP INDEX IF EXISTS idx_image_assets_thumbnail;
+DELETE FROM image_assets WHERE kind = 'thumbnail';
+ALTER TABLE image_assets DROP CONSTRAINT IF EXISTS image_assets_thumbnail_variant_check;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS size;
+ALTER TABLE image_assets DROP COLUMN IF EXISTS source;
+ALTER TABLE image_assets DROP CONSTRAINT image_assets_kind_check;
+ALTER TABLE image_assets ADD CONSTRAINT image_assets_kind_check CHECK (kind IN ('cutout'));
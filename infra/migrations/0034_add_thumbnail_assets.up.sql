@@ -0,0 +1,14 @@
+-- Thumbnails are image assets too: downscaled JPEG copies of an image's
+-- original and staged files, so galleries don't download full-size files.
+ALTER TABLE image_assets DROP CONSTRAINT image_assets_kind_check;
+ALTER TABLE image_assets ADD CONSTRAINT image_assets_kind_check CHECK (kind IN ('cutout', 'thumbnail'));
+
+ALTER TABLE image_assets ADD COLUMN source VARCHAR(16) CHECK (source IN ('original', 'staged'));
+ALTER TABLE image_assets ADD COLUMN size VARCHAR(16) CHECK (size IN ('small', 'medium', 'large'));
+ALTER TABLE image_assets ADD CONSTRAINT image_assets_thumbnail_variant_check
+  CHECK (kind <> 'thumbnail' OR (source IS NOT NULL AND size IS NOT NULL));
+
+COMMENT ON COLUMN image_assets.source IS 'For thumbnails, the image file it was made from: original or staged';
+COMMENT ON COLUMN image_assets.size IS 'For thumbnails, the size bucket: small, medium or large';
+
+CREATE UNIQUE INDEX idx_image_assets_thumbnail ON image_assets(image_id, source, size) WHERE kind = 'thumbnail';
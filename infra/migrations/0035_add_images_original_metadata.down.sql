@@ -0,0 +1,2 @@
+-- Remove the preserved original metadata
+ALTER TABLE images DROP COLUMN IF EXISTS original_metadata;
@@ -0,0 +1,6 @@
+-- Camera details, capture time and GPS position read from the original's EXIF
+-- before the worker strips it from the file. Only kept when the worker is
+-- configured to preserve it (ORIGINAL_PRESERVE_METADATA).
+ALTER TABLE images ADD COLUMN original_metadata JSONB;
+
+COMMENT ON COLUMN images.original_metadata IS 'EXIF metadata stripped from the original (camera, taken_at, gps); NULL when not preserved or absent';
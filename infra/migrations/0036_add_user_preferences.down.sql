@@ -0,0 +1,2 @@
+-- Remove server-side UI preferences
+DROP TABLE IF EXISTS user_preferences;
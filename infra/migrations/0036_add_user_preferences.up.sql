@@ -0,0 +1,13 @@
+-- Server-side UI preferences, one row per user and namespace (staging,
+-- gallery, notifications, ui). Values are the namespace's JSON object
+-- encrypted by the API, so the database never holds them in plaintext.
+CREATE TABLE user_preferences (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  namespace TEXT NOT NULL,
+  ciphertext BYTEA NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, namespace)
+);
+
+COMMENT ON COLUMN user_preferences.ciphertext IS 'AES-256-GCM sealed JSON object, bound to user_id and namespace';
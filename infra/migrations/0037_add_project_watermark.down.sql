@@ -0,0 +1,3 @@
+-- Remove the project watermark flag and its settings
+DELETE FROM settings WHERE key IN ('watermark_text', 'watermark_position', 'watermark_opacity');
+ALTER TABLE projects DROP COLUMN IF EXISTS watermark;
@@ -0,0 +1,14 @@
+-- Projects can ask the worker to overlay a "Virtually Staged" watermark on
+-- their staged images, which MLS rules require in many markets. The text,
+-- position and opacity are shared settings so every project discloses the
+-- same way.
+ALTER TABLE projects ADD COLUMN watermark BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN projects.watermark IS 'Overlay the configured watermark on images staged in this project';
+
+INSERT INTO settings (key, value, description)
+VALUES
+    ('watermark_text', 'Virtually Staged', 'Text of the watermark drawn on staged images in watermarked projects'),
+    ('watermark_position', 'bottom_right', 'Watermark placement: top_left, top_right, bottom_left, bottom_right or center'),
+    ('watermark_opacity', '0.6', 'Watermark opacity between 0.1 and 1')
+ON CONFLICT (key) DO NOTHING;
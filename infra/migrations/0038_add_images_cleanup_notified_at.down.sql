@@ -0,0 +1,3 @@
+-- Remove the error cleanup notice timestamp
+DROP INDEX IF EXISTS idx_images_error_cleanup;
+ALTER TABLE images DROP COLUMN IF EXISTS cleanup_notified_at;
@@ -0,0 +1,9 @@
+-- Images left in error are moved to the trash after ERROR_IMAGE_RETENTION_DAYS.
+-- Their owners are emailed first; this records when, so the cleanup waits out
+-- the notice period and the owner isn't emailed twice about the same failure.
+ALTER TABLE images ADD COLUMN cleanup_notified_at TIMESTAMPTZ;
+
+-- Cleanup scans: live images in error, oldest failure first
+CREATE INDEX idx_images_error_cleanup ON images (updated_at) WHERE status = 'error' AND deleted_at IS NULL;
+
+COMMENT ON COLUMN images.cleanup_notified_at IS 'When the owner was told this errored image will be moved to the trash; NULL until then';
@@ -0,0 +1,2 @@
+-- Remove the per-project staging model
+ALTER TABLE projects DROP COLUMN IF EXISTS model_id;
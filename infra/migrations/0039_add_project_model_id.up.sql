@@ -0,0 +1,6 @@
+-- Projects can pin the staging model their images use. A user's preferred
+-- model applies to projects without one, and the global active_model setting
+-- to everything else. A job can still name a model of its own.
+ALTER TABLE projects ADD COLUMN model_id TEXT;
+
+COMMENT ON COLUMN projects.model_id IS 'Staging model for images in this project; NULL falls back to the user preference, then active_model';
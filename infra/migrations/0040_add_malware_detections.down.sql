@@ -0,0 +1,6 @@
+-- Remove malware detections. PostgreSQL can't drop an enum value, so rejected
+-- images go back to 'error' and 'rejected' stays on image_status unused.
+UPDATE images SET status = 'error' WHERE status = 'rejected';
+UPDATE image_events SET status = 'error' WHERE status = 'rejected';
+DROP INDEX IF EXISTS idx_malware_detections_created;
+DROP TABLE IF EXISTS malware_detections;
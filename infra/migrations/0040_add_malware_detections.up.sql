@@ -0,0 +1,21 @@
+-- Uploaded originals are scanned for malware before staging. Infected files
+-- are moved under a quarantine prefix in the bucket and their image is set to
+-- 'rejected', which unlike 'error' is never retried or cleaned up. Each
+-- detection is recorded here for admins to review.
+ALTER TYPE image_status ADD VALUE IF NOT EXISTS 'rejected';
+
+CREATE TABLE malware_detections (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  image_id UUID REFERENCES images(id) ON DELETE SET NULL,
+  original_url TEXT NOT NULL,
+  quarantine_url TEXT NOT NULL,
+  scanner TEXT NOT NULL,
+  signature TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_malware_detections_created ON malware_detections(created_at DESC);
+
+COMMENT ON COLUMN malware_detections.original_url IS 'Where the upload was stored before it was quarantined';
+COMMENT ON COLUMN malware_detections.quarantine_url IS 'Where the infected file was moved';
+COMMENT ON COLUMN malware_detections.signature IS 'What the scanner found, e.g. Eicar-Test-Signature';
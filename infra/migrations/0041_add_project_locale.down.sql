@@ -0,0 +1,2 @@
+-- Remove the per-project prompt locale
+ALTER TABLE projects DROP COLUMN IF EXISTS locale;
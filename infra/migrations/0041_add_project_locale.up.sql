@@ -0,0 +1,6 @@
+-- Projects can set the locale their listings are written for. Stage prompts
+-- describe furniture in US sizes (queen beds, rugs in feet); the worker
+-- rewrites them for the locale, e.g. UK bed names or metric rug sizes.
+ALTER TABLE projects ADD COLUMN locale TEXT;
+
+COMMENT ON COLUMN projects.locale IS 'Language-region tag such as en-GB used to localize furniture sizes in stage prompts; NULL keeps US phrasing';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_support_tickets_image;
+DROP INDEX IF EXISTS idx_support_tickets_user;
+DROP TABLE IF EXISTS support_tickets;
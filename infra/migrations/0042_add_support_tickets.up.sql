@@ -0,0 +1,21 @@
+-- Users can open a support ticket from an image that failed to stage. The
+-- ticket keeps a copy of the image's state and staging history when it was
+-- opened, and the tail of the model's prediction logs, so support has the
+-- context without asking for it and later re-runs don't change what they see.
+CREATE TABLE support_tickets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  image_id UUID REFERENCES images(id) ON DELETE SET NULL,
+  message TEXT NOT NULL,
+  snapshot JSONB NOT NULL,
+  prediction_logs TEXT,
+  delivery_id UUID REFERENCES outbound_deliveries(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_support_tickets_user ON support_tickets(user_id, created_at DESC);
+CREATE INDEX idx_support_tickets_image ON support_tickets(image_id);
+
+COMMENT ON COLUMN support_tickets.snapshot IS 'The image and its staging events when the ticket was opened';
+COMMENT ON COLUMN support_tickets.prediction_logs IS 'Last lines of the Replicate prediction logs; NULL when unavailable';
+COMMENT ON COLUMN support_tickets.delivery_id IS 'Outbox delivery forwarding the ticket to the helpdesk; NULL when not forwarded';
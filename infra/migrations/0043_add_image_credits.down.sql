@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_credit_ledger_image;
+DROP INDEX IF EXISTS idx_credit_ledger_checkout;
+DROP INDEX IF EXISTS idx_credit_ledger_user;
+DROP TABLE IF EXISTS credit_ledger;
+DROP TABLE IF EXISTS credit_balances;
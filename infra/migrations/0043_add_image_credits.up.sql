@@ -0,0 +1,30 @@
+-- Users without a subscription can buy packs of image credits through Stripe
+-- Checkout. Once their free monthly allowance is used, each new image spends a
+-- credit, which is given back if staging fails. Every change to a balance is a
+-- row in credit_ledger; credit_balances holds the running total so spending
+-- can be checked and applied in one statement.
+CREATE TABLE credit_balances (
+  user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  balance INTEGER NOT NULL DEFAULT 0 CHECK (balance >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE credit_ledger (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  delta INTEGER NOT NULL,
+  reason TEXT NOT NULL CHECK (reason IN ('purchase', 'debit', 'refund')),
+  image_id UUID REFERENCES images(id) ON DELETE SET NULL,
+  stripe_checkout_session_id TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_credit_ledger_user ON credit_ledger(user_id, created_at DESC);
+-- A Checkout Session grants its credits once, however often Stripe sends it.
+CREATE UNIQUE INDEX idx_credit_ledger_checkout ON credit_ledger(stripe_checkout_session_id)
+  WHERE stripe_checkout_session_id IS NOT NULL;
+-- An image is debited and refunded at most once.
+CREATE UNIQUE INDEX idx_credit_ledger_image ON credit_ledger(image_id, reason) WHERE image_id IS NOT NULL;
+
+COMMENT ON COLUMN credit_ledger.delta IS 'Credits added (positive) or spent (negative)';
+COMMENT ON COLUMN credit_ledger.image_id IS 'Image a debit paid for or a refund returned; NULL for purchases';
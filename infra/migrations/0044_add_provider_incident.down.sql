@@ -0,0 +1,2 @@
+-- Remove the provider incident flag
+DELETE FROM settings WHERE key = 'provider_incident';
@@ -0,0 +1,9 @@
+-- The provider incident flag drives the status banner and the "accepted,
+-- delayed" response new images get while a model provider is down. Workers
+-- raise and clear it from their circuit breaker; an admin can set it by hand
+-- with "source": "admin", which workers then leave alone.
+INSERT INTO settings (key, value, description)
+VALUES
+    ('provider_incident', '{"active":false}',
+     'Provider outage flag as JSON: active, source, models, since, retry_at and an optional banner message')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_overage_usage_user;
+DROP TABLE IF EXISTS overage_usage;
@@ -0,0 +1,15 @@
+-- Subscribers to plans with a metered overage price can keep creating images
+-- past their monthly limit, up to a configured cap. Each such image is reported
+-- to Stripe as a billing meter event identified by the image ID; this table
+-- remembers which images were, so the event can be cancelled when staging fails.
+CREATE TABLE overage_usage (
+  image_id UUID PRIMARY KEY REFERENCES images(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  stripe_customer_id TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  cancelled_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_overage_usage_user ON overage_usage(user_id, created_at DESC);
+
+COMMENT ON COLUMN overage_usage.cancelled_at IS 'When the meter event was cancelled because the image failed to stage';
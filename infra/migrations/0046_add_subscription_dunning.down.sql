@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_subscription_dunning_pending;
+DROP INDEX IF EXISTS idx_subscription_dunning_user;
+DROP TABLE IF EXISTS subscription_dunning;
@@ -0,0 +1,14 @@
+-- A subscriber whose renewal payment fails keeps their plan for a grace period
+-- while Stripe retries the charge. A row exists while the subscription is
+-- past_due and records when it became so; it is removed once the subscription
+-- moves to any other status. expired_at is set when the end of the grace
+-- period has been announced, so it is announced once.
+CREATE TABLE subscription_dunning (
+  subscription_id UUID PRIMARY KEY REFERENCES subscriptions(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  past_due_since TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expired_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_subscription_dunning_user ON subscription_dunning(user_id, past_due_since DESC);
+CREATE INDEX idx_subscription_dunning_pending ON subscription_dunning(past_due_since) WHERE expired_at IS NULL;
@@ -0,0 +1,18 @@
+-- Idempotency-Key headers on image creation and billing checkout. A row is
+-- claimed before the request runs, so a concurrent retry sees it in progress,
+-- and holds the successful response that retries with the same key replay.
+-- Keys are scoped to the caller; request_hash ties a key to the endpoint and
+-- body it was first used with.
+CREATE TABLE idempotency_keys (
+  owner TEXT NOT NULL,
+  key TEXT NOT NULL,
+  request_hash TEXT NOT NULL,
+  response_status INTEGER,
+  response_content_type TEXT,
+  response_body BYTEA,
+  locked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (owner, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
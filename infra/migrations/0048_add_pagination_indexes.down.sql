@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_subscriptions_user_created;
+DROP INDEX IF EXISTS idx_invoices_user_created;
+DROP INDEX IF EXISTS idx_projects_user_created;
+DROP INDEX IF EXISTS idx_images_project_created;
@@ -0,0 +1,7 @@
+-- Keyset indexes for the cursor-paged listings, which read newest first by
+-- (created_at, id) within a project or user.
+CREATE INDEX IF NOT EXISTS idx_images_project_created ON images (project_id, created_at DESC, id DESC)
+  WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_projects_user_created ON projects (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_invoices_user_created ON invoices (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_created ON subscriptions (user_id, created_at DESC, id DESC);
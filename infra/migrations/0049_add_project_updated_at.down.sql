@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_images_project_status;
+DROP INDEX IF EXISTS idx_projects_user_updated;
+DROP TRIGGER IF EXISTS trigger_projects_updated_at ON projects;
+DROP FUNCTION IF EXISTS update_projects_updated_at();
+ALTER TABLE projects DROP COLUMN IF EXISTS updated_at;
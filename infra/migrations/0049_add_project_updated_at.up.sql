@@ -0,0 +1,24 @@
+-- Projects can be listed by when they last changed. Existing rows start at
+-- their creation time; the trigger keeps the column current afterwards.
+ALTER TABLE projects ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+UPDATE projects SET updated_at = created_at;
+
+CREATE OR REPLACE FUNCTION update_projects_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = now();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_projects_updated_at
+  BEFORE UPDATE ON projects
+  FOR EACH ROW
+  EXECUTE FUNCTION update_projects_updated_at();
+
+CREATE INDEX IF NOT EXISTS idx_projects_user_updated ON projects (user_id, updated_at DESC, id DESC);
+
+-- Project listings filter by image status and count images per status.
+CREATE INDEX IF NOT EXISTS idx_images_project_status ON images (project_id, status)
+  WHERE deleted_at IS NULL;
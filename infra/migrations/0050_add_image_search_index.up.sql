@@ -0,0 +1,8 @@
+-- Full-text search over images by prompt, room type, style and model. The
+-- expression must match searchDocument in the API's image repository, or the
+-- index is not used. Underscores, slashes and dashes in the enum-like columns
+-- become spaces so "living_room" and "bytedance/seedream-4" match word by word.
+CREATE INDEX IF NOT EXISTS idx_images_search ON images USING GIN (
+  to_tsvector('english', coalesce(prompt, '') || ' ' ||
+    translate(coalesce(room_type, '') || ' ' || coalesce(style, '') || ' ' || coalesce(model_used, ''), '_/-', '   '))
+) WHERE deleted_at IS NULL;
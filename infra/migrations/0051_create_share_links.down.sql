@@ -0,0 +1,3 @@
+-- Remove share links
+DROP INDEX IF EXISTS idx_share_links_project;
+DROP TABLE IF EXISTS share_links;
@@ -0,0 +1,17 @@
+-- Public, read-only gallery links to a project's staged images.
+-- Anyone holding the token can view the gallery until the link expires or is
+-- revoked; views are counted on the link.
+CREATE TABLE share_links (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  token TEXT NOT NULL UNIQUE,
+  expires_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  view_count BIGINT NOT NULL DEFAULT 0,
+  last_viewed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- A project's links, newest first
+CREATE INDEX idx_share_links_project ON share_links(project_id, created_at DESC);
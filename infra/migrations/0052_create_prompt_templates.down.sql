@@ -0,0 +1,2 @@
+-- Remove prompt templates
+DROP TABLE IF EXISTS prompt_templates;
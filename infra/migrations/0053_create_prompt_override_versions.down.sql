@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trigger_prompt_overrides_history ON prompt_overrides;
+DROP TRIGGER IF EXISTS trigger_prompt_overrides_version ON prompt_overrides;
+DROP FUNCTION IF EXISTS record_prompt_override_version();
+DROP FUNCTION IF EXISTS next_prompt_override_version();
+DROP TABLE IF EXISTS prompt_override_versions;
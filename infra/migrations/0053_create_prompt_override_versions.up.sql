@@ -0,0 +1,53 @@
+-- Every text an override has had, so admins can see how a prompt was tuned
+-- and put an earlier version back. The triggers record a version whenever an
+-- override is created or its text changes, however it was written (the admin
+-- prompt endpoints or a bundle import).
+CREATE TABLE prompt_override_versions (
+  room_type VARCHAR(50) NOT NULL,
+  style VARCHAR(50) NOT NULL,
+  version INTEGER NOT NULL,
+  prompt TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  -- Auth subject of the admin who wrote this version.
+  created_by TEXT,
+  PRIMARY KEY (room_type, style, version)
+);
+
+INSERT INTO prompt_override_versions (room_type, style, version, prompt, created_at, created_by)
+SELECT room_type, style, version, prompt, updated_at, updated_by FROM prompt_overrides;
+
+-- An override created after an earlier one was deleted carries on from the
+-- last recorded version instead of starting again at 1.
+CREATE OR REPLACE FUNCTION next_prompt_override_version()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.version = COALESCE((
+    SELECT MAX(version) FROM prompt_override_versions
+    WHERE room_type = NEW.room_type AND style = NEW.style
+  ), 0) + 1;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_prompt_overrides_version
+  BEFORE INSERT ON prompt_overrides
+  FOR EACH ROW
+  EXECUTE FUNCTION next_prompt_override_version();
+
+CREATE OR REPLACE FUNCTION record_prompt_override_version()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'UPDATE' AND OLD.prompt = NEW.prompt THEN
+    RETURN NULL;
+  END IF;
+  INSERT INTO prompt_override_versions (room_type, style, version, prompt, created_at, created_by)
+  VALUES (NEW.room_type, NEW.style, NEW.version, NEW.prompt, NEW.updated_at, NEW.updated_by)
+  ON CONFLICT (room_type, style, version) DO NOTHING;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_prompt_overrides_history
+  AFTER INSERT OR UPDATE ON prompt_overrides
+  FOR EACH ROW
+  EXECUTE FUNCTION record_prompt_override_version();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_images_prompt_variant;
+ALTER TABLE images DROP COLUMN IF EXISTS approved;
+ALTER TABLE images DROP COLUMN IF EXISTS prompt_variant_id;
+ALTER TABLE images DROP COLUMN IF EXISTS prompt_experiment_id;
+DROP TABLE IF EXISTS prompt_experiment_variants;
+DROP TABLE IF EXISTS prompt_experiments;
@@ -0,0 +1,43 @@
+-- A/B tests of staging prompts. While an experiment runs, the worker assigns
+-- each stage job for its room type and style one of the variants, weighted by
+-- weight, stages with that variant's prompt and tags the image with it. At
+-- most one experiment runs per room type and style.
+CREATE TABLE prompt_experiments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name VARCHAR(100) NOT NULL,
+  room_type VARCHAR(50) NOT NULL,
+  style VARCHAR(50) NOT NULL,
+  status VARCHAR(16) NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'stopped')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  -- Auth subject of the admin who started the experiment.
+  created_by TEXT,
+  stopped_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_prompt_experiments_running ON prompt_experiments (room_type, style)
+  WHERE status = 'running';
+
+CREATE TABLE prompt_experiment_variants (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  experiment_id UUID NOT NULL REFERENCES prompt_experiments(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  prompt TEXT NOT NULL CHECK (prompt <> ''),
+  weight INTEGER NOT NULL DEFAULT 1 CHECK (weight BETWEEN 1 AND 100),
+  -- Order the variants were defined in.
+  position INTEGER NOT NULL,
+  UNIQUE (experiment_id, name)
+);
+
+ALTER TABLE images ADD COLUMN prompt_experiment_id UUID REFERENCES prompt_experiments(id) ON DELETE SET NULL;
+ALTER TABLE images ADD COLUMN prompt_variant_id UUID REFERENCES prompt_experiment_variants(id) ON DELETE SET NULL;
+
+-- The owner's verdict on the staged result: true approved, false rejected,
+-- NULL not given. Experiment reports compare approval rates per variant.
+ALTER TABLE images ADD COLUMN approved BOOLEAN;
+
+CREATE INDEX IF NOT EXISTS idx_images_prompt_variant ON images (prompt_variant_id)
+  WHERE prompt_variant_id IS NOT NULL;
+
+COMMENT ON COLUMN images.prompt_experiment_id IS 'Prompt experiment the image was staged in; NULL when it was not';
+COMMENT ON COLUMN images.prompt_variant_id IS 'Experiment variant whose prompt staged the image; NULL when not in an experiment';
+COMMENT ON COLUMN images.approved IS 'Owner verdict on the staged result: true approved, false rejected, NULL not given';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_image_feedback_created;
+DROP TABLE IF EXISTS image_feedback;
@@ -0,0 +1,25 @@
+-- Users rate staged images from 1 to 5, with an optional comment. Each user
+-- has one rating per image, so a restaged variant gets its own. The rating
+-- keeps a copy of how the image was staged, so quality can still be compared
+-- by model and prompt after the image is deleted.
+CREATE TABLE image_feedback (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  image_id UUID REFERENCES images(id) ON DELETE SET NULL,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  comment TEXT,
+  model_used TEXT,
+  room_type VARCHAR(50),
+  style VARCHAR(50),
+  custom_prompt BOOLEAN NOT NULL DEFAULT false,
+  prompt_variant_id UUID REFERENCES prompt_experiment_variants(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (image_id, user_id)
+);
+
+CREATE INDEX idx_image_feedback_created ON image_feedback(created_at);
+
+COMMENT ON COLUMN image_feedback.model_used IS 'Model that staged the image when it was rated';
+COMMENT ON COLUMN image_feedback.custom_prompt IS 'Whether the image was staged with the user''s own prompt rather than the library''s';
+COMMENT ON COLUMN image_feedback.prompt_variant_id IS 'Prompt experiment variant that staged the image; NULL when not in an experiment';
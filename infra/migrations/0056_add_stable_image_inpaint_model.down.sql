@@ -0,0 +1,2 @@
+-- Remove Stability AI inpainting model configuration
+DELETE FROM settings WHERE key = 'model_config_stable_image_inpaint';
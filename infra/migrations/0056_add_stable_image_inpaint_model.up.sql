@@ -0,0 +1,14 @@
+-- Seed default configuration for the Stability AI inpainting model
+INSERT INTO settings (key, value, description, model_settings)
+VALUES (
+    'model_config_stable_image_inpaint',
+    'stability-ai/stable-image-inpaint',
+    'Configuration for Stability AI Stable Image Inpaint model',
+    '{
+        "negative_prompt": "",
+        "strength": 0.7,
+        "output_format": "png"
+    }'::jsonb
+) ON CONFLICT (key) DO UPDATE SET
+    model_settings = EXCLUDED.model_settings,
+    value = EXCLUDED.value;
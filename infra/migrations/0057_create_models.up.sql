@@ -0,0 +1,63 @@
+-- The staging model catalog, read by both the API and the worker. Migrations
+-- add models; the API's model sync refreshes the description, latest version
+-- and input schema of Replicate models from Replicate; admins enable and
+-- disable models. Disabled and unlisted models can't be chosen for new jobs,
+-- and the worker fails jobs that still name them.
+CREATE TABLE models (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  provider TEXT NOT NULL DEFAULT 'replicate' CHECK (provider IN ('replicate', 'openai', 'stability')),
+  version TEXT NOT NULL DEFAULT '',
+  latest_version TEXT,
+  input_schema JSONB,
+  cost_per_image_usd NUMERIC(10, 4) NOT NULL DEFAULT 0,
+  enabled BOOLEAN NOT NULL DEFAULT true,
+  position INTEGER NOT NULL DEFAULT 0,
+  synced_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_by UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+COMMENT ON COLUMN models.provider IS 'API that runs the model''s predictions';
+COMMENT ON COLUMN models.version IS 'Version shown in the admin UI; jobs run the pinned or latest version';
+COMMENT ON COLUMN models.latest_version IS 'Latest upstream version, as of the last sync; NULL for models not on Replicate';
+COMMENT ON COLUMN models.input_schema IS 'OpenAPI schema of the latest version''s input, as of the last sync';
+COMMENT ON COLUMN models.cost_per_image_usd IS 'Provider list price for staging one image; 0 when billed to the user''s own account';
+COMMENT ON COLUMN models.position IS 'Order the admin UI lists the model in';
+
+INSERT INTO models (id, name, description, provider, version, cost_per_image_usd, position) VALUES
+  ('qwen/qwen-image-edit', 'Qwen Image Edit',
+   'Fast image editing model optimized for virtual staging. Requires input image.',
+   'replicate', 'v1', 0.03, 1),
+  ('black-forest-labs/flux-kontext-max', 'Flux Kontext Max',
+   'High-quality image generation and editing with advanced context understanding. '
+   'Supports both text-to-image and image-to-image.',
+   'replicate', 'v1', 0.08, 2),
+  ('black-forest-labs/flux-kontext-pro', 'Flux Kontext Pro',
+   'State-of-the-art text-based image editing with high-quality outputs and excellent prompt following. '
+   'Professional-grade editing capabilities.',
+   'replicate', 'v1', 0.04, 3),
+  ('bytedance/seedream-3', 'Seedream 3',
+   'Unified text-to-image generation and precise editing. '
+   'Supports both workflows with natural language commands.',
+   'replicate', 'v1', 0.03, 4),
+  ('bytedance/seedream-4', 'Seedream 4',
+   'Latest Seedream model with support for up to 4K resolution. '
+   'High-quality text-to-image and image editing.',
+   'replicate', 'v1', 0.03, 5),
+  ('openai/gpt-image-1', 'GPT Image 1',
+   'A multimodal image generation model that creates high-quality images. '
+   'You need to bring your own verified OpenAI key to use this model. '
+   'Your OpenAI account will be charged for usage.',
+   'openai', 'v1', 0, 6),
+  ('openai/gpt-image-1.5', 'GPT Image 1.5',
+   'A multimodal image generation model that creates high-quality images. '
+   'You need to bring your own verified OpenAI key to use this model. '
+   'Your OpenAI account will be charged for usage.',
+   'openai', 'v1', 0, 7),
+  ('stability-ai/stable-image-inpaint', 'Stable Image Inpaint',
+   'Stability AI''s inpainting model, run on Stability''s own API rather than Replicate. '
+   'The worker needs a Stability API key to use this model.',
+   'stability', 'v2beta', 0.03, 8);
@@ -0,0 +1,2 @@
+-- Remove per-user provider API keys
+DROP TABLE IF EXISTS user_credentials;
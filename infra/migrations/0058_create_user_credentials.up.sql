@@ -0,0 +1,15 @@
+-- API keys users bring for model providers, one row per user and provider.
+-- The key is encrypted by the API and decrypted only by the worker when it
+-- runs one of the user's jobs; hint keeps its last four characters so users
+-- can tell which key is stored.
+CREATE TABLE user_credentials (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  provider TEXT NOT NULL,
+  ciphertext BYTEA NOT NULL,
+  hint TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, provider)
+);
+
+COMMENT ON COLUMN user_credentials.ciphertext IS 'AES-256-GCM sealed API key, bound to user_id and provider';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_images_primary_image_id;
+ALTER TABLE images DROP COLUMN IF EXISTS primary_image_id;
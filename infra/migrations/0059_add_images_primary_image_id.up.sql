@@ -0,0 +1,8 @@
+-- Models configured to make several images per prediction stage them all in
+-- one job. The first output goes on the image the job was queued for; each
+-- other one is stored as a new variant that points back at that image.
+ALTER TABLE images ADD COLUMN primary_image_id UUID REFERENCES images(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_images_primary_image_id ON images(primary_image_id) WHERE primary_image_id IS NOT NULL;
+
+COMMENT ON COLUMN images.primary_image_id IS 'Image whose staging job also made this one; NULL for images staged by their own job';
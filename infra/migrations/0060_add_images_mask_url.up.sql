@@ -0,0 +1,5 @@
+-- A mask limits staging to part of the photo: white areas are restaged,
+-- black ones kept as they are. Variants of an image inherit its mask.
+ALTER TABLE images ADD COLUMN mask_url TEXT;
+
+COMMENT ON COLUMN images.mask_url IS 'PNG mask of the region to stage, white where the model may repaint; NULL stages the whole photo';
@@ -0,0 +1,7 @@
+-- What the staging job does with the furniture already in the photo: stage
+-- adds furniture, declutter removes the owner's furniture and belongings, and
+-- both replaces them with staged furniture. Variants keep their source's mode.
+ALTER TABLE images ADD COLUMN mode VARCHAR(16) NOT NULL DEFAULT 'stage'
+  CHECK (mode IN ('stage', 'declutter', 'both'));
+
+COMMENT ON COLUMN images.mode IS 'stage adds furniture, declutter empties the room, both replaces its furniture';
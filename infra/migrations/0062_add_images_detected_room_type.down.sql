@@ -0,0 +1,3 @@
+ALTER TABLE images
+  DROP COLUMN IF EXISTS room_type_confidence,
+  DROP COLUMN IF EXISTS detected_room_type;
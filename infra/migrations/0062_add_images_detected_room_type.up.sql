@@ -0,0 +1,9 @@
+-- When a photo is uploaded without a room type, the worker can classify it and
+-- stage it with the prompt for the room it detected. The detection is kept
+-- next to the room type the client chose, which stays NULL.
+ALTER TABLE images
+  ADD COLUMN detected_room_type TEXT,
+  ADD COLUMN room_type_confidence DOUBLE PRECISION CHECK (room_type_confidence BETWEEN 0 AND 1);
+
+COMMENT ON COLUMN images.detected_room_type IS 'Room type the worker detected for an image uploaded without one';
+COMMENT ON COLUMN images.room_type_confidence IS 'Confidence (0-1) the detection model gave detected_room_type';
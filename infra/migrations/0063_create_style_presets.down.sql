@@ -0,0 +1,2 @@
+-- Remove style presets
+DROP TABLE IF EXISTS style_presets;
@@ -0,0 +1,16 @@
+-- Admin-defined style presets. A preset bundles a staging model, an optional
+-- prompt template and overrides of the model's config under one name, so image
+-- requests can reference a preset instead of setting each knob.
+CREATE TABLE style_presets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL UNIQUE,
+  description TEXT NOT NULL DEFAULT '',
+  model_id TEXT NOT NULL,
+  prompt_template TEXT,
+  config_overrides JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN style_presets.prompt_template IS 'Prompt with optional {room} and {style} placeholders; NULL stages with the prompt library';
+COMMENT ON COLUMN style_presets.config_overrides IS 'Model config fields laid over the admin config for the model';
@@ -0,0 +1,4 @@
+-- PostgreSQL can't drop an enum value, so canceled images go back to 'error'
+-- and 'canceled' stays on image_status unused.
+UPDATE images SET status = 'error' WHERE status = 'canceled';
+UPDATE image_events SET status = 'error' WHERE status = 'canceled';
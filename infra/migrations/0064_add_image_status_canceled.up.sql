@@ -0,0 +1,3 @@
+-- Owners can cancel an image's staging job while it is queued or processing.
+-- The image is then set to 'canceled', which the worker never overwrites.
+ALTER TYPE image_status ADD VALUE IF NOT EXISTS 'canceled';
@@ -0,0 +1,2 @@
+-- Remove dead letters
+DROP TABLE IF EXISTS dead_letters;
@@ -0,0 +1,23 @@
+-- Staging tasks that failed for good: stage:run and declutter:run tasks that
+-- used up their retries or were told not to retry. The worker records them
+-- with their payload so admins can retry or discard them from the console.
+CREATE TABLE dead_letters (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  task_id TEXT NOT NULL,
+  task_type VARCHAR(32) NOT NULL,
+  queue TEXT NOT NULL,
+  image_id UUID REFERENCES images(id) ON DELETE SET NULL,
+  payload JSONB NOT NULL,
+  error TEXT NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  failed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Console listing (newest first)
+CREATE INDEX idx_dead_letters_failed_at ON dead_letters(failed_at DESC);
+
+-- Dead letters of one image
+CREATE INDEX idx_dead_letters_image_id ON dead_letters(image_id);
+
+COMMENT ON COLUMN dead_letters.task_id IS 'Asynq task ID; the archived task is deleted when the dead letter is retried or discarded';
+COMMENT ON COLUMN dead_letters.attempts IS 'Times the task was retried before it was given up';
@@ -0,0 +1,2 @@
+-- Remove the staged file's content type
+ALTER TABLE images DROP COLUMN IF EXISTS staged_content_type;
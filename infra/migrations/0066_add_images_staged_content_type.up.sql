@@ -0,0 +1,5 @@
+-- Models can be configured to emit PNG or WebP, so staged files are stored in
+-- the format they came back in rather than always as JPEG.
+ALTER TABLE images ADD COLUMN staged_content_type TEXT;
+
+COMMENT ON COLUMN images.staged_content_type IS 'Content type of the staged file, e.g. image/webp; NULL for images staged before it was recorded';
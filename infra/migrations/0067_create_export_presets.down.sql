@@ -0,0 +1,2 @@
+-- Remove export presets
+DROP TABLE IF EXISTS export_presets;
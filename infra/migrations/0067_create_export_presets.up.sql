@@ -0,0 +1,24 @@
+-- Organization export presets. A preset names the size, format, watermark and
+-- MLS banner a brokerage wants on exported images, so a project ZIP can be
+-- requested with ?preset=<name> instead of editing every photo.
+CREATE TABLE export_presets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  width INTEGER CHECK (width > 0),
+  height INTEGER CHECK (height > 0),
+  format TEXT NOT NULL DEFAULT 'jpeg' CHECK (format IN ('jpeg', 'png')),
+  quality INTEGER NOT NULL DEFAULT 90 CHECK (quality BETWEEN 1 AND 100),
+  watermark_text TEXT,
+  watermark_position TEXT NOT NULL DEFAULT 'bottom_right',
+  mls_banner BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (org_id, name)
+);
+
+COMMENT ON COLUMN export_presets.width IS 'Largest exported width in pixels; NULL leaves the width unbounded';
+COMMENT ON COLUMN export_presets.height IS 'Largest exported height in pixels; NULL leaves the height unbounded';
+COMMENT ON COLUMN export_presets.quality IS 'JPEG quality; ignored for PNG';
+COMMENT ON COLUMN export_presets.watermark_text IS 'Text watermarked onto each image; NULL exports without a watermark';
+COMMENT ON COLUMN export_presets.mls_banner IS 'Adds the virtually-staged disclosure banner MLS listings require';
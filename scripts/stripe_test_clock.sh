@@ -0,0 +1,92 @@
+#!/bin/bash
+# Drive a sandbox user's Stripe test clock through the admin API.
+# Requires the API to run with STRIPE_TEST_CLOCKS_ENABLED=true and STRIPE_TEST_SECRET_KEY set.
+#
+# Usage: ./scripts/stripe_test_clock.sh <create|status|advance|delete> <user_id> [days|RFC3339 time]
+#
+# Examples:
+#   ./scripts/stripe_test_clock.sh create  "$USER_ID"
+#   ./scripts/stripe_test_clock.sh advance "$USER_ID" 31
+#   ./scripts/stripe_test_clock.sh advance "$USER_ID" 2025-03-01T00:00:00Z
+
+set -e
+
+API_URL="${API_URL:-http://localhost:8080}"
+ACTION="$1"
+USER_ID="$2"
+TARGET="$3"
+
+usage() {
+    echo "Usage: $0 <create|status|advance|delete> <user_id> [days|RFC3339 time]"
+    exit 1
+}
+
+if [[ -z "$ACTION" || -z "$USER_ID" ]]; then
+    usage
+fi
+
+for tool in curl jq; do
+    if ! command -v "$tool" &> /dev/null; then
+        echo "❌ $tool is required but not installed."
+        exit 1
+    fi
+done
+
+AUTH_HEADER=()
+if [[ -n "$TOKEN" ]]; then
+    AUTH_HEADER=(-H "Authorization: Bearer $TOKEN")
+fi
+
+CLOCK_URL="$API_URL/api/v1/admin/users/$USER_ID/test-clock"
+
+call() {
+    local method="$1"
+    local url="$2"
+    local body="$3"
+
+    if [[ -n "$body" ]]; then
+        curl -sS -f -X "$method" "$url" "${AUTH_HEADER[@]}" -H "Content-Type: application/json" -d "$body"
+    else
+        curl -sS -f -X "$method" "$url" "${AUTH_HEADER[@]}"
+    fi
+}
+
+case "$ACTION" in
+    create)
+        call POST "$CLOCK_URL" | jq .
+        ;;
+    status)
+        call GET "$CLOCK_URL" | jq .
+        ;;
+    advance)
+        if [[ -z "$TARGET" ]]; then
+            usage
+        fi
+        if [[ "$TARGET" =~ ^[0-9]+$ ]]; then
+            BODY=$(jq -n --argjson days "$TARGET" '{days: $days}')
+        else
+            BODY=$(jq -n --arg t "$TARGET" '{frozen_time: $t}')
+        fi
+        call POST "$CLOCK_URL/advance" "$BODY" | jq .
+
+        # Stripe advances asynchronously; wait until the clock settles so renewals
+        # and invoices generated by the jump are visible before the caller asserts.
+        for _ in $(seq 1 30); do
+            STATUS=$(call GET "$CLOCK_URL" | jq -r .status)
+            if [[ "$STATUS" != "advancing" ]]; then
+                echo "Clock status: $STATUS"
+                exit 0
+            fi
+            sleep 2
+        done
+        echo "❌ Clock still advancing after 60s"
+        exit 1
+        ;;
+    delete)
+        call DELETE "$CLOCK_URL"
+        echo "✅ Test clock deleted"
+        ;;
+    *)
+        usage
+        ;;
+esac